@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/devhost/launcher"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+
+	"github.com/pterm/pterm"
+	"github.com/pterm/pterm/putils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	servicesDir string
+	useRabbitmq bool
+)
+
+var rootCmd = &cobra.Command{
+	Use:              "dev-host",
+	Short:            "dev-host runs all the microservices in one process for local development",
+	Long:             `This is a command runner which composes catalogs write, catalogs read and orders services in one process with an in-memory bus.`,
+	TraverseChildren: true,
+	SilenceUsage:     true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := defaultLogger.GetLogger()
+
+		if servicesDir == "" {
+			servicesDir = filepath.Join(environment.GetProjectRootWorkingDirectory(), "..", "services")
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		devHost := launcher.NewLauncher(
+			launcher.Services(servicesDir),
+			launcher.Options{InMemoryBus: !useRabbitmq},
+			logger,
+		)
+
+		if err := devHost.Start(ctx); err != nil {
+			return err
+		}
+
+		logger.Info("all services are started, press ctrl+c to stop")
+		<-ctx.Done()
+
+		devHost.Stop()
+
+		return nil
+	},
+}
+
+func main() {
+	rootCmd.Flags().StringVar(
+		&servicesDir,
+		"services-dir",
+		"",
+		"the directory of the services modules, default is the services directory next to the dev host",
+	)
+	rootCmd.Flags().BoolVar(
+		&useRabbitmq,
+		"rabbitmq",
+		false,
+		"use the rabbitmq of the docker-compose infrastructure instead of the in-memory bus",
+	)
+
+	pterm.DefaultBigText.WithLetters(
+		putils.LettersFromStringWithStyle("Dev", pterm.FgLightGreen.ToStyle()),
+		putils.LettersFromStringWithStyle(" Host", pterm.FgLightMagenta.ToStyle())).
+		Render()
+
+	err := rootCmd.Execute()
+	if err != nil {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,163 @@
+package launcher
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/constants"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/fxapp/contracts"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	catalogsReadHost "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/host"
+	catalogsWriteHost "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/host"
+	ordersHost "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/host"
+
+	"emperror.dev/errors"
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultStartTimeout = 30 * time.Second
+	defaultStopTimeout  = 15 * time.Second
+	inMemoryBusEnv      = "RabbitmqUseInMemory"
+)
+
+// Service is a service which is composed in the development host
+type Service struct {
+	Name string
+	// Dir is the directory of the service module, the configs and the relative paths of the service (e.g. migrations)
+	// are resolved from it
+	Dir            string
+	NewApplication func() (contracts.Application, error)
+}
+
+type Options struct {
+	// InMemoryBus replaces rabbitmq with the process-wide in-memory broker, so the services exchange their messages
+	// without a running rabbitmq
+	InMemoryBus  bool
+	StartTimeout time.Duration
+	StopTimeout  time.Duration
+}
+
+type startedApplication struct {
+	name        string
+	application contracts.Application
+}
+
+// Launcher runs the services in one process, the services share the infrastructure containers (postgres, mongo,
+// eventstoredb, redis) of the docker-compose infrastructure and, with the in-memory bus, the message broker.
+type Launcher struct {
+	services []Service
+	options  Options
+	logger   logger.Logger
+	started  []startedApplication
+}
+
+func NewLauncher(services []Service, options Options, logger logger.Logger) *Launcher {
+	if options.StartTimeout == 0 {
+		options.StartTimeout = defaultStartTimeout
+	}
+
+	if options.StopTimeout == 0 {
+		options.StopTimeout = defaultStopTimeout
+	}
+
+	return &Launcher{services: services, options: options, logger: logger}
+}
+
+// Start builds and starts the services one by one. the services read their configs and resolve their working directory
+// while building and starting, so they can't be started concurrently. on a failure the started services are stopped.
+func (l *Launcher) Start(ctx context.Context) error {
+	if l.options.InMemoryBus {
+		if err := os.Setenv(inMemoryBusEnv, "true"); err != nil {
+			return errors.WrapIf(err, "error in enabling the in-memory bus")
+		}
+	}
+
+	for _, service := range l.services {
+		err := l.startService(ctx, service)
+		if err != nil {
+			l.Stop()
+
+			return errors.WrapIff(err, "error in starting service %s", service.Name)
+		}
+
+		l.logger.Infof("service %s started", service.Name)
+	}
+
+	return nil
+}
+
+// Stop stops the started services in the reverse order of starting
+func (l *Launcher) Stop() {
+	for i := len(l.started) - 1; i >= 0; i-- {
+		started := l.started[i]
+
+		stopCtx, cancel := context.WithTimeout(context.Background(), l.options.StopTimeout)
+		err := started.application.Stop(stopCtx)
+		cancel()
+
+		if err != nil {
+			l.logger.Errorf("error in stopping service %s: %v", started.name, err)
+			continue
+		}
+
+		l.logger.Infof("service %s stopped", started.name)
+	}
+
+	l.started = nil
+}
+
+func (l *Launcher) startService(ctx context.Context, service Service) error {
+	dir, err := filepath.Abs(service.Dir)
+	if err != nil {
+		return err
+	}
+
+	// the application builder searches the project root (the nearest go.mod) from the working directory
+	if err := os.Chdir(dir); err != nil {
+		return errors.WrapIff(err, "error in changing working directory to %s", dir)
+	}
+
+	// when we `Set` a viper with string value, we should get it from viper with `viper.GetString`
+	viper.Set(constants.ConfigPath, filepath.Join(dir, "config"))
+
+	application, err := service.NewApplication()
+	if err != nil {
+		return err
+	}
+
+	startCtx, cancel := context.WithTimeout(ctx, l.options.StartTimeout)
+	defer cancel()
+
+	if err := application.Start(startCtx); err != nil {
+		return err
+	}
+
+	l.started = append(l.started, startedApplication{name: service.Name, application: application})
+
+	return nil
+}
+
+// Services returns the services of the repository in their starting order, the catalogs read service and the orders
+// service consume the messages of the catalogs write service
+func Services(servicesDir string) []Service {
+	return []Service{
+		{
+			Name:           "catalogs-write-service",
+			Dir:            filepath.Join(servicesDir, "catalogwriteservice"),
+			NewApplication: catalogsWriteHost.NewApplication,
+		},
+		{
+			Name:           "catalogs-read-service",
+			Dir:            filepath.Join(servicesDir, "catalogreadservice"),
+			NewApplication: catalogsReadHost.NewApplication,
+		},
+		{
+			Name:           "orders-service",
+			Dir:            filepath.Join(servicesDir, "orderservice"),
+			NewApplication: ordersHost.NewApplication,
+		},
+	}
+}
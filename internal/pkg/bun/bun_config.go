@@ -6,5 +6,5 @@ type BunConfig struct {
 	User     string `mapstructure:"user"`
 	DBName   string `mapstructure:"dbName"`
 	SSLMode  bool   `mapstructure:"sslMode"`
-	Password string `mapstructure:"password"`
+	Password string `mapstructure:"password" secret:"true"`
 }
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"sync"
+)
+
+// boundOptions keeps the bound options by their config directory, so the apps composed in one process (e.g. the
+// development host) don't see the options of each other
+//
+//nolint:gochecknoglobals
+var boundOptions = struct {
+	sync.RWMutex
+	byDir map[string]map[string]any
+}{byDir: make(map[string]map[string]any)}
+
+func recordBoundOptions(configDir string, configKey string, options any) {
+	boundOptions.Lock()
+	defer boundOptions.Unlock()
+
+	dirOptions, ok := boundOptions.byDir[configDir]
+	if !ok {
+		dirOptions = make(map[string]any)
+		boundOptions.byDir[configDir] = dirOptions
+	}
+
+	dirOptions[configKey] = options
+}
+
+// BoundOptions returns the options bound from the config files of the directory by their config keys, they are
+// resolved with the defaults, the config files and the environment variables. the options keep their secrets, so they
+// should be dumped with `Redact`.
+func BoundOptions(configDir string) map[string]any {
+	boundOptions.RLock()
+	defer boundOptions.RUnlock()
+
+	options := make(map[string]any, len(boundOptions.byDir[configDir]))
+	for key, value := range boundOptions.byDir[configDir] {
+		options[key] = value
+	}
+
+	return options
+}
@@ -12,6 +12,7 @@ import (
 
 	"emperror.dev/errors"
 	"github.com/caarlos0/env/v8"
+	"github.com/iancoleman/strcase"
 	"github.com/mcuadros/go-defaults"
 	"github.com/spf13/viper"
 )
@@ -24,14 +25,7 @@ func BindConfigKey[T any](
 	configKey string,
 	environments ...environment.Environment,
 ) (T, error) {
-	var configPath string
-
-	currentEnv := environment.Environment("")
-	if len(environments) > 0 {
-		currentEnv = environments[0]
-	} else {
-		currentEnv = constants.Dev
-	}
+	currentEnv := currentEnvironment(environments...)
 
 	cfg := typeMapper.GenericInstanceByT[T]()
 
@@ -39,45 +33,43 @@ func BindConfigKey[T any](
 	// https://github.com/mcuadros/go-defaults
 	defaults.SetDefaults(cfg)
 
-	// https://articles.wesionary.team/environment-variable-configuration-in-your-golang-project-using-viper-4e8289ef664d
-	// when we `Set` a viper with string value, we should get it from viper with `viper.GetString`, elsewhere we get empty string
-	// load `config path` from env variable or viper internal registry
-	configPathFromEnv := viper.GetString(constants.ConfigPath)
-
-	if configPathFromEnv != "" {
-		configPath = configPathFromEnv
-	} else {
-		// https://stackoverflow.com/questions/31873396/is-it-possible-to-get-the-current-root-of-package-structure-as-a-string-in-golan
-		// https://stackoverflow.com/questions/18537257/how-to-get-the-directory-of-the-currently-running-file
-		appRootPath := viper.GetString(constants.AppRootPath)
-		if appRootPath == "" {
-			appRootPath = environment.GetProjectRootWorkingDirectory()
-		}
-
-		d, err := searchForConfigFileDir(appRootPath, currentEnv)
-		if err != nil {
-			return *new(T), err
-		}
-
-		configPath = d
+	configPath, err := ConfigDir(currentEnv)
+	if err != nil {
+		return *new(T), err
 	}
 
+	// a new viper instance reads the config file, because `AddConfigPath` appends to the search paths, with the global
+	// instance an app composed after another one in the same process reads the config file of the first app
 	// https://github.com/spf13/viper/issues/390#issuecomment-718756752
-	viper.SetConfigName(fmt.Sprintf("config.%s", currentEnv))
-	viper.AddConfigPath(configPath)
-	viper.SetConfigType(constants.Json)
+	v := viper.New()
+	v.SetConfigName(fmt.Sprintf("config.%s", currentEnv.Base()))
+	v.AddConfigPath(configPath)
+	v.SetConfigType(constants.Json)
 
-	if err := viper.ReadInConfig(); err != nil {
+	if err := v.ReadInConfig(); err != nil {
 		return *new(T), errors.WrapIf(err, "viper.ReadInConfig")
 	}
 
+	if currentEnv.IsCustom() {
+		// the config file of a custom environment only keeps the options which override the config of its base
+		// environment, and it is optional
+		v.SetConfigName(fmt.Sprintf("config.%s", currentEnv))
+
+		if err := v.MergeInConfig(); err != nil {
+			var notFoundErr viper.ConfigFileNotFoundError
+			if !errors.As(err, &notFoundErr) {
+				return *new(T), errors.WrapIf(err, "viper.MergeInConfig")
+			}
+		}
+	}
+
 	if len(configKey) == 0 {
 		// load configs from config file to config object
-		if err := viper.Unmarshal(cfg); err != nil {
+		if err := v.Unmarshal(cfg); err != nil {
 			return *new(T), errors.WrapIf(err, "viper.Unmarshal")
 		}
 	} else {
-		if err := viper.UnmarshalKey(configKey, cfg); err != nil {
+		if err := v.UnmarshalKey(configKey, cfg); err != nil {
 			return *new(T), errors.WrapIf(err, "viper.Unmarshal")
 		}
 	}
@@ -89,9 +81,44 @@ func BindConfigKey[T any](
 		fmt.Printf("%+v\n", err)
 	}
 
+	if len(configKey) == 0 {
+		configKey = strcase.ToLowerCamel(typeMapper.GetGenericNonePointerTypeNameByT[T]())
+	}
+	recordBoundOptions(configPath, configKey, cfg)
+
 	return cfg, nil
 }
 
+// ConfigDir returns the directory of the config files of the environment, from the `CONFIG_PATH` or else the first
+// directory under the app root with a config file of the environment
+func ConfigDir(environments ...environment.Environment) (string, error) {
+	// https://articles.wesionary.team/environment-variable-configuration-in-your-golang-project-using-viper-4e8289ef664d
+	// when we `Set` a viper with string value, we should get it from viper with `viper.GetString`, elsewhere we get empty string
+	// load `config path` from env variable or viper internal registry
+	configPathFromEnv := viper.GetString(constants.ConfigPath)
+	if configPathFromEnv != "" {
+		return configPathFromEnv, nil
+	}
+
+	// https://stackoverflow.com/questions/31873396/is-it-possible-to-get-the-current-root-of-package-structure-as-a-string-in-golan
+	// https://stackoverflow.com/questions/18537257/how-to-get-the-directory-of-the-currently-running-file
+	appRootPath := viper.GetString(constants.AppRootPath)
+	if appRootPath == "" {
+		appRootPath = environment.GetProjectRootWorkingDirectory()
+	}
+
+	// the config file of a custom environment is next to the config file of its base environment
+	return searchForConfigFileDir(appRootPath, currentEnvironment(environments...).Base())
+}
+
+func currentEnvironment(environments ...environment.Environment) environment.Environment {
+	if len(environments) > 0 {
+		return environments[0]
+	}
+
+	return constants.Dev
+}
+
 // searchForConfigFileDir searches for the first directory within the specified root directory and its subdirectories
 // that contains a file named "config.%s.json" where "%s" is replaced with the provided environment string.
 // It returns the path of the first directory that contains the config file or an error if no such directory is found.
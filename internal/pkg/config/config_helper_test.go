@@ -0,0 +1,64 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/constants"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sampleOptions struct {
+	Host string `mapstructure:"host"`
+	Port int    `mapstructure:"port"`
+}
+
+func Test_Bind_Config_Of_Custom_Environment_Overrides_Base_Config(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dir, "config.production.json"),
+		[]byte(`{"sampleOptions": {"host": "prod-host", "port": 5432}}`),
+		0o600,
+	))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dir, "config.staging.json"),
+		[]byte(`{"sampleOptions": {"host": "staging-host"}}`),
+		0o600,
+	))
+
+	viper.Set(constants.ConfigPath, dir)
+	defer viper.Set(constants.ConfigPath, "")
+
+	staging, err := BindConfigKey[*sampleOptions]("sampleOptions", environment.Staging)
+	require.NoError(t, err)
+	assert.Equal(t, "staging-host", staging.Host)
+	assert.Equal(t, 5432, staging.Port)
+
+	// the config file of a custom environment is optional
+	perf, err := BindConfigKey[*sampleOptions]("sampleOptions", environment.Perf)
+	require.NoError(t, err)
+	assert.Equal(t, "prod-host", perf.Host)
+}
+
+func Test_Bound_Options_Of_Config_Dir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dir, "config.development.json"),
+		[]byte(`{"sampleOptions": {"host": "dev-host", "port": 5432}}`),
+		0o600,
+	))
+
+	viper.Set(constants.ConfigPath, dir)
+	defer viper.Set(constants.ConfigPath, "")
+
+	options, err := BindConfigKey[*sampleOptions]("sampleOptions", environment.Development)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{"sampleOptions": options}, BoundOptions(dir))
+	assert.Empty(t, BoundOptions(t.TempDir()))
+}
@@ -19,8 +19,25 @@ var (
 	Development = Environment(constants.Dev)
 	Test        = Environment(constants.Test)
 	Production  = Environment(constants.Production)
+	Staging     = Environment(constants.Staging)
+	Perf        = Environment(constants.Perf)
+	CI          = Environment(constants.CI)
 )
 
+// baseEnvironments keeps the built-in environment which a custom environment behaves like, the modules check the
+// behavior of an environment with its `Base` and the config of a custom environment overrides the config of its base.
+var baseEnvironments = map[Environment]Environment{ //nolint:gochecknoglobals
+	Staging: Production,
+	Perf:    Production,
+	CI:      Test,
+}
+
+// RegisterEnvironment registers a custom environment which behaves like the `base` environment, it should be called
+// before composing the app.
+func RegisterEnvironment(env Environment, base Environment) {
+	baseEnvironments[env] = base.Base()
+}
+
 func ConfigAppEnv(environments ...Environment) Environment {
 	environment := Environment("")
 	if len(environments) > 0 {
@@ -64,6 +81,44 @@ func (env Environment) IsTest() bool {
 	return env == Test
 }
 
+func (env Environment) IsStaging() bool {
+	return env == Staging
+}
+
+func (env Environment) IsPerf() bool {
+	return env == Perf
+}
+
+func (env Environment) IsCI() bool {
+	return env == CI
+}
+
+// Is reports whether the environment is one of the `environments`
+func (env Environment) Is(environments ...Environment) bool {
+	for _, e := range environments {
+		if env == e {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Base returns the built-in environment which the environment behaves like, for the built-in and the unknown
+// environments it is the environment itself.
+func (env Environment) Base() Environment {
+	if base, ok := baseEnvironments[env]; ok {
+		return base
+	}
+
+	return env
+}
+
+// IsCustom reports whether the environment is a registered custom environment
+func (env Environment) IsCustom() bool {
+	return env.Base() != env
+}
+
 func (env Environment) GetEnvironmentName() string {
 	return string(env)
 }
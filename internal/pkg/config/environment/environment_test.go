@@ -0,0 +1,36 @@
+package environment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Custom_Environments_Behave_Like_Their_Base(t *testing.T) {
+	assert.Equal(t, Production, Staging.Base())
+	assert.Equal(t, Production, Perf.Base())
+	assert.Equal(t, Test, CI.Base())
+	assert.True(t, Staging.IsCustom())
+	assert.True(t, Staging.IsStaging())
+	assert.False(t, Staging.IsProduction())
+
+	assert.Equal(t, Development, Development.Base())
+	assert.False(t, Development.IsCustom())
+
+	unknown := Environment("sandbox")
+	assert.Equal(t, unknown, unknown.Base())
+	assert.True(t, unknown.Is(Development, unknown))
+	assert.False(t, unknown.Is(Development, Production))
+}
+
+func Test_Register_Environment_Uses_The_Built_In_Base(t *testing.T) {
+	qa := Environment("qa")
+	preview := Environment("preview")
+
+	RegisterEnvironment(qa, Development)
+	// a custom environment based on another custom environment behaves like the built-in base of that environment
+	RegisterEnvironment(preview, Staging)
+
+	assert.Equal(t, Development, qa.Base())
+	assert.Equal(t, Production, preview.Base())
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/iancoleman/strcase"
+)
+
+// RedactedValue replaces the non-empty secret fields of the dumped options
+const RedactedValue = "[redacted]"
+
+const secretTag = "secret"
+
+// Redact returns a dump of the options with maps keyed by the `mapstructure` names of the fields, the fields with a
+// `secret:"true"` tag, like the passwords and the api keys, are replaced with RedactedValue when they are not empty, so
+// the options can be logged or returned by the diagnostics endpoints
+func Redact(options any) any {
+	return redactValue(reflect.ValueOf(options))
+}
+
+func redactValue(value reflect.Value) any {
+	if !value.IsValid() {
+		return nil
+	}
+
+	// the durations and the times are dumped in their text form instead of their numbers
+	switch v := value.Interface().(type) {
+	case time.Duration:
+		return v.String()
+	case time.Time:
+		return v
+	case fmt.Stringer:
+		if value.Kind() != reflect.Struct && value.Kind() != reflect.Pointer {
+			return v.String()
+		}
+	}
+
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+
+		return redactValue(value.Elem())
+	case reflect.Struct:
+		return redactStruct(value)
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return nil
+		}
+
+		items := make([]any, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			items[i] = redactValue(value.Index(i))
+		}
+
+		return items
+	case reflect.Map:
+		if value.IsNil() {
+			return nil
+		}
+
+		items := make(map[string]any, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			items[fmt.Sprint(iter.Key().Interface())] = redactValue(iter.Value())
+		}
+
+		return items
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return nil
+	default:
+		return value.Interface()
+	}
+}
+
+func redactStruct(value reflect.Value) map[string]any {
+	fields := make(map[string]any, value.NumField())
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() || field.Tag.Get("mapstructure") == "-" {
+			continue
+		}
+
+		name, squash := fieldName(field)
+		fieldValue := value.Field(i)
+
+		if field.Tag.Get(secretTag) == "true" {
+			if fieldValue.IsZero() {
+				fields[name] = ""
+			} else {
+				fields[name] = RedactedValue
+			}
+
+			continue
+		}
+
+		redacted := redactValue(fieldValue)
+
+		// the embedded structs with a `,squash` tag are bound to the fields of their parent
+		if nested, ok := redacted.(map[string]any); ok && squash {
+			for k, v := range nested {
+				fields[k] = v
+			}
+
+			continue
+		}
+
+		fields[name] = redacted
+	}
+
+	return fields
+}
+
+func fieldName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("mapstructure")
+	name, options, _ := strings.Cut(tag, ",")
+
+	squash := strings.Contains(options, "squash")
+	if name == "" {
+		name = strcase.ToLowerCamel(field.Name)
+	}
+
+	return name, squash
+}
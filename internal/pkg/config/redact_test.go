@@ -0,0 +1,62 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type redactHostOptions struct {
+	Host     string `mapstructure:"host"`
+	Password string `mapstructure:"password" secret:"true"`
+	Token    string `mapstructure:"token"    secret:"true"`
+}
+
+type redactUserOptions struct {
+	UserId string `mapstructure:"userId"`
+	ApiKey string `mapstructure:"apiKey" secret:"true"`
+}
+
+type RedactBaseOptions struct {
+	Name string `mapstructure:"name"`
+}
+
+type redactOptions struct {
+	RedactBaseOptions `mapstructure:",squash"`
+	Host              *redactHostOptions   `mapstructure:"hostOptions"`
+	Users             []*redactUserOptions `mapstructure:"users"`
+	Timeout           time.Duration        `mapstructure:"timeout"`
+	Labels            map[string]string
+	Ignored           string `mapstructure:"-"`
+	internal          string
+}
+
+func Test_Redact(t *testing.T) {
+	options := &redactOptions{
+		RedactBaseOptions: RedactBaseOptions{Name: "catalogs"},
+		Host:              &redactHostOptions{Host: "localhost", Password: "postgres"},
+		Users:             []*redactUserOptions{{UserId: "admin", ApiKey: "admin-key"}},
+		Timeout:           5 * time.Second,
+		Labels:            map[string]string{"team": "catalogs"},
+		Ignored:           "ignored",
+		internal:          "internal",
+	}
+
+	assert.Equal(t, map[string]any{
+		"name":        "catalogs",
+		"hostOptions": map[string]any{"host": "localhost", "password": RedactedValue, "token": ""},
+		"users":       []any{map[string]any{"userId": "admin", "apiKey": RedactedValue}},
+		"timeout":     "5s",
+		"labels":      map[string]any{"team": "catalogs"},
+	}, Redact(options))
+}
+
+func Test_Redact_Nil_Options(t *testing.T) {
+	assert.Nil(t, Redact(nil))
+	assert.Equal(
+		t,
+		map[string]any{"name": "", "hostOptions": nil, "users": nil, "timeout": "0s", "labels": nil},
+		Redact(&redactOptions{}),
+	)
+}
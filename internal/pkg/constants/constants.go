@@ -25,6 +25,9 @@ const (
 	Dev                  = "development"
 	Test                 = "test"
 	Production           = "production"
+	Staging              = "staging"
+	Perf                 = "perf"
+	CI                   = "ci"
 )
 
 const (
@@ -34,6 +37,7 @@ const (
 	ErrUnauthorizedTitle        = "Unauthorized"
 	ErrForbiddenTitle           = "Forbidden"
 	ErrRequestTimeoutTitle      = "Request Timeout"
+	ErrRequestCanceledTitle     = "Request Canceled"
 	ErrInternalServerErrorTitle = "Internal Server Error"
 	ErrDomainTitle              = "Domain Model Error"
 	ErrApplicationTitle         = "Application Service Error"
@@ -0,0 +1,36 @@
+package changefeed
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+)
+
+// Change is an event of the change feed, the Cursor of a change reads the changes after it, so a consumer can
+// checkpoint in the middle of a page
+type Change struct {
+	Id         string            `json:"id"`
+	Type       string            `json:"type"`
+	Stream     string            `json:"stream,omitempty"`
+	Cursor     string            `json:"cursor"`
+	OccurredAt time.Time         `json:"occurredAt"`
+	Data       json.RawMessage   `json:"data"`
+	Metadata   metadata.Metadata `json:"metadata,omitempty"`
+}
+
+// ChangePage is a page of the change feed, the Cursor reads the next page and it is the requested cursor when there
+// is no new change, so a consumer keeps polling with the last cursor it has processed
+type ChangePage struct {
+	Changes []*Change `json:"changes"`
+	Cursor  string    `json:"cursor"`
+	HasMore bool      `json:"hasMore"`
+}
+
+// ChangeFeed reads the changes of a service in the order of their cursors, the cursors are monotonically increasing, so
+// a consumer which stores the cursor after processing a page reads every change at least once
+type ChangeFeed interface {
+	// Read returns the changes after the cursor, an empty cursor reads from the start of the feed
+	Read(ctx context.Context, cursor string, limit int) (*ChangePage, error)
+}
@@ -0,0 +1,156 @@
+package changefeed
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/contracts"
+	apikey "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/api_key"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+	// pollInterval re-reads the feed of a long poll for the changes which are not notified, like the changes of the
+	// other instances or the held back changes of the outbox
+	pollInterval = time.Second
+)
+
+type ChangeFeedEndpoint struct {
+	feed         ChangeFeed
+	notifier     ChangeNotifier
+	options      *ChangeFeedOptions
+	echoServer   contracts.EchoHttpServer
+	pollInterval time.Duration
+}
+
+func NewChangeFeedEndpoint(
+	feed ChangeFeed,
+	notifier ChangeNotifier,
+	options *ChangeFeedOptions,
+	server contracts.EchoHttpServer,
+) *ChangeFeedEndpoint {
+	return &ChangeFeedEndpoint{
+		feed:         feed,
+		notifier:     notifier,
+		options:      options,
+		echoServer:   server,
+		pollInterval: pollInterval,
+	}
+}
+
+// RegisterEndpoints registers the feed endpoint on the configured path, it is authenticated with the api keys of the
+// consumers and it is not registered without any consumer
+func (e *ChangeFeedEndpoint) RegisterEndpoints() {
+	var keys []apikey.Option
+	for _, consumer := range e.options.Consumers {
+		if consumer != nil {
+			keys = append(keys, apikey.WithKey(consumer.ApiKey, consumer.UserId))
+		}
+	}
+
+	if len(keys) == 0 || e.options.Path == "" {
+		return
+	}
+
+	e.echoServer.GetEchoInstance().GET(e.options.Path, e.changes, apikey.ApiKey(keys...))
+}
+
+// changes returns the page of the changes after the `cursor` query param, a consumer should store the cursor of the
+// page only after processing its changes, so the changes of a failed page are read again. With the `wait` query param
+// in seconds it is a long poll, an empty page waits for a change up to `wait` seconds, so the clients without the
+// server sent events, like the mobile apps, sync their deltas without polling the feed in a loop.
+func (e *ChangeFeedEndpoint) changes(c echo.Context) error {
+	limit, err := limitParam(c)
+	if err != nil {
+		return err
+	}
+
+	wait, err := waitParam(c, e.options.MaxWaitSeconds)
+	if err != nil {
+		return err
+	}
+
+	page, err := e.read(c.Request().Context(), c.QueryParam("cursor"), limit, wait)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+// read returns the first page which has a change, or the empty page when there is no change in the wait time
+func (e *ChangeFeedEndpoint) read(
+	ctx context.Context,
+	cursor string,
+	limit int,
+	wait time.Duration,
+) (*ChangePage, error) {
+	timeout := time.NewTimer(wait)
+	defer timeout.Stop()
+
+	for {
+		changed := e.notifier.Changed()
+
+		page, err := e.feed.Read(ctx, cursor, limit)
+		if err != nil || len(page.Changes) > 0 || page.HasMore || wait <= 0 {
+			return page, err
+		}
+
+		// a page without any change in a busy feed is not empty, its cursor skips the events of the other streams
+		cursor = page.Cursor
+
+		poll := time.NewTimer(e.pollInterval)
+
+		select {
+		case <-changed:
+		case <-poll.C:
+		case <-timeout.C:
+			poll.Stop()
+
+			return page, nil
+		case <-ctx.Done():
+			poll.Stop()
+
+			return page, nil
+		}
+
+		poll.Stop()
+	}
+}
+
+func limitParam(c echo.Context) (int, error) {
+	value := c.QueryParam("limit")
+	if value == "" {
+		return defaultLimit, nil
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 || limit > maxLimit {
+		return 0, customErrors.NewBadRequestError(fmt.Sprintf("limit should be a positive number up to %d", maxLimit))
+	}
+
+	return limit, nil
+}
+
+func waitParam(c echo.Context, maxWaitSeconds int) (time.Duration, error) {
+	value := c.QueryParam("wait")
+	if value == "" {
+		return 0, nil
+	}
+
+	wait, err := strconv.Atoi(value)
+	if err != nil || wait < 0 || wait > maxWaitSeconds {
+		return 0, customErrors.NewBadRequestError(
+			fmt.Sprintf("wait should be a number of seconds up to %d", maxWaitSeconds),
+		)
+	}
+
+	return time.Duration(wait) * time.Second, nil
+}
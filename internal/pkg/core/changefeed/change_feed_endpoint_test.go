@@ -0,0 +1,155 @@
+package changefeed
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	customEcho "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/contracts"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeChangeFeed struct {
+	cursor string
+	limit  int
+}
+
+func (f *fakeChangeFeed) Read(ctx context.Context, cursor string, limit int) (*ChangePage, error) {
+	f.cursor, f.limit = cursor, limit
+
+	return &ChangePage{
+		Changes: []*Change{{Id: "1", Type: "ProductCreatedV1", Cursor: "next", Data: []byte(`{"name":"phone"}`)}},
+		Cursor:  "next",
+		HasMore: true,
+	}, nil
+}
+
+// emptyUntilChangedFeed returns empty pages until its change is stored
+type emptyUntilChangedFeed struct {
+	changed atomic.Bool
+	reads   atomic.Int32
+}
+
+func (f *emptyUntilChangedFeed) Read(ctx context.Context, cursor string, limit int) (*ChangePage, error) {
+	f.reads.Add(1)
+	if !f.changed.Load() {
+		return &ChangePage{Changes: []*Change{}, Cursor: cursor}, nil
+	}
+
+	return &ChangePage{Changes: []*Change{{Id: "1", Cursor: "next"}}, Cursor: "next"}, nil
+}
+
+func newTestEchoServer(feed ChangeFeed, consumers ...*ConsumerOptions) contracts.EchoHttpServer {
+	server := customEcho.NewEchoHttpServer(&config.EchoHttpOptions{}, defaultLogger.GetLogger(), nil)
+	NewChangeFeedEndpoint(
+		feed,
+		NewChangeNotifier(),
+		&ChangeFeedOptions{Path: "api/v1/products/changes", Consumers: consumers, MaxWaitSeconds: 10},
+		server,
+	).RegisterEndpoints()
+
+	return server
+}
+
+func serve(server contracts.EchoHttpServer, target string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.Header.Set("X-Api-Key", "secret")
+	rec := httptest.NewRecorder()
+	server.GetEchoInstance().ServeHTTP(rec, req)
+
+	return rec
+}
+
+func Test_Change_Feed_Endpoint_Read(t *testing.T) {
+	feed := &fakeChangeFeed{}
+	server := newTestEchoServer(feed, &ConsumerOptions{UserId: "etl", ApiKey: "secret"})
+
+	rec := serve(server, "/api/v1/products/changes?cursor=abc&limit=10")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "abc", feed.cursor)
+	assert.Equal(t, 10, feed.limit)
+	assert.Contains(t, rec.Body.String(), `"data":{"name":"phone"}`)
+	assert.Contains(t, rec.Body.String(), `"cursor":"next","hasMore":true`)
+}
+
+func Test_Change_Feed_Endpoint_Default_Limit(t *testing.T) {
+	feed := &fakeChangeFeed{}
+	server := newTestEchoServer(feed, &ConsumerOptions{UserId: "etl", ApiKey: "secret"})
+
+	rec := serve(server, "/api/v1/products/changes")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "", feed.cursor)
+	assert.Equal(t, defaultLimit, feed.limit)
+}
+
+func Test_Change_Feed_Endpoint_Invalid_Limit(t *testing.T) {
+	for _, limit := range []string{"0", "5000", "ten"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/products/changes?limit="+limit, nil)
+		c := echo.New().NewContext(req, httptest.NewRecorder())
+
+		_, err := limitParam(c)
+
+		assert.True(t, customErrors.IsBadRequestError(err), limit)
+	}
+}
+
+func Test_Change_Feed_Endpoint_Without_Consumers(t *testing.T) {
+	server := newTestEchoServer(&fakeChangeFeed{})
+
+	rec := serve(server, "/api/v1/products/changes")
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func Test_Change_Feed_Long_Poll_Wakes_Up_On_Change(t *testing.T) {
+	feed := &emptyUntilChangedFeed{}
+	notifier := NewChangeNotifier()
+	endpoint := &ChangeFeedEndpoint{feed: feed, notifier: notifier, pollInterval: time.Minute}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		feed.changed.Store(true)
+		notifier.Notify()
+	}()
+
+	page, err := endpoint.read(context.Background(), "abc", 10, 5*time.Second)
+
+	assert.NoError(t, err)
+	assert.Len(t, page.Changes, 1)
+	assert.Equal(t, "next", page.Cursor)
+	assert.Equal(t, int32(2), feed.reads.Load())
+}
+
+func Test_Change_Feed_Long_Poll_Returns_Empty_Page_After_Wait(t *testing.T) {
+	feed := &emptyUntilChangedFeed{}
+	endpoint := &ChangeFeedEndpoint{feed: feed, notifier: NewChangeNotifier(), pollInterval: 20 * time.Millisecond}
+
+	page, err := endpoint.read(context.Background(), "abc", 10, 100*time.Millisecond)
+
+	assert.NoError(t, err)
+	assert.Empty(t, page.Changes)
+	assert.Equal(t, "abc", page.Cursor)
+	assert.Greater(t, feed.reads.Load(), int32(1))
+}
+
+func Test_Change_Feed_Endpoint_Invalid_Wait(t *testing.T) {
+	for _, wait := range []string{"-1", "11", "ten"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/products/changes?wait="+wait, nil)
+		c := echo.New().NewContext(req, httptest.NewRecorder())
+
+		_, err := waitParam(c, 10)
+
+		assert.True(t, customErrors.IsBadRequestError(err), wait)
+	}
+}
@@ -0,0 +1,21 @@
+package changefeed
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provides the change feed endpoint, the ChangeFeed is provided by the persistence modules like
+// `postgresmessaging.ChangeFeedModule` or `eventstroredb.ChangeFeedModule`, the endpoint needs an echo server. The
+// ChangeNotifier of the long polls is provided here and notified by the persistence modules which can observe the changes.
+// https://uber-go.github.io/fx/modules.html
+var Module = fx.Module( //nolint:gochecknoglobals
+	"changefeedfx",
+	fx.Provide(
+		ProvideConfig,
+		NewChangeNotifier,
+		NewChangeFeedEndpoint,
+	),
+	fx.Invoke(func(endpoint *ChangeFeedEndpoint) {
+		endpoint.RegisterEndpoints()
+	}),
+)
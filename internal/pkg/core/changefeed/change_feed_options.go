@@ -0,0 +1,35 @@
+package changefeed
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[ChangeFeedOptions]())
+
+// ChangeFeedOptions controls the change feed endpoint of a service, the feed is read by the external consumers like the
+// etl jobs which can't subscribe to the broker.
+type ChangeFeedOptions struct {
+	// Path is the path of the feed endpoint, like `api/v1/products/changes`
+	Path string `mapstructure:"path"`
+	// Consumers authenticate the feed endpoint with the api key of a consumer in the `X-Api-Key` header, the endpoint
+	// is not registered without any consumer
+	Consumers []*ConsumerOptions `mapstructure:"consumers"`
+	// SettleSeconds holds back the changes which are younger than it in the feeds which can't order the changes by
+	// their commit, like the outbox, so a change which is committed after a later change is not skipped by a cursor
+	SettleSeconds int `mapstructure:"settleSeconds" default:"2"`
+	// MaxWaitSeconds is the longest `wait` of a long poll, it should be shorter than the write timeout of the http server
+	MaxWaitSeconds int `mapstructure:"maxWaitSeconds" default:"10"`
+}
+
+type ConsumerOptions struct {
+	UserId string `mapstructure:"userId"`
+	ApiKey string `mapstructure:"apiKey" secret:"true"`
+}
+
+func ProvideConfig(environment environment.Environment) (*ChangeFeedOptions, error) {
+	return config.BindConfigKey[*ChangeFeedOptions](optionName, environment)
+}
@@ -0,0 +1,39 @@
+package changefeed
+
+import (
+	"sync"
+)
+
+// ChangeNotifier wakes up the long polls of the change feed when a change is stored. The notifications are only of the
+// current instance, so a long poll re-reads the feed periodically for the changes of the other instances too.
+type ChangeNotifier interface {
+	// Notify wakes up the waiters of the current changes
+	Notify()
+	// Changed returns a channel which is closed on the next Notify, it should be taken before reading the feed, so a
+	// change which is stored after the read is not missed
+	Changed() <-chan struct{}
+}
+
+type changeNotifier struct {
+	mu      sync.Mutex
+	changed chan struct{}
+}
+
+func NewChangeNotifier() ChangeNotifier {
+	return &changeNotifier{changed: make(chan struct{})}
+}
+
+func (n *changeNotifier) Notify() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	close(n.changed)
+	n.changed = make(chan struct{})
+}
+
+func (n *changeNotifier) Changed() <-chan struct{} {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.changed
+}
@@ -0,0 +1,32 @@
+package changefeed
+
+import (
+	"encoding/base64"
+	"strings"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+)
+
+const cursorSeparator = "|"
+
+// EncodeCursor returns the opaque token of a position in a feed, the consumers should not parse the token because the
+// positions of the feeds are different
+func EncodeCursor(parts ...string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strings.Join(parts, cursorSeparator)))
+}
+
+// DecodeCursor returns the parts of a cursor token which is created by EncodeCursor, a token with another number of
+// parts is not a cursor of the feed
+func DecodeCursor(cursor string, parts int) ([]string, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, customErrors.NewBadRequestErrorWrap(err, "cursor is not valid")
+	}
+
+	values := strings.Split(string(decoded), cursorSeparator)
+	if len(values) != parts {
+		return nil, customErrors.NewBadRequestError("cursor is not valid")
+	}
+
+	return values, nil
+}
@@ -0,0 +1,27 @@
+package changefeed
+
+import (
+	"testing"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Cursor_Round_Trip(t *testing.T) {
+	cursor := EncodeCursor("1700000000000000000", "7f3c1a52-3c4e-4a8e-9d59-8b0f4e2a9c11")
+
+	parts, err := DecodeCursor(cursor, 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"1700000000000000000", "7f3c1a52-3c4e-4a8e-9d59-8b0f4e2a9c11"}, parts)
+}
+
+func Test_Decode_Invalid_Cursor(t *testing.T) {
+	_, err := DecodeCursor("not a cursor!", 2)
+	assert.True(t, customErrors.IsBadRequestError(err))
+
+	_, err = DecodeCursor(EncodeCursor("1"), 2)
+	assert.True(t, customErrors.IsBadRequestError(err))
+}
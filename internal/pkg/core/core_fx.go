@@ -1,7 +1,13 @@
 package core
 
 import (
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer/avro"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer/json"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer/msgpack"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer/protobuf"
 
 	"go.uber.org/fx"
 )
@@ -16,4 +22,22 @@ var Module = fx.Module(
 		json.NewDefaultMessageJsonSerializer,
 		json.NewDefaultMetadataJsonSerializer,
 	),
+	// the message serializers of the content types, the buses select their serializers from them by their
+	// `contentType` option
+	fx.Provide(
+		asMessageSerializer(json.NewDefaultMessageJsonSerializer),
+		asMessageSerializer(protobuf.NewProtobufMessageSerializer, ``, `optional:"true"`),
+		asMessageSerializer(avro.NewAvroMessageSerializer, ``, `optional:"true"`),
+		asMessageSerializer(msgpack.NewMsgpackMessageSerializer),
+	),
 )
+
+// asMessageSerializer adds a message serializer to the message serializers group, the schema registry of the
+// serializers is optional and is provided by the `schemaregistry` module
+func asMessageSerializer(f interface{}, paramTags ...string) interface{} {
+	return fx.Annotate(
+		f,
+		fx.ParamTags(paramTags...),
+		fx.ResultTags(fmt.Sprintf(`group:"%s"`, serializer.MessageSerializersGroup)),
+	)
+}
@@ -0,0 +1,294 @@
+package cqrs
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"emperror.dev/errors"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+// HandlerDescriptor describes a registered request handler in the dispatch table
+type HandlerDescriptor struct {
+	RequestType  reflect.Type
+	ResponseType reflect.Type
+	HandlerType  reflect.Type
+}
+
+// dispatchFunc is a request handler composed with the pipeline behaviors, so sending a request doesn't resolve
+// handlers and pipelines again
+type dispatchFunc func(ctx context.Context, request interface{}) (interface{}, error)
+
+type handlerRegistration struct {
+	descriptor HandlerDescriptor
+	// compose builds the dispatchFunc of the handler for the given pipeline behaviors
+	compose func(behaviors []mediatr.PipelineBehavior) dispatchFunc
+}
+
+type dispatchTable struct {
+	mu            sync.Mutex
+	registrations map[reflect.Type][]*handlerRegistration
+	behaviors     []mediatr.PipelineBehavior
+	// dispatchers is the immutable table which is built on startup by `BuildDispatchTable`, nil means the table is not
+	// built yet and requests are sent through the mediatr registry
+	dispatchers atomic.Pointer[map[reflect.Type]dispatchFunc]
+}
+
+var defaultDispatchTable = newDispatchTable()
+
+func newDispatchTable() *dispatchTable {
+	return &dispatchTable{registrations: map[reflect.Type][]*handlerRegistration{}}
+}
+
+// RegisterRequestHandler registers the request handler to the dispatch table and the mediatr registry, so requests
+// sent with both `Send` and `mediatr.Send` reach the handler.
+func RegisterRequestHandler[TRequest any, TResponse any](
+	handler mediatr.RequestHandler[TRequest, TResponse],
+) error {
+	registration := &handlerRegistration{
+		descriptor: HandlerDescriptor{
+			RequestType:  typemapper.GetGenericTypeByT[TRequest](),
+			ResponseType: typemapper.GetGenericTypeByT[TResponse](),
+			HandlerType:  reflect.TypeOf(handler),
+		},
+		compose: func(behaviors []mediatr.PipelineBehavior) dispatchFunc {
+			return composeDispatchFunc[TRequest, TResponse](handler, behaviors)
+		},
+	}
+
+	defaultDispatchTable.addRegistration(registration)
+
+	return mediatr.RegisterRequestHandler[TRequest, TResponse](handler)
+}
+
+// RegisterRequestPipelineBehaviors registers the pipeline behaviors to the dispatch table and the mediatr registry,
+// behaviors run in the registration order. a behavior with an already registered type is skipped, so the apps which
+// are composed in one process (e.g. the development host) share the behaviors of the first registered app.
+func RegisterRequestPipelineBehaviors(behaviors ...mediatr.PipelineBehavior) error {
+	defaultDispatchTable.mu.Lock()
+	defer defaultDispatchTable.mu.Unlock()
+
+	for _, behavior := range behaviors {
+		if defaultDispatchTable.existsBehaviorType(reflect.TypeOf(behavior)) {
+			continue
+		}
+
+		// the mediatr registry has no way to clear the behaviors, so after `ClearRegistrations` the behavior type could
+		// still exist there, the only error of the registration is the existing behavior type
+		_ = mediatr.RegisterRequestPipelineBehaviors(behavior)
+
+		defaultDispatchTable.behaviors = append(defaultDispatchTable.behaviors, behavior)
+	}
+
+	return nil
+}
+
+// BuildDispatchTable validates the registered handlers and pre-resolves them with their pipeline behaviors into the
+// dispatch table. It should be called on startup after registering all handlers and behaviors, handlers registered
+// afterward are only reachable through the mediatr registry.
+func BuildDispatchTable(requestsPackagePrefixes ...string) error {
+	return defaultDispatchTable.build(requestsPackagePrefixes)
+}
+
+// ValidateRequestHandlers checks every request has exactly one handler, requests are the registered requests and the
+// commands and queries in the packages with the given prefixes.
+func ValidateRequestHandlers(requestsPackagePrefixes ...string) error {
+	defaultDispatchTable.mu.Lock()
+	defer defaultDispatchTable.mu.Unlock()
+
+	return defaultDispatchTable.validate(requestsPackagePrefixes)
+}
+
+// RegisteredHandlers returns the descriptors of the registered request handlers ordered by request type name
+func RegisteredHandlers() []HandlerDescriptor {
+	defaultDispatchTable.mu.Lock()
+	defer defaultDispatchTable.mu.Unlock()
+
+	var descriptors []HandlerDescriptor
+	for _, registrations := range defaultDispatchTable.registrations {
+		for _, registration := range registrations {
+			descriptors = append(descriptors, registration.descriptor)
+		}
+	}
+
+	sort.Slice(descriptors, func(i, j int) bool {
+		return descriptors[i].RequestType.String() < descriptors[j].RequestType.String()
+	})
+
+	return descriptors
+}
+
+// ClearRegistrations removes the registered handlers and behaviors from the dispatch table and the mediatr registry
+func ClearRegistrations() {
+	defaultDispatchTable.mu.Lock()
+	defer defaultDispatchTable.mu.Unlock()
+
+	defaultDispatchTable.registrations = map[reflect.Type][]*handlerRegistration{}
+	defaultDispatchTable.behaviors = nil
+	defaultDispatchTable.dispatchers.Store(nil)
+
+	mediatr.ClearRequestRegistrations()
+}
+
+// Send the request to its pre-resolved handler in the dispatch table, it falls back to `mediatr.Send` when the
+// dispatch table is not built yet.
+func Send[TRequest any, TResponse any](ctx context.Context, request TRequest) (TResponse, error) {
+	dispatchers := defaultDispatchTable.dispatchers.Load()
+	if dispatchers == nil {
+		return mediatr.Send[TRequest, TResponse](ctx, request)
+	}
+
+	dispatch, ok := (*dispatchers)[reflect.TypeOf(request)]
+	if !ok {
+		return mediatr.Send[TRequest, TResponse](ctx, request)
+	}
+
+	response, err := dispatch(ctx, request)
+	if err != nil {
+		return *new(TResponse), errors.Wrap(err, "error handling request")
+	}
+
+	typedResponse, ok := response.(TResponse)
+	if !ok && response != nil {
+		return *new(TResponse), errors.Errorf(
+			"response of request %T is %T, expected %s",
+			request,
+			response,
+			typemapper.GetGenericTypeByT[TResponse](),
+		)
+	}
+
+	return typedResponse, nil
+}
+
+func (d *dispatchTable) addRegistration(registration *handlerRegistration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	requestType := registration.descriptor.RequestType
+	d.registrations[requestType] = append(d.registrations[requestType], registration)
+}
+
+func (d *dispatchTable) existsBehaviorType(behaviorType reflect.Type) bool {
+	for _, behavior := range d.behaviors {
+		if reflect.TypeOf(behavior) == behaviorType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (d *dispatchTable) build(requestsPackagePrefixes []string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err := d.validate(requestsPackagePrefixes); err != nil {
+		return err
+	}
+
+	dispatchers := make(map[reflect.Type]dispatchFunc, len(d.registrations))
+	for requestType, registrations := range d.registrations {
+		dispatchers[requestType] = registrations[0].compose(d.behaviors)
+	}
+
+	d.dispatchers.Store(&dispatchers)
+
+	return nil
+}
+
+func (d *dispatchTable) validate(requestsPackagePrefixes []string) error {
+	var problems []string
+
+	for requestType, registrations := range d.registrations {
+		if len(registrations) > 1 {
+			handlerTypes := make([]string, 0, len(registrations))
+			for _, registration := range registrations {
+				handlerTypes = append(handlerTypes, registration.descriptor.HandlerType.String())
+			}
+
+			problems = append(problems, fmt.Sprintf(
+				"request %s has %d handlers: %s",
+				requestType,
+				len(registrations),
+				strings.Join(handlerTypes, ", "),
+			))
+		}
+	}
+
+	for _, requestType := range discoverRequestTypes(requestsPackagePrefixes) {
+		if _, ok := d.registrations[requestType]; !ok {
+			problems = append(problems, fmt.Sprintf("request %s has no handler", requestType))
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	sort.Strings(problems)
+
+	return errors.Errorf("invalid request handlers registrations: %s", strings.Join(problems, "; "))
+}
+
+// discoverRequestTypes returns the pointer types of the commands and queries declared in the packages with the given
+// prefixes, requests are sent as pointers so the struct types are skipped.
+func discoverRequestTypes(packagePrefixes []string) []reflect.Type {
+	if len(packagePrefixes) == 0 {
+		return nil
+	}
+
+	seen := make(map[reflect.Type]bool)
+	var requestTypes []reflect.Type
+
+	candidates := append(
+		typemapper.TypesImplementedInterface[Command](),
+		typemapper.TypesImplementedInterface[Query]()...,
+	)
+	for _, typ := range candidates {
+		if seen[typ] || typ.Kind() != reflect.Ptr {
+			continue
+		}
+		seen[typ] = true
+
+		for _, prefix := range packagePrefixes {
+			if strings.HasPrefix(typ.Elem().PkgPath(), prefix) {
+				requestTypes = append(requestTypes, typ)
+
+				break
+			}
+		}
+	}
+
+	return requestTypes
+}
+
+func composeDispatchFunc[TRequest any, TResponse any](
+	handler mediatr.RequestHandler[TRequest, TResponse],
+	behaviors []mediatr.PipelineBehavior,
+) dispatchFunc {
+	var dispatch dispatchFunc = func(ctx context.Context, request interface{}) (interface{}, error) {
+		return handler.Handle(ctx, request.(TRequest))
+	}
+
+	// the first registered behavior is the outermost one
+	for i := len(behaviors) - 1; i >= 0; i-- {
+		behavior := behaviors[i]
+		next := dispatch
+
+		dispatch = func(ctx context.Context, request interface{}) (interface{}, error) {
+			return behavior.Handle(ctx, request, func(ctx context.Context) (interface{}, error) {
+				return next(ctx, request)
+			})
+		}
+	}
+
+	return dispatch
+}
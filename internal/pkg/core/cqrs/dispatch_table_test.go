@@ -0,0 +1,162 @@
+package cqrs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mehdihadeli/go-mediatr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type PingDispatchTest struct {
+	Command
+
+	Message string
+}
+
+type pingDispatchTestHandler struct{}
+
+func (h *pingDispatchTestHandler) Handle(ctx context.Context, command *PingDispatchTest) (string, error) {
+	return "pong: " + command.Message, nil
+}
+
+type UnhandledDispatchTest struct {
+	Query
+}
+
+// mediatr registry accepts one behavior per type
+type firstRecorderBehavior struct {
+	order *[]string
+}
+
+func (b *firstRecorderBehavior) Handle(
+	ctx context.Context,
+	request interface{},
+	next mediatr.RequestHandlerFunc,
+) (interface{}, error) {
+	*b.order = append(*b.order, "first")
+
+	return next(ctx)
+}
+
+type secondRecorderBehavior struct {
+	order *[]string
+}
+
+func (b *secondRecorderBehavior) Handle(
+	ctx context.Context,
+	request interface{},
+	next mediatr.RequestHandlerFunc,
+) (interface{}, error) {
+	*b.order = append(*b.order, "second")
+
+	return next(ctx)
+}
+
+func Test_Send_Through_Built_Dispatch_Table(t *testing.T) {
+	ClearRegistrations()
+	defer ClearRegistrations()
+
+	var order []string
+	err := RegisterRequestPipelineBehaviors(
+		&firstRecorderBehavior{order: &order},
+		&secondRecorderBehavior{order: &order},
+	)
+	require.NoError(t, err)
+
+	err = RegisterRequestHandler[*PingDispatchTest, string](&pingDispatchTestHandler{})
+	require.NoError(t, err)
+
+	require.NoError(t, BuildDispatchTable())
+
+	response, err := Send[*PingDispatchTest, string](context.Background(), &PingDispatchTest{Message: "hi"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "pong: hi", response)
+	assert.Equal(t, []string{"first", "second"}, order)
+}
+
+func Test_Register_Pipeline_Behaviors_Skips_Registered_Behavior_Types(t *testing.T) {
+	ClearRegistrations()
+	defer ClearRegistrations()
+
+	var order []string
+	err := RegisterRequestPipelineBehaviors(&firstRecorderBehavior{order: &order})
+	require.NoError(t, err)
+
+	// a second app in the same process registers the same behavior types
+	err = RegisterRequestPipelineBehaviors(
+		&firstRecorderBehavior{order: &order},
+		&secondRecorderBehavior{order: &order},
+	)
+	require.NoError(t, err)
+
+	err = RegisterRequestHandler[*PingDispatchTest, string](&pingDispatchTestHandler{})
+	require.NoError(t, err)
+
+	require.NoError(t, BuildDispatchTable())
+
+	_, err = Send[*PingDispatchTest, string](context.Background(), &PingDispatchTest{Message: "hi"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"first", "second"}, order)
+}
+
+func Test_Send_Falls_Back_To_Mediatr_Before_Building_Table(t *testing.T) {
+	ClearRegistrations()
+	defer ClearRegistrations()
+
+	err := RegisterRequestHandler[*PingDispatchTest, string](&pingDispatchTestHandler{})
+	require.NoError(t, err)
+
+	response, err := Send[*PingDispatchTest, string](context.Background(), &PingDispatchTest{Message: "hi"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "pong: hi", response)
+}
+
+func Test_Validate_Request_Handlers_Reports_Duplicate_Handlers(t *testing.T) {
+	ClearRegistrations()
+	defer ClearRegistrations()
+
+	err := RegisterRequestHandler[*PingDispatchTest, string](&pingDispatchTestHandler{})
+	require.NoError(t, err)
+
+	err = RegisterRequestHandler[*PingDispatchTest, string](&pingDispatchTestHandler{})
+	assert.Error(t, err)
+
+	err = BuildDispatchTable()
+	assert.ErrorContains(t, err, "PingDispatchTest has 2 handlers")
+}
+
+func Test_Validate_Request_Handlers_Reports_Requests_Without_Handler(t *testing.T) {
+	ClearRegistrations()
+	defer ClearRegistrations()
+
+	err := RegisterRequestHandler[*PingDispatchTest, string](&pingDispatchTestHandler{})
+	require.NoError(t, err)
+
+	// requests are discovered from the binary type links, so the pointer type should be referenced
+	assert.True(t, IsQuery(&UnhandledDispatchTest{}))
+
+	err = ValidateRequestHandlers("github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs")
+
+	assert.ErrorContains(t, err, "UnhandledDispatchTest has no handler")
+	assert.NotContains(t, err.Error(), "PingDispatchTest")
+}
+
+func Test_Registered_Handlers(t *testing.T) {
+	ClearRegistrations()
+	defer ClearRegistrations()
+
+	err := RegisterRequestHandler[*PingDispatchTest, string](&pingDispatchTestHandler{})
+	require.NoError(t, err)
+
+	descriptors := RegisteredHandlers()
+
+	require.Len(t, descriptors, 1)
+	assert.Equal(t, "*cqrs.PingDispatchTest", descriptors[0].RequestType.String())
+	assert.Equal(t, "string", descriptors[0].ResponseType.String())
+	assert.Equal(t, "*cqrs.pingDispatchTestHandler", descriptors[0].HandlerType.String())
+}
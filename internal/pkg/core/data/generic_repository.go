@@ -16,6 +16,9 @@ type GenericRepositoryWithDataModel[TDataModel interface{}, TEntity interface{}]
 	GetByFilter(ctx context.Context, filters map[string]interface{}) ([]TEntity, error)
 	GetByFuncFilter(ctx context.Context, filterFunc func(TEntity) bool) ([]TEntity, error)
 	GetAll(ctx context.Context, listQuery *utils.ListQuery) (*utils.ListResult[TEntity], error)
+	// Iterate streams the entities matching the filters to the fn through a database cursor, without loading them all in
+	// the memory. The iteration stops at the first error of the fn.
+	Iterate(ctx context.Context, filters map[string]interface{}, fn func(entity TEntity) error) error
 	FirstOrDefault(ctx context.Context, filters map[string]interface{}) (TEntity, error)
 	Search(ctx context.Context, searchTerm string, listQuery *utils.ListQuery) (*utils.ListResult[TEntity], error)
 	Update(ctx context.Context, entity TEntity) error
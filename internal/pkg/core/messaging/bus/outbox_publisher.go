@@ -0,0 +1,15 @@
+package bus
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+)
+
+// OutboxPublisher stores the messages in the outbox with the transaction of the context instead of publishing them
+// directly, so the messages are persisted atomically with the changes of the handler and the outbox worker forwards
+// them to the broker after the commit.
+type OutboxPublisher interface {
+	PublishViaOutbox(ctx context.Context, message types.IMessage, meta metadata.Metadata) error
+}
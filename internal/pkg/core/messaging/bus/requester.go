@@ -0,0 +1,85 @@
+package bus
+
+import (
+	"context"
+
+	messageHeader "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/messageheader"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/producer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"emperror.dev/errors"
+)
+
+// ErrRequestTimeout is returned when the response of a request is not received before the request timeout
+var ErrRequestTimeout = errors.New("the response of the request is not received before the timeout")
+
+// Requester sends the request messages over the broker and waits for their responses, e.g. for the queries between
+// the services when their grpc endpoints are not available. the responses are received by the response consumers of
+// the requester, so the response types should be registered on the bus before it starts.
+type Requester interface {
+	// Request publishes the request with a new correlation id and the reply address of the response type, and waits
+	// for the response with the same correlation id until the request timeout or the context is done
+	Request(
+		ctx context.Context,
+		message types.IMessage,
+		responseType types.IMessage,
+		meta metadata.Metadata,
+	) (types.IMessage, error)
+}
+
+// Request sends the request message and returns its response of the `TResponse` type, the response type is a pointer
+// type like the messages of the consumers
+func Request[TResponse types.IMessage](
+	ctx context.Context,
+	requester Requester,
+	message types.IMessage,
+) (TResponse, error) {
+	var empty TResponse
+
+	response, err := requester.Request(ctx, message, typeMapper.GenericInstanceByT[TResponse](), nil)
+	if err != nil {
+		return empty, err
+	}
+
+	typedResponse, ok := response.(TResponse)
+	if !ok {
+		return empty, errors.Errorf(
+			"the response of the request is `%s`, not `%s`",
+			typeMapper.GetTypeName(response),
+			typeMapper.GetGenericTypeNameByT[TResponse](),
+		)
+	}
+
+	return typedResponse, nil
+}
+
+// Respond publishes the response of a consumed request to the reply address of the request with its correlation id,
+// it is called by the consumer handler of the request. the reply address is declared by the requester, so publishing
+// the response fails when the requester is stopped.
+func Respond(
+	ctx context.Context,
+	producer producer.Producer,
+	consumeContext types.MessageConsumeContext,
+	response types.IMessage,
+) error {
+	replyTo := messageHeader.GetReplyTo(consumeContext.Metadata())
+	if replyTo == "" {
+		return errors.Errorf(
+			"message with id: {%s} is not a request, it has no reply address",
+			consumeContext.MessageId(),
+		)
+	}
+
+	meta := metadata.Metadata{}
+	messageHeader.SetCorrelationId(meta, consumeContext.CorrelationId())
+	messageHeader.SetInReplyTo(meta, consumeContext.MessageId())
+
+	err := producer.PublishMessageWithTopicName(ctx, response, meta, replyTo)
+	if err != nil {
+		return errors.WrapIf(err, "error in publishing the response of the request")
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package claimcheck
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+)
+
+// ErrBlobNotFound is returned by the blob store when there is no blob for the key
+var ErrBlobNotFound = errors.New("claim-check blob not found")
+
+// BlobStore stores the offloaded message payloads by their claim-check keys
+type BlobStore interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,89 @@
+package claimcheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	// KeyHeader is the message header of the claim-check key of an offloaded payload
+	KeyHeader = "claim-check-key"
+	// SizeHeader is the message header of the size of an offloaded payload
+	SizeHeader = "claim-check-size"
+)
+
+// Reference is the payload which is published instead of an offloaded payload
+type Reference struct {
+	ClaimCheckKey string `json:"claimCheckKey"`
+}
+
+// ClaimCheck offloads the large message payloads to a blob store and publishes a claim-check reference instead, the
+// consumers resolve the reference back to the payload.
+type ClaimCheck interface {
+	// Offload stores the payload in the blob store when it exceeds the threshold and returns the reference payload
+	// to publish, the claim-check headers are added to the metadata. small payloads are returned as is.
+	Offload(ctx context.Context, payload []byte, meta metadata.Metadata) ([]byte, error)
+	// Resolve loads the offloaded payload of a message with the claim-check headers, ok is false for the messages
+	// without a claim-check.
+	Resolve(ctx context.Context, meta metadata.Metadata) (payload []byte, ok bool, err error)
+}
+
+type claimCheck struct {
+	options   *ClaimCheckOptions
+	blobStore BlobStore
+}
+
+func NewClaimCheck(options *ClaimCheckOptions, blobStore BlobStore) ClaimCheck {
+	return &claimCheck{options: options, blobStore: blobStore}
+}
+
+func (c *claimCheck) Offload(
+	ctx context.Context,
+	payload []byte,
+	meta metadata.Metadata,
+) ([]byte, error) {
+	if !c.options.Enabled || len(payload) <= c.options.ThresholdBytes {
+		return payload, nil
+	}
+
+	key := uuid.NewV4().String()
+	if err := c.blobStore.Put(ctx, key, payload); err != nil {
+		return nil, errors.WrapIf(err, "error in offloading the message payload")
+	}
+
+	reference, err := json.Marshal(&Reference{ClaimCheckKey: key})
+	if err != nil {
+		return nil, err
+	}
+
+	meta.Set(KeyHeader, key)
+	meta.Set(SizeHeader, int64(len(payload)))
+
+	return reference, nil
+}
+
+func (c *claimCheck) Resolve(
+	ctx context.Context,
+	meta metadata.Metadata,
+) ([]byte, bool, error) {
+	key, ok := meta.Get(KeyHeader).(string)
+	if !ok || key == "" {
+		return nil, false, nil
+	}
+
+	payload, err := c.blobStore.Get(ctx, key)
+	if err != nil {
+		return nil, true, errors.WrapIf(
+			err,
+			fmt.Sprintf("error in resolving the claim-check '%s'", key),
+		)
+	}
+
+	return payload, true, nil
+}
@@ -0,0 +1,37 @@
+package claimcheck
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[ClaimCheckOptions]())
+
+type ClaimCheckOptions struct {
+	// Enabled enables offloading the large payloads on publish, the claim-checks are resolved on consume regardless
+	Enabled bool `mapstructure:"enabled"`
+	// ThresholdBytes is the max size of a serialized payload which is published inline
+	ThresholdBytes int `mapstructure:"thresholdBytes" default:"262144"`
+	// StoragePath is the root directory of the blob store, it should be shared by the producers and the consumers,
+	// and the old blobs should be removed by a retention policy of the storage, because a message can have several
+	// consumers and the producer doesn't know when all of them consumed it
+	StoragePath string `mapstructure:"storagePath"`
+}
+
+func (c *ClaimCheckOptions) GetStoragePath() string {
+	if c.StoragePath == "" {
+		return filepath.Join(os.TempDir(), "claimchecks")
+	}
+
+	return c.StoragePath
+}
+
+func ProvideConfig(environment environment.Environment) (*ClaimCheckOptions, error) {
+	return config.BindConfigKey[*ClaimCheckOptions](optionName, environment)
+}
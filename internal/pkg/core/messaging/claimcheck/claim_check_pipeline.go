@@ -0,0 +1,64 @@
+package claimcheck
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/pipeline"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer"
+
+	"emperror.dev/errors"
+)
+
+type claimCheckPipeline struct {
+	claimCheck        ClaimCheck
+	messageSerializer serializer.MessageSerializer
+}
+
+// NewClaimCheckPipeline creates a consumer pipeline which replaces the message of a claim-check reference with the
+// offloaded payload, so the handlers receive the actual message.
+func NewClaimCheckPipeline(
+	claimCheck ClaimCheck,
+	messageSerializer serializer.MessageSerializer,
+) pipeline.ConsumerPipeline {
+	return &claimCheckPipeline{claimCheck: claimCheck, messageSerializer: messageSerializer}
+}
+
+func (c *claimCheckPipeline) Handle(
+	ctx context.Context,
+	consumerContext types.MessageConsumeContext,
+	next pipeline.ConsumerHandlerFunc,
+) error {
+	meta := consumerContext.Metadata()
+	if meta == nil {
+		return next(ctx)
+	}
+
+	payload, ok, err := c.claimCheck.Resolve(ctx, meta)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return next(ctx)
+	}
+
+	setter, ok := consumerContext.(types.MessageSetter)
+	if !ok {
+		return errors.New("consume context doesn't support replacing the claim-check message")
+	}
+
+	message, err := c.messageSerializer.Deserialize(
+		payload,
+		consumerContext.MessageType(),
+		consumerContext.ContentType(),
+	)
+	if err != nil {
+		return errors.WrapIf(err, "error in deserializing the claim-check payload")
+	}
+
+	setter.SetMessage(message)
+	// the message is resolved once, the next handlers and the retries of the consumer use the resolved message
+	delete(meta, KeyHeader)
+
+	return next(ctx)
+}
@@ -0,0 +1,125 @@
+package claimcheck
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	jsonSerializer "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer/json"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type claimCheckTestMessage struct {
+	*types.Message
+	Data string
+}
+
+func newTestClaimCheck(t *testing.T, threshold int) ClaimCheck {
+	options := &ClaimCheckOptions{Enabled: true, ThresholdBytes: threshold, StoragePath: t.TempDir()}
+
+	return NewClaimCheck(options, NewFileSystemBlobStore(options))
+}
+
+func Test_Offload_Small_Payload(t *testing.T) {
+	claimCheck := newTestClaimCheck(t, 10)
+	meta := metadata.Metadata{}
+
+	body, err := claimCheck.Offload(context.Background(), []byte("small"), meta)
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte("small"), body)
+	assert.False(t, meta.ExistsKey(KeyHeader))
+}
+
+func Test_Offload_Disabled(t *testing.T) {
+	options := &ClaimCheckOptions{ThresholdBytes: 1, StoragePath: t.TempDir()}
+	claimCheck := NewClaimCheck(options, NewFileSystemBlobStore(options))
+
+	body, err := claimCheck.Offload(context.Background(), []byte("payload"), metadata.Metadata{})
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte("payload"), body)
+}
+
+func Test_Offload_And_Resolve_Large_Payload(t *testing.T) {
+	claimCheck := newTestClaimCheck(t, 10)
+	meta := metadata.Metadata{}
+	payload := []byte(strings.Repeat("a", 100))
+
+	body, err := claimCheck.Offload(context.Background(), payload, meta)
+	require.NoError(t, err)
+
+	var reference Reference
+	require.NoError(t, json.Unmarshal(body, &reference))
+	assert.Equal(t, reference.ClaimCheckKey, meta.Get(KeyHeader))
+	assert.Equal(t, int64(100), meta.Get(SizeHeader))
+
+	resolved, ok, err := claimCheck.Resolve(context.Background(), meta)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, payload, resolved)
+}
+
+func Test_Resolve_Without_Claim_Check(t *testing.T) {
+	claimCheck := newTestClaimCheck(t, 10)
+
+	_, ok, err := claimCheck.Resolve(context.Background(), metadata.Metadata{})
+
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func Test_ClaimCheckPipeline_Replaces_Message(t *testing.T) {
+	claimCheck := newTestClaimCheck(t, 10)
+	serializer := jsonSerializer.NewDefaultMessageJsonSerializer(jsonSerializer.NewDefaultJsonSerializer())
+
+	message := &claimCheckTestMessage{
+		Message: types.NewMessage(uuid.NewV4().String()),
+		Data:    strings.Repeat("a", 100),
+	}
+	messageType := typeMapper.GetTypeName(message)
+
+	serialized, err := serializer.Serialize(message)
+	require.NoError(t, err)
+
+	meta := metadata.Metadata{}
+	body, err := claimCheck.Offload(context.Background(), serialized.Data, meta)
+	require.NoError(t, err)
+
+	reference, err := serializer.Deserialize(body, messageType, serialized.ContentType)
+	require.NoError(t, err)
+
+	consumeContext := types.NewMessageConsumeContext(
+		reference,
+		meta,
+		serialized.ContentType,
+		messageType,
+		time.Now(),
+		1,
+		message.GeMessageId(),
+		"",
+	)
+
+	var handled types.IMessage
+	err = NewClaimCheckPipeline(claimCheck, serializer).Handle(
+		context.Background(),
+		consumeContext,
+		func(ctx context.Context) error {
+			handled = consumeContext.Message()
+			return nil
+		},
+	)
+
+	require.NoError(t, err)
+	require.IsType(t, &claimCheckTestMessage{}, handled)
+	assert.Equal(t, message.Data, handled.(*claimCheckTestMessage).Data)
+	assert.False(t, meta.ExistsKey(KeyHeader))
+}
@@ -0,0 +1,16 @@
+package claimcheck
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provided to fxlog
+// https://uber-go.github.io/fx/modules.html
+var Module = fx.Module( //nolint:gochecknoglobals
+	"claimcheckfx",
+	fx.Provide(
+		ProvideConfig,
+		NewFileSystemBlobStore,
+		NewClaimCheck,
+	),
+)
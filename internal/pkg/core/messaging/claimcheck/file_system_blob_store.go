@@ -0,0 +1,101 @@
+package claimcheck
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"emperror.dev/errors"
+)
+
+type fileSystemBlobStore struct {
+	rootPath string
+}
+
+// NewFileSystemBlobStore creates a blob store on a directory, like a volume which is mounted to all services
+func NewFileSystemBlobStore(options *ClaimCheckOptions) BlobStore {
+	return &fileSystemBlobStore{rootPath: options.GetStoragePath()}
+}
+
+func (f *fileSystemBlobStore) Put(ctx context.Context, key string, data []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	path, err := f.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(f.rootPath, 0o755); err != nil {
+		return errors.WrapIf(err, "error in creating the claim-check storage directory")
+	}
+
+	// the blob is written to a temp file and renamed, so a consumer never reads a partially written blob
+	tempFile, err := os.CreateTemp(f.rootPath, key+".*.tmp")
+	if err != nil {
+		return errors.WrapIf(err, "error in creating the claim-check blob")
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.Write(data); err != nil {
+		_ = tempFile.Close()
+		return errors.WrapIf(err, "error in writing the claim-check blob")
+	}
+
+	if err := tempFile.Close(); err != nil {
+		return errors.WrapIf(err, "error in writing the claim-check blob")
+	}
+
+	return errors.WrapIf(os.Rename(tempFile.Name(), path), "error in writing the claim-check blob")
+}
+
+func (f *fileSystemBlobStore) Get(ctx context.Context, key string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	path, err := f.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, errors.WithMessage(ErrBlobNotFound, fmt.Sprintf("key '%s'", key))
+	}
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in reading the claim-check blob")
+	}
+
+	return data, nil
+}
+
+func (f *fileSystemBlobStore) Delete(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	path, err := f.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.WrapIf(err, "error in deleting the claim-check blob")
+	}
+
+	return nil
+}
+
+// path maps the key to a file in the root directory, the keys come from the message headers, so the keys with a path
+// are rejected
+func (f *fileSystemBlobStore) path(key string) (string, error) {
+	if key == "" || key == "." || key == ".." || strings.ContainsAny(key, `/\`) {
+		return "", errors.Errorf("invalid claim-check key '%s'", key)
+	}
+
+	return filepath.Join(f.rootPath, key), nil
+}
@@ -0,0 +1,37 @@
+package claimcheck
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FileSystemBlobStore(t *testing.T) {
+	store := NewFileSystemBlobStore(&ClaimCheckOptions{StoragePath: t.TempDir()})
+	ctx := context.Background()
+
+	require.NoError(t, store.Put(ctx, "key", []byte("payload")))
+
+	data, err := store.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("payload"), data)
+
+	require.NoError(t, store.Delete(ctx, "key"))
+	require.NoError(t, store.Delete(ctx, "key"))
+
+	_, err = store.Get(ctx, "key")
+	assert.True(t, errors.Is(err, ErrBlobNotFound))
+}
+
+func Test_FileSystemBlobStore_Rejects_Keys_With_Path(t *testing.T) {
+	store := NewFileSystemBlobStore(&ClaimCheckOptions{StoragePath: t.TempDir()})
+
+	for _, key := range []string{"", "..", "../key", "dir/key", `dir\key`} {
+		_, err := store.Get(context.Background(), key)
+		assert.Error(t, err, key)
+		assert.False(t, errors.Is(err, ErrBlobNotFound), key)
+	}
+}
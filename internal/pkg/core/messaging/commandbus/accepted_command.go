@@ -0,0 +1,26 @@
+package commandbus
+
+import (
+	"encoding/json"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+)
+
+// AcceptedCommand is the message of a command which is enqueued by the CommandBus, the command is serialized as json and
+// is dispatched by the handler of its registered command type
+type AcceptedCommand struct {
+	*types.Message
+	TrackingId  string          `json:"trackingId"`
+	CommandType string          `json:"commandType"`
+	Command     json.RawMessage `json:"command"`
+}
+
+func NewAcceptedCommand(trackingId string, commandType string, command json.RawMessage) *AcceptedCommand {
+	return &AcceptedCommand{
+		// the tracking id is the message id, so the inbox and the redeliveries of the message see the same command
+		Message:     types.NewMessage(trackingId),
+		TrackingId:  trackingId,
+		CommandType: commandType,
+		Command:     command,
+	}
+}
@@ -0,0 +1,111 @@
+package commandbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+
+	"emperror.dev/errors"
+)
+
+type acceptedCommandHandler struct {
+	store  CommandStatusStore
+	logger logger.Logger
+}
+
+// NewAcceptedCommandHandler creates the consumer handler of the AcceptedCommand messages, it dispatches the command and
+// keeps its status. a failed command is recorded as failed and the message is acknowledged, the client decides to send
+// it again, but the message is redelivered when its status can't be stored.
+func NewAcceptedCommandHandler(store CommandStatusStore, logger logger.Logger) consumer.ConsumerHandler {
+	return &acceptedCommandHandler{store: store, logger: logger}
+}
+
+func (h *acceptedCommandHandler) Handle(ctx context.Context, consumeContext types.MessageConsumeContext) error {
+	message, ok := consumeContext.Message().(*AcceptedCommand)
+	if !ok {
+		return errors.New("error in casting message to AcceptedCommand")
+	}
+
+	trackedCommand, err := h.store.Get(ctx, message.TrackingId)
+	if customErrors.IsNotFoundError(err) {
+		// the status is removed by the cleanup, so the command is too old to run
+		h.logger.Warnf(
+			"(acceptedCommandHandler) command with tracking id: {%s} is not tracked, skipping it",
+			message.TrackingId,
+		)
+
+		return nil
+	}
+
+	if err != nil {
+		return errors.WrapIf(err, "error in getting the command status")
+	}
+
+	if trackedCommand.Status.IsTerminal() {
+		return nil
+	}
+
+	dispatch, ok := getAsyncDispatchFunc(message.CommandType)
+	if !ok {
+		return h.finish(
+			ctx,
+			trackedCommand,
+			nil,
+			errors.Errorf("command `%s` is not registered as an async command", message.CommandType),
+		)
+	}
+
+	trackedCommand.Status = Processing
+	trackedCommand.UpdatedAt = time.Now()
+	if err := h.store.Update(ctx, trackedCommand); err != nil {
+		return errors.WrapIf(err, "error in updating the command status")
+	}
+
+	result, dispatchErr := dispatch(ctx, message.Command)
+
+	return h.finish(ctx, trackedCommand, result, dispatchErr)
+}
+
+func (h *acceptedCommandHandler) finish(
+	ctx context.Context,
+	trackedCommand *TrackedCommand,
+	result interface{},
+	dispatchErr error,
+) error {
+	trackedCommand.Status = Succeeded
+	trackedCommand.UpdatedAt = time.Now()
+
+	if dispatchErr != nil {
+		trackedCommand.Status = Failed
+		trackedCommand.Error = dispatchErr.Error()
+
+		h.logger.Errorw(
+			fmt.Sprintf(
+				"(acceptedCommandHandler) command with tracking id: {%s} failed, err: %v",
+				trackedCommand.TrackingId,
+				dispatchErr,
+			),
+			logger.Fields{"TrackingId": trackedCommand.TrackingId, "CommandType": trackedCommand.CommandType},
+		)
+	} else if result != nil {
+		// the command is already handled, so a result which can't be serialized doesn't fail it
+		data, err := json.Marshal(result)
+		if err != nil {
+			h.logger.Errorf("(acceptedCommandHandler) error in serializing the command result: {%v}", err)
+		} else {
+			trackedCommand.Result = data
+		}
+	}
+
+	if err := h.store.Update(ctx, trackedCommand); err != nil {
+		return errors.WrapIf(err, "error in updating the command status")
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package commandbus
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"emperror.dev/errors"
+)
+
+// asyncDispatchFunc deserializes the json command and sends it to its request handler
+type asyncDispatchFunc func(ctx context.Context, command json.RawMessage) (interface{}, error)
+
+var (
+	asyncCommandsMu sync.RWMutex                     //nolint:gochecknoglobals
+	asyncCommands   = map[string]asyncDispatchFunc{} //nolint:gochecknoglobals
+)
+
+// RegisterAsyncCommand allows the command to be enqueued with the CommandBus, the accepted command is sent with
+// `cqrs.Send` to its registered request handler. the commands are pointer types like the requests of `cqrs.Send`.
+// registering a command twice is a no-op, so the apps which are composed in one process share the registration.
+func RegisterAsyncCommand[TCommand any, TResponse any]() {
+	commandType := typeMapper.GetGenericFullTypeNameByT[TCommand]()
+
+	asyncCommandsMu.Lock()
+	defer asyncCommandsMu.Unlock()
+
+	if _, ok := asyncCommands[commandType]; ok {
+		return
+	}
+
+	asyncCommands[commandType] = func(ctx context.Context, data json.RawMessage) (interface{}, error) {
+		command := typeMapper.GenericInstanceByT[TCommand]()
+		if err := json.Unmarshal(data, command); err != nil {
+			return nil, errors.WrapIff(err, "error in deserializing the command `%s`", commandType)
+		}
+
+		return cqrs.Send[TCommand, TResponse](ctx, command)
+	}
+}
+
+func isAsyncCommand(commandType string) bool {
+	_, ok := getAsyncDispatchFunc(commandType)
+
+	return ok
+}
+
+func getAsyncDispatchFunc(commandType string) (asyncDispatchFunc, bool) {
+	asyncCommandsMu.RLock()
+	defer asyncCommandsMu.RUnlock()
+
+	dispatch, ok := asyncCommands[commandType]
+
+	return dispatch, ok
+}
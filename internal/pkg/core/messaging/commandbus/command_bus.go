@@ -0,0 +1,91 @@
+package commandbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/producer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// CommandBus enqueues the commands through the message broker instead of sending them to their handlers in the request,
+// the slow commands are accepted immediately and their statuses are tracked until their handlers finish.
+type CommandBus interface {
+	// Enqueue publishes the command and returns its tracking id, the command should be registered with
+	// `RegisterAsyncCommand`
+	Enqueue(ctx context.Context, command interface{}) (string, error)
+	// Status returns the tracked status of the command, a not found error is returned for an unknown tracking id
+	Status(ctx context.Context, trackingId string) (*TrackedCommand, error)
+}
+
+type commandBus struct {
+	producer producer.Producer
+	store    CommandStatusStore
+	logger   logger.Logger
+}
+
+func NewCommandBus(producer producer.Producer, store CommandStatusStore, logger logger.Logger) CommandBus {
+	return &commandBus{producer: producer, store: store, logger: logger}
+}
+
+func (b *commandBus) Enqueue(ctx context.Context, command interface{}) (string, error) {
+	commandType := typeMapper.GetFullTypeName(command)
+	if !isAsyncCommand(commandType) {
+		return "", errors.Errorf("command `%s` is not registered as an async command", commandType)
+	}
+
+	data, err := json.Marshal(command)
+	if err != nil {
+		return "", customErrors.NewMarshalingErrorWrap(err, "error in serializing the command")
+	}
+
+	trackingId := uuid.NewV4().String()
+
+	// the status is added before publishing, so the handler of the command always finds it
+	err = b.store.Add(ctx, NewTrackedCommand(trackingId, commandType))
+	if err != nil {
+		return "", errors.WrapIf(err, "error in adding the command status")
+	}
+
+	err = b.producer.PublishMessage(ctx, NewAcceptedCommand(trackingId, commandType, data), metadata.Metadata{})
+	if err != nil {
+		b.markPublishFailed(ctx, trackingId, commandType, err)
+
+		return "", errors.WrapIf(err, "error in publishing the accepted command")
+	}
+
+	b.logger.Infow(
+		fmt.Sprintf("[commandBus.Enqueue] command `%s` accepted with tracking id: {%s}", commandType, trackingId),
+		logger.Fields{"TrackingId": trackingId, "CommandType": commandType},
+	)
+
+	return trackingId, nil
+}
+
+func (b *commandBus) Status(ctx context.Context, trackingId string) (*TrackedCommand, error) {
+	return b.store.Get(ctx, trackingId)
+}
+
+// markPublishFailed fails the status of a command which is not published, the client doesn't receive its tracking id
+// but the status is not left as accepted for ever
+func (b *commandBus) markPublishFailed(ctx context.Context, trackingId string, commandType string, publishErr error) {
+	trackedCommand := NewTrackedCommand(trackingId, commandType)
+	trackedCommand.Status = Failed
+	trackedCommand.Error = publishErr.Error()
+	trackedCommand.UpdatedAt = time.Now()
+
+	if err := b.store.Update(ctx, trackedCommand); err != nil {
+		b.logger.Errorw(
+			fmt.Sprintf("[commandBus.Enqueue] error in failing the command with tracking id: {%s}, err: %v", trackingId, err),
+			logger.Fields{"TrackingId": trackingId, "CommandType": commandType},
+		)
+	}
+}
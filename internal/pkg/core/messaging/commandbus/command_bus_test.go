@@ -0,0 +1,207 @@
+package commandbus
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ImportProductsTest struct {
+	Names []string
+	Fail  bool
+}
+
+type ImportProductsTestResult struct {
+	Imported int
+}
+
+type importProductsTestHandler struct {
+	calls int
+}
+
+func (h *importProductsTestHandler) Handle(
+	ctx context.Context,
+	command *ImportProductsTest,
+) (*ImportProductsTestResult, error) {
+	h.calls++
+	if command.Fail {
+		return nil, errors.New("import failed")
+	}
+
+	return &ImportProductsTestResult{Imported: len(command.Names)}, nil
+}
+
+type UnregisteredAsyncCommandTest struct{}
+
+type fakeProducer struct {
+	messages []types.IMessage
+	err      error
+}
+
+func (p *fakeProducer) PublishMessage(_ context.Context, message types.IMessage, _ metadata.Metadata) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.messages = append(p.messages, message)
+
+	return nil
+}
+
+func (p *fakeProducer) PublishMessageWithTopicName(
+	ctx context.Context,
+	message types.IMessage,
+	meta metadata.Metadata,
+	_ string,
+) error {
+	return p.PublishMessage(ctx, message, meta)
+}
+
+func (p *fakeProducer) PublishMessageWithDelay(
+	ctx context.Context,
+	message types.IMessage,
+	meta metadata.Metadata,
+	_ time.Duration,
+) error {
+	return p.PublishMessage(ctx, message, meta)
+}
+
+func (p *fakeProducer) PublishMessages(ctx context.Context, messages []types.IMessage) error {
+	for _, message := range messages {
+		if err := p.PublishMessage(ctx, message, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *fakeProducer) IsProduced(func(message types.IMessage)) {}
+
+func setupCommandBus(t *testing.T) (CommandBus, CommandStatusStore, *fakeProducer, *importProductsTestHandler) {
+	t.Helper()
+
+	cqrs.ClearRegistrations()
+	t.Cleanup(cqrs.ClearRegistrations)
+
+	handler := &importProductsTestHandler{}
+	require.NoError(t, cqrs.RegisterRequestHandler[*ImportProductsTest, *ImportProductsTestResult](handler))
+	RegisterAsyncCommand[*ImportProductsTest, *ImportProductsTestResult]()
+
+	store := NewInMemoryCommandStatusStore()
+	producer := &fakeProducer{}
+
+	return NewCommandBus(producer, store, defaultLogger.GetLogger()), store, producer, handler
+}
+
+func consumeAccepted(t *testing.T, store CommandStatusStore, message types.IMessage) {
+	t.Helper()
+
+	consumeContext := types.NewMessageConsumeContext(
+		message,
+		metadata.Metadata{},
+		"application/json",
+		"AcceptedCommand",
+		time.Now(),
+		1,
+		message.GeMessageId(),
+		"",
+	)
+
+	err := NewAcceptedCommandHandler(store, defaultLogger.GetLogger()).Handle(context.Background(), consumeContext)
+	require.NoError(t, err)
+}
+
+func Test_Command_Bus_Tracks_Succeeded_Command(t *testing.T) {
+	bus, store, producer, handler := setupCommandBus(t)
+
+	trackingId, err := bus.Enqueue(context.Background(), &ImportProductsTest{Names: []string{"a", "b"}})
+	require.NoError(t, err)
+
+	status, err := bus.Status(context.Background(), trackingId)
+	require.NoError(t, err)
+	assert.Equal(t, Accepted, status.Status)
+	require.Len(t, producer.messages, 1)
+
+	consumeAccepted(t, store, producer.messages[0])
+
+	status, err = bus.Status(context.Background(), trackingId)
+	require.NoError(t, err)
+	assert.Equal(t, Succeeded, status.Status)
+	assert.Equal(t, 1, handler.calls)
+
+	var result ImportProductsTestResult
+	require.NoError(t, json.Unmarshal(status.Result, &result))
+	assert.Equal(t, 2, result.Imported)
+}
+
+func Test_Command_Bus_Tracks_Failed_Command(t *testing.T) {
+	bus, store, producer, _ := setupCommandBus(t)
+
+	trackingId, err := bus.Enqueue(context.Background(), &ImportProductsTest{Fail: true})
+	require.NoError(t, err)
+
+	consumeAccepted(t, store, producer.messages[0])
+
+	status, err := bus.Status(context.Background(), trackingId)
+	require.NoError(t, err)
+	assert.Equal(t, Failed, status.Status)
+	assert.Contains(t, status.Error, "import failed")
+	assert.Empty(t, status.Result)
+}
+
+func Test_Command_Bus_Skips_Redelivered_Finished_Command(t *testing.T) {
+	bus, store, producer, handler := setupCommandBus(t)
+
+	_, err := bus.Enqueue(context.Background(), &ImportProductsTest{Names: []string{"a"}})
+	require.NoError(t, err)
+
+	consumeAccepted(t, store, producer.messages[0])
+	consumeAccepted(t, store, producer.messages[0])
+
+	assert.Equal(t, 1, handler.calls)
+}
+
+func Test_Command_Bus_Rejects_Unregistered_Command(t *testing.T) {
+	bus, _, producer, _ := setupCommandBus(t)
+
+	_, err := bus.Enqueue(context.Background(), &UnregisteredAsyncCommandTest{})
+	require.Error(t, err)
+	assert.Empty(t, producer.messages)
+}
+
+func Test_Command_Bus_Fails_Not_Published_Command(t *testing.T) {
+	bus, store, producer, _ := setupCommandBus(t)
+	producer.err = errors.New("broker is down")
+
+	_, err := bus.Enqueue(context.Background(), &ImportProductsTest{Names: []string{"a"}})
+	require.Error(t, err)
+
+	removed, err := store.RemoveUpdatedBefore(context.Background(), time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), removed)
+}
+
+func Test_Command_Bus_Status_Of_Unknown_Command(t *testing.T) {
+	bus, _, _, _ := setupCommandBus(t)
+
+	_, err := bus.Status(context.Background(), "unknown")
+	assert.True(t, customErrors.IsNotFoundError(err))
+}
+
+func Test_Is_Respond_Async_Preferred(t *testing.T) {
+	assert.True(t, IsRespondAsyncPreferred("respond-async"))
+	assert.True(t, IsRespondAsyncPreferred("return=minimal, Respond-Async; wait=10"))
+	assert.False(t, IsRespondAsyncPreferred(""))
+	assert.False(t, IsRespondAsyncPreferred("return=representation"))
+}
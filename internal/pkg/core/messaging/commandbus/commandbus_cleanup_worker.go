@@ -0,0 +1,39 @@
+package commandbus
+
+import (
+	"context"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/web"
+
+	"go.uber.org/fx"
+)
+
+// registerCleanupWorker removes the statuses of the commands which are not updated during the retention periodically
+// during the application lifetime
+func registerCleanupWorker(
+	lc fx.Lifecycle,
+	store CommandStatusStore,
+	options *CommandBusOptions,
+	logger logger.Logger,
+) error {
+	return web.RegisterPeriodicWorker(
+		lc,
+		"command statuses cleanup worker",
+		time.Duration(options.CleanupIntervalSeconds)*time.Second,
+		logger,
+		func(ctx context.Context) error {
+			removed, err := store.RemoveUpdatedBefore(ctx, time.Now().Add(-options.Retention()))
+			if err != nil {
+				return err
+			}
+
+			if removed > 0 {
+				logger.Infof("(commandStatusCleanupWorker) %d command statuses removed", removed)
+			}
+
+			return nil
+		},
+	)
+}
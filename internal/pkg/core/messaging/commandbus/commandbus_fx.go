@@ -0,0 +1,15 @@
+package commandbus
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provides the CommandBus, its options and the cleanup worker of the command statuses, the CommandStatusStore is
+// provided by the persistence modules like `mongodb.CommandStatusModule`
+// https://uber-go.github.io/fx/modules.html
+var Module = fx.Module( //nolint:gochecknoglobals
+	"commandbusfx",
+	fx.Provide(ProvideConfig),
+	fx.Provide(NewCommandBus),
+	fx.Invoke(registerCleanupWorker),
+)
@@ -0,0 +1,29 @@
+package commandbus
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[CommandBusOptions]())
+
+// CommandBusOptions controls the retention of the statuses of the enqueued commands.
+type CommandBusOptions struct {
+	// RetentionHours is how long the status of a command is kept after its last update
+	RetentionHours int `mapstructure:"retentionHours"         default:"24"`
+	// CleanupIntervalSeconds is the delay between two runs of the command statuses cleanup worker
+	CleanupIntervalSeconds int `mapstructure:"cleanupIntervalSeconds" default:"3600"`
+}
+
+func (o *CommandBusOptions) Retention() time.Duration {
+	return time.Duration(o.RetentionHours) * time.Hour
+}
+
+func ProvideConfig(environment environment.Environment) (*CommandBusOptions, error) {
+	return config.BindConfigKey[*CommandBusOptions](optionName, environment)
+}
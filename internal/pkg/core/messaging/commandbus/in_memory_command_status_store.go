@@ -0,0 +1,84 @@
+package commandbus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+)
+
+type inMemoryCommandStatusStore struct {
+	mu       sync.RWMutex
+	commands map[string]*TrackedCommand
+}
+
+// NewInMemoryCommandStatusStore keeps the command statuses in the memory of the process, it is used by the tests and
+// the services which run a single instance.
+func NewInMemoryCommandStatusStore() CommandStatusStore {
+	return &inMemoryCommandStatusStore{commands: make(map[string]*TrackedCommand)}
+}
+
+func (s *inMemoryCommandStatusStore) Add(_ context.Context, command *TrackedCommand) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.commands[command.TrackingId]; ok {
+		return customErrors.NewConflictError(
+			fmt.Sprintf("command with tracking id `%s` already exists", command.TrackingId),
+		)
+	}
+
+	copied := *command
+	s.commands[command.TrackingId] = &copied
+
+	return nil
+}
+
+func (s *inMemoryCommandStatusStore) Get(_ context.Context, trackingId string) (*TrackedCommand, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	command, ok := s.commands[trackingId]
+	if !ok {
+		return nil, customErrors.NewNotFoundError(
+			fmt.Sprintf("command with tracking id `%s` not found", trackingId),
+		)
+	}
+
+	copied := *command
+
+	return &copied, nil
+}
+
+func (s *inMemoryCommandStatusStore) Update(_ context.Context, command *TrackedCommand) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.commands[command.TrackingId]; !ok {
+		return customErrors.NewNotFoundError(
+			fmt.Sprintf("command with tracking id `%s` not found", command.TrackingId),
+		)
+	}
+
+	copied := *command
+	s.commands[command.TrackingId] = &copied
+
+	return nil
+}
+
+func (s *inMemoryCommandStatusStore) RemoveUpdatedBefore(_ context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var removed int64
+	for trackingId, command := range s.commands {
+		if command.UpdatedAt.Before(before) {
+			delete(s.commands, trackingId)
+			removed++
+		}
+	}
+
+	return removed, nil
+}
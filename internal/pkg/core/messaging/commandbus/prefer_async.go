@@ -0,0 +1,25 @@
+package commandbus
+
+import (
+	"strings"
+)
+
+const (
+	// PreferHeader is the http header which the clients use to ask for an accepted response instead of waiting for the
+	// command, https://www.rfc-editor.org/rfc/rfc7240#section-4.1
+	PreferHeader = "Prefer"
+	RespondAsync = "respond-async"
+)
+
+// IsRespondAsyncPreferred returns true if the value of the Prefer header has the `respond-async` preference
+func IsRespondAsyncPreferred(prefer string) bool {
+	for _, preference := range strings.Split(prefer, ",") {
+		// the preferences can have parameters, e.g. `respond-async; wait=10`
+		name, _, _ := strings.Cut(preference, ";")
+		if strings.EqualFold(strings.TrimSpace(name), RespondAsync) {
+			return true
+		}
+	}
+
+	return false
+}
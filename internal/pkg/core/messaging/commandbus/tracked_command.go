@@ -0,0 +1,64 @@
+package commandbus
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+type CommandStatus string
+
+const (
+	// Accepted is the status of a command which is enqueued and is not handled yet
+	Accepted CommandStatus = "Accepted"
+	// Processing is the status of a command which its handler is running
+	Processing CommandStatus = "Processing"
+	Succeeded  CommandStatus = "Succeeded"
+	Failed     CommandStatus = "Failed"
+)
+
+// IsTerminal returns true when the handling of the command is finished
+func (s CommandStatus) IsTerminal() bool {
+	return s == Succeeded || s == Failed
+}
+
+// TrackedCommand is the status of a command which is enqueued by the CommandBus, the tracking id is returned to the
+// client with the accepted response
+type TrackedCommand struct {
+	TrackingId  string        `gorm:"primaryKey"                      bson:"trackingId"`
+	CommandType string        `bson:"commandType"`
+	Status      CommandStatus `bson:"status"`
+	// Result is the json response of the command handler when the command is succeeded
+	Result     json.RawMessage `bson:"result,omitempty"`
+	Error      string          `bson:"error,omitempty"`
+	AcceptedAt time.Time       `bson:"acceptedAt"`
+	UpdatedAt  time.Time       `gorm:"index;default:current_timestamp" bson:"updatedAt"`
+}
+
+func NewTrackedCommand(trackingId string, commandType string) *TrackedCommand {
+	now := time.Now()
+
+	return &TrackedCommand{
+		TrackingId:  trackingId,
+		CommandType: commandType,
+		Status:      Accepted,
+		AcceptedAt:  now,
+		UpdatedAt:   now,
+	}
+}
+
+func (c *TrackedCommand) TableName() string {
+	return "tracked_commands"
+}
+
+// CommandStatusStore keeps the statuses of the enqueued commands, so the clients can poll the result of a command
+type CommandStatusStore interface {
+	// Add stores the status of a new command
+	Add(ctx context.Context, command *TrackedCommand) error
+	// Get returns the status of the command, a not found error is returned for an unknown tracking id
+	Get(ctx context.Context, trackingId string) (*TrackedCommand, error)
+	// Update replaces the status of an existing command
+	Update(ctx context.Context, command *TrackedCommand) error
+	// RemoveUpdatedBefore removes the commands updated before the time and returns the number of removed commands
+	RemoveUpdatedBefore(ctx context.Context, before time.Time) (int64, error)
+}
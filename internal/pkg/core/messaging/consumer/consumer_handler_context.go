@@ -0,0 +1,22 @@
+package consumer
+
+import (
+	"context"
+
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+)
+
+type handlerContextKey struct{}
+
+// WithHandler adds the name of the handler which is running to the context, so the pipelines can distinguish the
+// handlers of a consumer, because the pipelines run once for each handler.
+func WithHandler(ctx context.Context, handler ConsumerHandler) context.Context {
+	return context.WithValue(ctx, handlerContextKey{}, typeMapper.GetFullTypeName(handler))
+}
+
+// GetHandlerName returns the name of the running handler of the context
+func GetHandlerName(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(handlerContextKey{}).(string)
+
+	return name, ok && name != ""
+}
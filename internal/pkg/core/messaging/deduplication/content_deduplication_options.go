@@ -0,0 +1,60 @@
+package deduplication
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[ContentDeduplicationOptions]())
+
+// ContentDeduplicationOptions controls the deduplication of the consumed messages by the hash of their content, it
+// skips the retries of the producers which publish the same payload with a new message id, the inbox only skips the
+// redeliveries of the same message id.
+type ContentDeduplicationOptions struct {
+	Enabled bool `mapstructure:"enabled"       default:"false"`
+	// WindowSeconds is how long a content hash is kept after its last message, a message with the same content in the
+	// window is skipped
+	WindowSeconds int `mapstructure:"windowSeconds" default:"300"`
+	// IgnoredFields are the top level fields of the messages which are not hashed, like the timestamps which change
+	// with each retry of the producer, the message id and the created time are never hashed
+	IgnoredFields []string `mapstructure:"ignoredFields"`
+	// Consumers override the options for the consumers of a message, keyed by the message name like
+	// `order_created_v_1`
+	Consumers map[string]*ConsumerContentDeduplicationOptions `mapstructure:"consumers"`
+}
+
+type ConsumerContentDeduplicationOptions struct {
+	// Enabled enables or disables the deduplication of the consumer, it is the global value when it is not set
+	Enabled       *bool    `mapstructure:"enabled"`
+	WindowSeconds int      `mapstructure:"windowSeconds"`
+	IgnoredFields []string `mapstructure:"ignoredFields"`
+}
+
+// ConsumerOptions returns the options of the consumers of the message, the unset options of the consumers are the
+// global options
+func (o *ContentDeduplicationOptions) ConsumerOptions(messageName string) (bool, time.Duration, []string) {
+	enabled, windowSeconds, ignoredFields := o.Enabled, o.WindowSeconds, o.IgnoredFields
+
+	if consumerOptions, ok := o.Consumers[messageName]; ok && consumerOptions != nil {
+		if consumerOptions.Enabled != nil {
+			enabled = *consumerOptions.Enabled
+		}
+		if consumerOptions.WindowSeconds > 0 {
+			windowSeconds = consumerOptions.WindowSeconds
+		}
+		if len(consumerOptions.IgnoredFields) > 0 {
+			ignoredFields = consumerOptions.IgnoredFields
+		}
+	}
+
+	return enabled, time.Duration(windowSeconds) * time.Second, ignoredFields
+}
+
+func ProvideConfig(environment environment.Environment) (*ContentDeduplicationOptions, error) {
+	return config.BindConfigKey[*ContentDeduplicationOptions](optionName, environment)
+}
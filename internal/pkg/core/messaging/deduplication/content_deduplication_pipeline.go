@@ -0,0 +1,131 @@
+package deduplication
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/pipeline"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+
+	"emperror.dev/errors"
+)
+
+// unhashedFields are the fields of `types.Message` which change with each publish of the same payload
+var unhashedFields = []string{"messageId", "created"} //nolint:gochecknoglobals
+
+type contentDeduplicationPipeline struct {
+	store   DeduplicationStore
+	options *ContentDeduplicationOptions
+	logger  logger.Logger
+}
+
+// NewContentDeduplicationPipeline creates a consumer pipeline which skips the messages with the content of a message
+// which is handled by the handler in the window. the content is claimed before the handler runs, so the concurrent
+// duplicates are skipped too, and it is released when the handler fails, so the failed message is handled again on
+// its redelivery.
+func NewContentDeduplicationPipeline(
+	store DeduplicationStore,
+	options *ContentDeduplicationOptions,
+	logger logger.Logger,
+) pipeline.ConsumerPipeline {
+	return &contentDeduplicationPipeline{store: store, options: options, logger: logger}
+}
+
+func (c *contentDeduplicationPipeline) Handle(
+	ctx context.Context,
+	consumerContext types.MessageConsumeContext,
+	next pipeline.ConsumerHandlerFunc,
+) error {
+	if consumerContext.Message() == nil {
+		return next(ctx)
+	}
+
+	messageName := utils.GetMessageName(consumerContext.Message())
+	enabled, window, ignoredFields := c.options.ConsumerOptions(messageName)
+	if !enabled || window <= 0 {
+		return next(ctx)
+	}
+
+	contentHash, err := ContentHash(consumerContext.Message(), ignoredFields...)
+	if err != nil {
+		return errors.WrapIf(err, "error in hashing the content of the message")
+	}
+
+	// the pipelines run once for each handler of the consumer, so each handler has its own content keys
+	consumerName, ok := consumer.GetHandlerName(ctx)
+	if !ok {
+		consumerName = consumerContext.MessageType()
+	}
+	key := fmt.Sprintf("%s:%s:%s", consumerName, messageName, contentHash)
+
+	claimed, err := c.store.Claim(ctx, key, window)
+	if err != nil {
+		return errors.WrapIf(err, "error in claiming the content of the message")
+	}
+
+	if !claimed {
+		c.logger.Infow(
+			fmt.Sprintf(
+				"[contentDeduplicationPipeline.Handle] content of message with id: {%s} is already handled by {%s}, skipping",
+				consumerContext.MessageId(),
+				consumerName,
+			),
+			logger.Fields{"MessageId": consumerContext.MessageId(), "Consumer": consumerName, "ContentHash": contentHash},
+		)
+
+		return nil
+	}
+
+	if err := next(ctx); err != nil {
+		// the handler is failed, the error of the release is only logged, the duplicates of the message are skipped
+		// until the end of the window in that case
+		if releaseErr := c.store.Release(context.WithoutCancel(ctx), key); releaseErr != nil {
+			c.logger.Errorw(
+				fmt.Sprintf(
+					"[contentDeduplicationPipeline.Handle] error in releasing content of message with id: {%s}, err: %v",
+					consumerContext.MessageId(),
+					releaseErr,
+				),
+				logger.Fields{"MessageId": consumerContext.MessageId(), "Consumer": consumerName},
+			)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// ContentHash returns the sha256 of the json of the message without its message id, its created time and the ignored
+// fields, the keys of the json are sorted, so the hash doesn't depend on the order of the fields
+func ContentHash(message types.IMessage, ignoredFields ...string) (string, error) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return "", err
+	}
+
+	content := map[string]interface{}{}
+	if err := json.Unmarshal(data, &content); err != nil {
+		return "", err
+	}
+
+	for _, field := range append(unhashedFields, ignoredFields...) {
+		delete(content, field)
+	}
+
+	// the maps are marshaled with their sorted keys
+	canonical, err := json.Marshal(content)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(canonical)
+
+	return hex.EncodeToString(hash[:]), nil
+}
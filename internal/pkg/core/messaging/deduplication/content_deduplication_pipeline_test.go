@@ -0,0 +1,206 @@
+package deduplication
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type orderPlaced struct {
+	*types.Message
+	OrderId  string    `json:"orderId"`
+	Total    float64   `json:"total"`
+	SentAt   time.Time `json:"sentAt"`
+	Customer string    `json:"customer"`
+}
+
+type (
+	deduplicationTestHandler      struct{}
+	otherDeduplicationTestHandler struct{}
+)
+
+func (h *deduplicationTestHandler) Handle(context.Context, types.MessageConsumeContext) error {
+	return nil
+}
+
+func (h *otherDeduplicationTestHandler) Handle(context.Context, types.MessageConsumeContext) error {
+	return nil
+}
+
+// newOrderPlacedContext publishes the same order with a new message id, like a retry of a producer
+func newOrderPlacedContext(orderId string, total float64) types.MessageConsumeContext {
+	messageId := uuid.NewV4().String()
+	message := &orderPlaced{
+		Message:  types.NewMessage(messageId),
+		OrderId:  orderId,
+		Total:    total,
+		SentAt:   time.Now(),
+		Customer: "customer-1",
+	}
+
+	return types.NewMessageConsumeContext(
+		message,
+		metadata.Metadata{},
+		"application/json",
+		"orderPlaced",
+		time.Now(),
+		1,
+		messageId,
+		"",
+	)
+}
+
+func newTestPipeline(store DeduplicationStore, options *ContentDeduplicationOptions) *contentDeduplicationPipeline {
+	return NewContentDeduplicationPipeline(
+		store,
+		options,
+		defaultLogger.GetLogger(),
+	).(*contentDeduplicationPipeline)
+}
+
+func countingNext(calls *int) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		*calls++
+
+		return nil
+	}
+}
+
+func Test_Content_Deduplication_Skips_The_Same_Content_With_A_New_Message_Id(t *testing.T) {
+	pipe := newTestPipeline(
+		NewInMemoryDeduplicationStore(),
+		&ContentDeduplicationOptions{Enabled: true, WindowSeconds: 60, IgnoredFields: []string{"sentAt"}},
+	)
+
+	calls := 0
+	require.NoError(t, pipe.Handle(context.Background(), newOrderPlacedContext("order-1", 10), countingNext(&calls)))
+	require.NoError(t, pipe.Handle(context.Background(), newOrderPlacedContext("order-1", 10), countingNext(&calls)))
+	require.NoError(t, pipe.Handle(context.Background(), newOrderPlacedContext("order-1", 12), countingNext(&calls)))
+
+	assert.Equal(t, 2, calls)
+}
+
+func Test_Content_Deduplication_Hashes_The_Not_Ignored_Fields(t *testing.T) {
+	pipe := newTestPipeline(
+		NewInMemoryDeduplicationStore(),
+		&ContentDeduplicationOptions{Enabled: true, WindowSeconds: 60},
+	)
+
+	calls := 0
+	require.NoError(t, pipe.Handle(context.Background(), newOrderPlacedContext("order-1", 10), countingNext(&calls)))
+	time.Sleep(time.Millisecond)
+	require.NoError(t, pipe.Handle(context.Background(), newOrderPlacedContext("order-1", 10), countingNext(&calls)))
+
+	assert.Equal(t, 2, calls)
+}
+
+func Test_Content_Deduplication_Handles_A_Failed_Message_Again(t *testing.T) {
+	pipe := newTestPipeline(
+		NewInMemoryDeduplicationStore(),
+		&ContentDeduplicationOptions{Enabled: true, WindowSeconds: 60, IgnoredFields: []string{"sentAt"}},
+	)
+	handlerErr := errors.New("handler failed")
+
+	err := pipe.Handle(context.Background(), newOrderPlacedContext("order-1", 10), func(ctx context.Context) error {
+		return handlerErr
+	})
+	require.ErrorIs(t, err, handlerErr)
+
+	calls := 0
+	require.NoError(t, pipe.Handle(context.Background(), newOrderPlacedContext("order-1", 10), countingNext(&calls)))
+
+	assert.Equal(t, 1, calls)
+}
+
+func Test_Content_Deduplication_Keeps_The_Contents_Per_Handler(t *testing.T) {
+	pipe := newTestPipeline(
+		NewInMemoryDeduplicationStore(),
+		&ContentDeduplicationOptions{Enabled: true, WindowSeconds: 60, IgnoredFields: []string{"sentAt"}},
+	)
+
+	calls := 0
+	handlerCtx := consumer.WithHandler(context.Background(), &deduplicationTestHandler{})
+	otherHandlerCtx := consumer.WithHandler(context.Background(), &otherDeduplicationTestHandler{})
+	require.NoError(t, pipe.Handle(handlerCtx, newOrderPlacedContext("order-1", 10), countingNext(&calls)))
+	require.NoError(t, pipe.Handle(otherHandlerCtx, newOrderPlacedContext("order-1", 10), countingNext(&calls)))
+
+	assert.Equal(t, 2, calls)
+}
+
+func Test_Content_Deduplication_Uses_The_Options_Of_The_Consumer(t *testing.T) {
+	disabled := false
+	pipe := newTestPipeline(
+		NewInMemoryDeduplicationStore(),
+		&ContentDeduplicationOptions{
+			Enabled:       true,
+			WindowSeconds: 60,
+			IgnoredFields: []string{"sentAt"},
+			Consumers: map[string]*ConsumerContentDeduplicationOptions{
+				"order_placed": {Enabled: &disabled},
+			},
+		},
+	)
+
+	calls := 0
+	require.NoError(t, pipe.Handle(context.Background(), newOrderPlacedContext("order-1", 10), countingNext(&calls)))
+	require.NoError(t, pipe.Handle(context.Background(), newOrderPlacedContext("order-1", 10), countingNext(&calls)))
+
+	assert.Equal(t, 2, calls)
+}
+
+func Test_Consumer_Options_Override_The_Global_Options(t *testing.T) {
+	enabled := true
+	options := &ContentDeduplicationOptions{
+		WindowSeconds: 60,
+		IgnoredFields: []string{"sentAt"},
+		Consumers: map[string]*ConsumerContentDeduplicationOptions{
+			"order_placed": {Enabled: &enabled, WindowSeconds: 10},
+		},
+	}
+
+	isEnabled, window, ignoredFields := options.ConsumerOptions("order_placed")
+	assert.True(t, isEnabled)
+	assert.Equal(t, 10*time.Second, window)
+	assert.Equal(t, []string{"sentAt"}, ignoredFields)
+
+	isEnabled, window, _ = options.ConsumerOptions("order_shipped")
+	assert.False(t, isEnabled)
+	assert.Equal(t, time.Minute, window)
+}
+
+func Test_In_Memory_Store_Slides_The_Window_With_The_Duplicates(t *testing.T) {
+	store := NewInMemoryDeduplicationStore().(*inMemoryDeduplicationStore)
+	now := time.Now()
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	claimed, err := store.Claim(ctx, "key", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	now = now.Add(50 * time.Second)
+	claimed, err = store.Claim(ctx, "key", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	// the duplicate started the window again
+	now = now.Add(50 * time.Second)
+	claimed, err = store.Claim(ctx, "key", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	now = now.Add(2 * time.Minute)
+	claimed, err = store.Claim(ctx, "key", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+}
@@ -0,0 +1,13 @@
+package deduplication
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provides the content deduplication options, the DeduplicationStore is provided by the persistence modules
+// like `redis.DeduplicationModule`
+// https://uber-go.github.io/fx/modules.html
+var Module = fx.Module( //nolint:gochecknoglobals
+	"deduplicationfx",
+	fx.Provide(ProvideConfig),
+)
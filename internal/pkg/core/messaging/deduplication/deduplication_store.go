@@ -0,0 +1,15 @@
+package deduplication
+
+import (
+	"context"
+	"time"
+)
+
+// DeduplicationStore keeps the content hashes of the consumed messages for their window
+type DeduplicationStore interface {
+	// Claim records the key for the window and returns false when the key is already recorded, a recorded key gets the
+	// window again, so the window slides while the duplicates arrive
+	Claim(ctx context.Context, key string, window time.Duration) (bool, error)
+	// Release removes the key, so the message of the key is handled again on its redelivery
+	Release(ctx context.Context, key string) error
+}
@@ -0,0 +1,46 @@
+package deduplication
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type inMemoryDeduplicationStore struct {
+	mu       sync.Mutex
+	expiries map[string]time.Time
+	now      func() time.Time
+}
+
+// NewInMemoryDeduplicationStore keeps the content hashes in the memory of the process, it is used by the tests and the
+// services which run a single instance.
+func NewInMemoryDeduplicationStore() DeduplicationStore {
+	return &inMemoryDeduplicationStore{expiries: make(map[string]time.Time), now: time.Now}
+}
+
+func (s *inMemoryDeduplicationStore) Claim(_ context.Context, key string, window time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	expiry, ok := s.expiries[key]
+	s.expiries[key] = now.Add(window)
+
+	// the expired keys are removed on each claim, so the map only keeps the keys of the windows
+	for recorded, recordedExpiry := range s.expiries {
+		if !recordedExpiry.After(now) {
+			delete(s.expiries, recorded)
+		}
+	}
+
+	return !ok || !expiry.After(now), nil
+}
+
+func (s *inMemoryDeduplicationStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.expiries, key)
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package encryption
+
+import (
+	"mime"
+	"reflect"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"emperror.dev/errors"
+)
+
+const jsonContentType = "application/json"
+
+type encryptingMessageSerializer struct {
+	serializer.MessageSerializer
+	encryptor FieldEncryptor
+}
+
+// NewEncryptingMessageSerializer returns a message serializer which encrypts the configured fields of the serialized
+// messages and decrypts them before their deserialization. the fields are encrypted in the json payloads, so the
+// messages with fields to encrypt can't be serialized with the other content types.
+func NewEncryptingMessageSerializer(
+	messageSerializer serializer.MessageSerializer,
+	encryptor FieldEncryptor,
+) serializer.MessageSerializer {
+	if encryptor == nil {
+		return messageSerializer
+	}
+
+	return &encryptingMessageSerializer{MessageSerializer: messageSerializer, encryptor: encryptor}
+}
+
+func (e *encryptingMessageSerializer) Serialize(message types.IMessage) (*serializer.EventSerializationResult, error) {
+	return e.SerializeObject(message)
+}
+
+func (e *encryptingMessageSerializer) SerializeObject(
+	message interface{},
+) (*serializer.EventSerializationResult, error) {
+	result, err := e.MessageSerializer.SerializeObject(message)
+	if err != nil || message == nil {
+		return result, err
+	}
+
+	return e.encrypt(utils.GetMessageName(message), result)
+}
+
+func (e *encryptingMessageSerializer) SerializeEnvelop(
+	messageEnvelop types.MessageEnvelope,
+) (*serializer.EventSerializationResult, error) {
+	result, err := e.MessageSerializer.SerializeEnvelop(messageEnvelop)
+	if err != nil || messageEnvelop.Message == nil {
+		return result, err
+	}
+
+	return e.encrypt(utils.GetMessageName(messageEnvelop.Message), result)
+}
+
+func (e *encryptingMessageSerializer) Deserialize(
+	data []byte,
+	messageType string,
+	contentType string,
+) (types.IMessage, error) {
+	data, err := e.decrypt(messageNameByTypeName(messageType), data, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.MessageSerializer.Deserialize(data, messageType, contentType)
+}
+
+func (e *encryptingMessageSerializer) DeserializeObject(
+	data []byte,
+	messageType string,
+	contentType string,
+) (interface{}, error) {
+	data, err := e.decrypt(messageNameByTypeName(messageType), data, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.MessageSerializer.DeserializeObject(data, messageType, contentType)
+}
+
+func (e *encryptingMessageSerializer) DeserializeType(
+	data []byte,
+	messageType reflect.Type,
+	contentType string,
+) (types.IMessage, error) {
+	data, err := e.decrypt(utils.GetMessageNameFromType(messageType), data, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.MessageSerializer.DeserializeType(data, messageType, contentType)
+}
+
+func (e *encryptingMessageSerializer) DeserializeInto(data []byte, message interface{}, contentType string) error {
+	data, err := e.decrypt(utils.GetMessageName(message), data, contentType)
+	if err != nil {
+		return err
+	}
+
+	return e.MessageSerializer.DeserializeInto(data, message, contentType)
+}
+
+func (e *encryptingMessageSerializer) encrypt(
+	messageName string,
+	result *serializer.EventSerializationResult,
+) (*serializer.EventSerializationResult, error) {
+	if !e.encryptor.Encrypts(messageName) {
+		return result, nil
+	}
+
+	// the message is not published with its fields in plaintext
+	if !isJson(result.ContentType) {
+		return nil, errors.Errorf(
+			"the fields of `%s` can't be encrypted in the `%s` content type, they are only encrypted in json",
+			messageName,
+			result.ContentType,
+		)
+	}
+
+	data, err := e.encryptor.Encrypt(messageName, result.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &serializer.EventSerializationResult{Data: data, ContentType: result.ContentType}, nil
+}
+
+// decrypt decrypts the json payloads, the messages without a content type have the content type of the serializer
+func (e *encryptingMessageSerializer) decrypt(messageName string, data []byte, contentType string) ([]byte, error) {
+	if contentType == "" {
+		contentType = e.ContentType()
+	}
+
+	if !isJson(contentType) {
+		return data, nil
+	}
+
+	return e.encryptor.Decrypt(messageName, data)
+}
+
+// messageNameByTypeName returns the message name of the registered type, the unknown types have no fields to decrypt
+func messageNameByTypeName(messageType string) string {
+	typ := typeMapper.TypeByName(messageType)
+	if typ == nil {
+		return ""
+	}
+
+	return utils.GetMessageNameFromType(typ)
+}
+
+func isJson(contentType string) bool {
+	media, _, err := mime.ParseMediaType(contentType)
+
+	return err == nil && media == jsonContentType
+}
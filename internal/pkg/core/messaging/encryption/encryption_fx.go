@@ -0,0 +1,15 @@
+package encryption
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provided to fxlog
+// https://uber-go.github.io/fx/modules.html
+var Module = fx.Module( //nolint:gochecknoglobals
+	"encryptionfx",
+	fx.Provide(
+		ProvideConfig,
+		NewFieldEncryptor,
+	),
+)
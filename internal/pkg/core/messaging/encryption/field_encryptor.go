@@ -0,0 +1,232 @@
+package encryption
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"emperror.dev/errors"
+)
+
+// encryptedPrefix marks the encrypted values of the fields, it is followed by the key id and the base64 nonce and
+// ciphertext of the json value of the field. the message name and the field path are the additional data of the
+// ciphertext, so an encrypted value can't be moved to another field or message
+const encryptedPrefix = "enc:v1:"
+
+// FieldEncryptor encrypts the configured fields of the serialized json messages with AES-GCM, so the personal data
+// of the messages like the emails and the addresses is not kept in plaintext by the broker.
+type FieldEncryptor interface {
+	// Encrypt encrypts the configured fields of the message in the json payload, the payloads of the messages without
+	// configured fields are returned as is
+	Encrypt(messageName string, payload []byte) ([]byte, error)
+	// Encrypts returns true when the message has fields to encrypt
+	Encrypts(messageName string) bool
+	// Decrypt decrypts the configured fields of the message in the json payload, the payloads of the messages without
+	// configured fields are returned as is
+	Decrypt(messageName string, payload []byte) ([]byte, error)
+}
+
+type fieldEncryptor struct {
+	options *MessageEncryptionOptions
+	ciphers map[string]cipher.AEAD
+}
+
+func NewFieldEncryptor(options *MessageEncryptionOptions) (FieldEncryptor, error) {
+	encryptor := &fieldEncryptor{options: options, ciphers: make(map[string]cipher.AEAD, len(options.Keys))}
+
+	for keyId, encodedKey := range options.Keys {
+		if keyId == "" || strings.Contains(keyId, ":") {
+			return nil, errors.Errorf("message encryption key id `%s` is invalid, it can't be empty or have `:`", keyId)
+		}
+
+		key, err := base64.StdEncoding.DecodeString(encodedKey)
+		if err != nil {
+			return nil, errors.WrapIff(err, "message encryption key `%s` is not a base64 key", keyId)
+		}
+
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, errors.WrapIff(err, "message encryption key `%s` is not an AES key", keyId)
+		}
+
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, errors.WrapIff(err, "error in creating the cipher of the message encryption key `%s`", keyId)
+		}
+
+		encryptor.ciphers[keyId] = aead
+	}
+
+	if options.Enabled {
+		if _, ok := encryptor.ciphers[options.ActiveKeyId]; !ok {
+			return nil, errors.Errorf("message encryption active key `%s` is not in the keys", options.ActiveKeyId)
+		}
+	}
+
+	return encryptor, nil
+}
+
+func (f *fieldEncryptor) Encrypts(messageName string) bool {
+	return f.options.Enabled && len(f.options.Fields[messageName]) > 0
+}
+
+func (f *fieldEncryptor) Encrypt(messageName string, payload []byte) ([]byte, error) {
+	if !f.Encrypts(messageName) || len(payload) == 0 {
+		return payload, nil
+	}
+
+	document, err := decode(payload)
+	if err != nil {
+		return nil, errors.WrapIff(err, "error in decoding the payload of `%s` for encryption", messageName)
+	}
+
+	for _, field := range f.options.Fields[messageName] {
+		err = transformPath(document, strings.Split(field, "."), func(value interface{}) (interface{}, error) {
+			return f.encryptValue(fieldAdditionalData(messageName, field), value)
+		})
+		if err != nil {
+			return nil, errors.WrapIff(err, "error in encrypting the field `%s` of `%s`", field, messageName)
+		}
+	}
+
+	return json.Marshal(document)
+}
+
+// Decrypt doesn't check the enabled option, so the messages which are encrypted before disabling the encryption are
+// still decrypted
+func (f *fieldEncryptor) Decrypt(messageName string, payload []byte) ([]byte, error) {
+	fields := f.options.Fields[messageName]
+	if len(fields) == 0 || !bytes.Contains(payload, []byte(encryptedPrefix)) {
+		return payload, nil
+	}
+
+	document, err := decode(payload)
+	if err != nil {
+		return nil, errors.WrapIff(err, "error in decoding the payload of `%s` for decryption", messageName)
+	}
+
+	for _, field := range fields {
+		err = transformPath(document, strings.Split(field, "."), func(value interface{}) (interface{}, error) {
+			return f.decryptValue(fieldAdditionalData(messageName, field), value)
+		})
+		if err != nil {
+			return nil, errors.WrapIff(err, "error in decrypting the field `%s` of `%s`", field, messageName)
+		}
+	}
+
+	return json.Marshal(document)
+}
+
+// encryptValue encrypts the json of the value, so the fields of any type are encrypted to a string. the strings with
+// the encrypted prefix are encrypted too, so a plaintext which looks like a ciphertext is not published as is
+func (f *fieldEncryptor) encryptValue(additionalData []byte, value interface{}) (interface{}, error) {
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	aead := f.ciphers[f.options.ActiveKeyId]
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	sealed := aead.Seal(nonce, nonce, plaintext, additionalData)
+
+	return fmt.Sprintf(
+		"%s%s:%s",
+		encryptedPrefix,
+		f.options.ActiveKeyId,
+		base64.StdEncoding.EncodeToString(sealed),
+	), nil
+}
+
+// decryptValue decrypts the encrypted string of the field, the values which are not encrypted like the fields of the
+// messages which are published before their encryption are returned as is
+func (f *fieldEncryptor) decryptValue(additionalData []byte, value interface{}) (interface{}, error) {
+	encrypted, ok := value.(string)
+	if !ok || !strings.HasPrefix(encrypted, encryptedPrefix) {
+		return value, nil
+	}
+
+	keyId, encoded, ok := strings.Cut(strings.TrimPrefix(encrypted, encryptedPrefix), ":")
+	if !ok {
+		return nil, errors.New("encrypted field has no key id")
+	}
+
+	aead, ok := f.ciphers[keyId]
+	if !ok {
+		return nil, errors.Errorf("there is no message encryption key `%s` to decrypt the field", keyId)
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return nil, errors.New("encrypted field is not a valid ciphertext")
+	}
+
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], additionalData)
+	if err != nil {
+		return nil, errors.WrapIff(err, "error in decrypting the field with the key `%s`", keyId)
+	}
+
+	return decode(plaintext)
+}
+
+// transformPath replaces the value of the field path in the json document, the items of the arrays on the path are
+// replaced one by one and the missing and the null fields are skipped
+func transformPath(
+	node interface{},
+	path []string,
+	transform func(value interface{}) (interface{}, error),
+) error {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		field, ok := value[path[0]]
+		if !ok || field == nil {
+			return nil
+		}
+
+		if len(path) > 1 {
+			return transformPath(field, path[1:], transform)
+		}
+
+		transformed, err := transform(field)
+		if err != nil {
+			return err
+		}
+		value[path[0]] = transformed
+	case []interface{}:
+		for _, item := range value {
+			if err := transformPath(item, path, transform); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// fieldAdditionalData binds the ciphertext of a field to its message and field path
+func fieldAdditionalData(messageName string, field string) []byte {
+	return []byte(messageName + ":" + field)
+}
+
+// decode decodes the json with its numbers as `json.Number`, so the large integers are not changed by re-encoding
+func decode(data []byte) (interface{}, error) {
+	var document interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+
+	return document, nil
+}
@@ -0,0 +1,192 @@
+package encryption
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer/json"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type CustomerRegisteredV1 struct {
+	*types.Message
+	AccountEmail string           `json:"accountEmail"`
+	Name         string           `json:"name"`
+	Age          int              `json:"age"`
+	Addresses    []*AddressDto    `json:"addresses"`
+	Tags         []string         `json:"tags"`
+	Extra        map[string]int64 `json:"extra"`
+}
+
+type AddressDto struct {
+	Street string `json:"street"`
+	City   string `json:"city"`
+}
+
+func newTestOptions() *MessageEncryptionOptions {
+	return &MessageEncryptionOptions{
+		Enabled:     true,
+		ActiveKeyId: "k2",
+		Keys: map[string]string{
+			"k1": base64.StdEncoding.EncodeToString([]byte(strings.Repeat("a", 32))),
+			"k2": base64.StdEncoding.EncodeToString([]byte(strings.Repeat("b", 32))),
+		},
+		Fields: map[string][]string{
+			"customer_registered_v_1": {"accountEmail", "age", "addresses.street", "missing.field"},
+		},
+	}
+}
+
+func newTestMessage() *CustomerRegisteredV1 {
+	return &CustomerRegisteredV1{
+		Message:      types.NewMessage(uuid.NewV4().String()),
+		AccountEmail: "customer@example.com",
+		Name:         "customer",
+		Age:          30,
+		Addresses:    []*AddressDto{{Street: "first street", City: "city"}, {Street: "second street", City: "city"}},
+		Tags:         []string{"vip"},
+		Extra:        map[string]int64{"id": 9007199254740993},
+	}
+}
+
+func Test_Encrypted_Fields_Are_Not_In_Plaintext_And_Are_Decrypted(t *testing.T) {
+	encryptor, err := NewFieldEncryptor(newTestOptions())
+	require.NoError(t, err)
+
+	messageSerializer := NewEncryptingMessageSerializer(
+		json.NewDefaultMessageJsonSerializer(json.NewDefaultJsonSerializer()),
+		encryptor,
+	)
+
+	message := newTestMessage()
+	result, err := messageSerializer.Serialize(message)
+	require.NoError(t, err)
+
+	payload := string(result.Data)
+	assert.NotContains(t, payload, "customer@example.com")
+	assert.NotContains(t, payload, "first street")
+	assert.NotContains(t, payload, "second street")
+	assert.Contains(t, payload, `"name":"customer"`)
+	assert.Contains(t, payload, encryptedPrefix+"k2:")
+
+	deserialized := &CustomerRegisteredV1{}
+	err = messageSerializer.DeserializeInto(result.Data, deserialized, result.ContentType)
+	require.NoError(t, err)
+
+	assert.Equal(t, message.AccountEmail, deserialized.AccountEmail)
+	assert.Equal(t, message.Age, deserialized.Age)
+	assert.Equal(t, message.Addresses, deserialized.Addresses)
+	assert.Equal(t, message.Tags, deserialized.Tags)
+	assert.Equal(t, message.Extra, deserialized.Extra)
+}
+
+func Test_Fields_Encrypted_With_A_Rotated_Key_Are_Decrypted(t *testing.T) {
+	options := newTestOptions()
+	options.ActiveKeyId = "k1"
+
+	oldEncryptor, err := NewFieldEncryptor(options)
+	require.NoError(t, err)
+
+	encrypted, err := oldEncryptor.Encrypt("customer_registered_v_1", []byte(`{"accountEmail":"customer@example.com"}`))
+	require.NoError(t, err)
+
+	newEncryptor, err := NewFieldEncryptor(newTestOptions())
+	require.NoError(t, err)
+
+	decrypted, err := newEncryptor.Decrypt("customer_registered_v_1", encrypted)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"accountEmail":"customer@example.com"}`, string(decrypted))
+}
+
+func Test_Decrypt_Without_The_Key_Fails(t *testing.T) {
+	encryptor, err := NewFieldEncryptor(newTestOptions())
+	require.NoError(t, err)
+
+	encrypted, err := encryptor.Encrypt("customer_registered_v_1", []byte(`{"accountEmail":"customer@example.com"}`))
+	require.NoError(t, err)
+
+	otherEncryptor, err := NewFieldEncryptor(&MessageEncryptionOptions{Fields: newTestOptions().Fields})
+	require.NoError(t, err)
+
+	_, err = otherEncryptor.Decrypt("customer_registered_v_1", encrypted)
+	assert.Error(t, err)
+}
+
+func Test_Only_The_Configured_Fields_Are_Decrypted(t *testing.T) {
+	encryptor, err := NewFieldEncryptor(newTestOptions())
+	require.NoError(t, err)
+
+	encrypted, err := encryptor.Encrypt("customer_registered_v_1", []byte(`{"accountEmail":"customer@example.com"}`))
+	require.NoError(t, err)
+
+	document, err := decode(encrypted)
+	require.NoError(t, err)
+	ciphertext := document.(map[string]interface{})["accountEmail"].(string)
+
+	// the name is not a configured field, so its value is not decrypted
+	payload := []byte(`{"name":"` + ciphertext + `"}`)
+	decrypted, err := encryptor.Decrypt("customer_registered_v_1", payload)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(payload), string(decrypted))
+
+	// the other messages have no configured fields
+	payload = []byte(`{"accountEmail":"` + ciphertext + `"}`)
+	decrypted, err = encryptor.Decrypt("order_created_v_1", payload)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(payload), string(decrypted))
+}
+
+func Test_Encrypted_Value_Moved_To_Another_Field_Is_Not_Decrypted(t *testing.T) {
+	encryptor, err := NewFieldEncryptor(newTestOptions())
+	require.NoError(t, err)
+
+	encrypted, err := encryptor.Encrypt("customer_registered_v_1", []byte(`{"accountEmail":"customer@example.com"}`))
+	require.NoError(t, err)
+
+	document, err := decode(encrypted)
+	require.NoError(t, err)
+	ciphertext := document.(map[string]interface{})["accountEmail"].(string)
+
+	payload := []byte(`{"addresses":[{"street":"` + ciphertext + `"}]}`)
+	_, err = encryptor.Decrypt("customer_registered_v_1", payload)
+	assert.Error(t, err)
+}
+
+func Test_Plaintext_With_The_Encrypted_Prefix_Is_Encrypted(t *testing.T) {
+	encryptor, err := NewFieldEncryptor(newTestOptions())
+	require.NoError(t, err)
+
+	payload := []byte(`{"accountEmail":"` + encryptedPrefix + `k2:customer@example.com"}`)
+
+	encrypted, err := encryptor.Encrypt("customer_registered_v_1", payload)
+	require.NoError(t, err)
+	assert.NotContains(t, string(encrypted), "customer@example.com")
+
+	decrypted, err := encryptor.Decrypt("customer_registered_v_1", encrypted)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(payload), string(decrypted))
+}
+
+func Test_Messages_Without_Fields_Are_Not_Changed(t *testing.T) {
+	encryptor, err := NewFieldEncryptor(newTestOptions())
+	require.NoError(t, err)
+
+	payload := []byte(`{"accountEmail":"customer@example.com"}`)
+
+	encrypted, err := encryptor.Encrypt("order_created_v_1", payload)
+	require.NoError(t, err)
+	assert.Equal(t, payload, encrypted)
+}
+
+func Test_Enabled_Encryption_Without_The_Active_Key_Fails(t *testing.T) {
+	options := newTestOptions()
+	options.ActiveKeyId = "k3"
+
+	_, err := NewFieldEncryptor(options)
+	assert.Error(t, err)
+}
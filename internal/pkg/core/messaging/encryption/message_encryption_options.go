@@ -0,0 +1,30 @@
+package encryption
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[MessageEncryptionOptions]())
+
+type MessageEncryptionOptions struct {
+	// Enabled enables encrypting the fields on publish, the encrypted fields are decrypted on consume regardless
+	Enabled bool `mapstructure:"enabled"`
+	// ActiveKeyId is the id of the key of `Keys` which encrypts the fields, the other keys only decrypt the fields of
+	// the messages which are encrypted before a key rotation
+	ActiveKeyId string `mapstructure:"activeKeyId"`
+	// Keys are the base64 AES keys of 16, 24 or 32 bytes by their ids, they should be injected from a secret store like
+	// vault, e.g. with the `MessageEncryptionKeys` environment variable in the `id1:key1,id2:key2` format
+	Keys map[string]string `mapstructure:"keys" env:"MessageEncryptionKeys" secret:"true"`
+	// Fields are the json fields of the messages which are encrypted by the names of the messages, the names are the
+	// exchange names of the messages, e.g. `order_created_v_1: [accountEmail, deliveryAddress]`. the nested fields are
+	// separated by dots and the fields of the items of an array are encrypted in each item.
+	Fields map[string][]string `mapstructure:"fields"`
+}
+
+func ProvideConfig(environment environment.Environment) (*MessageEncryptionOptions, error) {
+	return config.BindConfigKey[*MessageEncryptionOptions](optionName, environment)
+}
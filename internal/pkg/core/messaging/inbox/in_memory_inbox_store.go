@@ -0,0 +1,58 @@
+package inbox
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type inMemoryInboxStore struct {
+	mu       sync.RWMutex
+	messages map[string]*InboxMessage
+}
+
+// NewInMemoryInboxStore keeps the inbox in the memory of the process, it is used by the tests and the services which
+// don't need a durable inbox.
+func NewInMemoryInboxStore() InboxStore {
+	return &inMemoryInboxStore{messages: make(map[string]*InboxMessage)}
+}
+
+func (s *inMemoryInboxStore) Exists(_ context.Context, messageId string, consumer string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.messages[inboxKey(messageId, consumer)]
+
+	return ok, nil
+}
+
+func (s *inMemoryInboxStore) Add(_ context.Context, message *InboxMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := inboxKey(message.MessageId, message.Consumer)
+	if _, ok := s.messages[key]; !ok {
+		s.messages[key] = message
+	}
+
+	return nil
+}
+
+func (s *inMemoryInboxStore) RemoveProcessedBefore(_ context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var removed int64
+	for key, message := range s.messages {
+		if message.ProcessedAt.Before(before) {
+			delete(s.messages, key)
+			removed++
+		}
+	}
+
+	return removed, nil
+}
+
+func inboxKey(messageId string, consumer string) string {
+	return consumer + ":" + messageId
+}
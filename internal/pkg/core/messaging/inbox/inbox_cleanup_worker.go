@@ -0,0 +1,43 @@
+package inbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/web"
+
+	"go.uber.org/fx"
+)
+
+// registerCleanupWorker removes the messages older than the retention from the inbox periodically during the
+// application lifetime
+func registerCleanupWorker(
+	lc fx.Lifecycle,
+	store InboxStore,
+	options *InboxOptions,
+	logger logger.Logger,
+) error {
+	if !options.Enabled {
+		return nil
+	}
+
+	return web.RegisterPeriodicWorker(
+		lc,
+		"inbox cleanup worker",
+		time.Duration(options.CleanupIntervalSeconds)*time.Second,
+		logger,
+		func(ctx context.Context) error {
+			removed, err := store.RemoveProcessedBefore(ctx, time.Now().Add(-options.Retention()))
+			if err != nil {
+				return err
+			}
+
+			if removed > 0 {
+				logger.Infof("(inboxCleanupWorker) %d inbox messages removed", removed)
+			}
+
+			return nil
+		},
+	)
+}
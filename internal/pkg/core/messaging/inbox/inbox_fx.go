@@ -0,0 +1,14 @@
+package inbox
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provides the inbox options and the cleanup worker, the InboxStore is provided by the persistence modules like
+// `postgresmessaging.Module` or `mongodb.InboxModule`
+// https://uber-go.github.io/fx/modules.html
+var Module = fx.Module( //nolint:gochecknoglobals
+	"inboxfx",
+	fx.Provide(ProvideConfig),
+	fx.Invoke(registerCleanupWorker),
+)
@@ -0,0 +1,30 @@
+package inbox
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[InboxOptions]())
+
+// InboxOptions controls the deduplication of the consumed messages and the retention of the inbox.
+type InboxOptions struct {
+	Enabled bool `mapstructure:"enabled"                default:"true"`
+	// RetentionHours is how long a handled message is kept, a message redelivered after that is handled again
+	RetentionHours int `mapstructure:"retentionHours"         default:"168"`
+	// CleanupIntervalSeconds is the delay between two runs of the inbox cleanup worker
+	CleanupIntervalSeconds int `mapstructure:"cleanupIntervalSeconds" default:"3600"`
+}
+
+func (o *InboxOptions) Retention() time.Duration {
+	return time.Duration(o.RetentionHours) * time.Hour
+}
+
+func ProvideConfig(environment environment.Environment) (*InboxOptions, error) {
+	return config.BindConfigKey[*InboxOptions](optionName, environment)
+}
@@ -0,0 +1,84 @@
+package inbox
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/pipeline"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+
+	"emperror.dev/errors"
+)
+
+type inboxPipeline struct {
+	store   InboxStore
+	options *InboxOptions
+	logger  logger.Logger
+}
+
+// NewInboxPipeline creates a consumer pipeline which skips the messages already handled by the handler, the message is
+// added to the inbox after the handler succeeds, so a failed message is handled again on its redelivery.
+func NewInboxPipeline(
+	store InboxStore,
+	options *InboxOptions,
+	logger logger.Logger,
+) pipeline.ConsumerPipeline {
+	return &inboxPipeline{store: store, options: options, logger: logger}
+}
+
+func (i *inboxPipeline) Handle(
+	ctx context.Context,
+	consumerContext types.MessageConsumeContext,
+	next pipeline.ConsumerHandlerFunc,
+) error {
+	messageId := consumerContext.MessageId()
+	if !i.options.Enabled || messageId == "" {
+		return next(ctx)
+	}
+
+	// the pipelines run once for each handler of the consumer, so each handler has its own inbox entry
+	consumerName, ok := consumer.GetHandlerName(ctx)
+	if !ok {
+		consumerName = consumerContext.MessageType()
+	}
+
+	exists, err := i.store.Exists(ctx, messageId, consumerName)
+	if err != nil {
+		return errors.WrapIf(err, "error in checking the inbox for the message")
+	}
+
+	if exists {
+		i.logger.Infow(
+			fmt.Sprintf(
+				"[inboxPipeline.Handle] message with id: {%s} is already handled by {%s}, skipping the duplicate",
+				messageId,
+				consumerName,
+			),
+			logger.Fields{"MessageId": messageId, "Consumer": consumerName},
+		)
+
+		return nil
+	}
+
+	if err := next(ctx); err != nil {
+		return err
+	}
+
+	// the handler is succeeded, so the message should be acknowledged even if the inbox is not updated, the next
+	// redelivery of the message is handled again in that case
+	err = i.store.Add(ctx, NewInboxMessage(messageId, consumerName, consumerContext.MessageType()))
+	if err != nil {
+		i.logger.Errorw(
+			fmt.Sprintf(
+				"[inboxPipeline.Handle] error in adding message with id: {%s} to the inbox, err: %v",
+				messageId,
+				err,
+			),
+			logger.Fields{"MessageId": messageId, "Consumer": consumerName},
+		)
+	}
+
+	return nil
+}
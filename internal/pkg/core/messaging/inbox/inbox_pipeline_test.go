@@ -0,0 +1,158 @@
+package inbox
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type inboxTestMessage struct {
+	*types.Message
+}
+
+type (
+	inboxTestHandler      struct{}
+	otherInboxTestHandler struct{}
+)
+
+func (h *inboxTestHandler) Handle(context.Context, types.MessageConsumeContext) error {
+	return nil
+}
+
+func (h *otherInboxTestHandler) Handle(context.Context, types.MessageConsumeContext) error {
+	return nil
+}
+
+func newInboxTestContext(messageId string) types.MessageConsumeContext {
+	message := &inboxTestMessage{Message: types.NewMessage(messageId)}
+
+	return types.NewMessageConsumeContext(
+		message,
+		metadata.Metadata{},
+		"application/json",
+		"inboxTestMessage",
+		time.Now(),
+		1,
+		messageId,
+		"",
+	)
+}
+
+func newTestInboxPipeline(store InboxStore, enabled bool) *inboxPipeline {
+	return NewInboxPipeline(
+		store,
+		&InboxOptions{Enabled: enabled, RetentionHours: 1},
+		defaultLogger.GetLogger(),
+	).(*inboxPipeline)
+}
+
+func Test_Inbox_Pipeline_Skips_Handled_Message(t *testing.T) {
+	pipe := newTestInboxPipeline(NewInMemoryInboxStore(), true)
+	consumeContext := newInboxTestContext(uuid.NewV4().String())
+
+	calls := 0
+	next := func(ctx context.Context) error {
+		calls++
+		return nil
+	}
+
+	require.NoError(t, pipe.Handle(context.Background(), consumeContext, next))
+	require.NoError(t, pipe.Handle(context.Background(), consumeContext, next))
+
+	assert.Equal(t, 1, calls)
+}
+
+func Test_Inbox_Pipeline_Handles_Failed_Message_Again(t *testing.T) {
+	pipe := newTestInboxPipeline(NewInMemoryInboxStore(), true)
+	consumeContext := newInboxTestContext(uuid.NewV4().String())
+
+	calls := 0
+	err := pipe.Handle(context.Background(), consumeContext, func(ctx context.Context) error {
+		calls++
+		return errors.New("handler failed")
+	})
+	require.Error(t, err)
+
+	err = pipe.Handle(context.Background(), consumeContext, func(ctx context.Context) error {
+		calls++
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, calls)
+}
+
+func Test_Inbox_Pipeline_Keeps_Handlers_Separate(t *testing.T) {
+	pipe := newTestInboxPipeline(NewInMemoryInboxStore(), true)
+	consumeContext := newInboxTestContext(uuid.NewV4().String())
+
+	calls := 0
+	next := func(ctx context.Context) error {
+		calls++
+		return nil
+	}
+
+	firstCtx := consumer.WithHandler(context.Background(), &inboxTestHandler{})
+	secondCtx := consumer.WithHandler(context.Background(), &otherInboxTestHandler{})
+
+	require.NoError(t, pipe.Handle(firstCtx, consumeContext, next))
+	require.NoError(t, pipe.Handle(secondCtx, consumeContext, next))
+	require.NoError(t, pipe.Handle(firstCtx, consumeContext, next))
+
+	assert.Equal(t, 2, calls)
+}
+
+func Test_Inbox_Pipeline_Disabled(t *testing.T) {
+	store := NewInMemoryInboxStore()
+	pipe := newTestInboxPipeline(store, false)
+	consumeContext := newInboxTestContext(uuid.NewV4().String())
+
+	calls := 0
+	next := func(ctx context.Context) error {
+		calls++
+		return nil
+	}
+
+	require.NoError(t, pipe.Handle(context.Background(), consumeContext, next))
+	require.NoError(t, pipe.Handle(context.Background(), consumeContext, next))
+
+	assert.Equal(t, 2, calls)
+
+	exists, err := store.Exists(context.Background(), consumeContext.MessageId(), consumeContext.MessageType())
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func Test_In_Memory_Inbox_Store_Removes_Expired_Messages(t *testing.T) {
+	store := NewInMemoryInboxStore()
+	ctx := context.Background()
+
+	expired := NewInboxMessage(uuid.NewV4().String(), "consumer", "inboxTestMessage")
+	expired.ProcessedAt = time.Now().Add(-2 * time.Hour)
+	recent := NewInboxMessage(uuid.NewV4().String(), "consumer", "inboxTestMessage")
+
+	require.NoError(t, store.Add(ctx, expired))
+	require.NoError(t, store.Add(ctx, recent))
+
+	removed, err := store.RemoveProcessedBefore(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), removed)
+
+	exists, err := store.Exists(ctx, expired.MessageId, expired.Consumer)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	exists, err = store.Exists(ctx, recent.MessageId, recent.Consumer)
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
@@ -0,0 +1,38 @@
+package inbox
+
+import (
+	"context"
+	"time"
+)
+
+// InboxMessage is a consumed message which is handled by a consumer handler, the message id and the consumer are the
+// key of the inbox
+type InboxMessage struct {
+	MessageId   string    `gorm:"primaryKey"                      bson:"messageId"`
+	Consumer    string    `gorm:"primaryKey"                      bson:"consumer"`
+	MessageType string    `bson:"messageType"`
+	ProcessedAt time.Time `gorm:"index;default:current_timestamp" bson:"processedAt"`
+}
+
+func NewInboxMessage(messageId string, consumer string, messageType string) *InboxMessage {
+	return &InboxMessage{
+		MessageId:   messageId,
+		Consumer:    consumer,
+		MessageType: messageType,
+		ProcessedAt: time.Now(),
+	}
+}
+
+func (m *InboxMessage) TableName() string {
+	return "inbox_messages"
+}
+
+// InboxStore keeps the handled messages of the consumers, so a redelivered message is not handled twice
+type InboxStore interface {
+	// Exists returns true if the message is already handled by the consumer
+	Exists(ctx context.Context, messageId string, consumer string) (bool, error)
+	// Add stores a handled message, adding a message which already exists is not an error
+	Add(ctx context.Context, message *InboxMessage) error
+	// RemoveProcessedBefore removes the messages handled before the time and returns the number of removed messages
+	RemoveProcessedBefore(ctx context.Context, before time.Time) (int64, error)
+}
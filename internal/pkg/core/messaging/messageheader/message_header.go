@@ -7,4 +7,14 @@ const (
 	Type          string = "type"
 	ContentType   string = "content-type"
 	Created       string = "created"
+	TenantId      string = "tenant-id"
+	// ReplyTo is the address of the responses of a request, the responder publishes its response to it with the
+	// correlation id of the request
+	ReplyTo string = "reply-to"
+	// InReplyTo is the message id of the request of a response
+	InReplyTo string = "in-reply-to"
+	// Priority and Expiration are the delivery options of a published message, the brokers which support them remove
+	// them from the headers of the message
+	Priority   string = "publish-priority"
+	Expiration string = "publish-expiration"
 )
@@ -53,3 +53,27 @@ func GetMessageCreated(m metadata.Metadata) time.Time {
 func SetMessageCreated(m metadata.Metadata, val time.Time) {
 	m.Set(Created, val)
 }
+
+func GetTenantId(m metadata.Metadata) string {
+	return m.GetString(TenantId)
+}
+
+func SetTenantId(m metadata.Metadata, val string) {
+	m.Set(TenantId, val)
+}
+
+func GetReplyTo(m metadata.Metadata) string {
+	return m.GetString(ReplyTo)
+}
+
+func SetReplyTo(m metadata.Metadata, val string) {
+	m.Set(ReplyTo, val)
+}
+
+func GetInReplyTo(m metadata.Metadata) string {
+	return m.GetString(InReplyTo)
+}
+
+func SetInReplyTo(m metadata.Metadata, val string) {
+	m.Set(InReplyTo, val)
+}
@@ -11,6 +11,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	time "time"
+
 	types "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
 )
 
@@ -235,6 +237,55 @@ func (_c *Bus_PublishMessage_Call) RunAndReturn(run func(context.Context, types.
 	return _c
 }
 
+// PublishMessageWithDelay provides a mock function with given fields: ctx, message, meta, delay
+func (_m *Bus) PublishMessageWithDelay(ctx context.Context, message types.IMessage, meta metadata.Metadata, delay time.Duration) error {
+	ret := _m.Called(ctx, message, meta, delay)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishMessageWithDelay")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.IMessage, metadata.Metadata, time.Duration) error); ok {
+		r0 = rf(ctx, message, meta, delay)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Bus_PublishMessageWithDelay_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishMessageWithDelay'
+type Bus_PublishMessageWithDelay_Call struct {
+	*mock.Call
+}
+
+// PublishMessageWithDelay is a helper method to define mock.On call
+//   - ctx context.Context
+//   - message types.IMessage
+//   - meta metadata.Metadata
+//   - delay time.Duration
+func (_e *Bus_Expecter) PublishMessageWithDelay(ctx interface{}, message interface{}, meta interface{}, delay interface{}) *Bus_PublishMessageWithDelay_Call {
+	return &Bus_PublishMessageWithDelay_Call{Call: _e.mock.On("PublishMessageWithDelay", ctx, message, meta, delay)}
+}
+
+func (_c *Bus_PublishMessageWithDelay_Call) Run(run func(ctx context.Context, message types.IMessage, meta metadata.Metadata, delay time.Duration)) *Bus_PublishMessageWithDelay_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.IMessage), args[2].(metadata.Metadata), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *Bus_PublishMessageWithDelay_Call) Return(_a0 error) *Bus_PublishMessageWithDelay_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Bus_PublishMessageWithDelay_Call) RunAndReturn(run func(context.Context, types.IMessage, metadata.Metadata, time.Duration) error) *Bus_PublishMessageWithDelay_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // PublishMessageWithTopicName provides a mock function with given fields: ctx, message, meta, topicOrExchangeName
 func (_m *Bus) PublishMessageWithTopicName(ctx context.Context, message types.IMessage, meta metadata.Metadata, topicOrExchangeName string) error {
 	ret := _m.Called(ctx, message, meta, topicOrExchangeName)
@@ -284,6 +335,53 @@ func (_c *Bus_PublishMessageWithTopicName_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// PublishMessages provides a mock function with given fields: ctx, messages
+func (_m *Bus) PublishMessages(ctx context.Context, messages []types.IMessage) error {
+	ret := _m.Called(ctx, messages)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishMessages")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []types.IMessage) error); ok {
+		r0 = rf(ctx, messages)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Bus_PublishMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishMessages'
+type Bus_PublishMessages_Call struct {
+	*mock.Call
+}
+
+// PublishMessages is a helper method to define mock.On call
+//   - ctx context.Context
+//   - messages []types.IMessage
+func (_e *Bus_Expecter) PublishMessages(ctx interface{}, messages interface{}) *Bus_PublishMessages_Call {
+	return &Bus_PublishMessages_Call{Call: _e.mock.On("PublishMessages", ctx, messages)}
+}
+
+func (_c *Bus_PublishMessages_Call) Run(run func(ctx context.Context, messages []types.IMessage)) *Bus_PublishMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]types.IMessage))
+	})
+	return _c
+}
+
+func (_c *Bus_PublishMessages_Call) Return(_a0 error) *Bus_PublishMessages_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Bus_PublishMessages_Call) RunAndReturn(run func(context.Context, []types.IMessage) error) *Bus_PublishMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Start provides a mock function with given fields: ctx
 func (_m *Bus) Start(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -318,9 +318,9 @@ func (_c *MessagePersistenceService_GetAllActive_Call) RunAndReturn(run func(con
 	return _c
 }
 
-// GetByFilter provides a mock function with given fields: ctx, predicate
-func (_m *MessagePersistenceService) GetByFilter(ctx context.Context, predicate func(*persistmessage.StoreMessage) bool) ([]*persistmessage.StoreMessage, error) {
-	ret := _m.Called(ctx, predicate)
+// GetByFilter provides a mock function with given fields: ctx, filters
+func (_m *MessagePersistenceService) GetByFilter(ctx context.Context, filters map[string]interface{}) ([]*persistmessage.StoreMessage, error) {
+	ret := _m.Called(ctx, filters)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetByFilter")
@@ -328,19 +328,19 @@ func (_m *MessagePersistenceService) GetByFilter(ctx context.Context, predicate
 
 	var r0 []*persistmessage.StoreMessage
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, func(*persistmessage.StoreMessage) bool) ([]*persistmessage.StoreMessage, error)); ok {
-		return rf(ctx, predicate)
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]interface{}) ([]*persistmessage.StoreMessage, error)); ok {
+		return rf(ctx, filters)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, func(*persistmessage.StoreMessage) bool) []*persistmessage.StoreMessage); ok {
-		r0 = rf(ctx, predicate)
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]interface{}) []*persistmessage.StoreMessage); ok {
+		r0 = rf(ctx, filters)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*persistmessage.StoreMessage)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, func(*persistmessage.StoreMessage) bool) error); ok {
-		r1 = rf(ctx, predicate)
+	if rf, ok := ret.Get(1).(func(context.Context, map[string]interface{}) error); ok {
+		r1 = rf(ctx, filters)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -355,14 +355,14 @@ type MessagePersistenceService_GetByFilter_Call struct {
 
 // GetByFilter is a helper method to define mock.On call
 //   - ctx context.Context
-//   - predicate func(*persistmessage.StoreMessage) bool
-func (_e *MessagePersistenceService_Expecter) GetByFilter(ctx interface{}, predicate interface{}) *MessagePersistenceService_GetByFilter_Call {
-	return &MessagePersistenceService_GetByFilter_Call{Call: _e.mock.On("GetByFilter", ctx, predicate)}
+//   - filters map[string]interface{}
+func (_e *MessagePersistenceService_Expecter) GetByFilter(ctx interface{}, filters interface{}) *MessagePersistenceService_GetByFilter_Call {
+	return &MessagePersistenceService_GetByFilter_Call{Call: _e.mock.On("GetByFilter", ctx, filters)}
 }
 
-func (_c *MessagePersistenceService_GetByFilter_Call) Run(run func(ctx context.Context, predicate func(*persistmessage.StoreMessage) bool)) *MessagePersistenceService_GetByFilter_Call {
+func (_c *MessagePersistenceService_GetByFilter_Call) Run(run func(ctx context.Context, filters map[string]interface{})) *MessagePersistenceService_GetByFilter_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(func(*persistmessage.StoreMessage) bool))
+		run(args[0].(context.Context), args[1].(map[string]interface{}))
 	})
 	return _c
 }
@@ -372,7 +372,7 @@ func (_c *MessagePersistenceService_GetByFilter_Call) Return(_a0 []*persistmessa
 	return _c
 }
 
-func (_c *MessagePersistenceService_GetByFilter_Call) RunAndReturn(run func(context.Context, func(*persistmessage.StoreMessage) bool) ([]*persistmessage.StoreMessage, error)) *MessagePersistenceService_GetByFilter_Call {
+func (_c *MessagePersistenceService_GetByFilter_Call) RunAndReturn(run func(context.Context, map[string]interface{}) ([]*persistmessage.StoreMessage, error)) *MessagePersistenceService_GetByFilter_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -8,6 +8,7 @@ import (
 	messageHeader "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/messageheader"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/otel/tracing"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/tenant"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/constants"
 	tracingHeaders "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/tracing_headers"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/utils"
@@ -41,17 +42,30 @@ func StartConsumerSpan(
 
 	opts := getTraceOptions(meta, payload, consumerTracingOptions)
 
+	// the process span is a child of the publish span, so the flow of the message is in a single trace, and it is also
+	// linked to the publish span as the messaging conventions define, so the backends without the parent relation
+	// between the services (e.g. with a sampled out consumer trace) still relate them
+	// https://opentelemetry.io/docs/specs/semconv/messaging/messaging-spans/#trace-structure
+	if publishSpanContext := trace.SpanContextFromContext(parentSpanContext); publishSpanContext.IsValid() {
+		opts = append(opts, trace.WithLinks(trace.Link{
+			SpanContext: publishSpanContext,
+			Attributes:  []attribute.KeyValue{semconv.MessagingOperationPublish},
+		}))
+	}
+
 	// https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/trace/semantic_conventions/messaging.md#span-name
 	// SpanName = Destination ShortTypeName + Operation ShortTypeName
 	ctx, span := tracing.MessagingTracer.Start(
 		parentSpanContext,
-		fmt.Sprintf("%s %s", consumerTracingOptions.Destination, "receive"),
+		fmt.Sprintf("%s %s", consumerTracingOptions.Destination, "process"),
 		opts...)
 
 	span.AddEvent(fmt.Sprintf("start consuming message '%s' from the broker", messageHeader.GetMessageName(*meta)))
 
-	// Emulate Work loads
-	time.Sleep(1 * time.Second)
+	// the tenant of the producer is resolved for the handlers of the message
+	if tenantId := messageHeader.GetTenantId(*meta); tenantId != "" {
+		ctx = tenant.SetTenantId(ctx, tenantId)
+	}
 
 	// we don't want next trace (AfterConsume) becomes child of this span, so we should not use new ctx for (AfterConsume) span. if already exists a span on ctx next span will be a child of that span
 	return ctx, span
@@ -85,9 +99,10 @@ func getTraceOptions(
 	// https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/trace/semantic_conventions/messaging.md#topic-with-multiple-consumers
 	// https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/trace/semantic_conventions/messaging.md#batch-receiving
 	attrs := []attribute.KeyValue{
-		semconv.MessageIDKey.String(messageHeader.GetMessageId(*meta)),
+		semconv.MessagingMessageID(messageHeader.GetMessageId(*meta)),
 		semconv.MessagingMessageConversationID(correlationId),
-		semconv.MessagingOperationReceive,
+		semconv.MessagingMessagePayloadSizeBytes(len(payload)),
+		semconv.MessagingOperationProcess,
 		attribute.Key(constants.TraceId).String(tracingHeaders.GetTracingTraceId(*meta)),
 		attribute.Key(constants.Traceparent).String(tracingHeaders.GetTracingTraceparent(*meta)),
 		attribute.Key(constants.ParentSpanId).String(tracingHeaders.GetTracingParentSpanId(*meta)),
@@ -116,7 +131,10 @@ func addAfterBaggage(ctx context.Context, meta *metadata.Metadata) context.Conte
 
 	correlationIdBag, _ := baggage.NewMember(string(semconv.MessagingMessageConversationIDKey), correlationId)
 	messageIdBag, _ := baggage.NewMember(string(semconv.MessageIDKey), messageHeader.GetMessageId(*meta))
-	b, _ := baggage.New(correlationIdBag, messageIdBag)
+	// the existing members like the debug entry are kept, so they are propagated with the message
+	b := baggage.FromContext(ctx)
+	b, _ = b.SetMember(correlationIdBag)
+	b, _ = b.SetMember(messageIdBag)
 	ctx = baggage.ContextWithBaggage(ctx, b)
 
 	// new context including baggage
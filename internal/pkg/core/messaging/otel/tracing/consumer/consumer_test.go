@@ -0,0 +1,70 @@
+package consumer
+
+import (
+	"context"
+	"testing"
+
+	messageHeader "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/messageheader"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/otel/tracing/producer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func Test_Consumer_Span_Is_Linked_To_Publish_Span(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(tracesdk.NewTracerProvider(tracesdk.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	message := types.NewMessage("message-1")
+	meta := metadata.Metadata{}
+	messageHeader.SetMessageId(meta, message.GeMessageId())
+	messageHeader.SetMessageName(meta, "message")
+
+	_, publishSpan := producer.StartProducerSpan(
+		context.Background(),
+		message,
+		&meta,
+		"{}",
+		&producer.ProducerTracingOptions{MessagingSystem: "rabbitmq", Destination: "orders"},
+	)
+	_ = producer.FinishProducerSpan(publishSpan, nil)
+
+	// the headers of the message are all that the consumer gets from the producer
+	received := metadata.MapToMetadata(metadata.MetadataToMap(meta))
+
+	_, processSpan := StartConsumerSpan(
+		context.Background(),
+		&received,
+		"{}",
+		&ConsumerTracingOptions{MessagingSystem: "rabbitmq", Destination: "orders_queue"},
+	)
+	_ = FinishConsumerSpan(processSpan, nil)
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 2)
+
+	publish, process := ended[0], ended[1]
+	assert.Equal(t, "orders publish", publish.Name())
+	assert.Equal(t, trace.SpanKindProducer, publish.SpanKind())
+	assert.Contains(t, publish.Attributes(), semconv.MessagingOperationPublish)
+
+	assert.Equal(t, "orders_queue process", process.Name())
+	assert.Equal(t, trace.SpanKindConsumer, process.SpanKind())
+	assert.Contains(t, process.Attributes(), semconv.MessagingOperationProcess)
+	assert.Contains(t, process.Attributes(), semconv.MessagingMessageID("message-1"))
+
+	// a single trace for the flow, with a link to the publish span
+	assert.Equal(t, publish.SpanContext().TraceID(), process.SpanContext().TraceID())
+	assert.Equal(t, publish.SpanContext().SpanID(), process.Parent().SpanID())
+	require.Len(t, process.Links(), 1)
+	assert.Equal(t, publish.SpanContext().SpanID(), process.Links()[0].SpanContext.SpanID())
+}
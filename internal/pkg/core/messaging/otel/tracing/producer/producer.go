@@ -9,6 +9,7 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/otel/tracing"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/tenant"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/constants"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/utils"
 
@@ -35,6 +36,11 @@ func StartProducerSpan(
 ) (context.Context, trace.Span) {
 	ctx = addAfterBaggage(ctx, message, meta)
 
+	// the resolved tenant goes with the message, so the consumers can attach it to their spans, metrics and logs
+	if tenantId, ok := tenant.GetTenantId(ctx); ok {
+		messageHeader.SetTenantId(*meta, tenantId)
+	}
+
 	// If there's a span context in the message, use that as the parent context.
 	// extracts the tracing from the header and puts it into the context
 	carrier := tracing.NewMessageCarrier(meta)
@@ -46,7 +52,7 @@ func StartProducerSpan(
 	// SpanName = Destination ShortTypeName + Operation ShortTypeName
 	ctx, span := tracing.MessagingTracer.Start(
 		parentSpanContext,
-		fmt.Sprintf("%s %s", producerTracingOptions.Destination, "send"),
+		fmt.Sprintf("%s %s", producerTracingOptions.Destination, "publish"),
 		opts...)
 
 	span.AddEvent(
@@ -108,8 +114,9 @@ func getTraceOptions(
 	// https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/trace/semantic_conventions/messaging.md#topic-with-multiple-consumers
 	// https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/trace/semantic_conventions/messaging.md#batch-receiving
 	attrs := []attribute.KeyValue{
-		semconv.MessageIDKey.String(message.GeMessageId()),
+		semconv.MessagingMessageID(message.GeMessageId()),
 		semconv.MessagingMessageConversationID(correlationId),
+		semconv.MessagingMessagePayloadSizeBytes(len(payload)),
 		attribute.Key(tracing.MessageType).
 			String(message.GetMessageTypeName()),
 		attribute.Key(tracing.MessageName).
@@ -121,7 +128,11 @@ func getTraceOptions(
 		semconv.MessagingSystemKey.String(
 			producerTracingOptions.MessagingSystem,
 		),
-		semconv.MessagingOperationKey.String("send"),
+		semconv.MessagingOperationPublish,
+	}
+
+	if tenantId := messageHeader.GetTenantId(*meta); tenantId != "" {
+		attrs = append(attrs, tenant.TenantIdKey.String(tenantId))
 	}
 
 	if producerTracingOptions.OtherAttributes != nil &&
@@ -151,7 +162,10 @@ func addAfterBaggage(
 		string(semconv.MessageIDKey),
 		message.GeMessageId(),
 	)
-	b, _ := baggage.New(correlationIdBag, messageIdBag)
+	// the existing members like the debug entry are kept, so they are propagated with the message
+	b := baggage.FromContext(ctx)
+	b, _ = b.SetMember(correlationIdBag)
+	b, _ = b.SetMember(messageIdBag)
 	ctx = baggage.ContextWithBaggage(ctx, b)
 
 	// new context including baggage
@@ -19,7 +19,7 @@ type MessagePersistenceService interface {
 	GetAllActive(ctx context.Context) ([]*StoreMessage, error)
 	GetByFilter(
 		ctx context.Context,
-		predicate func(*StoreMessage) bool,
+		filters map[string]interface{},
 	) ([]*StoreMessage, error)
 	GetById(ctx context.Context, id uuid.UUID) (*StoreMessage, error)
 	Remove(ctx context.Context, storeMessage *StoreMessage) (bool, error)
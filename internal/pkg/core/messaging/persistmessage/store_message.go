@@ -21,11 +21,14 @@ const (
 	Processed MessageStatus = 2
 )
 
+// StoreMessage is a message in the outbox or the inbox, the Metadata keeps the serialized headers of the message envelope.
+// the change feed of the outbox reads the messages in the order of their creation time and id.
 type StoreMessage struct {
-	ID            uuid.UUID `gorm:"primaryKey"`
+	ID            uuid.UUID `gorm:"primaryKey;index:idx_store_messages_feed,priority:2"`
 	DataType      string
 	Data          string
-	CreatedAt     time.Time `gorm:"default:current_timestamp"`
+	Metadata      string
+	CreatedAt     time.Time `gorm:"default:current_timestamp;index:idx_store_messages_feed,priority:1"`
 	RetryCount    int
 	MessageStatus MessageStatus
 	DeliveryType  MessageDeliveryType
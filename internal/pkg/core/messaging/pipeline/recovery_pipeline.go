@@ -0,0 +1,56 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+
+	"emperror.dev/errors"
+)
+
+type recoveryPipeline struct {
+	logger logger.Logger
+}
+
+// NewRecoveryPipeline returns the panic of a handler as its error, so the message is retried or dead-lettered like a
+// failed message instead of stopping the consumer, it should be the first pipeline to recover the panics of the other
+// pipelines as well
+func NewRecoveryPipeline(l logger.Logger) ConsumerPipeline {
+	return &recoveryPipeline{logger: l}
+}
+
+func (r *recoveryPipeline) Handle(
+	ctx context.Context,
+	consumerContext types.MessageConsumeContext,
+	next ConsumerHandlerFunc,
+) (err error) {
+	defer func() {
+		recovered := recover()
+		if recovered == nil {
+			return
+		}
+
+		r.logger.Errorw(
+			fmt.Sprintf(
+				"panic in handling message '%s' with id '%s': %v",
+				consumerContext.MessageType(),
+				consumerContext.MessageId(),
+				recovered,
+			),
+			logger.Fields{"Stack": string(debug.Stack())},
+		)
+
+		if recoveredErr, ok := recovered.(error); ok {
+			err = errors.WrapIff(recoveredErr, "panic in handling message '%s'", consumerContext.MessageType())
+
+			return
+		}
+
+		err = errors.Errorf("panic in handling message '%s': %v", consumerContext.MessageType(), recovered)
+	}()
+
+	return next(ctx)
+}
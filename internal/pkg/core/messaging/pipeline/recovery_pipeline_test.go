@@ -0,0 +1,60 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newConsumeContext() types.MessageConsumeContext {
+	return types.NewMessageConsumeContext(
+		types.NewMessage("message-id"),
+		nil,
+		"application/json",
+		"test_message",
+		time.Now(),
+		1,
+		"message-id",
+		"correlation-id",
+	)
+}
+
+func Test_Recovery_Pipeline_Returns_The_Panic_Of_The_Handler_As_Error(t *testing.T) {
+	recovery := NewRecoveryPipeline(defaultLogger.GetLogger())
+
+	err := recovery.Handle(context.Background(), newConsumeContext(), func(ctx context.Context) error {
+		panic("handler panicked")
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "handler panicked")
+}
+
+func Test_Recovery_Pipeline_Keeps_The_Panic_Error(t *testing.T) {
+	recovery := NewRecoveryPipeline(defaultLogger.GetLogger())
+	panicErr := errors.New("nil map")
+
+	err := recovery.Handle(context.Background(), newConsumeContext(), func(ctx context.Context) error {
+		panic(panicErr)
+	})
+
+	assert.ErrorIs(t, err, panicErr)
+}
+
+func Test_Recovery_Pipeline_Returns_The_Error_Of_The_Handler(t *testing.T) {
+	recovery := NewRecoveryPipeline(defaultLogger.GetLogger())
+	handlerErr := errors.New("handler failed")
+
+	err := recovery.Handle(context.Background(), newConsumeContext(), func(ctx context.Context) error {
+		return handlerErr
+	})
+
+	assert.Equal(t, handlerErr, err)
+}
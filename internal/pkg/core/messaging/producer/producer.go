@@ -2,11 +2,17 @@ package producer
 
 import (
 	"context"
+	"time"
 
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+
+	"emperror.dev/errors"
 )
 
+// ErrDelayedDeliveryNotSupported is returned by the producers of the brokers which can't deliver a message after a delay
+var ErrDelayedDeliveryNotSupported = errors.New("delayed delivery is not supported by the message broker")
+
 type Producer interface {
 	PublishMessage(ctx context.Context, message types.IMessage, meta metadata.Metadata) error
 	PublishMessageWithTopicName(
@@ -15,5 +21,18 @@ type Producer interface {
 		meta metadata.Metadata,
 		topicOrExchangeName string,
 	) error
+	// PublishMessageWithDelay publishes the message which is delivered to its consumers after the delay, e.g. for the
+	// reminders and the timeouts without a separate scheduler. a message without a positive delay is published
+	// immediately.
+	PublishMessageWithDelay(
+		ctx context.Context,
+		message types.IMessage,
+		meta metadata.Metadata,
+		delay time.Duration,
+	) error
+	// PublishMessages publishes a batch of messages, e.g. for the rebuild of a read model, the producers which support
+	// it publish the messages without a round-trip per message. it returns an error when a message of the batch is not
+	// published, the messages before it may be published.
+	PublishMessages(ctx context.Context, messages []types.IMessage) error
 	IsProduced(func(message types.IMessage))
 }
@@ -0,0 +1,80 @@
+package producer
+
+import (
+	"maps"
+	"strconv"
+	"time"
+
+	messageHeader "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/messageheader"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+)
+
+// PublishOption sets a delivery option of a published message. The options are kept in the metadata of the message,
+// so they are passed to `PublishMessage` and survive the outbox with the rest of the metadata.
+type PublishOption func(meta metadata.Metadata)
+
+// WithPriority delivers the message before the messages with a lower priority of the same queue, the queue should
+// support the priorities, e.g. the rabbitmq consumers with `WithMaxPriority`
+func WithPriority(priority uint8) PublishOption {
+	return func(meta metadata.Metadata) {
+		// the values are kept as strings, the numbers of the json metadata of the outbox are not kept as integers
+		meta.Set(messageHeader.Priority, strconv.Itoa(int(priority)))
+	}
+}
+
+// WithExpiration discards the message if it is not consumed before the expiration
+func WithExpiration(expiration time.Duration) PublishOption {
+	return func(meta metadata.Metadata) {
+		meta.Set(messageHeader.Expiration, strconv.FormatInt(expiration.Milliseconds(), 10))
+	}
+}
+
+// WithHeader adds a header to the published message
+func WithHeader(key string, value interface{}) PublishOption {
+	return func(meta metadata.Metadata) {
+		meta.Set(key, value)
+	}
+}
+
+// WithHeaders adds the headers to the published message
+func WithHeaders(headers map[string]interface{}) PublishOption {
+	return func(meta metadata.Metadata) {
+		for key, value := range headers {
+			meta.Set(key, value)
+		}
+	}
+}
+
+// PublishMetadata returns a copy of the metadata with the publish options, the metadata of the caller doesn't change
+//
+//	err := producer.PublishMessage(ctx, event, producer.PublishMetadata(meta, producer.WithPriority(9)))
+func PublishMetadata(meta metadata.Metadata, opts ...PublishOption) metadata.Metadata {
+	published := metadata.Metadata{}
+	maps.Copy(published, meta)
+
+	for _, opt := range opts {
+		opt(published)
+	}
+
+	return published
+}
+
+// GetPriority returns the priority of the published message, ok is false when the message has no priority
+func GetPriority(meta metadata.Metadata) (priority uint8, ok bool) {
+	value, err := strconv.ParseUint(meta.GetString(messageHeader.Priority), 10, 8)
+	if err != nil {
+		return 0, false
+	}
+
+	return uint8(value), true
+}
+
+// GetExpiration returns the expiration of the published message, ok is false when the message has no expiration
+func GetExpiration(meta metadata.Metadata) (expiration time.Duration, ok bool) {
+	value, err := strconv.ParseInt(meta.GetString(messageHeader.Expiration), 10, 64)
+	if err != nil || value < 0 {
+		return 0, false
+	}
+
+	return time.Duration(value) * time.Millisecond, true
+}
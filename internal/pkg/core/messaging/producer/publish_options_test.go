@@ -0,0 +1,48 @@
+package producer
+
+import (
+	"testing"
+	"time"
+
+	messageHeader "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/messageheader"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Publish_Metadata_With_Options(t *testing.T) {
+	meta := metadata.Metadata{messageHeader.CorrelationId: "correlation"}
+
+	published := PublishMetadata(
+		meta,
+		WithPriority(9),
+		WithExpiration(30*time.Second),
+		WithHeader("tenant-tier", "gold"),
+		WithHeaders(map[string]interface{}{"source": "payments"}),
+	)
+
+	priority, ok := GetPriority(published)
+	assert.True(t, ok)
+	assert.Equal(t, uint8(9), priority)
+
+	expiration, ok := GetExpiration(published)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, expiration)
+
+	assert.Equal(t, "correlation", published.GetString(messageHeader.CorrelationId))
+	assert.Equal(t, "gold", published.GetString("tenant-tier"))
+	assert.Equal(t, "payments", published.GetString("source"))
+
+	// the metadata of the caller doesn't change
+	assert.Len(t, meta, 1)
+}
+
+func Test_Publish_Metadata_Without_Options(t *testing.T) {
+	published := PublishMetadata(nil)
+
+	_, ok := GetPriority(published)
+	assert.False(t, ok)
+
+	_, ok = GetExpiration(published)
+	assert.False(t, ok)
+}
@@ -0,0 +1,73 @@
+package quarantine
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	// ErrorHistoryHeader keeps the errors of the failed attempts of a retried message as a json array
+	ErrorHistoryHeader = "x-error-history"
+	// maxErrorHistory is the max number of the errors in the header, the oldest errors are dropped first
+	maxErrorHistory = 10
+	// maxStackTraceLength limits the size of a stack trace, the headers of a message should stay small
+	maxStackTraceLength = 4096
+)
+
+// QuarantineError is the error of a failed attempt of a message
+type QuarantineError struct {
+	Attempt    int       `bson:"attempt"    json:"attempt"`
+	Error      string    `bson:"error"      json:"error"`
+	StackTrace string    `bson:"stackTrace" json:"stackTrace,omitempty"`
+	OccurredAt time.Time `bson:"occurredAt" json:"occurredAt"`
+}
+
+// NewQuarantineError creates the error of an attempt, the stack trace is the `%+v` format of the error which includes
+// the stack of the `emperror.dev/errors` errors
+func NewQuarantineError(attempt int, err error) *QuarantineError {
+	quarantineError := &QuarantineError{Attempt: attempt, OccurredAt: time.Now()}
+	if err == nil {
+		return quarantineError
+	}
+
+	quarantineError.Error = err.Error()
+	if stackTrace := fmt.Sprintf("%+v", err); stackTrace != quarantineError.Error {
+		if len(stackTrace) > maxStackTraceLength {
+			stackTrace = stackTrace[:maxStackTraceLength]
+		}
+		quarantineError.StackTrace = stackTrace
+	}
+
+	return quarantineError
+}
+
+// ErrorHistory returns the errors of the header of the message, a missing or an invalid header has no errors
+func ErrorHistory(headers map[string]any) []*QuarantineError {
+	value, ok := headers[ErrorHistoryHeader].(string)
+	if !ok || value == "" {
+		return nil
+	}
+
+	var history []*QuarantineError
+	if err := json.Unmarshal([]byte(value), &history); err != nil {
+		return nil
+	}
+
+	return history
+}
+
+// AppendErrorHistory adds the error of an attempt to the header of the message
+func AppendErrorHistory(headers map[string]any, quarantineError *QuarantineError) {
+	history := append(ErrorHistory(headers), quarantineError)
+	if len(history) > maxErrorHistory {
+		history = history[len(history)-maxErrorHistory:]
+	}
+
+	value, err := json.Marshal(history)
+	if err != nil {
+		return
+	}
+
+	headers[ErrorHistoryHeader] = string(value)
+}
@@ -0,0 +1,43 @@
+package quarantine
+
+import (
+	"fmt"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Append_Error_History(t *testing.T) {
+	headers := map[string]any{}
+
+	AppendErrorHistory(headers, NewQuarantineError(1, errors.New("timeout")))
+	AppendErrorHistory(headers, NewQuarantineError(2, errors.New("product not found")))
+
+	history := ErrorHistory(headers)
+	require.Len(t, history, 2)
+	assert.Equal(t, 1, history[0].Attempt)
+	assert.Equal(t, "timeout", history[0].Error)
+	assert.Equal(t, "product not found", history[1].Error)
+	assert.Contains(t, history[1].StackTrace, "Test_Append_Error_History")
+}
+
+func Test_Append_Error_History_Keeps_Last_Errors(t *testing.T) {
+	headers := map[string]any{}
+
+	for attempt := 1; attempt <= maxErrorHistory+2; attempt++ {
+		AppendErrorHistory(headers, NewQuarantineError(attempt, fmt.Errorf("attempt %d failed", attempt)))
+	}
+
+	history := ErrorHistory(headers)
+	require.Len(t, history, maxErrorHistory)
+	assert.Equal(t, 3, history[0].Attempt)
+	assert.Equal(t, maxErrorHistory+2, history[maxErrorHistory-1].Attempt)
+	assert.Empty(t, history[0].StackTrace)
+}
+
+func Test_Error_History_Of_Invalid_Header(t *testing.T) {
+	assert.Empty(t, ErrorHistory(map[string]any{ErrorHistoryHeader: "invalid"}))
+	assert.Empty(t, ErrorHistory(nil))
+}
@@ -0,0 +1,86 @@
+package quarantine
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+)
+
+type inMemoryQuarantineStore struct {
+	mu       sync.RWMutex
+	messages map[string]*QuarantinedMessage
+}
+
+// NewInMemoryQuarantineStore keeps the quarantined messages in the memory of the process, it is used by the tests.
+func NewInMemoryQuarantineStore() QuarantineStore {
+	return &inMemoryQuarantineStore{messages: make(map[string]*QuarantinedMessage)}
+}
+
+func (s *inMemoryQuarantineStore) Add(_ context.Context, message *QuarantinedMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.messages[message.Id]; ok {
+		return customErrors.NewConflictError(
+			fmt.Sprintf("quarantined message with id `%s` already exists", message.Id),
+		)
+	}
+
+	copied := *message
+	s.messages[message.Id] = &copied
+
+	return nil
+}
+
+func (s *inMemoryQuarantineStore) Get(_ context.Context, id string) (*QuarantinedMessage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	message, ok := s.messages[id]
+	if !ok {
+		return nil, customErrors.NewNotFoundError(fmt.Sprintf("quarantined message with id `%s` not found", id))
+	}
+
+	copied := *message
+
+	return &copied, nil
+}
+
+func (s *inMemoryQuarantineStore) List(_ context.Context, consumer string, limit int) ([]*QuarantinedMessage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var messages []*QuarantinedMessage
+	for _, message := range s.messages {
+		if consumer == "" || message.Consumer == consumer {
+			copied := *message
+			messages = append(messages, &copied)
+		}
+	}
+
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].QuarantinedAt.After(messages[j].QuarantinedAt)
+	})
+
+	if limit > 0 && len(messages) > limit {
+		messages = messages[:limit]
+	}
+
+	return messages, nil
+}
+
+func (s *inMemoryQuarantineStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.messages[id]; !ok {
+		return customErrors.NewNotFoundError(fmt.Sprintf("quarantined message with id `%s` not found", id))
+	}
+
+	delete(s.messages, id)
+
+	return nil
+}
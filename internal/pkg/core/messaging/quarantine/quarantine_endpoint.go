@@ -0,0 +1,123 @@
+package quarantine
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/contracts"
+	apikey "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/api_key"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+const defaultLimit = 20
+
+type QuarantineEndpoint struct {
+	store      QuarantineStore
+	replayer   MessageReplayer
+	options    *QuarantineOptions
+	echoServer contracts.EchoHttpServer
+}
+
+type replayResponse struct {
+	Id    string `json:"id"`
+	Queue string `json:"queue"`
+}
+
+func NewQuarantineEndpoint(
+	store QuarantineStore,
+	replayer MessageReplayer,
+	options *QuarantineOptions,
+	server contracts.EchoHttpServer,
+) *QuarantineEndpoint {
+	return &QuarantineEndpoint{store: store, replayer: replayer, options: options, echoServer: server}
+}
+
+// RegisterEndpoints registers the `admin/quarantine` endpoints, they are authenticated with the api keys of the admin
+// users and are not registered without any user
+func (e *QuarantineEndpoint) RegisterEndpoints() {
+	var keys []apikey.Option
+	for _, user := range e.options.AdminUsers {
+		if user != nil {
+			keys = append(keys, apikey.WithKey(user.ApiKey, user.UserId))
+		}
+	}
+
+	if len(keys) == 0 {
+		return
+	}
+
+	group := e.echoServer.GetEchoInstance().Group("admin/quarantine", apikey.ApiKey(keys...))
+	group.GET("", e.messages)
+	group.GET("/:id", e.message)
+	group.POST("/:id/replay", e.replay)
+	group.DELETE("/:id", e.delete)
+}
+
+func (e *QuarantineEndpoint) messages(c echo.Context) error {
+	limit, err := limitParam(c)
+	if err != nil {
+		return err
+	}
+
+	messages, err := e.store.List(c.Request().Context(), c.QueryParam("consumer"), limit)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, messages)
+}
+
+func (e *QuarantineEndpoint) message(c echo.Context) error {
+	message, err := e.store.Get(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, message)
+}
+
+// replay publishes the message to its queue and removes it from the quarantine, a message which fails again is
+// quarantined again with a new id after the retries of its consumer
+func (e *QuarantineEndpoint) replay(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	message, err := e.store.Get(ctx, c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	if err := e.replayer.Replay(ctx, message); err != nil {
+		return customErrors.NewInternalServerErrorWrap(err, "error in replaying the quarantined message")
+	}
+
+	if err := e.store.Delete(ctx, message.Id); err != nil {
+		return errors.WrapIf(err, "the quarantined message is replayed but it is not removed from the quarantine")
+	}
+
+	return c.JSON(http.StatusOK, &replayResponse{Id: message.Id, Queue: message.Queue})
+}
+
+func (e *QuarantineEndpoint) delete(c echo.Context) error {
+	if err := e.store.Delete(c.Request().Context(), c.Param("id")); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func limitParam(c echo.Context) (int, error) {
+	value := c.QueryParam("limit")
+	if value == "" {
+		return defaultLimit, nil
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 {
+		return 0, customErrors.NewBadRequestError("limit should be a positive number")
+	}
+
+	return limit, nil
+}
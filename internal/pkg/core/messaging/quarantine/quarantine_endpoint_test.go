@@ -0,0 +1,113 @@
+package quarantine
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	customEcho "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/contracts"
+	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeMessageReplayer struct {
+	replayed []*QuarantinedMessage
+}
+
+func (f *fakeMessageReplayer) Replay(ctx context.Context, message *QuarantinedMessage) error {
+	f.replayed = append(f.replayed, message)
+
+	return nil
+}
+
+func newTestEchoServer(
+	store QuarantineStore,
+	replayer MessageReplayer,
+	users ...*AdminUserOptions,
+) contracts.EchoHttpServer {
+	server := customEcho.NewEchoHttpServer(&config.EchoHttpOptions{}, defaultLogger.GetLogger(), nil)
+	NewQuarantineEndpoint(store, replayer, &QuarantineOptions{AdminUsers: users}, server).RegisterEndpoints()
+
+	return server
+}
+
+func newTestStore(t *testing.T) QuarantineStore {
+	t.Helper()
+
+	store := NewInMemoryQuarantineStore()
+	require.NoError(t, store.Add(context.Background(), &QuarantinedMessage{
+		Id:            "1",
+		MessageId:     "message-1",
+		Consumer:      "OrderCreated",
+		Queue:         "order_created",
+		RetryCount:    3,
+		Errors:        []*QuarantineError{{Attempt: 4, Error: "product not found"}},
+		QuarantinedAt: time.Now(),
+	}))
+
+	return store
+}
+
+func serve(server contracts.EchoHttpServer, method string, target string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	req.Header.Set("X-Api-Key", "secret")
+	rec := httptest.NewRecorder()
+	server.GetEchoInstance().ServeHTTP(rec, req)
+
+	return rec
+}
+
+func Test_Quarantine_Endpoint_List(t *testing.T) {
+	server := newTestEchoServer(
+		newTestStore(t),
+		&fakeMessageReplayer{},
+		&AdminUserOptions{UserId: "admin", ApiKey: "secret"},
+	)
+
+	rec := serve(server, http.MethodGet, "/admin/quarantine?consumer=OrderCreated")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"messageId":"message-1"`)
+	assert.Contains(t, rec.Body.String(), `"error":"product not found"`)
+}
+
+func Test_Quarantine_Endpoint_Replay(t *testing.T) {
+	store := newTestStore(t)
+	replayer := &fakeMessageReplayer{}
+	server := newTestEchoServer(store, replayer, &AdminUserOptions{UserId: "admin", ApiKey: "secret"})
+
+	rec := serve(server, http.MethodPost, "/admin/quarantine/1/replay")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"id":"1","queue":"order_created"}`, rec.Body.String())
+	require.Len(t, replayer.replayed, 1)
+	assert.Equal(t, "message-1", replayer.replayed[0].MessageId)
+
+	_, err := store.Get(context.Background(), "1")
+	assert.Error(t, err)
+}
+
+func Test_Quarantine_Endpoint_Delete(t *testing.T) {
+	store := newTestStore(t)
+	server := newTestEchoServer(store, &fakeMessageReplayer{}, &AdminUserOptions{UserId: "admin", ApiKey: "secret"})
+
+	rec := serve(server, http.MethodDelete, "/admin/quarantine/1")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	_, err := store.Get(context.Background(), "1")
+	assert.Error(t, err)
+}
+
+func Test_Quarantine_Endpoint_Not_Registered_Without_Admin_Users(t *testing.T) {
+	server := newTestEchoServer(newTestStore(t), &fakeMessageReplayer{})
+
+	rec := serve(server, http.MethodGet, "/admin/quarantine")
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
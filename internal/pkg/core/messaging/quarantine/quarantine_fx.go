@@ -0,0 +1,20 @@
+package quarantine
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provides the quarantine admin endpoints, the QuarantineStore is provided by the persistence modules like
+// `postgresgorm.QuarantineModule` or `mongodb.QuarantineModule` and the MessageReplayer by the message broker, the
+// endpoints need an echo server
+// https://uber-go.github.io/fx/modules.html
+var Module = fx.Module( //nolint:gochecknoglobals
+	"quarantinefx",
+	fx.Provide(
+		ProvideConfig,
+		NewQuarantineEndpoint,
+	),
+	fx.Invoke(func(endpoint *QuarantineEndpoint) {
+		endpoint.RegisterEndpoints()
+	}),
+)
@@ -0,0 +1,28 @@
+package quarantine
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[QuarantineOptions]())
+
+// QuarantineOptions controls the admin endpoints of the quarantined messages, the messages are quarantined by the
+// consumers when a QuarantineStore is provided.
+type QuarantineOptions struct {
+	// AdminUsers authenticate the admin endpoints with the api key of a user in the `X-Api-Key` header, the
+	// endpoints are not registered without any user
+	AdminUsers []*AdminUserOptions `mapstructure:"adminUsers"`
+}
+
+type AdminUserOptions struct {
+	UserId string `mapstructure:"userId"`
+	ApiKey string `mapstructure:"apiKey" secret:"true"`
+}
+
+func ProvideConfig(environment environment.Environment) (*QuarantineOptions, error) {
+	return config.BindConfigKey[*QuarantineOptions](optionName, environment)
+}
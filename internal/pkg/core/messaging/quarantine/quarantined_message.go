@@ -0,0 +1,48 @@
+package quarantine
+
+import (
+	"context"
+	"time"
+)
+
+// QuarantinedMessage is a poison message which is failed after the max retries of its consumer, it is kept with its
+// headers and the errors of its attempts until it is replayed to its queue or deleted
+type QuarantinedMessage struct {
+	Id            string `gorm:"primaryKey"                  bson:"_id"           json:"id"`
+	MessageId     string `gorm:"index"                       bson:"messageId"     json:"messageId"`
+	CorrelationId string `bson:"correlationId"               json:"correlationId"`
+	MessageType   string `bson:"messageType"                 json:"messageType"`
+	ContentType   string `bson:"contentType"                 json:"contentType"`
+	Consumer      string `gorm:"index"                       bson:"consumer"      json:"consumer"`
+	// Queue is the consumer queue which the message is replayed to
+	Queue      string         `bson:"queue"                       json:"queue"`
+	Headers    map[string]any `gorm:"serializer:json;type:jsonb"  bson:"headers"       json:"headers"`
+	Body       []byte         `bson:"body"                        json:"body"`
+	RetryCount int            `bson:"retryCount"                  json:"retryCount"`
+	// Errors are the errors of the failed attempts of the message, the oldest first
+	Errors           []*QuarantineError `gorm:"serializer:json;type:jsonb"  bson:"errors"        json:"errors"`
+	MessageTimestamp time.Time          `bson:"messageTimestamp"            json:"messageTimestamp"`
+	QuarantinedAt    time.Time          `gorm:"index"                       bson:"quarantinedAt" json:"quarantinedAt"`
+}
+
+func (m *QuarantinedMessage) TableName() string {
+	return "quarantined_messages"
+}
+
+// QuarantineStore keeps the quarantined messages of the consumers
+type QuarantineStore interface {
+	// Add stores a quarantined message
+	Add(ctx context.Context, message *QuarantinedMessage) error
+	// Get returns the message, a not found error is returned for an unknown id
+	Get(ctx context.Context, id string) (*QuarantinedMessage, error)
+	// List returns the last quarantined messages of a consumer, the newest first, an empty consumer lists the messages
+	// of all consumers
+	List(ctx context.Context, consumer string, limit int) ([]*QuarantinedMessage, error)
+	// Delete removes the message, a not found error is returned for an unknown id
+	Delete(ctx context.Context, id string) error
+}
+
+// MessageReplayer publishes a quarantined message to its queue again, it is provided by the message broker
+type MessageReplayer interface {
+	Replay(ctx context.Context, message *QuarantinedMessage) error
+}
@@ -14,10 +14,17 @@ type IMessage interface {
 	GetMessageFullTypeName() string
 }
 
+// TxMessage is implemented by the messages whose handlers should run in a database transaction, the transaction
+// pipeline of the consumers commits the changes of all the handlers of the message or none of them
+type TxMessage interface {
+	IMessage
+	IsTxMessage() bool
+}
+
 type Message struct {
-	MessageId string    `json:"messageId,omitempty"`
-	Created   time.Time `json:"created"`
-	EventType string    `json:"eventType"`
+	MessageId string    `json:"messageId,omitempty" avro:"messageId"`
+	Created   time.Time `json:"created"             avro:"created"`
+	EventType string    `json:"eventType"           avro:"eventType"`
 	isMessage bool
 }
 
@@ -17,6 +17,11 @@ type MessageConsumeContext interface {
 	Message() IMessage
 }
 
+// MessageSetter replaces the message of a consume context, like the resolved message of a claim-check reference
+type MessageSetter interface {
+	SetMessage(message IMessage)
+}
+
 type messageConsumeContext struct {
 	metadata      metadata.Metadata
 	contentType   string
@@ -54,6 +59,10 @@ func (m *messageConsumeContext) Message() IMessage {
 	return m.message
 }
 
+func (m *messageConsumeContext) SetMessage(message IMessage) {
+	m.message = message
+}
+
 func (m *messageConsumeContext) MessageId() string {
 	return m.messageId
 }
@@ -0,0 +1,26 @@
+package sequence
+
+import (
+	"context"
+	"sync"
+)
+
+type inMemorySequenceGenerator struct {
+	mu        sync.Mutex
+	sequences map[string]int64
+}
+
+// NewInMemorySequenceGenerator keeps the sequences in the memory of the process, it is used by the tests and the
+// services which run a single instance.
+func NewInMemorySequenceGenerator() SequenceGenerator {
+	return &inMemorySequenceGenerator{sequences: make(map[string]int64)}
+}
+
+func (s *inMemorySequenceGenerator) Next(_ context.Context, name string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sequences[name]++
+
+	return s.sequences[name], nil
+}
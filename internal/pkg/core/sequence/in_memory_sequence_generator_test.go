@@ -0,0 +1,56 @@
+package sequence
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_InMemorySequenceGenerator_Generates_Unique_Numbers_Concurrently(t *testing.T) {
+	generator := NewInMemorySequenceGenerator()
+
+	const count = 100
+	numbers := make(chan int64, count)
+
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			number, err := generator.Next(context.Background(), "orders")
+			assert.NoError(t, err)
+			numbers <- number
+		}()
+	}
+	wg.Wait()
+	close(numbers)
+
+	seen := make(map[int64]bool)
+	for number := range numbers {
+		assert.False(t, seen[number], "number %d is generated twice", number)
+		seen[number] = true
+	}
+
+	for number := int64(1); number <= count; number++ {
+		assert.True(t, seen[number], "number %d is not generated", number)
+	}
+}
+
+func Test_InMemorySequenceGenerator_Keeps_Sequences_Separately(t *testing.T) {
+	generator := NewInMemorySequenceGenerator()
+
+	first, err := generator.Next(context.Background(), "orders-ORD")
+	require.NoError(t, err)
+	other, err := generator.Next(context.Background(), "orders-ACME")
+	require.NoError(t, err)
+	second, err := generator.Next(context.Background(), "orders-ORD")
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1), first)
+	assert.Equal(t, int64(1), other)
+	assert.Equal(t, int64(2), second)
+}
@@ -0,0 +1,11 @@
+package sequence
+
+import "context"
+
+// SequenceGenerator generates the increasing numbers of the named sequences, the numbers of a sequence are unique
+// across the instances of a service. a number is not returned to its sequence when its operation fails, so a sequence
+// can have gaps but never duplicates.
+type SequenceGenerator interface {
+	// Next returns the next number of the sequence, the first number of a sequence is 1
+	Next(ctx context.Context, name string) (int64, error)
+}
@@ -0,0 +1,261 @@
+package avro
+
+import (
+	"context"
+	"encoding/binary"
+	"sync"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer/schemaregistry"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"emperror.dev/errors"
+	"github.com/hamba/avro/v2"
+)
+
+// ContentType is the content type of the avro serialized messages
+const ContentType = "application/avro"
+
+// https://avro.apache.org/docs/1.11.1/specification/#single-object-encoding
+var singleObjectMarker = []byte{0xc3, 0x01} //nolint:gochecknoglobals
+
+const headerLength = 10
+
+// AvroMessage is a message with an avro schema, the schema is the reader schema of the message and its fields are
+// matched with the `avro` tags of the message fields
+type AvroMessage interface {
+	AvroSchema() string
+}
+
+type avroCodec struct {
+	api            avro.API
+	registry       *schemaRegistry
+	schemaRegistry schemaregistry.SchemaRegistry
+}
+
+// NewAvroMessageSerializer returns a message serializer for the messages which implement AvroMessage. the messages are
+// written with the avro single object encoding, so the fingerprint of their writer schema is sent with them, and a
+// consumer with another version of the schema resolves the writer schema to its own schema with the avro schema
+// evolution rules. the writer schemas of the other services should be registered with `RegisterSchema`.
+//
+// with a schema registry the schemas are registered with the full names of their records as their subjects, and the
+// messages are written in the confluent wire format, so the consumers resolve the writer schemas from the registry.
+func NewAvroMessageSerializer(
+	s serializer.Serializer,
+	schemaRegistry schemaregistry.SchemaRegistry,
+) serializer.MessageSerializer {
+	return serializer.NewCodecMessageSerializer(
+		&avroCodec{api: avro.DefaultConfig, registry: defaultRegistry, schemaRegistry: schemaRegistry},
+		s,
+	)
+}
+
+// RegisterSchema registers a writer schema, e.g. a former version of the schema of a message, so the messages written
+// with it can be read with the current schema of the message
+func RegisterSchema(schema string) error {
+	_, _, err := defaultRegistry.parse(schema)
+
+	return err
+}
+
+func (a *avroCodec) ContentType() string {
+	return ContentType
+}
+
+func (a *avroCodec) Marshal(message interface{}) ([]byte, error) {
+	schema, fingerprint, err := a.readerSchema(message)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := a.api.Marshal(schema, message)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.schemaRegistry != nil {
+		registered, err := a.schemaRegistry.Register(
+			context.Background(),
+			subject(schema, message),
+			&schemaregistry.Schema{Schema: schema.String(), SchemaType: schemaregistry.SchemaTypeAvro},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return schemaregistry.EncodeHeader(registered.Id, data), nil
+	}
+
+	result := make([]byte, headerLength, headerLength+len(data))
+	copy(result, singleObjectMarker)
+	binary.LittleEndian.PutUint64(result[len(singleObjectMarker):], fingerprint)
+
+	return append(result, data...), nil
+}
+
+func (a *avroCodec) Unmarshal(data []byte, message interface{}) error {
+	if a.schemaRegistry != nil {
+		return a.unmarshalWithSchemaRegistry(data, message)
+	}
+
+	if len(data) < headerLength || data[0] != singleObjectMarker[0] || data[1] != singleObjectMarker[1] {
+		return errors.New("the data is not in the avro single object encoding")
+	}
+
+	readerSchema, readerFingerprint, err := a.readerSchema(message)
+	if err != nil {
+		return err
+	}
+
+	writerFingerprint := binary.LittleEndian.Uint64(data[len(singleObjectMarker):headerLength])
+	schema, err := a.registry.resolve(readerSchema, readerFingerprint, writerFingerprint)
+	if err != nil {
+		return err
+	}
+
+	return a.api.Unmarshal(schema, data[headerLength:], message)
+}
+
+func (a *avroCodec) unmarshalWithSchemaRegistry(data []byte, message interface{}) error {
+	schemaId, payload, err := schemaregistry.DecodeHeader(data)
+	if err != nil {
+		return err
+	}
+
+	readerSchema, readerFingerprint, err := a.readerSchema(message)
+	if err != nil {
+		return err
+	}
+
+	writer, err := a.schemaRegistry.GetSchema(context.Background(), schemaId)
+	if err != nil {
+		return err
+	}
+	if writer.SchemaType != "" && writer.SchemaType != schemaregistry.SchemaTypeAvro {
+		return errors.Errorf("schema `%d` is not an avro schema", schemaId)
+	}
+
+	_, writerFingerprint, err := a.registry.parse(writer.Schema)
+	if err != nil {
+		return err
+	}
+
+	schema, err := a.registry.resolve(readerSchema, readerFingerprint, writerFingerprint)
+	if err != nil {
+		return err
+	}
+
+	return a.api.Unmarshal(schema, payload, message)
+}
+
+func (a *avroCodec) readerSchema(message interface{}) (avro.Schema, uint64, error) {
+	avroMessage, ok := message.(AvroMessage)
+	if !ok {
+		return nil, 0, errors.Errorf("type `%T` doesn't implement AvroMessage", message)
+	}
+
+	return a.registry.parse(avroMessage.AvroSchema())
+}
+
+// subject returns the full name of the record of the schema, the record name strategy of the registry
+func subject(schema avro.Schema, message interface{}) string {
+	if namedSchema, ok := schema.(avro.NamedSchema); ok {
+		return namedSchema.FullName()
+	}
+
+	return typeMapper.GetTypeName(message)
+}
+
+type resolvedSchemaKey struct {
+	reader uint64
+	writer uint64
+}
+
+// schemaRegistry keeps the parsed schemas by their CRC-64-AVRO fingerprints and the reader schemas resolved from the
+// writer schemas
+type schemaRegistry struct {
+	mu       sync.RWMutex
+	bySource map[string]avro.Schema
+	byPrint  map[uint64]avro.Schema
+	resolved map[resolvedSchemaKey]avro.Schema
+}
+
+//nolint:gochecknoglobals
+var defaultRegistry = &schemaRegistry{
+	bySource: make(map[string]avro.Schema),
+	byPrint:  make(map[uint64]avro.Schema),
+	resolved: make(map[resolvedSchemaKey]avro.Schema),
+}
+
+func (r *schemaRegistry) parse(source string) (avro.Schema, uint64, error) {
+	r.mu.RLock()
+	schema, ok := r.bySource[source]
+	r.mu.RUnlock()
+
+	if !ok {
+		var err error
+		// each schema is parsed with its own cache, so the versions of a schema with the same name don't conflict
+		schema, err = avro.ParseWithCache(source, "", &avro.SchemaCache{})
+		if err != nil {
+			return nil, 0, errors.WrapIf(err, "error in parsing the avro schema")
+		}
+	}
+
+	fingerprint, err := schemaFingerprint(schema)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if !ok {
+		r.mu.Lock()
+		r.bySource[source] = schema
+		r.byPrint[fingerprint] = schema
+		r.mu.Unlock()
+	}
+
+	return schema, fingerprint, nil
+}
+
+func (r *schemaRegistry) resolve(
+	reader avro.Schema,
+	readerFingerprint uint64,
+	writerFingerprint uint64,
+) (avro.Schema, error) {
+	if readerFingerprint == writerFingerprint {
+		return reader, nil
+	}
+
+	key := resolvedSchemaKey{reader: readerFingerprint, writer: writerFingerprint}
+
+	r.mu.RLock()
+	resolved, ok := r.resolved[key]
+	writer, writerOk := r.byPrint[writerFingerprint]
+	r.mu.RUnlock()
+
+	if ok {
+		return resolved, nil
+	}
+	if !writerOk {
+		return nil, errors.Errorf("the writer schema with the fingerprint `%x` is not registered", writerFingerprint)
+	}
+
+	resolved, err := avro.NewSchemaCompatibility().Resolve(reader, writer)
+	if err != nil {
+		return nil, errors.WrapIf(err, "the writer schema is not compatible with the reader schema")
+	}
+
+	r.mu.Lock()
+	r.resolved[key] = resolved
+	r.mu.Unlock()
+
+	return resolved, nil
+}
+
+func schemaFingerprint(schema avro.Schema) (uint64, error) {
+	fingerprint, err := schema.FingerprintUsing(avro.CRC64Avro)
+	if err != nil {
+		return 0, errors.WrapIf(err, "error in fingerprinting the avro schema")
+	}
+
+	return binary.BigEndian.Uint64(fingerprint), nil
+}
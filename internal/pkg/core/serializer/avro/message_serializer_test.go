@@ -0,0 +1,149 @@
+//go:build unit
+// +build unit
+
+package avro
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer/json"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer/schemaregistry"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const productV1Schema = `{
+	"type": "record",
+	"name": "product",
+	"fields": [
+		{"name": "name", "type": "string"},
+		{"name": "price", "type": "double"}
+	]
+}`
+
+const productV2Schema = `{
+	"type": "record",
+	"name": "product",
+	"fields": [
+		{"name": "name", "type": "string"},
+		{"name": "price", "type": "double"},
+		{"name": "currency", "type": "string", "default": "USD"}
+	]
+}`
+
+type productV1 struct {
+	Name  string  `json:"name" avro:"name"`
+	Price float64 `json:"price" avro:"price"`
+}
+
+func (p *productV1) AvroSchema() string {
+	return productV1Schema
+}
+
+type productV2 struct {
+	Name     string  `json:"name" avro:"name"`
+	Price    float64 `json:"price" avro:"price"`
+	Currency string  `json:"currency" avro:"currency"`
+}
+
+func (p *productV2) AvroSchema() string {
+	return productV2Schema
+}
+
+// productV3 adds a field without a default, so it can't read the messages of the former versions
+type productV3 struct {
+	Name     string  `json:"name"     avro:"name"`
+	Price    float64 `json:"price"    avro:"price"`
+	Discount float64 `json:"discount" avro:"discount"`
+}
+
+func (p *productV3) AvroSchema() string {
+	return `{
+		"type": "record",
+		"name": "product",
+		"fields": [
+			{"name": "name", "type": "string"},
+			{"name": "price", "type": "double"},
+			{"name": "discount", "type": "double"}
+		]
+	}`
+}
+
+func Test_Deserialize_Message_With_Its_Own_Schema(t *testing.T) {
+	serializer := NewAvroMessageSerializer(json.NewDefaultJsonSerializer(), nil)
+
+	serialized, err := serializer.SerializeObject(&productV2{Name: "book", Price: 10, Currency: "EUR"})
+	require.NoError(t, err)
+	assert.Equal(t, ContentType, serialized.ContentType)
+
+	product := &productV2{}
+	require.NoError(t, serializer.DeserializeInto(serialized.Data, product, ContentType))
+	assert.Equal(t, &productV2{Name: "book", Price: 10, Currency: "EUR"}, product)
+}
+
+func Test_Deserialize_Message_Of_Former_Schema_With_Defaults_Of_New_Fields(t *testing.T) {
+	serializer := NewAvroMessageSerializer(json.NewDefaultJsonSerializer(), nil)
+
+	serialized, err := serializer.SerializeObject(&productV1{Name: "book", Price: 10})
+	require.NoError(t, err)
+
+	product := &productV2{}
+	require.NoError(t, serializer.DeserializeInto(serialized.Data, product, ContentType))
+	assert.Equal(t, &productV2{Name: "book", Price: 10, Currency: "USD"}, product)
+}
+
+func Test_Deserialize_Message_Of_New_Schema_Without_Its_New_Fields(t *testing.T) {
+	serializer := NewAvroMessageSerializer(json.NewDefaultJsonSerializer(), nil)
+
+	serialized, err := serializer.SerializeObject(&productV2{Name: "book", Price: 10, Currency: "EUR"})
+	require.NoError(t, err)
+
+	product := &productV1{}
+	require.NoError(t, serializer.DeserializeInto(serialized.Data, product, ContentType))
+	assert.Equal(t, &productV1{Name: "book", Price: 10}, product)
+}
+
+func Test_Deserialize_Message_Of_Unregistered_Schema_Should_Fail(t *testing.T) {
+	serializer := NewAvroMessageSerializer(json.NewDefaultJsonSerializer(), nil)
+
+	serialized, err := serializer.SerializeObject(&productV1{Name: "book", Price: 10})
+	require.NoError(t, err)
+
+	// an unknown fingerprint of the writer schema
+	serialized.Data[2] ^= 0xff
+
+	err = serializer.DeserializeInto(serialized.Data, &productV2{}, ContentType)
+	assert.Error(t, err)
+}
+
+func Test_Schema_Registry_Should_Resolve_Writer_Schema_Of_Message(t *testing.T) {
+	registry := schemaregistry.NewInMemorySchemaRegistry()
+	serializer := NewAvroMessageSerializer(json.NewDefaultJsonSerializer(), registry)
+
+	serialized, err := serializer.SerializeObject(&productV1{Name: "book", Price: 10})
+	require.NoError(t, err)
+
+	schemaId, _, err := schemaregistry.DecodeHeader(serialized.Data)
+	require.NoError(t, err)
+
+	schema, err := registry.GetSchema(context.Background(), schemaId)
+	require.NoError(t, err)
+	assert.Equal(t, schemaregistry.SchemaTypeAvro, schema.SchemaType)
+
+	product := &productV2{}
+	require.NoError(t, serializer.DeserializeInto(serialized.Data, product, ContentType))
+	assert.Equal(t, &productV2{Name: "book", Price: 10, Currency: "USD"}, product)
+}
+
+func Test_Schema_Registry_Should_Reject_Message_Of_Incompatible_Schema(t *testing.T) {
+	registry := schemaregistry.NewInMemorySchemaRegistry()
+	serializer := NewAvroMessageSerializer(json.NewDefaultJsonSerializer(), registry)
+
+	_, err := serializer.SerializeObject(&productV1{Name: "book", Price: 10})
+	require.NoError(t, err)
+
+	_, err = serializer.SerializeObject(&productV3{Name: "book", Price: 10, Discount: 2})
+	assert.ErrorIs(t, err, schemaregistry.ErrIncompatibleSchema)
+}
@@ -0,0 +1,127 @@
+package serializer
+
+import (
+	"reflect"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"emperror.dev/errors"
+)
+
+// MessageCodec encodes the messages in the format of its content type, e.g. protobuf or avro
+type MessageCodec interface {
+	ContentType() string
+	Marshal(message interface{}) ([]byte, error)
+	// Unmarshal decodes the data to the message pointer
+	Unmarshal(data []byte, message interface{}) error
+}
+
+type codecMessageSerializer struct {
+	codec      MessageCodec
+	serializer Serializer
+}
+
+// NewCodecMessageSerializer returns a message serializer which encodes the messages with the codec, the serializer is
+// kept for the headers and the metadata of the messages which are serialized with it regardless of the codec
+func NewCodecMessageSerializer(codec MessageCodec, serializer Serializer) MessageSerializer {
+	return &codecMessageSerializer{codec: codec, serializer: serializer}
+}
+
+func (c *codecMessageSerializer) Serialize(message types.IMessage) (*EventSerializationResult, error) {
+	return c.SerializeObject(message)
+}
+
+func (c *codecMessageSerializer) SerializeObject(message interface{}) (*EventSerializationResult, error) {
+	if message == nil {
+		return &EventSerializationResult{Data: nil, ContentType: c.ContentType()}, nil
+	}
+
+	data, err := c.codec.Marshal(message)
+	if err != nil {
+		return nil, errors.WrapIff(err, "error in Marshaling: `%s`", typeMapper.GetTypeName(message))
+	}
+
+	return &EventSerializationResult{Data: data, ContentType: c.ContentType()}, nil
+}
+
+func (c *codecMessageSerializer) SerializeEnvelop(
+	messageEnvelop types.MessageEnvelope,
+) (*EventSerializationResult, error) {
+	return c.SerializeObject(messageEnvelop.Message)
+}
+
+func (c *codecMessageSerializer) Deserialize(
+	data []byte,
+	messageType string,
+	contentType string,
+) (types.IMessage, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	targetMessagePointer := typeMapper.EmptyInstanceByTypeNameAndImplementedInterface[types.IMessage](messageType)
+	if targetMessagePointer == nil {
+		return nil, errors.Errorf("message type `%s` is not impelemted IMessage or can't be instansiated", messageType)
+	}
+
+	if err := c.DeserializeInto(data, targetMessagePointer, contentType); err != nil {
+		return nil, err
+	}
+
+	return targetMessagePointer.(types.IMessage), nil
+}
+
+func (c *codecMessageSerializer) DeserializeObject(
+	data []byte,
+	messageType string,
+	contentType string,
+) (interface{}, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	targetMessagePointer := typeMapper.InstanceByTypeName(messageType)
+	if targetMessagePointer == nil {
+		return nil, errors.Errorf("message type `%s` can't be instansiated", messageType)
+	}
+
+	if err := c.DeserializeInto(data, targetMessagePointer, contentType); err != nil {
+		return nil, err
+	}
+
+	return targetMessagePointer, nil
+}
+
+func (c *codecMessageSerializer) DeserializeType(
+	data []byte,
+	messageType reflect.Type,
+	contentType string,
+) (types.IMessage, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	// we use message short type name instead of full type name because this message in other receiver packages could have different package name
+	return c.Deserialize(data, typeMapper.GetTypeName(messageType), contentType)
+}
+
+func (c *codecMessageSerializer) DeserializeInto(data []byte, message interface{}, contentType string) error {
+	if contentType != c.ContentType() {
+		return errors.Errorf("contentType: %s is not supported", contentType)
+	}
+
+	if err := c.codec.Unmarshal(data, message); err != nil {
+		return errors.WrapIff(err, "error in Unmarshaling: `%s`", typeMapper.GetTypeName(message))
+	}
+
+	return nil
+}
+
+func (c *codecMessageSerializer) ContentType() string {
+	return c.codec.ContentType()
+}
+
+func (c *codecMessageSerializer) Serializer() Serializer {
+	return c.serializer
+}
@@ -110,7 +110,7 @@ func (s *DefaultEventJsonSerializer) DeserializeType(
 }
 
 func (s *DefaultEventJsonSerializer) ContentType() string {
-	return "application/json"
+	return ContentType
 }
 
 func (s *DefaultEventJsonSerializer) Serializer() serializer.Serializer {
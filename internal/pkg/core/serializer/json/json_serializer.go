@@ -9,6 +9,9 @@ import (
 	"github.com/mitchellh/mapstructure"
 )
 
+// ContentType is the content type of the json serialized messages and events
+const ContentType = "application/json"
+
 type jsonSerializer struct{}
 
 func NewDefaultJsonSerializer() serializer.Serializer {
@@ -42,11 +42,12 @@ func (m *DefaultMessageJsonSerializer) SerializeObject(
 	return result, nil
 }
 
+// SerializeEnvelop serializes the message of the envelope, the headers are not a part of the message payload and are kept
+// by the transport or the message store separately
 func (m *DefaultMessageJsonSerializer) SerializeEnvelop(
 	messageEnvelop types.MessageEnvelope,
 ) (*serializer.EventSerializationResult, error) {
-	// TODO implement me
-	panic("implement me")
+	return m.SerializeObject(messageEnvelop.Message)
 }
 
 func (m *DefaultMessageJsonSerializer) Deserialize(
@@ -118,8 +119,20 @@ func (m *DefaultMessageJsonSerializer) DeserializeType(
 	return m.Deserialize(data, messageTypeName, contentType)
 }
 
+func (m *DefaultMessageJsonSerializer) DeserializeInto(data []byte, message interface{}, contentType string) error {
+	if contentType != m.ContentType() {
+		return errors.Errorf("contentType: %s is not supported", contentType)
+	}
+
+	if err := m.serializer.Unmarshal(data, message); err != nil {
+		return errors.WrapIff(err, "error in Unmarshaling: `%s`", typeMapper.GetTypeName(message))
+	}
+
+	return nil
+}
+
 func (m *DefaultMessageJsonSerializer) ContentType() string {
-	return "application/json"
+	return ContentType
 }
 
 func (m *DefaultMessageJsonSerializer) Serializer() serializer.Serializer {
@@ -13,6 +13,9 @@ type MessageSerializer interface {
 	Deserialize(data []byte, messageType string, contentType string) (types.IMessage, error)
 	DeserializeObject(data []byte, messageType string, contentType string) (interface{}, error)
 	DeserializeType(data []byte, messageType reflect.Type, contentType string) (types.IMessage, error)
+	// DeserializeInto deserializes the data to the message pointer, the consumers with a known message type use it
+	// instead of resolving the message type by its name
+	DeserializeInto(data []byte, message interface{}, contentType string) error
 	ContentType() string
 	Serializer() Serializer
 }
@@ -129,6 +129,54 @@ func (_c *MessageSerializer_Deserialize_Call) RunAndReturn(run func([]byte, stri
 	return _c
 }
 
+// DeserializeInto provides a mock function with given fields: data, message, contentType
+func (_m *MessageSerializer) DeserializeInto(data []byte, message interface{}, contentType string) error {
+	ret := _m.Called(data, message, contentType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeserializeInto")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]byte, interface{}, string) error); ok {
+		r0 = rf(data, message, contentType)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MessageSerializer_DeserializeInto_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeserializeInto'
+type MessageSerializer_DeserializeInto_Call struct {
+	*mock.Call
+}
+
+// DeserializeInto is a helper method to define mock.On call
+//   - data []byte
+//   - message interface{}
+//   - contentType string
+func (_e *MessageSerializer_Expecter) DeserializeInto(data interface{}, message interface{}, contentType interface{}) *MessageSerializer_DeserializeInto_Call {
+	return &MessageSerializer_DeserializeInto_Call{Call: _e.mock.On("DeserializeInto", data, message, contentType)}
+}
+
+func (_c *MessageSerializer_DeserializeInto_Call) Run(run func(data []byte, message interface{}, contentType string)) *MessageSerializer_DeserializeInto_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]byte), args[1].(interface{}), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MessageSerializer_DeserializeInto_Call) Return(_a0 error) *MessageSerializer_DeserializeInto_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MessageSerializer_DeserializeInto_Call) RunAndReturn(run func([]byte, interface{}, string) error) *MessageSerializer_DeserializeInto_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeserializeObject provides a mock function with given fields: data, messageType, contentType
 func (_m *MessageSerializer) DeserializeObject(data []byte, messageType string, contentType string) (interface{}, error) {
 	ret := _m.Called(data, messageType, contentType)
@@ -0,0 +1,43 @@
+package msgpack
+
+import (
+	"bytes"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// ContentType is the content type of the messagepack serialized messages
+const ContentType = "application/msgpack"
+
+type msgpackCodec struct{}
+
+// NewMsgpackMessageSerializer returns a message serializer which encodes the messages with messagepack, the fields are
+// named by their `json` tags, so the messages don't need their own tags
+func NewMsgpackMessageSerializer(s serializer.Serializer) serializer.MessageSerializer {
+	return serializer.NewCodecMessageSerializer(&msgpackCodec{}, s)
+}
+
+func (m *msgpackCodec) ContentType() string {
+	return ContentType
+}
+
+func (m *msgpackCodec) Marshal(message interface{}) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	encoder := msgpack.NewEncoder(&buffer)
+	encoder.SetCustomStructTag("json")
+	if err := encoder.Encode(message); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func (m *msgpackCodec) Unmarshal(data []byte, message interface{}) error {
+	decoder := msgpack.NewDecoder(bytes.NewReader(data))
+	decoder.SetCustomStructTag("json")
+
+	return decoder.Decode(message)
+}
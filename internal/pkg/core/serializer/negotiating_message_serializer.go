@@ -0,0 +1,113 @@
+package serializer
+
+import (
+	"mime"
+	"reflect"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+
+	"emperror.dev/errors"
+)
+
+// MessageSerializersGroup is the fx group of the message serializers of the content types, a bus selects its
+// serializer from them
+const MessageSerializersGroup = "message-serializers"
+
+type negotiatingMessageSerializer struct {
+	MessageSerializer
+	serializers map[string]MessageSerializer
+}
+
+// NewNegotiatingMessageSerializer returns a message serializer which serializes the messages with the serializer of the
+// content type and deserializes them with the serializer of their own content type, so the consumers of a bus keep
+// consuming the messages of the producers with another content type, e.g. during a migration from json to protobuf.
+// the messages without a content type are deserialized with the serializer of the content type.
+func NewNegotiatingMessageSerializer(
+	contentType string,
+	serializers []MessageSerializer,
+) (MessageSerializer, error) {
+	negotiating := &negotiatingMessageSerializer{serializers: make(map[string]MessageSerializer)}
+	for _, serializer := range serializers {
+		if serializer != nil {
+			negotiating.serializers[serializer.ContentType()] = serializer
+		}
+	}
+
+	defaultSerializer, ok := negotiating.serializers[mediaType(contentType)]
+	if !ok {
+		return nil, errors.Errorf("there is no message serializer for the content type `%s`", contentType)
+	}
+	negotiating.MessageSerializer = defaultSerializer
+
+	return negotiating, nil
+}
+
+func (n *negotiatingMessageSerializer) Deserialize(
+	data []byte,
+	messageType string,
+	contentType string,
+) (types.IMessage, error) {
+	serializer, err := n.negotiate(contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	return serializer.Deserialize(data, messageType, serializer.ContentType())
+}
+
+func (n *negotiatingMessageSerializer) DeserializeObject(
+	data []byte,
+	messageType string,
+	contentType string,
+) (interface{}, error) {
+	serializer, err := n.negotiate(contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	return serializer.DeserializeObject(data, messageType, serializer.ContentType())
+}
+
+func (n *negotiatingMessageSerializer) DeserializeType(
+	data []byte,
+	messageType reflect.Type,
+	contentType string,
+) (types.IMessage, error) {
+	serializer, err := n.negotiate(contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	return serializer.DeserializeType(data, messageType, serializer.ContentType())
+}
+
+func (n *negotiatingMessageSerializer) DeserializeInto(data []byte, message interface{}, contentType string) error {
+	serializer, err := n.negotiate(contentType)
+	if err != nil {
+		return err
+	}
+
+	return serializer.DeserializeInto(data, message, serializer.ContentType())
+}
+
+func (n *negotiatingMessageSerializer) negotiate(contentType string) (MessageSerializer, error) {
+	if contentType == "" {
+		return n.MessageSerializer, nil
+	}
+
+	serializer, ok := n.serializers[mediaType(contentType)]
+	if !ok {
+		return nil, errors.Errorf("contentType: %s is not supported", contentType)
+	}
+
+	return serializer, nil
+}
+
+// mediaType removes the parameters of the content type, like `application/json; charset=utf-8`
+func mediaType(contentType string) string {
+	if media, _, err := mime.ParseMediaType(contentType); err == nil {
+		return media
+	}
+
+	return contentType
+}
@@ -0,0 +1,129 @@
+//go:build unit
+// +build unit
+
+package serializer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer/avro"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer/json"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer/msgpack"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer/protobuf"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type productCreated struct {
+	*types.Message
+	ProductId string  `json:"productId" avro:"productId"`
+	Price     float64 `json:"price" avro:"price"`
+}
+
+func (p *productCreated) AvroSchema() string {
+	return `{
+		"type": "record",
+		"name": "productCreated",
+		"fields": [
+			{"name": "messageId", "type": "string"},
+			{"name": "created", "type": {"type": "long", "logicalType": "timestamp-micros"}},
+			{"name": "eventType", "type": "string"},
+			{"name": "productId", "type": "string"},
+			{"name": "price", "type": "double"}
+		]
+	}`
+}
+
+func newProductCreated() *productCreated {
+	message := types.NewMessage(uuid.NewV4().String())
+	message.Created = message.Created.UTC().Truncate(time.Microsecond)
+
+	return &productCreated{Message: message, ProductId: uuid.NewV4().String(), Price: 12.5}
+}
+
+func messageSerializers() []serializer.MessageSerializer {
+	jsonSerializer := json.NewDefaultJsonSerializer()
+
+	return []serializer.MessageSerializer{
+		json.NewDefaultMessageJsonSerializer(jsonSerializer),
+		protobuf.NewProtobufMessageSerializer(jsonSerializer, nil),
+		avro.NewAvroMessageSerializer(jsonSerializer, nil),
+		msgpack.NewMsgpackMessageSerializer(jsonSerializer),
+	}
+}
+
+func Test_Negotiating_Serializer_Should_Deserialize_By_Content_Type_Of_Message(t *testing.T) {
+	consumerSerializer, err := serializer.NewNegotiatingMessageSerializer(json.ContentType, messageSerializers())
+	require.NoError(t, err)
+
+	for _, contentType := range []string{json.ContentType, avro.ContentType, msgpack.ContentType} {
+		t.Run(contentType, func(t *testing.T) {
+			producerSerializer, err := serializer.NewNegotiatingMessageSerializer(contentType, messageSerializers())
+			require.NoError(t, err)
+
+			message := newProductCreated()
+			serialized, err := producerSerializer.Serialize(message)
+			require.NoError(t, err)
+			assert.Equal(t, contentType, serialized.ContentType)
+
+			consumed := &productCreated{}
+			err = consumerSerializer.DeserializeInto(serialized.Data, consumed, serialized.ContentType)
+			require.NoError(t, err)
+			assert.Equal(t, message.MessageId, consumed.MessageId)
+			assert.True(t, message.Created.Equal(consumed.Created))
+			assert.Equal(t, message.ProductId, consumed.ProductId)
+			assert.Equal(t, message.Price, consumed.Price)
+		})
+	}
+}
+
+func Test_Negotiating_Serializer_Should_Serialize_Protobuf_Messages(t *testing.T) {
+	messageSerializer, err := serializer.NewNegotiatingMessageSerializer(protobuf.ContentType, messageSerializers())
+	require.NoError(t, err)
+
+	serialized, err := messageSerializer.SerializeObject(wrapperspb.String("product"))
+	require.NoError(t, err)
+
+	consumed := &wrapperspb.StringValue{}
+	err = messageSerializer.DeserializeInto(serialized.Data, consumed, serialized.ContentType)
+	require.NoError(t, err)
+	assert.Equal(t, "product", consumed.GetValue())
+
+	_, err = messageSerializer.Serialize(newProductCreated())
+	assert.Error(t, err)
+}
+
+func Test_Negotiating_Serializer_Should_Use_Content_Type_Of_Bus_For_Messages_Without_Content_Type(t *testing.T) {
+	messageSerializer, err := serializer.NewNegotiatingMessageSerializer(msgpack.ContentType, messageSerializers())
+	require.NoError(t, err)
+
+	message := newProductCreated()
+	serialized, err := messageSerializer.Serialize(message)
+	require.NoError(t, err)
+
+	consumed := &productCreated{}
+	require.NoError(t, messageSerializer.DeserializeInto(serialized.Data, consumed, ""))
+	assert.Equal(t, message.ProductId, consumed.ProductId)
+
+	// the parameters of the content type are ignored
+	serialized, err = json.NewDefaultMessageJsonSerializer(json.NewDefaultJsonSerializer()).Serialize(message)
+	require.NoError(t, err)
+	require.NoError(t, messageSerializer.DeserializeInto(serialized.Data, consumed, "application/json; charset=utf-8"))
+}
+
+func Test_Negotiating_Serializer_Should_Reject_Unknown_Content_Types(t *testing.T) {
+	_, err := serializer.NewNegotiatingMessageSerializer("application/xml", messageSerializers())
+	assert.Error(t, err)
+
+	messageSerializer, err := serializer.NewNegotiatingMessageSerializer(json.ContentType, messageSerializers())
+	require.NoError(t, err)
+
+	err = messageSerializer.DeserializeInto([]byte("<product/>"), &productCreated{}, "application/xml")
+	assert.Error(t, err)
+}
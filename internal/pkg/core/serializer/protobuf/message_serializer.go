@@ -0,0 +1,136 @@
+package protobuf
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer/schemaregistry"
+
+	"emperror.dev/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ContentType is the content type of the protobuf serialized messages
+const ContentType = "application/x-protobuf"
+
+// the well-known types are built in the schema registry and are not registered
+const wellKnownTypesPrefix = "google/protobuf/"
+
+type protobufCodec struct {
+	registry schemaregistry.SchemaRegistry
+}
+
+// NewProtobufMessageSerializer returns a message serializer for the messages which are generated from the protobuf
+// schemas and implement `proto.Message`, the other messages are rejected. with a schema registry the schema files of
+// the messages are registered with the full names of the messages as their subjects, and the messages are written in
+// the confluent wire format.
+func NewProtobufMessageSerializer(
+	s serializer.Serializer,
+	registry schemaregistry.SchemaRegistry,
+) serializer.MessageSerializer {
+	return serializer.NewCodecMessageSerializer(&protobufCodec{registry: registry}, s)
+}
+
+func (p *protobufCodec) ContentType() string {
+	return ContentType
+}
+
+func (p *protobufCodec) Marshal(message interface{}) ([]byte, error) {
+	protoMessage, err := toProtoMessage(message)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := proto.Marshal(protoMessage)
+	if err != nil || p.registry == nil {
+		return data, err
+	}
+
+	descriptor := protoMessage.ProtoReflect().Descriptor()
+
+	registered, err := p.registerFile(
+		context.Background(),
+		string(descriptor.FullName()),
+		descriptor.ParentFile(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return schemaregistry.EncodeHeader(
+		registered.Id,
+		append(schemaregistry.EncodeMessageIndexes(messageIndexes(descriptor)), data...),
+	), nil
+}
+
+func (p *protobufCodec) Unmarshal(data []byte, message interface{}) error {
+	protoMessage, err := toProtoMessage(message)
+	if err != nil {
+		return err
+	}
+
+	if p.registry == nil {
+		return proto.Unmarshal(data, protoMessage)
+	}
+
+	schemaId, payload, err := schemaregistry.DecodeHeader(data)
+	if err != nil {
+		return err
+	}
+
+	// the schema of the message should be registered, the protobuf messages are decoded with their own type
+	schema, err := p.registry.GetSchema(context.Background(), schemaId)
+	if err != nil {
+		return err
+	}
+	if schema.SchemaType != schemaregistry.SchemaTypeProtobuf {
+		return errors.Errorf("schema `%d` is not a protobuf schema", schemaId)
+	}
+
+	_, payload, err = schemaregistry.DecodeMessageIndexes(payload)
+	if err != nil {
+		return err
+	}
+
+	return proto.Unmarshal(payload, protoMessage)
+}
+
+// registerFile registers the schema of the file after the files which are imported by it
+func (p *protobufCodec) registerFile(
+	ctx context.Context,
+	subject string,
+	file protoreflect.FileDescriptor,
+) (*schemaregistry.RegisteredSchema, error) {
+	schema := &schemaregistry.Schema{Schema: fileSchema(file), SchemaType: schemaregistry.SchemaTypeProtobuf}
+
+	for i := 0; i < file.Imports().Len(); i++ {
+		imported := file.Imports().Get(i)
+		if strings.HasPrefix(imported.Path(), wellKnownTypesPrefix) {
+			continue
+		}
+
+		registered, err := p.registerFile(ctx, imported.Path(), imported.FileDescriptor)
+		if err != nil {
+			return nil, err
+		}
+
+		schema.References = append(schema.References, &schemaregistry.Reference{
+			Name:    imported.Path(),
+			Subject: imported.Path(),
+			Version: registered.Version,
+		})
+	}
+
+	return p.registry.Register(ctx, subject, schema)
+}
+
+func toProtoMessage(message interface{}) (proto.Message, error) {
+	protoMessage, ok := message.(proto.Message)
+	if !ok {
+		return nil, errors.Errorf("type `%T` doesn't implement proto.Message", message)
+	}
+
+	return protoMessage, nil
+}
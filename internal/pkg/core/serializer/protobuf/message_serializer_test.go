@@ -0,0 +1,51 @@
+//go:build unit
+// +build unit
+
+package protobuf
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer/json"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer/schemaregistry"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/apipb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func Test_Schema_Registry_Should_Register_Schema_Of_Message(t *testing.T) {
+	registry := schemaregistry.NewInMemorySchemaRegistry()
+	serializer := NewProtobufMessageSerializer(json.NewDefaultJsonSerializer(), registry)
+
+	serialized, err := serializer.SerializeObject(wrapperspb.String("product"))
+	require.NoError(t, err)
+
+	schemaId, payload, err := schemaregistry.DecodeHeader(serialized.Data)
+	require.NoError(t, err)
+
+	indexes, _, err := schemaregistry.DecodeMessageIndexes(payload)
+	require.NoError(t, err)
+	// `StringValue` is the eighth message of `wrappers.proto`
+	assert.Equal(t, []int{7}, indexes)
+
+	schema, err := registry.GetSchema(context.Background(), schemaId)
+	require.NoError(t, err)
+	assert.Equal(t, schemaregistry.SchemaTypeProtobuf, schema.SchemaType)
+	assert.Contains(t, schema.Schema, "package google.protobuf;")
+	assert.Contains(t, schema.Schema, "message StringValue {\n  string value = 1;\n}")
+
+	consumed := &wrapperspb.StringValue{}
+	require.NoError(t, serializer.DeserializeInto(serialized.Data, consumed, ContentType))
+	assert.Equal(t, "product", consumed.GetValue())
+}
+
+func Test_File_Schema_Should_Keep_Imports_Enums_And_Repeated_Fields(t *testing.T) {
+	schema := fileSchema((&apipb.Api{}).ProtoReflect().Descriptor().ParentFile())
+
+	assert.Contains(t, schema, `import "google/protobuf/source_context.proto";`)
+	assert.Contains(t, schema, "repeated .google.protobuf.Method methods = 2;")
+	assert.Contains(t, schema, ".google.protobuf.Syntax syntax = 7;")
+}
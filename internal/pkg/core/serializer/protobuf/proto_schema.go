@@ -0,0 +1,139 @@
+package protobuf
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// fileSchema renders the `.proto` schema of a file descriptor for the schema registry, the schema keeps the packages,
+// the imports, the messages and the enums of the file without their options
+func fileSchema(file protoreflect.FileDescriptor) string {
+	var builder strings.Builder
+
+	syntax := "proto3"
+	if file.Syntax() == protoreflect.Proto2 {
+		syntax = "proto2"
+	}
+	fmt.Fprintf(&builder, "syntax = \"%s\";\n", syntax)
+
+	if file.Package() != "" {
+		fmt.Fprintf(&builder, "package %s;\n", file.Package())
+	}
+
+	for i := 0; i < file.Imports().Len(); i++ {
+		fmt.Fprintf(&builder, "import \"%s\";\n", file.Imports().Get(i).Path())
+	}
+
+	writeEnums(&builder, file.Enums(), "")
+	writeMessages(&builder, file.Messages(), "", syntax)
+
+	return builder.String()
+}
+
+// messageIndexes returns the indexes of the message and its parent messages in the file
+func messageIndexes(message protoreflect.MessageDescriptor) []int {
+	var indexes []int
+
+	var descriptor protoreflect.Descriptor = message
+	for {
+		messageDescriptor, ok := descriptor.(protoreflect.MessageDescriptor)
+		if !ok {
+			break
+		}
+
+		indexes = append([]int{messageDescriptor.Index()}, indexes...)
+		descriptor = messageDescriptor.Parent()
+	}
+
+	return indexes
+}
+
+func writeMessages(builder *strings.Builder, messages protoreflect.MessageDescriptors, indent string, syntax string) {
+	for i := 0; i < messages.Len(); i++ {
+		message := messages.Get(i)
+		// the map entries are written as the map fields
+		if message.IsMapEntry() {
+			continue
+		}
+
+		fmt.Fprintf(builder, "%smessage %s {\n", indent, message.Name())
+
+		nestedIndent := indent + "  "
+		writeEnums(builder, message.Enums(), nestedIndent)
+		writeMessages(builder, message.Messages(), nestedIndent, syntax)
+
+		fields := message.Fields()
+		for j := 0; j < fields.Len(); j++ {
+			field := fields.Get(j)
+
+			oneof := field.ContainingOneof()
+			if oneof != nil && !oneof.IsSynthetic() {
+				// the fields of a oneof are written with the first field of the oneof
+				if oneof.Fields().Get(0) != field {
+					continue
+				}
+
+				fmt.Fprintf(builder, "%soneof %s {\n", nestedIndent, oneof.Name())
+				for k := 0; k < oneof.Fields().Len(); k++ {
+					writeField(builder, oneof.Fields().Get(k), nestedIndent+"  ", "")
+				}
+				fmt.Fprintf(builder, "%s}\n", nestedIndent)
+
+				continue
+			}
+
+			writeField(builder, field, nestedIndent, fieldLabel(field, syntax))
+		}
+
+		fmt.Fprintf(builder, "%s}\n", indent)
+	}
+}
+
+func writeField(builder *strings.Builder, field protoreflect.FieldDescriptor, indent string, label string) {
+	fieldType := kindName(field)
+	if field.IsMap() {
+		fieldType = fmt.Sprintf("map<%s, %s>", kindName(field.MapKey()), kindName(field.MapValue()))
+		label = ""
+	}
+
+	fmt.Fprintf(builder, "%s%s%s %s = %d;\n", indent, label, fieldType, field.Name(), field.Number())
+}
+
+func writeEnums(builder *strings.Builder, enums protoreflect.EnumDescriptors, indent string) {
+	for i := 0; i < enums.Len(); i++ {
+		enum := enums.Get(i)
+
+		fmt.Fprintf(builder, "%senum %s {\n", indent, enum.Name())
+		for j := 0; j < enum.Values().Len(); j++ {
+			value := enum.Values().Get(j)
+			fmt.Fprintf(builder, "%s  %s = %d;\n", indent, value.Name(), value.Number())
+		}
+		fmt.Fprintf(builder, "%s}\n", indent)
+	}
+}
+
+func fieldLabel(field protoreflect.FieldDescriptor, syntax string) string {
+	switch {
+	case field.IsList():
+		return "repeated "
+	case field.Cardinality() == protoreflect.Required:
+		return "required "
+	case syntax == "proto2" || field.HasOptionalKeyword():
+		return "optional "
+	default:
+		return ""
+	}
+}
+
+func kindName(field protoreflect.FieldDescriptor) string {
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return "." + string(field.Message().FullName())
+	case protoreflect.EnumKind:
+		return "." + string(field.Enum().FullName())
+	default:
+		return field.Kind().String()
+	}
+}
@@ -0,0 +1,151 @@
+package schemaregistry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/client"
+
+	"emperror.dev/errors"
+	"github.com/go-resty/resty/v2"
+)
+
+// https://docs.confluent.io/platform/current/schema-registry/develop/api.html
+const contentType = "application/vnd.schemaregistry.v1+json"
+
+type confluentSchemaRegistry struct {
+	client *resty.Client
+
+	mu         sync.RWMutex
+	registered map[string]*RegisteredSchema
+	schemas    map[int]*Schema
+}
+
+type registeredSchemaResponse struct {
+	Id      int `json:"id"`
+	Version int `json:"version"`
+}
+
+type errorResponse struct {
+	ErrorCode int    `json:"error_code"`
+	Message   string `json:"message"`
+}
+
+// NewConfluentSchemaRegistry returns a client of the confluent schema registry api, the apicurio registry is used with
+// its confluent compatible api
+func NewConfluentSchemaRegistry(options *SchemaRegistryOptions) SchemaRegistry {
+	httpClient := client.NewHttpClient().
+		SetBaseURL(options.Url).
+		SetTimeout(options.Timeout).
+		SetHeader("Accept", contentType)
+
+	if options.Username != "" {
+		httpClient.SetBasicAuth(options.Username, options.Password)
+	}
+
+	return &confluentSchemaRegistry{
+		client:     httpClient,
+		registered: make(map[string]*RegisteredSchema),
+		schemas:    make(map[int]*Schema),
+	}
+}
+
+func (c *confluentSchemaRegistry) Register(
+	ctx context.Context,
+	subject string,
+	schema *Schema,
+) (*RegisteredSchema, error) {
+	key := registrationKey(subject, schema)
+
+	c.mu.RLock()
+	registered, ok := c.registered[key]
+	c.mu.RUnlock()
+
+	if ok {
+		return registered, nil
+	}
+
+	if err := c.post(ctx, fmt.Sprintf("/subjects/%s/versions", url.PathEscape(subject)), schema, nil); err != nil {
+		return nil, errors.WrapIff(err, "error in registering the schema of the subject `%s`", subject)
+	}
+
+	// the version of the schema is looked up after the registration, the registration only returns the id
+	registered = &RegisteredSchema{}
+	response := &registeredSchemaResponse{}
+	if err := c.post(ctx, fmt.Sprintf("/subjects/%s", url.PathEscape(subject)), schema, response); err != nil {
+		return nil, errors.WrapIff(err, "error in looking up the schema of the subject `%s`", subject)
+	}
+	registered.Id = response.Id
+	registered.Version = response.Version
+
+	c.mu.Lock()
+	c.registered[key] = registered
+	c.schemas[registered.Id] = schema
+	c.mu.Unlock()
+
+	return registered, nil
+}
+
+func (c *confluentSchemaRegistry) GetSchema(ctx context.Context, id int) (*Schema, error) {
+	c.mu.RLock()
+	schema, ok := c.schemas[id]
+	c.mu.RUnlock()
+
+	if ok {
+		return schema, nil
+	}
+
+	schema = &Schema{}
+	response, err := c.client.R().SetContext(ctx).SetResult(schema).Get(fmt.Sprintf("/schemas/ids/%d", id))
+	if err != nil {
+		return nil, errors.WrapIff(err, "error in getting the schema `%d`", id)
+	}
+	if err := responseError(response); err != nil {
+		return nil, errors.WrapIff(err, "error in getting the schema `%d`", id)
+	}
+
+	c.mu.Lock()
+	c.schemas[id] = schema
+	c.mu.Unlock()
+
+	return schema, nil
+}
+
+func (c *confluentSchemaRegistry) post(ctx context.Context, path string, body interface{}, result interface{}) error {
+	request := c.client.R().SetContext(ctx).SetHeader("Content-Type", contentType).SetBody(body)
+	if result != nil {
+		request.SetResult(result)
+	}
+
+	response, err := request.Post(path)
+	if err != nil {
+		return err
+	}
+
+	return responseError(response)
+}
+
+func responseError(response *resty.Response) error {
+	if !response.IsError() {
+		return nil
+	}
+
+	message := response.String()
+	errorResponse := &errorResponse{}
+	if err := json.Unmarshal(response.Body(), errorResponse); err == nil && errorResponse.Message != "" {
+		message = errorResponse.Message
+	}
+
+	switch response.StatusCode() {
+	case http.StatusConflict:
+		return errors.WithMessage(ErrIncompatibleSchema, message)
+	case http.StatusNotFound:
+		return errors.WithMessage(ErrSchemaNotFound, message)
+	default:
+		return errors.Errorf("schema registry responded with status `%d`: %s", response.StatusCode(), message)
+	}
+}
@@ -0,0 +1,108 @@
+package schemaregistry
+
+import (
+	"context"
+	"sync"
+
+	"emperror.dev/errors"
+	"github.com/hamba/avro/v2"
+)
+
+//nolint:gochecknoglobals
+var sharedInMemoryRegistry = NewInMemorySchemaRegistry()
+
+type inMemorySchemaRegistry struct {
+	mu sync.RWMutex
+	// schemas are the schemas by their ids, the id of a schema is its index plus one
+	schemas []*Schema
+	// subjects are the ids of the versions of the subjects
+	subjects map[string][]int
+}
+
+// NewInMemorySchemaRegistry returns a registry which keeps the schemas in memory, e.g. for the tests. the new versions
+// of the avro schemas are validated with the backward compatibility of the confluent registry, so a consumer with
+// the new version of a schema reads the messages of the former version.
+func NewInMemorySchemaRegistry() SchemaRegistry {
+	return &inMemorySchemaRegistry{subjects: make(map[string][]int)}
+}
+
+func (r *inMemorySchemaRegistry) Register(
+	_ context.Context,
+	subject string,
+	schema *Schema,
+) (*RegisteredSchema, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	versions := r.subjects[subject]
+	for i, id := range versions {
+		if registrationKey(subject, r.schemas[id-1]) == registrationKey(subject, schema) {
+			return &RegisteredSchema{Id: id, Version: i + 1}, nil
+		}
+	}
+
+	if len(versions) > 0 {
+		if err := checkCompatibility(schema, r.schemas[versions[len(versions)-1]-1]); err != nil {
+			return nil, errors.WrapIff(err, "error in registering the schema of the subject `%s`", subject)
+		}
+	}
+
+	// the same schema has the same id in all the subjects
+	id := 0
+	for i, registered := range r.schemas {
+		if registrationKey("", registered) == registrationKey("", schema) {
+			id = i + 1
+			break
+		}
+	}
+
+	if id == 0 {
+		r.schemas = append(r.schemas, schema)
+		id = len(r.schemas)
+	}
+
+	r.subjects[subject] = append(versions, id)
+
+	return &RegisteredSchema{Id: id, Version: len(r.subjects[subject])}, nil
+}
+
+func (r *inMemorySchemaRegistry) GetSchema(_ context.Context, id int) (*Schema, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if id <= 0 || id > len(r.schemas) {
+		return nil, errors.WithMessagef(ErrSchemaNotFound, "schema `%d` not found", id)
+	}
+
+	return r.schemas[id-1], nil
+}
+
+func checkCompatibility(schema *Schema, latest *Schema) error {
+	if schemaType(schema) != SchemaTypeAvro || schemaType(latest) != SchemaTypeAvro {
+		return nil
+	}
+
+	reader, err := avro.ParseWithCache(schema.Schema, "", &avro.SchemaCache{})
+	if err != nil {
+		return errors.WrapIf(err, "error in parsing the avro schema")
+	}
+
+	writer, err := avro.ParseWithCache(latest.Schema, "", &avro.SchemaCache{})
+	if err != nil {
+		return errors.WrapIf(err, "error in parsing the avro schema")
+	}
+
+	if err := avro.NewSchemaCompatibility().Compatible(reader, writer); err != nil {
+		return errors.WithMessage(ErrIncompatibleSchema, err.Error())
+	}
+
+	return nil
+}
+
+func schemaType(schema *Schema) string {
+	if schema.SchemaType == "" {
+		return SchemaTypeAvro
+	}
+
+	return schema.SchemaType
+}
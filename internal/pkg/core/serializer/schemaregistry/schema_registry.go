@@ -0,0 +1,62 @@
+package schemaregistry
+
+import (
+	"context"
+	"fmt"
+
+	"emperror.dev/errors"
+)
+
+const (
+	SchemaTypeAvro     = "AVRO"
+	SchemaTypeProtobuf = "PROTOBUF"
+)
+
+// ErrIncompatibleSchema is returned when a new version of the schema of a subject is not compatible with its former
+// versions by the compatibility level of the subject
+var ErrIncompatibleSchema = errors.New("schema is not compatible with the former versions of the subject")
+
+// ErrSchemaNotFound is returned for an unknown schema id
+var ErrSchemaNotFound = errors.New("schema not found")
+
+// Schema is a schema of a subject of the registry
+type Schema struct {
+	Schema string `json:"schema"`
+	// SchemaType is the type of the schema, the registry treats the schemas without a type as the avro schemas
+	SchemaType string `json:"schemaType,omitempty"`
+	// References are the schemas which are imported by the schema, like the imported files of a protobuf schema
+	References []*Reference `json:"references,omitempty"`
+}
+
+// Reference is a reference of a schema to the version of another subject
+type Reference struct {
+	Name    string `json:"name"`
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+// SchemaRegistry registers the schemas of the published messages and resolves the schemas of the consumed messages by
+// their ids, the ids are sent with the messages in the confluent wire format
+type SchemaRegistry interface {
+	// Register registers the schema under the subject and returns its id, the registry validates the schema with the
+	// former versions of the subject, so an incompatible schema fails the publish of its message. the registered
+	// schemas are cached, so the registry is called once for each schema.
+	Register(ctx context.Context, subject string, schema *Schema) (*RegisteredSchema, error)
+	// GetSchema returns the schema of the id, the schemas are cached
+	GetSchema(ctx context.Context, id int) (*Schema, error)
+}
+
+// RegisteredSchema is the id and the version of a schema in its subject
+type RegisteredSchema struct {
+	Id      int
+	Version int
+}
+
+func registrationKey(subject string, schema *Schema) string {
+	key := subject + "\x00" + schemaType(schema) + "\x00" + schema.Schema
+	for _, reference := range schema.References {
+		key += fmt.Sprintf("\x00%s:%s:%d", reference.Name, reference.Subject, reference.Version)
+	}
+
+	return key
+}
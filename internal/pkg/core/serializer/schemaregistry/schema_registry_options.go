@@ -0,0 +1,32 @@
+package schemaregistry
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[SchemaRegistryOptions]())
+
+type SchemaRegistryOptions struct {
+	// Enabled registers the schemas of the avro and the protobuf messages on publish and resolves them on consume, the
+	// messages are written in the confluent wire format with the id of their schema
+	Enabled bool `mapstructure:"enabled"`
+	// Url is the url of the confluent schema registry or a registry with a confluent compatible api, like the
+	// `/apis/ccompat/v7` api of apicurio
+	Url      string `mapstructure:"url"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password" secret:"true"`
+	// Timeout is the timeout of the calls to the registry
+	Timeout time.Duration `mapstructure:"timeout" default:"10s"`
+	// UseInMemory replaces the registry with a process-wide in-memory registry, e.g. for the tests
+	UseInMemory bool `mapstructure:"useInMemory"`
+}
+
+func ProvideConfig(environment environment.Environment) (*SchemaRegistryOptions, error) {
+	return config.BindConfigKey[*SchemaRegistryOptions](optionName, environment)
+}
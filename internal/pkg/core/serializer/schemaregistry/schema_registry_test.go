@@ -0,0 +1,135 @@
+//go:build unit
+// +build unit
+
+package schemaregistry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const productV1Schema = `{"type": "record", "name": "product", "fields": [{"name": "name", "type": "string"}]}`
+
+const productV2Schema = `{
+	"type": "record",
+	"name": "product",
+	"fields": [{"name": "name", "type": "string"}, {"name": "currency", "type": "string", "default": "USD"}]
+}`
+
+const incompatibleProductSchema = `{
+	"type": "record",
+	"name": "product",
+	"fields": [{"name": "name", "type": "string"}, {"name": "currency", "type": "string"}]
+}`
+
+func Test_In_Memory_Registry_Should_Register_Compatible_Versions_Of_Subject(t *testing.T) {
+	registry := NewInMemorySchemaRegistry()
+	ctx := context.Background()
+
+	v1, err := registry.Register(ctx, "product", &Schema{Schema: productV1Schema, SchemaType: SchemaTypeAvro})
+	require.NoError(t, err)
+	assert.Equal(t, 1, v1.Version)
+
+	v2, err := registry.Register(ctx, "product", &Schema{Schema: productV2Schema, SchemaType: SchemaTypeAvro})
+	require.NoError(t, err)
+	assert.Equal(t, 2, v2.Version)
+	assert.NotEqual(t, v1.Id, v2.Id)
+
+	again, err := registry.Register(ctx, "product", &Schema{Schema: productV1Schema, SchemaType: SchemaTypeAvro})
+	require.NoError(t, err)
+	assert.Equal(t, v1, again)
+
+	schema, err := registry.GetSchema(ctx, v2.Id)
+	require.NoError(t, err)
+	assert.Equal(t, productV2Schema, schema.Schema)
+}
+
+func Test_In_Memory_Registry_Should_Reject_Incompatible_Versions_Of_Subject(t *testing.T) {
+	registry := NewInMemorySchemaRegistry()
+	ctx := context.Background()
+
+	_, err := registry.Register(ctx, "product", &Schema{Schema: productV1Schema, SchemaType: SchemaTypeAvro})
+	require.NoError(t, err)
+
+	// a new field without a default can't be read from the messages of the former version
+	_, err = registry.Register(ctx, "product", &Schema{Schema: incompatibleProductSchema, SchemaType: SchemaTypeAvro})
+	assert.ErrorIs(t, err, ErrIncompatibleSchema)
+
+	_, err = registry.GetSchema(ctx, 100)
+	assert.ErrorIs(t, err, ErrSchemaNotFound)
+}
+
+func Test_Wire_Format(t *testing.T) {
+	data := EncodeHeader(42, append(EncodeMessageIndexes([]int{1, 0}), []byte("payload")...))
+
+	id, payload, err := DecodeHeader(data)
+	require.NoError(t, err)
+	assert.Equal(t, 42, id)
+
+	indexes, payload, err := DecodeMessageIndexes(payload)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 0}, indexes)
+	assert.Equal(t, "payload", string(payload))
+
+	indexes, _, err = DecodeMessageIndexes(EncodeMessageIndexes([]int{0}))
+	require.NoError(t, err)
+	assert.Equal(t, []int{0}, indexes)
+
+	_, _, err = DecodeHeader([]byte("payload"))
+	assert.Error(t, err)
+}
+
+func Test_Confluent_Registry_Should_Cache_Registered_Schemas(t *testing.T) {
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", contentType)
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/subjects/product/versions":
+			_ = json.NewEncoder(w).Encode(map[string]int{"id": 7})
+		case r.Method == http.MethodPost && r.URL.Path == "/subjects/product":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 7, "version": 3})
+		case r.Method == http.MethodGet && r.URL.Path == "/schemas/ids/8":
+			_ = json.NewEncoder(w).Encode(&Schema{Schema: productV2Schema})
+		case r.Method == http.MethodPost && r.URL.Path == "/subjects/order/versions":
+			w.WriteHeader(http.StatusConflict)
+			_ = json.NewEncoder(w).Encode(&errorResponse{ErrorCode: 409, Message: "incompatible schema"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(&errorResponse{ErrorCode: 40403, Message: "schema not found"})
+		}
+	}))
+	defer server.Close()
+
+	registry := NewConfluentSchemaRegistry(&SchemaRegistryOptions{Url: server.URL, Timeout: 5e9})
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		registered, err := registry.Register(ctx, "product", &Schema{Schema: productV1Schema})
+		require.NoError(t, err)
+		assert.Equal(t, &RegisteredSchema{Id: 7, Version: 3}, registered)
+	}
+
+	for i := 0; i < 3; i++ {
+		schema, err := registry.GetSchema(ctx, 8)
+		require.NoError(t, err)
+		assert.Equal(t, productV2Schema, schema.Schema)
+	}
+
+	assert.Equal(t, int32(3), calls.Load())
+
+	_, err := registry.Register(ctx, "order", &Schema{Schema: productV1Schema})
+	assert.ErrorIs(t, err, ErrIncompatibleSchema)
+
+	_, err = registry.GetSchema(ctx, 9)
+	assert.ErrorIs(t, err, ErrSchemaNotFound)
+}
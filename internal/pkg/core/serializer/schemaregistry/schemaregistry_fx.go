@@ -0,0 +1,28 @@
+package schemaregistry
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provided to fxlog
+// https://uber-go.github.io/fx/modules.html
+var Module = fx.Module( //nolint:gochecknoglobals
+	"schemaregistryfx",
+	fx.Provide(
+		ProvideConfig,
+		NewSchemaRegistry,
+	),
+)
+
+// NewSchemaRegistry returns the registry of the options, it is nil when the registry is not enabled, so the avro and
+// the protobuf messages are written without a registry
+func NewSchemaRegistry(options *SchemaRegistryOptions) SchemaRegistry {
+	switch {
+	case !options.Enabled:
+		return nil
+	case options.UseInMemory:
+		return sharedInMemoryRegistry
+	default:
+		return NewConfluentSchemaRegistry(options)
+	}
+}
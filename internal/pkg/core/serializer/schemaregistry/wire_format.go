@@ -0,0 +1,72 @@
+package schemaregistry
+
+import (
+	"encoding/binary"
+
+	"emperror.dev/errors"
+)
+
+// https://docs.confluent.io/platform/current/schema-registry/fundamentals/serdes-develop/index.html#wire-format
+const (
+	magicByte    = 0x0
+	headerLength = 5
+)
+
+// EncodeHeader writes the confluent wire format header of the schema id before the payload
+func EncodeHeader(schemaId int, payload []byte) []byte {
+	data := make([]byte, headerLength, headerLength+len(payload))
+	data[0] = magicByte
+	binary.BigEndian.PutUint32(data[1:headerLength], uint32(schemaId))
+
+	return append(data, payload...)
+}
+
+// DecodeHeader reads the schema id of the confluent wire format header and returns the payload after the header
+func DecodeHeader(data []byte) (int, []byte, error) {
+	if len(data) < headerLength || data[0] != magicByte {
+		return 0, nil, errors.New("the data is not in the confluent wire format")
+	}
+
+	return int(binary.BigEndian.Uint32(data[1:headerLength])), data[headerLength:], nil
+}
+
+// EncodeMessageIndexes writes the indexes of a protobuf message in its schema file, e.g. `[1, 0]` for the first nested
+// message of the second message of the file, the first message of the file is written with a single `0`
+func EncodeMessageIndexes(indexes []int) []byte {
+	if len(indexes) == 1 && indexes[0] == 0 {
+		return []byte{0}
+	}
+
+	data := binary.AppendVarint(nil, int64(len(indexes)))
+	for _, index := range indexes {
+		data = binary.AppendVarint(data, int64(index))
+	}
+
+	return data
+}
+
+// DecodeMessageIndexes reads the indexes of a protobuf message and returns the payload after them
+func DecodeMessageIndexes(data []byte) ([]int, []byte, error) {
+	count, n := binary.Varint(data)
+	if n <= 0 || count < 0 || count > int64(len(data)) {
+		return nil, nil, errors.New("invalid protobuf message indexes")
+	}
+	data = data[n:]
+
+	if count == 0 {
+		return []int{0}, data, nil
+	}
+
+	indexes := make([]int, count)
+	for i := range indexes {
+		index, n := binary.Varint(data)
+		if n <= 0 {
+			return nil, nil, errors.New("invalid protobuf message indexes")
+		}
+
+		indexes[i] = int(index)
+		data = data[n:]
+	}
+
+	return indexes, data, nil
+}
@@ -0,0 +1,73 @@
+package tenant
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// DefaultMaxMetricTenants is the default number of distinct tenants which are recorded on the metrics
+	DefaultMaxMetricTenants = 100
+	// OverflowTenantId is recorded on the metrics instead of the tenants over the max metric tenants
+	OverflowTenantId = "other"
+)
+
+// cardinalityGuard limits the distinct values of the tenant attribute, every distinct value creates new time series
+// for all the metrics, so the tenants after the limit are recorded as `other`.
+type cardinalityGuard struct {
+	mu         sync.RWMutex
+	maxTenants int
+	tenants    map[string]struct{}
+}
+
+var defaultGuard = newCardinalityGuard(DefaultMaxMetricTenants)
+
+func newCardinalityGuard(maxTenants int) *cardinalityGuard {
+	return &cardinalityGuard{maxTenants: maxTenants, tenants: map[string]struct{}{}}
+}
+
+// SetMaxMetricTenants changes the number of distinct tenants which are recorded on the metrics, it should be called
+// on startup.
+func SetMaxMetricTenants(maxTenants int) {
+	defaultGuard.mu.Lock()
+	defer defaultGuard.mu.Unlock()
+
+	defaultGuard.maxTenants = maxTenants
+}
+
+// MetricAttributes returns the guarded tenant attribute of the metrics, it is empty when there is no resolved tenant
+func MetricAttributes(ctx context.Context) []attribute.KeyValue {
+	tenantId, ok := GetTenantId(ctx)
+	if !ok {
+		return nil
+	}
+
+	return []attribute.KeyValue{TenantIdKey.String(defaultGuard.guard(tenantId))}
+}
+
+func (g *cardinalityGuard) guard(tenantId string) string {
+	g.mu.RLock()
+	_, exists := g.tenants[tenantId]
+	g.mu.RUnlock()
+
+	if exists {
+		return tenantId
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, exists := g.tenants[tenantId]; exists {
+		return tenantId
+	}
+
+	if len(g.tenants) >= g.maxTenants {
+		return OverflowTenantId
+	}
+
+	g.tenants[tenantId] = struct{}{}
+
+	return tenantId
+}
@@ -0,0 +1,75 @@
+package tenant
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// TenantIdKey is the attribute key of the tenant on the spans, the metrics and the log fields
+	TenantIdKey = attribute.Key("tenant.id")
+	// TenantIdHeader is the message header which carries the tenant between the services
+	TenantIdHeader = "tenant-id"
+)
+
+type tenantContextKey struct{}
+
+// holder is shared by the request context and its children, so the instrumentations which start before the tenant
+// is resolved (like the http and grpc middlewares) see the tenant after the request is handled.
+type holder struct {
+	mu       sync.RWMutex
+	tenantId string
+}
+
+// NewContext adds a tenant holder to the context if there isn't one, the instrumentations call it on the start of a
+// request so a tenant resolved later in the request is visible to them.
+func NewContext(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(tenantContextKey{}).(*holder); ok {
+		return ctx
+	}
+
+	return context.WithValue(ctx, tenantContextKey{}, &holder{})
+}
+
+// SetTenantId is called by the tenant resolver, it stores the tenant on the request context and attaches it to the
+// current span.
+func SetTenantId(ctx context.Context, tenantId string) context.Context {
+	ctx = NewContext(ctx)
+
+	h := ctx.Value(tenantContextKey{}).(*holder)
+	h.mu.Lock()
+	h.tenantId = tenantId
+	h.mu.Unlock()
+
+	if tenantId != "" {
+		trace.SpanFromContext(ctx).SetAttributes(TenantIdKey.String(tenantId))
+	}
+
+	return ctx
+}
+
+// GetTenantId returns the resolved tenant of the context
+func GetTenantId(ctx context.Context) (string, bool) {
+	h, ok := ctx.Value(tenantContextKey{}).(*holder)
+	if !ok {
+		return "", false
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.tenantId, h.tenantId != ""
+}
+
+// SpanAttributes returns the tenant attribute of the spans, it is empty when there is no resolved tenant
+func SpanAttributes(ctx context.Context) []attribute.KeyValue {
+	tenantId, ok := GetTenantId(ctx)
+	if !ok {
+		return nil
+	}
+
+	return []attribute.KeyValue{TenantIdKey.String(tenantId)}
+}
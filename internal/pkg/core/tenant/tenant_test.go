@@ -0,0 +1,59 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func Test_Tenant_Resolved_After_NewContext_Is_Visible_On_Parent(t *testing.T) {
+	ctx := NewContext(context.Background())
+
+	// the resolver works on a child context of the request context
+	childCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	SetTenantId(childCtx, "tenant-1")
+
+	tenantId, ok := GetTenantId(ctx)
+
+	assert.True(t, ok)
+	assert.Equal(t, "tenant-1", tenantId)
+	assert.Equal(t, ctx, NewContext(ctx))
+}
+
+func Test_GetTenantId_Without_Tenant(t *testing.T) {
+	_, ok := GetTenantId(context.Background())
+	assert.False(t, ok)
+
+	_, ok = GetTenantId(NewContext(context.Background()))
+	assert.False(t, ok)
+
+	assert.Empty(t, SpanAttributes(context.Background()))
+	assert.Empty(t, MetricAttributes(context.Background()))
+}
+
+func Test_SetTenantId_Adds_Tenant_To_Current_Span(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := trace.NewTracerProvider(trace.WithSpanProcessor(recorder))
+
+	ctx, span := provider.Tracer("test").Start(context.Background(), "test")
+	ctx = SetTenantId(ctx, "tenant-1")
+	span.End()
+
+	tenantId, ok := GetTenantId(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "tenant-1", tenantId)
+	assert.Contains(t, recorder.Ended()[0].Attributes(), TenantIdKey.String("tenant-1"))
+}
+
+func Test_Cardinality_Guard_Overflows_After_Max_Tenants(t *testing.T) {
+	guard := newCardinalityGuard(2)
+
+	assert.Equal(t, "tenant-1", guard.guard("tenant-1"))
+	assert.Equal(t, "tenant-2", guard.guard("tenant-2"))
+	assert.Equal(t, OverflowTenantId, guard.guard("tenant-3"))
+	assert.Equal(t, "tenant-1", guard.guard("tenant-1"))
+}
@@ -0,0 +1,31 @@
+package deprecation
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/pipeline"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/utils"
+)
+
+type consumerPipeline struct {
+	registry Registry
+}
+
+// NewConsumerPipeline records the usages of the deprecated message types, so the producers which still publish them
+// are found before their sunset, the messages are handled as usual.
+func NewConsumerPipeline(registry Registry) pipeline.ConsumerPipeline {
+	return &consumerPipeline{registry: registry}
+}
+
+func (c *consumerPipeline) Handle(
+	ctx context.Context,
+	consumerContext types.MessageConsumeContext,
+	next pipeline.ConsumerHandlerFunc,
+) error {
+	if deprecation, ok := c.registry.Message(utils.GetMessageName(consumerContext.Message())); ok {
+		c.registry.Used(ctx, deprecation.Contract)
+	}
+
+	return next(ctx)
+}
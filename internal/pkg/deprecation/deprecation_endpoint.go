@@ -0,0 +1,47 @@
+package deprecation
+
+import (
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/contracts"
+	apikey "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/api_key"
+
+	"github.com/labstack/echo/v4"
+)
+
+type DeprecationEndpoint struct {
+	registry   Registry
+	options    *DeprecationOptions
+	echoServer contracts.EchoHttpServer
+}
+
+func NewDeprecationEndpoint(
+	registry Registry,
+	options *DeprecationOptions,
+	server contracts.EchoHttpServer,
+) *DeprecationEndpoint {
+	return &DeprecationEndpoint{registry: registry, options: options, echoServer: server}
+}
+
+// RegisterEndpoints registers the `deprecations` admin endpoint, it is authenticated with the api keys of the admin
+// users and is not registered without any user
+func (e *DeprecationEndpoint) RegisterEndpoints() {
+	var keys []apikey.Option
+	for _, user := range e.options.AdminUsers {
+		if user != nil {
+			keys = append(keys, apikey.WithKey(user.ApiKey, user.UserId))
+		}
+	}
+
+	if len(keys) == 0 {
+		return
+	}
+
+	e.echoServer.GetEchoInstance().GET("deprecations", e.getDeprecations, apikey.ApiKey(keys...))
+}
+
+// getDeprecations returns the deprecated contracts with their usages in the current instance, the usages of all the
+// instances are in the `deprecated_contract_usages_total` metric
+func (e *DeprecationEndpoint) getDeprecations(c echo.Context) error {
+	return c.JSON(http.StatusOK, e.registry.Deprecations())
+}
@@ -0,0 +1,51 @@
+package deprecation
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/metrics"
+
+	"go.uber.org/fx"
+)
+
+// Module provides the Registry of the deprecations and their admin endpoint, the deprecated routes are annotated by
+// the `deprecation` echo middleware and the deprecated messages are recorded by the consumer pipeline, the endpoint
+// needs an echo server
+// https://uber-go.github.io/fx/modules.html
+var Module = fx.Module( //nolint:gochecknoglobals
+	"deprecationfx",
+	fx.Provide(
+		ProvideConfig,
+		provideRegistry,
+		NewDeprecationEndpoint,
+	),
+	fx.Invoke(func(endpoint *DeprecationEndpoint) {
+		endpoint.RegisterEndpoints()
+	}),
+)
+
+// provideRegistry registers the deprecations of the config, an invalid deprecation fails the startup
+func provideRegistry(
+	options *DeprecationOptions,
+	log logger.Logger,
+	appMetrics metrics.AppMetrics,
+) (Registry, error) {
+	registry, err := NewRegistry(log, appMetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, deprecationOptions := range options.Deprecations {
+		if deprecationOptions == nil {
+			continue
+		}
+
+		deprecation, err := NewDeprecation(deprecationOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		registry.Deprecate(deprecation)
+	}
+
+	return registry, nil
+}
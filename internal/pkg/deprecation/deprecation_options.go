@@ -0,0 +1,43 @@
+package deprecation
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[DeprecationOptions]())
+
+// DeprecationOptions declares the deprecated contracts of a service, the contracts can be deprecated in the code with
+// the Registry too.
+type DeprecationOptions struct {
+	Deprecations []*DeprecatedContractOptions `mapstructure:"deprecations"`
+	// AdminUsers authenticate the admin endpoint of the deprecations with the api key of a user in the `X-Api-Key`
+	// header, the endpoint is not registered without any user
+	AdminUsers []*AdminUserOptions `mapstructure:"adminUsers"`
+}
+
+// DeprecatedContractOptions is a deprecated route or message type, only one of Endpoint and Message should be set
+type DeprecatedContractOptions struct {
+	// Endpoint is the method and the registered path of a route, like `get /api/v1/products/:id`
+	Endpoint string `mapstructure:"endpoint"`
+	// Message is the name of a message type, like `product_created_v1`
+	Message string `mapstructure:"message"`
+	// DeprecatedAt is the date of the deprecation, like `2025-01-31`
+	DeprecatedAt string `mapstructure:"deprecatedAt"`
+	// Sunset is the date that the contract is removed at, like `2025-06-30`, it is optional
+	Sunset string `mapstructure:"sunset"`
+	// Link is the documentation of the deprecation, like the migration guide to its replacement
+	Link string `mapstructure:"link"`
+}
+
+type AdminUserOptions struct {
+	UserId string `mapstructure:"userId"`
+	ApiKey string `mapstructure:"apiKey" secret:"true"`
+}
+
+func ProvideConfig(environment environment.Environment) (*DeprecationOptions, error) {
+	return config.BindConfigKey[*DeprecationOptions](optionName, environment)
+}
@@ -0,0 +1,231 @@
+package deprecation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+
+	"emperror.dev/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// the kinds of the deprecated contracts in the `kind` attribute of the usages metric
+const (
+	EndpointKind = "endpoint"
+	MessageKind  = "message"
+)
+
+const dateLayout = "2006-01-02"
+
+// Deprecation is a deprecated contract of the service with the usages of the contract in the current instance
+type Deprecation struct {
+	// Contract is the name of the contract, like `endpoint:get /api/v1/products/:id` or `message:product_created_v1`
+	Contract     string     `json:"contract"`
+	Kind         string     `json:"kind"`
+	DeprecatedAt time.Time  `json:"deprecatedAt"`
+	Sunset       *time.Time `json:"sunset,omitempty"`
+	Link         string     `json:"link,omitempty"`
+	Usages       int64      `json:"usages"`
+	LastUsedAt   *time.Time `json:"lastUsedAt,omitempty"`
+}
+
+// Registry keeps the deprecated routes and message types of the service, a used deprecated contract is logged once
+// and counted in the `deprecated_contract_usages_total` metric with the `contract` and the `kind` attributes
+type Registry interface {
+	// Deprecate registers a deprecated contract, a contract which is registered again is replaced
+	Deprecate(deprecation *Deprecation)
+	// Endpoint returns the deprecation of a route by its method and its registered path
+	Endpoint(method string, path string) (*Deprecation, bool)
+	// Message returns the deprecation of a message type by its name
+	Message(messageName string) (*Deprecation, bool)
+	// Used records a usage of a deprecated contract
+	Used(ctx context.Context, contract string)
+	// Deprecations returns the deprecated contracts ordered by their sunset, the contracts without a sunset are last
+	Deprecations() []*Deprecation
+}
+
+type registry struct {
+	mu           sync.RWMutex
+	deprecations map[string]*Deprecation
+	log          logger.Logger
+	usages       metric.Int64Counter
+}
+
+// NewRegistry creates a registry of the deprecations, the meter is optional
+func NewRegistry(log logger.Logger, meter metric.Meter) (Registry, error) {
+	r := &registry{deprecations: make(map[string]*Deprecation), log: log}
+
+	if meter != nil {
+		usages, err := meter.Int64Counter(
+			"deprecated_contract_usages_total",
+			metric.WithDescription("The total number of the usages of the deprecated routes and message types"),
+		)
+		if err != nil {
+			return nil, err
+		}
+		r.usages = usages
+	}
+
+	return r, nil
+}
+
+// EndpointContract is the contract name of a route, like `endpoint:get /api/v1/products/:id`
+func EndpointContract(method string, path string) string {
+	return fmt.Sprintf("%s:%s %s", EndpointKind, strings.ToLower(method), path)
+}
+
+// MessageContract is the contract name of a message type, like `message:product_created_v1`
+func MessageContract(messageName string) string {
+	return fmt.Sprintf("%s:%s", MessageKind, messageName)
+}
+
+func (r *registry) Deprecate(deprecation *Deprecation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deprecations[deprecation.Contract] = deprecation
+}
+
+func (r *registry) Endpoint(method string, path string) (*Deprecation, bool) {
+	return r.get(EndpointContract(method, path))
+}
+
+func (r *registry) Message(messageName string) (*Deprecation, bool) {
+	return r.get(MessageContract(messageName))
+}
+
+func (r *registry) Used(ctx context.Context, contract string) {
+	r.mu.Lock()
+	deprecation, ok := r.deprecations[contract]
+	if !ok {
+		r.mu.Unlock()
+
+		return
+	}
+
+	firstUsage := deprecation.Usages == 0
+	now := time.Now()
+	deprecation.Usages++
+	deprecation.LastUsedAt = &now
+	r.mu.Unlock()
+
+	// the usages are counted by the metric, so only the first usage of a contract in the instance is logged
+	if firstUsage {
+		r.log.Warnf("deprecated contract '%s' is used, its sunset is %s", contract, formatSunset(deprecation.Sunset))
+	}
+
+	if r.usages != nil {
+		r.usages.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("contract", contract),
+			attribute.String("kind", deprecation.Kind),
+		))
+	}
+}
+
+func (r *registry) Deprecations() []*Deprecation {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	deprecations := make([]*Deprecation, 0, len(r.deprecations))
+	for _, deprecation := range r.deprecations {
+		// a copy, so the usages are not changed while the list is serialized
+		deprecationCopy := *deprecation
+		deprecations = append(deprecations, &deprecationCopy)
+	}
+
+	sort.Slice(deprecations, func(i, j int) bool {
+		left, right := deprecations[i].Sunset, deprecations[j].Sunset
+		if left == nil || right == nil {
+			if left == nil && right == nil {
+				return deprecations[i].Contract < deprecations[j].Contract
+			}
+
+			return right == nil
+		}
+
+		if left.Equal(*right) {
+			return deprecations[i].Contract < deprecations[j].Contract
+		}
+
+		return left.Before(*right)
+	})
+
+	return deprecations
+}
+
+func (r *registry) get(contract string) (*Deprecation, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	deprecation, ok := r.deprecations[contract]
+
+	return deprecation, ok
+}
+
+// NewDeprecation creates the deprecation of a contract of the config
+func NewDeprecation(options *DeprecatedContractOptions) (*Deprecation, error) {
+	var deprecation *Deprecation
+
+	switch {
+	case options.Endpoint != "" && options.Message != "":
+		return nil, errors.Errorf(
+			"deprecation of endpoint '%s' and message '%s' should be separated",
+			options.Endpoint,
+			options.Message,
+		)
+	case options.Endpoint != "":
+		method, path, ok := strings.Cut(strings.TrimSpace(options.Endpoint), " ")
+		if !ok || strings.TrimSpace(path) == "" {
+			return nil, errors.Errorf(
+				"deprecated endpoint '%s' should be a method and a path, like `get /api/v1/products/:id`",
+				options.Endpoint,
+			)
+		}
+
+		deprecation = &Deprecation{Contract: EndpointContract(method, strings.TrimSpace(path)), Kind: EndpointKind}
+	case options.Message != "":
+		deprecation = &Deprecation{Contract: MessageContract(options.Message), Kind: MessageKind}
+	default:
+		return nil, errors.New("deprecation should have an endpoint or a message")
+	}
+
+	deprecatedAt, err := time.Parse(dateLayout, options.DeprecatedAt)
+	if err != nil {
+		return nil, errors.WrapIff(
+			err,
+			"deprecation date of '%s' should be a date like `2025-01-31`",
+			deprecation.Contract,
+		)
+	}
+	deprecation.DeprecatedAt = deprecatedAt
+
+	if options.Sunset != "" {
+		sunset, err := time.Parse(dateLayout, options.Sunset)
+		if err != nil {
+			return nil, errors.WrapIff(
+				err,
+				"sunset of '%s' should be a date like `2025-06-30`",
+				deprecation.Contract,
+			)
+		}
+		deprecation.Sunset = &sunset
+	}
+
+	deprecation.Link = options.Link
+
+	return deprecation, nil
+}
+
+func formatSunset(sunset *time.Time) string {
+	if sunset == nil {
+		return "not scheduled"
+	}
+
+	return sunset.Format(dateLayout)
+}
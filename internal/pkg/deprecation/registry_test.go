@@ -0,0 +1,97 @@
+package deprecation
+
+import (
+	"context"
+	"testing"
+
+	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRegistry(t *testing.T, options ...*DeprecatedContractOptions) Registry {
+	t.Helper()
+
+	registry, err := NewRegistry(defaultLogger.GetLogger(), nil)
+	require.NoError(t, err)
+
+	for _, option := range options {
+		deprecation, err := NewDeprecation(option)
+		require.NoError(t, err)
+
+		registry.Deprecate(deprecation)
+	}
+
+	return registry
+}
+
+func Test_Deprecation_Of_Config(t *testing.T) {
+	deprecation, err := NewDeprecation(&DeprecatedContractOptions{
+		Endpoint:     "GET /api/v1/products/:id",
+		DeprecatedAt: "2025-01-31",
+		Sunset:       "2025-06-30",
+		Link:         "https://docs.example.com/products-v2",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "endpoint:get /api/v1/products/:id", deprecation.Contract)
+	assert.Equal(t, EndpointKind, deprecation.Kind)
+	assert.Equal(t, "2025-01-31", deprecation.DeprecatedAt.Format(dateLayout))
+	assert.Equal(t, "2025-06-30", deprecation.Sunset.Format(dateLayout))
+	assert.Equal(t, "https://docs.example.com/products-v2", deprecation.Link)
+}
+
+func Test_Invalid_Deprecation_Of_Config(t *testing.T) {
+	testCases := map[string]*DeprecatedContractOptions{
+		"without contract":      {DeprecatedAt: "2025-01-31"},
+		"endpoint and message":  {Endpoint: "get /api/v1/orders", Message: "order_created_v1", DeprecatedAt: "2025-01-31"},
+		"endpoint without path": {Endpoint: "get", DeprecatedAt: "2025-01-31"},
+		"invalid date":          {Message: "order_created_v1", DeprecatedAt: "31/01/2025"},
+		"invalid sunset":        {Message: "order_created_v1", DeprecatedAt: "2025-01-31", Sunset: "soon"},
+	}
+
+	for name, options := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewDeprecation(options)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_Used_Deprecation(t *testing.T) {
+	registry := newTestRegistry(t, &DeprecatedContractOptions{Message: "order_created_v1", DeprecatedAt: "2025-01-31"})
+
+	deprecation, ok := registry.Message("order_created_v1")
+	require.True(t, ok)
+
+	registry.Used(context.Background(), deprecation.Contract)
+	registry.Used(context.Background(), deprecation.Contract)
+	registry.Used(context.Background(), MessageContract("order_created_v2"))
+
+	deprecations := registry.Deprecations()
+	require.Len(t, deprecations, 1)
+	assert.Equal(t, int64(2), deprecations[0].Usages)
+	assert.NotNil(t, deprecations[0].LastUsedAt)
+}
+
+func Test_Deprecations_Are_Ordered_By_Sunset(t *testing.T) {
+	registry := newTestRegistry(
+		t,
+		&DeprecatedContractOptions{Message: "order_created_v1", DeprecatedAt: "2025-01-31"},
+		&DeprecatedContractOptions{Endpoint: "get /api/v1/orders", DeprecatedAt: "2025-01-31", Sunset: "2025-09-30"},
+		&DeprecatedContractOptions{Endpoint: "get /api/v1/orders/:id", DeprecatedAt: "2025-01-31", Sunset: "2025-03-31"},
+	)
+
+	var contracts []string
+	for _, deprecation := range registry.Deprecations() {
+		contracts = append(contracts, deprecation.Contract)
+	}
+
+	assert.Equal(t, []string{
+		"endpoint:get /api/v1/orders/:id",
+		"endpoint:get /api/v1/orders",
+		"message:order_created_v1",
+	}, contracts)
+}
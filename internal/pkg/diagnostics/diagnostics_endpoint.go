@@ -0,0 +1,126 @@
+package diagnostics
+
+import (
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/contracts"
+	apikey "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/api_key"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ConfigDump is the resolved configuration of the app with its secrets redacted
+type ConfigDump struct {
+	Environment string `json:"environment"`
+	// ConfigDir is the directory of the config files of the app
+	ConfigDir string `json:"configDir"`
+	// Options are the bound options of the app by their config keys, after the defaults, the config files and the
+	// environment variables are applied
+	Options map[string]any `json:"options"`
+}
+
+// LogLevels are the log levels of the app, the categories without their own level use the default level
+type LogLevels struct {
+	DefaultLevel string            `json:"defaultLevel"`
+	Levels       map[string]string `json:"levels"`
+}
+
+// SetLogLevelRequest changes the level of a log category
+type SetLogLevelRequest struct {
+	Level string `json:"level"`
+}
+
+type DiagnosticsEndpoint struct {
+	options     *DiagnosticsOptions
+	environment environment.Environment
+	logLevels   *logger.LogLevels
+	echoServer  contracts.EchoHttpServer
+}
+
+func NewDiagnosticsEndpoint(
+	options *DiagnosticsOptions,
+	environment environment.Environment,
+	logLevels *logger.LogLevels,
+	server contracts.EchoHttpServer,
+) *DiagnosticsEndpoint {
+	return &DiagnosticsEndpoint{
+		options:     options,
+		environment: environment,
+		logLevels:   logLevels,
+		echoServer:  server,
+	}
+}
+
+// RegisterEndpoints registers the `diagnostics` admin endpoints, they are authenticated with the api keys of the admin
+// users and are not registered without any user
+func (e *DiagnosticsEndpoint) RegisterEndpoints() error {
+	var keys []apikey.Option
+	for _, user := range e.options.AdminUsers {
+		if user != nil {
+			keys = append(keys, apikey.WithKey(user.ApiKey, user.UserId))
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	// the config directory is resolved while the app is composed, the development host changes it for each app
+	configDir, err := config.ConfigDir(e.environment)
+	if err != nil {
+		return err
+	}
+
+	group := e.echoServer.GetEchoInstance().Group("diagnostics", apikey.ApiKey(keys...))
+	group.GET("/config", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, NewConfigDump(e.environment, configDir))
+	})
+	group.GET("/log-levels", e.getLogLevels)
+	group.PUT("/log-levels/:category", e.setLogLevel)
+	group.DELETE("/log-levels/:category", e.resetLogLevel)
+
+	return nil
+}
+
+func (e *DiagnosticsEndpoint) getLogLevels(c echo.Context) error {
+	return c.JSON(http.StatusOK, e.currentLogLevels())
+}
+
+// setLogLevel changes the level of a log category at runtime, e.g. `grpcPayloads`, the change is not persisted and is
+// lost on a restart
+func (e *DiagnosticsEndpoint) setLogLevel(c echo.Context) error {
+	request := &SetLogLevelRequest{}
+	if err := c.Bind(request); err != nil {
+		return customErrors.NewBadRequestErrorWrap(err, "error in binding the log level request")
+	}
+
+	if err := e.logLevels.SetLevel(c.Param("category"), request.Level); err != nil {
+		return customErrors.NewBadRequestErrorWrap(err, "error in setting the log level")
+	}
+
+	return c.JSON(http.StatusOK, e.currentLogLevels())
+}
+
+func (e *DiagnosticsEndpoint) resetLogLevel(c echo.Context) error {
+	e.logLevels.ResetLevel(c.Param("category"))
+
+	return c.JSON(http.StatusOK, e.currentLogLevels())
+}
+
+func (e *DiagnosticsEndpoint) currentLogLevels() *LogLevels {
+	return &LogLevels{DefaultLevel: e.logLevels.DefaultLevel(), Levels: e.logLevels.Levels()}
+}
+
+// NewConfigDump returns the bound options of the config directory with their secrets redacted
+func NewConfigDump(environment environment.Environment, configDir string) *ConfigDump {
+	options := make(map[string]any)
+	for key, value := range config.BoundOptions(configDir) {
+		options[key] = config.Redact(value)
+	}
+
+	return &ConfigDump{Environment: environment.GetEnvironmentName(), ConfigDir: configDir, Options: options}
+}
@@ -0,0 +1,18 @@
+package diagnostics
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provides the diagnostics admin endpoints of the config and the log levels, they need an echo server
+// https://uber-go.github.io/fx/modules.html
+var Module = fx.Module( //nolint:gochecknoglobals
+	"diagnosticsfx",
+	fx.Provide(
+		ProvideConfig,
+		NewDiagnosticsEndpoint,
+	),
+	fx.Invoke(func(endpoint *DiagnosticsEndpoint) error {
+		return endpoint.RegisterEndpoints()
+	}),
+)
@@ -0,0 +1,27 @@
+package diagnostics
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[DiagnosticsOptions]())
+
+// DiagnosticsOptions controls the diagnostics admin endpoints.
+type DiagnosticsOptions struct {
+	// AdminUsers authenticate the admin endpoints with the api key of a user in the `X-Api-Key` header, the
+	// endpoints are not registered without any user
+	AdminUsers []*AdminUserOptions `mapstructure:"adminUsers"`
+}
+
+type AdminUserOptions struct {
+	UserId string `mapstructure:"userId"`
+	ApiKey string `mapstructure:"apiKey" secret:"true"`
+}
+
+func ProvideConfig(environment environment.Environment) (*DiagnosticsOptions, error) {
+	return config.BindConfigKey[*DiagnosticsOptions](optionName, environment)
+}
@@ -0,0 +1,16 @@
+package projection
+
+import (
+	"context"
+)
+
+// IRebuildableProjection is a projection which its read model can be dropped and rebuilt by replaying the events from
+// the start of the event store
+type IRebuildableProjection interface {
+	IProjection
+	// DropReadModel deletes the data of the read model before the replay
+	DropReadModel(ctx context.Context) error
+	// ReplayProjection returns the projection of the replayed events, it projects to the same read model without the
+	// side effects of the live projection, e.g. publishing the integration events again
+	ReplayProjection() IProjection
+}
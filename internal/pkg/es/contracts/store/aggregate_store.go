@@ -34,6 +34,15 @@ type AggregateStore[T models.IHaveEventSourcedAggregate] interface {
 		position readPosition.StreamReadPosition,
 	) (T, error)
 
+	// Update loads the aggregate, runs the update on it and stores it. A concurrent append to the stream is resolved by
+	// the conflict strategy of the aggregate type, the errors of the update are returned as is.
+	Update(
+		ctx context.Context,
+		aggregateId uuid.UUID,
+		metadata metadata.Metadata,
+		update func(aggregate T) error,
+	) (T, *appendResult.AppendEventsResult, error)
+
 	// Exists check aggregate exists by AggregateId.
 	Exists(ctx context.Context, aggregateId uuid.UUID) (bool, error)
 }
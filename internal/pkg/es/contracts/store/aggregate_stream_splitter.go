@@ -0,0 +1,32 @@
+package store
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models"
+	streamSegment "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_segment"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// AggregateStreamSplitter is responsible for splitting the oversized streams of the long-lived aggregates into archived
+// segments, the aggregate should implement `models.IHaveContinuationSnapshot`.
+type AggregateStreamSplitter[T models.IHaveEventSourcedAggregate] interface {
+	// Split copies the events of the current segment of the aggregate stream to a new segment stream, appends the
+	// continuation snapshot of the aggregate to the stream and truncates the stream before the snapshot. The stream is
+	// split only when its current segment has at least minEvents events, zero always splits it.
+	Split(
+		ctx context.Context,
+		aggregateId uuid.UUID,
+		minEvents int64,
+		metadata metadata.Metadata,
+	) (*streamSegment.SplitStreamResult, error)
+
+	// Segments returns the archived segments of the aggregate stream, the oldest first.
+	Segments(ctx context.Context, aggregateId uuid.UUID) ([]*streamSegment.StreamSegment, error)
+
+	// ReadAllEvents reads the events of the archived segments and the aggregate stream in the stored order, the events
+	// of the segments keep their versions in the aggregate stream.
+	ReadAllEvents(ctx context.Context, aggregateId uuid.UUID) ([]*models.StreamEvent, error)
+}
@@ -0,0 +1,18 @@
+package store
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/snapshot"
+	streamName "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_name"
+)
+
+// SnapshotStore keeps the latest snapshot of the aggregate streams, so the aggregates with long streams are loaded from
+// their snapshot and the events after it instead of replaying their whole stream.
+type SnapshotStore interface {
+	// Load returns the latest snapshot of the aggregate stream, it returns nil when the stream doesn't have a snapshot
+	Load(ctx context.Context, stream streamName.StreamName) (*snapshot.Snapshot, error)
+
+	// Save stores the snapshot as the latest snapshot of its aggregate stream
+	Save(ctx context.Context, snapshot *snapshot.Snapshot) error
+}
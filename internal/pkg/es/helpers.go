@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/projection"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/upcasting"
 
 	"go.uber.org/fx"
 )
@@ -15,3 +16,12 @@ func AsProjection(handler interface{}) interface{} {
 		fx.ResultTags(fmt.Sprintf(`group:"projections"`)),
 	)
 }
+
+// AsUpcaster provides an upcaster of the stored events to the `esdbUpcasters` group of the upcaster registry
+func AsUpcaster(handler interface{}) interface{} {
+	return fx.Annotate(
+		handler,
+		fx.As(new(upcasting.Upcaster)),
+		fx.ResultTags(`group:"esdbUpcasters"`),
+	)
+}
@@ -326,6 +326,76 @@ func (_c *AggregateStore_StoreWithVersion_Call[T]) RunAndReturn(run func(T, meta
 	return _c
 }
 
+// Update provides a mock function with given fields: ctx, aggregateId, _a2, update
+func (_m *AggregateStore[T]) Update(ctx context.Context, aggregateId uuid.UUID, _a2 metadata.Metadata, update func(T) error) (T, *appendResult.AppendEventsResult, error) {
+	ret := _m.Called(ctx, aggregateId, _a2, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 T
+	var r1 *appendResult.AppendEventsResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, metadata.Metadata, func(T) error) (T, *appendResult.AppendEventsResult, error)); ok {
+		return rf(ctx, aggregateId, _a2, update)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, metadata.Metadata, func(T) error) T); ok {
+		r0 = rf(ctx, aggregateId, _a2, update)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(T)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, metadata.Metadata, func(T) error) *appendResult.AppendEventsResult); ok {
+		r1 = rf(ctx, aggregateId, _a2, update)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*appendResult.AppendEventsResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, metadata.Metadata, func(T) error) error); ok {
+		r2 = rf(ctx, aggregateId, _a2, update)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// AggregateStore_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type AggregateStore_Update_Call[T models.IHaveEventSourcedAggregate] struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - aggregateId uuid.UUID
+//   - _a2 metadata.Metadata
+//   - update func(T) error
+func (_e *AggregateStore_Expecter[T]) Update(ctx interface{}, aggregateId interface{}, _a2 interface{}, update interface{}) *AggregateStore_Update_Call[T] {
+	return &AggregateStore_Update_Call[T]{Call: _e.mock.On("Update", ctx, aggregateId, _a2, update)}
+}
+
+func (_c *AggregateStore_Update_Call[T]) Run(run func(ctx context.Context, aggregateId uuid.UUID, _a2 metadata.Metadata, update func(T) error)) *AggregateStore_Update_Call[T] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(metadata.Metadata), args[3].(func(T) error))
+	})
+	return _c
+}
+
+func (_c *AggregateStore_Update_Call[T]) Return(_a0 T, _a1 *appendResult.AppendEventsResult, _a2 error) *AggregateStore_Update_Call[T] {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *AggregateStore_Update_Call[T]) RunAndReturn(run func(context.Context, uuid.UUID, metadata.Metadata, func(T) error) (T, *appendResult.AppendEventsResult, error)) *AggregateStore_Update_Call[T] {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewAggregateStore creates a new instance of AggregateStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAggregateStore[T models.IHaveEventSourcedAggregate](t interface {
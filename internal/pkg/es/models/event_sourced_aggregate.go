@@ -47,6 +47,20 @@ type IHaveEventSourcedAggregate interface {
 	IEventSourcedAggregateRoot
 }
 
+// IHaveContinuationSnapshot this interface should implement by the aggregates with long-lived streams, the continuation
+// snapshot is appended to the stream when it is split and the earlier events are truncated, so it should restore the
+// whole state of the aggregate in its `When`
+type IHaveContinuationSnapshot interface {
+	ContinuationSnapshot() (domain.IDomainEvent, error)
+}
+
+// IHaveCommutativeEvents this interface should implement by the aggregates which merge the concurrent updates, the
+// uncommitted events of an update are appended after the concurrent events when each pair of them commutes, i.e. they
+// result in the same state in any order and the concurrent events don't break the invariants of the update
+type IHaveCommutativeEvents interface {
+	Commutes(concurrent domain.IDomainEvent, uncommitted domain.IDomainEvent) bool
+}
+
 // IEventSourcedAggregateRoot contains all methods of AggregateBase
 type IEventSourcedAggregateRoot interface {
 	domain.IEntity
@@ -215,7 +229,7 @@ func (a *EventSourcedAggregateRoot) fold(
 		)
 	}
 	a.originalVersion++
-	a.currentVersion++
+	a.currentVersion = a.originalVersion
 
 	return nil
 }
@@ -0,0 +1,45 @@
+package snapshot
+
+import (
+	"strconv"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/domain"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	streamName "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_name"
+)
+
+// the values of the snapshot metadata are stored as strings like the segment metadata
+const (
+	// SourceStreamMetadataKey is the aggregate stream that the snapshot is taken from
+	SourceStreamMetadataKey = "snapshot-source-stream"
+	// VersionMetadataKey is the version of the aggregate stream that the snapshot is taken at
+	VersionMetadataKey = "snapshot-version"
+)
+
+// Snapshot is the state of an aggregate at a version of its stream. The state is the continuation snapshot of the
+// aggregate, so the aggregate is loaded by folding the snapshot and the events of the stream after its version.
+type Snapshot struct {
+	StreamName streamName.StreamName
+	Version    int64
+	State      domain.IDomainEvent
+}
+
+func SetSource(meta metadata.Metadata, sourceStream streamName.StreamName, version int64) {
+	meta.Set(SourceStreamMetadataKey, sourceStream.String())
+	meta.Set(VersionMetadataKey, strconv.FormatInt(version, 10))
+}
+
+// GetSource returns the aggregate stream and the version of a snapshot, ok is false when the metadata is not of a
+// snapshot
+func GetSource(meta metadata.Metadata) (streamName.StreamName, int64, bool) {
+	if meta == nil || meta.GetString(SourceStreamMetadataKey) == "" || !meta.ExistsKey(VersionMetadataKey) {
+		return "", 0, false
+	}
+
+	version, err := strconv.ParseInt(meta.GetString(VersionMetadataKey), 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+
+	return streamName.StreamName(meta.GetString(SourceStreamMetadataKey)), version, true
+}
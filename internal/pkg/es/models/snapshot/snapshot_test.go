@@ -0,0 +1,43 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	streamName "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_name"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Snapshot_Metadata_Survives_Json_Round_Trip(t *testing.T) {
+	meta := metadata.Metadata{}
+	SetSource(meta, "order-1", 99)
+
+	data, err := json.Marshal(meta)
+	require.NoError(t, err)
+
+	result := metadata.Metadata{}
+	require.NoError(t, json.Unmarshal(data, &result))
+
+	stream, version, ok := GetSource(result)
+	assert.True(t, ok)
+	assert.Equal(t, streamName.StreamName("order-1"), stream)
+	assert.Equal(t, int64(99), version)
+}
+
+func Test_Events_Without_Snapshot_Metadata(t *testing.T) {
+	_, _, ok := GetSource(nil)
+	assert.False(t, ok)
+
+	_, _, ok = GetSource(metadata.Metadata{SourceStreamMetadataKey: "order-1"})
+	assert.False(t, ok)
+}
+
+func Test_Snapshot_Stream_Of_Aggregate_Stream(t *testing.T) {
+	stream := streamName.StreamName("order-1")
+
+	assert.Equal(t, "order", stream.Category())
+	assert.Equal(t, streamName.StreamName("order_snapshot-1"), stream.Snapshot())
+}
@@ -48,3 +48,29 @@ func ForID[T models.IHaveEventSourcedAggregate](aggregateID uuid.UUID) StreamNam
 
 	return StreamName(fmt.Sprintf("%s-%s", strings.ToLower(aggregateName), aggregateID.String()))
 }
+
+// Segment gets the stream name of an archived segment of the stream, the segment streams are in a separate category,
+// so they are not caught by the subscriptions to the aggregate streams
+func (n StreamName) Segment(number int) StreamName {
+	name := n.String()
+	index := strings.Index(name, "-")
+
+	return StreamName(fmt.Sprintf("%s_segment%s-%d", name[:index], name[index:], number))
+}
+
+// Snapshot gets the stream name of the snapshots of the stream, like the segments they are in a separate category
+func (n StreamName) Snapshot() StreamName {
+	name := n.String()
+
+	return StreamName(fmt.Sprintf("%s_snapshot%s", n.Category(), strings.TrimPrefix(name, n.Category())))
+}
+
+// Category gets the aggregate type of the stream, e.g. `order` for `order-{id}`
+func (n StreamName) Category() string {
+	name := n.String()
+	if index := strings.Index(name, "-"); index >= 0 {
+		return name[:index]
+	}
+
+	return name
+}
@@ -0,0 +1,81 @@
+package streamSegment
+
+import (
+	"strconv"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+)
+
+// the values of the segment metadata are stored as strings, the numbers of the json metadata are not kept as int64
+const (
+	// SourceStreamMetadataKey is the stream that the event of a segment is copied from
+	SourceStreamMetadataKey = "segment-source-stream"
+	// SourceVersionMetadataKey is the version of the event of a segment in its source stream
+	SourceVersionMetadataKey = "segment-source-version"
+	// PreviousSegmentMetadataKey is the segment stream before the continuation snapshot
+	PreviousSegmentMetadataKey = "segment-previous"
+	// SegmentNumberMetadataKey is the number of the segment before the continuation snapshot
+	SegmentNumberMetadataKey = "segment-number"
+)
+
+// StreamSegment is an archived part of a split stream, the events of the segment are copied from the stream
+// before it is truncated
+type StreamSegment struct {
+	Number       int
+	StreamName   string
+	FirstVersion int64
+	LastVersion  int64
+	EventsCount  int64
+}
+
+// SplitStreamResult is the result of splitting a stream, Split is false when the current segment of the stream is not
+// long enough to split
+type SplitStreamResult struct {
+	Split           bool
+	EventsCount     int64
+	Segment         *StreamSegment
+	SnapshotVersion int64
+}
+
+func SetSourceEvent(meta metadata.Metadata, sourceStream string, sourceVersion int64) {
+	meta.Set(SourceStreamMetadataKey, sourceStream)
+	meta.Set(SourceVersionMetadataKey, strconv.FormatInt(sourceVersion, 10))
+}
+
+// GetSourceVersion returns the version of the event in its source stream, ok is false for the events which are not
+// copied to a segment
+func GetSourceVersion(meta metadata.Metadata) (int64, bool) {
+	return getInt64(meta, SourceVersionMetadataKey)
+}
+
+func SetPreviousSegment(meta metadata.Metadata, previousSegment string, number int) {
+	meta.Set(PreviousSegmentMetadataKey, previousSegment)
+	meta.Set(SegmentNumberMetadataKey, strconv.Itoa(number))
+}
+
+// GetPreviousSegment returns the segment stream that the continuation snapshot is linked to
+func GetPreviousSegment(meta metadata.Metadata) (string, int, bool) {
+	if meta == nil || meta.GetString(PreviousSegmentMetadataKey) == "" {
+		return "", 0, false
+	}
+
+	number, ok := getInt64(meta, SegmentNumberMetadataKey)
+	if !ok {
+		return "", 0, false
+	}
+
+	return meta.GetString(PreviousSegmentMetadataKey), int(number), true
+}
+
+func getInt64(meta metadata.Metadata, key string) (int64, bool) {
+	if meta == nil || !meta.ExistsKey(key) {
+		return 0, false
+	}
+
+	value, err := strconv.ParseInt(meta.GetString(key), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return value, true
+}
@@ -0,0 +1,41 @@
+package streamSegment
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Segment_Metadata_Survives_Json_Round_Trip(t *testing.T) {
+	meta := metadata.Metadata{}
+	SetSourceEvent(meta, "order-1", 41)
+	SetPreviousSegment(meta, "order_segment-1-2", 2)
+
+	data, err := json.Marshal(meta)
+	require.NoError(t, err)
+
+	result := metadata.Metadata{}
+	require.NoError(t, json.Unmarshal(data, &result))
+
+	version, ok := GetSourceVersion(result)
+	assert.True(t, ok)
+	assert.Equal(t, int64(41), version)
+	assert.Equal(t, "order-1", result.GetString(SourceStreamMetadataKey))
+
+	previous, number, ok := GetPreviousSegment(result)
+	assert.True(t, ok)
+	assert.Equal(t, "order_segment-1-2", previous)
+	assert.Equal(t, 2, number)
+}
+
+func Test_Events_Without_Segment_Metadata(t *testing.T) {
+	_, ok := GetSourceVersion(nil)
+	assert.False(t, ok)
+
+	_, _, ok = GetPreviousSegment(metadata.Metadata{"user-id": "admin"})
+	assert.False(t, ok)
+}
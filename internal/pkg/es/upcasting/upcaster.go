@@ -0,0 +1,60 @@
+package upcasting
+
+import (
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+)
+
+// Upcaster transforms the json payload of a stored event from a schema version to the next schema version, so the
+// events which are stored with an old schema are deserialized to the current struct of the event
+type Upcaster interface {
+	// EventType is the stored type name of the event, like `*OrderCreatedV1`
+	EventType() string
+	// FromVersion is the schema version of the payloads which are transformed, the result has the next schema version
+	FromVersion() int
+	Upcast(payload map[string]interface{}) error
+}
+
+type upcaster struct {
+	eventType   string
+	fromVersion int
+	upcast      func(payload map[string]interface{}) error
+}
+
+// NewUpcaster creates the upcaster of the event T from the schema version `fromVersion` to the next schema version
+func NewUpcaster[T any](fromVersion int, upcast func(payload map[string]interface{}) error) Upcaster {
+	return &upcaster{
+		eventType:   typeMapper.GetGenericTypeNameByT[T](),
+		fromVersion: fromVersion,
+		upcast:      upcast,
+	}
+}
+
+// RenameField creates the upcaster of a renamed json field of the event T, the payloads which already have the new
+// field are not changed
+func RenameField[T any](fromVersion int, from string, to string) Upcaster {
+	return NewUpcaster[T](fromVersion, func(payload map[string]interface{}) error {
+		value, ok := payload[from]
+		if !ok {
+			return nil
+		}
+
+		delete(payload, from)
+		if _, exists := payload[to]; !exists {
+			payload[to] = value
+		}
+
+		return nil
+	})
+}
+
+func (u *upcaster) EventType() string {
+	return u.eventType
+}
+
+func (u *upcaster) FromVersion() int {
+	return u.fromVersion
+}
+
+func (u *upcaster) Upcast(payload map[string]interface{}) error {
+	return u.upcast(payload)
+}
@@ -0,0 +1,98 @@
+package upcasting
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"emperror.dev/errors"
+)
+
+// UpcasterRegistry keeps the upcasters of the event types by their schema versions
+type UpcasterRegistry interface {
+	// Upcast transforms the json payload of an event from its stored schema version with the chain of the registered
+	// upcasters, it returns the payload and the schema version after the last upcaster. a payload without an upcaster
+	// of its schema version is returned unchanged.
+	Upcast(eventType string, schemaVersion int, data []byte) ([]byte, int, error)
+	// HasUpcaster reports whether there is an upcaster of the event type from the schema version
+	HasUpcaster(eventType string, schemaVersion int) bool
+}
+
+type upcasterKey struct {
+	eventType   string
+	fromVersion int
+}
+
+type upcasterRegistry struct {
+	upcasters map[upcasterKey]Upcaster
+}
+
+// NewUpcasterRegistry creates the registry of the upcasters, the upcasters are provided with the `esdbUpcasters` fx
+// group, and an event type can have only an upcaster from each schema version
+func NewUpcasterRegistry(upcasters []Upcaster) (UpcasterRegistry, error) {
+	registry := &upcasterRegistry{upcasters: make(map[upcasterKey]Upcaster)}
+
+	for _, upcaster := range upcasters {
+		key := upcasterKey{eventType: upcaster.EventType(), fromVersion: upcaster.FromVersion()}
+		if _, exists := registry.upcasters[key]; exists {
+			return nil, errors.Errorf(
+				"upcaster of the event `%s` from the schema version %d is already registered",
+				key.eventType,
+				key.fromVersion,
+			)
+		}
+
+		registry.upcasters[key] = upcaster
+	}
+
+	return registry, nil
+}
+
+func (r *upcasterRegistry) HasUpcaster(eventType string, schemaVersion int) bool {
+	_, ok := r.upcasters[upcasterKey{eventType: eventType, fromVersion: schemaVersion}]
+
+	return ok
+}
+
+func (r *upcasterRegistry) Upcast(eventType string, schemaVersion int, data []byte) ([]byte, int, error) {
+	if !r.HasUpcaster(eventType, schemaVersion) {
+		return data, schemaVersion, nil
+	}
+
+	payload := map[string]interface{}{}
+
+	// the numbers are kept as json numbers, so the large integers of the payload are not rounded by the round trip
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&payload); err != nil {
+		return nil, 0, errors.WrapIf(
+			err,
+			fmt.Sprintf("error in decoding the payload of the event `%s` for upcasting", eventType),
+		)
+	}
+
+	for {
+		upcaster, ok := r.upcasters[upcasterKey{eventType: eventType, fromVersion: schemaVersion}]
+		if !ok {
+			break
+		}
+
+		if err := upcaster.Upcast(payload); err != nil {
+			return nil, 0, errors.WrapIf(
+				err,
+				fmt.Sprintf("error in upcasting the event `%s` from the schema version %d", eventType, schemaVersion),
+			)
+		}
+		schemaVersion++
+	}
+
+	result, err := json.Marshal(payload)
+	if err != nil {
+		return nil, 0, errors.WrapIf(
+			err,
+			fmt.Sprintf("error in encoding the upcasted payload of the event `%s`", eventType),
+		)
+	}
+
+	return result, schemaVersion, nil
+}
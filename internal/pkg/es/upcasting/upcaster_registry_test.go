@@ -0,0 +1,78 @@
+package upcasting
+
+import (
+	"strings"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testOrderCreated struct{}
+
+func Test_Upcast_Chains_The_Upcasters_To_The_Current_Schema_Version(t *testing.T) {
+	registry, err := NewUpcasterRegistry([]Upcaster{
+		RenameField[*testOrderCreated](1, "order_id", "orderId"),
+		NewUpcaster[*testOrderCreated](2, func(payload map[string]interface{}) error {
+			payload["currency"] = "USD"
+
+			return nil
+		}),
+	})
+	require.NoError(t, err)
+
+	data, version, err := registry.Upcast(
+		"*testOrderCreated",
+		1,
+		[]byte(`{"order_id":"order-1","quantity":9007199254740993}`),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 3, version)
+	assert.JSONEq(t, `{"orderId":"order-1","quantity":9007199254740993,"currency":"USD"}`, string(data))
+	// the large integers are not rounded by the round trip
+	assert.True(t, strings.Contains(string(data), "9007199254740993"))
+
+	data, version, err = registry.Upcast("*testOrderCreated", 2, []byte(`{"orderId":"order-1"}`))
+	require.NoError(t, err)
+	assert.Equal(t, 3, version)
+	assert.JSONEq(t, `{"orderId":"order-1","currency":"USD"}`, string(data))
+}
+
+func Test_Upcast_Returns_Current_Payloads_Unchanged(t *testing.T) {
+	registry, err := NewUpcasterRegistry([]Upcaster{RenameField[*testOrderCreated](1, "order_id", "orderId")})
+	require.NoError(t, err)
+
+	payload := []byte(`{"orderId":"order-1"}`)
+
+	data, version, err := registry.Upcast("*testOrderCreated", 2, payload)
+	require.NoError(t, err)
+	assert.Equal(t, 2, version)
+	assert.Equal(t, payload, data)
+
+	data, version, err = registry.Upcast("*otherEvent", 1, payload)
+	require.NoError(t, err)
+	assert.Equal(t, 1, version)
+	assert.Equal(t, payload, data)
+}
+
+func Test_Upcast_Errors(t *testing.T) {
+	_, err := NewUpcasterRegistry([]Upcaster{
+		RenameField[*testOrderCreated](1, "order_id", "orderId"),
+		RenameField[*testOrderCreated](1, "total", "totalPrice"),
+	})
+	assert.Error(t, err)
+
+	registry, err := NewUpcasterRegistry([]Upcaster{
+		NewUpcaster[*testOrderCreated](1, func(payload map[string]interface{}) error {
+			return errors.New("missing field")
+		}),
+	})
+	require.NoError(t, err)
+
+	_, _, err = registry.Upcast("*testOrderCreated", 1, []byte(`{}`))
+	assert.ErrorContains(t, err, "missing field")
+
+	_, _, err = registry.Upcast("*testOrderCreated", 1, []byte(`not json`))
+	assert.Error(t, err)
+}
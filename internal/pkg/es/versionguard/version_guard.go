@@ -0,0 +1,65 @@
+package versionguard
+
+import (
+	"emperror.dev/errors"
+)
+
+// NoVersion is the applied version of a read model which no event of its stream is applied to
+const NoVersion int64 = -1
+
+// Decision is the result of comparing the version of an event with the applied version of its read model
+type Decision string
+
+const (
+	// Apply is the decision of an event which is newer than the applied version of the read model
+	Apply Decision = "apply"
+	// Duplicate is the decision of an event which is already applied to the read model, e.g. an event which is
+	// projected again after a failed checkpoint or by the replay of a rebuild
+	Duplicate Decision = "duplicate"
+	// Stale is the decision of an event which is older than the applied version of the read model, e.g. an event of a
+	// slower instance or consumer after a newer event of the stream is applied
+	Stale Decision = "stale"
+)
+
+// StaleEventError is returned by the guarded writes of a read model when the same or a newer event of the stream is
+// already applied to the stored read model, e.g. by a concurrent consumer between the read and the write
+var StaleEventError = errors.New("the same or a newer event is already applied to the read model")
+
+// VersionedReadModel is a read model which keeps the version of the last applied event of its stream
+type VersionedReadModel interface {
+	GetVersion() int64
+	SetVersion(version int64)
+}
+
+// Check compares the version of an event in its stream with the version of the last applied event of the stream, the
+// events of a stream are applied only in the increasing order of their versions, so the result doesn't depend on the
+// order of the deliveries
+func Check(appliedVersion int64, eventVersion int64) Decision {
+	switch {
+	case eventVersion > appliedVersion:
+		return Apply
+	case eventVersion == appliedVersion:
+		return Duplicate
+	default:
+		return Stale
+	}
+}
+
+// ApplyEvent runs the update of the read model and sets its version when the event is newer than the applied version
+// of the read model, the read model is not changed for a duplicate or a stale event, so its write can be skipped
+func ApplyEvent(readModel VersionedReadModel, eventVersion int64, update func()) Decision {
+	decision := Check(readModel.GetVersion(), eventVersion)
+	if decision != Apply {
+		return decision
+	}
+
+	update()
+	readModel.SetVersion(eventVersion)
+
+	return decision
+}
+
+// IsSkipped reports whether the write of an event is skipped by a version guard
+func IsSkipped(err error) bool {
+	return errors.Is(err, StaleEventError)
+}
@@ -0,0 +1,46 @@
+package versionguard
+
+import (
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type testReadModel struct {
+	Version int64
+	Status  string
+}
+
+func (t *testReadModel) GetVersion() int64 {
+	return t.Version
+}
+
+func (t *testReadModel) SetVersion(version int64) {
+	t.Version = version
+}
+
+func Test_Check_Compares_Event_With_Applied_Version(t *testing.T) {
+	assert.Equal(t, Apply, Check(NoVersion, 0))
+	assert.Equal(t, Apply, Check(2, 5))
+	assert.Equal(t, Duplicate, Check(5, 5))
+	assert.Equal(t, Stale, Check(5, 4))
+}
+
+func Test_Apply_Event_Skips_Duplicate_And_Stale_Events(t *testing.T) {
+	readModel := &testReadModel{Version: 1, Status: "created"}
+
+	// the events of version 3 and 2 are delivered out of order, the older event is ignored instead of overwriting the
+	// newer state
+	assert.Equal(t, Apply, ApplyEvent(readModel, 3, func() { readModel.Status = "paid" }))
+	assert.Equal(t, Stale, ApplyEvent(readModel, 2, func() { readModel.Status = "submitted" }))
+	assert.Equal(t, Duplicate, ApplyEvent(readModel, 3, func() { readModel.Status = "paid again" }))
+
+	assert.Equal(t, "paid", readModel.Status)
+	assert.Equal(t, int64(3), readModel.Version)
+}
+
+func Test_Is_Skipped(t *testing.T) {
+	assert.True(t, IsSkipped(errors.WrapIf(StaleEventError, "error in updating the order")))
+	assert.False(t, IsSkipped(errors.New("connection refused")))
+}
@@ -10,9 +10,11 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/store"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models"
 	appendResult "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/append_result"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/snapshot"
 	streamName "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_name"
 	readPosition "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_position/read_position"
 	expectedStreamVersion "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_version"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb/config"
 	esErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb/errors"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/attribute"
@@ -27,24 +29,35 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
+// esdbAggregateStore loads the aggregates by replaying their stream. The aggregates with a continuation snapshot are
+// snapshotted every `N` events when the snapshot frequency of their type is configured, then they are loaded from their
+// latest snapshot and the events after it.
 type esdbAggregateStore[T models.IHaveEventSourcedAggregate] struct {
-	log        logger.Logger
-	eventStore store.EventStore
-	serializer *EsdbSerializer
-	tracer     trace.Tracer
+	log             logger.Logger
+	eventStore      store.EventStore
+	serializer      *EsdbSerializer
+	snapshotStore   store.SnapshotStore
+	snapshotOptions *config.SnapshotOptions
+	conflictOptions *config.ConflictOptions
+	tracer          trace.Tracer
 }
 
 func NewEventStoreAggregateStore[T models.IHaveEventSourcedAggregate](
 	log logger.Logger,
 	eventStore store.EventStore,
 	serializer *EsdbSerializer,
+	snapshotStore store.SnapshotStore,
+	cfg *config.EventStoreDbOptions,
 	tracer trace.Tracer,
 ) store.AggregateStore[T] {
 	return &esdbAggregateStore[T]{
-		log:        log,
-		eventStore: eventStore,
-		serializer: serializer,
-		tracer:     tracer,
+		log:             log,
+		eventStore:      eventStore,
+		serializer:      serializer,
+		snapshotStore:   snapshotStore,
+		snapshotOptions: cfg.Snapshot,
+		conflictOptions: cfg.Conflict,
+		tracer:          tracer,
 	}
 }
 
@@ -74,6 +87,8 @@ func (a *esdbAggregateStore[T]) StoreWithVersion(
 	streamId := streamName.For[T](aggregate)
 	span.SetAttributes(attribute2.String("StreamId", streamId.String()))
 
+	previousVersion := aggregate.OriginalVersion()
+
 	var streamEvents []*models.StreamEvent
 
 	linq.From(aggregate.UncommittedEvents()).
@@ -111,6 +126,8 @@ func (a *esdbAggregateStore[T]) StoreWithVersion(
 
 	aggregate.MarkUncommittedEventAsCommitted()
 
+	a.takeSnapshot(ctx, aggregate, streamId, previousVersion)
+
 	span.SetAttributes(attribute.Object("Aggregate", aggregate))
 
 	a.log.Infow(
@@ -156,6 +173,138 @@ func (a *esdbAggregateStore[T]) Store(
 	return streamAppendResult, nil
 }
 
+func (a *esdbAggregateStore[T]) Update(
+	ctx context.Context,
+	aggregateId uuid.UUID,
+	metadata metadata.Metadata,
+	update func(aggregate T) error,
+) (T, *appendResult.AppendEventsResult, error) {
+	ctx, span := a.tracer.Start(ctx, "esdbAggregateStore.Update")
+	span.SetAttributes(attribute2.String("AggregateID", aggregateId.String()))
+	defer span.End()
+
+	streamId := streamName.ForID[T](aggregateId)
+	strategy := a.conflictOptions.GetStrategy(streamId.Category())
+	maxRetries := a.conflictOptions.GetMaxRetries(streamId.Category())
+
+	aggregate, err := a.Load(ctx, aggregateId)
+	if err != nil {
+		return *new(T), nil, utils.TraceStatusFromSpan(span, err)
+	}
+
+	err = update(aggregate)
+	if err != nil {
+		return *new(T), nil, utils.TraceStatusFromSpan(span, err)
+	}
+
+	for attempt := 0; ; attempt++ {
+		streamAppendResult, err := a.Store(aggregate, metadata, ctx)
+		if err == nil {
+			return aggregate, streamAppendResult, nil
+		}
+
+		if !errors.Is(err, esdb.ErrWrongExpectedStreamRevision) {
+			return *new(T), nil, utils.TraceErrStatusFromSpan(span, err)
+		}
+
+		if strategy == config.FailConflictStrategy || attempt >= maxRetries {
+			// the append error is a bad request error, so only its cause is kept for a conflict response
+			return *new(T), nil, utils.TraceErrStatusFromSpan(
+				span,
+				esErrors.NewConcurrencyConflictError(esdb.ErrWrongExpectedStreamRevision, streamId.String()),
+			)
+		}
+
+		a.log.Infow(
+			fmt.Sprintf(
+				"[esdbAggregateStore.Update] stream %s is changed by a concurrent update, resolving the conflict with the '%s' strategy on attempt %d",
+				streamId.String(),
+				strategy,
+				attempt+1,
+			),
+			logger.Fields{"StreamId": streamId.String(), "Strategy": strategy},
+		)
+
+		aggregate, err = a.resolveConflict(ctx, aggregate, strategy, update)
+		if err != nil {
+			return *new(T), nil, utils.TraceStatusFromSpan(span, err)
+		}
+	}
+}
+
+// resolveConflict reloads the aggregate with the concurrent events, the uncommitted events of the stale aggregate are
+// applied again when the merge strategy is used and they commute with the concurrent events, otherwise the update runs
+// again on the reloaded aggregate
+func (a *esdbAggregateStore[T]) resolveConflict(
+	ctx context.Context,
+	stale T,
+	strategy config.ConflictStrategy,
+	update func(aggregate T) error,
+) (T, error) {
+	aggregate, err := a.Load(ctx, stale.Id())
+	if err != nil {
+		return *new(T), err
+	}
+
+	if strategy == config.MergeConflictStrategy {
+		commute, err := a.commutes(ctx, stale, aggregate.OriginalVersion())
+		if err != nil {
+			return *new(T), err
+		}
+
+		if commute {
+			for _, event := range stale.UncommittedEvents() {
+				err = aggregate.Apply(event, true)
+				if err != nil {
+					return *new(T), errors.WrapIf(
+						err,
+						"[esdbAggregateStore_resolveConflict:Apply] error in merging the uncommitted events",
+					)
+				}
+			}
+
+			return aggregate, nil
+		}
+	}
+
+	return aggregate, update(aggregate)
+}
+
+// commutes checks the uncommitted events of the stale aggregate commute with the concurrent events appended after its
+// original version
+func (a *esdbAggregateStore[T]) commutes(ctx context.Context, stale T, version int64) (bool, error) {
+	commutative, ok := any(stale).(models.IHaveCommutativeEvents)
+	if !ok {
+		return false, nil
+	}
+
+	streamId := streamName.For[T](stale)
+
+	streamEvents, err := a.getStreamEvents(streamId, readPosition.FromInt64(stale.OriginalVersion()+1), ctx)
+	if err != nil {
+		return false, err
+	}
+
+	// the stream is truncated after the original version, e.g. by a split, so the concurrent events are not known
+	if len(streamEvents) == 0 || streamEvents[0].Version != stale.OriginalVersion()+1 {
+		return false, nil
+	}
+
+	for _, streamEvent := range streamEvents {
+		if streamEvent.Version > version {
+			break
+		}
+
+		for _, uncommitted := range stale.UncommittedEvents() {
+			if !commutative.Commutes(streamEvent.Event, uncommitted) {
+				return false, nil
+			}
+		}
+	}
+
+	return true, nil
+}
+
 func (a *esdbAggregateStore[T]) Load(
 	ctx context.Context,
 	aggregateId uuid.UUID,
@@ -163,6 +312,15 @@ func (a *esdbAggregateStore[T]) Load(
 	ctx, span := a.tracer.Start(ctx, "esdbAggregateStore.Load")
 	defer span.End()
 
+	aggregate, loaded, err := a.loadFromSnapshot(ctx, aggregateId)
+	if err != nil {
+		return *new(T), utils.TraceStatusFromSpan(span, err)
+	}
+
+	if loaded {
+		return aggregate, nil
+	}
+
 	position := readPosition.Start
 
 	return a.LoadWithReadPosition(ctx, aggregateId, position)
@@ -177,32 +335,11 @@ func (a *esdbAggregateStore[T]) LoadWithReadPosition(
 	span.SetAttributes(attribute2.String("AggregateID", aggregateId.String()))
 	defer span.End()
 
-	var typeNameType T
-	aggregateInstance := typeMapper.InstancePointerByTypeName(
-		typeMapper.GetFullTypeName(typeNameType),
-	)
-	aggregate, ok := aggregateInstance.(T)
-	if !ok {
-		return *new(T), errors.New(
-			fmt.Sprintf(
-				"[esdbAggregateStore_LoadWithReadPosition] aggregate is not a %s",
-				typeMapper.GetFullTypeName(typeNameType),
-			),
-		)
-	}
-
-	method := reflect.ValueOf(aggregate).MethodByName("NewEmptyAggregate")
-	if !method.IsValid() {
-		return *new(T), utils.TraceErrStatusFromSpan(
-			span,
-			errors.New(
-				"[esdbAggregateStore_LoadWithReadPosition:MethodByName] aggregate does not have a `NewEmptyAggregate` method",
-			),
-		)
+	aggregate, err := newEmptyAggregate[T]()
+	if err != nil {
+		return *new(T), utils.TraceErrStatusFromSpan(span, err)
 	}
 
-	method.Call([]reflect.Value{})
-
 	streamId := streamName.ForID[T](aggregateId)
 	span.SetAttributes(attribute2.String("StreamId", streamId.String()))
 
@@ -228,6 +365,10 @@ func (a *esdbAggregateStore[T]) LoadWithReadPosition(
 		)
 	}
 
+	// the events before the read position or the truncated events of a split stream are not folded, so the versions
+	// start from the first read event
+	aggregate.SetOriginalVersion(streamEvents[0].Version - 1)
+
 	var meta metadata.Metadata
 	var domainEvents []domain.IDomainEvent
 
@@ -270,16 +411,181 @@ func (a *esdbAggregateStore[T]) Exists(
 	return a.eventStore.StreamExists(streamId, ctx)
 }
 
+// loadFromSnapshot loads the aggregate from its latest snapshot and the events after it, loaded is false when the
+// aggregate type is not snapshotted or its stream doesn't have a usable snapshot, then it is loaded from its stream
+func (a *esdbAggregateStore[T]) loadFromSnapshot(
+	ctx context.Context,
+	aggregateId uuid.UUID,
+) (T, bool, error) {
+	streamId := streamName.ForID[T](aggregateId)
+	if !a.isSnapshotted(streamId) {
+		return *new(T), false, nil
+	}
+
+	snap, err := a.snapshotStore.Load(ctx, streamId)
+	if err != nil {
+		// the snapshots only speed up the loading, so the aggregate is loaded from its stream
+		a.log.Errorf(
+			"[esdbAggregateStore.loadFromSnapshot] error in loading the snapshot of stream %s: %v",
+			streamId.String(),
+			err,
+		)
+
+		return *new(T), false, nil
+	}
+
+	if snap == nil {
+		return *new(T), false, nil
+	}
+
+	streamEvents, err := a.getStreamEvents(streamId, readPosition.FromInt64(snap.Version+1), ctx)
+	if errors.Is(err, esdb.ErrStreamNotFound) {
+		return *new(T), false, nil
+	}
+
+	if err != nil {
+		return *new(T), false, errors.WrapIff(
+			err,
+			"[esdbAggregateStore.loadFromSnapshot] error in loading the events of aggregate {%s} after its snapshot",
+			aggregateId.String(),
+		)
+	}
+
+	// the stream is truncated after the snapshot, e.g. by a split, so the events after the snapshot are not in the
+	// stream anymore
+	if len(streamEvents) > 0 && streamEvents[0].Version != snap.Version+1 {
+		return *new(T), false, nil
+	}
+
+	aggregate, err := newEmptyAggregate[T]()
+	if err != nil {
+		return *new(T), false, err
+	}
+
+	aggregate.SetOriginalVersion(snap.Version - 1)
+
+	domainEvents := []domain.IDomainEvent{snap.State}
+	var meta metadata.Metadata
+
+	for _, streamEvent := range streamEvents {
+		meta = streamEvent.Metadata
+		domainEvents = append(domainEvents, streamEvent.Event)
+	}
+
+	err = aggregate.LoadFromHistory(domainEvents, meta)
+	if err != nil {
+		return *new(T), false, err
+	}
+
+	a.log.Infow(
+		fmt.Sprintf(
+			"Loaded aggregate with streamId {%s} from its snapshot at version {%d} and {%d} events",
+			streamId.String(),
+			snap.Version,
+			len(streamEvents),
+		),
+		logger.Fields{"StreamId": streamId.String(), "SnapshotVersion": snap.Version},
+	)
+
+	return aggregate, true, nil
+}
+
+// takeSnapshot stores a snapshot of the aggregate when its stored events reach the next multiple of the snapshot
+// frequency of its type. The events are already stored, so a failed snapshot is only logged.
+func (a *esdbAggregateStore[T]) takeSnapshot(
+	ctx context.Context,
+	aggregate T,
+	streamId streamName.StreamName,
+	previousVersion int64,
+) {
+	if !a.isSnapshotted(streamId) {
+		return
+	}
+
+	frequency := a.snapshotOptions.GetFrequency(streamId.Category())
+
+	// the versions are zero based, so a stream with `frequency` events has the `frequency-1` version
+	version := aggregate.CurrentVersion()
+	if (version+1)/frequency <= (previousVersion+1)/frequency {
+		return
+	}
+
+	snapshotter := any(aggregate).(models.IHaveContinuationSnapshot)
+
+	state, err := snapshotter.ContinuationSnapshot()
+	if err == nil {
+		state.WithAggregate(aggregate.Id(), version)
+		err = a.snapshotStore.Save(ctx, &snapshot.Snapshot{StreamName: streamId, Version: version, State: state})
+	}
+
+	if err != nil {
+		a.log.Errorf(
+			"[esdbAggregateStore.takeSnapshot] error in taking the snapshot of stream %s at version %d: %v",
+			streamId.String(),
+			version,
+			err,
+		)
+	}
+}
+
+// isSnapshotted checks the aggregate type has a snapshot frequency and a continuation snapshot for its state
+func (a *esdbAggregateStore[T]) isSnapshotted(streamId streamName.StreamName) bool {
+	if a.snapshotStore == nil || a.snapshotOptions.GetFrequency(streamId.Category()) == 0 {
+		return false
+	}
+
+	var aggregate T
+	_, ok := any(aggregate).(models.IHaveContinuationSnapshot)
+
+	return ok
+}
+
+func newEmptyAggregate[T models.IHaveEventSourcedAggregate]() (T, error) {
+	var typeNameType T
+	aggregateInstance := typeMapper.InstancePointerByTypeName(
+		typeMapper.GetFullTypeName(typeNameType),
+	)
+	aggregate, ok := aggregateInstance.(T)
+	if !ok {
+		return *new(T), errors.New(
+			fmt.Sprintf(
+				"[esdbAggregateStore_LoadWithReadPosition] aggregate is not a %s",
+				typeMapper.GetFullTypeName(typeNameType),
+			),
+		)
+	}
+
+	method := reflect.ValueOf(aggregate).MethodByName("NewEmptyAggregate")
+	if !method.IsValid() {
+		return *new(T), errors.New(
+			"[esdbAggregateStore_LoadWithReadPosition:MethodByName] aggregate does not have a `NewEmptyAggregate` method",
+		)
+	}
+
+	method.Call([]reflect.Value{})
+
+	return aggregate, nil
+}
+
 func (a *esdbAggregateStore[T]) getStreamEvents(
 	streamId streamName.StreamName,
 	position readPosition.StreamReadPosition,
 	ctx context.Context,
+) ([]*models.StreamEvent, error) {
+	return readStreamEvents(a.eventStore, streamId, position, ctx)
+}
+
+func readStreamEvents(
+	eventStore store.EventStore,
+	streamId streamName.StreamName,
+	position readPosition.StreamReadPosition,
+	ctx context.Context,
 ) ([]*models.StreamEvent, error) {
 	pageSize := 500
 	var streamEvents []*models.StreamEvent
 
 	for true {
-		events, err := a.eventStore.ReadEvents(
+		events, err := eventStore.ReadEvents(
 			streamId,
 			position,
 			uint64(pageSize),
@@ -295,7 +601,8 @@ func (a *esdbAggregateStore[T]) getStreamEvents(
 		if len(events) < pageSize {
 			break
 		}
-		position = readPosition.FromInt64(int64(len(events)) + position.Value())
+		// the truncated streams don't start from the zero version, so the next page starts after the last read event
+		position = readPosition.FromInt64(events[len(events)-1].Version + 1)
 	}
 
 	return streamEvents, nil
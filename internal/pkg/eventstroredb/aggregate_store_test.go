@@ -0,0 +1,393 @@
+package eventstroredb
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/domain"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/store"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models"
+	appendResult "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/append_result"
+	streamName "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_name"
+	readPosition "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_position/read_position"
+	expectedStreamVersion "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_version"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb/config"
+	esErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb/errors"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"emperror.dev/errors"
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type cartItemAdded struct {
+	*domain.DomainEvent
+	Item string `json:"item"`
+}
+
+type cartClosed struct {
+	*domain.DomainEvent
+}
+
+type cartNoteAdded struct {
+	*domain.DomainEvent
+	Note string `json:"note"`
+}
+
+func newCartItemAdded(item string) *cartItemAdded {
+	event := &cartItemAdded{Item: item}
+	event.DomainEvent = domain.NewDomainEvent(typeMapper.GetTypeName(event))
+
+	return event
+}
+
+func newCartClosed() *cartClosed {
+	event := &cartClosed{}
+	event.DomainEvent = domain.NewDomainEvent(typeMapper.GetTypeName(event))
+
+	return event
+}
+
+func newCartNoteAdded(note string) *cartNoteAdded {
+	event := &cartNoteAdded{Note: note}
+	event.DomainEvent = domain.NewDomainEvent(typeMapper.GetTypeName(event))
+
+	return event
+}
+
+// testCart adds its items in any order, but a closed cart rejects the new items, its notes are merged with every update
+// except the close
+type testCart struct {
+	*models.EventSourcedAggregateRoot
+	items  []string
+	notes  []string
+	closed bool
+}
+
+func (c *testCart) NewEmptyAggregate() {
+	c.EventSourcedAggregateRoot = models.NewEventSourcedAggregateRoot(typeMapper.GetFullTypeName(c), c.When)
+}
+
+func (c *testCart) AddItem(item string) error {
+	if c.closed {
+		return customErrors.NewDomainError(fmt.Sprintf("cart with id %s is closed", c.Id()))
+	}
+
+	return c.Apply(newCartItemAdded(item), true)
+}
+
+func (c *testCart) AddNote(note string) error {
+	if c.closed {
+		return customErrors.NewDomainError(fmt.Sprintf("cart with id %s is closed", c.Id()))
+	}
+
+	return c.Apply(newCartNoteAdded(note), true)
+}
+
+func (c *testCart) When(event domain.IDomainEvent) error {
+	switch evt := event.(type) {
+	case *cartItemAdded:
+		c.items = append(c.items, evt.Item)
+	case *cartNoteAdded:
+		c.notes = append(c.notes, evt.Note)
+	case *cartClosed:
+		c.closed = true
+	}
+
+	c.SetId(event.GetAggregateId())
+
+	return nil
+}
+
+func (c *testCart) Commutes(concurrent domain.IDomainEvent, uncommitted domain.IDomainEvent) bool {
+	_, concurrentAdded := concurrent.(*cartItemAdded)
+	_, uncommittedAdded := uncommitted.(*cartItemAdded)
+	_, concurrentNote := concurrent.(*cartNoteAdded)
+	_, uncommittedNote := uncommitted.(*cartNoteAdded)
+	_, concurrentClosed := concurrent.(*cartClosed)
+	_, uncommittedClosed := uncommitted.(*cartClosed)
+
+	if concurrentNote || uncommittedNote {
+		return !concurrentClosed && !uncommittedClosed
+	}
+
+	return concurrentAdded && uncommittedAdded
+}
+
+// fakeAggregateEventStore appends the events of the streams in memory, the concurrent func runs before each append
+// for appending the events of a concurrent update
+type fakeAggregateEventStore struct {
+	store.EventStore
+	streams    map[streamName.StreamName][]*models.StreamEvent
+	concurrent func()
+}
+
+func newFakeAggregateEventStore() *fakeAggregateEventStore {
+	return &fakeAggregateEventStore{streams: map[streamName.StreamName][]*models.StreamEvent{}}
+}
+
+func (f *fakeAggregateEventStore) StreamExists(name streamName.StreamName, ctx context.Context) (bool, error) {
+	return len(f.streams[name]) > 0, nil
+}
+
+func (f *fakeAggregateEventStore) ReadEvents(
+	name streamName.StreamName,
+	position readPosition.StreamReadPosition,
+	count uint64,
+	ctx context.Context,
+) ([]*models.StreamEvent, error) {
+	if len(f.streams[name]) == 0 {
+		return nil, esdb.ErrStreamNotFound
+	}
+
+	var events []*models.StreamEvent
+	for _, event := range f.streams[name] {
+		if event.Version >= position.Value() && uint64(len(events)) < count {
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
+
+func (f *fakeAggregateEventStore) AppendEvents(
+	name streamName.StreamName,
+	expectedVersion expectedStreamVersion.ExpectedStreamVersion,
+	events []*models.StreamEvent,
+	ctx context.Context,
+) (*appendResult.AppendEventsResult, error) {
+	if f.concurrent != nil {
+		f.concurrent()
+	}
+
+	version := int64(len(f.streams[name])) - 1
+	if !expectedVersion.IsAny() && expectedVersion.Value() != version {
+		err := fmt.Errorf("%w, reason: stream is at version %d", esdb.ErrWrongExpectedStreamRevision, version)
+
+		return nil, errors.WithMessage(esErrors.NewAppendToStreamError(err, name.String()), "error in appending to stream")
+	}
+
+	f.append(name, events...)
+
+	return appendResult.From(0, uint64(len(f.streams[name]))), nil
+}
+
+func (f *fakeAggregateEventStore) append(name streamName.StreamName, events ...*models.StreamEvent) {
+	for _, event := range events {
+		f.streams[name] = append(f.streams[name], &models.StreamEvent{
+			EventID: event.EventID,
+			Version: int64(len(f.streams[name])),
+			Event:   event.Event,
+		})
+	}
+}
+
+// appendConcurrently appends the event of a concurrent update to the stream of the cart
+func (f *fakeAggregateEventStore) appendConcurrently(cartId uuid.UUID, event domain.IDomainEvent) {
+	name := streamName.ForID[*testCart](cartId)
+	event.WithAggregate(cartId, int64(len(f.streams[name])))
+	f.append(name, &models.StreamEvent{EventID: event.GetEventId(), Event: event})
+}
+
+func newTestAggregateStore(
+	t *testing.T,
+	conflict *config.ConflictOptions,
+) (store.AggregateStore[*testCart], *fakeAggregateEventStore, uuid.UUID) {
+	t.Helper()
+
+	eventStore := newFakeAggregateEventStore()
+	aggregateStore := NewEventStoreAggregateStore[*testCart](
+		defaultLogger.GetLogger(),
+		eventStore,
+		newTestEsdbSerializer(t),
+		nil,
+		&config.EventStoreDbOptions{Conflict: conflict},
+		trace.NewNoopTracerProvider().Tracer("aggregate-store-test"),
+	)
+
+	cart := &testCart{}
+	cart.NewEmptyAggregate()
+	cart.SetId(uuid.NewV4())
+	require.NoError(t, cart.AddItem("a"))
+
+	_, err := aggregateStore.Store(cart, nil, context.Background())
+	require.NoError(t, err)
+
+	return aggregateStore, eventStore, cart.Id()
+}
+
+func Test_Update_Retries_The_Update_On_The_Reloaded_Aggregate(t *testing.T) {
+	aggregateStore, eventStore, cartId := newTestAggregateStore(
+		t,
+		&config.ConflictOptions{Strategy: config.RetryConflictStrategy},
+	)
+
+	eventStore.concurrent = func() {
+		eventStore.concurrent = nil
+		eventStore.appendConcurrently(cartId, newCartItemAdded("c"))
+	}
+
+	updates := 0
+	cart, _, err := aggregateStore.Update(context.Background(), cartId, nil, func(cart *testCart) error {
+		updates++
+
+		return cart.AddItem("b")
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, updates)
+	assert.Equal(t, []string{"a", "c", "b"}, cart.items)
+	assert.Len(t, eventStore.streams[streamName.ForID[*testCart](cartId)], 3)
+}
+
+func Test_Update_Merges_The_Commutative_Events(t *testing.T) {
+	aggregateStore, eventStore, cartId := newTestAggregateStore(
+		t,
+		&config.ConflictOptions{
+			Aggregates: map[string]*config.AggregateConflictOptions{
+				"testcart": {Strategy: config.MergeConflictStrategy},
+			},
+		},
+	)
+
+	eventStore.concurrent = func() {
+		eventStore.concurrent = nil
+		eventStore.appendConcurrently(cartId, newCartItemAdded("c"))
+	}
+
+	updates := 0
+	cart, _, err := aggregateStore.Update(context.Background(), cartId, nil, func(cart *testCart) error {
+		updates++
+
+		return cart.AddItem("b")
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, updates)
+	assert.Equal(t, []string{"a", "c", "b"}, cart.items)
+
+	stream := eventStore.streams[streamName.ForID[*testCart](cartId)]
+	require.Len(t, stream, 3)
+	assert.Equal(t, "b", stream[2].Event.(*cartItemAdded).Item)
+}
+
+func Test_Update_Runs_The_Update_Again_When_The_Events_Do_Not_Commute(t *testing.T) {
+	aggregateStore, eventStore, cartId := newTestAggregateStore(
+		t,
+		&config.ConflictOptions{Strategy: config.MergeConflictStrategy},
+	)
+
+	eventStore.concurrent = func() {
+		eventStore.concurrent = nil
+		eventStore.appendConcurrently(cartId, newCartClosed())
+	}
+
+	_, _, err := aggregateStore.Update(context.Background(), cartId, nil, func(cart *testCart) error {
+		return cart.AddItem("b")
+	})
+
+	// the update is rejected by the concurrent close instead of being merged after it
+	assert.True(t, customErrors.IsDomainError(err, customErrors.GetCustomError(err).Status()))
+	assert.False(t, esErrors.IsConcurrencyConflictError(err))
+	assert.Len(t, eventStore.streams[streamName.ForID[*testCart](cartId)], 2)
+}
+
+func Test_Update_Merges_A_Note_Except_After_The_Close(t *testing.T) {
+	tests := []struct {
+		name       string
+		concurrent func() domain.IDomainEvent
+		updates    int
+		rejected   bool
+	}{
+		{
+			name:       "after an item",
+			concurrent: func() domain.IDomainEvent { return newCartItemAdded("c") },
+			updates:    1,
+		},
+		{
+			name:       "after the close",
+			concurrent: func() domain.IDomainEvent { return newCartClosed() },
+			updates:    2,
+			rejected:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			aggregateStore, eventStore, cartId := newTestAggregateStore(
+				t,
+				&config.ConflictOptions{Strategy: config.MergeConflictStrategy},
+			)
+
+			eventStore.concurrent = func() {
+				eventStore.concurrent = nil
+				eventStore.appendConcurrently(cartId, test.concurrent())
+			}
+
+			updates := 0
+			_, _, err := aggregateStore.Update(context.Background(), cartId, nil, func(cart *testCart) error {
+				updates++
+
+				return cart.AddNote("gift wrap")
+			})
+
+			assert.Equal(t, test.updates, updates)
+			stream := eventStore.streams[streamName.ForID[*testCart](cartId)]
+			if test.rejected {
+				// the note is added again on the closed cart instead of being merged after the close
+				assert.True(t, customErrors.IsDomainError(err, customErrors.GetCustomError(err).Status()))
+				assert.Len(t, stream, 2)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, stream, 3)
+			assert.Equal(t, "gift wrap", stream[2].Event.(*cartNoteAdded).Note)
+		})
+	}
+}
+
+func Test_Update_Fails_With_A_Conflict_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		conflict *config.ConflictOptions
+		updates  int
+	}{
+		{name: "without conflict options", updates: 1},
+		{
+			name:     "after the retries",
+			conflict: &config.ConflictOptions{Strategy: config.RetryConflictStrategy, MaxRetries: 2},
+			updates:  3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			aggregateStore, eventStore, cartId := newTestAggregateStore(t, test.conflict)
+
+			eventStore.concurrent = func() {
+				eventStore.appendConcurrently(cartId, newCartItemAdded("c"))
+			}
+
+			updates := 0
+			_, _, err := aggregateStore.Update(context.Background(), cartId, nil, func(cart *testCart) error {
+				updates++
+
+				return cart.AddItem("b")
+			})
+
+			assert.Equal(t, test.updates, updates)
+			assert.True(t, esErrors.IsConcurrencyConflictError(err))
+			assert.True(t, customErrors.IsConflictError(err))
+			assert.False(t, customErrors.IsBadRequestError(err))
+			assert.ErrorIs(t, err, esdb.ErrWrongExpectedStreamRevision)
+		})
+	}
+}
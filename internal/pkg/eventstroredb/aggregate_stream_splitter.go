@@ -0,0 +1,452 @@
+package eventstroredb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/store"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models"
+	streamName "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_name"
+	readPosition "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_position/read_position"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_position/truncatePosition"
+	streamSegment "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_segment"
+	expectedStreamVersion "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_version"
+	esErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb/errors"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/attribute"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/utils"
+
+	"emperror.dev/errors"
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	uuid "github.com/satori/go.uuid"
+	attribute2 "go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// esdbAggregateStreamSplitter splits the aggregate stream in these steps, each step can be run again when the split is
+// interrupted:
+//  1. the events of the current segment are copied to the segment stream with their ids, only the missing events are
+//     appended when the segment stream exists.
+//  2. the continuation snapshot is appended to the aggregate stream with the expected version of the copied events,
+//     so the split fails when the aggregate is changed meanwhile. It is linked to the segment stream in its metadata.
+//  3. the aggregate stream is truncated before the continuation snapshot.
+type esdbAggregateStreamSplitter[T models.IHaveEventSourcedAggregate] struct {
+	log            logger.Logger
+	eventStore     store.EventStore
+	aggregateStore store.AggregateStore[T]
+	serializer     *EsdbSerializer
+	tracer         trace.Tracer
+}
+
+func NewEventStoreAggregateStreamSplitter[T models.IHaveEventSourcedAggregate](
+	log logger.Logger,
+	eventStore store.EventStore,
+	aggregateStore store.AggregateStore[T],
+	serializer *EsdbSerializer,
+	tracer trace.Tracer,
+) store.AggregateStreamSplitter[T] {
+	return &esdbAggregateStreamSplitter[T]{
+		log:            log,
+		eventStore:     eventStore,
+		aggregateStore: aggregateStore,
+		serializer:     serializer,
+		tracer:         tracer,
+	}
+}
+
+func (s *esdbAggregateStreamSplitter[T]) Split(
+	ctx context.Context,
+	aggregateId uuid.UUID,
+	minEvents int64,
+	meta metadata.Metadata,
+) (*streamSegment.SplitStreamResult, error) {
+	ctx, span := s.tracer.Start(ctx, "esdbAggregateStreamSplitter.Split")
+	span.SetAttributes(attribute2.String("AggregateID", aggregateId.String()))
+	defer span.End()
+
+	streamId := streamName.ForID[T](aggregateId)
+	span.SetAttributes(attribute2.String("StreamId", streamId.String()))
+
+	streamEvents, number, truncated, err := s.readCurrentSegment(ctx, aggregateId, streamId)
+	if err != nil {
+		return nil, utils.TraceErrStatusFromSpan(span, err)
+	}
+
+	// the truncation of the last split is failed, the events before its continuation snapshot are still in the stream
+	if !truncated {
+		err = s.truncate(ctx, streamId, streamEvents[0].Version)
+		if err != nil {
+			return nil, utils.TraceErrStatusFromSpan(span, err)
+		}
+	}
+
+	result := &streamSegment.SplitStreamResult{EventsCount: int64(len(streamEvents))}
+	if minEvents > 0 && result.EventsCount < minEvents {
+		return result, nil
+	}
+
+	aggregate, err := s.aggregateStore.Load(ctx, aggregateId)
+	if err != nil {
+		return nil, utils.TraceErrStatusFromSpan(
+			span,
+			errors.WrapIf(err, "[esdbAggregateStreamSplitter_Split:Load] error in loading aggregate"),
+		)
+	}
+
+	snapshotter, ok := any(aggregate).(models.IHaveContinuationSnapshot)
+	if !ok {
+		return nil, utils.TraceErrStatusFromSpan(
+			span,
+			errors.Errorf(
+				"[esdbAggregateStreamSplitter_Split] aggregate of stream %s doesn't have a continuation snapshot",
+				streamId.String(),
+			),
+		)
+	}
+
+	lastVersion := streamEvents[len(streamEvents)-1].Version
+	if aggregate.OriginalVersion() != lastVersion {
+		return nil, utils.TraceErrStatusFromSpan(
+			span,
+			customErrors.NewConflictError(
+				fmt.Sprintf(
+					"[esdbAggregateStreamSplitter_Split] stream %s is changed while splitting it",
+					streamId.String(),
+				),
+			),
+		)
+	}
+
+	segmentStream := streamId.Segment(number)
+
+	err = s.copyToSegment(ctx, streamId, segmentStream, streamEvents)
+	if err != nil {
+		return nil, utils.TraceErrStatusFromSpan(span, err)
+	}
+
+	snapshot, err := snapshotter.ContinuationSnapshot()
+	if err != nil {
+		return nil, utils.TraceErrStatusFromSpan(
+			span,
+			errors.WrapIf(
+				err,
+				"[esdbAggregateStreamSplitter_Split:ContinuationSnapshot] error in creating continuation snapshot",
+			),
+		)
+	}
+	snapshot.WithAggregate(aggregateId, lastVersion+1)
+
+	snapshotMeta := metadata.Metadata{}
+	for key, value := range meta {
+		snapshotMeta[key] = value
+	}
+	streamSegment.SetPreviousSegment(snapshotMeta, segmentStream.String(), number)
+
+	_, err = s.eventStore.AppendEvents(
+		streamId,
+		expectedStreamVersion.FromInt64(lastVersion),
+		[]*models.StreamEvent{s.serializer.DomainEventToStreamEvent(snapshot, snapshotMeta, lastVersion+1)},
+		ctx,
+	)
+	if err != nil {
+		return nil, utils.TraceErrStatusFromSpan(
+			span,
+			errors.WrapIf(
+				err,
+				"[esdbAggregateStreamSplitter_Split:AppendEvents] error in appending continuation snapshot",
+			),
+		)
+	}
+
+	err = s.truncate(ctx, streamId, lastVersion+1)
+	if err != nil {
+		return nil, utils.TraceErrStatusFromSpan(span, err)
+	}
+
+	result.Split = true
+	result.SnapshotVersion = lastVersion + 1
+	result.Segment = &streamSegment.StreamSegment{
+		Number:       number,
+		StreamName:   segmentStream.String(),
+		FirstVersion: streamEvents[0].Version,
+		LastVersion:  lastVersion,
+		EventsCount:  result.EventsCount,
+	}
+
+	span.SetAttributes(attribute.Object("SplitStreamResult", result))
+
+	s.log.Infow(
+		fmt.Sprintf(
+			"[esdbAggregateStreamSplitter.Split] stream %s is split to segment %s",
+			streamId.String(),
+			segmentStream.String(),
+		),
+		logger.Fields{"StreamId": streamId.String(), "Segment": result.Segment},
+	)
+
+	return result, nil
+}
+
+func (s *esdbAggregateStreamSplitter[T]) Segments(
+	ctx context.Context,
+	aggregateId uuid.UUID,
+) ([]*streamSegment.StreamSegment, error) {
+	ctx, span := s.tracer.Start(ctx, "esdbAggregateStreamSplitter.Segments")
+	span.SetAttributes(attribute2.String("AggregateID", aggregateId.String()))
+	defer span.End()
+
+	streamId := streamName.ForID[T](aggregateId)
+
+	streamEvents, _, _, err := s.readCurrentSegment(ctx, aggregateId, streamId)
+	if err != nil {
+		return nil, utils.TraceErrStatusFromSpan(span, err)
+	}
+
+	segments, _, err := s.readSegments(ctx, streamEvents[0], false)
+	if err != nil {
+		return nil, utils.TraceErrStatusFromSpan(span, err)
+	}
+
+	return segments, nil
+}
+
+func (s *esdbAggregateStreamSplitter[T]) ReadAllEvents(
+	ctx context.Context,
+	aggregateId uuid.UUID,
+) ([]*models.StreamEvent, error) {
+	ctx, span := s.tracer.Start(ctx, "esdbAggregateStreamSplitter.ReadAllEvents")
+	span.SetAttributes(attribute2.String("AggregateID", aggregateId.String()))
+	defer span.End()
+
+	streamId := streamName.ForID[T](aggregateId)
+
+	streamEvents, _, _, err := s.readCurrentSegment(ctx, aggregateId, streamId)
+	if err != nil {
+		return nil, utils.TraceErrStatusFromSpan(span, err)
+	}
+
+	_, segmentsEvents, err := s.readSegments(ctx, streamEvents[0], true)
+	if err != nil {
+		return nil, utils.TraceErrStatusFromSpan(span, err)
+	}
+
+	return append(segmentsEvents, streamEvents...), nil
+}
+
+// readCurrentSegment reads the events of the aggregate stream from its last continuation snapshot and returns the
+// number of the next segment, truncated is false when the stream has events before the continuation snapshot
+func (s *esdbAggregateStreamSplitter[T]) readCurrentSegment(
+	ctx context.Context,
+	aggregateId uuid.UUID,
+	streamId streamName.StreamName,
+) (streamEvents []*models.StreamEvent, number int, truncated bool, err error) {
+	streamEvents, err = readStreamEvents(s.eventStore, streamId, readPosition.Start, ctx)
+	if errors.Is(err, esdb.ErrStreamNotFound) || (err == nil && len(streamEvents) == 0) {
+		return nil, 0, false, errors.WithMessage(
+			esErrors.NewAggregateNotFoundError(err, aggregateId),
+			"[esdbAggregateStreamSplitter.readCurrentSegment] error in reading aggregate stream",
+		)
+	}
+	if err != nil {
+		return nil, 0, false, errors.WrapIff(
+			err,
+			"[esdbAggregateStreamSplitter_readCurrentSegment:readStreamEvents] error in reading stream %s",
+			streamId.String(),
+		)
+	}
+
+	for i := len(streamEvents) - 1; i >= 0; i-- {
+		if previousNumber, ok := continuationSnapshotOf(streamEvents[i]); ok {
+			return streamEvents[i:], previousNumber + 1, i == 0, nil
+		}
+	}
+
+	return streamEvents, 1, true, nil
+}
+
+// readSegments follows the links of the continuation snapshots from the current segment to the first segment and
+// returns the segments and their events, the oldest first
+func (s *esdbAggregateStreamSplitter[T]) readSegments(
+	ctx context.Context,
+	firstEvent *models.StreamEvent,
+	withEvents bool,
+) ([]*streamSegment.StreamSegment, []*models.StreamEvent, error) {
+	var segments []*streamSegment.StreamSegment
+	var segmentsEvents [][]*models.StreamEvent
+
+	previous, number, ok := streamSegment.GetPreviousSegment(firstEvent.Metadata)
+	for ok {
+		segmentStream := streamName.StreamName(previous)
+
+		var events []*models.StreamEvent
+		var err error
+		if withEvents {
+			events, err = readStreamEvents(s.eventStore, segmentStream, readPosition.Start, ctx)
+		} else {
+			events, err = s.readSegmentBounds(ctx, segmentStream)
+		}
+		if err != nil {
+			return nil, nil, errors.WrapIff(
+				err,
+				"[esdbAggregateStreamSplitter_readSegments] error in reading segment stream %s",
+				previous,
+			)
+		}
+		if len(events) == 0 {
+			return nil, nil, errors.Errorf("[esdbAggregateStreamSplitter_readSegments] segment stream %s is empty", previous)
+		}
+
+		first := events[0]
+		last := events[len(events)-1]
+		for _, event := range events {
+			if version, ok := streamSegment.GetSourceVersion(event.Metadata); ok {
+				event.Version = version
+			}
+		}
+
+		segments = append(segments, &streamSegment.StreamSegment{
+			Number:       number,
+			StreamName:   previous,
+			FirstVersion: first.Version,
+			LastVersion:  last.Version,
+			EventsCount:  last.Version - first.Version + 1,
+		})
+		segmentsEvents = append(segmentsEvents, events)
+
+		previous, number, ok = streamSegment.GetPreviousSegment(first.Metadata)
+	}
+
+	var orderedSegments []*streamSegment.StreamSegment
+	var orderedEvents []*models.StreamEvent
+	for i := len(segments) - 1; i >= 0; i-- {
+		orderedSegments = append(orderedSegments, segments[i])
+		if withEvents {
+			orderedEvents = append(orderedEvents, segmentsEvents[i]...)
+		}
+	}
+
+	return orderedSegments, orderedEvents, nil
+}
+
+// readSegmentBounds reads the first and the last events of the segment stream
+func (s *esdbAggregateStreamSplitter[T]) readSegmentBounds(
+	ctx context.Context,
+	segmentStream streamName.StreamName,
+) ([]*models.StreamEvent, error) {
+	first, err := s.eventStore.ReadEventsFromStart(segmentStream, 1, ctx)
+	if err != nil || len(first) == 0 {
+		return first, err
+	}
+
+	last, err := s.eventStore.ReadEventsBackwardsFromEnd(segmentStream, 1, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(first, last...), nil
+}
+
+// copyToSegment appends the events which are not copied yet to the segment stream, the copied events keep their ids,
+// so the existing events of the segment stream should be the first events of the current segment
+func (s *esdbAggregateStreamSplitter[T]) copyToSegment(
+	ctx context.Context,
+	streamId streamName.StreamName,
+	segmentStream streamName.StreamName,
+	streamEvents []*models.StreamEvent,
+) error {
+	copied, err := readStreamEvents(s.eventStore, segmentStream, readPosition.Start, ctx)
+	if err != nil && !errors.Is(err, esdb.ErrStreamNotFound) {
+		return errors.WrapIff(
+			err,
+			"[esdbAggregateStreamSplitter_copyToSegment:readStreamEvents] error in reading segment stream %s",
+			segmentStream.String(),
+		)
+	}
+
+	if len(copied) > len(streamEvents) {
+		return errors.Errorf(
+			"[esdbAggregateStreamSplitter_copyToSegment] segment stream %s has more events than stream %s",
+			segmentStream.String(),
+			streamId.String(),
+		)
+	}
+	for i, event := range copied {
+		if event.EventID != streamEvents[i].EventID {
+			return errors.Errorf(
+				"[esdbAggregateStreamSplitter_copyToSegment] event %d of segment stream %s doesn't match stream %s",
+				i,
+				segmentStream.String(),
+				streamId.String(),
+			)
+		}
+	}
+
+	var copies []*models.StreamEvent
+	for _, event := range streamEvents[len(copied):] {
+		meta := metadata.Metadata{}
+		for key, value := range event.Metadata {
+			meta[key] = value
+		}
+		streamSegment.SetSourceEvent(meta, streamId.String(), event.Version)
+
+		copies = append(copies, &models.StreamEvent{
+			EventID:  event.EventID,
+			Event:    event.Event,
+			Metadata: meta,
+			Version:  event.Version,
+			Position: event.Position,
+		})
+	}
+
+	if len(copies) == 0 {
+		return nil
+	}
+
+	_, err = s.eventStore.AppendEvents(
+		segmentStream,
+		expectedStreamVersion.FromInt64(int64(len(copied))-1),
+		copies,
+		ctx,
+	)
+	if err != nil {
+		return errors.WrapIff(
+			err,
+			"[esdbAggregateStreamSplitter_copyToSegment:AppendEvents] error in copying events to segment stream %s",
+			segmentStream.String(),
+		)
+	}
+
+	return nil
+}
+
+func (s *esdbAggregateStreamSplitter[T]) truncate(
+	ctx context.Context,
+	streamId streamName.StreamName,
+	before int64,
+) error {
+	// the expected version of the truncation is the version of the metadata stream
+	_, err := s.eventStore.TruncateStream(
+		streamId,
+		truncatePosition.FromInt64(before),
+		expectedStreamVersion.Any,
+		ctx,
+	)
+	if err != nil {
+		return errors.WrapIff(
+			err,
+			"[esdbAggregateStreamSplitter_truncate:TruncateStream] error in truncating stream %s",
+			streamId.String(),
+		)
+	}
+
+	return nil
+}
+
+// continuationSnapshotOf returns the number of the previous segment of a continuation snapshot
+func continuationSnapshotOf(streamEvent *models.StreamEvent) (int, bool) {
+	_, number, ok := streamSegment.GetPreviousSegment(streamEvent.Metadata)
+
+	return number, ok
+}
@@ -2,6 +2,10 @@ package config
 
 import (
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
@@ -21,13 +25,293 @@ type EventStoreDbOptions struct {
 	// HTTP is the primary protocol for EventStoreDB. It is used in gRPC communication and HTTP APIs (management, gossip and diagnostics).
 	HttpPort     int           `mapstructure:"httpPort"`
 	Subscription *Subscription `mapstructure:"subscription"`
+	// Cluster is optional, without it the client connects to the single node on `Host` and `HttpPort`
+	Cluster *ClusterOptions `mapstructure:"cluster"`
+	// KeepAliveInterval and KeepAliveTimeout detect the dead connections, the client reconnects (and rediscovers the
+	// cluster) on the next operation after a dead connection.
+	KeepAliveInterval time.Duration `mapstructure:"keepAliveInterval"`
+	KeepAliveTimeout  time.Duration `mapstructure:"keepAliveTimeout"`
+	Retry             *RetryOptions `mapstructure:"retry"`
+	// Metadata is optional, without it the event metadata is encoded as json
+	Metadata *MetadataOptions `mapstructure:"metadata"`
+	// Snapshot is optional, without it the aggregates are loaded by replaying their whole stream
+	Snapshot *SnapshotOptions `mapstructure:"snapshot"`
+	// Rebuild is optional, without it the read models are rebuilt with the default rate and batch size
+	Rebuild *RebuildOptions `mapstructure:"rebuild"`
+	// Conflict is optional, without it a concurrent append to the stream of an updated aggregate fails the update
+	Conflict *ConflictOptions `mapstructure:"conflict"`
+}
+
+type MetadataEncoding string
+
+const (
+	// JsonMetadataEncoding is readable by the eventstoredb ui and the server side projections, so the streams can be
+	// filtered by the metadata (e.g. `$correlationId`)
+	JsonMetadataEncoding MetadataEncoding = "json"
+	// BinaryMetadataEncoding is compact and keeps the value types, but it is opaque for the eventstoredb tooling
+	BinaryMetadataEncoding MetadataEncoding = "binary"
+)
+
+// MetadataOptions is the encoding of the event metadata on write, the events are read with both of the encodings
+// regardless of this option, so the encoding can be changed on an existing store.
+type MetadataOptions struct {
+	Encoding MetadataEncoding `mapstructure:"encoding"`
+}
+
+func (m *MetadataOptions) GetEncoding() MetadataEncoding {
+	if m == nil || m.Encoding == "" {
+		return JsonMetadataEncoding
+	}
+
+	return m.Encoding
+}
+
+// SnapshotOptions is the snapshot frequency of the aggregates, a snapshot of an aggregate is stored every `Frequency`
+// events of its stream. Only the aggregates with a continuation snapshot are snapshotted.
+type SnapshotOptions struct {
+	// Frequency is the default number of the events between the snapshots, zero disables the snapshots
+	Frequency int64 `mapstructure:"frequency"`
+	// Aggregates overrides the frequency of the aggregate types, they are keyed by their stream category, e.g. `order`
+	Aggregates map[string]*AggregateSnapshotOptions `mapstructure:"aggregates"`
+}
+
+type AggregateSnapshotOptions struct {
+	// Frequency is the number of the events between the snapshots of the aggregate type, zero disables its snapshots
+	Frequency int64 `mapstructure:"frequency"`
+}
+
+// GetFrequency returns the snapshot frequency of an aggregate type by its stream category, zero means the aggregate
+// type is not snapshotted
+func (s *SnapshotOptions) GetFrequency(category string) int64 {
+	if s == nil {
+		return 0
+	}
+
+	frequency := s.Frequency
+	for name, aggregate := range s.Aggregates {
+		if aggregate != nil && strings.EqualFold(name, category) {
+			frequency = aggregate.Frequency
+		}
+	}
+
+	if frequency < 0 {
+		return 0
+	}
+
+	return frequency
+}
+
+type ConflictStrategy string
+
+const (
+	// FailConflictStrategy fails the update with a conflict error
+	FailConflictStrategy ConflictStrategy = "fail"
+	// RetryConflictStrategy reloads the aggregate and runs the update again, so its invariants are checked against the
+	// concurrent events
+	RetryConflictStrategy ConflictStrategy = "retry"
+	// MergeConflictStrategy appends the uncommitted events after the concurrent events without running the update
+	// again when the aggregate reports they commute, otherwise it reloads the aggregate and runs the update again
+	MergeConflictStrategy ConflictStrategy = "merge"
+)
+
+// ConflictOptions is the resolution of the optimistic concurrency conflicts of the aggregate updates, an update fails
+// with a conflict error when its retries are exhausted.
+type ConflictOptions struct {
+	// Strategy is the default strategy of the aggregate types
+	Strategy ConflictStrategy `mapstructure:"strategy"`
+	// MaxRetries is the default number of the resolutions of an update
+	MaxRetries int `mapstructure:"maxRetries"`
+	// Aggregates overrides the options of the aggregate types, they are keyed by their stream category, e.g. `order`
+	Aggregates map[string]*AggregateConflictOptions `mapstructure:"aggregates"`
+}
+
+type AggregateConflictOptions struct {
+	Strategy   ConflictStrategy `mapstructure:"strategy"`
+	MaxRetries int              `mapstructure:"maxRetries"`
+}
+
+const defaultConflictMaxRetries = 3
+
+// GetStrategy returns the conflict strategy of an aggregate type by its stream category
+func (c *ConflictOptions) GetStrategy(category string) ConflictStrategy {
+	if c == nil {
+		return FailConflictStrategy
+	}
+
+	strategy := c.Strategy
+	if aggregate := c.aggregate(category); aggregate != nil && aggregate.Strategy != "" {
+		strategy = aggregate.Strategy
+	}
+
+	if strategy == "" {
+		return FailConflictStrategy
+	}
+
+	return strategy
+}
+
+// GetMaxRetries returns the max number of the conflict resolutions of an update of an aggregate type by its stream
+// category
+func (c *ConflictOptions) GetMaxRetries(category string) int {
+	if c == nil {
+		return defaultConflictMaxRetries
+	}
+
+	maxRetries := c.MaxRetries
+	if aggregate := c.aggregate(category); aggregate != nil && aggregate.MaxRetries > 0 {
+		maxRetries = aggregate.MaxRetries
+	}
+
+	if maxRetries <= 0 {
+		return defaultConflictMaxRetries
+	}
+
+	return maxRetries
+}
+
+func (c *ConflictOptions) aggregate(category string) *AggregateConflictOptions {
+	for name, aggregate := range c.Aggregates {
+		if aggregate != nil && strings.EqualFold(name, category) {
+			return aggregate
+		}
+	}
+
+	return nil
+}
+
+// https://developers.eventstore.com/clients/grpc/#connection-string
+// https://developers.eventstore.com/server/v20.10/cluster.html#cluster-with-gossip-seeds
+
+type ClusterOptions struct {
+	// GossipSeeds are the cluster nodes in `host:port` form used for discovering the cluster members
+	GossipSeeds []string `mapstructure:"gossipSeeds"`
+	// DnsDiscover uses the `Host` as a dns name that resolves to the cluster nodes
+	DnsDiscover bool `mapstructure:"dnsDiscover"`
+	// NodePreference is one of `leader`, `follower`, `random` or `readOnlyReplica`
+	NodePreference      string        `mapstructure:"nodePreference"`
+	MaxDiscoverAttempts int           `mapstructure:"maxDiscoverAttempts"`
+	DiscoveryInterval   time.Duration `mapstructure:"discoveryInterval"`
+	GossipTimeout       time.Duration `mapstructure:"gossipTimeout"`
+}
+
+// RebuildOptions limits the replay of the events for rebuilding a read model, so the rebuild doesn't overload the
+// event store and the database of the read model while they serve the live traffic.
+type RebuildOptions struct {
+	// EventsPerSecond is the max rate of the replayed events
+	EventsPerSecond int `mapstructure:"eventsPerSecond"`
+	// BatchSize is the number of the events of each read of `$all`
+	BatchSize uint64 `mapstructure:"batchSize"`
+}
+
+const (
+	defaultRebuildEventsPerSecond = 500
+	defaultRebuildBatchSize       = 500
+)
+
+func (r *RebuildOptions) GetEventsPerSecond() int {
+	if r == nil || r.EventsPerSecond <= 0 {
+		return defaultRebuildEventsPerSecond
+	}
+
+	return r.EventsPerSecond
+}
+
+func (r *RebuildOptions) GetBatchSize() uint64 {
+	if r == nil || r.BatchSize == 0 {
+		return defaultRebuildBatchSize
+	}
+
+	return r.BatchSize
+}
+
+// RetryOptions is the retry policy of the event store operations on the transient failures (e.g. a leader change or
+// an unavailable node).
+type RetryOptions struct {
+	MaxAttempts  int           `mapstructure:"maxAttempts"`
+	InitialDelay time.Duration `mapstructure:"initialDelay"`
+	MaxDelay     time.Duration `mapstructure:"maxDelay"`
+}
+
+const (
+	defaultRetryMaxAttempts    = 3
+	defaultRetryInitialDelay   = 200 * time.Millisecond
+	defaultRetryMaxDelay       = 2 * time.Second
+	defaultResubscribeDelay    = time.Second
+	defaultResubscribeMaxDelay = 30 * time.Second
+)
+
+func (r *RetryOptions) GetMaxAttempts() int {
+	if r == nil || r.MaxAttempts <= 0 {
+		return defaultRetryMaxAttempts
+	}
+
+	return r.MaxAttempts
+}
+
+func (r *RetryOptions) GetInitialDelay() time.Duration {
+	if r == nil || r.InitialDelay <= 0 {
+		return defaultRetryInitialDelay
+	}
+
+	return r.InitialDelay
+}
+
+func (r *RetryOptions) GetMaxDelay() time.Duration {
+	if r == nil || r.MaxDelay <= 0 {
+		return defaultRetryMaxDelay
+	}
+
+	return r.MaxDelay
 }
 
 // https://developers.eventstore.com/server/v20.10/networking.html#http-configuration
 // https://developers.eventstore.com/clients/grpc/#connection-string
 
 func (e *EventStoreDbOptions) GrpcEndPoint() string {
-	return fmt.Sprintf("esdb://%s:%d?tls=false", e.Host, e.HttpPort)
+	scheme := "esdb"
+	hosts := fmt.Sprintf("%s:%d", e.Host, e.HttpPort)
+
+	if e.Cluster != nil {
+		if e.Cluster.DnsDiscover {
+			scheme = "esdb+discover"
+		} else if len(e.Cluster.GossipSeeds) > 0 {
+			hosts = strings.Join(e.Cluster.GossipSeeds, ",")
+		}
+	}
+
+	return fmt.Sprintf("%s://%s?%s", scheme, hosts, e.connectionSettings())
+}
+
+func (e *EventStoreDbOptions) connectionSettings() string {
+	// the settings order is stable for having a deterministic connection string
+	settings := []string{"tls=false"}
+	add := func(key string, value string) {
+		settings = append(settings, fmt.Sprintf("%s=%s", key, url.QueryEscape(value)))
+	}
+
+	if e.Cluster != nil {
+		if e.Cluster.NodePreference != "" {
+			add("nodePreference", e.Cluster.NodePreference)
+		}
+		if e.Cluster.MaxDiscoverAttempts > 0 {
+			add("maxDiscoverAttempts", strconv.Itoa(e.Cluster.MaxDiscoverAttempts))
+		}
+		if e.Cluster.DiscoveryInterval > 0 {
+			add("discoveryInterval", strconv.FormatInt(e.Cluster.DiscoveryInterval.Milliseconds(), 10))
+		}
+		if e.Cluster.GossipTimeout > 0 {
+			add("gossipTimeout", strconv.FormatInt(int64(e.Cluster.GossipTimeout.Seconds()), 10))
+		}
+	}
+
+	if e.KeepAliveInterval != 0 {
+		add("keepAliveInterval", strconv.FormatInt(e.KeepAliveInterval.Milliseconds(), 10))
+	}
+	if e.KeepAliveTimeout != 0 {
+		add("keepAliveTimeout", strconv.FormatInt(e.KeepAliveTimeout.Milliseconds(), 10))
+	}
+
+	return strings.Join(settings, "&")
 }
 
 // https://developers.eventstore.com/clients/dotnet/21.2/#connect-to-eventstoredb
@@ -41,12 +325,39 @@ func (e *EventStoreDbOptions) TcpEndPoint() string {
 // https://developers.eventstore.com/clients/http-api/v5
 
 func (e *EventStoreDbOptions) HttpEndPoint() string {
-	return fmt.Sprintf("http://%s:%d", e.Host, e.Host)
+	return fmt.Sprintf("http://%s:%d", e.Host, e.HttpPort)
 }
 
 type Subscription struct {
 	Prefix         []string `mapstructure:"prefix"         validate:"required"`
 	SubscriptionId string   `mapstructure:"subscriptionId" validate:"required"`
+	// ResubscribeDelay is the initial delay before resubscribing after a subscription drop, it doubles on each
+	// consecutive failure up to `MaxResubscribeDelay`.
+	ResubscribeDelay    time.Duration `mapstructure:"resubscribeDelay"`
+	MaxResubscribeDelay time.Duration `mapstructure:"maxResubscribeDelay"`
+}
+
+// ResubscribeBackoff returns the delay before the resubscribe `attempt` (zero based).
+func (s *Subscription) ResubscribeBackoff(attempt int) time.Duration {
+	delay := defaultResubscribeDelay
+	maxDelay := defaultResubscribeMaxDelay
+
+	if s != nil && s.ResubscribeDelay > 0 {
+		delay = s.ResubscribeDelay
+	}
+	if s != nil && s.MaxResubscribeDelay > 0 {
+		maxDelay = s.MaxResubscribeDelay
+	}
+
+	for i := 0; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > maxDelay {
+		return maxDelay
+	}
+
+	return delay
 }
 
 func ProvideConfig(environment environment.Environment) (*EventStoreDbOptions, error) {
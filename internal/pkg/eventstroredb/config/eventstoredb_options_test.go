@@ -0,0 +1,107 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GrpcEndPoint_Single_Node(t *testing.T) {
+	options := &EventStoreDbOptions{Host: "localhost", HttpPort: 2113}
+
+	assert.Equal(t, "esdb://localhost:2113?tls=false", options.GrpcEndPoint())
+}
+
+func Test_GrpcEndPoint_Cluster(t *testing.T) {
+	options := &EventStoreDbOptions{
+		Host:     "localhost",
+		HttpPort: 2113,
+		Cluster: &ClusterOptions{
+			GossipSeeds:         []string{"node1:2113", "node2:2113", "node3:2113"},
+			NodePreference:      "follower",
+			MaxDiscoverAttempts: 5,
+			DiscoveryInterval:   500 * time.Millisecond,
+			GossipTimeout:       3 * time.Second,
+		},
+		KeepAliveInterval: 15 * time.Second,
+		KeepAliveTimeout:  5 * time.Second,
+	}
+
+	settings, err := esdb.ParseConnectionString(options.GrpcEndPoint())
+	require.NoError(t, err)
+
+	assert.Len(t, settings.GossipSeeds, 3)
+	assert.Equal(t, esdb.NodePreference_Follower, settings.NodePreference)
+	assert.Equal(t, 5, settings.MaxDiscoverAttempts)
+	assert.Equal(t, 500, settings.DiscoveryInterval)
+	assert.Equal(t, 3, settings.GossipTimeout)
+	assert.Equal(t, 15*time.Second, settings.KeepAliveInterval)
+	assert.Equal(t, 5*time.Second, settings.KeepAliveTimeout)
+	assert.True(t, settings.DisableTLS)
+}
+
+func Test_GrpcEndPoint_Dns_Discover(t *testing.T) {
+	options := &EventStoreDbOptions{
+		Host:     "esdb.cluster.local",
+		HttpPort: 2113,
+		Cluster:  &ClusterOptions{DnsDiscover: true},
+	}
+
+	settings, err := esdb.ParseConnectionString(options.GrpcEndPoint())
+	require.NoError(t, err)
+
+	assert.True(t, settings.DnsDiscover)
+}
+
+func Test_Resubscribe_Backoff(t *testing.T) {
+	subscription := &Subscription{
+		ResubscribeDelay:    time.Second,
+		MaxResubscribeDelay: 3 * time.Second,
+	}
+
+	assert.Equal(t, time.Second, subscription.ResubscribeBackoff(0))
+	assert.Equal(t, 2*time.Second, subscription.ResubscribeBackoff(1))
+	assert.Equal(t, 3*time.Second, subscription.ResubscribeBackoff(2))
+	assert.Equal(t, defaultResubscribeDelay, (*Subscription)(nil).ResubscribeBackoff(0))
+}
+
+func Test_Snapshot_Frequency(t *testing.T) {
+	options := &SnapshotOptions{
+		Frequency: 100,
+		Aggregates: map[string]*AggregateSnapshotOptions{
+			"order":    {Frequency: 50},
+			"giftcard": {Frequency: 0},
+		},
+	}
+
+	assert.Equal(t, int64(50), options.GetFrequency("order"))
+	assert.Equal(t, int64(0), options.GetFrequency("giftcard"))
+	assert.Equal(t, int64(100), options.GetFrequency("orderdraft"))
+
+	var disabled *SnapshotOptions
+	assert.Equal(t, int64(0), disabled.GetFrequency("order"))
+}
+
+func Test_Conflict_Options(t *testing.T) {
+	options := &ConflictOptions{
+		Strategy:   RetryConflictStrategy,
+		MaxRetries: 5,
+		Aggregates: map[string]*AggregateConflictOptions{
+			"order":    {Strategy: MergeConflictStrategy},
+			"giftcard": {Strategy: FailConflictStrategy, MaxRetries: 1},
+		},
+	}
+
+	assert.Equal(t, MergeConflictStrategy, options.GetStrategy("Order"))
+	assert.Equal(t, 5, options.GetMaxRetries("order"))
+	assert.Equal(t, FailConflictStrategy, options.GetStrategy("giftcard"))
+	assert.Equal(t, 1, options.GetMaxRetries("giftcard"))
+	assert.Equal(t, RetryConflictStrategy, options.GetStrategy("orderdraft"))
+
+	var disabled *ConflictOptions
+	assert.Equal(t, FailConflictStrategy, disabled.GetStrategy("order"))
+	assert.Equal(t, 3, disabled.GetMaxRetries("order"))
+}
@@ -0,0 +1,44 @@
+package errors
+
+import (
+	"fmt"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+)
+
+type concurrencyConflictError struct {
+	customErrors.ConflictError
+}
+
+type ConcurrencyConflictError interface {
+	customErrors.ConflictError
+	IsConcurrencyConflictError() bool
+}
+
+func NewConcurrencyConflictError(err error, streamId string) error {
+	conflict := customErrors.NewConflictErrorWrap(
+		err,
+		fmt.Sprintf("stream %s is changed by a concurrent update", streamId),
+	)
+	customErr := customErrors.GetCustomError(conflict)
+	br := &concurrencyConflictError{
+		ConflictError: customErr.(customErrors.ConflictError),
+	}
+
+	return errors.WithStackIf(br)
+}
+
+func (err *concurrencyConflictError) IsConcurrencyConflictError() bool {
+	return true
+}
+
+func IsConcurrencyConflictError(err error) bool {
+	var an ConcurrencyConflictError
+	if errors.As(err, &an) {
+		return an.IsConcurrencyConflictError()
+	}
+
+	return false
+}
@@ -0,0 +1,206 @@
+package eventstroredb
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/changefeed"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb/config"
+	esErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb/errors"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+)
+
+// maxChangeFeedScans limits the batches of `$all` which are read for a page, so a page of a feed which has only a few
+// streams in a busy `$all` returns with the skipped position as its cursor
+const maxChangeFeedScans = 10
+
+type readAllFunc func(ctx context.Context, from esdb.AllPosition, count uint64) ([]*esdb.ResolvedEvent, error)
+
+type esdbChangeFeed struct {
+	readAll            readAllFunc
+	metadataSerializer serializer.MetadataSerializer
+	prefixes           []string
+}
+
+// NewEsdbChangeFeed reads the events of the subscription stream prefixes from `$all` as the change feed of the
+// service, the cursor is the commit and the prepare position of an event in `$all`
+func NewEsdbChangeFeed(
+	client *esdb.Client,
+	esdbSerializer *EsdbSerializer,
+	retryPolicy RetryPolicy,
+	cfg *config.EventStoreDbOptions,
+) changefeed.ChangeFeed {
+	var prefixes []string
+	if cfg.Subscription != nil {
+		prefixes = cfg.Subscription.Prefix
+	}
+
+	return &esdbChangeFeed{
+		readAll:            newEsdbReadAll(client, esdbSerializer, retryPolicy, esdb.Forwards),
+		metadataSerializer: esdbSerializer.metadataSerializer,
+		prefixes:           prefixes,
+	}
+}
+
+// newEsdbReadAll reads a batch of `$all` from a position with the retries of the transient failures
+func newEsdbReadAll(
+	client *esdb.Client,
+	esdbSerializer *EsdbSerializer,
+	retryPolicy RetryPolicy,
+	direction esdb.Direction,
+) readAllFunc {
+	return func(ctx context.Context, from esdb.AllPosition, count uint64) ([]*esdb.ResolvedEvent, error) {
+		var events []*esdb.ResolvedEvent
+		err := retryPolicy.Execute(ctx, "ReadAll", func() error {
+			stream, err := client.ReadAll(ctx, esdb.ReadAllOptions{Direction: direction, From: from}, count)
+			if err != nil {
+				return err
+			}
+			defer stream.Close()
+
+			events, err = esdbSerializer.EsdbReadStreamToResolvedEvents(stream)
+
+			return err
+		})
+
+		return events, err
+	}
+}
+
+func (e *esdbChangeFeed) Read(ctx context.Context, cursor string, limit int) (*changefeed.ChangePage, error) {
+	var from esdb.AllPosition = esdb.Start{}
+	var after *esdb.Position
+
+	if cursor != "" {
+		position, err := decodeEsdbCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		from, after = position, &position
+	}
+
+	page := &changefeed.ChangePage{Changes: []*changefeed.Change{}, Cursor: cursor}
+
+	for scan := 0; scan < maxChangeFeedScans; scan++ {
+		// the read of `$all` from a position includes the event of the position, so one more event is read
+		events, err := e.readAll(ctx, from, uint64(limit)+1)
+		if err != nil {
+			return nil, errors.WithMessage(esErrors.NewReadStreamError(err), "error in reading the change feed")
+		}
+
+		for _, event := range events {
+			recorded := event.OriginalEvent()
+			if after != nil && recorded.Position == *after {
+				continue
+			}
+
+			if len(page.Changes) == limit {
+				page.HasMore = true
+
+				return page, nil
+			}
+
+			position := recorded.Position
+			from, after = position, &position
+			page.Cursor = encodeEsdbCursor(position)
+
+			if !isSubscribedEvent(recorded, e.prefixes) {
+				continue
+			}
+
+			change, err := e.change(recorded, page.Cursor)
+			if err != nil {
+				return nil, err
+			}
+
+			page.Changes = append(page.Changes, change)
+		}
+
+		if len(events) <= limit {
+			return page, nil
+		}
+	}
+
+	page.HasMore = true
+
+	return page, nil
+}
+
+// isSubscribedEvent skips the system events and the events of the streams which are not in the subscription prefixes
+func isSubscribedEvent(recorded *esdb.RecordedEvent, prefixes []string) bool {
+	if strings.HasPrefix(recorded.EventType, "$") || strings.HasPrefix(recorded.StreamID, "$") {
+		return false
+	}
+
+	if len(prefixes) == 0 {
+		return true
+	}
+
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(recorded.StreamID, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e *esdbChangeFeed) change(recorded *esdb.RecordedEvent, cursor string) (*changefeed.Change, error) {
+	change := &changefeed.Change{
+		Id:         recorded.EventID.String(),
+		Type:       recorded.EventType,
+		Stream:     recorded.StreamID,
+		Cursor:     cursor,
+		OccurredAt: recorded.CreatedDate,
+		Data:       recorded.Data,
+	}
+
+	if !json.Valid(change.Data) {
+		data, err := json.Marshal(string(recorded.Data))
+		if err != nil {
+			return nil, errors.WrapIf(err, "error in marshaling the data of the event")
+		}
+
+		change.Data = data
+	}
+
+	if len(recorded.UserMetadata) > 0 {
+		meta, err := e.metadataSerializer.Deserialize(recorded.UserMetadata)
+		if err != nil {
+			return nil, errors.WrapIf(err, "error in deserializing the metadata of the event")
+		}
+
+		change.Metadata = meta
+	}
+
+	return change, nil
+}
+
+func encodeEsdbCursor(position esdb.Position) string {
+	return changefeed.EncodeCursor(
+		strconv.FormatUint(position.Commit, 10),
+		strconv.FormatUint(position.Prepare, 10),
+	)
+}
+
+func decodeEsdbCursor(cursor string) (esdb.Position, error) {
+	parts, err := changefeed.DecodeCursor(cursor, 2)
+	if err != nil {
+		return esdb.Position{}, err
+	}
+
+	commit, commitErr := strconv.ParseUint(parts[0], 10, 64)
+	prepare, prepareErr := strconv.ParseUint(parts[1], 10, 64)
+	if commitErr != nil || prepareErr != nil {
+		return esdb.Position{}, customErrors.NewBadRequestError("cursor is not valid")
+	}
+
+	return esdb.Position{Commit: commit, Prepare: prepare}, nil
+}
@@ -0,0 +1,118 @@
+package eventstroredb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb/config"
+
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAll is `$all` of the tests, a read from a position includes the event of the position like the eventstoredb
+type fakeAll []*esdb.ResolvedEvent
+
+func (f fakeAll) readAll(ctx context.Context, from esdb.AllPosition, count uint64) ([]*esdb.ResolvedEvent, error) {
+	start := 0
+	if position, ok := from.(esdb.Position); ok {
+		for start < len(f) && f[start].Event.Position.Commit < position.Commit {
+			start++
+		}
+	}
+
+	end := start + int(count)
+	if end > len(f) {
+		end = len(f)
+	}
+
+	return f[start:end], nil
+}
+
+func newFakeAll(streams ...string) fakeAll {
+	var events fakeAll
+	for i, stream := range streams {
+		eventType := "OrderCreatedV1"
+		if stream[0] == '$' {
+			eventType = "$metadata"
+		}
+
+		events = append(events, &esdb.ResolvedEvent{Event: &esdb.RecordedEvent{
+			EventID:   uuid.Must(uuid.NewV4()),
+			EventType: eventType,
+			StreamID:  stream,
+			Position:  esdb.Position{Commit: uint64(i+1) * 100, Prepare: uint64(i+1) * 100},
+			Data:      []byte(`{"orderId":"1"}`),
+		}})
+	}
+
+	return events
+}
+
+func newTestChangeFeed(all fakeAll) *esdbChangeFeed {
+	return &esdbChangeFeed{
+		readAll:            all.readAll,
+		metadataSerializer: newTestMetadataEncoder(config.JsonMetadataEncoding),
+		prefixes:           []string{"order-"},
+	}
+}
+
+func Test_Esdb_Change_Feed_Pages(t *testing.T) {
+	feed := newTestChangeFeed(newFakeAll("order-1", "$settings", "product-1", "order-2", "order-3"))
+
+	page, err := feed.Read(context.Background(), "", 2)
+	require.NoError(t, err)
+
+	require.Len(t, page.Changes, 2)
+	assert.Equal(t, "order-1", page.Changes[0].Stream)
+	assert.Equal(t, "order-2", page.Changes[1].Stream)
+	assert.Equal(t, page.Changes[1].Cursor, page.Cursor)
+	assert.True(t, page.HasMore)
+
+	page, err = feed.Read(context.Background(), page.Cursor, 2)
+	require.NoError(t, err)
+
+	require.Len(t, page.Changes, 1)
+	assert.Equal(t, "order-3", page.Changes[0].Stream)
+	assert.JSONEq(t, `{"orderId":"1"}`, string(page.Changes[0].Data))
+	assert.False(t, page.HasMore)
+
+	// there is no new change, so the cursor is not changed
+	last, err := feed.Read(context.Background(), page.Cursor, 2)
+	require.NoError(t, err)
+
+	assert.Empty(t, last.Changes)
+	assert.Equal(t, page.Cursor, last.Cursor)
+}
+
+func Test_Esdb_Change_Feed_Skips_Other_Streams_Over_Scans(t *testing.T) {
+	streams := make([]string, 0, maxChangeFeedScans*2+1)
+	for i := 0; i < maxChangeFeedScans*2; i++ {
+		streams = append(streams, "product-1")
+	}
+	feed := newTestChangeFeed(newFakeAll(append(streams, "order-1")...))
+
+	page, err := feed.Read(context.Background(), "", 1)
+	require.NoError(t, err)
+
+	// the page returns after the max scans with the position of the skipped events
+	assert.Empty(t, page.Changes)
+	assert.True(t, page.HasMore)
+	assert.NotEmpty(t, page.Cursor)
+
+	for page.HasMore && len(page.Changes) == 0 {
+		page, err = feed.Read(context.Background(), page.Cursor, 1)
+		require.NoError(t, err)
+	}
+
+	require.Len(t, page.Changes, 1)
+	assert.Equal(t, "order-1", page.Changes[0].Stream)
+}
+
+func Test_Esdb_Change_Feed_Invalid_Cursor(t *testing.T) {
+	_, err := newTestChangeFeed(nil).Read(context.Background(), "invalid", 1)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,24 @@
+package eventstroredb
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/changefeed"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models"
+)
+
+// EsdbChangeNotifier is a projection which wakes up the long polls of the change feed on the events of the
+// subscription, so the long polls don't wait for their next read of `$all`
+type EsdbChangeNotifier struct {
+	notifier changefeed.ChangeNotifier
+}
+
+func NewEsdbChangeNotifier(notifier changefeed.ChangeNotifier) *EsdbChangeNotifier {
+	return &EsdbChangeNotifier{notifier: notifier}
+}
+
+func (n *EsdbChangeNotifier) ProcessEvent(ctx context.Context, streamEvent *models.StreamEvent) error {
+	n.notifier.Notify()
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package eventstroredb
+
+import (
+	"testing"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/domain"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer/json"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/upcasting"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb/config"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// upcastedOrderCreatedV1 renamed its `order_id` field in the schema version 2
+type upcastedOrderCreatedV1 struct {
+	*domain.DomainEvent
+	OrderId string `json:"orderId"`
+}
+
+func (u *upcastedOrderCreatedV1) SchemaVersion() int {
+	return 2
+}
+
+func newTestEsdbSerializer(t *testing.T) *EsdbSerializer {
+	t.Helper()
+
+	registry, err := upcasting.NewUpcasterRegistry([]upcasting.Upcaster{
+		upcasting.RenameField[*upcastedOrderCreatedV1](1, "order_id", "orderId"),
+	})
+	require.NoError(t, err)
+
+	return NewEsdbSerializer(
+		json.NewDefaultMetadataJsonSerializer(json.NewDefaultJsonSerializer()),
+		json.NewDefaultEventJsonSerializer(json.NewDefaultJsonSerializer()),
+		registry,
+		&config.EventStoreDbOptions{},
+	)
+}
+
+func Test_Esdb_Serializer_Upcasts_Old_Schema_Versions(t *testing.T) {
+	serializer := newTestEsdbSerializer(t)
+
+	// the events which are stored before the schema version metadata have the first schema version
+	event, meta, err := serializer.Deserialize(&esdb.ResolvedEvent{Event: &esdb.RecordedEvent{
+		EventID:     uuid.Must(uuid.NewV4()),
+		EventType:   typeMapper.GetGenericTypeNameByT[*upcastedOrderCreatedV1](),
+		ContentType: "application/json",
+		Data:        []byte(`{"order_id":"order-1"}`),
+	}})
+	require.NoError(t, err)
+
+	require.IsType(t, &upcastedOrderCreatedV1{}, event)
+	assert.Equal(t, "order-1", event.(*upcastedOrderCreatedV1).OrderId)
+	assert.Equal(t, 2, eventSchemaVersion(meta))
+}
+
+func Test_Esdb_Serializer_Round_Trip_Of_Current_Schema_Version(t *testing.T) {
+	serializer := newTestEsdbSerializer(t)
+
+	created := &upcastedOrderCreatedV1{OrderId: "order-1"}
+	created.DomainEvent = domain.NewDomainEvent(typeMapper.GetTypeName(created))
+
+	eventData, err := serializer.Serialize(created, metadata.Metadata{})
+	require.NoError(t, err)
+
+	streamEvent, err := serializer.ResolvedEventToStreamEvent(&esdb.ResolvedEvent{Event: &esdb.RecordedEvent{
+		EventID:      eventData.EventID,
+		EventType:    eventData.EventType,
+		ContentType:  "application/json",
+		Data:         eventData.Data,
+		UserMetadata: eventData.Metadata,
+	}})
+	require.NoError(t, err)
+
+	assert.Equal(t, "order-1", streamEvent.Event.(*upcastedOrderCreatedV1).OrderId)
+	assert.Equal(t, 2, eventSchemaVersion(streamEvent.Metadata))
+}
@@ -12,6 +12,8 @@ import (
 	readPosition "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_position/read_position"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_position/truncatePosition"
 	expectedStreamVersion "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_version"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/upcasting"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb/config"
 	esErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb/errors"
 	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
 
@@ -25,18 +27,80 @@ import (
 type EsdbSerializer struct {
 	metadataSerializer serializer.MetadataSerializer
 	eventSerializer    serializer.EventSerializer
+	upcasters          upcasting.UpcasterRegistry
 }
 
 func NewEsdbSerializer(
 	metadataSerializer serializer.MetadataSerializer,
 	eventSerializer serializer.EventSerializer,
+	upcasters upcasting.UpcasterRegistry,
+	cfg *config.EventStoreDbOptions,
 ) *EsdbSerializer {
 	return &EsdbSerializer{
-		metadataSerializer: metadataSerializer,
+		metadataSerializer: newMetadataEncoder(metadataSerializer, cfg.Metadata.GetEncoding()),
 		eventSerializer:    eventSerializer,
+		upcasters:          upcasters,
 	}
 }
 
+// deserialize returns the event of the current schema, the payload of an event which is stored with an old schema
+// version is upcasted before it is deserialized, and the schema version of its metadata is set to the upcasted version
+func (e *EsdbSerializer) deserialize(
+	recordedEvent *esdb.RecordedEvent,
+) (domain.IDomainEvent, metadata.Metadata, error) {
+	meta, err := e.metadataSerializer.Deserialize(recordedEvent.UserMetadata)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data := recordedEvent.Data
+	version := eventSchemaVersion(meta)
+
+	if e.upcasters != nil && e.upcasters.HasUpcaster(recordedEvent.EventType, version) {
+		if recordedEvent.ContentType != "application/json" {
+			return nil, nil, errors.Errorf(
+				"the event `%s` with the content type `%s` can't be upcasted",
+				recordedEvent.EventType,
+				recordedEvent.ContentType,
+			)
+		}
+
+		data, version, err = e.upcasters.Upcast(recordedEvent.EventType, version, data)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if meta == nil {
+			meta = metadata.Metadata{}
+		}
+		meta.Set(SchemaVersionMetadataKey, version)
+	}
+
+	event, err := e.eventSerializer.Deserialize(data, recordedEvent.EventType, recordedEvent.ContentType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return event, meta, nil
+}
+
+// eventMetadata returns a copy of the metadata with the content type and the schema version of the event
+func (e *EsdbSerializer) eventMetadata(
+	meta metadata.Metadata,
+	event interface{},
+	contentType string,
+) metadata.Metadata {
+	result := metadata.Metadata{}
+	for key, value := range meta {
+		result[key] = value
+	}
+
+	result.Set(ContentTypeMetadataKey, contentType)
+	result.Set(SchemaVersionMetadataKey, schemaVersion(event))
+
+	return result
+}
+
 func (e *EsdbSerializer) StreamEventToEventData(
 	streamEvent *models.StreamEvent,
 ) (esdb.EventData, error) {
@@ -45,7 +109,9 @@ func (e *EsdbSerializer) StreamEventToEventData(
 		return *new(esdb.EventData), err
 	}
 
-	metadataSerializationResult, err := e.metadataSerializer.Serialize(streamEvent.Metadata)
+	metadataSerializationResult, err := e.metadataSerializer.Serialize(
+		e.eventMetadata(streamEvent.Metadata, streamEvent.Event, eventSerializationResult.ContentType),
+	)
 	if err != nil {
 		return *new(esdb.EventData), err
 	}
@@ -139,16 +205,7 @@ func (e *EsdbSerializer) EsdbPositionToStreamReadPosition(
 func (e *EsdbSerializer) ResolvedEventToStreamEvent(
 	resolveEvent *esdb.ResolvedEvent,
 ) (*models.StreamEvent, error) {
-	deserializedEvent, err := e.eventSerializer.Deserialize(
-		resolveEvent.Event.Data,
-		resolveEvent.Event.EventType,
-		resolveEvent.Event.ContentType,
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	deserializedMeta, err := e.metadataSerializer.Deserialize(resolveEvent.Event.UserMetadata)
+	deserializedEvent, deserializedMeta, err := e.deserialize(resolveEvent.Event)
 	if err != nil {
 		return nil, err
 	}
@@ -200,7 +257,9 @@ func (e *EsdbSerializer) Serialize(
 		return nil, err
 	}
 
-	serializedMeta, err := e.metadataSerializer.Serialize(meta)
+	serializedMeta, err := e.metadataSerializer.Serialize(
+		e.eventMetadata(meta, data, serializedData.ContentType),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -224,7 +283,9 @@ func (e *EsdbSerializer) SerializeObject(
 		return nil, err
 	}
 
-	serializedMeta, err := e.metadataSerializer.Serialize(meta)
+	serializedMeta, err := e.metadataSerializer.Serialize(
+		e.eventMetadata(meta, data, serializedData.ContentType),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -242,49 +303,13 @@ func (e *EsdbSerializer) SerializeObject(
 func (e *EsdbSerializer) Deserialize(
 	resolveEvent *esdb.ResolvedEvent,
 ) (domain.IDomainEvent, metadata.Metadata, error) {
-	eventType := resolveEvent.Event.EventType
-	data := resolveEvent.Event.Data
-	userMeta := resolveEvent.Event.UserMetadata
-
-	payload, err := e.eventSerializer.Deserialize(
-		data,
-		eventType,
-		resolveEvent.Event.ContentType,
-	)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	meta, err := e.metadataSerializer.Deserialize(userMeta)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	return payload, meta, nil
+	return e.deserialize(resolveEvent.Event)
 }
 
 func (e *EsdbSerializer) DeserializeObject(
 	resolveEvent *esdb.ResolvedEvent,
 ) (interface{}, metadata.Metadata, error) {
-	eventType := resolveEvent.Event.EventType
-	data := resolveEvent.Event.Data
-	userMeta := resolveEvent.Event.UserMetadata
-
-	payload, err := e.eventSerializer.Deserialize(
-		data,
-		eventType,
-		resolveEvent.Event.ContentType,
-	)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	meta, err := e.metadataSerializer.Deserialize(userMeta)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	return payload, meta, nil
+	return e.deserialize(resolveEvent.Event)
 }
 
 func (e *EsdbSerializer) DomainEventToStreamEvent(
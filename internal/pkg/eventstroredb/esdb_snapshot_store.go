@@ -0,0 +1,125 @@
+package eventstroredb
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/store"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/snapshot"
+	streamName "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_name"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_position/truncatePosition"
+	expectedStreamVersion "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_version"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/utils"
+
+	"emperror.dev/errors"
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	attribute2 "go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// esdbSnapshotStore keeps the snapshots of an aggregate stream in its snapshot stream, e.g. `order_snapshot-{id}`. The
+// snapshot stream is truncated before its latest snapshot, so it doesn't grow with the aggregate stream.
+type esdbSnapshotStore struct {
+	log        logger.Logger
+	eventStore store.EventStore
+	serializer *SnapshotSerializer
+	tracer     trace.Tracer
+}
+
+func NewEsdbSnapshotStore(
+	log logger.Logger,
+	eventStore store.EventStore,
+	serializer *SnapshotSerializer,
+	tracer trace.Tracer,
+) store.SnapshotStore {
+	return &esdbSnapshotStore{
+		log:        log,
+		eventStore: eventStore,
+		serializer: serializer,
+		tracer:     tracer,
+	}
+}
+
+func (s *esdbSnapshotStore) Load(
+	ctx context.Context,
+	stream streamName.StreamName,
+) (*snapshot.Snapshot, error) {
+	ctx, span := s.tracer.Start(ctx, "esdbSnapshotStore.Load")
+	span.SetAttributes(attribute2.String("StreamId", stream.String()))
+	defer span.End()
+
+	streamEvents, err := s.eventStore.ReadEventsBackwardsFromEnd(stream.Snapshot(), 1, ctx)
+	if errors.Is(err, esdb.ErrStreamNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, utils.TraceErrStatusFromSpan(
+			span,
+			errors.WrapIff(
+				err,
+				"[esdbSnapshotStore_Load:ReadEventsBackwardsFromEnd] error in reading the snapshot of stream %s",
+				stream.String(),
+			),
+		)
+	}
+
+	if len(streamEvents) == 0 {
+		return nil, nil
+	}
+
+	snap, err := s.serializer.StreamEventToSnapshot(streamEvents[0])
+	if err != nil {
+		return nil, utils.TraceErrStatusFromSpan(span, err)
+	}
+
+	span.SetAttributes(attribute2.Int64("SnapshotVersion", snap.Version))
+
+	return snap, nil
+}
+
+func (s *esdbSnapshotStore) Save(ctx context.Context, snap *snapshot.Snapshot) error {
+	ctx, span := s.tracer.Start(ctx, "esdbSnapshotStore.Save")
+	span.SetAttributes(
+		attribute2.String("StreamId", snap.StreamName.String()),
+		attribute2.Int64("SnapshotVersion", snap.Version),
+	)
+	defer span.End()
+
+	snapshotStream := snap.StreamName.Snapshot()
+
+	result, err := s.eventStore.AppendEvents(
+		snapshotStream,
+		expectedStreamVersion.Any,
+		[]*models.StreamEvent{s.serializer.SnapshotToStreamEvent(snap)},
+		ctx,
+	)
+	if err != nil {
+		return utils.TraceErrStatusFromSpan(
+			span,
+			errors.WrapIff(
+				err,
+				"[esdbSnapshotStore_Save:AppendEvents] error in storing the snapshot of stream %s",
+				snap.StreamName.String(),
+			),
+		)
+	}
+
+	// the earlier snapshots are not read anymore, a failed truncation only keeps them until the next snapshot
+	_, err = s.eventStore.TruncateStream(
+		snapshotStream,
+		truncatePosition.FromInt64(int64(result.NextExpectedVersion)),
+		expectedStreamVersion.Any,
+		ctx,
+	)
+	if err != nil {
+		s.log.Warnf(
+			"[esdbSnapshotStore.Save] error in truncating the snapshot stream %s: %v",
+			snapshotStream.String(),
+			err,
+		)
+	}
+
+	return nil
+}
@@ -2,41 +2,52 @@ package eventstroredb
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"time"
 
-	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/events"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
-	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
 
 	"emperror.dev/errors"
 	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/gofrs/uuid"
+	"go.uber.org/fx"
 )
 
+// CheckpointModule provides the esdb backed SubscriptionCheckpointRepository, the checkpoints are kept in the
+// `$checkpoint_stream_{subscriptionId}` streams, another store like `postgresgorm.CheckpointModule` can be used instead
+var CheckpointModule = fx.Module( //nolint:gochecknoglobals
+	"esdbcheckpointfx",
+	fx.Provide(NewEsdbSubscriptionCheckpointRepository),
+)
+
+// checkpointEventType is the event type of the checkpoints, it is not a domain event of the type mapper, so the
+// checkpoints are serialized as plain json
+const checkpointEventType = "CheckpointStored"
+
 type esdbSubscriptionCheckpointRepository struct {
-	client        *esdb.Client
-	log           logger.Logger
-	esdbSerilizer *EsdbSerializer
+	client      *esdb.Client
+	log         logger.Logger
+	retryPolicy RetryPolicy
 }
 
 type CheckpointStored struct {
-	Position       uint64
-	SubscriptionId string
-	CheckpointAt   time.Time
-	*events.Event
+	Position       uint64    `json:"position"`
+	SubscriptionId string    `json:"subscriptionId"`
+	CheckpointAt   time.Time `json:"checkpointAt"`
 }
 
 func NewEsdbSubscriptionCheckpointRepository(
 	client *esdb.Client,
 	logger logger.Logger,
-	esdbSerializer *EsdbSerializer,
+	retryPolicy RetryPolicy,
 ) contracts.SubscriptionCheckpointRepository {
 	return &esdbSubscriptionCheckpointRepository{
-		client:        client,
-		log:           logger,
-		esdbSerilizer: esdbSerializer,
+		client:      client,
+		log:         logger,
+		retryPolicy: retryPolicy,
 	}
 }
 
@@ -46,13 +57,19 @@ func (e *esdbSubscriptionCheckpointRepository) Load(
 ) (uint64, error) {
 	streamName := getCheckpointStreamName(subscriptionId)
 
-	stream, err := e.client.ReadStream(
-		ctx,
-		streamName,
-		esdb.ReadStreamOptions{
-			Direction: esdb.Backwards,
-			From:      esdb.End{},
-		}, 1)
+	var stream *esdb.ReadStream
+	err := e.retryPolicy.Execute(ctx, "ReadStream", func() error {
+		var err error
+		stream, err = e.client.ReadStream(
+			ctx,
+			streamName,
+			esdb.ReadStreamOptions{
+				Direction: esdb.Backwards,
+				From:      esdb.End{},
+			}, 1)
+
+		return err
+	})
 
 	if errors.Is(err, esdb.ErrStreamNotFound) {
 		return 0, nil
@@ -60,6 +77,8 @@ func (e *esdbSubscriptionCheckpointRepository) Load(
 		return 0, errors.WrapIf(err, "db.ReadStream")
 	}
 
+	defer stream.Close()
+
 	event, err := stream.Recv()
 	if errors.Is(err, esdb.ErrStreamNotFound) {
 		return 0, errors.WrapIf(err, "stream.Recv")
@@ -71,19 +90,12 @@ func (e *esdbSubscriptionCheckpointRepository) Load(
 		return 0, errors.WrapIf(err, "stream.Recv")
 	}
 
-	deserialized, _, err := e.esdbSerilizer.DeserializeObject(event)
-	if err != nil {
-		return 0, err
-	}
-
-	v, ok := deserialized.(*CheckpointStored)
-	if !ok {
-		return 0, nil
+	checkpoint := &CheckpointStored{}
+	if err := json.Unmarshal(event.Event.Data, checkpoint); err != nil {
+		return 0, errors.WrapIf(err, "[esdbSubscriptionCheckpointRepository_Load] error in unmarshaling the checkpoint")
 	}
 
-	stream.Close()
-
-	return v.Position, nil
+	return checkpoint.Position, nil
 }
 
 func (e *esdbSubscriptionCheckpointRepository) Store(
@@ -95,20 +107,36 @@ func (e *esdbSubscriptionCheckpointRepository) Store(
 		SubscriptionId: subscriptionId,
 		Position:       position,
 		CheckpointAt:   time.Now(),
-		Event:          events.NewEvent(typeMapper.GetTypeName(&CheckpointStored{})),
 	}
 	streamName := getCheckpointStreamName(subscriptionId)
-	eventData, err := e.esdbSerilizer.SerializeObject(checkpoint, nil)
+
+	data, err := json.Marshal(checkpoint)
 	if err != nil {
-		return errors.WrapIf(err, "esdbSerilizer.Serialize")
+		return errors.WrapIf(err, "[esdbSubscriptionCheckpointRepository_Store] error in marshaling the checkpoint")
 	}
 
-	_, err = e.client.AppendToStream(
-		ctx,
-		streamName,
-		esdb.AppendToStreamOptions{ExpectedRevision: esdb.StreamExists{}},
-		*eventData,
-	)
+	id, err := uuid.NewV4()
+	if err != nil {
+		return errors.WrapIf(err, "[esdbSubscriptionCheckpointRepository_Store] error in creating the checkpoint id")
+	}
+
+	eventData := &esdb.EventData{
+		EventID:     id,
+		EventType:   checkpointEventType,
+		ContentType: esdb.JsonContentType,
+		Data:        data,
+	}
+
+	err = e.retryPolicy.Execute(ctx, "AppendToStream", func() error {
+		_, err := e.client.AppendToStream(
+			ctx,
+			streamName,
+			esdb.AppendToStreamOptions{ExpectedRevision: esdb.StreamExists{}},
+			*eventData,
+		)
+
+		return err
+	})
 
 	if errors.Is(err, esdb.ErrWrongExpectedStreamRevision) {
 		streamMeta := esdb.StreamMetadata{}
@@ -117,22 +145,30 @@ func (e *esdbSubscriptionCheckpointRepository) Store(
 		// WrongExpectedVersionException means that stream did not exist
 		// Set the checkpoint stream to have at most 1 event
 		// using stream metadata $maxCount property
-		_, err := e.client.SetStreamMetadata(
-			ctx,
-			streamName,
-			esdb.AppendToStreamOptions{ExpectedRevision: esdb.NoStream{}},
-			streamMeta)
+		err := e.retryPolicy.Execute(ctx, "SetStreamMetadata", func() error {
+			_, err := e.client.SetStreamMetadata(
+				ctx,
+				streamName,
+				esdb.AppendToStreamOptions{ExpectedRevision: esdb.NoStream{}},
+				streamMeta)
+
+			return err
+		})
 		if err != nil {
 			return errors.WrapIf(err, "client.SetStreamMetadata")
 		}
 
 		// append event again expecting stream to not exist
-		_, err = e.client.AppendToStream(
-			ctx,
-			streamName,
-			esdb.AppendToStreamOptions{ExpectedRevision: esdb.NoStream{}},
-			*eventData,
-		)
+		err = e.retryPolicy.Execute(ctx, "AppendToStream", func() error {
+			_, err := e.client.AppendToStream(
+				ctx,
+				streamName,
+				esdb.AppendToStreamOptions{ExpectedRevision: esdb.NoStream{}},
+				*eventData,
+			)
+
+			return err
+		})
 		if err != nil {
 			return err
 		}
@@ -144,5 +180,5 @@ func (e *esdbSubscriptionCheckpointRepository) Store(
 }
 
 func getCheckpointStreamName(subscriptionId string) string {
-	return fmt.Sprintf("$cehckpoint_stream_%s", subscriptionId)
+	return fmt.Sprintf("$checkpoint_stream_%s", subscriptionId)
 }
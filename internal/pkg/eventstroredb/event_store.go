@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/store"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models"
 	appendResult "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/append_result"
@@ -27,10 +28,12 @@ import (
 // https://developers.eventstore.com/clients/grpc/reading-events.html#reading-from-a-stream
 // https://developers.eventstore.com/clients/grpc/appending-events.html#append-your-first-event
 type eventStoreDbEventStore struct {
-	log        logger.Logger
-	client     *esdb.Client
-	serializer *EsdbSerializer
-	tracer     trace.Tracer
+	log         logger.Logger
+	client      *esdb.Client
+	serializer  *EsdbSerializer
+	tracer      trace.Tracer
+	retryPolicy RetryPolicy
+	enrichers   []MetadataEnricher
 }
 
 func NewEventStoreDbEventStore(
@@ -38,12 +41,16 @@ func NewEventStoreDbEventStore(
 	client *esdb.Client,
 	serializer *EsdbSerializer,
 	tracer trace.Tracer,
+	retryPolicy RetryPolicy,
+	enrichers []MetadataEnricher,
 ) store.EventStore {
 	return &eventStoreDbEventStore{
-		log:        log,
-		client:     client,
-		serializer: serializer,
-		tracer:     tracer,
+		log:         log,
+		client:      client,
+		serializer:  serializer,
+		tracer:      tracer,
+		retryPolicy: retryPolicy,
+		enrichers:   enrichers,
 	}
 }
 
@@ -55,14 +62,20 @@ func (e *eventStoreDbEventStore) StreamExists(
 	span.SetAttributes(attribute2.String("StreamName", streamName.String()))
 	defer span.End()
 
-	stream, err := e.client.ReadStream(
-		ctx,
-		streamName.String(),
-		esdb.ReadStreamOptions{
-			Direction: esdb.Backwards,
-			From:      esdb.End{},
-		},
-		1)
+	var stream *esdb.ReadStream
+	err := e.retryPolicy.Execute(ctx, "ReadStream", func() error {
+		var err error
+		stream, err = e.client.ReadStream(
+			ctx,
+			streamName.String(),
+			esdb.ReadStreamOptions{
+				Direction: esdb.Backwards,
+				From:      esdb.End{},
+			},
+			1)
+
+		return err
+	})
 	if err != nil {
 		return false, utils.TraceErrStatusFromSpan(
 			span,
@@ -78,6 +91,28 @@ func (e *eventStoreDbEventStore) StreamExists(
 	return stream != nil, nil
 }
 
+// enrich returns a copy of the stream event with the enriched metadata, the events of an aggregate share the same
+// metadata so it should not be changed in place.
+func (e *eventStoreDbEventStore) enrich(ctx context.Context, streamEvent *models.StreamEvent) *models.StreamEvent {
+	if len(e.enrichers) == 0 {
+		return streamEvent
+	}
+
+	meta := metadata.Metadata{}
+	for key, value := range streamEvent.Metadata {
+		meta[key] = value
+	}
+
+	enriched := *streamEvent
+	enriched.Metadata = meta
+
+	for _, enricher := range e.enrichers {
+		enricher.Enrich(ctx, &enriched)
+	}
+
+	return &enriched
+}
+
 func (e *eventStoreDbEventStore) AppendEvents(
 	streamName streamName.StreamName,
 	expectedVersion expectedStreamVersion.ExpectedStreamVersion,
@@ -90,7 +125,7 @@ func (e *eventStoreDbEventStore) AppendEvents(
 
 	var eventsData []esdb.EventData
 	linq.From(events).SelectT(func(s *models.StreamEvent) esdb.EventData {
-		data, err := e.serializer.StreamEventToEventData(s)
+		data, err := e.serializer.StreamEventToEventData(e.enrich(ctx, s))
 		if err != nil {
 			return *new(esdb.EventData)
 		}
@@ -99,15 +134,21 @@ func (e *eventStoreDbEventStore) AppendEvents(
 
 	var appendEventsResult *appendResult.AppendEventsResult
 
-	res, err := e.client.AppendToStream(
-		ctx,
-		streamName.String(),
-		esdb.AppendToStreamOptions{
-			ExpectedRevision: e.serializer.ExpectedStreamVersionToEsdbExpectedRevision(
-				expectedVersion,
-			),
-		},
-		eventsData...)
+	var res *esdb.WriteResult
+	err := e.retryPolicy.Execute(ctx, "AppendToStream", func() error {
+		var err error
+		res, err = e.client.AppendToStream(
+			ctx,
+			streamName.String(),
+			esdb.AppendToStreamOptions{
+				ExpectedRevision: e.serializer.ExpectedStreamVersionToEsdbExpectedRevision(
+					expectedVersion,
+				),
+			},
+			eventsData...)
+
+		return err
+	})
 	if err != nil {
 		return nil, utils.TraceErrStatusFromSpan(
 			span,
@@ -183,17 +224,23 @@ func (e *eventStoreDbEventStore) ReadEvents(
 	span.SetAttributes(attribute2.String("StreamName", streamName.String()))
 	defer span.End()
 
-	readStream, err := e.client.ReadStream(
-		ctx,
-		streamName.String(),
-		esdb.ReadStreamOptions{
-			Direction: esdb.Forwards,
-			From: e.serializer.StreamReadPositionToStreamPosition(
-				readPosition,
-			),
-			ResolveLinkTos: true,
-		},
-		count)
+	var readStream *esdb.ReadStream
+	err := e.retryPolicy.Execute(ctx, "ReadStream", func() error {
+		var err error
+		readStream, err = e.client.ReadStream(
+			ctx,
+			streamName.String(),
+			esdb.ReadStreamOptions{
+				Direction: esdb.Forwards,
+				From: e.serializer.StreamReadPositionToStreamPosition(
+					readPosition,
+				),
+				ResolveLinkTos: true,
+			},
+			count)
+
+		return err
+	})
 	if err != nil {
 		return nil, utils.TraceErrStatusFromSpan(
 			span,
@@ -276,17 +323,23 @@ func (e *eventStoreDbEventStore) ReadEventsBackwards(
 	span.SetAttributes(attribute2.String("StreamName", streamName.String()))
 	defer span.End()
 
-	readStream, err := e.client.ReadStream(
-		ctx,
-		streamName.String(),
-		esdb.ReadStreamOptions{
-			Direction: esdb.Backwards,
-			From: e.serializer.StreamReadPositionToStreamPosition(
-				readPosition,
-			),
-			ResolveLinkTos: true,
-		},
-		count)
+	var readStream *esdb.ReadStream
+	err := e.retryPolicy.Execute(ctx, "ReadStream", func() error {
+		var err error
+		readStream, err = e.client.ReadStream(
+			ctx,
+			streamName.String(),
+			esdb.ReadStreamOptions{
+				Direction: esdb.Backwards,
+				From: e.serializer.StreamReadPositionToStreamPosition(
+					readPosition,
+				),
+				ResolveLinkTos: true,
+			},
+			count)
+
+		return err
+	})
 	if err != nil {
 		return nil, utils.TraceErrStatusFromSpan(
 			span,
@@ -375,15 +428,21 @@ func (e *eventStoreDbEventStore) TruncateStream(
 	streamMetadata.SetTruncateBefore(
 		e.serializer.StreamTruncatePositionToInt64(truncatePosition),
 	)
-	writeResult, err := e.client.SetStreamMetadata(
-		ctx,
-		streamName.String(),
-		esdb.AppendToStreamOptions{
-			ExpectedRevision: e.serializer.ExpectedStreamVersionToEsdbExpectedRevision(
-				expectedVersion,
-			),
-		},
-		streamMetadata)
+	var writeResult *esdb.WriteResult
+	err := e.retryPolicy.Execute(ctx, "SetStreamMetadata", func() error {
+		var err error
+		writeResult, err = e.client.SetStreamMetadata(
+			ctx,
+			streamName.String(),
+			esdb.AppendToStreamOptions{
+				ExpectedRevision: e.serializer.ExpectedStreamVersionToEsdbExpectedRevision(
+					expectedVersion,
+				),
+			},
+			streamMetadata)
+
+		return err
+	})
 	if err != nil {
 		return nil, utils.TraceErrStatusFromSpan(
 			span,
@@ -419,14 +478,20 @@ func (e *eventStoreDbEventStore) DeleteStream(
 	span.SetAttributes(attribute2.String("StreamName", streamName.String()))
 	defer span.End()
 
-	deleteResult, err := e.client.DeleteStream(
-		ctx,
-		streamName.String(),
-		esdb.DeleteStreamOptions{
-			ExpectedRevision: e.serializer.ExpectedStreamVersionToEsdbExpectedRevision(
-				expectedVersion,
-			),
-		})
+	var deleteResult *esdb.DeleteResult
+	err := e.retryPolicy.Execute(ctx, "DeleteStream", func() error {
+		var err error
+		deleteResult, err = e.client.DeleteStream(
+			ctx,
+			streamName.String(),
+			esdb.DeleteStreamOptions{
+				ExpectedRevision: e.serializer.ExpectedStreamVersionToEsdbExpectedRevision(
+					expectedVersion,
+				),
+			})
+
+		return err
+	})
 	if err != nil {
 		return utils.TraceErrStatusFromSpan(
 			span,
@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/upcasting"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb/config"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
 
@@ -12,7 +14,8 @@ import (
 )
 
 var (
-	// ModuleFunc provided to fxlog
+	// ModuleFunc provided to fxlog, the checkpoints of its subscription worker are kept by the
+	// SubscriptionCheckpointRepository of `CheckpointModule` or `postgresgorm.CheckpointModule`
 	// https://uber-go.github.io/fx/modules.html
 	ModuleFunc = func(projectionBuilderConstructor interface{}) fx.Option { //nolint:gochecknoglobals
 		return fx.Module(
@@ -29,10 +32,24 @@ var (
 	eventstoreProviders = fx.Options(fx.Provide( //nolint:gochecknoglobals
 		config.ProvideConfig,
 		NewEsdbSerializer,
+		fx.Annotate(
+			upcasting.NewUpcasterRegistry,
+			fx.ParamTags(`group:"esdbUpcasters"`),
+		),
+		NewSnapshotSerializer,
+		NewEsdbSnapshotStore,
 		NewEventStoreDB,
-		NewEventStoreDbEventStore,
-		NewEsdbSubscriptionCheckpointRepository,
+		NewRetryPolicy,
+		fx.Annotate(
+			NewEventStoreDbEventStore,
+			fx.ParamTags(``, ``, ``, ``, ``, `group:"esdbMetadataEnrichers"`),
+		),
 		NewEsdbSubscriptionAllWorker,
+		NewProjectionManager,
+		fx.Annotate(
+			NewCorrelationMetadataEnricher,
+			fx.ResultTags(`group:"esdbMetadataEnrichers"`),
+		),
 	))
 
 	// FiberInvokes - execute after registering all of our provided
@@ -40,12 +57,23 @@ var (
 	// - invokes always execute its func compare to provides that only run when we request for them.
 	// - return value will be discarded and can not be provided
 	eventstoreInvokes = fx.Options(fx.Invoke(registerHooks)) //nolint:gochecknoglobals
+
+	// ChangeFeedModule provides the `$all` backed ChangeFeed, it should be used with `changefeed.Module`, the events of
+	// the subscription notify the long polls of the feed
+	ChangeFeedModule = fx.Module( //nolint:gochecknoglobals
+		"eventstoredbchangefeedfx",
+		fx.Provide(
+			NewEsdbChangeFeed,
+			es.AsProjection(NewEsdbChangeNotifier),
+		),
+	)
 )
 
 // we don't want to register any dependencies here, its func body should execute always even we don't request for that, so we should use `invoke`
 func registerHooks(
 	lc fx.Lifecycle,
 	worker EsdbSubscriptionAllWorker,
+	projectionManager ProjectionManager,
 	logger logger.Logger,
 	cfg *config.EventStoreDbOptions,
 ) {
@@ -63,6 +91,7 @@ func registerHooks(
 						Prefixes: cfg.Subscription.Prefix,
 					},
 					SubscriptionId: cfg.Subscription.SubscriptionId,
+					Rebuild:        IsRebuildProjections(),
 				}
 				if err := worker.SubscribeAll(lifetimeCtx, option); err != nil {
 					logger.Errorf(
@@ -82,6 +111,9 @@ func registerHooks(
 			_, cancel := context.WithTimeout(lifetimeCtx, 5*time.Second)
 			defer cancel()
 
+			// the running rebuilds are canceled, their read models should be rebuilt again after the restart
+			projectionManager.Stop()
+
 			return nil
 		},
 	})
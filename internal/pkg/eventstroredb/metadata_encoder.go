@@ -0,0 +1,236 @@
+package eventstroredb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb/config"
+
+	"emperror.dev/errors"
+)
+
+// binaryMetadataMarker starts the binary encoded metadata, a json document never starts with a zero byte, so the
+// encoding of the stored metadata is detected on read.
+const (
+	binaryMetadataMarker  byte = 0x00
+	binaryMetadataVersion byte = 0x01
+)
+
+const (
+	binaryNil byte = iota
+	binaryString
+	binaryBool
+	binaryInt
+	binaryFloat
+	binaryTime
+	binaryJson
+)
+
+// metadataEncoder writes the metadata with the configured encoding and reads both of the json and the binary encodings
+type metadataEncoder struct {
+	jsonSerializer serializer.MetadataSerializer
+	encoding       config.MetadataEncoding
+}
+
+func newMetadataEncoder(
+	jsonSerializer serializer.MetadataSerializer,
+	encoding config.MetadataEncoding,
+) serializer.MetadataSerializer {
+	return &metadataEncoder{jsonSerializer: jsonSerializer, encoding: encoding}
+}
+
+func (m *metadataEncoder) Serialize(meta metadata.Metadata) ([]byte, error) {
+	if m.encoding == config.BinaryMetadataEncoding {
+		return encodeBinaryMetadata(meta)
+	}
+
+	return m.jsonSerializer.Serialize(meta)
+}
+
+func (m *metadataEncoder) Deserialize(data []byte) (metadata.Metadata, error) {
+	if len(data) > 0 && data[0] == binaryMetadataMarker {
+		return decodeBinaryMetadata(data)
+	}
+
+	return m.jsonSerializer.Deserialize(data)
+}
+
+func encodeBinaryMetadata(meta metadata.Metadata) ([]byte, error) {
+	if meta == nil {
+		return nil, nil
+	}
+
+	buf := &bytes.Buffer{}
+	buf.WriteByte(binaryMetadataMarker)
+	buf.WriteByte(binaryMetadataVersion)
+	writeUvarint(buf, uint64(len(meta)))
+
+	// the keys are sorted for having a deterministic encoding
+	keys := meta.Keys()
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		writeBytes(buf, []byte(key))
+		if err := writeBinaryValue(buf, meta[key]); err != nil {
+			return nil, errors.WrapIff(err, "failed to encode metadata key '%s'", key)
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writeBinaryValue(buf *bytes.Buffer, value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		buf.WriteByte(binaryNil)
+	case string:
+		buf.WriteByte(binaryString)
+		writeBytes(buf, []byte(v))
+	case bool:
+		buf.WriteByte(binaryBool)
+		if v {
+			buf.WriteByte(1)
+		} else {
+			buf.WriteByte(0)
+		}
+	case int:
+		writeBinaryInt(buf, int64(v))
+	case int32:
+		writeBinaryInt(buf, int64(v))
+	case int64:
+		writeBinaryInt(buf, v)
+	case float64:
+		buf.WriteByte(binaryFloat)
+		var b [8]byte
+		binary.BigEndian.PutUint64(b[:], math.Float64bits(v))
+		buf.Write(b[:])
+	case time.Time:
+		buf.WriteByte(binaryTime)
+		writeBytes(buf, []byte(v.Format(time.RFC3339Nano)))
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		buf.WriteByte(binaryJson)
+		writeBytes(buf, data)
+	}
+
+	return nil
+}
+
+func writeBinaryInt(buf *bytes.Buffer, value int64) {
+	buf.WriteByte(binaryInt)
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(b[:], value)
+	buf.Write(b[:n])
+}
+
+func writeUvarint(buf *bytes.Buffer, value uint64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], value)
+	buf.Write(b[:n])
+}
+
+func writeBytes(buf *bytes.Buffer, data []byte) {
+	writeUvarint(buf, uint64(len(data)))
+	buf.Write(data)
+}
+
+func decodeBinaryMetadata(data []byte) (metadata.Metadata, error) {
+	reader := bytes.NewReader(data[1:])
+
+	version, err := reader.ReadByte()
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to read metadata version")
+	}
+	if version != binaryMetadataVersion {
+		return nil, errors.Errorf("unsupported binary metadata version %d", version)
+	}
+
+	count, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to read metadata length")
+	}
+
+	meta := metadata.Metadata{}
+	for i := uint64(0); i < count; i++ {
+		key, err := readBytes(reader)
+		if err != nil {
+			return nil, errors.WrapIf(err, "failed to read metadata key")
+		}
+
+		value, err := readBinaryValue(reader)
+		if err != nil {
+			return nil, errors.WrapIff(err, "failed to read metadata key '%s'", key)
+		}
+
+		meta[string(key)] = value
+	}
+
+	return meta, nil
+}
+
+func readBinaryValue(reader *bytes.Reader) (interface{}, error) {
+	valueType, err := reader.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	switch valueType {
+	case binaryNil:
+		return nil, nil
+	case binaryString:
+		data, err := readBytes(reader)
+		return string(data), err
+	case binaryBool:
+		b, err := reader.ReadByte()
+		return b == 1, err
+	case binaryInt:
+		return binary.ReadVarint(reader)
+	case binaryFloat:
+		var b [8]byte
+		if _, err := io.ReadFull(reader, b[:]); err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b[:])), nil
+	case binaryTime:
+		data, err := readBytes(reader)
+		if err != nil {
+			return nil, err
+		}
+		return time.Parse(time.RFC3339Nano, string(data))
+	case binaryJson:
+		data, err := readBytes(reader)
+		if err != nil {
+			return nil, err
+		}
+		var value interface{}
+		err = json.Unmarshal(data, &value)
+		return value, err
+	default:
+		return nil, errors.Errorf("unknown binary metadata value type %d", valueType)
+	}
+}
+
+func readBytes(reader *bytes.Reader) ([]byte, error) {
+	length, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, err
+	}
+	if length > uint64(reader.Len()) {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	data := make([]byte, length)
+	_, err = io.ReadFull(reader, data)
+
+	return data, err
+}
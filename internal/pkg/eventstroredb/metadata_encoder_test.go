@@ -0,0 +1,69 @@
+package eventstroredb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer/json"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMetadataEncoder(encoding config.MetadataEncoding) *metadataEncoder {
+	jsonSerializer := json.NewDefaultMetadataJsonSerializer(json.NewDefaultJsonSerializer())
+
+	return newMetadataEncoder(jsonSerializer, encoding).(*metadataEncoder)
+}
+
+func Test_Binary_Metadata_Round_Trip(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	meta := metadata.Metadata{
+		CorrelationIdMetadataKey: "correlation-1",
+		SchemaVersionMetadataKey: 2,
+		"retried":                true,
+		"ratio":                  0.5,
+		"created":                created,
+		"empty":                  nil,
+		"tags":                   []string{"a", "b"},
+	}
+
+	data, err := newTestMetadataEncoder(config.BinaryMetadataEncoding).Serialize(meta)
+	require.NoError(t, err)
+	assert.Equal(t, binaryMetadataMarker, data[0])
+
+	decoded, err := newTestMetadataEncoder(config.JsonMetadataEncoding).Deserialize(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, metadata.Metadata{
+		CorrelationIdMetadataKey: "correlation-1",
+		SchemaVersionMetadataKey: int64(2),
+		"retried":                true,
+		"ratio":                  0.5,
+		"created":                created,
+		"empty":                  nil,
+		"tags":                   []interface{}{"a", "b"},
+	}, decoded)
+}
+
+func Test_Json_Metadata_Is_Read_By_Binary_Encoder(t *testing.T) {
+	meta := metadata.Metadata{CorrelationIdMetadataKey: "correlation-1"}
+
+	data, err := newTestMetadataEncoder(config.JsonMetadataEncoding).Serialize(meta)
+	require.NoError(t, err)
+	assert.Equal(t, byte('{'), data[0])
+
+	decoded, err := newTestMetadataEncoder(config.BinaryMetadataEncoding).Deserialize(data)
+	require.NoError(t, err)
+	assert.Equal(t, meta, decoded)
+}
+
+func Test_Binary_Metadata_Rejects_Truncated_Data(t *testing.T) {
+	data, err := encodeBinaryMetadata(metadata.Metadata{"key": "value"})
+	require.NoError(t, err)
+
+	_, err = decodeBinaryMetadata(data[:len(data)-2])
+	assert.Error(t, err)
+}
@@ -0,0 +1,132 @@
+package eventstroredb
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	messageHeader "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/messageheader"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models"
+
+	"go.opentelemetry.io/otel/baggage"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// the well-known metadata keys of the stored events, `$correlationId` and `$causationId` are the eventstoredb system
+// keys which are used by the `$by_correlation_id` system projection.
+const (
+	ContentTypeMetadataKey   = "content-type"
+	SchemaVersionMetadataKey = "schema-version"
+	CorrelationIdMetadataKey = "$correlationId"
+	CausationIdMetadataKey   = "$causationId"
+	UserIdMetadataKey        = "user-id"
+)
+
+// MetadataEnricher adds metadata to the events before appending them to the event store, the enrichers are provided
+// with the `esdbMetadataEnrichers` fx group.
+type MetadataEnricher interface {
+	Enrich(ctx context.Context, streamEvent *models.StreamEvent)
+}
+
+// MetadataEnricherFunc is an adapter to use ordinary functions as metadata enrichers
+type MetadataEnricherFunc func(ctx context.Context, streamEvent *models.StreamEvent)
+
+func (f MetadataEnricherFunc) Enrich(ctx context.Context, streamEvent *models.StreamEvent) {
+	f(ctx, streamEvent)
+}
+
+// SchemaVersioned is implemented by the events with a schema version other than 1
+type SchemaVersioned interface {
+	SchemaVersion() int
+}
+
+type correlationMetadataEnricher struct{}
+
+// NewCorrelationMetadataEnricher sets the correlation id from the metadata, the consumed message or the current trace,
+// and the causation id from the consumed message, so the events of a message are correlated in the event store.
+func NewCorrelationMetadataEnricher() MetadataEnricher {
+	return &correlationMetadataEnricher{}
+}
+
+func (c *correlationMetadataEnricher) Enrich(ctx context.Context, streamEvent *models.StreamEvent) {
+	meta := streamEvent.Metadata
+	bag := baggage.FromContext(ctx)
+
+	if !meta.ExistsKey(CorrelationIdMetadataKey) {
+		correlationId := messageHeader.GetCorrelationId(meta)
+		if correlationId == "" {
+			correlationId = bag.Member(string(semconv.MessagingMessageConversationIDKey)).Value()
+		}
+		if correlationId == "" {
+			if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+				correlationId = spanContext.TraceID().String()
+			}
+		}
+		if correlationId != "" {
+			meta.Set(CorrelationIdMetadataKey, correlationId)
+		}
+	}
+
+	if !meta.ExistsKey(CausationIdMetadataKey) {
+		// the id of the message which is consumed in this context
+		if causationId := bag.Member(string(semconv.MessageIDKey)).Value(); causationId != "" {
+			meta.Set(CausationIdMetadataKey, causationId)
+		}
+	}
+}
+
+// UserIdResolver returns the user of the current request
+type UserIdResolver func(ctx context.Context) (string, bool)
+
+type userMetadataEnricher struct {
+	resolver UserIdResolver
+}
+
+// NewUserMetadataEnricher sets the user id of the current request, it should be provided to the
+// `esdbMetadataEnrichers` group with the resolver of the authentication.
+func NewUserMetadataEnricher(resolver UserIdResolver) MetadataEnricher {
+	return &userMetadataEnricher{resolver: resolver}
+}
+
+func (u *userMetadataEnricher) Enrich(ctx context.Context, streamEvent *models.StreamEvent) {
+	if streamEvent.Metadata.ExistsKey(UserIdMetadataKey) {
+		return
+	}
+
+	if userId, ok := u.resolver(ctx); ok {
+		streamEvent.Metadata.Set(UserIdMetadataKey, userId)
+	}
+}
+
+func schemaVersion(event interface{}) int {
+	if versioned, ok := event.(SchemaVersioned); ok {
+		return versioned.SchemaVersion()
+	}
+
+	return 1
+}
+
+// eventSchemaVersion returns the schema version of a stored event, the numbers of the json metadata are decoded as
+// float64 and the numbers of the binary metadata as int64, and the events without it have the first schema version
+func eventSchemaVersion(meta metadata.Metadata) int {
+	switch version := meta.Get(SchemaVersionMetadataKey).(type) {
+	case int:
+		return version
+	case int64:
+		return int(version)
+	case float64:
+		return int(version)
+	case json.Number:
+		if value, err := version.Int64(); err == nil {
+			return int(value)
+		}
+	case string:
+		if value, err := strconv.Atoi(version); err == nil {
+			return value
+		}
+	}
+
+	return 1
+}
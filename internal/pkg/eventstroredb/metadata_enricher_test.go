@@ -0,0 +1,52 @@
+package eventstroredb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/baggage"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+func Test_Correlation_Enricher_Uses_Consumed_Message(t *testing.T) {
+	correlationIdBag, _ := baggage.NewMember(string(semconv.MessagingMessageConversationIDKey), "correlation-1")
+	messageIdBag, _ := baggage.NewMember(string(semconv.MessageIDKey), "message-1")
+	b, _ := baggage.New(correlationIdBag, messageIdBag)
+	ctx := baggage.ContextWithBaggage(context.Background(), b)
+
+	streamEvent := &models.StreamEvent{Metadata: metadata.Metadata{}}
+	NewCorrelationMetadataEnricher().Enrich(ctx, streamEvent)
+
+	assert.Equal(t, "correlation-1", streamEvent.Metadata.Get(CorrelationIdMetadataKey))
+	assert.Equal(t, "message-1", streamEvent.Metadata.Get(CausationIdMetadataKey))
+}
+
+func Test_Correlation_Enricher_Keeps_Existing_Correlation(t *testing.T) {
+	streamEvent := &models.StreamEvent{
+		Metadata: metadata.Metadata{"correlation-id": "correlation-2"},
+	}
+	NewCorrelationMetadataEnricher().Enrich(context.Background(), streamEvent)
+
+	assert.Equal(t, "correlation-2", streamEvent.Metadata.Get(CorrelationIdMetadataKey))
+	assert.False(t, streamEvent.Metadata.ExistsKey(CausationIdMetadataKey))
+}
+
+func Test_Enrich_Does_Not_Change_Shared_Metadata(t *testing.T) {
+	shared := metadata.Metadata{"key": "value"}
+	store := &eventStoreDbEventStore{
+		enrichers: []MetadataEnricher{
+			NewUserMetadataEnricher(func(ctx context.Context) (string, bool) {
+				return "user-1", true
+			}),
+		},
+	}
+
+	enriched := store.enrich(context.Background(), &models.StreamEvent{Metadata: shared})
+
+	assert.Equal(t, metadata.Metadata{"key": "value", UserIdMetadataKey: "user-1"}, enriched.Metadata)
+	assert.Equal(t, metadata.Metadata{"key": "value"}, shared)
+}
@@ -0,0 +1,480 @@
+package eventstroredb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/projection"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb/config"
+	esErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb/errors"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"emperror.dev/errors"
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/iancoleman/strcase"
+	"golang.org/x/time/rate"
+)
+
+type RebuildStatus string
+
+const (
+	RebuildIdle      RebuildStatus = "idle"
+	RebuildRunning   RebuildStatus = "running"
+	RebuildCompleted RebuildStatus = "completed"
+	RebuildFailed    RebuildStatus = "failed"
+	RebuildCanceled  RebuildStatus = "canceled"
+)
+
+// RebuildProgress is the progress of the last rebuild of a projection in this instance
+type RebuildProgress struct {
+	Projection string
+	Status     RebuildStatus
+	// Paused reports whether the live projection is paused, it stays paused after a failed or a canceled rebuild
+	// because its read model is partially rebuilt
+	Paused         bool
+	StartedBy      string
+	ReplayedEvents int64
+	// Position is the commit position of the last replayed event of `$all`
+	Position uint64
+	// EndPosition is the commit position of the last event of `$all` at the start of the rebuild
+	EndPosition uint64
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+	Error       string
+}
+
+// Percent returns the replayed percent of `$all` up to its end at the start of the rebuild
+func (p *RebuildProgress) Percent() float64 {
+	if p.Status == RebuildCompleted {
+		return 100
+	}
+	if p.EndPosition == 0 {
+		return 0
+	}
+
+	percent := float64(p.Position) * 100 / float64(p.EndPosition)
+	if percent > 100 {
+		return 100
+	}
+
+	return percent
+}
+
+// ProjectionManager rebuilds the read models of the rebuildable projections of the subscription. a rebuild pauses the
+// live projection, drops its read model and replays `$all` from the start to the replay projection with a limited
+// rate, then the live projection is resumed after the events which are projected by the replay.
+type ProjectionManager interface {
+	// Manage wraps the live projections of the subscription, a rebuild of a rebuildable projection pauses its wrapper,
+	// the other projections are returned as they are. the rebuildable projections are named by their snake case type
+	// names, like the projection toggles.
+	Manage(projections ...projection.IProjection) []projection.IProjection
+	// Rebuilds returns the progress of the rebuildable projections ordered by their names
+	Rebuilds() []*RebuildProgress
+	// Rebuild starts the rebuild of a projection in the background and returns its initial progress
+	Rebuild(ctx context.Context, name string, startedBy string) (*RebuildProgress, error)
+	// Cancel stops the running rebuild of a projection and waits for it
+	Cancel(name string) error
+	// Stop cancels the running rebuilds of this instance
+	Stop()
+}
+
+type readLastFunc func(ctx context.Context) ([]*esdb.ResolvedEvent, error)
+
+type projectionManager struct {
+	readAll       readAllFunc
+	readLast      readLastFunc
+	toStreamEvent func(resolvedEvent *esdb.ResolvedEvent) (*models.StreamEvent, error)
+	prefixes      []string
+	options       *config.RebuildOptions
+	log           logger.Logger
+	mu            sync.Mutex
+	projections   map[string]*managedProjection
+}
+
+func NewProjectionManager(
+	client *esdb.Client,
+	esdbSerializer *EsdbSerializer,
+	retryPolicy RetryPolicy,
+	cfg *config.EventStoreDbOptions,
+	log logger.Logger,
+) ProjectionManager {
+	var prefixes []string
+	if cfg.Subscription != nil {
+		prefixes = cfg.Subscription.Prefix
+	}
+
+	readBackwards := newEsdbReadAll(client, esdbSerializer, retryPolicy, esdb.Backwards)
+
+	return &projectionManager{
+		readAll: newEsdbReadAll(client, esdbSerializer, retryPolicy, esdb.Forwards),
+		readLast: func(ctx context.Context) ([]*esdb.ResolvedEvent, error) {
+			return readBackwards(ctx, esdb.End{}, 1)
+		},
+		toStreamEvent: esdbSerializer.ResolvedEventToStreamEvent,
+		prefixes:      prefixes,
+		options:       cfg.Rebuild,
+		log:           log,
+		projections:   make(map[string]*managedProjection),
+	}
+}
+
+func (m *projectionManager) Manage(projections ...projection.IProjection) []projection.IProjection {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	managed := make([]projection.IProjection, 0, len(projections))
+	for _, p := range projections {
+		rebuildable, ok := unwrapProjection(p).(projection.IRebuildableProjection)
+		if !ok {
+			managed = append(managed, p)
+
+			continue
+		}
+
+		name := strcase.ToSnake(typeMapper.GetNonePointerTypeName(rebuildable))
+		managedProjection := &managedProjection{name: name, live: p, rebuildable: rebuildable, log: m.log}
+		m.projections[name] = managedProjection
+		managed = append(managed, managedProjection)
+	}
+
+	return managed
+}
+
+func (m *projectionManager) Rebuilds() []*RebuildProgress {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rebuilds := make([]*RebuildProgress, 0, len(m.projections))
+	for _, managed := range m.projections {
+		rebuilds = append(rebuilds, managed.progress())
+	}
+
+	sort.Slice(rebuilds, func(i, j int) bool {
+		return rebuilds[i].Projection < rebuilds[j].Projection
+	})
+
+	return rebuilds
+}
+
+func (m *projectionManager) Rebuild(ctx context.Context, name string, startedBy string) (*RebuildProgress, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	managed, ok := m.projections[name]
+	if !ok {
+		return nil, customErrors.NewNotFoundError(fmt.Sprintf("projection %s is not rebuildable", name))
+	}
+
+	if managed.rebuild != nil && managed.rebuild.running() {
+		return nil, customErrors.NewConflictError(fmt.Sprintf("projection %s is already rebuilding", name))
+	}
+
+	last, err := m.readLast(ctx)
+	if err != nil {
+		return nil, errors.WithMessage(esErrors.NewReadStreamError(err), "error in reading the end of $all")
+	}
+
+	var endPosition uint64
+	if len(last) > 0 {
+		endPosition = last[0].OriginalEvent().Position.Commit
+	}
+
+	// the rebuild lives until its completion or cancel, so it doesn't use the request context
+	lifetimeCtx, cancel := context.WithCancel(context.Background())
+
+	now := time.Now()
+	rebuild := &projectionRebuild{
+		progress: RebuildProgress{
+			Projection:  name,
+			Status:      RebuildRunning,
+			StartedBy:   startedBy,
+			EndPosition: endPosition,
+			StartedAt:   &now,
+		},
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	managed.rebuild = rebuild
+	managed.paused.Store(true)
+
+	go m.run(lifetimeCtx, managed, rebuild)
+
+	m.log.Infow(
+		fmt.Sprintf("[projectionManager.Rebuild] rebuild of projection '%s' is started", name),
+		logger.Fields{"Projection": name, "StartedBy": startedBy, "EndPosition": endPosition},
+	)
+
+	return managed.progress(), nil
+}
+
+func (m *projectionManager) Cancel(name string) error {
+	m.mu.Lock()
+	managed, ok := m.projections[name]
+	var rebuild *projectionRebuild
+	if ok {
+		rebuild = managed.rebuild
+	}
+	m.mu.Unlock()
+
+	if !ok {
+		return customErrors.NewNotFoundError(fmt.Sprintf("projection %s is not rebuildable", name))
+	}
+
+	if rebuild == nil || !rebuild.running() {
+		return customErrors.NewConflictError(fmt.Sprintf("there is no running rebuild of projection %s", name))
+	}
+
+	rebuild.cancel()
+	<-rebuild.done
+
+	return nil
+}
+
+func (m *projectionManager) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, managed := range m.projections {
+		if managed.rebuild != nil && managed.rebuild.running() {
+			managed.rebuild.cancel()
+			<-managed.rebuild.done
+		}
+	}
+}
+
+func (m *projectionManager) run(ctx context.Context, managed *managedProjection, rebuild *projectionRebuild) {
+	defer close(rebuild.done)
+	defer rebuild.cancel()
+
+	err := managed.rebuildable.DropReadModel(ctx)
+	if err != nil {
+		m.complete(ctx, managed, rebuild, errors.WrapIf(err, "error in dropping the read model"))
+
+		return
+	}
+
+	limiter := rate.NewLimiter(rate.Limit(m.options.GetEventsPerSecond()), 1)
+	replay := managed.rebuildable.ReplayProjection()
+
+	after, err := m.replay(ctx, replay, limiter, rebuild, nil)
+	if err != nil {
+		m.complete(ctx, managed, rebuild, err)
+
+		return
+	}
+
+	// the events after the first pass are replayed while the live events are blocked, so the live projection
+	// continues exactly after the last replayed event
+	managed.mu.Lock()
+	defer managed.mu.Unlock()
+
+	after, err = m.replay(ctx, replay, limiter, rebuild, after)
+	if err != nil {
+		m.complete(ctx, managed, rebuild, err)
+
+		return
+	}
+
+	if after != nil {
+		managed.skipUntil = after.Commit
+	}
+	managed.paused.Store(false)
+
+	m.complete(ctx, managed, rebuild, nil)
+}
+
+// replay projects the subscribed events of `$all` after the position until the end of `$all`, it returns the position
+// of the last read event
+func (m *projectionManager) replay(
+	ctx context.Context,
+	replay projection.IProjection,
+	limiter *rate.Limiter,
+	rebuild *projectionRebuild,
+	after *esdb.Position,
+) (*esdb.Position, error) {
+	var from esdb.AllPosition = esdb.Start{}
+	if after != nil {
+		from = *after
+	}
+
+	batchSize := m.options.GetBatchSize()
+
+	for {
+		// the read of `$all` from a position includes the event of the position, so one more event is read
+		events, err := m.readAll(ctx, from, batchSize+1)
+		if err != nil {
+			return after, errors.WithMessage(esErrors.NewReadStreamError(err), "error in reading $all for the rebuild")
+		}
+
+		var read uint64
+		for _, event := range events {
+			recorded := event.OriginalEvent()
+			if after != nil && recorded.Position == *after {
+				continue
+			}
+			read++
+
+			if isSubscribedEvent(recorded, m.prefixes) && len(recorded.Data) > 0 {
+				if err := limiter.Wait(ctx); err != nil {
+					return after, err
+				}
+
+				streamEvent, err := m.toStreamEvent(event)
+				if err != nil {
+					return after, errors.WrapIf(err, "failed to convert resolved event to stream event")
+				}
+
+				if err := replay.ProcessEvent(ctx, streamEvent); err != nil {
+					return after, errors.WrapIf(err, "failed to project the replayed event")
+				}
+
+				rebuild.replayed()
+			}
+
+			position := recorded.Position
+			from, after = position, &position
+			rebuild.advance(position.Commit)
+		}
+
+		if read < batchSize {
+			return after, nil
+		}
+	}
+}
+
+func (m *projectionManager) complete(
+	ctx context.Context,
+	managed *managedProjection,
+	rebuild *projectionRebuild,
+	err error,
+) {
+	now := time.Now()
+
+	rebuild.mu.Lock()
+	defer rebuild.mu.Unlock()
+
+	rebuild.progress.CompletedAt = &now
+
+	switch {
+	case err == nil:
+		rebuild.progress.Status = RebuildCompleted
+		m.log.Infow(
+			fmt.Sprintf("[projectionManager.run] rebuild of projection '%s' is completed", managed.name),
+			logger.Fields{"Projection": managed.name, "ReplayedEvents": rebuild.progress.ReplayedEvents},
+		)
+	case ctx.Err() != nil:
+		rebuild.progress.Status = RebuildCanceled
+		m.log.Infow(
+			fmt.Sprintf("[projectionManager.run] rebuild of projection '%s' is canceled", managed.name),
+			logger.Fields{"Projection": managed.name, "Position": rebuild.progress.Position},
+		)
+	default:
+		rebuild.progress.Status = RebuildFailed
+		rebuild.progress.Error = err.Error()
+		m.log.Errorf("[projectionManager.run] error in rebuilding projection '%s': {%v}", managed.name, err)
+	}
+}
+
+// managedProjection is a live projection which is paused while its read model is rebuilt
+type managedProjection struct {
+	name        string
+	live        projection.IProjection
+	rebuildable projection.IRebuildableProjection
+	log         logger.Logger
+	paused      atomic.Bool
+	// mu serializes the live events with the end of a rebuild
+	mu sync.Mutex
+	// skipUntil is the position of the last replayed event, the live events up to it are projected by the rebuild
+	skipUntil uint64
+	// rebuild is guarded by the mutex of the manager
+	rebuild *projectionRebuild
+}
+
+func (p *managedProjection) ProcessEvent(ctx context.Context, streamEvent *models.StreamEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.paused.Load() || uint64(streamEvent.Position) <= p.skipUntil {
+		p.log.Debugw(
+			"[managedProjection.ProcessEvent] projection is rebuilt, event is skipped",
+			logger.Fields{"Projection": p.name, "EventId": streamEvent.EventID},
+		)
+
+		return nil
+	}
+
+	return p.live.ProcessEvent(ctx, streamEvent)
+}
+
+// Unwrap returns the live projection
+func (p *managedProjection) Unwrap() projection.IProjection {
+	return p.live
+}
+
+func (p *managedProjection) progress() *RebuildProgress {
+	progress := &RebuildProgress{Projection: p.name, Status: RebuildIdle}
+	if p.rebuild != nil {
+		progress = p.rebuild.snapshot()
+	}
+	progress.Paused = p.paused.Load()
+
+	return progress
+}
+
+type projectionRebuild struct {
+	mu       sync.Mutex
+	progress RebuildProgress
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+func (r *projectionRebuild) running() bool {
+	select {
+	case <-r.done:
+		return false
+	default:
+		return true
+	}
+}
+
+func (r *projectionRebuild) replayed() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.progress.ReplayedEvents++
+}
+
+func (r *projectionRebuild) advance(position uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.progress.Position = position
+}
+
+func (r *projectionRebuild) snapshot() *RebuildProgress {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	progress := r.progress
+
+	return &progress
+}
+
+// unwrapProjection returns the innermost projection of the wrappers, e.g. the projection of a toggle
+func unwrapProjection(p projection.IProjection) projection.IProjection {
+	for {
+		wrapper, ok := p.(interface{ Unwrap() projection.IProjection })
+		if !ok {
+			return p
+		}
+
+		p = wrapper.Unwrap()
+	}
+}
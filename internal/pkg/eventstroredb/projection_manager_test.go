@@ -0,0 +1,160 @@
+package eventstroredb
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/projection"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb/config"
+	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+
+	"emperror.dev/errors"
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeReadModelProjection keeps the positions of its live and replayed events as its read model
+type fakeReadModelProjection struct {
+	mu       sync.Mutex
+	live     []int64
+	replayed []int64
+	dropped  int
+	failAt   int64
+}
+
+func (f *fakeReadModelProjection) ProcessEvent(ctx context.Context, streamEvent *models.StreamEvent) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.live = append(f.live, streamEvent.Position)
+
+	return nil
+}
+
+func (f *fakeReadModelProjection) DropReadModel(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.dropped++
+	f.replayed = nil
+
+	return nil
+}
+
+func (f *fakeReadModelProjection) ReplayProjection() projection.IProjection {
+	return replayFunc(func(streamEvent *models.StreamEvent) error {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		if streamEvent.Position == f.failAt {
+			return errors.New("projection failed")
+		}
+
+		f.replayed = append(f.replayed, streamEvent.Position)
+
+		return nil
+	})
+}
+
+type replayFunc func(streamEvent *models.StreamEvent) error
+
+func (r replayFunc) ProcessEvent(ctx context.Context, streamEvent *models.StreamEvent) error {
+	return r(streamEvent)
+}
+
+type otherProjection struct{}
+
+func (otherProjection) ProcessEvent(ctx context.Context, streamEvent *models.StreamEvent) error {
+	return nil
+}
+
+func newTestProjectionManager(all fakeAll) *projectionManager {
+	return &projectionManager{
+		readAll: all.readAll,
+		readLast: func(ctx context.Context) ([]*esdb.ResolvedEvent, error) {
+			return all[len(all)-1:], nil
+		},
+		toStreamEvent: func(resolvedEvent *esdb.ResolvedEvent) (*models.StreamEvent, error) {
+			return &models.StreamEvent{Position: int64(resolvedEvent.OriginalEvent().Position.Commit)}, nil
+		},
+		prefixes:    []string{"order-"},
+		options:     &config.RebuildOptions{EventsPerSecond: 10000, BatchSize: 2},
+		log:         defaultLogger.GetLogger(),
+		projections: make(map[string]*managedProjection),
+	}
+}
+
+func waitRebuild(t *testing.T, manager *projectionManager, name string) *RebuildProgress {
+	t.Helper()
+
+	manager.mu.Lock()
+	rebuild := manager.projections[name].rebuild
+	manager.mu.Unlock()
+
+	<-rebuild.done
+
+	return manager.Rebuilds()[0]
+}
+
+func Test_Projection_Manager_Rebuilds_Read_Model_And_Resumes_Live_Projection(t *testing.T) {
+	ctx := context.Background()
+	manager := newTestProjectionManager(
+		newFakeAll("order-1", "$settings", "product-1", "order-2", "order-3"),
+	)
+	readModel := &fakeReadModelProjection{}
+
+	managed := manager.Manage(readModel, otherProjection{})
+	require.Len(t, managed, 2)
+	assert.Equal(t, otherProjection{}, managed[1])
+
+	progress, err := manager.Rebuild(ctx, "fake_read_model_projection", "admin")
+	require.NoError(t, err)
+	assert.Equal(t, RebuildRunning, progress.Status)
+	assert.True(t, progress.Paused)
+	assert.Equal(t, uint64(500), progress.EndPosition)
+
+	progress = waitRebuild(t, manager, "fake_read_model_projection")
+	assert.Equal(t, RebuildCompleted, progress.Status)
+	assert.False(t, progress.Paused)
+	assert.Equal(t, int64(3), progress.ReplayedEvents)
+	assert.Equal(t, uint64(500), progress.Position)
+	assert.Equal(t, float64(100), progress.Percent())
+	assert.Equal(t, 1, readModel.dropped)
+	assert.Equal(t, []int64{100, 400, 500}, readModel.replayed)
+
+	// the live events up to the last replayed event are projected by the rebuild
+	require.NoError(t, managed[0].ProcessEvent(ctx, &models.StreamEvent{Position: 500}))
+	require.NoError(t, managed[0].ProcessEvent(ctx, &models.StreamEvent{Position: 600}))
+	assert.Equal(t, []int64{600}, readModel.live)
+}
+
+func Test_Projection_Manager_Keeps_Live_Projection_Paused_After_Failed_Rebuild(t *testing.T) {
+	ctx := context.Background()
+	manager := newTestProjectionManager(newFakeAll("order-1", "order-2", "order-3"))
+	readModel := &fakeReadModelProjection{failAt: 200}
+	managed := manager.Manage(readModel)
+
+	_, err := manager.Rebuild(ctx, "fake_read_model_projection", "admin")
+	require.NoError(t, err)
+
+	progress := waitRebuild(t, manager, "fake_read_model_projection")
+	assert.Equal(t, RebuildFailed, progress.Status)
+	assert.True(t, progress.Paused)
+	assert.Contains(t, progress.Error, "projection failed")
+
+	require.NoError(t, managed[0].ProcessEvent(ctx, &models.StreamEvent{Position: 400}))
+	assert.Empty(t, readModel.live)
+}
+
+func Test_Projection_Manager_Rejects_Unknown_Projection(t *testing.T) {
+	manager := newTestProjectionManager(newFakeAll("order-1"))
+	manager.Manage(otherProjection{})
+
+	_, err := manager.Rebuild(context.Background(), "other_projection", "admin")
+	assert.Error(t, err)
+	assert.Error(t, manager.Cancel("other_projection"))
+	assert.Empty(t, manager.Rebuilds())
+}
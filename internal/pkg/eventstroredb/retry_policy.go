@@ -0,0 +1,84 @@
+package eventstroredb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+
+	"emperror.dev/errors"
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/avast/retry-go"
+)
+
+// RetryPolicy retries the event store operations on the transient failures. the esdb client reconnects (and
+// rediscovers the leader in a cluster) after a failed operation, so the next attempt usually goes to a healthy node.
+type RetryPolicy interface {
+	Execute(ctx context.Context, operation string, action func() error) error
+}
+
+type retryPolicy struct {
+	options []retry.Option
+	log     logger.Logger
+}
+
+func NewRetryPolicy(cfg *config.EventStoreDbOptions, log logger.Logger) RetryPolicy {
+	return &retryPolicy{
+		log: log,
+		options: []retry.Option{
+			retry.Attempts(uint(cfg.Retry.GetMaxAttempts())),
+			retry.Delay(cfg.Retry.GetInitialDelay()),
+			retry.MaxDelay(cfg.Retry.GetMaxDelay()),
+			retry.DelayType(retry.BackOffDelay),
+			retry.LastErrorOnly(true),
+			retry.RetryIf(IsTransientError),
+		},
+	}
+}
+
+func (r *retryPolicy) Execute(
+	ctx context.Context,
+	operation string,
+	action func() error,
+) error {
+	return retry.Do(
+		action,
+		append(
+			r.options,
+			retry.Context(ctx),
+			retry.OnRetry(func(attempt uint, err error) {
+				r.log.Warn(
+					fmt.Sprintf(
+						"esdb operation '%s' failed on attempt %d, retrying: %v",
+						operation,
+						attempt+1,
+						err,
+					),
+				)
+			}),
+		)...,
+	)
+}
+
+// IsTransientError reports whether retrying the failed operation can succeed, business failures like a wrong expected
+// version or a missing stream are not transient.
+func IsTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if errors.Is(err, esdb.ErrWrongExpectedStreamRevision) ||
+		errors.Is(err, esdb.ErrPermissionDenied) ||
+		errors.Is(err, esdb.ErrStreamNotFound) {
+		return false
+	}
+
+	var streamDeletedError *esdb.StreamDeletedError
+
+	return !errors.As(err, &streamDeletedError)
+}
@@ -0,0 +1,77 @@
+package eventstroredb
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb/config"
+	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+
+	"emperror.dev/errors"
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRetryPolicy() RetryPolicy {
+	return NewRetryPolicy(
+		&config.EventStoreDbOptions{
+			Retry: &config.RetryOptions{
+				MaxAttempts:  3,
+				InitialDelay: time.Millisecond,
+				MaxDelay:     5 * time.Millisecond,
+			},
+		},
+		defaultLogger.GetLogger(),
+	)
+}
+
+func Test_Retry_Policy_Retries_Transient_Errors(t *testing.T) {
+	attempts := 0
+
+	err := newTestRetryPolicy().Execute(context.Background(), "AppendToStream", func() error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("not leader exception")
+		}
+
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func Test_Retry_Policy_Returns_Last_Error(t *testing.T) {
+	attempts := 0
+
+	err := newTestRetryPolicy().Execute(context.Background(), "ReadStream", func() error {
+		attempts++
+		return fmt.Errorf("can't get a connection handle: attempt %d", attempts)
+	})
+
+	assert.EqualError(t, err, "can't get a connection handle: attempt 3")
+	assert.Equal(t, 3, attempts)
+}
+
+func Test_Retry_Policy_Does_Not_Retry_Business_Errors(t *testing.T) {
+	attempts := 0
+
+	err := newTestRetryPolicy().Execute(context.Background(), "AppendToStream", func() error {
+		attempts++
+		return fmt.Errorf("%w, reason: conflict", esdb.ErrWrongExpectedStreamRevision)
+	})
+
+	assert.ErrorIs(t, err, esdb.ErrWrongExpectedStreamRevision)
+	assert.Equal(t, 1, attempts)
+}
+
+func Test_Is_Transient_Error(t *testing.T) {
+	assert.False(t, IsTransientError(nil))
+	assert.False(t, IsTransientError(context.Canceled))
+	assert.False(t, IsTransientError(esdb.ErrStreamNotFound))
+	assert.False(t, IsTransientError(esdb.ErrPermissionDenied))
+	assert.False(t, IsTransientError(&esdb.StreamDeletedError{StreamName: "order-1"}))
+	assert.True(t, IsTransientError(errors.New("not leader exception")))
+}
@@ -0,0 +1,49 @@
+package eventstroredb
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/snapshot"
+
+	"emperror.dev/errors"
+)
+
+// SnapshotSerializer converts the snapshots to the events of the snapshot streams, the state of a snapshot is
+// serialized like the other domain events and its aggregate stream and version are kept in the metadata
+type SnapshotSerializer struct {
+	serializer *EsdbSerializer
+}
+
+func NewSnapshotSerializer(serializer *EsdbSerializer) *SnapshotSerializer {
+	return &SnapshotSerializer{serializer: serializer}
+}
+
+func (s *SnapshotSerializer) SnapshotToStreamEvent(snap *snapshot.Snapshot) *models.StreamEvent {
+	meta := metadata.Metadata{}
+	snapshot.SetSource(meta, snap.StreamName, snap.Version)
+
+	return s.serializer.DomainEventToStreamEvent(snap.State, meta, snap.Version)
+}
+
+func (s *SnapshotSerializer) StreamEventToSnapshot(streamEvent *models.StreamEvent) (*snapshot.Snapshot, error) {
+	stream, version, ok := snapshot.GetSource(streamEvent.Metadata)
+	if !ok {
+		return nil, errors.Errorf(
+			"[SnapshotSerializer_StreamEventToSnapshot] event %s is not a snapshot",
+			streamEvent.EventID,
+		)
+	}
+
+	if streamEvent.Event == nil {
+		return nil, errors.Errorf(
+			"[SnapshotSerializer_StreamEventToSnapshot] snapshot of stream %s doesn't have a state",
+			stream.String(),
+		)
+	}
+
+	return &snapshot.Snapshot{
+		StreamName: stream,
+		Version:    version,
+		State:      streamEvent.Event,
+	}, nil
+}
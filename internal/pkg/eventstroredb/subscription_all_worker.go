@@ -3,6 +3,8 @@ package eventstroredb
 import (
 	"context"
 	"fmt"
+	"os"
+	"slices"
 	"time"
 
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es"
@@ -10,7 +12,6 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/projection"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb/config"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
-	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
 
 	"emperror.dev/errors"
 	"github.com/EventStore/EventStore-Client-Go/esdb"
@@ -26,6 +27,8 @@ type esdbSubscriptionAllWorker struct {
 	subscriptionCheckpointRepository contracts.SubscriptionCheckpointRepository
 	subscriptionId                   string
 	projectionPublisher              projection.IProjectionPublisher
+	// replayUntil is the checkpoint of a rebuilt subscription, the events up to it are replayed only to the projections
+	replayUntil uint64
 }
 
 type EsdbSubscriptionAllWorker interface {
@@ -42,6 +45,22 @@ type EventStoreDBSubscriptionToAllOptions struct {
 	ResolveLinkTos              bool
 	IgnoreDeserializationErrors bool
 	Prefix                      string
+	// SkipEventBus publishes the events only to the projections of the worker, it is used by the subscriptions which
+	// rebuild a read model from the start, so the handlers of the internal event bus don't handle the events again
+	SkipEventBus bool
+	// Rebuild replays the subscription from the start of `$all` to rebuild its projections, the events up to its stored
+	// checkpoint are published only to the projections and the checkpoint is not stored until the replay passes it, so
+	// an interrupted rebuild is started again with the flag instead of continuing from the middle of the replay
+	Rebuild bool
+}
+
+// RebuildProjectionsFlag rebuilds the projections of the subscription from the start of `$all`, the flag is read from
+// the arguments of the process like the `--local` flag
+const RebuildProjectionsFlag = "--rebuild-projections"
+
+// IsRebuildProjections reports whether the app is started with the `--rebuild-projections` flag
+func IsRebuildProjections() bool {
+	return slices.Contains(os.Args[1:], RebuildProjectionsFlag)
 }
 
 func NewEsdbSubscriptionAllWorker(
@@ -95,7 +114,20 @@ func (s *esdbSubscriptionAllWorker) SubscribeAll(
 	}
 
 	var from esdb.AllPosition
-	if checkpoint == 0 {
+	s.replayUntil = 0
+
+	if subscriptionOption.Rebuild {
+		s.log.Info(
+			fmt.Sprintf(
+				"rebuilding the projections of subscription to all '%s' from the start, replaying until position %d.",
+				subscriptionOption.SubscriptionId,
+				checkpoint,
+			),
+		)
+
+		s.replayUntil = checkpoint
+		from = esdb.Start{}
+	} else if checkpoint == 0 {
 		from = esdb.Start{}
 	} else {
 		from = esdb.Position{
@@ -114,57 +146,83 @@ func (s *esdbSubscriptionAllWorker) SubscribeAll(
 	// https://developers.eventstore.com/clients/grpc/subscriptions.html#subscribing-to-all-1
 	// https://github.com/EventStore/EventStore-Client-Go/blob/master/samples/subscribingToStream.go#L113
 	// https://developers.eventstore.com/clients/grpc/subscriptions.html#handling-subscription-drops
-	for {
+	for attempt := 0; ; {
 		stream, err := s.db.SubscribeToAll(ctx, options)
-		if err != nil {
-			time.Sleep(1 * time.Second)
-			continue
-		}
+		if err == nil {
+			s.log.Info(
+				fmt.Sprintf("subscription to all '%s' started.", subscriptionOption.SubscriptionId),
+			)
+
+			var dropped bool
+			dropped, err = s.receiveEvents(ctx, stream, &options)
+			stream.Close()
+
+			if !dropped {
+				// a failed handler stops the worker, resubscribing would skip the failed event
+				return err
+			}
 
-		s.log.Info(
-			fmt.Sprintf("subscription to all '%s' started.", subscriptionOption.SubscriptionId),
-		)
+			// the subscription was alive, so the backoff starts again
+			attempt = 0
+		}
 
-		for true {
-			event := stream.Recv()
+		if ctx.Err() != nil {
+			// context canceled or deadlined
+			return ctx.Err()
+		}
 
-			if event.SubscriptionDropped != nil {
-				s.log.Errorf(
-					"subscription to all '%s' dropped: %s",
-					s.subscriptionId,
-					event.SubscriptionDropped.Error,
-				)
-				stream.Close()
-				break
-			}
+		delay := s.cfg.Subscription.ResubscribeBackoff(attempt)
+		attempt++
 
-			if event.EventAppeared != nil {
-				streamId := event.EventAppeared.OriginalEvent().StreamID
-				revision := event.EventAppeared.OriginalEvent().EventNumber
-				s.log.Info(
-					fmt.Sprintf(
-						"event appeared in subscription to all '%s'. streamId: %s, revision: %d",
-						s.subscriptionId,
-						streamId,
-						revision,
-					),
-				)
-
-				options.From = event.EventAppeared.OriginalEvent().Position
-
-				// handles the event...
-				err := s.handleEvent(ctx, event.EventAppeared)
-				if err != nil {
-					return err
-				}
-			}
-		}
+		s.log.Errorf(
+			"subscription to all '%s' dropped, resubscribing from position %v in %s: %v",
+			s.subscriptionId,
+			options.From,
+			delay,
+			err,
+		)
 
 		select {
 		case <-ctx.Done():
-			time.Sleep(1 * time.Second)
-			// context canceled or deadlined
 			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// receiveEvents handles the events of the subscription until it drops, `options.From` follows the last handled
+// position, so a resubscribe continues after it
+func (s *esdbSubscriptionAllWorker) receiveEvents(
+	ctx context.Context,
+	stream *esdb.Subscription,
+	options *esdb.SubscribeToAllOptions,
+) (bool, error) {
+	for {
+		event := stream.Recv()
+
+		if event.SubscriptionDropped != nil {
+			return true, event.SubscriptionDropped.Error
+		}
+
+		if event.EventAppeared != nil {
+			streamId := event.EventAppeared.OriginalEvent().StreamID
+			revision := event.EventAppeared.OriginalEvent().EventNumber
+			s.log.Info(
+				fmt.Sprintf(
+					"event appeared in subscription to all '%s'. streamId: %s, revision: %d",
+					s.subscriptionId,
+					streamId,
+					revision,
+				),
+			)
+
+			// handles the event...
+			err := s.handleEvent(ctx, event.EventAppeared)
+			if err != nil {
+				return false, err
+			}
+
+			options.From = event.EventAppeared.OriginalEvent().Position
 		}
 	}
 }
@@ -182,13 +240,17 @@ func (s *esdbSubscriptionAllWorker) handleEvent(
 		return errors.WrapIf(err, "failed to convert resolved event to stream event")
 	}
 
+	replayed := s.replayUntil > 0 && resolvedEvent.Event.Position.Commit <= s.replayUntil
+
 	// publish to internal event bus - for handling event and project it manually tp corresponding read model
-	err = mediatr.Publish(ctx, streamEvent)
-	if err != nil {
-		return errors.WrapIf(
-			err,
-			"failed to publish stream event for the mediatr (internal event bus for handling event)",
-		)
+	if !s.subscriptionOption.SkipEventBus && !replayed {
+		err = mediatr.Publish(ctx, streamEvent)
+		if err != nil {
+			return errors.WrapIf(
+				err,
+				"failed to publish stream event for the mediatr (internal event bus for handling event)",
+			)
+		}
 	}
 
 	// publish to projection publisher
@@ -197,6 +259,10 @@ func (s *esdbSubscriptionAllWorker) handleEvent(
 		return errors.WrapIf(err, "failed to publish stream event in the handle event")
 	}
 
+	if replayed {
+		return nil
+	}
+
 	err = s.subscriptionCheckpointRepository.Store(
 		s.subscriptionId,
 		resolvedEvent.Event.Position.Commit,
@@ -219,25 +285,10 @@ func (s *esdbSubscriptionAllWorker) isEventWithEmptyData(resolvedEvent *esdb.Res
 }
 
 func (s *esdbSubscriptionAllWorker) isCheckpointEvent(resolvedEvent *esdb.ResolvedEvent) bool {
-	name := typeMapper.GetFullTypeName(CheckpointStored{})
-	if resolvedEvent.Event.EventType != name {
+	if resolvedEvent.Event.EventType != checkpointEventType {
 		return false
 	}
 
 	s.log.Info("checkpoint event received - skipping")
 	return true
 }
-
-//https://developers.eventstore.com/clients/grpc/subscriptions.html#handling-subscription-drops
-//func (s *esdbSubscriptionAllWorker) resubscribe(ctx context.Context) {
-//	for true {
-//		err := s.SubscribeAll(ctx, s.subscriptionOption)
-//		if err != nil {
-//			s.log.Error(err)
-//			time.Sleep(time.Second * 1000)
-//			continue
-//		}
-//
-//		break
-//	}
-//}
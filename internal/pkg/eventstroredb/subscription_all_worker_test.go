@@ -0,0 +1,21 @@
+package eventstroredb
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Rebuild_Projections_Flag(t *testing.T) {
+	args := os.Args
+	defer func() {
+		os.Args = args
+	}()
+
+	os.Args = []string{"app", "--local"}
+	assert.False(t, IsRebuildProjections())
+
+	os.Args = []string{"app", RebuildProjectionsFlag}
+	assert.True(t, IsRebuildProjections())
+}
@@ -0,0 +1,33 @@
+package exchangerates
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[ExchangeRateOptions]())
+
+type ExchangeRateOptions struct {
+	// BaseCurrency is the currency of the prices of the price lists and the catalog, the rates are the amounts of the
+	// currencies for one unit of the base currency
+	BaseCurrency string `mapstructure:"baseCurrency" default:"USD"`
+	// Rates are the rates of the config provider, it is used when no exchange-rate provider is registered
+	Rates map[string]float64 `mapstructure:"rates"`
+	// RefreshInterval is the interval of fetching the rates from the providers, zero disables the refresh
+	RefreshInterval time.Duration `mapstructure:"refreshInterval" default:"1h"`
+	// StaleAfter is the age of the rates after that they are stale, the stale rates are used with a warning until a
+	// refresh succeeds
+	StaleAfter time.Duration `mapstructure:"staleAfter" default:"24h"`
+	// MaxStaleness is the age of the rates after that they are not used anymore and the conversions fail, zero keeps
+	// using the stale rates
+	MaxStaleness time.Duration `mapstructure:"maxStaleness" default:"72h"`
+}
+
+func ProvideConfig(environment environment.Environment) (*ExchangeRateOptions, error) {
+	return config.BindConfigKey[*ExchangeRateOptions](optionName, environment)
+}
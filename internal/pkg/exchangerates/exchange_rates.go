@@ -0,0 +1,187 @@
+package exchangerates
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+
+	"emperror.dev/errors"
+)
+
+var (
+	// ErrRatesUnavailable is returned when no provider has returned the rates yet, or the rates are older than the max
+	// staleness
+	ErrRatesUnavailable = errors.New("exchange rates are not available")
+	// ErrUnknownCurrency is returned when the rates don't have the currency
+	ErrUnknownCurrency = errors.New("currency has no exchange rate")
+)
+
+// ExchangeRates converts the amounts between the currencies with the cached rates of the providers, the cached rates are
+// replaced on each successful refresh and the stale rates are used with a warning when the providers are not available.
+type ExchangeRates interface {
+	BaseCurrency() string
+	// Rate returns the amount of the `to` currency for one unit of the `from` currency
+	Rate(from string, to string) (float64, error)
+	Convert(amount float64, from string, to string) (float64, error)
+	// Refresh fetches the rates from the first provider which returns them, the previous rates are kept on error
+	Refresh(ctx context.Context) error
+}
+
+type exchangeRates struct {
+	options   *ExchangeRateOptions
+	providers []Provider
+	log       logger.Logger
+	now       func() time.Time
+
+	mu        sync.RWMutex
+	rates     map[string]float64
+	fetchedAt time.Time
+	// staleWarned is set after the first warning of the stale rates, so the conversions don't flood the logs
+	staleWarned bool
+}
+
+func NewExchangeRates(options *ExchangeRateOptions, log logger.Logger, providers ...Provider) ExchangeRates {
+	return &exchangeRates{
+		options:   options,
+		providers: providers,
+		log:       log,
+		now:       time.Now,
+	}
+}
+
+func (e *exchangeRates) BaseCurrency() string {
+	return NormalizeCurrency(e.options.BaseCurrency)
+}
+
+func (e *exchangeRates) Rate(from string, to string) (float64, error) {
+	from, to = NormalizeCurrency(from), NormalizeCurrency(to)
+	if from == to {
+		return 1, nil
+	}
+
+	rates, err := e.currentRates()
+	if err != nil {
+		return 0, err
+	}
+
+	fromRate, err := rateOf(rates, from)
+	if err != nil {
+		return 0, err
+	}
+
+	toRate, err := rateOf(rates, to)
+	if err != nil {
+		return 0, err
+	}
+
+	return toRate / fromRate, nil
+}
+
+func (e *exchangeRates) Convert(amount float64, from string, to string) (float64, error) {
+	rate, err := e.Rate(from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	return amount * rate, nil
+}
+
+func (e *exchangeRates) Refresh(ctx context.Context) error {
+	if len(e.providers) == 0 {
+		return errors.WithStack(ErrRatesUnavailable)
+	}
+
+	var err error
+	for _, provider := range e.providers {
+		rates, providerErr := provider.Rates(ctx, e.BaseCurrency())
+		if providerErr == nil && len(rates) == 0 {
+			providerErr = errors.New("provider returned no rates")
+		}
+		if providerErr != nil {
+			err = errors.Append(
+				err,
+				errors.WrapIf(providerErr, fmt.Sprintf("error in fetching rates of provider '%s'", provider.Name())),
+			)
+
+			continue
+		}
+
+		e.setRates(rates)
+
+		return nil
+	}
+
+	return err
+}
+
+func (e *exchangeRates) setRates(providerRates map[string]float64) {
+	rates := make(map[string]float64, len(providerRates)+1)
+	for currency, rate := range providerRates {
+		if rate > 0 {
+			rates[NormalizeCurrency(currency)] = rate
+		}
+	}
+	rates[e.BaseCurrency()] = 1
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.rates = rates
+	e.fetchedAt = e.now()
+	e.staleWarned = false
+}
+
+// currentRates returns the cached rates, the stale rates are returned with a warning until they are older than the max
+// staleness
+func (e *exchangeRates) currentRates() (map[string]float64, error) {
+	e.mu.RLock()
+	rates, fetchedAt, staleWarned := e.rates, e.fetchedAt, e.staleWarned
+	e.mu.RUnlock()
+
+	if rates == nil {
+		return nil, errors.WithStack(ErrRatesUnavailable)
+	}
+
+	age := e.now().Sub(fetchedAt)
+
+	if e.options.MaxStaleness > 0 && age > e.options.MaxStaleness {
+		return nil, errors.WrapIff(
+			ErrRatesUnavailable,
+			"exchange rates are fetched %s ago, more than the max staleness %s",
+			age.Round(time.Second),
+			e.options.MaxStaleness,
+		)
+	}
+
+	if e.options.StaleAfter > 0 && age > e.options.StaleAfter && !staleWarned {
+		e.mu.Lock()
+		e.staleWarned = true
+		e.mu.Unlock()
+
+		e.log.Warnf(
+			"exchange rates are stale, they are fetched at %s, %s ago",
+			fetchedAt.Format(time.RFC3339),
+			age.Round(time.Second),
+		)
+	}
+
+	return rates, nil
+}
+
+func rateOf(rates map[string]float64, currency string) (float64, error) {
+	rate, ok := rates[currency]
+	if !ok {
+		return 0, errors.WrapIff(ErrUnknownCurrency, "currency '%s'", currency)
+	}
+
+	return rate, nil
+}
+
+// NormalizeCurrency upper cases the ISO 4217 code of the currency, because the config keys are lower cased by viper
+func NormalizeCurrency(currency string) string {
+	return strings.ToUpper(strings.TrimSpace(currency))
+}
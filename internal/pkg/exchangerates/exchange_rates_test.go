@@ -0,0 +1,110 @@
+package exchangerates
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubProvider struct {
+	rates map[string]float64
+	err   error
+}
+
+func (s *stubProvider) Name() string {
+	return "stub"
+}
+
+func (s *stubProvider) Rates(ctx context.Context, baseCurrency string) (map[string]float64, error) {
+	return s.rates, s.err
+}
+
+func newTestExchangeRates(now *time.Time, providers ...Provider) *exchangeRates {
+	options := &ExchangeRateOptions{BaseCurrency: "USD", StaleAfter: time.Hour, MaxStaleness: 24 * time.Hour}
+	rates := NewExchangeRates(options, defaultLogger.GetLogger(), providers...).(*exchangeRates)
+	rates.now = func() time.Time { return *now }
+
+	return rates
+}
+
+func Test_Exchange_Rates_Convert_Through_Base_Currency(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	rates := newTestExchangeRates(&now, &stubProvider{rates: map[string]float64{"eur": 0.5, "GBP": 0.25}})
+	require.NoError(t, rates.Refresh(context.Background()))
+
+	amount, err := rates.Convert(10, "USD", "EUR")
+	require.NoError(t, err)
+	assert.InDelta(t, 5, amount, 1e-9)
+
+	amount, err = rates.Convert(10, "eur", "gbp")
+	require.NoError(t, err)
+	assert.InDelta(t, 5, amount, 1e-9)
+
+	rate, err := rates.Rate("GBP", "GBP")
+	require.NoError(t, err)
+	assert.Equal(t, float64(1), rate)
+
+	_, err = rates.Rate("USD", "JPY")
+	assert.True(t, errors.Is(err, ErrUnknownCurrency))
+}
+
+func Test_Exchange_Rates_Fall_Back_To_Next_Provider(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	rates := newTestExchangeRates(
+		&now,
+		&stubProvider{err: errors.New("rates service is not available")},
+		&stubProvider{rates: map[string]float64{"EUR": 0.5}},
+	)
+	require.NoError(t, rates.Refresh(context.Background()))
+
+	rate, err := rates.Rate("USD", "EUR")
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, rate)
+}
+
+func Test_Exchange_Rates_Use_Stale_Rates_Until_Max_Staleness(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	provider := &stubProvider{rates: map[string]float64{"EUR": 0.5}}
+	rates := newTestExchangeRates(&now, provider)
+	require.NoError(t, rates.Refresh(context.Background()))
+
+	provider.err = errors.New("rates service is not available")
+	now = now.Add(2 * time.Hour)
+	require.Error(t, rates.Refresh(context.Background()))
+
+	rate, err := rates.Rate("USD", "EUR")
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, rate)
+	assert.True(t, rates.staleWarned)
+
+	now = now.Add(24 * time.Hour)
+	_, err = rates.Rate("USD", "EUR")
+	assert.True(t, errors.Is(err, ErrRatesUnavailable))
+}
+
+func Test_Exchange_Rates_Are_Unavailable_Before_First_Refresh(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	rates := newTestExchangeRates(&now, &stubProvider{err: errors.New("rates service is not available")})
+	require.Error(t, rates.Refresh(context.Background()))
+
+	_, err := rates.Convert(10, "USD", "EUR")
+	assert.True(t, errors.Is(err, ErrRatesUnavailable))
+
+	amount, err := rates.Convert(10, "USD", "usd")
+	require.NoError(t, err)
+	assert.Equal(t, float64(10), amount)
+}
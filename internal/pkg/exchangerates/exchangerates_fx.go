@@ -0,0 +1,76 @@
+package exchangerates
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/web"
+
+	"go.uber.org/fx"
+)
+
+// Module provided to fxlog
+// https://uber-go.github.io/fx/modules.html
+var Module = fx.Module( //nolint:gochecknoglobals
+	"exchangeratesfx",
+	fx.Provide(
+		ProvideConfig,
+		fx.Annotate(
+			provideExchangeRates,
+			fx.ParamTags(``, ``, ``, `group:"exchangeRateProviders"`),
+		),
+	),
+	fx.Invoke(registerHooks),
+)
+
+// provideExchangeRates fetches the rates at startup from the exchange-rate providers of the `exchangeRateProviders`
+// group, the config rates are used when no provider is registered. a failed fetch doesn't fail the startup, the
+// conversions fail until a refresh succeeds.
+func provideExchangeRates(
+	environment environment.Environment,
+	options *ExchangeRateOptions,
+	log logger.Logger,
+	providers []Provider,
+) ExchangeRates {
+	if len(providers) == 0 {
+		providers = []Provider{NewConfigProvider(environment)}
+	}
+
+	rates := NewExchangeRates(options, log, providers...)
+
+	if err := rates.Refresh(context.Background()); err != nil {
+		log.Errorf("(ExchangeRates.Refresh) error in fetching exchange rates: {%v}", err)
+	}
+
+	return rates
+}
+
+// we don't want to register any dependencies here, its func body should execute always even we don't request for that, so we should use `invoke`
+func registerHooks(
+	lc fx.Lifecycle,
+	rates ExchangeRates,
+	options *ExchangeRateOptions,
+	log logger.Logger,
+) error {
+	if options.RefreshInterval <= 0 {
+		return nil
+	}
+
+	return web.RegisterPeriodicWorker(
+		lc,
+		"exchange rates refresh worker",
+		options.RefreshInterval,
+		log,
+		func(ctx context.Context) error {
+			if err := rates.Refresh(ctx); err != nil && ctx.Err() == nil {
+				log.Warnf(
+					"(ExchangeRates.Refresh) error in refreshing exchange rates, the previous rates are used: {%v}",
+					err,
+				)
+			}
+
+			return nil
+		},
+	)
+}
@@ -0,0 +1,38 @@
+package exchangerates
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+)
+
+// Provider fetches the exchange rates from a source like the config or a rates service, the providers are provided
+// with the `exchangeRateProviders` fx group and are tried in order until one of them returns the rates.
+type Provider interface {
+	Name() string
+	// Rates returns the amounts of the currencies for one unit of the base currency
+	Rates(ctx context.Context, baseCurrency string) (map[string]float64, error)
+}
+
+type configProvider struct {
+	environment environment.Environment
+}
+
+// NewConfigProvider reads the rates of the `exchangeRateOptions` config on each refresh, so the changes of the config
+// file or the environment variables are applied on the next refresh.
+func NewConfigProvider(environment environment.Environment) Provider {
+	return &configProvider{environment: environment}
+}
+
+func (c *configProvider) Name() string {
+	return "config"
+}
+
+func (c *configProvider) Rates(ctx context.Context, baseCurrency string) (map[string]float64, error) {
+	options, err := ProvideConfig(c.environment)
+	if err != nil {
+		return nil, err
+	}
+
+	return options.Rates, nil
+}
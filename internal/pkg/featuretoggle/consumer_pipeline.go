@@ -0,0 +1,34 @@
+package featuretoggle
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/pipeline"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/utils"
+)
+
+type consumerPipeline struct {
+	toggles FeatureToggles
+}
+
+// NewConsumerPipeline pauses the consumer of a disabled message until it is enabled again, the message isn't
+// acknowledged while it is paused, so it is redelivered if the service stops.
+func NewConsumerPipeline(toggles FeatureToggles) pipeline.ConsumerPipeline {
+	return &consumerPipeline{toggles: toggles}
+}
+
+func (c *consumerPipeline) Handle(
+	ctx context.Context,
+	consumerContext types.MessageConsumeContext,
+	next pipeline.ConsumerHandlerFunc,
+) error {
+	if c.toggles != nil {
+		err := c.toggles.WaitEnabled(ctx, ConsumerToggle(utils.GetMessageName(consumerContext.Message())))
+		if err != nil {
+			return err
+		}
+	}
+
+	return next(ctx)
+}
@@ -0,0 +1,26 @@
+package featuretoggle
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[FeatureToggleOptions]())
+
+type FeatureToggleOptions struct {
+	// Toggles are the switches of the consumers, endpoints, projections and workers by their toggle name, like
+	// `projection:mongo_order_projection`, the features without a toggle are enabled
+	Toggles map[string]bool `mapstructure:"toggles"`
+	// RefreshInterval is the interval of re-evaluating the toggles of the config and the feature-flag providers, so
+	// the changes are applied without restarting the service, zero disables the refresh
+	RefreshInterval time.Duration `mapstructure:"refreshInterval" default:"30s"`
+}
+
+func ProvideConfig(environment environment.Environment) (*FeatureToggleOptions, error) {
+	return config.BindConfigKey[*FeatureToggleOptions](optionName, environment)
+}
@@ -0,0 +1,127 @@
+package featuretoggle
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"emperror.dev/errors"
+)
+
+// the prefixes of the toggle names, they can't contain `.` because it is the key delimiter of the config
+const (
+	consumerPrefix   = "consumer:"
+	endpointPrefix   = "endpoint:"
+	projectionPrefix = "projection:"
+	workerPrefix     = "worker:"
+)
+
+// FeatureToggles evaluates the toggles of the service, a feature is enabled unless it is disabled by a provider.
+type FeatureToggles interface {
+	IsEnabled(name string) bool
+	// WaitEnabled blocks until the feature is enabled or the context is done
+	WaitEnabled(ctx context.Context, name string) error
+	// Refresh re-evaluates the toggles of the providers, the previous toggles are kept on error
+	Refresh(ctx context.Context) error
+}
+
+type featureToggles struct {
+	providers []Provider
+	mu        sync.RWMutex
+	toggles   map[string]bool
+	// changed is closed and replaced on each refresh, so the waiters re-check their toggle
+	changed chan struct{}
+}
+
+// NewFeatureToggles evaluates the toggles of the providers in order, so the later providers override the earlier ones
+func NewFeatureToggles(providers ...Provider) FeatureToggles {
+	return &featureToggles{
+		providers: providers,
+		toggles:   map[string]bool{},
+		changed:   make(chan struct{}),
+	}
+}
+
+func (f *featureToggles) IsEnabled(name string) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return f.isEnabled(name)
+}
+
+func (f *featureToggles) WaitEnabled(ctx context.Context, name string) error {
+	for {
+		f.mu.RLock()
+		enabled := f.isEnabled(name)
+		changed := f.changed
+		f.mu.RUnlock()
+
+		if enabled {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+func (f *featureToggles) Refresh(ctx context.Context) error {
+	toggles := map[string]bool{}
+
+	for _, provider := range f.providers {
+		providerToggles, err := provider.Toggles(ctx)
+		if err != nil {
+			return errors.WrapIf(err, fmt.Sprintf("error in evaluating toggles of provider '%s'", provider.Name()))
+		}
+
+		for name, enabled := range providerToggles {
+			toggles[normalize(name)] = enabled
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.toggles = toggles
+	close(f.changed)
+	f.changed = make(chan struct{})
+
+	return nil
+}
+
+func (f *featureToggles) isEnabled(name string) bool {
+	if enabled, ok := f.toggles[normalize(name)]; ok {
+		return enabled
+	}
+
+	return true
+}
+
+// ConsumerToggle is the toggle name of the consumer of a message, like `consumer:product_created_v1`
+func ConsumerToggle(messageName string) string {
+	return consumerPrefix + messageName
+}
+
+// EndpointToggle is the toggle name of an http route, like `endpoint:post /api/v1/orders`
+func EndpointToggle(method string, path string) string {
+	return fmt.Sprintf("%s%s %s", endpointPrefix, method, path)
+}
+
+// ProjectionToggle is the toggle name of a projection, like `projection:mongo_order_projection`
+func ProjectionToggle(projectionName string) string {
+	return projectionPrefix + projectionName
+}
+
+// WorkerToggle is the toggle name of a background worker, like `worker:retention`
+func WorkerToggle(workerName string) string {
+	return workerPrefix + workerName
+}
+
+// normalize lower cases the names, because the config keys are lower cased by viper
+func normalize(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
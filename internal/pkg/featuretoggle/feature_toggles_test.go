@@ -0,0 +1,106 @@
+package featuretoggle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubProvider struct {
+	toggles map[string]bool
+	err     error
+}
+
+func (s *stubProvider) Name() string {
+	return "stub"
+}
+
+func (s *stubProvider) Toggles(ctx context.Context) (map[string]bool, error) {
+	return s.toggles, s.err
+}
+
+func Test_Feature_Toggles_Are_Enabled_By_Default(t *testing.T) {
+	t.Parallel()
+
+	toggles := NewFeatureToggles()
+	require.NoError(t, toggles.Refresh(context.Background()))
+
+	assert.True(t, toggles.IsEnabled(WorkerToggle("retention")))
+}
+
+func Test_Feature_Toggles_Later_Providers_Override(t *testing.T) {
+	t.Parallel()
+
+	config := &stubProvider{toggles: map[string]bool{
+		"projection:mongo_order_projection": false,
+		"worker:retention":                  false,
+	}}
+	flags := &stubProvider{toggles: map[string]bool{"Worker:Retention": true}}
+
+	toggles := NewFeatureToggles(config, flags)
+	require.NoError(t, toggles.Refresh(context.Background()))
+
+	assert.False(t, toggles.IsEnabled(ProjectionToggle("mongo_order_projection")))
+	assert.True(t, toggles.IsEnabled(WorkerToggle("retention")))
+	assert.True(t, toggles.IsEnabled(EndpointToggle("GET", "/api/v1/orders")))
+}
+
+func Test_Feature_Toggles_Keep_Previous_Toggles_On_Error(t *testing.T) {
+	t.Parallel()
+
+	provider := &stubProvider{toggles: map[string]bool{"worker:retention": false}}
+	toggles := NewFeatureToggles(provider)
+	require.NoError(t, toggles.Refresh(context.Background()))
+
+	provider.toggles = map[string]bool{}
+	provider.err = errors.New("provider is not available")
+
+	assert.Error(t, toggles.Refresh(context.Background()))
+	assert.False(t, toggles.IsEnabled(WorkerToggle("retention")))
+}
+
+func Test_Feature_Toggles_Wait_Enabled(t *testing.T) {
+	t.Parallel()
+
+	provider := &stubProvider{toggles: map[string]bool{"consumer:order_created_v1": false}}
+	toggles := NewFeatureToggles(provider)
+	require.NoError(t, toggles.Refresh(context.Background()))
+
+	waited := make(chan error, 1)
+	go func() {
+		waited <- toggles.WaitEnabled(context.Background(), ConsumerToggle("order_created_v1"))
+	}()
+
+	select {
+	case <-waited:
+		t.Fatal("wait should block while the toggle is disabled")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	provider.toggles = map[string]bool{"consumer:order_created_v1": true}
+	require.NoError(t, toggles.Refresh(context.Background()))
+
+	select {
+	case err := <-waited:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("wait should return after the toggle is enabled")
+	}
+}
+
+func Test_Feature_Toggles_Wait_Enabled_Canceled(t *testing.T) {
+	t.Parallel()
+
+	toggles := NewFeatureToggles(&stubProvider{toggles: map[string]bool{"consumer:order_created_v1": false}})
+	require.NoError(t, toggles.Refresh(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := toggles.WaitEnabled(ctx, ConsumerToggle("order_created_v1"))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,58 @@
+package featuretoggle
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/web"
+
+	"go.uber.org/fx"
+)
+
+// Module provided to fxlog
+// https://uber-go.github.io/fx/modules.html
+var Module = fx.Module( //nolint:gochecknoglobals
+	"featuretogglefx",
+	fx.Provide(
+		ProvideConfig,
+		fx.Annotate(
+			provideFeatureToggles,
+			fx.ParamTags(``, `group:"featureToggleProviders"`),
+		),
+	),
+	fx.Invoke(registerHooks),
+)
+
+// provideFeatureToggles evaluates the toggles at startup, the config toggles are overridden by the feature-flag
+// providers of the `featureToggleProviders` group
+func provideFeatureToggles(environment environment.Environment, providers []Provider) (FeatureToggles, error) {
+	toggles := NewFeatureToggles(append([]Provider{NewConfigProvider(environment)}, providers...)...)
+
+	err := toggles.Refresh(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return toggles, nil
+}
+
+// we don't want to register any dependencies here, its func body should execute always even we don't request for that, so we should use `invoke`
+func registerHooks(
+	lc fx.Lifecycle,
+	toggles FeatureToggles,
+	options *FeatureToggleOptions,
+	log logger.Logger,
+) error {
+	if options.RefreshInterval <= 0 {
+		return nil
+	}
+
+	return web.RegisterPeriodicWorker(
+		lc,
+		"feature toggles refresh worker",
+		options.RefreshInterval,
+		log,
+		toggles.Refresh,
+	)
+}
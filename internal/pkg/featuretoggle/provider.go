@@ -0,0 +1,37 @@
+package featuretoggle
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+)
+
+// Provider evaluates the toggles from a source like the config or a feature-flag service, the providers are provided
+// with the `featureToggleProviders` fx group and override the toggles of the config.
+type Provider interface {
+	Name() string
+	Toggles(ctx context.Context) (map[string]bool, error)
+}
+
+type configProvider struct {
+	environment environment.Environment
+}
+
+// NewConfigProvider reads the toggles of the `featureToggleOptions` config on each evaluation, so the changes of the
+// config file or the environment variables are applied on the next refresh.
+func NewConfigProvider(environment environment.Environment) Provider {
+	return &configProvider{environment: environment}
+}
+
+func (c *configProvider) Name() string {
+	return "config"
+}
+
+func (c *configProvider) Toggles(ctx context.Context) (map[string]bool, error) {
+	options, err := ProvideConfig(c.environment)
+	if err != nil {
+		return nil, err
+	}
+
+	return options.Toggles, nil
+}
@@ -0,0 +1,58 @@
+package featuretoggle
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/projection"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+type toggledProjection struct {
+	projection projection.IProjection
+	toggles    FeatureToggles
+	toggle     string
+	log        logger.Logger
+}
+
+// NewToggledProjections wraps the projections with their toggles. the events of a disabled projection are skipped,
+// because all the projections share the subscription checkpoint, so the read model of the projection should be
+// rebuilt if the skipped events are needed.
+func NewToggledProjections(
+	toggles FeatureToggles,
+	log logger.Logger,
+	projections ...projection.IProjection,
+) []projection.IProjection {
+	toggled := make([]projection.IProjection, 0, len(projections))
+	for _, p := range projections {
+		toggled = append(toggled, &toggledProjection{
+			projection: p,
+			toggles:    toggles,
+			toggle:     ProjectionToggle(strcase.ToSnake(typeMapper.GetNonePointerTypeName(p))),
+			log:        log,
+		})
+	}
+
+	return toggled
+}
+
+func (t *toggledProjection) ProcessEvent(ctx context.Context, streamEvent *models.StreamEvent) error {
+	if !t.toggles.IsEnabled(t.toggle) {
+		t.log.Debugw(
+			"[toggledProjection.ProcessEvent] projection is disabled, event is skipped",
+			logger.Fields{"Toggle": t.toggle, "EventId": streamEvent.EventID},
+		)
+
+		return nil
+	}
+
+	return t.projection.ProcessEvent(ctx, streamEvent)
+}
+
+// Unwrap returns the projection of the toggle
+func (t *toggledProjection) Unwrap() projection.IProjection {
+	return t.projection
+}
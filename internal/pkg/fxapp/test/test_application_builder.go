@@ -4,6 +4,7 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/fxapp"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/fxapp/contracts"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/inmemorybus"
 
 	"go.uber.org/fx/fxtest"
 )
@@ -32,3 +33,12 @@ func (a *TestApplicationBuilder) Build() contracts.Application {
 
 	return app
 }
+
+// WithInMemoryBus registers the in-memory bus as the `bus.Bus` and the `producer.Producer` of the app, so the tests
+// don't need a rabbitmq container, the configuration constructor returns the `inmemorybus.ConfigurationFunc` and can be
+// nil. the app should not register a message broker module.
+func (a *TestApplicationBuilder) WithInMemoryBus(configurationConstructor interface{}) *TestApplicationBuilder {
+	a.ProvideModule(inmemorybus.ModuleFunc(configurationConstructor))
+
+	return a
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/grpc/config"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/grpc/handlers/otel"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/grpc/interceptors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
 
 	"emperror.dev/errors"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
@@ -25,17 +27,31 @@ type GrpcClient interface {
 	WaitForAvailableConnection() error
 }
 
-func NewGrpcClient(config *config.GrpcOptions) (GrpcClient, error) {
+func NewGrpcClient(
+	config *config.GrpcOptions,
+	logger logger.Logger,
+	logLevels *logger.LogLevels,
+) (GrpcClient, error) {
 	// Grpc Client to call Grpc Server
 	// https://sahansera.dev/building-grpc-client-go/
 	// https://github.com/open-telemetry/opentelemetry-go-contrib/blob/df16f32df86b40077c9c90d06f33c4cdb6dd5afa/instrumentation/google.golang.org/grpc/otelgrpc/example_interceptor_test.go
-	conn, err := grpc.Dial(fmt.Sprintf("%s%s", config.Host, config.Port),
+	dialOptions := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		// https://github.com/open-telemetry/opentelemetry-go-contrib/blob/main/instrumentation/google.golang.org/grpc/otelgrpc/example/client/main.go#L47C3-L47C52
 		// https://github.com/open-telemetry/opentelemetry-go-contrib/blob/main/instrumentation/google.golang.org/grpc/otelgrpc/doc.go
 		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
 		grpc.WithStatsHandler(otel.NewClientHandler()),
-	)
+	}
+	if config.PayloadLogging.Enabled {
+		dialOptions = append(
+			dialOptions,
+			grpc.WithChainUnaryInterceptor(
+				interceptors.UnaryClientPayloadLoggingInterceptor(config.PayloadLogging, logger, logLevels),
+			),
+		)
+	}
+
+	conn, err := grpc.Dial(fmt.Sprintf("%s%s", config.Host, config.Port), dialOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -15,6 +15,25 @@ type GrpcOptions struct {
 	Host        string `mapstructure:"host"        env:"Host"`
 	Development bool   `mapstructure:"development" env:"Development"`
 	Name        string `mapstructure:"name"        env:"ShortTypeName"`
+	// PayloadLogging logs the sampled payloads of the unary calls for debugging the contracts between the services
+	PayloadLogging GrpcPayloadLoggingOptions `mapstructure:"payloadLogging"`
+}
+
+// GrpcPayloadLoggingOptions controls the payload logging interceptors of the server and the client, the payloads are
+// only logged while the level of the `grpcPayloads` log category is `debug`, so they can be turned on and off at
+// runtime with the log levels diagnostics endpoint.
+type GrpcPayloadLoggingOptions struct {
+	// Enabled adds the payload logging interceptors
+	Enabled bool `mapstructure:"enabled"`
+	// Level is the initial level of the `grpcPayloads` log category
+	Level string `mapstructure:"level"           default:"info"`
+	// SampleRate is the fraction of the calls with logged payloads, between 0 and 1
+	SampleRate float64 `mapstructure:"sampleRate"      default:"0.1"`
+	// MaxPayloadBytes truncates the logged payloads, zero doesn't truncate them
+	MaxPayloadBytes int `mapstructure:"maxPayloadBytes" default:"4096"`
+	// ScrubFields are replaced with `[redacted]` in the logged payloads besides the passwords, the api keys, the
+	// tokens, the secrets and the authorizations
+	ScrubFields []string `mapstructure:"scrubFields"`
 }
 
 func ProvideConfig(environment environment.Environment) (*GrpcOptions, error) {
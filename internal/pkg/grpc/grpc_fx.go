@@ -27,7 +27,7 @@ var (
 		// https://uber-go.github.io/fx/annotate.html
 		fx.Annotate(
 			NewGrpcServer,
-			fx.ParamTags(``, ``),
+			fx.ParamTags(``, ``, ``),
 		),
 		NewGrpcClient,
 	))
@@ -5,6 +5,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/tenant"
+
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
@@ -12,6 +14,7 @@ import (
 	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/stats"
 	"google.golang.org/grpc/status"
 )
@@ -176,12 +179,35 @@ func (h *handler) tagRPC(
 
 	gctx := gRPCContext{attributes: attributes, startTime: time.Now()}
 
+	ctx = h.propagateTenant(ctx)
+
 	return inject(
 		context.WithValue(ctx, gRPCContextKey{}, &gctx),
 		h.config.propagator,
 	)
 }
 
+// propagateTenant sends the resolved tenant of the client to the server with the `tenant-id` metadata, on the server
+// side the tenant holder is added to the context so the tenant resolved in the handler is recorded on the metrics.
+func (h *handler) propagateTenant(ctx context.Context) context.Context {
+	if h.spanKind == trace.SpanKindClient {
+		if tenantId, ok := tenant.GetTenantId(ctx); ok {
+			return metadata.AppendToOutgoingContext(ctx, tenant.TenantIdHeader, tenantId)
+		}
+
+		return ctx
+	}
+
+	ctx = tenant.NewContext(ctx)
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(tenant.TenantIdHeader); len(values) > 0 && values[0] != "" {
+			ctx = tenant.SetTenantId(ctx, values[0])
+		}
+	}
+
+	return ctx
+}
+
 func (h *handler) handleRPC(ctx context.Context, rs stats.RPCStats) {
 	_ = trace.SpanFromContext(ctx)
 	gctx, _ := ctx.Value(gRPCContextKey{}).(*gRPCContext)
@@ -191,17 +217,21 @@ func (h *handler) handleRPC(ctx context.Context, rs stats.RPCStats) {
 	case *stats.InPayload:
 		if gctx != nil {
 			// https://github.com/open-telemetry/opentelemetry-go/blob/main/example/prometheus/main.go#L52
-			opt := metric.WithAttributes(gctx.attributes...)
+			opt := metric.WithAttributes(withTenant(ctx, gctx.attributes)...)
 			h.rpcRequestSize.Record(ctx, int64(rs.Length), opt)
 		}
 
 	case *stats.OutPayload:
 		if gctx != nil {
 			// https://github.com/open-telemetry/opentelemetry-go/blob/main/example/prometheus/main.go#L52
-			opt := metric.WithAttributes(gctx.attributes...)
+			opt := metric.WithAttributes(withTenant(ctx, gctx.attributes)...)
 			h.rpcResponseSize.Record(ctx, int64(rs.Length), opt)
 		}
 	case *stats.End:
+		if gctx != nil {
+			gctx.attributes = withTenant(ctx, gctx.attributes)
+		}
+
 		if rs.Error != nil {
 			s, _ := status.FromError(rs.Error)
 			gctx.attributes = append(gctx.attributes, statusCodeAttr(s.Code()))
@@ -243,6 +273,20 @@ func (h *handler) handleRPC(ctx context.Context, rs stats.RPCStats) {
 	}
 }
 
+// withTenant returns a copy of the attributes with the tenant attribute, the payload stats of a stream can be handled
+// concurrently so the shared attributes shouldn't be appended in place.
+func withTenant(ctx context.Context, attributes []attribute.KeyValue) []attribute.KeyValue {
+	tenantAttributes := tenant.MetricAttributes(ctx)
+	if len(tenantAttributes) == 0 {
+		return attributes
+	}
+
+	result := make([]attribute.KeyValue, 0, len(attributes)+len(tenantAttributes))
+	result = append(result, attributes...)
+
+	return append(result, tenantAttributes...)
+}
+
 func statusCodeAttr(c codes.Code) attribute.KeyValue {
 	return semconv.RPCGRPCStatusCodeKey.Int(int(c))
 }
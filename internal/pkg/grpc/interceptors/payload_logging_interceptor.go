@@ -0,0 +1,200 @@
+package interceptors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	grpcConfig "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/grpc/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// PayloadsLogCategory is the log category of the grpc payloads, the payloads are only logged while its level is `debug`
+const PayloadsLogCategory = "grpcPayloads"
+
+// defaultScrubFields are always scrubbed from the logged payloads
+var defaultScrubFields = []string{"password", "apiKey", "token", "secret", "authorization"} //nolint:gochecknoglobals
+
+type payloadLogger struct {
+	log             logger.Logger
+	levels          *logger.LogLevels
+	sampleRate      float64
+	maxPayloadBytes int
+	scrubFields     map[string]bool
+}
+
+func newPayloadLogger(
+	options grpcConfig.GrpcPayloadLoggingOptions,
+	log logger.Logger,
+	levels *logger.LogLevels,
+) *payloadLogger {
+	if err := levels.InitLevel(PayloadsLogCategory, options.Level); err != nil {
+		log.Warnf("invalid grpc payload logging level '%s', the default log level is used", options.Level)
+	}
+
+	scrubFields := make(map[string]bool)
+	for _, field := range append(append([]string{}, defaultScrubFields...), options.ScrubFields...) {
+		scrubFields[normalizeFieldName(field)] = true
+	}
+
+	return &payloadLogger{
+		log:             log,
+		levels:          levels,
+		sampleRate:      options.SampleRate,
+		maxPayloadBytes: options.MaxPayloadBytes,
+		scrubFields:     scrubFields,
+	}
+}
+
+// UnaryServerPayloadLoggingInterceptor logs the sampled request and response payloads of the unary calls of the server,
+// the payloads are scrubbed and truncated to the max payload size
+func UnaryServerPayloadLoggingInterceptor(
+	options grpcConfig.GrpcPayloadLoggingOptions,
+	log logger.Logger,
+	levels *logger.LogLevels,
+) grpc.UnaryServerInterceptor {
+	payloadLogger := newPayloadLogger(options, log, levels)
+
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if !payloadLogger.sampled() {
+			return handler(ctx, req)
+		}
+
+		startTime := time.Now()
+		resp, err := handler(ctx, req)
+		payloadLogger.logPayloads("server", info.FullMethod, req, resp, time.Since(startTime), err)
+
+		return resp, err
+	}
+}
+
+// UnaryClientPayloadLoggingInterceptor logs the sampled request and reply payloads of the unary calls of the client,
+// the payloads are scrubbed and truncated to the max payload size
+func UnaryClientPayloadLoggingInterceptor(
+	options grpcConfig.GrpcPayloadLoggingOptions,
+	log logger.Logger,
+	levels *logger.LogLevels,
+) grpc.UnaryClientInterceptor {
+	payloadLogger := newPayloadLogger(options, log, levels)
+
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if !payloadLogger.sampled() {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		startTime := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		payloadLogger.logPayloads("client", method, req, reply, time.Since(startTime), err)
+
+		return err
+	}
+}
+
+func (p *payloadLogger) sampled() bool {
+	if !p.levels.Enabled(PayloadsLogCategory, "debug") {
+		return false
+	}
+
+	return p.sampleRate >= 1 || rand.Float64() < p.sampleRate //nolint:gosec
+}
+
+func (p *payloadLogger) logPayloads(
+	kind string,
+	method string,
+	req interface{},
+	resp interface{},
+	duration time.Duration,
+	err error,
+) {
+	fields := logger.Fields{
+		"grpc.kind":     kind,
+		"grpc.method":   method,
+		"grpc.code":     status.Code(err).String(),
+		"grpc.duration": duration.String(),
+		"grpc.request":  p.payload(req),
+	}
+	if err == nil {
+		fields["grpc.response"] = p.payload(resp)
+	}
+
+	// the category level decides about the payloads, so they are written regardless of the level of the logger
+	p.log.Infow(fmt.Sprintf("grpc %s payloads of %s", kind, method), fields)
+}
+
+// payload returns the json of the message with the scrubbed fields replaced and truncated to the max payload size
+func (p *payloadLogger) payload(message interface{}) string {
+	if message == nil {
+		return ""
+	}
+
+	var data []byte
+	var err error
+
+	if protoMessage, ok := message.(proto.Message); ok {
+		data, err = protojson.Marshal(protoMessage)
+	} else {
+		data, err = json.Marshal(message)
+	}
+	if err != nil {
+		return fmt.Sprintf("<unserializable payload: %v>", err)
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err == nil {
+		if scrubbed, err := json.Marshal(p.scrub(value)); err == nil {
+			data = scrubbed
+		}
+	}
+
+	if p.maxPayloadBytes > 0 && len(data) > p.maxPayloadBytes {
+		return fmt.Sprintf("%s...<truncated %d bytes>", data[:p.maxPayloadBytes], len(data)-p.maxPayloadBytes)
+	}
+
+	return string(data)
+}
+
+func (p *payloadLogger) scrub(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if p.scrubFields[normalizeFieldName(key)] {
+				v[key] = config.RedactedValue
+				continue
+			}
+
+			v[key] = p.scrub(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = p.scrub(item)
+		}
+	}
+
+	return value
+}
+
+// normalizeFieldName matches the json names and the proto names of a field, like `apiKey` and `api_key`
+func normalizeFieldName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
@@ -0,0 +1,102 @@
+package interceptors
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	grpcConfig "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/grpc/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	logConfig "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/empty"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type capturingLogger struct {
+	logger.Logger
+	entries []logger.Fields
+}
+
+func (l *capturingLogger) Infow(_ string, fields logger.Fields) {
+	l.entries = append(l.entries, fields)
+}
+
+func callServer(
+	t *testing.T,
+	options grpcConfig.GrpcPayloadLoggingOptions,
+	levels *logger.LogLevels,
+	req interface{},
+) *capturingLogger {
+	t.Helper()
+
+	log := &capturingLogger{Logger: empty.EmptyLogger}
+	interceptor := UnaryServerPayloadLoggingInterceptor(options, log, levels)
+
+	_, err := interceptor(
+		context.Background(),
+		req,
+		&grpc.UnaryServerInfo{FullMethod: "/products_service.ProductsService/CreateProduct"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return map[string]interface{}{"productId": "1"}, nil
+		},
+	)
+	require.NoError(t, err)
+
+	return log
+}
+
+func Test_Payload_Logging_Scrubs_And_Truncates_Payloads(t *testing.T) {
+	levels := logger.NewLogLevels(&logConfig.LogOptions{LogLevel: "info"})
+	require.NoError(t, levels.SetLevel(PayloadsLogCategory, "debug"))
+
+	req, err := structpb.NewStruct(map[string]interface{}{
+		"name":     "product",
+		"api_key":  "secret-key",
+		"customer": map[string]interface{}{"cardNumber": "4111"},
+	})
+	require.NoError(t, err)
+
+	log := callServer(t, grpcConfig.GrpcPayloadLoggingOptions{
+		SampleRate:  1,
+		ScrubFields: []string{"cardNumber"},
+	}, levels, req)
+
+	require.Len(t, log.entries, 1)
+	request := log.entries[0]["grpc.request"].(string)
+	assert.Contains(t, request, `"name":"product"`)
+	assert.Contains(t, request, `"api_key":"`+config.RedactedValue+`"`)
+	assert.Contains(t, request, `"cardNumber":"`+config.RedactedValue+`"`)
+	assert.NotContains(t, request, "secret-key")
+	assert.Equal(t, `{"productId":"1"}`, log.entries[0]["grpc.response"])
+	assert.Equal(t, "OK", log.entries[0]["grpc.code"])
+
+	log = callServer(t, grpcConfig.GrpcPayloadLoggingOptions{SampleRate: 1, MaxPayloadBytes: 10}, levels, req)
+
+	require.Len(t, log.entries, 1)
+	assert.True(t, strings.HasSuffix(log.entries[0]["grpc.request"].(string), "bytes>"))
+}
+
+func Test_Payload_Logging_Follows_Log_Level_Of_Category(t *testing.T) {
+	levels := logger.NewLogLevels(&logConfig.LogOptions{LogLevel: "debug"})
+	options := grpcConfig.GrpcPayloadLoggingOptions{Level: "info", SampleRate: 1}
+
+	// the initial level of the category turns the payloads off
+	log := callServer(t, options, levels, map[string]interface{}{"name": "product"})
+	assert.Empty(t, log.entries)
+
+	require.NoError(t, levels.SetLevel(PayloadsLogCategory, "debug"))
+	log = callServer(t, options, levels, map[string]interface{}{"name": "product"})
+	assert.Len(t, log.entries, 1)
+}
+
+func Test_Payload_Logging_Without_Samples(t *testing.T) {
+	levels := logger.NewLogLevels(&logConfig.LogOptions{LogLevel: "debug"})
+
+	log := callServer(t, grpcConfig.GrpcPayloadLoggingOptions{SampleRate: 0}, levels, map[string]interface{}{})
+	assert.Empty(t, log.entries)
+}
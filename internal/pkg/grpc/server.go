@@ -47,12 +47,19 @@ type grpcServer struct {
 func NewGrpcServer(
 	config *config.GrpcOptions,
 	logger logger.Logger,
+	logLevels *logger.LogLevels,
 ) GrpcServer {
 	unaryServerInterceptors := []googleGrpc.UnaryServerInterceptor{
 		interceptors.UnaryServerInterceptor(),
 		grpcCtxTags.UnaryServerInterceptor(),
 		grpcRecovery.UnaryServerInterceptor(),
 	}
+	if config.PayloadLogging.Enabled {
+		unaryServerInterceptors = append(
+			unaryServerInterceptors,
+			interceptors.UnaryServerPayloadLoggingInterceptor(config.PayloadLogging, logger, logLevels),
+		)
+	}
 	streamServerInterceptors := []googleGrpc.StreamServerInterceptor{
 		interceptors.StreamServerInterceptor(),
 	}
@@ -0,0 +1,12 @@
+package contracts
+
+import "context"
+
+// DependencyMonitor watches the dependencies of the app, e.g. `mongodb` or `postgres`, by the names of their health
+// checks
+type DependencyMonitor interface {
+	// Unhealthy returns the unhealthy dependencies, the dependencies without a health check are healthy
+	Unhealthy(ctx context.Context, dependencies []string) []string
+	// WaitHealthy blocks while any of the dependencies is unhealthy, until the context is done
+	WaitHealthy(ctx context.Context, dependencies []string) error
+}
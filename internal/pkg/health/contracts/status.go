@@ -7,11 +7,13 @@ const (
 
 type Status struct {
 	Status string `json:"status"`
+	// Error is why the dependency is down, e.g. the state of its connection
+	Error string `json:"error,omitempty"`
 }
 
 func NewStatus(err error) Status {
 	if err != nil {
-		return Status{Status: StatusDown}
+		return Status{Status: StatusDown, Error: err.Error()}
 	}
 	return Status{Status: StatusUp}
 }
@@ -0,0 +1,95 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/health/contracts"
+)
+
+type dependencyStatus struct {
+	err       error
+	checkedAt time.Time
+}
+
+// dependencyMonitor keeps the results of the health checks for the check interval, so the consumers which ask for
+// their dependencies on each message don't run a check per message
+type dependencyMonitor struct {
+	healths  map[string]contracts.Health
+	interval time.Duration
+
+	mu       sync.Mutex
+	statuses map[string]*dependencyStatus
+}
+
+func NewDependencyMonitor(
+	healthParams contracts.HealthParams,
+	options *DependencyMonitorOptions,
+) contracts.DependencyMonitor {
+	healths := make(map[string]contracts.Health)
+	for _, health := range healthParams.Healths {
+		healths[health.GetHealthName()] = health
+	}
+
+	return &dependencyMonitor{
+		healths:  healths,
+		interval: options.CheckInterval,
+		statuses: make(map[string]*dependencyStatus),
+	}
+}
+
+func (m *dependencyMonitor) Unhealthy(ctx context.Context, dependencies []string) []string {
+	var unhealthy []string
+
+	for _, dependency := range dependencies {
+		if err := m.check(ctx, dependency); err != nil {
+			unhealthy = append(unhealthy, dependency)
+		}
+	}
+
+	return unhealthy
+}
+
+func (m *dependencyMonitor) WaitHealthy(ctx context.Context, dependencies []string) error {
+	if len(m.Unhealthy(ctx, dependencies)) == 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if len(m.Unhealthy(ctx, dependencies)) == 0 {
+				return nil
+			}
+		}
+	}
+}
+
+func (m *dependencyMonitor) check(ctx context.Context, dependency string) error {
+	health, ok := m.healths[dependency]
+	if !ok {
+		return nil
+	}
+
+	m.mu.Lock()
+	status, ok := m.statuses[dependency]
+	m.mu.Unlock()
+
+	if ok && time.Since(status.checkedAt) < m.interval {
+		return status.err
+	}
+
+	status = &dependencyStatus{err: health.CheckHealth(ctx), checkedAt: time.Now()}
+
+	m.mu.Lock()
+	m.statuses[dependency] = status
+	m.mu.Unlock()
+
+	return status.err
+}
@@ -0,0 +1,22 @@
+package health
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[DependencyMonitorOptions]())
+
+type DependencyMonitorOptions struct {
+	// CheckInterval is how long the result of a health check is kept, an unhealthy dependency is checked again after it
+	CheckInterval time.Duration `mapstructure:"checkInterval" default:"5s"`
+}
+
+func NewDependencyMonitorOptions(environment environment.Environment) (*DependencyMonitorOptions, error) {
+	return config.BindConfigKey[*DependencyMonitorOptions](optionName, environment)
+}
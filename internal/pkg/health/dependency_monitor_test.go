@@ -0,0 +1,70 @@
+package health
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/health/contracts"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeHealth struct {
+	name    string
+	healthy atomic.Bool
+	checks  atomic.Int32
+}
+
+func (h *fakeHealth) CheckHealth(context.Context) error {
+	h.checks.Add(1)
+	if h.healthy.Load() {
+		return nil
+	}
+
+	return errors.New("unhealthy")
+}
+
+func (h *fakeHealth) GetHealthName() string {
+	return h.name
+}
+
+func newTestDependencyMonitor(interval time.Duration, healths ...contracts.Health) contracts.DependencyMonitor {
+	return NewDependencyMonitor(
+		contracts.HealthParams{Healths: healths},
+		&DependencyMonitorOptions{CheckInterval: interval},
+	)
+}
+
+func Test_Dependency_Monitor_Keeps_Check_Results_For_The_Interval(t *testing.T) {
+	mongo := &fakeHealth{name: "mongodb"}
+	monitor := newTestDependencyMonitor(time.Minute, mongo)
+
+	assert.Equal(t, []string{"mongodb"}, monitor.Unhealthy(context.Background(), []string{"mongodb", "postgres"}))
+
+	mongo.healthy.Store(true)
+	assert.Equal(t, []string{"mongodb"}, monitor.Unhealthy(context.Background(), []string{"mongodb"}))
+	assert.Equal(t, int32(1), mongo.checks.Load())
+}
+
+func Test_Dependency_Monitor_Waits_Until_Dependencies_Are_Healthy(t *testing.T) {
+	mongo := &fakeHealth{name: "mongodb"}
+	monitor := newTestDependencyMonitor(10*time.Millisecond, mongo)
+
+	time.AfterFunc(50*time.Millisecond, func() { mongo.healthy.Store(true) })
+
+	require.NoError(t, monitor.WaitHealthy(context.Background(), []string{"mongodb"}))
+	assert.Empty(t, monitor.Unhealthy(context.Background(), []string{"mongodb"}))
+}
+
+func Test_Dependency_Monitor_Stops_Waiting_With_The_Context(t *testing.T) {
+	monitor := newTestDependencyMonitor(10*time.Millisecond, &fakeHealth{name: "mongodb"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, monitor.WaitHealthy(ctx, []string{"mongodb"}), context.DeadlineExceeded)
+}
@@ -8,6 +8,8 @@ var Module = fx.Options( //nolint:gochecknoglobals
 	fx.Provide(
 		NewHealthService,
 		NewHealthCheckEndpoint,
+		NewDependencyMonitorOptions,
+		NewDependencyMonitor,
 	),
 	fx.Invoke(func(endpoint *HealthCheckEndpoint) {
 		endpoint.RegisterEndpoints()
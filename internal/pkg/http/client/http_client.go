@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/go-resty/resty/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 )
 
 const (
@@ -22,7 +24,13 @@ func NewHttpClient() *resty.Client {
 	client := resty.New().
 		SetTimeout(timeout).
 		SetRetryCount(retryCount).
-		SetRetryWaitTime(retryWaitTime)
+		SetRetryWaitTime(retryWaitTime).
+		OnBeforeRequest(func(_ *resty.Client, request *resty.Request) error {
+			// propagates the trace context and the baggage entries like the `debug` entry to the downstream services
+			otel.GetTextMapPropagator().Inject(request.Context(), propagation.HeaderCarrier(request.Header))
+
+			return nil
+		})
 
 	return client
 }
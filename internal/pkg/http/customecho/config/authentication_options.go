@@ -0,0 +1,103 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/authentication"
+
+	"emperror.dev/errors"
+)
+
+// AuthenticationOptions composes the authentication schemes of the routes, the schemes are referenced by their names,
+// `anonymous`, `jwt`, `apiKey` and `internalMtls`, and a scheme other than `anonymous` needs its own options
+type AuthenticationOptions struct {
+	// DefaultSchemes authenticate the routes without a matching route, without default schemes these routes are not
+	// authenticated
+	DefaultSchemes []string `mapstructure:"defaultSchemes"`
+	// Routes override the DefaultSchemes per route group, the longest matching path wins
+	Routes       []*RouteAuthenticationOptions `mapstructure:"routes"`
+	Jwt          *JwtAuthenticationOptions     `mapstructure:"jwt"`
+	ApiKey       *ApiKeyAuthenticationOptions  `mapstructure:"apiKey"`
+	InternalMtls *MtlsAuthenticationOptions    `mapstructure:"internalMtls"`
+}
+
+// RouteAuthenticationOptions sets the schemes of the routes starting with the path, the schemes are tried in order
+type RouteAuthenticationOptions struct {
+	Path    string   `mapstructure:"path"`
+	Schemes []string `mapstructure:"schemes"`
+}
+
+type JwtAuthenticationOptions struct {
+	// SigningKey is the HMAC key of the tokens
+	SigningKey string `mapstructure:"signingKey" env:"JwtSigningKey" secret:"true"`
+	// Issuer is checked against the `iss` claim when it is not empty
+	Issuer string `mapstructure:"issuer"`
+	// Audience is checked against the `aud` claim when it is not empty
+	Audience string `mapstructure:"audience"`
+}
+
+type ApiKeyAuthenticationOptions struct {
+	// Header is the request header of the api key, the default header is `X-Api-Key`
+	Header string               `mapstructure:"header"`
+	Users  []*ApiKeyUserOptions `mapstructure:"users"`
+}
+
+type ApiKeyUserOptions struct {
+	UserId string `mapstructure:"userId"`
+	ApiKey string `mapstructure:"apiKey" secret:"true"`
+}
+
+type MtlsAuthenticationOptions struct {
+	// AllowedCommonNames are the accepted common names of the client certificates, empty accepts every verified
+	// certificate
+	AllowedCommonNames []string `mapstructure:"allowedCommonNames"`
+}
+
+// Validate checks the referenced schemes are known and configured, so a misconfigured route fails on startup instead of
+// rejecting its requests
+func (o *AuthenticationOptions) Validate() error {
+	if err := o.validateSchemes(o.DefaultSchemes); err != nil {
+		return errors.WrapIf(err, "invalid default authentication schemes")
+	}
+
+	for _, route := range o.Routes {
+		if route == nil || !strings.HasPrefix(route.Path, "/") {
+			return errors.New("route authentication path should start with '/'")
+		}
+
+		if len(route.Schemes) == 0 {
+			return errors.New(fmt.Sprintf("route authentication for path '%s' has no schemes", route.Path))
+		}
+
+		if err := o.validateSchemes(route.Schemes); err != nil {
+			return errors.WrapIf(err, fmt.Sprintf("invalid authentication schemes for path '%s'", route.Path))
+		}
+	}
+
+	return nil
+}
+
+func (o *AuthenticationOptions) validateSchemes(schemes []string) error {
+	for _, scheme := range schemes {
+		switch scheme {
+		case authentication.AnonymousScheme:
+		case authentication.JwtScheme:
+			if o.Jwt == nil || o.Jwt.SigningKey == "" {
+				return errors.New("jwt scheme needs a signing key")
+			}
+		case authentication.ApiKeyScheme:
+			if o.ApiKey == nil || len(o.ApiKey.Users) == 0 {
+				return errors.New("apiKey scheme needs at least one user")
+			}
+		case authentication.InternalMtlsScheme:
+			if o.InternalMtls == nil {
+				return errors.New("internalMtls scheme needs its options")
+			}
+		default:
+			return errors.New(fmt.Sprintf("unknown authentication scheme '%s'", scheme))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Validate_Authentication(t *testing.T) {
+	jwtOptions := &JwtAuthenticationOptions{SigningKey: "key"}
+
+	testCases := []struct {
+		name    string
+		options *AuthenticationOptions
+		valid   bool
+	}{
+		{name: "empty", options: &AuthenticationOptions{}, valid: true},
+		{
+			name: "composed routes",
+			options: &AuthenticationOptions{
+				DefaultSchemes: []string{"jwt"},
+				Routes: []*RouteAuthenticationOptions{
+					{Path: "/api/v1/products/public", Schemes: []string{"anonymous"}},
+					{Path: "/api/v1/admin", Schemes: []string{"internalMtls", "apiKey"}},
+				},
+				Jwt:          jwtOptions,
+				ApiKey:       &ApiKeyAuthenticationOptions{Users: []*ApiKeyUserOptions{{UserId: "admin", ApiKey: "key"}}},
+				InternalMtls: &MtlsAuthenticationOptions{},
+			},
+			valid: true,
+		},
+		{name: "unknown scheme", options: &AuthenticationOptions{DefaultSchemes: []string{"basic"}}},
+		{name: "jwt without signing key", options: &AuthenticationOptions{DefaultSchemes: []string{"jwt"}}},
+		{
+			name: "api key without users",
+			options: &AuthenticationOptions{
+				Routes: []*RouteAuthenticationOptions{{Path: "/api", Schemes: []string{"apiKey"}}},
+			},
+		},
+		{
+			name: "route without schemes",
+			options: &AuthenticationOptions{
+				Routes: []*RouteAuthenticationOptions{{Path: "/api"}},
+			},
+		},
+		{
+			name: "relative route path",
+			options: &AuthenticationOptions{
+				Routes: []*RouteAuthenticationOptions{{Path: "api", Schemes: []string{"anonymous"}}},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := (&EchoHttpOptions{Authentication: testCase.options}).Validate()
+			if testCase.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
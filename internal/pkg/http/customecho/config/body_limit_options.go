@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/constants"
+
+	"emperror.dev/errors"
+	"github.com/labstack/gommon/bytes"
+)
+
+// RouteBodyLimitOptions overrides the max request body size for the routes starting with the path, like a route
+// group with large uploads
+type RouteBodyLimitOptions struct {
+	Path  string `mapstructure:"path"`
+	Limit string `mapstructure:"limit"`
+}
+
+// GetBodyLimit returns the default max request body size, like `2M`
+func (c *EchoHttpOptions) GetBodyLimit() string {
+	if c.BodyLimit == "" {
+		return constants.BodyLimit
+	}
+
+	return c.BodyLimit
+}
+
+// Validate checks the body limits are parsable and the authentication schemes are configured, so an invalid option
+// fails on startup instead of the requests
+func (c *EchoHttpOptions) Validate() error {
+	if _, err := bytes.Parse(c.GetBodyLimit()); err != nil {
+		return errors.WrapIf(err, fmt.Sprintf("invalid body limit '%s'", c.GetBodyLimit()))
+	}
+
+	for _, routeLimit := range c.BodyLimits {
+		if routeLimit == nil || !strings.HasPrefix(routeLimit.Path, "/") {
+			return errors.New("route body limit path should start with '/'")
+		}
+
+		if _, err := bytes.Parse(routeLimit.Limit); err != nil {
+			return errors.WrapIf(
+				err,
+				fmt.Sprintf("invalid body limit '%s' for path '%s'", routeLimit.Limit, routeLimit.Path),
+			)
+		}
+	}
+
+	if c.Authentication != nil {
+		if err := c.Authentication.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
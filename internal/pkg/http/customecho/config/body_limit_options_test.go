@@ -0,0 +1,52 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/constants"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetBodyLimit(t *testing.T) {
+	assert.Equal(t, constants.BodyLimit, (&EchoHttpOptions{}).GetBodyLimit())
+	assert.Equal(t, "10M", (&EchoHttpOptions{BodyLimit: "10M"}).GetBodyLimit())
+}
+
+func Test_Validate_Body_Limits(t *testing.T) {
+	testCases := []struct {
+		name    string
+		options *EchoHttpOptions
+		valid   bool
+	}{
+		{name: "default", options: &EchoHttpOptions{}, valid: true},
+		{
+			name: "route limits",
+			options: &EchoHttpOptions{
+				BodyLimit:  "1M",
+				BodyLimits: []*RouteBodyLimitOptions{{Path: "/api/v1/products/import", Limit: "50M"}},
+			},
+			valid: true,
+		},
+		{name: "invalid default limit", options: &EchoHttpOptions{BodyLimit: "large"}},
+		{
+			name:    "invalid route limit",
+			options: &EchoHttpOptions{BodyLimits: []*RouteBodyLimitOptions{{Path: "/api", Limit: "large"}}},
+		},
+		{
+			name:    "relative route path",
+			options: &EchoHttpOptions{BodyLimits: []*RouteBodyLimitOptions{{Path: "api", Limit: "1M"}}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.options.Validate()
+			if testCase.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -22,6 +22,13 @@ type EchoHttpOptions struct {
 	Timeout             int      `mapstructure:"timeout"                                 env:"Timeout"`
 	Host                string   `mapstructure:"host"                                    env:"Host"`
 	Name                string   `mapstructure:"name"                                    env:"ShortTypeName"`
+	// BodyLimit is the default max request body size, like `2M`
+	BodyLimit string `mapstructure:"bodyLimit" env:"BodyLimit"`
+	// BodyLimits overrides the BodyLimit per route group, the longest matching path wins
+	BodyLimits []*RouteBodyLimitOptions `mapstructure:"bodyLimits"`
+	// Authentication composes the authentication schemes per route group, without it the requests are not
+	// authenticated by the server
+	Authentication *AuthenticationOptions `mapstructure:"authentication"`
 }
 
 func (c *EchoHttpOptions) Address() string {
@@ -37,5 +44,14 @@ func (c *EchoHttpOptions) BasePathAddress() string {
 }
 
 func ProvideConfig(environment environment.Environment) (*EchoHttpOptions, error) {
-	return config.BindConfigKey[*EchoHttpOptions](optionName, environment)
+	cfg, err := config.BindConfigKey[*EchoHttpOptions](optionName, environment)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
 }
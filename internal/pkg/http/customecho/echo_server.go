@@ -8,7 +8,11 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/constants"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/config"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/contracts"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/eventstream"
 	hadnlers "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/hadnlers"
+	apikey "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/api_key"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/authentication"
+	bodylimit "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/body_limit"
 	ipratelimit "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/ip_ratelimit"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/log"
 	otelMetrics "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/otel_metrics"
@@ -18,6 +22,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/samber/lo"
 	"go.opentelemetry.io/otel/metric"
 )
 
@@ -134,17 +139,75 @@ func (s *echoHttpServer) SetupDefaultMiddlewares() {
 			otelMetrics.WithServiceName(s.config.Name),
 			otelMetrics.WithSkipper(skipper)),
 	)
-	s.echo.Use(middleware.BodyLimit(constants.BodyLimit))
+	s.echo.Use(bodylimit.BodyLimit(s.bodyLimitOptions()...))
 	s.echo.Use(ipratelimit.IPRateLimit())
+	if s.config.Authentication != nil {
+		s.echo.Use(authentication.Authentication(s.authenticationOptions(skipper)...))
+	}
 	s.echo.Use(middleware.RequestID())
 	s.echo.Use(middleware.GzipWithConfig(middleware.GzipConfig{
-		Level:   constants.GzipLevel,
-		Skipper: skipper,
+		Level: constants.GzipLevel,
+		// the server sent events are flushed one by one, so they are not compressed
+		Skipper: func(c echo.Context) bool {
+			return skipper(c) || eventstream.IsStreamRequest(c)
+		},
 	}))
 	// should be last middleware
 	s.echo.Use(problemdetail.ProblemDetail(problemdetail.WithSkipper(skipper)))
 }
 
+func (s *echoHttpServer) bodyLimitOptions() []bodylimit.Option {
+	options := []bodylimit.Option{bodylimit.WithLimit(s.config.GetBodyLimit())}
+	for _, routeLimit := range s.config.BodyLimits {
+		options = append(options, bodylimit.WithRouteLimit(routeLimit.Path, routeLimit.Limit))
+	}
+
+	return options
+}
+
+func (s *echoHttpServer) authenticationOptions(skipper middleware.Skipper) []authentication.Option {
+	authOptions := s.config.Authentication
+
+	schemes := map[string]authentication.Scheme{
+		authentication.AnonymousScheme: authentication.Anonymous(),
+	}
+	if authOptions.Jwt != nil {
+		schemes[authentication.JwtScheme] = authentication.Jwt(
+			authOptions.Jwt.SigningKey,
+			authOptions.Jwt.Issuer,
+			authOptions.Jwt.Audience,
+		)
+	}
+	if authOptions.ApiKey != nil {
+		apiKeyOptions := []apikey.Option{apikey.WithHeader(authOptions.ApiKey.Header)}
+		for _, user := range authOptions.ApiKey.Users {
+			apiKeyOptions = append(apiKeyOptions, apikey.WithKey(user.ApiKey, user.UserId))
+		}
+		schemes[authentication.ApiKeyScheme] = authentication.ApiKey(apiKeyOptions...)
+	}
+	if authOptions.InternalMtls != nil {
+		schemes[authentication.InternalMtlsScheme] = authentication.InternalMtls(
+			authOptions.InternalMtls.AllowedCommonNames...,
+		)
+	}
+
+	// the scheme names are checked by `EchoHttpOptions.Validate`
+	options := []authentication.Option{
+		authentication.WithSkipper(skipper),
+		authentication.WithDefaultSchemes(lo.Map(authOptions.DefaultSchemes, func(name string, _ int) authentication.Scheme {
+			return schemes[name]
+		})...),
+	}
+	for _, route := range authOptions.Routes {
+		options = append(options, authentication.WithRouteSchemes(
+			route.Path,
+			lo.Map(route.Schemes, func(name string, _ int) authentication.Scheme { return schemes[name] })...,
+		))
+	}
+
+	return options
+}
+
 func (s *echoHttpServer) ApplyVersioningFromHeader() {
 	s.echo.Pre(apiVersion)
 }
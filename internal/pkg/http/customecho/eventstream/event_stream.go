@@ -0,0 +1,90 @@
+package eventstream
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/goccy/go-json"
+	"github.com/labstack/echo/v4"
+)
+
+// MIMETextEventStream is the content type of the server sent events
+const MIMETextEventStream = "text/event-stream"
+
+// Stream writes the server sent events of a request, every event is flushed to the client as it is written
+type Stream struct {
+	response *echo.Response
+}
+
+// IsStreamRequest reports whether the client accepts the server sent events, the responses of these requests are not
+// compressed, so their events are not held back by the compression
+func IsStreamRequest(c echo.Context) bool {
+	return strings.Contains(c.Request().Header.Get(echo.HeaderAccept), MIMETextEventStream)
+}
+
+// NewStream starts the event stream of the request. the write timeout of the server is removed for the request, so the
+// stream lives until the client disconnects or the handler returns. `retry` is the reconnect delay of the clients.
+func NewStream(c echo.Context, retry time.Duration) (*Stream, error) {
+	response := c.Response()
+
+	// the writer of a compressed response doesn't support it, so the stream requests are not compressed
+	err := http.NewResponseController(response).SetWriteDeadline(time.Time{})
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return nil, errors.WrapIf(err, "error in removing the write deadline of the event stream")
+	}
+
+	header := response.Header()
+	header.Set(echo.HeaderContentType, MIMETextEventStream)
+	header.Set(echo.HeaderCacheControl, "no-cache")
+	header.Set(echo.HeaderConnection, "keep-alive")
+	// the reverse proxies like nginx buffer the responses without it
+	header.Set("X-Accel-Buffering", "no")
+	response.WriteHeader(http.StatusOK)
+
+	stream := &Stream{response: response}
+	if retry > 0 {
+		if err := stream.write(fmt.Sprintf("retry: %d\n\n", retry.Milliseconds())); err != nil {
+			return nil, err
+		}
+	}
+
+	return stream, nil
+}
+
+// Send writes an event with the json of the data, the id is optional
+func (s *Stream) Send(event string, id string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return errors.WrapIf(err, "error in marshaling the data of the event")
+	}
+
+	var builder strings.Builder
+	if id != "" {
+		builder.WriteString("id: " + id + "\n")
+	}
+	if event != "" {
+		builder.WriteString("event: " + event + "\n")
+	}
+	builder.WriteString("data: ")
+	builder.Write(payload)
+	builder.WriteString("\n\n")
+
+	return s.write(builder.String())
+}
+
+// Heartbeat writes a comment, it keeps the idle connection open through the proxies
+func (s *Stream) Heartbeat() error {
+	return s.write(": heartbeat\n\n")
+}
+
+func (s *Stream) write(data string) error {
+	if _, err := s.response.Write([]byte(data)); err != nil {
+		return errors.WrapIf(err, "error in writing the event stream")
+	}
+	s.response.Flush()
+
+	return nil
+}
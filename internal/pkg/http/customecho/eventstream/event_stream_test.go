@@ -0,0 +1,41 @@
+package eventstream
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Stream_Writes_Events(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(echo.HeaderAccept, MIMETextEventStream)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	assert.True(t, IsStreamRequest(c))
+
+	stream, err := NewStream(c, 3*time.Second)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send("dashboard", "1", map[string]int{"orders": 2}))
+	require.NoError(t, stream.Heartbeat())
+
+	assert.Equal(t, MIMETextEventStream, rec.Header().Get(echo.HeaderContentType))
+	assert.True(t, rec.Flushed)
+	assert.Equal(
+		t,
+		"retry: 3000\n\nid: 1\nevent: dashboard\ndata: {\"orders\":2}\n\n: heartbeat\n\n",
+		rec.Body.String(),
+	)
+}
+
+func Test_Is_Not_Stream_Request(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(echo.HeaderAccept, echo.MIMEApplicationJSON)
+
+	assert.False(t, IsStreamRequest(echo.New().NewContext(req, httptest.NewRecorder())))
+}
@@ -0,0 +1,72 @@
+package apikey
+
+import (
+	"context"
+	"crypto/subtle"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"github.com/labstack/echo/v4"
+)
+
+type userIdContextKey struct{}
+
+// ApiKey authenticates the requests with the api keys of the options, the user id of the key is added to the request
+// context and can be read with `UserId`. a request without a known key fails with an unauthorized error.
+func ApiKey(opts ...Option) echo.MiddlewareFunc {
+	config := defualtConfig
+
+	for _, opt := range opts {
+		opt.apply(&config)
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			userId, ok := authenticate(c.Request().Header.Get(config.header), config.keys)
+			if !ok {
+				return customErrors.NewUnAuthorizedError("missing or invalid api key")
+			}
+
+			req := c.Request()
+			c.SetRequest(req.WithContext(WithUserId(req.Context(), userId)))
+
+			return next(c)
+		}
+	}
+}
+
+// WithUserId returns a copy of the context with the authenticated user id
+func WithUserId(ctx context.Context, userId string) context.Context {
+	return context.WithValue(ctx, userIdContextKey{}, userId)
+}
+
+// UserId returns the user id of the authenticated request, it matches the `eventstroredb.UserIdResolver` signature
+func UserId(ctx context.Context) (string, bool) {
+	userId, ok := ctx.Value(userIdContextKey{}).(string)
+
+	return userId, ok && userId != ""
+}
+
+func authenticate(key string, keys map[string]string) (string, bool) {
+	if key == "" {
+		return "", false
+	}
+
+	// every key is compared in constant time, so the response time doesn't leak a matching prefix
+	var (
+		userId string
+		found  bool
+	)
+	for k, v := range keys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(k)) == 1 {
+			userId = v
+			found = true
+		}
+	}
+
+	return userId, found
+}
@@ -0,0 +1,61 @@
+package apikey
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func serve(t *testing.T, header string, key string) (string, error) {
+	t.Helper()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/backoffice/orders", nil)
+	if key != "" {
+		req.Header.Set(header, key)
+	}
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	var userId string
+	middleware := ApiKey(WithKey("secret-key", "admin"), WithKey("other-key", "support"))
+	err := middleware(func(c echo.Context) error {
+		userId, _ = UserId(c.Request().Context())
+		return nil
+	})(c)
+
+	return userId, err
+}
+
+func Test_ApiKey(t *testing.T) {
+	testCases := []struct {
+		name         string
+		header       string
+		key          string
+		userId       string
+		unauthorized bool
+	}{
+		{name: "valid key", header: defaultHeader, key: "secret-key", userId: "admin"},
+		{name: "second valid key", header: defaultHeader, key: "other-key", userId: "support"},
+		{name: "missing key", header: defaultHeader, unauthorized: true},
+		{name: "invalid key", header: defaultHeader, key: "secret", unauthorized: true},
+		{name: "wrong header", header: "Authorization", key: "secret-key", unauthorized: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userId, err := serve(t, tc.header, tc.key)
+			if tc.unauthorized {
+				assert.True(t, customErrors.IsUnAuthorizedError(err))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.userId, userId)
+		})
+	}
+}
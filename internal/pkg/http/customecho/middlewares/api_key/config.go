@@ -0,0 +1,60 @@
+package apikey
+
+import (
+	"github.com/labstack/echo/v4/middleware"
+)
+
+const defaultHeader = "X-Api-Key"
+
+type config struct {
+	Skipper middleware.Skipper
+	header  string
+	// keys maps the api keys to their user ids
+	keys map[string]string
+}
+
+var defualtConfig = config{
+	Skipper: middleware.DefaultSkipper,
+	header:  defaultHeader,
+}
+
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (o optionFunc) apply(c *config) {
+	o(c)
+}
+
+func WithSkipper(skipper middleware.Skipper) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.Skipper = skipper
+	})
+}
+
+// WithHeader sets the request header of the api key, the default header is `X-Api-Key`
+func WithHeader(header string) Option {
+	return optionFunc(func(cfg *config) {
+		if header != "" {
+			cfg.header = header
+		}
+	})
+}
+
+// WithKey accepts the api key and authenticates its requests as the user id
+func WithKey(key string, userId string) Option {
+	return optionFunc(func(cfg *config) {
+		if key == "" {
+			return
+		}
+
+		keys := make(map[string]string, len(cfg.keys)+1)
+		for k, v := range cfg.keys {
+			keys[k] = v
+		}
+		keys[key] = userId
+		cfg.keys = keys
+	})
+}
@@ -0,0 +1,130 @@
+package authentication
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type principalContextKey struct{}
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	// Scheme is the name of the scheme which authenticated the request
+	Scheme string
+	// UserId is empty for the anonymous requests
+	UserId string
+	// CustomerGroup is the pricing group of the customer, like a b2b tier, it is empty when the credentials don't have
+	// a customer group
+	CustomerGroup string
+}
+
+// Authentication authenticates the requests with the schemes of the longest matching route or the default schemes, the
+// schemes are tried in order and the first accepting scheme wins, so a route can accept a jwt from the gateway and an
+// api key from the internal tools. a request which no scheme accepts fails with an unauthorized error.
+func Authentication(opts ...Option) echo.MiddlewareFunc {
+	config := defualtConfig
+
+	for _, opt := range opts {
+		opt.apply(&config)
+	}
+
+	routes := append([]routeSchemes(nil), config.routeSchemes...)
+
+	// longest paths first, so the most specific route schemes win
+	sort.SliceStable(routes, func(i, j int) bool {
+		return len(routes[i].path) > len(routes[j].path)
+	})
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			schemes := schemesFor(c.Request().URL.Path, config.defaultSchemes, routes)
+			if len(schemes) == 0 {
+				return next(c)
+			}
+
+			for _, scheme := range schemes {
+				principal, ok := scheme.Authenticate(c)
+				if !ok {
+					continue
+				}
+
+				req := c.Request()
+				c.SetRequest(req.WithContext(WithPrincipal(req.Context(), principal)))
+
+				return next(c)
+			}
+
+			return customErrors.NewUnAuthorizedError(
+				fmt.Sprintf(
+					"request is not authenticated by any of the schemes: %s",
+					strings.Join(lo.Map(schemes, func(s Scheme, _ int) string { return s.Name() }), ", "),
+				),
+			)
+		}
+	}
+}
+
+// WithPrincipal returns a copy of the context with the authenticated principal
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// GetPrincipal returns the principal of the authenticated request
+func GetPrincipal(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(*Principal)
+
+	return principal, ok && principal != nil
+}
+
+// UserId returns the user id of the authenticated request, it matches the `eventstroredb.UserIdResolver` signature
+func UserId(ctx context.Context) (string, bool) {
+	principal, ok := GetPrincipal(ctx)
+	if !ok || principal.UserId == "" {
+		return "", false
+	}
+
+	return principal.UserId, true
+}
+
+// CustomerGroup returns the customer group of the authenticated request, it is the customer context of the priced
+// responses
+func CustomerGroup(ctx context.Context) (string, bool) {
+	principal, ok := GetPrincipal(ctx)
+	if !ok || principal.CustomerGroup == "" {
+		return "", false
+	}
+
+	return principal.CustomerGroup, true
+}
+
+func schemesFor(path string, defaultSchemes []Scheme, routes []routeSchemes) []Scheme {
+	for _, route := range routes {
+		if matchPath(path, route.path) {
+			return route.schemes
+		}
+	}
+
+	return defaultSchemes
+}
+
+// matchPath matches the path with the route path on the segment boundaries, so `/products` doesn't match `/products-v2`
+func matchPath(path string, routePath string) bool {
+	if !strings.HasPrefix(path, routePath) {
+		return false
+	}
+
+	return len(path) == len(routePath) ||
+		strings.HasSuffix(routePath, "/") ||
+		path[len(routePath)] == '/'
+}
@@ -0,0 +1,201 @@
+package authentication
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apikey "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/api_key"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const signingKey = "test-signing-key"
+
+func newToken(t *testing.T, key string, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(key))
+	require.NoError(t, err)
+
+	return token
+}
+
+func serve(t *testing.T, req *http.Request) (*Principal, error) {
+	t.Helper()
+
+	middleware := Authentication(
+		WithDefaultSchemes(Jwt(signingKey, "identity", "catalogs")),
+		WithRouteSchemes("/api/v1/products/public", Anonymous()),
+		WithRouteSchemes("/api/v1/admin", ApiKey(apikey.WithKey("admin-key", "admin")), InternalMtls("orders")),
+		WithRouteSchemes("/api/v1/admin/jobs", Jwt(signingKey, "", ""), ApiKey(apikey.WithKey("admin-key", "admin"))),
+	)
+
+	var principal *Principal
+	err := middleware(func(c echo.Context) error {
+		principal, _ = GetPrincipal(c.Request().Context())
+		return nil
+	})(echo.New().NewContext(req, httptest.NewRecorder()))
+
+	return principal, err
+}
+
+func Test_Authentication(t *testing.T) {
+	validToken := newToken(t, signingKey, jwt.MapClaims{
+		"sub": "user-1",
+		"iss": "identity",
+		"aud": "catalogs",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	expiredToken := newToken(t, signingKey, jwt.MapClaims{
+		"sub": "user-1",
+		"iss": "identity",
+		"aud": "catalogs",
+		"exp": time.Now().Add(-time.Hour).Unix(),
+	})
+	otherAudienceToken := newToken(t, signingKey, jwt.MapClaims{"sub": "user-1", "iss": "identity", "aud": "orders"})
+	otherKeyToken := newToken(t, "other-key", jwt.MapClaims{"sub": "user-1", "iss": "identity", "aud": "catalogs"})
+
+	testCases := []struct {
+		name         string
+		path         string
+		headers      map[string]string
+		clientCert   string
+		scheme       string
+		userId       string
+		unauthorized bool
+	}{
+		{
+			name:    "default jwt",
+			path:    "/api/v1/products",
+			headers: map[string]string{echo.HeaderAuthorization: "Bearer " + validToken},
+			scheme:  JwtScheme,
+			userId:  "user-1",
+		},
+		{name: "default without token", path: "/api/v1/products", unauthorized: true},
+		{
+			name:         "expired jwt",
+			path:         "/api/v1/products",
+			headers:      map[string]string{echo.HeaderAuthorization: "Bearer " + expiredToken},
+			unauthorized: true,
+		},
+		{
+			name:         "jwt of another audience",
+			path:         "/api/v1/products",
+			headers:      map[string]string{echo.HeaderAuthorization: "Bearer " + otherAudienceToken},
+			unauthorized: true,
+		},
+		{
+			name:         "jwt signed with another key",
+			path:         "/api/v1/products",
+			headers:      map[string]string{echo.HeaderAuthorization: "Bearer " + otherKeyToken},
+			unauthorized: true,
+		},
+		{name: "anonymous route", path: "/api/v1/products/public/featured", scheme: AnonymousScheme},
+		{
+			name:    "admin api key",
+			path:    "/api/v1/admin/dead-letters",
+			headers: map[string]string{"X-Api-Key": "admin-key"},
+			scheme:  ApiKeyScheme,
+			userId:  "admin",
+		},
+		{
+			name:       "admin client certificate",
+			path:       "/api/v1/admin/dead-letters",
+			clientCert: "orders",
+			scheme:     InternalMtlsScheme,
+			userId:     "orders",
+		},
+		{
+			name:         "admin client certificate not allowed",
+			path:         "/api/v1/admin/dead-letters",
+			clientCert:   "payments",
+			unauthorized: true,
+		},
+		{
+			name:         "admin jwt",
+			path:         "/api/v1/admin/dead-letters",
+			headers:      map[string]string{echo.HeaderAuthorization: "Bearer " + validToken},
+			unauthorized: true,
+		},
+		{
+			name:    "longest route wins",
+			path:    "/api/v1/admin/jobs/1",
+			headers: map[string]string{echo.HeaderAuthorization: "Bearer " + validToken},
+			scheme:  JwtScheme,
+			userId:  "user-1",
+		},
+		{
+			name:    "route segment boundary",
+			path:    "/api/v1/administrators",
+			headers: map[string]string{echo.HeaderAuthorization: "Bearer " + validToken},
+			scheme:  JwtScheme,
+			userId:  "user-1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+			if tc.clientCert != "" {
+				cert := &x509.Certificate{Subject: pkix.Name{CommonName: tc.clientCert}}
+				req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+			}
+
+			principal, err := serve(t, req)
+			if tc.unauthorized {
+				assert.True(t, customErrors.IsUnAuthorizedError(err))
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, principal)
+			assert.Equal(t, tc.scheme, principal.Scheme)
+			assert.Equal(t, tc.userId, principal.UserId)
+		})
+	}
+}
+
+func Test_Authentication_Without_Schemes(t *testing.T) {
+	middleware := Authentication(WithRouteSchemes("/api/v1/admin", Jwt(signingKey, "", "")))
+
+	called := false
+	err := middleware(func(c echo.Context) error {
+		called = true
+		return nil
+	})(echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/products", nil), httptest.NewRecorder()))
+
+	assert.NoError(t, err)
+	assert.True(t, called)
+}
+
+func Test_Jwt_Customer_Group_Is_Kept_In_The_Context(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/jobs", nil)
+	req.Header.Set(
+		echo.HeaderAuthorization,
+		"Bearer "+newToken(t, signingKey, jwt.MapClaims{"sub": "user-1", CustomerGroupClaim: "wholesale"}),
+	)
+
+	var customerGroup string
+	err := Authentication(WithDefaultSchemes(Jwt(signingKey, "", "")))(func(c echo.Context) error {
+		customerGroup, _ = CustomerGroup(c.Request().Context())
+		return nil
+	})(echo.New().NewContext(req, httptest.NewRecorder()))
+
+	require.NoError(t, err)
+	assert.Equal(t, "wholesale", customerGroup)
+
+	_, ok := CustomerGroup(WithPrincipal(req.Context(), &Principal{Scheme: AnonymousScheme}))
+	assert.False(t, ok)
+}
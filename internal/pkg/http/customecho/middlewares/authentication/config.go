@@ -0,0 +1,52 @@
+package authentication
+
+import (
+	"github.com/labstack/echo/v4/middleware"
+)
+
+type routeSchemes struct {
+	path    string
+	schemes []Scheme
+}
+
+type config struct {
+	Skipper        middleware.Skipper
+	defaultSchemes []Scheme
+	routeSchemes   []routeSchemes
+}
+
+var defualtConfig = config{
+	Skipper: middleware.DefaultSkipper,
+}
+
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (o optionFunc) apply(c *config) {
+	o(c)
+}
+
+func WithSkipper(skipper middleware.Skipper) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.Skipper = skipper
+	})
+}
+
+// WithDefaultSchemes sets the schemes of the requests without a matching route, without default schemes these requests
+// are not authenticated
+func WithDefaultSchemes(schemes ...Scheme) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.defaultSchemes = schemes
+	})
+}
+
+// WithRouteSchemes sets the schemes of the requests with a path starting with the given path, a request is
+// authenticated by the first scheme which accepts it
+func WithRouteSchemes(path string, schemes ...Scheme) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.routeSchemes = append(cfg.routeSchemes, routeSchemes{path: path, schemes: schemes})
+	})
+}
@@ -0,0 +1,159 @@
+package authentication
+
+import (
+	"fmt"
+	"strings"
+
+	apikey "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/api_key"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+const (
+	AnonymousScheme    = "anonymous"
+	JwtScheme          = "jwt"
+	ApiKeyScheme       = "apiKey"
+	InternalMtlsScheme = "internalMtls"
+)
+
+// CustomerGroupClaim is the jwt claim of the customer group
+const CustomerGroupClaim = "customer_group"
+
+// Scheme authenticates a request with a single kind of credentials
+type Scheme interface {
+	Name() string
+	// Authenticate returns the principal of the request, or false when the request doesn't have valid credentials of
+	// the scheme
+	Authenticate(c echo.Context) (*Principal, bool)
+}
+
+type anonymousScheme struct{}
+
+// Anonymous accepts every request without a user id, so it should be the last scheme of a route
+func Anonymous() Scheme {
+	return anonymousScheme{}
+}
+
+func (s anonymousScheme) Name() string {
+	return AnonymousScheme
+}
+
+func (s anonymousScheme) Authenticate(_ echo.Context) (*Principal, bool) {
+	return &Principal{Scheme: AnonymousScheme}, true
+}
+
+type apiKeyScheme struct {
+	middleware echo.MiddlewareFunc
+}
+
+// ApiKey authenticates the requests with the `apikey` middleware, so the user id can be read with `apikey.UserId` as
+// well
+func ApiKey(opts ...apikey.Option) Scheme {
+	return apiKeyScheme{middleware: apikey.ApiKey(opts...)}
+}
+
+func (s apiKeyScheme) Name() string {
+	return ApiKeyScheme
+}
+
+func (s apiKeyScheme) Authenticate(c echo.Context) (*Principal, bool) {
+	var userId string
+	authenticated := false
+
+	err := s.middleware(func(c echo.Context) error {
+		userId, authenticated = apikey.UserId(c.Request().Context())
+		return nil
+	})(c)
+	if err != nil || !authenticated {
+		return nil, false
+	}
+
+	return &Principal{Scheme: ApiKeyScheme, UserId: userId}, true
+}
+
+type jwtScheme struct {
+	signingKey []byte
+	issuer     string
+	audience   string
+}
+
+// Jwt authenticates the requests with a HMAC signed bearer token, the `sub` claim is the user id and the optional
+// `customer_group` claim is the customer group. the issuer and the audience are only checked when they are not empty.
+func Jwt(signingKey string, issuer string, audience string) Scheme {
+	return jwtScheme{signingKey: []byte(signingKey), issuer: issuer, audience: audience}
+}
+
+func (s jwtScheme) Name() string {
+	return JwtScheme
+}
+
+func (s jwtScheme) Authenticate(c echo.Context) (*Principal, bool) {
+	authorization := c.Request().Header.Get(echo.HeaderAuthorization)
+	tokenString, ok := cutPrefixFold(authorization, "Bearer ")
+	if !ok || tokenString == "" {
+		return nil, false
+	}
+
+	claims := jwt.MapClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method '%s'", token.Header["alg"])
+		}
+
+		return s.signingKey, nil
+	})
+	if err != nil || !token.Valid {
+		return nil, false
+	}
+
+	if s.issuer != "" && !claims.VerifyIssuer(s.issuer, true) {
+		return nil, false
+	}
+
+	if s.audience != "" && !claims.VerifyAudience(s.audience, true) {
+		return nil, false
+	}
+
+	subject, _ := claims["sub"].(string)
+	customerGroup, _ := claims[CustomerGroupClaim].(string)
+
+	return &Principal{Scheme: JwtScheme, UserId: subject, CustomerGroup: customerGroup}, true
+}
+
+type internalMtlsScheme struct {
+	allowedCommonNames []string
+}
+
+// InternalMtls authenticates the requests with a client certificate verified by the tls config of the server, the
+// common name of the certificate is the user id. without allowed common names every verified certificate is accepted.
+func InternalMtls(allowedCommonNames ...string) Scheme {
+	return internalMtlsScheme{allowedCommonNames: allowedCommonNames}
+}
+
+func (s internalMtlsScheme) Name() string {
+	return InternalMtlsScheme
+}
+
+func (s internalMtlsScheme) Authenticate(c echo.Context) (*Principal, bool) {
+	state := c.Request().TLS
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return nil, false
+	}
+
+	commonName := state.VerifiedChains[0][0].Subject.CommonName
+	if len(s.allowedCommonNames) > 0 && !lo.Contains(s.allowedCommonNames, commonName) {
+		return nil, false
+	}
+
+	return &Principal{Scheme: InternalMtlsScheme, UserId: commonName}, true
+}
+
+func cutPrefixFold(s string, prefix string) (string, bool) {
+	if len(s) < len(prefix) || !strings.EqualFold(s[:len(prefix)], prefix) {
+		return "", false
+	}
+
+	return s[len(prefix):], true
+}
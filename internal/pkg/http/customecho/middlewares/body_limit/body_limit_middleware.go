@@ -0,0 +1,122 @@
+package bodylimit
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/bytes"
+)
+
+type parsedRouteLimit struct {
+	path  string
+	limit int64
+}
+
+// BodyLimit limits the request body size with the longest matching route limit or the default limit. unlike
+// `middleware.BodyLimit` it doesn't read the body, the body is wrapped and fails while it is read, so the streaming
+// handlers like the multipart uploads don't need to buffer the whole body.
+func BodyLimit(opts ...Option) echo.MiddlewareFunc {
+	config := defualtConfig
+
+	for _, opt := range opts {
+		opt.apply(&config)
+	}
+
+	defaultLimit := mustParse(config.limit)
+
+	routeLimits := make([]parsedRouteLimit, 0, len(config.routeLimits))
+	for _, routeLimit := range config.routeLimits {
+		routeLimits = append(
+			routeLimits,
+			parsedRouteLimit{path: routeLimit.path, limit: mustParse(routeLimit.limit)},
+		)
+	}
+
+	// longest paths first, so the most specific route limit wins
+	sort.SliceStable(routeLimits, func(i, j int) bool {
+		return len(routeLimits[i].path) > len(routeLimits[j].path)
+	})
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			req := c.Request()
+			limit := limitFor(req.URL.Path, defaultLimit, routeLimits)
+
+			if req.ContentLength > limit {
+				return NewRequestBodyTooLargeError(limit)
+			}
+
+			req.Body = &limitedReader{reader: req.Body, limit: limit}
+
+			return next(c)
+		}
+	}
+}
+
+// NewRequestBodyTooLargeError creates an api error with the `413` status code
+func NewRequestBodyTooLargeError(limit int64) error {
+	return customErrors.NewApiError(
+		fmt.Sprintf("request body is larger than %s", bytes.Format(limit)),
+		http.StatusRequestEntityTooLarge,
+	)
+}
+
+func limitFor(path string, defaultLimit int64, routeLimits []parsedRouteLimit) int64 {
+	for _, routeLimit := range routeLimits {
+		if matchPath(path, routeLimit.path) {
+			return routeLimit.limit
+		}
+	}
+
+	return defaultLimit
+}
+
+// matchPath matches the path with the route path on the segment boundaries, so `/products` doesn't match `/products-v2`
+func matchPath(path string, routePath string) bool {
+	if !strings.HasPrefix(path, routePath) {
+		return false
+	}
+
+	return len(path) == len(routePath) ||
+		strings.HasSuffix(routePath, "/") ||
+		path[len(routePath)] == '/'
+}
+
+func mustParse(limit string) int64 {
+	parsed, err := bytes.Parse(limit)
+	if err != nil {
+		panic(fmt.Errorf("bodylimit: invalid body limit '%s': %w", limit, err))
+	}
+
+	return parsed
+}
+
+type limitedReader struct {
+	reader io.ReadCloser
+	limit  int64
+	read   int64
+}
+
+func (r *limitedReader) Read(b []byte) (int, error) {
+	n, err := r.reader.Read(b)
+	r.read += int64(n)
+	if r.read > r.limit {
+		return n, NewRequestBodyTooLargeError(r.limit)
+	}
+
+	return n, err
+}
+
+func (r *limitedReader) Close() error {
+	return r.reader.Close()
+}
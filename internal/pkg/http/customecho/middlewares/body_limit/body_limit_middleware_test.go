@@ -0,0 +1,76 @@
+package bodylimit
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readBody(c echo.Context) error {
+	_, err := io.ReadAll(c.Request().Body)
+	return err
+}
+
+func serve(t *testing.T, path string, body string, contentLength int64) error {
+	t.Helper()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.ContentLength = contentLength
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	middleware := BodyLimit(
+		WithLimit("10B"),
+		WithRouteLimit("/api/v1/products", "20B"),
+		WithRouteLimit("/api/v1/products/import", "40B"),
+	)
+
+	return middleware(readBody)(c)
+}
+
+func Test_BodyLimit(t *testing.T) {
+	testCases := []struct {
+		name     string
+		path     string
+		bodySize int
+		tooLarge bool
+	}{
+		{name: "default limit", path: "/api/v1/orders", bodySize: 10},
+		{name: "exceeds default limit", path: "/api/v1/orders", bodySize: 11, tooLarge: true},
+		{name: "route limit", path: "/api/v1/products/1", bodySize: 20},
+		{name: "exceeds route limit", path: "/api/v1/products", bodySize: 21, tooLarge: true},
+		{name: "longest route limit", path: "/api/v1/products/import", bodySize: 40},
+		{name: "route limit on segment boundary", path: "/api/v1/products-v2", bodySize: 11, tooLarge: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			body := strings.Repeat("a", testCase.bodySize)
+
+			for _, contentLength := range []int64{int64(testCase.bodySize), -1} {
+				err := serve(t, testCase.path, body, contentLength)
+
+				if testCase.tooLarge {
+					require.Error(t, err)
+					assert.True(t, customErrors.IsApiError(err, http.StatusRequestEntityTooLarge))
+				} else {
+					assert.NoError(t, err)
+				}
+			}
+		})
+	}
+}
+
+func Test_BodyLimit_Panics_On_Invalid_Limit(t *testing.T) {
+	assert.Panics(t, func() {
+		BodyLimit(WithRouteLimit("/api", "ten megabytes"))
+	})
+}
@@ -0,0 +1,55 @@
+package bodylimit
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/constants"
+
+	"github.com/labstack/echo/v4/middleware"
+)
+
+type routeLimit struct {
+	path  string
+	limit string
+}
+
+type config struct {
+	Skipper     middleware.Skipper
+	limit       string
+	routeLimits []routeLimit
+}
+
+var defualtConfig = config{
+	Skipper: middleware.DefaultSkipper,
+	limit:   constants.BodyLimit,
+}
+
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (o optionFunc) apply(c *config) {
+	o(c)
+}
+
+func WithSkipper(skipper middleware.Skipper) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.Skipper = skipper
+	})
+}
+
+// WithLimit sets the default max body size of the requests, like `2M`
+func WithLimit(limit string) Option {
+	return optionFunc(func(cfg *config) {
+		if limit != "" {
+			cfg.limit = limit
+		}
+	})
+}
+
+// WithRouteLimit overrides the max body size of the requests with a path starting with the given path
+func WithRouteLimit(path string, limit string) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.routeLimits = append(cfg.routeLimits, routeLimit{path: path, limit: limit})
+	})
+}
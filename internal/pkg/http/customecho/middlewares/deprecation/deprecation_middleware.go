@@ -0,0 +1,47 @@
+package deprecationmiddleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/deprecation"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// DeprecationHeader is the date of the deprecation as a unix timestamp, like `@1735603200`
+	// https://www.rfc-editor.org/rfc/rfc9745
+	DeprecationHeader = "Deprecation"
+	// SunsetHeader is the date that the route is removed at
+	// https://www.rfc-editor.org/rfc/rfc8594
+	SunsetHeader = "Sunset"
+)
+
+// Deprecation annotates the responses of the deprecated routes with the `Deprecation`, the `Sunset` and the `Link`
+// headers and records their usages, the deprecation of a route is found by its method and its registered path, like
+// `endpoint:get /api/v1/products/:id`
+func Deprecation(registry deprecation.Registry) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			routeDeprecation, ok := registry.Endpoint(c.Request().Method, c.Path())
+			if !ok {
+				return next(c)
+			}
+
+			// the headers are set before the handler, so they are in the response of a failed request too
+			header := c.Response().Header()
+			header.Set(DeprecationHeader, fmt.Sprintf("@%d", routeDeprecation.DeprecatedAt.Unix()))
+			if routeDeprecation.Sunset != nil {
+				header.Set(SunsetHeader, routeDeprecation.Sunset.UTC().Format(http.TimeFormat))
+			}
+			if routeDeprecation.Link != "" {
+				header.Add("Link", fmt.Sprintf(`<%s>; rel="deprecation"`, routeDeprecation.Link))
+			}
+
+			registry.Used(c.Request().Context(), routeDeprecation.Contract)
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package deprecationmiddleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/deprecation"
+	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Deprecation(t *testing.T) {
+	registry, err := deprecation.NewRegistry(defaultLogger.GetLogger(), nil)
+	require.NoError(t, err)
+
+	routeDeprecation, err := deprecation.NewDeprecation(&deprecation.DeprecatedContractOptions{
+		Endpoint:     "get /api/v1/products/:id",
+		DeprecatedAt: "2025-01-31",
+		Sunset:       "2025-06-30",
+		Link:         "https://docs.example.com/products-v2",
+	})
+	require.NoError(t, err)
+	registry.Deprecate(routeDeprecation)
+
+	e := echo.New()
+	e.Use(Deprecation(registry))
+	e.GET("/api/v1/products/:id", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+	e.GET("/api/v2/products/:id", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/products/1", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "@1738281600", rec.Header().Get(DeprecationHeader))
+	assert.Equal(t, "Mon, 30 Jun 2025 00:00:00 GMT", rec.Header().Get(SunsetHeader))
+	assert.Equal(t, `<https://docs.example.com/products-v2>; rel="deprecation"`, rec.Header().Get("Link"))
+	assert.Equal(t, int64(1), registry.Deprecations()[0].Usages)
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v2/products/1", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get(DeprecationHeader))
+	assert.Empty(t, rec.Header().Get(SunsetHeader))
+}
@@ -0,0 +1,35 @@
+package featuretogglemiddleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/featuretoggle"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"github.com/labstack/echo/v4"
+)
+
+// FeatureToggle rejects the requests of the disabled routes with the `503` status code, the toggle name of a route is
+// its method and its registered path, like `endpoint:post /api/v1/orders/:id/cancel`
+func FeatureToggle(toggles featuretoggle.FeatureToggles) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			toggle := featuretoggle.EndpointToggle(strings.ToLower(c.Request().Method), c.Path())
+			if !toggles.IsEnabled(toggle) {
+				return NewEndpointDisabledError(toggle)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// NewEndpointDisabledError creates an api error with the `503` status code
+func NewEndpointDisabledError(toggle string) error {
+	return customErrors.NewApiError(
+		fmt.Sprintf("endpoint is disabled by the '%s' toggle", toggle),
+		http.StatusServiceUnavailable,
+	)
+}
@@ -0,0 +1,57 @@
+package featuretogglemiddleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/featuretoggle"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticProvider map[string]bool
+
+func (s staticProvider) Name() string {
+	return "static"
+}
+
+func (s staticProvider) Toggles(ctx context.Context) (map[string]bool, error) {
+	return s, nil
+}
+
+func Test_FeatureToggle(t *testing.T) {
+	toggles := featuretoggle.NewFeatureToggles(staticProvider{"endpoint:post /api/v1/orders/:id/cancel": false})
+	require.NoError(t, toggles.Refresh(context.Background()))
+
+	testCases := []struct {
+		name     string
+		method   string
+		path     string
+		disabled bool
+	}{
+		{name: "disabled route", method: http.MethodPost, path: "/api/v1/orders/:id/cancel", disabled: true},
+		{name: "other method", method: http.MethodGet, path: "/api/v1/orders/:id/cancel"},
+		{name: "route without toggle", method: http.MethodPost, path: "/api/v1/orders"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			c := e.NewContext(httptest.NewRequest(testCase.method, "/", nil), httptest.NewRecorder())
+			c.SetPath(testCase.path)
+
+			err := FeatureToggle(toggles)(func(c echo.Context) error { return nil })(c)
+			if testCase.disabled {
+				require.Error(t, err)
+				assert.Equal(t, http.StatusServiceUnavailable, customErrors.GetCustomError(err).Status())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
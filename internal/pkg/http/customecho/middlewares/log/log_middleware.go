@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/tenant"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
 
 	"github.com/labstack/echo/v4"
@@ -70,6 +71,9 @@ func EchoLogger(l logger.Logger, opts ...Option) echo.MiddlewareFunc {
 				return requestMiddleware(next)(c)
 			}
 
+			// the tenant holder is added before handling the request, so the tenant resolved in the next handlers is logged
+			c.SetRequest(c.Request().WithContext(tenant.NewContext(c.Request().Context())))
+
 			start := time.Now()
 
 			err := requestMiddleware(next)(c)
@@ -99,6 +103,10 @@ func EchoLogger(l logger.Logger, opts ...Option) echo.MiddlewareFunc {
 			}
 			fields["request_id"] = id
 
+			if tenantId, ok := tenant.GetTenantId(req.Context()); ok {
+				fields["tenant_id"] = tenantId
+			}
+
 			n := res.Status
 			switch {
 			case n >= 500:
@@ -28,6 +28,13 @@ type HTTPLabels struct {
 	// definition. For example `/users/{ID}` instead of `/users/100`.
 	Path string
 	Host string
+	// Tenant is the guarded tenant attribute of the resolved tenant, it is empty when there is no tenant. It is not
+	// recorded on the in-flight requests because the tenant is not resolved at the start of the request.
+	Tenant []attribute.KeyValue
+}
+
+func (h HTTPLabels) withTenant(attributes ...attribute.KeyValue) metric.MeasurementOption {
+	return metric.WithAttributes(append(attributes, h.Tenant...)...)
 }
 
 // HTTPMetricsRecorder is a recorder of HTTP metrics for prometheus. Use NewHTTPMetricsRecorder to initialize it.
@@ -201,7 +208,7 @@ func (h *HTTPMetricsRecorder) AddRequestToTotal(
 	}
 
 	h.reqTotal.Add(ctx, 1,
-		metric.WithAttributes(
+		values.withTenant(
 			attribute.String("method", values.Method),
 			attribute.Int("code", values.Code),
 			attribute.String("type", "Http"),
@@ -221,7 +228,7 @@ func (h *HTTPMetricsRecorder) AddRequestDuration(
 
 	h.reqDuration.Record(
 		ctx, duration.Seconds(),
-		metric.WithAttributes(
+		values.withTenant(
 			attribute.String("method", values.Method),
 			attribute.String("host", values.Host),
 			attribute.String("path", values.Path),
@@ -263,7 +270,7 @@ func (h *HTTPMetricsRecorder) AddRequestError(
 	h.errorCounter.Add(
 		ctx,
 		1,
-		metric.WithAttributes(
+		values.withTenant(
 			attribute.String("method", values.Method),
 			attribute.String("path", values.Path),
 			attribute.String("type", "Http"),
@@ -283,7 +290,7 @@ func (h *HTTPMetricsRecorder) AddRequestSuccess(
 	h.successCounter.Add(
 		ctx,
 		1,
-		metric.WithAttributes(
+		values.withTenant(
 			attribute.String("method", values.Method),
 			attribute.String("path", values.Path),
 			attribute.String("type", "Http"),
@@ -323,7 +330,7 @@ func (h *HTTPMetricsRecorder) AddRequestSize(
 	}
 
 	size := computeApproximateRequestSize(request)
-	h.reqSize.Record(ctx, int64(size), metric.WithAttributes(
+	h.reqSize.Record(ctx, int64(size), values.withTenant(
 		attribute.String("method", values.Method),
 		attribute.String("path", values.Path),
 		attribute.String("type", "Http"),
@@ -342,7 +349,7 @@ func (h *HTTPMetricsRecorder) AddResponseSize(
 	}
 
 	size := response.Size
-	h.resSize.Record(ctx, size, metric.WithAttributes(
+	h.resSize.Record(ctx, size, values.withTenant(
 		attribute.String("method", values.Method),
 		attribute.String("path", values.Path),
 		attribute.String("type", "Http"),
@@ -10,6 +10,8 @@ package otelmetrics
 import (
 	"time"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/tenant"
+
 	"github.com/labstack/echo/v4"
 )
 
@@ -30,8 +32,10 @@ func HTTPMetrics(opts ...Option) echo.MiddlewareFunc {
 				return next(c)
 			}
 
-			request := c.Request()
-			ctx := request.Context()
+			// the tenant holder is added before handling the request, so the tenant resolved in the next handlers is visible in the recorded metrics
+			ctx := tenant.NewContext(c.Request().Context())
+			request := c.Request().WithContext(ctx)
+			c.SetRequest(request)
 
 			values := HTTPLabels{
 				Method: request.Method,
@@ -47,6 +51,7 @@ func HTTPMetrics(opts ...Option) echo.MiddlewareFunc {
 				elapsed := time.Since(start)
 
 				values.Code = c.Response().Status
+				values.Tenant = tenant.MetricAttributes(ctx)
 
 				httpMetricsRecorder.AddRequestToTotal(ctx, values)
 
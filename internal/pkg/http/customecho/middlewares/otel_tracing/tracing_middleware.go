@@ -8,6 +8,7 @@ package oteltracing
 import (
 	"fmt"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/tenant"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/utils"
 
 	"github.com/labstack/echo/v4"
@@ -43,7 +44,8 @@ func HttpTrace(opts ...Option) echo.MiddlewareFunc {
 				c.SetRequest(request)
 			}()
 
-			// create new ctx from existing savedCtx
+			// create new ctx from existing savedCtx, the tenant holder is added to savedCtx so the tenant resolved in the next handlers is visible after restoring it
+			savedCtx = tenant.NewContext(savedCtx)
 			ctx := cfg.propagators.Extract(
 				savedCtx,
 				propagation.HeaderCarrier(request.Header),
@@ -87,6 +89,8 @@ func HttpTrace(opts ...Option) echo.MiddlewareFunc {
 				c.Error(err)
 			}
 
+			span.SetAttributes(tenant.SpanAttributes(ctx)...)
+
 			status := c.Response().Status
 			err = utils.HttpTraceStatusFromSpanWithCode(span, err, status)
 
@@ -0,0 +1,86 @@
+package multipartstream
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+// PartHandler handles a part of the multipart body, the part is only readable until the handler returns
+type PartHandler func(part *multipart.Part) error
+
+// FileHandler handles the content of a multipart file, the file is only readable until the handler returns
+type FileHandler func(fileName string, contentType string, file io.Reader) error
+
+// ReadParts streams the parts of the multipart request body to the handler one by one. unlike `c.MultipartForm()`
+// and `c.FormFile()` the files are neither buffered in memory nor written to the temp files, so the max body size
+// of the uploads is only limited by the body limit of the route.
+func ReadParts(c echo.Context, handler PartHandler) error {
+	reader, err := c.Request().MultipartReader()
+	if err != nil {
+		return customErrors.NewBadRequestErrorWrap(err, "request body is not a multipart form")
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return partError(err)
+		}
+
+		err = handler(part)
+		_ = part.Close()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// ReadFile streams the file of the form field to the handler and skips the other parts, it returns a bad request
+// error when there is no file for the field.
+func ReadFile(c echo.Context, fieldName string, handler FileHandler) error {
+	found := false
+
+	err := ReadParts(c, func(part *multipart.Part) error {
+		if found || part.FormName() != fieldName || part.FileName() == "" {
+			return nil
+		}
+		found = true
+
+		if err := handler(part.FileName(), part.Header.Get(echo.HeaderContentType), part); err != nil {
+			return partError(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return customErrors.NewBadRequestError(fmt.Sprintf("file '%s' is required", fieldName))
+	}
+
+	return nil
+}
+
+// partError keeps the custom errors like the body limit error, and converts the read failures of a malformed body to
+// a bad request
+func partError(err error) error {
+	if customErrors.IsCustomError(err) {
+		return err
+	}
+
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, multipart.ErrMessageTooLarge) {
+		return customErrors.NewBadRequestErrorWrap(err, "multipart body is malformed")
+	}
+
+	return err
+}
@@ -0,0 +1,88 @@
+package multipartstream
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMultipartContext(t *testing.T, files map[string]string) echo.Context {
+	t.Helper()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	require.NoError(t, writer.WriteField("description", "products"))
+
+	for fieldName, content := range files {
+		file, err := writer.CreateFormFile(fieldName, fieldName+".csv")
+		require.NoError(t, err)
+		_, err = file.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/", body)
+	req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+
+	return echo.New().NewContext(req, httptest.NewRecorder())
+}
+
+func Test_ReadParts(t *testing.T) {
+	c := newMultipartContext(t, map[string]string{"file": "name,price"})
+
+	var names []string
+	err := ReadParts(c, func(part *multipart.Part) error {
+		names = append(names, part.FormName())
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"description", "file"}, names)
+}
+
+func Test_ReadFile(t *testing.T) {
+	c := newMultipartContext(t, map[string]string{"file": "name,price"})
+
+	var fileName, content string
+	err := ReadFile(c, "file", func(name string, contentType string, file io.Reader) error {
+		data, err := io.ReadAll(file)
+		fileName, content = name, string(data)
+
+		return err
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "file.csv", fileName)
+	assert.Equal(t, "name,price", content)
+}
+
+func Test_ReadFile_Without_File(t *testing.T) {
+	c := newMultipartContext(t, map[string]string{"image": "content"})
+
+	err := ReadFile(c, "file", func(string, string, io.Reader) error {
+		return nil
+	})
+
+	assert.True(t, customErrors.IsBadRequestError(err))
+}
+
+func Test_ReadParts_Not_Multipart(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString("{}"))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c := echo.New().NewContext(req, httptest.NewRecorder())
+
+	err := ReadParts(c, func(*multipart.Part) error {
+		return nil
+	})
+
+	assert.True(t, customErrors.IsBadRequestError(err))
+}
@@ -0,0 +1,111 @@
+package customErrors
+
+import (
+	"context"
+	"net/http"
+
+	"emperror.dev/errors"
+)
+
+// StatusClientClosedRequest is the non-standard status code for requests that their client has gone away before
+// completing the request
+const StatusClientClosedRequest = 499
+
+// ErrCanceled matches all canceled errors with `errors.Is`, regardless of their message and cause
+var ErrCanceled = errors.Sentinel("operation canceled")
+
+func NewCanceledError(message string) CanceledError {
+	// `NewPlain` doesn't add stack-trace at all
+	canceledErrMessage := errors.NewPlain("canceled error")
+	// `WrapIf` add stack-trace if not added before
+	stackErr := errors.WrapIf(canceledErrMessage, message)
+
+	canceledError := &canceledError{
+		CustomError: NewCustomError(stackErr, StatusClientClosedRequest, message),
+	}
+
+	return canceledError
+}
+
+func NewCanceledErrorWrap(err error, message string) CanceledError {
+	if err == nil {
+		return NewCanceledError(message)
+	}
+
+	// `WithMessage` doesn't add stack-trace at all
+	canceledErrMessage := errors.WithMessage(err, "canceled error")
+	// `WrapIf` add stack-trace if not added before
+	stackErr := errors.WrapIf(canceledErrMessage, message)
+
+	// an exceeded deadline is a timeout of the request, but an explicit cancellation means the caller has gone away
+	statusCode := StatusClientClosedRequest
+	if errors.Is(err, context.DeadlineExceeded) {
+		statusCode = http.StatusRequestTimeout
+	}
+
+	canceledError := &canceledError{
+		CustomError: NewCustomError(stackErr, statusCode, message),
+	}
+
+	return canceledError
+}
+
+// CheckContext returns a CanceledError when the context is canceled or its deadline is exceeded, so long-running
+// flows can stop between their steps.
+func CheckContext(ctx context.Context, message string) error {
+	if ctx.Err() == nil {
+		return nil
+	}
+
+	return NewCanceledErrorWrap(ctx.Err(), message)
+}
+
+// WrapIfCanceled wraps the error in a CanceledError when it is caused by the context cancellation, otherwise it
+// returns the error as is.
+func WrapIfCanceled(ctx context.Context, err error, message string) error {
+	if err == nil || IsCanceledError(err) {
+		return err
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return NewCanceledErrorWrap(err, message)
+	}
+
+	// drivers don't always return the context error, for example when the connection is closed by the cancellation
+	if ctx.Err() != nil {
+		return NewCanceledErrorWrap(errors.Append(ctx.Err(), err), message)
+	}
+
+	return err
+}
+
+type canceledError struct {
+	CustomError
+}
+
+type CanceledError interface {
+	CustomError
+	isCanceledError()
+}
+
+func (c *canceledError) isCanceledError() {
+}
+
+// Is makes `errors.Is(err, ErrCanceled)` true for all canceled errors
+func (c *canceledError) Is(target error) bool {
+	return target == ErrCanceled
+}
+
+func IsCanceledError(err error) bool {
+	var canceledError CanceledError
+
+	if _, ok := err.(CanceledError); ok {
+		return true
+	}
+
+	if errors.As(err, &canceledError) {
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,52 @@
+package customErrors
+
+import (
+	"net/http"
+
+	"emperror.dev/errors"
+)
+
+// NewConcurrencyConflictError is a conflict error for an optimistic concurrency check, the entity is changed by another
+// writer after it was read, so the change can be retried on the current version of the entity
+func NewConcurrencyConflictError(message string) ConcurrencyConflictError {
+	// `NewPlain` doesn't add stack-trace at all
+	concurrencyErrMessage := errors.NewPlain("concurrency conflict error")
+	// `WrapIf` add stack-trace if not added before
+	stackErr := errors.WrapIf(concurrencyErrMessage, message)
+
+	concurrencyConflictError := &concurrencyConflictError{
+		CustomError: NewCustomError(stackErr, http.StatusConflict, message),
+	}
+
+	return concurrencyConflictError
+}
+
+type concurrencyConflictError struct {
+	CustomError
+}
+
+// ConcurrencyConflictError is also a ConflictError, so it is returned with a `409` status
+type ConcurrencyConflictError interface {
+	ConflictError
+	isConcurrencyConflictError()
+}
+
+func (c *concurrencyConflictError) isConflictError() {
+}
+
+func (c *concurrencyConflictError) isConcurrencyConflictError() {
+}
+
+func IsConcurrencyConflictError(err error) bool {
+	var concurrencyConflictError ConcurrencyConflictError
+
+	if _, ok := err.(ConcurrencyConflictError); ok {
+		return true
+	}
+
+	if errors.As(err, &concurrencyConflictError) {
+		return true
+	}
+
+	return false
+}
@@ -1,6 +1,7 @@
 package customErrors
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"testing"
@@ -492,6 +493,38 @@ func Test_Conflict_Error(t *testing.T) {
 	}
 }
 
+func Test_Canceled_Error(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// drivers may return their own error when the query is canceled
+	driverErr := errors.NewPlain("conn closed")
+	canceledErr := WrapIfCanceled(ctx, driverErr, "error in finding products")
+	err := errors.WithMessage(canceledErr, "this is a top error message")
+
+	assert.True(t, IsCustomError(err))
+	assert.True(t, IsCanceledError(err))
+	assert.True(t, errors.Is(err, ErrCanceled))
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.False(t, IsCanceledError(WrapIfCanceled(context.Background(), driverErr, "error in finding products")))
+	assert.Nil(t, CheckContext(context.Background(), "operation canceled"))
+
+	var canceledError CanceledError
+	errors.As(err, &canceledError)
+
+	assert.Equal(t, StatusClientClosedRequest, canceledError.Status())
+	assert.Equal(t, "error in finding products", canceledError.Message())
+
+	timeoutCtx, timeoutCancel := context.WithTimeout(context.Background(), 0)
+	defer timeoutCancel()
+
+	timeoutErr := CheckContext(timeoutCtx, "operation timed out")
+
+	assert.True(t, IsCanceledError(timeoutErr))
+	assert.True(t, errors.Is(timeoutErr, context.DeadlineExceeded))
+	assert.Equal(t, http.StatusRequestTimeout, GetCustomError(timeoutErr).Status())
+}
+
 func myfoo(e error) error {
 	// https://itnext.io/golang-error-handling-best-practice-a36f47b0b94c
 	// Note: Do not repeat Wrap, it will record redundancy call stacks, we usually care about root stack trace
@@ -501,3 +534,19 @@ func myfoo(e error) error {
 func mybar(e error) error {
 	return errors.WithMessage(myfoo(e), "bar failed") // or grpc_errors.WrapIf()
 }
+
+func Test_Concurrency_Conflict_Error(t *testing.T) {
+	concurrencyErr := NewConcurrencyConflictError("product version `2` is changed")
+	err := errors.WithMessage(concurrencyErr, "outer error wrapper")
+
+	assert.True(t, IsConcurrencyConflictError(err))
+	assert.True(t, IsConflictError(err))
+	assert.True(t, IsCustomError(err))
+	assert.False(t, IsConcurrencyConflictError(NewConflictError("conflict error")))
+
+	var conflictError ConflictError
+	errors.As(err, &conflictError)
+
+	assert.Equal(t, http.StatusConflict, conflictError.Status())
+	assert.Equal(t, "product version `2` is changed", conflictError.Message())
+}
@@ -31,6 +31,24 @@ func NewConflictProblemDetail(detail string, stackTrace string) ProblemDetailErr
 	}
 }
 
+// NewCanceledProblemDetail creates the problem detail of a canceled request, the status is `408` for the exceeded
+// deadlines and `499` for the requests canceled by the client
+func NewCanceledProblemDetail(status int, detail string, stackTrace string) ProblemDetailErr {
+	title := constants.ErrRequestCanceledTitle
+	if status == http.StatusRequestTimeout {
+		title = constants.ErrRequestTimeoutTitle
+	}
+
+	return &problemDetail{
+		Title:      title,
+		Detail:     detail,
+		Status:     status,
+		Type:       getDefaultType(status),
+		Timestamp:  time.Now(),
+		StackTrace: stackTrace,
+	}
+}
+
 func NewBadRequestProblemDetail(detail string, stackTrace string) ProblemDetailErr {
 	return &problemDetail{
 		Title:      constants.ErrBadRequestTitle,
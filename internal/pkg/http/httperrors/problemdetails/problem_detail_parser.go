@@ -46,6 +46,12 @@ func ParseError(err error) ProblemDetailErr {
 
 	if err != nil && customErr != nil {
 		switch {
+		// cancellation is checked first, because handlers wrap the repositories errors in application errors
+		case customErrors.IsCanceledError(err):
+			var canceledErr customErrors.CanceledError
+			errors.As(err, &canceledErr)
+
+			return NewCanceledProblemDetail(canceledErr.Status(), err.Error(), stackTrace)
 		case customErrors.IsDomainError(err, customErr.Status()):
 			return NewDomainProblemDetail(
 				customErr.Status(),
@@ -99,6 +105,12 @@ func ParseError(err error) ProblemDetailErr {
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
 			return NewNotFoundErrorProblemDetail(err.Error(), stackTrace)
+		case errors.Is(err, context.Canceled):
+			return NewCanceledProblemDetail(
+				customErrors.StatusClientClosedRequest,
+				err.Error(),
+				stackTrace,
+			)
 		case errors.Is(err, context.DeadlineExceeded):
 			return NewProblemDetail(
 				http.StatusRequestTimeout,
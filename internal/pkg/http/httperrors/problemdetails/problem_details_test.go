@@ -1,6 +1,7 @@
 package problemDetails
 
 import (
+	"context"
 	"net/http"
 	"testing"
 
@@ -52,6 +53,22 @@ func Test_Parse_Error(t *testing.T) {
 	notfoundPrb := ParseError(notFoundError)
 	assert.NotNil(t, notFoundError)
 	assert.Equal(t, notfoundPrb.GetStatus(), 404)
+
+	// Canceled ProblemDetail, wrapped in an application error by the handler
+	canceledError := customErrors.NewApplicationErrorWrap(
+		customErrors.NewCanceledErrorWrap(context.Canceled, "canceled error"),
+		"application error",
+	)
+	canceledPrb := ParseError(canceledError)
+	assert.NotNil(t, canceledPrb)
+	assert.Equal(t, canceledPrb.GetStatus(), customErrors.StatusClientClosedRequest)
+	assert.Equal(t, canceledPrb.GetTitle(), "Request Canceled")
+
+	// Timeout ProblemDetail
+	timeoutError := customErrors.NewCanceledErrorWrap(context.DeadlineExceeded, "timeout error")
+	timeoutPrb := ParseError(timeoutError)
+	assert.NotNil(t, timeoutPrb)
+	assert.Equal(t, timeoutPrb.GetStatus(), http.StatusRequestTimeout)
 }
 
 func TestMap(t *testing.T) {
@@ -0,0 +1,385 @@
+package inmemorybus
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/bus"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	messageHeader "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/messageheader"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/pipeline"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+
+	"emperror.dev/errors"
+)
+
+// ConfigurationFunc connects the handlers and the pipelines of the consumers to the bus
+type ConfigurationFunc func(bus InMemoryBus) error
+
+type InMemoryBus interface {
+	bus.Bus
+	// ConnectConsumerHandlerWithPipelines connects a handler which runs inside the pipelines, like the handlers of a
+	// consumer of the brokers run inside the pipelines of the consumer
+	ConnectConsumerHandlerWithPipelines(
+		messageType types.IMessage,
+		consumerHandler consumer.ConsumerHandler,
+		pipelines ...pipeline.ConsumerPipeline,
+	) error
+}
+
+type handlerRegistration struct {
+	handler   consumer.ConsumerHandler
+	pipelines []pipeline.ConsumerPipeline
+}
+
+type delivery struct {
+	ctx            context.Context
+	consumeContext types.MessageConsumeContext
+}
+
+type inMemoryBus struct {
+	options                 *InMemoryBusOptions
+	logger                  logger.Logger
+	mu                      sync.RWMutex
+	handlers                map[reflect.Type][]*handlerRegistration
+	consumers               map[reflect.Type][]consumer.Consumer
+	deliveries              chan *delivery
+	quit                    chan struct{}
+	workers                 sync.WaitGroup
+	delayed                 map[*time.Timer]struct{}
+	deliveryTag             atomic.Uint64
+	started                 bool
+	isConsumedNotifications []func(message types.IMessage)
+	isProducedNotifications []func(message types.IMessage)
+}
+
+// NewInMemoryBus dispatches the published messages to the handlers of their message type in the process, the handlers
+// receive the published message itself without serializing it, so they should not change it.
+func NewInMemoryBus(
+	options *InMemoryBusOptions,
+	logger logger.Logger,
+	configurationFunc ConfigurationFunc,
+) (InMemoryBus, error) {
+	if options == nil {
+		options = &InMemoryBusOptions{AutoStart: true, DispatchMode: AsyncDispatch, BufferSize: 1000, ConcurrencyLimit: 1}
+	}
+
+	inMemoryBus := &inMemoryBus{
+		options:    options,
+		logger:     logger,
+		handlers:   make(map[reflect.Type][]*handlerRegistration),
+		consumers:  make(map[reflect.Type][]consumer.Consumer),
+		deliveries: make(chan *delivery, max(options.BufferSize, 0)),
+		delayed:    make(map[*time.Timer]struct{}),
+	}
+
+	if configurationFunc != nil {
+		if err := configurationFunc(inMemoryBus); err != nil {
+			return nil, errors.WrapIf(err, "error in configuring the in-memory bus")
+		}
+	}
+
+	return inMemoryBus, nil
+}
+
+func (b *inMemoryBus) IsConsumed(h func(message types.IMessage)) {
+	b.isConsumedNotifications = append(b.isConsumedNotifications, h)
+}
+
+func (b *inMemoryBus) IsProduced(h func(message types.IMessage)) {
+	b.isProducedNotifications = append(b.isProducedNotifications, h)
+}
+
+// ConnectConsumerHandler adds the handler to the handlers of the message type
+func (b *inMemoryBus) ConnectConsumerHandler(messageType types.IMessage, consumerHandler consumer.ConsumerHandler) error {
+	return b.ConnectConsumerHandlerWithPipelines(messageType, consumerHandler)
+}
+
+func (b *inMemoryBus) ConnectConsumerHandlerWithPipelines(
+	messageType types.IMessage,
+	consumerHandler consumer.ConsumerHandler,
+	pipelines ...pipeline.ConsumerPipeline,
+) error {
+	if consumerHandler == nil {
+		return errors.New("consumer handler is nil")
+	}
+
+	typ := utils.GetMessageBaseReflectType(messageType)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[typ] = append(b.handlers[typ], &handlerRegistration{handler: consumerHandler, pipelines: pipelines})
+
+	return nil
+}
+
+// ConnectConsumer adds a consumer which is started and stopped with the bus, the consumer receives the messages of its
+// own broker and not the messages of the in-memory bus
+func (b *inMemoryBus) ConnectConsumer(messageType types.IMessage, consumer consumer.Consumer) error {
+	typ := utils.GetMessageBaseReflectType(messageType)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.consumers[typ] = append(b.consumers[typ], consumer)
+
+	return nil
+}
+
+// Start starts the workers of the async dispatch and the connected consumers, the messages which are published before
+// starting are queued in the channel of the bus
+func (b *inMemoryBus) Start(ctx context.Context) error {
+	b.mu.Lock()
+	if b.started {
+		b.mu.Unlock()
+
+		return nil
+	}
+
+	b.started = true
+	b.quit = make(chan struct{})
+
+	var consumers []consumer.Consumer
+	for _, typeConsumers := range b.consumers {
+		consumers = append(consumers, typeConsumers...)
+	}
+	b.mu.Unlock()
+
+	if b.options.DispatchMode != SyncDispatch {
+		for i := 0; i < max(b.options.ConcurrencyLimit, 1); i++ {
+			b.workers.Add(1)
+			go b.work(b.quit)
+		}
+	}
+
+	for _, c := range consumers {
+		if err := c.Start(ctx); err != nil {
+			return errors.WrapIff(err, "error in starting consumer %s", c.GetName())
+		}
+	}
+
+	return nil
+}
+
+// Stop waits for the workers to handle the queued messages, the delayed messages which are not due yet are dropped
+func (b *inMemoryBus) Stop() error {
+	b.mu.Lock()
+	if !b.started {
+		b.mu.Unlock()
+
+		return nil
+	}
+
+	b.started = false
+	close(b.quit)
+
+	for timer := range b.delayed {
+		timer.Stop()
+		delete(b.delayed, timer)
+	}
+
+	var consumers []consumer.Consumer
+	for _, typeConsumers := range b.consumers {
+		consumers = append(consumers, typeConsumers...)
+	}
+	b.mu.Unlock()
+
+	b.workers.Wait()
+
+	var err error
+	for _, c := range consumers {
+		err = errors.Append(err, c.Stop())
+	}
+
+	return err
+}
+
+func (b *inMemoryBus) PublishMessage(ctx context.Context, message types.IMessage, meta metadata.Metadata) error {
+	if message == nil {
+		return errors.New("message is nil")
+	}
+
+	consumeContext := b.consumeContext(message, meta)
+
+	for _, notification := range b.isProducedNotifications {
+		if notification != nil {
+			notification(message)
+		}
+	}
+
+	if b.options.DispatchMode == SyncDispatch {
+		return b.dispatch(ctx, consumeContext)
+	}
+
+	// the publisher context is usually canceled after the publish, like the context of a request, but the message is
+	// handled later by a worker
+	select {
+	case b.deliveries <- &delivery{ctx: context.WithoutCancel(ctx), consumeContext: consumeContext}:
+		return nil
+	case <-ctx.Done():
+		return errors.WrapIf(ctx.Err(), "error in queueing the message in the in-memory bus")
+	}
+}
+
+// PublishMessageWithTopicName publishes the message to the handlers of its message type, the in-memory bus has no
+// topics
+func (b *inMemoryBus) PublishMessageWithTopicName(
+	ctx context.Context,
+	message types.IMessage,
+	meta metadata.Metadata,
+	topicOrExchangeName string,
+) error {
+	return b.PublishMessage(ctx, message, meta)
+}
+
+func (b *inMemoryBus) PublishMessageWithDelay(
+	ctx context.Context,
+	message types.IMessage,
+	meta metadata.Metadata,
+	delay time.Duration,
+) error {
+	if delay <= 0 {
+		return b.PublishMessage(ctx, message, meta)
+	}
+
+	ctx = context.WithoutCancel(ctx)
+
+	// the timer is registered before its func can take the lock, so the func always finds it
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var timer *time.Timer
+	timer = time.AfterFunc(delay, func() {
+		b.mu.Lock()
+		delete(b.delayed, timer)
+		b.mu.Unlock()
+
+		if err := b.PublishMessage(ctx, message, meta); err != nil {
+			b.logger.Errorw(
+				"[inMemoryBus.PublishMessageWithDelay] error in publishing the delayed message",
+				logger.Fields{"message_id": message.GeMessageId(), "error": err.Error()},
+			)
+		}
+	})
+	b.delayed[timer] = struct{}{}
+
+	return nil
+}
+
+func (b *inMemoryBus) PublishMessages(ctx context.Context, messages []types.IMessage) error {
+	for _, message := range messages {
+		if err := b.PublishMessage(ctx, message, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *inMemoryBus) consumeContext(message types.IMessage, meta metadata.Metadata) types.MessageConsumeContext {
+	// each publish has its own metadata, so the pipelines don't change the metadata of the publisher
+	consumeMeta := metadata.Metadata{}
+	for key, value := range meta {
+		consumeMeta[key] = value
+	}
+
+	return types.NewMessageConsumeContext(
+		message,
+		consumeMeta,
+		"",
+		message.GetMessageTypeName(),
+		message.GetCreated(),
+		b.deliveryTag.Add(1),
+		message.GeMessageId(),
+		messageHeader.GetCorrelationId(consumeMeta),
+	)
+}
+
+func (b *inMemoryBus) work(quit <-chan struct{}) {
+	defer b.workers.Done()
+
+	for {
+		select {
+		case d := <-b.deliveries:
+			b.handleDelivery(d)
+		case <-quit:
+			// the queued messages are handled before stopping, so a graceful stop doesn't lose them
+			for {
+				select {
+				case d := <-b.deliveries:
+					b.handleDelivery(d)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (b *inMemoryBus) handleDelivery(d *delivery) {
+	if err := b.dispatch(d.ctx, d.consumeContext); err != nil {
+		b.logger.Errorw(
+			"[inMemoryBus.handleDelivery] error in handling the message of the in-memory bus, the message is dropped",
+			logger.Fields{"message_id": d.consumeContext.MessageId(), "error": err.Error()},
+		)
+	}
+}
+
+// dispatch runs the handlers of the message type one by one until a handler fails
+func (b *inMemoryBus) dispatch(ctx context.Context, consumeContext types.MessageConsumeContext) error {
+	typ := utils.GetMessageBaseReflectType(consumeContext.Message())
+
+	b.mu.RLock()
+	handlers := b.handlers[typ]
+	b.mu.RUnlock()
+
+	if len(handlers) == 0 {
+		return nil
+	}
+
+	for _, registration := range handlers {
+		if err := runHandler(ctx, registration.handler, registration.pipelines, consumeContext); err != nil {
+			return errors.WrapIf(err, fmt.Sprintf("error in handling message %s", consumeContext.MessageType()))
+		}
+	}
+
+	for _, notification := range b.isConsumedNotifications {
+		if notification != nil {
+			notification(consumeContext.Message())
+		}
+	}
+
+	return nil
+}
+
+// runHandler runs the handler inside the pipelines, the first pipeline is the outermost one
+func runHandler(
+	ctx context.Context,
+	handler consumer.ConsumerHandler,
+	pipelines []pipeline.ConsumerPipeline,
+	consumeContext types.MessageConsumeContext,
+) error {
+	// the pipelines run for each handler, so they can keep the state of the message per handler, like the inbox
+	ctx = consumer.WithHandler(ctx, handler)
+
+	next := func(ctx context.Context) error {
+		return handler.Handle(ctx, consumeContext)
+	}
+
+	for i := len(pipelines) - 1; i >= 0; i-- {
+		pipe, inner := pipelines[i], next
+		next = func(ctx context.Context) error {
+			return pipe.Handle(ctx, consumeContext, inner)
+		}
+	}
+
+	return next(ctx)
+}
@@ -0,0 +1,36 @@
+package inmemorybus
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[InMemoryBusOptions]())
+
+type DispatchMode string
+
+const (
+	// SyncDispatch runs the handlers in the goroutine of the publisher and returns their error to it, so a test can
+	// assert the result of the handlers right after publishing
+	SyncDispatch DispatchMode = "sync"
+	// AsyncDispatch queues the messages in a channel which is drained by the workers of the bus, like a broker
+	AsyncDispatch DispatchMode = "async"
+)
+
+// InMemoryBusOptions controls the in-memory bus which dispatches the messages of an app to its own handlers without a
+// message broker.
+type InMemoryBusOptions struct {
+	AutoStart    bool         `mapstructure:"autoStart"        default:"true"`
+	DispatchMode DispatchMode `mapstructure:"dispatchMode"     default:"async"`
+	// BufferSize is the capacity of the channel of the async dispatch, a publish waits while the channel is full
+	BufferSize int `mapstructure:"bufferSize"       default:"1000"`
+	// ConcurrencyLimit is the number of the workers of the async dispatch
+	ConcurrencyLimit int `mapstructure:"concurrencyLimit" default:"1"`
+}
+
+func ProvideConfig(environment environment.Environment) (*InMemoryBusOptions, error) {
+	return config.BindConfigKey[*InMemoryBusOptions](optionName, environment)
+}
@@ -0,0 +1,213 @@
+package inmemorybus
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/pipeline"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type OrderCreated struct {
+	*types.Message
+}
+
+type OrderShipped struct {
+	*types.Message
+}
+
+type recordingHandler struct {
+	mu       sync.Mutex
+	messages []string
+	err      error
+}
+
+func (r *recordingHandler) Handle(ctx context.Context, consumeContext types.MessageConsumeContext) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.messages = append(r.messages, consumeContext.MessageId())
+
+	return r.err
+}
+
+func (r *recordingHandler) Messages() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string(nil), r.messages...)
+}
+
+type recordingPipeline struct {
+	name  string
+	calls *[]string
+}
+
+func (r *recordingPipeline) Handle(
+	ctx context.Context,
+	consumeContext types.MessageConsumeContext,
+	next pipeline.ConsumerHandlerFunc,
+) error {
+	*r.calls = append(*r.calls, r.name)
+
+	return next(ctx)
+}
+
+func newTestBus(t *testing.T, mode DispatchMode) InMemoryBus {
+	t.Helper()
+
+	bus, err := NewInMemoryBus(
+		&InMemoryBusOptions{DispatchMode: mode, BufferSize: 10, ConcurrencyLimit: 2},
+		defaultLogger.GetLogger(),
+		nil,
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, bus.Start(context.Background()))
+	t.Cleanup(func() {
+		_ = bus.Stop()
+	})
+
+	return bus
+}
+
+func Test_Sync_Dispatch_Handles_The_Message_Before_Publish_Returns(t *testing.T) {
+	bus := newTestBus(t, SyncDispatch)
+	created, shipped := &recordingHandler{}, &recordingHandler{}
+	require.NoError(t, bus.ConnectConsumerHandler(&OrderCreated{}, created))
+	require.NoError(t, bus.ConnectConsumerHandler(OrderShipped{}, shipped))
+
+	err := bus.PublishMessage(context.Background(), &OrderCreated{Message: types.NewMessage("message-1")}, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"message-1"}, created.Messages())
+	assert.Empty(t, shipped.Messages())
+}
+
+func Test_Sync_Dispatch_Returns_The_Error_Of_The_Handler(t *testing.T) {
+	bus := newTestBus(t, SyncDispatch)
+	handlerErr := errors.New("handler failed")
+	require.NoError(t, bus.ConnectConsumerHandler(&OrderCreated{}, &recordingHandler{err: handlerErr}))
+
+	err := bus.PublishMessage(context.Background(), &OrderCreated{Message: types.NewMessage("message-1")}, nil)
+
+	assert.ErrorIs(t, err, handlerErr)
+}
+
+func Test_Async_Dispatch_Handles_The_Messages_In_The_Workers(t *testing.T) {
+	bus := newTestBus(t, AsyncDispatch)
+	handler := &recordingHandler{}
+	require.NoError(t, bus.ConnectConsumerHandler(&OrderCreated{}, handler))
+
+	var consumed sync.WaitGroup
+	consumed.Add(3)
+	bus.IsConsumed(func(message types.IMessage) {
+		consumed.Done()
+	})
+
+	for _, id := range []string{"message-1", "message-2", "message-3"} {
+		require.NoError(t, bus.PublishMessage(context.Background(), &OrderCreated{Message: types.NewMessage(id)}, nil))
+	}
+
+	consumed.Wait()
+	assert.ElementsMatch(t, []string{"message-1", "message-2", "message-3"}, handler.Messages())
+}
+
+func Test_Stop_Handles_The_Queued_Messages(t *testing.T) {
+	bus, err := NewInMemoryBus(
+		&InMemoryBusOptions{DispatchMode: AsyncDispatch, BufferSize: 10, ConcurrencyLimit: 1},
+		defaultLogger.GetLogger(),
+		nil,
+	)
+	require.NoError(t, err)
+	handler := &recordingHandler{}
+	require.NoError(t, bus.ConnectConsumerHandler(&OrderCreated{}, handler))
+
+	// the messages are queued until the bus starts
+	require.NoError(t, bus.PublishMessage(context.Background(), &OrderCreated{Message: types.NewMessage("message-1")}, nil))
+	require.NoError(t, bus.PublishMessage(context.Background(), &OrderCreated{Message: types.NewMessage("message-2")}, nil))
+
+	require.NoError(t, bus.Start(context.Background()))
+	require.NoError(t, bus.Stop())
+
+	assert.Equal(t, []string{"message-1", "message-2"}, handler.Messages())
+}
+
+func Test_Handler_Runs_Inside_Its_Pipelines(t *testing.T) {
+	bus := newTestBus(t, SyncDispatch)
+	var calls []string
+	handler := &recordingHandler{}
+	require.NoError(t, bus.ConnectConsumerHandlerWithPipelines(
+		&OrderCreated{},
+		handler,
+		&recordingPipeline{name: "outer", calls: &calls},
+		&recordingPipeline{name: "inner", calls: &calls},
+	))
+
+	err := bus.PublishMessage(context.Background(), &OrderCreated{Message: types.NewMessage("message-1")}, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"outer", "inner"}, calls)
+	assert.Equal(t, []string{"message-1"}, handler.Messages())
+}
+
+func Test_Consume_Context_Has_A_Copy_Of_The_Metadata(t *testing.T) {
+	bus := newTestBus(t, SyncDispatch)
+	meta := metadata.Metadata{"tenant": "tenant-1"}
+	var consumeMeta metadata.Metadata
+	require.NoError(t, bus.ConnectConsumerHandlerWithPipelines(
+		&OrderCreated{},
+		&recordingHandler{},
+		pipelineFunc(func(ctx context.Context, consumeContext types.MessageConsumeContext) {
+			consumeMeta = consumeContext.Metadata()
+			consumeMeta["handled"] = true
+		}),
+	))
+
+	err := bus.PublishMessage(context.Background(), &OrderCreated{Message: types.NewMessage("message-1")}, meta)
+
+	require.NoError(t, err)
+	assert.Equal(t, "tenant-1", consumeMeta["tenant"])
+	assert.NotContains(t, meta, "handled")
+}
+
+func Test_Delayed_Message_Is_Published_After_The_Delay(t *testing.T) {
+	bus := newTestBus(t, SyncDispatch)
+	handler := &recordingHandler{}
+	require.NoError(t, bus.ConnectConsumerHandler(&OrderCreated{}, handler))
+
+	published := time.Now()
+	err := bus.PublishMessageWithDelay(
+		context.Background(),
+		&OrderCreated{Message: types.NewMessage("message-1")},
+		nil,
+		50*time.Millisecond,
+	)
+	require.NoError(t, err)
+	assert.Empty(t, handler.Messages())
+
+	assert.Eventually(t, func() bool {
+		return len(handler.Messages()) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.GreaterOrEqual(t, time.Since(published), 50*time.Millisecond)
+}
+
+type pipelineFunc func(ctx context.Context, consumeContext types.MessageConsumeContext)
+
+func (p pipelineFunc) Handle(
+	ctx context.Context,
+	consumeContext types.MessageConsumeContext,
+	next pipeline.ConsumerHandlerFunc,
+) error {
+	p(ctx, consumeContext)
+
+	return next(ctx)
+}
@@ -0,0 +1,66 @@
+package inmemorybus
+
+import (
+	"context"
+
+	bus2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/bus"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/producer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+
+	"go.uber.org/fx"
+)
+
+var (
+	// ModuleFunc provided to fxlog, the configuration constructor returns the `ConfigurationFunc` of the bus, it is
+	// optional for the apps which only publish messages
+	// https://uber-go.github.io/fx/modules.html
+	ModuleFunc = func(configurationConstructor interface{}) fx.Option { //nolint:gochecknoglobals
+		options := []fx.Option{inMemoryBusProviders, inMemoryBusInvokes}
+		if configurationConstructor != nil {
+			options = append(options, fx.Provide(configurationConstructor))
+		}
+
+		return fx.Module("inmemorybusfx", options...)
+	}
+
+	inMemoryBusProviders = fx.Options( //nolint:gochecknoglobals
+		fx.Provide(ProvideConfig),
+		fx.Provide(fx.Annotate(
+			NewInMemoryBus,
+			fx.ParamTags(``, ``, `optional:"true"`),
+			fx.As(new(producer.Producer)),
+			fx.As(new(bus2.Bus)),
+			fx.As(new(InMemoryBus)),
+		)),
+	)
+
+	inMemoryBusInvokes = fx.Options(fx.Invoke(registerHooks)) //nolint:gochecknoglobals
+)
+
+func registerHooks(lc fx.Lifecycle, bus InMemoryBus, options *InMemoryBusOptions, logger logger.Logger) {
+	if !options.AutoStart {
+		return
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			// the handlers get the context of the publisher, so the start context is only used by the consumers
+			if err := bus.Start(context.Background()); err != nil {
+				return err
+			}
+
+			logger.Info("in-memory bus is started.")
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			if err := bus.Stop(); err != nil {
+				logger.Errorf("error shutting down in-memory bus: %v", err)
+			} else {
+				logger.Info("in-memory bus shutdown gracefully")
+			}
+
+			return nil
+		},
+	})
+}
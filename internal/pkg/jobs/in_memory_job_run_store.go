@@ -0,0 +1,88 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+)
+
+type inMemoryJobRunStore struct {
+	mu   sync.RWMutex
+	runs map[string]*JobRun
+}
+
+// NewInMemoryJobRunStore keeps the job runs in the memory of the process, it is used by the tests and the services
+// which run a single instance.
+func NewInMemoryJobRunStore() JobRunStore {
+	return &inMemoryJobRunStore{runs: make(map[string]*JobRun)}
+}
+
+func (s *inMemoryJobRunStore) Add(_ context.Context, run *JobRun) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.runs[run.Id]; ok {
+		return customErrors.NewConflictError(fmt.Sprintf("job run with id `%s` already exists", run.Id))
+	}
+
+	copied := *run
+	s.runs[run.Id] = &copied
+
+	return nil
+}
+
+func (s *inMemoryJobRunStore) Get(_ context.Context, id string) (*JobRun, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	run, ok := s.runs[id]
+	if !ok {
+		return nil, customErrors.NewNotFoundError(fmt.Sprintf("job run with id `%s` not found", id))
+	}
+
+	copied := *run
+
+	return &copied, nil
+}
+
+func (s *inMemoryJobRunStore) Update(_ context.Context, run *JobRun) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.runs[run.Id]; !ok {
+		return customErrors.NewNotFoundError(fmt.Sprintf("job run with id `%s` not found", run.Id))
+	}
+
+	copied := *run
+	s.runs[run.Id] = &copied
+
+	return nil
+}
+
+func (s *inMemoryJobRunStore) List(_ context.Context, jobName string, limit int) ([]*JobRun, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	runs := make([]*JobRun, 0, len(s.runs))
+	for _, run := range s.runs {
+		if jobName != "" && run.JobName != jobName {
+			continue
+		}
+
+		copied := *run
+		runs = append(runs, &copied)
+	}
+
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].StartedAt.After(runs[j].StartedAt)
+	})
+
+	if limit > 0 && len(runs) > limit {
+		runs = runs[:limit]
+	}
+
+	return runs, nil
+}
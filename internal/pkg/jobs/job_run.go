@@ -0,0 +1,92 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type JobStatus string
+
+const (
+	// Running is the status of a job run which is not finished yet
+	Running   JobStatus = "Running"
+	Succeeded JobStatus = "Succeeded"
+	Failed    JobStatus = "Failed"
+	// Canceled is the status of a job run which is stopped by its context, e.g. on the shutdown of the app
+	Canceled JobStatus = "Canceled"
+)
+
+// IsTerminal returns true when the job run is finished
+func (s JobStatus) IsTerminal() bool {
+	return s != Running
+}
+
+// JobRun is a single run of a job with its last reported progress and its result, the runs are persisted by the
+// JobRunStore and are queryable with the jobs admin endpoints
+type JobRun struct {
+	Id      string    `gorm:"primaryKey"                 bson:"id"                json:"id"`
+	JobName string    `gorm:"index"                      bson:"jobName"           json:"jobName"`
+	Status  JobStatus `bson:"status"                     json:"status"`
+	// Percent is the progress of the run from 0 to 100, it is only known when the job reports its total count
+	Percent        float64 `bson:"percent"                    json:"percent"`
+	ProcessedCount int64   `bson:"processedCount"             json:"processedCount"`
+	TotalCount     int64   `bson:"totalCount"                 json:"totalCount"`
+	// Result is the json result of the job when the run is succeeded
+	Result     json.RawMessage `gorm:"type:jsonb"                 bson:"result,omitempty"  json:"result,omitempty"`
+	Error      string          `bson:"error,omitempty"            json:"error,omitempty"`
+	StartedAt  time.Time       `gorm:"index"                      bson:"startedAt"         json:"startedAt"`
+	UpdatedAt  time.Time       `gorm:"default:current_timestamp"  bson:"updatedAt"         json:"updatedAt"`
+	FinishedAt *time.Time      `bson:"finishedAt,omitempty"       json:"finishedAt,omitempty"`
+}
+
+func NewJobRun(jobName string) *JobRun {
+	now := time.Now()
+
+	return &JobRun{
+		Id:        uuid.NewV4().String(),
+		JobName:   jobName,
+		Status:    Running,
+		StartedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func (r *JobRun) TableName() string {
+	return "job_runs"
+}
+
+// Duration returns the running time of a finished run, or the time since its start for a running one
+func (r *JobRun) Duration() time.Duration {
+	if r.FinishedAt != nil {
+		return r.FinishedAt.Sub(r.StartedAt)
+	}
+
+	return time.Since(r.StartedAt)
+}
+
+// GetResult unmarshals the result of a succeeded run to the result type of its job
+func GetResult[TResult any](run *JobRun) (TResult, error) {
+	var result TResult
+	if len(run.Result) == 0 {
+		return result, nil
+	}
+
+	err := json.Unmarshal(run.Result, &result)
+
+	return result, err
+}
+
+// JobRunStore keeps the runs of the jobs with their progress and results
+type JobRunStore interface {
+	// Add stores a new run
+	Add(ctx context.Context, run *JobRun) error
+	// Get returns the run, a not found error is returned for an unknown id
+	Get(ctx context.Context, id string) (*JobRun, error)
+	// Update replaces the progress, the status and the result of an existing run
+	Update(ctx context.Context, run *JobRun) error
+	// List returns the last runs of a job, the newest first, an empty job name lists the runs of all jobs
+	List(ctx context.Context, jobName string, limit int) ([]*JobRun, error)
+}
@@ -0,0 +1,155 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/utils"
+	errorutils "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils/errorutils"
+
+	"emperror.dev/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Job is the work of a job run, it reports its progress with `ReportProgress` and its result is persisted as json
+type Job[TResult any] func(ctx context.Context) (TResult, error)
+
+// JobRunner runs the jobs, each run is persisted with its progress and result and is traced in its own span
+type JobRunner struct {
+	store   JobRunStore
+	options *JobsOptions
+	tracer  tracing.AppTracer
+	logger  logger.Logger
+}
+
+func NewJobRunner(
+	store JobRunStore,
+	options *JobsOptions,
+	tracer tracing.AppTracer,
+	logger logger.Logger,
+) *JobRunner {
+	return &JobRunner{store: store, options: options, tracer: tracer, logger: logger}
+}
+
+func (r *JobRunner) Store() JobRunStore {
+	return r.store
+}
+
+// Run runs the job in the current goroutine and returns its finished run and result, the error of the job is returned
+// after its failed run is persisted
+func Run[TResult any](
+	ctx context.Context,
+	runner *JobRunner,
+	jobName string,
+	job Job[TResult],
+) (*JobRun, TResult, error) {
+	run := NewJobRun(jobName)
+	if err := runner.store.Add(ctx, run); err != nil {
+		var result TResult
+
+		return nil, result, errors.WrapIff(err, "error in adding the run of the job `%s`", jobName)
+	}
+
+	return execute(ctx, runner, run, job)
+}
+
+// Start runs the job in the background and returns its running run, the run is not canceled with the context, e.g.
+// the request context of an admin endpoint, and its progress and result are read from the JobRunStore
+func Start[TResult any](
+	ctx context.Context,
+	runner *JobRunner,
+	jobName string,
+	job Job[TResult],
+) (*JobRun, error) {
+	run := NewJobRun(jobName)
+	if err := runner.store.Add(ctx, run); err != nil {
+		return nil, errors.WrapIff(err, "error in adding the run of the job `%s`", jobName)
+	}
+
+	started := *run
+	ctx = context.WithoutCancel(ctx)
+
+	go func() {
+		defer errorutils.HandlePanic()
+
+		_, _, _ = execute(ctx, runner, run, job)
+	}()
+
+	return &started, nil
+}
+
+func execute[TResult any](
+	ctx context.Context,
+	runner *JobRunner,
+	run *JobRun,
+	job Job[TResult],
+) (*JobRun, TResult, error) {
+	ctx, span := runner.tracer.Start(ctx, fmt.Sprintf("jobs.%s", run.JobName))
+	span.SetAttributes(attribute.String("job.name", run.JobName), attribute.String("job.run_id", run.Id))
+	defer span.End()
+
+	progress := newRunProgress(run, runner.store, runner.options.ProgressInterval(), runner.logger)
+
+	result, err := job(withProgressReporter(ctx, progress))
+
+	finished := progress.snapshot()
+	now := time.Now()
+	finished.UpdatedAt = now
+	finished.FinishedAt = &now
+
+	switch {
+	case err == nil:
+		finished.Status = Succeeded
+		finished.Percent = 100
+		if data, marshalErr := json.Marshal(result); marshalErr == nil {
+			finished.Result = data
+		} else {
+			runner.logger.Errorf(
+				"(jobRunner) error in marshaling the result of the job run `%s`: {%v}",
+				finished.Id,
+				marshalErr,
+			)
+		}
+	case customErrors.IsCanceledError(err) || ctx.Err() != nil:
+		finished.Status = Canceled
+		finished.Error = err.Error()
+	default:
+		finished.Status = Failed
+		finished.Error = err.Error()
+	}
+
+	span.SetAttributes(
+		attribute.String("job.status", string(finished.Status)),
+		attribute.Int64("job.processed_count", finished.ProcessedCount),
+	)
+
+	// the run is persisted even when the job is canceled by its context
+	if updateErr := runner.store.Update(context.WithoutCancel(ctx), finished); updateErr != nil {
+		runner.logger.Errorf("(jobRunner) error in persisting the job run `%s`: {%v}", finished.Id, updateErr)
+	}
+
+	runner.logger.Infow(
+		fmt.Sprintf(
+			"job run `%s` of `%s` finished with status %s in %s",
+			finished.Id,
+			finished.JobName,
+			finished.Status,
+			finished.Duration(),
+		),
+		logger.Fields{"JobName": finished.JobName, "JobRunId": finished.Id, "Status": finished.Status},
+	)
+
+	if err != nil {
+		return finished, result, utils.TraceErrStatusFromSpan(
+			span,
+			errors.WrapIff(err, "error in running the job `%s`", finished.JobName),
+		)
+	}
+
+	return finished, result, nil
+}
@@ -0,0 +1,110 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+
+	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	testUtils "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/test/utils"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type exportResult struct {
+	ExportedCount int64 `json:"exportedCount"`
+}
+
+func newTestJobRunner() *JobRunner {
+	return NewJobRunner(
+		NewInMemoryJobRunStore(),
+		&JobsOptions{},
+		tracing.NewAppTracer("jobs-test"),
+		defaultLogger.GetLogger(),
+	)
+}
+
+func Test_Run_Should_Persist_Succeeded_Run_With_Typed_Result(t *testing.T) {
+	ctx := context.Background()
+	runner := newTestJobRunner()
+
+	run, result, err := Run(ctx, runner, "export-products", func(ctx context.Context) (*exportResult, error) {
+		for i := int64(1); i <= 4; i++ {
+			ReportProgress(ctx, i, 4)
+		}
+
+		return &exportResult{ExportedCount: 4}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), result.ExportedCount)
+
+	persisted, err := runner.Store().Get(ctx, run.Id)
+	require.NoError(t, err)
+	assert.Equal(t, Succeeded, persisted.Status)
+	assert.Equal(t, float64(100), persisted.Percent)
+	assert.Equal(t, int64(4), persisted.ProcessedCount)
+	assert.NotNil(t, persisted.FinishedAt)
+
+	persistedResult, err := GetResult[*exportResult](persisted)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), persistedResult.ExportedCount)
+}
+
+func Test_Run_Should_Persist_Failed_Run(t *testing.T) {
+	ctx := context.Background()
+	runner := newTestJobRunner()
+
+	run, _, err := Run(ctx, runner, "import-products", func(ctx context.Context) (*exportResult, error) {
+		ReportProgress(ctx, 1, 0)
+
+		return nil, errors.New("invalid line 2")
+	})
+	require.Error(t, err)
+
+	persisted, err := runner.Store().Get(ctx, run.Id)
+	require.NoError(t, err)
+	assert.Equal(t, Failed, persisted.Status)
+	assert.Equal(t, "invalid line 2", persisted.Error)
+	assert.Equal(t, int64(1), persisted.ProcessedCount)
+	assert.Zero(t, persisted.Percent)
+}
+
+func Test_Start_Should_Report_Progress_Of_Running_Job(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	runner := newTestJobRunner()
+
+	proceed := make(chan struct{})
+	run, err := Start(ctx, runner, "rebuild-projections", func(ctx context.Context) (int, error) {
+		ReportProgress(ctx, 5, 10)
+		<-proceed
+
+		return 10, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, Running, run.Status)
+
+	// the background run is not canceled with the context of its caller
+	cancel()
+
+	err = testUtils.WaitUntilConditionMet(func() bool {
+		running, err := runner.Store().Get(context.Background(), run.Id)
+
+		return err == nil && running.Percent == 50
+	})
+	require.NoError(t, err)
+
+	close(proceed)
+
+	err = testUtils.WaitUntilConditionMet(func() bool {
+		finished, err := runner.Store().Get(context.Background(), run.Id)
+
+		return err == nil && finished.Status == Succeeded
+	})
+	require.NoError(t, err)
+
+	runs, err := runner.Store().List(context.Background(), "rebuild-projections", 10)
+	require.NoError(t, err)
+	assert.Len(t, runs, 1)
+}
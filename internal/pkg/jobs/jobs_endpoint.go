@@ -0,0 +1,84 @@
+package jobs
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/contracts"
+	apikey "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/api_key"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"github.com/labstack/echo/v4"
+)
+
+const defaultLimit = 20
+
+type JobsEndpoint struct {
+	store      JobRunStore
+	options    *JobsOptions
+	echoServer contracts.EchoHttpServer
+}
+
+func NewJobsEndpoint(
+	store JobRunStore,
+	options *JobsOptions,
+	server contracts.EchoHttpServer,
+) *JobsEndpoint {
+	return &JobsEndpoint{store: store, options: options, echoServer: server}
+}
+
+// RegisterEndpoints registers the `jobs` admin endpoints, they are authenticated with the api keys of the admin users
+// and are not registered without any user
+func (e *JobsEndpoint) RegisterEndpoints() {
+	var keys []apikey.Option
+	for _, user := range e.options.AdminUsers {
+		if user != nil {
+			keys = append(keys, apikey.WithKey(user.ApiKey, user.UserId))
+		}
+	}
+
+	if len(keys) == 0 {
+		return
+	}
+
+	group := e.echoServer.GetEchoInstance().Group("jobs", apikey.ApiKey(keys...))
+	group.GET("", e.runs)
+	group.GET("/:id", e.run)
+}
+
+func (e *JobsEndpoint) runs(c echo.Context) error {
+	limit, err := limitParam(c)
+	if err != nil {
+		return err
+	}
+
+	runs, err := e.store.List(c.Request().Context(), c.QueryParam("name"), limit)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, runs)
+}
+
+func (e *JobsEndpoint) run(c echo.Context) error {
+	run, err := e.store.Get(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, run)
+}
+
+func limitParam(c echo.Context) (int, error) {
+	value := c.QueryParam("limit")
+	if value == "" {
+		return defaultLimit, nil
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 {
+		return 0, customErrors.NewBadRequestError("limit should be a positive number")
+	}
+
+	return limit, nil
+}
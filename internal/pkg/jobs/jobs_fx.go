@@ -0,0 +1,20 @@
+package jobs
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provides the JobRunner, its options and the jobs admin endpoints, the JobRunStore is provided by the
+// persistence modules like `postgresgorm.JobRunModule`, and the endpoints need an echo server
+// https://uber-go.github.io/fx/modules.html
+var Module = fx.Module( //nolint:gochecknoglobals
+	"jobsfx",
+	fx.Provide(
+		ProvideConfig,
+		NewJobRunner,
+		NewJobsEndpoint,
+	),
+	fx.Invoke(func(endpoint *JobsEndpoint) {
+		endpoint.RegisterEndpoints()
+	}),
+)
@@ -0,0 +1,36 @@
+package jobs
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[JobsOptions]())
+
+// JobsOptions controls how often the progress of the job runs is persisted and the admin endpoints of the job runs.
+type JobsOptions struct {
+	// ProgressIntervalMillis is the min delay between two persisted progress reports of a run, the reports between
+	// them only change the run in memory
+	ProgressIntervalMillis int `mapstructure:"progressIntervalMillis" default:"1000"`
+	// AdminUsers authenticate the admin endpoints with the api key of a user in the `X-Api-Key` header, the
+	// endpoints are not registered without any user
+	AdminUsers []*AdminUserOptions `mapstructure:"adminUsers"`
+}
+
+type AdminUserOptions struct {
+	UserId string `mapstructure:"userId"`
+	ApiKey string `mapstructure:"apiKey" secret:"true"`
+}
+
+func (o *JobsOptions) ProgressInterval() time.Duration {
+	return time.Duration(o.ProgressIntervalMillis) * time.Millisecond
+}
+
+func ProvideConfig(environment environment.Environment) (*JobsOptions, error) {
+	return config.BindConfigKey[*JobsOptions](optionName, environment)
+}
@@ -0,0 +1,76 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+)
+
+type progressContextKey struct{}
+
+// ProgressReporter reports the progress of a running job, the total count is zero when it is not known
+type ProgressReporter interface {
+	Report(ctx context.Context, processedCount int64, totalCount int64)
+}
+
+// ReportProgress reports the progress to the job run of the context, it does nothing outside a job run, so the
+// handlers can report their progress whether they run as a job or not
+func ReportProgress(ctx context.Context, processedCount int64, totalCount int64) {
+	if reporter, ok := ctx.Value(progressContextKey{}).(ProgressReporter); ok {
+		reporter.Report(ctx, processedCount, totalCount)
+	}
+}
+
+func withProgressReporter(ctx context.Context, reporter ProgressReporter) context.Context {
+	return context.WithValue(ctx, progressContextKey{}, reporter)
+}
+
+// runProgress keeps the progress of a run and persists it at most once per interval, so a job can report its progress
+// on each processed item
+type runProgress struct {
+	mu          sync.Mutex
+	run         *JobRun
+	store       JobRunStore
+	interval    time.Duration
+	persistedAt time.Time
+	logger      logger.Logger
+}
+
+func newRunProgress(run *JobRun, store JobRunStore, interval time.Duration, logger logger.Logger) *runProgress {
+	return &runProgress{run: run, store: store, interval: interval, persistedAt: run.StartedAt, logger: logger}
+}
+
+func (p *runProgress) Report(ctx context.Context, processedCount int64, totalCount int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+
+	p.run.ProcessedCount = processedCount
+	p.run.TotalCount = totalCount
+	if totalCount > 0 {
+		p.run.Percent = min(float64(processedCount)*100/float64(totalCount), 100)
+	}
+	p.run.UpdatedAt = now
+
+	if now.Sub(p.persistedAt) < p.interval {
+		return
+	}
+
+	p.persistedAt = now
+	if err := p.store.Update(ctx, p.run); err != nil {
+		p.logger.Errorf("(jobRunner) error in persisting the progress of the job run `%s`: {%v}", p.run.Id, err)
+	}
+}
+
+// snapshot returns a copy of the run with its last reported progress
+func (p *runProgress) snapshot() *JobRun {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	copied := *p.run
+
+	return &copied
+}
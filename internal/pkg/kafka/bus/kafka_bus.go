@@ -0,0 +1,310 @@
+package bus
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/bus"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/claimcheck"
+	consumer2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/kafka/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/kafka/configurations"
+	kafkaconsumer "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/kafka/consumer"
+	consumerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/kafka/consumer/configurations"
+	kafkaproducer "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/kafka/producer"
+	producerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/kafka/producer/configurations"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"emperror.dev/errors"
+	"github.com/samber/lo"
+)
+
+type KafkaBus interface {
+	bus.Bus
+	consumerConfigurations.KafkaConsumerConnector
+	// Configuration returns the producers and the consumers configurations of the bus, including the consumers
+	// connected after creating the bus
+	Configuration() *configurations.KafkaConfiguration
+	// Close flushes the pending publishes and closes the producer, the bus should be stopped before closing
+	Close() error
+}
+
+type kafkaBus struct {
+	messageTypeConsumers    map[reflect.Type][]consumer2.Consumer
+	producer                kafkaproducer.KafkaProducer
+	kafkaConfiguration      *configurations.KafkaConfiguration
+	kafkaOptions            *config.KafkaOptions
+	logger                  logger.Logger
+	messageSerializer       serializer.MessageSerializer
+	claimCheck              claimcheck.ClaimCheck
+	isConsumedNotifications []func(message types.IMessage)
+	isProducedNotifications []func(message types.IMessage)
+}
+
+func NewKafkaBus(
+	logger logger.Logger,
+	kafkaOptions *config.KafkaOptions,
+	messageSerializer serializer.MessageSerializer,
+	claimCheck claimcheck.ClaimCheck,
+	kafkaBuilderFunc configurations.KafkaConfigurationBuilderFuc,
+) (KafkaBus, error) {
+	builder := configurations.NewKafkaConfigurationBuilder()
+	if kafkaBuilderFunc != nil {
+		kafkaBuilderFunc(builder)
+	}
+
+	kafkaBus := &kafkaBus{
+		logger:               logger,
+		kafkaOptions:         kafkaOptions,
+		messageSerializer:    messageSerializer,
+		claimCheck:           claimCheck,
+		kafkaConfiguration:   builder.Build(),
+		messageTypeConsumers: map[reflect.Type][]consumer2.Consumer{},
+	}
+
+	producersConfigurationMap := make(
+		map[string]*producerConfigurations.KafkaProducerConfiguration,
+	)
+	lo.ForEach(
+		kafkaBus.kafkaConfiguration.ProducersConfigurations,
+		func(config *producerConfigurations.KafkaProducerConfiguration, index int) {
+			key := config.ProducerMessageType.String()
+			producersConfigurationMap[key] = config
+		},
+	)
+
+	for _, consumerConfiguration := range kafkaBus.kafkaConfiguration.ConsumersConfigurations {
+		if err := kafkaBus.addConsumer(consumerConfiguration); err != nil {
+			return nil, err
+		}
+	}
+
+	kafkaProducer, err := kafkaproducer.NewKafkaProducer(
+		kafkaOptions,
+		producersConfigurationMap,
+		logger,
+		messageSerializer,
+		claimCheck,
+		// IsProduced Notification
+		func(message types.IMessage) {
+			for _, notification := range kafkaBus.isProducedNotifications {
+				if notification != nil {
+					notification(message)
+				}
+			}
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	kafkaBus.producer = kafkaProducer
+
+	return kafkaBus, nil
+}
+
+func (k *kafkaBus) addConsumer(
+	consumerConfiguration *consumerConfigurations.KafkaConsumerConfiguration,
+) error {
+	kafkaConsumer, err := kafkaconsumer.NewKafkaConsumer(
+		k.kafkaOptions,
+		consumerConfiguration,
+		k.messageSerializer,
+		k.logger,
+		k.claimCheck,
+		// IsConsumed Notification
+		func(message types.IMessage) {
+			for _, notification := range k.isConsumedNotifications {
+				if notification != nil {
+					notification(message)
+				}
+			}
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	k.messageTypeConsumers[consumerConfiguration.ConsumerMessageType] = append(
+		k.messageTypeConsumers[consumerConfiguration.ConsumerMessageType],
+		kafkaConsumer,
+	)
+
+	return nil
+}
+
+func (k *kafkaBus) IsConsumed(h func(message types.IMessage)) {
+	k.isConsumedNotifications = append(k.isConsumedNotifications, h)
+}
+
+func (k *kafkaBus) IsProduced(h func(message types.IMessage)) {
+	k.isProducedNotifications = append(k.isProducedNotifications, h)
+}
+
+// ConnectConsumer Add a new consumer to existing message type consumers. if there is no consumer, will create a new consumer for the message type
+func (k *kafkaBus) ConnectConsumer(
+	messageType types.IMessage,
+	consumer consumer2.Consumer,
+) error {
+	typeName := utils.GetMessageBaseReflectType(messageType)
+
+	k.messageTypeConsumers[typeName] = append(
+		k.messageTypeConsumers[typeName],
+		consumer,
+	)
+
+	return nil
+}
+
+// ConnectKafkaConsumer Add a new consumer to existing message type consumers. if there is no consumer, will create a new consumer for the message type
+func (k *kafkaBus) ConnectKafkaConsumer(
+	messageType types.IMessage,
+	consumerBuilderFunc consumerConfigurations.KafkaConsumerConfigurationBuilderFuc,
+) error {
+	builder := consumerConfigurations.NewKafkaConsumerConfigurationBuilder(messageType)
+	if consumerBuilderFunc != nil {
+		consumerBuilderFunc(builder)
+	}
+	consumerConfig := builder.Build()
+
+	if err := k.addConsumer(consumerConfig); err != nil {
+		return err
+	}
+
+	k.kafkaConfiguration.ConsumersConfigurations = append(
+		k.kafkaConfiguration.ConsumersConfigurations,
+		consumerConfig,
+	)
+
+	return nil
+}
+
+// ConnectConsumerHandler Add handler to existing consumer. creates new consumer if not exist
+func (k *kafkaBus) ConnectConsumerHandler(
+	messageType types.IMessage,
+	consumerHandler consumer2.ConsumerHandler,
+) error {
+	typeName := utils.GetMessageBaseReflectType(messageType)
+
+	// if there is a consumer for a message type, we should add handler to existing consumers
+	if consumersForType := k.messageTypeConsumers[typeName]; consumersForType != nil {
+		for _, c := range consumersForType {
+			c.ConnectHandler(consumerHandler)
+		}
+
+		return nil
+	}
+
+	// if there is no consumer for a message type, we should create new one and add handler to the consumer
+	return k.ConnectKafkaConsumer(
+		messageType,
+		func(builder consumerConfigurations.KafkaConsumerConfigurationBuilder) {
+			builder.WithHandlers(func(builder consumer2.ConsumerHandlerConfigurationBuilder) {
+				builder.AddHandler(consumerHandler)
+			})
+		},
+	)
+}
+
+func (k *kafkaBus) Configuration() *configurations.KafkaConfiguration {
+	return k.kafkaConfiguration
+}
+
+func (k *kafkaBus) Start(ctx context.Context) error {
+	k.logger.Infof("kafka is running on brokers: %v", k.kafkaOptions.Brokers)
+
+	for messageType, consumers := range k.messageTypeConsumers {
+		name := typeMapper.GetTypeNameByType(messageType)
+		k.logger.Info(fmt.Sprintf("consuming message type %s", name))
+		for _, kafkaConsumer := range consumers {
+			err := kafkaConsumer.Start(ctx)
+			if err != nil {
+				k.logger.Error(
+					fmt.Sprintf(
+						"error in consumer %s, with err: %v",
+						kafkaConsumer.GetName(),
+						err,
+					),
+				)
+				err2 := k.Stop()
+				if err2 != nil {
+					return errors.WrapIf(err, err2.Error())
+				}
+				return err
+			}
+			k.logger.Info(
+				fmt.Sprintf("consumer %s, started", kafkaConsumer.GetName()),
+			)
+		}
+	}
+
+	return nil
+}
+
+func (k *kafkaBus) Stop() error {
+	waitGroup := sync.WaitGroup{}
+
+	for _, consumers := range k.messageTypeConsumers {
+		for _, c := range consumers {
+			waitGroup.Add(1)
+
+			go func(c consumer2.Consumer) {
+				defer waitGroup.Done()
+
+				err := c.Stop()
+				if err != nil {
+					k.logger.Errorf("error in stopping consumer %s: %v", c.GetName(), err)
+				}
+			}(c)
+		}
+	}
+	waitGroup.Wait()
+
+	return nil
+}
+
+func (k *kafkaBus) Close() error {
+	return k.producer.Close()
+}
+
+func (k *kafkaBus) PublishMessage(
+	ctx context.Context,
+	message types.IMessage,
+	meta metadata.Metadata,
+) error {
+	return k.producer.PublishMessage(ctx, message, meta)
+}
+
+func (k *kafkaBus) PublishMessageWithTopicName(
+	ctx context.Context,
+	message types.IMessage,
+	meta metadata.Metadata,
+	topicOrExchangeName string,
+) error {
+	return k.producer.PublishMessageWithTopicName(
+		ctx,
+		message,
+		meta,
+		topicOrExchangeName,
+	)
+}
+
+func (k *kafkaBus) PublishMessageWithDelay(
+	ctx context.Context,
+	message types.IMessage,
+	meta metadata.Metadata,
+	delay time.Duration,
+) error {
+	return k.producer.PublishMessageWithDelay(ctx, message, meta, delay)
+}
+
+func (k *kafkaBus) PublishMessages(ctx context.Context, messages []types.IMessage) error {
+	return k.producer.PublishMessages(ctx, messages)
+}
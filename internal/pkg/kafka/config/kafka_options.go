@@ -0,0 +1,44 @@
+package config
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+type KafkaOptions struct {
+	// Brokers is the list of the bootstrap brokers in `host:port` form.
+	Brokers   []string `mapstructure:"brokers"`
+	ClientId  string   `mapstructure:"clientId"`
+	AutoStart bool     `mapstructure:"autoStart" default:"true"`
+	// AutoCreateTopics creates the topics of the producers and the consumers on start with `Partitions` and
+	// `ReplicationFactor`, the existing topics are not changed.
+	AutoCreateTopics  bool `mapstructure:"autoCreateTopics"  default:"true"`
+	Partitions        int  `mapstructure:"partitions"        default:"3"`
+	ReplicationFactor int  `mapstructure:"replicationFactor" default:"1"`
+	// RequiredAcks is the number of the acknowledges of a publish, `-1` waits for all the in-sync replicas.
+	RequiredAcks int `mapstructure:"requiredAcks" default:"-1"`
+	// BatchTimeout is the maximum time a publish waits for filling a batch, the publishes wait for the acknowledges.
+	BatchTimeout time.Duration `mapstructure:"batchTimeout" default:"10ms"`
+	// MinBytes and MaxBytes are the minimum and the maximum size of a fetch of the consumers.
+	MinBytes int `mapstructure:"minBytes" default:"1"`
+	MaxBytes int `mapstructure:"maxBytes" default:"10485760"`
+	// MaxWait is the maximum time a fetch of the consumers waits for `MinBytes`.
+	MaxWait time.Duration `mapstructure:"maxWait" default:"500ms"`
+	// StartFromOldest starts a new consumer group from the oldest message of the partitions instead of the newest one.
+	StartFromOldest bool `mapstructure:"startFromOldest" default:"true"`
+	// ContentType is the content type of the published messages, `application/json`, `application/x-protobuf`,
+	// `application/avro` or `application/msgpack`, the consumers deserialize the messages by their own content types.
+	ContentType string `mapstructure:"contentType" default:"application/json"`
+}
+
+func ProvideConfig(environment environment.Environment) (*KafkaOptions, error) {
+	optionName := strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[KafkaOptions]())
+	cfg, err := config.BindConfigKey[*KafkaOptions](optionName, environment)
+
+	return cfg, err
+}
@@ -0,0 +1,36 @@
+package configurations
+
+import (
+	consumerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/kafka/consumer/configurations"
+	producerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/kafka/producer/configurations"
+)
+
+type KafkaConfiguration struct {
+	ProducersConfigurations []*producerConfigurations.KafkaProducerConfiguration
+	ConsumersConfigurations []*consumerConfigurations.KafkaConsumerConfiguration
+}
+
+// Topics returns the distinct topics of the producers and the consumers, including the dead-letter topics
+func (c *KafkaConfiguration) Topics() []string {
+	var topics []string
+	seen := map[string]bool{}
+
+	add := func(topic string) {
+		if topic == "" || seen[topic] {
+			return
+		}
+		seen[topic] = true
+		topics = append(topics, topic)
+	}
+
+	for _, producer := range c.ProducersConfigurations {
+		add(producer.Topic)
+	}
+
+	for _, consumer := range c.ConsumersConfigurations {
+		add(consumer.Topic)
+		add(consumer.DeadLetterTopic)
+	}
+
+	return topics
+}
@@ -0,0 +1,82 @@
+package configurations
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	consumerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/kafka/consumer/configurations"
+	producerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/kafka/producer/configurations"
+
+	"github.com/samber/lo"
+)
+
+type KafkaConfigurationBuilder interface {
+	AddProducer(
+		producerMessageType types.IMessage,
+		producerBuilderFunc producerConfigurations.KafkaProducerConfigurationBuilderFuc,
+	) KafkaConfigurationBuilder
+	AddConsumer(
+		consumerMessageType types.IMessage,
+		consumerBuilderFunc consumerConfigurations.KafkaConsumerConfigurationBuilderFuc,
+	) KafkaConfigurationBuilder
+	Build() *KafkaConfiguration
+}
+
+type kafkaConfigurationBuilder struct {
+	kafkaConfiguration *KafkaConfiguration
+	consumerBuilders   []consumerConfigurations.KafkaConsumerConfigurationBuilder
+	producerBuilders   []producerConfigurations.KafkaProducerConfigurationBuilder
+}
+
+func NewKafkaConfigurationBuilder() KafkaConfigurationBuilder {
+	return &kafkaConfigurationBuilder{
+		kafkaConfiguration: &KafkaConfiguration{},
+	}
+}
+
+func (k *kafkaConfigurationBuilder) AddProducer(
+	producerMessageType types.IMessage,
+	producerBuilderFunc producerConfigurations.KafkaProducerConfigurationBuilderFuc,
+) KafkaConfigurationBuilder {
+	builder := producerConfigurations.NewKafkaProducerConfigurationBuilder(producerMessageType)
+	if producerBuilderFunc != nil {
+		producerBuilderFunc(builder)
+	}
+
+	k.producerBuilders = append(k.producerBuilders, builder)
+
+	return k
+}
+
+func (k *kafkaConfigurationBuilder) AddConsumer(
+	consumerMessageType types.IMessage,
+	consumerBuilderFunc consumerConfigurations.KafkaConsumerConfigurationBuilderFuc,
+) KafkaConfigurationBuilder {
+	builder := consumerConfigurations.NewKafkaConsumerConfigurationBuilder(consumerMessageType)
+	if consumerBuilderFunc != nil {
+		consumerBuilderFunc(builder)
+	}
+
+	k.consumerBuilders = append(k.consumerBuilders, builder)
+
+	return k
+}
+
+func (k *kafkaConfigurationBuilder) Build() *KafkaConfiguration {
+	consumersConfigs := lo.Map(
+		k.consumerBuilders,
+		func(builder consumerConfigurations.KafkaConsumerConfigurationBuilder, index int) *consumerConfigurations.KafkaConsumerConfiguration {
+			return builder.Build()
+		},
+	)
+
+	producersConfigs := lo.Map(
+		k.producerBuilders,
+		func(builder producerConfigurations.KafkaProducerConfigurationBuilder, index int) *producerConfigurations.KafkaProducerConfiguration {
+			return builder.Build()
+		},
+	)
+
+	k.kafkaConfiguration.ConsumersConfigurations = consumersConfigs
+	k.kafkaConfiguration.ProducersConfigurations = producersConfigs
+
+	return k.kafkaConfiguration
+}
@@ -0,0 +1,15 @@
+package configurations
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+)
+
+type KafkaConsumerConnector interface {
+	consumer.ConsumerConnector
+	// ConnectKafkaConsumer Add a new consumer to existing message type consumers. if there is no consumer, will create a new consumer for the message type
+	ConnectKafkaConsumer(
+		messageType types.IMessage,
+		consumerBuilderFunc KafkaConsumerConfigurationBuilderFuc,
+	) error
+}
@@ -0,0 +1,43 @@
+package configurations
+
+import (
+	"fmt"
+	"reflect"
+
+	consumer2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/pipeline"
+	types2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/utils"
+)
+
+type KafkaConsumerConfiguration struct {
+	Name                string
+	ConsumerMessageType reflect.Type
+	Pipelines           []pipeline.ConsumerPipeline
+	Handlers            []consumer2.ConsumerHandler
+	*consumer2.ConsumerOptions
+	Topic string
+	// GroupId is the consumer group of the consumer, the partitions of the topic are divided between the consumers
+	// of a group and each message is handled by one consumer of the group.
+	GroupId string
+	// ConcurrencyLimit is the number of the messages which are handled at once, the messages of a partition are always
+	// handled in order by the same routine.
+	ConcurrencyLimit int
+	// DeadLetterTopic receives the messages which are failed after the retries, without it a failed message is skipped.
+	DeadLetterTopic string
+}
+
+func NewDefaultKafkaConsumerConfiguration(
+	messageType types2.IMessage,
+) *KafkaConsumerConfiguration {
+	name := fmt.Sprintf("%s_consumer", utils.GetMessageName(messageType))
+
+	return &KafkaConsumerConfiguration{
+		ConsumerOptions:     &consumer2.ConsumerOptions{ExitOnError: false, ConsumerId: ""},
+		ConcurrencyLimit:    1,
+		Topic:               utils.GetTopicOrExchangeName(messageType),
+		GroupId:             utils.GetQueueName(messageType),
+		ConsumerMessageType: utils.GetMessageBaseReflectType(messageType),
+		Name:                name,
+	}
+}
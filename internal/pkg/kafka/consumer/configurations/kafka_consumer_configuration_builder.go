@@ -0,0 +1,122 @@
+package configurations
+
+import (
+	messageConsumer "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/pipeline"
+	types2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+)
+
+type KafkaConsumerConfigurationBuilder interface {
+	WithHandlers(
+		consumerBuilderFunc messageConsumer.ConsumerHandlerConfigurationBuilderFunc,
+	) KafkaConsumerConfigurationBuilder
+	WithPipelines(
+		pipelineBuilderFunc pipeline.ConsumerPipelineConfigurationBuilderFunc,
+	) KafkaConsumerConfigurationBuilder
+	WithExitOnError(exitOnError bool) KafkaConsumerConfigurationBuilder
+	WithConcurrencyLimit(limit int) KafkaConsumerConfigurationBuilder
+	WithConsumerId(consumerId string) KafkaConsumerConfigurationBuilder
+	WithTopicName(topicName string) KafkaConsumerConfigurationBuilder
+	WithGroupId(groupId string) KafkaConsumerConfigurationBuilder
+	WithDeadLetterTopic(topicName string) KafkaConsumerConfigurationBuilder
+	WithName(name string) KafkaConsumerConfigurationBuilder
+	Build() *KafkaConsumerConfiguration
+}
+
+type kafkaConsumerConfigurationBuilder struct {
+	kafkaConsumerConfigurations *KafkaConsumerConfiguration
+	pipelinesBuilder            pipeline.ConsumerPipelineConfigurationBuilder
+	handlersBuilder             messageConsumer.ConsumerHandlerConfigurationBuilder
+}
+
+func NewKafkaConsumerConfigurationBuilder(
+	messageType types2.IMessage,
+) KafkaConsumerConfigurationBuilder {
+	return &kafkaConsumerConfigurationBuilder{
+		kafkaConsumerConfigurations: NewDefaultKafkaConsumerConfiguration(messageType),
+	}
+}
+
+func (b *kafkaConsumerConfigurationBuilder) WithPipelines(
+	pipelineBuilderFunc pipeline.ConsumerPipelineConfigurationBuilderFunc,
+) KafkaConsumerConfigurationBuilder {
+	builder := pipeline.NewConsumerPipelineConfigurationBuilder()
+	if pipelineBuilderFunc != nil {
+		pipelineBuilderFunc(builder)
+	}
+	b.pipelinesBuilder = builder
+
+	return b
+}
+
+func (b *kafkaConsumerConfigurationBuilder) WithHandlers(
+	consumerBuilderFunc messageConsumer.ConsumerHandlerConfigurationBuilderFunc,
+) KafkaConsumerConfigurationBuilder {
+	builder := messageConsumer.NewConsumerHandlersConfigurationBuilder()
+	if consumerBuilderFunc != nil {
+		consumerBuilderFunc(builder)
+	}
+	b.handlersBuilder = builder
+
+	return b
+}
+
+func (b *kafkaConsumerConfigurationBuilder) WithExitOnError(
+	exitOnError bool,
+) KafkaConsumerConfigurationBuilder {
+	b.kafkaConsumerConfigurations.ExitOnError = exitOnError
+	return b
+}
+
+func (b *kafkaConsumerConfigurationBuilder) WithName(
+	name string,
+) KafkaConsumerConfigurationBuilder {
+	b.kafkaConsumerConfigurations.Name = name
+	return b
+}
+
+func (b *kafkaConsumerConfigurationBuilder) WithConcurrencyLimit(
+	limit int,
+) KafkaConsumerConfigurationBuilder {
+	b.kafkaConsumerConfigurations.ConcurrencyLimit = limit
+	return b
+}
+
+func (b *kafkaConsumerConfigurationBuilder) WithConsumerId(
+	consumerId string,
+) KafkaConsumerConfigurationBuilder {
+	b.kafkaConsumerConfigurations.ConsumerId = consumerId
+	return b
+}
+
+func (b *kafkaConsumerConfigurationBuilder) WithTopicName(
+	topicName string,
+) KafkaConsumerConfigurationBuilder {
+	b.kafkaConsumerConfigurations.Topic = topicName
+	return b
+}
+
+func (b *kafkaConsumerConfigurationBuilder) WithGroupId(
+	groupId string,
+) KafkaConsumerConfigurationBuilder {
+	b.kafkaConsumerConfigurations.GroupId = groupId
+	return b
+}
+
+func (b *kafkaConsumerConfigurationBuilder) WithDeadLetterTopic(
+	topicName string,
+) KafkaConsumerConfigurationBuilder {
+	b.kafkaConsumerConfigurations.DeadLetterTopic = topicName
+	return b
+}
+
+func (b *kafkaConsumerConfigurationBuilder) Build() *KafkaConsumerConfiguration {
+	if b.pipelinesBuilder != nil {
+		b.kafkaConsumerConfigurations.Pipelines = b.pipelinesBuilder.Build().Pipelines
+	}
+	if b.handlersBuilder != nil {
+		b.kafkaConsumerConfigurations.Handlers = b.handlersBuilder.Build().Handlers
+	}
+
+	return b.kafkaConsumerConfigurations
+}
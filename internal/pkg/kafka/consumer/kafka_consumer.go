@@ -0,0 +1,391 @@
+package consumer
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/claimcheck"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	messageHeader "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/messageheader"
+	consumertracing "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/otel/tracing/consumer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/pipeline"
+	messagingTypes "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/kafka/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/kafka/consumer/configurations"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/kafka/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	errorutils "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils/errorutils"
+
+	"emperror.dev/errors"
+	"github.com/avast/retry-go"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+const (
+	retryAttempts = 3
+	retryDelay    = 300 * time.Millisecond
+	// DeadLetterErrorHeader keeps the error of a dead-lettered message
+	DeadLetterErrorHeader = "x-exception"
+	// DeadLetterTopicHeader keeps the source topic of a dead-lettered message
+	DeadLetterTopicHeader = "x-original-topic"
+)
+
+var retryOptions = []retry.Option{
+	retry.Attempts(retryAttempts),
+	retry.Delay(retryDelay),
+	retry.DelayType(retry.BackOffDelay),
+}
+
+// kafkaConsumer consumes a topic in a consumer group, the messages of each partition are dispatched to the same
+// routine, so they are handled in order and their offsets are committed in order. a message which is failed after
+// the retries is published to the dead-letter topic of the consumer or skipped, kafka has no requeue.
+type kafkaConsumer struct {
+	kafkaConsumerOptions    *configurations.KafkaConsumerConfiguration
+	kafkaOptions            *config.KafkaOptions
+	reader                  *kafka.Reader
+	deadLetterWriter        *kafka.Writer
+	messageSerializer       serializer.MessageSerializer
+	logger                  logger.Logger
+	handlers                []consumer.ConsumerHandler
+	pipelines               []pipeline.ConsumerPipeline
+	isConsumedNotifications []func(message messagingTypes.IMessage)
+	cancel                  context.CancelFunc
+	waitGroup               sync.WaitGroup
+}
+
+// NewKafkaConsumer create a new generic Kafka consumer
+func NewKafkaConsumer(
+	kafkaOptions *config.KafkaOptions,
+	consumerConfiguration *configurations.KafkaConsumerConfiguration,
+	messageSerializer serializer.MessageSerializer,
+	logger logger.Logger,
+	claimCheck claimcheck.ClaimCheck,
+	isConsumedNotifications ...func(message messagingTypes.IMessage),
+) (consumer.Consumer, error) {
+	if consumerConfiguration == nil {
+		return nil, errors.New("consumer configuration is required")
+	}
+
+	if consumerConfiguration.ConsumerMessageType == nil {
+		return nil, errors.New(
+			"consumer ConsumerMessageType property is required",
+		)
+	}
+
+	pipelines := consumerConfiguration.Pipelines
+	if claimCheck != nil {
+		// the claim-check references are resolved before the other pipelines, so all of them receive the actual message
+		pipelines = append(
+			[]pipeline.ConsumerPipeline{claimcheck.NewClaimCheckPipeline(claimCheck, messageSerializer)},
+			pipelines...,
+		)
+	}
+
+	cons := &kafkaConsumer{
+		kafkaConsumerOptions: consumerConfiguration,
+		kafkaOptions:         kafkaOptions,
+		messageSerializer:    messageSerializer,
+		logger:               logger,
+		handlers:             consumerConfiguration.Handlers,
+		pipelines:            pipelines,
+	}
+
+	cons.isConsumedNotifications = isConsumedNotifications
+
+	return cons, nil
+}
+
+func (k *kafkaConsumer) IsConsumed(h func(message messagingTypes.IMessage)) {
+	k.isConsumedNotifications = append(k.isConsumedNotifications, h)
+}
+
+func (k *kafkaConsumer) Start(ctx context.Context) error {
+	if len(k.kafkaOptions.Brokers) == 0 {
+		return errors.New("there is no kafka broker in the options")
+	}
+
+	startOffset := kafka.LastOffset
+	if k.kafkaOptions.StartFromOldest {
+		startOffset = kafka.FirstOffset
+	}
+
+	k.reader = kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     k.kafkaOptions.Brokers,
+		GroupID:     k.kafkaConsumerOptions.GroupId,
+		Topic:       k.kafkaConsumerOptions.Topic,
+		Dialer:      types.NewDialer(k.kafkaOptions),
+		MinBytes:    k.kafkaOptions.MinBytes,
+		MaxBytes:    k.kafkaOptions.MaxBytes,
+		MaxWait:     k.kafkaOptions.MaxWait,
+		StartOffset: startOffset,
+		// the offsets are committed synchronously after handling the messages
+		CommitInterval: 0,
+	})
+
+	if k.kafkaConsumerOptions.DeadLetterTopic != "" {
+		k.deadLetterWriter = &kafka.Writer{
+			Addr:                   kafka.TCP(k.kafkaOptions.Brokers...),
+			Topic:                  k.kafkaConsumerOptions.DeadLetterTopic,
+			Balancer:               &kafka.Hash{},
+			RequiredAcks:           kafka.RequiredAcks(k.kafkaOptions.RequiredAcks),
+			AllowAutoTopicCreation: k.kafkaOptions.AutoCreateTopics,
+			Transport:              &kafka.Transport{ClientID: k.kafkaOptions.ClientId},
+		}
+	}
+
+	ctx, k.cancel = context.WithCancel(ctx)
+
+	concurrency := k.kafkaConsumerOptions.ConcurrencyLimit
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	partitions := make([]chan kafka.Message, concurrency)
+	for i := range partitions {
+		partitions[i] = make(chan kafka.Message)
+
+		k.waitGroup.Add(1)
+		go func(messages <-chan kafka.Message) {
+			defer k.waitGroup.Done()
+			defer errorutils.HandlePanic()
+
+			for msg := range messages {
+				k.handleReceived(ctx, msg)
+			}
+		}(partitions[i])
+	}
+
+	k.waitGroup.Add(1)
+	go func() {
+		defer k.waitGroup.Done()
+		defer errorutils.HandlePanic()
+		defer func() {
+			for _, messages := range partitions {
+				close(messages)
+			}
+		}()
+
+		for {
+			msg, err := k.reader.FetchMessage(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					k.logger.Info("shutting down consumer")
+					return
+				}
+
+				k.logger.Errorf("error in fetching message of the topic %s: %v", k.kafkaConsumerOptions.Topic, err)
+				continue
+			}
+
+			// the messages of a partition go to the same routine, so the order of a partition is kept
+			select {
+			case partitions[msg.Partition%concurrency] <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop waits for the messages in handling, the messages which are not committed are consumed again by the group
+func (k *kafkaConsumer) Stop() error {
+	if k.cancel != nil {
+		k.cancel()
+	}
+
+	k.waitGroup.Wait()
+
+	var err error
+	if k.reader != nil {
+		err = k.reader.Close()
+	}
+
+	if k.deadLetterWriter != nil {
+		err = errors.Combine(err, k.deadLetterWriter.Close())
+	}
+
+	return err
+}
+
+func (k *kafkaConsumer) ConnectHandler(handler consumer.ConsumerHandler) {
+	k.handlers = append(k.handlers, handler)
+}
+
+func (k *kafkaConsumer) GetName() string {
+	return k.kafkaConsumerOptions.Name
+}
+
+func (k *kafkaConsumer) handleReceived(ctx context.Context, msg kafka.Message) {
+	meta := types.HeadersToMetadata(msg.Headers)
+
+	consumerTraceOption := &consumertracing.ConsumerTracingOptions{
+		MessagingSystem: "kafka",
+		DestinationKind: "topic",
+		Destination:     msg.Topic,
+		OtherAttributes: []attribute.KeyValue{
+			semconv.MessagingKafkaConsumerGroup(k.kafkaConsumerOptions.GroupId),
+			semconv.MessagingKafkaDestinationPartition(msg.Partition),
+			semconv.MessagingKafkaMessageOffset(int(msg.Offset)),
+			semconv.MessagingKafkaMessageKey(string(msg.Key)),
+		},
+	}
+	ctx, beforeConsumeSpan := consumertracing.StartConsumerSpan(
+		ctx,
+		&meta,
+		string(msg.Value),
+		consumerTraceOption,
+	)
+
+	message, err := k.deserializeMessage(
+		msg,
+		messageHeader.GetMessageType(meta),
+		meta.GetString(messageHeader.ContentType),
+	)
+	if err != nil {
+		k.logger.Error(consumertracing.FinishConsumerSpan(beforeConsumeSpan, err))
+		k.deadLetter(ctx, msg, err)
+		k.commit(ctx, msg)
+
+		return
+	}
+
+	consumeContext := messagingTypes.NewMessageConsumeContext(
+		message,
+		meta,
+		meta.GetString(messageHeader.ContentType),
+		messageHeader.GetMessageType(meta),
+		messageHeader.GetMessageCreated(meta),
+		uint64(msg.Offset),
+		messageHeader.GetMessageId(meta),
+		messageHeader.GetCorrelationId(meta),
+	)
+
+	for _, handler := range k.handlers {
+		err = k.runHandlersWithRetry(ctx, handler, consumeContext)
+		if err != nil {
+			break
+		}
+	}
+
+	if err != nil {
+		k.logger.Errorw(
+			"[kafkaConsumer.handleReceived] error in handling consume message of Kafka",
+			logger.Fields{"message_id": consumeContext.MessageId(), "error": err.Error()},
+		)
+		_ = consumertracing.FinishConsumerSpan(beforeConsumeSpan, err)
+		k.deadLetter(ctx, msg, err)
+	} else {
+		_ = consumertracing.FinishConsumerSpan(beforeConsumeSpan, nil)
+
+		for _, notification := range k.isConsumedNotifications {
+			if notification != nil {
+				notification(consumeContext.Message())
+			}
+		}
+	}
+
+	k.commit(ctx, msg)
+}
+
+func (k *kafkaConsumer) commit(ctx context.Context, msg kafka.Message) {
+	if err := k.reader.CommitMessages(ctx, msg); err != nil {
+		k.logger.Errorf(
+			"error in committing offset %d of partition %d of the topic %s: %v",
+			msg.Offset,
+			msg.Partition,
+			msg.Topic,
+			err,
+		)
+	}
+}
+
+// deadLetter publishes a failed message with its error to the dead-letter topic of the consumer, the message keeps
+// its key, so the dead-letter topic keeps the order of the messages of a key
+func (k *kafkaConsumer) deadLetter(ctx context.Context, msg kafka.Message, cause error) {
+	if k.deadLetterWriter == nil {
+		return
+	}
+
+	headers := append(
+		append([]kafka.Header{}, msg.Headers...),
+		kafka.Header{Key: DeadLetterErrorHeader, Value: []byte(cause.Error())},
+		kafka.Header{Key: DeadLetterTopicHeader, Value: []byte(msg.Topic)},
+	)
+
+	err := k.deadLetterWriter.WriteMessages(ctx, kafka.Message{
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: headers,
+		Time:    msg.Time,
+	})
+	if err != nil {
+		k.logger.Errorf(
+			"error in publishing message with offset %d of the topic %s to the dead-letter topic: %v",
+			msg.Offset,
+			msg.Topic,
+			err,
+		)
+	}
+}
+
+func (k *kafkaConsumer) runHandlersWithRetry(
+	ctx context.Context,
+	handler consumer.ConsumerHandler,
+	messageConsumeContext messagingTypes.MessageConsumeContext,
+) error {
+	// the pipelines run for each handler, so they can keep the state of the message per handler, like the inbox
+	ctx = consumer.WithHandler(ctx, handler)
+
+	return retry.Do(func() error {
+		var next pipeline.ConsumerHandlerFunc = func(ctx context.Context) error {
+			return handler.Handle(ctx, messageConsumeContext)
+		}
+
+		// the first pipeline is the outermost one
+		for i := len(k.pipelines) - 1; i >= 0; i-- {
+			pipe := k.pipelines[i]
+			nextHandler := next
+
+			next = func(ctx context.Context) error {
+				return pipe.Handle(ctx, messageConsumeContext, nextHandler)
+			}
+		}
+
+		return next(ctx)
+	}, append(retryOptions, retry.Context(ctx))...)
+}
+
+// deserializeMessage deserializes the message to the consumer message type, each topic has one message type
+func (k *kafkaConsumer) deserializeMessage(
+	msg kafka.Message,
+	eventType string,
+	contentType string,
+) (messagingTypes.IMessage, error) {
+	if len(msg.Value) == 0 {
+		return nil, errors.New("message body is nil or empty in the consumer")
+	}
+
+	messageType := k.kafkaConsumerOptions.ConsumerMessageType
+	if messageType.Kind() == reflect.Pointer {
+		messageType = messageType.Elem()
+	}
+
+	messagePointer := reflect.New(messageType).Interface()
+	if err := k.messageSerializer.DeserializeInto(msg.Value, messagePointer, contentType); err != nil {
+		return nil, errors.WrapIff(err, "error in deserializing of type '%s' in the consumer", eventType)
+	}
+
+	message, ok := messagePointer.(messagingTypes.IMessage)
+	if !ok {
+		return nil, errors.Errorf("type '%s' doesn't implement IMessage", messageType)
+	}
+
+	return message, nil
+}
@@ -0,0 +1,32 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/health/contracts"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/kafka/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/kafka/types"
+
+	"emperror.dev/errors"
+)
+
+type kafkaHealthChecker struct {
+	kafkaOptions *config.KafkaOptions
+}
+
+func NewKafkaHealthChecker(kafkaOptions *config.KafkaOptions) contracts.Health {
+	return &kafkaHealthChecker{kafkaOptions: kafkaOptions}
+}
+
+func (k *kafkaHealthChecker) CheckHealth(ctx context.Context) error {
+	conn, err := types.Dial(ctx, k.kafkaOptions)
+	if err != nil {
+		return errors.WrapIf(err, "kafka is not available")
+	}
+
+	return conn.Close()
+}
+
+func (k *kafkaHealthChecker) GetHealthName() string {
+	return "kafka"
+}
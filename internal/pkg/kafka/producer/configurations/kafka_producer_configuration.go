@@ -0,0 +1,22 @@
+package configurations
+
+import (
+	"reflect"
+
+	types2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/utils"
+)
+
+type KafkaProducerConfiguration struct {
+	ProducerMessageType reflect.Type
+	Topic               string
+}
+
+func NewDefaultKafkaProducerConfiguration(
+	messageType types2.IMessage,
+) *KafkaProducerConfiguration {
+	return &KafkaProducerConfiguration{
+		Topic:               utils.GetTopicOrExchangeName(messageType),
+		ProducerMessageType: utils.GetMessageBaseReflectType(messageType),
+	}
+}
@@ -0,0 +1,33 @@
+package configurations
+
+import (
+	types2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+)
+
+type KafkaProducerConfigurationBuilder interface {
+	WithTopicName(topicName string) KafkaProducerConfigurationBuilder
+	Build() *KafkaProducerConfiguration
+}
+
+type kafkaProducerConfigurationBuilder struct {
+	kafkaProducerOptions *KafkaProducerConfiguration
+}
+
+func NewKafkaProducerConfigurationBuilder(
+	messageType types2.IMessage,
+) KafkaProducerConfigurationBuilder {
+	return &kafkaProducerConfigurationBuilder{
+		kafkaProducerOptions: NewDefaultKafkaProducerConfiguration(messageType),
+	}
+}
+
+func (b *kafkaProducerConfigurationBuilder) WithTopicName(
+	topicName string,
+) KafkaProducerConfigurationBuilder {
+	b.kafkaProducerOptions.Topic = topicName
+	return b
+}
+
+func (b *kafkaProducerConfigurationBuilder) Build() *KafkaProducerConfiguration {
+	return b.kafkaProducerOptions
+}
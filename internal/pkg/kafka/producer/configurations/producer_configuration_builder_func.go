@@ -0,0 +1,3 @@
+package configurations
+
+type KafkaProducerConfigurationBuilderFuc func(builder KafkaProducerConfigurationBuilder)
@@ -0,0 +1,242 @@
+package producer
+
+import (
+	"context"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/claimcheck"
+	messageHeader "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/messageheader"
+	producer3 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/otel/tracing/producer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/producer"
+	types2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/kafka/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/kafka/producer/configurations"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/kafka/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// PartitionKeyer is implemented by the messages which should keep their order with the other messages of the same key,
+// like the events of an aggregate. the messages of a key are published to the same partition, the messages without a
+// partition key are keyed by their message id.
+type PartitionKeyer interface {
+	PartitionKey() string
+}
+
+type KafkaProducer interface {
+	producer.Producer
+	// Close flushes the pending messages and closes the connections of the producer
+	Close() error
+}
+
+type kafkaProducer struct {
+	logger                  logger.Logger
+	kafkaOptions            *config.KafkaOptions
+	writer                  *kafka.Writer
+	messageSerializer       serializer.MessageSerializer
+	producersConfigurations map[string]*configurations.KafkaProducerConfiguration
+	claimCheck              claimcheck.ClaimCheck
+	isProducedNotifications []func(message types2.IMessage)
+}
+
+func NewKafkaProducer(
+	cfg *config.KafkaOptions,
+	kafkaProducersConfiguration map[string]*configurations.KafkaProducerConfiguration,
+	logger logger.Logger,
+	eventSerializer serializer.MessageSerializer,
+	claimCheck claimcheck.ClaimCheck,
+	isProducedNotifications ...func(message types2.IMessage),
+) (KafkaProducer, error) {
+	writer := &kafka.Writer{
+		Addr: kafka.TCP(cfg.Brokers...),
+		// the hash balancer publishes the messages of a key to the same partition, so they are consumed in order
+		Balancer:               &kafka.Hash{},
+		RequiredAcks:           kafka.RequiredAcks(cfg.RequiredAcks),
+		BatchTimeout:           cfg.BatchTimeout,
+		AllowAutoTopicCreation: cfg.AutoCreateTopics,
+		Transport:              &kafka.Transport{ClientID: cfg.ClientId},
+	}
+
+	p := &kafkaProducer{
+		claimCheck:              claimCheck,
+		logger:                  logger,
+		kafkaOptions:            cfg,
+		writer:                  writer,
+		messageSerializer:       eventSerializer,
+		producersConfigurations: kafkaProducersConfiguration,
+	}
+
+	p.isProducedNotifications = isProducedNotifications
+
+	return p, nil
+}
+
+func (k *kafkaProducer) IsProduced(h func(message types2.IMessage)) {
+	k.isProducedNotifications = append(k.isProducedNotifications, h)
+}
+
+func (k *kafkaProducer) PublishMessage(
+	ctx context.Context,
+	message types2.IMessage,
+	meta metadata.Metadata,
+) error {
+	return k.PublishMessageWithTopicName(ctx, message, meta, "")
+}
+
+// PublishMessageWithDelay publishes the messages without a delay, kafka has no delayed delivery, so a delayed message is
+// rejected instead of being delivered early
+func (k *kafkaProducer) PublishMessageWithDelay(
+	ctx context.Context,
+	message types2.IMessage,
+	meta metadata.Metadata,
+	delay time.Duration,
+) error {
+	if delay > 0 {
+		return errors.WithMessagef(
+			producer.ErrDelayedDeliveryNotSupported,
+			"can't publish message with id: {%s} with delay",
+			message.GeMessageId(),
+		)
+	}
+
+	return k.PublishMessage(ctx, message, meta)
+}
+
+// PublishMessages publishes the messages one by one, the kafka writer batches the writes of its partitions by itself
+func (k *kafkaProducer) PublishMessages(ctx context.Context, messages []types2.IMessage) error {
+	for _, message := range messages {
+		if err := k.PublishMessage(ctx, message, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (k *kafkaProducer) getProducerConfigurationByMessage(
+	message types2.IMessage,
+) *configurations.KafkaProducerConfiguration {
+	messageType := utils.GetMessageBaseReflectType(message)
+	return k.producersConfigurations[messageType.String()]
+}
+
+func (k *kafkaProducer) PublishMessageWithTopicName(
+	ctx context.Context,
+	message types2.IMessage,
+	meta metadata.Metadata,
+	topicOrExchangeName string,
+) error {
+	producerConfiguration := k.getProducerConfigurationByMessage(message)
+
+	if producerConfiguration == nil {
+		producerConfiguration = configurations.NewDefaultKafkaProducerConfiguration(message)
+	}
+
+	topic := producerConfiguration.Topic
+	if topicOrExchangeName != "" {
+		topic = topicOrExchangeName
+	}
+
+	meta = k.getMetadata(message, meta)
+	key := partitionKey(message)
+
+	producerOptions := &producer3.ProducerTracingOptions{
+		MessagingSystem: "kafka",
+		DestinationKind: "topic",
+		Destination:     topic,
+		OtherAttributes: []attribute.KeyValue{
+			semconv.MessagingKafkaMessageKey(key),
+		},
+	}
+
+	serializedObj, err := k.messageSerializer.Serialize(message)
+	if err != nil {
+		return err
+	}
+
+	body := serializedObj.Data
+
+	ctx, beforeProduceSpan := producer3.StartProducerSpan(
+		ctx,
+		message,
+		&meta,
+		string(body),
+		producerOptions,
+	)
+
+	// the large payloads are offloaded to the blob store and a claim-check reference is published instead
+	if k.claimCheck != nil {
+		body, err = k.claimCheck.Offload(ctx, body, meta)
+		if err != nil {
+			return producer3.FinishProducerSpan(beforeProduceSpan, err)
+		}
+	}
+
+	err = k.writer.WriteMessages(ctx, kafka.Message{
+		Topic:   topic,
+		Key:     []byte(key),
+		Value:   body,
+		Headers: types.MetadataToHeaders(meta),
+		Time:    time.Now(),
+	})
+	if err != nil {
+		return producer3.FinishProducerSpan(beforeProduceSpan, err)
+	}
+
+	if len(k.isProducedNotifications) > 0 {
+		for _, notification := range k.isProducedNotifications {
+			if notification != nil {
+				notification(message)
+			}
+		}
+	}
+
+	return producer3.FinishProducerSpan(beforeProduceSpan, nil)
+}
+
+func (k *kafkaProducer) Close() error {
+	return k.writer.Close()
+}
+
+func (k *kafkaProducer) getMetadata(
+	message types2.IMessage,
+	meta metadata.Metadata,
+) metadata.Metadata {
+	meta = metadata.FromMetadata(meta)
+
+	// just message type name not full type name because in other side package name for type could be different
+	messageHeader.SetMessageType(meta, message.GetMessageTypeName())
+	meta.Set(messageHeader.ContentType, k.messageSerializer.ContentType())
+
+	if messageHeader.GetMessageId(meta) == "" {
+		messageHeader.SetMessageId(meta, message.GeMessageId())
+	}
+
+	if messageHeader.GetMessageCreated(meta) == *new(time.Time) {
+		messageHeader.SetMessageCreated(meta, message.GetCreated())
+	}
+
+	if messageHeader.GetCorrelationId(meta) == "" {
+		cid := uuid.NewV4().String()
+		messageHeader.SetCorrelationId(meta, cid)
+	}
+	messageHeader.SetMessageName(meta, utils.GetMessageName(message))
+
+	return meta
+}
+
+func partitionKey(message types2.IMessage) string {
+	if keyer, ok := message.(PartitionKeyer); ok && keyer.PartitionKey() != "" {
+		return keyer.PartitionKey()
+	}
+
+	return message.GeMessageId()
+}
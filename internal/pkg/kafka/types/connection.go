@@ -0,0 +1,83 @@
+package types
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/kafka/config"
+
+	"emperror.dev/errors"
+	"github.com/segmentio/kafka-go"
+)
+
+const dialTimeout = 10 * time.Second
+
+func NewDialer(kafkaOptions *config.KafkaOptions) *kafka.Dialer {
+	return &kafka.Dialer{
+		ClientID:  kafkaOptions.ClientId,
+		Timeout:   dialTimeout,
+		DualStack: true,
+	}
+}
+
+// Dial connects to the first available broker of the bootstrap brokers
+func Dial(ctx context.Context, kafkaOptions *config.KafkaOptions) (*kafka.Conn, error) {
+	if len(kafkaOptions.Brokers) == 0 {
+		return nil, errors.New("there is no kafka broker in the options")
+	}
+
+	dialer := NewDialer(kafkaOptions)
+
+	var err error
+	for _, broker := range kafkaOptions.Brokers {
+		var conn *kafka.Conn
+
+		conn, err = dialer.DialContext(ctx, "tcp", broker)
+		if err == nil {
+			return conn, nil
+		}
+	}
+
+	return nil, errors.WrapIf(err, "error in connecting to the kafka brokers")
+}
+
+// EnsureTopics creates the topics which don't exist on the controller broker, the existing topics are not changed
+func EnsureTopics(ctx context.Context, kafkaOptions *config.KafkaOptions, topics ...string) error {
+	if len(topics) == 0 {
+		return nil
+	}
+
+	conn, err := Dial(ctx, kafkaOptions)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	controller, err := conn.Controller()
+	if err != nil {
+		return errors.WrapIf(err, "error in getting the kafka controller")
+	}
+
+	controllerConn, err := NewDialer(kafkaOptions).DialContext(
+		ctx,
+		"tcp",
+		net.JoinHostPort(controller.Host, strconv.Itoa(controller.Port)),
+	)
+	if err != nil {
+		return errors.WrapIf(err, "error in connecting to the kafka controller")
+	}
+	defer controllerConn.Close()
+
+	topicConfigs := make([]kafka.TopicConfig, 0, len(topics))
+	for _, topic := range topics {
+		topicConfigs = append(topicConfigs, kafka.TopicConfig{
+			Topic:             topic,
+			NumPartitions:     kafkaOptions.Partitions,
+			ReplicationFactor: kafkaOptions.ReplicationFactor,
+		})
+	}
+
+	return errors.WrapIf(controllerConn.CreateTopics(topicConfigs...), "error in creating the kafka topics")
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	messageHeader "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/messageheader"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// MetadataToHeaders converts the metadata of a message to the kafka headers, the kafka header values are bytes, so the
+// time values are kept in RFC3339 format and the other non-string values in their default format
+func MetadataToHeaders(meta metadata.Metadata) []kafka.Header {
+	headers := make([]kafka.Header, 0, len(meta))
+
+	for key, value := range meta {
+		var headerValue string
+
+		switch v := value.(type) {
+		case string:
+			headerValue = v
+		case []byte:
+			headerValue = string(v)
+		case time.Time:
+			headerValue = v.Format(time.RFC3339Nano)
+		case nil:
+			continue
+		default:
+			headerValue = fmt.Sprint(v)
+		}
+
+		headers = append(headers, kafka.Header{Key: key, Value: []byte(headerValue)})
+	}
+
+	return headers
+}
+
+// HeadersToMetadata converts the kafka headers of a message to the metadata, the `created` header is parsed to time
+// like the other brokers
+func HeadersToMetadata(headers []kafka.Header) metadata.Metadata {
+	meta := metadata.Metadata{}
+
+	for _, header := range headers {
+		meta.Set(header.Key, string(header.Value))
+	}
+
+	if created := meta.GetString(messageHeader.Created); created != "" {
+		if t, err := time.Parse(time.RFC3339Nano, created); err == nil {
+			messageHeader.SetMessageCreated(meta, t)
+		}
+	}
+
+	return meta
+}
@@ -0,0 +1,30 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	messageHeader "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/messageheader"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Headers_Round_Trip_Keeps_Metadata(t *testing.T) {
+	created := time.Date(2024, 3, 1, 10, 30, 0, 123, time.UTC)
+
+	meta := metadata.Metadata{}
+	messageHeader.SetMessageId(meta, "message-1")
+	messageHeader.SetCorrelationId(meta, "correlation-1")
+	messageHeader.SetMessageCreated(meta, created)
+	meta.Set("retry", 2)
+	meta.Set("empty", nil)
+
+	result := HeadersToMetadata(MetadataToHeaders(meta))
+
+	assert.Equal(t, "message-1", messageHeader.GetMessageId(result))
+	assert.Equal(t, "correlation-1", messageHeader.GetCorrelationId(result))
+	assert.True(t, created.Equal(messageHeader.GetMessageCreated(result)))
+	assert.Equal(t, "2", result.GetString("retry"))
+	assert.False(t, result.ExistsKey("empty"))
+}
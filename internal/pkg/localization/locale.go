@@ -0,0 +1,87 @@
+package localization
+
+import (
+	"strings"
+
+	"emperror.dev/errors"
+	"golang.org/x/text/language"
+)
+
+const (
+	// AcceptLanguageHeader is the http header that clients use for requesting localized content
+	AcceptLanguageHeader = "Accept-Language"
+	// ContentLanguageHeader is the http header that describes the locale of the returned content
+	ContentLanguageHeader = "Content-Language"
+)
+
+// NormalizeLocale validates a BCP 47 locale and returns its canonical form, for example `en_us` becomes `en-US`
+func NormalizeLocale(locale string) (string, error) {
+	tag, err := language.Parse(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+	if err != nil {
+		return "", errors.WrapIf(err, "invalid locale")
+	}
+
+	return tag.String(), nil
+}
+
+// ParseAcceptLanguage returns requested locales of an `Accept-Language` header ordered by their quality
+func ParseAcceptLanguage(header string) []string {
+	if strings.TrimSpace(header) == "" {
+		return nil
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return nil
+	}
+
+	locales := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		locales = append(locales, tag.String())
+	}
+
+	return locales
+}
+
+// WithFallbacks expands requested locales with their parent languages, `fr-CA` will also fall back to `fr`
+func WithFallbacks(locales []string) []string {
+	seen := make(map[string]bool)
+	result := make([]string, 0, len(locales)*2)
+
+	add := func(locale string) {
+		if locale != "" && !seen[locale] {
+			seen[locale] = true
+			result = append(result, locale)
+		}
+	}
+
+	for _, locale := range locales {
+		add(locale)
+
+		tag, err := language.Parse(locale)
+		if err != nil {
+			continue
+		}
+		base, confidence := tag.Base()
+		if confidence != language.No {
+			add(base.String())
+		}
+	}
+
+	return result
+}
+
+// Resolve returns the best matching translation for the requested locales, respecting language fallbacks
+func Resolve[T any](translations map[string]T, locales []string) (T, string, bool) {
+	if len(translations) == 0 {
+		return *new(T), "", false
+	}
+
+	for _, locale := range WithFallbacks(locales) {
+		if translation, ok := translations[locale]; ok {
+			return translation, locale, true
+		}
+	}
+
+	return *new(T), "", false
+}
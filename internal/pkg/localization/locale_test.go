@@ -0,0 +1,35 @@
+package localization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Normalize_Locale(t *testing.T) {
+	locale, err := NormalizeLocale("en_us")
+	assert.NoError(t, err)
+	assert.Equal(t, "en-US", locale)
+
+	_, err = NormalizeLocale("not a locale")
+	assert.Error(t, err)
+}
+
+func Test_Parse_Accept_Language(t *testing.T) {
+	locales := ParseAcceptLanguage("fr-CA;q=0.8, de, en;q=0.5")
+
+	assert.Equal(t, []string{"de", "fr-CA", "en"}, locales)
+	assert.Empty(t, ParseAcceptLanguage(""))
+}
+
+func Test_Resolve_Should_Fall_Back_To_Base_Language(t *testing.T) {
+	translations := map[string]string{"fr": "Bonjour", "de": "Hallo"}
+
+	translation, locale, ok := Resolve(translations, []string{"fr-CA", "de"})
+	assert.True(t, ok)
+	assert.Equal(t, "fr", locale)
+	assert.Equal(t, "Bonjour", translation)
+
+	_, _, ok = Resolve(translations, []string{"es"})
+	assert.False(t, ok)
+}
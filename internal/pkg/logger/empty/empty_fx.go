@@ -19,4 +19,5 @@ var Module = fx.Module("emptyfx",
 			fx.As(new(logger2.Logger)),
 		),
 		config.ProvideLogConfig,
+		logger2.NewLogLevels,
 	))
@@ -0,0 +1,118 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/config"
+
+	"emperror.dev/errors"
+)
+
+// the log levels by their severity
+var levelSeverities = map[string]int{ //nolint:gochecknoglobals
+	"debug": 0,
+	"info":  1,
+	"warn":  2,
+	"error": 3,
+	"panic": 4,
+	"fatal": 5,
+}
+
+// LogLevels keeps the log levels of the log categories, they can be changed at runtime, e.g. with the diagnostics
+// endpoints, so a verbose category like the grpc payloads can be turned on without a restart. a category without its
+// own level uses the default level of the log options.
+type LogLevels struct {
+	mu           sync.RWMutex
+	defaultLevel string
+	levels       map[string]string
+}
+
+func NewLogLevels(cfg *config.LogOptions) *LogLevels {
+	defaultLevel := "debug"
+	if cfg != nil {
+		if _, ok := levelSeverities[strings.ToLower(cfg.LogLevel)]; ok {
+			defaultLevel = strings.ToLower(cfg.LogLevel)
+		}
+	}
+
+	return &LogLevels{defaultLevel: defaultLevel, levels: make(map[string]string)}
+}
+
+// Level returns the level of the category
+func (l *LogLevels) Level(category string) string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if level, ok := l.levels[category]; ok {
+		return level
+	}
+
+	return l.defaultLevel
+}
+
+// SetLevel changes the level of the category, the level is one of `debug`, `info`, `warn`, `error`, `panic` and `fatal`
+func (l *LogLevels) SetLevel(category string, level string) error {
+	level = strings.ToLower(level)
+	if _, ok := levelSeverities[level]; !ok {
+		return errors.New(fmt.Sprintf("unknown log level '%s'", level))
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.levels[category] = level
+
+	return nil
+}
+
+// InitLevel sets the initial level of a category when it doesn't have its own level yet, so the runtime changes of the
+// level are kept
+func (l *LogLevels) InitLevel(category string, level string) error {
+	l.mu.RLock()
+	_, ok := l.levels[category]
+	l.mu.RUnlock()
+
+	if ok {
+		return nil
+	}
+
+	return l.SetLevel(category, level)
+}
+
+// ResetLevel removes the level of the category, so it uses the default level again
+func (l *LogLevels) ResetLevel(category string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.levels, category)
+}
+
+// Enabled reports whether the logs of the category with the level are written
+func (l *LogLevels) Enabled(category string, level string) bool {
+	severity, ok := levelSeverities[strings.ToLower(level)]
+	if !ok {
+		return false
+	}
+
+	return severity >= levelSeverities[l.Level(category)]
+}
+
+// Levels returns the levels of the categories with their own level
+func (l *LogLevels) Levels() map[string]string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	levels := make(map[string]string, len(l.levels))
+	for category, level := range l.levels {
+		levels[category] = level
+	}
+
+	return levels
+}
+
+// DefaultLevel returns the level of the categories without their own level
+func (l *LogLevels) DefaultLevel() string {
+	return l.defaultLevel
+}
@@ -0,0 +1,38 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Log_Levels(t *testing.T) {
+	levels := NewLogLevels(&config.LogOptions{LogLevel: "info"})
+
+	assert.Equal(t, "info", levels.Level("grpcPayloads"))
+	assert.False(t, levels.Enabled("grpcPayloads", "debug"))
+	assert.True(t, levels.Enabled("grpcPayloads", "warn"))
+
+	require.NoError(t, levels.SetLevel("grpcPayloads", "DEBUG"))
+	assert.True(t, levels.Enabled("grpcPayloads", "debug"))
+	assert.Equal(t, map[string]string{"grpcPayloads": "debug"}, levels.Levels())
+
+	// the initial level doesn't override a runtime change
+	require.NoError(t, levels.InitLevel("grpcPayloads", "error"))
+	assert.Equal(t, "debug", levels.Level("grpcPayloads"))
+
+	levels.ResetLevel("grpcPayloads")
+	assert.Equal(t, "info", levels.Level("grpcPayloads"))
+
+	assert.Error(t, levels.SetLevel("grpcPayloads", "verbose"))
+}
+
+func Test_Log_Levels_Without_Options(t *testing.T) {
+	levels := NewLogLevels(nil)
+
+	assert.Equal(t, "debug", levels.DefaultLevel())
+	assert.True(t, levels.Enabled("grpcPayloads", "debug"))
+}
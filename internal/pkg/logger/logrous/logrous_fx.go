@@ -19,12 +19,13 @@ var Module = fx.Module("logrousfx",
 			fx.As(new(logger.Logger)),
 		),
 		config.ProvideLogConfig,
+		logger.NewLogLevels,
 	))
 
 var ModuleFunc = func(l logger.Logger) fx.Option {
 	return fx.Module("logrousfx",
 
-		fx.Provide(config.ProvideLogConfig),
+		fx.Provide(config.ProvideLogConfig, logger.NewLogLevels),
 		fx.Supply(fx.Annotate(l, fx.As(new(logger.Logger)))),
 	)
 }
@@ -56,7 +56,7 @@ func (l *logrusLogger) initLogger(env environment.Environment) {
 	// Can be any io.Writer, see below for File example
 	logrusLogger.SetOutput(os.Stdout)
 
-	if env.IsDevelopment() {
+	if env.Base().IsDevelopment() {
 		logrusLogger.SetReportCaller(false)
 		logrusLogger.SetFormatter(&logrus.TextFormatter{
 			DisableColors: false,
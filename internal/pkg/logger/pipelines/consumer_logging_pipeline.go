@@ -0,0 +1,61 @@
+package loggingpipelines
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/pipeline"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+)
+
+type consumerLoggerPipeline struct {
+	logger logger.Logger
+}
+
+// NewConsumerLoggingPipeline logs the handling of the messages by the consumer handlers, like the logging pipeline of
+// the mediator for the requests
+func NewConsumerLoggingPipeline(l logger.Logger) pipeline.ConsumerPipeline {
+	return &consumerLoggerPipeline{logger: l}
+}
+
+func (c *consumerLoggerPipeline) Handle(
+	ctx context.Context,
+	consumerContext types.MessageConsumeContext,
+	next pipeline.ConsumerHandlerFunc,
+) error {
+	handlerName, _ := consumer.GetHandlerName(ctx)
+	fields := logger.Fields{
+		"MessageId":     consumerContext.MessageId(),
+		"CorrelationId": consumerContext.CorrelationId(),
+		"Handler":       handlerName,
+	}
+
+	c.logger.Infow(fmt.Sprintf("Handling message: '%s'", consumerContext.MessageType()), fields)
+
+	startTime := time.Now()
+
+	err := next(ctx)
+	if err != nil {
+		c.logger.Infow(
+			fmt.Sprintf(
+				"Message '%s' failed after %s with error: %v",
+				consumerContext.MessageType(),
+				time.Since(startTime),
+				err,
+			),
+			fields,
+		)
+
+		return err
+	}
+
+	c.logger.Infow(
+		fmt.Sprintf("Message '%s' handled successfully in %s", consumerContext.MessageType(), time.Since(startTime)),
+		fields,
+	)
+
+	return nil
+}
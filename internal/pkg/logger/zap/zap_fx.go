@@ -16,6 +16,7 @@ var Module = fx.Module("zapfx",
 	// - execute its func only if it requested
 	fx.Provide(
 		config.ProvideLogConfig,
+		logger.NewLogLevels,
 		NewZapLogger,
 		fx.Annotate(
 			NewZapLogger,
@@ -27,7 +28,7 @@ var ModuleFunc = func(l logger.Logger) fx.Option {
 	return fx.Module(
 		"zapfx",
 
-		fx.Provide(config.ProvideLogConfig),
+		fx.Provide(config.ProvideLogConfig, logger.NewLogLevels),
 		fx.Supply(fx.Annotate(l, fx.As(new(logger.Logger)))),
 		fx.Supply(fx.Annotate(l, fx.As(new(ZapLogger)))),
 	)
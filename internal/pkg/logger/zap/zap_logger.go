@@ -73,7 +73,7 @@ func (l *zapLogger) initLogger(env environment.Environment) {
 	var encoderCfg zapcore.EncoderConfig
 	var encoder zapcore.Encoder
 
-	if env.IsProduction() {
+	if env.Base().IsProduction() {
 		encoderCfg = zap.NewProductionEncoderConfig()
 		encoderCfg.NameKey = "[SERVICE]"
 		encoderCfg.TimeKey = "[TIME]"
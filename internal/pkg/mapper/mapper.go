@@ -67,6 +67,7 @@ func Configure(config *MapperConfig) {
 func ClearMappings() {
 	profiles = map[string][][2]string{}
 	maps = map[mappingsEntry]interface{}{}
+	clearStructMappings()
 }
 
 func CreateMap[TSrc any, TDst any]() error {
@@ -331,7 +332,7 @@ func getTypeMethods(val reflect.Type) []string {
 func mapStructs[TDes any, TSrc any](src reflect.Value, dest reflect.Value) {
 	// get values types
 	// if types or their slices were not registered - abort
-	profile, ok := profiles[getProfileKey(src.Type(), dest.Type())]
+	mapping, ok := getStructMapping(src.Type(), dest.Type())
 	if !ok {
 		defaultLogger.GetLogger().Errorf(
 			"no conversion specified for types %s and %s",
@@ -341,13 +342,18 @@ func mapStructs[TDes any, TSrc any](src reflect.Value, dest reflect.Value) {
 		return
 	}
 
-	// iterate over struct fields and map values
-	for _, keys := range profile {
-		destinationField := dest.FieldByName(keys[DestKeyIndex])
-		sourceField := src.FieldByName(keys[SrcKeyIndex])
+	// iterate over resolved struct fields and map values
+	for _, field := range mapping.fields {
+		// there is no destination field for the profile key
+		if field.destIndex == nil {
+			continue
+		}
+
+		destinationField := dest.FieldByIndex(field.destIndex)
 		var sourceFiledValue reflect.Value
 
-		if sourceField.Kind() != reflect.Invalid {
+		if field.srcIndex != nil {
+			sourceField := src.FieldByIndex(field.srcIndex)
 			// var destinationFieldValue reflect.Value
 			if !sourceField.CanInterface() {
 				if mapperConfig.MapUnexportedFields {
@@ -356,7 +362,7 @@ func mapStructs[TDes any, TSrc any](src reflect.Value, dest reflect.Value) {
 					)
 				} else {
 					// for getting pointer for non-pointer struct we can use reflect.Addr() for calling pointer receivers properties
-					sourceFiledValue = reflectionHelper.GetFieldValueFromMethodAndReflectValue(src.Addr(), strcase.ToCamel(keys[SrcKeyIndex]))
+					sourceFiledValue = reflectionHelper.GetFieldValueFromMethodAndReflectValue(src.Addr(), field.srcMethod)
 				}
 			} else {
 				if mapperConfig.MapUnexportedFields {
@@ -367,7 +373,7 @@ func mapStructs[TDes any, TSrc any](src reflect.Value, dest reflect.Value) {
 			}
 		} else {
 			// there is no field corresponding to destination filed, so we search on source methods (properties) for getting src field value for example `Id()` property
-			sourceFiledValue = reflectionHelper.GetFieldValueFromMethodAndReflectValue(src.Addr(), strcase.ToCamel(keys[SrcKeyIndex]))
+			sourceFiledValue = reflectionHelper.GetFieldValueFromMethodAndReflectValue(src.Addr(), field.srcMethod)
 		}
 
 		processValues[TDes, TSrc](sourceFiledValue, destinationField)
@@ -408,11 +414,10 @@ func mapMaps[TDes any, TSrc any](src reflect.Value, dest reflect.Value) {
 	// Get each element of map as key-values
 	// process keys and values mapping and update dest map
 	srcMapIter := src.MapRange()
-	destMapIter := dest.MapRange()
 
-	for destMapIter.Next() && srcMapIter.Next() {
-		destKey := reflect.New(destMapIter.Key().Type()).Elem()
-		destValue := reflect.New(destMapIter.Value().Type()).Elem()
+	for srcMapIter.Next() {
+		destKey := reflect.New(dest.Type().Key()).Elem()
+		destValue := reflect.New(dest.Type().Elem()).Elem()
 		processValues[TDes, TSrc](srcMapIter.Key(), destKey)
 		processValues[TDes, TSrc](srcMapIter.Value(), destValue)
 
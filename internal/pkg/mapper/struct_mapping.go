@@ -0,0 +1,90 @@
+package mapper
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/iancoleman/strcase"
+)
+
+// fieldMapping is a profile entry with the resolved source and destination fields, so mapping a struct doesn't
+// search the fields by their names on each call.
+type fieldMapping struct {
+	// srcIndex is nil when the source value comes from a method (property) like `Id()`
+	srcIndex []int
+	// srcMethod is the source method name which is used for the unexported source fields and the properties
+	srcMethod string
+	// destIndex is nil when there is no destination field for the profile key
+	destIndex []int
+}
+
+type structMapping struct {
+	fields []fieldMapping
+}
+
+// structMappings caches the resolved profiles by their source and destination types
+var structMappings sync.Map
+
+// WarmUp resolves the profiles of all registered struct maps, it should be called on startup after creating the maps,
+// so the first mappings don't pay the reflection cost. It returns the number of resolved maps.
+func WarmUp() int {
+	count := 0
+
+	for entry, fn := range maps {
+		// custom maps and pointer entries don't have a profile, the pointers are mapped with their struct entries
+		if fn != nil ||
+			entry.SourceType.Kind() != reflect.Struct ||
+			entry.DestinationType.Kind() != reflect.Struct {
+			continue
+		}
+
+		if _, ok := getStructMapping(entry.SourceType, entry.DestinationType); ok {
+			count++
+		}
+	}
+
+	return count
+}
+
+func getStructMapping(srcType reflect.Type, destType reflect.Type) (*structMapping, bool) {
+	key := mappingsEntry{SourceType: srcType, DestinationType: destType}
+	if mapping, ok := structMappings.Load(key); ok {
+		return mapping.(*structMapping), true
+	}
+
+	profile, ok := profiles[getProfileKey(srcType, destType)]
+	if !ok {
+		return nil, false
+	}
+
+	mapping, _ := structMappings.LoadOrStore(key, newStructMapping(srcType, destType, profile))
+
+	return mapping.(*structMapping), true
+}
+
+func newStructMapping(srcType reflect.Type, destType reflect.Type, profile [][2]string) *structMapping {
+	mapping := &structMapping{fields: make([]fieldMapping, 0, len(profile))}
+
+	for _, keys := range profile {
+		field := fieldMapping{srcMethod: strcase.ToCamel(keys[SrcKeyIndex])}
+
+		if srcField, ok := srcType.FieldByName(keys[SrcKeyIndex]); ok {
+			field.srcIndex = srcField.Index
+		}
+
+		if destField, ok := destType.FieldByName(keys[DestKeyIndex]); ok {
+			field.destIndex = destField.Index
+		}
+
+		mapping.fields = append(mapping.fields, field)
+	}
+
+	return mapping
+}
+
+func clearStructMappings() {
+	structMappings.Range(func(key, _ interface{}) bool {
+		structMappings.Delete(key)
+		return true
+	})
+}
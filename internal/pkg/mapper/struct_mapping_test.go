@@ -0,0 +1,83 @@
+package mapper
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type warmUpSource struct {
+	Id    string
+	Name  string
+	price float64
+}
+
+func (s *warmUpSource) Price() float64 {
+	return s.price
+}
+
+type warmUpDestination struct {
+	Id          string
+	Name        string `mapper:"name"`
+	Price       float64
+	Description string
+}
+
+func Test_WarmUp_Resolves_Registered_Struct_Maps(t *testing.T) {
+	ClearMappings()
+	defer ClearMappings()
+
+	require.NoError(t, CreateMap[*warmUpSource, *warmUpDestination]())
+	require.NoError(t, CreateCustomMap(func(src warmUpDestination) warmUpSource {
+		return warmUpSource{Id: src.Id}
+	}))
+
+	assert.Equal(t, 1, WarmUp())
+
+	mapping, ok := structMappings.Load(mappingsEntry{
+		SourceType:      reflect.TypeOf(warmUpSource{}),
+		DestinationType: reflect.TypeOf(warmUpDestination{}),
+	})
+	require.True(t, ok)
+
+	fields := map[string]fieldMapping{}
+	for _, field := range mapping.(*structMapping).fields {
+		fields[field.srcMethod] = field
+	}
+
+	assert.Equal(t, []int{0}, fields["Id"].srcIndex)
+	assert.Equal(t, []int{0}, fields["Id"].destIndex)
+	assert.Equal(t, []int{2}, fields["Price"].srcIndex)
+	assert.Equal(t, []int{2}, fields["Price"].destIndex)
+}
+
+func Test_Map_With_Resolved_Struct_Map(t *testing.T) {
+	ClearMappings()
+	defer ClearMappings()
+
+	require.NoError(t, CreateMap[*warmUpSource, *warmUpDestination]())
+	WarmUp()
+
+	destination, err := Map[*warmUpDestination](&warmUpSource{Id: "1", Name: "product", price: 10})
+
+	require.NoError(t, err)
+	assert.Equal(t, &warmUpDestination{Id: "1", Name: "product", Price: 10}, destination)
+}
+
+func Test_ClearMappings_Clears_Resolved_Struct_Maps(t *testing.T) {
+	ClearMappings()
+
+	require.NoError(t, CreateMap[*warmUpSource, *warmUpDestination]())
+	require.Equal(t, 1, WarmUp())
+
+	ClearMappings()
+
+	assert.Equal(t, 0, WarmUp())
+	_, ok := structMappings.Load(mappingsEntry{
+		SourceType:      reflect.TypeOf(warmUpSource{}),
+		DestinationType: reflect.TypeOf(warmUpDestination{}),
+	})
+	assert.False(t, ok)
+}
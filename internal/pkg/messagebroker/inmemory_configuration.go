@@ -0,0 +1,36 @@
+package messagebroker
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/inmemorybus"
+	rabbitmqConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/configurations"
+
+	"emperror.dev/errors"
+)
+
+// InMemoryConfigurationFromRabbitMQ connects the handlers of the consumers of a rabbitmq configuration to the in-memory
+// bus with the pipelines of their consumers, the producers and the topology of the configuration are not used because
+// the in-memory bus dispatches a message to all the handlers of its type.
+func InMemoryConfigurationFromRabbitMQ(
+	rabbitmqBuilderFunc rabbitmqConfigurations.RabbitMQConfigurationBuilderFuc,
+) inmemorybus.ConfigurationFunc {
+	return func(bus inmemorybus.InMemoryBus) error {
+		rabbitmqBuilder := rabbitmqConfigurations.NewRabbitMQConfigurationBuilder()
+		if rabbitmqBuilderFunc != nil {
+			rabbitmqBuilderFunc(rabbitmqBuilder)
+		}
+
+		// the bus pipelines are already added to the pipelines of the consumers by the builder
+		for _, consumerConfiguration := range rabbitmqBuilder.Build().ConsumersConfigurations {
+			message := newMessage(consumerConfiguration.ConsumerMessageType)
+
+			for _, handler := range consumerConfiguration.Handlers {
+				err := bus.ConnectConsumerHandlerWithPipelines(message, handler, consumerConfiguration.Pipelines...)
+				if err != nil {
+					return errors.WrapIff(err, "error in connecting the handlers of %s", consumerConfiguration.Name)
+				}
+			}
+		}
+
+		return nil
+	}
+}
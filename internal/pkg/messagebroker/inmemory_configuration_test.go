@@ -0,0 +1,87 @@
+package messagebroker
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/pipeline"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/inmemorybus"
+	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+	rabbitmqConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/configurations"
+	rabbitmqConsumerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/consumer/configurations"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingHandler struct {
+	handled int
+}
+
+func (c *countingHandler) Handle(ctx context.Context, consumeContext types.MessageConsumeContext) error {
+	c.handled++
+
+	return nil
+}
+
+type countingPipeline struct {
+	handled int
+}
+
+func (c *countingPipeline) Handle(
+	ctx context.Context,
+	consumeContext types.MessageConsumeContext,
+	next pipeline.ConsumerHandlerFunc,
+) error {
+	c.handled++
+
+	return next(ctx)
+}
+
+func Test_InMemory_Configuration_From_RabbitMQ(t *testing.T) {
+	handler := &countingHandler{}
+	busPipeline := &countingPipeline{}
+
+	bus, err := inmemorybus.NewInMemoryBus(
+		&inmemorybus.InMemoryBusOptions{DispatchMode: inmemorybus.SyncDispatch},
+		defaultLogger.GetLogger(),
+		InMemoryConfigurationFromRabbitMQ(func(builder rabbitmqConfigurations.RabbitMQConfigurationBuilder) {
+			builder.
+				AddPipelines(busPipeline).
+				AddConsumer(
+					OrderCreated{},
+					func(builder rabbitmqConsumerConfigurations.RabbitMQConsumerConfigurationBuilder) {
+						builder.WithHandlers(func(handlersBuilder consumer.ConsumerHandlerConfigurationBuilder) {
+							handlersBuilder.AddHandler(handler)
+						})
+					},
+				)
+		}),
+	)
+	require.NoError(t, err)
+	require.NoError(t, bus.Start(context.Background()))
+	defer bus.Stop()
+
+	require.NoError(t, bus.PublishMessage(context.Background(), &OrderCreated{Message: types.NewMessage("1")}, nil))
+	require.NoError(t, bus.PublishMessage(context.Background(), &OrderShipped{Message: types.NewMessage("2")}, nil))
+
+	assert.Equal(t, 1, handler.handled)
+	assert.Equal(t, 1, busPipeline.handled)
+}
+
+func Test_Local_Flag_Selects_The_InMemory_Broker(t *testing.T) {
+	args := os.Args
+	defer func() {
+		os.Args = args
+	}()
+	t.Setenv(BrokerTypeEnv, string(Kafka))
+
+	os.Args = []string{"app"}
+	assert.Equal(t, Kafka, GetBrokerType())
+
+	os.Args = []string{"app", LocalFlag}
+	assert.Equal(t, InMemory, GetBrokerType())
+}
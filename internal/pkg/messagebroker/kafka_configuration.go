@@ -0,0 +1,104 @@
+package messagebroker
+
+import (
+	"reflect"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/pipeline"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	kafkaConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/kafka/configurations"
+	kafkaConsumerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/kafka/consumer/configurations"
+	kafkaProducerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/kafka/producer/configurations"
+	rabbitmqConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/configurations"
+	rabbitmqConsumerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/consumer/configurations"
+	rabbitmqProducerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/producer/configurations"
+)
+
+// KafkaConfigurationFromRabbitMQ creates the kafka producers and consumers of a rabbitmq configuration with the same
+// handlers and pipelines, so a service keeps one messaging configuration for both brokers. the exchange of a rabbitmq
+// producer or consumer is the kafka topic, the queue of a consumer is its consumer group and the dead-letter queue of
+// a consumer is its dead-letter topic.
+func KafkaConfigurationFromRabbitMQ(
+	rabbitmqBuilderFunc rabbitmqConfigurations.RabbitMQConfigurationBuilderFuc,
+) kafkaConfigurations.KafkaConfigurationBuilderFuc {
+	return func(builder kafkaConfigurations.KafkaConfigurationBuilder) {
+		rabbitmqBuilder := rabbitmqConfigurations.NewRabbitMQConfigurationBuilder()
+		if rabbitmqBuilderFunc != nil {
+			rabbitmqBuilderFunc(rabbitmqBuilder)
+		}
+
+		rabbitmqConfiguration := rabbitmqBuilder.Build()
+
+		for _, producerConfiguration := range rabbitmqConfiguration.ProducersConfigurations {
+			addProducer(builder, producerConfiguration)
+		}
+
+		for _, consumerConfiguration := range rabbitmqConfiguration.ConsumersConfigurations {
+			addConsumer(builder, consumerConfiguration)
+		}
+	}
+}
+
+func addProducer(
+	builder kafkaConfigurations.KafkaConfigurationBuilder,
+	producerConfiguration *rabbitmqProducerConfigurations.RabbitMQProducerConfiguration,
+) {
+	builder.AddProducer(
+		newMessage(producerConfiguration.ProducerMessageType),
+		func(producerBuilder kafkaProducerConfigurations.KafkaProducerConfigurationBuilder) {
+			if producerConfiguration.ExchangeOptions.Name != "" {
+				producerBuilder.WithTopicName(producerConfiguration.ExchangeOptions.Name)
+			}
+		},
+	)
+}
+
+func addConsumer(
+	builder kafkaConfigurations.KafkaConfigurationBuilder,
+	consumerConfiguration *rabbitmqConsumerConfigurations.RabbitMQConsumerConfiguration,
+) {
+	builder.AddConsumer(
+		newMessage(consumerConfiguration.ConsumerMessageType),
+		func(consumerBuilder kafkaConsumerConfigurations.KafkaConsumerConfigurationBuilder) {
+			exchange, _, queue := consumerConfiguration.Topology()
+
+			consumerBuilder.
+				WithName(consumerConfiguration.Name).
+				WithTopicName(exchange).
+				WithGroupId(queue).
+				WithConcurrencyLimit(consumerConfiguration.ConcurrencyLimit).
+				WithHandlers(func(handlersBuilder consumer.ConsumerHandlerConfigurationBuilder) {
+					for _, handler := range consumerConfiguration.Handlers {
+						handlersBuilder.AddHandler(handler)
+					}
+				}).
+				WithPipelines(func(pipelinesBuilder pipeline.ConsumerPipelineConfigurationBuilder) {
+					for _, consumerPipeline := range consumerConfiguration.Pipelines {
+						pipelinesBuilder.AddPipeline(consumerPipeline)
+					}
+				})
+
+			if consumerConfiguration.ConsumerOptions != nil {
+				consumerBuilder.
+					WithConsumerId(consumerConfiguration.ConsumerId).
+					WithExitOnError(consumerConfiguration.ExitOnError)
+			}
+
+			if deadLetterOptions := consumerConfiguration.DeadLetterOptions; deadLetterOptions != nil {
+				_, deadLetterQueue := deadLetterOptions.Names(queue)
+				consumerBuilder.WithDeadLetterTopic(deadLetterQueue)
+			}
+		},
+	)
+}
+
+// newMessage creates a message of the configured message type, the builders create their default names from it
+func newMessage(messageType reflect.Type) types.IMessage {
+	if messageType.Kind() == reflect.Pointer {
+		messageType = messageType.Elem()
+	}
+
+	message, _ := reflect.New(messageType).Interface().(types.IMessage)
+
+	return message
+}
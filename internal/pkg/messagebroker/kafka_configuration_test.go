@@ -0,0 +1,77 @@
+package messagebroker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	kafkaConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/kafka/configurations"
+	rabbitmqConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/configurations"
+	rabbitmqConsumerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/consumer/configurations"
+	rabbitmqProducerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/producer/configurations"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type OrderCreated struct {
+	*types.Message
+}
+
+type OrderShipped struct {
+	*types.Message
+}
+
+type orderCreatedHandler struct{}
+
+func (o *orderCreatedHandler) Handle(ctx context.Context, consumeContext types.MessageConsumeContext) error {
+	return nil
+}
+
+func Test_Kafka_Configuration_From_RabbitMQ(t *testing.T) {
+	handler := &orderCreatedHandler{}
+
+	builderFunc := KafkaConfigurationFromRabbitMQ(func(builder rabbitmqConfigurations.RabbitMQConfigurationBuilder) {
+		builder.
+			AddProducer(
+				OrderShipped{},
+				func(builder rabbitmqProducerConfigurations.RabbitMQProducerConfigurationBuilder) {
+					builder.WithExchangeName("orders_shipped")
+				},
+			).
+			AddConsumer(
+				OrderCreated{},
+				func(builder rabbitmqConsumerConfigurations.RabbitMQConsumerConfigurationBuilder) {
+					builder.WithQueueName("orders_created_queue")
+					builder.WithConcurrencyLimit(4)
+					builder.WithDeadLetter(3, time.Hour)
+					builder.WithHandlers(func(handlersBuilder consumer.ConsumerHandlerConfigurationBuilder) {
+						handlersBuilder.AddHandler(handler)
+					})
+				},
+			)
+	})
+
+	builder := kafkaConfigurations.NewKafkaConfigurationBuilder()
+	builderFunc(builder)
+	configuration := builder.Build()
+
+	require.Len(t, configuration.ProducersConfigurations, 1)
+	assert.Equal(t, "orders_shipped", configuration.ProducersConfigurations[0].Topic)
+
+	require.Len(t, configuration.ConsumersConfigurations, 1)
+	consumerConfiguration := configuration.ConsumersConfigurations[0]
+	assert.Equal(t, "orders_created_queue", consumerConfiguration.GroupId)
+	assert.Equal(t, "orders_created_queue.dlq", consumerConfiguration.DeadLetterTopic)
+	assert.Equal(t, 4, consumerConfiguration.ConcurrencyLimit)
+	assert.NotEmpty(t, consumerConfiguration.Topic)
+	assert.Equal(t, []consumer.ConsumerHandler{handler}, consumerConfiguration.Handlers)
+
+	assert.ElementsMatch(
+		t,
+		[]string{"orders_shipped", consumerConfiguration.Topic, "orders_created_queue.dlq"},
+		configuration.Topics(),
+	)
+}
@@ -0,0 +1,45 @@
+package messagebroker
+
+import (
+	"os"
+	"slices"
+	"strings"
+)
+
+type BrokerType string
+
+const (
+	RabbitMQ BrokerType = "rabbitmq"
+	Kafka    BrokerType = "kafka"
+	Nats     BrokerType = "nats"
+	// InMemory dispatches the messages of the app to its own handlers in the process, for the local run mode without a
+	// message broker
+	InMemory BrokerType = "inmemory"
+)
+
+// BrokerTypeEnv selects the message broker of the apps, the fx modules are composed before loading the config files,
+// so the broker is selected with an environment variable instead of the config file. the default broker is rabbitmq.
+const BrokerTypeEnv = "MessageBrokerType"
+
+// LocalFlag runs the app with the in-memory bus, the flag is read from the arguments of the process because the modules
+// are composed before the command line is parsed
+const LocalFlag = "--local"
+
+// GetBrokerType returns the message broker selected by the `MessageBrokerType` environment variable, the `--local` flag
+// selects the in-memory bus over it
+func GetBrokerType() BrokerType {
+	if slices.Contains(os.Args[1:], LocalFlag) {
+		return InMemory
+	}
+
+	switch BrokerType(strings.ToLower(strings.TrimSpace(os.Getenv(BrokerTypeEnv)))) {
+	case Kafka:
+		return Kafka
+	case Nats:
+		return Nats
+	case InMemory:
+		return InMemory
+	default:
+		return RabbitMQ
+	}
+}
@@ -0,0 +1,42 @@
+package messagebroker
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/inmemorybus"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/kafka"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/nats"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq"
+
+	"go.uber.org/fx"
+)
+
+// ModuleFunc composes the message broker selected by the `MessageBrokerType` environment variable, all the brokers use
+// the rabbitmq configuration of the service, so the handlers don't change with the broker. the `rabbitmqModules` are
+// only composed with rabbitmq, like the modules which depend on the rabbitmq bus.
+// https://uber-go.github.io/fx/modules.html
+var ModuleFunc = func(rabbitmqConfigurationConstructor interface{}, rabbitmqModules ...fx.Option) fx.Option { //nolint:gochecknoglobals
+	switch GetBrokerType() {
+	case Kafka:
+		return fx.Module(
+			"messagebrokerfx",
+			fx.Provide(rabbitmqConfigurationConstructor),
+			kafka.ModuleFunc(KafkaConfigurationFromRabbitMQ),
+		)
+	case Nats:
+		return fx.Module(
+			"messagebrokerfx",
+			fx.Provide(rabbitmqConfigurationConstructor),
+			nats.ModuleFunc(NatsConfigurationFromRabbitMQ),
+		)
+	case InMemory:
+		return fx.Module(
+			"messagebrokerfx",
+			fx.Provide(rabbitmqConfigurationConstructor),
+			inmemorybus.ModuleFunc(InMemoryConfigurationFromRabbitMQ),
+		)
+	}
+
+	return fx.Module(
+		"messagebrokerfx",
+		append([]fx.Option{rabbitmq.ModuleFunc(rabbitmqConfigurationConstructor)}, rabbitmqModules...)...,
+	)
+}
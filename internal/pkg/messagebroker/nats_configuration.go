@@ -0,0 +1,92 @@
+package messagebroker
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/pipeline"
+	natsConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/nats/configurations"
+	natsConsumerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/nats/consumer/configurations"
+	natsProducerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/nats/producer/configurations"
+	rabbitmqConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/configurations"
+	rabbitmqConsumerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/consumer/configurations"
+	rabbitmqProducerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/producer/configurations"
+)
+
+// NatsConfigurationFromRabbitMQ creates the nats producers and consumers of a rabbitmq configuration with the same
+// handlers and pipelines. the exchange of a rabbitmq producer or consumer is the nats subject, the queue of a consumer
+// is its durable consumer and the dead-letter queue of a consumer is its dead-letter subject, which receives a failed
+// message after its `MaxRetries` redeliveries.
+func NatsConfigurationFromRabbitMQ(
+	rabbitmqBuilderFunc rabbitmqConfigurations.RabbitMQConfigurationBuilderFuc,
+) natsConfigurations.NatsConfigurationBuilderFuc {
+	return func(builder natsConfigurations.NatsConfigurationBuilder) {
+		rabbitmqBuilder := rabbitmqConfigurations.NewRabbitMQConfigurationBuilder()
+		if rabbitmqBuilderFunc != nil {
+			rabbitmqBuilderFunc(rabbitmqBuilder)
+		}
+
+		rabbitmqConfiguration := rabbitmqBuilder.Build()
+
+		for _, producerConfiguration := range rabbitmqConfiguration.ProducersConfigurations {
+			addNatsProducer(builder, producerConfiguration)
+		}
+
+		for _, consumerConfiguration := range rabbitmqConfiguration.ConsumersConfigurations {
+			addNatsConsumer(builder, consumerConfiguration)
+		}
+	}
+}
+
+func addNatsProducer(
+	builder natsConfigurations.NatsConfigurationBuilder,
+	producerConfiguration *rabbitmqProducerConfigurations.RabbitMQProducerConfiguration,
+) {
+	builder.AddProducer(
+		newMessage(producerConfiguration.ProducerMessageType),
+		func(producerBuilder natsProducerConfigurations.NatsProducerConfigurationBuilder) {
+			if producerConfiguration.ExchangeOptions.Name != "" {
+				producerBuilder.WithSubject(producerConfiguration.ExchangeOptions.Name)
+			}
+		},
+	)
+}
+
+func addNatsConsumer(
+	builder natsConfigurations.NatsConfigurationBuilder,
+	consumerConfiguration *rabbitmqConsumerConfigurations.RabbitMQConsumerConfiguration,
+) {
+	builder.AddConsumer(
+		newMessage(consumerConfiguration.ConsumerMessageType),
+		func(consumerBuilder natsConsumerConfigurations.NatsConsumerConfigurationBuilder) {
+			exchange, _, queue := consumerConfiguration.Topology()
+
+			consumerBuilder.
+				WithName(consumerConfiguration.Name).
+				WithSubject(exchange).
+				WithDurable(queue).
+				WithConcurrencyLimit(consumerConfiguration.ConcurrencyLimit).
+				WithHandlers(func(handlersBuilder consumer.ConsumerHandlerConfigurationBuilder) {
+					for _, handler := range consumerConfiguration.Handlers {
+						handlersBuilder.AddHandler(handler)
+					}
+				}).
+				WithPipelines(func(pipelinesBuilder pipeline.ConsumerPipelineConfigurationBuilder) {
+					for _, consumerPipeline := range consumerConfiguration.Pipelines {
+						pipelinesBuilder.AddPipeline(consumerPipeline)
+					}
+				})
+
+			if consumerConfiguration.ConsumerOptions != nil {
+				consumerBuilder.
+					WithConsumerId(consumerConfiguration.ConsumerId).
+					WithExitOnError(consumerConfiguration.ExitOnError)
+			}
+
+			if deadLetterOptions := consumerConfiguration.DeadLetterOptions; deadLetterOptions != nil {
+				_, deadLetterQueue := deadLetterOptions.Names(queue)
+				consumerBuilder.
+					WithDeadLetterSubject(deadLetterQueue).
+					WithMaxDeliver(deadLetterOptions.MaxRetries + 1)
+			}
+		},
+	)
+}
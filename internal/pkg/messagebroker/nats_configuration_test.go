@@ -0,0 +1,66 @@
+package messagebroker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	natsConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/nats/configurations"
+	rabbitmqConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/configurations"
+	rabbitmqConsumerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/consumer/configurations"
+	rabbitmqProducerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/producer/configurations"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Nats_Configuration_From_RabbitMQ(t *testing.T) {
+	handler := &orderCreatedHandler{}
+
+	builderFunc := NatsConfigurationFromRabbitMQ(func(builder rabbitmqConfigurations.RabbitMQConfigurationBuilder) {
+		builder.
+			AddProducer(
+				OrderShipped{},
+				func(builder rabbitmqProducerConfigurations.RabbitMQProducerConfigurationBuilder) {
+					builder.WithExchangeName("orders_shipped")
+				},
+			).
+			AddConsumer(
+				OrderCreated{},
+				func(builder rabbitmqConsumerConfigurations.RabbitMQConsumerConfigurationBuilder) {
+					builder.WithQueueName("orders_created_queue")
+					builder.WithConcurrencyLimit(4)
+					builder.WithDeadLetter(3, time.Hour)
+					builder.WithHandlers(func(handlersBuilder consumer.ConsumerHandlerConfigurationBuilder) {
+						handlersBuilder.AddHandler(handler)
+					})
+				},
+			)
+	})
+
+	builder := natsConfigurations.NewNatsConfigurationBuilder()
+	builderFunc(builder)
+	configuration := builder.Build()
+
+	require.Len(t, configuration.ProducersConfigurations, 1)
+	assert.Equal(t, "orders_shipped", configuration.ProducersConfigurations[0].Subject)
+
+	require.Len(t, configuration.ConsumersConfigurations, 1)
+	consumerConfiguration := configuration.ConsumersConfigurations[0]
+	assert.Equal(t, "orders_created_queue", consumerConfiguration.Durable)
+	assert.Equal(t, "orders_created_queue.dlq", consumerConfiguration.DeadLetterSubject)
+	assert.Equal(t, 4, consumerConfiguration.MaxDeliver)
+	assert.Equal(t, 4, consumerConfiguration.ConcurrencyLimit)
+	assert.NotEmpty(t, consumerConfiguration.Subject)
+	assert.Equal(t, []consumer.ConsumerHandler{handler}, consumerConfiguration.Handlers)
+
+	assert.Equal(
+		t,
+		map[string][]string{
+			"orders_shipped":                   {"orders_shipped"},
+			consumerConfiguration.StreamName(): {consumerConfiguration.Subject},
+			"orders_created_queue_dlq":         {"orders_created_queue.dlq"},
+		},
+		configuration.Streams(),
+	)
+}
@@ -21,7 +21,7 @@ type MigrationOptions struct {
 	User          string `mapstructure:"user"`
 	DBName        string `mapstructure:"dbName"`
 	SSLMode       bool   `mapstructure:"sslMode"`
-	Password      string `mapstructure:"password"`
+	Password      string `mapstructure:"password" secret:"true"`
 	VersionTable  string `mapstructure:"versionTable"`
 	MigrationsDir string `mapstructure:"migrationsDir"`
 	SkipMigration bool   `mapstructure:"skipMigration"`
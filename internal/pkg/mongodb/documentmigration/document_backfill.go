@@ -0,0 +1,179 @@
+package documentmigration
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CollectionMigratorsGroup is the fx group of the collection migrators which are backfilled by the backfill worker
+const CollectionMigratorsGroup = "collectionMigrators"
+
+// CollectionMigrator is the migrator of the documents of a collection
+type CollectionMigrator struct {
+	Collection string
+	Migrator   *DocumentMigrator
+}
+
+func NewCollectionMigrator(collection string, migrator *DocumentMigrator) *CollectionMigrator {
+	return &CollectionMigrator{Collection: collection, Migrator: migrator}
+}
+
+type BackfillResult struct {
+	Collection    string `json:"collection"`
+	ScannedCount  int64  `json:"scannedCount"`
+	MigratedCount int64  `json:"migratedCount"`
+	// ConflictedCount is the number of the documents which are changed between their read and their update, they are
+	// migrated by the next backfill or by their next write
+	ConflictedCount int64 `json:"conflictedCount"`
+}
+
+// Backfill migrates the pending documents of the collection in the batches of their ids, so a backfill continues after
+// the documents of the previous batch even when some of them are not migrated. the update of a document only sets and
+// unsets the fields which are changed by its migrations, and only when they are not changed after its read, so the
+// concurrent writes of the projections are not lost.
+func Backfill(
+	ctx context.Context,
+	collection *mongo.Collection,
+	migrator *DocumentMigrator,
+	batchSize int,
+) (*BackfillResult, error) {
+	result := &BackfillResult{Collection: collection.Name()}
+	if migrator.CurrentVersion() == 0 {
+		return result, nil
+	}
+
+	var lastId interface{}
+	for {
+		filter := migrator.PendingFilter()
+		if lastId != nil {
+			filter = append(filter, bson.E{Key: "_id", Value: bson.D{{Key: "$gt", Value: lastId}}})
+		}
+
+		cursor, err := collection.Find(
+			ctx,
+			filter,
+			options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(int64(batchSize)),
+		)
+		if err != nil {
+			return result, errors.WrapIf(
+				customErrors.WrapIfCanceled(ctx, err, "finding the pending documents canceled"),
+				"error in finding the pending documents",
+			)
+		}
+
+		var documents []bson.Raw
+		if err := cursor.All(ctx, &documents); err != nil {
+			return result, errors.WrapIf(
+				customErrors.WrapIfCanceled(ctx, err, "decoding the pending documents canceled"),
+				"error in decoding the pending documents",
+			)
+		}
+
+		for _, document := range documents {
+			result.ScannedCount++
+
+			migrated, err := migrateDocument(ctx, collection, migrator, document)
+			if err != nil {
+				return result, err
+			}
+
+			if migrated {
+				result.MigratedCount++
+			} else {
+				result.ConflictedCount++
+			}
+		}
+
+		if len(documents) < batchSize {
+			return result, nil
+		}
+
+		lastId = documents[len(documents)-1].Lookup("_id")
+	}
+}
+
+func migrateDocument(
+	ctx context.Context,
+	collection *mongo.Collection,
+	migrator *DocumentMigrator,
+	document bson.Raw,
+) (bool, error) {
+	original, migrated := bson.M{}, bson.M{}
+	if err := bson.Unmarshal(document, &original); err != nil {
+		return false, errors.WrapIf(err, "error in decoding the pending document")
+	}
+	if err := bson.Unmarshal(document, &migrated); err != nil {
+		return false, errors.WrapIf(err, "error in decoding the pending document")
+	}
+
+	if _, err := migrator.Migrate(migrated); err != nil {
+		return false, errors.WrapIf(err, fmt.Sprintf("error in migrating the document %v", original["_id"]))
+	}
+
+	filter, update := migrationUpdate(document, original, migrated)
+
+	updateResult, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, errors.WrapIf(
+			customErrors.WrapIfCanceled(ctx, err, "updating the migrated document canceled"),
+			fmt.Sprintf("error in updating the migrated document %v", original["_id"]),
+		)
+	}
+
+	return updateResult.MatchedCount > 0, nil
+}
+
+// migrationUpdate returns the update of the fields which are changed by the migrations, the filter matches the document
+// only when these fields still have the values of its read
+func migrationUpdate(document bson.Raw, original bson.M, migrated bson.M) (bson.D, bson.D) {
+	var changed []string
+	for field, value := range migrated {
+		if originalValue, ok := original[field]; field == "_id" || ok && reflect.DeepEqual(originalValue, value) {
+			continue
+		}
+		changed = append(changed, field)
+	}
+	for field := range original {
+		if _, ok := migrated[field]; !ok {
+			changed = append(changed, field)
+		}
+	}
+	sort.Strings(changed)
+
+	filter := bson.D{{Key: "_id", Value: document.Lookup("_id")}}
+	set, unset := bson.D{}, bson.D{}
+
+	for _, field := range changed {
+		// the raw value keeps the order of the fields of an embedded document, which is compared by the filter
+		if value, err := document.LookupErr(field); err == nil {
+			filter = append(filter, bson.E{Key: field, Value: value})
+		} else {
+			filter = append(filter, bson.E{Key: field, Value: bson.D{{Key: "$exists", Value: false}}})
+		}
+
+		if value, ok := migrated[field]; ok {
+			set = append(set, bson.E{Key: field, Value: value})
+		} else {
+			unset = append(unset, bson.E{Key: field, Value: ""})
+		}
+	}
+
+	update := bson.D{}
+	if len(set) > 0 {
+		update = append(update, bson.E{Key: "$set", Value: set})
+	}
+	if len(unset) > 0 {
+		update = append(update, bson.E{Key: "$unset", Value: unset})
+	}
+
+	return filter, update
+}
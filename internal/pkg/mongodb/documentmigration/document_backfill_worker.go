@@ -0,0 +1,93 @@
+package documentmigration
+
+import (
+	"context"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mongodb"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/web"
+
+	"emperror.dev/errors"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/fx"
+)
+
+// registerBackfillWorker migrates the pending documents of the collections on start and on the backfill interval
+// during the application lifetime
+func registerBackfillWorker(
+	lc fx.Lifecycle,
+	migrators []*CollectionMigrator,
+	mongoClient *mongo.Client,
+	mongoOptions *mongodb.MongoDbOptions,
+	options *DocumentMigrationOptions,
+	logger logger.Logger,
+) error {
+	for _, migrator := range migrators {
+		if err := migrator.Migrator.Validate(); err != nil {
+			return errors.WrapIff(err, "invalid document migrations of collection %s", migrator.Collection)
+		}
+	}
+
+	if !options.BackfillEnabled || len(migrators) == 0 {
+		return nil
+	}
+
+	return web.RegisterPeriodicWorker(
+		lc,
+		"document migration backfill worker",
+		options.BackfillInterval,
+		logger,
+		func(ctx context.Context) error {
+			return backfillDocuments(ctx, migrators, mongoClient, mongoOptions, options, logger)
+		},
+		web.WithRunOnStart(),
+	)
+}
+
+func backfillDocuments(
+	ctx context.Context,
+	migrators []*CollectionMigrator,
+	mongoClient *mongo.Client,
+	mongoOptions *mongodb.MongoDbOptions,
+	options *DocumentMigrationOptions,
+	log logger.Logger,
+) error {
+	for _, migrator := range migrators {
+		result, err := Backfill(
+			ctx,
+			mongoOptions.Collection(mongoClient, migrator.Collection),
+			migrator.Migrator,
+			options.BackfillBatchSize,
+		)
+		if customErrors.IsCanceledError(err) || ctx.Err() != nil {
+			return err
+		}
+
+		// a failed collection doesn't stop backfilling the other collections
+		if err != nil {
+			log.Errorf(
+				"(documentBackfillWorker) error in backfilling the document migrations of collection %s: {%v}",
+				migrator.Collection,
+				err,
+			)
+
+			continue
+		}
+
+		if result.ScannedCount > 0 {
+			log.Infow(
+				"(documentBackfillWorker) document migrations backfilled",
+				logger.Fields{
+					"Collection":      result.Collection,
+					"ScannedCount":    result.ScannedCount,
+					"MigratedCount":   result.MigratedCount,
+					"ConflictedCount": result.ConflictedCount,
+					"SchemaVersion":   migrator.Migrator.CurrentVersion(),
+				},
+			)
+		}
+	}
+
+	return nil
+}
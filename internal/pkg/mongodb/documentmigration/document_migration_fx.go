@@ -0,0 +1,24 @@
+package documentmigration
+
+import (
+	"fmt"
+
+	"go.uber.org/fx"
+)
+
+// Module backfills the migrations of the collection migrators of the `CollectionMigratorsGroup` group, it should be
+// used with `mongodb.Module`
+// https://uber-go.github.io/fx/modules.html
+var Module = fx.Module( //nolint:gochecknoglobals
+	"documentmigrationfx",
+	fx.Provide(ProvideConfig),
+	fx.Invoke(fx.Annotate(
+		registerBackfillWorker,
+		fx.ParamTags(``, fmt.Sprintf(`group:"%s"`, CollectionMigratorsGroup)),
+	)),
+)
+
+// AsCollectionMigrator annotates the constructor of a collection migrator, so it is backfilled by the `Module`
+func AsCollectionMigrator(constructor interface{}) interface{} {
+	return fx.Annotate(constructor, fx.ResultTags(fmt.Sprintf(`group:"%s"`, CollectionMigratorsGroup)))
+}
@@ -0,0 +1,27 @@
+package documentmigration
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[DocumentMigrationOptions]())
+
+// DocumentMigrationOptions controls the backfill of the read model migrations, the documents are migrated on read
+// without the backfill too, the backfill persists their migrations, so the queries on the new fields see them.
+type DocumentMigrationOptions struct {
+	BackfillEnabled   bool `mapstructure:"backfillEnabled"   default:"true"`
+	BackfillBatchSize int  `mapstructure:"backfillBatchSize" default:"500"`
+	// BackfillInterval is the interval of the backfills after the backfill on start, they migrate the documents which
+	// are written by the instances of the previous version during a rolling deployment
+	BackfillInterval time.Duration `mapstructure:"backfillInterval"  default:"1h"`
+}
+
+func ProvideConfig(environment environment.Environment) (*DocumentMigrationOptions, error) {
+	return config.BindConfigKey[*DocumentMigrationOptions](optionName, environment)
+}
@@ -0,0 +1,147 @@
+package documentmigration
+
+import (
+	"fmt"
+	"sort"
+
+	"emperror.dev/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// SchemaVersionField keeps the version of the shape of a document, the documents without it have the version zero,
+// like the documents which are written before their first migration
+const SchemaVersionField = "schemaVersion"
+
+// DocumentMigration changes the shape of a document of the previous version to its version, it changes the document
+// in place and should not depend on the other documents, so it can run lazily on read and in the backfill.
+type DocumentMigration struct {
+	Version     int
+	Description string
+	Migrate     func(document bson.M) error
+}
+
+// DocumentMigrator upgrades the documents of a read model to the last version of its migrations, so a change of the
+// shape of the read model doesn't need a replay of its projection.
+type DocumentMigrator struct {
+	migrations []*DocumentMigration
+}
+
+// NewDocumentMigrator creates the migrator of the migrations, the migrations run in the order of their versions
+func NewDocumentMigrator(migrations ...*DocumentMigration) *DocumentMigrator {
+	sorted := append([]*DocumentMigration(nil), migrations...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Version < sorted[j].Version
+	})
+
+	return &DocumentMigrator{migrations: sorted}
+}
+
+// Validate checks the versions of the migrations are positive and unique
+func (m *DocumentMigrator) Validate() error {
+	for i, migration := range m.migrations {
+		if migration.Version <= 0 {
+			return errors.Errorf("version of the document migration `%s` should be positive", migration.Description)
+		}
+
+		if migration.Migrate == nil {
+			return errors.Errorf("document migration %d has no migrate func", migration.Version)
+		}
+
+		if i > 0 && m.migrations[i-1].Version == migration.Version {
+			return errors.Errorf("document migration version %d is duplicated", migration.Version)
+		}
+	}
+
+	return nil
+}
+
+// CurrentVersion is the version of the documents which are written by the current code, the writers of the read model
+// should stamp it on their documents
+func (m *DocumentMigrator) CurrentVersion() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// PendingFilter matches the documents of the versions before the current version, including the documents without a
+// version
+func (m *DocumentMigrator) PendingFilter() bson.D {
+	notCurrent := bson.D{{Key: "$not", Value: bson.D{{Key: "$gte", Value: m.CurrentVersion()}}}}
+
+	return bson.D{{Key: SchemaVersionField, Value: notCurrent}}
+}
+
+// Migrate runs the pending migrations of the document and stamps the current version on it, it reports whether the
+// document is changed
+func (m *DocumentMigrator) Migrate(document bson.M) (bool, error) {
+	version := SchemaVersion(document)
+
+	migrated := false
+	for _, migration := range m.migrations {
+		if migration.Version <= version {
+			continue
+		}
+
+		if err := migration.Migrate(document); err != nil {
+			return false, errors.WrapIf(
+				err,
+				fmt.Sprintf("error in running the document migration %d `%s`", migration.Version, migration.Description),
+			)
+		}
+
+		document[SchemaVersionField] = migration.Version
+		migrated = true
+	}
+
+	return migrated, nil
+}
+
+// Unmarshal decodes a document into the value after running its pending migrations, it is called by the
+// `UnmarshalBSON` of the read models, so every read of an old document gets the current shape. the value should be a
+// pointer of a type without `UnmarshalBSON`, like a type definition of the read model, otherwise it is called again.
+//
+//	func (p *Product) UnmarshalBSON(data []byte) error {
+//		type product Product
+//
+//		return ProductMigrator.Unmarshal(data, (*product)(p))
+//	}
+func (m *DocumentMigrator) Unmarshal(data []byte, value interface{}) error {
+	// the current documents are decoded without the migrations
+	if version, ok := bson.Raw(data).Lookup(SchemaVersionField).AsInt64OK(); ok && version >= int64(m.CurrentVersion()) {
+		return bson.Unmarshal(data, value)
+	}
+
+	document := bson.M{}
+	if err := bson.Unmarshal(data, &document); err != nil {
+		return err
+	}
+
+	if _, err := m.Migrate(document); err != nil {
+		return err
+	}
+
+	migrated, err := bson.Marshal(document)
+	if err != nil {
+		return errors.WrapIf(err, "error in marshaling the migrated document")
+	}
+
+	return bson.Unmarshal(migrated, value)
+}
+
+// SchemaVersion returns the version of the document, it is zero for the documents without a version
+func SchemaVersion(document bson.M) int {
+	switch version := document[SchemaVersionField].(type) {
+	case int32:
+		return int(version)
+	case int64:
+		return int(version)
+	case int:
+		return version
+	case float64:
+		return int(version)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,166 @@
+package documentmigration
+
+import (
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type product struct {
+	Id            string  `bson:"_id"`
+	Title         string  `bson:"title"`
+	Price         float64 `bson:"price"`
+	Currency      string  `bson:"currency"`
+	SchemaVersion int     `bson:"schemaVersion"`
+}
+
+type productDocument product
+
+func (p *productDocument) UnmarshalBSON(data []byte) error {
+	type document productDocument
+
+	return productMigrator.Unmarshal(data, (*document)(p))
+}
+
+var productMigrator = NewDocumentMigrator(
+	&DocumentMigration{
+		Version:     2,
+		Description: "default the currency",
+		Migrate: func(document bson.M) error {
+			if _, ok := document["currency"]; !ok {
+				document["currency"] = "USD"
+			}
+
+			return nil
+		},
+	},
+	&DocumentMigration{
+		Version:     1,
+		Description: "rename name to title",
+		Migrate: func(document bson.M) error {
+			document["title"] = document["name"]
+			delete(document, "name")
+
+			return nil
+		},
+	},
+)
+
+func Test_Migrate_Runs_The_Pending_Migrations_In_Order(t *testing.T) {
+	document := bson.M{"_id": "1", "name": "book", "price": 10.5}
+
+	migrated, err := productMigrator.Migrate(document)
+
+	require.NoError(t, err)
+	assert.True(t, migrated)
+	assert.Equal(t, bson.M{"_id": "1", "title": "book", "price": 10.5, "currency": "USD", "schemaVersion": 2}, document)
+}
+
+func Test_Migrate_Skips_The_Applied_Migrations(t *testing.T) {
+	document := bson.M{"_id": "1", "title": "book", "schemaVersion": int32(1)}
+
+	migrated, err := productMigrator.Migrate(document)
+
+	require.NoError(t, err)
+	assert.True(t, migrated)
+	assert.Equal(t, "book", document["title"])
+	assert.Equal(t, "USD", document["currency"])
+
+	migrated, err = productMigrator.Migrate(document)
+
+	require.NoError(t, err)
+	assert.False(t, migrated)
+}
+
+func Test_Migrate_Returns_The_Error_Of_The_Migration(t *testing.T) {
+	migrationErr := errors.New("invalid document")
+	migrator := NewDocumentMigrator(&DocumentMigration{
+		Version: 1,
+		Migrate: func(document bson.M) error {
+			return migrationErr
+		},
+	})
+
+	_, err := migrator.Migrate(bson.M{})
+
+	assert.ErrorIs(t, err, migrationErr)
+}
+
+func Test_Validate_Rejects_Duplicated_Versions(t *testing.T) {
+	migrate := func(document bson.M) error { return nil }
+
+	assert.NoError(t, productMigrator.Validate())
+	assert.Error(t, NewDocumentMigrator(
+		&DocumentMigration{Version: 1, Migrate: migrate},
+		&DocumentMigration{Version: 1, Migrate: migrate},
+	).Validate())
+	assert.Error(t, NewDocumentMigrator(&DocumentMigration{Version: 0, Migrate: migrate}).Validate())
+}
+
+func Test_Unmarshal_Migrates_An_Old_Document_On_Read(t *testing.T) {
+	data, err := bson.Marshal(bson.D{{Key: "_id", Value: "1"}, {Key: "name", Value: "book"}, {Key: "price", Value: 10.5}})
+	require.NoError(t, err)
+
+	var document productDocument
+	require.NoError(t, bson.Unmarshal(data, &document))
+
+	assert.Equal(t, productDocument{Id: "1", Title: "book", Price: 10.5, Currency: "USD", SchemaVersion: 2}, document)
+}
+
+func Test_Unmarshal_Decodes_A_Current_Document_Without_Migrations(t *testing.T) {
+	data, err := bson.Marshal(
+		bson.D{{Key: "_id", Value: "1"}, {Key: "title", Value: "book"}, {Key: "schemaVersion", Value: 2}},
+	)
+	require.NoError(t, err)
+
+	var document productDocument
+	require.NoError(t, bson.Unmarshal(data, &document))
+
+	assert.Equal(t, productDocument{Id: "1", Title: "book", SchemaVersion: 2}, document)
+}
+
+func Test_Migration_Update_Only_Changes_The_Migrated_Fields(t *testing.T) {
+	document, err := bson.Marshal(bson.D{
+		{Key: "_id", Value: "1"},
+		{Key: "name", Value: "book"},
+		{Key: "price", Value: 10.5},
+		{Key: "popularity", Value: 3},
+	})
+	require.NoError(t, err)
+
+	original, migrated := bson.M{}, bson.M{}
+	require.NoError(t, bson.Unmarshal(document, &original))
+	require.NoError(t, bson.Unmarshal(document, &migrated))
+	_, err = productMigrator.Migrate(migrated)
+	require.NoError(t, err)
+
+	filter, update := migrationUpdate(document, original, migrated)
+
+	raw := bson.Raw(document)
+	assert.Equal(t, bson.D{
+		{Key: "_id", Value: raw.Lookup("_id")},
+		{Key: "currency", Value: bson.D{{Key: "$exists", Value: false}}},
+		{Key: "name", Value: raw.Lookup("name")},
+		{Key: "schemaVersion", Value: bson.D{{Key: "$exists", Value: false}}},
+		{Key: "title", Value: bson.D{{Key: "$exists", Value: false}}},
+	}, filter)
+	assert.Equal(t, bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "currency", Value: "USD"},
+			{Key: "schemaVersion", Value: 2},
+			{Key: "title", Value: "book"},
+		}},
+		{Key: "$unset", Value: bson.D{{Key: "name", Value: ""}}},
+	}, update)
+}
+
+func Test_Pending_Filter_Matches_The_Documents_Before_The_Current_Version(t *testing.T) {
+	assert.Equal(
+		t,
+		bson.D{{Key: "schemaVersion", Value: bson.D{{Key: "$not", Value: bson.D{{Key: "$gte", Value: 2}}}}}},
+		productMigrator.PendingFilter(),
+	)
+}
@@ -3,6 +3,7 @@ package mongodb
 import (
 	"context"
 
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils"
 
 	"emperror.dev/errors"
@@ -13,11 +14,27 @@ import (
 
 // https://stackoverflow.com/a/23650312/581476
 
+// Paginate returns a page of the documents which match the filter, the optional sort keys order the documents before
+// skipping the previous pages
 func Paginate[T any](
 	ctx context.Context,
 	listQuery *utils.ListQuery,
 	collection *mongo.Collection,
 	filter interface{},
+	sort ...bson.E,
+) (*utils.ListResult[T], error) {
+	return PaginateWithProjection[T](ctx, listQuery, collection, filter, nil, sort...)
+}
+
+// PaginateWithProjection returns a page of the documents like Paginate with only the fields of the projection, e.g.
+// the fields of the sparse fieldset of the list query, a nil projection returns the whole documents
+func PaginateWithProjection[T any](
+	ctx context.Context,
+	listQuery *utils.ListQuery,
+	collection *mongo.Collection,
+	filter interface{},
+	projection bson.D,
+	sort ...bson.E,
 ) (*utils.ListResult[T], error) {
 	if filter == nil {
 		filter = bson.D{}
@@ -25,21 +42,29 @@ func Paginate[T any](
 
 	count, err := collection.CountDocuments(ctx, filter)
 	if err != nil {
-		return nil, errors.WrapIf(err, "CountDocuments")
+		return nil, errors.WrapIf(
+			customErrors.WrapIfCanceled(ctx, err, "counting the documents canceled"),
+			"CountDocuments",
+		)
 	}
 
 	limit := int64(listQuery.GetLimit())
 	skip := int64(listQuery.GetOffset())
 
-	cursor, err := collection.Find(
-		ctx,
-		filter,
-		&options.FindOptions{
-			Limit: &limit,
-			Skip:  &skip,
-		})
+	findOptions := &options.FindOptions{
+		Limit: &limit,
+		Skip:  &skip,
+	}
+	if len(sort) > 0 {
+		findOptions.Sort = bson.D(sort)
+	}
+	if len(projection) > 0 {
+		findOptions.Projection = projection
+	}
+
+	cursor, err := collection.Find(ctx, filter, findOptions)
 	if err != nil {
-		return nil, err
+		return nil, customErrors.WrapIfCanceled(ctx, err, "finding the documents canceled")
 	}
 
 	defer cursor.Close(ctx)
@@ -49,7 +74,7 @@ func Paginate[T any](
 	// https://www.mongodb.com/docs/drivers/go/current/fundamentals/crud/read-operations/cursor/#retrieve-all-documents
 	err = cursor.All(ctx, &items)
 	if err != nil {
-		return nil, err
+		return nil, customErrors.WrapIfCanceled(ctx, err, "decoding the documents canceled")
 	}
 
 	return utils.NewListResult[T](
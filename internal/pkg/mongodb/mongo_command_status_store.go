@@ -0,0 +1,134 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/commandbus"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+
+	"emperror.dev/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/fx"
+)
+
+const commandStatusCollection = "tracked_commands"
+
+// CommandStatusModule provides the mongo backed CommandStatusStore, it should be used with `commandbus.Module`
+var CommandStatusModule = fx.Module( //nolint:gochecknoglobals
+	"mongocommandstatusfx",
+	fx.Provide(NewMongoCommandStatusStore),
+	fx.Invoke(registerCommandStatusIndexes),
+)
+
+type commandStatusDocument struct {
+	Id                        string `bson:"_id"`
+	commandbus.TrackedCommand `bson:",inline"`
+}
+
+type mongoCommandStatusStore struct {
+	mongoOptions *MongoDbOptions
+	mongoClient  *mongo.Client
+}
+
+// NewMongoCommandStatusStore keeps the command statuses in the `tracked_commands` collection, the id of a document is
+// the tracking id of its command.
+func NewMongoCommandStatusStore(mongoOptions *MongoDbOptions, mongoClient *mongo.Client) commandbus.CommandStatusStore {
+	return &mongoCommandStatusStore{mongoOptions: mongoOptions, mongoClient: mongoClient}
+}
+
+func (m *mongoCommandStatusStore) Add(ctx context.Context, command *commandbus.TrackedCommand) error {
+	_, err := m.collection().InsertOne(ctx, &commandStatusDocument{Id: command.TrackingId, TrackedCommand: *command})
+	if mongo.IsDuplicateKeyError(err) {
+		return customErrors.NewConflictErrorWrap(
+			err,
+			fmt.Sprintf("command with tracking id `%s` already exists", command.TrackingId),
+		)
+	}
+
+	if err != nil {
+		return errors.WrapIf(err, "error in adding the command status")
+	}
+
+	return nil
+}
+
+func (m *mongoCommandStatusStore) Get(ctx context.Context, trackingId string) (*commandbus.TrackedCommand, error) {
+	var document commandStatusDocument
+
+	err := m.collection().FindOne(ctx, bson.M{"_id": trackingId}).Decode(&document)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, customErrors.NewNotFoundErrorWrap(
+			err,
+			fmt.Sprintf("command with tracking id `%s` not found", trackingId),
+		)
+	}
+
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in getting the command status")
+	}
+
+	return &document.TrackedCommand, nil
+}
+
+func (m *mongoCommandStatusStore) Update(ctx context.Context, command *commandbus.TrackedCommand) error {
+	document := &commandStatusDocument{Id: command.TrackingId, TrackedCommand: *command}
+
+	result, err := m.collection().ReplaceOne(ctx, bson.M{"_id": command.TrackingId}, document)
+	if err != nil {
+		return errors.WrapIf(err, "error in updating the command status")
+	}
+
+	if result.MatchedCount == 0 {
+		return customErrors.NewNotFoundError(
+			fmt.Sprintf("command with tracking id `%s` not found", command.TrackingId),
+		)
+	}
+
+	return nil
+}
+
+func (m *mongoCommandStatusStore) RemoveUpdatedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := m.collection().DeleteMany(ctx, bson.M{"updatedAt": bson.M{"$lt": before}})
+	if err != nil {
+		return 0, errors.WrapIf(err, "error in removing the command statuses")
+	}
+
+	return result.DeletedCount, nil
+}
+
+func (m *mongoCommandStatusStore) collection() *mongo.Collection {
+	return m.mongoOptions.Collection(m.mongoClient, commandStatusCollection)
+}
+
+// registerCommandStatusIndexes creates the index of the command statuses cleanup on application start, creating an
+// existing index is a no-op
+func registerCommandStatusIndexes(
+	lc fx.Lifecycle,
+	mongoClient *mongo.Client,
+	mongoOptions *MongoDbOptions,
+	log logger.Logger,
+) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			_, err := mongoClient.Database(mongoOptions.Database).
+				Collection(commandStatusCollection).
+				Indexes().
+				CreateOne(ctx, mongo.IndexModel{
+					Keys:    bson.D{{Key: "updatedAt", Value: 1}},
+					Options: options.Index().SetName("updated_at"),
+				})
+			if err != nil {
+				return errors.WrapIf(err, "error in creating command status indexes")
+			}
+
+			log.Info("command status indexes created")
+
+			return nil
+		},
+	})
+}
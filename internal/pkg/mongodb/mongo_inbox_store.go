@@ -0,0 +1,108 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/inbox"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+
+	"emperror.dev/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/fx"
+)
+
+const inboxCollection = "inbox_messages"
+
+// InboxModule provides the mongo backed InboxStore, it should be used with `inbox.Module`
+var InboxModule = fx.Module( //nolint:gochecknoglobals
+	"mongoinboxfx",
+	fx.Provide(NewMongoInboxStore),
+	fx.Invoke(registerInboxIndexes),
+)
+
+type inboxDocument struct {
+	Id                 string `bson:"_id"`
+	inbox.InboxMessage `bson:",inline"`
+}
+
+type mongoInboxStore struct {
+	mongoOptions *MongoDbOptions
+	mongoClient  *mongo.Client
+}
+
+// NewMongoInboxStore keeps the inbox in the `inbox_messages` collection, the id of a document is the key of the
+// message, so a message which is added twice is rejected by the unique `_id` index.
+func NewMongoInboxStore(mongoOptions *MongoDbOptions, mongoClient *mongo.Client) inbox.InboxStore {
+	return &mongoInboxStore{mongoOptions: mongoOptions, mongoClient: mongoClient}
+}
+
+func (m *mongoInboxStore) Exists(ctx context.Context, messageId string, consumer string) (bool, error) {
+	count, err := m.collection().
+		CountDocuments(ctx, bson.M{"_id": inboxDocumentId(messageId, consumer)}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, errors.WrapIf(err, "error in checking the inbox message existence")
+	}
+
+	return count > 0, nil
+}
+
+func (m *mongoInboxStore) Add(ctx context.Context, message *inbox.InboxMessage) error {
+	_, err := m.collection().InsertOne(ctx, &inboxDocument{
+		Id:           inboxDocumentId(message.MessageId, message.Consumer),
+		InboxMessage: *message,
+	})
+	// a message which is added concurrently by a redelivery is ignored
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return errors.WrapIf(err, "error in adding the inbox message")
+	}
+
+	return nil
+}
+
+func (m *mongoInboxStore) RemoveProcessedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := m.collection().DeleteMany(ctx, bson.M{"processedAt": bson.M{"$lt": before}})
+	if err != nil {
+		return 0, errors.WrapIf(err, "error in removing the inbox messages")
+	}
+
+	return result.DeletedCount, nil
+}
+
+func (m *mongoInboxStore) collection() *mongo.Collection {
+	return m.mongoOptions.Collection(m.mongoClient, inboxCollection)
+}
+
+func inboxDocumentId(messageId string, consumer string) string {
+	return consumer + ":" + messageId
+}
+
+// registerInboxIndexes creates the index of the inbox cleanup on application start, creating an existing index is a
+// no-op
+func registerInboxIndexes(
+	lc fx.Lifecycle,
+	mongoClient *mongo.Client,
+	mongoOptions *MongoDbOptions,
+	log logger.Logger,
+) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			_, err := mongoClient.Database(mongoOptions.Database).
+				Collection(inboxCollection).
+				Indexes().
+				CreateOne(ctx, mongo.IndexModel{
+					Keys:    bson.D{{Key: "processedAt", Value: 1}},
+					Options: options.Index().SetName("processed_at"),
+				})
+			if err != nil {
+				return errors.WrapIf(err, "error in creating inbox indexes")
+			}
+
+			log.Info("inbox indexes created")
+
+			return nil
+		},
+	})
+}
@@ -12,10 +12,16 @@ type MongoDbOptions struct {
 	Host          string `mapstructure:"host"`
 	Port          int    `mapstructure:"port"`
 	User          string `mapstructure:"user"`
-	Password      string `mapstructure:"password"`
+	Password      string `mapstructure:"password" secret:"true"`
 	Database      string `mapstructure:"database"`
 	UseAuth       bool   `mapstructure:"useAuth"`
 	EnableTracing bool   `mapstructure:"enableTracing" default:"true"`
+	// ReadWriteOptions are the client level defaults of the read preference and the read/write concerns
+	ReadWriteOptions `mapstructure:",squash"`
+	RetryWrites      bool `mapstructure:"retryWrites"   default:"true"`
+	RetryReads       bool `mapstructure:"retryReads"    default:"true"`
+	// Collections overrides the client level read/write options per collection, keyed by the collection name
+	Collections map[string]*CollectionOptions `mapstructure:"collections"`
 }
 
 func provideConfig(
@@ -0,0 +1,142 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/quarantine"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+
+	"emperror.dev/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/fx"
+)
+
+const quarantineCollection = "quarantined_messages"
+
+// QuarantineModule provides the mongo backed QuarantineStore, it should be used with `quarantine.Module`
+var QuarantineModule = fx.Module( //nolint:gochecknoglobals
+	"mongoquarantinefx",
+	fx.Provide(NewMongoQuarantineStore),
+	fx.Invoke(registerQuarantineIndexes),
+)
+
+type mongoQuarantineStore struct {
+	mongoOptions *MongoDbOptions
+	mongoClient  *mongo.Client
+}
+
+// NewMongoQuarantineStore keeps the quarantined messages in the `quarantined_messages` collection
+func NewMongoQuarantineStore(mongoOptions *MongoDbOptions, mongoClient *mongo.Client) quarantine.QuarantineStore {
+	return &mongoQuarantineStore{mongoOptions: mongoOptions, mongoClient: mongoClient}
+}
+
+func (m *mongoQuarantineStore) Add(ctx context.Context, message *quarantine.QuarantinedMessage) error {
+	_, err := m.collection().InsertOne(ctx, message)
+	if mongo.IsDuplicateKeyError(err) {
+		return customErrors.NewConflictErrorWrap(
+			err,
+			fmt.Sprintf("quarantined message with id `%s` already exists", message.Id),
+		)
+	}
+
+	if err != nil {
+		return errors.WrapIf(err, "error in adding the quarantined message")
+	}
+
+	return nil
+}
+
+func (m *mongoQuarantineStore) Get(ctx context.Context, id string) (*quarantine.QuarantinedMessage, error) {
+	message := &quarantine.QuarantinedMessage{}
+
+	err := m.collection().FindOne(ctx, bson.M{"_id": id}).Decode(message)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, customErrors.NewNotFoundErrorWrap(
+			err,
+			fmt.Sprintf("quarantined message with id `%s` not found", id),
+		)
+	}
+
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in getting the quarantined message")
+	}
+
+	return message, nil
+}
+
+func (m *mongoQuarantineStore) List(
+	ctx context.Context,
+	consumer string,
+	limit int,
+) ([]*quarantine.QuarantinedMessage, error) {
+	filter := bson.M{}
+	if consumer != "" {
+		filter["consumer"] = consumer
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "quarantinedAt", Value: -1}})
+	if limit > 0 {
+		findOptions.SetLimit(int64(limit))
+	}
+
+	cursor, err := m.collection().Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in listing the quarantined messages")
+	}
+
+	var messages []*quarantine.QuarantinedMessage
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, errors.WrapIf(err, "error in decoding the quarantined messages")
+	}
+
+	return messages, nil
+}
+
+func (m *mongoQuarantineStore) Delete(ctx context.Context, id string) error {
+	result, err := m.collection().DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return errors.WrapIf(err, "error in deleting the quarantined message")
+	}
+
+	if result.DeletedCount == 0 {
+		return customErrors.NewNotFoundError(fmt.Sprintf("quarantined message with id `%s` not found", id))
+	}
+
+	return nil
+}
+
+func (m *mongoQuarantineStore) collection() *mongo.Collection {
+	return m.mongoOptions.Collection(m.mongoClient, quarantineCollection)
+}
+
+// registerQuarantineIndexes creates the index of the quarantine list on application start, creating an existing index
+// is a no-op
+func registerQuarantineIndexes(
+	lc fx.Lifecycle,
+	mongoClient *mongo.Client,
+	mongoOptions *MongoDbOptions,
+	log logger.Logger,
+) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			_, err := mongoClient.Database(mongoOptions.Database).
+				Collection(quarantineCollection).
+				Indexes().
+				CreateOne(ctx, mongo.IndexModel{
+					Keys:    bson.D{{Key: "consumer", Value: 1}, {Key: "quarantinedAt", Value: -1}},
+					Options: options.Index().SetName("consumer_quarantined_at"),
+				})
+			if err != nil {
+				return errors.WrapIf(err, "error in creating quarantine indexes")
+			}
+
+			log.Info("quarantine indexes created")
+
+			return nil
+		},
+	})
+}
@@ -0,0 +1,69 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/sequence"
+
+	"emperror.dev/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/fx"
+)
+
+const sequencesCollection = "sequences"
+
+// SequenceModule provides the mongo backed SequenceGenerator
+var SequenceModule = fx.Module( //nolint:gochecknoglobals
+	"mongosequencefx",
+	fx.Provide(NewMongoSequenceGenerator),
+)
+
+type sequenceDocument struct {
+	Name  string `bson:"_id"`
+	Value int64  `bson:"value"`
+}
+
+type mongoSequenceGenerator struct {
+	mongoOptions *MongoDbOptions
+	mongoClient  *mongo.Client
+}
+
+// NewMongoSequenceGenerator keeps the sequences in the `sequences` collection, the id of a document is the name of its
+// sequence. a number is generated with an atomic increment of its document, so the numbers are unique across the
+// instances of a service.
+func NewMongoSequenceGenerator(mongoOptions *MongoDbOptions, mongoClient *mongo.Client) sequence.SequenceGenerator {
+	return &mongoSequenceGenerator{mongoOptions: mongoOptions, mongoClient: mongoClient}
+}
+
+func (m *mongoSequenceGenerator) Next(ctx context.Context, name string) (int64, error) {
+	number, err := m.increment(ctx, name)
+	// the concurrent upserts of a new sequence can conflict on its id, the increment is retried on the inserted document
+	if mongo.IsDuplicateKeyError(err) {
+		number, err = m.increment(ctx, name)
+	}
+
+	if err != nil {
+		return 0, errors.WrapIf(err, fmt.Sprintf("error in generating the next number of the sequence `%s`", name))
+	}
+
+	return number, nil
+}
+
+func (m *mongoSequenceGenerator) increment(ctx context.Context, name string) (int64, error) {
+	var document sequenceDocument
+
+	err := m.mongoOptions.Collection(m.mongoClient, sequencesCollection).FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": name},
+		bson.M{"$inc": bson.M{"value": 1}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&document)
+	if err != nil {
+		return 0, err
+	}
+
+	return document.Value, nil
+}
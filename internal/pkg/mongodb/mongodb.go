@@ -22,6 +22,10 @@ const (
 
 // NewMongoDB Create new MongoDB client
 func NewMongoDB(cfg *MongoDbOptions) (*mongo.Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
 	uriAddress := fmt.Sprintf(
 		"mongodb://%s:%s@%s:%d",
 		cfg.User,
@@ -33,7 +37,13 @@ func NewMongoDB(cfg *MongoDbOptions) (*mongo.Client, error) {
 		SetConnectTimeout(connectTimeout).
 		SetMaxConnIdleTime(maxConnIdleTime).
 		SetMinPoolSize(minPoolSize).
-		SetMaxPoolSize(maxPoolSize)
+		SetMaxPoolSize(maxPoolSize).
+		SetRetryWrites(cfg.RetryWrites).
+		SetRetryReads(cfg.RetryReads)
+
+	if err := cfg.ReadWriteOptions.applyToClient(opt); err != nil {
+		return nil, err
+	}
 
 	if cfg.UseAuth {
 		opt = opt.SetAuth(
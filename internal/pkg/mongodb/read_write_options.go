@@ -0,0 +1,248 @@
+package mongodb
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"emperror.dev/errors"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+// https://www.mongodb.com/docs/manual/core/read-preference/
+// https://www.mongodb.com/docs/manual/reference/read-concern/
+// https://www.mongodb.com/docs/manual/reference/write-concern/
+
+type ReadWriteOptions struct {
+	// ReadPreference is one of `primary`, `primaryPreferred`, `secondary`, `secondaryPreferred` or `nearest`
+	ReadPreference string        `mapstructure:"readPreference"`
+	MaxStaleness   time.Duration `mapstructure:"maxStaleness"`
+	// ReadConcern is one of `local`, `available`, `majority`, `linearizable` or `snapshot`
+	ReadConcern  string               `mapstructure:"readConcern"`
+	WriteConcern *WriteConcernOptions `mapstructure:"writeConcern"`
+}
+
+type WriteConcernOptions struct {
+	// W is `majority`, a tag set name or the number of the nodes that acknowledge the write
+	W        string        `mapstructure:"w"`
+	Journal  *bool         `mapstructure:"journal"`
+	WTimeout time.Duration `mapstructure:"wTimeout"`
+}
+
+// CollectionOptions overrides the client level read/write options for the repository of a collection
+type CollectionOptions struct {
+	ReadWriteOptions `mapstructure:",squash"`
+	// ListReadPreference is the read preference of the heavy list queries (e.g. `secondaryPreferred` for taking
+	// pagination and search load off the primary), it falls back to `ReadPreference`
+	ListReadPreference string `mapstructure:"listReadPreference"`
+}
+
+var readConcernLevels = map[string]bool{
+	"local":        true,
+	"available":    true,
+	"majority":     true,
+	"linearizable": true,
+	"snapshot":     true,
+}
+
+// Validate checks the read/write options of the client and all the collections, so an invalid configuration fails
+// on startup instead of on the first query
+func (m *MongoDbOptions) Validate() error {
+	if _, err := m.ReadWriteOptions.readPreference(m.ReadPreference); err != nil {
+		return err
+	}
+
+	if _, err := m.ReadWriteOptions.readConcern(); err != nil {
+		return err
+	}
+
+	if _, err := m.ReadWriteOptions.writeConcern(); err != nil {
+		return err
+	}
+
+	for name, collectionOptions := range m.Collections {
+		if _, err := collectionOptions.MongoOptions(false); err != nil {
+			return errors.WrapIf(err, fmt.Sprintf("invalid options for collection `%s`", name))
+		}
+
+		if _, err := collectionOptions.MongoOptions(true); err != nil {
+			return errors.WrapIf(err, fmt.Sprintf("invalid options for collection `%s`", name))
+		}
+	}
+
+	return nil
+}
+
+// CollectionOptions returns the configured overrides of a collection, it returns nil when there is no override
+func (m *MongoDbOptions) CollectionOptions(collectionName string) *CollectionOptions {
+	if m == nil {
+		return nil
+	}
+
+	return m.Collections[collectionName]
+}
+
+// Collection returns the collection with its configured read/write options
+func (m *MongoDbOptions) Collection(db *mongo.Client, collectionName string) *mongo.Collection {
+	return NewCollection(db, m.Database, collectionName, m.CollectionOptions(collectionName), false)
+}
+
+// ListCollection returns the collection with its configured read/write options for the list queries
+func (m *MongoDbOptions) ListCollection(db *mongo.Client, collectionName string) *mongo.Collection {
+	return NewCollection(db, m.Database, collectionName, m.CollectionOptions(collectionName), true)
+}
+
+// NewCollection returns a collection handle with the overrides applied, invalid overrides are rejected on startup by
+// `MongoDbOptions.Validate`, so here they fall back to the client defaults
+func NewCollection(
+	db *mongo.Client,
+	databaseName string,
+	collectionName string,
+	collectionOptions *CollectionOptions,
+	forList bool,
+) *mongo.Collection {
+	database := db.Database(databaseName)
+
+	mongoOptions, err := collectionOptions.MongoOptions(forList)
+	if err != nil || mongoOptions == nil {
+		return database.Collection(collectionName)
+	}
+
+	return database.Collection(collectionName, mongoOptions)
+}
+
+// MongoOptions converts the overrides to the driver collection options, it returns nil when there is no override
+func (c *CollectionOptions) MongoOptions(forList bool) (*options.CollectionOptions, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	mode := c.ReadPreference
+	if forList && c.ListReadPreference != "" {
+		mode = c.ListReadPreference
+	}
+
+	readPreference, err := c.readPreference(mode)
+	if err != nil {
+		return nil, err
+	}
+
+	readConcern, err := c.readConcern()
+	if err != nil {
+		return nil, err
+	}
+
+	writeConcern, err := c.writeConcern()
+	if err != nil {
+		return nil, err
+	}
+
+	if readPreference == nil && readConcern == nil && writeConcern == nil {
+		return nil, nil
+	}
+
+	collectionOptions := options.Collection()
+	if readPreference != nil {
+		collectionOptions.SetReadPreference(readPreference)
+	}
+	if readConcern != nil {
+		collectionOptions.SetReadConcern(readConcern)
+	}
+	if writeConcern != nil {
+		collectionOptions.SetWriteConcern(writeConcern)
+	}
+
+	return collectionOptions, nil
+}
+
+func (r *ReadWriteOptions) applyToClient(clientOptions *options.ClientOptions) error {
+	readPreference, err := r.readPreference(r.ReadPreference)
+	if err != nil {
+		return err
+	}
+	if readPreference != nil {
+		clientOptions.SetReadPreference(readPreference)
+	}
+
+	readConcern, err := r.readConcern()
+	if err != nil {
+		return err
+	}
+	if readConcern != nil {
+		clientOptions.SetReadConcern(readConcern)
+	}
+
+	writeConcern, err := r.writeConcern()
+	if err != nil {
+		return err
+	}
+	if writeConcern != nil {
+		clientOptions.SetWriteConcern(writeConcern)
+	}
+
+	return nil
+}
+
+func (r *ReadWriteOptions) readPreference(mode string) (*readpref.ReadPref, error) {
+	if mode == "" {
+		return nil, nil
+	}
+
+	readPreferenceMode, err := readpref.ModeFromString(mode)
+	if err != nil {
+		return nil, errors.WrapIf(err, "invalid read preference")
+	}
+
+	var readPreferenceOptions []readpref.Option
+	// max staleness is not allowed for the primary read preference
+	if r.MaxStaleness > 0 && readPreferenceMode != readpref.PrimaryMode {
+		readPreferenceOptions = append(readPreferenceOptions, readpref.WithMaxStaleness(r.MaxStaleness))
+	}
+
+	return readpref.New(readPreferenceMode, readPreferenceOptions...)
+}
+
+func (r *ReadWriteOptions) readConcern() (*readconcern.ReadConcern, error) {
+	if r.ReadConcern == "" {
+		return nil, nil
+	}
+
+	if !readConcernLevels[r.ReadConcern] {
+		return nil, errors.Errorf("invalid read concern `%s`", r.ReadConcern)
+	}
+
+	return &readconcern.ReadConcern{Level: r.ReadConcern}, nil
+}
+
+func (r *ReadWriteOptions) writeConcern() (*writeconcern.WriteConcern, error) {
+	if r.WriteConcern == nil {
+		return nil, nil
+	}
+
+	writeConcern := &writeconcern.WriteConcern{
+		Journal:  r.WriteConcern.Journal,
+		WTimeout: r.WriteConcern.WTimeout,
+	}
+
+	if r.WriteConcern.W != "" {
+		if w, err := strconv.Atoi(r.WriteConcern.W); err == nil {
+			if w < 0 {
+				return nil, errors.Errorf("invalid write concern w `%d`", w)
+			}
+			writeConcern.W = w
+		} else {
+			// `majority` or a custom tag set name
+			writeConcern.W = r.WriteConcern.W
+		}
+	}
+
+	if w, ok := writeConcern.W.(int); ok && w == 0 && writeConcern.Journal != nil && *writeConcern.Journal {
+		return nil, errors.New("a write concern can't have both w=0 and journal=true")
+	}
+
+	return writeConcern, nil
+}
@@ -0,0 +1,102 @@
+package mongodb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+func Test_Collection_Options_Without_Overrides(t *testing.T) {
+	var collectionOptions *CollectionOptions
+
+	mongoOptions, err := collectionOptions.MongoOptions(false)
+
+	require.NoError(t, err)
+	assert.Nil(t, mongoOptions)
+}
+
+func Test_Collection_Options_List_Read_Preference(t *testing.T) {
+	collectionOptions := &CollectionOptions{
+		ReadWriteOptions: ReadWriteOptions{
+			ReadPreference: "primary",
+			MaxStaleness:   2 * time.Minute,
+			ReadConcern:    "majority",
+			WriteConcern:   &WriteConcernOptions{W: "2", WTimeout: time.Second},
+		},
+		ListReadPreference: "secondaryPreferred",
+	}
+
+	mongoOptions, err := collectionOptions.MongoOptions(false)
+	require.NoError(t, err)
+	assert.Equal(t, readpref.PrimaryMode, mongoOptions.ReadPreference.Mode())
+	assert.Equal(t, "majority", mongoOptions.ReadConcern.Level)
+	assert.Equal(t, 2, mongoOptions.WriteConcern.W)
+	assert.Equal(t, time.Second, mongoOptions.WriteConcern.WTimeout)
+
+	listOptions, err := collectionOptions.MongoOptions(true)
+	require.NoError(t, err)
+	assert.Equal(t, readpref.SecondaryPreferredMode, listOptions.ReadPreference.Mode())
+	maxStaleness, ok := listOptions.ReadPreference.MaxStaleness()
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Minute, maxStaleness)
+}
+
+func Test_Validate_Rejects_Invalid_Options(t *testing.T) {
+	journal := true
+
+	testCases := []struct {
+		name    string
+		options *MongoDbOptions
+	}{
+		{
+			name:    "read preference",
+			options: &MongoDbOptions{ReadWriteOptions: ReadWriteOptions{ReadPreference: "leader"}},
+		},
+		{
+			name:    "read concern",
+			options: &MongoDbOptions{ReadWriteOptions: ReadWriteOptions{ReadConcern: "strong"}},
+		},
+		{
+			name: "write concern",
+			options: &MongoDbOptions{
+				ReadWriteOptions: ReadWriteOptions{
+					WriteConcern: &WriteConcernOptions{W: "0", Journal: &journal},
+				},
+			},
+		},
+		{
+			name: "collection list read preference",
+			options: &MongoDbOptions{
+				Collections: map[string]*CollectionOptions{
+					"products": {ListReadPreference: "replica"},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Error(t, testCase.options.Validate())
+		})
+	}
+}
+
+func Test_Validate_Accepts_Valid_Options(t *testing.T) {
+	options := &MongoDbOptions{
+		ReadWriteOptions: ReadWriteOptions{
+			ReadPreference: "nearest",
+			ReadConcern:    "local",
+			WriteConcern:   &WriteConcernOptions{W: "majority"},
+		},
+		Collections: map[string]*CollectionOptions{
+			"products": {ListReadPreference: "secondaryPreferred"},
+		},
+	}
+
+	assert.NoError(t, options.Validate())
+	assert.Nil(t, options.CollectionOptions("orders"))
+	assert.NotNil(t, options.CollectionOptions("products"))
+}
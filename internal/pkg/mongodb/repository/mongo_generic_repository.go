@@ -30,37 +30,61 @@ import (
 // https://www.mongodb.com/docs/drivers/go/current/fundamentals/bson/
 // https://www.mongodb.com/docs
 type mongoGenericRepository[TDataModel interface{}, TEntity interface{}] struct {
-	db             *mongo.Client
-	databaseName   string
-	collectionName string
+	db                *mongo.Client
+	databaseName      string
+	collectionName    string
+	collectionOptions *mongodb.CollectionOptions
 }
 
-// NewGenericMongoRepositoryWithDataModel create new gorm generic repository
+// NewGenericMongoRepositoryWithDataModel create new gorm generic repository, the optional collection options override
+// the client read/write options for this repository
 func NewGenericMongoRepositoryWithDataModel[TDataModel interface{}, TEntity interface{}](
 	db *mongo.Client,
 	databaseName string,
 	collectionName string,
+	collectionOptions ...*mongodb.CollectionOptions,
 ) data.GenericRepositoryWithDataModel[TDataModel, TEntity] {
 	return &mongoGenericRepository[TDataModel, TEntity]{
-		db:             db,
-		collectionName: collectionName,
-		databaseName:   databaseName,
+		db:                db,
+		collectionName:    collectionName,
+		databaseName:      databaseName,
+		collectionOptions: firstCollectionOptions(collectionOptions),
 	}
 }
 
-// NewGenericMongoRepository create new gorm generic repository
+// NewGenericMongoRepository create new gorm generic repository, the optional collection options override the client
+// read/write options for this repository
 func NewGenericMongoRepository[TEntity interface{}](
 	db *mongo.Client,
 	databaseName string,
 	collectionName string,
+	collectionOptions ...*mongodb.CollectionOptions,
 ) data.GenericRepository[TEntity] {
 	return &mongoGenericRepository[TEntity, TEntity]{
-		db:             db,
-		collectionName: collectionName,
-		databaseName:   databaseName,
+		db:                db,
+		collectionName:    collectionName,
+		databaseName:      databaseName,
+		collectionOptions: firstCollectionOptions(collectionOptions),
 	}
 }
 
+func firstCollectionOptions(collectionOptions []*mongodb.CollectionOptions) *mongodb.CollectionOptions {
+	if len(collectionOptions) == 0 {
+		return nil
+	}
+
+	return collectionOptions[0]
+}
+
+func (m *mongoGenericRepository[TDataModel, TEntity]) collection() *mongo.Collection {
+	return mongodb.NewCollection(m.db, m.databaseName, m.collectionName, m.collectionOptions, false)
+}
+
+// listCollection is used by the list queries, so they can read from the secondaries with `ListReadPreference`
+func (m *mongoGenericRepository[TDataModel, TEntity]) listCollection() *mongo.Collection {
+	return mongodb.NewCollection(m.db, m.databaseName, m.collectionName, m.collectionOptions, true)
+}
+
 func (m *mongoGenericRepository[TDataModel, TEntity]) Add(
 	ctx context.Context,
 	entity TEntity,
@@ -68,12 +92,12 @@ func (m *mongoGenericRepository[TDataModel, TEntity]) Add(
 	dataModelType := typeMapper.GetGenericTypeByT[TDataModel]()
 	modelType := typeMapper.GetGenericTypeByT[TEntity]()
 
-	collection := m.db.Database(m.databaseName).Collection(m.collectionName)
+	collection := m.collection()
 
 	if modelType == dataModelType {
 		_, err := collection.InsertOne(ctx, entity, &options.InsertOneOptions{})
 		if err != nil {
-			return err
+			return customErrors.WrapIfCanceled(ctx, err, "adding the entity canceled")
 		}
 		return nil
 	} else {
@@ -83,7 +107,7 @@ func (m *mongoGenericRepository[TDataModel, TEntity]) Add(
 		}
 		_, err = collection.InsertOne(ctx, dataModel, &options.InsertOneOptions{})
 		if err != nil {
-			return err
+			return customErrors.WrapIfCanceled(ctx, err, "adding the entity canceled")
 		}
 		e, err := mapper.Map[TEntity](dataModel)
 		if err != nil {
@@ -99,6 +123,11 @@ func (m *mongoGenericRepository[TDataModel, TEntity]) AddAll(
 	entities []TEntity,
 ) error {
 	for _, entity := range entities {
+		// stop the bulk operation between the items, when the context is canceled
+		if err := customErrors.CheckContext(ctx, "adding the entities canceled"); err != nil {
+			return err
+		}
+
 		err := m.Add(ctx, entity)
 		if err != nil {
 			return err
@@ -114,7 +143,7 @@ func (m *mongoGenericRepository[TDataModel, TEntity]) GetById(
 ) (TEntity, error) {
 	dataModelType := typeMapper.GetGenericTypeByT[TDataModel]()
 	modelType := typeMapper.GetGenericTypeByT[TEntity]()
-	collection := m.db.Database(m.databaseName).Collection(m.collectionName)
+	collection := m.collection()
 
 	if modelType == dataModelType {
 		var model TEntity
@@ -123,6 +152,12 @@ func (m *mongoGenericRepository[TDataModel, TEntity]) GetById(
 		// https://www.mongodb.com/docs/drivers/go/current/fundamentals/bson/
 		// https://pkg.go.dev/go.mongodb.org/mongo-driver@v1.10.3/bson
 		if err := collection.FindOne(ctx, bson.M{"_id": id.String()}).Decode(&model); err != nil {
+			if canceledErr := customErrors.WrapIfCanceled(ctx, err, "loading the entity canceled"); customErrors.IsCanceledError(
+				canceledErr,
+			) {
+				return *new(TEntity), canceledErr
+			}
+
 			// ErrNoDocuments means that the filter did not match any documents in the collection
 			if err == mongo.ErrNoDocuments {
 				return *new(TEntity), customErrors.NewNotFoundErrorWrap(
@@ -145,6 +180,12 @@ func (m *mongoGenericRepository[TDataModel, TEntity]) GetById(
 	} else {
 		var dataModel TDataModel
 		if err := collection.FindOne(ctx, bson.M{"_id": id.String()}).Decode(&dataModel); err != nil {
+			if canceledErr := customErrors.WrapIfCanceled(ctx, err, "loading the entity canceled"); customErrors.IsCanceledError(
+				canceledErr,
+			) {
+				return *new(TEntity), canceledErr
+			}
+
 			// ErrNoDocuments means that the filter did not match any documents in the collection
 			if err == mongo.ErrNoDocuments {
 				return *new(TEntity), customErrors.NewNotFoundErrorWrap(err, fmt.Sprintf("can't find the entity with id %s into the database.", id.String()))
@@ -165,7 +206,7 @@ func (m *mongoGenericRepository[TDataModel, TEntity]) GetAll(
 ) (*utils.ListResult[TEntity], error) {
 	dataModelType := typeMapper.GetGenericTypeByT[TDataModel]()
 	modelType := typeMapper.GetGenericTypeByT[TEntity]()
-	collection := m.db.Database(m.databaseName).Collection(m.collectionName)
+	collection := m.listCollection()
 
 	if modelType == dataModelType {
 		result, err := mongodb.Paginate[TEntity](
@@ -198,7 +239,7 @@ func (m *mongoGenericRepository[TDataModel, TEntity]) Search(
 ) (*utils.ListResult[TEntity], error) {
 	dataModelType := typeMapper.GetGenericTypeByT[TDataModel]()
 	modelType := typeMapper.GetGenericTypeByT[TEntity]()
-	collection := m.db.Database(m.databaseName).Collection(m.collectionName)
+	collection := m.listCollection()
 
 	if modelType == dataModelType {
 		fields := reflectionHelper.GetAllFields(
@@ -261,12 +302,12 @@ func (m *mongoGenericRepository[TDataModel, TEntity]) GetByFilter(
 ) ([]TEntity, error) {
 	dataModelType := typeMapper.GetGenericTypeByT[TDataModel]()
 	modelType := typeMapper.GetGenericTypeByT[TEntity]()
-	collection := m.db.Database(m.databaseName).Collection(m.collectionName)
+	collection := m.listCollection()
 
 	// we could use also bson.D{} for filtering, it is also a map
 	cursorResult, err := collection.Find(ctx, filters)
 	if err != nil {
-		return nil, err
+		return nil, customErrors.WrapIfCanceled(ctx, err, "finding the entities canceled")
 	}
 
 	defer cursorResult.Close(ctx) // nolint: errcheck
@@ -282,6 +323,11 @@ func (m *mongoGenericRepository[TDataModel, TEntity]) GetByFilter(
 			models = append(models, e)
 		}
 
+		// `Next` returns false on the cancellation, so the cursor error should be checked
+		if err := cursorResult.Err(); err != nil {
+			return nil, customErrors.WrapIfCanceled(ctx, err, "iterating the entities canceled")
+		}
+
 		return models, nil
 	} else {
 		var dataModels []TDataModel
@@ -294,6 +340,11 @@ func (m *mongoGenericRepository[TDataModel, TEntity]) GetByFilter(
 			dataModels = append(dataModels, d)
 		}
 
+		// `Next` returns false on the cancellation, so the cursor error should be checked
+		if err := cursorResult.Err(); err != nil {
+			return nil, customErrors.WrapIfCanceled(ctx, err, "iterating the entities canceled")
+		}
+
 		models, err := mapper.Map[[]TEntity](dataModels)
 		if err != nil {
 			return nil, err
@@ -302,6 +353,58 @@ func (m *mongoGenericRepository[TDataModel, TEntity]) GetByFilter(
 	}
 }
 
+func (m *mongoGenericRepository[TDataModel, TEntity]) Iterate(
+	ctx context.Context,
+	filters map[string]interface{},
+	fn func(entity TEntity) error,
+) error {
+	dataModelType := typeMapper.GetGenericTypeByT[TDataModel]()
+	modelType := typeMapper.GetGenericTypeByT[TEntity]()
+	collection := m.listCollection()
+
+	if filters == nil {
+		filters = map[string]interface{}{}
+	}
+
+	// https://www.mongodb.com/docs/drivers/go/current/fundamentals/crud/read-operations/cursor/#retrieve-documents-individually
+	cursorResult, err := collection.Find(ctx, filters)
+	if err != nil {
+		return customErrors.WrapIfCanceled(ctx, err, "iterating the entities canceled")
+	}
+	defer cursorResult.Close(ctx) // nolint: errcheck
+
+	for cursorResult.Next(ctx) {
+		// rows which are already fetched by the driver don't observe the cancellation
+		if err := customErrors.CheckContext(ctx, "iterating the entities canceled"); err != nil {
+			return err
+		}
+
+		var entity TEntity
+		if modelType == dataModelType {
+			if err := cursorResult.Decode(&entity); err != nil {
+				return errors.WrapIf(err, "Iterate")
+			}
+		} else {
+			var d TDataModel
+			if err := cursorResult.Decode(&d); err != nil {
+				return errors.WrapIf(err, "Iterate")
+			}
+
+			entity, err = mapper.Map[TEntity](d)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := fn(entity); err != nil {
+			return err
+		}
+	}
+
+	// `Next` returns false on the cancellation, so the cursor error should be checked
+	return customErrors.WrapIfCanceled(ctx, cursorResult.Err(), "iterating the entities canceled")
+}
+
 func (m *mongoGenericRepository[TDataModel, TEntity]) GetByFuncFilter(
 	ctx context.Context,
 	filterFunc func(TEntity) bool,
@@ -315,7 +418,7 @@ func (m *mongoGenericRepository[TDataModel, TEntity]) FirstOrDefault(
 ) (TEntity, error) {
 	dataModelType := typeMapper.GetGenericTypeByT[TDataModel]()
 	modelType := typeMapper.GetGenericTypeByT[TEntity]()
-	collection := m.db.Database(m.databaseName).Collection(m.collectionName)
+	collection := m.collection()
 
 	if modelType == dataModelType {
 		var model TEntity
@@ -325,7 +428,7 @@ func (m *mongoGenericRepository[TDataModel, TEntity]) FirstOrDefault(
 			if err == mongo.ErrNoDocuments {
 				return *new(TEntity), nil
 			}
-			return *new(TEntity), err
+			return *new(TEntity), customErrors.WrapIfCanceled(ctx, err, "loading the entity canceled")
 		}
 
 		return model, nil
@@ -336,7 +439,7 @@ func (m *mongoGenericRepository[TDataModel, TEntity]) FirstOrDefault(
 			if err == mongo.ErrNoDocuments {
 				return *new(TEntity), nil
 			}
-			return *new(TEntity), err
+			return *new(TEntity), customErrors.WrapIfCanceled(ctx, err, "loading the entity canceled")
 		}
 
 		model, err := mapper.Map[TEntity](dataModel)
@@ -353,7 +456,7 @@ func (m *mongoGenericRepository[TDataModel, TEntity]) Update(
 ) error {
 	dataModelType := typeMapper.GetGenericTypeByT[TDataModel]()
 	modelType := typeMapper.GetGenericTypeByT[TEntity]()
-	collection := m.db.Database(m.databaseName).Collection(m.collectionName)
+	collection := m.collection()
 	ops := options.FindOneAndUpdate()
 	ops.SetReturnDocument(options.After)
 	ops.SetUpsert(true)
@@ -371,7 +474,7 @@ func (m *mongoGenericRepository[TDataModel, TEntity]) Update(
 		var updated TEntity
 		// https://www.mongodb.com/docs/manual/reference/method/db.collection.findOneAndUpdate/
 		if err := collection.FindOneAndUpdate(ctx, bson.M{"_id": id}, bson.M{"$set": entity}, ops).Decode(&updated); err != nil {
-			return err
+			return customErrors.WrapIfCanceled(ctx, err, "updating the entity canceled")
 		}
 	} else {
 		dataModel, err := mapper.Map[TDataModel](entity)
@@ -389,7 +492,7 @@ func (m *mongoGenericRepository[TDataModel, TEntity]) Update(
 		}
 		// https://www.mongodb.com/docs/manual/reference/method/db.collection.findOneAndUpdate/
 		if err := collection.FindOneAndUpdate(ctx, bson.M{"_id": id}, bson.M{"$set": dataModel}, ops).Decode(&dataModel); err != nil {
-			return err
+			return customErrors.WrapIfCanceled(ctx, err, "updating the entity canceled")
 		}
 
 		e, err := mapper.Map[TEntity](dataModel)
@@ -407,6 +510,11 @@ func (m *mongoGenericRepository[TDataModel, TEntity]) UpdateAll(
 	entities []TEntity,
 ) error {
 	for _, e := range entities {
+		// stop the bulk operation between the items, when the context is canceled
+		if err := customErrors.CheckContext(ctx, "updating the entities canceled"); err != nil {
+			return err
+		}
+
 		err := m.Update(ctx, e)
 		if err != nil {
 			return err
@@ -420,10 +528,10 @@ func (m *mongoGenericRepository[TDataModel, TEntity]) Delete(
 	ctx context.Context,
 	id uuid.UUID,
 ) error {
-	collection := m.db.Database(m.databaseName).Collection(m.collectionName)
+	collection := m.collection()
 
 	if err := collection.FindOneAndDelete(ctx, bson.M{"_id": id.String()}).Err(); err != nil {
-		return err
+		return customErrors.WrapIfCanceled(ctx, err, "deleting the entity canceled")
 	}
 
 	return nil
@@ -436,7 +544,7 @@ func (m *mongoGenericRepository[TDataModel, TEntity]) SkipTake(
 ) ([]TEntity, error) {
 	dataModelType := typeMapper.GetGenericTypeByT[TDataModel]()
 	modelType := typeMapper.GetGenericTypeByT[TEntity]()
-	collection := m.db.Database(m.databaseName).Collection(m.collectionName)
+	collection := m.listCollection()
 	l := int64(take)
 	s := int64(skip)
 
@@ -445,7 +553,7 @@ func (m *mongoGenericRepository[TDataModel, TEntity]) SkipTake(
 		Skip:  &s,
 	})
 	if err != nil {
-		return nil, err
+		return nil, customErrors.WrapIfCanceled(ctx, err, "loading the entities canceled")
 	}
 	defer cursorResult.Close(ctx) // nolint: errcheck
 
@@ -459,6 +567,11 @@ func (m *mongoGenericRepository[TDataModel, TEntity]) SkipTake(
 			models = append(models, e)
 		}
 
+		// `Next` returns false on the cancellation, so the cursor error should be checked
+		if err := cursorResult.Err(); err != nil {
+			return nil, customErrors.WrapIfCanceled(ctx, err, "iterating the entities canceled")
+		}
+
 		return models, nil
 	} else {
 		var dataModels []TDataModel
@@ -469,6 +582,11 @@ func (m *mongoGenericRepository[TDataModel, TEntity]) SkipTake(
 			}
 			dataModels = append(dataModels, d)
 		}
+
+		// `Next` returns false on the cancellation, so the cursor error should be checked
+		if err := cursorResult.Err(); err != nil {
+			return nil, customErrors.WrapIfCanceled(ctx, err, "iterating the entities canceled")
+		}
 		models, err := mapper.Map[[]TEntity](dataModels)
 		if err != nil {
 			return nil, err
@@ -480,7 +598,7 @@ func (m *mongoGenericRepository[TDataModel, TEntity]) SkipTake(
 func (m *mongoGenericRepository[TDataModel, TEntity]) Count(
 	ctx context.Context,
 ) int64 {
-	collection := m.db.Database(m.databaseName).Collection(m.collectionName)
+	collection := m.collection()
 	count, err := collection.CountDocuments(ctx, bson.M{})
 	if err != nil {
 		return 0
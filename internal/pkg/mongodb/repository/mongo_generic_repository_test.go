@@ -346,6 +346,62 @@ func (c *mongoGenericRepositoryTest) Test_GetByFilter_With_Data_Model() {
 	c.Assert().Equal(len(models), 1)
 }
 
+func (c *mongoGenericRepositoryTest) Test_Iterate() {
+	ctx := context.Background()
+
+	var names []string
+	err := c.productRepository.Iterate(
+		ctx,
+		nil,
+		func(product *ProductMongo) error {
+			names = append(names, product.Name)
+
+			return nil
+		},
+	)
+	c.Require().NoError(err)
+
+	c.Assert().Equal(len(c.products), len(names))
+}
+
+func (c *mongoGenericRepositoryTest) Test_Iterate_With_Data_Model() {
+	ctx := context.Background()
+
+	var names []string
+	err := c.productRepositoryWithDataModel.Iterate(
+		ctx,
+		map[string]interface{}{"name": c.products[0].Name},
+		func(product *Product) error {
+			names = append(names, product.Name)
+
+			return nil
+		},
+	)
+	c.Require().NoError(err)
+
+	c.Assert().Equal([]string{c.products[0].Name}, names)
+}
+
+func (c *mongoGenericRepositoryTest) Test_Iterate_Canceled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	count := 0
+	err := c.productRepository.Iterate(
+		ctx,
+		nil,
+		func(product *ProductMongo) error {
+			count++
+			cancel()
+
+			return nil
+		},
+	)
+
+	c.Assert().True(customErrors.IsCanceledError(err))
+	c.Assert().Equal(1, count)
+}
+
 func (c *mongoGenericRepositoryTest) Test_Update() {
 	ctx := context.Background()
 
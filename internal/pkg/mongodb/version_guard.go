@@ -0,0 +1,21 @@
+package mongodb
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// VersionGuardFilter matches the documents of the filter only when their version field is older than the version of
+// the event, so the write of a duplicate or a stale event doesn't match the document even when a concurrent consumer
+// applies a newer event between the read and the write. the documents without the version field, which are written
+// before the version guards, are matched for any event version.
+func VersionGuardFilter(filter bson.M, versionField string, eventVersion int64) bson.M {
+	return bson.M{
+		"$and": bson.A{
+			filter,
+			bson.M{"$or": bson.A{
+				bson.M{versionField: bson.M{"$lt": eventVersion}},
+				bson.M{versionField: bson.M{"$exists": false}},
+			}},
+		},
+	}
+}
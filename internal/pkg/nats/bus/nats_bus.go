@@ -0,0 +1,303 @@
+package bus
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/bus"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/claimcheck"
+	consumer2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/producer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/nats/configurations"
+	natsconsumer "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/nats/consumer"
+	consumerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/nats/consumer/configurations"
+	natsproducer "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/nats/producer"
+	producerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/nats/producer/configurations"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"emperror.dev/errors"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/samber/lo"
+)
+
+type NatsBus interface {
+	bus.Bus
+	consumerConfigurations.NatsConsumerConnector
+	// Configuration returns the producers and the consumers configurations of the bus, including the consumers
+	// connected after creating the bus
+	Configuration() *configurations.NatsConfiguration
+}
+
+type natsBus struct {
+	messageTypeConsumers    map[reflect.Type][]consumer2.Consumer
+	producer                producer.Producer
+	natsConfiguration       *configurations.NatsConfiguration
+	jetStream               jetstream.JetStream
+	logger                  logger.Logger
+	messageSerializer       serializer.MessageSerializer
+	claimCheck              claimcheck.ClaimCheck
+	isConsumedNotifications []func(message types.IMessage)
+	isProducedNotifications []func(message types.IMessage)
+}
+
+func NewNatsBus(
+	logger logger.Logger,
+	jetStream jetstream.JetStream,
+	messageSerializer serializer.MessageSerializer,
+	claimCheck claimcheck.ClaimCheck,
+	natsBuilderFunc configurations.NatsConfigurationBuilderFuc,
+) (NatsBus, error) {
+	builder := configurations.NewNatsConfigurationBuilder()
+	if natsBuilderFunc != nil {
+		natsBuilderFunc(builder)
+	}
+
+	natsBus := &natsBus{
+		logger:               logger,
+		jetStream:            jetStream,
+		messageSerializer:    messageSerializer,
+		claimCheck:           claimCheck,
+		natsConfiguration:    builder.Build(),
+		messageTypeConsumers: map[reflect.Type][]consumer2.Consumer{},
+	}
+
+	producersConfigurationMap := make(
+		map[string]*producerConfigurations.NatsProducerConfiguration,
+	)
+	lo.ForEach(
+		natsBus.natsConfiguration.ProducersConfigurations,
+		func(config *producerConfigurations.NatsProducerConfiguration, index int) {
+			key := config.ProducerMessageType.String()
+			producersConfigurationMap[key] = config
+		},
+	)
+
+	for _, consumerConfiguration := range natsBus.natsConfiguration.ConsumersConfigurations {
+		if err := natsBus.addConsumer(consumerConfiguration); err != nil {
+			return nil, err
+		}
+	}
+
+	natsProducer, err := natsproducer.NewNatsProducer(
+		jetStream,
+		producersConfigurationMap,
+		logger,
+		messageSerializer,
+		claimCheck,
+		// IsProduced Notification
+		func(message types.IMessage) {
+			for _, notification := range natsBus.isProducedNotifications {
+				if notification != nil {
+					notification(message)
+				}
+			}
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	natsBus.producer = natsProducer
+
+	return natsBus, nil
+}
+
+func (n *natsBus) addConsumer(
+	consumerConfiguration *consumerConfigurations.NatsConsumerConfiguration,
+) error {
+	natsConsumer, err := natsconsumer.NewNatsConsumer(
+		n.jetStream,
+		consumerConfiguration,
+		n.messageSerializer,
+		n.logger,
+		n.claimCheck,
+		// IsConsumed Notification
+		func(message types.IMessage) {
+			for _, notification := range n.isConsumedNotifications {
+				if notification != nil {
+					notification(message)
+				}
+			}
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	n.messageTypeConsumers[consumerConfiguration.ConsumerMessageType] = append(
+		n.messageTypeConsumers[consumerConfiguration.ConsumerMessageType],
+		natsConsumer,
+	)
+
+	return nil
+}
+
+func (n *natsBus) IsConsumed(h func(message types.IMessage)) {
+	n.isConsumedNotifications = append(n.isConsumedNotifications, h)
+}
+
+func (n *natsBus) IsProduced(h func(message types.IMessage)) {
+	n.isProducedNotifications = append(n.isProducedNotifications, h)
+}
+
+// ConnectConsumer Add a new consumer to existing message type consumers. if there is no consumer, will create a new consumer for the message type
+func (n *natsBus) ConnectConsumer(
+	messageType types.IMessage,
+	consumer consumer2.Consumer,
+) error {
+	typeName := utils.GetMessageBaseReflectType(messageType)
+
+	n.messageTypeConsumers[typeName] = append(
+		n.messageTypeConsumers[typeName],
+		consumer,
+	)
+
+	return nil
+}
+
+// ConnectNatsConsumer Add a new consumer to existing message type consumers. if there is no consumer, will create a new consumer for the message type
+func (n *natsBus) ConnectNatsConsumer(
+	messageType types.IMessage,
+	consumerBuilderFunc consumerConfigurations.NatsConsumerConfigurationBuilderFuc,
+) error {
+	builder := consumerConfigurations.NewNatsConsumerConfigurationBuilder(messageType)
+	if consumerBuilderFunc != nil {
+		consumerBuilderFunc(builder)
+	}
+	consumerConfig := builder.Build()
+
+	if err := n.addConsumer(consumerConfig); err != nil {
+		return err
+	}
+
+	n.natsConfiguration.ConsumersConfigurations = append(
+		n.natsConfiguration.ConsumersConfigurations,
+		consumerConfig,
+	)
+
+	return nil
+}
+
+// ConnectConsumerHandler Add handler to existing consumer. creates new consumer if not exist
+func (n *natsBus) ConnectConsumerHandler(
+	messageType types.IMessage,
+	consumerHandler consumer2.ConsumerHandler,
+) error {
+	typeName := utils.GetMessageBaseReflectType(messageType)
+
+	// if there is a consumer for a message type, we should add handler to existing consumers
+	if consumersForType := n.messageTypeConsumers[typeName]; consumersForType != nil {
+		for _, c := range consumersForType {
+			c.ConnectHandler(consumerHandler)
+		}
+
+		return nil
+	}
+
+	// if there is no consumer for a message type, we should create new one and add handler to the consumer
+	return n.ConnectNatsConsumer(
+		messageType,
+		func(builder consumerConfigurations.NatsConsumerConfigurationBuilder) {
+			builder.WithHandlers(func(builder consumer2.ConsumerHandlerConfigurationBuilder) {
+				builder.AddHandler(consumerHandler)
+			})
+		},
+	)
+}
+
+func (n *natsBus) Configuration() *configurations.NatsConfiguration {
+	return n.natsConfiguration
+}
+
+func (n *natsBus) Start(ctx context.Context) error {
+	for messageType, consumers := range n.messageTypeConsumers {
+		name := typeMapper.GetTypeNameByType(messageType)
+		n.logger.Info(fmt.Sprintf("consuming message type %s", name))
+		for _, natsConsumer := range consumers {
+			err := natsConsumer.Start(ctx)
+			if err != nil {
+				n.logger.Error(
+					fmt.Sprintf(
+						"error in consumer %s, with err: %v",
+						natsConsumer.GetName(),
+						err,
+					),
+				)
+				err2 := n.Stop()
+				if err2 != nil {
+					return errors.WrapIf(err, err2.Error())
+				}
+				return err
+			}
+			n.logger.Info(
+				fmt.Sprintf("consumer %s, started", natsConsumer.GetName()),
+			)
+		}
+	}
+
+	return nil
+}
+
+func (n *natsBus) Stop() error {
+	waitGroup := sync.WaitGroup{}
+
+	for _, consumers := range n.messageTypeConsumers {
+		for _, c := range consumers {
+			waitGroup.Add(1)
+
+			go func(c consumer2.Consumer) {
+				defer waitGroup.Done()
+
+				err := c.Stop()
+				if err != nil {
+					n.logger.Errorf("error in stopping consumer %s: %v", c.GetName(), err)
+				}
+			}(c)
+		}
+	}
+	waitGroup.Wait()
+
+	return nil
+}
+
+func (n *natsBus) PublishMessage(
+	ctx context.Context,
+	message types.IMessage,
+	meta metadata.Metadata,
+) error {
+	return n.producer.PublishMessage(ctx, message, meta)
+}
+
+func (n *natsBus) PublishMessageWithTopicName(
+	ctx context.Context,
+	message types.IMessage,
+	meta metadata.Metadata,
+	topicOrExchangeName string,
+) error {
+	return n.producer.PublishMessageWithTopicName(
+		ctx,
+		message,
+		meta,
+		topicOrExchangeName,
+	)
+}
+
+func (n *natsBus) PublishMessageWithDelay(
+	ctx context.Context,
+	message types.IMessage,
+	meta metadata.Metadata,
+	delay time.Duration,
+) error {
+	return n.producer.PublishMessageWithDelay(ctx, message, meta, delay)
+}
+
+func (n *natsBus) PublishMessages(ctx context.Context, messages []types.IMessage) error {
+	return n.producer.PublishMessages(ctx, messages)
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+type NatsOptions struct {
+	// Urls is the list of the nats servers in `nats://host:port` form.
+	Urls       []string `mapstructure:"urls"`
+	ClientName string   `mapstructure:"clientName"`
+	AutoStart  bool     `mapstructure:"autoStart"  default:"true"`
+	// AutoProvisionStreams creates or updates the streams of the producers and the consumers on start, each subject
+	// is kept in a stream with the same name unless the consumer sets its stream.
+	AutoProvisionStreams bool `mapstructure:"autoProvisionStreams" default:"true"`
+	// StreamStorage is the storage of the provisioned streams, `file` or `memory`.
+	StreamStorage  string `mapstructure:"streamStorage"  default:"file"`
+	StreamReplicas int    `mapstructure:"streamReplicas" default:"1"`
+	// StreamMaxAge is how long a message is kept in the provisioned streams, zero keeps the messages until the limits
+	// of the stream.
+	StreamMaxAge time.Duration `mapstructure:"streamMaxAge"`
+	// DuplicateWindow is the window in which the stream drops a message with an already published message id.
+	DuplicateWindow time.Duration `mapstructure:"duplicateWindow" default:"2m"`
+	// ReconnectWait is the wait between the reconnect attempts, the connection reconnects until it is closed.
+	ReconnectWait time.Duration `mapstructure:"reconnectWait" default:"2s"`
+	// ContentType is the content type of the published messages, `application/json`, `application/x-protobuf`,
+	// `application/avro` or `application/msgpack`, the consumers deserialize the messages by their own content types.
+	ContentType string `mapstructure:"contentType" default:"application/json"`
+}
+
+func ProvideConfig(environment environment.Environment) (*NatsOptions, error) {
+	optionName := strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[NatsOptions]())
+	cfg, err := config.BindConfigKey[*NatsOptions](optionName, environment)
+
+	return cfg, err
+}
@@ -0,0 +1,38 @@
+package configurations
+
+import (
+	consumerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/nats/consumer/configurations"
+	producerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/nats/producer/configurations"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/nats/types"
+)
+
+type NatsConfiguration struct {
+	ProducersConfigurations []*producerConfigurations.NatsProducerConfiguration
+	ConsumersConfigurations []*consumerConfigurations.NatsConsumerConfiguration
+}
+
+// Streams returns the streams of the producers and the consumers with their distinct subjects, including the streams
+// of the dead-letter subjects
+func (c *NatsConfiguration) Streams() map[string][]string {
+	streams := map[string][]string{}
+	seen := map[string]bool{}
+
+	add := func(stream string, subject string) {
+		if subject == "" || seen[stream+"/"+subject] {
+			return
+		}
+		seen[stream+"/"+subject] = true
+		streams[stream] = append(streams[stream], subject)
+	}
+
+	for _, producer := range c.ProducersConfigurations {
+		add(producer.StreamName(), producer.Subject)
+	}
+
+	for _, consumer := range c.ConsumersConfigurations {
+		add(consumer.StreamName(), consumer.Subject)
+		add(types.StreamName(consumer.DeadLetterSubject), consumer.DeadLetterSubject)
+	}
+
+	return streams
+}
@@ -0,0 +1,82 @@
+package configurations
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	consumerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/nats/consumer/configurations"
+	producerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/nats/producer/configurations"
+
+	"github.com/samber/lo"
+)
+
+type NatsConfigurationBuilder interface {
+	AddProducer(
+		producerMessageType types.IMessage,
+		producerBuilderFunc producerConfigurations.NatsProducerConfigurationBuilderFuc,
+	) NatsConfigurationBuilder
+	AddConsumer(
+		consumerMessageType types.IMessage,
+		consumerBuilderFunc consumerConfigurations.NatsConsumerConfigurationBuilderFuc,
+	) NatsConfigurationBuilder
+	Build() *NatsConfiguration
+}
+
+type natsConfigurationBuilder struct {
+	natsConfiguration *NatsConfiguration
+	consumerBuilders  []consumerConfigurations.NatsConsumerConfigurationBuilder
+	producerBuilders  []producerConfigurations.NatsProducerConfigurationBuilder
+}
+
+func NewNatsConfigurationBuilder() NatsConfigurationBuilder {
+	return &natsConfigurationBuilder{
+		natsConfiguration: &NatsConfiguration{},
+	}
+}
+
+func (k *natsConfigurationBuilder) AddProducer(
+	producerMessageType types.IMessage,
+	producerBuilderFunc producerConfigurations.NatsProducerConfigurationBuilderFuc,
+) NatsConfigurationBuilder {
+	builder := producerConfigurations.NewNatsProducerConfigurationBuilder(producerMessageType)
+	if producerBuilderFunc != nil {
+		producerBuilderFunc(builder)
+	}
+
+	k.producerBuilders = append(k.producerBuilders, builder)
+
+	return k
+}
+
+func (k *natsConfigurationBuilder) AddConsumer(
+	consumerMessageType types.IMessage,
+	consumerBuilderFunc consumerConfigurations.NatsConsumerConfigurationBuilderFuc,
+) NatsConfigurationBuilder {
+	builder := consumerConfigurations.NewNatsConsumerConfigurationBuilder(consumerMessageType)
+	if consumerBuilderFunc != nil {
+		consumerBuilderFunc(builder)
+	}
+
+	k.consumerBuilders = append(k.consumerBuilders, builder)
+
+	return k
+}
+
+func (k *natsConfigurationBuilder) Build() *NatsConfiguration {
+	consumersConfigs := lo.Map(
+		k.consumerBuilders,
+		func(builder consumerConfigurations.NatsConsumerConfigurationBuilder, index int) *consumerConfigurations.NatsConsumerConfiguration {
+			return builder.Build()
+		},
+	)
+
+	producersConfigs := lo.Map(
+		k.producerBuilders,
+		func(builder producerConfigurations.NatsProducerConfigurationBuilder, index int) *producerConfigurations.NatsProducerConfiguration {
+			return builder.Build()
+		},
+	)
+
+	k.natsConfiguration.ConsumersConfigurations = consumersConfigs
+	k.natsConfiguration.ProducersConfigurations = producersConfigs
+
+	return k.natsConfiguration
+}
@@ -0,0 +1,3 @@
+package configurations
+
+type NatsConfigurationBuilderFuc func(builder NatsConfigurationBuilder)
@@ -0,0 +1,3 @@
+package configurations
+
+type NatsConsumerConfigurationBuilderFuc func(builder NatsConsumerConfigurationBuilder)
@@ -0,0 +1,67 @@
+package configurations
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	consumer2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/pipeline"
+	types2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/nats/types"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+type NatsConsumerConfiguration struct {
+	Name                string
+	ConsumerMessageType reflect.Type
+	Pipelines           []pipeline.ConsumerPipeline
+	Handlers            []consumer2.ConsumerHandler
+	*consumer2.ConsumerOptions
+	Subject string
+	// Stream keeps the subject of the consumer, it defaults to the stream name of the subject.
+	Stream string
+	// Durable is the durable consumer of the stream, the instances of a service share the durable consumer and each
+	// message is handled by one of them.
+	Durable string
+	// AckPolicy is the ack policy of the durable consumer, with `AckNonePolicy` a failed message is not redelivered.
+	AckPolicy jetstream.AckPolicy
+	// AckWait is how long the server waits for the ack of a message before redelivering it.
+	AckWait time.Duration
+	// MaxDeliver is the number of times a message is delivered before it is dead-lettered or dropped.
+	MaxDeliver int
+	// ConcurrencyLimit is the number of the messages which are handled at once, it is also the maximum number of the
+	// messages which are delivered and not acked yet.
+	ConcurrencyLimit int
+	// DeadLetterSubject receives the messages which are failed on their last delivery.
+	DeadLetterSubject string
+}
+
+func NewDefaultNatsConsumerConfiguration(
+	messageType types2.IMessage,
+) *NatsConsumerConfiguration {
+	name := fmt.Sprintf("%s_consumer", utils.GetMessageName(messageType))
+
+	return &NatsConsumerConfiguration{
+		ConsumerOptions:     &consumer2.ConsumerOptions{ExitOnError: false, ConsumerId: ""},
+		ConcurrencyLimit:    1,
+		AckPolicy:           jetstream.AckExplicitPolicy,
+		AckWait:             30 * time.Second,
+		MaxDeliver:          5,
+		Subject:             utils.GetTopicOrExchangeName(messageType),
+		Durable:             utils.GetQueueName(messageType),
+		ConsumerMessageType: utils.GetMessageBaseReflectType(messageType),
+		Name:                name,
+	}
+}
+
+// StreamName returns the stream of the consumer subject
+func (c *NatsConsumerConfiguration) StreamName() string {
+	if c.Stream != "" {
+		return c.Stream
+	}
+
+	return types.StreamName(c.Subject)
+}
@@ -0,0 +1,158 @@
+package configurations
+
+import (
+	"time"
+
+	messageConsumer "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/pipeline"
+	types2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+type NatsConsumerConfigurationBuilder interface {
+	WithHandlers(
+		consumerBuilderFunc messageConsumer.ConsumerHandlerConfigurationBuilderFunc,
+	) NatsConsumerConfigurationBuilder
+	WithPipelines(
+		pipelineBuilderFunc pipeline.ConsumerPipelineConfigurationBuilderFunc,
+	) NatsConsumerConfigurationBuilder
+	WithExitOnError(exitOnError bool) NatsConsumerConfigurationBuilder
+	WithConcurrencyLimit(limit int) NatsConsumerConfigurationBuilder
+	WithConsumerId(consumerId string) NatsConsumerConfigurationBuilder
+	WithSubject(subject string) NatsConsumerConfigurationBuilder
+	WithStreamName(streamName string) NatsConsumerConfigurationBuilder
+	WithDurable(durable string) NatsConsumerConfigurationBuilder
+	WithAckPolicy(ackPolicy jetstream.AckPolicy) NatsConsumerConfigurationBuilder
+	WithAckWait(ackWait time.Duration) NatsConsumerConfigurationBuilder
+	WithMaxDeliver(maxDeliver int) NatsConsumerConfigurationBuilder
+	WithDeadLetterSubject(subject string) NatsConsumerConfigurationBuilder
+	WithName(name string) NatsConsumerConfigurationBuilder
+	Build() *NatsConsumerConfiguration
+}
+
+type natsConsumerConfigurationBuilder struct {
+	natsConsumerConfigurations *NatsConsumerConfiguration
+	pipelinesBuilder           pipeline.ConsumerPipelineConfigurationBuilder
+	handlersBuilder            messageConsumer.ConsumerHandlerConfigurationBuilder
+}
+
+func NewNatsConsumerConfigurationBuilder(
+	messageType types2.IMessage,
+) NatsConsumerConfigurationBuilder {
+	return &natsConsumerConfigurationBuilder{
+		natsConsumerConfigurations: NewDefaultNatsConsumerConfiguration(messageType),
+	}
+}
+
+func (b *natsConsumerConfigurationBuilder) WithPipelines(
+	pipelineBuilderFunc pipeline.ConsumerPipelineConfigurationBuilderFunc,
+) NatsConsumerConfigurationBuilder {
+	builder := pipeline.NewConsumerPipelineConfigurationBuilder()
+	if pipelineBuilderFunc != nil {
+		pipelineBuilderFunc(builder)
+	}
+	b.pipelinesBuilder = builder
+
+	return b
+}
+
+func (b *natsConsumerConfigurationBuilder) WithHandlers(
+	consumerBuilderFunc messageConsumer.ConsumerHandlerConfigurationBuilderFunc,
+) NatsConsumerConfigurationBuilder {
+	builder := messageConsumer.NewConsumerHandlersConfigurationBuilder()
+	if consumerBuilderFunc != nil {
+		consumerBuilderFunc(builder)
+	}
+	b.handlersBuilder = builder
+
+	return b
+}
+
+func (b *natsConsumerConfigurationBuilder) WithExitOnError(
+	exitOnError bool,
+) NatsConsumerConfigurationBuilder {
+	b.natsConsumerConfigurations.ExitOnError = exitOnError
+	return b
+}
+
+func (b *natsConsumerConfigurationBuilder) WithName(
+	name string,
+) NatsConsumerConfigurationBuilder {
+	b.natsConsumerConfigurations.Name = name
+	return b
+}
+
+func (b *natsConsumerConfigurationBuilder) WithConcurrencyLimit(
+	limit int,
+) NatsConsumerConfigurationBuilder {
+	b.natsConsumerConfigurations.ConcurrencyLimit = limit
+	return b
+}
+
+func (b *natsConsumerConfigurationBuilder) WithConsumerId(
+	consumerId string,
+) NatsConsumerConfigurationBuilder {
+	b.natsConsumerConfigurations.ConsumerId = consumerId
+	return b
+}
+
+func (b *natsConsumerConfigurationBuilder) WithSubject(
+	subject string,
+) NatsConsumerConfigurationBuilder {
+	b.natsConsumerConfigurations.Subject = subject
+	return b
+}
+
+func (b *natsConsumerConfigurationBuilder) WithStreamName(
+	streamName string,
+) NatsConsumerConfigurationBuilder {
+	b.natsConsumerConfigurations.Stream = streamName
+	return b
+}
+
+func (b *natsConsumerConfigurationBuilder) WithDurable(
+	durable string,
+) NatsConsumerConfigurationBuilder {
+	b.natsConsumerConfigurations.Durable = durable
+	return b
+}
+
+func (b *natsConsumerConfigurationBuilder) WithAckPolicy(
+	ackPolicy jetstream.AckPolicy,
+) NatsConsumerConfigurationBuilder {
+	b.natsConsumerConfigurations.AckPolicy = ackPolicy
+	return b
+}
+
+func (b *natsConsumerConfigurationBuilder) WithAckWait(
+	ackWait time.Duration,
+) NatsConsumerConfigurationBuilder {
+	b.natsConsumerConfigurations.AckWait = ackWait
+	return b
+}
+
+func (b *natsConsumerConfigurationBuilder) WithMaxDeliver(
+	maxDeliver int,
+) NatsConsumerConfigurationBuilder {
+	b.natsConsumerConfigurations.MaxDeliver = maxDeliver
+	return b
+}
+
+func (b *natsConsumerConfigurationBuilder) WithDeadLetterSubject(
+	subject string,
+) NatsConsumerConfigurationBuilder {
+	b.natsConsumerConfigurations.DeadLetterSubject = subject
+	return b
+}
+
+func (b *natsConsumerConfigurationBuilder) Build() *NatsConsumerConfiguration {
+	if b.pipelinesBuilder != nil {
+		b.natsConsumerConfigurations.Pipelines = b.pipelinesBuilder.Build().Pipelines
+	}
+	if b.handlersBuilder != nil {
+		b.natsConsumerConfigurations.Handlers = b.handlersBuilder.Build().Handlers
+	}
+
+	return b.natsConsumerConfigurations
+}
@@ -0,0 +1,381 @@
+package consumer
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/claimcheck"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	messageHeader "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/messageheader"
+	consumertracing "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/otel/tracing/consumer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/pipeline"
+	messagingTypes "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/nats/consumer/configurations"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/nats/types"
+	errorutils "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils/errorutils"
+
+	"emperror.dev/errors"
+	"github.com/avast/retry-go"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	retryAttempts = 3
+	retryDelay    = 300 * time.Millisecond
+	// DeadLetterErrorHeader keeps the error of a dead-lettered message
+	DeadLetterErrorHeader = "x-exception"
+	// DeadLetterSubjectHeader keeps the source subject of a dead-lettered message
+	DeadLetterSubjectHeader = "x-original-subject"
+)
+
+var retryOptions = []retry.Option{
+	retry.Attempts(retryAttempts),
+	retry.Delay(retryDelay),
+	retry.DelayType(retry.BackOffDelay),
+}
+
+// natsConsumer consumes a subject with a durable pull consumer of its stream. a message which is failed after the
+// retries is negatively acked and redelivered by the server until `MaxDeliver`, on its last delivery it is published
+// to the dead-letter subject of the consumer or dropped.
+type natsConsumer struct {
+	natsConsumerOptions     *configurations.NatsConsumerConfiguration
+	jetStream               jetstream.JetStream
+	consumeContext          jetstream.ConsumeContext
+	messageSerializer       serializer.MessageSerializer
+	logger                  logger.Logger
+	handlers                []consumer.ConsumerHandler
+	pipelines               []pipeline.ConsumerPipeline
+	isConsumedNotifications []func(message messagingTypes.IMessage)
+	cancel                  context.CancelFunc
+	waitGroup               sync.WaitGroup
+}
+
+// NewNatsConsumer create a new generic NATS JetStream consumer
+func NewNatsConsumer(
+	jetStream jetstream.JetStream,
+	consumerConfiguration *configurations.NatsConsumerConfiguration,
+	messageSerializer serializer.MessageSerializer,
+	logger logger.Logger,
+	claimCheck claimcheck.ClaimCheck,
+	isConsumedNotifications ...func(message messagingTypes.IMessage),
+) (consumer.Consumer, error) {
+	if consumerConfiguration == nil {
+		return nil, errors.New("consumer configuration is required")
+	}
+
+	if consumerConfiguration.ConsumerMessageType == nil {
+		return nil, errors.New(
+			"consumer ConsumerMessageType property is required",
+		)
+	}
+
+	pipelines := consumerConfiguration.Pipelines
+	if claimCheck != nil {
+		// the claim-check references are resolved before the other pipelines, so all of them receive the actual message
+		pipelines = append(
+			[]pipeline.ConsumerPipeline{claimcheck.NewClaimCheckPipeline(claimCheck, messageSerializer)},
+			pipelines...,
+		)
+	}
+
+	cons := &natsConsumer{
+		natsConsumerOptions: consumerConfiguration,
+		jetStream:           jetStream,
+		messageSerializer:   messageSerializer,
+		logger:              logger,
+		handlers:            consumerConfiguration.Handlers,
+		pipelines:           pipelines,
+	}
+
+	cons.isConsumedNotifications = isConsumedNotifications
+
+	return cons, nil
+}
+
+func (n *natsConsumer) IsConsumed(h func(message messagingTypes.IMessage)) {
+	n.isConsumedNotifications = append(n.isConsumedNotifications, h)
+}
+
+func (n *natsConsumer) Start(ctx context.Context) error {
+	concurrency := n.natsConsumerOptions.ConcurrencyLimit
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	consumerConfig := jetstream.ConsumerConfig{
+		Durable:       n.natsConsumerOptions.Durable,
+		FilterSubject: n.natsConsumerOptions.Subject,
+		DeliverPolicy: jetstream.DeliverAllPolicy,
+		AckPolicy:     n.natsConsumerOptions.AckPolicy,
+		AckWait:       n.natsConsumerOptions.AckWait,
+		MaxDeliver:    n.natsConsumerOptions.MaxDeliver,
+	}
+	if n.natsConsumerOptions.AckPolicy != jetstream.AckNonePolicy {
+		// the server doesn't deliver more messages than the handling routines until the previous ones are acked
+		consumerConfig.MaxAckPending = concurrency
+	}
+
+	streamName := n.natsConsumerOptions.StreamName()
+
+	jsConsumer, err := n.jetStream.CreateOrUpdateConsumer(ctx, streamName, consumerConfig)
+	if err != nil {
+		return errors.WrapIff(
+			err,
+			"error in creating the durable consumer %s of the stream %s",
+			n.natsConsumerOptions.Durable,
+			streamName,
+		)
+	}
+
+	ctx, n.cancel = context.WithCancel(ctx)
+
+	messages := make(chan jetstream.Msg)
+	for i := 0; i < concurrency; i++ {
+		n.waitGroup.Add(1)
+		go func() {
+			defer n.waitGroup.Done()
+			defer errorutils.HandlePanic()
+
+			for {
+				select {
+				case msg := <-messages:
+					n.handleReceived(ctx, streamName, msg)
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	n.consumeContext, err = jsConsumer.Consume(
+		func(msg jetstream.Msg) {
+			// the messages which are not handled before stopping are redelivered after their ack wait
+			select {
+			case messages <- msg:
+			case <-ctx.Done():
+			}
+		},
+		jetstream.ConsumeErrHandler(func(consumeCtx jetstream.ConsumeContext, err error) {
+			n.logger.Errorf("error in consuming the subject %s: %v", n.natsConsumerOptions.Subject, err)
+		}),
+	)
+	if err != nil {
+		n.cancel()
+		n.waitGroup.Wait()
+
+		return errors.WrapIff(err, "error in consuming the subject %s", n.natsConsumerOptions.Subject)
+	}
+
+	return nil
+}
+
+// Stop stops receiving the messages and waits for the messages in handling
+func (n *natsConsumer) Stop() error {
+	if n.consumeContext != nil {
+		n.consumeContext.Stop()
+	}
+
+	if n.cancel != nil {
+		n.cancel()
+	}
+
+	n.waitGroup.Wait()
+
+	return nil
+}
+
+func (n *natsConsumer) ConnectHandler(handler consumer.ConsumerHandler) {
+	n.handlers = append(n.handlers, handler)
+}
+
+func (n *natsConsumer) GetName() string {
+	return n.natsConsumerOptions.Name
+}
+
+func (n *natsConsumer) handleReceived(ctx context.Context, streamName string, msg jetstream.Msg) {
+	meta := types.HeadersToMetadata(msg.Headers())
+
+	var deliveryTag uint64
+	var numDelivered uint64
+	if msgMetadata, err := msg.Metadata(); err == nil {
+		deliveryTag = msgMetadata.Sequence.Stream
+		numDelivered = msgMetadata.NumDelivered
+	}
+
+	consumerTraceOption := &consumertracing.ConsumerTracingOptions{
+		MessagingSystem: "nats",
+		DestinationKind: "subject",
+		Destination:     msg.Subject(),
+		OtherAttributes: []attribute.KeyValue{
+			types.MessagingNatsStream.String(streamName),
+			attribute.String("messaging.nats.durable", n.natsConsumerOptions.Durable),
+			attribute.Int64("messaging.nats.num_delivered", int64(numDelivered)),
+		},
+	}
+	ctx, beforeConsumeSpan := consumertracing.StartConsumerSpan(
+		ctx,
+		&meta,
+		string(msg.Data()),
+		consumerTraceOption,
+	)
+
+	message, err := n.deserializeMessage(
+		msg,
+		messageHeader.GetMessageType(meta),
+		meta.GetString(messageHeader.ContentType),
+	)
+	if err != nil {
+		// a message which can't be deserialized is failed on all of its deliveries
+		n.logger.Error(consumertracing.FinishConsumerSpan(beforeConsumeSpan, err))
+		n.deadLetter(ctx, msg, err)
+		n.settle(msg, msg.Term)
+
+		return
+	}
+
+	consumeContext := messagingTypes.NewMessageConsumeContext(
+		message,
+		meta,
+		meta.GetString(messageHeader.ContentType),
+		messageHeader.GetMessageType(meta),
+		messageHeader.GetMessageCreated(meta),
+		deliveryTag,
+		messageHeader.GetMessageId(meta),
+		messageHeader.GetCorrelationId(meta),
+	)
+
+	for _, handler := range n.handlers {
+		err = n.runHandlersWithRetry(ctx, handler, consumeContext)
+		if err != nil {
+			break
+		}
+	}
+
+	if err == nil {
+		_ = consumertracing.FinishConsumerSpan(beforeConsumeSpan, nil)
+		n.settle(msg, msg.Ack)
+
+		for _, notification := range n.isConsumedNotifications {
+			if notification != nil {
+				notification(consumeContext.Message())
+			}
+		}
+
+		return
+	}
+
+	n.logger.Errorw(
+		"[natsConsumer.handleReceived] error in handling consume message of NATS",
+		logger.Fields{"message_id": consumeContext.MessageId(), "error": err.Error(), "delivered": numDelivered},
+	)
+	_ = consumertracing.FinishConsumerSpan(beforeConsumeSpan, err)
+
+	maxDeliver := n.natsConsumerOptions.MaxDeliver
+	if maxDeliver > 0 && numDelivered >= uint64(maxDeliver) {
+		n.deadLetter(ctx, msg, err)
+		n.settle(msg, msg.Term)
+
+		return
+	}
+
+	n.settle(msg, msg.Nak)
+}
+
+// settle acks, naks or terms a message, the messages of a consumer without ack policy are not settled
+func (n *natsConsumer) settle(msg jetstream.Msg, settleFunc func() error) {
+	if n.natsConsumerOptions.AckPolicy == jetstream.AckNonePolicy {
+		return
+	}
+
+	if err := settleFunc(); err != nil {
+		n.logger.Errorf("error in settling message of the subject %s: %v", msg.Subject(), err)
+	}
+}
+
+// deadLetter publishes a failed message with its error to the dead-letter subject of the consumer
+func (n *natsConsumer) deadLetter(ctx context.Context, msg jetstream.Msg, cause error) {
+	if n.natsConsumerOptions.DeadLetterSubject == "" {
+		return
+	}
+
+	headers := nats.Header{}
+	for key, values := range msg.Headers() {
+		headers[key] = append([]string{}, values...)
+	}
+	headers[DeadLetterErrorHeader] = []string{cause.Error()}
+	headers[DeadLetterSubjectHeader] = []string{msg.Subject()}
+
+	_, err := n.jetStream.PublishMsg(ctx, &nats.Msg{
+		Subject: n.natsConsumerOptions.DeadLetterSubject,
+		Data:    msg.Data(),
+		Header:  headers,
+	})
+	if err != nil {
+		n.logger.Errorf(
+			"error in publishing message of the subject %s to the dead-letter subject: %v",
+			msg.Subject(),
+			err,
+		)
+	}
+}
+
+func (n *natsConsumer) runHandlersWithRetry(
+	ctx context.Context,
+	handler consumer.ConsumerHandler,
+	messageConsumeContext messagingTypes.MessageConsumeContext,
+) error {
+	// the pipelines run for each handler, so they can keep the state of the message per handler, like the inbox
+	ctx = consumer.WithHandler(ctx, handler)
+
+	return retry.Do(func() error {
+		var next pipeline.ConsumerHandlerFunc = func(ctx context.Context) error {
+			return handler.Handle(ctx, messageConsumeContext)
+		}
+
+		// the first pipeline is the outermost one
+		for i := len(n.pipelines) - 1; i >= 0; i-- {
+			pipe := n.pipelines[i]
+			nextHandler := next
+
+			next = func(ctx context.Context) error {
+				return pipe.Handle(ctx, messageConsumeContext, nextHandler)
+			}
+		}
+
+		return next(ctx)
+	}, append(retryOptions, retry.Context(ctx))...)
+}
+
+// deserializeMessage deserializes the message to the consumer message type, each subject has one message type
+func (n *natsConsumer) deserializeMessage(
+	msg jetstream.Msg,
+	eventType string,
+	contentType string,
+) (messagingTypes.IMessage, error) {
+	if len(msg.Data()) == 0 {
+		return nil, errors.New("message body is nil or empty in the consumer")
+	}
+
+	messageType := n.natsConsumerOptions.ConsumerMessageType
+	if messageType.Kind() == reflect.Pointer {
+		messageType = messageType.Elem()
+	}
+
+	messagePointer := reflect.New(messageType).Interface()
+	if err := n.messageSerializer.DeserializeInto(msg.Data(), messagePointer, contentType); err != nil {
+		return nil, errors.WrapIff(err, "error in deserializing of type '%s' in the consumer", eventType)
+	}
+
+	message, ok := messagePointer.(messagingTypes.IMessage)
+	if !ok {
+		return nil, errors.Errorf("type '%s' doesn't implement IMessage", messageType)
+	}
+
+	return message, nil
+}
@@ -0,0 +1,31 @@
+package nats
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/health/contracts"
+
+	"emperror.dev/errors"
+	"github.com/nats-io/nats.go"
+)
+
+type natsHealthChecker struct {
+	conn *nats.Conn
+}
+
+func NewNatsHealthChecker(conn *nats.Conn) contracts.Health {
+	return &natsHealthChecker{conn: conn}
+}
+
+func (n *natsHealthChecker) CheckHealth(ctx context.Context) error {
+	if !n.conn.IsConnected() {
+		return errors.Errorf("nats is not available, the connection status is %s", n.conn.Status())
+	}
+
+	// the flush round trip checks the server is responsive
+	return errors.WrapIf(n.conn.FlushWithContext(ctx), "nats is not responsive")
+}
+
+func (n *natsHealthChecker) GetHealthName() string {
+	return "nats"
+}
@@ -0,0 +1,140 @@
+package nats
+
+import (
+	"context"
+	"fmt"
+
+	bus2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/bus"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/claimcheck"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/encryption"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/producer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/health/contracts"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/nats/bus"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/nats/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/nats/types"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.uber.org/fx"
+)
+
+var (
+	// ModuleFunc provided to fxlog
+	// https://uber-go.github.io/fx/modules.html
+	ModuleFunc = func(natsConfigurationConstructor interface{}) fx.Option { //nolint:gochecknoglobals
+		return fx.Module(
+			"natsfx",
+			claimcheck.Module,
+			encryption.Module,
+			fx.Provide(natsConfigurationConstructor),
+			natsProviders,
+			natsInvokes,
+		)
+	}
+
+	// - order is not important in provide
+	// - provide can have parameter and will resolve if registered
+	// - execute its func only if it requested
+	natsProviders = fx.Options(
+		fx.Provide(config.ProvideConfig),
+		// the bus serializes its messages with the serializer of its content type
+		fx.Decorate(fx.Annotate(
+			newMessageSerializer,
+			fx.ParamTags(``, fmt.Sprintf(`group:"%s"`, serializer.MessageSerializersGroup), ``),
+		)),
+		fx.Provide(types.NewConnection),
+		fx.Provide(types.NewJetStream),
+		fx.Provide(fx.Annotate(
+			bus.NewNatsBus,
+			fx.ParamTags(``, ``, ``, ``, `optional:"true"`),
+			fx.As(new(producer.Producer)),
+			fx.As(new(bus2.Bus)),
+			fx.As(new(bus.NatsBus)),
+		)),
+		fx.Provide(fx.Annotate(
+			NewNatsHealthChecker,
+			fx.As(new(contracts.Health)),
+			fx.ResultTags(fmt.Sprintf(`group:"%s"`, "healths")),
+		))) //nolint:gochecknoglobals
+
+	// - execute after registering all of our provided
+	// - they execute by their orders
+	// - invokes always execute its func compare to provides that only run when we request for them.
+	// - return value will be discarded and can not be provided
+	natsInvokes = fx.Options(fx.Invoke(registerHooks)) //nolint:gochecknoglobals
+)
+
+func newMessageSerializer(
+	options *config.NatsOptions,
+	serializers []serializer.MessageSerializer,
+	encryptor encryption.FieldEncryptor,
+) (serializer.MessageSerializer, error) {
+	messageSerializer, err := serializer.NewNegotiatingMessageSerializer(options.ContentType, serializers)
+	if err != nil {
+		return nil, err
+	}
+
+	// the configured fields of the messages are encrypted before they are published
+	return encryption.NewEncryptingMessageSerializer(messageSerializer, encryptor), nil
+}
+
+// we don't want to register any dependencies here, its func body should execute always even we don't request for that, so we should use `invoke`
+func registerHooks(
+	lc fx.Lifecycle,
+	bus bus.NatsBus,
+	conn *nats.Conn,
+	jetStream jetstream.JetStream,
+	natsOptions *config.NatsOptions,
+	logger logger.Logger,
+) {
+	lifeTimeCtx, cancel := context.WithCancel(context.Background())
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			if natsOptions.AutoStart == false {
+				return nil
+			}
+
+			// https://github.com/uber-go/fx/blob/v1.20.0/app.go#L573
+			// this ctx is just for startup dependencies setup and OnStart callbacks, and it has short timeout 15s, and it is not alive in whole lifetime app
+			// if we need an app context which is alive until the app context done we should create it manually here
+			if natsOptions.AutoProvisionStreams {
+				// the durable consumers are created on the streams, so the streams are provisioned first
+				err := types.EnsureStreams(ctx, jetStream, natsOptions, bus.Configuration().Streams())
+				if err != nil {
+					logger.Errorf("error in provisioning nats streams: %v", err)
+				}
+			}
+
+			go func() {
+				if err := bus.Start(lifeTimeCtx); err != nil {
+					logger.Errorf(
+						"(bus.Start) error in running nats consumers: {%v}",
+						err,
+					)
+				}
+			}()
+			logger.Info("nats is listening.")
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			// https://github.com/uber-go/fx/blob/v1.20.0/app.go#L573
+			// this ctx is just for stopping callbacks or OnStop callbacks, and it has short timeout 15s, and it is not alive in whole lifetime app
+			cancel()
+
+			if err := bus.Stop(); err != nil {
+				logger.Errorf("error shutting down nats consumers: %v", err)
+			} else {
+				logger.Info("nats consumers shutdown gracefully")
+			}
+
+			// the connection is closed after the consumers, so the handlers in progress can still publish
+			conn.Close()
+
+			return nil
+		},
+	})
+}
@@ -0,0 +1,34 @@
+package configurations
+
+import (
+	"reflect"
+
+	types2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/nats/types"
+)
+
+type NatsProducerConfiguration struct {
+	ProducerMessageType reflect.Type
+	Subject             string
+	// Stream keeps the subject of the producer, it defaults to the stream name of the subject.
+	Stream string
+}
+
+func NewDefaultNatsProducerConfiguration(
+	messageType types2.IMessage,
+) *NatsProducerConfiguration {
+	return &NatsProducerConfiguration{
+		Subject:             utils.GetTopicOrExchangeName(messageType),
+		ProducerMessageType: utils.GetMessageBaseReflectType(messageType),
+	}
+}
+
+// StreamName returns the stream of the producer subject
+func (c *NatsProducerConfiguration) StreamName() string {
+	if c.Stream != "" {
+		return c.Stream
+	}
+
+	return types.StreamName(c.Subject)
+}
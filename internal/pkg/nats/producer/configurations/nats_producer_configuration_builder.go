@@ -0,0 +1,41 @@
+package configurations
+
+import (
+	types2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+)
+
+type NatsProducerConfigurationBuilder interface {
+	WithSubject(subject string) NatsProducerConfigurationBuilder
+	WithStreamName(streamName string) NatsProducerConfigurationBuilder
+	Build() *NatsProducerConfiguration
+}
+
+type natsProducerConfigurationBuilder struct {
+	natsProducerOptions *NatsProducerConfiguration
+}
+
+func NewNatsProducerConfigurationBuilder(
+	messageType types2.IMessage,
+) NatsProducerConfigurationBuilder {
+	return &natsProducerConfigurationBuilder{
+		natsProducerOptions: NewDefaultNatsProducerConfiguration(messageType),
+	}
+}
+
+func (b *natsProducerConfigurationBuilder) WithSubject(
+	subject string,
+) NatsProducerConfigurationBuilder {
+	b.natsProducerOptions.Subject = subject
+	return b
+}
+
+func (b *natsProducerConfigurationBuilder) WithStreamName(
+	streamName string,
+) NatsProducerConfigurationBuilder {
+	b.natsProducerOptions.Stream = streamName
+	return b
+}
+
+func (b *natsProducerConfigurationBuilder) Build() *NatsProducerConfiguration {
+	return b.natsProducerOptions
+}
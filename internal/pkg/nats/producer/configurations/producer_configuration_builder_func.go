@@ -0,0 +1,3 @@
+package configurations
+
+type NatsProducerConfigurationBuilderFuc func(builder NatsProducerConfigurationBuilder)
@@ -0,0 +1,208 @@
+package producer
+
+import (
+	"context"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/claimcheck"
+	messageHeader "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/messageheader"
+	producer3 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/otel/tracing/producer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/producer"
+	types2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/nats/producer/configurations"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/nats/types"
+
+	"emperror.dev/errors"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	uuid "github.com/satori/go.uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type natsProducer struct {
+	logger                  logger.Logger
+	jetStream               jetstream.JetStream
+	messageSerializer       serializer.MessageSerializer
+	producersConfigurations map[string]*configurations.NatsProducerConfiguration
+	claimCheck              claimcheck.ClaimCheck
+	isProducedNotifications []func(message types2.IMessage)
+}
+
+func NewNatsProducer(
+	jetStream jetstream.JetStream,
+	natsProducersConfiguration map[string]*configurations.NatsProducerConfiguration,
+	logger logger.Logger,
+	eventSerializer serializer.MessageSerializer,
+	claimCheck claimcheck.ClaimCheck,
+	isProducedNotifications ...func(message types2.IMessage),
+) (producer.Producer, error) {
+	p := &natsProducer{
+		claimCheck:              claimCheck,
+		logger:                  logger,
+		jetStream:               jetStream,
+		messageSerializer:       eventSerializer,
+		producersConfigurations: natsProducersConfiguration,
+	}
+
+	p.isProducedNotifications = isProducedNotifications
+
+	return p, nil
+}
+
+func (n *natsProducer) IsProduced(h func(message types2.IMessage)) {
+	n.isProducedNotifications = append(n.isProducedNotifications, h)
+}
+
+func (n *natsProducer) PublishMessage(
+	ctx context.Context,
+	message types2.IMessage,
+	meta metadata.Metadata,
+) error {
+	return n.PublishMessageWithTopicName(ctx, message, meta, "")
+}
+
+// PublishMessageWithDelay publishes the messages without a delay, nats has no delayed delivery, so a delayed message is
+// rejected instead of being delivered early
+func (n *natsProducer) PublishMessageWithDelay(
+	ctx context.Context,
+	message types2.IMessage,
+	meta metadata.Metadata,
+	delay time.Duration,
+) error {
+	if delay > 0 {
+		return errors.WithMessagef(
+			producer.ErrDelayedDeliveryNotSupported,
+			"can't publish message with id: {%s} with delay",
+			message.GeMessageId(),
+		)
+	}
+
+	return n.PublishMessage(ctx, message, meta)
+}
+
+// PublishMessages publishes the messages one by one, each message waits for its jetstream ack
+func (n *natsProducer) PublishMessages(ctx context.Context, messages []types2.IMessage) error {
+	for _, message := range messages {
+		if err := n.PublishMessage(ctx, message, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (n *natsProducer) getProducerConfigurationByMessage(
+	message types2.IMessage,
+) *configurations.NatsProducerConfiguration {
+	messageType := utils.GetMessageBaseReflectType(message)
+	return n.producersConfigurations[messageType.String()]
+}
+
+func (n *natsProducer) PublishMessageWithTopicName(
+	ctx context.Context,
+	message types2.IMessage,
+	meta metadata.Metadata,
+	topicOrExchangeName string,
+) error {
+	producerConfiguration := n.getProducerConfigurationByMessage(message)
+
+	if producerConfiguration == nil {
+		producerConfiguration = configurations.NewDefaultNatsProducerConfiguration(message)
+	}
+
+	subject := producerConfiguration.Subject
+	stream := producerConfiguration.StreamName()
+	if topicOrExchangeName != "" {
+		subject = topicOrExchangeName
+		stream = types.StreamName(subject)
+	}
+
+	meta = n.getMetadata(message, meta)
+
+	producerOptions := &producer3.ProducerTracingOptions{
+		MessagingSystem: "nats",
+		DestinationKind: "subject",
+		Destination:     subject,
+		OtherAttributes: []attribute.KeyValue{
+			types.MessagingNatsStream.String(stream),
+		},
+	}
+
+	serializedObj, err := n.messageSerializer.Serialize(message)
+	if err != nil {
+		return err
+	}
+
+	body := serializedObj.Data
+
+	ctx, beforeProduceSpan := producer3.StartProducerSpan(
+		ctx,
+		message,
+		&meta,
+		string(body),
+		producerOptions,
+	)
+
+	// the large payloads are offloaded to the blob store and a claim-check reference is published instead
+	if n.claimCheck != nil {
+		body, err = n.claimCheck.Offload(ctx, body, meta)
+		if err != nil {
+			return producer3.FinishProducerSpan(beforeProduceSpan, err)
+		}
+	}
+
+	// the stream drops a message with the same message id in its duplicate window, so a retried publish is stored once
+	_, err = n.jetStream.PublishMsg(
+		ctx,
+		&nats.Msg{
+			Subject: subject,
+			Data:    body,
+			Header:  types.MetadataToHeaders(meta),
+		},
+		jetstream.WithMsgID(messageHeader.GetMessageId(meta)),
+	)
+	if err != nil {
+		return producer3.FinishProducerSpan(beforeProduceSpan, err)
+	}
+
+	if len(n.isProducedNotifications) > 0 {
+		for _, notification := range n.isProducedNotifications {
+			if notification != nil {
+				notification(message)
+			}
+		}
+	}
+
+	return producer3.FinishProducerSpan(beforeProduceSpan, nil)
+}
+
+func (n *natsProducer) getMetadata(
+	message types2.IMessage,
+	meta metadata.Metadata,
+) metadata.Metadata {
+	meta = metadata.FromMetadata(meta)
+
+	// just message type name not full type name because in other side package name for type could be different
+	messageHeader.SetMessageType(meta, message.GetMessageTypeName())
+	meta.Set(messageHeader.ContentType, n.messageSerializer.ContentType())
+
+	if messageHeader.GetMessageId(meta) == "" {
+		messageHeader.SetMessageId(meta, message.GeMessageId())
+	}
+
+	if messageHeader.GetMessageCreated(meta) == *new(time.Time) {
+		messageHeader.SetMessageCreated(meta, message.GetCreated())
+	}
+
+	if messageHeader.GetCorrelationId(meta) == "" {
+		cid := uuid.NewV4().String()
+		messageHeader.SetCorrelationId(meta, cid)
+	}
+	messageHeader.SetMessageName(meta, utils.GetMessageName(message))
+
+	return meta
+}
@@ -0,0 +1,123 @@
+package types
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/nats/config"
+
+	"emperror.dev/errors"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/samber/lo"
+)
+
+// NewConnection connects to the nats servers, the connection reconnects to the servers until it is closed, also when
+// the servers are not available on start
+func NewConnection(natsOptions *config.NatsOptions, logger logger.Logger) (*nats.Conn, error) {
+	if len(natsOptions.Urls) == 0 {
+		return nil, errors.New("there is no nats server in the options")
+	}
+
+	conn, err := nats.Connect(
+		strings.Join(natsOptions.Urls, ","),
+		nats.Name(natsOptions.ClientName),
+		nats.MaxReconnects(-1),
+		nats.RetryOnFailedConnect(true),
+		nats.ReconnectWait(natsOptions.ReconnectWait),
+		nats.DisconnectErrHandler(func(conn *nats.Conn, err error) {
+			if err != nil {
+				logger.Errorf("nats connection is disconnected: %v", err)
+			}
+		}),
+		nats.ReconnectHandler(func(conn *nats.Conn) {
+			logger.Infof("nats connection is reconnected to %s", conn.ConnectedUrl())
+		}),
+	)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in connecting to the nats servers")
+	}
+
+	return conn, nil
+}
+
+func NewJetStream(conn *nats.Conn) (jetstream.JetStream, error) {
+	js, err := jetstream.New(conn)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in creating the jetstream context")
+	}
+
+	return js, nil
+}
+
+// StreamName returns a valid stream name for a subject, the stream names can't contain the subject separators and
+// wildcards
+func StreamName(subject string) string {
+	return strings.NewReplacer(".", "_", "*", "_", ">", "_", " ", "_", "/", "_", "\\", "_").Replace(subject)
+}
+
+// EnsureStreams creates the missing streams and adds the missing subjects to the existing streams, the other settings
+// of the existing streams are not changed
+func EnsureStreams(
+	ctx context.Context,
+	js jetstream.JetStream,
+	natsOptions *config.NatsOptions,
+	streamsSubjects map[string][]string,
+) error {
+	for streamName, subjects := range streamsSubjects {
+		stream, err := js.Stream(ctx, streamName)
+		if errors.Is(err, jetstream.ErrStreamNotFound) {
+			_, err = js.CreateStream(ctx, newStreamConfig(natsOptions, streamName, subjects))
+			if err != nil {
+				return errors.WrapIff(err, "error in creating the nats stream %s", streamName)
+			}
+
+			continue
+		}
+		if err != nil {
+			return errors.WrapIff(err, "error in getting the nats stream %s", streamName)
+		}
+
+		streamConfig := stream.CachedInfo().Config
+		missingSubjects := false
+		for _, subject := range subjects {
+			if !lo.Contains(streamConfig.Subjects, subject) {
+				streamConfig.Subjects = append(streamConfig.Subjects, subject)
+				missingSubjects = true
+			}
+		}
+
+		if !missingSubjects {
+			continue
+		}
+
+		if _, err = js.UpdateStream(ctx, streamConfig); err != nil {
+			return errors.WrapIff(err, "error in adding the subjects to the nats stream %s", streamName)
+		}
+	}
+
+	return nil
+}
+
+func newStreamConfig(natsOptions *config.NatsOptions, streamName string, subjects []string) jetstream.StreamConfig {
+	storage := jetstream.FileStorage
+	if strings.EqualFold(natsOptions.StreamStorage, "memory") {
+		storage = jetstream.MemoryStorage
+	}
+
+	sortedSubjects := append([]string{}, subjects...)
+	sort.Strings(sortedSubjects)
+
+	return jetstream.StreamConfig{
+		Name:       streamName,
+		Subjects:   sortedSubjects,
+		Storage:    storage,
+		Replicas:   natsOptions.StreamReplicas,
+		MaxAge:     natsOptions.StreamMaxAge,
+		Duplicates: natsOptions.DuplicateWindow,
+		// the messages are kept until their limits, so the durable consumers of the other services receive them too
+		Retention: jetstream.LimitsPolicy,
+	}
+}
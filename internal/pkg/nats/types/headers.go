@@ -0,0 +1,65 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	messageHeader "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/messageheader"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// MessagingNatsStream is the stream of a published or a consumed message in the spans
+const MessagingNatsStream = attribute.Key("messaging.nats.stream")
+
+// MetadataToHeaders converts the metadata of a message to the nats headers, the nats header values are strings, so
+// the time values are kept in RFC3339 format and the other non-string values in their default format
+func MetadataToHeaders(meta metadata.Metadata) nats.Header {
+	headers := nats.Header{}
+
+	for key, value := range meta {
+		var headerValue string
+
+		switch v := value.(type) {
+		case string:
+			headerValue = v
+		case []byte:
+			headerValue = string(v)
+		case time.Time:
+			headerValue = v.Format(time.RFC3339Nano)
+		case nil:
+			continue
+		default:
+			headerValue = fmt.Sprint(v)
+		}
+
+		// the keys are kept as they are, `Set` canonicalizes them
+		headers[key] = []string{headerValue}
+	}
+
+	return headers
+}
+
+// HeadersToMetadata converts the nats headers of a message to the metadata, the `created` header is parsed to time
+// like the other brokers
+func HeadersToMetadata(headers nats.Header) metadata.Metadata {
+	meta := metadata.Metadata{}
+
+	for key, values := range headers {
+		if len(values) == 0 {
+			continue
+		}
+
+		meta.Set(key, values[0])
+	}
+
+	if created := meta.GetString(messageHeader.Created); created != "" {
+		if t, err := time.Parse(time.RFC3339Nano, created); err == nil {
+			messageHeader.SetMessageCreated(meta, t)
+		}
+	}
+
+	return meta
+}
@@ -0,0 +1,36 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	messageHeader "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/messageheader"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Headers_Round_Trip_Keeps_Metadata(t *testing.T) {
+	created := time.Date(2024, 3, 1, 10, 30, 0, 123, time.UTC)
+
+	meta := metadata.Metadata{}
+	messageHeader.SetMessageId(meta, "message-1")
+	messageHeader.SetCorrelationId(meta, "correlation-1")
+	messageHeader.SetMessageCreated(meta, created)
+	meta.Set("retry", 2)
+	meta.Set("empty", nil)
+
+	result := HeadersToMetadata(MetadataToHeaders(meta))
+
+	assert.Equal(t, "message-1", messageHeader.GetMessageId(result))
+	assert.Equal(t, "correlation-1", messageHeader.GetCorrelationId(result))
+	assert.True(t, created.Equal(messageHeader.GetMessageCreated(result)))
+	assert.Equal(t, "2", result.GetString("retry"))
+	assert.False(t, result.ExistsKey("empty"))
+}
+
+func Test_Stream_Name_Replaces_The_Invalid_Characters(t *testing.T) {
+	assert.Equal(t, "product_created_v_1", StreamName("product_created_v_1"))
+	assert.Equal(t, "product_created_v_1_dlq", StreamName("product_created_v_1.dlq"))
+	assert.Equal(t, "orders____", StreamName("orders.*.>"))
+}
@@ -0,0 +1,199 @@
+package failover
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"emperror.dev/errors"
+)
+
+const bufferFileExtension = ".batch"
+
+// ErrBatchTooLarge is returned for a batch which is larger than the max size of the buffer
+var ErrBatchTooLarge = errors.Sentinel("telemetry batch is larger than the buffer")
+
+// DiskBuffer keeps the failed export batches as files of a directory up to a max size, the oldest batches are dropped
+// when a new batch doesn't fit. the name of a batch file has its order and its number of items, so the dropped items
+// are counted without reading the file, and the batches of a previous run are replayed after a restart.
+type DiskBuffer struct {
+	mu        sync.Mutex
+	directory string
+	maxBytes  int64
+	size      int64
+	sequence  int64
+	batches   []*bufferedBatch
+}
+
+type bufferedBatch struct {
+	name  string
+	size  int64
+	items int
+}
+
+// NewDiskBuffer opens the buffer of a directory, the directory is created when it doesn't exist
+func NewDiskBuffer(directory string, maxBytes int64) (*DiskBuffer, error) {
+	if err := os.MkdirAll(directory, 0o750); err != nil {
+		return nil, errors.WrapIf(err, "error in creating the telemetry buffer directory")
+	}
+
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in reading the telemetry buffer directory")
+	}
+
+	buffer := &DiskBuffer{directory: directory, maxBytes: maxBytes, sequence: time.Now().UnixNano()}
+
+	for _, entry := range entries {
+		sequence, items, ok := parseBatchName(entry.Name())
+		if entry.IsDir() || !ok {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		buffer.batches = append(buffer.batches, &bufferedBatch{name: entry.Name(), size: info.Size(), items: items})
+		buffer.size += info.Size()
+		if sequence >= buffer.sequence {
+			buffer.sequence = sequence + 1
+		}
+	}
+
+	sort.Slice(buffer.batches, func(i, j int) bool {
+		return buffer.batches[i].name < buffer.batches[j].name
+	})
+
+	return buffer, nil
+}
+
+// Append adds a batch of `items` items, it returns the number of the items of the oldest batches which are dropped to
+// keep the max size. a batch which is larger than the max size is not added and ErrBatchTooLarge is returned.
+func (b *DiskBuffer) Append(data []byte, items int) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	size := int64(len(data))
+	if size > b.maxBytes {
+		return 0, ErrBatchTooLarge
+	}
+
+	dropped := 0
+	for b.size+size > b.maxBytes && len(b.batches) > 0 {
+		oldest := b.batches[0]
+		if err := os.Remove(filepath.Join(b.directory, oldest.name)); err != nil && !os.IsNotExist(err) {
+			return dropped, errors.WrapIf(err, "error in dropping the oldest telemetry batch")
+		}
+
+		b.batches = b.batches[1:]
+		b.size -= oldest.size
+		dropped += oldest.items
+	}
+
+	b.sequence++
+	name := batchName(b.sequence, items)
+
+	// the batch is renamed after it is written, so a partial batch of a crash is not replayed
+	temp := filepath.Join(b.directory, name+".tmp")
+	if err := os.WriteFile(temp, data, 0o640); err != nil {
+		_ = os.Remove(temp)
+
+		return dropped, errors.WrapIf(err, "error in writing the telemetry batch")
+	}
+	if err := os.Rename(temp, filepath.Join(b.directory, name)); err != nil {
+		_ = os.Remove(temp)
+
+		return dropped, errors.WrapIf(err, "error in writing the telemetry batch")
+	}
+
+	b.batches = append(b.batches, &bufferedBatch{name: name, size: size, items: items})
+	b.size += size
+
+	return dropped, nil
+}
+
+// Oldest returns the oldest batch, ok is false when the buffer is empty
+func (b *DiskBuffer) Oldest() (name string, data []byte, items int, ok bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for len(b.batches) > 0 {
+		oldest := b.batches[0]
+
+		data, err := os.ReadFile(filepath.Join(b.directory, oldest.name))
+		if os.IsNotExist(err) {
+			b.batches = b.batches[1:]
+			b.size -= oldest.size
+
+			continue
+		}
+		if err != nil {
+			return "", nil, 0, false, errors.WrapIf(err, "error in reading the telemetry batch")
+		}
+
+		return oldest.name, data, oldest.items, true, nil
+	}
+
+	return "", nil, 0, false, nil
+}
+
+// Remove deletes a batch after it is replayed
+func (b *DiskBuffer) Remove(name string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i, batch := range b.batches {
+		if batch.name != name {
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(b.directory, name)); err != nil && !os.IsNotExist(err) {
+			return errors.WrapIf(err, "error in removing the replayed telemetry batch")
+		}
+
+		b.batches = append(b.batches[:i], b.batches[i+1:]...)
+		b.size -= batch.size
+
+		return nil
+	}
+
+	return nil
+}
+
+// Len returns the number of the buffered batches
+func (b *DiskBuffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.batches)
+}
+
+func batchName(sequence int64, items int) string {
+	return fmt.Sprintf("%020d-%d%s", sequence, items, bufferFileExtension)
+}
+
+func parseBatchName(name string) (int64, int, bool) {
+	if !strings.HasSuffix(name, bufferFileExtension) {
+		return 0, 0, false
+	}
+
+	parts := strings.Split(strings.TrimSuffix(name, bufferFileExtension), "-")
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+
+	sequence, sequenceErr := strconv.ParseInt(parts[0], 10, 64)
+	items, itemsErr := strconv.Atoi(parts[1])
+	if sequenceErr != nil || itemsErr != nil {
+		return 0, 0, false
+	}
+
+	return sequence, items, true
+}
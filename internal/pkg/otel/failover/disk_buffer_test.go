@@ -0,0 +1,76 @@
+package failover
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Disk_Buffer_Drops_The_Oldest_Batches_When_It_Is_Full(t *testing.T) {
+	buffer, err := NewDiskBuffer(t.TempDir(), 10)
+	require.NoError(t, err)
+
+	dropped, err := buffer.Append([]byte("aaaa"), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+
+	dropped, err = buffer.Append([]byte("bbbb"), 2)
+	require.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+
+	dropped, err = buffer.Append([]byte("cccc"), 3)
+	require.NoError(t, err)
+	assert.Equal(t, 1, dropped)
+	assert.Equal(t, 2, buffer.Len())
+
+	_, err = buffer.Append([]byte("too large batch"), 4)
+	assert.ErrorIs(t, err, ErrBatchTooLarge)
+	assert.Equal(t, 2, buffer.Len())
+
+	name, data, items, ok, err := buffer.Oldest()
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, []byte("bbbb"), data)
+	assert.Equal(t, 2, items)
+
+	require.NoError(t, buffer.Remove(name))
+
+	_, data, _, ok, err = buffer.Oldest()
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, []byte("cccc"), data)
+}
+
+func Test_Disk_Buffer_Keeps_The_Batches_Of_A_Previous_Run(t *testing.T) {
+	directory := t.TempDir()
+
+	buffer, err := NewDiskBuffer(directory, 100)
+	require.NoError(t, err)
+
+	_, err = buffer.Append([]byte("first"), 1)
+	require.NoError(t, err)
+	_, err = buffer.Append([]byte("second"), 2)
+	require.NoError(t, err)
+
+	reopened, err := NewDiskBuffer(directory, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 2, reopened.Len())
+
+	_, err = reopened.Append([]byte("third"), 3)
+	require.NoError(t, err)
+
+	var batches []string
+	for {
+		name, data, _, ok, err := reopened.Oldest()
+		require.NoError(t, err)
+		if !ok {
+			break
+		}
+
+		batches = append(batches, string(data))
+		require.NoError(t, reopened.Remove(name))
+	}
+
+	assert.Equal(t, []string{"first", "second", "third"}, batches)
+}
@@ -0,0 +1,100 @@
+package failover
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	SignalTraces  = "traces"
+	SignalMetrics = "metrics"
+
+	reasonExportFailed = "export_failed"
+	reasonBufferFull   = "buffer_full"
+	reasonBufferFailed = "buffer_failed"
+	reasonCorrupted    = "corrupted"
+)
+
+// exportMetrics counts the items of the failed exports, the instruments are created with the global meter provider,
+// so they are delegated to the meter provider of the metrics module after it is configured
+type exportMetrics struct {
+	signal    string
+	exporter  string
+	failovers metric.Int64Counter
+	buffered  metric.Int64Counter
+	replayed  metric.Int64Counter
+	dropped   metric.Int64Counter
+}
+
+func newExportMetrics(signal string, exporter string) (*exportMetrics, error) {
+	meter := otel.Meter("github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/failover")
+
+	failovers, err := meter.Int64Counter(
+		"telemetry_export_failovers_total",
+		metric.WithDescription("number of the items which are exported to the secondary endpoint"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	buffered, err := meter.Int64Counter(
+		"telemetry_export_buffered_total",
+		metric.WithDescription("number of the items which are buffered on disk after a failed export"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	replayed, err := meter.Int64Counter(
+		"telemetry_export_replayed_total",
+		metric.WithDescription("number of the buffered items which are exported to the primary endpoint"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	dropped, err := meter.Int64Counter(
+		"telemetry_export_dropped_total",
+		metric.WithDescription("number of the items which are dropped after a failed export"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &exportMetrics{
+		signal:    signal,
+		exporter:  exporter,
+		failovers: failovers,
+		buffered:  buffered,
+		replayed:  replayed,
+		dropped:   dropped,
+	}, nil
+}
+
+func (m *exportMetrics) attributes(attrs ...attribute.KeyValue) metric.MeasurementOption {
+	return metric.WithAttributes(
+		append(attrs, attribute.String("signal", m.signal), attribute.String("exporter", m.exporter))...)
+}
+
+func (m *exportMetrics) failover(ctx context.Context, items int) {
+	m.failovers.Add(ctx, int64(items), m.attributes())
+}
+
+func (m *exportMetrics) buffer(ctx context.Context, items int) {
+	m.buffered.Add(ctx, int64(items), m.attributes())
+}
+
+func (m *exportMetrics) replay(ctx context.Context, items int) {
+	m.replayed.Add(ctx, int64(items), m.attributes())
+}
+
+func (m *exportMetrics) drop(ctx context.Context, items int, reason string) {
+	if items == 0 {
+		return
+	}
+
+	m.dropped.Add(ctx, int64(items), m.attributes(attribute.String("reason", reason)))
+}
@@ -0,0 +1,39 @@
+package failover
+
+const (
+	defaultMaxBufferMB         = 100
+	defaultReplayBatches       = 10
+	megabyte             int64 = 1024 * 1024
+)
+
+// FailoverOptions keeps the telemetry of an unreachable otlp endpoint, the failed exports are sent to the secondary
+// endpoint, and the failed spans of both endpoints are buffered on disk and replayed to the primary endpoint after it
+// is reachable again. without it a failed export is dropped.
+type FailoverOptions struct {
+	// SecondaryEndpoint is optional, it is the otlp grpc endpoint of the failed exports of the primary endpoints
+	SecondaryEndpoint string            `mapstructure:"secondaryEndpoint"`
+	SecondaryHeaders  map[string]string `mapstructure:"secondaryHeaders"`
+	// BufferDirectory is optional, the spans of each exporter are buffered in a sub directory of it, the metrics are
+	// not buffered, their cumulative values are exported again by the next successful export
+	BufferDirectory string `mapstructure:"bufferDirectory"`
+	// MaxBufferMB bounds the buffer of each exporter, the oldest spans are dropped when it is full
+	MaxBufferMB int `mapstructure:"maxBufferMB"`
+	// ReplayBatches is the number of the buffered batches which are replayed after each successful export
+	ReplayBatches int `mapstructure:"replayBatches"`
+}
+
+func (f *FailoverOptions) GetMaxBufferBytes() int64 {
+	if f == nil || f.MaxBufferMB <= 0 {
+		return defaultMaxBufferMB * megabyte
+	}
+
+	return int64(f.MaxBufferMB) * megabyte
+}
+
+func (f *FailoverOptions) GetReplayBatches() int {
+	if f == nil || f.ReplayBatches <= 0 {
+		return defaultReplayBatches
+	}
+
+	return f.ReplayBatches
+}
@@ -0,0 +1,137 @@
+package failover
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// metricExporter exports the metrics to the primary exporter and a failed export to the secondary exporter. the
+// metrics are not buffered on disk, with the cumulative temporality of the otlp exporters the next successful export
+// has the values of a dropped export, so only the points between the exports are lost.
+type metricExporter struct {
+	primary   metric.Exporter
+	secondary metric.Exporter
+	metrics   *exportMetrics
+}
+
+// NewMetricExporter creates an otlp grpc metric exporter with the failover of the options, the primary exporter is
+// created with the `opts` and the secondary exporter with the `opts` and the secondary endpoint and headers
+func NewMetricExporter(
+	ctx context.Context,
+	name string,
+	options *FailoverOptions,
+	opts ...otlpmetricgrpc.Option,
+) (metric.Exporter, error) {
+	primary, err := otlpmetricgrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var secondary metric.Exporter
+	if options.SecondaryEndpoint != "" {
+		secondaryOpts := append(
+			append([]otlpmetricgrpc.Option{}, opts...),
+			otlpmetricgrpc.WithEndpoint(options.SecondaryEndpoint),
+			otlpmetricgrpc.WithHeaders(options.SecondaryHeaders),
+		)
+
+		secondary, err = otlpmetricgrpc.New(ctx, secondaryOpts...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewFailoverMetricExporter(name, primary, secondary)
+}
+
+// NewFailoverMetricExporter creates the failover exporter of the primary exporter, the secondary exporter is optional
+func NewFailoverMetricExporter(name string, primary metric.Exporter, secondary metric.Exporter) (metric.Exporter, error) {
+	metrics, err := newExportMetrics(SignalMetrics, name)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in creating the telemetry failover metrics")
+	}
+
+	return &metricExporter{primary: primary, secondary: secondary, metrics: metrics}, nil
+}
+
+func (e *metricExporter) Temporality(kind metric.InstrumentKind) metricdata.Temporality {
+	return e.primary.Temporality(kind)
+}
+
+func (e *metricExporter) Aggregation(kind metric.InstrumentKind) metric.Aggregation {
+	return e.primary.Aggregation(kind)
+}
+
+func (e *metricExporter) Export(ctx context.Context, resourceMetrics *metricdata.ResourceMetrics) error {
+	err := e.primary.Export(ctx, resourceMetrics)
+	if err == nil {
+		return nil
+	}
+
+	points := dataPointsCount(resourceMetrics)
+
+	if e.secondary != nil {
+		secondaryErr := e.secondary.Export(ctx, resourceMetrics)
+		if secondaryErr == nil {
+			e.metrics.failover(ctx, points)
+
+			return nil
+		}
+
+		err = errors.Append(err, secondaryErr)
+	}
+
+	e.metrics.drop(ctx, points, reasonExportFailed)
+
+	return err
+}
+
+func (e *metricExporter) ForceFlush(ctx context.Context) error {
+	err := e.primary.ForceFlush(ctx)
+
+	if e.secondary != nil {
+		err = errors.Append(err, e.secondary.ForceFlush(ctx))
+	}
+
+	return err
+}
+
+func (e *metricExporter) Shutdown(ctx context.Context) error {
+	err := e.primary.Shutdown(ctx)
+
+	if e.secondary != nil {
+		err = errors.Append(err, e.secondary.Shutdown(ctx))
+	}
+
+	return err
+}
+
+func dataPointsCount(resourceMetrics *metricdata.ResourceMetrics) int {
+	count := 0
+	for _, scopeMetrics := range resourceMetrics.ScopeMetrics {
+		for _, m := range scopeMetrics.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				count += len(data.DataPoints)
+			case metricdata.Sum[float64]:
+				count += len(data.DataPoints)
+			case metricdata.Gauge[int64]:
+				count += len(data.DataPoints)
+			case metricdata.Gauge[float64]:
+				count += len(data.DataPoints)
+			case metricdata.Histogram[int64]:
+				count += len(data.DataPoints)
+			case metricdata.Histogram[float64]:
+				count += len(data.DataPoints)
+			default:
+				count++
+			}
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,206 @@
+package failover
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+
+	"emperror.dev/errors"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// traceClient uploads the spans to the primary client, a failed upload is sent to the secondary client, and when it
+// fails too the spans are buffered on disk. the buffered spans are replayed to the primary client after its next
+// successful upload, a few batches per upload, so a recovered endpoint is not flooded.
+type traceClient struct {
+	primary       otlptrace.Client
+	secondary     otlptrace.Client
+	buffer        *DiskBuffer
+	replayBatches int
+	metrics       *exportMetrics
+	replayMu      sync.Mutex
+}
+
+// NewTraceExporter creates an otlp grpc span exporter with the failover of the options, the primary exporter is created
+// with the `opts` and the secondary exporter with the `opts` and the secondary endpoint and headers
+func NewTraceExporter(
+	ctx context.Context,
+	name string,
+	options *FailoverOptions,
+	opts ...otlptracegrpc.Option,
+) (*otlptrace.Exporter, error) {
+	primary := otlptracegrpc.NewClient(opts...)
+
+	var secondary otlptrace.Client
+	if options.SecondaryEndpoint != "" {
+		secondaryOpts := append(
+			append([]otlptracegrpc.Option{}, opts...),
+			otlptracegrpc.WithEndpoint(options.SecondaryEndpoint),
+			otlptracegrpc.WithHeaders(options.SecondaryHeaders),
+		)
+		secondary = otlptracegrpc.NewClient(secondaryOpts...)
+	}
+
+	var buffer *DiskBuffer
+	if options.BufferDirectory != "" {
+		var err error
+
+		buffer, err = NewDiskBuffer(filepath.Join(options.BufferDirectory, name), options.GetMaxBufferBytes())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	client, err := NewTraceClient(name, primary, secondary, buffer, options.GetReplayBatches())
+	if err != nil {
+		return nil, err
+	}
+
+	return otlptrace.New(ctx, client)
+}
+
+// NewTraceClient creates the failover client of the primary client, the secondary client and the buffer are optional
+func NewTraceClient(
+	name string,
+	primary otlptrace.Client,
+	secondary otlptrace.Client,
+	buffer *DiskBuffer,
+	replayBatches int,
+) (otlptrace.Client, error) {
+	metrics, err := newExportMetrics(SignalTraces, name)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in creating the telemetry failover metrics")
+	}
+
+	return &traceClient{
+		primary:       primary,
+		secondary:     secondary,
+		buffer:        buffer,
+		replayBatches: replayBatches,
+		metrics:       metrics,
+	}, nil
+}
+
+func (c *traceClient) Start(ctx context.Context) error {
+	if err := c.primary.Start(ctx); err != nil {
+		return err
+	}
+
+	if c.secondary != nil {
+		return c.secondary.Start(ctx)
+	}
+
+	return nil
+}
+
+func (c *traceClient) Stop(ctx context.Context) error {
+	err := c.primary.Stop(ctx)
+
+	if c.secondary != nil {
+		err = errors.Append(err, c.secondary.Stop(ctx))
+	}
+
+	return err
+}
+
+func (c *traceClient) UploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
+	err := c.primary.UploadTraces(ctx, protoSpans)
+	if err == nil {
+		c.replay(ctx)
+
+		return nil
+	}
+
+	spans := spansCount(protoSpans)
+
+	if c.secondary != nil {
+		secondaryErr := c.secondary.UploadTraces(ctx, protoSpans)
+		if secondaryErr == nil {
+			c.metrics.failover(ctx, spans)
+
+			return nil
+		}
+
+		err = errors.Append(err, secondaryErr)
+	}
+
+	if c.buffer == nil {
+		c.metrics.drop(ctx, spans, reasonExportFailed)
+
+		return err
+	}
+
+	data, marshalErr := proto.Marshal(&tracepb.TracesData{ResourceSpans: protoSpans})
+	if marshalErr != nil {
+		c.metrics.drop(ctx, spans, reasonBufferFailed)
+
+		return errors.Append(err, marshalErr)
+	}
+
+	dropped, bufferErr := c.buffer.Append(data, spans)
+	c.metrics.drop(ctx, dropped, reasonBufferFull)
+
+	if errors.Is(bufferErr, ErrBatchTooLarge) {
+		c.metrics.drop(ctx, spans, reasonBufferFull)
+
+		return nil
+	}
+	if bufferErr != nil {
+		c.metrics.drop(ctx, spans, reasonBufferFailed)
+
+		return errors.Append(err, bufferErr)
+	}
+
+	c.metrics.buffer(ctx, spans)
+
+	return nil
+}
+
+// replay uploads the oldest buffered batches to the primary client, the concurrent uploads don't wait for a running
+// replay
+func (c *traceClient) replay(ctx context.Context) {
+	if c.buffer == nil || !c.replayMu.TryLock() {
+		return
+	}
+	defer c.replayMu.Unlock()
+
+	for i := 0; i < c.replayBatches; i++ {
+		name, data, spans, ok, err := c.buffer.Oldest()
+		if err != nil || !ok {
+			return
+		}
+
+		traces := &tracepb.TracesData{}
+		if err := proto.Unmarshal(data, traces); err != nil {
+			if c.buffer.Remove(name) == nil {
+				c.metrics.drop(ctx, spans, reasonCorrupted)
+			}
+
+			continue
+		}
+
+		if err := c.primary.UploadTraces(ctx, traces.ResourceSpans); err != nil {
+			return
+		}
+
+		if err := c.buffer.Remove(name); err != nil {
+			return
+		}
+
+		c.metrics.replay(ctx, spans)
+	}
+}
+
+func spansCount(protoSpans []*tracepb.ResourceSpans) int {
+	count := 0
+	for _, resourceSpans := range protoSpans {
+		for _, scopeSpans := range resourceSpans.GetScopeSpans() {
+			count += len(scopeSpans.GetSpans())
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,113 @@
+package failover
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+type fakeTraceClient struct {
+	mu       sync.Mutex
+	failing  bool
+	uploaded []string
+}
+
+func (f *fakeTraceClient) Start(ctx context.Context) error {
+	return nil
+}
+
+func (f *fakeTraceClient) Stop(ctx context.Context) error {
+	return nil
+}
+
+func (f *fakeTraceClient) UploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.failing {
+		return errors.New("endpoint is unreachable")
+	}
+
+	for _, resourceSpans := range protoSpans {
+		for _, scopeSpans := range resourceSpans.ScopeSpans {
+			for _, span := range scopeSpans.Spans {
+				f.uploaded = append(f.uploaded, span.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeTraceClient) setFailing(failing bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.failing = failing
+}
+
+func testSpans(names ...string) []*tracepb.ResourceSpans {
+	spans := make([]*tracepb.Span, 0, len(names))
+	for _, name := range names {
+		spans = append(spans, &tracepb.Span{Name: name})
+	}
+
+	return []*tracepb.ResourceSpans{{ScopeSpans: []*tracepb.ScopeSpans{{Spans: spans}}}}
+}
+
+func Test_Trace_Client_Fails_Over_To_The_Secondary_Client(t *testing.T) {
+	primary := &fakeTraceClient{failing: true}
+	secondary := &fakeTraceClient{}
+
+	client, err := NewTraceClient("test", primary, secondary, nil, 10)
+	require.NoError(t, err)
+
+	require.NoError(t, client.UploadTraces(context.Background(), testSpans("create-order")))
+
+	assert.Empty(t, primary.uploaded)
+	assert.Equal(t, []string{"create-order"}, secondary.uploaded)
+}
+
+func Test_Trace_Client_Replays_The_Buffered_Spans_After_The_Primary_Client_Recovers(t *testing.T) {
+	primary := &fakeTraceClient{failing: true}
+	secondary := &fakeTraceClient{failing: true}
+
+	buffer, err := NewDiskBuffer(t.TempDir(), megabyte)
+	require.NoError(t, err)
+
+	client, err := NewTraceClient("test", primary, secondary, buffer, 1)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	require.NoError(t, client.UploadTraces(ctx, testSpans("create-order", "update-order")))
+	require.NoError(t, client.UploadTraces(ctx, testSpans("submit-order")))
+	assert.Equal(t, 2, buffer.Len())
+
+	primary.setFailing(false)
+
+	// each successful upload replays one buffered batch
+	require.NoError(t, client.UploadTraces(ctx, testSpans("cancel-order")))
+	assert.Equal(t, []string{"cancel-order", "create-order", "update-order"}, primary.uploaded)
+	assert.Equal(t, 1, buffer.Len())
+
+	require.NoError(t, client.UploadTraces(ctx, testSpans("pay-order")))
+	assert.Equal(
+		t,
+		[]string{"cancel-order", "create-order", "update-order", "pay-order", "submit-order"},
+		primary.uploaded,
+	)
+	assert.Equal(t, 0, buffer.Len())
+}
+
+func Test_Trace_Client_Returns_The_Error_Without_A_Buffer(t *testing.T) {
+	client, err := NewTraceClient("test", &fakeTraceClient{failing: true}, nil, nil, 10)
+	require.NoError(t, err)
+
+	assert.Error(t, client.UploadTraces(context.Background(), testSpans("create-order")))
+}
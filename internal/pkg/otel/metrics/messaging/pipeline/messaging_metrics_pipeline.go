@@ -130,5 +130,6 @@ func (m *messagingMetricsPipeline) Handle(
 
 	durationValueRecorder.Record(ctx, duration, opt)
 
-	return nil
+	// the error of the handler is returned to the consumer, so the failed message is retried or dead-lettered
+	return err
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/contracts"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/failover"
 
 	"emperror.dev/errors"
 	"github.com/labstack/echo/v4"
@@ -170,7 +171,7 @@ func (o *OtelMetrics) configExporters() ([]metric.Reader, error) {
 
 			// send otel traces to jaeger builtin collector endpoint (default grpc port: 4317)
 			// https://opentelemetry.io/docs/collector/
-			exporter, err := otlpmetricgrpc.New(ctx, metricOpts...)
+			exporter, err := o.newOTLPExporter(ctx, "elastic-apm", metricOpts)
 			if err != nil {
 				return nil, errors.WrapIf(
 					err,
@@ -202,7 +203,7 @@ func (o *OtelMetrics) configExporters() ([]metric.Reader, error) {
 
 			// send otel traces to jaeger builtin collector endpoint (default grpc port: 4317)
 			// https://opentelemetry.io/docs/collector/
-			exporter, err := otlpmetricgrpc.New(ctx, metricOpts...)
+			exporter, err := o.newOTLPExporter(ctx, "uptrace", metricOpts)
 			if err != nil {
 				return nil, errors.WrapIf(
 					err,
@@ -232,7 +233,7 @@ func (o *OtelMetrics) configExporters() ([]metric.Reader, error) {
 
 			// send otel traces to jaeger builtin collector endpoint (default grpc port: 4317)
 			// https://opentelemetry.io/docs/collector/
-			exporter, err := otlpmetricgrpc.New(ctx, metricOpts...)
+			exporter, err := o.newOTLPExporter(ctx, "signoz", metricOpts)
 			if err != nil {
 				return nil, errors.WrapIf(
 					err,
@@ -272,7 +273,7 @@ func (o *OtelMetrics) configExporters() ([]metric.Reader, error) {
 			// https://opentelemetry.io/docs/collector/
 			// https://github.com/uptrace/uptrace-go/blob/master/example/otlp-metrics/main.go#L28
 			// https://github.com/open-telemetry/opentelemetry-go/blob/main/exporters/otlp/otlpmetric/otlpmetricgrpc/example_test.go
-			exporter, err := otlpmetricgrpc.New(ctx, metricOpts...)
+			exporter, err := o.newOTLPExporter(ctx, oltpProvider.Name, metricOpts)
 			if err != nil {
 				return nil, errors.WrapIf(err, "failed to create otlptracegrpc exporter")
 			}
@@ -288,6 +289,24 @@ func (o *OtelMetrics) configExporters() ([]metric.Reader, error) {
 	return exporters, nil
 }
 
+// newOTLPExporter creates an otlp grpc exporter, with the failover options the failed exports go to the secondary
+// endpoint instead of being dropped
+func (o *OtelMetrics) newOTLPExporter(
+	ctx context.Context,
+	name string,
+	metricOpts []otlpmetricgrpc.Option,
+) (metric.Exporter, error) {
+	if o.config.Failover == nil {
+		return otlpmetricgrpc.New(ctx, metricOpts...)
+	}
+
+	if name == "" {
+		name = "otlp"
+	}
+
+	return failover.NewMetricExporter(ctx, name, o.config.Failover, metricOpts...)
+}
+
 // we could also use our existing server app port and a new /metrics endpoint instead of a new server with different port for our app metrics
 
 func (o *OtelMetrics) RegisterMetricsEndpoint(
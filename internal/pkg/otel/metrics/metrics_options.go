@@ -3,6 +3,7 @@ package metrics
 import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/failover"
 	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
 
 	"github.com/iancoleman/strcase"
@@ -16,19 +17,20 @@ type OTLPProvider struct {
 }
 
 type MetricsOptions struct {
-	Host                      string         `mapstructure:"host"`
-	Port                      string         `mapstructure:"port"`
-	ServiceName               string         `mapstructure:"serviceName"`
-	Version                   string         `mapstructure:"version"`
-	MetricsRoutePath          string         `mapstructure:"metricsRoutePath"`
-	EnableHostMetrics         bool           `mapstructure:"enableHostMetrics"`
-	UseStdout                 bool           `mapstructure:"useStdout"`
-	InstrumentationName       string         `mapstructure:"instrumentationName"`
-	UseOTLP                   bool           `mapstructure:"useOTLP"`
-	OTLPProviders             []OTLPProvider `mapstructure:"otlpProviders"`
-	ElasticApmExporterOptions *OTLPProvider  `mapstructure:"elasticApmExporterOptions"`
-	UptraceExporterOptions    *OTLPProvider  `mapstructure:"uptraceExporterOptions"`
-	SignozExporterOptions     *OTLPProvider  `mapstructure:"signozExporterOptions"`
+	Host                      string                    `mapstructure:"host"`
+	Port                      string                    `mapstructure:"port"`
+	ServiceName               string                    `mapstructure:"serviceName"`
+	Version                   string                    `mapstructure:"version"`
+	MetricsRoutePath          string                    `mapstructure:"metricsRoutePath"`
+	EnableHostMetrics         bool                      `mapstructure:"enableHostMetrics"`
+	UseStdout                 bool                      `mapstructure:"useStdout"`
+	InstrumentationName       string                    `mapstructure:"instrumentationName"`
+	UseOTLP                   bool                      `mapstructure:"useOTLP"`
+	OTLPProviders             []OTLPProvider            `mapstructure:"otlpProviders"`
+	Failover                  *failover.FailoverOptions `mapstructure:"failover"`
+	ElasticApmExporterOptions *OTLPProvider             `mapstructure:"elasticApmExporterOptions"`
+	UptraceExporterOptions    *OTLPProvider             `mapstructure:"uptraceExporterOptions"`
+	SignozExporterOptions     *OTLPProvider             `mapstructure:"signozExporterOptions"`
 }
 
 func ProvideMetricsConfig(
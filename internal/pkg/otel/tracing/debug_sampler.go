@@ -0,0 +1,66 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DebugBaggageKey is the baggage entry which forces the full tracing of a business flow, the `baggage: debug=1` header
+// is propagated with the baggage propagator across http, grpc and rabbitmq, so all the downstream services sample the
+// spans of the flow regardless of their sampler.
+const (
+	DebugBaggageKey   = "debug"
+	DebugBaggageValue = "1"
+	DebugAttributeKey = attribute.Key("debug")
+)
+
+type debugBaggageSampler struct {
+	delegate tracesdk.Sampler
+}
+
+// NewDebugBaggageSampler samples the spans of a debug flow and uses the delegate sampler for the other spans
+func NewDebugBaggageSampler(delegate tracesdk.Sampler) tracesdk.Sampler {
+	return &debugBaggageSampler{delegate: delegate}
+}
+
+func (d *debugBaggageSampler) ShouldSample(parameters tracesdk.SamplingParameters) tracesdk.SamplingResult {
+	if !IsDebugFlow(parameters.ParentContext) {
+		return d.delegate.ShouldSample(parameters)
+	}
+
+	return tracesdk.SamplingResult{
+		Decision:   tracesdk.RecordAndSample,
+		Attributes: []attribute.KeyValue{DebugAttributeKey.Bool(true)},
+		Tracestate: trace.SpanContextFromContext(parameters.ParentContext).TraceState(),
+	}
+}
+
+func (d *debugBaggageSampler) Description() string {
+	return fmt.Sprintf("DebugBaggageSampler{%s}", d.delegate.Description())
+}
+
+// IsDebugFlow returns true when the context belongs to a flow which is started with the debug baggage entry
+func IsDebugFlow(ctx context.Context) bool {
+	return baggage.FromContext(ctx).Member(DebugBaggageKey).Value() == DebugBaggageValue
+}
+
+// WithDebugFlow adds the debug baggage entry to the context, so the spans of the flow are sampled here and in all the
+// downstream services
+func WithDebugFlow(ctx context.Context) context.Context {
+	member, err := baggage.NewMember(DebugBaggageKey, DebugBaggageValue)
+	if err != nil {
+		return ctx
+	}
+
+	bag, err := baggage.FromContext(ctx).SetMember(member)
+	if err != nil {
+		return ctx
+	}
+
+	return baggage.ContextWithBaggage(ctx, bag)
+}
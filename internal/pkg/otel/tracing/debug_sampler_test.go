@@ -0,0 +1,49 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func Test_Debug_Baggage_Sampler_Samples_Debug_Flow(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := tracesdk.NewTracerProvider(
+		tracesdk.WithSpanProcessor(recorder),
+		tracesdk.WithSampler(NewDebugBaggageSampler(tracesdk.NeverSample())),
+	)
+	tracer := provider.Tracer("test")
+
+	_, span := tracer.Start(context.Background(), "not-sampled")
+	span.End()
+
+	ctx, span := tracer.Start(WithDebugFlow(context.Background()), "sampled")
+	_, child := tracer.Start(ctx, "sampled-child")
+	child.End()
+	span.End()
+
+	ended := recorder.Ended()
+	assert.Len(t, ended, 2)
+	assert.Equal(t, "sampled-child", ended[0].Name())
+	assert.Contains(t, ended[1].Attributes(), DebugAttributeKey.Bool(true))
+}
+
+func Test_Debug_Flow_Is_Propagated_With_Baggage_Header(t *testing.T) {
+	member, _ := baggage.NewMember("correlation-id", "123")
+	bag, _ := baggage.New(member)
+	ctx := WithDebugFlow(baggage.ContextWithBaggage(context.Background(), bag))
+
+	carrier := propagation.HeaderCarrier{}
+	propagation.Baggage{}.Inject(ctx, carrier)
+
+	extracted := propagation.Baggage{}.Extract(context.Background(), carrier)
+
+	assert.True(t, IsDebugFlow(extracted))
+	assert.Equal(t, "123", baggage.FromContext(extracted).Member("correlation-id").Value())
+	assert.False(t, IsDebugFlow(context.Background()))
+}
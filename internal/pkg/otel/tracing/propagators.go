@@ -0,0 +1,72 @@
+package tracing
+
+import (
+	"strings"
+
+	"emperror.dev/errors"
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/contrib/propagators/jaeger"
+	"go.opentelemetry.io/contrib/propagators/ot"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// the names of the propagators are the values of the `OTEL_PROPAGATORS` env of the otel sdk specification
+// https://opentelemetry.io/docs/specs/otel/configuration/sdk-environment-variables/#general-sdk-configuration
+const (
+	// TraceContextPropagator is the w3c `traceparent` and `tracestate` headers
+	TraceContextPropagator = "tracecontext"
+	// BaggagePropagator is the w3c `baggage` header, the debug flows of the `DebugBaggageSampler` need it
+	BaggagePropagator = "baggage"
+	// B3Propagator is the zipkin single `b3` header
+	B3Propagator = "b3"
+	// B3MultiPropagator is the zipkin `x-b3-*` headers
+	B3MultiPropagator = "b3multi"
+	// JaegerPropagator is the jaeger `uber-trace-id` header
+	JaegerPropagator = "jaeger"
+	// OTPropagator is the opentracing `ot-tracer-*` headers
+	OTPropagator = "ot"
+)
+
+// DefaultPropagators is the composition of the services without the `propagators` option, `ot` should be placed
+// before `tracecontext` for preventing conflict
+var DefaultPropagators = []string{OTPropagator, BaggagePropagator, TraceContextPropagator} //nolint:gochecknoglobals
+
+// NewPropagator composes the propagators by their names, all of them inject their headers and the later ones override
+// the context extracted by the former ones when the incoming request has the headers of multiple formats
+// https://github.com/open-telemetry/opentelemetry-go-contrib/tree/main/propagators
+func NewPropagator(names []string) (propagation.TextMapPropagator, error) {
+	if len(names) == 0 {
+		names = DefaultPropagators
+	}
+
+	propagators := make([]propagation.TextMapPropagator, 0, len(names))
+	for _, name := range names {
+		propagator, err := newPropagator(name)
+		if err != nil {
+			return nil, err
+		}
+
+		propagators = append(propagators, propagator)
+	}
+
+	return propagation.NewCompositeTextMapPropagator(propagators...), nil
+}
+
+func newPropagator(name string) (propagation.TextMapPropagator, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case TraceContextPropagator:
+		return propagation.TraceContext{}, nil
+	case BaggagePropagator:
+		return propagation.Baggage{}, nil
+	case B3Propagator:
+		return b3.New(b3.WithInjectEncoding(b3.B3SingleHeader)), nil
+	case B3MultiPropagator:
+		return b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader)), nil
+	case JaegerPropagator:
+		return jaeger.Jaeger{}, nil
+	case OTPropagator:
+		return ot.OT{}, nil
+	default:
+		return nil, errors.Errorf("unknown tracing propagator %q", name)
+	}
+}
@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func Test_Propagators_Inject_Their_Headers(t *testing.T) {
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	tests := []struct {
+		name    string
+		names   []string
+		headers []string
+	}{
+		{name: "default", headers: []string{"ot-tracer-traceid", "traceparent"}},
+		{name: "tracecontext", names: []string{"tracecontext"}, headers: []string{"traceparent"}},
+		{name: "b3 single header", names: []string{"b3"}, headers: []string{"b3"}},
+		{name: "b3 multiple headers", names: []string{"B3Multi"}, headers: []string{"x-b3-traceid", "x-b3-spanid"}},
+		{name: "jaeger", names: []string{"jaeger"}, headers: []string{"uber-trace-id"}},
+		{name: "b3 with tracecontext", names: []string{"tracecontext", "b3"}, headers: []string{"traceparent", "b3"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			propagator, err := NewPropagator(test.names)
+			require.NoError(t, err)
+
+			carrier := propagation.MapCarrier{}
+			propagator.Inject(trace.ContextWithSpanContext(context.Background(), spanContext), carrier)
+
+			for _, header := range test.headers {
+				assert.NotEmpty(t, carrier.Get(header), header)
+			}
+
+			extracted := trace.SpanContextFromContext(propagator.Extract(context.Background(), carrier))
+			assert.Equal(t, spanContext.TraceID(), extracted.TraceID())
+			assert.Equal(t, spanContext.SpanID(), extracted.SpanID())
+		})
+	}
+}
+
+func Test_Propagator_Extracts_The_Legacy_B3_Headers(t *testing.T) {
+	propagator, err := NewPropagator([]string{TraceContextPropagator, B3MultiPropagator})
+	require.NoError(t, err)
+
+	carrier := propagation.MapCarrier{
+		"x-b3-traceid": "4bf92f3577b34da6a3ce929d0e0e4736",
+		"x-b3-spanid":  "00f067aa0ba902b7",
+		"x-b3-sampled": "1",
+	}
+
+	extracted := trace.SpanContextFromContext(propagator.Extract(context.Background(), carrier))
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", extracted.TraceID().String())
+	assert.True(t, extracted.IsSampled())
+	assert.True(t, extracted.IsRemote())
+}
+
+func Test_Unknown_Propagator(t *testing.T) {
+	_, err := NewPropagator([]string{TraceContextPropagator, "xray"})
+	assert.ErrorContains(t, err, `unknown tracing propagator "xray"`)
+}
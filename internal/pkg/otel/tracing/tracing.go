@@ -17,16 +17,15 @@ import (
 	"time"
 
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/failover"
 
 	"emperror.dev/errors"
 	"github.com/samber/lo"
-	"go.opentelemetry.io/contrib/propagators/ot"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
 	"go.opentelemetry.io/otel/exporters/zipkin"
-	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	tracesdk "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
@@ -70,6 +69,11 @@ func (o *TracingOpenTelemetry) Shutdown(ctx context.Context) error {
 	return o.provider.Shutdown(ctx)
 }
 
+// RegisterSpanProcessor adds a processor to the trace provider, e.g. a span collector of the tests
+func (o *TracingOpenTelemetry) RegisterSpanProcessor(processor tracesdk.SpanProcessor) {
+	o.provider.RegisterSpanProcessor(processor)
+}
+
 func (o *TracingOpenTelemetry) newResource() (*resource.Resource, error) {
 	// https://github.com/uptrace/uptrace-go/blob/master/example/otlp-traces/main.go#L49C1-L56C5
 	resource, err := resource.New(context.Background(),
@@ -98,6 +102,11 @@ func (o *TracingOpenTelemetry) initTracer(
 		return nil, err
 	}
 
+	propagator, err := NewPropagator(o.config.Propagators)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to create propagator")
+	}
+
 	var sampler tracesdk.Sampler
 	if o.config.AlwaysOnSampler {
 		sampler = tracesdk.AlwaysSample()
@@ -105,6 +114,10 @@ func (o *TracingOpenTelemetry) initTracer(
 		sampler = tracesdk.NeverSample()
 	}
 
+	if o.config.DebugBaggageSampling {
+		sampler = NewDebugBaggageSampler(sampler)
+	}
+
 	batchExporters := lo.Map(
 		exporters,
 		func(item tracesdk.SpanExporter, index int) tracesdk.TracerProviderOption {
@@ -131,13 +144,7 @@ func (o *TracingOpenTelemetry) initTracer(
 	// https://github.com/open-telemetry/opentelemetry-go/blob/main/propagation/trace_context.go
 	// https://github.com/open-telemetry/opentelemetry-go/blob/main/propagation/baggage.go/
 	// https://trstringer.com/otel-part5-propagation/
-	otel.SetTextMapPropagator(
-		propagation.NewCompositeTextMapPropagator(
-			ot.OT{}, // should be placed before `TraceContext` for preventing conflict
-			propagation.Baggage{},
-			propagation.TraceContext{},
-		),
-	)
+	otel.SetTextMapPropagator(propagator)
 
 	// https://trstringer.com/otel-part2-instrumentation/
 	// Finally, set the tracer that can be used for this package. global app tracer
@@ -178,7 +185,7 @@ func (o *TracingOpenTelemetry) configExporters() ([]tracesdk.SpanExporter, error
 
 			// send otel traces to jaeger builtin collector endpoint (default grpc port: 4317)
 			// https://opentelemetry.io/docs/collector/
-			jaegerTraceExporter, err := otlptracegrpc.New(ctx, traceOpts...)
+			jaegerTraceExporter, err := o.newOTLPExporter(ctx, "jaeger", traceOpts)
 			if err != nil {
 				return nil, errors.WrapIf(
 					err,
@@ -204,9 +211,10 @@ func (o *TracingOpenTelemetry) configExporters() ([]tracesdk.SpanExporter, error
 
 			// send otel traces to jaeger builtin collector endpoint (default grpc port: 4317)
 			// https://opentelemetry.io/docs/collector/
-			grafanaTempoTraceExporter, err := otlptracegrpc.New(
+			grafanaTempoTraceExporter, err := o.newOTLPExporter(
 				ctx,
-				traceOpts...)
+				"grafana-tempo",
+				traceOpts)
 			if err != nil {
 				return nil, errors.WrapIf(
 					err,
@@ -247,7 +255,7 @@ func (o *TracingOpenTelemetry) configExporters() ([]tracesdk.SpanExporter, error
 
 			// send otel traces to jaeger builtin collector endpoint (default grpc port: 4317)
 			// https://opentelemetry.io/docs/collector/
-			elasticApmExporter, err := otlptracegrpc.New(ctx, traceOpts...)
+			elasticApmExporter, err := o.newOTLPExporter(ctx, "elastic-apm", traceOpts)
 			if err != nil {
 				return nil, errors.WrapIf(
 					err,
@@ -274,7 +282,7 @@ func (o *TracingOpenTelemetry) configExporters() ([]tracesdk.SpanExporter, error
 
 			// send otel traces to jaeger builtin collector endpoint (default grpc port: 4317)
 			// https://opentelemetry.io/docs/collector/
-			uptraceExporter, err := otlptracegrpc.New(ctx, traceOpts...)
+			uptraceExporter, err := o.newOTLPExporter(ctx, "uptrace", traceOpts)
 			if err != nil {
 				return nil, errors.WrapIf(
 					err,
@@ -300,7 +308,7 @@ func (o *TracingOpenTelemetry) configExporters() ([]tracesdk.SpanExporter, error
 
 			// send otel traces to jaeger builtin collector endpoint (default grpc port: 4317)
 			// https://opentelemetry.io/docs/collector/
-			signozExporter, err := otlptracegrpc.New(ctx, traceOpts...)
+			signozExporter, err := o.newOTLPExporter(ctx, "signoz", traceOpts)
 			if err != nil {
 				return nil, errors.WrapIf(
 					err,
@@ -337,7 +345,7 @@ func (o *TracingOpenTelemetry) configExporters() ([]tracesdk.SpanExporter, error
 			// https://opentelemetry.io/docs/collector/
 			// https://github.com/uptrace/uptrace-go/blob/master/example/otlp-traces/main.go#L29
 			// https://github.com/open-telemetry/opentelemetry-go/blob/main/exporters/otlp/otlptrace/otlptracehttp/example_test.go#L70
-			traceExporter, err := otlptracegrpc.New(ctx, traceOpts...)
+			traceExporter, err := o.newOTLPExporter(ctx, oltpProvider.Name, traceOpts)
 			if err != nil {
 				return nil, errors.WrapIf(err, "failed to create otlptracegrpc exporter")
 			}
@@ -348,3 +356,21 @@ func (o *TracingOpenTelemetry) configExporters() ([]tracesdk.SpanExporter, error
 
 	return exporters, nil
 }
+
+// newOTLPExporter creates an otlp grpc exporter, with the failover options the failed exports go to the secondary
+// endpoint or the disk buffer instead of being dropped
+func (o *TracingOpenTelemetry) newOTLPExporter(
+	ctx context.Context,
+	name string,
+	traceOpts []otlptracegrpc.Option,
+) (tracesdk.SpanExporter, error) {
+	if o.config.Failover == nil {
+		return otlptracegrpc.New(ctx, traceOpts...)
+	}
+
+	if name == "" {
+		name = "otlp"
+	}
+
+	return failover.NewTraceExporter(ctx, name, o.config.Failover, traceOpts...)
+}
@@ -3,6 +3,7 @@ package tracing
 import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/failover"
 	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
 
 	"github.com/iancoleman/strcase"
@@ -16,21 +17,26 @@ type OTLPProvider struct {
 }
 
 type TracingOptions struct {
-	Enabled                   bool                   `mapstructure:"enabled"`
-	ServiceName               string                 `mapstructure:"serviceName"`
-	Version                   string                 `mapstructure:"version"`
-	InstrumentationName       string                 `mapstructure:"instrumentationName"`
-	Id                        int64                  `mapstructure:"id"`
-	AlwaysOnSampler           bool                   `mapstructure:"alwaysOnSampler"`
-	ZipkinExporterOptions     *ZipkinExporterOptions `mapstructure:"zipkinExporterOptions"`
-	JaegerExporterOptions     *OTLPProvider          `mapstructure:"jaegerExporterOptions"`
-	ElasticApmExporterOptions *OTLPProvider          `mapstructure:"elasticApmExporterOptions"`
-	UptraceExporterOptions    *OTLPProvider          `mapstructure:"uptraceExporterOptions"`
-	SignozExporterOptions     *OTLPProvider          `mapstructure:"signozExporterOptions"`
-	TempoExporterOptions      *OTLPProvider          `mapstructure:"tempoExporterOptions"`
-	UseStdout                 bool                   `mapstructure:"useStdout"`
-	UseOTLP                   bool                   `mapstructure:"useOTLP"`
-	OTLPProviders             []OTLPProvider         `mapstructure:"otlpProviders"`
+	Enabled                   bool                      `mapstructure:"enabled"`
+	ServiceName               string                    `mapstructure:"serviceName"`
+	Version                   string                    `mapstructure:"version"`
+	InstrumentationName       string                    `mapstructure:"instrumentationName"`
+	Id                        int64                     `mapstructure:"id"`
+	AlwaysOnSampler           bool                      `mapstructure:"alwaysOnSampler"`
+	DebugBaggageSampling      bool                      `mapstructure:"debugBaggageSampling" default:"true"`
+	ZipkinExporterOptions     *ZipkinExporterOptions    `mapstructure:"zipkinExporterOptions"`
+	JaegerExporterOptions     *OTLPProvider             `mapstructure:"jaegerExporterOptions"`
+	ElasticApmExporterOptions *OTLPProvider             `mapstructure:"elasticApmExporterOptions"`
+	UptraceExporterOptions    *OTLPProvider             `mapstructure:"uptraceExporterOptions"`
+	SignozExporterOptions     *OTLPProvider             `mapstructure:"signozExporterOptions"`
+	TempoExporterOptions      *OTLPProvider             `mapstructure:"tempoExporterOptions"`
+	UseStdout                 bool                      `mapstructure:"useStdout"`
+	UseOTLP                   bool                      `mapstructure:"useOTLP"`
+	OTLPProviders             []OTLPProvider            `mapstructure:"otlpProviders"`
+	Failover                  *failover.FailoverOptions `mapstructure:"failover"`
+	// Propagators are the formats of the trace context headers, e.g. `["tracecontext", "baggage", "b3multi"]`, the
+	// `DefaultPropagators` are used when it is empty
+	Propagators []string `mapstructure:"propagators"`
 }
 
 type ZipkinExporterOptions struct {
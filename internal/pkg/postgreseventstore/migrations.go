@@ -0,0 +1,47 @@
+package postgreseventstore
+
+import (
+	"embed"
+
+	"emperror.dev/errors"
+	"github.com/pressly/goose/v3"
+	"gorm.io/gorm"
+)
+
+// MigrationsDir is the directory of the goose migrations of the `events` and `event_streams` tables in Migrations
+const MigrationsDir = "migrations"
+
+// MigrationsTableName is the goose version table of the event store migrations, it is separated from the version table
+// of the service migrations, so the versions of the two migrations don't collide
+const MigrationsTableName = "event_store_db_version"
+
+// Migrations are the goose migrations of the event store tables, they are applied by `ModuleFunc` on the startup
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
+
+// MigrateEventStore applies the embedded goose migrations of the event store tables with their own version table. goose
+// keeps the base fs and the version table globally, so they are restored for the goose migrations of the service
+func MigrateEventStore(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	goose.SetBaseFS(Migrations)
+	goose.SetTableName(MigrationsTableName)
+	defer func() {
+		goose.SetBaseFS(nil)
+		goose.SetTableName("goose_db_version")
+	}()
+
+	if err := goose.SetDialect("postgres"); err != nil {
+		return err
+	}
+
+	if err := goose.Up(sqlDB, MigrationsDir); err != nil {
+		return errors.WrapIf(err, "error in applying the event store migrations")
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS events
+(
+    global_position bigserial PRIMARY KEY,
+    event_id        uuid,
+    stream_name     text   NOT NULL,
+    version         bigint NOT NULL,
+    event_type      text   NOT NULL,
+    content_type    text,
+    data            bytea,
+    metadata        bytea,
+    created_at      timestamp with time zone
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_events_stream_version ON events (stream_name, version);
+CREATE INDEX IF NOT EXISTS idx_events_event_id ON events (event_id);
+
+CREATE TABLE IF NOT EXISTS event_streams
+(
+    stream_name text PRIMARY KEY,
+    version     bigint,
+    updated_at  timestamp with time zone
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS event_streams;
+DROP TABLE IF EXISTS events;
+-- +goose StatementEnd
@@ -0,0 +1,542 @@
+package postgreseventstore
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/store"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models"
+	appendResult "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/append_result"
+	streamName "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_name"
+	readPosition "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_position/read_position"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_position/truncatePosition"
+	expectedStreamVersion "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_version"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb"
+	esErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb/errors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/utils"
+
+	"emperror.dev/errors"
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	uuid2 "github.com/gofrs/uuid"
+	uuid "github.com/satori/go.uuid"
+	attribute2 "go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// appendLockKey is the key of the transaction level advisory lock of the appends. the appends are serialized, so the
+// global positions are committed in their order and a subscription never passes an event which is committed later with
+// a lower position.
+const appendLockKey = 7_410_296_118
+
+// postgresEventStore keeps the events of all the streams in the `events` table, the events are serialized with the
+// EsdbSerializer, so they have the same payloads, metadata and upcasters as the events of eventstoredb.
+type postgresEventStore struct {
+	log        logger.Logger
+	db         *gorm.DB
+	serializer *eventstroredb.EsdbSerializer
+	tracer     trace.Tracer
+	enrichers  []eventstroredb.MetadataEnricher
+}
+
+func NewPostgresEventStore(
+	log logger.Logger,
+	db *gorm.DB,
+	serializer *eventstroredb.EsdbSerializer,
+	tracer trace.Tracer,
+	enrichers []eventstroredb.MetadataEnricher,
+) store.EventStore {
+	return &postgresEventStore{
+		log:        log,
+		db:         db,
+		serializer: serializer,
+		tracer:     tracer,
+		enrichers:  enrichers,
+	}
+}
+
+func (p *postgresEventStore) StreamExists(stream streamName.StreamName, ctx context.Context) (bool, error) {
+	ctx, span := p.tracer.Start(ctx, "postgresEventStore.StreamExists")
+	span.SetAttributes(attribute2.String("StreamName", stream.String()))
+	defer span.End()
+
+	_, exists, err := p.streamVersion(p.db.WithContext(ctx), stream)
+	if err != nil {
+		return false, utils.TraceErrStatusFromSpan(
+			span,
+			errors.WithMessage(esErrors.NewReadStreamError(err), "error in reading stream"),
+		)
+	}
+
+	return exists, nil
+}
+
+func (p *postgresEventStore) ReadEventsFromStart(
+	stream streamName.StreamName,
+	count uint64,
+	ctx context.Context,
+) ([]*models.StreamEvent, error) {
+	return p.ReadEvents(stream, readPosition.Start, count, ctx)
+}
+
+func (p *postgresEventStore) ReadEvents(
+	stream streamName.StreamName,
+	position readPosition.StreamReadPosition,
+	count uint64,
+	ctx context.Context,
+) ([]*models.StreamEvent, error) {
+	ctx, span := p.tracer.Start(ctx, "postgresEventStore.ReadEvents")
+	span.SetAttributes(attribute2.String("StreamName", stream.String()))
+	defer span.End()
+
+	events, err := p.readStream(ctx, stream, position, count, false)
+	if err != nil {
+		return nil, utils.TraceErrStatusFromSpan(span, err)
+	}
+
+	return events, nil
+}
+
+func (p *postgresEventStore) ReadEventsWithMaxCount(
+	stream streamName.StreamName,
+	position readPosition.StreamReadPosition,
+	ctx context.Context,
+) ([]*models.StreamEvent, error) {
+	return p.ReadEvents(stream, position, uint64(math.MaxUint64), ctx)
+}
+
+func (p *postgresEventStore) ReadEventsBackwards(
+	stream streamName.StreamName,
+	position readPosition.StreamReadPosition,
+	count uint64,
+	ctx context.Context,
+) ([]*models.StreamEvent, error) {
+	ctx, span := p.tracer.Start(ctx, "postgresEventStore.ReadEventsBackwards")
+	span.SetAttributes(attribute2.String("StreamName", stream.String()))
+	defer span.End()
+
+	events, err := p.readStream(ctx, stream, position, count, true)
+	if err != nil {
+		return nil, utils.TraceErrStatusFromSpan(span, err)
+	}
+
+	return events, nil
+}
+
+func (p *postgresEventStore) ReadEventsBackwardsFromEnd(
+	stream streamName.StreamName,
+	count uint64,
+	ctx context.Context,
+) ([]*models.StreamEvent, error) {
+	return p.ReadEventsBackwards(stream, readPosition.End, count, ctx)
+}
+
+func (p *postgresEventStore) ReadEventsBackwardsWithMaxCount(
+	stream streamName.StreamName,
+	position readPosition.StreamReadPosition,
+	ctx context.Context,
+) ([]*models.StreamEvent, error) {
+	return p.ReadEventsBackwards(stream, position, uint64(math.MaxUint64), ctx)
+}
+
+func (p *postgresEventStore) AppendEvents(
+	stream streamName.StreamName,
+	expectedVersion expectedStreamVersion.ExpectedStreamVersion,
+	events []*models.StreamEvent,
+	ctx context.Context,
+) (*appendResult.AppendEventsResult, error) {
+	ctx, span := p.tracer.Start(ctx, "postgresEventStore.AppendEvents")
+	span.SetAttributes(attribute2.String("StreamName", stream.String()))
+	defer span.End()
+
+	var result *appendResult.AppendEventsResult
+
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockStreams(tx); err != nil {
+			return err
+		}
+
+		version, exists, err := p.streamVersion(tx, stream)
+		if err != nil {
+			return err
+		}
+
+		if err := checkExpectedVersion(expectedVersion, exists, version); err != nil {
+			return err
+		}
+
+		storedEvents := make([]*StoredEvent, 0, len(events))
+		for i, event := range events {
+			storedEvent, err := p.toStoredEvent(ctx, stream, version+1+int64(i), event)
+			if err != nil {
+				return err
+			}
+
+			storedEvents = append(storedEvents, storedEvent)
+		}
+
+		if len(storedEvents) > 0 {
+			if err := tx.Create(&storedEvents).Error; err != nil {
+				return err
+			}
+
+			version = storedEvents[len(storedEvents)-1].Version
+		}
+
+		if err := p.saveStreamVersion(tx, stream, version); err != nil {
+			return err
+		}
+
+		result = appendResult.From(0, uint64(version))
+		if len(storedEvents) > 0 {
+			result.GlobalPosition = uint64(storedEvents[len(storedEvents)-1].GlobalPosition)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, utils.TraceErrStatusFromSpan(
+			span,
+			errors.WithMessage(
+				esErrors.NewAppendToStreamError(err, stream.String()),
+				"error in appending to stream",
+			),
+		)
+	}
+
+	p.log.Infow(
+		"events append to stream successfully",
+		logger.Fields{"AppendEventsResult": result, "StreamId": stream.String()},
+	)
+
+	return result, nil
+}
+
+func (p *postgresEventStore) AppendNewEvents(
+	stream streamName.StreamName,
+	events []*models.StreamEvent,
+	ctx context.Context,
+) (*appendResult.AppendEventsResult, error) {
+	return p.AppendEvents(stream, expectedStreamVersion.NoStream, events, ctx)
+}
+
+// TruncateStream deletes the events before the truncate position, unlike eventstoredb the truncated events are not
+// kept until a scavenge, so they are not read by the subscriptions which are behind the truncation
+func (p *postgresEventStore) TruncateStream(
+	stream streamName.StreamName,
+	position truncatePosition.StreamTruncatePosition,
+	expectedVersion expectedStreamVersion.ExpectedStreamVersion,
+	ctx context.Context,
+) (*appendResult.AppendEventsResult, error) {
+	ctx, span := p.tracer.Start(ctx, "postgresEventStore.TruncateStream")
+	span.SetAttributes(attribute2.String("StreamName", stream.String()))
+	defer span.End()
+
+	var result *appendResult.AppendEventsResult
+
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockStreams(tx); err != nil {
+			return err
+		}
+
+		version, exists, err := p.streamVersion(tx, stream)
+		if err != nil {
+			return err
+		}
+
+		if err := checkExpectedVersion(expectedVersion, exists, version); err != nil {
+			return err
+		}
+
+		err = tx.Where("stream_name = ? AND version < ?", stream.String(), position.Value()).
+			Delete(&StoredEvent{}).
+			Error
+		if err != nil {
+			return err
+		}
+
+		result = appendResult.From(0, uint64(version))
+
+		return nil
+	})
+	if err != nil {
+		return nil, utils.TraceErrStatusFromSpan(
+			span,
+			errors.WithMessage(
+				esErrors.NewTruncateStreamError(err, stream.String()),
+				"error in truncating stream",
+			),
+		)
+	}
+
+	p.log.Infow(
+		fmt.Sprintf("stream with id %s truncated successfully", stream.String()),
+		logger.Fields{"StreamId": stream.String(), "TruncatePosition": position.Value()},
+	)
+
+	return result, nil
+}
+
+// DeleteStream deletes the events and the version of the stream, so a new stream with the same name starts from the
+// zero version
+func (p *postgresEventStore) DeleteStream(
+	stream streamName.StreamName,
+	expectedVersion expectedStreamVersion.ExpectedStreamVersion,
+	ctx context.Context,
+) error {
+	ctx, span := p.tracer.Start(ctx, "postgresEventStore.DeleteStream")
+	span.SetAttributes(attribute2.String("StreamName", stream.String()))
+	defer span.End()
+
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockStreams(tx); err != nil {
+			return err
+		}
+
+		version, exists, err := p.streamVersion(tx, stream)
+		if err != nil {
+			return err
+		}
+
+		if err := checkExpectedVersion(expectedVersion, exists, version); err != nil {
+			return err
+		}
+
+		if err := tx.Where("stream_name = ?", stream.String()).Delete(&StoredEvent{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("stream_name = ?", stream.String()).Delete(&EventStream{}).Error
+	})
+	if err != nil {
+		return utils.TraceErrStatusFromSpan(
+			span,
+			errors.WithMessage(
+				esErrors.NewDeleteStreamError(err, stream.String()),
+				"error in deleting stream",
+			),
+		)
+	}
+
+	p.log.Infow(
+		fmt.Sprintf("stream with id %s deleted successfully", stream.String()),
+		logger.Fields{"StreamId": stream.String()},
+	)
+
+	return nil
+}
+
+// lockStreams takes the advisory lock of the appends in the transaction, the truncates and the deletes take it too, so
+// their expected version checks don't race with the appends of the stream
+func lockStreams(tx *gorm.DB) error {
+	return tx.Exec("SELECT pg_advisory_xact_lock(?)", appendLockKey).Error
+}
+
+// readStream reads the events of a stream by their versions, a stream which doesn't exist returns
+// `esdb.ErrStreamNotFound` like eventstoredb, so the aggregate and snapshot stores handle both of the stores the same
+func (p *postgresEventStore) readStream(
+	ctx context.Context,
+	stream streamName.StreamName,
+	position readPosition.StreamReadPosition,
+	count uint64,
+	backwards bool,
+) ([]*models.StreamEvent, error) {
+	db := p.db.WithContext(ctx)
+
+	_, exists, err := p.streamVersion(db, stream)
+	if err != nil {
+		return nil, errors.WithMessage(esErrors.NewReadStreamError(err), "error in reading stream")
+	}
+
+	if !exists {
+		return nil, errors.WithMessage(
+			esErrors.NewReadStreamError(esdb.ErrStreamNotFound),
+			"error in reading stream",
+		)
+	}
+
+	query := db.Where("stream_name = ?", stream.String())
+
+	switch {
+	case backwards && !position.IsEnd():
+		query = query.Where("version <= ?", position.Value()).Order("version desc")
+	case backwards:
+		query = query.Order("version desc")
+	case position.IsEnd():
+		// the forward reads from the end of the stream don't have any event
+		return []*models.StreamEvent{}, nil
+	default:
+		query = query.Where("version >= ?", position.Value()).Order("version asc")
+	}
+
+	if count < math.MaxInt32 {
+		query = query.Limit(int(count))
+	}
+
+	var storedEvents []*StoredEvent
+	if err := query.Find(&storedEvents).Error; err != nil {
+		return nil, errors.WithMessage(esErrors.NewReadStreamError(err), "error in reading stream")
+	}
+
+	events, err := toStreamEvents(p.serializer, storedEvents)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in converting to stream events")
+	}
+
+	return events, nil
+}
+
+// streamVersion returns the current version of the stream, exists is false for a stream which doesn't exist
+func (p *postgresEventStore) streamVersion(db *gorm.DB, stream streamName.StreamName) (int64, bool, error) {
+	var streams []*EventStream
+
+	if err := db.Where("stream_name = ?", stream.String()).Limit(1).Find(&streams).Error; err != nil {
+		return 0, false, err
+	}
+
+	if len(streams) == 0 {
+		return int64(expectedStreamVersion.NoStream), false, nil
+	}
+
+	return streams[0].Version, true, nil
+}
+
+func (p *postgresEventStore) saveStreamVersion(db *gorm.DB, stream streamName.StreamName, version int64) error {
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "stream_name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"version", "updated_at"}),
+	}).Create(&EventStream{StreamName: stream.String(), Version: version}).Error
+}
+
+// enrich returns a copy of the stream event with the enriched metadata, the events of an aggregate share the same
+// metadata so it should not be changed in place.
+func (p *postgresEventStore) enrich(ctx context.Context, streamEvent *models.StreamEvent) *models.StreamEvent {
+	if len(p.enrichers) == 0 {
+		return streamEvent
+	}
+
+	meta := metadata.Metadata{}
+	for key, value := range streamEvent.Metadata {
+		meta[key] = value
+	}
+
+	enriched := *streamEvent
+	enriched.Metadata = meta
+
+	for _, enricher := range p.enrichers {
+		enricher.Enrich(ctx, &enriched)
+	}
+
+	return &enriched
+}
+
+func (p *postgresEventStore) toStoredEvent(
+	ctx context.Context,
+	stream streamName.StreamName,
+	version int64,
+	streamEvent *models.StreamEvent,
+) (*StoredEvent, error) {
+	eventData, err := p.serializer.StreamEventToEventData(p.enrich(ctx, streamEvent))
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in serializing the stream event")
+	}
+
+	contentType := "application/octet-stream"
+	if eventData.ContentType == esdb.JsonContentType {
+		contentType = "application/json"
+	}
+
+	eventId := streamEvent.EventID
+	if uuid.Equal(eventId, uuid.Nil) {
+		eventId = uuid.NewV4()
+	}
+
+	return &StoredEvent{
+		EventId:     eventId,
+		StreamName:  stream.String(),
+		Version:     version,
+		EventType:   eventData.EventType,
+		ContentType: contentType,
+		Data:        eventData.Data,
+		Metadata:    eventData.Metadata,
+	}, nil
+}
+
+// toStreamEvents deserializes the stored events with the EsdbSerializer, so the payloads of the old schema versions are
+// upcasted like the events of eventstoredb
+func toStreamEvents(
+	serializer *eventstroredb.EsdbSerializer,
+	storedEvents []*StoredEvent,
+) ([]*models.StreamEvent, error) {
+	events := make([]*models.StreamEvent, 0, len(storedEvents))
+
+	for _, storedEvent := range storedEvents {
+		event, err := toStreamEvent(serializer, storedEvent)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func toStreamEvent(serializer *eventstroredb.EsdbSerializer, storedEvent *StoredEvent) (*models.StreamEvent, error) {
+	eventId, err := uuid2.FromString(storedEvent.EventId.String())
+	if err != nil {
+		return nil, err
+	}
+
+	position := esdb.Position{
+		Commit:  uint64(storedEvent.GlobalPosition),
+		Prepare: uint64(storedEvent.GlobalPosition),
+	}
+
+	return serializer.ResolvedEventToStreamEvent(&esdb.ResolvedEvent{Event: &esdb.RecordedEvent{
+		EventID:      eventId,
+		EventType:    storedEvent.EventType,
+		ContentType:  storedEvent.ContentType,
+		StreamID:     storedEvent.StreamName,
+		EventNumber:  uint64(storedEvent.Version),
+		Position:     position,
+		CreatedDate:  storedEvent.CreatedAt,
+		Data:         storedEvent.Data,
+		UserMetadata: storedEvent.Metadata,
+	}})
+}
+
+// checkExpectedVersion checks the expected version of an append, truncate or delete, a conflict returns
+// `esdb.ErrWrongExpectedStreamRevision` like eventstoredb
+func checkExpectedVersion(
+	expectedVersion expectedStreamVersion.ExpectedStreamVersion,
+	exists bool,
+	version int64,
+) error {
+	switch {
+	case expectedVersion.IsAny():
+		return nil
+	case expectedVersion.IsNoStream() && !exists:
+		return nil
+	case expectedVersion.IsStreamExists() && exists:
+		return nil
+	case expectedVersion.Value() >= 0 && exists && expectedVersion.Value() == version:
+		return nil
+	}
+
+	actual := "no stream"
+	if exists {
+		actual = fmt.Sprintf("version %d", version)
+	}
+
+	return errors.WithStack(fmt.Errorf(
+		"%w, reason: expected version %d but the stream has %s",
+		esdb.ErrWrongExpectedStreamRevision,
+		expectedVersion.Value(),
+		actual,
+	))
+}
@@ -0,0 +1,66 @@
+package postgreseventstore
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/upcasting"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/web"
+
+	"go.uber.org/fx"
+)
+
+// ModuleFunc provides the postgres backed EventStore instead of `eventstroredb.ModuleFunc`, the aggregate stores are
+// created with `eventstroredb.NewEventStoreAggregateStore` on top of it. It should be used with `postgresgorm.Module`
+// and `postgresgorm.CheckpointModule`, the tables of the events are created on the startup by the goose migrations of
+// `Migrations`.
+// https://uber-go.github.io/fx/modules.html
+var ModuleFunc = func(projectionBuilderConstructor interface{}) fx.Option { //nolint:gochecknoglobals
+	return fx.Module(
+		"postgreseventstorefx",
+		fx.Provide(projectionBuilderConstructor),
+		fx.Provide(
+			config.ProvideConfig,
+			provideConfig,
+			eventstroredb.NewEsdbSerializer,
+			fx.Annotate(
+				upcasting.NewUpcasterRegistry,
+				fx.ParamTags(`group:"esdbUpcasters"`),
+			),
+			eventstroredb.NewSnapshotSerializer,
+			eventstroredb.NewEsdbSnapshotStore,
+			fx.Annotate(
+				NewPostgresEventStore,
+				fx.ParamTags(``, ``, ``, ``, `group:"esdbMetadataEnrichers"`),
+			),
+			NewPostgresSubscriptionAllWorker,
+			fx.Annotate(
+				eventstroredb.NewCorrelationMetadataEnricher,
+				fx.ResultTags(`group:"esdbMetadataEnrichers"`),
+			),
+		),
+		fx.Invoke(MigrateEventStore),
+		fx.Invoke(registerHooks),
+	)
+}
+
+func registerHooks(
+	lc fx.Lifecycle,
+	worker PostgresSubscriptionAllWorker,
+	logger logger.Logger,
+	cfg *config.EventStoreDbOptions,
+) {
+	web.RegisterLifetimeWorker(lc, "postgres subscription worker", logger, func(ctx context.Context) error {
+		option := &PostgresSubscriptionToAllOptions{
+			Rebuild: eventstroredb.IsRebuildProjections(),
+		}
+		if cfg.Subscription != nil {
+			option.SubscriptionId = cfg.Subscription.SubscriptionId
+			option.Prefixes = cfg.Subscription.Prefix
+		}
+
+		return worker.SubscribeAll(ctx, option)
+	})
+}
@@ -0,0 +1,307 @@
+//go:build integration
+// +build integration
+
+package postgreseventstore
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/domain"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer/json"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/store"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models"
+	streamName "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_name"
+	readPosition "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_position/read_position"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_position/truncatePosition"
+	expectedStreamVersion "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_version"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/upcasting"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb/config"
+	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+	gormContainer "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/test/containers/testcontainer/gorm"
+
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+type itemAdded struct {
+	*domain.DomainEvent
+	Item string `json:"item"`
+}
+
+func newItemAdded(item string) *itemAdded {
+	event := &itemAdded{Item: item}
+	event.DomainEvent = domain.NewDomainEvent(typeMapper.GetTypeName(event))
+
+	return event
+}
+
+// recordingProjection records the items of the projected events, it signals after each event
+type recordingProjection struct {
+	mu       sync.Mutex
+	items    []string
+	projects chan struct{}
+}
+
+func newRecordingProjection() *recordingProjection {
+	return &recordingProjection{projects: make(chan struct{}, 100)}
+}
+
+func (r *recordingProjection) ProcessEvent(_ context.Context, streamEvent *models.StreamEvent) error {
+	r.mu.Lock()
+	r.items = append(r.items, streamEvent.Event.(*itemAdded).Item)
+	r.mu.Unlock()
+
+	r.projects <- struct{}{}
+
+	return nil
+}
+
+func (r *recordingProjection) Items() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string(nil), r.items...)
+}
+
+type postgresEventStoreFixture struct {
+	db         *gorm.DB
+	serializer *eventstroredb.EsdbSerializer
+	eventStore store.EventStore
+}
+
+func newPostgresEventStoreFixture(t *testing.T) *postgresEventStoreFixture {
+	t.Helper()
+
+	gormOptions, err := gormContainer.NewGormTestContainers(defaultLogger.GetLogger()).
+		PopulateContainerOptions(context.Background(), t)
+	require.NoError(t, err)
+
+	db, err := postgresgorm.NewGorm(gormOptions)
+	require.NoError(t, err)
+
+	// the tables are created by the embedded goose migrations like on the startup of the module
+	require.NoError(t, MigrateEventStore(db))
+
+	require.NoError(t, db.AutoMigrate(&postgresgorm.SubscriptionCheckpoint{}))
+
+	registry, err := upcasting.NewUpcasterRegistry(nil)
+	require.NoError(t, err)
+
+	serializer := eventstroredb.NewEsdbSerializer(
+		json.NewDefaultMetadataJsonSerializer(json.NewDefaultJsonSerializer()),
+		json.NewDefaultEventJsonSerializer(json.NewDefaultJsonSerializer()),
+		registry,
+		&config.EventStoreDbOptions{},
+	)
+
+	return &postgresEventStoreFixture{
+		db:         db,
+		serializer: serializer,
+		eventStore: NewPostgresEventStore(
+			defaultLogger.GetLogger(),
+			db,
+			serializer,
+			trace.NewNoopTracerProvider().Tracer("postgres-event-store-test"),
+			nil,
+		),
+	}
+}
+
+func (f *postgresEventStoreFixture) append(t *testing.T, stream streamName.StreamName, items ...string) {
+	t.Helper()
+
+	events := make([]*models.StreamEvent, 0, len(items))
+	for _, item := range items {
+		event := newItemAdded(item)
+		events = append(events, &models.StreamEvent{EventID: event.GetEventId(), Event: event, Metadata: metadata.Metadata{}})
+	}
+
+	_, err := f.eventStore.AppendEvents(stream, expectedStreamVersion.Any, events, context.Background())
+	require.NoError(t, err)
+}
+
+func items(events []*models.StreamEvent) []string {
+	result := make([]string, 0, len(events))
+	for _, event := range events {
+		result = append(result, event.Event.(*itemAdded).Item)
+	}
+
+	return result
+}
+
+func Test_Concurrent_Appends_With_The_Same_Expected_Version(t *testing.T) {
+	fixture := newPostgresEventStoreFixture(t)
+	stream := streamName.StreamName("cart-" + uuid.NewV4().String())
+	fixture.append(t, stream, "a")
+
+	const appends = 10
+
+	var wg sync.WaitGroup
+	errs := make(chan error, appends)
+
+	for i := 0; i < appends; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			event := newItemAdded("b")
+			_, err := fixture.eventStore.AppendEvents(
+				stream,
+				expectedStreamVersion.FromInt64(0),
+				[]*models.StreamEvent{{EventID: event.GetEventId(), Event: event, Metadata: metadata.Metadata{}}},
+				context.Background(),
+			)
+			errs <- err
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	succeeded := 0
+	for err := range errs {
+		if err == nil {
+			succeeded++
+
+			continue
+		}
+
+		assert.ErrorIs(t, err, esdb.ErrWrongExpectedStreamRevision)
+	}
+
+	assert.Equal(t, 1, succeeded)
+
+	events, err := fixture.eventStore.ReadEventsFromStart(stream, 100, context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, items(events))
+}
+
+func Test_Read_Events_In_Both_Directions(t *testing.T) {
+	fixture := newPostgresEventStoreFixture(t)
+	stream := streamName.StreamName("cart-" + uuid.NewV4().String())
+	fixture.append(t, stream, "a", "b", "c", "d", "e")
+
+	ctx := context.Background()
+
+	forward, err := fixture.eventStore.ReadEvents(stream, readPosition.FromInt64(1), 3, ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "c", "d"}, items(forward))
+	assert.Equal(t, int64(1), forward[0].Version)
+
+	fromEnd, err := fixture.eventStore.ReadEvents(stream, readPosition.End, 3, ctx)
+	require.NoError(t, err)
+	assert.Empty(t, fromEnd)
+
+	backwards, err := fixture.eventStore.ReadEventsBackwardsFromEnd(stream, 2, ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"e", "d"}, items(backwards))
+
+	backwards, err = fixture.eventStore.ReadEventsBackwards(stream, readPosition.FromInt64(2), 10, ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c", "b", "a"}, items(backwards))
+
+	_, err = fixture.eventStore.ReadEventsFromStart(streamName.StreamName("cart-"+uuid.NewV4().String()), 10, ctx)
+	assert.ErrorIs(t, err, esdb.ErrStreamNotFound)
+}
+
+func Test_Truncate_And_Delete_Stream(t *testing.T) {
+	fixture := newPostgresEventStoreFixture(t)
+	stream := streamName.StreamName("cart-" + uuid.NewV4().String())
+	fixture.append(t, stream, "a", "b", "c")
+
+	ctx := context.Background()
+
+	_, err := fixture.eventStore.TruncateStream(stream, truncatePosition.FromInt64(2), expectedStreamVersion.FromInt64(1), ctx)
+	assert.ErrorIs(t, err, esdb.ErrWrongExpectedStreamRevision)
+
+	_, err = fixture.eventStore.TruncateStream(stream, truncatePosition.FromInt64(2), expectedStreamVersion.FromInt64(2), ctx)
+	require.NoError(t, err)
+
+	events, err := fixture.eventStore.ReadEventsFromStart(stream, 10, ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c"}, items(events))
+
+	// the truncated stream keeps its version
+	fixture.append(t, stream, "d")
+	events, err = fixture.eventStore.ReadEventsBackwardsFromEnd(stream, 1, ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), events[0].Version)
+
+	require.NoError(t, fixture.eventStore.DeleteStream(stream, expectedStreamVersion.StreamExists, ctx))
+
+	exists, err := fixture.eventStore.StreamExists(stream, ctx)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func Test_Subscription_Resumes_From_Its_Checkpoint(t *testing.T) {
+	fixture := newPostgresEventStoreFixture(t)
+	stream := streamName.StreamName("cart-" + uuid.NewV4().String())
+	fixture.append(t, stream, "a", "b")
+
+	// other streams are filtered by the prefixes of the subscription
+	fixture.append(t, streamName.StreamName("order-"+uuid.NewV4().String()), "order")
+
+	checkpoints := postgresgorm.NewPostgresSubscriptionCheckpointRepository(fixture.db)
+	options := &PostgresSubscriptionToAllOptions{
+		SubscriptionId: "cart-projections",
+		Prefixes:       []string{"cart-"},
+		SkipEventBus:   true,
+	}
+
+	subscribe := func(expected int) *recordingProjection {
+		projection := newRecordingProjection()
+		worker := NewPostgresSubscriptionAllWorker(
+			defaultLogger.GetLogger(),
+			fixture.db,
+			&config.EventStoreDbOptions{Subscription: &config.Subscription{ResubscribeDelay: 10 * time.Millisecond}},
+			&PostgresEventStoreOptions{PollInterval: 20 * time.Millisecond, BatchSize: 1},
+			fixture.serializer,
+			checkpoints,
+			func(builder eventstroredb.ProjectionsBuilder) { builder.AddProjection(projection) },
+		)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+
+		go func() { done <- worker.SubscribeAll(ctx, options) }()
+
+		for i := 0; i < expected; i++ {
+			select {
+			case <-projection.projects:
+			case <-time.After(10 * time.Second):
+				t.Fatalf("subscription projected %d of %d events", i, expected)
+			}
+		}
+
+		// the subscription doesn't project the events which are not expected
+		select {
+		case <-projection.projects:
+			t.Fatalf("subscription projected more than %d events", expected)
+		case <-time.After(200 * time.Millisecond):
+		}
+
+		cancel()
+		assert.ErrorIs(t, <-done, context.Canceled)
+
+		return projection
+	}
+
+	assert.Equal(t, []string{"a", "b"}, subscribe(2).Items())
+
+	fixture.append(t, stream, "c", "d")
+
+	assert.Equal(t, []string{"c", "d"}, subscribe(2).Items())
+}
@@ -0,0 +1,47 @@
+package postgreseventstore
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+const (
+	defaultPollInterval = 500 * time.Millisecond
+	defaultBatchSize    = 500
+)
+
+// PostgresEventStoreOptions is the polling of the subscription worker, the subscription, snapshot and metadata options
+// are read from the `eventStoreDbOptions`, so a service switches between the event stores without changing them.
+type PostgresEventStoreOptions struct {
+	// PollInterval is the delay before reading the new events after the subscription worker reaches the last event
+	PollInterval time.Duration `mapstructure:"pollInterval"`
+	// BatchSize is the number of the events of each read of the subscription worker
+	BatchSize int `mapstructure:"batchSize"`
+}
+
+func (p *PostgresEventStoreOptions) GetPollInterval() time.Duration {
+	if p == nil || p.PollInterval <= 0 {
+		return defaultPollInterval
+	}
+
+	return p.PollInterval
+}
+
+func (p *PostgresEventStoreOptions) GetBatchSize() int {
+	if p == nil || p.BatchSize <= 0 {
+		return defaultBatchSize
+	}
+
+	return p.BatchSize
+}
+
+func provideConfig(environment environment.Environment) (*PostgresEventStoreOptions, error) {
+	optionName := strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[PostgresEventStoreOptions]())
+
+	return config.BindConfigKey[*PostgresEventStoreOptions](optionName, environment)
+}
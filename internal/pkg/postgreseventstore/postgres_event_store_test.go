@@ -0,0 +1,64 @@
+package postgreseventstore
+
+import (
+	"testing"
+
+	expectedStreamVersion "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_version"
+
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Check_Expected_Version(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected expectedStreamVersion.ExpectedStreamVersion
+		exists   bool
+		version  int64
+		conflict bool
+	}{
+		{name: "any on a new stream", expected: expectedStreamVersion.Any, version: -1},
+		{name: "any on an existing stream", expected: expectedStreamVersion.Any, exists: true, version: 3},
+		{name: "no stream on a new stream", expected: expectedStreamVersion.NoStream, version: -1},
+		{
+			name:     "no stream on an existing stream",
+			expected: expectedStreamVersion.NoStream,
+			exists:   true,
+			version:  0,
+			conflict: true,
+		},
+		{name: "stream exists on an existing stream", expected: expectedStreamVersion.StreamExists, exists: true},
+		{
+			name:     "stream exists on a new stream",
+			expected: expectedStreamVersion.StreamExists,
+			version:  -1,
+			conflict: true,
+		},
+		{name: "current version", expected: expectedStreamVersion.FromInt64(3), exists: true, version: 3},
+		{
+			name:     "stale version",
+			expected: expectedStreamVersion.FromInt64(2),
+			exists:   true,
+			version:  3,
+			conflict: true,
+		},
+		{name: "version on a new stream", expected: expectedStreamVersion.FromInt64(0), version: -1, conflict: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkExpectedVersion(test.expected, test.exists, test.version)
+			if test.conflict {
+				assert.ErrorIs(t, err, esdb.ErrWrongExpectedStreamRevision)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_Like_Prefix_Escapes_The_Wildcards(t *testing.T) {
+	assert.Equal(t, "order-%", likePrefix("order-"))
+	assert.Equal(t, `order\_snapshot-%`, likePrefix("order_snapshot-"))
+	assert.Equal(t, `100\%\\-%`, likePrefix(`100%\-`))
+}
@@ -0,0 +1,228 @@
+package postgreseventstore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/projection"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+
+	"emperror.dev/errors"
+	"github.com/mehdihadeli/go-mediatr"
+	"gorm.io/gorm"
+)
+
+// PostgresSubscriptionAllWorker polls the events of all the streams by their global position and publishes them like
+// the eventstoredb subscription to `$all`
+type PostgresSubscriptionAllWorker interface {
+	SubscribeAll(ctx context.Context, subscriptionOption *PostgresSubscriptionToAllOptions) error
+}
+
+type PostgresSubscriptionToAllOptions struct {
+	SubscriptionId string
+	// Prefixes filters the events by the prefixes of their stream names, e.g. `order-`, empty reads all the streams
+	Prefixes []string
+	// SkipEventBus publishes the events only to the projections of the worker
+	SkipEventBus bool
+	// Rebuild replays the subscription from the first event to rebuild its projections, the events up to its stored
+	// checkpoint are published only to the projections
+	Rebuild bool
+}
+
+type postgresSubscriptionAllWorker struct {
+	log                              logger.Logger
+	db                               *gorm.DB
+	cfg                              *config.EventStoreDbOptions
+	options                          *PostgresEventStoreOptions
+	serializer                       *eventstroredb.EsdbSerializer
+	subscriptionCheckpointRepository contracts.SubscriptionCheckpointRepository
+	projectionPublisher              projection.IProjectionPublisher
+}
+
+func NewPostgresSubscriptionAllWorker(
+	log logger.Logger,
+	db *gorm.DB,
+	cfg *config.EventStoreDbOptions,
+	options *PostgresEventStoreOptions,
+	serializer *eventstroredb.EsdbSerializer,
+	subscriptionRepository contracts.SubscriptionCheckpointRepository,
+	projectionBuilderFunc eventstroredb.ProjectionBuilderFuc,
+) PostgresSubscriptionAllWorker {
+	builder := eventstroredb.NewProjectionsBuilder()
+	if projectionBuilderFunc != nil {
+		projectionBuilderFunc(builder)
+	}
+
+	return &postgresSubscriptionAllWorker{
+		log:                              log,
+		db:                               db,
+		cfg:                              cfg,
+		options:                          options,
+		serializer:                       serializer,
+		subscriptionCheckpointRepository: subscriptionRepository,
+		projectionPublisher:              es.NewProjectionPublisher(builder.Build().Projections),
+	}
+}
+
+func (s *postgresSubscriptionAllWorker) SubscribeAll(
+	ctx context.Context,
+	subscriptionOption *PostgresSubscriptionToAllOptions,
+) error {
+	if subscriptionOption.SubscriptionId == "" {
+		subscriptionOption.SubscriptionId = "defaultLogger"
+	}
+
+	s.log.Info(fmt.Sprintf("starting subscription to all '%s'.", subscriptionOption.SubscriptionId))
+
+	checkpoint, err := s.subscriptionCheckpointRepository.Load(subscriptionOption.SubscriptionId, ctx)
+	if err != nil {
+		return err
+	}
+
+	from := int64(checkpoint)
+	replayUntil := int64(0)
+
+	if subscriptionOption.Rebuild {
+		s.log.Info(
+			fmt.Sprintf(
+				"rebuilding the projections of subscription to all '%s' from the start, replaying until position %d.",
+				subscriptionOption.SubscriptionId,
+				checkpoint,
+			),
+		)
+
+		replayUntil = from
+		from = 0
+	}
+
+	batchSize := s.options.GetBatchSize()
+
+	for attempt := 0; ; {
+		storedEvents, err := s.readEvents(ctx, from, subscriptionOption.Prefixes, batchSize)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			// a failed read is retried like a dropped subscription, the handled events are not read again
+			delay := s.cfg.Subscription.ResubscribeBackoff(attempt)
+			attempt++
+
+			s.log.Errorf(
+				"reading the events of subscription to all '%s' failed, reading again from position %d in %s: %v",
+				subscriptionOption.SubscriptionId,
+				from,
+				delay,
+				err,
+			)
+
+			if !sleep(ctx, delay) {
+				return ctx.Err()
+			}
+
+			continue
+		}
+
+		attempt = 0
+
+		for _, storedEvent := range storedEvents {
+			streamEvent, err := toStreamEvent(s.serializer, storedEvent)
+			if err != nil {
+				return errors.WrapIf(err, "failed to convert stored event to stream event")
+			}
+
+			replayed := replayUntil > 0 && streamEvent.Position <= replayUntil
+
+			// a failed handler stops the worker, reading again would skip the failed event
+			if err := s.handleEvent(ctx, subscriptionOption, streamEvent, replayed); err != nil {
+				return err
+			}
+
+			from = streamEvent.Position
+		}
+
+		if len(storedEvents) < batchSize && !sleep(ctx, s.options.GetPollInterval()) {
+			return ctx.Err()
+		}
+	}
+}
+
+// readEvents reads the events after the global position, the events of the other streams are filtered by the query
+func (s *postgresSubscriptionAllWorker) readEvents(
+	ctx context.Context,
+	from int64,
+	prefixes []string,
+	limit int,
+) ([]*StoredEvent, error) {
+	query := s.db.WithContext(ctx).Where("global_position > ?", from)
+
+	if len(prefixes) > 0 {
+		filter := s.db.Where("stream_name LIKE ?", likePrefix(prefixes[0]))
+		for _, prefix := range prefixes[1:] {
+			filter = filter.Or("stream_name LIKE ?", likePrefix(prefix))
+		}
+
+		query = query.Where(filter)
+	}
+
+	var storedEvents []*StoredEvent
+	if err := query.Order("global_position asc").Limit(limit).Find(&storedEvents).Error; err != nil {
+		return nil, err
+	}
+
+	return storedEvents, nil
+}
+
+func (s *postgresSubscriptionAllWorker) handleEvent(
+	ctx context.Context,
+	subscriptionOption *PostgresSubscriptionToAllOptions,
+	streamEvent *models.StreamEvent,
+	replayed bool,
+) error {
+	// publish to internal event bus - for handling event and project it manually tp corresponding read model
+	if !subscriptionOption.SkipEventBus && !replayed {
+		err := mediatr.Publish(ctx, streamEvent)
+		if err != nil {
+			return errors.WrapIf(
+				err,
+				"failed to publish stream event for the mediatr (internal event bus for handling event)",
+			)
+		}
+	}
+
+	err := s.projectionPublisher.Publish(ctx, streamEvent)
+	if err != nil {
+		return errors.WrapIf(err, "failed to publish stream event in the handle event")
+	}
+
+	if replayed {
+		return nil
+	}
+
+	err = s.subscriptionCheckpointRepository.Store(
+		subscriptionOption.SubscriptionId,
+		uint64(streamEvent.Position),
+		ctx,
+	)
+	if err != nil {
+		return errors.WrapIf(err, "failed to store subscription checkpoint")
+	}
+
+	return nil
+}
+
+// sleep waits for the delay, it returns false when the context is done before it
+func sleep(ctx context.Context, delay time.Duration) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(delay):
+		return true
+	}
+}
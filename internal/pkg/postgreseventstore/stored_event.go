@@ -0,0 +1,46 @@
+package postgreseventstore
+
+import (
+	"strings"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// StoredEvent is an appended event, the events of a stream are unique by their version, so a concurrent append with
+// the same expected version fails instead of forking the stream
+type StoredEvent struct {
+	// GlobalPosition is the order of the event in all the streams, the subscriptions read the events by it
+	GlobalPosition int64     `gorm:"primaryKey;autoIncrement"`
+	EventId        uuid.UUID `gorm:"type:uuid;index"`
+	StreamName     string    `gorm:"not null;uniqueIndex:idx_events_stream_version"`
+	Version        int64     `gorm:"not null;uniqueIndex:idx_events_stream_version"`
+	EventType      string    `gorm:"not null"`
+	ContentType    string
+	Data           []byte
+	Metadata       []byte
+	CreatedAt      time.Time
+}
+
+func (e *StoredEvent) TableName() string {
+	return "events"
+}
+
+// EventStream is the current version of a stream, the truncated streams keep their version after their events are
+// deleted
+type EventStream struct {
+	StreamName string `gorm:"primaryKey"`
+	Version    int64
+	UpdatedAt  time.Time
+}
+
+func (s *EventStream) TableName() string {
+	return "event_streams"
+}
+
+// likePrefix returns the `like` pattern of the streams with a prefix, the wildcards of the prefix are escaped
+func likePrefix(prefix string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix)
+
+	return escaped + "%"
+}
@@ -0,0 +1,12 @@
+package contracts
+
+// VersionColumn is the column of the optimistic concurrency version of the versioned data models
+const VersionColumn = "version"
+
+// VersionedDataModel is a data model with an optimistic concurrency version in its `version` column. the versioned
+// updates only change the row while its version is the version that was read, and increment it, so a concurrent
+// read-modify-write of the same row fails with a concurrency conflict instead of silently overwriting the other change.
+type VersionedDataModel interface {
+	GetVersion() int64
+	SetVersion(version int64)
+}
@@ -0,0 +1,75 @@
+package datatypes
+
+import (
+	"database/sql/driver"
+
+	"emperror.dev/errors"
+	"github.com/goccy/go-json"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// JSON is a raw json document persisted as `jsonb` in postgres and as `text` in other dialects
+type JSON json.RawMessage
+
+// Value return json value, implement driver.Valuer interface
+func (j JSON) Value() (driver.Value, error) {
+	if len(j) == 0 {
+		return nil, nil
+	}
+
+	return string(j), nil
+}
+
+// Scan scan value into JSON, implements sql.Scanner interface
+func (j *JSON) Scan(value interface{}) error {
+	if value == nil {
+		*j = nil
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		*j = append((*j)[0:0], v...)
+	case string:
+		*j = JSON(v)
+	default:
+		return errors.Errorf("failed to unmarshal JSON value: %v", value)
+	}
+
+	return nil
+}
+
+// MarshalJSON to output non base64 encoded []byte
+func (j JSON) MarshalJSON() ([]byte, error) {
+	if len(j) == 0 {
+		return []byte("null"), nil
+	}
+
+	return json.RawMessage(j).MarshalJSON()
+}
+
+// UnmarshalJSON to deserialize []byte
+func (j *JSON) UnmarshalJSON(b []byte) error {
+	*j = append((*j)[0:0], b...)
+
+	return nil
+}
+
+// GormDataType gorm common data type
+func (JSON) GormDataType() string {
+	return "json"
+}
+
+// GormDBDataType gorm db data type
+func (JSON) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return dbDataType(db)
+}
+
+func dbDataType(db *gorm.DB) string {
+	if db.Dialector.Name() == "postgres" {
+		return "JSONB"
+	}
+
+	return "TEXT"
+}
@@ -0,0 +1,74 @@
+package datatypes
+
+import (
+	"context"
+	"database/sql/driver"
+
+	"emperror.dev/errors"
+	"github.com/goccy/go-json"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// https://gorm.io/docs/data_types.html
+
+// JSONMap is a `map[string]interface{}` persisted as `jsonb` in postgres and as `text` in other dialects
+type JSONMap map[string]interface{}
+
+// Value return json value, implement driver.Valuer interface
+func (m JSONMap) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(m)
+
+	return string(b), err
+}
+
+// Scan scan value into JSONMap, implements sql.Scanner interface
+func (m *JSONMap) Scan(value interface{}) error {
+	if value == nil {
+		*m = nil
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return errors.Errorf("failed to unmarshal JSONMap value: %v", value)
+	}
+
+	result := JSONMap{}
+	if err := json.Unmarshal(bytes, &result); err != nil {
+		return err
+	}
+
+	*m = result
+
+	return nil
+}
+
+// GormDataType gorm common data type
+func (JSONMap) GormDataType() string {
+	return "jsonmap"
+}
+
+// GormDBDataType gorm db data type
+func (JSONMap) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return dbDataType(db)
+}
+
+func (m JSONMap) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	data, _ := m.Value()
+	if data == nil {
+		return gorm.Expr("NULL")
+	}
+
+	return gorm.Expr("?", data)
+}
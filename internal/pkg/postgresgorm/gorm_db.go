@@ -50,6 +50,8 @@ func NewGorm(cfg *GormOptions) (*gorm.DB, error) {
 		gormPostgres.Open(dataSourceName),
 		&gorm.Config{
 			Logger: gromlog.NewGormCustomLogger(defaultlogger.GetLogger()),
+			// the unique violations are returned as `gorm.ErrDuplicatedKey`
+			TranslateError: true,
 		},
 	)
 	if err != nil {
@@ -110,6 +112,8 @@ func createPostgresDB(cfg *GormOptions) error {
 		gormPostgres.Open(dataSourceName),
 		&gorm.Config{
 			Logger: gromlog.NewGormCustomLogger(defaultlogger.GetLogger()),
+			// the unique violations are returned as `gorm.ErrDuplicatedKey`
+			TranslateError: true,
 		},
 	)
 	if err != nil {
@@ -21,7 +21,7 @@ type GormOptions struct {
 	User          string `mapstructure:"user"`
 	DBName        string `mapstructure:"dbName"`
 	SSLMode       bool   `mapstructure:"sslMode"`
-	Password      string `mapstructure:"password"`
+	Password      string `mapstructure:"password" secret:"true"`
 	EnableTracing bool   `mapstructure:"enableTracing" default:"true"`
 }
 
@@ -3,6 +3,7 @@ package gormdbcontext
 import (
 	"context"
 
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
 	defaultlogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/contracts"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/helpers/gormextensions"
@@ -54,6 +55,9 @@ func (c *gormDBContext) RunInTx(
 ) error {
 	// https://gorm.io/docs/transactions.html#Transaction
 	tx := c.DB().WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return customErrors.WrapIfCanceled(ctx, tx.Error, "error in beginning the transaction")
+	}
 
 	defaultlogger.GetLogger().Info("beginning database transaction")
 
@@ -80,6 +84,14 @@ func (c *gormDBContext) RunInTx(
 		defaultlogger.GetLogger().Error("rolling back transaction")
 		tx.WithContext(ctx).Rollback()
 
+		return customErrors.WrapIfCanceled(ctx, err, "transaction action canceled")
+	}
+
+	// the action may ignore the cancellation, so we don't commit the changes of a canceled context
+	if err = customErrors.CheckContext(ctx, "transaction canceled before commit"); err != nil {
+		defaultlogger.GetLogger().Error("rolling back canceled transaction")
+		tx.WithContext(ctx).Rollback()
+
 		return err
 	}
 
@@ -87,7 +99,9 @@ func (c *gormDBContext) RunInTx(
 
 	if err = tx.WithContext(ctx).Commit().Error; err != nil {
 		defaultlogger.GetLogger().Errorf("transaction commit error: %+v", err)
+
+		return customErrors.WrapIfCanceled(ctx, err, "transaction commit canceled")
 	}
 
-	return err
+	return nil
 }
@@ -11,8 +11,10 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/scopes"
 	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
 
+	"emperror.dev/errors"
 	"github.com/iancoleman/strcase"
 	uuid "github.com/satori/go.uuid"
+	"gorm.io/gorm"
 )
 
 func Exists[TDataModel interface{}](
@@ -20,13 +22,50 @@ func Exists[TDataModel interface{}](
 	dbContext contracts.GormDBContext,
 	id uuid.UUID,
 ) bool {
+	exists, _ := existsByID[TDataModel](ctx, dbContext, id)
+
+	return exists
+}
+
+func existsByID[TDataModel interface{}](
+	ctx context.Context,
+	dbContext contracts.GormDBContext,
+	id uuid.UUID,
+) (bool, error) {
 	var count int64
 
 	dataModel := typeMapper.GenericInstanceByT[TDataModel]()
 
-	dbContext.DB().WithContext(ctx).Model(dataModel).Scopes(scopes.FilterByID(id)).Count(&count)
+	result := dbContext.DB().WithContext(ctx).Model(dataModel).Scopes(scopes.FilterByID(id)).Count(&count)
+	if result.Error != nil {
+		return false, customErrors.WrapIfCanceled(ctx, result.Error, "error in checking the existence")
+	}
 
-	return count > 0
+	return count > 0, nil
+}
+
+// canceledErr returns a canceled error when the db error is caused by the context cancellation, otherwise nil, so a
+// canceled operation is not reported as a not found or a conflict
+func canceledErr(ctx context.Context, err error, message string) error {
+	if err = customErrors.WrapIfCanceled(ctx, err, message); customErrors.IsCanceledError(err) {
+		return err
+	}
+
+	return nil
+}
+
+// updateErr returns a conflict error when the update violates a unique index like a taken sku, the postgres unique
+// violations are translated to `gorm.ErrDuplicatedKey` by the `TranslateError` option of the gorm config
+func updateErr(ctx context.Context, err error, canceledMessage string, name string) error {
+	if canceled := canceledErr(ctx, err, canceledMessage); canceled != nil {
+		return canceled
+	}
+
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return customErrors.NewConflictErrorWrap(err, fmt.Sprintf("%s with the same unique values already exists", name))
+	}
+
+	return customErrors.NewInternalServerErrorWrap(err, fmt.Sprintf("error in updating the %s", name))
 }
 
 func FindModelByID[TDataModel interface{}, TModel interface{}](
@@ -49,6 +88,10 @@ func FindModelByID[TDataModel interface{}, TModel interface{}](
 
 	result := dbContext.DB().WithContext(ctx).First(&dataModel, id)
 	if result.Error != nil {
+		if err := canceledErr(ctx, result.Error, "finding the model canceled"); err != nil {
+			return *new(TModel), err
+		}
+
 		return *new(TModel), customErrors.NewNotFoundErrorWrap(
 			result.Error,
 			fmt.Sprintf(
@@ -91,6 +134,10 @@ func FindDataModelByID[TDataModel interface{}](
 
 	result := dbContext.DB().WithContext(ctx).First(&dataModel, id)
 	if result.Error != nil {
+		if err := canceledErr(ctx, result.Error, "finding the data model canceled"); err != nil {
+			return *new(TDataModel), err
+		}
+
 		return *new(TDataModel), customErrors.NewNotFoundErrorWrap(
 			result.Error,
 			fmt.Sprintf(
@@ -116,7 +163,11 @@ func DeleteDataModelByID[TDataModel interface{}](
 
 	dataModelName := strcase.ToSnake(typeMapper.GetGenericNonePointerTypeNameByT[TDataModel]())
 
-	exists := Exists[TDataModel](ctx, dbContext, id)
+	exists, err := existsByID[TDataModel](ctx, dbContext, id)
+	if err != nil {
+		return err
+	}
+
 	if !exists {
 		return customErrors.NewNotFoundError(fmt.Sprintf("%s with id `%s` not found in the database",
 			dataModelName,
@@ -131,6 +182,10 @@ func DeleteDataModelByID[TDataModel interface{}](
 	// result := dbContext.WithContext(ctx).Delete(&TDataModel{Id: id})
 	result := txDBContext.DB().WithContext(ctx).Delete(dataModel, id)
 	if result.Error != nil {
+		if err := canceledErr(ctx, result.Error, "deleting the data model canceled"); err != nil {
+			return err
+		}
+
 		return customErrors.NewInternalServerErrorWrap(
 			result.Error,
 			fmt.Sprintf(
@@ -168,6 +223,10 @@ func AddModel[TDataModel interface{}, TModel interface{}](
 	// https://gorm.io/docs/create.html
 	result := txDBContext.DB().WithContext(ctx).Create(dataModel)
 	if result.Error != nil {
+		if err := canceledErr(ctx, result.Error, "adding the model canceled"); err != nil {
+			return *new(TModel), err
+		}
+
 		return *new(TModel), customErrors.NewConflictErrorWrap(
 			result.Error,
 			fmt.Sprintf("%s already exists", modelName),
@@ -200,6 +259,10 @@ func AddDataModel[TDataModel interface{}](
 	// https://gorm.io/docs/create.html
 	result := txDBContext.DB().WithContext(ctx).Create(dataModel)
 	if result.Error != nil {
+		if err := canceledErr(ctx, result.Error, "adding the data model canceled"); err != nil {
+			return *new(TDataModel), err
+		}
+
 		return *new(TDataModel), customErrors.NewConflictErrorWrap(
 			result.Error,
 			fmt.Sprintf("%s already exists", dataModelName),
@@ -233,10 +296,7 @@ func UpdateModel[TDataModel interface{}, TModel interface{}](
 	// https://gorm.io/docs/update.html
 	result := txDBContext.DB().WithContext(ctx).Updates(dataModel)
 	if result.Error != nil {
-		return *new(TModel), customErrors.NewInternalServerErrorWrap(
-			result.Error,
-			fmt.Sprintf("error in updating the %s", modelName),
-		)
+		return *new(TModel), updateErr(ctx, result.Error, "updating the model canceled", modelName)
 	}
 
 	defaultlogger.GetLogger().Infof("Number of affected rows are: %d", result.RowsAffected)
@@ -265,9 +325,91 @@ func UpdateDataModel[TDataModel interface{}](
 	// https://gorm.io/docs/update.html
 	result := txDBContext.DB().WithContext(ctx).Updates(dataModel)
 	if result.Error != nil {
-		return *new(TDataModel), customErrors.NewInternalServerErrorWrap(
+		return *new(TDataModel), updateErr(ctx, result.Error, "updating the data model canceled", dataModelName)
+	}
+
+	defaultlogger.GetLogger().Infof("Number of affected rows are: %d", result.RowsAffected)
+
+	return dataModel, nil
+}
+
+// UpdateVersionedModel update the model inner a tx if exists with an optimistic concurrency check, the row is only
+// updated while it has the version of the model and its version is incremented, otherwise a concurrency conflict error
+// is returned and the update can be retried on the current row
+func UpdateVersionedModel[TDataModel contracts.VersionedDataModel, TModel interface{}](
+	ctx context.Context,
+	dbContext contracts.GormDBContext,
+	model TModel,
+) (TModel, error) {
+	dataModelName := strcase.ToSnake(typeMapper.GetGenericNonePointerTypeNameByT[TDataModel]())
+	modelName := strcase.ToSnake(typeMapper.GetGenericNonePointerTypeNameByT[TModel]())
+
+	dataModel, err := mapper.Map[TDataModel](model)
+	if err != nil {
+		return *new(TModel), customErrors.NewInternalServerErrorWrap(
+			err,
+			fmt.Sprintf("error in the mapping %s", dataModelName),
+		)
+	}
+
+	dataModel, err = UpdateVersionedDataModel[TDataModel](ctx, dbContext, dataModel)
+	if err != nil {
+		return *new(TModel), err
+	}
+
+	modelResult, err := mapper.Map[TModel](dataModel)
+	if err != nil {
+		return *new(TModel), customErrors.NewInternalServerErrorWrap(
+			err,
+			fmt.Sprintf("error in the mapping %s", modelName),
+		)
+	}
+
+	return modelResult, nil
+}
+
+// UpdateVersionedDataModel update the data-model inner a tx if exists with an optimistic concurrency check, the row is
+// only updated while it has the version of the data-model and its version is incremented, otherwise a concurrency
+// conflict error is returned and the version of the data-model is kept
+func UpdateVersionedDataModel[TDataModel contracts.VersionedDataModel](
+	ctx context.Context,
+	dbContext contracts.GormDBContext,
+	dataModel TDataModel,
+) (TDataModel, error) {
+	txDBContext := dbContext.WithTxIfExists(ctx)
+
+	dataModelName := strcase.ToSnake(typeMapper.GetGenericNonePointerTypeNameByT[TDataModel]())
+
+	expectedVersion := dataModel.GetVersion()
+	dataModel.SetVersion(expectedVersion + 1)
+
+	// https://gorm.io/docs/update.html#Update-with-conditions
+	result := txDBContext.DB().
+		WithContext(ctx).
+		Model(dataModel).
+		Where(fmt.Sprintf("%s = ?", contracts.VersionColumn), expectedVersion).
+		Updates(dataModel)
+	if result.Error != nil {
+		dataModel.SetVersion(expectedVersion)
+
+		return *new(TDataModel), updateErr(
+			ctx,
 			result.Error,
-			fmt.Sprintf("error in updating the %s", dataModelName),
+			"updating the versioned data model canceled",
+			dataModelName,
+		)
+	}
+
+	// the row is changed or deleted by another writer after it was read
+	if result.RowsAffected == 0 {
+		dataModel.SetVersion(expectedVersion)
+
+		return *new(TDataModel), customErrors.NewConcurrencyConflictError(
+			fmt.Sprintf(
+				"%s with version `%d` is changed by another writer",
+				dataModelName,
+				expectedVersion,
+			),
 		)
 	}
 
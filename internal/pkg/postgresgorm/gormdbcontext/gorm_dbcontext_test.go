@@ -11,12 +11,14 @@ import (
 
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/external/fxlog"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/zap"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mapper"
 	gormPostgres "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/contracts"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/scopes"
+	testUtils "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/test/utils"
 
 	"emperror.dev/errors"
 	"github.com/brianvoe/gofakeit/v6"
@@ -34,6 +36,7 @@ type ProductDataModel struct {
 	Name        string
 	Description string
 	Price       float64
+	Version     int64
 	CreatedAt   time.Time `gorm:"default:current_timestamp"`
 	UpdatedAt   time.Time
 	// for soft delete - https://gorm.io/docs/delete.html#Soft-Delete
@@ -51,12 +54,21 @@ func (p *ProductDataModel) String() string {
 	return string(j)
 }
 
+func (p *ProductDataModel) GetVersion() int64 {
+	return p.Version
+}
+
+func (p *ProductDataModel) SetVersion(version int64) {
+	p.Version = version
+}
+
 // Product model
 type Product struct {
 	Id          uuid.UUID
 	Name        string
 	Description string
 	Price       float64
+	Version     int64
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
@@ -211,6 +223,114 @@ func (s *GormDBContextTestSuite) Test_UpdateProduct() {
 	s.Assert().Equal(res.Name, p2.Name)
 }
 
+func (s *GormDBContextTestSuite) Test_UpdateVersionedProduct() {
+	s.Require().NotNil(s.dbContext)
+
+	id := s.items[0].Id
+
+	p, err := FindModelByID[*ProductDataModel, *Product](context.Background(), s.dbContext, id)
+	s.Require().NoError(err)
+
+	p.Name = gofakeit.Name()
+
+	res, err := UpdateVersionedModel[*ProductDataModel, *Product](context.Background(), s.dbContext, p)
+	s.Require().NoError(err)
+	s.Assert().Equal(int64(1), res.Version)
+
+	p2, err := FindModelByID[*ProductDataModel, *Product](context.Background(), s.dbContext, id)
+	s.Require().NoError(err)
+
+	s.Assert().Equal(p.Name, p2.Name)
+	s.Assert().Equal(int64(1), p2.Version)
+}
+
+func (s *GormDBContextTestSuite) Test_UpdateVersionedProduct_Should_Return_Concurrency_Conflict_For_Stale_Version() {
+	s.Require().NotNil(s.dbContext)
+
+	id := s.items[0].Id
+
+	first, err := FindModelByID[*ProductDataModel, *Product](context.Background(), s.dbContext, id)
+	s.Require().NoError(err)
+	second, err := FindModelByID[*ProductDataModel, *Product](context.Background(), s.dbContext, id)
+	s.Require().NoError(err)
+
+	first.Name = gofakeit.Name()
+	_, err = UpdateVersionedModel[*ProductDataModel, *Product](context.Background(), s.dbContext, first)
+	s.Require().NoError(err)
+
+	second.Name = gofakeit.Name()
+	_, err = UpdateVersionedModel[*ProductDataModel, *Product](context.Background(), s.dbContext, second)
+	s.Require().Error(err)
+	s.Assert().True(customErrors.IsConcurrencyConflictError(err))
+
+	p, err := FindModelByID[*ProductDataModel, *Product](context.Background(), s.dbContext, id)
+	s.Require().NoError(err)
+
+	s.Assert().Equal(first.Name, p.Name)
+	s.Assert().Equal(int64(1), p.Version)
+}
+
+func (s *GormDBContextTestSuite) Test_Update_Error_Should_Return_Conflict_For_Duplicated_Key() {
+	err := updateErr(context.Background(), gorm.ErrDuplicatedKey, "updating the model canceled", "product")
+	s.Assert().True(customErrors.IsConflictError(err))
+
+	err = updateErr(context.Background(), gorm.ErrInvalidData, "updating the model canceled", "product")
+	s.Assert().False(customErrors.IsConflictError(err))
+	s.Assert().True(customErrors.IsInternalServerError(err))
+}
+
+// Test_UpdateVersionedProduct_Concurrently runs concurrent read-modify-writes of the same product, each write either
+// succeeds or fails with a concurrency conflict and no write is lost
+func (s *GormDBContextTestSuite) Test_UpdateVersionedProduct_Concurrently() {
+	s.Require().NotNil(s.dbContext)
+
+	const writers = 20
+
+	// sqlite has a single writer, so the statements are serialized on one connection while the read-modify-writes of
+	// the writers are still interleaved
+	sqlDB, err := s.dbContext.DB().DB()
+	s.Require().NoError(err)
+	sqlDB.SetMaxOpenConns(1)
+
+	id := s.items[0].Id
+
+	errs := testUtils.RunConcurrently(writers, func(_ int) error {
+		p, err := FindModelByID[*ProductDataModel, *Product](context.Background(), s.dbContext, id)
+		if err != nil {
+			return err
+		}
+
+		p.Price++
+
+		_, err = UpdateVersionedModel[*ProductDataModel, *Product](context.Background(), s.dbContext, p)
+
+		return err
+	})
+
+	var succeeded, conflicted int64
+
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case customErrors.IsConcurrencyConflictError(err):
+			conflicted++
+		default:
+			s.Failf("unexpected error in updating the product", "%v", err)
+		}
+	}
+
+	s.Assert().Equal(int64(writers), succeeded+conflicted)
+	s.Assert().Positive(succeeded)
+
+	p, err := FindModelByID[*ProductDataModel, *Product](context.Background(), s.dbContext, id)
+	s.Require().NoError(err)
+
+	// each succeeded write increments the version and the price once
+	s.Assert().Equal(succeeded, p.Version)
+	s.Assert().InDelta(s.items[0].Price+float64(succeeded), p.Price, 0.001)
+}
+
 // TestSuite Hooks
 
 func (s *GormDBContextTestSuite) SetupTest() {
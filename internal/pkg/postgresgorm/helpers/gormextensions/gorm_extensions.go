@@ -3,6 +3,7 @@ package gormextensions
 import (
 	"context"
 
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/constants"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/contracts"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/scopes"
@@ -61,8 +62,11 @@ func Paginate[TDataModel any, TEntity any](
 	)
 
 	// https://gorm.io/docs/advanced_query.html#Smart-Select-Fields
-	if err := db.Scopes(scopes.FilterPaginate[TDataModel](ctx, listQuery)).Find(&items).Error; err != nil {
-		return nil, errors.WrapIf(err, "error in finding products.")
+	if err := db.WithContext(ctx).Scopes(scopes.FilterPaginate[TDataModel](ctx, listQuery)).Find(&items).Error; err != nil {
+		return nil, errors.WrapIf(
+			customErrors.WrapIfCanceled(ctx, err, "finding the page canceled"),
+			"error in finding products.",
+		)
 	}
 
 	return utils.NewListResult[TEntity](
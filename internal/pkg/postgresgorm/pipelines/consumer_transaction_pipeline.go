@@ -0,0 +1,95 @@
+package pipelines
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/pipeline"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/helpers/gormextensions"
+
+	"gorm.io/gorm"
+)
+
+type consumerTransactionPipeline struct {
+	logger logger.Logger
+	db     *gorm.DB
+}
+
+// NewConsumerTransactionPipeline runs the handlers of the transactional messages in a unit of work, the transaction is
+// added to the context like the transaction pipeline of the mediator, it is committed when the handler succeeds and
+// rolled back when it fails or panics
+func NewConsumerTransactionPipeline(
+	l logger.Logger,
+	db *gorm.DB,
+) pipeline.ConsumerPipeline {
+	return &consumerTransactionPipeline{
+		logger: l,
+		db:     db,
+	}
+}
+
+func (c *consumerTransactionPipeline) Handle(
+	ctx context.Context,
+	consumerContext types.MessageConsumeContext,
+	next pipeline.ConsumerHandlerFunc,
+) error {
+	txMessage, ok := consumerContext.Message().(types.TxMessage)
+	if !ok || !txMessage.IsTxMessage() {
+		return next(ctx)
+	}
+
+	messageName := consumerContext.MessageType()
+
+	// https://gorm.io/docs/transactions.html#Transaction
+	tx := c.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return customErrors.WrapIfCanceled(
+			ctx,
+			tx.Error,
+			fmt.Sprintf("error in beginning transaction for message `%s`", messageName),
+		)
+	}
+
+	c.logger.Infof("beginning database transaction for message `%s`", messageName)
+
+	ctx = gormextensions.SetTxToContext(ctx, tx)
+
+	// the panic is rolled back and passed to the recovery pipeline
+	defer func() {
+		if r := recover(); r != nil {
+			tx.WithContext(ctx).Rollback()
+
+			panic(r)
+		}
+	}()
+
+	if err := next(ctx); err != nil {
+		c.logger.Errorf("rolling back transaction for message `%s`", messageName)
+		tx.WithContext(ctx).Rollback()
+
+		return err
+	}
+
+	// the handler may ignore the cancellation, so we don't commit the changes of a canceled message
+	if err := customErrors.CheckContext(ctx, fmt.Sprintf("message `%s` canceled before commit", messageName)); err != nil {
+		c.logger.Errorf("rolling back canceled transaction for message `%s`", messageName)
+		tx.WithContext(ctx).Rollback()
+
+		return err
+	}
+
+	c.logger.Infof("committing transaction for message `%s`", messageName)
+
+	if err := tx.WithContext(ctx).Commit().Error; err != nil {
+		return customErrors.WrapIfCanceled(
+			ctx,
+			err,
+			fmt.Sprintf("transaction commit canceled for message `%s`", messageName),
+		)
+	}
+
+	return nil
+}
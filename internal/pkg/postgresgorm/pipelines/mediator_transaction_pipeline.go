@@ -2,8 +2,10 @@ package pipelines
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/helpers/gormextensions"
 	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
@@ -43,6 +45,13 @@ func (m *mediatorTransactionPipeline) Handle(
 
 	// https://gorm.io/docs/transactions.html#Transaction
 	tx := m.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, customErrors.WrapIfCanceled(
+			ctx,
+			tx.Error,
+			fmt.Sprintf("error in beginning transaction for request `%s`", requestName),
+		)
+	}
 
 	m.logger.Infof(
 		"beginning database transaction for request `%s`",
@@ -80,14 +89,27 @@ func (m *mediatorTransactionPipeline) Handle(
 		return nil, err
 	}
 
+	// the handler may ignore the cancellation, so we don't commit the changes of a canceled request
+	if err = customErrors.CheckContext(ctx, fmt.Sprintf("request `%s` canceled before commit", requestName)); err != nil {
+		m.logger.Errorf(
+			"rolling back canceled transaction for request `%s`",
+			requestName,
+		)
+		tx.WithContext(ctx).Rollback()
+
+		return nil, err
+	}
+
 	m.logger.Infof("committing transaction for request `%s`", requestName)
 
 	if err = tx.WithContext(ctx).Commit().Error; err != nil {
 		m.logger.Errorf("transaction commit error: ", err)
-	}
 
-	if err != nil {
-		return nil, err
+		return nil, customErrors.WrapIfCanceled(
+			ctx,
+			err,
+			fmt.Sprintf("transaction commit canceled for request `%s`", requestName),
+		)
 	}
 
 	return result, nil
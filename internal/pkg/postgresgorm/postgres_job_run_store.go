@@ -0,0 +1,100 @@
+package postgresgorm
+
+import (
+	"context"
+	"fmt"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/jobs"
+
+	"emperror.dev/errors"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// JobRunModule provides the postgres backed JobRunStore, it should be used with `jobs.Module`
+var JobRunModule = fx.Module( //nolint:gochecknoglobals
+	"postgresjobrunfx",
+	fx.Provide(NewPostgresJobRunStore),
+	fx.Invoke(migrateJobRuns),
+)
+
+type postgresJobRunStore struct {
+	db *gorm.DB
+}
+
+// NewPostgresJobRunStore keeps the job runs in the `job_runs` table
+func NewPostgresJobRunStore(db *gorm.DB) jobs.JobRunStore {
+	return &postgresJobRunStore{db: db}
+}
+
+func (p *postgresJobRunStore) Add(ctx context.Context, run *jobs.JobRun) error {
+	result := p.db.WithContext(ctx).Create(run)
+	if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+		return customErrors.NewConflictErrorWrap(
+			result.Error,
+			fmt.Sprintf("job run with id `%s` already exists", run.Id),
+		)
+	}
+
+	if result.Error != nil {
+		return customErrors.NewInternalServerErrorWrap(result.Error, "error in adding the job run")
+	}
+
+	return nil
+}
+
+func (p *postgresJobRunStore) Get(ctx context.Context, id string) (*jobs.JobRun, error) {
+	run := &jobs.JobRun{}
+
+	result := p.db.WithContext(ctx).Where("id = ?", id).First(run)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, customErrors.NewNotFoundErrorWrap(
+			result.Error,
+			fmt.Sprintf("job run with id `%s` not found", id),
+		)
+	}
+
+	if result.Error != nil {
+		return nil, customErrors.NewInternalServerErrorWrap(result.Error, "error in getting the job run")
+	}
+
+	return run, nil
+}
+
+func (p *postgresJobRunStore) Update(ctx context.Context, run *jobs.JobRun) error {
+	result := p.db.WithContext(ctx).Model(&jobs.JobRun{}).Where("id = ?", run.Id).
+		Select("*").
+		Updates(run)
+	if result.Error != nil {
+		return customErrors.NewInternalServerErrorWrap(result.Error, "error in updating the job run")
+	}
+
+	if result.RowsAffected == 0 {
+		return customErrors.NewNotFoundError(fmt.Sprintf("job run with id `%s` not found", run.Id))
+	}
+
+	return nil
+}
+
+func (p *postgresJobRunStore) List(ctx context.Context, jobName string, limit int) ([]*jobs.JobRun, error) {
+	var runs []*jobs.JobRun
+
+	query := p.db.WithContext(ctx).Order("started_at desc")
+	if jobName != "" {
+		query = query.Where("job_name = ?", jobName)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Find(&runs).Error; err != nil {
+		return nil, customErrors.NewInternalServerErrorWrap(err, "error in listing the job runs")
+	}
+
+	return runs, nil
+}
+
+func migrateJobRuns(db *gorm.DB) error {
+	return db.Migrator().AutoMigrate(&jobs.JobRun{})
+}
@@ -0,0 +1,102 @@
+package postgresgorm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/quarantine"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// QuarantineModule provides the postgres backed QuarantineStore, it should be used with `quarantine.Module`
+var QuarantineModule = fx.Module( //nolint:gochecknoglobals
+	"postgresquarantinefx",
+	fx.Provide(NewPostgresQuarantineStore),
+	fx.Invoke(migrateQuarantinedMessages),
+)
+
+type postgresQuarantineStore struct {
+	db *gorm.DB
+}
+
+// NewPostgresQuarantineStore keeps the quarantined messages in the `quarantined_messages` table
+func NewPostgresQuarantineStore(db *gorm.DB) quarantine.QuarantineStore {
+	return &postgresQuarantineStore{db: db}
+}
+
+func (p *postgresQuarantineStore) Add(ctx context.Context, message *quarantine.QuarantinedMessage) error {
+	result := p.db.WithContext(ctx).Create(message)
+	if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+		return customErrors.NewConflictErrorWrap(
+			result.Error,
+			fmt.Sprintf("quarantined message with id `%s` already exists", message.Id),
+		)
+	}
+
+	if result.Error != nil {
+		return customErrors.NewInternalServerErrorWrap(result.Error, "error in adding the quarantined message")
+	}
+
+	return nil
+}
+
+func (p *postgresQuarantineStore) Get(ctx context.Context, id string) (*quarantine.QuarantinedMessage, error) {
+	message := &quarantine.QuarantinedMessage{}
+
+	result := p.db.WithContext(ctx).Where("id = ?", id).First(message)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, customErrors.NewNotFoundErrorWrap(
+			result.Error,
+			fmt.Sprintf("quarantined message with id `%s` not found", id),
+		)
+	}
+
+	if result.Error != nil {
+		return nil, customErrors.NewInternalServerErrorWrap(result.Error, "error in getting the quarantined message")
+	}
+
+	return message, nil
+}
+
+func (p *postgresQuarantineStore) List(
+	ctx context.Context,
+	consumer string,
+	limit int,
+) ([]*quarantine.QuarantinedMessage, error) {
+	var messages []*quarantine.QuarantinedMessage
+
+	query := p.db.WithContext(ctx).Order("quarantined_at desc")
+	if consumer != "" {
+		query = query.Where("consumer = ?", consumer)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Find(&messages).Error; err != nil {
+		return nil, customErrors.NewInternalServerErrorWrap(err, "error in listing the quarantined messages")
+	}
+
+	return messages, nil
+}
+
+func (p *postgresQuarantineStore) Delete(ctx context.Context, id string) error {
+	result := p.db.WithContext(ctx).Where("id = ?", id).Delete(&quarantine.QuarantinedMessage{})
+	if result.Error != nil {
+		return customErrors.NewInternalServerErrorWrap(result.Error, "error in deleting the quarantined message")
+	}
+
+	if result.RowsAffected == 0 {
+		return customErrors.NewNotFoundError(fmt.Sprintf("quarantined message with id `%s` not found", id))
+	}
+
+	return nil
+}
+
+func migrateQuarantinedMessages(db *gorm.DB) error {
+	return db.Migrator().AutoMigrate(&quarantine.QuarantinedMessage{})
+}
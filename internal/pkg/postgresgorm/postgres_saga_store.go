@@ -0,0 +1,136 @@
+package postgresgorm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/saga"
+
+	"emperror.dev/errors"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SagaModule provides the postgres backed SagaStore, it should be used with `saga.Module`
+var SagaModule = fx.Module( //nolint:gochecknoglobals
+	"postgressagafx",
+	fx.Provide(NewPostgresSagaStore),
+	fx.Invoke(migrateSagas),
+)
+
+type postgresSagaStore struct {
+	db *gorm.DB
+}
+
+// NewPostgresSagaStore keeps the saga instances in the `saga_instances` table
+func NewPostgresSagaStore(db *gorm.DB) saga.SagaStore {
+	return &postgresSagaStore{db: db}
+}
+
+func (p *postgresSagaStore) Add(ctx context.Context, instance *saga.SagaInstance) error {
+	// an existing saga is skipped by the insert and found by the affected rows, so the sqlite store of the tests behaves
+	// the same
+	result := p.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(instance)
+	if result.Error != nil {
+		return customErrors.NewInternalServerErrorWrap(result.Error, "error in adding the saga")
+	}
+
+	if result.RowsAffected == 0 {
+		return customErrors.NewConflictError(fmt.Sprintf("saga with id `%s` already exists", instance.Id))
+	}
+
+	return nil
+}
+
+func (p *postgresSagaStore) Get(ctx context.Context, id string) (*saga.SagaInstance, error) {
+	instance := &saga.SagaInstance{}
+
+	result := p.db.WithContext(ctx).Where("id = ?", id).First(instance)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, customErrors.NewNotFoundErrorWrap(
+			result.Error,
+			fmt.Sprintf("saga with id `%s` not found", id),
+		)
+	}
+
+	if result.Error != nil {
+		return nil, customErrors.NewInternalServerErrorWrap(result.Error, "error in getting the saga")
+	}
+
+	return instance, nil
+}
+
+func (p *postgresSagaStore) GetByMessageId(ctx context.Context, messageId string) (*saga.SagaInstance, error) {
+	instance := &saga.SagaInstance{}
+
+	result := p.db.WithContext(ctx).
+		Where("pending_message_id = ? AND status = ?", messageId, saga.Running).
+		First(instance)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, customErrors.NewNotFoundErrorWrap(
+			result.Error,
+			fmt.Sprintf("saga waiting for message `%s` not found", messageId),
+		)
+	}
+
+	if result.Error != nil {
+		return nil, customErrors.NewInternalServerErrorWrap(result.Error, "error in getting the saga of the message")
+	}
+
+	return instance, nil
+}
+
+// Update saves the instance with an optimistic concurrency check on its version
+func (p *postgresSagaStore) Update(ctx context.Context, instance *saga.SagaInstance) error {
+	version := instance.Version
+	instance.Version++
+
+	result := p.db.WithContext(ctx).Model(&saga.SagaInstance{}).
+		Where("id = ? AND version = ?", instance.Id, version).
+		Select("*").
+		Updates(instance)
+	if result.Error != nil {
+		instance.Version = version
+
+		return customErrors.NewInternalServerErrorWrap(result.Error, "error in updating the saga")
+	}
+
+	if result.RowsAffected == 0 {
+		instance.Version = version
+
+		return customErrors.NewConcurrencyConflictError(
+			fmt.Sprintf("saga with id `%s` is not found or is updated by another handler", instance.Id),
+		)
+	}
+
+	return nil
+}
+
+func (p *postgresSagaStore) ListTimedOut(
+	ctx context.Context,
+	sagaName string,
+	at time.Time,
+	limit int,
+) ([]*saga.SagaInstance, error) {
+	var instances []*saga.SagaInstance
+
+	query := p.db.WithContext(ctx).
+		Where("saga_name = ? AND status = ? AND timeout_at <= ?", sagaName, saga.Running, at).
+		Order("timeout_at")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Find(&instances).Error; err != nil {
+		return nil, customErrors.NewInternalServerErrorWrap(err, "error in listing the timed out sagas")
+	}
+
+	return instances, nil
+}
+
+func migrateSagas(db *gorm.DB) error {
+	return db.Migrator().AutoMigrate(&saga.SagaInstance{})
+}
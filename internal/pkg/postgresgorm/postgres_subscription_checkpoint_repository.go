@@ -0,0 +1,80 @@
+package postgresgorm
+
+import (
+	"context"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CheckpointModule provides the postgres backed SubscriptionCheckpointRepository, it should be used with
+// `eventstroredb.ModuleFunc` instead of `eventstroredb.CheckpointModule`
+var CheckpointModule = fx.Module( //nolint:gochecknoglobals
+	"postgrescheckpointfx",
+	fx.Provide(NewPostgresSubscriptionCheckpointRepository),
+	fx.Invoke(migrateSubscriptionCheckpoints),
+)
+
+// SubscriptionCheckpoint is the position of the last handled event of a subscription
+type SubscriptionCheckpoint struct {
+	SubscriptionId string `gorm:"primaryKey"`
+	Position       uint64
+	CheckpointAt   time.Time
+}
+
+type postgresSubscriptionCheckpointRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresSubscriptionCheckpointRepository keeps the checkpoints of the subscriptions in the
+// `subscription_checkpoints` table
+func NewPostgresSubscriptionCheckpointRepository(db *gorm.DB) contracts.SubscriptionCheckpointRepository {
+	return &postgresSubscriptionCheckpointRepository{db: db}
+}
+
+func (p *postgresSubscriptionCheckpointRepository) Load(subscriptionId string, ctx context.Context) (uint64, error) {
+	checkpoint := &SubscriptionCheckpoint{}
+
+	result := p.db.WithContext(ctx).Where("subscription_id = ?", subscriptionId).First(checkpoint)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+
+	if result.Error != nil {
+		return 0, customErrors.NewInternalServerErrorWrap(result.Error, "error in loading the subscription checkpoint")
+	}
+
+	return checkpoint.Position, nil
+}
+
+func (p *postgresSubscriptionCheckpointRepository) Store(
+	subscriptionId string,
+	position uint64,
+	ctx context.Context,
+) error {
+	checkpoint := &SubscriptionCheckpoint{
+		SubscriptionId: subscriptionId,
+		Position:       position,
+		CheckpointAt:   time.Now(),
+	}
+
+	result := p.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "subscription_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"position", "checkpoint_at"}),
+	}).Create(checkpoint)
+	if result.Error != nil {
+		return customErrors.NewInternalServerErrorWrap(result.Error, "error in storing the subscription checkpoint")
+	}
+
+	return nil
+}
+
+func migrateSubscriptionCheckpoints(db *gorm.DB) error {
+	return db.Migrator().AutoMigrate(&SubscriptionCheckpoint{})
+}
@@ -54,7 +54,7 @@ func (r *gormGenericRepository[TDataModel, TEntity]) Add(
 	if modelType == dataModelType {
 		err := r.db.WithContext(ctx).Create(entity).Error
 		if err != nil {
-			return err
+			return customErrors.WrapIfCanceled(ctx, err, "adding the entity canceled")
 		}
 
 		return nil
@@ -65,7 +65,7 @@ func (r *gormGenericRepository[TDataModel, TEntity]) Add(
 		}
 		err = r.db.WithContext(ctx).Create(dataModel).Error
 		if err != nil {
-			return err
+			return customErrors.WrapIfCanceled(ctx, err, "adding the entity canceled")
 		}
 		e, err := mapper.Map[TEntity](dataModel)
 		if err != nil {
@@ -82,6 +82,11 @@ func (r *gormGenericRepository[TDataModel, TEntity]) AddAll(
 	entities []TEntity,
 ) error {
 	for _, entity := range entities {
+		// stop the bulk operation between the items, when the context is canceled
+		if err := customErrors.CheckContext(ctx, "adding the entities canceled"); err != nil {
+			return err
+		}
+
 		err := r.Add(ctx, entity)
 		if err != nil {
 			return err
@@ -101,6 +106,12 @@ func (r *gormGenericRepository[TDataModel, TEntity]) GetById(
 	if modelType == dataModelType {
 		var model TEntity
 		if err := r.db.WithContext(ctx).First(&model, id).Error; err != nil {
+			if canceledErr := customErrors.WrapIfCanceled(ctx, err, "loading the entity canceled"); customErrors.IsCanceledError(
+				canceledErr,
+			) {
+				return *new(TEntity), canceledErr
+			}
+
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return *new(TEntity), customErrors.NewNotFoundErrorWrap(
 					err,
@@ -124,6 +135,12 @@ func (r *gormGenericRepository[TDataModel, TEntity]) GetById(
 	} else {
 		var dataModel TDataModel
 		if err := r.db.WithContext(ctx).First(&dataModel, id).Error; err != nil {
+			if canceledErr := customErrors.WrapIfCanceled(ctx, err, "loading the entity canceled"); customErrors.IsCanceledError(
+				canceledErr,
+			) {
+				return *new(TEntity), canceledErr
+			}
+
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return *new(TEntity), customErrors.NewNotFoundErrorWrap(err, fmt.Sprintf("can't find the entity with id %s into the database.", id.String()))
 			}
@@ -198,14 +215,14 @@ func (r *gormGenericRepository[TDataModel, TEntity]) GetByFilter(
 		var models []TEntity
 		err := r.db.WithContext(ctx).Where(filters).Find(&models).Error
 		if err != nil {
-			return nil, err
+			return nil, customErrors.WrapIfCanceled(ctx, err, "finding the entities canceled")
 		}
 		return models, nil
 	} else {
 		var dataModels []TDataModel
 		err := r.db.WithContext(ctx).Where(filters).Find(&dataModels).Error
 		if err != nil {
-			return nil, err
+			return nil, customErrors.WrapIfCanceled(ctx, err, "finding the entities canceled")
 		}
 		models, err := mapper.Map[[]TEntity](dataModels)
 		if err != nil {
@@ -215,6 +232,59 @@ func (r *gormGenericRepository[TDataModel, TEntity]) GetByFilter(
 	}
 }
 
+func (r *gormGenericRepository[TDataModel, TEntity]) Iterate(
+	ctx context.Context,
+	filters map[string]interface{},
+	fn func(entity TEntity) error,
+) error {
+	dataModelType := typeMapper.GetGenericTypeByT[TDataModel]()
+	modelType := typeMapper.GetGenericTypeByT[TEntity]()
+
+	var dataModel TDataModel
+	query := r.db.WithContext(ctx).Model(&dataModel)
+	if len(filters) > 0 {
+		query = query.Where(filters)
+	}
+
+	// https://gorm.io/docs/advanced_query.html#Iteration
+	rows, err := query.Rows()
+	if err != nil {
+		return customErrors.WrapIfCanceled(ctx, err, "iterating the entities canceled")
+	}
+	defer rows.Close() // nolint: errcheck
+
+	for rows.Next() {
+		// rows which are already fetched by the driver don't observe the cancellation
+		if err := customErrors.CheckContext(ctx, "iterating the entities canceled"); err != nil {
+			return err
+		}
+
+		var entity TEntity
+		if modelType == dataModelType {
+			if err := r.db.ScanRows(rows, &entity); err != nil {
+				return customErrors.WrapIfCanceled(ctx, err, "iterating the entities canceled")
+			}
+		} else {
+			var d TDataModel
+			if err := r.db.ScanRows(rows, &d); err != nil {
+				return customErrors.WrapIfCanceled(ctx, err, "iterating the entities canceled")
+			}
+
+			entity, err = mapper.Map[TEntity](d)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := fn(entity); err != nil {
+			return err
+		}
+	}
+
+	// `Next` returns false on the cancellation, so the rows error should be checked
+	return customErrors.WrapIfCanceled(ctx, rows.Err(), "iterating the entities canceled")
+}
+
 func (r *gormGenericRepository[TDataModel, TEntity]) GetByFuncFilter(
 	ctx context.Context,
 	filterFunc func(TEntity) bool,
@@ -238,7 +308,7 @@ func (r *gormGenericRepository[TDataModel, TEntity]) Update(
 	if modelType == dataModelType {
 		err := r.db.WithContext(ctx).Save(entity).Error
 		if err != nil {
-			return err
+			return customErrors.WrapIfCanceled(ctx, err, "updating the entity canceled")
 		}
 	} else {
 		dataModel, err := mapper.Map[TDataModel](entity)
@@ -247,7 +317,7 @@ func (r *gormGenericRepository[TDataModel, TEntity]) Update(
 		}
 		err = r.db.WithContext(ctx).Save(dataModel).Error
 		if err != nil {
-			return err
+			return customErrors.WrapIfCanceled(ctx, err, "updating the entity canceled")
 		}
 		e, err := mapper.Map[TEntity](dataModel)
 		if err != nil {
@@ -264,6 +334,11 @@ func (r *gormGenericRepository[TDataModel, TEntity]) UpdateAll(
 	entities []TEntity,
 ) error {
 	for _, e := range entities {
+		// stop the bulk operation between the items, when the context is canceled
+		if err := customErrors.CheckContext(ctx, "updating the entities canceled"); err != nil {
+			return err
+		}
+
 		err := r.Update(ctx, e)
 		if err != nil {
 			return err
@@ -284,7 +359,7 @@ func (r *gormGenericRepository[TDataModel, TEntity]) Delete(
 
 	err = r.db.WithContext(ctx).Delete(entity, id).Error
 	if err != nil {
-		return err
+		return customErrors.WrapIfCanceled(ctx, err, "deleting the entity canceled")
 	}
 
 	return nil
@@ -305,14 +380,14 @@ func (r *gormGenericRepository[TDataModel, TEntity]) SkipTake(
 			Find(&models).
 			Error
 		if err != nil {
-			return nil, err
+			return nil, customErrors.WrapIfCanceled(ctx, err, "loading the entities canceled")
 		}
 		return models, nil
 	} else {
 		var dataModels []TDataModel
 		err := r.db.WithContext(ctx).Offset(skip).Limit(take).Find(&dataModels).Error
 		if err != nil {
-			return nil, err
+			return nil, customErrors.WrapIfCanceled(ctx, err, "loading the entities canceled")
 		}
 		models, err := mapper.Map[[]TEntity](dataModels)
 		if err != nil {
@@ -344,14 +419,14 @@ func (r *gormGenericRepository[TDataModel, TEntity]) Find(
 			Find(&models).
 			Error
 		if err != nil {
-			return nil, err
+			return nil, customErrors.WrapIfCanceled(ctx, err, "finding the entities canceled")
 		}
 		return models, nil
 	} else {
 		var dataModels []TDataModel
 		err := r.db.WithContext(ctx).Where(specification.GetQuery(), specification.GetValues()...).Find(&dataModels).Error
 		if err != nil {
-			return nil, err
+			return nil, customErrors.WrapIfCanceled(ctx, err, "finding the entities canceled")
 		}
 		models, err := mapper.Map[[]TEntity](dataModels)
 		if err != nil {
@@ -0,0 +1,26 @@
+package messagepersistence
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[OutboxOptions]())
+
+// OutboxOptions controls the outbox worker which forwards the stored messages to the broker.
+type OutboxOptions struct {
+	Enabled bool `mapstructure:"enabled"         default:"true"`
+	// IntervalSeconds is the delay between two runs of the outbox worker
+	IntervalSeconds int `mapstructure:"intervalSeconds" default:"5"`
+	// BatchSize is the max number of messages that will be forwarded in a single run
+	BatchSize int `mapstructure:"batchSize"       default:"100"`
+	// MaxRetryCount is the number of failed forwards after that a message is not picked by the worker anymore
+	MaxRetryCount int `mapstructure:"maxRetryCount"   default:"10"`
+}
+
+func NewOutboxOptions(environment environment.Environment) (*OutboxOptions, error) {
+	return config.BindConfigKey[*OutboxOptions](optionName, environment)
+}
@@ -0,0 +1,32 @@
+package messagepersistence
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/bus"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/persistmessage"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+)
+
+type postgresOutboxPublisher struct {
+	messagePersistenceService persistmessage.MessagePersistenceService
+}
+
+// NewPostgresOutboxPublisher stores the published messages in the `store_messages` table with the gorm transaction of
+// the context, a message which is published without a transaction is stored immediately.
+func NewPostgresOutboxPublisher(
+	messagePersistenceService persistmessage.MessagePersistenceService,
+) bus.OutboxPublisher {
+	return &postgresOutboxPublisher{messagePersistenceService: messagePersistenceService}
+}
+
+func (p *postgresOutboxPublisher) PublishViaOutbox(
+	ctx context.Context,
+	message types.IMessage,
+	meta metadata.Metadata,
+) error {
+	messageEnvelope := types.NewMessageEnvelope(message, meta)
+
+	return p.messagePersistenceService.AddPublishMessage(*messageEnvelope, ctx)
+}
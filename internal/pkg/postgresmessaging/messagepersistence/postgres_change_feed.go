@@ -0,0 +1,125 @@
+package messagepersistence
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/changefeed"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/persistmessage"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+)
+
+type postgresChangeFeed struct {
+	messagingDBContext *PostgresMessagePersistenceDBContext
+	options            *changefeed.ChangeFeedOptions
+}
+
+// NewPostgresChangeFeed reads the published messages of the outbox as the change feed of the service, the cursor is
+// the creation time and the id of a message, so the processed messages should not be cleaned up while the consumers of
+// the feed need them
+func NewPostgresChangeFeed(
+	postgresMessagePersistenceDBContext *PostgresMessagePersistenceDBContext,
+	options *changefeed.ChangeFeedOptions,
+) changefeed.ChangeFeed {
+	return &postgresChangeFeed{messagingDBContext: postgresMessagePersistenceDBContext, options: options}
+}
+
+func (p *postgresChangeFeed) Read(ctx context.Context, cursor string, limit int) (*changefeed.ChangePage, error) {
+	// the creation time is set before the commit of a message, so the young messages are held back until the
+	// transactions which are started before them are committed
+	settledAt := time.Now().Add(-time.Duration(p.options.SettleSeconds) * time.Second)
+
+	query := p.messagingDBContext.DB().
+		WithContext(ctx).
+		Where("delivery_type = ? AND created_at <= ?", persistmessage.Outbox, settledAt)
+
+	if cursor != "" {
+		createdAt, id, err := decodeOutboxCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		query = query.Where("created_at > ? OR (created_at = ? AND id > ?)", createdAt, createdAt, id)
+	}
+
+	var storeMessages []*persistmessage.StoreMessage
+
+	// one more message is loaded to know whether there is a next page
+	result := query.Order("created_at, id").Limit(limit + 1).Find(&storeMessages)
+	if result.Error != nil {
+		return nil, customErrors.NewInternalServerErrorWrap(result.Error, "error in reading the change feed")
+	}
+
+	page := &changefeed.ChangePage{Changes: []*changefeed.Change{}, Cursor: cursor}
+	if len(storeMessages) > limit {
+		page.HasMore = true
+		storeMessages = storeMessages[:limit]
+	}
+
+	for _, storeMessage := range storeMessages {
+		change, err := outboxChange(storeMessage)
+		if err != nil {
+			return nil, err
+		}
+
+		page.Changes = append(page.Changes, change)
+		page.Cursor = change.Cursor
+	}
+
+	return page, nil
+}
+
+func outboxChange(storeMessage *persistmessage.StoreMessage) (*changefeed.Change, error) {
+	change := &changefeed.Change{
+		Id:         storeMessage.ID.String(),
+		Type:       storeMessage.DataType,
+		Cursor:     encodeOutboxCursor(storeMessage),
+		OccurredAt: storeMessage.CreatedAt,
+		Data:       json.RawMessage(storeMessage.Data),
+	}
+
+	// the messages are serialized with the json message serializer, the data of another serializer is returned as a
+	// json string
+	if !json.Valid(change.Data) {
+		data, err := json.Marshal(storeMessage.Data)
+		if err != nil {
+			return nil, errors.WrapIf(err, "error in marshaling the data of the outbox message")
+		}
+
+		change.Data = data
+	}
+
+	if storeMessage.Metadata != "" {
+		meta := metadata.Metadata{}
+		if err := json.Unmarshal([]byte(storeMessage.Metadata), &meta); err != nil {
+			return nil, errors.WrapIf(err, "error in unmarshaling the metadata of the outbox message")
+		}
+
+		change.Metadata = meta
+	}
+
+	return change, nil
+}
+
+func encodeOutboxCursor(storeMessage *persistmessage.StoreMessage) string {
+	return changefeed.EncodeCursor(strconv.FormatInt(storeMessage.CreatedAt.UnixNano(), 10), storeMessage.ID.String())
+}
+
+func decodeOutboxCursor(cursor string) (time.Time, string, error) {
+	parts, err := changefeed.DecodeCursor(cursor, 2)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+
+	createdAt, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return time.Time{}, "", customErrors.NewBadRequestErrorWrap(err, "cursor is not valid")
+	}
+
+	return time.Unix(0, createdAt), parts[1], nil
+}
@@ -0,0 +1,72 @@
+package messagepersistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/inbox"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"gorm.io/gorm/clause"
+)
+
+type postgresInboxStore struct {
+	messagingDBContext *PostgresMessagePersistenceDBContext
+}
+
+// NewPostgresInboxStore keeps the inbox in the `inbox_messages` table, the message is added in the transaction of the
+// context if there is one, so it is committed with the changes of the handler.
+func NewPostgresInboxStore(messagingDBContext *PostgresMessagePersistenceDBContext) inbox.InboxStore {
+	return &postgresInboxStore{messagingDBContext: messagingDBContext}
+}
+
+func (p *postgresInboxStore) Exists(ctx context.Context, messageId string, consumer string) (bool, error) {
+	var count int64
+
+	result := p.messagingDBContext.WithTxIfExists(ctx).
+		DB().
+		WithContext(ctx).
+		Model(&inbox.InboxMessage{}).
+		Where("message_id = ? AND consumer = ?", messageId, consumer).
+		Count(&count)
+	if result.Error != nil {
+		return false, customErrors.NewInternalServerErrorWrap(
+			result.Error,
+			"error in checking the inbox message existence",
+		)
+	}
+
+	return count > 0, nil
+}
+
+func (p *postgresInboxStore) Add(ctx context.Context, message *inbox.InboxMessage) error {
+	// a message which is added concurrently by a redelivery is ignored
+	result := p.messagingDBContext.WithTxIfExists(ctx).
+		DB().
+		WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(message)
+	if result.Error != nil {
+		return customErrors.NewInternalServerErrorWrap(
+			result.Error,
+			"error in adding the inbox message",
+		)
+	}
+
+	return nil
+}
+
+func (p *postgresInboxStore) RemoveProcessedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := p.messagingDBContext.DB().
+		WithContext(ctx).
+		Where("processed_at < ?", before).
+		Delete(&inbox.InboxMessage{})
+	if result.Error != nil {
+		return 0, customErrors.NewInternalServerErrorWrap(
+			result.Error,
+			"error in removing the inbox messages",
+		)
+	}
+
+	return result.RowsAffected, nil
+}
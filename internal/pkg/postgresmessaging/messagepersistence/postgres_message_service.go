@@ -6,44 +6,104 @@ import (
 	"fmt"
 
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/persistmessage"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/producer"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer"
 	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/contracts"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
 
 	uuid "github.com/satori/go.uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type postgresMessagePersistenceService struct {
 	messagingDBContext *PostgresMessagePersistenceDBContext
 	messageSerializer  serializer.MessageSerializer
+	producer           producer.Producer
+	outboxOptions      *OutboxOptions
 	logger             logger.Logger
 }
 
+// Process forwards a stored outbox message to the broker and marks it as processed, a failed forward increases the
+// retry count of the message
 func (m *postgresMessagePersistenceService) Process(messageID string, ctx context.Context) error {
-	// TODO implement me
-	panic("implement me")
+	id, err := uuid.FromString(messageID)
+	if err != nil {
+		return customErrors.NewBadRequestErrorWrap(
+			err,
+			fmt.Sprintf("message id `%s` is not valid", messageID),
+		)
+	}
+
+	storeMessage, err := m.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return m.process(ctx, storeMessage)
 }
 
+// ProcessAll forwards a batch of the stored outbox messages in the order of their creation, it stops at the first
+// failure, so the messages are not published out of order. the batch is locked with `SKIP LOCKED` in the transaction
+// that marks the messages as processed, so the concurrent outbox workers don't forward the same messages
 func (m *postgresMessagePersistenceService) ProcessAll(ctx context.Context) error {
-	// TODO implement me
-	panic("implement me")
+	var processErr error
+
+	err := m.messagingDBContext.RunInTx(ctx, func(ctx context.Context, _ contracts.GormDBContext) error {
+		var storeMessages []*persistmessage.StoreMessage
+
+		dbContext := m.messagingDBContext.WithTxIfExists(ctx)
+		result := dbContext.DB().
+			WithContext(ctx).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where(
+				"message_status = ? AND delivery_type = ? AND retry_count < ?",
+				persistmessage.Stored,
+				persistmessage.Outbox,
+				m.outboxOptions.MaxRetryCount,
+			).
+			Order("created_at").
+			Limit(m.outboxOptions.BatchSize).
+			Find(&storeMessages)
+		if result.Error != nil {
+			return customErrors.NewInternalServerErrorWrap(
+				result.Error,
+				"error in loading the stored outbox messages",
+			)
+		}
+
+		for _, storeMessage := range storeMessages {
+			// the increased retry count of a failed message is committed with the processed messages of the batch
+			if processErr = m.process(ctx, storeMessage); processErr != nil {
+				return nil
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return processErr
 }
 
 func (m *postgresMessagePersistenceService) AddPublishMessage(
 	messageEnvelope types.MessageEnvelope,
 	ctx context.Context,
 ) error {
-	// TODO implement me
-	panic("implement me")
+	return m.AddMessageCore(ctx, messageEnvelope, persistmessage.Outbox)
 }
 
 func (m *postgresMessagePersistenceService) AddReceivedMessage(
 	messageEnvelope types.MessageEnvelope,
 	ctx context.Context,
 ) error {
-	// TODO implement me
-	panic("implement me")
+	return m.AddMessageCore(ctx, messageEnvelope, persistmessage.Inbox)
 }
 
 func (m *postgresMessagePersistenceService) AddMessageCore(
@@ -75,13 +135,24 @@ func (m *postgresMessagePersistenceService) AddMessageCore(
 		return err
 	}
 
+	// `GetMessageFullTypeName` of the embedded message returns the name of the base message type, so we use the type of
+	// the actual message for deserializing it in the outbox worker
 	storeMessage := persistmessage.NewStoreMessage(
 		uuidId,
-		messageEnvelope.Message.GetMessageFullTypeName(),
+		typeMapper.GetFullTypeName(messageEnvelope.Message),
 		string(data.Data),
 		deliveryType,
 	)
 
+	if len(messageEnvelope.Headers) > 0 {
+		meta, err := m.messageSerializer.Serializer().Marshal(messageEnvelope.Headers)
+		if err != nil {
+			return err
+		}
+
+		storeMessage.Metadata = string(meta)
+	}
+
 	err = m.Add(ctx, storeMessage)
 	if err != nil {
 		return err
@@ -96,12 +167,78 @@ func (m *postgresMessagePersistenceService) AddMessageCore(
 	return nil
 }
 
+func (m *postgresMessagePersistenceService) process(
+	ctx context.Context,
+	storeMessage *persistmessage.StoreMessage,
+) error {
+	if storeMessage.MessageStatus == persistmessage.Processed {
+		return nil
+	}
+
+	if storeMessage.DeliveryType != persistmessage.Outbox {
+		return customErrors.NewBadRequestError(
+			fmt.Sprintf("storeMessage with id `%s` is not an outbox message", storeMessage.ID.String()),
+		)
+	}
+
+	err := m.publish(ctx, storeMessage)
+	if err != nil {
+		storeMessage.IncreaseRetry()
+		if updateErr := m.Update(ctx, storeMessage); updateErr != nil {
+			m.logger.Errorf(
+				"error in increasing retry count of the storeMessage with id: %v, err: %v",
+				storeMessage.ID,
+				updateErr,
+			)
+		}
+
+		return customErrors.NewApplicationErrorWrap(
+			err,
+			fmt.Sprintf("error in forwarding the storeMessage with id `%s`", storeMessage.ID.String()),
+		)
+	}
+
+	storeMessage.ChangeState(persistmessage.Processed)
+
+	return m.Update(ctx, storeMessage)
+}
+
+func (m *postgresMessagePersistenceService) publish(
+	ctx context.Context,
+	storeMessage *persistmessage.StoreMessage,
+) error {
+	message, err := m.messageSerializer.Deserialize(
+		[]byte(storeMessage.Data),
+		storeMessage.DataType,
+		m.messageSerializer.ContentType(),
+	)
+	if err != nil {
+		return err
+	}
+
+	var meta metadata.Metadata
+	if storeMessage.Metadata != "" {
+		err = m.messageSerializer.Serializer().Unmarshal([]byte(storeMessage.Metadata), &meta)
+		if err != nil {
+			return err
+		}
+	}
+
+	return m.producer.PublishMessage(ctx, message, meta)
+}
+
 func NewPostgresMessageService(
 	postgresMessagePersistenceDBContext *PostgresMessagePersistenceDBContext,
+	messageSerializer serializer.MessageSerializer,
+	producer producer.Producer,
+	outboxOptions *OutboxOptions,
 	l logger.Logger,
 ) persistmessage.MessagePersistenceService {
 	return &postgresMessagePersistenceService{
 		messagingDBContext: postgresMessagePersistenceDBContext,
+		messageSerializer:  messageSerializer,
+		producer:           producer,
+		outboxOptions:      outboxOptions,
 		logger:             l,
 	}
 }
@@ -173,12 +310,10 @@ func (m *postgresMessagePersistenceService) GetAllActive(
 ) ([]*persistmessage.StoreMessage, error) {
 	var storeMessages []*persistmessage.StoreMessage
 
-	predicate := func(sm *persistmessage.StoreMessage) bool {
-		return sm.MessageStatus == persistmessage.Stored
-	}
-
 	dbContext := m.messagingDBContext.WithTxIfExists(ctx)
-	result := dbContext.DB().Where(predicate).Find(&storeMessages)
+	result := dbContext.DB().
+		Where("message_status = ?", persistmessage.Stored).
+		Find(&storeMessages)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -188,12 +323,12 @@ func (m *postgresMessagePersistenceService) GetAllActive(
 
 func (m *postgresMessagePersistenceService) GetByFilter(
 	ctx context.Context,
-	predicate func(*persistmessage.StoreMessage) bool,
+	filters map[string]interface{},
 ) ([]*persistmessage.StoreMessage, error) {
 	var storeMessages []*persistmessage.StoreMessage
 
 	dbContext := m.messagingDBContext.WithTxIfExists(ctx)
-	result := dbContext.DB().Where(predicate).Find(&storeMessages)
+	result := dbContext.DB().WithContext(ctx).Where(filters).Find(&storeMessages)
 
 	if result.Error != nil {
 		return nil, result.Error
@@ -212,7 +347,16 @@ func (m *postgresMessagePersistenceService) GetById(
 	// https://gorm.io/docs/query.html#Struct-amp-Map-Conditions
 	// https://gorm.io/docs/query.html#Inline-Condition
 	// https://gorm.io/docs/advanced_query.html
-	result := m.messagingDBContext.DB().Find(&storeMessage, id)
+	result := m.messagingDBContext.DB().WithContext(ctx).First(&storeMessage, "id = ?", id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, customErrors.NewNotFoundErrorWrap(
+			result.Error,
+			fmt.Sprintf(
+				"storeMessage with id `%s` not found in the database",
+				id.String(),
+			),
+		)
+	}
 	if result.Error != nil {
 		return nil, customErrors.NewNotFoundErrorWrap(
 			result.Error,
@@ -266,14 +410,10 @@ func (m *postgresMessagePersistenceService) Remove(
 func (m *postgresMessagePersistenceService) CleanupMessages(
 	ctx context.Context,
 ) error {
-	predicate := func(sm *persistmessage.StoreMessage) bool {
-		return sm.MessageStatus == persistmessage.Processed
-	}
-
 	dbContext := m.messagingDBContext.WithTxIfExists(ctx)
 
 	result := dbContext.DB().
-		Where(predicate).
+		Where("message_status = ?", persistmessage.Processed).
 		Delete(&persistmessage.StoreMessage{})
 
 	if result.Error != nil {
@@ -12,7 +12,14 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/changefeed"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/inbox"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/mocks"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/persistmessage"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
 	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/external/fxlog"
@@ -23,6 +30,7 @@ import (
 
 	"emperror.dev/errors"
 	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/fx"
 	"go.uber.org/fx/fxtest"
@@ -35,6 +43,7 @@ type postgresMessageServiceTest struct {
 	logger              logger.Logger
 	messagingRepository persistmessage.MessagePersistenceService
 	dbContext           *PostgresMessagePersistenceDBContext
+	producer            *mocks.Producer
 	storeMessages       []*persistmessage.StoreMessage
 	ctx                 context.Context
 	dbFilePath          string
@@ -67,9 +76,15 @@ func TestPostgresMessageService(t *testing.T) {
 //	)
 //}
 
+type outboxTestMessage struct {
+	*types.Message
+	Name string
+}
+
 func (c *postgresMessageServiceTest) SetupTest() {
 	var gormDBContext *PostgresMessagePersistenceDBContext
 	var gormOptions *postgresgorm.GormOptions
+	var messageSerializer serializer.MessageSerializer
 
 	app := fxtest.New(
 		c.T(),
@@ -89,9 +104,19 @@ func (c *postgresMessageServiceTest) SetupTest() {
 		fx.Provide(NewPostgresMessagePersistenceDBContext),
 		fx.Populate(&gormDBContext),
 		fx.Populate(&gormOptions),
+		fx.Populate(&messageSerializer),
 	).RequireStart()
 
+	c.ctx = context.Background()
 	c.dbContext = gormDBContext
+	c.producer = &mocks.Producer{}
+	c.messagingRepository = NewPostgresMessageService(
+		gormDBContext,
+		messageSerializer,
+		c.producer,
+		&OutboxOptions{Enabled: true, IntervalSeconds: 1, BatchSize: 100, MaxRetryCount: 2},
+		c.logger,
+	)
 	c.dbFilePath = gormOptions.Dns()
 	c.app = app
 
@@ -160,6 +185,78 @@ func (c *postgresMessageServiceTest) Test_Add() {
 	c.Assert().Equal(message.ID, m.ID)
 }
 
+func (c *postgresMessageServiceTest) Test_Get_By_Filter_Should_Return_Matching_Messages() {
+	message := &persistmessage.StoreMessage{
+		ID:            uuid.NewV4(),
+		MessageStatus: persistmessage.Stored,
+		Data:          "test data 4",
+		DataType:      "string",
+		CreatedAt:     time.Now(),
+		DeliveryType:  persistmessage.Inbox,
+	}
+
+	err := c.messagingRepository.Add(c.ctx, message)
+	c.Require().NoError(err)
+
+	messages, err := c.messagingRepository.GetByFilter(
+		c.ctx,
+		map[string]interface{}{"delivery_type": persistmessage.Inbox},
+	)
+	c.Require().NoError(err)
+
+	c.Assert().Len(messages, 1)
+	c.Assert().Equal(message.ID, messages[0].ID)
+}
+
+func (c *postgresMessageServiceTest) Test_Publish_Via_Outbox_Should_Forward_Message_After_Commit() {
+	c.producer.On("PublishMessage", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	message := &outboxTestMessage{Message: types.NewMessage(uuid.NewV4().String()), Name: "test"}
+	publisher := NewPostgresOutboxPublisher(c.messagingRepository)
+
+	c.BeginTx()
+	err := publisher.PublishViaOutbox(c.ctx, message, metadata.Metadata{"correlation-id": "123"})
+	c.CommitTx()
+	c.Require().NoError(err)
+
+	c.producer.AssertNotCalled(c.T(), "PublishMessage", mock.Anything, mock.Anything, mock.Anything)
+
+	err = c.messagingRepository.ProcessAll(context.Background())
+	c.Require().NoError(err)
+
+	c.producer.AssertNumberOfCalls(c.T(), "PublishMessage", 1)
+	published := c.producer.Calls[0].Arguments.Get(1).(*outboxTestMessage)
+	c.Assert().Equal(message.MessageId, published.MessageId)
+	c.Assert().Equal("test", published.Name)
+	c.Assert().Equal("123", c.producer.Calls[0].Arguments.Get(2).(metadata.Metadata)["correlation-id"])
+
+	stored, err := c.messagingRepository.GetById(context.Background(), uuid.FromStringOrNil(message.MessageId))
+	c.Require().NoError(err)
+	c.Assert().Equal(persistmessage.Processed, stored.MessageStatus)
+}
+
+func (c *postgresMessageServiceTest) Test_Process_All_Should_Increase_Retry_Count_When_Forward_Fails() {
+	c.producer.On("PublishMessage", mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.New("broker is not available"))
+
+	message := &outboxTestMessage{Message: types.NewMessage(uuid.NewV4().String()), Name: "test"}
+
+	err := c.messagingRepository.AddPublishMessage(*types.NewMessageEnvelope(message, nil), c.ctx)
+	c.Require().NoError(err)
+
+	c.Assert().Error(c.messagingRepository.ProcessAll(c.ctx))
+	c.Assert().Error(c.messagingRepository.ProcessAll(c.ctx))
+
+	// the message reached the max retry count, so it is not picked anymore
+	c.Assert().NoError(c.messagingRepository.ProcessAll(c.ctx))
+	c.producer.AssertNumberOfCalls(c.T(), "PublishMessage", 2)
+
+	stored, err := c.messagingRepository.GetById(c.ctx, uuid.FromStringOrNil(message.MessageId))
+	c.Require().NoError(err)
+	c.Assert().Equal(persistmessage.Stored, stored.MessageStatus)
+	c.Assert().Equal(2, stored.RetryCount)
+}
+
 func (c *postgresMessageServiceTest) initDB() {
 	err := migrateGorm(c.dbContext.DB())
 	c.Require().NoError(err)
@@ -181,8 +278,109 @@ func (c *postgresMessageServiceTest) cleanupDB() error {
 	return err
 }
 
+func (c *postgresMessageServiceTest) Test_Inbox_Store_Should_Ignore_Duplicate_Message() {
+	store := NewPostgresInboxStore(c.dbContext)
+	message := inbox.NewInboxMessage(uuid.NewV4().String(), "consumer", "outboxTestMessage")
+
+	c.Require().NoError(store.Add(c.ctx, message))
+	c.Require().NoError(store.Add(c.ctx, inbox.NewInboxMessage(message.MessageId, "consumer", "outboxTestMessage")))
+
+	exists, err := store.Exists(c.ctx, message.MessageId, "consumer")
+	c.Require().NoError(err)
+	c.Assert().True(exists)
+
+	exists, err = store.Exists(c.ctx, message.MessageId, "other-consumer")
+	c.Require().NoError(err)
+	c.Assert().False(exists)
+}
+
+func (c *postgresMessageServiceTest) Test_Inbox_Store_Should_Remove_Expired_Messages() {
+	store := NewPostgresInboxStore(c.dbContext)
+
+	expired := inbox.NewInboxMessage(uuid.NewV4().String(), "consumer", "outboxTestMessage")
+	expired.ProcessedAt = time.Now().Add(-2 * time.Hour)
+	recent := inbox.NewInboxMessage(uuid.NewV4().String(), "consumer", "outboxTestMessage")
+
+	c.Require().NoError(store.Add(c.ctx, expired))
+	c.Require().NoError(store.Add(c.ctx, recent))
+
+	removed, err := store.RemoveProcessedBefore(c.ctx, time.Now().Add(-time.Hour))
+	c.Require().NoError(err)
+	c.Assert().Equal(int64(1), removed)
+
+	exists, err := store.Exists(c.ctx, recent.MessageId, "consumer")
+	c.Require().NoError(err)
+	c.Assert().True(exists)
+}
+
+func (c *postgresMessageServiceTest) Test_Change_Feed_Should_Read_Outbox_Messages_After_Cursor() {
+	feed := NewPostgresChangeFeed(c.dbContext, &changefeed.ChangeFeedOptions{SettleSeconds: 2})
+
+	createdAt := time.Now().Add(-time.Minute)
+	messages := []*persistmessage.StoreMessage{
+		{
+			ID:            uuid.NewV4(),
+			MessageStatus: persistmessage.Processed,
+			Data:          `{"name":"phone"}`,
+			Metadata:      `{"correlation-id":"123"}`,
+			DataType:      "productCreatedV1",
+			CreatedAt:     createdAt,
+			DeliveryType:  persistmessage.Outbox,
+		},
+		{
+			ID:            uuid.NewV4(),
+			MessageStatus: persistmessage.Stored,
+			Data:          `{"name":"tablet"}`,
+			DataType:      "productCreatedV1",
+			CreatedAt:     createdAt.Add(time.Second),
+			DeliveryType:  persistmessage.Outbox,
+		},
+		{
+			ID:            uuid.NewV4(),
+			MessageStatus: persistmessage.Processed,
+			Data:          `{"name":"received"}`,
+			DataType:      "orderCreatedV1",
+			CreatedAt:     createdAt.Add(2 * time.Second),
+			DeliveryType:  persistmessage.Inbox,
+		},
+		{
+			// the message is not settled yet, so it is held back
+			ID:            uuid.NewV4(),
+			MessageStatus: persistmessage.Stored,
+			Data:          `{"name":"laptop"}`,
+			DataType:      "productCreatedV1",
+			CreatedAt:     time.Now(),
+			DeliveryType:  persistmessage.Outbox,
+		},
+	}
+	c.Require().NoError(c.dbContext.DB().Where("1 = 1").Delete(&persistmessage.StoreMessage{}).Error)
+	c.Require().NoError(c.dbContext.DB().Create(messages).Error)
+
+	page, err := feed.Read(c.ctx, "", 1)
+	c.Require().NoError(err)
+	c.Require().Len(page.Changes, 1)
+	c.Assert().Equal(messages[0].ID.String(), page.Changes[0].Id)
+	c.Assert().JSONEq(`{"name":"phone"}`, string(page.Changes[0].Data))
+	c.Assert().Equal("123", page.Changes[0].Metadata["correlation-id"])
+	c.Assert().True(page.HasMore)
+
+	page, err = feed.Read(c.ctx, page.Cursor, 10)
+	c.Require().NoError(err)
+	c.Require().Len(page.Changes, 1)
+	c.Assert().Equal(messages[1].ID.String(), page.Changes[0].Id)
+	c.Assert().False(page.HasMore)
+
+	last, err := feed.Read(c.ctx, page.Cursor, 10)
+	c.Require().NoError(err)
+	c.Assert().Empty(last.Changes)
+	c.Assert().Equal(page.Cursor, last.Cursor)
+
+	_, err = feed.Read(c.ctx, "invalid", 10)
+	c.Assert().True(customErrors.IsBadRequestError(err))
+}
+
 func migrateGorm(db *gorm.DB) error {
-	err := db.AutoMigrate(&persistmessage.StoreMessage{})
+	err := db.AutoMigrate(&persistmessage.StoreMessage{}, &inbox.InboxMessage{})
 	if err != nil {
 		return err
 	}
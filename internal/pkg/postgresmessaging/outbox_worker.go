@@ -0,0 +1,36 @@
+package postgresmessaging
+
+import (
+	"context"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/persistmessage"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresmessaging/messagepersistence"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/web"
+
+	"go.uber.org/fx"
+)
+
+// registerOutboxWorker forwards the stored outbox messages to the broker periodically during the application lifetime,
+// a failed message is retried in the next run, until it reaches the max retry count
+func registerOutboxWorker(
+	lc fx.Lifecycle,
+	messagePersistenceService persistmessage.MessagePersistenceService,
+	options *messagepersistence.OutboxOptions,
+	logger logger.Logger,
+) error {
+	if !options.Enabled {
+		return nil
+	}
+
+	return web.RegisterPeriodicWorker(
+		lc,
+		"outbox worker",
+		time.Duration(options.IntervalSeconds)*time.Second,
+		logger,
+		func(ctx context.Context) error {
+			return messagePersistenceService.ProcessAll(ctx)
+		},
+	)
+}
@@ -1,6 +1,7 @@
 package postgresmessaging
 
 import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/inbox"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/persistmessage"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresmessaging/messagepersistence"
 
@@ -13,12 +14,23 @@ var Module = fx.Module(
 	fx.Provide(
 		messagepersistence.NewPostgresMessagePersistenceDBContext,
 		messagepersistence.NewPostgresMessageService,
+		messagepersistence.NewPostgresOutboxPublisher,
+		messagepersistence.NewOutboxOptions,
+		messagepersistence.NewPostgresInboxStore,
 	),
 	fx.Invoke(migrateMessaging),
+	fx.Invoke(registerOutboxWorker),
+)
+
+// ChangeFeedModule provides the outbox backed ChangeFeed, it should be used with `postgresmessaging.Module` and
+// `changefeed.Module`
+var ChangeFeedModule = fx.Module(
+	"postgreschangefeedfx",
+	fx.Provide(messagepersistence.NewPostgresChangeFeed),
 )
 
 func migrateMessaging(db *gorm.DB) error {
-	err := db.Migrator().AutoMigrate(&persistmessage.StoreMessage{})
+	err := db.Migrator().AutoMigrate(&persistmessage.StoreMessage{}, &inbox.InboxMessage{})
 
 	return err
 }
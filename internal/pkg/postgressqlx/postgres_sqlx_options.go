@@ -16,7 +16,7 @@ type PostgresSqlxOptions struct {
 	User     string `mapstructure:"user"`
 	DBName   string `mapstructure:"dbName"`
 	SSLMode  bool   `mapstructure:"sslMode"`
-	Password string `mapstructure:"password"`
+	Password string `mapstructure:"password" secret:"true"`
 }
 
 func provideConfig(environment environment.Environment) (*PostgresSqlxOptions, error) {
@@ -0,0 +1,52 @@
+package bus
+
+import (
+	"sync"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+)
+
+// pendingRequests keeps the requests of the bus which wait for their responses by their correlation ids, a response
+// without a waiting request, e.g. the response of a timed out request, is dropped
+type pendingRequests struct {
+	mutex    sync.Mutex
+	requests map[string]chan types.IMessage
+}
+
+func newPendingRequests() *pendingRequests {
+	return &pendingRequests{requests: make(map[string]chan types.IMessage)}
+}
+
+func (p *pendingRequests) add(correlationId string) <-chan types.IMessage {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	// the channel is buffered, so a response is completed without waiting for its request
+	response := make(chan types.IMessage, 1)
+	p.requests[correlationId] = response
+
+	return response
+}
+
+func (p *pendingRequests) remove(correlationId string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	delete(p.requests, correlationId)
+}
+
+// complete passes the response to its waiting request, it returns false when there is no waiting request
+func (p *pendingRequests) complete(correlationId string, response types.IMessage) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	request, ok := p.requests[correlationId]
+	if !ok {
+		return false
+	}
+	delete(p.requests, correlationId)
+
+	request <- response
+
+	return true
+}
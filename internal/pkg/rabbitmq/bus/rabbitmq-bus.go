@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"sync"
+	"time"
 
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/bus"
 	consumer2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
@@ -27,7 +28,11 @@ import (
 
 type RabbitmqBus interface {
 	bus.Bus
+	bus.Requester
 	consumerConfigurations.RabbitMQConsumerConnector
+	// Configuration returns the producers and the consumers configurations of the bus, including the consumers
+	// connected after creating the bus
+	Configuration() *configurations.RabbitMQConfiguration
 }
 
 type rabbitmqBus struct {
@@ -40,6 +45,8 @@ type rabbitmqBus struct {
 	producerFactory         producercontracts.ProducerFactory
 	isConsumedNotifications []func(message types.IMessage)
 	isProducedNotifications []func(message types.IMessage)
+	pendingRequests         *pendingRequests
+	replyAddresses          map[reflect.Type]string
 }
 
 func NewRabbitmqBus(
@@ -61,6 +68,8 @@ func NewRabbitmqBus(
 		producerFactory:       producerFactory,
 		rabbitmqConfigBuilder: builder,
 		messageTypeConsumers:  map[reflect.Type][]consumer2.Consumer{},
+		pendingRequests:       newPendingRequests(),
+		replyAddresses:        map[reflect.Type]string{},
 	}
 
 	producersConfigurationMap := make(
@@ -106,6 +115,12 @@ func NewRabbitmqBus(
 		)
 	}
 
+	for _, responseType := range rabbitBus.rabbitmqConfiguration.ResponseTypes {
+		if err := rabbitBus.connectResponseConsumer(responseType); err != nil {
+			return nil, err
+		}
+	}
+
 	mqProducer, err := producerFactory.CreateProducer(
 		producersConfigurationMap,
 		// IsProduced Notification
@@ -161,7 +176,7 @@ func (r *rabbitmqBus) ConnectRabbitMQConsumer(
 	if consumerBuilderFunc != nil {
 		consumerBuilderFunc(builder)
 	}
-	consumerConfig := builder.Build()
+	consumerConfig := r.rabbitmqConfiguration.WithBusPipelines(builder.Build())
 	mqConsumer, err := r.consumerFactory.CreateConsumer(
 		consumerConfig,
 		// IsConsumed Notification
@@ -183,6 +198,10 @@ func (r *rabbitmqBus) ConnectRabbitMQConsumer(
 		r.messageTypeConsumers[typeName],
 		mqConsumer,
 	)
+	r.rabbitmqConfiguration.ConsumersConfigurations = append(
+		r.rabbitmqConfiguration.ConsumersConfigurations,
+		consumerConfig,
+	)
 
 	return nil
 }
@@ -206,7 +225,7 @@ func (r *rabbitmqBus) ConnectConsumerHandler(
 		consumerBuilder.WithHandlers(func(builder consumer2.ConsumerHandlerConfigurationBuilder) {
 			builder.AddHandler(consumerHandler)
 		})
-		consumerConfig := consumerBuilder.Build()
+		consumerConfig := r.rabbitmqConfiguration.WithBusPipelines(consumerBuilder.Build())
 		mqConsumer, err := r.consumerFactory.CreateConsumer(
 			consumerConfig,
 			// IsConsumed Notification
@@ -225,14 +244,22 @@ func (r *rabbitmqBus) ConnectConsumerHandler(
 		}
 
 		r.messageTypeConsumers[typeName] = append(r.messageTypeConsumers[typeName], mqConsumer)
+		r.rabbitmqConfiguration.ConsumersConfigurations = append(
+			r.rabbitmqConfiguration.ConsumersConfigurations,
+			consumerConfig,
+		)
 	}
 	return nil
 }
 
+func (r *rabbitmqBus) Configuration() *configurations.RabbitMQConfiguration {
+	return r.rabbitmqConfiguration
+}
+
 func (r *rabbitmqBus) Start(ctx context.Context) error {
 	r.logger.Infof(
 		"rabbitmq is running on host: %s",
-		r.consumerFactory.Connection().Raw().LocalAddr().String(),
+		r.consumerFactory.Connection().Stats().Host,
 	)
 
 	for messageType, consumers := range r.messageTypeConsumers {
@@ -324,3 +351,16 @@ func (r *rabbitmqBus) PublishMessageWithTopicName(
 		topicOrExchangeName,
 	)
 }
+
+func (r *rabbitmqBus) PublishMessageWithDelay(
+	ctx context.Context,
+	message types.IMessage,
+	meta metadata.Metadata,
+	delay time.Duration,
+) error {
+	return r.producer.PublishMessageWithDelay(ctx, message, meta, delay)
+}
+
+func (r *rabbitmqBus) PublishMessages(ctx context.Context, messages []types.IMessage) error {
+	return r.producer.PublishMessages(ctx, messages)
+}
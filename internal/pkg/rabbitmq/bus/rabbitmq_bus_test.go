@@ -62,12 +62,17 @@ func Test_AddRabbitMQ(t *testing.T) {
 		conn,
 		serializer,
 		defaultlogger.GetLogger(),
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 	producerFactory := rabbitmqproducer.NewProducerFactory(
 		options,
 		conn,
 		serializer,
 		defaultlogger.GetLogger(),
+		nil,
 	)
 
 	b, err := NewRabbitmqBus(
@@ -0,0 +1,109 @@
+package bus
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/bus"
+	consumer2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	messageHeader "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/messageheader"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	consumerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/consumer/configurations"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// connectResponseConsumer consumes the responses of the response type from a reply exchange of the bus, the exchange and
+// its queue are deleted when the bus disconnects, so the responses of the requests of a stopped instance are dropped
+func (r *rabbitmqBus) connectResponseConsumer(responseType types.IMessage) error {
+	replyId := uuid.NewV4().String()
+	replyAddress := fmt.Sprintf("%s.reply.%s", utils.GetTopicOrExchangeName(responseType), replyId)
+
+	err := r.ConnectRabbitMQConsumer(
+		responseType,
+		func(builder consumerConfigurations.RabbitMQConsumerConfigurationBuilder) {
+			builder.
+				WithName(fmt.Sprintf("%s_reply_consumer", utils.GetMessageName(responseType))).
+				WithExchangeName(replyAddress).
+				WithDurable(false).
+				WithAutoDeleteExchange(true).
+				WithConsumptionMode(consumerConfigurations.ConsumptionModeBroadcast).
+				WithInstanceId(replyId).
+				WithHandlers(func(handlerBuilder consumer2.ConsumerHandlerConfigurationBuilder) {
+					handlerBuilder.AddHandler(&responseHandler{bus: r})
+				})
+		},
+	)
+	if err != nil {
+		return errors.WrapIff(
+			err,
+			"error in connecting the response consumer of `%s`",
+			typeMapper.GetTypeName(responseType),
+		)
+	}
+
+	r.replyAddresses[utils.GetMessageBaseReflectType(responseType)] = replyAddress
+
+	return nil
+}
+
+func (r *rabbitmqBus) Request(
+	ctx context.Context,
+	message types.IMessage,
+	responseType types.IMessage,
+	meta metadata.Metadata,
+) (types.IMessage, error) {
+	replyAddress, ok := r.replyAddresses[utils.GetMessageBaseReflectType(responseType)]
+	if !ok {
+		return nil, errors.Errorf(
+			"there is no response consumer for `%s`, it should be added with `AddResponseConsumer`",
+			typeMapper.GetTypeName(responseType),
+		)
+	}
+
+	correlationId := uuid.NewV4().String()
+	meta = metadata.FromMetadata(meta)
+	messageHeader.SetCorrelationId(meta, correlationId)
+	messageHeader.SetReplyTo(meta, replyAddress)
+
+	response := r.pendingRequests.add(correlationId)
+	defer r.pendingRequests.remove(correlationId)
+
+	ctx, cancel := context.WithTimeout(ctx, r.rabbitmqConfiguration.RequestTimeout)
+	defer cancel()
+
+	if err := r.PublishMessage(ctx, message, meta); err != nil {
+		return nil, errors.WrapIf(err, "error in publishing the request")
+	}
+
+	select {
+	case result := <-response:
+		return result, nil
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, errors.WithStackIf(bus.ErrRequestTimeout)
+		}
+
+		return nil, ctx.Err()
+	}
+}
+
+// responseHandler completes the waiting requests with their responses
+type responseHandler struct {
+	bus *rabbitmqBus
+}
+
+func (h *responseHandler) Handle(_ context.Context, consumeContext types.MessageConsumeContext) error {
+	if !h.bus.pendingRequests.complete(consumeContext.CorrelationId(), consumeContext.Message()) {
+		h.bus.logger.Infof(
+			"(responseHandler) there is no waiting request for the response with correlation id: {%s}, skipping it",
+			consumeContext.CorrelationId(),
+		)
+	}
+
+	return nil
+}
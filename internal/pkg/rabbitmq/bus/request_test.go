@@ -0,0 +1,156 @@
+package bus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	messageBus "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/bus"
+	messageConsumer "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/producer"
+	types3 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer/json"
+	defaultlogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/configurations"
+	rabbitmqconsumer "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/consumer"
+	consumerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/consumer/configurations"
+	rabbitmqproducer "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/producer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/types"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Request_Receives_The_Response_Of_The_Responder(t *testing.T) {
+	ctx := context.Background()
+
+	handler := &stockQueryHandler{}
+	responder := newInMemoryRequestTestBus(t, func(builder configurations.RabbitMQConfigurationBuilder) {
+		builder.AddConsumer(
+			StockQuery{},
+			func(consumerBuilder consumerConfigurations.RabbitMQConsumerConfigurationBuilder) {
+				consumerBuilder.WithHandlers(func(handlerBuilder messageConsumer.ConsumerHandlerConfigurationBuilder) {
+					handlerBuilder.AddHandler(handler)
+				})
+			},
+		)
+	})
+	requester := newInMemoryRequestTestBus(t, func(builder configurations.RabbitMQConfigurationBuilder) {
+		builder.AddResponseConsumer(StockQueryResult{})
+	})
+
+	handler.producer = responder
+
+	require.NoError(t, responder.Start(ctx))
+	defer responder.Stop()
+	require.NoError(t, requester.Start(ctx))
+	defer requester.Stop()
+
+	productId := uuid.NewV4().String()
+	result, err := messageBus.Request[*StockQueryResult](ctx, requester, NewStockQuery(productId))
+	require.NoError(t, err)
+
+	assert.Equal(t, productId, result.ProductId)
+	assert.Equal(t, 10, result.Available)
+}
+
+func Test_Request_Is_Timed_Out_Without_A_Responder(t *testing.T) {
+	ctx := context.Background()
+
+	requester := newInMemoryRequestTestBus(t, func(builder configurations.RabbitMQConfigurationBuilder) {
+		builder.AddResponseConsumer(StockQueryResult{}).WithRequestTimeout(200 * time.Millisecond)
+	})
+
+	require.NoError(t, requester.Start(ctx))
+	defer requester.Stop()
+
+	_, err := messageBus.Request[*StockQueryResult](ctx, requester, NewStockQuery(uuid.NewV4().String()))
+	assert.True(t, errors.Is(err, messageBus.ErrRequestTimeout))
+}
+
+func Test_Request_Without_Response_Consumer_Fails(t *testing.T) {
+	requester := newInMemoryRequestTestBus(t, nil)
+
+	_, err := messageBus.Request[*StockQueryResult](
+		context.Background(),
+		requester,
+		NewStockQuery(uuid.NewV4().String()),
+	)
+	assert.Error(t, err)
+}
+
+func newInMemoryRequestTestBus(
+	t *testing.T,
+	builderFunc configurations.RabbitMQConfigurationBuilderFuc,
+) RabbitmqBus {
+	t.Helper()
+
+	options := &config.RabbitmqOptions{UseInMemory: true}
+
+	conn, err := types.NewRabbitMQConnection(options)
+	require.NoError(t, err)
+
+	serializer := json.NewDefaultMessageJsonSerializer(json.NewDefaultJsonSerializer())
+	consumerFactory := rabbitmqconsumer.NewConsumerFactory(
+		options,
+		conn,
+		serializer,
+		defaultlogger.GetLogger(),
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+	producerFactory := rabbitmqproducer.NewProducerFactory(
+		options,
+		conn,
+		serializer,
+		defaultlogger.GetLogger(),
+		nil,
+	)
+
+	rabbitmqBus, err := NewRabbitmqBus(defaultlogger.GetLogger(), consumerFactory, producerFactory, builderFunc)
+	require.NoError(t, err)
+
+	return rabbitmqBus
+}
+
+type StockQuery struct {
+	*types3.Message
+	ProductId string
+}
+
+func NewStockQuery(productId string) *StockQuery {
+	return &StockQuery{Message: types3.NewMessage(uuid.NewV4().String()), ProductId: productId}
+}
+
+type StockQueryResult struct {
+	*types3.Message
+	ProductId string
+	Available int
+}
+
+type stockQueryHandler struct {
+	producer producer.Producer
+}
+
+func (h *stockQueryHandler) Handle(ctx context.Context, consumeContext types3.MessageConsumeContext) error {
+	query, ok := consumeContext.Message().(*StockQuery)
+	if !ok {
+		return errors.New("error in casting message to StockQuery")
+	}
+
+	return messageBus.Respond(
+		ctx,
+		h.producer,
+		consumeContext,
+		&StockQueryResult{
+			Message:   types3.NewMessage(uuid.NewV4().String()),
+			ProductId: query.ProductId,
+			Available: 10,
+		},
+	)
+}
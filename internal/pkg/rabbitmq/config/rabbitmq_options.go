@@ -2,12 +2,16 @@ package config
 
 import (
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
 	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
 
+	"emperror.dev/errors"
 	"github.com/iancoleman/strcase"
 )
 
@@ -16,8 +20,266 @@ type RabbitmqOptions struct {
 	DeliveryMode        uint8
 	Persisted           bool
 	AppId               string
-	AutoStart           bool `mapstructure:"autoStart"           default:"true"`
-	Reconnecting        bool `mapstructure:"reconnecting"        default:"true"`
+	AutoStart           bool                     `mapstructure:"autoStart"           default:"true"`
+	Reconnecting        bool                     `mapstructure:"reconnecting"        default:"true"`
+	ReconnectOptions    RabbitmqReconnectOptions `mapstructure:"reconnectOptions"`
+	// VerifyTopology compares the declared topology with the live broker, it uses the management api on `HttpPort` and is
+	// skipped when there is no http port. with the topology provisioning the drifts are handled by `TopologyOptions.OnDrift`
+	// before the bus starts, otherwise they are only logged after starting the consumers.
+	VerifyTopology bool `mapstructure:"verifyTopology" default:"true"`
+	// TopologyOptions controls the declaration of the exchanges, queues and bindings of the configuration at startup.
+	TopologyOptions RabbitmqTopologyOptions `mapstructure:"topologyOptions"`
+	// UseInMemory replaces the broker with a process-wide in-memory broker, the apps composed in one process (e.g. the
+	// development host) exchange their messages through it without a running rabbitmq.
+	UseInMemory bool `mapstructure:"useInMemory" env:"RabbitmqUseInMemory"`
+	// DelayedMessageExchange publishes the delayed messages through the delayed message exchange plugin, without the
+	// plugin a delayed message waits in a TTL queue which dead-letters it to its exchange after the delay.
+	// https://github.com/rabbitmq/rabbitmq-delayed-message-exchange
+	DelayedMessageExchange bool `mapstructure:"delayedMessageExchange"`
+	// PublishBatchOptions controls the publisher confirms of the batches published with `PublishMessages`.
+	PublishBatchOptions RabbitmqPublishBatchOptions `mapstructure:"publishBatchOptions"`
+	// ContentType is the content type of the published messages, `application/json`, `application/x-protobuf`,
+	// `application/avro` or `application/msgpack`, the consumers deserialize the messages by their own content types.
+	ContentType string `mapstructure:"contentType" default:"application/json"`
+	// Consumers tunes the consumers by their names, e.g. `product_created_v1_consumer`, over their configurations in code
+	Consumers map[string]*RabbitmqConsumerOptions `mapstructure:"consumers"`
+	// ChannelPoolOptions controls the pool of the publisher channels of the producer.
+	ChannelPoolOptions RabbitmqChannelPoolOptions `mapstructure:"channelPoolOptions"`
+	// PublishDeduplicationOptions skips the publishes of the messages which are already published by the producer.
+	PublishDeduplicationOptions RabbitmqPublishDeduplicationOptions `mapstructure:"publishDeduplicationOptions"`
+	// ShutdownOptions controls the drain of the consumers when the app stops.
+	ShutdownOptions RabbitmqShutdownOptions `mapstructure:"shutdownOptions"`
+	// Buses are the host options of the named buses by their names, e.g. `integration-bus` on another virtual host, the
+	// zero fields of a named bus keep the host options of the default bus. a named bus is registered with
+	// `rabbitmq.NamedBusModuleFunc` and has its own connection and topology.
+	Buses map[string]*RabbitmqHostOptions `mapstructure:"buses"`
+}
+
+// RabbitmqConsumerOptions tunes the concurrency of a consumer, the zero values keep the configuration of the consumer
+type RabbitmqConsumerOptions struct {
+	ConcurrencyLimit int `mapstructure:"concurrencyLimit"`
+	PrefetchCount    int `mapstructure:"prefetchCount"`
+	// HealthDependencies are the health checks which pause the consumer while they are unhealthy, e.g. `mongodb`
+	HealthDependencies []string `mapstructure:"healthDependencies"`
+}
+
+// RabbitmqPublishBatchOptions controls how often a batch publish waits for the publisher confirms of its messages.
+type RabbitmqPublishBatchOptions struct {
+	// BatchSize is the maximum number of published messages that wait for their confirms.
+	BatchSize int `mapstructure:"batchSize"     default:"500"`
+	// FlushInterval is the maximum time the published messages wait before their confirms are awaited, so a slow batch
+	// doesn't hold its confirms until the batch size is reached.
+	FlushInterval time.Duration `mapstructure:"flushInterval" default:"1s"`
+}
+
+// RabbitmqChannelPoolOptions controls the channels of the publishes, the publishes reuse the idle channels of the pool
+// instead of opening a channel for each publish, and wait for a channel while all the channels are in use.
+type RabbitmqChannelPoolOptions struct {
+	// Size is the maximum number of the open publisher channels of the producer.
+	Size int `mapstructure:"size" default:"16"`
+}
+
+// RabbitmqPublishDeduplicationOptions controls the deduplication of the publishes by the message ids, so a handler which
+// is retried after its messages are published doesn't publish them again. the published message ids are kept in the
+// memory of the producer, so the publishes of the other instances of the service are not deduplicated.
+type RabbitmqPublishDeduplicationOptions struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Window is how long a published message id is kept, a message with the same id is published again after it.
+	Window time.Duration `mapstructure:"window"     default:"5m"`
+	// MaxEntries bounds the kept message ids, the oldest ids are removed first.
+	MaxEntries int `mapstructure:"maxEntries" default:"10000"`
+}
+
+// RabbitmqShutdownOptions controls the drain of the consumers on shutdown, the consumers stop receiving deliveries
+// and wait for their deliveries in handling up to the drain timeout, the deliveries which are still in handling after
+// it are requeued, so another instance handles them again.
+type RabbitmqShutdownOptions struct {
+	// DrainTimeout should be shorter than the stop timeout of the app, so the deliveries are requeued before it stops.
+	DrainTimeout time.Duration `mapstructure:"drainTimeout" default:"10s"`
+}
+
+// RabbitmqTopologyOptions controls the provisioning of the topology of the configuration builder before the bus starts.
+type RabbitmqTopologyOptions struct {
+	// Provision declares all the exchanges, queues and bindings of the configuration before the bus starts, the consumers
+	// still declare their own topology after each reconnect, because the node they reconnect to may not have it.
+	Provision bool `mapstructure:"provision" default:"true"`
+	// OnDrift is what the provisioning does with an exchange or a queue of the broker which is declared with a different
+	// durability or arguments, `fail` stops the startup and `repair` deletes and declares it again. repairing a queue drops
+	// its messages and repairing an exchange drops the bindings of the other services to it until they declare them again.
+	OnDrift string `mapstructure:"onDrift" default:"fail"`
+}
+
+const (
+	TopologyDriftFail   = "fail"
+	TopologyDriftRepair = "repair"
+)
+
+// RabbitmqReconnectOptions controls the reconnecting behavior of the connection after a broker or network failure.
+type RabbitmqReconnectOptions struct {
+	// InitialDelay is the delay before the first reconnect attempt, the delay doubles after each failed round over the hosts.
+	InitialDelay time.Duration `mapstructure:"initialDelay"         default:"1s"`
+	// MaxDelay is the upper bound of the delay between reconnect attempts.
+	MaxDelay time.Duration `mapstructure:"maxDelay"             default:"30s"`
+	// PublishBufferSize is the maximum number of publishes that wait for the connection during a reconnect, publishes
+	// beyond this limit fail immediately.
+	PublishBufferSize int `mapstructure:"publishBufferSize"    default:"100"`
+	// PublishBufferTimeout is the maximum time a publish waits for the connection during a reconnect.
+	PublishBufferTimeout time.Duration `mapstructure:"publishBufferTimeout" default:"10s"`
+}
+
+const (
+	defaultReconnectInitialDelay = time.Second
+	defaultReconnectMaxDelay     = 30 * time.Second
+	defaultPublishBufferSize     = 100
+	defaultPublishBufferTimeout  = 10 * time.Second
+	defaultRabbitmqPort          = 5672
+	defaultPublishBatchSize      = 500
+	defaultPublishFlushInterval  = time.Second
+	defaultDeduplicationWindow   = 5 * time.Minute
+	defaultDeduplicationEntries  = 10000
+	defaultDrainTimeout          = 10 * time.Second
+	defaultChannelPoolSize       = 16
+)
+
+// ConsumerOptions returns the tuning of the consumer, the names are matched case-insensitively because the keys of
+// the config maps are lower cased by the config loader
+func (r *RabbitmqOptions) ConsumerOptions(name string) *RabbitmqConsumerOptions {
+	for consumerName, options := range r.Consumers {
+		if options != nil && strings.EqualFold(consumerName, name) {
+			return options
+		}
+	}
+
+	return nil
+}
+
+// BusOptions returns the options of a named bus, they are the options of the default bus with the host options of the
+// named bus over its host options. the names are matched case-insensitively like the consumer names.
+func (r *RabbitmqOptions) BusOptions(name string) (*RabbitmqOptions, error) {
+	var busHostOptions *RabbitmqHostOptions
+	for busName, hostOptions := range r.Buses {
+		if hostOptions != nil && strings.EqualFold(busName, name) {
+			busHostOptions = hostOptions
+			break
+		}
+	}
+
+	if busHostOptions == nil {
+		return nil, errors.Errorf("rabbitmq bus `%s` is not configured in the buses of the rabbitmq options", name)
+	}
+
+	hostOptions := &RabbitmqHostOptions{}
+	if r.RabbitmqHostOptions != nil {
+		*hostOptions = *r.RabbitmqHostOptions
+	}
+	hostOptions.merge(busHostOptions)
+
+	options := *r
+	options.RabbitmqHostOptions = hostOptions
+	options.Buses = nil
+
+	return &options, nil
+}
+
+func (r RabbitmqReconnectOptions) GetInitialDelay() time.Duration {
+	if r.InitialDelay <= 0 {
+		return defaultReconnectInitialDelay
+	}
+
+	return r.InitialDelay
+}
+
+func (r RabbitmqReconnectOptions) GetMaxDelay() time.Duration {
+	if r.MaxDelay <= 0 {
+		return defaultReconnectMaxDelay
+	}
+
+	return r.MaxDelay
+}
+
+func (r RabbitmqReconnectOptions) GetPublishBufferSize() int {
+	if r.PublishBufferSize <= 0 {
+		return defaultPublishBufferSize
+	}
+
+	return r.PublishBufferSize
+}
+
+func (r RabbitmqReconnectOptions) GetPublishBufferTimeout() time.Duration {
+	if r.PublishBufferTimeout <= 0 {
+		return defaultPublishBufferTimeout
+	}
+
+	return r.PublishBufferTimeout
+}
+
+// Delay returns the backoff delay before the reconnect round `attempt` (zero based).
+func (r RabbitmqReconnectOptions) Delay(attempt int) time.Duration {
+	delay := r.GetInitialDelay()
+	maxDelay := r.GetMaxDelay()
+
+	for i := 0; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > maxDelay {
+		return maxDelay
+	}
+
+	return delay
+}
+
+// Repair reports whether the drifts of the topology are repaired instead of failing the startup
+func (t RabbitmqTopologyOptions) Repair() bool {
+	return strings.EqualFold(t.OnDrift, TopologyDriftRepair)
+}
+
+func (b RabbitmqPublishBatchOptions) GetBatchSize() int {
+	if b.BatchSize <= 0 {
+		return defaultPublishBatchSize
+	}
+
+	return b.BatchSize
+}
+
+func (b RabbitmqPublishBatchOptions) GetFlushInterval() time.Duration {
+	if b.FlushInterval <= 0 {
+		return defaultPublishFlushInterval
+	}
+
+	return b.FlushInterval
+}
+
+func (c RabbitmqChannelPoolOptions) GetSize() int {
+	if c.Size <= 0 {
+		return defaultChannelPoolSize
+	}
+
+	return c.Size
+}
+
+func (d RabbitmqPublishDeduplicationOptions) GetWindow() time.Duration {
+	if d.Window <= 0 {
+		return defaultDeduplicationWindow
+	}
+
+	return d.Window
+}
+
+func (d RabbitmqPublishDeduplicationOptions) GetMaxEntries() int {
+	if d.MaxEntries <= 0 {
+		return defaultDeduplicationEntries
+	}
+
+	return d.MaxEntries
+}
+
+func (s RabbitmqShutdownOptions) GetDrainTimeout() time.Duration {
+	if s.DrainTimeout <= 0 {
+		return defaultDrainTimeout
+	}
+
+	return s.DrainTimeout
 }
 
 type RabbitmqHostOptions struct {
@@ -26,12 +288,90 @@ type RabbitmqHostOptions struct {
 	Port        int       `mapstructure:"port"`
 	HttpPort    int       `mapstructure:"httpPort"`
 	UserName    string    `mapstructure:"userName"`
-	Password    string    `mapstructure:"password"`
+	Password    string    `mapstructure:"password" secret:"true"`
 	RetryDelay  time.Time `mapstructure:"retryDelay"`
+	// Hosts is the list of the cluster nodes in `host` or `host:port` form, when it is empty `HostName` and `Port` are used.
+	// on a connection failure the next host in the list is tried.
+	Hosts []string `mapstructure:"hosts"`
 }
 
 func (h *RabbitmqHostOptions) AmqpEndPoint() string {
-	return fmt.Sprintf("amqp://%s:%s@%s:%d", h.UserName, h.Password, h.HostName, h.Port)
+	return fmt.Sprintf("amqp://%s:%s@%s:%d%s", h.UserName, h.Password, h.HostName, h.Port, h.virtualHostPath())
+}
+
+// AmqpEndPoints returns the amqp endpoints of all the configured hosts in the failover order.
+func (h *RabbitmqHostOptions) AmqpEndPoints() []string {
+	if len(h.Hosts) == 0 {
+		return []string{h.AmqpEndPoint()}
+	}
+
+	endpoints := make([]string, 0, len(h.Hosts))
+	for _, host := range h.Hosts {
+		hostName, port := h.splitHost(host)
+		endpoints = append(
+			endpoints,
+			fmt.Sprintf("amqp://%s:%s@%s:%d%s", h.UserName, h.Password, hostName, port, h.virtualHostPath()),
+		)
+	}
+
+	return endpoints
+}
+
+// virtualHostPath is the path of the virtual host in the amqp uri, the default virtual host `/` has no path
+func (h *RabbitmqHostOptions) virtualHostPath() string {
+	if h.VirtualHost == "" || h.VirtualHost == "/" {
+		return ""
+	}
+
+	return "/" + url.PathEscape(h.VirtualHost)
+}
+
+// merge sets the non-zero fields of the other host options over the host options, a host name of the other options
+// replaces the hosts of the cluster too, so it doesn't fail over to the nodes of another broker
+func (h *RabbitmqHostOptions) merge(other *RabbitmqHostOptions) {
+	if other.HostName != "" {
+		h.HostName = other.HostName
+		h.Hosts = nil
+	}
+	if other.VirtualHost != "" {
+		h.VirtualHost = other.VirtualHost
+	}
+	if other.Port != 0 {
+		h.Port = other.Port
+	}
+	if other.HttpPort != 0 {
+		h.HttpPort = other.HttpPort
+	}
+	if other.UserName != "" {
+		h.UserName = other.UserName
+	}
+	if other.Password != "" {
+		h.Password = other.Password
+	}
+	if len(other.Hosts) > 0 {
+		h.Hosts = other.Hosts
+	}
+}
+
+func (h *RabbitmqHostOptions) splitHost(host string) (string, int) {
+	host = strings.TrimSpace(host)
+
+	port := h.Port
+	if port == 0 {
+		port = defaultRabbitmqPort
+	}
+
+	index := strings.LastIndex(host, ":")
+	if index < 0 {
+		return host, port
+	}
+
+	p, err := strconv.Atoi(host[index+1:])
+	if err != nil {
+		return host, port
+	}
+
+	return host[:index], p
 }
 
 func (h *RabbitmqHostOptions) HttpEndPoint() string {
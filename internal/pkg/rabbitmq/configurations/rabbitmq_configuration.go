@@ -1,6 +1,10 @@
 package configurations
 
 import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/pipeline"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
 	consumerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/consumer/configurations"
 	producerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/producer/configurations"
 )
@@ -8,4 +12,30 @@ import (
 type RabbitMQConfiguration struct {
 	ProducersConfigurations []*producerConfigurations.RabbitMQProducerConfiguration
 	ConsumersConfigurations []*consumerConfigurations.RabbitMQConsumerConfiguration
+	// Pipelines are the pipelines of all the consumers of the bus, they wrap the pipelines of each consumer, like the
+	// pipeline behaviors of the mediator which wrap all the request handlers
+	Pipelines []pipeline.ConsumerPipeline
+	// ResponseTypes are the responses of the requests of the bus, the bus consumes each of them from a reply exchange of
+	// its own, so the responses of a request are only received by the instance which sent it
+	ResponseTypes []types.IMessage
+	// RequestTimeout is how long a request waits for its response, a shorter deadline of the context of the request wins
+	RequestTimeout time.Duration
+}
+
+// DefaultRequestTimeout is the request timeout of the buses which don't set it
+const DefaultRequestTimeout = 10 * time.Second
+
+// WithBusPipelines adds the pipelines of the bus before the pipelines of the consumer configuration
+func (r *RabbitMQConfiguration) WithBusPipelines(
+	consumerConfiguration *consumerConfigurations.RabbitMQConsumerConfiguration,
+) *consumerConfigurations.RabbitMQConsumerConfiguration {
+	if len(r.Pipelines) == 0 {
+		return consumerConfiguration
+	}
+
+	pipelines := make([]pipeline.ConsumerPipeline, 0, len(r.Pipelines)+len(consumerConfiguration.Pipelines))
+	pipelines = append(pipelines, r.Pipelines...)
+	consumerConfiguration.Pipelines = append(pipelines, consumerConfiguration.Pipelines...)
+
+	return consumerConfiguration
 }
@@ -1,6 +1,9 @@
 package configurations
 
 import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/pipeline"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
 	consumerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/consumer/configurations"
 	producerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/producer/configurations"
@@ -17,6 +20,14 @@ type RabbitMQConfigurationBuilder interface {
 		consumerMessageType types.IMessage,
 		consumerBuilderFunc consumerConfigurations.RabbitMQConsumerConfigurationBuilderFuc,
 	) RabbitMQConfigurationBuilder
+	// AddPipelines registers the pipelines once for all the consumers of the bus, they run in the registration order
+	// and before the pipelines of each consumer
+	AddPipelines(pipelines ...pipeline.ConsumerPipeline) RabbitMQConfigurationBuilder
+	// AddResponseConsumer consumes the responses of the requests of the bus which are answered with the response type
+	AddResponseConsumer(responseType types.IMessage) RabbitMQConfigurationBuilder
+	// WithRequestTimeout changes how long the requests of the bus wait for their responses, the default is
+	// `DefaultRequestTimeout`
+	WithRequestTimeout(timeout time.Duration) RabbitMQConfigurationBuilder
 	Build() *RabbitMQConfiguration
 }
 
@@ -28,7 +39,7 @@ type rabbitMQConfigurationBuilder struct {
 
 func NewRabbitMQConfigurationBuilder() RabbitMQConfigurationBuilder {
 	return &rabbitMQConfigurationBuilder{
-		rabbitMQConfiguration: &RabbitMQConfiguration{},
+		rabbitMQConfiguration: &RabbitMQConfiguration{RequestTimeout: DefaultRequestTimeout},
 	}
 }
 
@@ -60,11 +71,29 @@ func (r *rabbitMQConfigurationBuilder) AddConsumer(
 	return r
 }
 
+func (r *rabbitMQConfigurationBuilder) AddPipelines(pipelines ...pipeline.ConsumerPipeline) RabbitMQConfigurationBuilder {
+	r.rabbitMQConfiguration.Pipelines = append(r.rabbitMQConfiguration.Pipelines, pipelines...)
+
+	return r
+}
+
+func (r *rabbitMQConfigurationBuilder) AddResponseConsumer(responseType types.IMessage) RabbitMQConfigurationBuilder {
+	r.rabbitMQConfiguration.ResponseTypes = append(r.rabbitMQConfiguration.ResponseTypes, responseType)
+
+	return r
+}
+
+func (r *rabbitMQConfigurationBuilder) WithRequestTimeout(timeout time.Duration) RabbitMQConfigurationBuilder {
+	r.rabbitMQConfiguration.RequestTimeout = timeout
+
+	return r
+}
+
 func (r *rabbitMQConfigurationBuilder) Build() *RabbitMQConfiguration {
 	consumersConfigs := lo.Map(
 		r.consumerBuilders,
 		func(builder consumerConfigurations.RabbitMQConsumerConfigurationBuilder, index int) *consumerConfigurations.RabbitMQConsumerConfiguration {
-			return builder.Build()
+			return r.rabbitMQConfiguration.WithBusPipelines(builder.Build())
 		},
 	)
 
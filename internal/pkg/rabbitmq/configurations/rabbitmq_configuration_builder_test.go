@@ -0,0 +1,66 @@
+package configurations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/pipeline"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	consumerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/consumer/configurations"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type firstMessage struct {
+	*types.Message
+}
+
+type secondMessage struct {
+	*types.Message
+}
+
+type namedPipeline struct {
+	name string
+}
+
+func (n *namedPipeline) Handle(
+	ctx context.Context,
+	_ types.MessageConsumeContext,
+	next pipeline.ConsumerHandlerFunc,
+) error {
+	return next(ctx)
+}
+
+func Test_Bus_Pipelines_Run_Before_The_Pipelines_Of_Each_Consumer(t *testing.T) {
+	recovery := &namedPipeline{name: "recovery"}
+	logging := &namedPipeline{name: "logging"}
+	inbox := &namedPipeline{name: "inbox"}
+
+	builder := NewRabbitMQConfigurationBuilder()
+	builder.AddConsumer(
+		firstMessage{},
+		func(consumerBuilder consumerConfigurations.RabbitMQConsumerConfigurationBuilder) {
+			consumerBuilder.WIthPipelines(func(pipelinesBuilder pipeline.ConsumerPipelineConfigurationBuilder) {
+				pipelinesBuilder.AddPipeline(inbox)
+			})
+		},
+	)
+	builder.AddConsumer(secondMessage{}, nil)
+	// the pipelines of the bus can be added after the consumers
+	builder.AddPipelines(recovery, logging)
+
+	configuration := builder.Build()
+	require.Len(t, configuration.ConsumersConfigurations, 2)
+
+	assert.Equal(
+		t,
+		[]pipeline.ConsumerPipeline{recovery, logging, inbox},
+		configuration.ConsumersConfigurations[0].Pipelines,
+	)
+	assert.Equal(
+		t,
+		[]pipeline.ConsumerPipeline{recovery, logging},
+		configuration.ConsumersConfigurations[1].Pipelines,
+	)
+}
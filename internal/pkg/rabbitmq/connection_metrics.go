@@ -0,0 +1,105 @@
+package rabbitmq
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/metrics"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/types"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// RegisterConnectionMetrics registers observable instruments for the rabbitmq connection state, they are read from
+// the connection stats on each collection, so there is no cost on the publish and consume paths.
+func RegisterConnectionMetrics(
+	connection types.IConnection,
+	appMetrics metrics.AppMetrics,
+) error {
+	return registerConnectionMetrics(connection, appMetrics)
+}
+
+// registerConnectionMetrics registers the connection instruments, the observations of a named bus have its name in the
+// `rabbitmq.bus` attribute, so they are not mixed with the observations of the default bus
+func registerConnectionMetrics(
+	connection types.IConnection,
+	appMetrics metrics.AppMetrics,
+	busAttributes ...attribute.KeyValue,
+) error {
+	if appMetrics == nil || connection == nil {
+		return nil
+	}
+
+	connected, err := appMetrics.Int64ObservableGauge(
+		"rabbitmq.connection.connected",
+		metric.WithUnit("count"),
+		metric.WithDescription(
+			"Indicates whether the rabbitmq connection is connected (1) or not (0)",
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	reconnects, err := appMetrics.Int64ObservableCounter(
+		"rabbitmq.connection.reconnects_total",
+		metric.WithUnit("count"),
+		metric.WithDescription("Measures the number of successful rabbitmq reconnects"),
+	)
+	if err != nil {
+		return err
+	}
+
+	failedReconnects, err := appMetrics.Int64ObservableCounter(
+		"rabbitmq.connection.reconnects_failed_total",
+		metric.WithUnit("count"),
+		metric.WithDescription("Measures the number of failed rabbitmq reconnect attempts"),
+	)
+	if err != nil {
+		return err
+	}
+
+	bufferedPublishes, err := appMetrics.Int64ObservableGauge(
+		"rabbitmq.connection.buffered_publishes",
+		metric.WithUnit("count"),
+		metric.WithDescription(
+			"Measures the number of publishes waiting for the rabbitmq connection during a reconnect",
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = appMetrics.RegisterCallback(
+		func(_ context.Context, observer metric.Observer) error {
+			stats := connection.Stats()
+
+			var isConnected int64
+			if stats.State == types.Connected {
+				isConnected = 1
+			}
+
+			attributes := metric.WithAttributes(append(
+				[]attribute.KeyValue{
+					attribute.String("rabbitmq.host", stats.Host),
+					attribute.String("rabbitmq.connection.state", stats.State.String()),
+				},
+				busAttributes...,
+			)...)
+			busAttributesOption := metric.WithAttributes(busAttributes...)
+
+			observer.ObserveInt64(connected, isConnected, attributes)
+			observer.ObserveInt64(reconnects, stats.Reconnects, busAttributesOption)
+			observer.ObserveInt64(failedReconnects, stats.FailedReconnects, busAttributesOption)
+			observer.ObserveInt64(bufferedPublishes, stats.BufferedPublishes, busAttributesOption)
+
+			return nil
+		},
+		connected,
+		reconnects,
+		failedReconnects,
+		bufferedPublishes,
+	)
+
+	return err
+}
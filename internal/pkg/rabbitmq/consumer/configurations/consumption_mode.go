@@ -0,0 +1,37 @@
+package configurations
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// ConsumptionMode is how the instances of a service share the messages of a consumer
+type ConsumptionMode string
+
+const (
+	// ConsumptionModeCompeting consumes the messages from one queue which is shared by all the instances of the service,
+	// so each message is handled by one instance, it is the default mode.
+	ConsumptionModeCompeting ConsumptionMode = "competing"
+	// ConsumptionModeBroadcast consumes the messages from a queue of each instance, so every instance handles a copy of
+	// each message, e.g. for invalidating the local caches. the queue of an instance is exclusive to its connection, so
+	// it is deleted when the instance stops and the messages published while it is disconnected are not received.
+	ConsumptionModeBroadcast ConsumptionMode = "broadcast"
+)
+
+// instanceId identifies the process in the queue names of the broadcast consumers, the host name keeps the queues of
+// an instance recognizable on the broker and the random suffix separates the processes of the same host
+var instanceId = newInstanceId() //nolint:gochecknoglobals
+
+func newInstanceId() string {
+	suffix := strings.ReplaceAll(uuid.NewV4().String(), "-", "")[:8]
+
+	hostName, err := os.Hostname()
+	if err != nil || hostName == "" {
+		return suffix
+	}
+
+	return fmt.Sprintf("%s-%s", strings.ToLower(hostName), suffix)
+}
@@ -18,15 +18,34 @@ type RabbitMQConsumerConfiguration struct {
 	Pipelines           []pipeline.ConsumerPipeline
 	Handlers            []consumer2.ConsumerHandler
 	*consumer2.ConsumerOptions
+	// ConcurrencyLimit is the number of the workers which handle the deliveries of the consumer in parallel
 	ConcurrencyLimit int
-	// The prefetch count tells the Rabbit connection how many messages to retrieve from the server per request.
-	PrefetchCount   int
-	AutoAck         bool
-	NoLocal         bool
-	NoWait          bool
-	BindingOptions  *options.RabbitMQBindingOptions
-	QueueOptions    *options.RabbitMQQueueOptions
-	ExchangeOptions *options.RabbitMQExchangeOptions
+	// PrefetchCount is the maximum number of the unacknowledged deliveries of the consumer, the deliveries over the busy
+	// workers wait in the consumer, so it bounds them. it is raised to the concurrency limit when it is lower.
+	PrefetchCount int
+	// OrderingKeyHeader is the header of the deliveries which should be handled in order with the other deliveries of
+	// the same header value, e.g. the id of an aggregate, they are handled by the same worker. without it the deliveries
+	// are handled by any free worker and their order is not kept with a concurrency limit over one.
+	OrderingKeyHeader string
+	// HealthDependencies are the names of the health checks of the dependencies of the handlers, e.g. `mongodb`, the
+	// consumer pauses while any of them is unhealthy instead of retrying and dead-lettering its messages
+	HealthDependencies []string
+	AutoAck            bool
+	NoLocal            bool
+	NoWait             bool
+	BindingOptions     *options.RabbitMQBindingOptions
+	QueueOptions       *options.RabbitMQQueueOptions
+	ExchangeOptions    *options.RabbitMQExchangeOptions
+	// DeadLetterOptions declares a dead-letter queue for the consumer, without it a failed message is requeued forever
+	DeadLetterOptions *options.RabbitMQDeadLetterOptions
+	// RetryPolicy retries a failed message with immediate and delayed retries before dead-lettering it, without it the
+	// handlers are retried a few times in the consumer and the message is requeued or dead-lettered
+	RetryPolicy *options.RabbitMQRetryPolicyOptions
+	// ConsumptionMode is whether the instances of the service compete for the messages of one queue or each instance
+	// receives a copy of them in its own queue
+	ConsumptionMode ConsumptionMode
+	// InstanceId is the suffix of the queue of the instance in the broadcast mode
+	InstanceId string
 }
 
 func NewDefaultRabbitMQConsumerConfiguration(
@@ -54,5 +73,62 @@ func NewDefaultRabbitMQConsumerConfiguration(
 		},
 		ConsumerMessageType: utils.GetMessageBaseReflectType(messageType),
 		Name:                name,
+		ConsumptionMode:     ConsumptionModeCompeting,
+		InstanceId:          instanceId,
 	}
 }
+
+// Topology returns the exchange, the routing key and the queue of the consumer, the names which are not configured
+// are created from the consumer message type
+func (c *RabbitMQConsumerConfiguration) Topology() (exchange string, routingKey string, queue string) {
+	if c.ExchangeOptions.Name != "" {
+		exchange = c.ExchangeOptions.Name
+	} else {
+		exchange = utils.GetTopicOrExchangeNameFromType(c.ConsumerMessageType)
+	}
+
+	if c.BindingOptions.RoutingKey != "" {
+		routingKey = c.BindingOptions.RoutingKey
+	} else {
+		routingKey = utils.GetRoutingKeyFromType(c.ConsumerMessageType)
+	}
+
+	queue = c.baseQueueName()
+	if c.IsBroadcast() {
+		queue = fmt.Sprintf("%s.%s", queue, c.InstanceId)
+	}
+
+	return exchange, routingKey, queue
+}
+
+// BindingKeys returns the keys of the bindings of the consumer queue to its exchange, they are the binding keys of a
+// wildcard subscription or the routing key of the consumer
+func (c *RabbitMQConsumerConfiguration) BindingKeys() []string {
+	if len(c.BindingOptions.BindingKeys) > 0 {
+		return c.BindingOptions.BindingKeys
+	}
+
+	_, routingKey, _ := c.Topology()
+
+	return []string{routingKey}
+}
+
+// IsBroadcast reports whether each instance of the service receives a copy of the messages in its own queue
+func (c *RabbitMQConsumerConfiguration) IsBroadcast() bool {
+	return c.ConsumptionMode == ConsumptionModeBroadcast
+}
+
+// baseQueueName is the queue of the consumer which is shared by the instances in the competing mode
+func (c *RabbitMQConsumerConfiguration) baseQueueName() string {
+	if c.QueueOptions.Name != "" {
+		return c.QueueOptions.Name
+	}
+
+	return utils.GetQueueNameFromType(c.ConsumerMessageType)
+}
+
+// Prefetch returns the prefetch count of the consumer channel, it is at least the concurrency limit so no worker waits
+// for the broker while another worker is busy
+func (c *RabbitMQConsumerConfiguration) Prefetch() int {
+	return max(c.PrefetchCount, c.ConcurrencyLimit, 1)
+}
@@ -1,12 +1,20 @@
 package configurations
 
 import (
+	"time"
+
 	messageConsumer "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/pipeline"
 	types2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/consumer/options"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/types"
 )
 
+const (
+	defaultRetryMultiplier = 2
+	defaultRetryJitter     = 0.2
+)
+
 type RabbitMQConsumerConfigurationBuilder interface {
 	WithHandlers(
 		consumerBuilderFunc messageConsumer.ConsumerHandlerConfigurationBuilderFunc,
@@ -20,19 +28,34 @@ type RabbitMQConsumerConfigurationBuilder interface {
 	WithNoWait(noWait bool) RabbitMQConsumerConfigurationBuilder
 	WithConcurrencyLimit(limit int) RabbitMQConsumerConfigurationBuilder
 	WithPrefetchCount(count int) RabbitMQConsumerConfigurationBuilder
+	WithOrderingKeyHeader(header string) RabbitMQConsumerConfigurationBuilder
+	WithHealthDependencies(dependencies ...string) RabbitMQConsumerConfigurationBuilder
 	WithConsumerId(consumerId string) RabbitMQConsumerConfigurationBuilder
 	WithQueueName(queueName string) RabbitMQConsumerConfigurationBuilder
 	WithDurable(durable bool) RabbitMQConsumerConfigurationBuilder
 	WithAutoDeleteQueue(autoDelete bool) RabbitMQConsumerConfigurationBuilder
 	WithExclusiveQueue(exclusive bool) RabbitMQConsumerConfigurationBuilder
 	WithQueueArgs(args map[string]any) RabbitMQConsumerConfigurationBuilder
+	WithMaxPriority(maxPriority uint8) RabbitMQConsumerConfigurationBuilder
 	WithExchangeName(exchangeName string) RabbitMQConsumerConfigurationBuilder
 	WithAutoDeleteExchange(autoDelete bool) RabbitMQConsumerConfigurationBuilder
 	WithExchangeType(exchangeType types.ExchangeType) RabbitMQConsumerConfigurationBuilder
 	WithExchangeArgs(args map[string]any) RabbitMQConsumerConfigurationBuilder
 	WithRoutingKey(routingKey string) RabbitMQConsumerConfigurationBuilder
+	WithBindingKeys(bindingKeys ...string) RabbitMQConsumerConfigurationBuilder
 	WithBindingArgs(args map[string]any) RabbitMQConsumerConfigurationBuilder
 	WithName(name string) RabbitMQConsumerConfigurationBuilder
+	WithDeadLetter(maxRetries int, messageTTL time.Duration) RabbitMQConsumerConfigurationBuilder
+	WithDeadLetterQueueName(queueName string) RabbitMQConsumerConfigurationBuilder
+	WithRetryPolicy(
+		immediateRetries int,
+		delayedRetries int,
+		initialDelay time.Duration,
+		maxDelay time.Duration,
+	) RabbitMQConsumerConfigurationBuilder
+	WithRetryBackoff(multiplier float64, jitter float64) RabbitMQConsumerConfigurationBuilder
+	WithConsumptionMode(mode ConsumptionMode) RabbitMQConsumerConfigurationBuilder
+	WithInstanceId(instanceId string) RabbitMQConsumerConfigurationBuilder
 	Build() *RabbitMQConsumerConfiguration
 }
 
@@ -123,6 +146,20 @@ func (b *rabbitMQConsumerConfigurationBuilder) WithPrefetchCount(
 	return b
 }
 
+func (b *rabbitMQConsumerConfigurationBuilder) WithOrderingKeyHeader(
+	header string,
+) RabbitMQConsumerConfigurationBuilder {
+	b.rabbitmqConsumerConfigurations.OrderingKeyHeader = header
+	return b
+}
+
+func (b *rabbitMQConsumerConfigurationBuilder) WithHealthDependencies(
+	dependencies ...string,
+) RabbitMQConsumerConfigurationBuilder {
+	b.rabbitmqConsumerConfigurations.HealthDependencies = dependencies
+	return b
+}
+
 func (b *rabbitMQConsumerConfigurationBuilder) WithConsumerId(
 	consumerId string,
 ) RabbitMQConsumerConfigurationBuilder {
@@ -166,6 +203,22 @@ func (b *rabbitMQConsumerConfigurationBuilder) WithQueueArgs(
 	return b
 }
 
+// WithMaxPriority declares the consumer queue as a priority queue, so the messages published with
+// `producer.WithPriority` are delivered before the messages with a lower priority. The args of an existing queue can't
+// change, so the queue should be deleted or the queue name changed when the max priority is added.
+func (b *rabbitMQConsumerConfigurationBuilder) WithMaxPriority(
+	maxPriority uint8,
+) RabbitMQConsumerConfigurationBuilder {
+	queueArgs := make(map[string]any, len(b.rabbitmqConsumerConfigurations.QueueOptions.Args)+1)
+	for key, value := range b.rabbitmqConsumerConfigurations.QueueOptions.Args {
+		queueArgs[key] = value
+	}
+	queueArgs["x-max-priority"] = maxPriority
+	b.rabbitmqConsumerConfigurations.QueueOptions.Args = queueArgs
+
+	return b
+}
+
 func (b *rabbitMQConsumerConfigurationBuilder) WithExchangeName(
 	exchangeName string,
 ) RabbitMQConsumerConfigurationBuilder {
@@ -201,6 +254,20 @@ func (b *rabbitMQConsumerConfigurationBuilder) WithRoutingKey(
 	return b
 }
 
+// WithBindingKeys subscribes the consumer to the messages of its exchange which match any of the binding keys, instead
+// of its routing key. the keys support the `*` wildcard for exactly one word and the `#` wildcard for zero or more
+// words, e.g. `catalogs.product.*` receives `catalogs.product.created` and `catalogs.product.deleted`, so the exchange
+// is changed to a topic exchange. the messages of a subscription are deserialized by their own types, so the handlers
+// of the consumer should handle all of them.
+func (b *rabbitMQConsumerConfigurationBuilder) WithBindingKeys(
+	bindingKeys ...string,
+) RabbitMQConsumerConfigurationBuilder {
+	b.rabbitmqConsumerConfigurations.BindingOptions.BindingKeys = bindingKeys
+	b.rabbitmqConsumerConfigurations.ExchangeOptions.Type = types.ExchangeTopic
+
+	return b
+}
+
 func (b *rabbitMQConsumerConfigurationBuilder) WithBindingArgs(
 	args map[string]any,
 ) RabbitMQConsumerConfigurationBuilder {
@@ -208,7 +275,102 @@ func (b *rabbitMQConsumerConfigurationBuilder) WithBindingArgs(
 	return b
 }
 
+// WithDeadLetter requeues a failed message up to `maxRetries` times and then moves it to the dead-letter queue of the
+// consumer, the dead-lettered messages are kept for `messageTTL` or until they are requeued if it is zero
+func (b *rabbitMQConsumerConfigurationBuilder) WithDeadLetter(
+	maxRetries int,
+	messageTTL time.Duration,
+) RabbitMQConsumerConfigurationBuilder {
+	deadLetterOptions := b.deadLetterOptions()
+	deadLetterOptions.MaxRetries = maxRetries
+	deadLetterOptions.MessageTTL = messageTTL
+
+	return b
+}
+
+func (b *rabbitMQConsumerConfigurationBuilder) WithDeadLetterQueueName(
+	queueName string,
+) RabbitMQConsumerConfigurationBuilder {
+	b.deadLetterOptions().QueueName = queueName
+	return b
+}
+
+func (b *rabbitMQConsumerConfigurationBuilder) deadLetterOptions() *options.RabbitMQDeadLetterOptions {
+	if b.rabbitmqConsumerConfigurations.DeadLetterOptions == nil {
+		b.rabbitmqConsumerConfigurations.DeadLetterOptions = &options.RabbitMQDeadLetterOptions{}
+	}
+
+	return b.rabbitmqConsumerConfigurations.DeadLetterOptions
+}
+
+// WithRetryPolicy runs the handlers of a failed message `immediateRetries` more times in the consumer, then retries
+// it `delayedRetries` times through the retry queues with an exponential backoff from `initialDelay` up to `maxDelay`.
+// The message is moved to the dead-letter queue after the delayed retries if `WithDeadLetter` is configured, otherwise
+// it is requeued.
+func (b *rabbitMQConsumerConfigurationBuilder) WithRetryPolicy(
+	immediateRetries int,
+	delayedRetries int,
+	initialDelay time.Duration,
+	maxDelay time.Duration,
+) RabbitMQConsumerConfigurationBuilder {
+	retryPolicy := b.retryPolicy()
+	retryPolicy.ImmediateRetries = immediateRetries
+	retryPolicy.DelayedRetries = delayedRetries
+	retryPolicy.InitialDelay = initialDelay
+	retryPolicy.MaxDelay = maxDelay
+
+	return b
+}
+
+// WithRetryBackoff changes the growth of the delays of the delayed retries and the fraction of them which is randomly
+// reduced, the defaults are 2 and 0.2
+func (b *rabbitMQConsumerConfigurationBuilder) WithRetryBackoff(
+	multiplier float64,
+	jitter float64,
+) RabbitMQConsumerConfigurationBuilder {
+	retryPolicy := b.retryPolicy()
+	retryPolicy.Multiplier = multiplier
+	retryPolicy.Jitter = jitter
+
+	return b
+}
+
+func (b *rabbitMQConsumerConfigurationBuilder) retryPolicy() *options.RabbitMQRetryPolicyOptions {
+	if b.rabbitmqConsumerConfigurations.RetryPolicy == nil {
+		b.rabbitmqConsumerConfigurations.RetryPolicy = &options.RabbitMQRetryPolicyOptions{
+			Multiplier: defaultRetryMultiplier,
+			Jitter:     defaultRetryJitter,
+		}
+	}
+
+	return b.rabbitmqConsumerConfigurations.RetryPolicy
+}
+
+// WithConsumptionMode sets whether the instances of the service compete for the messages of the consumer or each instance
+// receives a copy of them, the default is `ConsumptionModeCompeting`. In `ConsumptionModeBroadcast` each instance
+// consumes from an exclusive queue with its instance id suffix, the dead-letter queue is shared by the instances and the
+// messages are only retried immediately, because the delayed retries need the retry queues of each instance.
+func (b *rabbitMQConsumerConfigurationBuilder) WithConsumptionMode(
+	mode ConsumptionMode,
+) RabbitMQConsumerConfigurationBuilder {
+	b.rabbitmqConsumerConfigurations.ConsumptionMode = mode
+	return b
+}
+
+// WithInstanceId changes the instance id of the queue in the broadcast mode, the default is the host name with a
+// random suffix of the process
+func (b *rabbitMQConsumerConfigurationBuilder) WithInstanceId(
+	instanceId string,
+) RabbitMQConsumerConfigurationBuilder {
+	b.rabbitmqConsumerConfigurations.InstanceId = instanceId
+	return b
+}
+
 func (b *rabbitMQConsumerConfigurationBuilder) Build() *RabbitMQConsumerConfiguration {
+	if b.rabbitmqConsumerConfigurations.IsBroadcast() {
+		b.configureBroadcast()
+	}
+
 	if b.pipelinesBuilder != nil {
 		b.rabbitmqConsumerConfigurations.Pipelines = b.pipelinesBuilder.Build().Pipelines
 	}
@@ -218,3 +380,21 @@ func (b *rabbitMQConsumerConfigurationBuilder) Build() *RabbitMQConsumerConfigur
 
 	return b.rabbitmqConsumerConfigurations
 }
+
+// configureBroadcast declares the queue of the instance as an exclusive queue which is deleted with the connection of
+// the instance, and shares the dead-letter queue of the consumer between the instances
+func (b *rabbitMQConsumerConfigurationBuilder) configureBroadcast() {
+	configuration := b.rabbitmqConsumerConfigurations
+
+	configuration.QueueOptions.Durable = false
+	configuration.QueueOptions.AutoDelete = true
+	configuration.QueueOptions.Exclusive = true
+
+	if deadLetterOptions := configuration.DeadLetterOptions; deadLetterOptions != nil {
+		deadLetterOptions.ExchangeName, deadLetterOptions.QueueName = deadLetterOptions.Names(configuration.baseQueueName())
+	}
+
+	if retryPolicy := configuration.RetryPolicy; retryPolicy != nil {
+		retryPolicy.DelayedRetries = 0
+	}
+}
@@ -1,21 +1,30 @@
 package consumer
 
 import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/claimcheck"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/quarantine"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
 	serializer "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/health/contracts"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/metrics"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/config"
 	consumerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/consumer/configurations"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/consumer/consumercontracts"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/inmemory"
 	types2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/types"
 )
 
 type consumerFactory struct {
-	connection      types2.IConnection
-	eventSerializer serializer.MessageSerializer
-	logger          logger.Logger
-	rabbitmqOptions *config.RabbitmqOptions
+	connection        types2.IConnection
+	eventSerializer   serializer.MessageSerializer
+	logger            logger.Logger
+	rabbitmqOptions   *config.RabbitmqOptions
+	claimCheck        claimcheck.ClaimCheck
+	appMetrics        metrics.AppMetrics
+	dependencyMonitor contracts.DependencyMonitor
+	quarantineStore   quarantine.QuarantineStore
 }
 
 func NewConsumerFactory(
@@ -23,12 +32,20 @@ func NewConsumerFactory(
 	connection types2.IConnection,
 	eventSerializer serializer.MessageSerializer,
 	l logger.Logger,
+	claimCheck claimcheck.ClaimCheck,
+	appMetrics metrics.AppMetrics,
+	dependencyMonitor contracts.DependencyMonitor,
+	quarantineStore quarantine.QuarantineStore,
 ) consumercontracts.ConsumerFactory {
 	return &consumerFactory{
-		rabbitmqOptions: rabbitmqOptions,
-		logger:          l,
-		eventSerializer: eventSerializer,
-		connection:      connection,
+		quarantineStore:   quarantineStore,
+		dependencyMonitor: dependencyMonitor,
+		appMetrics:        appMetrics,
+		claimCheck:        claimCheck,
+		rabbitmqOptions:   rabbitmqOptions,
+		logger:            l,
+		eventSerializer:   eventSerializer,
+		connection:        connection,
 	}
 }
 
@@ -36,12 +53,29 @@ func (c *consumerFactory) CreateConsumer(
 	consumerConfiguration *consumerConfigurations.RabbitMQConsumerConfiguration,
 	isConsumedNotifications ...func(message types.IMessage),
 ) (consumer.Consumer, error) {
+	if c.rabbitmqOptions.UseInMemory {
+		return NewInMemoryConsumer(
+			c.rabbitmqOptions,
+			consumerConfiguration,
+			c.eventSerializer,
+			c.logger,
+			c.claimCheck,
+			c.appMetrics,
+			c.dependencyMonitor,
+			inmemory.DefaultBroker(),
+			isConsumedNotifications...)
+	}
+
 	return NewRabbitMQConsumer(
 		c.rabbitmqOptions,
 		c.connection,
 		consumerConfiguration,
 		c.eventSerializer,
 		c.logger,
+		c.claimCheck,
+		c.appMetrics,
+		c.dependencyMonitor,
+		c.quarantineStore,
 		isConsumedNotifications...)
 }
 
@@ -0,0 +1,207 @@
+package consumer
+
+import (
+	"context"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/constants/telemetrytags"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/metrics"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type retryKind string
+
+const (
+	// immediateRetry runs the handlers again in the consumer
+	immediateRetry retryKind = "immediate"
+	// delayedRetry publishes the message to a retry queue of the consumer
+	delayedRetry retryKind = "delayed"
+	// requeueRetry publishes the message to the end of the consumer queue
+	requeueRetry retryKind = "requeue"
+)
+
+const (
+	queueAttribute     = "rabbitmq.queue"
+	retryKindAttribute = "rabbitmq.retry.kind"
+	outcomeAttribute   = "rabbitmq.consume.outcome"
+	consumeSucceeded   = "success"
+	consumeFailed      = "failure"
+)
+
+// consumerMetrics measures the consumes, the handler errors, the retries, the dead-lettered, the quarantined and the
+// in-flight messages of the consumers by their queues and their message types, a nil consumerMetrics measures nothing
+type consumerMetrics struct {
+	duration      metric.Float64Histogram
+	errors        metric.Int64Counter
+	retries       metric.Int64Counter
+	deadLettered  metric.Int64Counter
+	quarantined   metric.Int64Counter
+	inFlight      metric.Int64UpDownCounter
+	drainRequeued metric.Int64Counter
+}
+
+func newConsumerMetrics(appMetrics metrics.AppMetrics) (*consumerMetrics, error) {
+	if appMetrics == nil {
+		return nil, nil
+	}
+
+	duration, err := appMetrics.Float64Histogram(
+		"rabbitmq.consumer.duration",
+		metric.WithUnit("ms"),
+		metric.WithDescription("Measures the duration of the handlers of the messages of the rabbitmq consumers"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	handlerErrors, err := appMetrics.Int64Counter(
+		"rabbitmq.consumer.errors_total",
+		metric.WithUnit("count"),
+		metric.WithDescription(
+			"Measures the number of messages of the rabbitmq consumers which are failed after their immediate retries",
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	retries, err := appMetrics.Int64Counter(
+		"rabbitmq.consumer.retries_total",
+		metric.WithUnit("count"),
+		metric.WithDescription("Measures the number of retries of the failed messages of the rabbitmq consumers"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	deadLettered, err := appMetrics.Int64Counter(
+		"rabbitmq.consumer.dead_lettered_total",
+		metric.WithUnit("count"),
+		metric.WithDescription(
+			"Measures the number of messages moved to the dead-letter queues of the rabbitmq consumers",
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	quarantined, err := appMetrics.Int64Counter(
+		"rabbitmq.consumer.quarantined_total",
+		metric.WithUnit("count"),
+		metric.WithDescription(
+			"Measures the number of messages of the rabbitmq consumers which are quarantined after their max retries",
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	inFlight, err := appMetrics.Int64UpDownCounter(
+		"rabbitmq.consumer.in_flight",
+		metric.WithUnit("count"),
+		metric.WithDescription("Measures the number of messages of the rabbitmq consumers which are in handling"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	drainRequeued, err := appMetrics.Int64Counter(
+		"rabbitmq.consumer.drain_requeued_total",
+		metric.WithUnit("count"),
+		metric.WithDescription(
+			"Measures the number of messages of the rabbitmq consumers which are requeued because their handlers didn't finish in the drain timeout",
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &consumerMetrics{
+		duration:      duration,
+		errors:        handlerErrors,
+		retries:       retries,
+		deadLettered:  deadLettered,
+		quarantined:   quarantined,
+		inFlight:      inFlight,
+		drainRequeued: drainRequeued,
+	}, nil
+}
+
+// recordConsume records the duration of the handlers of a message with their immediate retries, and counts the message
+// as an error when they are failed
+func (m *consumerMetrics) recordConsume(
+	ctx context.Context,
+	queue string,
+	messageType string,
+	duration time.Duration,
+	err error,
+) {
+	if m == nil {
+		return
+	}
+
+	outcome := consumeSucceeded
+	if err != nil {
+		outcome = consumeFailed
+		m.errors.Add(ctx, 1, metric.WithAttributes(messageAttributes(queue, messageType)...))
+	}
+
+	m.duration.Record(
+		ctx,
+		float64(duration)/float64(time.Millisecond),
+		metric.WithAttributes(append(messageAttributes(queue, messageType), attribute.String(outcomeAttribute, outcome))...),
+	)
+}
+
+func (m *consumerMetrics) addRetry(ctx context.Context, queue string, messageType string, kind retryKind) {
+	if m == nil {
+		return
+	}
+
+	m.retries.Add(ctx, 1, metric.WithAttributes(
+		append(messageAttributes(queue, messageType), attribute.String(retryKindAttribute, string(kind)))...,
+	))
+}
+
+func (m *consumerMetrics) addDeadLettered(ctx context.Context, queue string, messageType string) {
+	if m == nil {
+		return
+	}
+
+	m.deadLettered.Add(ctx, 1, metric.WithAttributes(messageAttributes(queue, messageType)...))
+}
+
+func (m *consumerMetrics) addQuarantined(ctx context.Context, queue string, messageType string) {
+	if m == nil {
+		return
+	}
+
+	m.quarantined.Add(ctx, 1, metric.WithAttributes(messageAttributes(queue, messageType)...))
+}
+
+// addInFlight adds a message to the in-flight messages of the queue when it is dispatched to a handler, and removes it
+// with a negative delta when its handling is finished
+func (m *consumerMetrics) addInFlight(ctx context.Context, queue string, messageType string, delta int64) {
+	if m == nil {
+		return
+	}
+
+	m.inFlight.Add(ctx, delta, metric.WithAttributes(messageAttributes(queue, messageType)...))
+}
+
+func (m *consumerMetrics) addDrainRequeued(ctx context.Context, queue string, count int) {
+	if m == nil || count == 0 {
+		return
+	}
+
+	m.drainRequeued.Add(ctx, int64(count), metric.WithAttributes(attribute.String(queueAttribute, queue)))
+}
+
+func messageAttributes(queue string, messageType string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String(queueAttribute, queue),
+		attribute.String(telemetrytags.App.MessageType, messageType),
+	}
+}
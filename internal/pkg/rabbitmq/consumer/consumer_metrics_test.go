@@ -0,0 +1,84 @@
+package consumer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/constants/telemetrytags"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func Test_Consumer_Metrics_Are_Labeled_By_Queue_And_Message_Type(t *testing.T) {
+	ctx := context.Background()
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	consumerMetrics, err := newConsumerMetrics(provider.Meter("test"))
+	require.NoError(t, err)
+
+	consumerMetrics.recordConsume(ctx, "orders", "OrderCreatedV1", 20*time.Millisecond, nil)
+	consumerMetrics.recordConsume(ctx, "orders", "OrderCreatedV1", 10*time.Millisecond, errors.New("failed"))
+	consumerMetrics.addRetry(ctx, "orders", "OrderCreatedV1", delayedRetry)
+	consumerMetrics.addDeadLettered(ctx, "orders", "OrderCreatedV1")
+	consumerMetrics.addInFlight(ctx, "orders", "OrderCreatedV1", 1)
+	consumerMetrics.addInFlight(ctx, "orders", "OrderCreatedV1", 1)
+	consumerMetrics.addInFlight(ctx, "orders", "OrderCreatedV1", -1)
+
+	var collected metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &collected))
+
+	instruments := make(map[string]metricdata.Aggregation)
+	for _, scope := range collected.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			instruments[m.Name] = m.Data
+		}
+	}
+
+	messageType := attribute.String(telemetrytags.App.MessageType, "OrderCreatedV1")
+	queue := attribute.String(queueAttribute, "orders")
+
+	duration := instruments["rabbitmq.consumer.duration"].(metricdata.Histogram[float64])
+	require.Len(t, duration.DataPoints, 2)
+	for _, point := range duration.DataPoints {
+		assert.True(t, point.Attributes.HasValue(messageType.Key))
+		assert.True(t, point.Attributes.HasValue(queue.Key))
+		assert.Equal(t, uint64(1), point.Count)
+	}
+
+	for _, name := range []string{
+		"rabbitmq.consumer.errors_total",
+		"rabbitmq.consumer.retries_total",
+		"rabbitmq.consumer.dead_lettered_total",
+	} {
+		sum := instruments[name].(metricdata.Sum[int64])
+		require.Len(t, sum.DataPoints, 1, name)
+		assert.Equal(t, int64(1), sum.DataPoints[0].Value, name)
+
+		value, ok := sum.DataPoints[0].Attributes.Value(messageType.Key)
+		assert.True(t, ok, name)
+		assert.Equal(t, messageType.Value, value, name)
+	}
+
+	inFlight := instruments["rabbitmq.consumer.in_flight"].(metricdata.Sum[int64])
+	require.Len(t, inFlight.DataPoints, 1)
+	assert.False(t, inFlight.IsMonotonic)
+	assert.Equal(t, int64(1), inFlight.DataPoints[0].Value)
+}
+
+func Test_Nil_Consumer_Metrics_Measure_Nothing(t *testing.T) {
+	consumerMetrics, err := newConsumerMetrics(nil)
+	require.NoError(t, err)
+
+	consumerMetrics.recordConsume(context.Background(), "orders", "OrderCreatedV1", time.Millisecond, nil)
+	consumerMetrics.addRetry(context.Background(), "orders", "OrderCreatedV1", immediateRetry)
+	consumerMetrics.addDeadLettered(context.Background(), "orders", "OrderCreatedV1")
+	consumerMetrics.addInFlight(context.Background(), "orders", "OrderCreatedV1", 1)
+	consumerMetrics.addDrainRequeued(context.Background(), "orders", 1)
+}
@@ -0,0 +1,84 @@
+package consumer
+
+import (
+	"sync"
+
+	"emperror.dev/errors"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+// inFlightDeliveries keeps the deliveries of a consumer which are in handling, a delivery is settled once, by its
+// handler with an ack or a nack, or by the drain of the consumer with a requeue when its handler doesn't finish before
+// the drain timeout
+type inFlightDeliveries struct {
+	mutex      sync.Mutex
+	deliveries map[*inFlightDelivery]struct{}
+}
+
+type inFlightDelivery struct {
+	delivery amqp091.Delivery
+	settled  bool
+}
+
+func newInFlightDeliveries() *inFlightDeliveries {
+	return &inFlightDeliveries{deliveries: make(map[*inFlightDelivery]struct{})}
+}
+
+func (f *inFlightDeliveries) add(delivery amqp091.Delivery) *inFlightDelivery {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	tracked := &inFlightDelivery{delivery: delivery}
+	f.deliveries[tracked] = struct{}{}
+
+	return tracked
+}
+
+func (f *inFlightDeliveries) remove(tracked *inFlightDelivery) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	delete(f.deliveries, tracked)
+}
+
+// claim returns true when the delivery is not settled yet and marks it as settled, the caller owns the ack or the nack
+// of the delivery
+func (f *inFlightDeliveries) claim(tracked *inFlightDelivery) bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if tracked.settled {
+		return false
+	}
+	tracked.settled = true
+
+	return true
+}
+
+// requeueUnsettled requeues the deliveries which are not settled by their handlers, it returns the number of the
+// requeued deliveries
+func (f *inFlightDeliveries) requeueUnsettled() (int, error) {
+	f.mutex.Lock()
+	var unsettled []*inFlightDelivery
+	for tracked := range f.deliveries {
+		if !tracked.settled {
+			tracked.settled = true
+			unsettled = append(unsettled, tracked)
+		}
+	}
+	f.mutex.Unlock()
+
+	var err error
+	for _, tracked := range unsettled {
+		err = errors.Append(err, tracked.delivery.Nack(false, true))
+	}
+
+	return len(unsettled), err
+}
+
+func (f *inFlightDeliveries) count() int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return len(f.deliveries)
+}
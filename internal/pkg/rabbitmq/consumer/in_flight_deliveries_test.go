@@ -0,0 +1,54 @@
+package consumer
+
+import (
+	"testing"
+
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAcknowledger struct {
+	acked    []uint64
+	requeued []uint64
+}
+
+func (f *fakeAcknowledger) Ack(tag uint64, _ bool) error {
+	f.acked = append(f.acked, tag)
+	return nil
+}
+
+func (f *fakeAcknowledger) Nack(tag uint64, _ bool, requeue bool) error {
+	if requeue {
+		f.requeued = append(f.requeued, tag)
+	}
+	return nil
+}
+
+func (f *fakeAcknowledger) Reject(tag uint64, requeue bool) error {
+	return f.Nack(tag, false, requeue)
+}
+
+func Test_InFlightDeliveries_Requeues_Unsettled_Deliveries(t *testing.T) {
+	acknowledger := &fakeAcknowledger{}
+	inFlight := newInFlightDeliveries()
+
+	settled := inFlight.add(amqp091.Delivery{Acknowledger: acknowledger, DeliveryTag: 1})
+	unsettled := inFlight.add(amqp091.Delivery{Acknowledger: acknowledger, DeliveryTag: 2})
+	assert.Equal(t, 2, inFlight.count())
+
+	require.True(t, inFlight.claim(settled))
+	require.NoError(t, settled.delivery.Ack(false))
+
+	requeued, err := inFlight.requeueUnsettled()
+	require.NoError(t, err)
+	assert.Equal(t, 1, requeued)
+	assert.Equal(t, []uint64{2}, acknowledger.requeued)
+
+	// the handler which finishes after the drain doesn't settle its delivery again
+	assert.False(t, inFlight.claim(unsettled))
+
+	inFlight.remove(settled)
+	inFlight.remove(unsettled)
+	assert.Equal(t, 0, inFlight.count())
+}
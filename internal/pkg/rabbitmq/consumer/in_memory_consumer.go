@@ -0,0 +1,209 @@
+package consumer
+
+import (
+	"context"
+	"maps"
+	"reflect"
+	"sync"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/claimcheck"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	consumertracing "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/otel/tracing/consumer"
+	messagingTypes "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/health/contracts"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/metrics"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/consumer/configurations"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/inmemory"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/types"
+	errorutils "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils/errorutils"
+
+	"emperror.dev/errors"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// inMemoryConsumer consumes the deliveries of the in-memory broker, the handlers run with the same pipelines and
+// immediate retries of the rabbitmq consumer. a delivery which is failed after the retries is dropped, there is no
+// requeue and no delayed retry.
+type inMemoryConsumer struct {
+	*rabbitMQConsumer
+	broker          *inmemory.Broker
+	deliveryWorkers *workerPool[inmemory.Delivery]
+	cancel          context.CancelFunc
+	waitGroup       sync.WaitGroup
+}
+
+// NewInMemoryConsumer create a new generic consumer on the in-memory broker
+func NewInMemoryConsumer(
+	rabbitmqOptions *config.RabbitmqOptions,
+	consumerConfiguration *configurations.RabbitMQConsumerConfiguration,
+	messageSerializer serializer.MessageSerializer,
+	logger logger.Logger,
+	claimCheck claimcheck.ClaimCheck,
+	appMetrics metrics.AppMetrics,
+	dependencyMonitor contracts.DependencyMonitor,
+	broker *inmemory.Broker,
+	isConsumedNotifications ...func(message messagingTypes.IMessage),
+) (consumer.Consumer, error) {
+	rabbitmqConsumer, err := NewRabbitMQConsumer(
+		rabbitmqOptions,
+		types.NewInMemoryConnection(),
+		consumerConfiguration,
+		messageSerializer,
+		logger,
+		claimCheck,
+		appMetrics,
+		dependencyMonitor,
+		nil,
+		isConsumedNotifications...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &inMemoryConsumer{
+		rabbitMQConsumer: rabbitmqConsumer.(*rabbitMQConsumer),
+		broker:           broker,
+	}, nil
+}
+
+func (r *inMemoryConsumer) Start(ctx context.Context) error {
+	exchange, _, queue := r.topology()
+
+	var deliveries <-chan inmemory.Delivery
+	for _, bindingKey := range r.rabbitmqConsumerOptions.BindingKeys() {
+		deliveries = r.broker.Bind(exchange, bindingKey, queue)
+	}
+
+	ctx, r.cancel = context.WithCancel(ctx)
+
+	workers := newWorkerPool(r.rabbitmqConsumerOptions.ConcurrencyLimit, func(delivery inmemory.Delivery) {
+		r.handleDelivery(ctx, queue, delivery)
+	})
+	r.deliveryWorkers = workers
+
+	r.waitGroup.Add(1)
+	go func() {
+		defer r.waitGroup.Done()
+		defer errorutils.HandlePanic()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case delivery := <-deliveries:
+				if !r.waitForDependencies(ctx) {
+					return
+				}
+
+				if !workers.Dispatch(ctx, delivery, r.orderingKey(delivery.Headers)) {
+					return
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop waits for the deliveries in handling, the remaining deliveries stay in the queue of the broker
+func (r *inMemoryConsumer) Stop() error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+
+	r.waitGroup.Wait()
+
+	if r.deliveryWorkers != nil {
+		r.deliveryWorkers.Stop()
+	}
+
+	return nil
+}
+
+func (r *inMemoryConsumer) handleDelivery(ctx context.Context, queue string, delivery inmemory.Delivery) {
+	// each queue receives the same delivery, so the pipelines shouldn't change the headers of the other queues
+	meta := metadata.MapToMetadata(maps.Clone(delivery.Headers))
+	if meta == nil {
+		meta = metadata.Metadata{}
+	}
+
+	consumerTraceOption := &consumertracing.ConsumerTracingOptions{
+		MessagingSystem: "rabbitmq",
+		DestinationKind: "queue",
+		Destination:     queue,
+		OtherAttributes: []attribute.KeyValue{
+			semconv.MessagingRabbitmqDestinationRoutingKey(delivery.RoutingKey),
+		},
+	}
+	ctx, beforeConsumeSpan := consumertracing.StartConsumerSpan(
+		ctx,
+		&meta,
+		string(delivery.Body),
+		consumerTraceOption,
+	)
+
+	message, err := r.deserializeDelivery(delivery)
+	if err != nil {
+		r.logger.Error(consumertracing.FinishConsumerSpan(beforeConsumeSpan, err))
+		return
+	}
+
+	consumeContext := messagingTypes.NewMessageConsumeContext(
+		message,
+		meta,
+		delivery.ContentType,
+		delivery.Type,
+		delivery.Timestamp,
+		0,
+		delivery.MessageId,
+		delivery.CorrelationId,
+	)
+
+	if err := r.runHandlers(ctx, consumeContext); err != nil {
+		r.logger.Errorw(
+			"[inMemoryConsumer.handleDelivery] error in handling consume message of the in-memory broker, the message is dropped",
+			logger.Fields{"message_id": consumeContext.MessageId(), "error": err.Error()},
+		)
+		_ = consumertracing.FinishConsumerSpan(beforeConsumeSpan, err)
+
+		return
+	}
+
+	_ = consumertracing.FinishConsumerSpan(beforeConsumeSpan, nil)
+
+	for _, notification := range r.isConsumedNotifications {
+		if notification != nil {
+			notification(consumeContext.Message())
+		}
+	}
+}
+
+// deserializeDelivery deserializes the body to the consumer message type, the apps in one process could have the
+// message types with the same name in their packages, so the message type of the delivery isn't resolved by name.
+func (r *inMemoryConsumer) deserializeDelivery(delivery inmemory.Delivery) (messagingTypes.IMessage, error) {
+	if len(delivery.Body) == 0 {
+		return nil, errors.New("message body is nil or empty in the consumer")
+	}
+
+	messageType := r.rabbitmqConsumerOptions.ConsumerMessageType
+	if messageType.Kind() == reflect.Pointer {
+		messageType = messageType.Elem()
+	}
+
+	messagePointer := reflect.New(messageType).Interface()
+	if err := r.messageSerializer.DeserializeInto(delivery.Body, messagePointer, delivery.ContentType); err != nil {
+		return nil, errors.WrapIff(err, "error in deserializing of type '%s' in the consumer", delivery.Type)
+	}
+
+	message, ok := messagePointer.(messagingTypes.IMessage)
+	if !ok {
+		return nil, errors.Errorf("type '%s' doesn't implement IMessage", messageType)
+	}
+
+	return message, nil
+}
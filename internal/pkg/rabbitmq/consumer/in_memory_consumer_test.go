@@ -0,0 +1,321 @@
+package consumer
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	messageConsumer "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	types3 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer/json"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/health/contracts"
+	defaultLogger2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/bus"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/config"
+	rabbitmqConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/configurations"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/consumer/configurations"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/producer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/test/messaging/consumer"
+	testUtils "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/test/utils"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_In_Memory_Consumer_With_Fake_Message(t *testing.T) {
+	ctx := context.Background()
+
+	fakeHandler := consumer.NewRabbitMQFakeTestConsumerHandler[*ProducerConsumerMessage]()
+	rabbitmqBus := newInMemoryTestBus(t, fakeHandler)
+
+	require.NoError(t, rabbitmqBus.Start(ctx))
+	defer rabbitmqBus.Stop()
+
+	err := rabbitmqBus.PublishMessage(ctx, NewProducerConsumerMessage("test"), nil)
+	require.NoError(t, err)
+
+	err = testUtils.WaitUntilConditionMet(func() bool {
+		return fakeHandler.IsHandled()
+	})
+	require.NoError(t, err)
+}
+
+func Test_In_Memory_Consumer_With_Delayed_Message(t *testing.T) {
+	ctx := context.Background()
+
+	fakeHandler := consumer.NewRabbitMQFakeTestConsumerHandler[*ProducerConsumerMessage]()
+	rabbitmqBus := newInMemoryTestBus(t, fakeHandler)
+
+	require.NoError(t, rabbitmqBus.Start(ctx))
+	defer rabbitmqBus.Stop()
+
+	publishedAt := time.Now()
+	delay := 500 * time.Millisecond
+
+	err := rabbitmqBus.PublishMessageWithDelay(ctx, NewProducerConsumerMessage("test"), nil, delay)
+	require.NoError(t, err)
+
+	err = testUtils.WaitUntilConditionMet(func() bool {
+		return fakeHandler.IsHandled()
+	})
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(publishedAt), delay)
+}
+
+func Test_In_Memory_Consumer_With_Published_Batch(t *testing.T) {
+	ctx := context.Background()
+
+	handler := &countingHandler{}
+	rabbitmqBus := newInMemoryTestBus(t, handler)
+
+	require.NoError(t, rabbitmqBus.Start(ctx))
+	defer rabbitmqBus.Stop()
+
+	messages := make([]types3.IMessage, 0, 10)
+	for i := 0; i < 10; i++ {
+		messages = append(messages, NewProducerConsumerMessage("test"))
+	}
+
+	err := rabbitmqBus.PublishMessages(ctx, messages)
+	require.NoError(t, err)
+
+	err = testUtils.WaitUntilConditionMet(func() bool {
+		return handler.handled.Load() == 10
+	})
+	require.NoError(t, err)
+}
+
+func Test_In_Memory_Consumer_With_Immediate_Retries_Of_Retry_Policy(t *testing.T) {
+	ctx := context.Background()
+
+	handler := &failingHandler{}
+	rabbitmqBus := newInMemoryTestBus(
+		t,
+		handler,
+		func(consumerBuilder configurations.RabbitMQConsumerConfigurationBuilder) {
+			consumerBuilder.WithRetryPolicy(1, 2, time.Second, time.Minute)
+		},
+	)
+
+	require.NoError(t, rabbitmqBus.Start(ctx))
+	defer rabbitmqBus.Stop()
+
+	err := rabbitmqBus.PublishMessage(ctx, NewProducerConsumerMessage("test"), nil)
+	require.NoError(t, err)
+
+	// the delayed retries need the retry queues of the broker, so the in-memory consumer only runs the immediate retries
+	err = testUtils.WaitUntilConditionMet(func() bool {
+		return handler.attempts.Load() == 2
+	})
+	require.NoError(t, err)
+
+	time.Sleep(time.Second)
+	assert.Equal(t, int32(2), handler.attempts.Load())
+}
+
+func Test_In_Memory_Consumer_Pauses_While_Dependencies_Are_Unhealthy(t *testing.T) {
+	ctx := context.Background()
+
+	handler := &countingHandler{}
+	monitor := &fakeDependencyMonitor{}
+	rabbitmqBus := newInMemoryTestBusWithMonitor(
+		t,
+		handler,
+		monitor,
+		func(consumerBuilder configurations.RabbitMQConsumerConfigurationBuilder) {
+			consumerBuilder.WithHealthDependencies("mongodb")
+		},
+	)
+
+	require.NoError(t, rabbitmqBus.Start(ctx))
+	defer rabbitmqBus.Stop()
+
+	err := rabbitmqBus.PublishMessage(ctx, NewProducerConsumerMessage("test"), nil)
+	require.NoError(t, err)
+
+	time.Sleep(300 * time.Millisecond)
+	assert.Equal(t, int32(0), handler.handled.Load())
+
+	monitor.healthy.Store(true)
+
+	err = testUtils.WaitUntilConditionMet(func() bool {
+		return handler.handled.Load() == 1
+	})
+	require.NoError(t, err)
+}
+
+func Test_In_Memory_Consumer_In_Broadcast_Mode_Delivers_To_Every_Instance(t *testing.T) {
+	ctx := context.Background()
+
+	// the buses of the instances share the process-wide in-memory broker
+	handlers := []*countingHandler{{}, {}}
+	buses := make([]bus.RabbitmqBus, 0, len(handlers))
+	for _, handler := range handlers {
+		rabbitmqBus := newInMemoryTestBus(
+			t,
+			handler,
+			func(consumerBuilder configurations.RabbitMQConsumerConfigurationBuilder) {
+				consumerBuilder.
+					WithConsumptionMode(configurations.ConsumptionModeBroadcast).
+					WithInstanceId(uuid.NewV4().String())
+			},
+		)
+
+		require.NoError(t, rabbitmqBus.Start(ctx))
+		defer rabbitmqBus.Stop()
+
+		buses = append(buses, rabbitmqBus)
+	}
+
+	err := buses[0].PublishMessage(ctx, NewProducerConsumerMessage("test"), nil)
+	require.NoError(t, err)
+
+	err = testUtils.WaitUntilConditionMet(func() bool {
+		return handlers[0].handled.Load() == 1 && handlers[1].handled.Load() == 1
+	})
+	require.NoError(t, err)
+}
+
+func Test_In_Memory_Consumer_With_Wildcard_Binding_Keys(t *testing.T) {
+	ctx := context.Background()
+
+	handler := &countingHandler{}
+	rabbitmqBus := newInMemoryTestBus(
+		t,
+		handler,
+		func(consumerBuilder configurations.RabbitMQConsumerConfigurationBuilder) {
+			consumerBuilder.
+				WithQueueName("producer_consumer_message_wildcard").
+				WithBindingKeys("producer_consumer_message.#")
+		},
+	)
+
+	require.NoError(t, rabbitmqBus.Start(ctx))
+	defer rabbitmqBus.Stop()
+
+	err := rabbitmqBus.PublishMessage(ctx, NewProducerConsumerMessage("test"), nil)
+	require.NoError(t, err)
+
+	err = testUtils.WaitUntilConditionMet(func() bool {
+		return handler.handled.Load() == 1
+	})
+	require.NoError(t, err)
+}
+
+type fakeDependencyMonitor struct {
+	healthy atomic.Bool
+}
+
+func (m *fakeDependencyMonitor) Unhealthy(_ context.Context, dependencies []string) []string {
+	if m.healthy.Load() {
+		return nil
+	}
+
+	return dependencies
+}
+
+func (m *fakeDependencyMonitor) WaitHealthy(ctx context.Context, _ []string) error {
+	for !m.healthy.Load() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	return nil
+}
+
+type countingHandler struct {
+	handled atomic.Int32
+}
+
+func (h *countingHandler) Handle(_ context.Context, _ types3.MessageConsumeContext) error {
+	h.handled.Add(1)
+
+	return nil
+}
+
+type failingHandler struct {
+	attempts atomic.Int32
+}
+
+func (h *failingHandler) Handle(_ context.Context, _ types3.MessageConsumeContext) error {
+	h.attempts.Add(1)
+
+	return errors.New("handler failed")
+}
+
+func newInMemoryTestBus(
+	t *testing.T,
+	handler messageConsumer.ConsumerHandler,
+	consumerBuilderFuncs ...func(consumerBuilder configurations.RabbitMQConsumerConfigurationBuilder),
+) bus.RabbitmqBus {
+	t.Helper()
+
+	return newInMemoryTestBusWithMonitor(t, handler, nil, consumerBuilderFuncs...)
+}
+
+func newInMemoryTestBusWithMonitor(
+	t *testing.T,
+	handler messageConsumer.ConsumerHandler,
+	dependencyMonitor contracts.DependencyMonitor,
+	consumerBuilderFuncs ...func(consumerBuilder configurations.RabbitMQConsumerConfigurationBuilder),
+) bus.RabbitmqBus {
+	t.Helper()
+
+	options := &config.RabbitmqOptions{UseInMemory: true}
+
+	conn, err := types.NewRabbitMQConnection(options)
+	require.NoError(t, err)
+	require.True(t, conn.IsConnected())
+
+	eventSerializer := json.NewDefaultMessageJsonSerializer(
+		json.NewDefaultJsonSerializer(),
+	)
+	consumerFactory := NewConsumerFactory(
+		options,
+		conn,
+		eventSerializer,
+		defaultLogger2.GetLogger(),
+		nil,
+		nil,
+		dependencyMonitor,
+		nil,
+	)
+	producerFactory := producer.NewProducerFactory(
+		options,
+		conn,
+		eventSerializer,
+		defaultLogger2.GetLogger(),
+		nil,
+	)
+
+	rabbitmqBus, err := bus.NewRabbitmqBus(
+		defaultLogger2.GetLogger(),
+		consumerFactory,
+		producerFactory,
+		func(builder rabbitmqConfigurations.RabbitMQConfigurationBuilder) {
+			builder.AddConsumer(
+				ProducerConsumerMessage{},
+				func(consumerBuilder configurations.RabbitMQConsumerConfigurationBuilder) {
+					consumerBuilder.WithHandlers(
+						func(consumerHandlerBuilder messageConsumer.ConsumerHandlerConfigurationBuilder) {
+							consumerHandlerBuilder.AddHandler(handler)
+						},
+					)
+					for _, consumerBuilderFunc := range consumerBuilderFuncs {
+						consumerBuilderFunc(consumerBuilder)
+					}
+				},
+			)
+		},
+	)
+	require.NoError(t, err)
+
+	return rabbitmqBus
+}
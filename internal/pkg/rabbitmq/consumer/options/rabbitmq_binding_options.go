@@ -1,6 +1,32 @@
 package options
 
+import (
+	"strings"
+
+	"emperror.dev/errors"
+)
+
 type RabbitMQBindingOptions struct {
 	RoutingKey string
-	Args       map[string]any
+	// BindingKeys bind the queue of the consumer to its topic exchange with more than one key, they support the `*`
+	// wildcard for exactly one word and the `#` wildcard for zero or more words, e.g. `catalogs.product.*`. they are used
+	// instead of the RoutingKey when they are configured.
+	BindingKeys []string
+	Args        map[string]any
+}
+
+// ValidateBindingKey checks that the wildcards of a binding key are whole words, rabbitmq matches `catalogs.product*`
+// only with the literal word and not as a wildcard
+func ValidateBindingKey(bindingKey string) error {
+	if bindingKey == "" {
+		return errors.New("binding key is empty")
+	}
+
+	for _, word := range strings.Split(bindingKey, ".") {
+		if word != "*" && word != "#" && strings.ContainsAny(word, "*#") {
+			return errors.Errorf("wildcard of the binding key `%s` should be a whole word", bindingKey)
+		}
+	}
+
+	return nil
 }
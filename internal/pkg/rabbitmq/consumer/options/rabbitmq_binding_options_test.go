@@ -0,0 +1,18 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Validate_Binding_Key(t *testing.T) {
+	assert.NoError(t, ValidateBindingKey("catalogs.product.created"))
+	assert.NoError(t, ValidateBindingKey("catalogs.product.*"))
+	assert.NoError(t, ValidateBindingKey("catalogs.#"))
+	assert.NoError(t, ValidateBindingKey("#"))
+
+	assert.Error(t, ValidateBindingKey(""))
+	assert.Error(t, ValidateBindingKey("catalogs.product*"))
+	assert.Error(t, ValidateBindingKey("catalogs.#.created#"))
+}
@@ -0,0 +1,58 @@
+package options
+
+import "time"
+
+// DeadLetterRetryCountHeader keeps the number of times a failed message is requeued by the consumer
+const DeadLetterRetryCountHeader = "x-retry-count"
+
+// RabbitMQDeadLetterOptions routes the messages which still fail after `MaxRetries` requeues to a dead-letter queue,
+// through a dead-letter exchange of the consumer queue.
+type RabbitMQDeadLetterOptions struct {
+	// ExchangeName is the dead-letter exchange, it defaults to `<queue>.dlx`
+	ExchangeName string
+	// QueueName is the dead-letter queue, it defaults to `<queue>.dlq`
+	QueueName string
+	// MaxRetries is the number of times a failed message is requeued before it is dead-lettered
+	MaxRetries int
+	// MessageTTL is how long a message is kept in the dead-letter queue, zero keeps it until it is requeued or purged
+	MessageTTL time.Duration
+}
+
+// Names returns the dead-letter exchange and the dead-letter queue of the consumer queue
+func (o *RabbitMQDeadLetterOptions) Names(queue string) (exchange string, deadLetterQueue string) {
+	exchange = o.ExchangeName
+	if exchange == "" {
+		exchange = queue + ".dlx"
+	}
+
+	deadLetterQueue = o.QueueName
+	if deadLetterQueue == "" {
+		deadLetterQueue = queue + ".dlq"
+	}
+
+	return exchange, deadLetterQueue
+}
+
+// QueueArgs returns the args of the dead-letter queue
+func (o *RabbitMQDeadLetterOptions) QueueArgs() map[string]any {
+	if o.MessageTTL <= 0 {
+		return nil
+	}
+
+	return map[string]any{"x-message-ttl": o.MessageTTL.Milliseconds()}
+}
+
+// ConsumerQueueArgs returns the args of the consumer queue which route its rejected messages to the dead-letter queue
+func (o *RabbitMQDeadLetterOptions) ConsumerQueueArgs(queue string, args map[string]any) map[string]any {
+	exchange, deadLetterQueue := o.Names(queue)
+
+	// the args of the configuration are copied, because the consumer declares its queue again after each reconnect
+	queueArgs := make(map[string]any, len(args)+2)
+	for key, value := range args {
+		queueArgs[key] = value
+	}
+	queueArgs["x-dead-letter-exchange"] = exchange
+	queueArgs["x-dead-letter-routing-key"] = deadLetterQueue
+
+	return queueArgs
+}
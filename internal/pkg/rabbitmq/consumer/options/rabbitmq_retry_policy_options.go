@@ -0,0 +1,70 @@
+package options
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RabbitMQRetryPolicyOptions retries a failed message in three steps, first `ImmediateRetries` times in the consumer,
+// then `DelayedRetries` times through the retry queues of the consumer with an exponential backoff, and at last it is
+// moved to the dead-letter queue of the consumer.
+type RabbitMQRetryPolicyOptions struct {
+	// ImmediateRetries is the number of times the handlers run again in the consumer before the delayed retries
+	ImmediateRetries int
+	// DelayedRetries is the number of times a failed message is published to a retry queue of the consumer
+	DelayedRetries int
+	// InitialDelay is the delay of the first delayed retry
+	InitialDelay time.Duration
+	// MaxDelay caps the delay of the delayed retries, zero doesn't cap it
+	MaxDelay time.Duration
+	// Multiplier is the growth of the delay on each delayed retry
+	Multiplier float64
+	// Jitter is the fraction of the delay which is randomly reduced, so the failed messages don't retry all together
+	Jitter float64
+}
+
+// BaseDelay returns the delay of a delayed retry without the jitter, retry starts from 1
+func (o *RabbitMQRetryPolicyOptions) BaseDelay(retry int) time.Duration {
+	multiplier := o.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	delay := float64(o.InitialDelay) * math.Pow(multiplier, float64(retry-1))
+	if o.MaxDelay > 0 && delay > float64(o.MaxDelay) {
+		return o.MaxDelay
+	}
+
+	return time.Duration(delay)
+}
+
+// Delay returns the delay of a delayed retry with the jitter, the jitter only reduces the delay, so the messages of a
+// retry queue expire close to their publishing order
+func (o *RabbitMQRetryPolicyOptions) Delay(retry int) time.Duration {
+	delay := o.BaseDelay(retry)
+
+	jitter := math.Min(math.Max(o.Jitter, 0), 1)
+	if jitter == 0 {
+		return delay
+	}
+
+	return delay - time.Duration(rand.Float64()*jitter*float64(delay)) //nolint:gosec
+}
+
+// RetryQueueName returns the retry queue of a delayed retry of the consumer queue, each retry has its own queue because
+// the messages of a queue expire in their publishing order
+func (o *RabbitMQRetryPolicyOptions) RetryQueueName(queue string, retry int) string {
+	return fmt.Sprintf("%s.retry.%d", queue, retry)
+}
+
+// RetryQueueArgs returns the args of the retry queue of a delayed retry, the expired messages are routed back to the
+// consumer queue through the default exchange, so the other queues of the consumer exchange don't receive them again
+func (o *RabbitMQRetryPolicyOptions) RetryQueueArgs(queue string, retry int) map[string]any {
+	return map[string]any{
+		"x-dead-letter-exchange":    "",
+		"x-dead-letter-routing-key": queue,
+		"x-message-ttl":             o.BaseDelay(retry).Milliseconds(),
+	}
+}
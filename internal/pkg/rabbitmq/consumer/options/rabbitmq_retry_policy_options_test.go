@@ -0,0 +1,48 @@
+package options
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Retry_Policy_Delay_With_Exponential_Backoff(t *testing.T) {
+	retryPolicy := &RabbitMQRetryPolicyOptions{
+		DelayedRetries: 5,
+		InitialDelay:   time.Second,
+		MaxDelay:       5 * time.Second,
+		Multiplier:     2,
+	}
+
+	assert.Equal(t, time.Second, retryPolicy.Delay(1))
+	assert.Equal(t, 2*time.Second, retryPolicy.Delay(2))
+	assert.Equal(t, 4*time.Second, retryPolicy.Delay(3))
+	assert.Equal(t, 5*time.Second, retryPolicy.Delay(4))
+}
+
+func Test_Retry_Policy_Delay_With_Jitter(t *testing.T) {
+	retryPolicy := &RabbitMQRetryPolicyOptions{
+		DelayedRetries: 3,
+		InitialDelay:   time.Second,
+		Multiplier:     2,
+		Jitter:         0.5,
+	}
+
+	for i := 0; i < 100; i++ {
+		delay := retryPolicy.Delay(2)
+		assert.LessOrEqual(t, delay, 2*time.Second)
+		assert.GreaterOrEqual(t, delay, time.Second)
+	}
+}
+
+func Test_Retry_Queue_Args(t *testing.T) {
+	retryPolicy := &RabbitMQRetryPolicyOptions{InitialDelay: time.Second, Multiplier: 3}
+
+	assert.Equal(t, "orders.retry.2", retryPolicy.RetryQueueName("orders", 2))
+	assert.Equal(t, map[string]any{
+		"x-dead-letter-exchange":    "",
+		"x-dead-letter-routing-key": "orders",
+		"x-message-ttl":             int64(3000),
+	}, retryPolicy.RetryQueueArgs("orders", 2))
+}
@@ -0,0 +1,80 @@
+package consumer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/quarantine"
+	defaultLogger2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/consumer/configurations"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/consumer/options"
+
+	"emperror.dev/errors"
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newQuarantineTestConsumer(t *testing.T, store quarantine.QuarantineStore) *rabbitMQConsumer {
+	t.Helper()
+
+	cons, err := NewRabbitMQConsumer(
+		nil,
+		nil,
+		configurations.NewRabbitMQConsumerConfigurationBuilder(NewProducerConsumerMessage("test")).
+			WithDeadLetter(3, time.Hour).
+			Build(),
+		nil,
+		defaultLogger2.GetLogger(),
+		nil,
+		nil,
+		nil,
+		store,
+	)
+	require.NoError(t, err)
+
+	return cons.(*rabbitMQConsumer)
+}
+
+func Test_Quarantine_Stores_Message_With_Error_History(t *testing.T) {
+	ctx := context.Background()
+	store := quarantine.NewInMemoryQuarantineStore()
+	cons := newQuarantineTestConsumer(t, store)
+
+	headers := amqp091.Table{options.DeadLetterRetryCountHeader: int32(3)}
+	quarantine.AppendErrorHistory(headers, quarantine.NewQuarantineError(3, errors.New("timeout")))
+
+	quarantined := cons.quarantine(ctx, amqp091.Delivery{
+		MessageId:   "message-1",
+		Type:        "producer_consumer_message",
+		ContentType: "application/json",
+		Headers:     headers,
+		Body:        []byte(`{"data":"test"}`),
+	}, 3, errors.New("product not found"))
+	require.True(t, quarantined)
+
+	messages, err := store.List(ctx, "", 10)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+
+	message := messages[0]
+	_, _, queue := cons.topology()
+	assert.Equal(t, "message-1", message.MessageId)
+	assert.Equal(t, queue, message.Queue)
+	assert.Equal(t, 3, message.RetryCount)
+	assert.Equal(t, []byte(`{"data":"test"}`), message.Body)
+	assert.NotContains(t, message.Headers, quarantine.ErrorHistoryHeader)
+	require.Len(t, message.Errors, 2)
+	assert.Equal(t, "timeout", message.Errors[0].Error)
+	assert.Equal(t, 4, message.Errors[1].Attempt)
+	assert.Equal(t, "product not found", message.Errors[1].Error)
+}
+
+func Test_Quarantine_Without_Store(t *testing.T) {
+	cons := newQuarantineTestConsumer(t, nil)
+
+	quarantined := cons.quarantine(context.Background(), amqp091.Delivery{MessageId: "message-1"}, 3, errors.New("failed"))
+
+	assert.False(t, quarantined)
+}
@@ -4,18 +4,25 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strconv"
+	"sync"
 	"time"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/claimcheck"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
 	consumertracing "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/otel/tracing/consumer"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/pipeline"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/quarantine"
 	messagingTypes "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
-	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/utils"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/tenant"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/health/contracts"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/metrics"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/config"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/consumer/configurations"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/consumer/options"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/rabbitmqErrors"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/types"
 	errorutils "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils/errorutils"
@@ -24,6 +31,7 @@ import (
 	"github.com/ahmetb/go-linq/v3"
 	"github.com/avast/retry-go"
 	"github.com/rabbitmq/amqp091-go"
+	uuid "github.com/satori/go.uuid"
 	"go.opentelemetry.io/otel/attribute"
 	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 )
@@ -44,7 +52,8 @@ type rabbitMQConsumer struct {
 	connection              types.IConnection
 	handlerDefault          consumer.ConsumerHandler
 	channel                 *amqp091.Channel
-	deliveryRoutines        chan struct{} // chan should init before using channel
+	channelMutex            sync.Mutex
+	workers                 *workerPool[amqp091.Delivery]
 	messageSerializer       serializer.MessageSerializer
 	logger                  logger.Logger
 	rabbitmqOptions         *config.RabbitmqOptions
@@ -52,6 +61,11 @@ type rabbitMQConsumer struct {
 	handlers                []consumer.ConsumerHandler
 	pipelines               []pipeline.ConsumerPipeline
 	isConsumedNotifications []func(message messagingTypes.IMessage)
+	consumerMetrics         *consumerMetrics
+	dependencyMonitor       contracts.DependencyMonitor
+	inFlight                *inFlightDeliveries
+	cancelHandlers          context.CancelFunc
+	quarantineStore         quarantine.QuarantineStore
 }
 
 // NewRabbitMQConsumer create a new generic RabbitMQ consumer
@@ -61,6 +75,10 @@ func NewRabbitMQConsumer(
 	consumerConfiguration *configurations.RabbitMQConsumerConfiguration,
 	messageSerializer serializer.MessageSerializer,
 	logger logger.Logger,
+	claimCheck claimcheck.ClaimCheck,
+	appMetrics metrics.AppMetrics,
+	dependencyMonitor contracts.DependencyMonitor,
+	quarantineStore quarantine.QuarantineStore,
 	isConsumedNotifications ...func(message messagingTypes.IMessage),
 ) (consumer.Consumer, error) {
 	if consumerConfiguration == nil {
@@ -73,20 +91,43 @@ func NewRabbitMQConsumer(
 		)
 	}
 
-	deliveryRoutines := make(
-		chan struct{},
-		consumerConfiguration.ConcurrencyLimit,
-	)
+	for _, bindingKey := range consumerConfiguration.BindingOptions.BindingKeys {
+		if err := options.ValidateBindingKey(bindingKey); err != nil {
+			return nil, err
+		}
+	}
+
+	consumerMetrics, err := newConsumerMetrics(appMetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	if rabbitmqOptions != nil {
+		applyConsumerOptions(consumerConfiguration, rabbitmqOptions.ConsumerOptions(consumerConfiguration.Name))
+	}
+
+	pipelines := consumerConfiguration.Pipelines
+	if claimCheck != nil {
+		// the claim-check references are resolved before the other pipelines, so all of them receive the actual message
+		pipelines = append(
+			[]pipeline.ConsumerPipeline{claimcheck.NewClaimCheckPipeline(claimCheck, messageSerializer)},
+			pipelines...,
+		)
+	}
+
 	cons := &rabbitMQConsumer{
 		messageSerializer:       messageSerializer,
 		rabbitmqOptions:         rabbitmqOptions,
 		logger:                  logger,
 		rabbitmqConsumerOptions: consumerConfiguration,
-		deliveryRoutines:        deliveryRoutines,
 		ErrChan:                 make(chan error),
 		connection:              connection,
 		handlers:                consumerConfiguration.Handlers,
-		pipelines:               consumerConfiguration.Pipelines,
+		pipelines:               pipelines,
+		consumerMetrics:         consumerMetrics,
+		dependencyMonitor:       dependencyMonitor,
+		inFlight:                newInFlightDeliveries(),
+		quarantineStore:         quarantineStore,
 	}
 
 	cons.isConsumedNotifications = isConsumedNotifications
@@ -104,44 +145,43 @@ func (r *rabbitMQConsumer) Start(ctx context.Context) error {
 		return errors.New("connection is nil")
 	}
 
-	var exchange string
-	var queue string
-	var routingKey string
+	// the handlers are canceled when they don't finish in the drain timeout of the shutdown
+	handlersCtx, cancelHandlers := context.WithCancel(ctx)
+	r.cancelHandlers = cancelHandlers
 
-	if r.rabbitmqConsumerOptions.ExchangeOptions.Name != "" {
-		exchange = r.rabbitmqConsumerOptions.ExchangeOptions.Name
-	} else {
-		exchange = utils.GetTopicOrExchangeNameFromType(r.rabbitmqConsumerOptions.ConsumerMessageType)
-	}
+	// the workers are kept on a reconnect, the deliveries of the new channel are dispatched to them
+	r.workers = newWorkerPool(r.rabbitmqConsumerOptions.ConcurrencyLimit, func(delivery amqp091.Delivery) {
+		r.handleReceived(handlersCtx, delivery)
+	})
 
-	if r.rabbitmqConsumerOptions.BindingOptions.RoutingKey != "" {
-		routingKey = r.rabbitmqConsumerOptions.BindingOptions.RoutingKey
-	} else {
-		routingKey = utils.GetRoutingKeyFromType(r.rabbitmqConsumerOptions.ConsumerMessageType)
+	if r.rabbitmqOptions.Reconnecting {
+		r.reConsumeOnReconnect(ctx)
 	}
 
-	if r.rabbitmqConsumerOptions.QueueOptions.Name != "" {
-		queue = r.rabbitmqConsumerOptions.QueueOptions.Name
-	} else {
-		queue = utils.GetQueueNameFromType(r.rabbitmqConsumerOptions.ConsumerMessageType)
-	}
+	return r.consume(ctx)
+}
 
-	r.reConsumeOnDropConnection(ctx)
+// consume declares the consumer topology on a new channel and starts consuming, it runs again after each reconnect
+// because exchanges and queues on the new broker node might not exist (e.g. non-durable ones or a fresh node).
+func (r *rabbitMQConsumer) consume(ctx context.Context) error {
+	exchange, _, queue := r.topology()
 
 	// get a new channel on the connection - channel is unique for each consumer
 	ch, err := r.connection.Channel()
 	if err != nil {
 		return rabbitmqErrors.ErrDisconnected
 	}
+
+	r.channelMutex.Lock()
 	r.channel = ch
+	r.channelMutex.Unlock()
 
-	// The prefetch count tells the Rabbit connection how many messages to retrieve from the server per request.
-	prefetchCount := r.rabbitmqConsumerOptions.ConcurrencyLimit * r.rabbitmqConsumerOptions.PrefetchCount
-	if err := r.channel.Qos(prefetchCount, 0, false); err != nil {
+	// The prefetch count tells the Rabbit connection how many unacknowledged messages the consumer can have.
+	if err := ch.Qos(r.rabbitmqConsumerOptions.Prefetch(), 0, false); err != nil {
 		return err
 	}
 
-	err = r.channel.ExchangeDeclare(
+	err = ch.ExchangeDeclare(
 		exchange,
 		string(r.rabbitmqConsumerOptions.ExchangeOptions.Type),
 		r.rabbitmqConsumerOptions.ExchangeOptions.Durable,
@@ -153,28 +193,45 @@ func (r *rabbitMQConsumer) Start(ctx context.Context) error {
 		return err
 	}
 
-	_, err = r.channel.QueueDeclare(
+	if retryPolicy := r.rabbitmqConsumerOptions.RetryPolicy; retryPolicy != nil {
+		if err := r.declareRetryQueues(ch, queue, retryPolicy); err != nil {
+			return err
+		}
+	}
+
+	queueArgs := r.rabbitmqConsumerOptions.QueueOptions.Args
+	if deadLetterOptions := r.rabbitmqConsumerOptions.DeadLetterOptions; deadLetterOptions != nil {
+		// the dead-letter queue should exist before the consumer queue rejects any message to it
+		if err := r.declareDeadLetter(ch, queue, deadLetterOptions); err != nil {
+			return err
+		}
+		queueArgs = deadLetterOptions.ConsumerQueueArgs(queue, queueArgs)
+	}
+
+	_, err = ch.QueueDeclare(
 		queue,
 		r.rabbitmqConsumerOptions.QueueOptions.Durable,
 		r.rabbitmqConsumerOptions.QueueOptions.AutoDelete,
 		r.rabbitmqConsumerOptions.QueueOptions.Exclusive,
 		r.rabbitmqConsumerOptions.NoWait,
-		r.rabbitmqConsumerOptions.QueueOptions.Args)
+		queueArgs)
 	if err != nil {
 		return err
 	}
 
-	err = r.channel.QueueBind(
-		queue,
-		routingKey,
-		exchange,
-		r.rabbitmqConsumerOptions.NoWait,
-		r.rabbitmqConsumerOptions.BindingOptions.Args)
-	if err != nil {
-		return err
+	for _, bindingKey := range r.rabbitmqConsumerOptions.BindingKeys() {
+		err = ch.QueueBind(
+			queue,
+			bindingKey,
+			exchange,
+			r.rabbitmqConsumerOptions.NoWait,
+			r.rabbitmqConsumerOptions.BindingOptions.Args)
+		if err != nil {
+			return err
+		}
 	}
 
-	msgs, err := r.channel.Consume(
+	msgs, err := ch.Consume(
 		queue,
 		r.rabbitmqConsumerOptions.ConsumerId,
 		r.rabbitmqConsumerOptions.AutoAck, // When autoAck (also known as noAck) is true, the server will acknowledge deliveries to this consumer prior to writing the delivery to the network. When autoAck is true, the consumer should not call Delivery.Ack.
@@ -198,58 +255,242 @@ func (r *rabbitMQConsumer) Start(ctx context.Context) error {
 	// https://www.ribice.ba/golang-rabbitmq-client/
 	// https://medium.com/@dhanushgopinath/automatically-recovering-rabbitmq-connections-in-go-applications-7795a605ca59
 	// https://github.com/rabbitmq/amqp091-go/blob/main/_examples/pubsub/pubsub.go
-	for i := 0; i < r.rabbitmqConsumerOptions.ConcurrencyLimit; i++ {
-		r.logger.Infof("Processing messages on thread %d", i)
-		go func() {
-			for {
-				select {
-				case <-ctx.Done():
-					r.logger.Info("shutting down consumer")
-					return
-				case amqErr := <-chClosedCh:
-					// This case handles the event of closed channel e.g. abnormal shutdown
+	r.logger.Infof(
+		"Processing messages of %s with %d workers",
+		r.rabbitmqConsumerOptions.Name,
+		r.rabbitmqConsumerOptions.ConcurrencyLimit,
+	)
+	go func() {
+		defer errorutils.HandlePanic()
+
+		for {
+			select {
+			case <-ctx.Done():
+				r.logger.Info("shutting down consumer")
+				return
+			case amqErr, ok := <-chClosedCh:
+				// This case handles the event of closed channel e.g. abnormal shutdown, consuming starts again on
+				// a new channel after the connection reconnected
+				if ok {
 					r.logger.Errorf("AMQP Channel closed due to: %s", amqErr)
+				}
 
-					// Re-set channel to receive notifications
-					chClosedCh = make(chan *amqp091.Error, 1)
-					ch.NotifyClose(chClosedCh)
-				case msg, ok := <-msgs:
-					if !ok {
-						r.logger.Info("consumer connection dropped")
-						return
-					}
+				return
+			case msg, ok := <-msgs:
+				if !ok {
+					r.logger.Info("consumer connection dropped")
+					return
+				}
+
+				if !r.waitForDependencies(ctx) {
+					return
+				}
 
-					// handle received message and remove message form queue with a manual ack
-					r.handleReceived(ctx, msg)
+				// the dispatch waits for a free worker, meanwhile the broker sends no more than the prefetch count
+				if !r.workers.Dispatch(ctx, msg, r.orderingKey(msg.Headers)) {
+					return
 				}
 			}
-		}()
+		}
+	}()
+
+	return nil
+}
+
+// declareDeadLetter declares the dead-letter exchange and queue of the consumer queue, the exchange is a direct
+// exchange and the dead-letter queue is bound with its name
+func (r *rabbitMQConsumer) declareDeadLetter(
+	ch *amqp091.Channel,
+	queue string,
+	deadLetterOptions *options.RabbitMQDeadLetterOptions,
+) error {
+	exchange, deadLetterQueue := deadLetterOptions.Names(queue)
+
+	err := ch.ExchangeDeclare(
+		exchange,
+		string(types.ExchangeDirect),
+		true,
+		false,
+		false,
+		r.rabbitmqConsumerOptions.NoWait,
+		nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = ch.QueueDeclare(
+		deadLetterQueue,
+		true,
+		false,
+		false,
+		r.rabbitmqConsumerOptions.NoWait,
+		deadLetterOptions.QueueArgs())
+	if err != nil {
+		return err
+	}
+
+	return ch.QueueBind(deadLetterQueue, deadLetterQueue, exchange, r.rabbitmqConsumerOptions.NoWait, nil)
+}
+
+// declareRetryQueues declares a retry queue for each delayed retry of the retry policy, the expired messages of the
+// retry queues are routed back to the consumer queue
+func (r *rabbitMQConsumer) declareRetryQueues(
+	ch *amqp091.Channel,
+	queue string,
+	retryPolicy *options.RabbitMQRetryPolicyOptions,
+) error {
+	for retry := 1; retry <= retryPolicy.DelayedRetries; retry++ {
+		_, err := ch.QueueDeclare(
+			retryPolicy.RetryQueueName(queue, retry),
+			r.rabbitmqConsumerOptions.QueueOptions.Durable,
+			false,
+			false,
+			r.rabbitmqConsumerOptions.NoWait,
+			retryPolicy.RetryQueueArgs(queue, retry))
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// topology returns the exchange, the routing key and the queue of the consumer
+func (r *rabbitMQConsumer) topology() (exchange string, routingKey string, queue string) {
+	return r.rabbitmqConsumerOptions.Topology()
+}
+
+// Stop drains the consumer, it stops the deliveries and waits for the deliveries in handling up to the drain timeout,
+// then requeues the deliveries which are still in handling. the prefetched deliveries which are not dispatched to the
+// workers are requeued by the broker when the channel is closed.
 func (r *rabbitMQConsumer) Stop() error {
-	defer func() {
-		if r.channel != nil && r.channel.IsClosed() == false {
-			r.channel.Cancel(r.rabbitmqConsumerOptions.ConsumerId, false)
-			r.channel.Close()
-		}
-	}()
+	r.channelMutex.Lock()
+	if r.channel != nil && r.channel.IsClosed() == false {
+		r.channel.Cancel(r.rabbitmqConsumerOptions.ConsumerId, false)
+	}
+	r.channelMutex.Unlock()
 
-	done := make(chan struct{}, 1)
+	if r.workers != nil && !r.workers.StopWithin(r.drainTimeout()) {
+		r.requeueInFlight()
+	}
 
-	go func() {
-		for {
-			if len(r.deliveryRoutines) == 0 {
-				done <- struct{}{}
-			}
-		}
-	}()
+	if r.cancelHandlers != nil {
+		r.cancelHandlers()
+	}
 
-	select {
-	case <-done:
-		return nil
+	r.channelMutex.Lock()
+	defer r.channelMutex.Unlock()
+
+	if r.channel != nil && r.channel.IsClosed() == false {
+		return r.channel.Close()
+	}
+
+	return nil
+}
+
+// requeueInFlight requeues the deliveries whose handlers don't finish in the drain timeout and cancels the handlers, the
+// acks and the nacks of the canceled handlers are skipped, so the requeued deliveries are handled again by another
+// instance
+func (r *rabbitMQConsumer) requeueInFlight() {
+	_, _, queue := r.topology()
+
+	if r.rabbitmqConsumerOptions.AutoAck {
+		r.logger.Warnf(
+			"consumer %s didn't drain %d in-flight messages in %s, they are acknowledged already",
+			r.rabbitmqConsumerOptions.Name,
+			r.inFlight.count(),
+			r.drainTimeout(),
+		)
+
+		return
+	}
+
+	requeued, err := r.inFlight.requeueUnsettled()
+	if err != nil {
+		r.logger.Errorf("error in requeuing in-flight messages of consumer %s: %v", r.rabbitmqConsumerOptions.Name, err)
+	}
+
+	r.consumerMetrics.addDrainRequeued(context.Background(), queue, requeued)
+	r.logger.Warnf(
+		"consumer %s didn't drain in %s, %d in-flight messages are requeued",
+		r.rabbitmqConsumerOptions.Name,
+		r.drainTimeout(),
+		requeued,
+	)
+}
+
+func (r *rabbitMQConsumer) drainTimeout() time.Duration {
+	if r.rabbitmqOptions == nil {
+		return config.RabbitmqShutdownOptions{}.GetDrainTimeout()
+	}
+
+	return r.rabbitmqOptions.ShutdownOptions.GetDrainTimeout()
+}
+
+// waitForDependencies pauses the dispatch of the deliveries while a health dependency of the consumer is unhealthy, the
+// broker sends no more than the prefetch count to the paused consumer, so the other deliveries stay in the queue
+// instead of failing their retries during the outage
+func (r *rabbitMQConsumer) waitForDependencies(ctx context.Context) bool {
+	dependencies := r.rabbitmqConsumerOptions.HealthDependencies
+	if r.dependencyMonitor == nil || len(dependencies) == 0 {
+		return true
+	}
+
+	unhealthy := r.dependencyMonitor.Unhealthy(ctx, dependencies)
+	if len(unhealthy) == 0 {
+		return true
+	}
+
+	r.logger.Warnf(
+		"consumer %s is paused, its dependencies %v are unhealthy",
+		r.rabbitmqConsumerOptions.Name,
+		unhealthy,
+	)
+
+	if err := r.dependencyMonitor.WaitHealthy(ctx, dependencies); err != nil {
+		return false
+	}
+
+	r.logger.Infof("consumer %s is resumed, its dependencies are healthy", r.rabbitmqConsumerOptions.Name)
+
+	return true
+}
+
+// orderingKey returns the value of the ordering key header of the delivery, the deliveries without it have no order
+func (r *rabbitMQConsumer) orderingKey(headers map[string]interface{}) string {
+	header := r.rabbitmqConsumerOptions.OrderingKeyHeader
+	if header == "" {
+		return ""
+	}
+
+	value, ok := headers[header]
+	if !ok || value == nil {
+		return ""
+	}
+
+	return fmt.Sprint(value)
+}
+
+// applyConsumerOptions overrides the concurrency and the health dependencies of the consumer configuration with its
+// config options
+func applyConsumerOptions(
+	consumerConfiguration *configurations.RabbitMQConsumerConfiguration,
+	consumerOptions *config.RabbitmqConsumerOptions,
+) {
+	if consumerOptions == nil {
+		return
+	}
+
+	if consumerOptions.ConcurrencyLimit > 0 {
+		consumerConfiguration.ConcurrencyLimit = consumerOptions.ConcurrencyLimit
+	}
+
+	if consumerOptions.PrefetchCount > 0 {
+		consumerConfiguration.PrefetchCount = consumerOptions.PrefetchCount
+	}
+
+	if len(consumerOptions.HealthDependencies) > 0 {
+		consumerConfiguration.HealthDependencies = consumerOptions.HealthDependencies
 	}
 }
 
@@ -261,27 +502,29 @@ func (r *rabbitMQConsumer) GetName() string {
 	return r.rabbitmqConsumerOptions.Name
 }
 
-func (r *rabbitMQConsumer) reConsumeOnDropConnection(ctx context.Context) {
+func (r *rabbitMQConsumer) reConsumeOnReconnect(ctx context.Context) {
+	reconnected := r.connection.NotifyReconnected(make(chan struct{}, 1))
+
 	go func() {
 		defer errorutils.HandlePanic()
 		for {
 			select {
-			case reconnect := <-r.connection.ReconnectedChannel():
-				if reflect.ValueOf(reconnect).IsValid() {
-					r.logger.Info("reconsume_on_drop_connection started")
-					err := r.Start(ctx)
-					if err != nil {
-						r.logger.Error(
-							"reconsume_on_drop_connection finished with error: %v",
-							err,
-						)
-						continue
-					}
-					r.logger.Info(
-						"reconsume_on_drop_connection finished successfully",
+			case <-ctx.Done():
+				return
+			case <-reconnected:
+				r.logger.Info("reconsume_on_reconnect started")
+				// redeclare the topology and consume on the new connection, if it fails we try again on the next reconnect
+				err := r.consume(ctx)
+				if err != nil {
+					r.logger.Errorf(
+						"reconsume_on_reconnect finished with error: %v",
+						err,
 					)
-					return
+					continue
 				}
+				r.logger.Info(
+					"reconsume_on_reconnect finished successfully",
+				)
 			}
 		}
 	}()
@@ -291,11 +534,6 @@ func (r *rabbitMQConsumer) handleReceived(
 	ctx context.Context,
 	delivery amqp091.Delivery,
 ) {
-	// for ensuring our handlers execute completely after shutdown
-	r.deliveryRoutines <- struct{}{}
-
-	defer func() { <-r.deliveryRoutines }()
-
 	var meta metadata.Metadata
 	if delivery.Headers != nil {
 		meta = metadata.MapToMetadata(delivery.Headers)
@@ -324,12 +562,26 @@ func (r *rabbitMQConsumer) handleReceived(
 		return
 	}
 
+	_, _, queue := r.topology()
+	tracked := r.inFlight.add(delivery)
+	r.consumerMetrics.addInFlight(ctx, queue, delivery.Type, 1)
+	defer func() {
+		r.inFlight.remove(tracked)
+		r.consumerMetrics.addInFlight(context.WithoutCancel(ctx), queue, delivery.Type, -1)
+	}()
+
 	var ack func()
-	var nack func()
+	var nack func(handlerErr error)
 
 	// if auto-ack is enabled we should not call Ack method manually it could create some unexpected errors
 	if r.rabbitmqConsumerOptions.AutoAck == false {
 		ack = func() {
+			// the delivery is requeued by the drain when its handler is finished after the drain timeout
+			if !r.inFlight.claim(tracked) {
+				_ = consumertracing.FinishConsumerSpan(beforeConsumeSpan, nil)
+				return
+			}
+
 			if err := delivery.Ack(false); err != nil {
 				r.logger.Error(
 					"error sending ACK to RabbitMQ consumer: %v",
@@ -347,8 +599,23 @@ func (r *rabbitMQConsumer) handleReceived(
 			}
 		}
 
-		nack = func() {
-			if err := delivery.Nack(false, true); err != nil {
+		nack = func(handlerErr error) {
+			if !r.inFlight.claim(tracked) {
+				_ = consumertracing.FinishConsumerSpan(beforeConsumeSpan, ctx.Err())
+				return
+			}
+
+			var err error
+			switch {
+			case r.rabbitmqConsumerOptions.RetryPolicy != nil:
+				err = r.retryWithDelay(ctx, delivery, handlerErr)
+			case r.rabbitmqConsumerOptions.DeadLetterOptions != nil:
+				err = r.retryOrDeadLetter(ctx, delivery, handlerErr)
+			default:
+				err = delivery.Nack(false, true)
+			}
+
+			if err != nil {
 				r.logger.Error(
 					"error in sending Nack to RabbitMQ consumer: %v",
 					consumertracing.FinishConsumerSpan(beforeConsumeSpan, err),
@@ -362,37 +629,271 @@ func (r *rabbitMQConsumer) handleReceived(
 	r.handle(ctx, ack, nack, consumeContext)
 }
 
+// retryOrDeadLetter publishes a failed message to the end of the consumer queue with an increased retry count, and
+// quarantines or rejects it to the dead-letter queue after the max retries of the consumer
+func (r *rabbitMQConsumer) retryOrDeadLetter(ctx context.Context, delivery amqp091.Delivery, handlerErr error) error {
+	retryCount := deadLetterRetryCount(delivery.Headers)
+	if retryCount >= r.rabbitmqConsumerOptions.DeadLetterOptions.MaxRetries {
+		if r.quarantine(ctx, delivery, retryCount, handlerErr) {
+			return delivery.Ack(false)
+		}
+
+		return r.deadLetter(ctx, delivery, retryCount)
+	}
+
+	_, _, queue := r.topology()
+
+	// the message is published directly to the queue with the default exchange, so the other queues of the exchange
+	// don't receive it again
+	if err := r.republish(ctx, delivery, queue, retryCount+1, "", handlerErr); err != nil {
+		return err
+	}
+
+	r.consumerMetrics.addRetry(ctx, queue, delivery.Type, requeueRetry)
+
+	return delivery.Ack(false)
+}
+
+// retryWithDelay publishes a failed message to the retry queue of its next delayed retry, the message expires in the
+// retry queue after the backoff delay of the retry policy and returns to the consumer queue. After the delayed retries
+// it is quarantined or rejected to the dead-letter queue, or requeued if the consumer has none of them.
+func (r *rabbitMQConsumer) retryWithDelay(ctx context.Context, delivery amqp091.Delivery, handlerErr error) error {
+	retryPolicy := r.rabbitmqConsumerOptions.RetryPolicy
+	retryCount := deadLetterRetryCount(delivery.Headers)
+
+	if retryCount >= retryPolicy.DelayedRetries {
+		if r.quarantine(ctx, delivery, retryCount, handlerErr) {
+			return delivery.Ack(false)
+		}
+
+		if r.rabbitmqConsumerOptions.DeadLetterOptions != nil {
+			return r.deadLetter(ctx, delivery, retryCount)
+		}
+
+		return delivery.Nack(false, true)
+	}
+
+	_, _, queue := r.topology()
+	retry := retryCount + 1
+	delay := retryPolicy.Delay(retry)
+
+	// the per-message expiration adds the jitter to the delay, the ttl of the retry queue is the delay without jitter
+	err := r.republish(
+		ctx,
+		delivery,
+		retryPolicy.RetryQueueName(queue, retry),
+		retry,
+		strconv.FormatInt(delay.Milliseconds(), 10),
+		handlerErr,
+	)
+	if err != nil {
+		return err
+	}
+
+	r.logger.Infow(
+		fmt.Sprintf(
+			"[rabbitMQConsumer.retryWithDelay] message with id: {%s} is retried in %s, retry %d of %d",
+			delivery.MessageId,
+			delay,
+			retry,
+			retryPolicy.DelayedRetries,
+		),
+		logger.Fields{"MessageId": delivery.MessageId, "RetryCount": retry},
+	)
+	r.consumerMetrics.addRetry(ctx, queue, delivery.Type, delayedRetry)
+
+	return delivery.Ack(false)
+}
+
+func (r *rabbitMQConsumer) deadLetter(ctx context.Context, delivery amqp091.Delivery, retryCount int) error {
+	r.logger.Errorw(
+		fmt.Sprintf(
+			"[rabbitMQConsumer.deadLetter] message with id: {%s} failed after %d retries, moving it to the dead-letter queue",
+			delivery.MessageId,
+			retryCount,
+		),
+		logger.Fields{"MessageId": delivery.MessageId, "RetryCount": retryCount},
+	)
+
+	_, _, queue := r.topology()
+	r.consumerMetrics.addDeadLettered(ctx, queue, delivery.Type)
+
+	return delivery.Nack(false, false)
+}
+
+// republish publishes a copy of the delivery with the retry count and the error history to a queue through the default
+// exchange, the delivery is requeued if the publishing fails, so it is not lost
+func (r *rabbitMQConsumer) republish(
+	ctx context.Context,
+	delivery amqp091.Delivery,
+	queue string,
+	retryCount int,
+	expiration string,
+	handlerErr error,
+) error {
+	headers := amqp091.Table{}
+	for key, value := range delivery.Headers {
+		headers[key] = value
+	}
+	headers[options.DeadLetterRetryCountHeader] = int32(retryCount)
+	quarantine.AppendErrorHistory(headers, quarantine.NewQuarantineError(retryCount, handlerErr))
+
+	r.channelMutex.Lock()
+	ch := r.channel
+	r.channelMutex.Unlock()
+
+	err := ch.PublishWithContext(ctx, "", queue, false, false, amqp091.Publishing{
+		Headers:       headers,
+		ContentType:   delivery.ContentType,
+		DeliveryMode:  delivery.DeliveryMode,
+		CorrelationId: delivery.CorrelationId,
+		MessageId:     delivery.MessageId,
+		Timestamp:     delivery.Timestamp,
+		Type:          delivery.Type,
+		Expiration:    expiration,
+		Body:          delivery.Body,
+	})
+	if err != nil {
+		return errors.Combine(err, delivery.Nack(false, true))
+	}
+
+	return nil
+}
+
+// quarantine stores a message which is failed after the max retries in the quarantine store with the errors of its
+// attempts, it returns false when the consumer has no quarantine store or the message is not stored, then the message
+// is handled like a consumer without quarantine
+func (r *rabbitMQConsumer) quarantine(
+	ctx context.Context,
+	delivery amqp091.Delivery,
+	retryCount int,
+	handlerErr error,
+) bool {
+	if r.quarantineStore == nil {
+		return false
+	}
+
+	_, _, queue := r.topology()
+
+	headers := make(map[string]any, len(delivery.Headers))
+	for key, value := range delivery.Headers {
+		headers[key] = value
+	}
+	delete(headers, quarantine.ErrorHistoryHeader)
+
+	errs := append(quarantine.ErrorHistory(delivery.Headers), quarantine.NewQuarantineError(retryCount+1, handlerErr))
+
+	message := &quarantine.QuarantinedMessage{
+		Id:               uuid.NewV4().String(),
+		MessageId:        delivery.MessageId,
+		CorrelationId:    delivery.CorrelationId,
+		MessageType:      delivery.Type,
+		ContentType:      delivery.ContentType,
+		Consumer:         r.rabbitmqConsumerOptions.Name,
+		Queue:            queue,
+		Headers:          headers,
+		Body:             delivery.Body,
+		RetryCount:       retryCount,
+		Errors:           errs,
+		MessageTimestamp: delivery.Timestamp,
+		QuarantinedAt:    time.Now(),
+	}
+
+	// the message is stored even when the handlers are canceled by the shutdown, it is acked after that
+	if err := r.quarantineStore.Add(context.WithoutCancel(ctx), message); err != nil {
+		r.logger.Errorw(
+			fmt.Sprintf(
+				"[rabbitMQConsumer.quarantine] error in quarantining message with id: {%s}: %v",
+				delivery.MessageId,
+				err,
+			),
+			logger.Fields{"MessageId": delivery.MessageId},
+		)
+
+		return false
+	}
+
+	r.logger.Errorw(
+		fmt.Sprintf(
+			"[rabbitMQConsumer.quarantine] message with id: {%s} failed after %d retries, it is quarantined with id: {%s}",
+			delivery.MessageId,
+			retryCount,
+			message.Id,
+		),
+		logger.Fields{"MessageId": delivery.MessageId, "RetryCount": retryCount, "QuarantineId": message.Id},
+	)
+	r.consumerMetrics.addQuarantined(ctx, queue, delivery.Type)
+
+	return true
+}
+
+func deadLetterRetryCount(headers amqp091.Table) int {
+	switch count := headers[options.DeadLetterRetryCountHeader].(type) {
+	case int32:
+		return int(count)
+	case int64:
+		return int(count)
+	case int:
+		return count
+	default:
+		return 0
+	}
+}
+
 func (r *rabbitMQConsumer) handle(
 	ctx context.Context,
 	ack func(),
-	nack func(),
+	nack func(handlerErr error),
 	messageConsumeContext messagingTypes.MessageConsumeContext,
 ) {
-	var err error
-	for _, handler := range r.handlers {
-		err = r.runHandlersWithRetry(ctx, handler, messageConsumeContext)
-		if err != nil {
-			break
+	err := r.runHandlers(ctx, messageConsumeContext)
+	if err != nil {
+		fields := logger.Fields{"message_id": messageConsumeContext.MessageId()}
+		if tenantId, ok := tenant.GetTenantId(ctx); ok {
+			fields["tenant_id"] = tenantId
 		}
-	}
 
-	if err != nil {
-		r.logger.Error(
+		r.logger.Errorw(
 			"[rabbitMQConsumer.Handle] error in handling consume message of RabbitmqMQ, prepare for nacking message",
+			fields,
 		)
 		if nack != nil && r.rabbitmqConsumerOptions.AutoAck == false {
-			nack()
+			nack(err)
 		}
 	} else if err == nil && ack != nil && r.rabbitmqConsumerOptions.AutoAck == false {
 		ack()
 	}
 }
 
+// runHandlers runs the handlers of the message with their immediate retries until a handler fails, and measures them
+func (r *rabbitMQConsumer) runHandlers(
+	ctx context.Context,
+	messageConsumeContext messagingTypes.MessageConsumeContext,
+) error {
+	startTime := time.Now()
+
+	var err error
+	for _, handler := range r.handlers {
+		err = r.runHandlersWithRetry(ctx, handler, messageConsumeContext)
+		if err != nil {
+			break
+		}
+	}
+
+	_, _, queue := r.topology()
+	r.consumerMetrics.recordConsume(ctx, queue, messageConsumeContext.MessageType(), time.Since(startTime), err)
+
+	return err
+}
+
 func (r *rabbitMQConsumer) runHandlersWithRetry(
 	ctx context.Context,
 	handler consumer.ConsumerHandler,
 	messageConsumeContext messagingTypes.MessageConsumeContext,
 ) error {
+	// the pipelines run for each handler, so they can keep the state of the message per handler, like the inbox
+	ctx = consumer.WithHandler(ctx, handler)
+
 	err := retry.Do(func() error {
 		var lastHandler pipeline.ConsumerHandlerFunc
 
@@ -442,11 +943,31 @@ func (r *rabbitMQConsumer) runHandlersWithRetry(
 			}
 		}
 		return nil
-	}, append(retryOptions, retry.Context(ctx))...)
+	}, r.handlerRetryOptions(ctx, messageConsumeContext.MessageType())...)
 
 	return err
 }
 
+// handlerRetryOptions returns the options of the immediate retries of the handlers, the retry policy of the consumer
+// overrides the number of the attempts
+func (r *rabbitMQConsumer) handlerRetryOptions(ctx context.Context, messageType string) []retry.Option {
+	_, _, queue := r.topology()
+
+	attempts := uint(retryAttempts)
+	if retryPolicy := r.rabbitmqConsumerOptions.RetryPolicy; retryPolicy != nil {
+		attempts = uint(max(retryPolicy.ImmediateRetries, 0) + 1)
+	}
+
+	return append(
+		retryOptions,
+		retry.Attempts(attempts),
+		retry.Context(ctx),
+		retry.OnRetry(func(_ uint, _ error) {
+			r.consumerMetrics.addRetry(ctx, queue, messageType, immediateRetry)
+		}),
+	)
+}
+
 func (r *rabbitMQConsumer) createConsumeContext(
 	delivery amqp091.Delivery,
 ) (messagingTypes.MessageConsumeContext, error) {
@@ -479,37 +1000,33 @@ func (r *rabbitMQConsumer) deserializeData(
 	eventType string,
 	body []byte,
 ) messagingTypes.IMessage {
-	if contentType == "" {
-		contentType = "application/json"
-	}
-
 	if body == nil || len(body) == 0 {
 		r.logger.Error("message body is nil or empty in the consumer")
 		return nil
 	}
 
-	if contentType == "application/json" {
-		// r.rabbitmqConsumerOptions.ConsumerMessageType --> actual type
-		// deserialize, err := r.messageSerializer.DeserializeType(body, r.rabbitmqConsumerOptions.ConsumerMessageType, contentType)
-		deserialize, err := r.messageSerializer.Deserialize(
-			body,
-			eventType,
-			contentType,
-		) // or this to explicit type deserialization
-		if err != nil {
-			r.logger.Errorf(
-				fmt.Sprintf(
-					"error in deserilizng of type '%s' in the consumer",
-					eventType,
-				),
-			)
-			return nil
-		}
-
-		return deserialize
+	// the message is deserialized with the serializer of its content type, the messages without a content type are
+	// deserialized with the serializer of the bus
+	// r.rabbitmqConsumerOptions.ConsumerMessageType --> actual type
+	// deserialize, err := r.messageSerializer.DeserializeType(body, r.rabbitmqConsumerOptions.ConsumerMessageType, contentType)
+	deserialize, err := r.messageSerializer.Deserialize(
+		body,
+		eventType,
+		contentType,
+	) // or this to explicit type deserialization
+	if err != nil {
+		r.logger.Errorf(
+			fmt.Sprintf(
+				"error in deserilizng of type '%s' with the content type '%s' in the consumer: %v",
+				eventType,
+				contentType,
+				err,
+			),
+		)
+		return nil
 	}
 
-	return nil
+	return deserialize
 }
 
 func (r *rabbitMQConsumer) reversOrder(
@@ -58,12 +58,17 @@ func Test_Consumer_With_Fake_Message(t *testing.T) {
 		conn,
 		eventSerializer,
 		defaultLogger2.GetLogger(),
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 	producerFactory := producer.NewProducerFactory(
 		options,
 		conn,
 		eventSerializer,
 		defaultLogger2.GetLogger(),
+		nil,
 	)
 
 	fakeHandler := consumer.NewRabbitMQFakeTestConsumerHandler[ProducerConsumerMessage]()
@@ -0,0 +1,105 @@
+package consumer
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	errorutils "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils/errorutils"
+)
+
+// workerPool handles the deliveries of a consumer with a bounded number of workers, a dispatch waits while the workers
+// are busy, so the deliveries which are not dispatched stay unacknowledged and the prefetch count of the consumer holds
+// back the broker. the deliveries with an ordering key are dispatched to the worker of their key, so the deliveries of
+// a key are handled in order, the others are handled by any free worker.
+type workerPool[T any] struct {
+	shared    chan T
+	keyed     []chan T
+	done      chan struct{}
+	stopOnce  sync.Once
+	waitGroup sync.WaitGroup
+}
+
+func newWorkerPool[T any](workers int, handle func(T)) *workerPool[T] {
+	if workers <= 0 {
+		workers = 1
+	}
+
+	pool := &workerPool[T]{
+		shared: make(chan T),
+		keyed:  make([]chan T, workers),
+		done:   make(chan struct{}),
+	}
+
+	for i := range pool.keyed {
+		pool.keyed[i] = make(chan T)
+
+		pool.waitGroup.Add(1)
+		go func(keyed <-chan T) {
+			defer pool.waitGroup.Done()
+			defer errorutils.HandlePanic()
+
+			for {
+				select {
+				case <-pool.done:
+					return
+				case item := <-keyed:
+					handle(item)
+				case item := <-pool.shared:
+					handle(item)
+				}
+			}
+		}(pool.keyed[i])
+	}
+
+	return pool
+}
+
+// Dispatch waits for a free worker of the delivery, it returns false when the pool is stopped or the context is done
+// before the delivery is dispatched
+func (p *workerPool[T]) Dispatch(ctx context.Context, item T, orderingKey string) bool {
+	queue := p.shared
+	if orderingKey != "" {
+		hash := fnv.New32a()
+		_, _ = hash.Write([]byte(orderingKey))
+		queue = p.keyed[hash.Sum32()%uint32(len(p.keyed))]
+	}
+
+	select {
+	case queue <- item:
+		return true
+	case <-p.done:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Stop stops the workers after the deliveries in handling
+func (p *workerPool[T]) Stop() {
+	p.stopOnce.Do(func() { close(p.done) })
+	p.waitGroup.Wait()
+}
+
+// StopWithin stops the workers and waits for the deliveries in handling up to the timeout, it returns false when some
+// deliveries are still in handling after the timeout
+func (p *workerPool[T]) StopWithin(timeout time.Duration) bool {
+	p.stopOnce.Do(func() { close(p.done) })
+
+	stopped := make(chan struct{})
+	go func() {
+		p.waitGroup.Wait()
+		close(stopped)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-stopped:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
@@ -0,0 +1,125 @@
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WorkerPool_Bounds_Concurrent_Handlers(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	var handled sync.WaitGroup
+
+	pool := newWorkerPool(3, func(int) {
+		defer handled.Done()
+
+		current := running.Add(1)
+		for {
+			previous := maxRunning.Load()
+			if current <= previous || maxRunning.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		running.Add(-1)
+	})
+	defer pool.Stop()
+
+	for i := 0; i < 12; i++ {
+		handled.Add(1)
+		require.True(t, pool.Dispatch(context.Background(), i, ""))
+	}
+	handled.Wait()
+
+	assert.Equal(t, int32(3), maxRunning.Load())
+}
+
+func Test_WorkerPool_Keeps_Order_Of_Ordering_Key(t *testing.T) {
+	var mu sync.Mutex
+	handledByKey := map[string][]int{}
+	var handled sync.WaitGroup
+
+	type item struct {
+		key    string
+		number int
+	}
+
+	pool := newWorkerPool(4, func(i item) {
+		defer handled.Done()
+
+		// the earlier items of a key are slower, they are still handled first
+		time.Sleep(time.Duration(5-i.number) * time.Millisecond)
+
+		mu.Lock()
+		handledByKey[i.key] = append(handledByKey[i.key], i.number)
+		mu.Unlock()
+	})
+	defer pool.Stop()
+
+	for number := 0; number < 5; number++ {
+		for k := 0; k < 3; k++ {
+			key := fmt.Sprintf("product-%d", k)
+			handled.Add(1)
+			require.True(t, pool.Dispatch(context.Background(), item{key: key, number: number}, key))
+		}
+	}
+	handled.Wait()
+
+	for k := 0; k < 3; k++ {
+		assert.Equal(t, []int{0, 1, 2, 3, 4}, handledByKey[fmt.Sprintf("product-%d", k)])
+	}
+}
+
+func Test_WorkerPool_Stop_Waits_For_Handlers(t *testing.T) {
+	started := make(chan struct{})
+	var completed atomic.Bool
+
+	pool := newWorkerPool(1, func(int) {
+		close(started)
+		time.Sleep(50 * time.Millisecond)
+		completed.Store(true)
+	})
+
+	require.True(t, pool.Dispatch(context.Background(), 1, ""))
+	<-started
+
+	pool.Stop()
+
+	assert.True(t, completed.Load())
+	// a stopped pool doesn't wait for a worker
+	assert.False(t, pool.Dispatch(context.Background(), 2, ""))
+}
+
+func Test_WorkerPool_StopWithin_Returns_After_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	pool := newWorkerPool(1, func(int) { <-release })
+
+	require.True(t, pool.Dispatch(context.Background(), 1, ""))
+
+	assert.False(t, pool.StopWithin(20*time.Millisecond))
+
+	close(release)
+	assert.True(t, pool.StopWithin(time.Second))
+}
+
+func Test_WorkerPool_Dispatch_Returns_On_Done_Context(t *testing.T) {
+	release := make(chan struct{})
+	pool := newWorkerPool(1, func(int) { <-release })
+	defer pool.Stop()
+	defer close(release)
+
+	require.True(t, pool.Dispatch(context.Background(), 1, ""))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// the only worker is busy, so the dispatch waits until the context is done
+	assert.False(t, pool.Dispatch(ctx, 2, ""))
+}
@@ -0,0 +1,103 @@
+package deadletter
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/contracts"
+	apikey "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/api_key"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"github.com/labstack/echo/v4"
+)
+
+const defaultLimit = 20
+
+type DeadLetterEndpoint struct {
+	manager    DeadLetterManager
+	options    *RabbitmqDeadLetterOptions
+	echoServer contracts.EchoHttpServer
+}
+
+type requeueResponse struct {
+	Requeued int `json:"requeued"`
+}
+
+func NewDeadLetterEndpoint(
+	manager DeadLetterManager,
+	options *RabbitmqDeadLetterOptions,
+	server contracts.EchoHttpServer,
+) *DeadLetterEndpoint {
+	return &DeadLetterEndpoint{manager: manager, options: options, echoServer: server}
+}
+
+// RegisterEndpoints registers the `rabbitmq/deadletters` admin endpoints, they are authenticated with the api keys of
+// the admin users and are not registered without any user
+func (e *DeadLetterEndpoint) RegisterEndpoints() {
+	var keys []apikey.Option
+	for _, user := range e.options.AdminUsers {
+		if user != nil {
+			keys = append(keys, apikey.WithKey(user.ApiKey, user.UserId))
+		}
+	}
+
+	if len(keys) == 0 {
+		return
+	}
+
+	group := e.echoServer.GetEchoInstance().Group("rabbitmq/deadletters", apikey.ApiKey(keys...))
+	group.GET("", e.queues)
+	group.GET("/:queue", e.peek)
+	group.POST("/:queue/requeue", e.requeue)
+}
+
+func (e *DeadLetterEndpoint) queues(c echo.Context) error {
+	queues, err := e.manager.Queues(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, queues)
+}
+
+func (e *DeadLetterEndpoint) peek(c echo.Context) error {
+	limit, err := limitParam(c)
+	if err != nil {
+		return err
+	}
+
+	messages, err := e.manager.Peek(c.Request().Context(), c.Param("queue"), limit)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, messages)
+}
+
+func (e *DeadLetterEndpoint) requeue(c echo.Context) error {
+	limit, err := limitParam(c)
+	if err != nil {
+		return err
+	}
+
+	requeued, err := e.manager.Requeue(c.Request().Context(), c.Param("queue"), limit)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, &requeueResponse{Requeued: requeued})
+}
+
+func limitParam(c echo.Context) (int, error) {
+	value := c.QueryParam("limit")
+	if value == "" {
+		return defaultLimit, nil
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 {
+		return 0, customErrors.NewBadRequestError("limit should be a positive number")
+	}
+
+	return limit, nil
+}
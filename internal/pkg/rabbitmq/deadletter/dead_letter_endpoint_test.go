@@ -0,0 +1,106 @@
+package deadletter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	customEcho "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/contracts"
+	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/configurations"
+	consumerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/consumer/configurations"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type OrderCreated struct {
+	*types.Message
+}
+
+type OrderShipped struct {
+	*types.Message
+}
+
+type fakeDeadLetterManager struct {
+	requeuedQueue string
+	requeuedLimit int
+}
+
+func (f *fakeDeadLetterManager) Queues(ctx context.Context) ([]*DeadLetterQueue, error) {
+	return []*DeadLetterQueue{{Queue: "order_created", DeadLetterQueue: "order_created.dlq", MessageCount: 2}}, nil
+}
+
+func (f *fakeDeadLetterManager) Peek(ctx context.Context, deadLetterQueue string, limit int) ([]*DeadLetterMessage, error) {
+	return []*DeadLetterMessage{{MessageId: "1", Timestamp: time.Now()}}, nil
+}
+
+func (f *fakeDeadLetterManager) Requeue(ctx context.Context, deadLetterQueue string, limit int) (int, error) {
+	f.requeuedQueue = deadLetterQueue
+	f.requeuedLimit = limit
+
+	return limit, nil
+}
+
+func newTestEchoServer(manager DeadLetterManager, users ...*AdminUserOptions) contracts.EchoHttpServer {
+	server := customEcho.NewEchoHttpServer(&config.EchoHttpOptions{}, defaultLogger.GetLogger(), nil)
+	NewDeadLetterEndpoint(manager, &RabbitmqDeadLetterOptions{AdminUsers: users}, server).RegisterEndpoints()
+
+	return server
+}
+
+func Test_Dead_Letter_Queues_From_Configuration(t *testing.T) {
+	builder := configurations.NewRabbitMQConfigurationBuilder()
+	builder.
+		AddConsumer(OrderCreated{}, func(builder consumerConfigurations.RabbitMQConsumerConfigurationBuilder) {
+			builder.WithDeadLetter(3, time.Hour)
+		}).
+		AddConsumer(OrderShipped{}, func(builder consumerConfigurations.RabbitMQConsumerConfigurationBuilder) {})
+
+	queues := deadLetterQueues(builder.Build())
+
+	assert.Len(t, queues, 1)
+	assert.Equal(t, "order_created", queues[0].Queue)
+	assert.Equal(t, "order_created.dlq", queues[0].DeadLetterQueue)
+}
+
+func Test_Dead_Letter_Endpoint_Requeue(t *testing.T) {
+	manager := &fakeDeadLetterManager{}
+	server := newTestEchoServer(manager, &AdminUserOptions{UserId: "admin", ApiKey: "secret"})
+
+	req := httptest.NewRequest(http.MethodPost, "/rabbitmq/deadletters/order_created.dlq/requeue?limit=5", nil)
+	req.Header.Set("X-Api-Key", "secret")
+	rec := httptest.NewRecorder()
+	server.GetEchoInstance().ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"requeued":5}`, rec.Body.String())
+	assert.Equal(t, "order_created.dlq", manager.requeuedQueue)
+	assert.Equal(t, 5, manager.requeuedLimit)
+}
+
+func Test_Dead_Letter_Endpoint_Queues(t *testing.T) {
+	server := newTestEchoServer(&fakeDeadLetterManager{}, &AdminUserOptions{UserId: "admin", ApiKey: "secret"})
+
+	req := httptest.NewRequest(http.MethodGet, "/rabbitmq/deadletters", nil)
+	req.Header.Set("X-Api-Key", "secret")
+	rec := httptest.NewRecorder()
+	server.GetEchoInstance().ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"deadLetterQueue":"order_created.dlq"`)
+}
+
+func Test_Dead_Letter_Endpoint_Not_Registered_Without_Admin_Users(t *testing.T) {
+	server := newTestEchoServer(&fakeDeadLetterManager{})
+
+	req := httptest.NewRequest(http.MethodGet, "/rabbitmq/deadletters", nil)
+	rec := httptest.NewRecorder()
+	server.GetEchoInstance().ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,269 @@
+package deadletter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/bus"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/configurations"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/consumer/options"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/types"
+
+	"emperror.dev/errors"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+// DeadLetterQueue is the dead-letter queue of a consumer
+type DeadLetterQueue struct {
+	Consumer string `json:"consumer"`
+	// Queue is the consumer queue which the dead-lettered messages are requeued to
+	Queue           string `json:"queue"`
+	DeadLetterQueue string `json:"deadLetterQueue"`
+	MessageCount    int    `json:"messageCount"`
+}
+
+// DeadLetterMessage is a dead-lettered message, the body is kept as it is published
+type DeadLetterMessage struct {
+	MessageId     string         `json:"messageId"`
+	CorrelationId string         `json:"correlationId"`
+	MessageType   string         `json:"messageType"`
+	ContentType   string         `json:"contentType"`
+	Timestamp     time.Time      `json:"timestamp"`
+	RetryCount    int            `json:"retryCount"`
+	Headers       map[string]any `json:"headers"`
+	Body          string         `json:"body"`
+}
+
+// DeadLetterManager inspects and requeues the messages of the dead-letter queues of the consumers
+type DeadLetterManager interface {
+	// Queues returns the dead-letter queues of the consumers with their message counts
+	Queues(ctx context.Context) ([]*DeadLetterQueue, error)
+	// Peek returns up to limit messages of a dead-letter queue without removing them
+	Peek(ctx context.Context, deadLetterQueue string, limit int) ([]*DeadLetterMessage, error)
+	// Requeue moves up to limit messages of a dead-letter queue to their consumer queue and returns the number of the
+	// requeued messages
+	Requeue(ctx context.Context, deadLetterQueue string, limit int) (int, error)
+}
+
+type deadLetterManager struct {
+	bus        bus.RabbitmqBus
+	connection types.IConnection
+	logger     logger.Logger
+}
+
+func NewDeadLetterManager(
+	bus bus.RabbitmqBus,
+	connection types.IConnection,
+	logger logger.Logger,
+) DeadLetterManager {
+	return &deadLetterManager{bus: bus, connection: connection, logger: logger}
+}
+
+func (d *deadLetterManager) Queues(ctx context.Context) ([]*DeadLetterQueue, error) {
+	queues := deadLetterQueues(d.bus.Configuration())
+
+	for _, queue := range queues {
+		count, err := d.messageCount(queue.DeadLetterQueue)
+		if err != nil {
+			return nil, err
+		}
+		queue.MessageCount = count
+	}
+
+	return queues, nil
+}
+
+func (d *deadLetterManager) Peek(
+	ctx context.Context,
+	deadLetterQueue string,
+	limit int,
+) ([]*DeadLetterMessage, error) {
+	if _, err := d.findQueue(deadLetterQueue); err != nil {
+		return nil, err
+	}
+
+	ch, err := d.connection.Channel()
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in opening a channel for the dead-letter queue")
+	}
+	defer ch.Close()
+
+	var messages []*DeadLetterMessage
+	var lastTag uint64
+
+	for len(messages) < limit {
+		delivery, ok, err := ch.Get(deadLetterQueue, false)
+		if err != nil {
+			return nil, errors.WrapIf(err, "error in getting the dead-lettered message")
+		}
+		if !ok {
+			break
+		}
+
+		lastTag = delivery.DeliveryTag
+		messages = append(messages, newDeadLetterMessage(delivery))
+	}
+
+	// the messages are returned to the dead-letter queue in their original order
+	if lastTag > 0 {
+		if err := ch.Nack(lastTag, true, true); err != nil {
+			return nil, errors.WrapIf(err, "error in returning the dead-lettered messages")
+		}
+	}
+
+	return messages, nil
+}
+
+func (d *deadLetterManager) Requeue(ctx context.Context, deadLetterQueue string, limit int) (int, error) {
+	queue, err := d.findQueue(deadLetterQueue)
+	if err != nil {
+		return 0, err
+	}
+
+	ch, err := d.connection.Channel()
+	if err != nil {
+		return 0, errors.WrapIf(err, "error in opening a channel for the dead-letter queue")
+	}
+	defer ch.Close()
+
+	// the message is removed from the dead-letter queue after the broker confirms its publish to the consumer queue
+	if err := ch.Confirm(false); err != nil {
+		return 0, errors.WrapIf(err, "error in enabling the publisher confirms")
+	}
+
+	requeued := 0
+	for requeued < limit {
+		delivery, ok, err := ch.Get(deadLetterQueue, false)
+		if err != nil {
+			return requeued, errors.WrapIf(err, "error in getting the dead-lettered message")
+		}
+		if !ok {
+			break
+		}
+
+		if err := d.requeue(ctx, ch, queue.Queue, delivery); err != nil {
+			return requeued, errors.Combine(err, delivery.Nack(false, true))
+		}
+
+		if err := delivery.Ack(false); err != nil {
+			return requeued, errors.WrapIf(err, "error in removing the requeued message from the dead-letter queue")
+		}
+
+		requeued++
+	}
+
+	if requeued > 0 {
+		d.logger.Infof("%d messages of the dead-letter queue %s requeued to %s", requeued, deadLetterQueue, queue.Queue)
+	}
+
+	return requeued, nil
+}
+
+func (d *deadLetterManager) requeue(
+	ctx context.Context,
+	ch *amqp091.Channel,
+	queue string,
+	delivery amqp091.Delivery,
+) error {
+	headers := amqp091.Table{}
+	for key, value := range delivery.Headers {
+		headers[key] = value
+	}
+	// the requeued message gets all the retries of the consumer again
+	delete(headers, options.DeadLetterRetryCountHeader)
+
+	confirmation, err := ch.PublishWithDeferredConfirmWithContext(ctx, "", queue, false, false, amqp091.Publishing{
+		Headers:       headers,
+		ContentType:   delivery.ContentType,
+		DeliveryMode:  delivery.DeliveryMode,
+		CorrelationId: delivery.CorrelationId,
+		MessageId:     delivery.MessageId,
+		Timestamp:     delivery.Timestamp,
+		Type:          delivery.Type,
+		Body:          delivery.Body,
+	})
+	if err != nil {
+		return errors.WrapIf(err, "error in requeuing the dead-lettered message")
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return errors.WrapIf(err, "error in waiting for the requeue confirmation")
+	}
+	if !acked {
+		return errors.New("requeue of the dead-lettered message is not confirmed by the broker")
+	}
+
+	return nil
+}
+
+func (d *deadLetterManager) messageCount(deadLetterQueue string) (int, error) {
+	// a passive declare of a missing queue closes the channel, so each queue is inspected on its own channel
+	ch, err := d.connection.Channel()
+	if err != nil {
+		return 0, errors.WrapIf(err, "error in opening a channel for the dead-letter queue")
+	}
+	defer ch.Close()
+
+	queue, err := ch.QueueDeclarePassive(deadLetterQueue, true, false, false, false, nil)
+	if err != nil {
+		return 0, errors.WrapIf(err, fmt.Sprintf("error in inspecting the dead-letter queue %s", deadLetterQueue))
+	}
+
+	return queue.Messages, nil
+}
+
+func (d *deadLetterManager) findQueue(deadLetterQueue string) (*DeadLetterQueue, error) {
+	for _, queue := range deadLetterQueues(d.bus.Configuration()) {
+		if queue.DeadLetterQueue == deadLetterQueue {
+			return queue, nil
+		}
+	}
+
+	return nil, customErrors.NewNotFoundError(fmt.Sprintf("dead-letter queue %s not found", deadLetterQueue))
+}
+
+// deadLetterQueues returns the dead-letter queues of the consumers which are configured with a dead-letter
+func deadLetterQueues(configuration *configurations.RabbitMQConfiguration) []*DeadLetterQueue {
+	var queues []*DeadLetterQueue
+	if configuration == nil {
+		return queues
+	}
+
+	for _, consumerConfiguration := range configuration.ConsumersConfigurations {
+		if consumerConfiguration.DeadLetterOptions == nil {
+			continue
+		}
+
+		_, _, queue := consumerConfiguration.Topology()
+		_, deadLetterQueue := consumerConfiguration.DeadLetterOptions.Names(queue)
+		queues = append(queues, &DeadLetterQueue{
+			Consumer:        consumerConfiguration.Name,
+			Queue:           queue,
+			DeadLetterQueue: deadLetterQueue,
+		})
+	}
+
+	return queues
+}
+
+func newDeadLetterMessage(delivery amqp091.Delivery) *DeadLetterMessage {
+	retryCount := 0
+	if count, ok := delivery.Headers[options.DeadLetterRetryCountHeader].(int32); ok {
+		retryCount = int(count)
+	}
+
+	return &DeadLetterMessage{
+		MessageId:     delivery.MessageId,
+		CorrelationId: delivery.CorrelationId,
+		MessageType:   delivery.Type,
+		ContentType:   delivery.ContentType,
+		Timestamp:     delivery.Timestamp,
+		RetryCount:    retryCount,
+		Headers:       delivery.Headers,
+		Body:          string(delivery.Body),
+	}
+}
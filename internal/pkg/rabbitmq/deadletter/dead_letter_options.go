@@ -0,0 +1,33 @@
+package deadletter
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[RabbitmqDeadLetterOptions]())
+
+// RabbitmqDeadLetterOptions controls the requeue worker and the admin endpoints of the dead-letter queues, the
+// dead-letter queues themselves are declared by the consumers with `WithDeadLetter`.
+type RabbitmqDeadLetterOptions struct {
+	// RequeueIntervalSeconds is the delay between two scheduled requeues of the dead-lettered messages, zero disables
+	// the scheduled requeue and the messages are requeued only with the admin endpoint
+	RequeueIntervalSeconds int `mapstructure:"requeueIntervalSeconds"`
+	// RequeueBatchSize is the max number of messages of a dead-letter queue which are requeued in a single run
+	RequeueBatchSize int `mapstructure:"requeueBatchSize"       default:"100"`
+	// AdminUsers authenticate the admin endpoints with the api key of a user in the `X-Api-Key` header, the
+	// endpoints are not registered without any user
+	AdminUsers []*AdminUserOptions `mapstructure:"adminUsers"`
+}
+
+type AdminUserOptions struct {
+	UserId string `mapstructure:"userId"`
+	ApiKey string `mapstructure:"apiKey" secret:"true"`
+}
+
+func ProvideConfig(environment environment.Environment) (*RabbitmqDeadLetterOptions, error) {
+	return config.BindConfigKey[*RabbitmqDeadLetterOptions](optionName, environment)
+}
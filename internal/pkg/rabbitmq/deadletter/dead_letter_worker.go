@@ -0,0 +1,70 @@
+package deadletter
+
+import (
+	"context"
+	"time"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/web"
+
+	"emperror.dev/errors"
+	"go.uber.org/fx"
+)
+
+// registerRequeueWorker requeues the dead-lettered messages periodically during the application lifetime, a message
+// which fails again is dead-lettered again after the retries of its consumer
+func registerRequeueWorker(
+	lc fx.Lifecycle,
+	manager DeadLetterManager,
+	options *RabbitmqDeadLetterOptions,
+	logger logger.Logger,
+) error {
+	if options.RequeueIntervalSeconds <= 0 {
+		return nil
+	}
+
+	return web.RegisterPeriodicWorker(
+		lc,
+		"dead-letter requeue worker",
+		time.Duration(options.RequeueIntervalSeconds)*time.Second,
+		logger,
+		func(ctx context.Context) error {
+			return requeueDeadLetters(ctx, manager, options, logger)
+		},
+	)
+}
+
+func requeueDeadLetters(
+	ctx context.Context,
+	manager DeadLetterManager,
+	options *RabbitmqDeadLetterOptions,
+	logger logger.Logger,
+) error {
+	queues, err := manager.Queues(ctx)
+	if err != nil {
+		return errors.WrapIf(err, "error in loading the dead-letter queues")
+	}
+
+	for _, queue := range queues {
+		if queue.MessageCount == 0 {
+			continue
+		}
+
+		_, err := manager.Requeue(ctx, queue.DeadLetterQueue, options.RequeueBatchSize)
+		if customErrors.IsCanceledError(err) || ctx.Err() != nil {
+			return err
+		}
+
+		// a failed queue doesn't stop requeuing the other queues
+		if err != nil {
+			logger.Errorf(
+				"(deadLetterRequeueWorker) error in requeuing the messages of %s: {%v}",
+				queue.DeadLetterQueue,
+				err,
+			)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package deadletter
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provides the dead-letter admin endpoints and the requeue worker, it should be used with `rabbitmq.ModuleFunc`
+// and an echo server
+// https://uber-go.github.io/fx/modules.html
+var Module = fx.Module( //nolint:gochecknoglobals
+	"rabbitmqdeadletterfx",
+	fx.Provide(
+		ProvideConfig,
+		NewDeadLetterManager,
+		NewDeadLetterEndpoint,
+	),
+	fx.Invoke(func(endpoint *DeadLetterEndpoint) {
+		endpoint.RegisterEndpoints()
+	}),
+	fx.Invoke(registerRequeueWorker),
+)
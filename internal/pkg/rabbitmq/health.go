@@ -11,20 +11,35 @@ import (
 
 type gormHealthChecker struct {
 	connection types.IConnection
+	name       string
 }
 
 func NewRabbitMQHealthChecker(connection types.IConnection) contracts.Health {
-	return &gormHealthChecker{connection}
+	return &gormHealthChecker{connection: connection, name: "rabbitmq"}
+}
+
+// NewNamedRabbitMQHealthChecker checks the connection of a named bus, its health name is `rabbitmq-<bus name>`
+func NewNamedRabbitMQHealthChecker(busName string, connection types.IConnection) contracts.Health {
+	return &gormHealthChecker{connection: connection, name: "rabbitmq-" + busName}
 }
 
 func (g gormHealthChecker) CheckHealth(ctx context.Context) error {
 	if g.connection.IsConnected() {
 		return nil
-	} else {
-		return errors.New("rabbitmq is not available")
 	}
+
+	// the state of the connection tells a recovering connection from a closed one
+	stats := g.connection.Stats()
+
+	return errors.Errorf(
+		"%s is not available, connection is %s, host: %s, failed reconnects: %d",
+		g.name,
+		stats.State,
+		stats.Host,
+		stats.FailedReconnects,
+	)
 }
 
 func (g gormHealthChecker) GetHealthName() string {
-	return "rabbitmq"
+	return g.name
 }
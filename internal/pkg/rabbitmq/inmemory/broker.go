@@ -0,0 +1,162 @@
+package inmemory
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// QueueSize is the number of the deliveries a queue buffers before the publishes to it block
+const QueueSize = 1024
+
+// Delivery is a message published to the in-memory broker, it carries the properties of an amqp delivery which are
+// used by the consumers
+type Delivery struct {
+	Exchange      string
+	RoutingKey    string
+	MessageId     string
+	CorrelationId string
+	Type          string
+	ContentType   string
+	Timestamp     time.Time
+	Headers       map[string]interface{}
+	Body          []byte
+}
+
+type queue struct {
+	name       string
+	deliveries chan Delivery
+}
+
+type binding struct {
+	exchange   string
+	routingKey string
+	queue      *queue
+}
+
+// Broker routes the published deliveries to the bound queues like a rabbitmq topic exchange, each bound queue receives
+// a copy of the delivery and the consumers of a queue compete for its deliveries.
+type Broker struct {
+	mu       sync.RWMutex
+	queues   map[string]*queue
+	bindings []*binding
+}
+
+var defaultBroker = NewBroker() //nolint:gochecknoglobals
+
+func NewBroker() *Broker {
+	return &Broker{queues: map[string]*queue{}}
+}
+
+// DefaultBroker returns the process-wide broker, the apps composed in one process use it to exchange their messages
+func DefaultBroker() *Broker {
+	return defaultBroker
+}
+
+// Bind declares the queue if it doesn't exist and binds it to the exchange with the routing key, the routing key
+// supports the `*` and `#` wildcards of the topic exchanges. the deliveries of the queue are received from the returned
+// channel.
+func (b *Broker) Bind(exchange string, routingKey string, queueName string) <-chan Delivery {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	q, ok := b.queues[queueName]
+	if !ok {
+		q = &queue{name: queueName, deliveries: make(chan Delivery, QueueSize)}
+		b.queues[queueName] = q
+	}
+
+	for _, existing := range b.bindings {
+		if existing.queue == q && existing.exchange == exchange && existing.routingKey == routingKey {
+			return q.deliveries
+		}
+	}
+
+	b.bindings = append(b.bindings, &binding{exchange: exchange, routingKey: routingKey, queue: q})
+
+	return q.deliveries
+}
+
+// Publish routes the delivery to the bound queues, it blocks on a full queue until the context is done. a delivery
+// without any bound queue is dropped like an unroutable message of rabbitmq.
+func (b *Broker) Publish(ctx context.Context, delivery Delivery) error {
+	for _, q := range b.route(delivery.Exchange, delivery.RoutingKey) {
+		select {
+		case q.deliveries <- delivery:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// QueueLength returns the number of the deliveries which are waiting in the queue
+func (b *Broker) QueueLength(queueName string) int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	q, ok := b.queues[queueName]
+	if !ok {
+		return 0
+	}
+
+	return len(q.deliveries)
+}
+
+func (b *Broker) route(exchange string, routingKey string) []*queue {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	var queues []*queue
+	seen := map[*queue]bool{}
+
+	for _, bind := range b.bindings {
+		if bind.exchange != exchange || seen[bind.queue] || !matchRoutingKey(bind.routingKey, routingKey) {
+			continue
+		}
+
+		seen[bind.queue] = true
+		queues = append(queues, bind.queue)
+	}
+
+	return queues
+}
+
+// matchRoutingKey matches the routing key of a delivery with the binding key of a topic exchange, `*` matches exactly
+// one word and `#` matches zero or more words.
+func matchRoutingKey(bindingKey string, routingKey string) bool {
+	if bindingKey == routingKey || bindingKey == "#" {
+		return true
+	}
+
+	var routingWords []string
+	if routingKey != "" {
+		routingWords = strings.Split(routingKey, ".")
+	}
+
+	return matchWords(strings.Split(bindingKey, "."), routingWords)
+}
+
+func matchWords(bindingWords []string, routingWords []string) bool {
+	if len(bindingWords) == 0 {
+		return len(routingWords) == 0
+	}
+
+	switch bindingWords[0] {
+	case "#":
+		for i := 0; i <= len(routingWords); i++ {
+			if matchWords(bindingWords[1:], routingWords[i:]) {
+				return true
+			}
+		}
+
+		return false
+	case "*":
+		return len(routingWords) > 0 && matchWords(bindingWords[1:], routingWords[1:])
+	default:
+		return len(routingWords) > 0 && bindingWords[0] == routingWords[0] &&
+			matchWords(bindingWords[1:], routingWords[1:])
+	}
+}
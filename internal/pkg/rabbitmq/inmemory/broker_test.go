@@ -0,0 +1,50 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Match_Routing_Key(t *testing.T) {
+	assert.True(t, matchRoutingKey("product_created_v1", "product_created_v1"))
+	assert.True(t, matchRoutingKey("#", "product_created_v1"))
+	assert.True(t, matchRoutingKey("products.*", "products.created"))
+	assert.True(t, matchRoutingKey("products.#", "products"))
+	assert.True(t, matchRoutingKey("products.#", "products.created.v1"))
+	assert.True(t, matchRoutingKey("#.v1", "products.created.v1"))
+	assert.False(t, matchRoutingKey("products.*", "products.created.v1"))
+	assert.False(t, matchRoutingKey("products.*", "orders.created"))
+	assert.False(t, matchRoutingKey("product_created_v1", "product_updated_v1"))
+}
+
+func Test_Publish_Copies_To_Each_Bound_Queue(t *testing.T) {
+	broker := NewBroker()
+	readQueue := broker.Bind("product_created_v1", "product_created_v1", "catalogs_read_queue")
+	ordersQueue := broker.Bind("product_created_v1", "#", "orders_queue")
+	// binding twice doesn't duplicate the deliveries
+	broker.Bind("product_created_v1", "product_created_v1", "catalogs_read_queue")
+
+	err := broker.Publish(context.Background(), Delivery{
+		Exchange:   "product_created_v1",
+		RoutingKey: "product_created_v1",
+		MessageId:  "1",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "1", (<-readQueue).MessageId)
+	assert.Equal(t, "1", (<-ordersQueue).MessageId)
+	assert.Equal(t, 0, broker.QueueLength("catalogs_read_queue"))
+}
+
+func Test_Publish_Drops_Unroutable_Delivery(t *testing.T) {
+	broker := NewBroker()
+	broker.Bind("product_created_v1", "product_created_v1", "catalogs_read_queue")
+
+	err := broker.Publish(context.Background(), Delivery{Exchange: "order_created_v1", RoutingKey: "order_created_v1"})
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, broker.QueueLength("catalogs_read_queue"))
+}
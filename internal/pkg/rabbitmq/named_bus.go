@@ -0,0 +1,108 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"strings"
+
+	bus2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/bus"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/claimcheck"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/producer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/health/contracts"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/metrics"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/bus"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/configurations"
+	rabbitmqconsumer "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/consumer"
+	rabbitmqproducer "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/producer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/types"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/fx"
+)
+
+// BusTag is the fx tag of the values of a named bus, e.g. `fx.ParamTags(rabbitmq.BusTag("integration-bus"))` injects
+// the `bus.RabbitmqBus`, `bus.Bus` or `producer.Producer` of the `integration-bus`
+func BusTag(name string) string {
+	return fmt.Sprintf(`name:"rabbitmq-%s"`, name)
+}
+
+// NamedBusModuleFunc registers a named bus with its own connection, topology and consumers, e.g. an `integration-bus`
+// for the events of the partners on another virtual host, so they are isolated from the internal events of the
+// default bus. the host options of the bus are in the `buses` of the rabbitmq options, and its other options are the
+// options of the default bus, so it should be used with `rabbitmq.ModuleFunc`. with `useInMemory` the named buses
+// share the in-memory broker of the default bus.
+func NamedBusModuleFunc(name string, rabbitMQConfigurationConstructor interface{}) fx.Option {
+	tag := BusTag(name)
+
+	return fx.Module(
+		fmt.Sprintf("rabbitmq%sfx", strings.ReplaceAll(name, "-", "")),
+		messageSerializerDecorator,
+		fx.Provide(fx.Annotate(rabbitMQConfigurationConstructor, fx.ResultTags(tag))),
+		fx.Provide(fx.Annotate(
+			func(rabbitmqOptions *config.RabbitmqOptions) (*config.RabbitmqOptions, error) {
+				return rabbitmqOptions.BusOptions(name)
+			},
+			fx.ResultTags(tag),
+		)),
+		fx.Provide(fx.Annotate(types.NewRabbitMQConnection, fx.ParamTags(tag), fx.ResultTags(tag))),
+		fx.Provide(fx.Annotate(
+			newNamedBus,
+			fx.ParamTags(tag, tag, ``, ``, ``, tag, `optional:"true"`, `optional:"true"`),
+			fx.ResultTags(tag),
+		)),
+		fx.Provide(fx.Annotate(
+			func(rabbitmqBus bus.RabbitmqBus) producer.Producer { return rabbitmqBus },
+			fx.ParamTags(tag),
+			fx.ResultTags(tag),
+		)),
+		fx.Provide(fx.Annotate(
+			func(rabbitmqBus bus.RabbitmqBus) bus2.Bus { return rabbitmqBus },
+			fx.ParamTags(tag),
+			fx.ResultTags(tag),
+		)),
+		fx.Provide(fx.Annotate(
+			func(connection types.IConnection) contracts.Health {
+				return NewNamedRabbitMQHealthChecker(name, connection)
+			},
+			fx.ParamTags(tag),
+			fx.ResultTags(fmt.Sprintf(`group:"%s"`, "healths")),
+		)),
+		fx.Invoke(fx.Annotate(registerHooks, fx.ParamTags(``, tag, tag, tag, ``))),
+		fx.Invoke(fx.Annotate(
+			func(connection types.IConnection, appMetrics metrics.AppMetrics) error {
+				return registerConnectionMetrics(connection, appMetrics, attribute.String("rabbitmq.bus", name))
+			},
+			fx.ParamTags(tag, `optional:"true"`),
+		)),
+	)
+}
+
+// newNamedBus creates a bus on the connection of a named bus. the exhausted messages of a named bus are not
+// quarantined, because the replayer of the quarantine publishes on the connection of the default bus, so they are
+// dead-lettered in the virtual host of the named bus.
+func newNamedBus(
+	rabbitmqOptions *config.RabbitmqOptions,
+	connection types.IConnection,
+	eventSerializer serializer.MessageSerializer,
+	l logger.Logger,
+	claimCheck claimcheck.ClaimCheck,
+	rabbitmqBuilderFunc configurations.RabbitMQConfigurationBuilderFuc,
+	appMetrics metrics.AppMetrics,
+	dependencyMonitor contracts.DependencyMonitor,
+) (bus.RabbitmqBus, error) {
+	consumerFactory := rabbitmqconsumer.NewConsumerFactory(
+		rabbitmqOptions,
+		connection,
+		eventSerializer,
+		l,
+		claimCheck,
+		appMetrics,
+		dependencyMonitor,
+		nil,
+	)
+	producerFactory := rabbitmqproducer.NewProducerFactory(rabbitmqOptions, connection, eventSerializer, l, claimCheck)
+
+	return bus.NewRabbitmqBus(l, consumerFactory, producerFactory, rabbitmqBuilderFunc)
+}
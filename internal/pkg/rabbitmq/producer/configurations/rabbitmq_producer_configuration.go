@@ -5,6 +5,7 @@ import (
 
 	types2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/producer/options"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/types"
 )
@@ -19,6 +20,8 @@ type RabbitMQProducerConfiguration struct {
 	Expiration          string
 	ReplyTo             string
 	ContentEncoding     string
+	// RoutingKeyTemplate creates the routing key of each message from its metadata, it is used over the RoutingKey
+	RoutingKeyTemplate options.RoutingKeyTemplate
 }
 
 func NewDefaultRabbitMQProducerConfiguration(
@@ -35,3 +38,20 @@ func NewDefaultRabbitMQProducerConfiguration(
 		ProducerMessageType: utils.GetMessageBaseReflectType(messageType),
 	}
 }
+
+// ResolveRoutingKey returns the routing key of a message with its metadata, from the routing key template, the routing
+// key or the message type in order
+func (c *RabbitMQProducerConfiguration) ResolveRoutingKey(
+	message types2.IMessage,
+	meta metadata.Metadata,
+) (string, error) {
+	if c.RoutingKeyTemplate != "" {
+		return c.RoutingKeyTemplate.RoutingKey(meta)
+	}
+
+	if c.RoutingKey != "" {
+		return c.RoutingKey, nil
+	}
+
+	return utils.GetRoutingKey(message), nil
+}
@@ -2,6 +2,7 @@ package configurations
 
 import (
 	types2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/producer/options"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/types"
 )
 
@@ -11,6 +12,7 @@ type RabbitMQProducerConfigurationBuilder interface {
 	WithExchangeType(exchangeType types.ExchangeType) RabbitMQProducerConfigurationBuilder
 	WithExchangeName(exchangeName string) RabbitMQProducerConfigurationBuilder
 	WithRoutingKey(routingKey string) RabbitMQProducerConfigurationBuilder
+	WithRoutingKeyTemplate(template string) RabbitMQProducerConfigurationBuilder
 	WithExchangeArgs(args map[string]any) RabbitMQProducerConfigurationBuilder
 	WithDeliveryMode(deliveryMode uint8) RabbitMQProducerConfigurationBuilder
 	WithPriority(priority uint8) RabbitMQProducerConfigurationBuilder
@@ -61,6 +63,18 @@ func (b *rabbitMQProducerConfigurationBuilder) WithRoutingKey(
 	return b
 }
 
+// WithRoutingKeyTemplate creates the routing key of each message from its metadata, e.g. `catalogs.{aggregate}.{event}`
+// is `catalogs.product.created` for `ProductCreatedV1`, so the consumers subscribe to the messages with the wildcards of
+// the topic exchanges, see `options.RoutingKeyTemplate` for the placeholders
+func (b *rabbitMQProducerConfigurationBuilder) WithRoutingKeyTemplate(
+	template string,
+) RabbitMQProducerConfigurationBuilder {
+	b.rabbitmqProducerOptions.RoutingKeyTemplate = options.RoutingKeyTemplate(template)
+	b.rabbitmqProducerOptions.ExchangeOptions.Type = types.ExchangeTopic
+
+	return b
+}
+
 func (b *rabbitMQProducerConfigurationBuilder) WithExchangeName(
 	exchangeName string,
 ) RabbitMQProducerConfigurationBuilder {
@@ -0,0 +1,96 @@
+package producer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/producer/configurations"
+
+	"emperror.dev/errors"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	delayHeader                = "x-delay"
+	delayedMessageExchangeType = "x-delayed-message"
+	// delayQueueExpiry is how long an unused delay queue outlives its ttl, each publish to the queue redeclares it, so
+	// the queue is removed only after its last message is dead-lettered
+	delayQueueExpiry = time.Minute
+)
+
+// DelayedExchangeName is the delayed message exchange of the exchange, it is bound to the exchange with the routing key
+// of the message
+func DelayedExchangeName(exchange string) string {
+	return fmt.Sprintf("%s_delayed", exchange)
+}
+
+// DelayQueueName is the TTL queue of the delayed messages with the same exchange, routing key and delay, the messages
+// with the same delay expire in their publishing order, so each delay has its own queue
+func DelayQueueName(exchange string, routingKey string, delay time.Duration) string {
+	return fmt.Sprintf("%s_%s_delay_%d", exchange, routingKey, delay.Milliseconds())
+}
+
+// ensureDelay declares the topology of the delayed messages and returns the exchange and the routing key which the
+// delayed message is published to
+func (r *rabbitMQProducer) ensureDelay(
+	producerConfiguration *configurations.RabbitMQProducerConfiguration,
+	channel *amqp091.Channel,
+	exchange string,
+	routingKey string,
+	delay time.Duration,
+) (string, string, error) {
+	if r.rabbitmqOptions.DelayedMessageExchange {
+		return r.ensureDelayedExchange(producerConfiguration, channel, exchange, routingKey)
+	}
+
+	queueName := DelayQueueName(exchange, routingKey, delay)
+
+	_, err := channel.QueueDeclare(
+		queueName,
+		true,
+		false,
+		false,
+		false,
+		amqp091.Table{
+			"x-dead-letter-exchange":    exchange,
+			"x-dead-letter-routing-key": routingKey,
+			"x-message-ttl":             delay.Milliseconds(),
+			"x-expires":                 (delay + delayQueueExpiry).Milliseconds(),
+		},
+	)
+	if err != nil {
+		return "", "", errors.WrapIff(err, "error in declaring the delay queue `%s`", queueName)
+	}
+
+	// the default exchange routes the message to the queue with the same name as its routing key
+	return "", queueName, nil
+}
+
+func (r *rabbitMQProducer) ensureDelayedExchange(
+	producerConfiguration *configurations.RabbitMQProducerConfiguration,
+	channel *amqp091.Channel,
+	exchange string,
+	routingKey string,
+) (string, string, error) {
+	delayedExchange := DelayedExchangeName(exchange)
+
+	err := channel.ExchangeDeclare(
+		delayedExchange,
+		delayedMessageExchangeType,
+		producerConfiguration.ExchangeOptions.Durable,
+		false,
+		false,
+		false,
+		amqp091.Table{"x-delayed-type": string(producerConfiguration.ExchangeOptions.Type)},
+	)
+	if err != nil {
+		return "", "", errors.WrapIff(err, "error in declaring the delayed message exchange `%s`", delayedExchange)
+	}
+
+	err = channel.ExchangeBind(exchange, routingKey, delayedExchange, false, nil)
+	if err != nil {
+		return "", "", errors.WrapIff(err, "error in binding the delayed message exchange `%s`", delayedExchange)
+	}
+
+	return delayedExchange, routingKey, nil
+}
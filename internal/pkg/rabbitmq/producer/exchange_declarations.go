@@ -0,0 +1,55 @@
+package producer
+
+import (
+	"sync"
+)
+
+// exchangeDeclarations remembers the exchanges which are declared on the connection, so the publishes don't declare
+// their exchanges again. the declarations are forgotten after a reconnect, because the broker may have lost the
+// exchanges which are not durable, e.g. after its restart, so they are declared again by the next publishes.
+type exchangeDeclarations struct {
+	mu         sync.Mutex
+	reconnects int64
+	declared   map[string]bool
+}
+
+func newExchangeDeclarations() *exchangeDeclarations {
+	return &exchangeDeclarations{declared: make(map[string]bool)}
+}
+
+// ensure declares the exchange when it is not declared since the last reconnect, `reconnects` is the number of the
+// reconnects of the connection
+func (d *exchangeDeclarations) ensure(reconnects int64, exchange string, declare func() error) error {
+	d.mu.Lock()
+	if d.reconnects != reconnects {
+		d.reconnects = reconnects
+		d.declared = make(map[string]bool)
+	}
+	declared := d.declared[exchange]
+	d.mu.Unlock()
+
+	if declared {
+		return nil
+	}
+
+	// the concurrent publishes may declare an exchange more than once, which is a no-op for the broker
+	if err := declare(); err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	if d.reconnects == reconnects {
+		d.declared[exchange] = true
+	}
+	d.mu.Unlock()
+
+	return nil
+}
+
+// forget makes the next publish declare the exchange again
+func (d *exchangeDeclarations) forget(exchange string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.declared, exchange)
+}
@@ -0,0 +1,45 @@
+package producer
+
+import (
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ExchangeDeclarations_Declares_Again_After_Reconnect(t *testing.T) {
+	declarations := newExchangeDeclarations()
+	declared := 0
+	declare := func() error {
+		declared++
+
+		return nil
+	}
+
+	require.NoError(t, declarations.ensure(0, "orders", declare))
+	require.NoError(t, declarations.ensure(0, "orders", declare))
+	assert.Equal(t, 1, declared)
+
+	require.NoError(t, declarations.ensure(1, "orders", declare))
+	assert.Equal(t, 2, declared)
+
+	declarations.forget("orders")
+	require.NoError(t, declarations.ensure(1, "orders", declare))
+	assert.Equal(t, 3, declared)
+}
+
+func Test_ExchangeDeclarations_Does_Not_Remember_Failed_Declaration(t *testing.T) {
+	declarations := newExchangeDeclarations()
+
+	err := declarations.ensure(0, "orders", func() error { return errors.New("access refused") })
+	require.Error(t, err)
+
+	declared := false
+	require.NoError(t, declarations.ensure(0, "orders", func() error {
+		declared = true
+
+		return nil
+	}))
+	assert.True(t, declared)
+}
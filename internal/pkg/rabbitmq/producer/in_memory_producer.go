@@ -0,0 +1,180 @@
+package producer
+
+import (
+	"context"
+	"time"
+
+	messageHeader "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/messageheader"
+	producer3 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/otel/tracing/producer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/producer"
+	types2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/inmemory"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/producer/configurations"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/types"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// inMemoryProducer publishes the messages to the in-memory broker with the exchanges and the routing keys of the
+// rabbitmq producer, the payloads stay in the process so they are never offloaded to the claim-check store.
+type inMemoryProducer struct {
+	*rabbitMQProducer
+	broker *inmemory.Broker
+}
+
+// NewInMemoryProducer create a new producer on the in-memory broker
+func NewInMemoryProducer(
+	cfg *config.RabbitmqOptions,
+	rabbitmqProducersConfiguration map[string]*configurations.RabbitMQProducerConfiguration,
+	logger logger.Logger,
+	eventSerializer serializer.MessageSerializer,
+	broker *inmemory.Broker,
+	isProducedNotifications ...func(message types2.IMessage),
+) (producer.Producer, error) {
+	rabbitmqProducer, err := NewRabbitMQProducer(
+		cfg,
+		types.NewInMemoryConnection(),
+		rabbitmqProducersConfiguration,
+		logger,
+		eventSerializer,
+		nil,
+		isProducedNotifications...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &inMemoryProducer{
+		rabbitMQProducer: rabbitmqProducer.(*rabbitMQProducer),
+		broker:           broker,
+	}, nil
+}
+
+func (r *inMemoryProducer) PublishMessage(
+	ctx context.Context,
+	message types2.IMessage,
+	meta metadata.Metadata,
+) error {
+	return r.PublishMessageWithTopicName(ctx, message, meta, "")
+}
+
+func (r *inMemoryProducer) PublishMessageWithTopicName(
+	ctx context.Context,
+	message types2.IMessage,
+	meta metadata.Metadata,
+	topicOrExchangeName string,
+) error {
+	return r.publish(ctx, message, meta, topicOrExchangeName, 0)
+}
+
+// PublishMessageWithDelay keeps the delayed message in the process until its delay passes, so a delayed message is
+// lost when the process stops before its delivery
+func (r *inMemoryProducer) PublishMessageWithDelay(
+	ctx context.Context,
+	message types2.IMessage,
+	meta metadata.Metadata,
+	delay time.Duration,
+) error {
+	return r.publish(ctx, message, meta, "", delay)
+}
+
+// PublishMessages publishes the messages one by one, the in-memory broker has no confirm round-trips to batch
+func (r *inMemoryProducer) PublishMessages(ctx context.Context, messages []types2.IMessage) error {
+	for _, message := range messages {
+		if err := r.PublishMessage(ctx, message, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *inMemoryProducer) publish(
+	ctx context.Context,
+	message types2.IMessage,
+	meta metadata.Metadata,
+	topicOrExchangeName string,
+	delay time.Duration,
+) error {
+	if r.isDuplicate(message) {
+		return nil
+	}
+
+	producerConfiguration := r.getProducerConfigurationByMessage(message)
+
+	var exchange string
+
+	if topicOrExchangeName != "" {
+		exchange = topicOrExchangeName
+	} else if producerConfiguration != nil && producerConfiguration.ExchangeOptions.Name != "" {
+		exchange = producerConfiguration.ExchangeOptions.Name
+	} else {
+		exchange = utils.GetTopicOrExchangeName(message)
+	}
+
+	meta = r.getMetadata(message, meta)
+
+	routingKey := utils.GetRoutingKey(message)
+	if producerConfiguration != nil {
+		var err error
+		routingKey, err = producerConfiguration.ResolveRoutingKey(message, meta)
+		if err != nil {
+			return err
+		}
+	}
+
+	producerOptions := &producer3.ProducerTracingOptions{
+		MessagingSystem: "rabbitmq",
+		DestinationKind: "exchange",
+		Destination:     exchange,
+		OtherAttributes: []attribute.KeyValue{
+			semconv.MessagingRabbitmqDestinationRoutingKey(routingKey),
+		},
+	}
+
+	serializedObj, err := r.messageSerializer.Serialize(message)
+	if err != nil {
+		return err
+	}
+
+	ctx, beforeProduceSpan := producer3.StartProducerSpan(
+		ctx,
+		message,
+		&meta,
+		string(serializedObj.Data),
+		producerOptions,
+	)
+
+	delivery := inmemory.Delivery{
+		Exchange:      exchange,
+		RoutingKey:    routingKey,
+		MessageId:     message.GeMessageId(),
+		CorrelationId: messageHeader.GetCorrelationId(meta),
+		Type:          message.GetMessageTypeName(),
+		ContentType:   serializedObj.ContentType,
+		Timestamp:     time.Now(),
+		Headers:       metadata.MetadataToMap(meta),
+		Body:          serializedObj.Data,
+	}
+
+	if delay > 0 {
+		// the request context of the publisher is usually done before the delay passes
+		time.AfterFunc(delay, func() {
+			if err := r.broker.Publish(context.Background(), delivery); err != nil {
+				r.logger.Errorf("error in publishing the delayed message with id: {%s}, err: %v", delivery.MessageId, err)
+			}
+		})
+	} else if err := r.broker.Publish(ctx, delivery); err != nil {
+		return producer3.FinishProducerSpan(beforeProduceSpan, err)
+	}
+
+	r.notifyProduced(message)
+
+	return producer3.FinishProducerSpan(beforeProduceSpan, nil)
+}
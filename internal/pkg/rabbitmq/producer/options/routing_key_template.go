@@ -0,0 +1,90 @@
+package options
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	messageHeader "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/messageheader"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+
+	"emperror.dev/errors"
+)
+
+const (
+	// AggregatePlaceholder is the name of the message without its version and its last word, e.g. `product` for
+	// `product_created_v1`
+	AggregatePlaceholder = "aggregate"
+	// EventPlaceholder is the last word of the name of the message without its version, e.g. `created` for
+	// `product_created_v1`
+	EventPlaceholder = "event"
+	// VersionPlaceholder is the version suffix of the name of the message, e.g. `v1` for `product_created_v1`
+	VersionPlaceholder = "version"
+)
+
+var (
+	placeholderRegex = regexp.MustCompile(`\{([^{}]+)\}`)
+	versionRegex     = regexp.MustCompile(`^v\d+$`)
+)
+
+// RoutingKeyTemplate creates the routing key of a message from its metadata, a `{key}` placeholder is replaced with the
+// value of the key in the metadata of the message, e.g. `{name}` or `{tenant-id}`, and `{aggregate}`, `{event}` and
+// `{version}` are derived from the name of the message, so `catalogs.{aggregate}.{event}` is `catalogs.product.created`
+// for `ProductCreatedV1`.
+type RoutingKeyTemplate string
+
+// RoutingKey returns the routing key of the message with the metadata, a placeholder without a value in the metadata
+// is an error, so a message is not published with a routing key which its subscribers don't expect
+func (t RoutingKeyTemplate) RoutingKey(meta metadata.Metadata) (string, error) {
+	values := nameValues(messageHeader.GetMessageName(meta))
+
+	var missing []string
+	routingKey := placeholderRegex.ReplaceAllStringFunc(string(t), func(placeholder string) string {
+		key := placeholder[1 : len(placeholder)-1]
+
+		if value, ok := values[key]; ok {
+			return value
+		}
+
+		if value := meta.Get(key); value != nil && fmt.Sprint(value) != "" {
+			return fmt.Sprint(value)
+		}
+
+		missing = append(missing, key)
+
+		return ""
+	})
+
+	if len(missing) > 0 {
+		return "", errors.Errorf(
+			"routing key template `%s` has no value for %s in the message metadata",
+			t,
+			strings.Join(missing, ", "),
+		)
+	}
+
+	return routingKey, nil
+}
+
+// nameValues splits the snake case name of a message to its aggregate, its event and its version
+func nameValues(name string) map[string]string {
+	words := strings.Split(name, "_")
+	if len(words) < 2 {
+		return nil
+	}
+
+	values := map[string]string{}
+	if versionRegex.MatchString(words[len(words)-1]) {
+		values[VersionPlaceholder] = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+
+	if len(words) < 2 {
+		return values
+	}
+
+	values[AggregatePlaceholder] = strings.Join(words[:len(words)-1], "_")
+	values[EventPlaceholder] = words[len(words)-1]
+
+	return values
+}
@@ -0,0 +1,45 @@
+package options
+
+import (
+	"testing"
+
+	messageHeader "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/messageheader"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Routing_Key_Template_From_Message_Name(t *testing.T) {
+	meta := metadata.Metadata{}
+	messageHeader.SetMessageName(meta, "product_created_v1")
+
+	routingKey, err := RoutingKeyTemplate("catalogs.{aggregate}.{event}.{version}").RoutingKey(meta)
+	require.NoError(t, err)
+	assert.Equal(t, "catalogs.product.created.v1", routingKey)
+
+	messageHeader.SetMessageName(meta, "product_merchandising_changed")
+
+	routingKey, err = RoutingKeyTemplate("catalogs.{aggregate}.{event}").RoutingKey(meta)
+	require.NoError(t, err)
+	assert.Equal(t, "catalogs.product_merchandising.changed", routingKey)
+}
+
+func Test_Routing_Key_Template_From_Metadata(t *testing.T) {
+	meta := metadata.Metadata{}
+	messageHeader.SetMessageName(meta, "order_created_v1")
+	meta.Set(messageHeader.TenantId, "tenant-1")
+
+	routingKey, err := RoutingKeyTemplate("{tenant-id}.orders.{name}").RoutingKey(meta)
+	require.NoError(t, err)
+	assert.Equal(t, "tenant-1.orders.order_created_v1", routingKey)
+}
+
+func Test_Routing_Key_Template_Without_Metadata_Value(t *testing.T) {
+	meta := metadata.Metadata{}
+	messageHeader.SetMessageName(meta, "v1")
+
+	_, err := RoutingKeyTemplate("{tenant-id}.{aggregate}").RoutingKey(meta)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "tenant-id, aggregate")
+}
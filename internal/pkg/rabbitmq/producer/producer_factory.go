@@ -1,11 +1,13 @@
 package producer
 
 import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/claimcheck"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/producer"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
 	serializer "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/inmemory"
 	producerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/producer/configurations"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/producer/producercontracts"
 	types2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/types"
@@ -16,6 +18,7 @@ type producerFactory struct {
 	logger          logger.Logger
 	eventSerializer serializer.MessageSerializer
 	rabbitmqOptions *config.RabbitmqOptions
+	claimCheck      claimcheck.ClaimCheck
 }
 
 func NewProducerFactory(
@@ -23,8 +26,10 @@ func NewProducerFactory(
 	connection types2.IConnection,
 	eventSerializer serializer.MessageSerializer,
 	l logger.Logger,
+	claimCheck claimcheck.ClaimCheck,
 ) producercontracts.ProducerFactory {
 	return &producerFactory{
+		claimCheck:      claimCheck,
 		rabbitmqOptions: rabbitmqOptions,
 		logger:          l,
 		connection:      connection,
@@ -36,11 +41,22 @@ func (p *producerFactory) CreateProducer(
 	rabbitmqProducersConfiguration map[string]*producerConfigurations.RabbitMQProducerConfiguration,
 	isProducedNotifications ...func(message types.IMessage),
 ) (producer.Producer, error) {
+	if p.rabbitmqOptions.UseInMemory {
+		return NewInMemoryProducer(
+			p.rabbitmqOptions,
+			rabbitmqProducersConfiguration,
+			p.logger,
+			p.eventSerializer,
+			inmemory.DefaultBroker(),
+			isProducedNotifications...)
+	}
+
 	return NewRabbitMQProducer(
 		p.rabbitmqOptions,
 		p.connection,
 		rabbitmqProducersConfiguration,
 		p.logger,
 		p.eventSerializer,
+		p.claimCheck,
 		isProducedNotifications...)
 }
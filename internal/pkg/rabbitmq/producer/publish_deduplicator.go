@@ -0,0 +1,94 @@
+package producer
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/config"
+)
+
+// publishDeduplicator keeps the ids of the published messages for the deduplication window, the ids are kept in their
+// publishing order, so the expired and the oldest ids are removed from the front of the list
+type publishDeduplicator struct {
+	window     time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu        sync.Mutex
+	published map[string]*list.Element
+	order     *list.List
+}
+
+type publishedMessage struct {
+	messageId   string
+	publishedAt time.Time
+}
+
+func newPublishDeduplicator(options config.RabbitmqPublishDeduplicationOptions) *publishDeduplicator {
+	if !options.Enabled {
+		return nil
+	}
+
+	return &publishDeduplicator{
+		window:     options.GetWindow(),
+		maxEntries: options.GetMaxEntries(),
+		now:        time.Now,
+		published:  make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// IsPublished reports whether the message is published in the deduplication window, the messages without an id are
+// never deduplicated
+func (d *publishDeduplicator) IsPublished(messageId string) bool {
+	if d == nil || messageId == "" {
+		return false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.removeExpired()
+
+	_, ok := d.published[messageId]
+
+	return ok
+}
+
+// Published keeps the id of the message after the broker confirms its publish
+func (d *publishDeduplicator) Published(messageId string) {
+	if d == nil || messageId == "" {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if element, ok := d.published[messageId]; ok {
+		d.order.Remove(element)
+	}
+
+	d.published[messageId] = d.order.PushBack(&publishedMessage{messageId: messageId, publishedAt: d.now()})
+
+	for d.order.Len() > d.maxEntries {
+		d.remove(d.order.Front())
+	}
+}
+
+func (d *publishDeduplicator) removeExpired() {
+	now := d.now()
+
+	for element := d.order.Front(); element != nil; element = d.order.Front() {
+		if now.Sub(element.Value.(*publishedMessage).publishedAt) < d.window {
+			return
+		}
+
+		d.remove(element)
+	}
+}
+
+func (d *publishDeduplicator) remove(element *list.Element) {
+	d.order.Remove(element)
+	delete(d.published, element.Value.(*publishedMessage).messageId)
+}
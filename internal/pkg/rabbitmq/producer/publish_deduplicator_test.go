@@ -0,0 +1,52 @@
+package producer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPublishDeduplicator(window time.Duration, maxEntries int) (*publishDeduplicator, *time.Time) {
+	now := time.Now()
+	deduplicator := newPublishDeduplicator(
+		config.RabbitmqPublishDeduplicationOptions{Enabled: true, Window: window, MaxEntries: maxEntries},
+	)
+	deduplicator.now = func() time.Time { return now }
+
+	return deduplicator, &now
+}
+
+func Test_Published_Message_Is_Deduplicated_In_The_Window(t *testing.T) {
+	deduplicator, now := newTestPublishDeduplicator(time.Minute, 10)
+
+	assert.False(t, deduplicator.IsPublished("message-1"))
+	deduplicator.Published("message-1")
+	assert.True(t, deduplicator.IsPublished("message-1"))
+	assert.False(t, deduplicator.IsPublished("message-2"))
+
+	*now = now.Add(time.Minute)
+	assert.False(t, deduplicator.IsPublished("message-1"))
+}
+
+func Test_Oldest_Published_Messages_Are_Removed_Over_Max_Entries(t *testing.T) {
+	deduplicator, _ := newTestPublishDeduplicator(time.Minute, 2)
+
+	deduplicator.Published("message-1")
+	deduplicator.Published("message-2")
+	deduplicator.Published("message-3")
+
+	assert.False(t, deduplicator.IsPublished("message-1"))
+	assert.True(t, deduplicator.IsPublished("message-2"))
+	assert.True(t, deduplicator.IsPublished("message-3"))
+}
+
+func Test_Disabled_Deduplication_Publishes_All_Messages(t *testing.T) {
+	deduplicator := newPublishDeduplicator(config.RabbitmqPublishDeduplicationOptions{})
+
+	deduplicator.Published("message-1")
+	assert.False(t, deduplicator.IsPublished("message-1"))
+	assert.False(t, deduplicator.IsPublished(""))
+}
@@ -2,8 +2,10 @@ package producer
 
 import (
 	"context"
+	"strconv"
 	"time"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/claimcheck"
 	messageHeader "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/messageheader"
 	producer3 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/otel/tracing/producer"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/producer"
@@ -18,17 +20,23 @@ import (
 
 	"emperror.dev/errors"
 	"github.com/rabbitmq/amqp091-go"
+	"github.com/samber/lo"
 	uuid "github.com/satori/go.uuid"
 	"go.opentelemetry.io/otel/attribute"
 	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type rabbitMQProducer struct {
 	logger                  logger.Logger
 	rabbitmqOptions         *config.RabbitmqOptions
 	connection              types.IConnection
+	channels                types.ChannelPool
+	exchanges               *exchangeDeclarations
 	messageSerializer       serializer.MessageSerializer
 	producersConfigurations map[string]*configurations.RabbitMQProducerConfiguration
+	claimCheck              claimcheck.ClaimCheck
+	deduplicator            *publishDeduplicator
 	isProducedNotifications []func(message types2.IMessage)
 }
 
@@ -38,14 +46,19 @@ func NewRabbitMQProducer(
 	rabbitmqProducersConfiguration map[string]*configurations.RabbitMQProducerConfiguration,
 	logger logger.Logger,
 	eventSerializer serializer.MessageSerializer,
+	claimCheck claimcheck.ClaimCheck,
 	isProducedNotifications ...func(message types2.IMessage),
 ) (producer.Producer, error) {
 	p := &rabbitMQProducer{
+		claimCheck:              claimCheck,
 		logger:                  logger,
 		rabbitmqOptions:         cfg,
 		connection:              connection,
+		channels:                types.NewChannelPool(connection, cfg.ChannelPoolOptions.GetSize()),
+		exchanges:               newExchangeDeclarations(),
 		messageSerializer:       eventSerializer,
 		producersConfigurations: rabbitmqProducersConfiguration,
+		deduplicator:            newPublishDeduplicator(cfg.PublishDeduplicationOptions),
 	}
 
 	p.isProducedNotifications = isProducedNotifications
@@ -65,6 +78,23 @@ func (r *rabbitMQProducer) PublishMessage(
 	return r.PublishMessageWithTopicName(ctx, message, meta, "")
 }
 
+func publishOptions(
+	producerConfiguration *configurations.RabbitMQProducerConfiguration,
+	meta metadata.Metadata,
+) (priority uint8, expiration string) {
+	priority, expiration = producerConfiguration.Priority, producerConfiguration.Expiration
+
+	if value, ok := producer.GetPriority(meta); ok {
+		priority = value
+	}
+
+	if value, ok := producer.GetExpiration(meta); ok {
+		expiration = strconv.FormatInt(value.Milliseconds(), 10)
+	}
+
+	return priority, expiration
+}
+
 func (r *rabbitMQProducer) getProducerConfigurationByMessage(
 	message types2.IMessage,
 ) *configurations.RabbitMQProducerConfiguration {
@@ -78,6 +108,37 @@ func (r *rabbitMQProducer) PublishMessageWithTopicName(
 	meta metadata.Metadata,
 	topicOrExchangeName string,
 ) error {
+	return r.publish(ctx, message, meta, topicOrExchangeName, 0)
+}
+
+func (r *rabbitMQProducer) PublishMessageWithDelay(
+	ctx context.Context,
+	message types2.IMessage,
+	meta metadata.Metadata,
+	delay time.Duration,
+) error {
+	return r.publish(ctx, message, meta, "", delay)
+}
+
+// outgoingMessage is a serialized message with its producer span, which is finished after the publish of the message
+type outgoingMessage struct {
+	ctx                   context.Context
+	message               types2.IMessage
+	meta                  metadata.Metadata
+	producerConfiguration *configurations.RabbitMQProducerConfiguration
+	exchange              string
+	routingKey            string
+	body                  []byte
+	contentType           string
+	span                  trace.Span
+}
+
+func (r *rabbitMQProducer) prepare(
+	ctx context.Context,
+	message types2.IMessage,
+	meta metadata.Metadata,
+	topicOrExchangeName string,
+) (*outgoingMessage, error) {
 	producerConfiguration := r.getProducerConfigurationByMessage(message)
 
 	if producerConfiguration == nil {
@@ -87,7 +148,6 @@ func (r *rabbitMQProducer) PublishMessageWithTopicName(
 	}
 
 	var exchange string
-	var routingKey string
 
 	if topicOrExchangeName != "" {
 		exchange = topicOrExchangeName
@@ -97,14 +157,13 @@ func (r *rabbitMQProducer) PublishMessageWithTopicName(
 		exchange = utils.GetTopicOrExchangeName(message)
 	}
 
-	if producerConfiguration != nil && producerConfiguration.RoutingKey != "" {
-		routingKey = producerConfiguration.RoutingKey
-	} else {
-		routingKey = utils.GetRoutingKey(message)
-	}
-
 	meta = r.getMetadata(message, meta)
 
+	routingKey, err := producerConfiguration.ResolveRoutingKey(message, meta)
+	if err != nil {
+		return nil, err
+	}
+
 	producerOptions := &producer3.ProducerTracingOptions{
 		MessagingSystem: "rabbitmq",
 		DestinationKind: "exchange",
@@ -116,17 +175,89 @@ func (r *rabbitMQProducer) PublishMessageWithTopicName(
 
 	serializedObj, err := r.messageSerializer.Serialize(message)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	body := serializedObj.Data
+
 	ctx, beforeProduceSpan := producer3.StartProducerSpan(
 		ctx,
 		message,
 		&meta,
-		string(serializedObj.Data),
+		string(body),
 		producerOptions,
 	)
 
+	// the large payloads are offloaded to the blob store and a claim-check reference is published instead
+	if r.claimCheck != nil {
+		body, err = r.claimCheck.Offload(ctx, body, meta)
+		if err != nil {
+			return nil, producer3.FinishProducerSpan(beforeProduceSpan, err)
+		}
+	}
+
+	return &outgoingMessage{
+		ctx:                   ctx,
+		message:               message,
+		meta:                  meta,
+		producerConfiguration: producerConfiguration,
+		exchange:              exchange,
+		routingKey:            routingKey,
+		body:                  body,
+		contentType:           serializedObj.ContentType,
+		span:                  beforeProduceSpan,
+	}, nil
+}
+
+// publishing returns the amqp message with the publish options of the message, the publish options override the
+// options of the producer configuration, they are amqp properties and are not sent as headers
+func (m *outgoingMessage) publishing() amqp091.Publishing {
+	priority, expiration := publishOptions(m.producerConfiguration, m.meta)
+
+	// the reply address of a request overrides the reply address of the producer configuration
+	replyTo := m.producerConfiguration.ReplyTo
+	if requestReplyTo := messageHeader.GetReplyTo(m.meta); requestReplyTo != "" {
+		replyTo = requestReplyTo
+	}
+
+	return amqp091.Publishing{
+		CorrelationId: messageHeader.GetCorrelationId(m.meta),
+		MessageId:     m.message.GeMessageId(),
+		Timestamp:     time.Now(),
+		Headers: lo.OmitByKeys(
+			metadata.MetadataToMap(m.meta),
+			[]string{messageHeader.Priority, messageHeader.Expiration},
+		),
+		Type:            m.message.GetMessageTypeName(), // typeMapper.GetTypeName(message) - just message type name not full type name because in other side package name for type could be different
+		ContentType:     m.contentType,
+		Body:            m.body,
+		DeliveryMode:    m.producerConfiguration.DeliveryMode,
+		Expiration:      expiration,
+		AppId:           m.producerConfiguration.AppId,
+		Priority:        priority,
+		ReplyTo:         replyTo,
+		ContentEncoding: m.producerConfiguration.ContentEncoding,
+	}
+}
+
+func (r *rabbitMQProducer) publish(
+	ctx context.Context,
+	message types2.IMessage,
+	meta metadata.Metadata,
+	topicOrExchangeName string,
+	delay time.Duration,
+) error {
+	if r.isDuplicate(message) {
+		return nil
+	}
+
+	out, err := r.prepare(ctx, message, meta, topicOrExchangeName)
+	if err != nil {
+		return err
+	}
+
+	ctx, beforeProduceSpan := out.ctx, out.span
+
 	// https://github.com/rabbitmq/rabbitmq-tutorials/blob/master/go/publisher_confirms.go
 	if r.connection == nil {
 		return producer3.FinishProducerSpan(
@@ -135,76 +266,247 @@ func (r *rabbitMQProducer) PublishMessageWithTopicName(
 		)
 	}
 
-	if r.connection.IsClosed() {
+	// during a reconnect the publish waits (buffered) for the connection instead of failing immediately
+	if err := r.connection.WaitForConnection(ctx); err != nil {
 		return producer3.FinishProducerSpan(
 			beforeProduceSpan,
-			errors.New("connection is closed, wait for connection alive"),
+			errors.WrapIf(err, "connection is not alive"),
 		)
 	}
 
-	// create a unique channel on the connection and in the end close the channel
-	channel, err := r.connection.Channel()
+	channel, err := r.channels.Get(ctx)
 	if err != nil {
 		return producer3.FinishProducerSpan(beforeProduceSpan, err)
 	}
-	defer channel.Close()
+	defer r.channels.Put(channel)
+
+	// a channel of a failed publish is not reused, a failed declaration closes it and its confirm may still arrive. its
+	// exchange is declared again by the next publish, e.g. when the exchange is deleted and the publish closed the channel
+	fail := func(err error) error {
+		channel.Discard()
+		r.exchanges.forget(out.exchange)
 
-	err = r.ensureExchange(producerConfiguration, channel, exchange)
-	if err != nil {
 		return producer3.FinishProducerSpan(beforeProduceSpan, err)
 	}
 
-	if err := channel.Confirm(false); err != nil {
-		return producer3.FinishProducerSpan(beforeProduceSpan, err)
+	if messageHeader.GetInReplyTo(out.meta) != "" {
+		// the reply exchange of a request is declared by the requester with its own options
+		err = channel.ExchangeDeclarePassive(out.exchange, string(types.ExchangeTopic), false, true, false, false, nil)
+	} else {
+		err = r.exchanges.ensure(r.connection.Stats().Reconnects, out.exchange, func() error {
+			return r.ensureExchange(out.producerConfiguration, channel.Channel, out.exchange)
+		})
 	}
+	if err != nil {
+		return fail(err)
+	}
+
+	props := out.publishing()
+	publishExchange, publishRoutingKey := out.exchange, out.routingKey
 
-	confirms := make(chan amqp091.Confirmation)
-	channel.NotifyPublish(confirms)
+	if delay > 0 {
+		publishExchange, publishRoutingKey, err = r.ensureDelay(
+			out.producerConfiguration,
+			channel.Channel,
+			out.exchange,
+			out.routingKey,
+			delay,
+		)
+		if err != nil {
+			return fail(err)
+		}
 
-	props := amqp091.Publishing{
-		CorrelationId:   messageHeader.GetCorrelationId(meta),
-		MessageId:       message.GeMessageId(),
-		Timestamp:       time.Now(),
-		Headers:         metadata.MetadataToMap(meta),
-		Type:            message.GetMessageTypeName(), // typeMapper.GetTypeName(message) - just message type name not full type name because in other side package name for type could be different
-		ContentType:     serializedObj.ContentType,
-		Body:            serializedObj.Data,
-		DeliveryMode:    producerConfiguration.DeliveryMode,
-		Expiration:      producerConfiguration.Expiration,
-		AppId:           producerConfiguration.AppId,
-		Priority:        producerConfiguration.Priority,
-		ReplyTo:         producerConfiguration.ReplyTo,
-		ContentEncoding: producerConfiguration.ContentEncoding,
+		if r.rabbitmqOptions.DelayedMessageExchange {
+			// a new map, so the delay header is not added to the metadata of the caller
+			props.Headers = lo.Assign(props.Headers, map[string]interface{}{delayHeader: delay.Milliseconds()})
+		} else {
+			// a message which expires in the delay queue is dead-lettered before its delay, and the dead-lettering
+			// removes its expiration, so the expiration isn't applied to the messages of the delay queues
+			props.Expiration = ""
+		}
 	}
 
 	err = channel.PublishWithContext(
 		ctx,
-		exchange,
-		routingKey,
+		publishExchange,
+		publishRoutingKey,
 		true,
 		false,
 		props,
 	)
 	if err != nil {
-		return producer3.FinishProducerSpan(beforeProduceSpan, err)
+		return fail(err)
 	}
 
-	if confirmed := <-confirms; !confirmed.Ack {
-		return producer3.FinishProducerSpan(
-			beforeProduceSpan,
-			errors.New("ack not confirmed"),
-		)
+	select {
+	case confirmed, ok := <-channel.Confirms():
+		if !ok {
+			return fail(errors.New("channel closed before the publisher confirm"))
+		}
+
+		if !confirmed.Ack {
+			return producer3.FinishProducerSpan(
+				beforeProduceSpan,
+				errors.New("ack not confirmed"),
+			)
+		}
+	case <-ctx.Done():
+		return fail(errors.WrapIf(ctx.Err(), "waiting for the publisher confirm canceled"))
+	}
+
+	r.notifyProduced(message)
+
+	return producer3.FinishProducerSpan(beforeProduceSpan, err)
+}
+
+// PublishMessages publishes the messages on a single channel and awaits their publisher confirms in batches, after
+// `publishBatchOptions.batchSize` messages or `publishBatchOptions.flushInterval`, instead of a confirm round-trip per
+// message. the batch has its own channel out of the channel pool, because its confirms are buffered by the batch size
+func (r *rabbitMQProducer) PublishMessages(ctx context.Context, messages []types2.IMessage) error {
+	if len(messages) == 0 {
+		return nil
 	}
 
-	if len(r.isProducedNotifications) > 0 {
-		for _, notification := range r.isProducedNotifications {
-			if notification != nil {
-				notification(message)
+	if r.connection == nil {
+		return errors.New("connection is nil")
+	}
+
+	if err := r.connection.WaitForConnection(ctx); err != nil {
+		return errors.WrapIf(err, "connection is not alive")
+	}
+
+	channel, err := r.connection.Channel()
+	if err != nil {
+		return err
+	}
+	defer channel.Close()
+
+	if err := channel.Confirm(false); err != nil {
+		return err
+	}
+
+	batchSize := r.rabbitmqOptions.PublishBatchOptions.GetBatchSize()
+	flushInterval := r.rabbitmqOptions.PublishBatchOptions.GetFlushInterval()
+
+	// the confirms of a batch are buffered, so the channel doesn't block on the confirms before the batch is flushed
+	confirms := channel.NotifyPublish(make(chan amqp091.Confirmation, batchSize))
+
+	reconnects := r.connection.Stats().Reconnects
+	pending := make([]*outgoingMessage, 0, batchSize)
+	lastFlush := time.Now()
+
+	for _, message := range messages {
+		if r.isDuplicate(message) {
+			continue
+		}
+
+		out, err := r.prepare(ctx, message, nil, "")
+		if err != nil {
+			return errors.Combine(err, r.awaitConfirms(ctx, confirms, pending))
+		}
+
+		err = r.exchanges.ensure(reconnects, out.exchange, func() error {
+			return r.ensureExchange(out.producerConfiguration, channel, out.exchange)
+		})
+		if err != nil {
+			return errors.Combine(
+				producer3.FinishProducerSpan(out.span, err),
+				r.awaitConfirms(ctx, confirms, pending),
+			)
+		}
+
+		err = channel.PublishWithContext(out.ctx, out.exchange, out.routingKey, true, false, out.publishing())
+		if err != nil {
+			return errors.Combine(
+				producer3.FinishProducerSpan(out.span, err),
+				r.awaitConfirms(ctx, confirms, pending),
+			)
+		}
+
+		pending = append(pending, out)
+
+		if len(pending) >= batchSize || time.Since(lastFlush) >= flushInterval {
+			if err := r.awaitConfirms(ctx, confirms, pending); err != nil {
+				return err
 			}
+
+			pending = pending[:0]
+			lastFlush = time.Now()
 		}
 	}
 
-	return producer3.FinishProducerSpan(beforeProduceSpan, err)
+	return r.awaitConfirms(ctx, confirms, pending)
+}
+
+// awaitConfirms waits for the publisher confirms of the pending messages, the confirms arrive in the publishing order
+func (r *rabbitMQProducer) awaitConfirms(
+	ctx context.Context,
+	confirms <-chan amqp091.Confirmation,
+	pending []*outgoingMessage,
+) error {
+	nacked := 0
+
+	for i, out := range pending {
+		select {
+		case <-ctx.Done():
+			for _, canceled := range pending[i:] {
+				_ = producer3.FinishProducerSpan(canceled.span, ctx.Err())
+			}
+
+			return ctx.Err()
+		case confirmed, ok := <-confirms:
+			if !ok {
+				err := errors.New("channel closed before the publisher confirms")
+				for _, unconfirmed := range pending[i:] {
+					_ = producer3.FinishProducerSpan(unconfirmed.span, err)
+				}
+
+				return err
+			}
+
+			if !confirmed.Ack {
+				nacked++
+				_ = producer3.FinishProducerSpan(out.span, errors.New("ack not confirmed"))
+
+				continue
+			}
+
+			r.notifyProduced(out.message)
+			_ = producer3.FinishProducerSpan(out.span, nil)
+		}
+	}
+
+	if nacked > 0 {
+		return errors.Errorf("ack not confirmed for %d of %d messages", nacked, len(pending))
+	}
+
+	return nil
+}
+
+// isDuplicate reports whether the message is already published in the deduplication window, e.g. by a handler which is
+// retried after its publish is confirmed
+func (r *rabbitMQProducer) isDuplicate(message types2.IMessage) bool {
+	if !r.deduplicator.IsPublished(message.GeMessageId()) {
+		return false
+	}
+
+	r.logger.Infof(
+		"message with id: {%s} and type: {%s} is already published, the publish is skipped",
+		message.GeMessageId(),
+		message.GetMessageTypeName(),
+	)
+
+	return true
+}
+
+func (r *rabbitMQProducer) notifyProduced(message types2.IMessage) {
+	r.deduplicator.Published(message.GeMessageId())
+
+	for _, notification := range r.isProducedNotifications {
+		if notification != nil {
+			notification(message)
+		}
+	}
 }
 
 func (r *rabbitMQProducer) getMetadata(
@@ -0,0 +1,75 @@
+package quarantine
+
+import (
+	"context"
+	"fmt"
+
+	messagingQuarantine "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/quarantine"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/consumer/options"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/types"
+
+	"emperror.dev/errors"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+type messageReplayer struct {
+	connection types.IConnection
+	logger     logger.Logger
+}
+
+// NewMessageReplayer replays the quarantined messages to their consumer queues through the default exchange, so the
+// other queues of the exchange don't receive them again
+func NewMessageReplayer(connection types.IConnection, logger logger.Logger) messagingQuarantine.MessageReplayer {
+	return &messageReplayer{connection: connection, logger: logger}
+}
+
+func (m *messageReplayer) Replay(ctx context.Context, message *messagingQuarantine.QuarantinedMessage) error {
+	ch, err := m.connection.Channel()
+	if err != nil {
+		return errors.WrapIf(err, "error in opening a channel for replaying the quarantined message")
+	}
+	defer ch.Close()
+
+	// the message is removed from the quarantine only after the broker confirms its publish
+	if err := ch.Confirm(false); err != nil {
+		return errors.WrapIf(err, "error in enabling the publisher confirms")
+	}
+
+	headers := amqp091.Table{}
+	for key, value := range message.Headers {
+		headers[key] = value
+	}
+	// the replayed message gets all the retries of the consumer again
+	delete(headers, options.DeadLetterRetryCountHeader)
+	delete(headers, messagingQuarantine.ErrorHistoryHeader)
+
+	confirmation, err := ch.PublishWithDeferredConfirmWithContext(ctx, "", message.Queue, false, false, amqp091.Publishing{
+		Headers:       headers,
+		ContentType:   message.ContentType,
+		DeliveryMode:  amqp091.Persistent,
+		CorrelationId: message.CorrelationId,
+		MessageId:     message.MessageId,
+		Timestamp:     message.MessageTimestamp,
+		Type:          message.MessageType,
+		Body:          message.Body,
+	})
+	if err != nil {
+		return errors.WrapIf(err, "error in replaying the quarantined message")
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return errors.WrapIf(err, "error in waiting for the replay confirmation")
+	}
+	if !acked {
+		return errors.New("replay of the quarantined message is not confirmed by the broker")
+	}
+
+	m.logger.Infow(
+		fmt.Sprintf("quarantined message with id: {%s} replayed to %s", message.MessageId, message.Queue),
+		logger.Fields{"MessageId": message.MessageId, "QuarantineId": message.Id},
+	)
+
+	return nil
+}
@@ -4,4 +4,8 @@ import (
 	"emperror.dev/errors"
 )
 
-var ErrDisconnected = errors.New("disconnected from rabbitmq, trying to reconnect")
+var (
+	ErrDisconnected      = errors.New("disconnected from rabbitmq, trying to reconnect")
+	ErrConnectionClosed  = errors.New("rabbitmq connection is closed")
+	ErrPublishBufferFull = errors.New("rabbitmq publish buffer is full, too many publishes are waiting for reconnect")
+)
@@ -6,13 +6,19 @@ import (
 	"time"
 
 	bus2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/bus"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/claimcheck"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/encryption"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/producer"
+	messagingQuarantine "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/quarantine"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/health/contracts"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/bus"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/config"
 	rabbitmqconsumer "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/consumer"
 	rabbitmqproducer "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/producer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/quarantine"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/topology"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/types"
 
 	"go.uber.org/fx"
@@ -24,6 +30,8 @@ var (
 	ModuleFunc = func(rabbitMQConfigurationConstructor interface{}) fx.Option { //nolint:gochecknoglobals
 		return fx.Module(
 			"rabbitmqfx",
+			claimcheck.Module,
+			encryption.Module,
 			fx.Provide(rabbitMQConfigurationConstructor),
 			rabbitmqProviders,
 			rabbitmqInvokes,
@@ -35,6 +43,7 @@ var (
 	// - execute its func only if it requested
 	rabbitmqProviders = fx.Options(
 		fx.Provide(config.ProvideConfig),
+		messageSerializerDecorator,
 		fx.Provide(types.NewRabbitMQConnection),
 		fx.Provide(fx.Annotate(
 			bus.NewRabbitmqBus,
@@ -43,27 +52,62 @@ var (
 			fx.As(new(bus2.Bus)),
 			fx.As(new(bus.RabbitmqBus)),
 		)),
-		fx.Provide(rabbitmqconsumer.NewConsumerFactory),
+		fx.Provide(fx.Annotate(
+			rabbitmqconsumer.NewConsumerFactory,
+			// the exhausted messages are quarantined when a quarantine store is provided, e.g. by
+			// `postgresgorm.QuarantineModule`
+			fx.ParamTags(``, ``, ``, ``, ``, `optional:"true"`, `optional:"true"`, `optional:"true"`),
+		)),
 		fx.Provide(rabbitmqproducer.NewProducerFactory),
+		fx.Provide(fx.Annotate(
+			quarantine.NewMessageReplayer,
+			fx.As(new(messagingQuarantine.MessageReplayer)),
+		)),
 		fx.Provide(fx.Annotate(
 			NewRabbitMQHealthChecker,
 			fx.As(new(contracts.Health)),
 			fx.ResultTags(fmt.Sprintf(`group:"%s"`, "healths")),
 		)))
 
+	// the bus serializes its messages with the serializer of its content type, the decorations are scoped to their
+	// module, so each module of a bus decorates the serializer
+	messageSerializerDecorator = fx.Decorate(fx.Annotate(
+		newMessageSerializer,
+		fx.ParamTags(``, fmt.Sprintf(`group:"%s"`, serializer.MessageSerializersGroup), ``),
+	))
+
 	// - execute after registering all of our provided
 	// - they execute by their orders
 	// - invokes always execute its func compare to provides that only run when we request for them.
 	// - return value will be discarded and can not be provided
 	rabbitmqInvokes = fx.Options(
 		fx.Invoke(registerHooks),
+		fx.Invoke(fx.Annotate(
+			RegisterConnectionMetrics,
+			fx.ParamTags(``, `optional:"true"`),
+		)),
 	) //nolint:gochecknoglobals
 )
 
+func newMessageSerializer(
+	options *config.RabbitmqOptions,
+	serializers []serializer.MessageSerializer,
+	encryptor encryption.FieldEncryptor,
+) (serializer.MessageSerializer, error) {
+	messageSerializer, err := serializer.NewNegotiatingMessageSerializer(options.ContentType, serializers)
+	if err != nil {
+		return nil, err
+	}
+
+	// the configured fields of the messages are encrypted before they are published
+	return encryption.NewEncryptingMessageSerializer(messageSerializer, encryptor), nil
+}
+
 // we don't want to register any dependencies here, its func body should execute always even we don't request for that, so we should use `invoke`
 func registerHooks(
 	lc fx.Lifecycle,
 	bus bus.RabbitmqBus,
+	connection types.IConnection,
 	rabbitmqOptions *config.RabbitmqOptions,
 	logger logger.Logger,
 ) {
@@ -79,6 +123,11 @@ func registerHooks(
 			// this ctx is just for startup dependencies setup and OnStart callbacks, and it has short timeout 15s, and it is not alive in whole lifetime app
 			// if we need an app context which is alive until the app context done we should create it manually here
 
+			// the topology is declared before the consumers start, so a drifted broker fails the startup
+			if err := provisionTopology(ctx, bus, connection, rabbitmqOptions, logger); err != nil {
+				return err
+			}
+
 			go func() {
 				// if (ctx.Err() == nil), context not canceled or deadlined
 				if err := bus.Start(lifeTimeCtx); err != nil {
@@ -88,6 +137,12 @@ func registerHooks(
 					)
 					return
 				}
+
+				// without the provisioning the consumers declared their topology on start, so the drifts now are not
+				// fixed by the declarations
+				if !rabbitmqOptions.TopologyOptions.Provision {
+					verifyTopology(bus, rabbitmqOptions, logger)
+				}
 			}()
 			logger.Info("rabbitmq is listening.")
 
@@ -102,6 +157,11 @@ func registerHooks(
 				logger.Info("rabbitmq server shutdown gracefully")
 			}
 
+			// closing the connection stops the reconnecting loop and releases the buffered publishes
+			if err := connection.Close(); err != nil {
+				logger.Errorf("error in closing rabbitmq connection: %v", err)
+			}
+
 			_, cancel := context.WithTimeout(lifeTimeCtx, 5*time.Second)
 			defer cancel()
 
@@ -109,3 +169,44 @@ func registerHooks(
 		},
 	})
 }
+
+func provisionTopology(
+	ctx context.Context,
+	bus bus.RabbitmqBus,
+	connection types.IConnection,
+	rabbitmqOptions *config.RabbitmqOptions,
+	logger logger.Logger,
+) error {
+	if !rabbitmqOptions.TopologyOptions.Provision || rabbitmqOptions.UseInMemory {
+		return nil
+	}
+
+	manager := topology.NewManager(
+		connection,
+		topology.NewTopology(bus.Configuration()),
+		rabbitmqOptions,
+		logger,
+	)
+
+	return manager.Provision(ctx)
+}
+
+func verifyTopology(
+	bus bus.RabbitmqBus,
+	rabbitmqOptions *config.RabbitmqOptions,
+	logger logger.Logger,
+) {
+	if !rabbitmqOptions.VerifyTopology || rabbitmqOptions.UseInMemory ||
+		rabbitmqOptions.RabbitmqHostOptions == nil || rabbitmqOptions.RabbitmqHostOptions.HttpPort == 0 {
+		return
+	}
+
+	_, err := topology.Verify(
+		rabbitmqOptions.RabbitmqHostOptions,
+		topology.NewTopology(bus.Configuration()),
+		logger,
+	)
+	if err != nil {
+		logger.Warnf("error in verifying rabbitmq topology: %v", err)
+	}
+}
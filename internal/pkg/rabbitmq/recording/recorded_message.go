@@ -0,0 +1,179 @@
+package recording
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+const fileExtension = ".json"
+
+// RecordedMessage is a consumed message with all the properties which are needed for replaying it, each message is
+// kept in its own file so a recording can be edited or trimmed by hand before replaying it
+type RecordedMessage struct {
+	Exchange      string         `json:"exchange"`
+	RoutingKey    string         `json:"routingKey"`
+	MessageId     string         `json:"messageId"`
+	CorrelationId string         `json:"correlationId"`
+	Type          string         `json:"type"`
+	ContentType   string         `json:"contentType"`
+	DeliveryMode  uint8          `json:"deliveryMode"`
+	Timestamp     time.Time      `json:"timestamp"`
+	RecordedAt    time.Time      `json:"recordedAt"`
+	Headers       map[string]any `json:"headers"`
+	// Body is the published body of the message, the json message bodies are kept as they are to be readable
+	Body json.RawMessage `json:"body,omitempty"`
+	// RawBody is the published body of the message when it is not a valid json
+	RawBody []byte `json:"rawBody,omitempty"`
+}
+
+func NewRecordedMessage(delivery amqp091.Delivery) *RecordedMessage {
+	message := &RecordedMessage{
+		Exchange:      delivery.Exchange,
+		RoutingKey:    delivery.RoutingKey,
+		MessageId:     delivery.MessageId,
+		CorrelationId: delivery.CorrelationId,
+		Type:          delivery.Type,
+		ContentType:   delivery.ContentType,
+		DeliveryMode:  delivery.DeliveryMode,
+		Timestamp:     delivery.Timestamp,
+		RecordedAt:    time.Now(),
+		Headers:       delivery.Headers,
+	}
+
+	if json.Valid(delivery.Body) {
+		message.Body = delivery.Body
+	} else {
+		message.RawBody = delivery.Body
+	}
+
+	return message
+}
+
+// Publishing returns the message as it is published originally, the header types are not kept in the recording, so
+// the whole json numbers of the headers are replayed as int64 and the other numbers as float64
+func (m *RecordedMessage) Publishing() amqp091.Publishing {
+	body := []byte(m.Body)
+	if len(m.RawBody) > 0 {
+		body = m.RawBody
+	}
+
+	return amqp091.Publishing{
+		Headers:       headersTable(m.Headers),
+		ContentType:   m.ContentType,
+		DeliveryMode:  m.DeliveryMode,
+		CorrelationId: m.CorrelationId,
+		MessageId:     m.MessageId,
+		Timestamp:     m.Timestamp,
+		Type:          m.Type,
+		Body:          body,
+	}
+}
+
+// headersTable converts the decoded json headers to the types which are supported by the amqp tables
+func headersTable(headers map[string]any) amqp091.Table {
+	if headers == nil {
+		return nil
+	}
+
+	table := amqp091.Table{}
+	for key, value := range headers {
+		table[key] = headerValue(value)
+	}
+
+	return table
+}
+
+func headerValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		return headersTable(v)
+	case []any:
+		values := make([]any, len(v))
+		for i, item := range v {
+			values[i] = headerValue(item)
+		}
+
+		return values
+	case float64:
+		if v == math.Trunc(v) {
+			return int64(v)
+		}
+
+		return v
+	default:
+		return v
+	}
+}
+
+// WriteMessage writes the message to the next file of the recording directory, the files are named with their
+// sequence so they are replayed in the recorded order
+func WriteMessage(dir string, sequence int, message *RecordedMessage) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return errors.WrapIf(err, "error in creating the recording directory")
+	}
+
+	data, err := json.MarshalIndent(message, "", "  ")
+	if err != nil {
+		return errors.WrapIf(err, "error in serializing the recorded message")
+	}
+
+	file := filepath.Join(dir, fmt.Sprintf("%06d_%s%s", sequence, sanitize(message.MessageId), fileExtension))
+
+	return errors.WrapIf(os.WriteFile(file, data, 0o644), "error in writing the recorded message")
+}
+
+// ReadMessages reads the messages of a recording directory in the recorded order
+func ReadMessages(dir string) ([]*RecordedMessage, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in reading the recording directory")
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), fileExtension) {
+			files = append(files, entry.Name())
+		}
+	}
+	sort.Strings(files)
+
+	messages := make([]*RecordedMessage, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			return nil, errors.WrapIf(err, "error in reading the recorded message")
+		}
+
+		message := &RecordedMessage{}
+		if err := json.Unmarshal(data, message); err != nil {
+			return nil, errors.WrapIf(err, fmt.Sprintf("error in deserializing the recorded message %s", file))
+		}
+
+		messages = append(messages, message)
+	}
+
+	return messages, nil
+}
+
+func sanitize(name string) string {
+	if name == "" {
+		return "message"
+	}
+
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' || r == ' ' {
+			return '_'
+		}
+
+		return r
+	}, name)
+}
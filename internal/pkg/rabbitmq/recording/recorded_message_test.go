@@ -0,0 +1,61 @@
+package recording
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Write_And_Read_Messages_In_Recorded_Order(t *testing.T) {
+	dir := t.TempDir()
+
+	for i, id := range []string{"b", "a", "c"} {
+		delivery := amqp091.Delivery{
+			Exchange:    "order_created",
+			RoutingKey:  "order_created",
+			MessageId:   id,
+			Type:        "orderCreated",
+			ContentType: "application/json",
+			Timestamp:   time.Now().UTC().Truncate(time.Second),
+			Body:        []byte(`{"orderId":"` + id + `"}`),
+		}
+		require.NoError(t, WriteMessage(dir, i+1, NewRecordedMessage(delivery)))
+	}
+
+	messages, err := ReadMessages(dir)
+	require.NoError(t, err)
+
+	require.Len(t, messages, 3)
+	assert.Equal(t, "b", messages[0].MessageId)
+	assert.Equal(t, "a", messages[1].MessageId)
+	assert.Equal(t, "c", messages[2].MessageId)
+	assert.JSONEq(t, `{"orderId":"a"}`, string(messages[1].Body))
+}
+
+func Test_Recorded_Message_Publishing(t *testing.T) {
+	dir := t.TempDir()
+	delivery := amqp091.Delivery{
+		MessageId: "1",
+		Headers: amqp091.Table{
+			"x-retry-count": int32(2),
+			"x-death":       []any{amqp091.Table{"queue": "order_created", "count": int64(1)}},
+			"ratio":         0.5,
+		},
+		Body: []byte("not json"),
+	}
+	require.NoError(t, WriteMessage(dir, 1, NewRecordedMessage(delivery)))
+
+	messages, err := ReadMessages(dir)
+	require.NoError(t, err)
+
+	publishing := messages[0].Publishing()
+
+	assert.Equal(t, []byte("not json"), publishing.Body)
+	assert.Equal(t, int64(2), publishing.Headers["x-retry-count"])
+	assert.Equal(t, 0.5, publishing.Headers["ratio"])
+	assert.Equal(t, []any{amqp091.Table{"queue": "order_created", "count": int64(1)}}, publishing.Headers["x-death"])
+	assert.NoError(t, publishing.Headers.Validate())
+}
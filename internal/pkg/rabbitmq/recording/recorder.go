@@ -0,0 +1,125 @@
+package recording
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/types"
+
+	"emperror.dev/errors"
+)
+
+type RecordOptions struct {
+	// Exchange and RoutingKey are the binding of the consumer whose messages are recorded
+	Exchange   string
+	RoutingKey string
+	// Dir is the directory of the recording files
+	Dir string
+	// Limit is the number of the messages after that the recording stops, zero records until the context is canceled
+	Limit int
+}
+
+// Record records the messages routed to a consumer, it binds its own temporary queue with the binding of the consumer
+// so the consumer still receives all of its messages. it returns the number of the recorded messages.
+func Record(
+	ctx context.Context,
+	connection types.IConnection,
+	options *RecordOptions,
+	logger logger.Logger,
+) (int, error) {
+	ch, err := connection.Channel()
+	if err != nil {
+		return 0, errors.WrapIf(err, "error in opening a channel for recording")
+	}
+	defer ch.Close()
+
+	// a server-named exclusive queue is removed by the broker when the recorder disconnects
+	queue, err := ch.QueueDeclare("", false, true, true, false, nil)
+	if err != nil {
+		return 0, errors.WrapIf(err, "error in declaring the recording queue")
+	}
+
+	if err := ch.QueueBind(queue.Name, options.RoutingKey, options.Exchange, false, nil); err != nil {
+		return 0, errors.WrapIf(err, "error in binding the recording queue")
+	}
+
+	deliveries, err := ch.Consume(queue.Name, "", true, true, false, false, nil)
+	if err != nil {
+		return 0, errors.WrapIf(err, "error in consuming the recording queue")
+	}
+
+	logger.Infof(
+		"recording the messages of exchange %s with routing key %s to %s",
+		options.Exchange,
+		options.RoutingKey,
+		options.Dir,
+	)
+
+	recorded := 0
+	for options.Limit <= 0 || recorded < options.Limit {
+		select {
+		case <-ctx.Done():
+			return recorded, nil
+		case delivery, ok := <-deliveries:
+			if !ok {
+				return recorded, errors.New("recording channel is closed")
+			}
+
+			if err := WriteMessage(options.Dir, recorded+1, NewRecordedMessage(delivery)); err != nil {
+				return recorded, err
+			}
+
+			recorded++
+			logger.Infof("message with id %s recorded", delivery.MessageId)
+		}
+	}
+
+	return recorded, nil
+}
+
+// Copy records the messages waiting in a queue without removing them, like the messages of a dead-letter queue.
+// it returns the number of the recorded messages.
+func Copy(
+	ctx context.Context,
+	connection types.IConnection,
+	queue string,
+	options *RecordOptions,
+	logger logger.Logger,
+) (int, error) {
+	ch, err := connection.Channel()
+	if err != nil {
+		return 0, errors.WrapIf(err, "error in opening a channel for recording")
+	}
+	defer ch.Close()
+
+	recorded := 0
+	var lastTag uint64
+
+	for (options.Limit <= 0 || recorded < options.Limit) && ctx.Err() == nil {
+		delivery, ok, err := ch.Get(queue, false)
+		if err != nil {
+			return recorded, errors.WrapIf(err, "error in getting the message of the queue")
+		}
+		if !ok {
+			break
+		}
+
+		lastTag = delivery.DeliveryTag
+		if err := WriteMessage(options.Dir, recorded+1, NewRecordedMessage(delivery)); err != nil {
+			return recorded, errors.Combine(err, ch.Nack(lastTag, true, true))
+		}
+
+		recorded++
+	}
+
+	// the messages are returned to the queue in their original order
+	if lastTag > 0 {
+		if err := ch.Nack(lastTag, true, true); err != nil {
+			return recorded, errors.WrapIf(err, "error in returning the recorded messages to the queue")
+		}
+	}
+
+	logger.Infof("%d messages of queue %s recorded to %s", recorded, queue, options.Dir)
+
+	return recorded, nil
+}
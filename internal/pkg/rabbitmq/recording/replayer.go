@@ -0,0 +1,87 @@
+package recording
+
+import (
+	"context"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/types"
+
+	"emperror.dev/errors"
+)
+
+type ReplayOptions struct {
+	// Dir is the directory of the recording files
+	Dir string
+	// Queue publishes the messages directly to a queue with the default exchange, so only its consumer receives them,
+	// without it the messages are published to their recorded exchange and routing key
+	Queue string
+	// Delay is the delay between two replayed messages, zero replays the messages one by one after the broker confirms
+	// the previous one
+	Delay time.Duration
+}
+
+// Replay publishes the recorded messages in their recorded order with their original ids and headers, and returns
+// the number of the replayed messages
+func Replay(
+	ctx context.Context,
+	connection types.IConnection,
+	options *ReplayOptions,
+	logger logger.Logger,
+) (int, error) {
+	messages, err := ReadMessages(options.Dir)
+	if err != nil {
+		return 0, err
+	}
+
+	ch, err := connection.Channel()
+	if err != nil {
+		return 0, errors.WrapIf(err, "error in opening a channel for replaying")
+	}
+	defer ch.Close()
+
+	if err := ch.Confirm(false); err != nil {
+		return 0, errors.WrapIf(err, "error in enabling the publisher confirms")
+	}
+
+	replayed := 0
+	for _, message := range messages {
+		exchange, routingKey := message.Exchange, message.RoutingKey
+		if options.Queue != "" {
+			exchange, routingKey = "", options.Queue
+		}
+
+		confirmation, err := ch.PublishWithDeferredConfirmWithContext(
+			ctx,
+			exchange,
+			routingKey,
+			false,
+			false,
+			message.Publishing(),
+		)
+		if err != nil {
+			return replayed, errors.WrapIf(err, "error in replaying the recorded message")
+		}
+
+		acked, err := confirmation.WaitContext(ctx)
+		if err != nil {
+			return replayed, errors.WrapIf(err, "error in waiting for the replay confirmation")
+		}
+		if !acked {
+			return replayed, errors.Errorf("replay of message %s is not confirmed by the broker", message.MessageId)
+		}
+
+		replayed++
+		logger.Infof("message with id %s replayed", message.MessageId)
+
+		if options.Delay > 0 {
+			select {
+			case <-ctx.Done():
+				return replayed, ctx.Err()
+			case <-time.After(options.Delay):
+			}
+		}
+	}
+
+	return replayed, nil
+}
@@ -2,6 +2,7 @@ package in_memory
 
 import (
 	"context"
+	"time"
 
 	consumer2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
@@ -37,6 +38,21 @@ func (r *RabbitmqInMemoryHarnesses) PublishMessageWithTopicName(
 	return nil
 }
 
+func (r *RabbitmqInMemoryHarnesses) PublishMessageWithDelay(
+	ctx context.Context,
+	message types.IMessage,
+	meta metadata.Metadata,
+	delay time.Duration,
+) error {
+	r.publishedMessage = append(r.publishedMessage, message)
+	return nil
+}
+
+func (r *RabbitmqInMemoryHarnesses) PublishMessages(ctx context.Context, messages []types.IMessage) error {
+	r.publishedMessage = append(r.publishedMessage, messages...)
+	return nil
+}
+
 func (r *RabbitmqInMemoryHarnesses) IsProduced(f func(message types.IMessage)) {
 }
 
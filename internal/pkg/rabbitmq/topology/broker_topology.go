@@ -0,0 +1,75 @@
+package topology
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/config"
+
+	"emperror.dev/errors"
+	rabbithole "github.com/michaelklishin/rabbit-hole"
+)
+
+const defaultVirtualHost = "/"
+
+// ReadBrokerTopology reads the topology of the live broker from the rabbitmq management api
+func ReadBrokerTopology(options *config.RabbitmqHostOptions) (*Topology, error) {
+	client, err := rabbithole.NewClient(options.HttpEndPoint(), options.UserName, options.Password)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in creating rabbitmq management client")
+	}
+
+	virtualHost := options.VirtualHost
+	if virtualHost == "" {
+		virtualHost = defaultVirtualHost
+	}
+
+	exchanges, err := client.ListExchangesIn(virtualHost)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in listing rabbitmq exchanges")
+	}
+
+	queues, err := client.ListQueuesIn(virtualHost)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in listing rabbitmq queues")
+	}
+
+	bindings, err := client.ListBindingsIn(virtualHost)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in listing rabbitmq bindings")
+	}
+
+	t := &Topology{}
+	for _, exchange := range exchanges {
+		t.Exchanges = append(t.Exchanges, &Exchange{
+			Name:       exchange.Name,
+			Type:       exchange.Type,
+			Durable:    exchange.Durable,
+			AutoDelete: exchange.AutoDelete,
+			Arguments:  exchange.Arguments,
+		})
+	}
+
+	for _, queue := range queues {
+		t.Queues = append(t.Queues, &Queue{
+			Name:       queue.Name,
+			Durable:    queue.Durable,
+			AutoDelete: queue.AutoDelete,
+			Arguments:  queue.Arguments,
+		})
+	}
+
+	for _, binding := range bindings {
+		// the bindings of the default exchange are implicit for all the queues
+		if binding.DestinationType != "queue" || binding.Source == "" {
+			continue
+		}
+
+		t.Bindings = append(t.Bindings, &Binding{
+			Exchange:   binding.Source,
+			Queue:      binding.Destination,
+			RoutingKey: binding.RoutingKey,
+		})
+	}
+
+	t.sort()
+
+	return t, nil
+}
@@ -0,0 +1,196 @@
+package topology
+
+import (
+	"fmt"
+)
+
+type DriftKind string
+
+const (
+	MissingExchange    DriftKind = "missing_exchange"
+	MissingQueue       DriftKind = "missing_queue"
+	MissingBinding     DriftKind = "missing_binding"
+	UnexpectedBinding  DriftKind = "unexpected_binding"
+	MismatchedExchange DriftKind = "mismatched_exchange"
+	MismatchedQueue    DriftKind = "mismatched_queue"
+)
+
+// Drift is a difference between the declared topology and the topology of the live broker
+type Drift struct {
+	Kind    DriftKind
+	Name    string
+	Message string
+	// Binding is the missing or the unexpected binding of the queue
+	Binding *Binding
+}
+
+func (d Drift) String() string {
+	return fmt.Sprintf("[%s] %s: %s", d.Kind, d.Name, d.Message)
+}
+
+// Compare returns the drifts of the actual topology from the expected topology. exchanges, queues and bindings of the
+// broker which are not related to the declared queues are ignored, because the broker is shared with other services.
+func Compare(expected *Topology, actual *Topology) []Drift {
+	var drifts []Drift
+
+	for _, exchange := range expected.Exchanges {
+		actualExchange := actual.FindExchange(exchange.Name)
+		if actualExchange == nil {
+			drifts = append(drifts, Drift{
+				Kind:    MissingExchange,
+				Name:    exchange.Name,
+				Message: "exchange is not declared on the broker",
+			})
+
+			continue
+		}
+
+		if !exchange.matches(actualExchange) {
+			drifts = append(drifts, Drift{
+				Kind: MismatchedExchange,
+				Name: exchange.Name,
+				Message: fmt.Sprintf(
+					"expected type=%s durable=%t autoDelete=%t arguments=%v, but broker has type=%s durable=%t autoDelete=%t arguments=%v",
+					exchange.Type,
+					exchange.Durable,
+					exchange.AutoDelete,
+					exchange.Arguments,
+					actualExchange.Type,
+					actualExchange.Durable,
+					actualExchange.AutoDelete,
+					actualExchange.Arguments,
+				),
+			})
+		}
+	}
+
+	for _, queue := range expected.Queues {
+		actualQueue := actual.FindQueue(queue.Name)
+		if actualQueue == nil {
+			drifts = append(drifts, Drift{
+				Kind:    MissingQueue,
+				Name:    queue.Name,
+				Message: "queue is not declared on the broker",
+			})
+
+			continue
+		}
+
+		if !queue.matches(actualQueue) {
+			drifts = append(drifts, Drift{
+				Kind: MismatchedQueue,
+				Name: queue.Name,
+				Message: fmt.Sprintf(
+					"expected durable=%t autoDelete=%t arguments=%v, but broker has durable=%t autoDelete=%t arguments=%v",
+					queue.Durable,
+					queue.AutoDelete,
+					queue.Arguments,
+					actualQueue.Durable,
+					actualQueue.AutoDelete,
+					actualQueue.Arguments,
+				),
+			})
+		}
+	}
+
+	for _, binding := range expected.Bindings {
+		if !actual.HasBinding(binding) {
+			drifts = append(drifts, Drift{
+				Kind:    MissingBinding,
+				Name:    binding.Queue,
+				Binding: binding,
+				Message: fmt.Sprintf(
+					"queue is not bound to exchange '%s' with routing key '%s', the consumer of the queue receives nothing",
+					binding.Exchange,
+					binding.RoutingKey,
+				),
+			})
+		}
+	}
+
+	for _, binding := range actual.Bindings {
+		if expected.FindQueue(binding.Queue) == nil || expected.HasBinding(binding) {
+			continue
+		}
+
+		drifts = append(drifts, Drift{
+			Kind:    UnexpectedBinding,
+			Name:    binding.Queue,
+			Binding: binding,
+			Message: fmt.Sprintf(
+				"queue is bound to exchange '%s' with routing key '%s' which is not declared",
+				binding.Exchange,
+				binding.RoutingKey,
+			),
+		})
+	}
+
+	return drifts
+}
+
+// IsMismatch reports whether the drift is an exchange or a queue of the broker which is declared with different
+// properties, the broker rejects declaring them again, while the missing ones are fixed by declaring them.
+func (d Drift) IsMismatch() bool {
+	return d.Kind == MismatchedExchange || d.Kind == MismatchedQueue
+}
+
+func (e *Exchange) matches(actual *Exchange) bool {
+	return e.Name == actual.Name &&
+		e.Type == actual.Type &&
+		e.Durable == actual.Durable &&
+		e.AutoDelete == actual.AutoDelete &&
+		argumentsEqual(e.Arguments, actual.Arguments)
+}
+
+func (q *Queue) matches(actual *Queue) bool {
+	return q.Name == actual.Name &&
+		q.Durable == actual.Durable &&
+		q.AutoDelete == actual.AutoDelete &&
+		argumentsEqual(q.Arguments, actual.Arguments)
+}
+
+// argumentsEqual compares the arguments of the declarations, the numbers are compared by their values because the
+// management api returns all of them as json numbers
+func argumentsEqual(expected map[string]any, actual map[string]any) bool {
+	if len(expected) != len(actual) {
+		return false
+	}
+
+	for key, value := range expected {
+		actualValue, ok := actual[key]
+		if !ok || normalizeArgument(value) != normalizeArgument(actualValue) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func normalizeArgument(value any) any {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int8:
+		return float64(v)
+	case int16:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint8:
+		return float64(v)
+	case uint16:
+		return float64(v)
+	case uint32:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case float64:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
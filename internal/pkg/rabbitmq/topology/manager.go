@@ -0,0 +1,289 @@
+package topology
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/types"
+
+	"emperror.dev/errors"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+// Manager declares the topology of the configuration builders on the broker at startup
+type Manager interface {
+	// Provision declares all the exchanges, queues and bindings of the topology. the exchanges and the queues which are
+	// declared on the broker with different properties fail the provisioning, or are deleted and declared again when the
+	// drifts are repaired.
+	Provision(ctx context.Context) error
+}
+
+type manager struct {
+	connection types.IConnection
+	expected   *Topology
+	options    *config.RabbitmqOptions
+	log        logger.Logger
+	channel    *amqp091.Channel
+}
+
+func NewManager(
+	connection types.IConnection,
+	expected *Topology,
+	options *config.RabbitmqOptions,
+	log logger.Logger,
+) Manager {
+	return &manager{
+		connection: connection,
+		expected:   expected,
+		options:    options,
+		log:        log,
+	}
+}
+
+func (m *manager) Provision(ctx context.Context) error {
+	if err := m.connection.WaitForConnection(ctx); err != nil {
+		return errors.WrapIf(err, "error in waiting for the rabbitmq connection")
+	}
+	defer m.closeChannel()
+
+	if err := m.handleDrifts(m.brokerDrifts()); err != nil {
+		return err
+	}
+
+	for _, exchange := range m.expected.Exchanges {
+		if err := m.declare(exchange.Name, m.exchangeDeclaration(exchange)); err != nil {
+			return err
+		}
+	}
+
+	for _, queue := range m.expected.Queues {
+		if err := m.declare(queue.Name, m.queueDeclaration(queue)); err != nil {
+			return err
+		}
+	}
+
+	for _, binding := range m.expected.Bindings {
+		err := m.withChannel(func(ch *amqp091.Channel) error {
+			return ch.QueueBind(binding.Queue, binding.RoutingKey, binding.Exchange, false, nil)
+		})
+		if err != nil {
+			return errors.WrapIff(
+				err,
+				"error in binding queue '%s' to exchange '%s'",
+				binding.Queue,
+				binding.Exchange,
+			)
+		}
+	}
+
+	m.log.Infof(
+		"rabbitmq topology is provisioned with %d exchanges, %d queues and %d bindings",
+		len(m.expected.Exchanges),
+		len(m.expected.Queues),
+		len(m.expected.Bindings),
+	)
+
+	return nil
+}
+
+// brokerDrifts returns the drifts of the broker which declaring the topology doesn't fix, the missing exchanges, queues
+// and bindings are declared anyway. without the management api the mismatches are found by the rejected declarations.
+func (m *manager) brokerDrifts() []Drift {
+	hostOptions := m.options.RabbitmqHostOptions
+	if !m.options.VerifyTopology || hostOptions == nil || hostOptions.HttpPort == 0 {
+		return nil
+	}
+
+	actual, err := ReadBrokerTopology(hostOptions)
+	if err != nil {
+		m.log.Warnf("error in reading rabbitmq topology, the drifts are found by the declarations: %v", err)
+
+		return nil
+	}
+
+	var drifts []Drift
+	for _, drift := range Compare(m.expected, actual) {
+		if drift.IsMismatch() || drift.Kind == UnexpectedBinding {
+			drifts = append(drifts, drift)
+		}
+	}
+
+	return drifts
+}
+
+func (m *manager) handleDrifts(drifts []Drift) error {
+	if len(drifts) == 0 {
+		return nil
+	}
+
+	if !m.options.TopologyOptions.Repair() {
+		messages := make([]string, 0, len(drifts))
+		for _, drift := range drifts {
+			messages = append(messages, drift.String())
+		}
+
+		return errors.Errorf(
+			"rabbitmq topology drifted from the declared topology: %s",
+			strings.Join(messages, ", "),
+		)
+	}
+
+	for _, drift := range drifts {
+		m.log.Warn(fmt.Sprintf("repairing rabbitmq topology drift %s", drift))
+
+		var err error
+		switch drift.Kind {
+		case MismatchedExchange:
+			err = m.deleteExchange(drift.Name)
+		case MismatchedQueue:
+			err = m.deleteQueue(drift.Name)
+		case UnexpectedBinding:
+			err = m.unbind(drift.Binding)
+		}
+
+		if err != nil {
+			return errors.WrapIff(err, "error in repairing rabbitmq topology drift %s", drift)
+		}
+	}
+
+	return nil
+}
+
+// declare runs a declaration, a declaration which is rejected because the broker has it with different properties is
+// declared again after deleting it when the drifts are repaired
+func (m *manager) declare(name string, declaration declaration) error {
+	err := m.withChannel(declaration.declare)
+	if err == nil {
+		return nil
+	}
+
+	if !isPreconditionFailed(err) {
+		return errors.WrapIff(err, "error in declaring %s '%s'", declaration.kind, name)
+	}
+
+	if !m.options.TopologyOptions.Repair() {
+		return errors.WrapIff(
+			err,
+			"rabbitmq topology drifted from the declared topology, %s '%s' is declared with different properties",
+			declaration.kind,
+			name,
+		)
+	}
+
+	m.log.Warnf("repairing rabbitmq %s '%s' which is declared with different properties", declaration.kind, name)
+
+	if err := declaration.delete(); err != nil {
+		return errors.WrapIff(err, "error in deleting %s '%s'", declaration.kind, name)
+	}
+
+	if err := m.withChannel(declaration.declare); err != nil {
+		return errors.WrapIff(err, "error in declaring %s '%s'", declaration.kind, name)
+	}
+
+	return nil
+}
+
+type declaration struct {
+	kind    string
+	declare func(ch *amqp091.Channel) error
+	delete  func() error
+}
+
+func (m *manager) exchangeDeclaration(exchange *Exchange) declaration {
+	return declaration{
+		kind: "exchange",
+		declare: func(ch *amqp091.Channel) error {
+			return ch.ExchangeDeclare(
+				exchange.Name,
+				exchange.Type,
+				exchange.Durable,
+				exchange.AutoDelete,
+				false,
+				false,
+				exchange.Arguments,
+			)
+		},
+		delete: func() error {
+			return m.deleteExchange(exchange.Name)
+		},
+	}
+}
+
+func (m *manager) queueDeclaration(queue *Queue) declaration {
+	return declaration{
+		kind: "queue",
+		declare: func(ch *amqp091.Channel) error {
+			_, err := ch.QueueDeclare(queue.Name, queue.Durable, queue.AutoDelete, false, false, queue.Arguments)
+
+			return err
+		},
+		delete: func() error {
+			return m.deleteQueue(queue.Name)
+		},
+	}
+}
+
+func (m *manager) deleteExchange(name string) error {
+	return m.withChannel(func(ch *amqp091.Channel) error {
+		return ch.ExchangeDelete(name, false, false)
+	})
+}
+
+func (m *manager) deleteQueue(name string) error {
+	return m.withChannel(func(ch *amqp091.Channel) error {
+		_, err := ch.QueueDelete(name, false, false, false)
+
+		return err
+	})
+}
+
+func (m *manager) unbind(binding *Binding) error {
+	if binding == nil {
+		return nil
+	}
+
+	return m.withChannel(func(ch *amqp091.Channel) error {
+		return ch.QueueUnbind(binding.Queue, binding.RoutingKey, binding.Exchange, nil)
+	})
+}
+
+// withChannel runs the action on the channel of the manager, the broker closes a channel after an error, so a new
+// channel is opened for the next action
+func (m *manager) withChannel(action func(ch *amqp091.Channel) error) error {
+	if m.channel == nil || m.channel.IsClosed() {
+		ch, err := m.connection.Channel()
+		if err != nil {
+			return err
+		}
+
+		m.channel = ch
+	}
+
+	err := action(m.channel)
+	if err != nil {
+		m.closeChannel()
+	}
+
+	return err
+}
+
+func (m *manager) closeChannel() {
+	if m.channel == nil {
+		return
+	}
+
+	if !m.channel.IsClosed() {
+		_ = m.channel.Close()
+	}
+
+	m.channel = nil
+}
+
+func isPreconditionFailed(err error) bool {
+	var amqpErr *amqp091.Error
+
+	return errors.As(err, &amqpErr) && amqpErr.Code == amqp091.PreconditionFailed
+}
@@ -0,0 +1,220 @@
+package topology
+
+import (
+	"sort"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/configurations"
+	consumerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/consumer/configurations"
+	producerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/producer/configurations"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Topology is the exchanges, queues and bindings of the rabbitmq broker, either declared by the configuration builders
+// or read from a live broker.
+type Topology struct {
+	Exchanges []*Exchange `yaml:"exchanges"`
+	Queues    []*Queue    `yaml:"queues"`
+	Bindings  []*Binding  `yaml:"bindings"`
+}
+
+type Exchange struct {
+	Name       string         `yaml:"name"`
+	Type       string         `yaml:"type"`
+	Durable    bool           `yaml:"durable"`
+	AutoDelete bool           `yaml:"autoDelete"`
+	Arguments  map[string]any `yaml:"arguments,omitempty"`
+}
+
+type Queue struct {
+	Name       string         `yaml:"name"`
+	Durable    bool           `yaml:"durable"`
+	AutoDelete bool           `yaml:"autoDelete"`
+	Arguments  map[string]any `yaml:"arguments,omitempty"`
+}
+
+type Binding struct {
+	Exchange   string `yaml:"exchange"`
+	Queue      string `yaml:"queue"`
+	RoutingKey string `yaml:"routingKey"`
+}
+
+// NewTopologyFromBuilder builds the declared topology of a configuration builder func, without connecting to the broker
+func NewTopologyFromBuilder(
+	builderFunc configurations.RabbitMQConfigurationBuilderFuc,
+) *Topology {
+	builder := configurations.NewRabbitMQConfigurationBuilder()
+	if builderFunc != nil {
+		builderFunc(builder)
+	}
+
+	return NewTopology(builder.Build())
+}
+
+// NewTopology returns the topology the producers and the consumers of the configuration declare on the broker, the
+// same defaults of the producers and the consumers are used for the empty names.
+func NewTopology(configuration *configurations.RabbitMQConfiguration) *Topology {
+	t := &Topology{}
+	if configuration == nil {
+		return t
+	}
+
+	for _, producerConfiguration := range configuration.ProducersConfigurations {
+		t.addProducer(producerConfiguration)
+	}
+
+	for _, consumerConfiguration := range configuration.ConsumersConfigurations {
+		t.addConsumer(consumerConfiguration)
+	}
+
+	t.sort()
+
+	return t
+}
+
+func (t *Topology) addProducer(
+	producerConfiguration *producerConfigurations.RabbitMQProducerConfiguration,
+) {
+	exchangeOptions := producerConfiguration.ExchangeOptions
+
+	name := exchangeOptions.Name
+	if name == "" {
+		name = utils.GetTopicOrExchangeNameFromType(producerConfiguration.ProducerMessageType)
+	}
+
+	t.addExchange(&Exchange{
+		Name:       name,
+		Type:       string(exchangeOptions.Type),
+		Durable:    exchangeOptions.Durable,
+		AutoDelete: exchangeOptions.AutoDelete,
+		Arguments:  exchangeOptions.Args,
+	})
+}
+
+func (t *Topology) addConsumer(
+	consumerConfiguration *consumerConfigurations.RabbitMQConsumerConfiguration,
+) {
+	exchangeName, _, queueName := consumerConfiguration.Topology()
+
+	t.addExchange(&Exchange{
+		Name:       exchangeName,
+		Type:       string(consumerConfiguration.ExchangeOptions.Type),
+		Durable:    consumerConfiguration.ExchangeOptions.Durable,
+		AutoDelete: consumerConfiguration.ExchangeOptions.AutoDelete,
+		Arguments:  consumerConfiguration.ExchangeOptions.Args,
+	})
+
+	queueArgs := consumerConfiguration.QueueOptions.Args
+	if deadLetterOptions := consumerConfiguration.DeadLetterOptions; deadLetterOptions != nil {
+		deadLetterExchange, deadLetterQueue := deadLetterOptions.Names(queueName)
+		queueArgs = deadLetterOptions.ConsumerQueueArgs(queueName, queueArgs)
+
+		t.addExchange(&Exchange{Name: deadLetterExchange, Type: string(types.ExchangeDirect), Durable: true})
+		t.addQueueBinding(
+			&Queue{Name: deadLetterQueue, Durable: true, Arguments: deadLetterOptions.QueueArgs()},
+			&Binding{Exchange: deadLetterExchange, Queue: deadLetterQueue, RoutingKey: deadLetterQueue},
+		)
+	}
+
+	// an exclusive queue belongs to the connection of its consumer, e.g. the queue of an instance of a broadcast
+	// consumer, so only the consumer declares it
+	if consumerConfiguration.QueueOptions.Exclusive {
+		return
+	}
+
+	queue := &Queue{
+		Name:       queueName,
+		Durable:    consumerConfiguration.QueueOptions.Durable,
+		AutoDelete: consumerConfiguration.QueueOptions.AutoDelete,
+		Arguments:  queueArgs,
+	}
+	for _, bindingKey := range consumerConfiguration.BindingKeys() {
+		t.addQueueBinding(queue, &Binding{Exchange: exchangeName, Queue: queueName, RoutingKey: bindingKey})
+	}
+
+	// the retry queues are only bound to the default exchange, which is not a part of the topology
+	if retryPolicy := consumerConfiguration.RetryPolicy; retryPolicy != nil {
+		for retry := 1; retry <= retryPolicy.DelayedRetries; retry++ {
+			name := retryPolicy.RetryQueueName(queueName, retry)
+			if t.FindQueue(name) == nil {
+				t.Queues = append(t.Queues, &Queue{
+					Name:      name,
+					Durable:   consumerConfiguration.QueueOptions.Durable,
+					Arguments: retryPolicy.RetryQueueArgs(queueName, retry),
+				})
+			}
+		}
+	}
+}
+
+func (t *Topology) addQueueBinding(queue *Queue, binding *Binding) {
+	if t.FindQueue(queue.Name) == nil {
+		t.Queues = append(t.Queues, queue)
+	}
+
+	if !t.HasBinding(binding) {
+		t.Bindings = append(t.Bindings, binding)
+	}
+}
+
+func (t *Topology) addExchange(exchange *Exchange) {
+	if t.FindExchange(exchange.Name) == nil {
+		t.Exchanges = append(t.Exchanges, exchange)
+	}
+}
+
+func (t *Topology) FindExchange(name string) *Exchange {
+	for _, exchange := range t.Exchanges {
+		if exchange.Name == name {
+			return exchange
+		}
+	}
+
+	return nil
+}
+
+func (t *Topology) FindQueue(name string) *Queue {
+	for _, queue := range t.Queues {
+		if queue.Name == name {
+			return queue
+		}
+	}
+
+	return nil
+}
+
+func (t *Topology) HasBinding(binding *Binding) bool {
+	for _, b := range t.Bindings {
+		if *b == *binding {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Yaml returns the yaml document of the topology
+func (t *Topology) Yaml() ([]byte, error) {
+	return yaml.Marshal(t)
+}
+
+func (t *Topology) sort() {
+	sort.Slice(t.Exchanges, func(i, j int) bool {
+		return t.Exchanges[i].Name < t.Exchanges[j].Name
+	})
+	sort.Slice(t.Queues, func(i, j int) bool {
+		return t.Queues[i].Name < t.Queues[j].Name
+	})
+	sort.Slice(t.Bindings, func(i, j int) bool {
+		if t.Bindings[i].Exchange != t.Bindings[j].Exchange {
+			return t.Bindings[i].Exchange < t.Bindings[j].Exchange
+		}
+		if t.Bindings[i].Queue != t.Bindings[j].Queue {
+			return t.Bindings[i].Queue < t.Bindings[j].Queue
+		}
+
+		return t.Bindings[i].RoutingKey < t.Bindings[j].RoutingKey
+	})
+}
@@ -0,0 +1,232 @@
+package topology
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/configurations"
+	consumerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/consumer/configurations"
+	producerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/producer/configurations"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type OrderCreated struct {
+	*types.Message
+}
+
+type OrderShipped struct {
+	*types.Message
+}
+
+func newTestTopology() *Topology {
+	return NewTopologyFromBuilder(func(builder configurations.RabbitMQConfigurationBuilder) {
+		builder.
+			AddProducer(OrderCreated{}, func(builder producerConfigurations.RabbitMQProducerConfigurationBuilder) {}).
+			AddConsumer(OrderCreated{}, func(builder consumerConfigurations.RabbitMQConsumerConfigurationBuilder) {}).
+			AddConsumer(OrderShipped{}, func(builder consumerConfigurations.RabbitMQConsumerConfigurationBuilder) {
+				builder.WithQueueName("shipping_queue")
+			})
+	})
+}
+
+func Test_NewTopology_From_Builder(t *testing.T) {
+	topology := newTestTopology()
+
+	assert.Equal(t, []*Exchange{
+		{Name: "order_created", Type: "topic", Durable: true},
+		{Name: "order_shipped", Type: "topic", Durable: true},
+	}, topology.Exchanges)
+	assert.Equal(t, []*Queue{
+		{Name: "order_created", Durable: true},
+		{Name: "shipping_queue", Durable: true},
+	}, topology.Queues)
+	assert.Equal(t, []*Binding{
+		{Exchange: "order_created", Queue: "order_created", RoutingKey: "order_created"},
+		{Exchange: "order_shipped", Queue: "shipping_queue", RoutingKey: "order_shipped"},
+	}, topology.Bindings)
+}
+
+func Test_NewTopology_With_Dead_Letter(t *testing.T) {
+	topology := NewTopologyFromBuilder(func(builder configurations.RabbitMQConfigurationBuilder) {
+		builder.AddConsumer(OrderCreated{}, func(builder consumerConfigurations.RabbitMQConsumerConfigurationBuilder) {
+			builder.WithDeadLetter(3, time.Hour)
+		})
+	})
+
+	assert.Equal(t, []*Exchange{
+		{Name: "order_created", Type: "topic", Durable: true},
+		{Name: "order_created.dlx", Type: "direct", Durable: true},
+	}, topology.Exchanges)
+	assert.Equal(t, []*Queue{
+		{
+			Name:    "order_created",
+			Durable: true,
+			Arguments: map[string]any{
+				"x-dead-letter-exchange":    "order_created.dlx",
+				"x-dead-letter-routing-key": "order_created.dlq",
+			},
+		},
+		{Name: "order_created.dlq", Durable: true, Arguments: map[string]any{"x-message-ttl": int64(3600000)}},
+	}, topology.Queues)
+	assert.Equal(t, []*Binding{
+		{Exchange: "order_created", Queue: "order_created", RoutingKey: "order_created"},
+		{Exchange: "order_created.dlx", Queue: "order_created.dlq", RoutingKey: "order_created.dlq"},
+	}, topology.Bindings)
+}
+
+func Test_NewTopology_With_Retry_Policy(t *testing.T) {
+	topology := NewTopologyFromBuilder(func(builder configurations.RabbitMQConfigurationBuilder) {
+		builder.AddConsumer(OrderCreated{}, func(builder consumerConfigurations.RabbitMQConsumerConfigurationBuilder) {
+			builder.WithRetryPolicy(1, 2, time.Second, time.Minute)
+		})
+	})
+
+	assert.Equal(t, []*Queue{
+		{Name: "order_created", Durable: true},
+		{Name: "order_created.retry.1", Durable: true, Arguments: retryQueueArgs(1000)},
+		{Name: "order_created.retry.2", Durable: true, Arguments: retryQueueArgs(2000)},
+	}, topology.Queues)
+	assert.Equal(t, []*Binding{
+		{Exchange: "order_created", Queue: "order_created", RoutingKey: "order_created"},
+	}, topology.Bindings)
+}
+
+func Test_NewTopology_With_Binding_Keys(t *testing.T) {
+	topology := NewTopologyFromBuilder(func(builder configurations.RabbitMQConfigurationBuilder) {
+		builder.AddConsumer(OrderCreated{}, func(builder consumerConfigurations.RabbitMQConsumerConfigurationBuilder) {
+			builder.
+				WithExchangeName("orders").
+				WithQueueName("order_notifications").
+				WithBindingKeys("orders.order.*", "orders.payment.#")
+		})
+	})
+
+	assert.Equal(t, []*Exchange{{Name: "orders", Type: "topic", Durable: true}}, topology.Exchanges)
+	assert.Equal(t, []*Queue{{Name: "order_notifications", Durable: true}}, topology.Queues)
+	assert.Equal(t, []*Binding{
+		{Exchange: "orders", Queue: "order_notifications", RoutingKey: "orders.order.*"},
+		{Exchange: "orders", Queue: "order_notifications", RoutingKey: "orders.payment.#"},
+	}, topology.Bindings)
+}
+
+func retryQueueArgs(ttl int64) map[string]any {
+	return map[string]any{
+		"x-dead-letter-exchange":    "",
+		"x-dead-letter-routing-key": "order_created",
+		"x-message-ttl":             ttl,
+	}
+}
+
+func Test_NewTopology_Skips_Exclusive_Queues(t *testing.T) {
+	topology := NewTopologyFromBuilder(func(builder configurations.RabbitMQConfigurationBuilder) {
+		builder.AddConsumer(OrderCreated{}, func(builder consumerConfigurations.RabbitMQConsumerConfigurationBuilder) {
+			builder.WithExclusiveQueue(true)
+		})
+	})
+
+	assert.Len(t, topology.Exchanges, 1)
+	assert.Empty(t, topology.Queues)
+	assert.Empty(t, topology.Bindings)
+}
+
+func Test_NewTopology_With_Broadcast_Consumer(t *testing.T) {
+	topology := NewTopologyFromBuilder(func(builder configurations.RabbitMQConfigurationBuilder) {
+		builder.AddConsumer(OrderCreated{}, func(builder consumerConfigurations.RabbitMQConsumerConfigurationBuilder) {
+			builder.
+				WithConsumptionMode(consumerConfigurations.ConsumptionModeBroadcast).
+				WithInstanceId("instance-1").
+				WithDeadLetter(3, 0)
+		})
+	})
+
+	// the queue of the instance is declared by its consumer, the dead-letter queue is shared by the instances
+	assert.Equal(t, []*Exchange{
+		{Name: "order_created", Type: "topic", Durable: true},
+		{Name: "order_created.dlx", Type: "direct", Durable: true},
+	}, topology.Exchanges)
+	assert.Equal(t, []*Queue{
+		{Name: "order_created.dlq", Durable: true},
+	}, topology.Queues)
+	assert.Equal(t, []*Binding{
+		{Exchange: "order_created.dlx", Queue: "order_created.dlq", RoutingKey: "order_created.dlq"},
+	}, topology.Bindings)
+}
+
+func Test_Topology_Yaml(t *testing.T) {
+	data, err := newTestTopology().Yaml()
+	require.NoError(t, err)
+
+	assert.Contains(t, string(data), "exchanges:\n    - name: order_created\n      type: topic\n")
+	assert.Contains(t, string(data), "routingKey: order_shipped")
+}
+
+func Test_Compare_Without_Drift(t *testing.T) {
+	expected := newTestTopology()
+	actual := newTestTopology()
+	actual.Exchanges = append(actual.Exchanges, &Exchange{Name: "other_service_exchange", Type: "fanout"})
+	actual.Bindings = append(
+		actual.Bindings,
+		&Binding{Exchange: "other_service_exchange", Queue: "other_service_queue"},
+	)
+
+	assert.Empty(t, Compare(expected, actual))
+}
+
+func Test_Compare_With_Drift(t *testing.T) {
+	expected := newTestTopology()
+	actual := &Topology{
+		Exchanges: []*Exchange{
+			{Name: "order_created", Type: "fanout", Durable: true},
+		},
+		Queues: []*Queue{
+			{Name: "order_created", Durable: true},
+			{Name: "shipping_queue", Durable: true},
+		},
+		Bindings: []*Binding{
+			{Exchange: "order_created", Queue: "order_created", RoutingKey: "order_created"},
+			{Exchange: "order_shipped", Queue: "shipping_queue", RoutingKey: "order_shipped_v0"},
+		},
+	}
+
+	drifts := Compare(expected, actual)
+
+	kinds := make([]DriftKind, 0, len(drifts))
+	for _, drift := range drifts {
+		kinds = append(kinds, drift.Kind)
+	}
+
+	assert.Equal(
+		t,
+		[]DriftKind{MismatchedExchange, MissingExchange, MissingBinding, UnexpectedBinding},
+		kinds,
+	)
+	assert.Equal(t, "shipping_queue", drifts[2].Name)
+}
+
+func Test_Compare_Arguments_By_Their_Values(t *testing.T) {
+	expected := &Topology{
+		Queues: []*Queue{
+			{Name: "order_created.dlq", Durable: true, Arguments: map[string]any{"x-message-ttl": int64(3600000)}},
+		},
+	}
+
+	// the management api returns the numbers of the arguments as json numbers
+	actual := &Topology{
+		Queues: []*Queue{
+			{Name: "order_created.dlq", Durable: true, Arguments: map[string]any{"x-message-ttl": float64(3600000)}},
+		},
+	}
+	assert.Empty(t, Compare(expected, actual))
+
+	actual.Queues[0].Arguments["x-message-ttl"] = float64(60000)
+	drifts := Compare(expected, actual)
+	require.Len(t, drifts, 1)
+	assert.Equal(t, MismatchedQueue, drifts[0].Kind)
+	assert.True(t, drifts[0].IsMismatch())
+
+	actual.Queues[0].Arguments = nil
+	assert.Len(t, Compare(expected, actual), 1)
+}
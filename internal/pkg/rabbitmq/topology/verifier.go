@@ -0,0 +1,31 @@
+package topology
+
+import (
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/config"
+)
+
+// Verify compares the declared topology with the topology of the live broker and logs a warning for each drift
+func Verify(
+	options *config.RabbitmqHostOptions,
+	expected *Topology,
+	log logger.Logger,
+) ([]Drift, error) {
+	actual, err := ReadBrokerTopology(options)
+	if err != nil {
+		return nil, err
+	}
+
+	drifts := Compare(expected, actual)
+	for _, drift := range drifts {
+		log.Warn(fmt.Sprintf("rabbitmq topology drift %s", drift))
+	}
+
+	if len(drifts) == 0 {
+		log.Info("rabbitmq topology matches the declared topology")
+	}
+
+	return drifts, nil
+}
@@ -0,0 +1,136 @@
+package types
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+// ChannelPool reuses the publisher channels of a connection instead of opening a channel for each publish. the pooled
+// channels are in the confirm mode, and a channel which is closed, e.g. by a failed declaration or by the recovery of
+// its connection, is dropped from the pool.
+type ChannelPool interface {
+	// Get returns an idle channel or opens a new one, it waits for a channel while all the channels of the pool are in use
+	Get(ctx context.Context) (*PooledChannel, error)
+	// Put returns the channel to the pool, a closed or a discarded channel is closed and its slot is released
+	Put(channel *PooledChannel)
+}
+
+// PooledChannel is a channel of the pool with its publisher confirms
+type PooledChannel struct {
+	*amqp091.Channel
+	confirms  chan amqp091.Confirmation
+	discarded bool
+}
+
+// Confirms returns the publisher confirms of the channel, they arrive in the publishing order
+func (c *PooledChannel) Confirms() <-chan amqp091.Confirmation {
+	return c.confirms
+}
+
+// Discard closes the channel on Put instead of reusing it, e.g. after a publish which its confirm is not awaited, so
+// the next publish on the channel doesn't receive the stale confirm
+func (c *PooledChannel) Discard() {
+	c.discarded = true
+}
+
+type channelPool struct {
+	idle chan *PooledChannel
+	// slots has a slot for each open channel of the pool, idle or in use
+	slots chan struct{}
+	open  func() (*PooledChannel, error)
+}
+
+// NewChannelPool creates a pool of up to `size` open channels on the connection
+func NewChannelPool(connection IConnection, size int) ChannelPool {
+	return newChannelPool(size, func() (*PooledChannel, error) {
+		return openPooledChannel(connection)
+	})
+}
+
+func newChannelPool(size int, open func() (*PooledChannel, error)) *channelPool {
+	if size <= 0 {
+		size = 1
+	}
+
+	return &channelPool{
+		idle:  make(chan *PooledChannel, size),
+		slots: make(chan struct{}, size),
+		open:  open,
+	}
+}
+
+func (p *channelPool) Get(ctx context.Context) (*PooledChannel, error) {
+	for {
+		// the idle channels are reused before opening a new channel
+		select {
+		case channel := <-p.idle:
+			if !channel.IsClosed() {
+				return channel, nil
+			}
+			p.release()
+
+			continue
+		default:
+		}
+
+		select {
+		case channel := <-p.idle:
+			if !channel.IsClosed() {
+				return channel, nil
+			}
+			p.release()
+		case p.slots <- struct{}{}:
+			channel, err := p.open()
+			if err != nil {
+				p.release()
+
+				return nil, err
+			}
+
+			return channel, nil
+		case <-ctx.Done():
+			return nil, errors.WrapIf(ctx.Err(), "waiting for a rabbitmq channel canceled")
+		}
+	}
+}
+
+func (p *channelPool) Put(channel *PooledChannel) {
+	if channel == nil {
+		return
+	}
+
+	if channel.discarded || channel.IsClosed() {
+		_ = channel.Close()
+		p.release()
+
+		return
+	}
+
+	// the idle channels never exceed the slots, so it doesn't block
+	p.idle <- channel
+}
+
+func (p *channelPool) release() {
+	<-p.slots
+}
+
+func openPooledChannel(connection IConnection) (*PooledChannel, error) {
+	channel, err := connection.Channel()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := channel.Confirm(false); err != nil {
+		_ = channel.Close()
+
+		return nil, errors.WrapIf(err, "error in enabling the publisher confirms")
+	}
+
+	return &PooledChannel{
+		Channel: channel,
+		// a publish on a pooled channel awaits its confirm before the channel is reused
+		confirms: channel.NotifyPublish(make(chan amqp091.Confirmation, 1)),
+	}, nil
+}
@@ -0,0 +1,73 @@
+package types
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestChannelPool(size int, openErr error) (*channelPool, *int) {
+	opened := 0
+
+	return newChannelPool(size, func() (*PooledChannel, error) {
+		if openErr != nil {
+			return nil, openErr
+		}
+		opened++
+
+		return &PooledChannel{Channel: &amqp091.Channel{}}, nil
+	}), &opened
+}
+
+func Test_ChannelPool_Reuses_Idle_Channel(t *testing.T) {
+	pool, opened := newTestChannelPool(2, nil)
+
+	channel, err := pool.Get(context.Background())
+	require.NoError(t, err)
+	pool.Put(channel)
+
+	reused, err := pool.Get(context.Background())
+	require.NoError(t, err)
+
+	assert.Same(t, channel, reused)
+	assert.Equal(t, 1, *opened)
+}
+
+func Test_ChannelPool_Waits_While_All_Channels_Are_In_Use(t *testing.T) {
+	pool, opened := newTestChannelPool(1, nil)
+
+	channel, err := pool.Get(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err = pool.Get(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		pool.Put(channel)
+	}()
+
+	reused, err := pool.Get(context.Background())
+	require.NoError(t, err)
+	assert.Same(t, channel, reused)
+	assert.Equal(t, 1, *opened)
+}
+
+func Test_ChannelPool_Releases_Slot_When_Open_Fails(t *testing.T) {
+	pool, _ := newTestChannelPool(1, errors.New("disconnected"))
+
+	for i := 0; i < 2; i++ {
+		_, err := pool.Get(context.Background())
+		require.EqualError(t, err, "disconnected")
+	}
+
+	assert.Len(t, pool.slots, 0)
+}
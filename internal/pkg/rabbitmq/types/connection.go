@@ -1,151 +1,438 @@
 package types
 
 import (
+	"context"
 	"fmt"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/rabbitmqErrors"
 	errorUtils "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils/errorutils"
 
 	"emperror.dev/errors"
 	"github.com/rabbitmq/amqp091-go"
 )
 
+type ConnectionState int32
+
+const (
+	Disconnected ConnectionState = iota
+	Connecting
+	Connected
+	Closed
+)
+
+func (s ConnectionState) String() string {
+	switch s {
+	case Disconnected:
+		return "disconnected"
+	case Connecting:
+		return "connecting"
+	case Connected:
+		return "connected"
+	case Closed:
+		return "closed"
+	default:
+		return "unknown"
+	}
+}
+
+// ConnectionStats is a snapshot of the connection state, used for health and metrics
+type ConnectionStats struct {
+	State ConnectionState
+	// Host is the current (or last) connected host without the credentials
+	Host              string
+	Reconnects        int64
+	FailedReconnects  int64
+	BufferedPublishes int64
+}
+
 type internalConnection struct {
-	cfg *config.RabbitmqOptions
-	*amqp091.Connection
-	isConnected       bool
-	errConnectionChan chan error
-	errChannelChan    chan error
-	reconnectedChan   chan struct{}
+	cfg       *config.RabbitmqOptions
+	endpoints []string
+	dial      func(endpoint string) (*amqp091.Connection, error)
+
+	mu                 sync.RWMutex
+	connection         *amqp091.Connection
+	currentEndpoint    int
+	connectedChan      chan struct{} // closed when the connection is connected, replaced on disconnect
+	reconnectListeners []chan struct{}
+	closeChan          chan struct{}
+
+	state             atomic.Int32
+	reconnects        atomic.Int64
+	failedReconnects  atomic.Int64
+	bufferedPublishes atomic.Int64
+	publishSlots      chan struct{}
 }
 
 type IConnection interface {
 	IsClosed() bool
 	IsConnected() bool
-	// Channel gets a new channel on this internalConnection
+	// Channel gets a new channel on this internalConnection, it returns `rabbitmqErrors.ErrDisconnected` during a reconnect
 	Channel() (*amqp091.Channel, error)
 	Close() error
 	ReConnect() error
 	NotifyClose(receiver chan *amqp091.Error) chan *amqp091.Error
+	// NotifyReconnected registers a listener that receives a signal after each successful reconnect, the receiver
+	// should be buffered, signals are dropped for a receiver that is not ready.
+	NotifyReconnected(receiver chan struct{}) chan struct{}
+	// WaitForConnection blocks a publish until the connection is alive. during a reconnect at most `PublishBufferSize`
+	// callers wait, for at most `PublishBufferTimeout`.
+	WaitForConnection(ctx context.Context) error
+	State() ConnectionState
+	Stats() ConnectionStats
 	Raw() *amqp091.Connection
-	ErrorConnectionChannel() chan error
-	ReconnectedChannel() chan struct{}
 }
 
 func NewRabbitMQConnection(cfg *config.RabbitmqOptions) (IConnection, error) {
 	// https://levelup.gitconnected.com/connecting-a-service-in-golang-to-a-rabbitmq-server-835294d8c914
+	if cfg.UseInMemory {
+		return NewInMemoryConnection(), nil
+	}
+
 	if cfg.RabbitmqHostOptions == nil {
 		return nil, errors.New("rabbitmq host options is nil")
 	}
 
-	c := &internalConnection{
-		cfg:               cfg,
-		errConnectionChan: make(chan error),
-		// errChannelChan:    make(chan error),
-		reconnectedChan: make(chan struct{}),
-	}
+	c := newInternalConnection(cfg, amqp091.Dial)
 
-	err := c.connect()
+	err := c.connectToAnyHost()
 	if err != nil {
 		return nil, err
 	}
 
-	if cfg.Reconnecting {
-		go c.handleReconnecting()
+	return c, nil
+}
+
+func newInternalConnection(
+	cfg *config.RabbitmqOptions,
+	dial func(endpoint string) (*amqp091.Connection, error),
+) *internalConnection {
+	c := &internalConnection{
+		cfg:           cfg,
+		endpoints:     cfg.RabbitmqHostOptions.AmqpEndPoints(),
+		dial:          dial,
+		connectedChan: make(chan struct{}),
+		closeChan:     make(chan struct{}),
+		publishSlots:  make(chan struct{}, cfg.ReconnectOptions.GetPublishBufferSize()),
 	}
+	c.state.Store(int32(Disconnected))
 
-	return c, err
+	return c
 }
 
 func (c *internalConnection) Close() error {
-	return c.Connection.Close()
+	c.mu.Lock()
+	if c.State() == Closed {
+		c.mu.Unlock()
+		return nil
+	}
+
+	c.state.Store(int32(Closed))
+	close(c.closeChan)
+	conn := c.connection
+	c.mu.Unlock()
+
+	if conn == nil || conn.IsClosed() {
+		return nil
+	}
+
+	return conn.Close()
+}
+
+func (c *internalConnection) IsClosed() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.connection == nil || c.connection.IsClosed()
 }
 
 func (c *internalConnection) IsConnected() bool {
-	return c.isConnected
+	return c.State() == Connected
 }
 
-func (c *internalConnection) ErrorConnectionChannel() chan error {
-	return c.errConnectionChan
+func (c *internalConnection) State() ConnectionState {
+	return ConnectionState(c.state.Load())
 }
 
-func (c *internalConnection) ReconnectedChannel() chan struct{} {
-	return c.reconnectedChan
+func (c *internalConnection) Stats() ConnectionStats {
+	c.mu.RLock()
+	host := c.hostOf(c.currentEndpoint)
+	c.mu.RUnlock()
+
+	return ConnectionStats{
+		State:             c.State(),
+		Host:              host,
+		Reconnects:        c.reconnects.Load(),
+		FailedReconnects:  c.failedReconnects.Load(),
+		BufferedPublishes: c.bufferedPublishes.Load(),
+	}
 }
 
 func (c *internalConnection) ReConnect() error {
-	if c.Connection.IsClosed() == false {
+	if !c.IsClosed() {
 		return nil
 	}
 
-	return c.connect()
+	return c.connectToAnyHost()
 }
 
 func (c *internalConnection) Raw() *amqp091.Connection {
-	return c.Connection
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.connection
+}
+
+func (c *internalConnection) NotifyClose(receiver chan *amqp091.Error) chan *amqp091.Error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.connection == nil {
+		close(receiver)
+		return receiver
+	}
+
+	return c.connection.NotifyClose(receiver)
+}
+
+func (c *internalConnection) NotifyReconnected(receiver chan struct{}) chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.reconnectListeners = append(c.reconnectListeners, receiver)
+
+	return receiver
 }
 
 func (c *internalConnection) Channel() (*amqp091.Channel, error) {
-	ch, err := c.Connection.Channel()
-	//notifyChannelClose := ch.NotifyClose(make(chan *amqp091.Error))
-	//go func() {
-	//	<-notifyChannelClose //Listen to notifyChannelClose
-	//	c.errChannelChan <- errors.New("Channel Closed")
-	//}()
+	c.mu.RLock()
+	conn := c.connection
+	c.mu.RUnlock()
 
-	return ch, err
+	if !c.IsConnected() || conn == nil {
+		return nil, rabbitmqErrors.ErrDisconnected
+	}
+
+	return conn.Channel()
 }
 
-func (c *internalConnection) connect() error {
-	conn, err := amqp091.Dial(c.cfg.RabbitmqHostOptions.AmqpEndPoint())
-	if err != nil {
+func (c *internalConnection) WaitForConnection(ctx context.Context) error {
+	connectedChan, state := c.connectedSignal()
+
+	switch state {
+	case Connected:
+		return nil
+	case Closed:
+		return rabbitmqErrors.ErrConnectionClosed
+	}
+
+	if !c.cfg.Reconnecting {
+		return rabbitmqErrors.ErrDisconnected
+	}
+
+	// the buffer is bounded, so a long outage can't pile up unbounded publishes in memory
+	select {
+	case c.publishSlots <- struct{}{}:
+	default:
+		return rabbitmqErrors.ErrPublishBufferFull
+	}
+
+	c.bufferedPublishes.Add(1)
+	defer func() {
+		c.bufferedPublishes.Add(-1)
+		<-c.publishSlots
+	}()
+
+	timer := time.NewTimer(c.cfg.ReconnectOptions.GetPublishBufferTimeout())
+	defer timer.Stop()
+
+	select {
+	case <-connectedChan:
+		if c.State() == Closed {
+			return rabbitmqErrors.ErrConnectionClosed
+		}
+
+		return nil
+	case <-c.closeChan:
+		return rabbitmqErrors.ErrConnectionClosed
+	case <-timer.C:
 		return errors.WrapIf(
-			err,
-			fmt.Sprintf(
-				"Error in connecting to rabbitmq with host: %s",
-				c.cfg.RabbitmqHostOptions.AmqpEndPoint(),
-			),
+			rabbitmqErrors.ErrDisconnected,
+			"timeout in waiting for rabbitmq reconnect",
 		)
+	case <-ctx.Done():
+		return errors.WrapIf(ctx.Err(), "waiting for rabbitmq reconnect canceled")
+	}
+}
+
+func (c *internalConnection) connectedSignal() (chan struct{}, ConnectionState) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.connectedChan, c.State()
+}
+
+// connectToAnyHost tries all the hosts once, starting from the current host
+func (c *internalConnection) connectToAnyHost() error {
+	if !c.state.CompareAndSwap(int32(Disconnected), int32(Connecting)) {
+		if c.State() == Closed {
+			return rabbitmqErrors.ErrConnectionClosed
+		}
+	}
+
+	var lastErr error
+
+	for i := 0; i < len(c.endpoints); i++ {
+		c.mu.RLock()
+		index := (c.currentEndpoint + i) % len(c.endpoints)
+		c.mu.RUnlock()
+
+		conn, err := c.dial(c.endpoints[index])
+		if err != nil {
+			lastErr = errors.WrapIf(
+				err,
+				fmt.Sprintf(
+					"Error in connecting to rabbitmq with host: %s",
+					c.hostOf(index),
+				),
+			)
+			defaultLogger.GetLogger().Warn(lastErr.Error())
+
+			continue
+		}
+
+		c.setConnected(conn, index)
+
+		return nil
 	}
 
-	c.Connection = conn
-	c.isConnected = true
+	c.state.CompareAndSwap(int32(Connecting), int32(Disconnected))
+
+	return lastErr
+}
+
+func (c *internalConnection) setConnected(conn *amqp091.Connection, endpointIndex int) {
+	c.mu.Lock()
+
+	if c.State() == Closed {
+		c.mu.Unlock()
+		_ = conn.Close()
+
+		return
+	}
+
+	c.connection = conn
+	c.currentEndpoint = endpointIndex
+	c.state.Store(int32(Connected))
+	close(c.connectedChan)
+
+	c.mu.Unlock()
 
 	// https://stackoverflow.com/questions/41991926/how-to-detect-dead-rabbitmq-connection
-	notifyClose := c.Connection.NotifyClose(make(chan *amqp091.Error))
+	notifyClose := conn.NotifyClose(make(chan *amqp091.Error, 1))
 
 	go func() {
 		defer errorUtils.HandlePanic()
-		chanErr := <-notifyClose // Listen to NotifyClose
-		c.isConnected = false
-		c.errConnectionChan <- errors.WrapIf(chanErr, "rabbitmq Connection Closed with an error.")
+
+		chanErr, ok := <-notifyClose // Listen to NotifyClose
+		c.setDisconnected()
+
+		// a nil error means the connection closed gracefully with `Close`
+		if !ok || chanErr == nil || !c.cfg.Reconnecting {
+			return
+		}
+
+		defaultLogger.GetLogger().Errorf(
+			"rabbitmq connection to host %s closed with an error: %v",
+			c.hostOf(endpointIndex),
+			chanErr,
+		)
+
+		c.handleReconnecting()
 	}()
+}
+
+func (c *internalConnection) setDisconnected() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.State() != Connected {
+		return
+	}
 
-	return nil
+	c.state.Store(int32(Disconnected))
+	c.connectedChan = make(chan struct{})
 }
 
 func (c *internalConnection) handleReconnecting() {
-	defer errorUtils.HandlePanic()
-	for {
+	// start from the next host, the current host is probably the failed one
+	c.mu.Lock()
+	c.currentEndpoint = (c.currentEndpoint + 1) % len(c.endpoints)
+	c.mu.Unlock()
+
+	for attempt := 0; ; attempt++ {
+		delay := c.cfg.ReconnectOptions.Delay(attempt)
+
+		select {
+		case <-c.closeChan:
+			return
+		case <-time.After(delay):
+		}
+
+		defaultLogger.GetLogger().
+			Infof("Rabbitmq Connection Reconnecting started, attempt %d", attempt+1)
+
+		err := c.connectToAnyHost()
+		if err != nil {
+			c.failedReconnects.Add(1)
+			defaultLogger.GetLogger().
+				Error(fmt.Sprintf("Error in reconnecting, %s", err))
+
+			continue
+		}
+
+		if c.State() == Closed {
+			return
+		}
+
+		c.reconnects.Add(1)
+		defaultLogger.GetLogger().
+			Infof("Rabbitmq Connection Reconnected to host %s", c.Stats().Host)
+
+		c.notifyReconnected()
+
+		return
+	}
+}
+
+func (c *internalConnection) notifyReconnected() {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, listener := range c.reconnectListeners {
 		select {
-		case err := <-c.errConnectionChan:
-			if err != nil {
-				defaultLogger.GetLogger().
-					Info("Rabbitmq Connection Reconnecting started")
-				err := c.connect()
-				if err != nil {
-					defaultLogger.GetLogger().
-						Error(fmt.Sprintf("Error in reconnecting, %s", err))
-					continue
-				}
-
-				defaultLogger.GetLogger().
-					Info("Rabbitmq Connection Reconnected")
-				c.isConnected = true
-				c.reconnectedChan <- struct{}{}
-				continue
-			}
+		case listener <- struct{}{}:
+		default:
 		}
 	}
 }
+
+// hostOf returns the endpoint without the credentials, for using in logs and metrics
+func (c *internalConnection) hostOf(index int) string {
+	if index < 0 || index >= len(c.endpoints) {
+		return ""
+	}
+
+	u, err := url.Parse(c.endpoints[index])
+	if err != nil {
+		return ""
+	}
+
+	return u.Host
+}
@@ -0,0 +1,78 @@
+package types
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/rabbitmqErrors"
+
+	"github.com/rabbitmq/amqp091-go"
+)
+
+const inMemoryHost = "in-memory"
+
+type inMemoryConnection struct {
+	closed atomic.Bool
+}
+
+// NewInMemoryConnection creates the connection of the in-memory broker, it is always connected until it is closed and
+// it has no amqp channels, the in-memory consumers and producers use the broker directly.
+func NewInMemoryConnection() IConnection {
+	return &inMemoryConnection{}
+}
+
+func (c *inMemoryConnection) IsClosed() bool {
+	return c.closed.Load()
+}
+
+func (c *inMemoryConnection) IsConnected() bool {
+	return !c.closed.Load()
+}
+
+func (c *inMemoryConnection) Channel() (*amqp091.Channel, error) {
+	return nil, rabbitmqErrors.ErrDisconnected
+}
+
+func (c *inMemoryConnection) Close() error {
+	c.closed.Store(true)
+
+	return nil
+}
+
+func (c *inMemoryConnection) ReConnect() error {
+	c.closed.Store(false)
+
+	return nil
+}
+
+func (c *inMemoryConnection) NotifyClose(receiver chan *amqp091.Error) chan *amqp091.Error {
+	return receiver
+}
+
+func (c *inMemoryConnection) NotifyReconnected(receiver chan struct{}) chan struct{} {
+	return receiver
+}
+
+func (c *inMemoryConnection) WaitForConnection(ctx context.Context) error {
+	if c.closed.Load() {
+		return rabbitmqErrors.ErrConnectionClosed
+	}
+
+	return ctx.Err()
+}
+
+func (c *inMemoryConnection) State() ConnectionState {
+	if c.closed.Load() {
+		return Closed
+	}
+
+	return Connected
+}
+
+func (c *inMemoryConnection) Stats() ConnectionStats {
+	return ConnectionStats{State: c.State(), Host: inMemoryHost}
+}
+
+func (c *inMemoryConnection) Raw() *amqp091.Connection {
+	return nil
+}
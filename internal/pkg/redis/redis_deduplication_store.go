@@ -0,0 +1,56 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/deduplication"
+
+	"emperror.dev/errors"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/fx"
+)
+
+const deduplicationKeyPrefix = "deduplication:"
+
+// DeduplicationModule provides the redis backed DeduplicationStore, it should be used with `deduplication.Module`
+var DeduplicationModule = fx.Module( //nolint:gochecknoglobals
+	"redisdeduplicationfx",
+	fx.Provide(NewRedisDeduplicationStore),
+)
+
+type redisDeduplicationStore struct {
+	client redis.UniversalClient
+}
+
+// NewRedisDeduplicationStore keeps the content hashes in the `deduplication:` keys, the keys expire with their window,
+// so the instances of a service share the windows without a cleanup
+func NewRedisDeduplicationStore(client redis.UniversalClient) deduplication.DeduplicationStore {
+	return &redisDeduplicationStore{client: client}
+}
+
+func (r *redisDeduplicationStore) Claim(ctx context.Context, key string, window time.Duration) (bool, error) {
+	claimed, err := r.client.SetNX(ctx, deduplicationKeyPrefix+key, time.Now().Unix(), window).Result()
+	if err != nil {
+		return false, errors.WrapIf(err, "error in claiming the deduplication key")
+	}
+
+	if claimed {
+		return true, nil
+	}
+
+	// the window of a duplicate starts again, so the retries of a producer are skipped while they continue
+	if err := r.client.PExpire(ctx, deduplicationKeyPrefix+key, window).Err(); err != nil {
+		return false, errors.WrapIf(err, "error in extending the deduplication window")
+	}
+
+	return false, nil
+}
+
+func (r *redisDeduplicationStore) Release(ctx context.Context, key string) error {
+	if err := r.client.Del(ctx, deduplicationKeyPrefix+key).Err(); err != nil {
+		return errors.WrapIf(err, "error in releasing the deduplication key")
+	}
+
+	return nil
+}
@@ -13,7 +13,7 @@ var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[RedisOpti
 type RedisOptions struct {
 	Host          string `mapstructure:"host"`
 	Port          int    `mapstructure:"port"`
-	Password      string `mapstructure:"password"`
+	Password      string `mapstructure:"password" secret:"true"`
 	Database      int    `mapstructure:"database"`
 	PoolSize      int    `mapstructure:"poolSize"`
 	EnableTracing bool   `mapstructure:"enableTracing" default:"true"`
@@ -52,13 +52,6 @@ func discoverTypes() {
 					packages[loadedTypePtr.PkgPath()] = pkgTypesPtr
 				}
 
-				if strings.Contains(loadedType.String(), "Test") {
-					n := GetFullTypeNameByType(loadedType)
-					n2 := GetTypeNameByType(loadedType)
-					fmt.Println(n)
-					fmt.Println(n2)
-				}
-
 				types[GetFullTypeNameByType(loadedType)] = append(
 					types[GetFullTypeNameByType(loadedType)],
 					loadedType,
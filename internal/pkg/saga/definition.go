@@ -0,0 +1,116 @@
+package saga
+
+import (
+	"context"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	messagingUtils "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/utils"
+)
+
+// Action runs a step or its compensation, it returns the command message which is published for the participant of the
+// step, a nil message completes a local step without waiting for a reply
+type Action[T any] func(ctx context.Context, state *T) (types.IMessage, error)
+
+// ReplyHandler updates the state of the saga with the reply of a participant
+type ReplyHandler[T any] func(ctx context.Context, state *T, reply types.IMessage) error
+
+// Definition is the steps of a saga on the state T, the steps run in order and the completed steps are compensated in
+// reverse order when a step fails or times out.
+//
+//	definition := saga.NewDefinition[OrderFulfillmentState]("order_fulfillment")
+//	definition.Step("reserve_stock").
+//		Invoke(reserveStock).
+//		OnReply(&StockReservedV1{}, onStockReserved).
+//		OnFailure(&StockReservationFailedV1{}, nil).
+//		Compensate(releaseStock).
+//		WithTimeout(time.Minute)
+type Definition[T any] struct {
+	name          string
+	steps         []*step[T]
+	onCompleted   func(ctx context.Context, state *T) error
+	onCompensated func(ctx context.Context, state *T, reason string) error
+}
+
+type step[T any] struct {
+	name       string
+	invoke     Action[T]
+	compensate Action[T]
+	timeout    time.Duration
+	replies    map[string]*reply[T]
+}
+
+type reply[T any] struct {
+	handler ReplyHandler[T]
+	failure bool
+}
+
+func NewDefinition[T any](name string) *Definition[T] {
+	return &Definition[T]{name: name}
+}
+
+func (d *Definition[T]) Name() string {
+	return d.name
+}
+
+// Step adds a step to the end of the saga
+func (d *Definition[T]) Step(name string) *StepBuilder[T] {
+	s := &step[T]{name: name, replies: make(map[string]*reply[T])}
+	d.steps = append(d.steps, s)
+
+	return &StepBuilder[T]{step: s}
+}
+
+// OnCompleted runs after the last step of the saga is completed
+func (d *Definition[T]) OnCompleted(handler func(ctx context.Context, state *T) error) *Definition[T] {
+	d.onCompleted = handler
+
+	return d
+}
+
+// OnCompensated runs after the completed steps of a failed or timed out saga are compensated
+func (d *Definition[T]) OnCompensated(handler func(ctx context.Context, state *T, reason string) error) *Definition[T] {
+	d.onCompensated = handler
+
+	return d
+}
+
+type StepBuilder[T any] struct {
+	step *step[T]
+}
+
+// Invoke sets the action of the step
+func (b *StepBuilder[T]) Invoke(action Action[T]) *StepBuilder[T] {
+	b.step.invoke = action
+
+	return b
+}
+
+// OnReply completes the step with the reply message type, the handler is optional
+func (b *StepBuilder[T]) OnReply(message types.IMessage, handler ReplyHandler[T]) *StepBuilder[T] {
+	b.step.replies[messagingUtils.GetMessageName(message)] = &reply[T]{handler: handler}
+
+	return b
+}
+
+// OnFailure fails the step with the reply message type and compensates the completed steps, the handler is optional
+func (b *StepBuilder[T]) OnFailure(message types.IMessage, handler ReplyHandler[T]) *StepBuilder[T] {
+	b.step.replies[messagingUtils.GetMessageName(message)] = &reply[T]{handler: handler, failure: true}
+
+	return b
+}
+
+// Compensate sets the action which undoes the completed step
+func (b *StepBuilder[T]) Compensate(action Action[T]) *StepBuilder[T] {
+	b.step.compensate = action
+
+	return b
+}
+
+// WithTimeout fails the step when its reply is not received in the timeout, zero uses the default timeout of the
+// saga options
+func (b *StepBuilder[T]) WithTimeout(timeout time.Duration) *StepBuilder[T] {
+	b.step.timeout = timeout
+
+	return b
+}
@@ -0,0 +1,115 @@
+package saga
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+)
+
+type inMemorySagaStore struct {
+	mu        sync.RWMutex
+	instances map[string]*SagaInstance
+}
+
+// NewInMemorySagaStore keeps the saga instances in the memory of the process, it is used by the tests
+func NewInMemorySagaStore() SagaStore {
+	return &inMemorySagaStore{instances: make(map[string]*SagaInstance)}
+}
+
+func (s *inMemorySagaStore) Add(_ context.Context, instance *SagaInstance) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.instances[instance.Id]; ok {
+		return customErrors.NewConflictError(fmt.Sprintf("saga with id `%s` already exists", instance.Id))
+	}
+
+	copied := *instance
+	s.instances[instance.Id] = &copied
+
+	return nil
+}
+
+func (s *inMemorySagaStore) Get(_ context.Context, id string) (*SagaInstance, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	instance, ok := s.instances[id]
+	if !ok {
+		return nil, customErrors.NewNotFoundError(fmt.Sprintf("saga with id `%s` not found", id))
+	}
+
+	copied := *instance
+
+	return &copied, nil
+}
+
+func (s *inMemorySagaStore) GetByMessageId(_ context.Context, messageId string) (*SagaInstance, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, instance := range s.instances {
+		if instance.Status == Running && instance.PendingMessageId == messageId {
+			copied := *instance
+
+			return &copied, nil
+		}
+	}
+
+	return nil, customErrors.NewNotFoundError(fmt.Sprintf("saga waiting for message `%s` not found", messageId))
+}
+
+func (s *inMemorySagaStore) Update(_ context.Context, instance *SagaInstance) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.instances[instance.Id]
+	if !ok {
+		return customErrors.NewNotFoundError(fmt.Sprintf("saga with id `%s` not found", instance.Id))
+	}
+
+	if stored.Version != instance.Version {
+		return customErrors.NewConcurrencyConflictError(
+			fmt.Sprintf("saga with id `%s` is updated by another handler", instance.Id),
+		)
+	}
+
+	instance.Version++
+	copied := *instance
+	s.instances[instance.Id] = &copied
+
+	return nil
+}
+
+func (s *inMemorySagaStore) ListTimedOut(
+	_ context.Context,
+	sagaName string,
+	at time.Time,
+	limit int,
+) ([]*SagaInstance, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var instances []*SagaInstance
+	for _, instance := range s.instances {
+		if instance.SagaName == sagaName && instance.Status == Running && instance.TimeoutAt != nil &&
+			!instance.TimeoutAt.After(at) {
+			copied := *instance
+			instances = append(instances, &copied)
+		}
+	}
+
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].TimeoutAt.Before(*instances[j].TimeoutAt)
+	})
+
+	if limit > 0 && len(instances) > limit {
+		instances = instances[:limit]
+	}
+
+	return instances, nil
+}
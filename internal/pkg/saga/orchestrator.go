@@ -0,0 +1,321 @@
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	messageHeader "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/messageheader"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/producer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	messagingUtils "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+
+	"emperror.dev/errors"
+)
+
+// Orchestration compensates the timed out sagas of an orchestrator, the orchestrators are provided with the
+// `sagaOrchestrators` fx group
+type Orchestration interface {
+	Name() string
+	// CompensateTimedOut compensates the sagas whose current step is timed out at the time, it returns the number of
+	// the compensated sagas
+	CompensateTimedOut(ctx context.Context, at time.Time) (int, error)
+}
+
+// Orchestrator runs the sagas of a definition, the commands of the steps are published with their message id as the
+// correlation id, and the participants reply with the same correlation id, so a reply is correlated with the saga which
+// is waiting for it. the orchestrator is the consumer handler of the replies of the steps.
+//
+// a step is completed after its reply is handled, so the participants should handle the redelivered commands and the
+// compensations should be a no-op for the steps which are not done by the participant.
+type Orchestrator[T any] struct {
+	definition *Definition[T]
+	store      SagaStore
+	producer   producer.Producer
+	options    *SagaOptions
+	log        logger.Logger
+	now        func() time.Time
+}
+
+func NewOrchestrator[T any](
+	definition *Definition[T],
+	store SagaStore,
+	producer producer.Producer,
+	options *SagaOptions,
+	log logger.Logger,
+) *Orchestrator[T] {
+	return &Orchestrator[T]{
+		definition: definition,
+		store:      store,
+		producer:   producer,
+		options:    options,
+		log:        log,
+		now:        time.Now,
+	}
+}
+
+func (o *Orchestrator[T]) Name() string {
+	return o.definition.name
+}
+
+// Start creates the saga with the id and runs its first step, the id should be derived from the message which starts
+// the saga, so a redelivered message fails with a conflict error instead of running the saga twice
+func (o *Orchestrator[T]) Start(ctx context.Context, id string, state *T) error {
+	now := o.now()
+	instance := &SagaInstance{
+		Id:        id,
+		SagaName:  o.definition.name,
+		Status:    Running,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	return o.advance(ctx, instance, state, true)
+}
+
+// Handle handles the replies of the participants, the replies which are not expected by the current step of a saga,
+// e.g. the redelivered replies of the completed steps, are skipped
+func (o *Orchestrator[T]) Handle(ctx context.Context, consumeContext types.MessageConsumeContext) error {
+	correlationId := consumeContext.CorrelationId()
+	if correlationId == "" {
+		return nil
+	}
+
+	instance, err := o.store.GetByMessageId(ctx, correlationId)
+	if customErrors.IsNotFoundError(err) {
+		o.log.Infof(
+			"[Orchestrator.Handle] no %s saga is waiting for the reply of message `%s`, the reply is skipped",
+			o.definition.name,
+			correlationId,
+		)
+
+		return nil
+	}
+	if err != nil {
+		return errors.WrapIf(err, "error in getting the saga of the reply")
+	}
+
+	if instance.SagaName != o.definition.name || instance.CurrentStep >= len(o.definition.steps) {
+		return nil
+	}
+
+	currentStep := o.definition.steps[instance.CurrentStep]
+	replyName := messagingUtils.GetMessageName(consumeContext.Message())
+
+	r, ok := currentStep.replies[replyName]
+	if !ok {
+		o.log.Warnf(
+			"[Orchestrator.Handle] reply `%s` is not expected by step `%s` of saga `%s`, the reply is skipped",
+			replyName,
+			currentStep.name,
+			instance.Id,
+		)
+
+		return nil
+	}
+
+	state, err := o.unmarshalState(instance)
+	if err != nil {
+		return err
+	}
+
+	if r.handler != nil {
+		if err := r.handler(ctx, state, consumeContext.Message()); err != nil {
+			return errors.WrapIff(err, "error in handling reply `%s` of step `%s`", replyName, currentStep.name)
+		}
+	}
+
+	if r.failure {
+		return o.compensate(
+			ctx,
+			instance,
+			state,
+			fmt.Sprintf("step `%s` is failed with `%s`", currentStep.name, replyName),
+			false,
+		)
+	}
+
+	instance.CurrentStep++
+
+	return o.advance(ctx, instance, state, false)
+}
+
+func (o *Orchestrator[T]) CompensateTimedOut(ctx context.Context, at time.Time) (int, error) {
+	instances, err := o.store.ListTimedOut(ctx, o.definition.name, at, o.options.TimeoutBatchSize)
+	if err != nil {
+		return 0, errors.WrapIf(err, "error in listing the timed out sagas")
+	}
+
+	var compensated int
+	for _, instance := range instances {
+		state, err := o.unmarshalState(instance)
+		if err != nil {
+			return compensated, err
+		}
+
+		reason := fmt.Sprintf("step `%s` is timed out", o.definition.steps[instance.CurrentStep].name)
+
+		// the timed out step may be done by its participant after the timeout, so it is compensated as well
+		err = o.compensate(ctx, instance, state, reason, true)
+		if customErrors.IsConcurrencyConflictError(err) {
+			// the reply is handled in the meantime
+			continue
+		}
+		if err != nil {
+			return compensated, err
+		}
+
+		compensated++
+	}
+
+	return compensated, nil
+}
+
+// advance runs the steps from the current step until a step publishes a command or the saga is completed, the saga is
+// saved before publishing the command, so its reply finds the saga
+func (o *Orchestrator[T]) advance(ctx context.Context, instance *SagaInstance, state *T, isNew bool) error {
+	for instance.CurrentStep < len(o.definition.steps) {
+		currentStep := o.definition.steps[instance.CurrentStep]
+		if currentStep.invoke == nil {
+			instance.CurrentStep++
+
+			continue
+		}
+
+		message, err := currentStep.invoke(ctx, state)
+		if err != nil {
+			return errors.WrapIff(err, "error in running step `%s` of saga `%s`", currentStep.name, instance.Id)
+		}
+
+		if message == nil {
+			instance.CurrentStep++
+
+			continue
+		}
+
+		timeoutAt := o.now().Add(o.stepTimeout(currentStep))
+		instance.PendingMessageId = message.GeMessageId()
+		instance.TimeoutAt = &timeoutAt
+
+		if err := o.save(ctx, instance, state, isNew); err != nil {
+			return err
+		}
+
+		// a saga whose command is not published is compensated after the timeout of the step
+		return o.publish(ctx, message)
+	}
+
+	if o.definition.onCompleted != nil {
+		if err := o.definition.onCompleted(ctx, state); err != nil {
+			return errors.WrapIff(err, "error in completing saga `%s`", instance.Id)
+		}
+	}
+
+	instance.Status = Completed
+	instance.PendingMessageId = ""
+	instance.TimeoutAt = nil
+
+	return o.save(ctx, instance, state, isNew)
+}
+
+// compensate runs the compensations of the completed steps in reverse order, a saga whose compensation fails is saved
+// as failed to be fixed manually
+func (o *Orchestrator[T]) compensate(
+	ctx context.Context,
+	instance *SagaInstance,
+	state *T,
+	reason string,
+	includeCurrent bool,
+) error {
+	last := instance.CurrentStep - 1
+	if includeCurrent {
+		last = instance.CurrentStep
+	}
+
+	instance.Status = Compensated
+	instance.FailureReason = reason
+	instance.PendingMessageId = ""
+	instance.TimeoutAt = nil
+
+	if err := o.runCompensations(ctx, state, last, reason); err != nil {
+		o.log.Errorw(
+			fmt.Sprintf("[Orchestrator.compensate] error in compensating saga `%s`: %v", instance.Id, err),
+			logger.Fields{"SagaName": o.definition.name, "SagaId": instance.Id},
+		)
+
+		instance.Status = Failed
+		instance.FailureReason = fmt.Sprintf("%s, compensation is failed: %v", reason, err)
+	}
+
+	return o.save(ctx, instance, state, false)
+}
+
+func (o *Orchestrator[T]) runCompensations(ctx context.Context, state *T, last int, reason string) error {
+	for i := last; i >= 0; i-- {
+		currentStep := o.definition.steps[i]
+		if currentStep.compensate == nil {
+			continue
+		}
+
+		message, err := currentStep.compensate(ctx, state)
+		if err != nil {
+			return errors.WrapIff(err, "error in compensating step `%s`", currentStep.name)
+		}
+
+		if message != nil {
+			if err := o.publish(ctx, message); err != nil {
+				return errors.WrapIff(err, "error in publishing the compensation of step `%s`", currentStep.name)
+			}
+		}
+	}
+
+	if o.definition.onCompensated != nil {
+		return o.definition.onCompensated(ctx, state, reason)
+	}
+
+	return nil
+}
+
+func (o *Orchestrator[T]) save(ctx context.Context, instance *SagaInstance, state *T, isNew bool) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return errors.WrapIf(err, "error in marshaling the saga state")
+	}
+
+	instance.State = data
+	instance.UpdatedAt = o.now()
+
+	if isNew {
+		return o.store.Add(ctx, instance)
+	}
+
+	return o.store.Update(ctx, instance)
+}
+
+func (o *Orchestrator[T]) publish(ctx context.Context, message types.IMessage) error {
+	meta := metadata.Metadata{}
+	messageHeader.SetCorrelationId(meta, message.GeMessageId())
+
+	return o.producer.PublishMessage(ctx, message, meta)
+}
+
+func (o *Orchestrator[T]) unmarshalState(instance *SagaInstance) (*T, error) {
+	state := new(T)
+	if err := json.Unmarshal(instance.State, state); err != nil {
+		return nil, errors.WrapIff(err, "error in unmarshaling the state of saga `%s`", instance.Id)
+	}
+
+	return state, nil
+}
+
+func (o *Orchestrator[T]) stepTimeout(s *step[T]) time.Duration {
+	if s.timeout > 0 {
+		return s.timeout
+	}
+
+	return o.options.StepTimeout
+}
@@ -0,0 +1,251 @@
+package saga
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ReserveStock struct {
+	*types.Message
+}
+
+type StockReserved struct {
+	*types.Message
+	ReservationId string
+}
+
+type ReleaseStock struct {
+	*types.Message
+}
+
+type ProcessPayment struct {
+	*types.Message
+}
+
+type PaymentCompleted struct {
+	*types.Message
+}
+
+type PaymentFailed struct {
+	*types.Message
+}
+
+type fulfillmentState struct {
+	OrderId       string
+	ReservationId string
+	Confirmed     bool
+	FailureReason string
+}
+
+func newMessage() *types.Message {
+	return types.NewMessage(uuid.NewV4().String())
+}
+
+type fakeProducer struct {
+	messages []types.IMessage
+}
+
+func (f *fakeProducer) PublishMessage(_ context.Context, message types.IMessage, _ metadata.Metadata) error {
+	f.messages = append(f.messages, message)
+
+	return nil
+}
+
+func (f *fakeProducer) PublishMessageWithTopicName(
+	ctx context.Context,
+	message types.IMessage,
+	meta metadata.Metadata,
+	_ string,
+) error {
+	return f.PublishMessage(ctx, message, meta)
+}
+
+func (f *fakeProducer) PublishMessageWithDelay(
+	ctx context.Context,
+	message types.IMessage,
+	meta metadata.Metadata,
+	_ time.Duration,
+) error {
+	return f.PublishMessage(ctx, message, meta)
+}
+
+func (f *fakeProducer) PublishMessages(ctx context.Context, messages []types.IMessage) error {
+	for _, message := range messages {
+		_ = f.PublishMessage(ctx, message, nil)
+	}
+
+	return nil
+}
+
+func (f *fakeProducer) IsProduced(func(message types.IMessage)) {}
+
+func (f *fakeProducer) last() types.IMessage {
+	return f.messages[len(f.messages)-1]
+}
+
+func newFulfillmentOrchestrator(store SagaStore, producer *fakeProducer) *Orchestrator[fulfillmentState] {
+	definition := NewDefinition[fulfillmentState]("order_fulfillment").
+		OnCompleted(func(ctx context.Context, state *fulfillmentState) error {
+			return nil
+		}).
+		OnCompensated(func(ctx context.Context, state *fulfillmentState, reason string) error {
+			state.FailureReason = reason
+
+			return nil
+		})
+
+	definition.Step("reserve_stock").
+		Invoke(func(ctx context.Context, state *fulfillmentState) (types.IMessage, error) {
+			return &ReserveStock{Message: newMessage()}, nil
+		}).
+		OnReply(&StockReserved{}, func(ctx context.Context, state *fulfillmentState, reply types.IMessage) error {
+			state.ReservationId = reply.(*StockReserved).ReservationId
+
+			return nil
+		}).
+		Compensate(func(ctx context.Context, state *fulfillmentState) (types.IMessage, error) {
+			return &ReleaseStock{Message: newMessage()}, nil
+		}).
+		WithTimeout(time.Minute)
+
+	definition.Step("process_payment").
+		Invoke(func(ctx context.Context, state *fulfillmentState) (types.IMessage, error) {
+			return &ProcessPayment{Message: newMessage()}, nil
+		}).
+		OnReply(&PaymentCompleted{}, nil).
+		OnFailure(&PaymentFailed{}, nil)
+
+	definition.Step("confirm_order").
+		Invoke(func(ctx context.Context, state *fulfillmentState) (types.IMessage, error) {
+			state.Confirmed = true
+
+			return nil, nil
+		})
+
+	return NewOrchestrator(
+		definition,
+		store,
+		producer,
+		&SagaOptions{StepTimeout: 5 * time.Minute, TimeoutBatchSize: 10},
+		defaultLogger.GetLogger(),
+	)
+}
+
+func sendReply(t *testing.T, orchestrator *Orchestrator[fulfillmentState], message types.IMessage, correlationId string) {
+	t.Helper()
+
+	consumeContext := types.NewMessageConsumeContext(
+		message,
+		metadata.Metadata{},
+		"application/json",
+		"",
+		time.Now(),
+		0,
+		message.GeMessageId(),
+		correlationId,
+	)
+	require.NoError(t, orchestrator.Handle(context.Background(), consumeContext))
+}
+
+func loadState(t *testing.T, store SagaStore, id string) (*SagaInstance, *fulfillmentState) {
+	t.Helper()
+
+	instance, err := store.Get(context.Background(), id)
+	require.NoError(t, err)
+
+	state, err := (&Orchestrator[fulfillmentState]{}).unmarshalState(instance)
+	require.NoError(t, err)
+
+	return instance, state
+}
+
+func Test_Orchestrator_Completes_Saga_With_Replies(t *testing.T) {
+	t.Parallel()
+
+	store := NewInMemorySagaStore()
+	producer := &fakeProducer{}
+	orchestrator := newFulfillmentOrchestrator(store, producer)
+
+	require.NoError(t, orchestrator.Start(context.Background(), "order-1", &fulfillmentState{OrderId: "order-1"}))
+	reserve := producer.last()
+	assert.IsType(t, &ReserveStock{}, reserve)
+
+	sendReply(t, orchestrator, &StockReserved{Message: newMessage(), ReservationId: "reservation-1"}, reserve.GeMessageId())
+	payment := producer.last()
+	assert.IsType(t, &ProcessPayment{}, payment)
+
+	// the redelivered reply of the completed step is skipped
+	sendReply(t, orchestrator, &StockReserved{Message: newMessage(), ReservationId: "reservation-2"}, reserve.GeMessageId())
+	assert.Len(t, producer.messages, 2)
+
+	sendReply(t, orchestrator, &PaymentCompleted{Message: newMessage()}, payment.GeMessageId())
+
+	instance, state := loadState(t, store, "order-1")
+	assert.Equal(t, Completed, instance.Status)
+	assert.Empty(t, instance.PendingMessageId)
+	assert.Equal(t, "reservation-1", state.ReservationId)
+	assert.True(t, state.Confirmed)
+
+	err := orchestrator.Start(context.Background(), "order-1", &fulfillmentState{OrderId: "order-1"})
+	assert.True(t, customErrors.IsConflictError(err))
+}
+
+func Test_Orchestrator_Compensates_Completed_Steps_On_Failure(t *testing.T) {
+	t.Parallel()
+
+	store := NewInMemorySagaStore()
+	producer := &fakeProducer{}
+	orchestrator := newFulfillmentOrchestrator(store, producer)
+
+	require.NoError(t, orchestrator.Start(context.Background(), "order-1", &fulfillmentState{OrderId: "order-1"}))
+	sendReply(t, orchestrator, &StockReserved{Message: newMessage()}, producer.last().GeMessageId())
+	sendReply(t, orchestrator, &PaymentFailed{Message: newMessage()}, producer.last().GeMessageId())
+
+	assert.IsType(t, &ReleaseStock{}, producer.last())
+
+	instance, state := loadState(t, store, "order-1")
+	assert.Equal(t, Compensated, instance.Status)
+	assert.Contains(t, instance.FailureReason, "process_payment")
+	assert.Equal(t, instance.FailureReason, state.FailureReason)
+	assert.False(t, state.Confirmed)
+}
+
+func Test_Orchestrator_Compensates_Timed_Out_Sagas(t *testing.T) {
+	t.Parallel()
+
+	store := NewInMemorySagaStore()
+	producer := &fakeProducer{}
+	orchestrator := newFulfillmentOrchestrator(store, producer)
+
+	require.NoError(t, orchestrator.Start(context.Background(), "order-1", &fulfillmentState{OrderId: "order-1"}))
+	reserve := producer.last()
+
+	count, err := orchestrator.CompensateTimedOut(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	count, err = orchestrator.CompensateTimedOut(context.Background(), time.Now().Add(2*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	// the timed out step is compensated, because its participant may reserve the stock after the timeout
+	assert.IsType(t, &ReleaseStock{}, producer.last())
+
+	instance, _ := loadState(t, store, "order-1")
+	assert.Equal(t, Compensated, instance.Status)
+	assert.Contains(t, instance.FailureReason, "timed out")
+
+	// the late reply is skipped
+	sendReply(t, orchestrator, &StockReserved{Message: newMessage()}, reserve.GeMessageId())
+	assert.Len(t, producer.messages, 2)
+}
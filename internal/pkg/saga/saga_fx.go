@@ -0,0 +1,70 @@
+package saga
+
+import (
+	"context"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/web"
+
+	"go.uber.org/fx"
+)
+
+// Module provides the saga options and compensates the timed out sagas of the orchestrators of the `sagaOrchestrators`
+// group, the SagaStore is provided by `postgresgorm.SagaModule`
+// https://uber-go.github.io/fx/modules.html
+var Module = fx.Module( //nolint:gochecknoglobals
+	"sagafx",
+	fx.Provide(ProvideConfig),
+	fx.Invoke(fx.Annotate(registerHooks, fx.ParamTags(``, ``, ``, `group:"sagaOrchestrators"`))),
+)
+
+// AsOrchestration annotates the constructor of an orchestrator to be provided to the `sagaOrchestrators` group
+func AsOrchestration(constructor interface{}) interface{} {
+	return fx.Annotate(
+		constructor,
+		fx.As(new(Orchestration)),
+		fx.ResultTags(`group:"sagaOrchestrators"`),
+	)
+}
+
+// we don't want to register any dependencies here, its func body should execute always even we don't request for that, so we should use `invoke`
+func registerHooks(
+	lc fx.Lifecycle,
+	options *SagaOptions,
+	log logger.Logger,
+	orchestrations []Orchestration,
+) error {
+	if !options.Enabled || options.TimeoutCheckInterval <= 0 || len(orchestrations) == 0 {
+		return nil
+	}
+
+	return web.RegisterPeriodicWorker(
+		lc,
+		"saga timeouts worker",
+		options.TimeoutCheckInterval,
+		log,
+		func(ctx context.Context) error {
+			compensateTimedOut(ctx, orchestrations, log)
+
+			return nil
+		},
+	)
+}
+
+func compensateTimedOut(ctx context.Context, orchestrations []Orchestration, log logger.Logger) {
+	for _, orchestration := range orchestrations {
+		count, err := orchestration.CompensateTimedOut(ctx, time.Now())
+		if err != nil {
+			log.Errorf(
+				"(Orchestration.CompensateTimedOut) error in compensating timed out %s sagas: {%v}",
+				orchestration.Name(),
+				err,
+			)
+		}
+
+		if count > 0 {
+			log.Infof("(Orchestration.CompensateTimedOut) %d timed out %s sagas are compensated", count, orchestration.Name())
+		}
+	}
+}
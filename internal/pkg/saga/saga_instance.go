@@ -0,0 +1,45 @@
+package saga
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type SagaStatus string
+
+const (
+	// Running is the status of a saga which is waiting for the reply of its current step
+	Running   SagaStatus = "Running"
+	Completed SagaStatus = "Completed"
+	// Compensated is the status of a saga which is failed or timed out and its completed steps are compensated
+	Compensated SagaStatus = "Compensated"
+	// Failed is the status of a saga whose compensation is failed, it should be fixed manually
+	Failed SagaStatus = "Failed"
+)
+
+// IsTerminal returns true when the saga is finished
+func (s SagaStatus) IsTerminal() bool {
+	return s != Running
+}
+
+// SagaInstance is a single run of a saga definition with its state, the instances are persisted by the SagaStore after
+// each step, so a saga continues on another instance of the service
+type SagaInstance struct {
+	Id       string     `gorm:"primaryKey"        json:"id"`
+	SagaName string     `gorm:"index"             json:"sagaName"`
+	Status   SagaStatus `gorm:"index"             json:"status"`
+	// CurrentStep is the index of the step which is waiting for its reply
+	CurrentStep int `json:"currentStep"`
+	// State is the json state of the saga, it is passed to the steps of the definition
+	State json.RawMessage `gorm:"type:jsonb"        json:"state"`
+	// PendingMessageId is the id of the command of the current step, the reply is correlated with the saga by its
+	// correlation id which is the id of the command
+	PendingMessageId string `gorm:"index"             json:"pendingMessageId,omitempty"`
+	// TimeoutAt is the deadline of the reply of the current step, the saga is compensated after it
+	TimeoutAt     *time.Time `gorm:"index"             json:"timeoutAt,omitempty"`
+	FailureReason string     `json:"failureReason,omitempty"`
+	// Version is increased on each update, an update of a stale instance fails with a concurrency conflict
+	Version   int64     `json:"version"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
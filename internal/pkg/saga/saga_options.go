@@ -0,0 +1,29 @@
+package saga
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[SagaOptions]())
+
+type SagaOptions struct {
+	// Enabled starts the sagas, it should be enabled only when the participants of the sagas are deployed, otherwise
+	// each saga waits for the timeout of its first step and is compensated
+	Enabled bool `mapstructure:"enabled"`
+	// StepTimeout is the timeout of the reply of the steps without their own timeout
+	StepTimeout time.Duration `mapstructure:"stepTimeout"          default:"5m"`
+	// TimeoutCheckInterval is the interval of compensating the timed out sagas, zero disables the check
+	TimeoutCheckInterval time.Duration `mapstructure:"timeoutCheckInterval" default:"30s"`
+	// TimeoutBatchSize is the max number of the timed out sagas which are compensated in a check
+	TimeoutBatchSize int `mapstructure:"timeoutBatchSize"     default:"100"`
+}
+
+func ProvideConfig(environment environment.Environment) (*SagaOptions, error) {
+	return config.BindConfigKey[*SagaOptions](optionName, environment)
+}
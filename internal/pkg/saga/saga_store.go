@@ -0,0 +1,19 @@
+package saga
+
+import (
+	"context"
+	"time"
+)
+
+// SagaStore persists the saga instances, the postgres store is provided by `postgresgorm.SagaModule`
+type SagaStore interface {
+	Add(ctx context.Context, instance *SagaInstance) error
+	Get(ctx context.Context, id string) (*SagaInstance, error)
+	// GetByMessageId returns the running saga which is waiting for the reply of the message, it returns a not found
+	// error for the replies of the finished steps
+	GetByMessageId(ctx context.Context, messageId string) (*SagaInstance, error)
+	// Update saves the instance when its version is not changed since it is loaded and increases its version
+	Update(ctx context.Context, instance *SagaInstance) error
+	// ListTimedOut returns the running sagas of the saga name whose current step is timed out at the time
+	ListTimedOut(ctx context.Context, sagaName string, at time.Time, limit int) ([]*SagaInstance, error)
+}
@@ -0,0 +1,131 @@
+package shadow
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"emperror.dev/errors"
+)
+
+// Diff compares the json representations of the results, like they are served, and returns the paths of the
+// differences, like `items[2].price: 10 != 12`, up to the max number of the differences, zero returns all of them
+func Diff(primary interface{}, candidate interface{}, maxDiffs int) ([]string, error) {
+	primaryValue, err := toJsonValue(primary)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in marshaling primary result")
+	}
+
+	candidateValue, err := toJsonValue(candidate)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in marshaling candidate result")
+	}
+
+	return diffValues(primaryValue, candidateValue, maxDiffs), nil
+}
+
+func diffValues(primaryValue interface{}, candidateValue interface{}, maxDiffs int) []string {
+	d := &differ{maxDiffs: maxDiffs}
+	d.diff("", primaryValue, candidateValue)
+
+	return d.diffs
+}
+
+type differ struct {
+	maxDiffs int
+	diffs    []string
+}
+
+func (d *differ) diff(path string, primary interface{}, candidate interface{}) {
+	if d.maxDiffs > 0 && len(d.diffs) >= d.maxDiffs {
+		return
+	}
+
+	switch p := primary.(type) {
+	case map[string]interface{}:
+		c, ok := candidate.(map[string]interface{})
+		if !ok {
+			d.add(path, primary, candidate)
+			return
+		}
+
+		for _, key := range unionKeys(p, c) {
+			d.diff(joinPath(path, key), p[key], c[key])
+		}
+
+	case []interface{}:
+		c, ok := candidate.([]interface{})
+		if !ok {
+			d.add(path, primary, candidate)
+			return
+		}
+
+		if len(p) != len(c) {
+			d.add(joinPath(path, "length"), len(p), len(c))
+		}
+
+		for i := 0; i < len(p) && i < len(c); i++ {
+			d.diff(fmt.Sprintf("%s[%d]", path, i), p[i], c[i])
+		}
+
+	default:
+		if !reflect.DeepEqual(primary, candidate) {
+			d.add(path, primary, candidate)
+		}
+	}
+}
+
+func (d *differ) add(path string, primary interface{}, candidate interface{}) {
+	if d.maxDiffs > 0 && len(d.diffs) >= d.maxDiffs {
+		return
+	}
+
+	if path == "" {
+		path = "$"
+	}
+
+	d.diffs = append(d.diffs, fmt.Sprintf("%s: %v != %v", path, primary, candidate))
+}
+
+func toJsonValue(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// the numbers are compared by their text, so the large integers don't lose their precision
+	decoder.UseNumber()
+
+	var result interface{}
+	if err := decoder.Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func unionKeys(primary map[string]interface{}, candidate map[string]interface{}) []string {
+	keys := make([]string, 0, len(primary))
+	for key := range primary {
+		keys = append(keys, key)
+	}
+	for key := range candidate {
+		if _, ok := primary[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
@@ -0,0 +1,182 @@
+package shadow
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// the results of the comparisons in the `result` attribute of the comparisons metric
+const (
+	ResultMatch          = "match"
+	ResultMismatch       = "mismatch"
+	ResultCandidateError = "candidate_error"
+)
+
+type Options struct {
+	// SampleRate is the ratio of the queries which are mirrored to the candidate, between 0 and 1
+	SampleRate float64
+	// Timeout is the max duration of a mirrored query, the mirrored queries are not canceled with their request
+	Timeout time.Duration
+	// MaxDiffs is the max number of the logged differences of a mismatch
+	MaxDiffs int
+}
+
+// Mirror mirrors the queries of the primary implementation to a candidate implementation in the background and logs
+// and counts the differences of their results, so a new implementation can be verified with the production traffic
+// before serving it.
+type Mirror struct {
+	name        string
+	options     Options
+	log         logger.Logger
+	comparisons metric.Int64Counter
+	wg          sync.WaitGroup
+}
+
+// NewMirror creates a mirror, the meter is optional and the comparisons are counted in the
+// `<name>_shadow_comparisons_total` metric with the `operation` and the `result` attributes
+func NewMirror(name string, options Options, log logger.Logger, meter metric.Meter) (*Mirror, error) {
+	m := &Mirror{name: name, options: options, log: log}
+
+	if meter != nil {
+		comparisons, err := meter.Int64Counter(
+			fmt.Sprintf("%s_shadow_comparisons_total", name),
+			metric.WithDescription("The total number of the comparisons of the primary and the candidate results"),
+		)
+		if err != nil {
+			return nil, err
+		}
+		m.comparisons = comparisons
+	}
+
+	return m, nil
+}
+
+// Compare runs the candidate query in the background and compares its result with the result of the primary query,
+// the result of the primary is always served, so the candidate can't fail or slow down the request
+func Compare[T any](
+	ctx context.Context,
+	m *Mirror,
+	operation string,
+	primary T,
+	primaryErr error,
+	candidate func(ctx context.Context) (T, error),
+) {
+	if m == nil || !m.sampled() {
+		return
+	}
+
+	// the primary result is served to the caller, so it is captured before the caller changes it
+	var primaryValue interface{}
+	if primaryErr == nil {
+		var err error
+		primaryValue, err = toJsonValue(primary)
+		if err != nil {
+			m.log.Warnf("[Mirror.Compare] error in marshaling primary result of query '%s' of '%s', err: %v", operation, m.name, err)
+			return
+		}
+	}
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+
+		candidateCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), m.options.Timeout)
+		defer cancel()
+
+		candidateResult, candidateErr := candidate(candidateCtx)
+
+		m.compare(ctx, operation, primaryValue, primaryErr, candidateResult, candidateErr)
+	}()
+}
+
+// Wait blocks until the running comparisons are completed
+func (m *Mirror) Wait() {
+	m.wg.Wait()
+}
+
+func (m *Mirror) sampled() bool {
+	return m.options.SampleRate >= 1 || rand.Float64() < m.options.SampleRate //nolint:gosec
+}
+
+func (m *Mirror) compare(
+	ctx context.Context,
+	operation string,
+	primaryValue interface{},
+	primaryErr error,
+	candidate interface{},
+	candidateErr error,
+) {
+	switch {
+	// both failing, like a not found product, is the same result
+	case primaryErr != nil && candidateErr != nil:
+		m.record(ctx, operation, ResultMatch)
+
+	case candidateErr != nil:
+		m.log.Warnf(
+			"[Mirror.Compare] candidate query '%s' of '%s' failed while primary query succeeded, err: %v",
+			operation,
+			m.name,
+			candidateErr,
+		)
+		m.record(ctx, operation, ResultCandidateError)
+
+	case primaryErr != nil:
+		m.log.Warnf(
+			"[Mirror.Compare] candidate query '%s' of '%s' succeeded while primary query failed, err: %v",
+			operation,
+			m.name,
+			primaryErr,
+		)
+		m.record(ctx, operation, ResultMismatch)
+
+	default:
+		candidateValue, err := toJsonValue(candidate)
+		if err != nil {
+			m.log.Warnf(
+				"[Mirror.Compare] error in marshaling candidate result of query '%s' of '%s', err: %v",
+				operation,
+				m.name,
+				err,
+			)
+			m.record(ctx, operation, ResultCandidateError)
+
+			return
+		}
+
+		diffs := diffValues(primaryValue, candidateValue, m.options.MaxDiffs)
+		if len(diffs) > 0 {
+			m.log.Warnf(
+				"[Mirror.Compare] candidate result of query '%s' of '%s' is different from primary result: %s",
+				operation,
+				m.name,
+				strings.Join(diffs, "; "),
+			)
+			m.record(ctx, operation, ResultMismatch)
+
+			return
+		}
+
+		m.record(ctx, operation, ResultMatch)
+	}
+}
+
+func (m *Mirror) record(ctx context.Context, operation string, result string) {
+	if m.comparisons == nil {
+		return
+	}
+
+	m.comparisons.Add(
+		ctx,
+		1,
+		metric.WithAttributes(attribute.String("operation", operation), attribute.String("result", result)),
+	)
+}
@@ -0,0 +1,79 @@
+package shadow
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type product struct {
+	Id    string  `json:"id"`
+	Price float64 `json:"price,omitempty"`
+	Tags  []string
+}
+
+func Test_Diff(t *testing.T) {
+	t.Parallel()
+
+	primary := []*product{{Id: "1", Price: 10, Tags: []string{"a"}}, {Id: "2"}}
+
+	diffs, err := Diff(primary, []*product{{Id: "1", Price: 10, Tags: []string{"a"}}, {Id: "2"}}, 0)
+	require.NoError(t, err)
+	assert.Empty(t, diffs)
+
+	diffs, err = Diff(primary, []*product{{Id: "1", Price: 12, Tags: []string{"a", "b"}}}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"length: 2 != 1", "[0].Tags.length: 1 != 2", "[0].price: 10 != 12"}, diffs)
+
+	diffs, err = Diff(primary, []*product{{Id: "1", Price: 12, Tags: []string{"a", "b"}}}, 1)
+	require.NoError(t, err)
+	assert.Len(t, diffs, 1)
+}
+
+func Test_Mirror_Compare(t *testing.T) {
+	t.Parallel()
+
+	mirror, err := NewMirror(
+		"products",
+		Options{SampleRate: 1, Timeout: time.Second},
+		defaultLogger.GetLogger(),
+		nil,
+	)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var candidateCalled bool
+	var candidateCtxErr error
+	Compare(ctx, mirror, "GetProductById", &product{Id: "1"}, nil, func(ctx context.Context) (*product, error) {
+		// the mirrored query outlives the request
+		cancel()
+		candidateCalled = true
+		candidateCtxErr = ctx.Err()
+
+		return nil, errors.New("not found")
+	})
+	mirror.Wait()
+
+	assert.True(t, candidateCalled)
+	assert.NoError(t, candidateCtxErr)
+}
+
+func Test_Mirror_Compare_Not_Sampled(t *testing.T) {
+	t.Parallel()
+
+	mirror, err := NewMirror("products", Options{SampleRate: 0, Timeout: time.Second}, defaultLogger.GetLogger(), nil)
+	require.NoError(t, err)
+
+	Compare(context.Background(), mirror, "GetProductById", &product{Id: "1"}, nil, func(ctx context.Context) (*product, error) {
+		t.Error("candidate should not be called when the query is not sampled")
+		return nil, nil
+	})
+	mirror.Wait()
+}
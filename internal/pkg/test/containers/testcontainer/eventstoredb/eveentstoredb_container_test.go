@@ -27,6 +27,7 @@ func Test_Custom_EventStoreDB_Container(t *testing.T) {
 		core.Module,
 		eventstroredb.ModuleFunc(func() {
 		}),
+		eventstroredb.CheckpointModule,
 		fx.Decorate(EventstoreDBContainerOptionsDecorator(t, ctx)),
 		fx.Populate(&esdbClient),
 	).RequireStart()
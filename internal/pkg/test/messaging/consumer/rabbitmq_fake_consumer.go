@@ -3,13 +3,15 @@ package consumer
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/test/hypothesis"
 )
 
 type RabbitMQFakeTestConsumerHandler[T any] struct {
-	isHandled  bool
+	// isHandled is set by the consumer goroutine and read by the tests
+	isHandled  atomic.Bool
 	hypothesis hypothesis.Hypothesis[T]
 }
 
@@ -30,7 +32,7 @@ func (f *RabbitMQFakeTestConsumerHandler[T]) Handle(
 	ctx context.Context,
 	consumeContext types.MessageConsumeContext,
 ) error {
-	f.isHandled = true
+	f.isHandled.Store(true)
 	if f.hypothesis != nil {
 		m, ok := consumeContext.Message().(T)
 		if !ok {
@@ -43,5 +45,5 @@ func (f *RabbitMQFakeTestConsumerHandler[T]) Handle(
 }
 
 func (f *RabbitMQFakeTestConsumerHandler[T]) IsHandled() bool {
-	return f.isHandled
+	return f.isHandled.Load()
 }
@@ -0,0 +1,204 @@
+package spans
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SpanAssertion is a fluent assertion of an ended span, each chained matcher waits until a span matching all the
+// matchers so far is ended and fails the test after the timeout of the collector
+type SpanAssertion struct {
+	t         assert.TestingT
+	collector *SpanCollector
+	name      string
+	matchers  []spanMatcher
+	span      *tracetest.SpanStub
+	failed    bool
+}
+
+type spanMatcher struct {
+	description string
+	match       func(span tracetest.SpanStub) bool
+}
+
+// WithAttribute matches the spans having the attribute, the value is compared by its attribute type, e.g. an `int` is
+// compared with an `INT64` attribute and a `fmt.Stringer` like an uuid with a `STRING` attribute
+func (a *SpanAssertion) WithAttribute(key string, value interface{}) *SpanAssertion {
+	expected := attributeValue(value)
+
+	return a.with(
+		fmt.Sprintf("attribute %s=%s", key, expected.Emit()),
+		func(span tracetest.SpanStub) bool {
+			for _, attr := range span.Attributes {
+				if string(attr.Key) == key && attr.Value.Type() == expected.Type() &&
+					reflect.DeepEqual(attr.Value.AsInterface(), expected.AsInterface()) {
+					return true
+				}
+			}
+
+			return false
+		},
+	)
+}
+
+// WithStatus matches the spans having the status code, e.g. `codes.Error` for the failed operations
+func (a *SpanAssertion) WithStatus(code codes.Code) *SpanAssertion {
+	return a.with(fmt.Sprintf("status %s", code), func(span tracetest.SpanStub) bool {
+		return span.Status.Code == code
+	})
+}
+
+// WithKind matches the spans of a kind, e.g. `trace.SpanKindServer` for the http requests
+func (a *SpanAssertion) WithKind(kind trace.SpanKind) *SpanAssertion {
+	return a.with(fmt.Sprintf("kind %s", kind), func(span tracetest.SpanStub) bool {
+		return span.SpanKind == kind
+	})
+}
+
+// WithEvent matches the spans having an event with the name, e.g. `exception` for the recorded errors
+func (a *SpanAssertion) WithEvent(name string) *SpanAssertion {
+	return a.with(fmt.Sprintf("event %s", name), func(span tracetest.SpanStub) bool {
+		return lo.ContainsBy(span.Events, func(event tracesdk.Event) bool { return event.Name == name })
+	})
+}
+
+// WithParent matches the direct children of the span of the parent assertion
+func (a *SpanAssertion) WithParent(parent *SpanAssertion) *SpanAssertion {
+	if parent.span == nil {
+		return a.fail(fmt.Sprintf("the parent span %q of %q is not found", parent.name, a.name))
+	}
+
+	parentSpanId := parent.span.SpanContext.SpanID()
+
+	return a.with(fmt.Sprintf("parent %s", parent.name), func(span tracetest.SpanStub) bool {
+		return span.Parent.SpanID() == parentSpanId
+	})
+}
+
+// InTraceOf matches the spans in the trace of the span of the other assertion, it verifies the context is propagated
+// through the chain, e.g. from the http request to the consumer of the published message
+func (a *SpanAssertion) InTraceOf(other *SpanAssertion) *SpanAssertion {
+	if other.span == nil {
+		return a.fail(fmt.Sprintf("the span %q of the trace of %q is not found", other.name, a.name))
+	}
+
+	traceId := other.span.SpanContext.TraceID()
+
+	return a.with(fmt.Sprintf("trace of %s", other.name), func(span tracetest.SpanStub) bool {
+		return span.SpanContext.TraceID() == traceId
+	})
+}
+
+// Span returns the first span matching the assertion, it is empty when the assertion is failed
+func (a *SpanAssertion) Span() tracetest.SpanStub {
+	if a.span == nil {
+		return tracetest.SpanStub{}
+	}
+
+	return *a.span
+}
+
+func (a *SpanAssertion) with(description string, match func(span tracetest.SpanStub) bool) *SpanAssertion {
+	// the chained matchers of a failed assertion are skipped to report only its first failure
+	if a.failed {
+		return a
+	}
+
+	a.matchers = append(a.matchers, spanMatcher{description: description, match: match})
+
+	return a.wait()
+}
+
+func (a *SpanAssertion) wait() *SpanAssertion {
+	deadline := time.Now().Add(a.collector.timeout)
+	for {
+		if span, ok := a.find(); ok {
+			a.span = &span
+
+			return a
+		}
+
+		if time.Now().After(deadline) {
+			return a.fail(fmt.Sprintf("there is no ended span %s", a.describe()))
+		}
+
+		time.Sleep(defaultPollInterval)
+	}
+}
+
+func (a *SpanAssertion) find() (tracetest.SpanStub, bool) {
+	return lo.Find(a.collector.Spans(), func(span tracetest.SpanStub) bool {
+		if span.Name != a.name {
+			return false
+		}
+
+		return lo.EveryBy(a.matchers, func(matcher spanMatcher) bool { return matcher.match(span) })
+	})
+}
+
+func (a *SpanAssertion) fail(message string) *SpanAssertion {
+	if a.failed {
+		return a
+	}
+
+	a.failed = true
+	a.span = nil
+
+	names := lo.Uniq(lo.Map(a.collector.Spans(), func(span tracetest.SpanStub, index int) string { return span.Name }))
+	assert.Fail(a.t, message, "the ended spans are [%s]", strings.Join(names, ", "))
+
+	return a
+}
+
+func (a *SpanAssertion) describe() string {
+	descriptions := lo.Map(a.matchers, func(matcher spanMatcher, index int) string { return matcher.description })
+	if len(descriptions) == 0 {
+		return fmt.Sprintf("%q", a.name)
+	}
+
+	return fmt.Sprintf("%q with %s", a.name, strings.Join(descriptions, ", "))
+}
+
+// attributeValue converts the expected value to the attribute value it is recorded with
+func attributeValue(value interface{}) attribute.Value {
+	switch v := value.(type) {
+	case attribute.Value:
+		return v
+	case string:
+		return attribute.StringValue(v)
+	case bool:
+		return attribute.BoolValue(v)
+	case int:
+		return attribute.IntValue(v)
+	case int32:
+		return attribute.Int64Value(int64(v))
+	case int64:
+		return attribute.Int64Value(v)
+	case float32:
+		return attribute.Float64Value(float64(v))
+	case float64:
+		return attribute.Float64Value(v)
+	case []string:
+		return attribute.StringSliceValue(v)
+	case []bool:
+		return attribute.BoolSliceValue(v)
+	case []int:
+		return attribute.IntSliceValue(v)
+	case []int64:
+		return attribute.Int64SliceValue(v)
+	case []float64:
+		return attribute.Float64SliceValue(v)
+	default:
+		return attribute.StringValue(fmt.Sprint(v))
+	}
+}
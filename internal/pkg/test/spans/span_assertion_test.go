@@ -0,0 +1,100 @@
+package spans
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type fakeT struct {
+	errors []string
+}
+
+func (f *fakeT) Errorf(format string, args ...interface{}) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func newTestTracer(collector *SpanCollector) trace.Tracer {
+	provider := tracesdk.NewTracerProvider(tracesdk.WithSpanProcessor(collector.SpanProcessor()))
+
+	return provider.Tracer("spans-test")
+}
+
+func Test_Should_Have_Span_With_Attributes_And_Parent(t *testing.T) {
+	collector := NewSpanCollector().WithTimeout(time.Second)
+	tracer := newTestTracer(collector)
+	productId := uuid.NewV4()
+
+	ctx, parent := tracer.Start(context.Background(), "POST /api/v1/products", trace.WithSpanKind(trace.SpanKindServer))
+	_, child := tracer.Start(ctx, "CreateProduct")
+	child.SetAttributes(attribute.String("product_id", productId.String()), attribute.Int("items", 2))
+	child.SetStatus(codes.Ok, "")
+	child.End()
+	parent.End()
+
+	request := collector.ShouldHaveSpan(t, "POST /api/v1/products").WithKind(trace.SpanKindServer)
+	handler := collector.ShouldHaveSpan(t, "CreateProduct").
+		WithAttribute("product_id", productId).
+		WithAttribute("items", 2).
+		WithStatus(codes.Ok).
+		WithParent(request).
+		InTraceOf(request)
+
+	assert.Equal(t, child.SpanContext().SpanID(), handler.Span().SpanContext.SpanID())
+}
+
+func Test_Should_Have_Span_Waits_For_The_Asynchronous_Spans(t *testing.T) {
+	collector := NewSpanCollector().WithTimeout(5 * time.Second)
+	tracer := newTestTracer(collector)
+
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		_, span := tracer.Start(context.Background(), "ProductCreatedV1 consume")
+		span.End()
+	}()
+
+	collector.ShouldHaveSpan(t, "ProductCreatedV1 consume")
+}
+
+func Test_Should_Have_Span_Fails_Once_When_Not_Matched(t *testing.T) {
+	collector := NewSpanCollector().WithTimeout(100 * time.Millisecond)
+	tracer := newTestTracer(collector)
+
+	_, span := tracer.Start(context.Background(), "CreateProduct")
+	span.SetAttributes(attribute.String("product_id", "1"))
+	span.End()
+
+	ft := &fakeT{}
+	assertion := collector.ShouldHaveSpan(ft, "CreateProduct").
+		WithAttribute("product_id", "2").
+		WithStatus(codes.Error)
+
+	assert.Len(t, ft.errors, 1)
+	assert.Contains(t, ft.errors[0], `"CreateProduct" with attribute product_id=2`)
+	assert.Contains(t, ft.errors[0], "[CreateProduct]")
+	assert.Equal(t, trace.SpanID{}, assertion.Span().SpanContext.SpanID())
+
+	ft = &fakeT{}
+	collector.ShouldHaveSpan(ft, "UpdateProduct").InTraceOf(assertion)
+	assert.Len(t, ft.errors, 1)
+}
+
+func Test_Reset_Removes_The_Collected_Spans(t *testing.T) {
+	collector := NewSpanCollector()
+	tracer := newTestTracer(collector)
+
+	_, span := tracer.Start(context.Background(), "CreateProduct")
+	span.End()
+	assert.Len(t, collector.Spans(), 1)
+
+	collector.Reset()
+	assert.Empty(t, collector.Spans())
+}
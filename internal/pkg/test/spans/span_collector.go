@@ -0,0 +1,82 @@
+package spans
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+
+	"github.com/stretchr/testify/assert"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/fx"
+)
+
+const (
+	defaultTimeout      = 10 * time.Second
+	defaultPollInterval = 50 * time.Millisecond
+)
+
+// Module registers a SpanCollector on the trace provider of the test app, the collected spans are only recorded when
+// the `alwaysOnSampler` of the tracing options is enabled
+// https://uber-go.github.io/fx/modules.html
+var Module = fx.Module( //nolint:gochecknoglobals
+	"spanstestfx",
+	fx.Provide(NewSpanCollector),
+	fx.Invoke(registerSpanCollector),
+)
+
+// SpanCollector records the ended spans of a trace provider in memory for asserting the instrumentation of the tests
+type SpanCollector struct {
+	exporter  *tracetest.InMemoryExporter
+	processor tracesdk.SpanProcessor
+	timeout   time.Duration
+}
+
+func NewSpanCollector() *SpanCollector {
+	exporter := tracetest.NewInMemoryExporter()
+
+	return &SpanCollector{
+		exporter: exporter,
+		// the spans are exported synchronously when they end, so they are visible to the assertions without a flush
+		processor: tracesdk.NewSimpleSpanProcessor(exporter),
+		timeout:   defaultTimeout,
+	}
+}
+
+// SpanProcessor returns the processor of the collector to register it on a trace provider
+func (c *SpanCollector) SpanProcessor() tracesdk.SpanProcessor {
+	return c.processor
+}
+
+// WithTimeout sets how long the assertions wait for the spans of the asynchronous handlers, e.g. the consumers
+func (c *SpanCollector) WithTimeout(timeout time.Duration) *SpanCollector {
+	c.timeout = timeout
+
+	return c
+}
+
+// Spans returns the snapshots of the ended spans in the order of their end
+func (c *SpanCollector) Spans() tracetest.SpanStubs {
+	return c.exporter.GetSpans()
+}
+
+// Reset removes the collected spans, it should be called in the `SetupTest` of the tests sharing an app
+func (c *SpanCollector) Reset() {
+	c.exporter.Reset()
+}
+
+// ShouldHaveSpan waits until a span with the name is ended, its matchers are narrowed by chaining the assertion, e.g.
+// `ShouldHaveSpan(t, "CreateProduct").WithAttribute("product_id", id)`
+func (c *SpanCollector) ShouldHaveSpan(t assert.TestingT, name string) *SpanAssertion {
+	assertion := &SpanAssertion{
+		t:         t,
+		collector: c,
+		name:      name,
+	}
+
+	return assertion.wait()
+}
+
+func registerSpanCollector(tracingOtel *tracing.TracingOpenTelemetry, collector *SpanCollector) {
+	tracingOtel.RegisterSpanProcessor(collector.SpanProcessor())
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
@@ -47,3 +48,33 @@ func HttpRecorder(t *testing.T, e *echo.Echo, req *http.Request, f func(w *httpt
 		t.Fail()
 	}
 }
+
+// RunConcurrently runs the fn with the workers at the same time, the workers are released together after all of them
+// are started to maximize their contention, and returns the error of each worker by its index
+func RunConcurrently(workers int, fn func(worker int) error) []error {
+	errs := make([]error, workers)
+
+	var ready sync.WaitGroup
+	var done sync.WaitGroup
+	start := make(chan struct{})
+
+	ready.Add(workers)
+	done.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go func(worker int) {
+			defer done.Done()
+
+			ready.Done()
+			<-start
+
+			errs[worker] = fn(worker)
+		}(i)
+	}
+
+	ready.Wait()
+	close(start)
+	done.Wait()
+
+	return errs
+}
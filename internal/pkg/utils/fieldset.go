@@ -0,0 +1,146 @@
+package utils
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/goccy/go-json"
+	"github.com/samber/lo"
+)
+
+// ParseFields parses a comma separated sparse fieldset like `name,price`, the blank and the repeated fields are skipped
+func ParseFields(fields string) []string {
+	var parsed []string
+	seen := make(map[string]bool)
+
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" || seen[field] {
+			continue
+		}
+
+		seen[field] = true
+		parsed = append(parsed, field)
+	}
+
+	return parsed
+}
+
+// JsonFields returns the json names of the fields of the struct T
+func JsonFields[T any]() []string {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fields = append(fields, name)
+	}
+
+	return fields
+}
+
+// ValidateFields checks that the fields of a sparse fieldset are the json fields of the struct T, so a misspelled field
+// is rejected instead of silently returning nothing for it
+func ValidateFields[T any](fields []string) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	allowed := JsonFields[T]()
+
+	var unknown []string
+	for _, field := range fields {
+		if !lo.Contains(allowed, field) {
+			unknown = append(unknown, field)
+		}
+	}
+
+	if len(unknown) > 0 {
+		sort.Strings(allowed)
+
+		return errors.Errorf(
+			"unknown fields `%s`, the fields are: %s",
+			strings.Join(unknown, ","),
+			strings.Join(allowed, ","),
+		)
+	}
+
+	return nil
+}
+
+// ShapeFields returns the json object of the value with only the fields of the sparse fieldset, the fields which are
+// omitted from the json of the value are omitted from the shaped object too
+func ShapeFields(value interface{}, fields []string) (map[string]interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in marshaling the shaped value")
+	}
+
+	var object map[string]interface{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, errors.WrapIf(err, fmt.Sprintf("error in shaping the value of type %T", value))
+	}
+
+	if len(fields) == 0 || object == nil {
+		return object, nil
+	}
+
+	shaped := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		if fieldValue, ok := object[field]; ok {
+			shaped[field] = fieldValue
+		}
+	}
+
+	return shaped, nil
+}
+
+// ShapeListResult shapes the items of the list result with the sparse fieldset and keeps its paging
+func ShapeListResult[T any](
+	listResult *ListResult[T],
+	fields []string,
+) (*ListResult[map[string]interface{}], error) {
+	if listResult == nil {
+		return nil, nil
+	}
+
+	items := make([]map[string]interface{}, 0, len(listResult.Items))
+	for _, item := range listResult.Items {
+		shaped, err := ShapeFields(item, fields)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, shaped)
+	}
+
+	return &ListResult[map[string]interface{}]{
+		Items:      items,
+		Size:       listResult.Size,
+		Page:       listResult.Page,
+		TotalItems: listResult.TotalItems,
+		TotalPage:  listResult.TotalPage,
+	}, nil
+}
@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fieldsetTestDto struct {
+	Id       string  `json:"id"`
+	Name     string  `json:"name"`
+	Price    float64 `json:"price"`
+	Category string  `json:"category,omitempty"`
+	Secret   string  `json:"-"`
+}
+
+func Test_ParseFields_Skips_Blank_And_Repeated_Fields(t *testing.T) {
+	assert.Equal(t, []string{"name", "price"}, ParseFields(" name, ,price,name"))
+	assert.Nil(t, ParseFields(""))
+}
+
+func Test_ValidateFields_Rejects_Unknown_Fields(t *testing.T) {
+	require.NoError(t, ValidateFields[*fieldsetTestDto]([]string{"name", "price"}))
+	require.NoError(t, ValidateFields[*fieldsetTestDto](nil))
+
+	err := ValidateFields[*fieldsetTestDto]([]string{"name", "secret", "cost"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown fields `secret,cost`")
+	assert.Contains(t, err.Error(), "category,id,name,price")
+}
+
+func Test_ShapeListResult_Keeps_Fields_And_Paging(t *testing.T) {
+	listResult := NewListResult(
+		[]*fieldsetTestDto{{Id: "1", Name: "pizza", Price: 10}, {Id: "2", Name: "pasta", Price: 8, Category: "food"}},
+		2,
+		1,
+		5,
+	)
+
+	shaped, err := ShapeListResult(listResult, []string{"name", "category"})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, shaped.TotalPage)
+	assert.Equal(t, int64(5), shaped.TotalItems)
+	assert.Equal(
+		t,
+		[]map[string]interface{}{{"name": "pizza"}, {"name": "pasta", "category": "food"}},
+		shaped.Items,
+	)
+}
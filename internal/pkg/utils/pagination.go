@@ -55,6 +55,9 @@ type ListQuery struct {
 	Page    int            `query:"page"    json:"page,omitempty"`
 	OrderBy string         `query:"orderBy" json:"orderBy,omitempty"`
 	Filters []*FilterModel `query:"filters" json:"filters,omitempty"`
+	// Fields is the sparse fieldset of the items, the comma separated json names of their returned fields, e.g.
+	// `name,price`, all the fields are returned when it is empty
+	Fields string `query:"fields"  json:"fields,omitempty"`
 }
 
 func NewListQuery(size int, page int) *ListQuery {
@@ -77,7 +80,7 @@ func NewListQueryFromQueryParams(size string, page string) *ListQuery {
 
 func GetListQueryFromCtx(c echo.Context) (*ListQuery, error) {
 	q := &ListQuery{}
-	var page, size, orderBy string
+	var page, size, orderBy, fields string
 
 	// https://echo.labstack.com/guide/binding/#fast-binding-with-dedicated-helpers
 	err := echo.QueryParamsBinder(c).
@@ -97,6 +100,7 @@ func GetListQueryFromCtx(c echo.Context) (*ListQuery, error) {
 		String("size", &size).
 		String("page", &page).
 		String("orderBy", &orderBy).
+		String("fields", &fields).
 		BindError() // returns first binding error
 
 	if err = q.SetPage(page); err != nil {
@@ -106,6 +110,7 @@ func GetListQueryFromCtx(c echo.Context) (*ListQuery, error) {
 		return nil, err
 	}
 	q.SetOrderBy(orderBy)
+	q.Fields = fields
 
 	return q, nil
 }
@@ -173,6 +178,11 @@ func (q *ListQuery) GetSize() int {
 	return q.Size
 }
 
+// GetFields returns the fields of the sparse fieldset
+func (q *ListQuery) GetFields() []string {
+	return ParseFields(q.Fields)
+}
+
 // GetQueryString get query string
 func (q *ListQuery) GetQueryString() string {
 	return fmt.Sprintf("page=%v&size=%v&orderBy=%s", q.GetPage(), q.GetSize(), q.GetOrderBy())
@@ -0,0 +1,46 @@
+package validator
+
+import (
+	"reflect"
+
+	"github.com/go-playground/validator"
+)
+
+// WarmUp validates zero values of the given struct types, so the validator extracts and caches their struct metadata
+// on startup instead of on their first validation. It returns the number of warmed up types.
+func WarmUp(validate *validator.Validate, types ...reflect.Type) int {
+	count := 0
+
+	for _, t := range types {
+		for t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+
+		if t == nil || t.Kind() != reflect.Struct {
+			continue
+		}
+
+		if warmUpType(validate, t) {
+			count++
+		}
+	}
+
+	return count
+}
+
+func warmUpType(validate *validator.Validate, t reflect.Type) (ok bool) {
+	// an invalid validation tag panics, it should fail the validation of the type not the startup
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+
+	// the validation errors of the zero value are expected, the metadata is cached anyway
+	err := validate.Struct(reflect.New(t).Interface())
+	if _, invalid := err.(*validator.InvalidValidationError); invalid {
+		return false
+	}
+
+	return true
+}
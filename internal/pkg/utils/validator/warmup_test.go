@@ -0,0 +1,35 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-playground/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type warmUpRequest struct {
+	Name  string  `validate:"required"`
+	Price float64 `validate:"gt=0"`
+}
+
+type invalidTagRequest struct {
+	Name string `validate:"not_registered_tag"`
+}
+
+func Test_WarmUp(t *testing.T) {
+	validate := validator.New()
+
+	count := WarmUp(
+		validate,
+		reflect.TypeOf(warmUpRequest{}),
+		reflect.TypeOf(&warmUpRequest{}),
+		reflect.TypeOf(""),
+		reflect.TypeOf(invalidTagRequest{}),
+		nil,
+	)
+
+	assert.Equal(t, 2, count)
+	assert.Error(t, validate.Struct(&warmUpRequest{}))
+	assert.NoError(t, validate.Struct(&warmUpRequest{Name: "product", Price: 1}))
+}
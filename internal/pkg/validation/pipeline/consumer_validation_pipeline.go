@@ -0,0 +1,49 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/pipeline"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/validation"
+
+	"emperror.dev/errors"
+)
+
+type consumerValidationPipeline struct {
+	logger logger.Logger
+}
+
+// NewConsumerValidationPipeline normalizes and validates the messages which implement the normalizer and the validator
+// before their handlers, an invalid message isn't handled and is dead-lettered after its retries
+func NewConsumerValidationPipeline(l logger.Logger) pipeline.ConsumerPipeline {
+	return &consumerValidationPipeline{logger: l}
+}
+
+func (c *consumerValidationPipeline) Handle(
+	ctx context.Context,
+	consumerContext types.MessageConsumeContext,
+	next pipeline.ConsumerHandlerFunc,
+) error {
+	message := consumerContext.Message()
+
+	if n, ok := message.(validation.Normalizer); ok {
+		n.Normalize()
+	}
+
+	if v, ok := message.(validation.Validator); ok {
+		if err := v.Validate(); err != nil {
+			c.logger.Warnf(
+				"message '%s' with id '%s' is invalid: %v",
+				consumerContext.MessageType(),
+				consumerContext.MessageId(),
+				err,
+			)
+
+			return errors.WrapIff(err, "message '%s' is invalid", consumerContext.MessageType())
+		}
+	}
+
+	return next(ctx)
+}
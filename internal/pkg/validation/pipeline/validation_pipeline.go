@@ -22,6 +22,10 @@ func (m mediatorValidationPipeline) Handle(
 	request interface{},
 	next mediatr.RequestHandlerFunc,
 ) (interface{}, error) {
+	if n, ok := request.(validation.Normalizer); ok {
+		n.Normalize()
+	}
+
 	v, ok := request.(validation.Validator)
 	if ok {
 		err := v.Validate()
@@ -3,3 +3,9 @@ package validation
 type Validator interface {
 	Validate() error
 }
+
+// Normalizer is implemented by the requests which normalize their fields (e.g. trimming or case-normalizing the
+// identifiers), the validation pipeline normalizes the requests before validating them.
+type Normalizer interface {
+	Normalize()
+}
@@ -0,0 +1,77 @@
+package warmup
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+
+	"go.uber.org/fx"
+)
+
+// CacheWarmUpsGroup is the fx group of the cache warm-ups of a service
+const CacheWarmUpsGroup = "cache-warmups"
+
+// CacheWarmUp loads the hot read models of a service into its caches, e.g. the most queried products, so the first
+// requests after a deployment don't miss the caches
+type CacheWarmUp interface {
+	Name() string
+	WarmUp(ctx context.Context) error
+}
+
+// AsCacheWarmUp annotates the constructor of a cache warm-up to be provided to the cache warm-ups group
+func AsCacheWarmUp(warmUp interface{}) interface{} {
+	return fx.Annotate(
+		warmUp,
+		fx.As(new(CacheWarmUp)),
+		fx.ResultTags(fmt.Sprintf(`group:"%s"`, CacheWarmUpsGroup)),
+	)
+}
+
+// CacheWarmUps runs the cache warm-ups of the service after its start, the service is not ready until they are
+// completed. a failed or timed out warm-up is logged and doesn't keep the service unready, its cache is filled by the
+// requests instead.
+type CacheWarmUps struct {
+	warmUps   []CacheWarmUp
+	options   *WarmUpOptions
+	logger    logger.Logger
+	completed atomic.Bool
+}
+
+func NewCacheWarmUps(warmUps []CacheWarmUp, options *WarmUpOptions, logger logger.Logger) *CacheWarmUps {
+	return &CacheWarmUps{warmUps: warmUps, options: options, logger: logger}
+}
+
+func (c *CacheWarmUps) Run(ctx context.Context) {
+	defer c.completed.Store(true)
+
+	if len(c.warmUps) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.options.CacheWarmUpTimeout)
+	defer cancel()
+
+	start := time.Now()
+
+	for _, warmUp := range c.warmUps {
+		if warmUp == nil {
+			continue
+		}
+
+		if err := warmUp.WarmUp(ctx); err != nil {
+			c.logger.Errorf("error in cache warm-up %s: %v", warmUp.Name(), err)
+			continue
+		}
+
+		c.logger.Infof("cache warm-up %s completed", warmUp.Name())
+	}
+
+	c.logger.Infof("cache warm-ups completed in %s", time.Since(start))
+}
+
+func (c *CacheWarmUps) IsCompleted() bool {
+	return c.completed.Load()
+}
@@ -0,0 +1,80 @@
+package warmup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeCacheWarmUp struct {
+	warmUp func(ctx context.Context) error
+	called bool
+}
+
+func (f *fakeCacheWarmUp) Name() string {
+	return "fake"
+}
+
+func (f *fakeCacheWarmUp) WarmUp(ctx context.Context) error {
+	f.called = true
+
+	return f.warmUp(ctx)
+}
+
+func Test_Health_Is_Down_Until_Cache_WarmUps_Completed(t *testing.T) {
+	warmUp := &fakeCacheWarmUp{warmUp: func(context.Context) error { return nil }}
+	warmUps := NewCacheWarmUps([]CacheWarmUp{warmUp}, &WarmUpOptions{CacheWarmUpTimeout: time.Second}, defaultLogger.GetLogger())
+	healthChecker := NewCacheWarmUpHealthChecker(warmUps)
+
+	assert.ErrorIs(t, healthChecker.CheckHealth(context.Background()), ErrCacheWarmUpNotCompleted)
+
+	warmUps.Run(context.Background())
+
+	assert.True(t, warmUp.called)
+	assert.NoError(t, healthChecker.CheckHealth(context.Background()))
+}
+
+func Test_Failed_Cache_WarmUp_Does_Not_Stop_Others(t *testing.T) {
+	failed := &fakeCacheWarmUp{warmUp: func(context.Context) error { return errors.New("redis is down") }}
+	other := &fakeCacheWarmUp{warmUp: func(context.Context) error { return nil }}
+	warmUps := NewCacheWarmUps(
+		[]CacheWarmUp{failed, other},
+		&WarmUpOptions{CacheWarmUpTimeout: time.Second},
+		defaultLogger.GetLogger(),
+	)
+
+	warmUps.Run(context.Background())
+
+	assert.True(t, other.called)
+	assert.True(t, warmUps.IsCompleted())
+}
+
+func Test_Cache_WarmUps_Are_Bounded_By_Timeout(t *testing.T) {
+	slow := &fakeCacheWarmUp{warmUp: func(ctx context.Context) error {
+		<-ctx.Done()
+
+		return ctx.Err()
+	}}
+	warmUps := NewCacheWarmUps(
+		[]CacheWarmUp{slow},
+		&WarmUpOptions{CacheWarmUpTimeout: 20 * time.Millisecond},
+		defaultLogger.GetLogger(),
+	)
+
+	warmUps.Run(context.Background())
+
+	assert.True(t, warmUps.IsCompleted())
+}
+
+func Test_Cache_WarmUps_Without_WarmUp(t *testing.T) {
+	warmUps := NewCacheWarmUps(nil, &WarmUpOptions{}, defaultLogger.GetLogger())
+
+	warmUps.Run(context.Background())
+
+	assert.True(t, warmUps.IsCompleted())
+}
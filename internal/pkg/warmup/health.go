@@ -0,0 +1,56 @@
+package warmup
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/health/contracts"
+
+	"emperror.dev/errors"
+)
+
+var (
+	// ErrWarmUpNotCompleted is returned by the health check until the metadata warm-up is completed
+	ErrWarmUpNotCompleted = errors.New("metadata warm-up is not completed")
+	// ErrCacheWarmUpNotCompleted is returned by the health check until the cache warm-ups are completed
+	ErrCacheWarmUpNotCompleted = errors.New("cache warm-up is not completed")
+)
+
+type warmUpHealthChecker struct {
+	warmUp MetadataWarmUp
+}
+
+func NewWarmUpHealthChecker(warmUp MetadataWarmUp) contracts.Health {
+	return &warmUpHealthChecker{warmUp}
+}
+
+func (healthChecker *warmUpHealthChecker) CheckHealth(ctx context.Context) error {
+	if !healthChecker.warmUp.IsCompleted() {
+		return ErrWarmUpNotCompleted
+	}
+
+	return nil
+}
+
+func (healthChecker *warmUpHealthChecker) GetHealthName() string {
+	return "metadata-warmup"
+}
+
+type cacheWarmUpHealthChecker struct {
+	warmUps *CacheWarmUps
+}
+
+func NewCacheWarmUpHealthChecker(warmUps *CacheWarmUps) contracts.Health {
+	return &cacheWarmUpHealthChecker{warmUps}
+}
+
+func (healthChecker *cacheWarmUpHealthChecker) CheckHealth(ctx context.Context) error {
+	if !healthChecker.warmUps.IsCompleted() {
+		return ErrCacheWarmUpNotCompleted
+	}
+
+	return nil
+}
+
+func (healthChecker *cacheWarmUpHealthChecker) GetHealthName() string {
+	return "cache-warmup"
+}
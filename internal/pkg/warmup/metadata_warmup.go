@@ -0,0 +1,69 @@
+package warmup
+
+import (
+	"context"
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mapper"
+	validatorUtils "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils/validator"
+
+	"github.com/go-playground/validator"
+)
+
+// MetadataWarmUp precomputes the reflection metadata of the mapper and the validator on startup, so the first
+// requests don't pay the latency of building it.
+type MetadataWarmUp interface {
+	WarmUp(ctx context.Context) error
+	IsCompleted() bool
+}
+
+type metadataWarmUp struct {
+	validate  *validator.Validate
+	logger    logger.Logger
+	completed atomic.Bool
+}
+
+// NewMetadataWarmUp creates the metadata warm-up, validate is optional and the validator warm-up is skipped without it
+func NewMetadataWarmUp(validate *validator.Validate, logger logger.Logger) MetadataWarmUp {
+	return &metadataWarmUp{validate: validate, logger: logger}
+}
+
+func (m *metadataWarmUp) WarmUp(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	start := time.Now()
+
+	mappings := mapper.WarmUp()
+
+	var validations int
+	if m.validate != nil {
+		// the commands and queries are validated on each request, so their types are warmed up
+		var requestTypes []reflect.Type
+		for _, descriptor := range cqrs.RegisteredHandlers() {
+			requestTypes = append(requestTypes, descriptor.RequestType)
+		}
+
+		validations = validatorUtils.WarmUp(m.validate, requestTypes...)
+	}
+
+	m.completed.Store(true)
+
+	m.logger.Infof(
+		"metadata warm-up completed in %s, %d mapper profiles and %d validator types are cached",
+		time.Since(start),
+		mappings,
+		validations,
+	)
+
+	return nil
+}
+
+func (m *metadataWarmUp) IsCompleted() bool {
+	return m.completed.Load()
+}
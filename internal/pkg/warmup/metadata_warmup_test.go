@@ -0,0 +1,42 @@
+package warmup
+
+import (
+	"context"
+	"testing"
+
+	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+
+	"github.com/go-playground/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Health_Is_Down_Until_WarmUp_Completed(t *testing.T) {
+	warmUp := NewMetadataWarmUp(validator.New(), defaultLogger.GetLogger())
+	healthChecker := NewWarmUpHealthChecker(warmUp)
+
+	assert.ErrorIs(t, healthChecker.CheckHealth(context.Background()), ErrWarmUpNotCompleted)
+
+	require.NoError(t, warmUp.WarmUp(context.Background()))
+
+	assert.True(t, warmUp.IsCompleted())
+	assert.NoError(t, healthChecker.CheckHealth(context.Background()))
+}
+
+func Test_WarmUp_Without_Validator(t *testing.T) {
+	warmUp := NewMetadataWarmUp(nil, defaultLogger.GetLogger())
+
+	require.NoError(t, warmUp.WarmUp(context.Background()))
+
+	assert.True(t, warmUp.IsCompleted())
+}
+
+func Test_WarmUp_With_Canceled_Context(t *testing.T) {
+	warmUp := NewMetadataWarmUp(nil, defaultLogger.GetLogger())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.ErrorIs(t, warmUp.WarmUp(ctx), context.Canceled)
+	assert.False(t, warmUp.IsCompleted())
+}
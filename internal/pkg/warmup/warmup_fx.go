@@ -0,0 +1,87 @@
+package warmup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/health/contracts"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	errorutils "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils/errorutils"
+
+	"go.uber.org/fx"
+)
+
+var (
+	// Module provided to fxlog
+	// https://uber-go.github.io/fx/modules.html
+	Module = fx.Module( //nolint:gochecknoglobals
+		"warmupfx",
+		warmUpProviders,
+		warmUpInvokes,
+	)
+
+	warmUpProviders = fx.Provide( //nolint:gochecknoglobals
+		fx.Annotate(
+			NewMetadataWarmUp,
+			fx.ParamTags(`optional:"true"`),
+		),
+		fx.Annotate(
+			NewWarmUpHealthChecker,
+			fx.As(new(contracts.Health)),
+			fx.ResultTags(fmt.Sprintf(`group:"%s"`, "healths")),
+		),
+		ProvideConfig,
+		fx.Annotate(
+			NewCacheWarmUps,
+			fx.ParamTags(fmt.Sprintf(`group:"%s"`, CacheWarmUpsGroup)),
+		),
+		fx.Annotate(
+			NewCacheWarmUpHealthChecker,
+			fx.As(new(contracts.Health)),
+			fx.ResultTags(fmt.Sprintf(`group:"%s"`, "healths")),
+		),
+	)
+
+	warmUpInvokes = fx.Invoke(registerHooks, registerCacheWarmUpHooks) //nolint:gochecknoglobals
+)
+
+// the mappings and the request handlers are registered in the invokes, so they are all available in the OnStart hooks
+func registerHooks(
+	lc fx.Lifecycle,
+	warmUp MetadataWarmUp,
+	logger logger.Logger,
+) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			if err := warmUp.WarmUp(ctx); err != nil {
+				logger.Errorf("error in metadata warm-up: %v", err)
+
+				return err
+			}
+
+			return nil
+		},
+	})
+}
+
+// the cache warm-ups run in the background after the start, because they read the databases of the service and can be
+// slower than the start timeout
+func registerCacheWarmUpHooks(lc fx.Lifecycle, warmUps *CacheWarmUps) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			go func() {
+				defer errorutils.HandlePanic()
+				warmUps.Run(ctx)
+			}()
+
+			return nil
+		},
+		OnStop: func(_ context.Context) error {
+			cancel()
+
+			return nil
+		},
+	})
+}
@@ -0,0 +1,22 @@
+package warmup
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[WarmUpOptions]())
+
+type WarmUpOptions struct {
+	// CacheWarmUpTimeout bounds the cache warm-ups, the service becomes ready after it even if they are not completed
+	CacheWarmUpTimeout time.Duration `mapstructure:"cacheWarmUpTimeout" default:"30s"`
+}
+
+func ProvideConfig(environment environment.Environment) (*WarmUpOptions, error) {
+	return config.BindConfigKey[*WarmUpOptions](optionName, environment)
+}
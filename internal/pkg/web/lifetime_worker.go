@@ -0,0 +1,128 @@
+package web
+
+import (
+	"context"
+	"time"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+
+	"emperror.dev/errors"
+	"go.uber.org/fx"
+)
+
+type periodicWorkerOptions struct {
+	runOnStart bool
+}
+
+// PeriodicWorkerOption configures a worker of RegisterPeriodicWorker
+type PeriodicWorkerOption func(options *periodicWorkerOptions)
+
+// WithRunOnStart runs the worker once on start before waiting for its first interval
+func WithRunOnStart() PeriodicWorkerOption {
+	return func(options *periodicWorkerOptions) {
+		options.runOnStart = true
+	}
+}
+
+// RegisterLifetimeWorker runs the worker in the background from the start until the stop of the application. the
+// OnStart ctx has a short timeout and is not alive during the whole lifetime of the app, so the worker runs with its
+// own context which is canceled on stop, and the stop waits for the worker until the OnStop ctx is done.
+func RegisterLifetimeWorker(lc fx.Lifecycle, name string, log logger.Logger, execute ExecutionFunc) {
+	lifetimeCtx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	worker := NewBackgroundWorker(
+		func(ctx context.Context) error {
+			defer close(done)
+
+			err := execute(ctx)
+			if err != nil && ctx.Err() == nil && !customErrors.IsCanceledError(err) {
+				log.Errorf("(%s) error in running the worker: {%v}", name, err)
+			}
+
+			return nil
+		},
+		nil,
+	)
+	workersRunner := NewWorkersRunner([]Worker{worker})
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			workersRunner.Start(lifetimeCtx)
+			log.Infof("%s is running.", name)
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			cancel()
+
+			select {
+			case <-done:
+				log.Infof("%s stopped gracefully", name)
+			case <-ctx.Done():
+			}
+
+			return nil
+		},
+	})
+}
+
+// RegisterPeriodicWorker runs the worker on every interval with RegisterLifetimeWorker, a failed run is logged and the
+// worker runs again on the next interval, a canceled run stops the worker. a non-positive interval is rejected, so a
+// misconfigured worker fails on startup.
+func RegisterPeriodicWorker(
+	lc fx.Lifecycle,
+	name string,
+	interval time.Duration,
+	log logger.Logger,
+	execute ExecutionFunc,
+	options ...PeriodicWorkerOption,
+) error {
+	if interval <= 0 {
+		return errors.Errorf("interval of the %s should be positive, got %s", name, interval)
+	}
+
+	workerOptions := &periodicWorkerOptions{}
+	for _, option := range options {
+		option(workerOptions)
+	}
+
+	// run returns false when the worker should stop
+	run := func(ctx context.Context) bool {
+		err := execute(ctx)
+		if customErrors.IsCanceledError(err) || ctx.Err() != nil {
+			log.Infof("(%s) running the worker canceled", name)
+
+			return false
+		}
+
+		if err != nil {
+			log.Errorf("(%s) error in running the worker: {%v}", name, err)
+		}
+
+		return true
+	}
+
+	RegisterLifetimeWorker(lc, name, log, func(ctx context.Context) error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		if workerOptions.runOnStart && !run(ctx) {
+			return nil
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				if !run(ctx) {
+					return nil
+				}
+			}
+		}
+	})
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package web
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx/fxtest"
+)
+
+func Test_Periodic_Worker_Runs_Until_The_Stop(t *testing.T) {
+	lc := fxtest.NewLifecycle(t)
+
+	var runs atomic.Int32
+	err := RegisterPeriodicWorker(
+		lc,
+		"test worker",
+		10*time.Millisecond,
+		defaultLogger.GetLogger(),
+		func(ctx context.Context) error {
+			runs.Add(1)
+
+			// a failed run doesn't stop the worker
+			return errors.New("failed run")
+		},
+		WithRunOnStart(),
+	)
+	require.NoError(t, err)
+
+	lc.RequireStart()
+	assert.Eventually(t, func() bool { return runs.Load() >= 3 }, time.Second, 5*time.Millisecond)
+	lc.RequireStop()
+
+	// the stop waits for the worker, so it doesn't run after the stop
+	stopped := runs.Load()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, stopped, runs.Load())
+}
+
+func Test_Periodic_Worker_Stops_On_A_Canceled_Run(t *testing.T) {
+	lc := fxtest.NewLifecycle(t)
+
+	var runs atomic.Int32
+	err := RegisterPeriodicWorker(
+		lc,
+		"test worker",
+		10*time.Millisecond,
+		defaultLogger.GetLogger(),
+		func(ctx context.Context) error {
+			runs.Add(1)
+
+			return customErrors.NewCanceledError("canceled run")
+		},
+	)
+	require.NoError(t, err)
+
+	lc.RequireStart()
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(1), runs.Load())
+	lc.RequireStop()
+}
+
+func Test_Periodic_Worker_Rejects_A_Non_Positive_Interval(t *testing.T) {
+	lc := fxtest.NewLifecycle(t)
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		err := RegisterPeriodicWorker(
+			lc,
+			"test worker",
+			interval,
+			defaultLogger.GetLogger(),
+			func(ctx context.Context) error { return nil },
+		)
+		assert.Error(t, err)
+	}
+
+	// the rejected workers are not started
+	lc.RequireStart().RequireStop()
+}
+
+func Test_Lifetime_Worker_Context_Is_Canceled_On_Stop(t *testing.T) {
+	lc := fxtest.NewLifecycle(t)
+
+	started := make(chan struct{})
+	var canceled atomic.Bool
+	RegisterLifetimeWorker(lc, "test worker", defaultLogger.GetLogger(), func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		canceled.Store(true)
+
+		return ctx.Err()
+	})
+
+	lc.RequireStart()
+	<-started
+	require.False(t, canceled.Load())
+
+	lc.RequireStop()
+	assert.True(t, canceled.Load())
+}
@@ -2,7 +2,9 @@ package main
 
 import (
 	"os"
+	"strings"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/messagebroker"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/shared/app"
 
 	"github.com/pterm/pterm"
@@ -20,6 +22,16 @@ var rootCmd = &cobra.Command{
 	},
 }
 
+func init() {
+	// the flag is read by `messagebroker.GetBrokerType` when the modules are composed, it is declared here so cobra
+	// accepts it
+	rootCmd.Flags().Bool(
+		strings.TrimPrefix(messagebroker.LocalFlag, "--"),
+		false,
+		"run with the in-memory bus instead of the message broker",
+	)
+}
+
 // https://github.com/swaggo/swag#how-to-use-it-with-gin
 
 // @contact.name Mehdi Hadeli
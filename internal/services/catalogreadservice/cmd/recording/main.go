@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/external/fxlog"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/zap"
+	rabbitmqConfig "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/configurations"
+	consumerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/consumer/configurations"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/recording"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/configurations/rabbitmq"
+
+	"emperror.dev/errors"
+	"github.com/go-playground/validator"
+	"github.com/spf13/cobra"
+	"go.uber.org/fx"
+)
+
+func init() {
+	cmdRecord.Flags().String("consumer", "", "Name of the consumer whose messages are recorded")
+	cmdRecord.Flags().String("dir", "", "Directory of the recording files")
+	cmdRecord.Flags().Int("limit", 0, "Number of the messages after that the recording stops, default is until ctrl+c")
+	cmdRecord.Flags().String(
+		"queue",
+		"",
+		"Copy the messages waiting in a queue (e.g. a dead-letter queue) instead of the live messages of the consumer",
+	)
+	_ = cmdRecord.MarkFlagRequired("dir")
+
+	cmdReplay.Flags().String(
+		"consumer",
+		"",
+		"Name of the consumer whose queue receives the messages, default is the recorded exchange of the messages",
+	)
+	cmdReplay.Flags().String("dir", "", "Directory of the recording files")
+	cmdReplay.Flags().Duration("delay", 0, "Delay between two replayed messages")
+	_ = cmdReplay.MarkFlagRequired("dir")
+
+	rootCmd.AddCommand(cmdRecord)
+	rootCmd.AddCommand(cmdReplay)
+	rootCmd.AddCommand(cmdConsumers)
+}
+
+var (
+	rootCmd = &cobra.Command{ //nolint:gochecknoglobals
+		Use:          "recording",
+		Short:        "A tool for recording the consumed messages into files and replaying them against a local service",
+		SilenceUsage: true,
+	}
+
+	cmdRecord = &cobra.Command{ //nolint:gochecknoglobals
+		Use:   "record",
+		Short: "Record the messages of a consumer into files",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			consumerName, _ := cmd.Flags().GetString("consumer")
+			queue, _ := cmd.Flags().GetString("queue")
+			options := &recording.RecordOptions{}
+			options.Dir, _ = cmd.Flags().GetString("dir")
+			options.Limit, _ = cmd.Flags().GetInt("limit")
+
+			return runWithConnection(func(ctx context.Context, connection types.IConnection, logger logger.Logger) error {
+				var recorded int
+				var err error
+
+				if queue != "" {
+					recorded, err = recording.Copy(ctx, connection, queue, options, logger)
+				} else {
+					consumerConfiguration, findErr := findConsumer(consumerName, logger)
+					if findErr != nil {
+						return findErr
+					}
+
+					options.Exchange, options.RoutingKey, _ = consumerConfiguration.Topology()
+					recorded, err = recording.Record(ctx, connection, options, logger)
+				}
+
+				logger.Infof("%d messages recorded", recorded)
+
+				return err
+			})
+		},
+	}
+
+	cmdReplay = &cobra.Command{ //nolint:gochecknoglobals
+		Use:   "replay",
+		Short: "Replay the recorded messages in their recorded order",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			consumerName, _ := cmd.Flags().GetString("consumer")
+			options := &recording.ReplayOptions{}
+			options.Dir, _ = cmd.Flags().GetString("dir")
+			options.Delay, _ = cmd.Flags().GetDuration("delay")
+
+			return runWithConnection(func(ctx context.Context, connection types.IConnection, logger logger.Logger) error {
+				if consumerName != "" {
+					consumerConfiguration, err := findConsumer(consumerName, logger)
+					if err != nil {
+						return err
+					}
+
+					_, _, options.Queue = consumerConfiguration.Topology()
+				}
+
+				replayed, err := recording.Replay(ctx, connection, options, logger)
+				logger.Infof("%d messages replayed", replayed)
+
+				return err
+			})
+		},
+	}
+
+	cmdConsumers = &cobra.Command{ //nolint:gochecknoglobals
+		Use:   "consumers",
+		Short: "List the consumers which can be recorded",
+		Run: func(cmd *cobra.Command, args []string) {
+			for _, consumerConfiguration := range declaredConsumers(defaultLogger.GetLogger()) {
+				exchange, routingKey, queue := consumerConfiguration.Topology()
+				fmt.Printf(
+					"%s\texchange: %s\trouting key: %s\tqueue: %s\n",
+					consumerConfiguration.Name,
+					exchange,
+					routingKey,
+					queue,
+				)
+			}
+		},
+	}
+)
+
+// declaredConsumers builds the consumers only for their topology, so the handlers don't need a tracer and the feature
+// toggles
+func declaredConsumers(logger logger.Logger) []*consumerConfigurations.RabbitMQConsumerConfiguration {
+	builder := configurations.NewRabbitMQConfigurationBuilder()
+	rabbitmq.ConfigProductsRabbitMQ(builder, logger, validator.New(), nil, nil, nil, nil, nil)
+
+	return builder.Build().ConsumersConfigurations
+}
+
+func findConsumer(
+	name string,
+	logger logger.Logger,
+) (*consumerConfigurations.RabbitMQConsumerConfiguration, error) {
+	var names []string
+	for _, consumerConfiguration := range declaredConsumers(logger) {
+		if consumerConfiguration.Name == name {
+			return consumerConfiguration, nil
+		}
+		names = append(names, consumerConfiguration.Name)
+	}
+
+	return nil, errors.Errorf("consumer %q not found, the consumers are: %s", name, strings.Join(names, ", "))
+}
+
+// runWithConnection connects to the rabbitmq of the development config, the ctx is canceled with ctrl+c
+func runWithConnection(
+	run func(ctx context.Context, connection types.IConnection, logger logger.Logger) error,
+) error {
+	var rabbitmqOptions *rabbitmqConfig.RabbitmqOptions
+	var logger logger.Logger
+
+	app := fx.New(
+		config.ModuleFunc(environment.Development),
+		zap.Module,
+		fxlog.FxLogger,
+		fx.Provide(rabbitmqConfig.ProvideConfig),
+		fx.Populate(&rabbitmqOptions, &logger),
+	)
+	if err := app.Err(); err != nil {
+		return err
+	}
+
+	connection, err := types.NewRabbitMQConnection(rabbitmqOptions)
+	if err != nil {
+		return err
+	}
+	defer connection.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return run(ctx, connection, logger)
+}
+
+func main() {
+	if err := rootCmd.Execute(); err != nil {
+		defaultLogger.GetLogger().Error(err)
+		os.Exit(1)
+	}
+}
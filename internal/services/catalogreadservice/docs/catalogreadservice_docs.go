@@ -4,7 +4,7 @@ package docs
 
 import "github.com/swaggo/swag"
 
-const docTemplate = `{
+const docTemplatecatalogreadservice = `{
     "schemes": {{ marshal .Schemes }},
     "swagger": "2.0",
     "info": {
@@ -233,20 +233,20 @@ const docTemplate = `{
     }
 }`
 
-// SwaggerInfo holds exported Swagger Info so clients can modify it
-var SwaggerInfo = &swag.Spec{
+// SwaggerInfocatalogreadservice holds exported Swagger Info so clients can modify it
+var SwaggerInfocatalogreadservice = &swag.Spec{
 	Version:          "1.0",
 	Host:             "",
 	BasePath:         "",
 	Schemes:          []string{},
 	Title:            "Catalogs Read-Service Api",
 	Description:      "Catalogs Read-Service Api.",
-	InfoInstanceName: "swagger",
-	SwaggerTemplate:  docTemplate,
+	InfoInstanceName: "catalogreadservice",
+	SwaggerTemplate:  docTemplatecatalogreadservice,
 	LeftDelim:        "{{",
 	RightDelim:       "}}",
 }
 
 func init() {
-	swag.Register(SwaggerInfo.InstanceName(), SwaggerInfo)
+	swag.Register(SwaggerInfocatalogreadservice.InstanceName(), SwaggerInfocatalogreadservice)
 }
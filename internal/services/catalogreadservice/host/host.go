@@ -0,0 +1,23 @@
+// Package host exposes the composition of the catalogs read service to the launchers outside the service module, e.g.
+// the development host which runs all the services in one process.
+package host
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/fxapp/contracts"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/shared/app"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/shared/configurations/catalogs"
+)
+
+// NewApplication builds the catalogs read application with its configurations and endpoints, the caller starts and
+// stops the application.
+func NewApplication() (contracts.Application, error) {
+	appBuilder := app.NewCatalogsReadApplicationBuilder()
+	appBuilder.ProvideModule(catalogs.CatalogsServiceModule)
+
+	application := appBuilder.Build()
+
+	application.ConfigureCatalogs()
+	application.MapCatalogsEndpoints()
+
+	return application, nil
+}
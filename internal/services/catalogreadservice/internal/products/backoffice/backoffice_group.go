@@ -0,0 +1,29 @@
+package backoffice
+
+import (
+	echocontracts "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/contracts"
+	apikey "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/api_key"
+
+	"github.com/labstack/echo/v4"
+)
+
+// NewBackOfficeProductsGroup creates the `/api/v1/backoffice/products` group, every route of the group is
+// authenticated with the api keys of the back-office users
+func NewBackOfficeProductsGroup(
+	catalogsServer echocontracts.EchoHttpServer,
+	options *BackOfficeOptions,
+) *echo.Group {
+	var keys []apikey.Option
+	for _, user := range options.Users {
+		if user != nil {
+			keys = append(keys, apikey.WithKey(user.ApiKey, user.UserId))
+		}
+	}
+
+	var g *echo.Group
+	catalogsServer.RouteBuilder().RegisterGroupFunc("/api/v1", func(v1 *echo.Group) {
+		g = v1.Group("/backoffice/products", apikey.ApiKey(keys...))
+	})
+
+	return g
+}
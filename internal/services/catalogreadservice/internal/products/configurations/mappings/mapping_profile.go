@@ -7,7 +7,12 @@ import (
 )
 
 func ConfigureProductsMappings() error {
-	err := mapper.CreateMap[*models.Product, *dto.ProductDto]()
+	err := mapper.CreateMap[*models.ProductTranslation, *dto.ProductTranslationDto]()
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateMap[*models.Product, *dto.ProductDto]()
 	if err != nil {
 		return err
 	}
@@ -17,5 +22,10 @@ func ConfigureProductsMappings() error {
 		return err
 	}
 
+	err = mapper.CreateMap[*models.SearchSynonym, *dto.SearchSynonymDto]()
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -1,19 +1,34 @@
 package mediator
 
 import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/producer"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/contracts/data"
+	backfillSuggestTermsCommandV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/backfilling_suggest_terms/v1/commands"
+	backfillSuggestTermsDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/backfilling_suggest_terms/v1/dtos"
+	changeProductMerchandisingCommandV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/changing_product_merchandising/v1/commands"
+	changeProductVisibilityCommandV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/changing_product_visibility/v1/commands"
 	v1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/creating_product/v1"
 	createProductDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/creating_product/v1/dtos"
+	createSearchSynonymCommandV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/creating_search_synonyms/v1/commands"
+	createSearchSynonymDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/creating_search_synonyms/v1/dtos"
 	deleteProductCommandV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/deleting_products/v1/commands"
+	deleteSearchSynonymCommandV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/deleting_search_synonyms/v1/commands"
 	getProductByIdDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/get_product_by_id/v1/dtos"
 	getProductByIdQueryV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/get_product_by_id/v1/queries"
 	getProductsDtoV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/getting_products/v1/dtos"
 	getProductsQueryV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/getting_products/v1/queries"
+	getSearchSynonymsDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/getting_search_synonyms/v1/dtos"
+	getSearchSynonymsQueryV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/getting_search_synonyms/v1/queries"
+	increaseProductsPopularityCommandV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/increasing_products_popularity/v1/commands"
 	searchProductsDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/searching_products/v1/dtos"
 	searchProductsQueryV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/searching_products/v1/queries"
+	suggestProductsDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/suggesting_products/v1/dtos"
+	suggestProductsQueryV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/suggesting_products/v1/queries"
 	updateProductCommandV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/updating_products/v1/commands"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/searching"
 
 	"emperror.dev/errors"
 	"github.com/mehdihadeli/go-mediatr"
@@ -23,9 +38,13 @@ func ConfigProductsMediator(
 	logger logger.Logger,
 	mongoProductRepository data.ProductRepository,
 	cacheProductRepository data.ProductCacheRepository,
+	hotProductsRepository data.HotProductsRepository,
+	searchSynonymRepository data.SearchSynonymRepository,
+	searchQueryBuilder searching.SearchQueryBuilder,
+	messageProducer producer.Producer,
 	tracer tracing.AppTracer,
 ) error {
-	err := mediatr.RegisterRequestHandler[*v1.CreateProduct, *createProductDtosV1.CreateProductResponseDto](
+	err := cqrs.RegisterRequestHandler[*v1.CreateProduct, *createProductDtosV1.CreateProductResponseDto](
 		v1.NewCreateProductHandler(
 			logger,
 			mongoProductRepository,
@@ -37,7 +56,7 @@ func ConfigProductsMediator(
 		return errors.WrapIf(err, "error while registering handlers in the mediator")
 	}
 
-	err = mediatr.RegisterRequestHandler[*deleteProductCommandV1.DeleteProduct, *mediatr.Unit](
+	err = cqrs.RegisterRequestHandler[*deleteProductCommandV1.DeleteProduct, *mediatr.Unit](
 		deleteProductCommandV1.NewDeleteProductHandler(
 			logger,
 			mongoProductRepository,
@@ -49,7 +68,7 @@ func ConfigProductsMediator(
 		return errors.WrapIf(err, "error while registering handlers in the mediator")
 	}
 
-	err = mediatr.RegisterRequestHandler[*updateProductCommandV1.UpdateProduct, *mediatr.Unit](
+	err = cqrs.RegisterRequestHandler[*updateProductCommandV1.UpdateProduct, *mediatr.Unit](
 		updateProductCommandV1.NewUpdateProductHandler(
 			logger,
 			mongoProductRepository,
@@ -61,15 +80,110 @@ func ConfigProductsMediator(
 		return errors.WrapIf(err, "error while registering handlers in the mediator")
 	}
 
-	err = mediatr.RegisterRequestHandler[*getProductsQueryV1.GetProducts, *getProductsDtoV1.GetProductsResponseDto](
+	err = cqrs.RegisterRequestHandler[*changeProductVisibilityCommandV1.ChangeProductVisibility, *mediatr.Unit](
+		changeProductVisibilityCommandV1.NewChangeProductVisibilityHandler(
+			logger,
+			mongoProductRepository,
+			cacheProductRepository,
+			tracer,
+		),
+	)
+	if err != nil {
+		return errors.WrapIf(err, "error while registering handlers in the mediator")
+	}
+
+	err = cqrs.RegisterRequestHandler[*changeProductMerchandisingCommandV1.ChangeProductMerchandising, *mediatr.Unit](
+		changeProductMerchandisingCommandV1.NewChangeProductMerchandisingHandler(
+			logger,
+			mongoProductRepository,
+			cacheProductRepository,
+			tracer,
+		),
+	)
+	if err != nil {
+		return errors.WrapIf(err, "error while registering handlers in the mediator")
+	}
+
+	err = cqrs.RegisterRequestHandler[*getProductsQueryV1.GetProducts, *getProductsDtoV1.GetProductsResponseDto](
 		getProductsQueryV1.NewGetProductsHandler(logger, mongoProductRepository, tracer),
 	)
 	if err != nil {
 		return errors.WrapIf(err, "error while registering handlers in the mediator")
 	}
 
-	err = mediatr.RegisterRequestHandler[*searchProductsQueryV1.SearchProducts, *searchProductsDtosV1.SearchProductsResponseDto](
+	err = cqrs.RegisterRequestHandler[*searchProductsQueryV1.SearchProducts, *searchProductsDtosV1.SearchProductsResponseDto](
 		searchProductsQueryV1.NewSearchProductsHandler(
+			logger,
+			mongoProductRepository,
+			searchQueryBuilder,
+			tracer,
+		),
+	)
+	if err != nil {
+		return errors.WrapIf(err, "error while registering handlers in the mediator")
+	}
+
+	err = cqrs.RegisterRequestHandler[*createSearchSynonymCommandV1.CreateSearchSynonym, *createSearchSynonymDtosV1.CreateSearchSynonymResponseDto](
+		createSearchSynonymCommandV1.NewCreateSearchSynonymHandler(
+			logger,
+			searchSynonymRepository,
+			searchQueryBuilder,
+			messageProducer,
+			tracer,
+		),
+	)
+	if err != nil {
+		return errors.WrapIf(err, "error while registering handlers in the mediator")
+	}
+
+	err = cqrs.RegisterRequestHandler[*getSearchSynonymsQueryV1.GetSearchSynonyms, *getSearchSynonymsDtosV1.GetSearchSynonymsResponseDto](
+		getSearchSynonymsQueryV1.NewGetSearchSynonymsHandler(
+			logger,
+			searchSynonymRepository,
+			tracer,
+		),
+	)
+	if err != nil {
+		return errors.WrapIf(err, "error while registering handlers in the mediator")
+	}
+
+	err = cqrs.RegisterRequestHandler[*deleteSearchSynonymCommandV1.DeleteSearchSynonym, *mediatr.Unit](
+		deleteSearchSynonymCommandV1.NewDeleteSearchSynonymHandler(
+			logger,
+			searchSynonymRepository,
+			searchQueryBuilder,
+			messageProducer,
+			tracer,
+		),
+	)
+	if err != nil {
+		return errors.WrapIf(err, "error while registering handlers in the mediator")
+	}
+
+	err = cqrs.RegisterRequestHandler[*suggestProductsQueryV1.SuggestProducts, *suggestProductsDtosV1.SuggestProductsResponseDto](
+		suggestProductsQueryV1.NewSuggestProductsHandler(
+			logger,
+			mongoProductRepository,
+			tracer,
+		),
+	)
+	if err != nil {
+		return errors.WrapIf(err, "error while registering handlers in the mediator")
+	}
+
+	err = cqrs.RegisterRequestHandler[*increaseProductsPopularityCommandV1.IncreaseProductsPopularity, *mediatr.Unit](
+		increaseProductsPopularityCommandV1.NewIncreaseProductsPopularityHandler(
+			logger,
+			mongoProductRepository,
+			tracer,
+		),
+	)
+	if err != nil {
+		return errors.WrapIf(err, "error while registering handlers in the mediator")
+	}
+
+	err = cqrs.RegisterRequestHandler[*backfillSuggestTermsCommandV1.BackfillSuggestTerms, *backfillSuggestTermsDtosV1.BackfillSuggestTermsResponseDto](
+		backfillSuggestTermsCommandV1.NewBackfillSuggestTermsHandler(
 			logger,
 			mongoProductRepository,
 			tracer,
@@ -79,11 +193,12 @@ func ConfigProductsMediator(
 		return errors.WrapIf(err, "error while registering handlers in the mediator")
 	}
 
-	err = mediatr.RegisterRequestHandler[*getProductByIdQueryV1.GetProductById, *getProductByIdDtosV1.GetProductByIdResponseDto](
+	err = cqrs.RegisterRequestHandler[*getProductByIdQueryV1.GetProductById, *getProductByIdDtosV1.GetProductByIdResponseDto](
 		getProductByIdQueryV1.NewGetProductByIdHandler(
 			logger,
 			mongoProductRepository,
 			cacheProductRepository,
+			hotProductsRepository,
 			tracer,
 		),
 	)
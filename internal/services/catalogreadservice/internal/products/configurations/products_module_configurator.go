@@ -1,6 +1,8 @@
 package configurations
 
 import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/producer"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/fxapp/contracts"
 	logger2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
@@ -8,8 +10,12 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/configurations/mappings"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/configurations/mediator"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/contracts/data"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/searching"
 )
 
+// catalogsRequestsPackage is the package prefix of the catalogs commands and queries which should have a handler
+const catalogsRequestsPackage = "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice"
+
 type ProductsModuleConfigurator struct {
 	contracts.Application
 }
@@ -24,18 +30,38 @@ func NewProductsModuleConfigurator(
 
 func (c *ProductsModuleConfigurator) ConfigureProductsModule() {
 	c.ResolveFunc(
-		func(logger logger2.Logger, mongoRepository data.ProductRepository, cacheRepository data.ProductCacheRepository, tracer tracing.AppTracer) error {
+		func(
+			logger logger2.Logger,
+			mongoRepository data.ProductRepository,
+			cacheRepository data.ProductCacheRepository,
+			hotProductsRepository data.HotProductsRepository,
+			searchSynonymRepository data.SearchSynonymRepository,
+			searchQueryBuilder searching.SearchQueryBuilder,
+			messageProducer producer.Producer,
+			tracer tracing.AppTracer,
+		) error {
 			// config Products Mediators
 			err := mediator.ConfigProductsMediator(
 				logger,
 				mongoRepository,
 				cacheRepository,
+				hotProductsRepository,
+				searchSynonymRepository,
+				searchQueryBuilder,
+				messageProducer,
 				tracer,
 			)
 			if err != nil {
 				return err
 			}
 
+			// pre-resolve products request handlers and validate every command and query has exactly one handler
+			err = cqrs.BuildDispatchTable(catalogsRequestsPackage)
+			if err != nil {
+				return err
+			}
+			logger.Infof("%d request handlers registered in the dispatch table", len(cqrs.RegisteredHandlers()))
+
 			// config Products Mappings
 			err = mappings.ConfigureProductsMappings()
 			if err != nil {
@@ -1,23 +1,49 @@
 package rabbitmq
 
 import (
+	"time"
+
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/pipeline"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/featuretoggle"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
 	rabbitmqConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/configurations"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/consumer/configurations"
+	changeProductMerchandisingExternalEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/changing_product_merchandising/v1/events/integration_events/external_events"
+	changeProductVisibilityExternalEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/changing_product_visibility/v1/events/integration_events/external_events"
 	createProductExternalEventV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/creating_product/v1/events/integrationevents/externalevents"
 	deleteProductExternalEventV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/deleting_products/v1/events/integration_events/external_events"
+	increaseProductsPopularityExternalEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/increasing_products_popularity/v1/events/integration_events/external_events"
 	updateProductExternalEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/updating_products/v1/events/integration_events/external_events"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/searching"
 
 	"github.com/go-playground/validator"
 )
 
+const (
+	// the failed messages are retried once in the consumer, then a few times with a backoff through the retry queues and
+	// at last they are kept in the dead-letter queue of the consumer for a week
+	immediateRetries     = 1
+	deadLetterMaxRetries = 3
+	retryInitialDelay    = 10 * time.Second
+	retryMaxDelay        = 5 * time.Minute
+	deadLetterMessageTTL = 7 * 24 * time.Hour
+)
+
+// projectionHealthDependencies pause the consumers while the read models can't be written, so their messages are not
+// retried and dead-lettered during a mongo outage
+var projectionHealthDependencies = []string{"mongodb"} //nolint:gochecknoglobals
+
 func ConfigProductsRabbitMQ(
 	builder rabbitmqConfigurations.RabbitMQConfigurationBuilder,
 	logger logger.Logger,
 	validator *validator.Validate,
 	tracer tracing.AppTracer,
+	featureToggles featuretoggle.FeatureToggles,
+	inboxPipeline pipeline.ConsumerPipeline,
+	deduplicationPipeline pipeline.ConsumerPipeline,
+	searchQueryBuilder searching.SearchQueryBuilder,
 ) {
 	// add custom message type mappings
 	// utils.RegisterCustomMessageTypesToRegistrty(map[string]types.IMessage{"productCreatedV1": &creatingProductIntegration.ProductCreatedV1{}})
@@ -26,6 +52,10 @@ func ConfigProductsRabbitMQ(
 		AddConsumer(
 			createProductExternalEventV1.ProductCreatedV1{},
 			func(builder configurations.RabbitMQConsumerConfigurationBuilder) {
+				builder.WIthPipelines(consumerPipelines(featureToggles, inboxPipeline, deduplicationPipeline))
+				builder.WithDeadLetter(deadLetterMaxRetries, deadLetterMessageTTL)
+				builder.WithRetryPolicy(immediateRetries, deadLetterMaxRetries, retryInitialDelay, retryMaxDelay)
+				builder.WithHealthDependencies(projectionHealthDependencies...)
 				builder.WithHandlers(
 					func(handlersBuilder consumer.ConsumerHandlerConfigurationBuilder) {
 						handlersBuilder.AddHandler(
@@ -41,6 +71,10 @@ func ConfigProductsRabbitMQ(
 		AddConsumer(
 			deleteProductExternalEventV1.ProductDeletedV1{},
 			func(builder configurations.RabbitMQConsumerConfigurationBuilder) {
+				builder.WIthPipelines(consumerPipelines(featureToggles, inboxPipeline, deduplicationPipeline))
+				builder.WithDeadLetter(deadLetterMaxRetries, deadLetterMessageTTL)
+				builder.WithRetryPolicy(immediateRetries, deadLetterMaxRetries, retryInitialDelay, retryMaxDelay)
+				builder.WithHealthDependencies(projectionHealthDependencies...)
 				builder.WithHandlers(
 					func(handlersBuilder consumer.ConsumerHandlerConfigurationBuilder) {
 						handlersBuilder.AddHandler(
@@ -58,9 +92,32 @@ func ConfigProductsRabbitMQ(
 					},
 				)
 			}).
+		AddConsumer(
+			deleteProductExternalEventV1.ProductsDeletedV1{},
+			func(builder configurations.RabbitMQConsumerConfigurationBuilder) {
+				builder.WIthPipelines(consumerPipelines(featureToggles, inboxPipeline, deduplicationPipeline))
+				builder.WithDeadLetter(deadLetterMaxRetries, deadLetterMessageTTL)
+				builder.WithRetryPolicy(immediateRetries, deadLetterMaxRetries, retryInitialDelay, retryMaxDelay)
+				builder.WithHealthDependencies(projectionHealthDependencies...)
+				builder.WithHandlers(
+					func(handlersBuilder consumer.ConsumerHandlerConfigurationBuilder) {
+						handlersBuilder.AddHandler(
+							deleteProductExternalEventV1.NewProductsDeletedConsumer(
+								logger,
+								validator,
+								tracer,
+							),
+						)
+					},
+				)
+			}).
 		AddConsumer(
 			updateProductExternalEventsV1.ProductUpdatedV1{},
 			func(builder configurations.RabbitMQConsumerConfigurationBuilder) {
+				builder.WIthPipelines(consumerPipelines(featureToggles, inboxPipeline, deduplicationPipeline))
+				builder.WithDeadLetter(deadLetterMaxRetries, deadLetterMessageTTL)
+				builder.WithRetryPolicy(immediateRetries, deadLetterMaxRetries, retryInitialDelay, retryMaxDelay)
+				builder.WithHealthDependencies(projectionHealthDependencies...)
 				builder.WithHandlers(
 					func(handlersBuilder consumer.ConsumerHandlerConfigurationBuilder) {
 						handlersBuilder.AddHandler(
@@ -77,5 +134,93 @@ func ConfigProductsRabbitMQ(
 						)
 					},
 				)
+			}).
+		AddConsumer(
+			changeProductVisibilityExternalEventsV1.ProductVisibilityChangedV1{},
+			func(builder configurations.RabbitMQConsumerConfigurationBuilder) {
+				builder.WIthPipelines(consumerPipelines(featureToggles, inboxPipeline, deduplicationPipeline))
+				builder.WithDeadLetter(deadLetterMaxRetries, deadLetterMessageTTL)
+				builder.WithRetryPolicy(immediateRetries, deadLetterMaxRetries, retryInitialDelay, retryMaxDelay)
+				builder.WithHealthDependencies(projectionHealthDependencies...)
+				builder.WithHandlers(
+					func(handlersBuilder consumer.ConsumerHandlerConfigurationBuilder) {
+						handlersBuilder.AddHandler(
+							changeProductVisibilityExternalEventsV1.NewProductVisibilityChangedConsumer(
+								logger,
+								validator,
+								tracer,
+							),
+						)
+					},
+				)
+			}).
+		AddConsumer(
+			changeProductMerchandisingExternalEventsV1.ProductMerchandisingChangedV1{},
+			func(builder configurations.RabbitMQConsumerConfigurationBuilder) {
+				builder.WIthPipelines(consumerPipelines(featureToggles, inboxPipeline, deduplicationPipeline))
+				builder.WithDeadLetter(deadLetterMaxRetries, deadLetterMessageTTL)
+				builder.WithRetryPolicy(immediateRetries, deadLetterMaxRetries, retryInitialDelay, retryMaxDelay)
+				builder.WithHealthDependencies(projectionHealthDependencies...)
+				builder.WithHandlers(
+					func(handlersBuilder consumer.ConsumerHandlerConfigurationBuilder) {
+						handlersBuilder.AddHandler(
+							changeProductMerchandisingExternalEventsV1.NewProductMerchandisingChangedConsumer(
+								logger,
+								validator,
+								tracer,
+							),
+						)
+					},
+				)
+			}).
+		AddConsumer(
+			increaseProductsPopularityExternalEventsV1.OrderCreatedV1{},
+			func(builder configurations.RabbitMQConsumerConfigurationBuilder) {
+				builder.WIthPipelines(consumerPipelines(featureToggles, inboxPipeline, deduplicationPipeline))
+				builder.WithDeadLetter(deadLetterMaxRetries, deadLetterMessageTTL)
+				builder.WithRetryPolicy(immediateRetries, deadLetterMaxRetries, retryInitialDelay, retryMaxDelay)
+				builder.WithHealthDependencies(projectionHealthDependencies...)
+				builder.WithHandlers(
+					func(handlersBuilder consumer.ConsumerHandlerConfigurationBuilder) {
+						handlersBuilder.AddHandler(
+							increaseProductsPopularityExternalEventsV1.NewOrderCreatedConsumer(
+								logger,
+								validator,
+								tracer,
+							),
+						)
+					},
+				)
+			}).
+		AddProducer(searching.SearchSynonymsChangedV1{}, nil).
+		// every instance caches the synonyms, so each of them receives the change in its own queue
+		AddConsumer(
+			searching.SearchSynonymsChangedV1{},
+			func(builder configurations.RabbitMQConsumerConfigurationBuilder) {
+				builder.WithConsumptionMode(configurations.ConsumptionModeBroadcast)
+				builder.WithHandlers(
+					func(handlersBuilder consumer.ConsumerHandlerConfigurationBuilder) {
+						handlersBuilder.AddHandler(searching.NewSearchSynonymsChangedConsumer(searchQueryBuilder, logger))
+					},
+				)
 			})
 }
+
+// consumerPipelines pauses the consumers which are disabled by their `consumer:<message_name>` feature toggle and
+// skips the redelivered messages which are already handled, with the inbox pipeline
+func consumerPipelines(
+	featureToggles featuretoggle.FeatureToggles,
+	inboxPipeline pipeline.ConsumerPipeline,
+	deduplicationPipeline pipeline.ConsumerPipeline,
+) pipeline.ConsumerPipelineConfigurationBuilderFunc {
+	return func(pipelinesBuilder pipeline.ConsumerPipelineConfigurationBuilder) {
+		pipelinesBuilder.AddPipeline(featuretoggle.NewConsumerPipeline(featureToggles))
+		if inboxPipeline != nil {
+			pipelinesBuilder.AddPipeline(inboxPipeline)
+		}
+		// the redeliveries of a message id are skipped by the inbox before they are hashed
+		if deduplicationPipeline != nil {
+			pipelinesBuilder.AddPipeline(deduplicationPipeline)
+		}
+	}
+}
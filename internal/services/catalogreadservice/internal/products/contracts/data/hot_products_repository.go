@@ -0,0 +1,13 @@
+package data
+
+import (
+	"context"
+)
+
+// HotProductsRepository keeps the popularity of the products by their queries, the hot products are loaded into the
+// cache on the start of the service
+type HotProductsRepository interface {
+	RecordProductQuery(ctx context.Context, id string) error
+	// GetHotProductIds returns the ids of the most queried products, the most queried first
+	GetHotProductIds(ctx context.Context, count int) ([]string, error)
+}
@@ -17,7 +17,14 @@ type ProductRepository interface {
 		searchText string,
 		listQuery *utils.ListQuery,
 	) (*utils.ListResult[*models.Product], error)
+	// SuggestProducts returns the published products which their names start with the normalized prefix, ordered by popularity
+	SuggestProducts(ctx context.Context, prefix string, limit int) ([]*models.Product, error)
+	// IncreaseProductsPopularity increases popularity of the products with the given name, it returns number of matched products
+	IncreaseProductsPopularity(ctx context.Context, name string, count int64) (int64, error)
 	GetProductById(ctx context.Context, uuid string) (*models.Product, error)
+	// IterateProducts streams all products, including the unpublished ones, to the fn through a database cursor, so bulk
+	// flows keep their memory flat
+	IterateProducts(ctx context.Context, fn func(product *models.Product) error) error
 	GetProductByProductId(ctx context.Context, uuid string) (*models.Product, error)
 	CreateProduct(ctx context.Context, product *models.Product) (*models.Product, error)
 	UpdateProduct(ctx context.Context, product *models.Product) (*models.Product, error)
@@ -0,0 +1,13 @@
+package data
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/models"
+)
+
+type SearchSynonymRepository interface {
+	GetAllSearchSynonyms(ctx context.Context) ([]*models.SearchSynonym, error)
+	CreateSearchSynonym(ctx context.Context, synonym *models.SearchSynonym) (*models.SearchSynonym, error)
+	DeleteSearchSynonymByID(ctx context.Context, uuid string) error
+}
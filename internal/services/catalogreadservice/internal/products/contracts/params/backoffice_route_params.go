@@ -0,0 +1,20 @@
+package params
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/shared/contracts"
+
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/fx"
+)
+
+// BackOfficeRouteParams is used by the back-office endpoints, their group authenticates the requests
+type BackOfficeRouteParams struct {
+	fx.In
+
+	CatalogsMetrics *contracts.CatalogsMetrics
+	Logger          logger.Logger
+	BackOfficeGroup *echo.Group `name:"backoffice-product-echo-group"`
+	Validator       *validator.Validate
+}
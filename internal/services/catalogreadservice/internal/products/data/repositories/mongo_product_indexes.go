@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mongodb"
+
+	"emperror.dev/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/fx"
+)
+
+// productIndexes are the indexes of the products collection, the multikey index on `suggestTerms` is the n-gram
+// completion index of the suggestions endpoint, `merchandising_score` backs the default order of list and search and
+// `schema_version` backs the backfill of the product migrations
+var productIndexes = []mongo.IndexModel{
+	{
+		Keys: bson.D{
+			{Key: "suggestTerms", Value: 1},
+			{Key: "popularity", Value: -1},
+		},
+		Options: options.Index().SetName("suggest_terms_popularity"),
+	},
+	{
+		Keys:    bson.D{{Key: "productId", Value: 1}},
+		Options: options.Index().SetName("product_id"),
+	},
+	{
+		Keys:    bson.D{{Key: "merchandisingScore", Value: -1}, {Key: "_id", Value: 1}},
+		Options: options.Index().SetName("merchandising_score"),
+	},
+	{
+		Keys:    bson.D{{Key: "schemaVersion", Value: 1}, {Key: "_id", Value: 1}},
+		Options: options.Index().SetName("schema_version"),
+	},
+}
+
+// RegisterMongoProductIndexes creates the indexes of the products collection on application start, creating an existing index is a no-op
+func RegisterMongoProductIndexes(
+	lc fx.Lifecycle,
+	db *mongo.Client,
+	mongoOptions *mongodb.MongoDbOptions,
+	log logger.Logger,
+) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			err := CreateMongoProductIndexes(ctx, db, mongoOptions, productCollection)
+			if err != nil {
+				return err
+			}
+
+			log.Info("products indexes created")
+
+			return nil
+		},
+	})
+}
+
+// CreateMongoProductIndexes creates the indexes of the products on the collection
+func CreateMongoProductIndexes(
+	ctx context.Context,
+	db *mongo.Client,
+	mongoOptions *mongodb.MongoDbOptions,
+	collection string,
+) error {
+	_, err := db.Database(mongoOptions.Database).
+		Collection(collection).
+		Indexes().
+		CreateMany(ctx, productIndexes)
+	if err != nil {
+		return errors.WrapIf(err, fmt.Sprintf("error in creating indexes of '%s' collection", collection))
+	}
+
+	return nil
+}
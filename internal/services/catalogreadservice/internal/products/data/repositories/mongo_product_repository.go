@@ -10,10 +10,13 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"regexp"
 
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/data"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mongodb"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mongodb/documentmigration"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mongodb/repository"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/attribute"
@@ -24,7 +27,10 @@ import (
 
 	"emperror.dev/errors"
 	uuid2 "github.com/satori/go.uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	attribute2 "go.opentelemetry.io/otel/attribute"
 )
 
@@ -32,10 +38,32 @@ const (
 	productCollection = "products"
 )
 
+// publishedFilter excludes the products that are unpublished by their publishing schedule
+var publishedFilter = bson.E{Key: "unpublished", Value: bson.D{{Key: "$ne", Value: true}}}
+
+// merchandisingSort lists the pinned and the manually sorted products first, `_id` keeps the order of the other
+// products stable between the pages
+var merchandisingSort = []bson.E{{Key: "merchandisingScore", Value: -1}, {Key: "_id", Value: 1}}
+
+// searchableFields are the fields which search term will be matched against them
+var searchableFields = []string{"productId", "name", "description", "category"}
+
+// projectedFields are the document fields of the fields of the product dto in a sparse fieldset, the localized fields
+// need the translations and `_id` is always returned by mongo
+var projectedFields = map[string][]string{
+	"id":          nil,
+	"name":        {"name", "translations"},
+	"description": {"description", "translations"},
+	"locale":      {"translations"},
+}
+
 type mongoProductRepository struct {
 	log                    logger.Logger
 	mongoGenericRepository data.GenericRepository[*models.Product]
-	tracer                 tracing.AppTracer
+	collection             *mongo.Collection
+	// listCollection is used by the paginated list and search queries, it has the `listReadPreference` of the collection
+	listCollection *mongo.Collection
+	tracer         tracing.AppTracer
 }
 
 func NewMongoProductRepository(
@@ -43,19 +71,39 @@ func NewMongoProductRepository(
 	db *mongo.Client,
 	mongoOptions *mongodb.MongoDbOptions,
 	tracer tracing.AppTracer,
+) data2.ProductRepository {
+	return NewMongoProductRepositoryWithCollection(log, db, mongoOptions, tracer, productCollection)
+}
+
+// NewMongoProductRepositoryWithCollection creates a products repository on another collection, like the candidate
+// collection of the shadow reads
+func NewMongoProductRepositoryWithCollection(
+	log logger.Logger,
+	db *mongo.Client,
+	mongoOptions *mongodb.MongoDbOptions,
+	tracer tracing.AppTracer,
+	collection string,
 ) data2.ProductRepository {
 	mongoRepo := repository.NewGenericMongoRepository[*models.Product](
 		db,
 		mongoOptions.Database,
-		productCollection,
+		collection,
+		mongoOptions.CollectionOptions(collection),
 	)
 	return &mongoProductRepository{
 		log:                    log,
 		mongoGenericRepository: mongoRepo,
+		collection:             mongoOptions.Collection(db, collection),
+		listCollection:         mongoOptions.ListCollection(db, collection),
 		tracer:                 tracer,
 	}
 }
 
+// NewProductCollectionMigrator backfills the product migrations of the products collection
+func NewProductCollectionMigrator() *documentmigration.CollectionMigrator {
+	return documentmigration.NewCollectionMigrator(productCollection, models.ProductMigrator)
+}
+
 func (p *mongoProductRepository) GetAllProducts(
 	ctx context.Context,
 	listQuery *utils.ListQuery,
@@ -64,7 +112,14 @@ func (p *mongoProductRepository) GetAllProducts(
 	defer span.End()
 
 	// https://www.mongodb.com/docs/drivers/go/current/fundamentals/crud/read-operations/query-document/
-	result, err := p.mongoGenericRepository.GetAll(ctx, listQuery)
+	result, err := mongodb.PaginateWithProjection[*models.Product](
+		ctx,
+		listQuery,
+		p.listCollection,
+		bson.D{publishedFilter},
+		productProjection(listQuery.GetFields()),
+		merchandisingSort...,
+	)
 	if err != nil {
 		return nil, utils2.TraceErrStatusFromSpan(
 			span,
@@ -94,7 +149,22 @@ func (p *mongoProductRepository) SearchProducts(
 	span.SetAttributes(attribute2.String("SearchText", searchText))
 	defer span.End()
 
-	result, err := p.mongoGenericRepository.Search(ctx, searchText, listQuery)
+	var searchFilters bson.A
+	for _, field := range searchableFields {
+		searchFilters = append(
+			searchFilters,
+			bson.D{{Key: field, Value: primitive.Regex{Pattern: searchText}}},
+		)
+	}
+
+	result, err := mongodb.PaginateWithProjection[*models.Product](
+		ctx,
+		listQuery,
+		p.listCollection,
+		bson.D{publishedFilter, {Key: "$or", Value: searchFilters}},
+		productProjection(listQuery.GetFields()),
+		merchandisingSort...,
+	)
 	if err != nil {
 		return nil, utils2.TraceErrStatusFromSpan(
 			span,
@@ -118,6 +188,125 @@ func (p *mongoProductRepository) SearchProducts(
 	return result, nil
 }
 
+// productProjection returns the projection of the product documents for the sparse fieldset of the product dto, the
+// schema version is projected so the projected documents are not migrated on read again
+func productProjection(fields []string) bson.D {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	projection := bson.D{{Key: documentmigration.SchemaVersionField, Value: 1}}
+	projected := map[string]bool{documentmigration.SchemaVersionField: true}
+	for _, field := range fields {
+		documentFields, ok := projectedFields[field]
+		if !ok {
+			documentFields = []string{field}
+		}
+
+		for _, documentField := range documentFields {
+			if projected[documentField] {
+				continue
+			}
+
+			projected[documentField] = true
+			projection = append(projection, bson.E{Key: documentField, Value: 1})
+		}
+	}
+
+	return projection
+}
+
+func (p *mongoProductRepository) SuggestProducts(
+	ctx context.Context,
+	prefix string,
+	limit int,
+) ([]*models.Product, error) {
+	ctx, span := p.tracer.Start(ctx, "mongoProductRepository.SuggestProducts")
+	span.SetAttributes(attribute2.String("Prefix", prefix))
+	defer span.End()
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "popularity", Value: -1}, {Key: "name", Value: 1}}).
+		SetLimit(int64(limit)).
+		SetProjection(bson.D{{Key: "suggestTerms", Value: 0}})
+
+	cursor, err := p.collection.Find(
+		ctx,
+		bson.D{publishedFilter, {Key: "suggestTerms", Value: prefix}},
+		findOptions,
+	)
+	if err != nil {
+		return nil, utils2.TraceErrStatusFromSpan(
+			span,
+			errors.WrapIf(
+				customErrors.WrapIfCanceled(ctx, err, "finding product suggestions canceled"),
+				"error in finding product suggestions",
+			),
+		)
+	}
+	defer cursor.Close(ctx)
+
+	var products []*models.Product
+	if err = cursor.All(ctx, &products); err != nil {
+		return nil, utils2.TraceErrStatusFromSpan(
+			span,
+			errors.WrapIf(
+				customErrors.WrapIfCanceled(ctx, err, "decoding product suggestions canceled"),
+				"error in decoding product suggestions",
+			),
+		)
+	}
+
+	p.log.Infow(
+		fmt.Sprintf(
+			"%d product suggestions loaded for prefix '%s'",
+			len(products),
+			prefix,
+		),
+		logger.Fields{"Prefix": prefix},
+	)
+
+	return products, nil
+}
+
+func (p *mongoProductRepository) IncreaseProductsPopularity(
+	ctx context.Context,
+	name string,
+	count int64,
+) (int64, error) {
+	ctx, span := p.tracer.Start(ctx, "mongoProductRepository.IncreaseProductsPopularity")
+	span.SetAttributes(attribute2.String("Name", name))
+	defer span.End()
+
+	// order items only keep the title of the product, so we match products with a case-insensitive exact name
+	filter := bson.D{
+		{
+			Key:   "name",
+			Value: primitive.Regex{Pattern: "^" + regexp.QuoteMeta(name) + "$", Options: "i"},
+		},
+	}
+
+	result, err := p.collection.UpdateMany(
+		ctx,
+		filter,
+		bson.D{{Key: "$inc", Value: bson.D{{Key: "popularity", Value: count}}}},
+	)
+	if err != nil {
+		return 0, utils2.TraceErrStatusFromSpan(
+			span,
+			errors.WrapIf(
+				customErrors.WrapIfCanceled(ctx, err, "increasing popularity of products canceled"),
+				fmt.Sprintf(
+					"error in increasing popularity of products with name %s",
+					name,
+				),
+			),
+		)
+	}
+
+	return result.MatchedCount, nil
+}
+
 func (p *mongoProductRepository) GetProductById(
 	ctx context.Context,
 	uuid string,
@@ -197,6 +386,38 @@ func (p *mongoProductRepository) GetProductByProductId(
 	return product, nil
 }
 
+func (p *mongoProductRepository) IterateProducts(
+	ctx context.Context,
+	fn func(product *models.Product) error,
+) error {
+	ctx, span := p.tracer.Start(ctx, "mongoProductRepository.IterateProducts")
+	defer span.End()
+
+	count := 0
+	err := p.mongoGenericRepository.Iterate(ctx, nil, func(product *models.Product) error {
+		count++
+
+		return fn(product)
+	})
+	if err != nil {
+		return utils2.TraceErrStatusFromSpan(
+			span,
+			errors.WrapIf(
+				err,
+				"error in iterating products",
+			),
+		)
+	}
+
+	span.SetAttributes(attribute2.Int("Count", count))
+	p.log.Infow(
+		fmt.Sprintf("%d products iterated", count),
+		logger.Fields{"Count": count},
+	)
+
+	return nil
+}
+
 func (p *mongoProductRepository) CreateProduct(
 	ctx context.Context,
 	product *models.Product,
@@ -204,6 +425,8 @@ func (p *mongoProductRepository) CreateProduct(
 	ctx, span := p.tracer.Start(ctx, "mongoProductRepository.CreateProduct")
 	defer span.End()
 
+	product.SchemaVersion = models.ProductMigrator.CurrentVersion()
+
 	err := p.mongoGenericRepository.Add(ctx, product)
 	if err != nil {
 		return nil, utils2.TraceErrStatusFromSpan(
@@ -235,6 +458,9 @@ func (p *mongoProductRepository) UpdateProduct(
 	ctx, span := p.tracer.Start(ctx, "mongoProductRepository.UpdateProduct")
 	defer span.End()
 
+	// the product is loaded with the current shape, so its update persists its migrations
+	updateProduct.SchemaVersion = models.ProductMigrator.CurrentVersion()
+
 	err := p.mongoGenericRepository.Update(ctx, updateProduct)
 	// https://www.mongodb.com/docs/manual/reference/method/db.collection.findOneAndUpdate/
 	if err != nil {
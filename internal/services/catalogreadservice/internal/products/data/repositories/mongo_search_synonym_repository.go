@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/data"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mongodb"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mongodb/repository"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/attribute"
+	utils2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/utils"
+	data2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/contracts/data"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/models"
+
+	"emperror.dev/errors"
+	uuid2 "github.com/satori/go.uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	attribute2 "go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	searchSynonymCollection = "search_synonyms"
+)
+
+type mongoSearchSynonymRepository struct {
+	log                    logger.Logger
+	mongoGenericRepository data.GenericRepository[*models.SearchSynonym]
+	tracer                 tracing.AppTracer
+}
+
+func NewMongoSearchSynonymRepository(
+	log logger.Logger,
+	db *mongo.Client,
+	mongoOptions *mongodb.MongoDbOptions,
+	tracer tracing.AppTracer,
+) data2.SearchSynonymRepository {
+	mongoRepo := repository.NewGenericMongoRepository[*models.SearchSynonym](
+		db,
+		mongoOptions.Database,
+		searchSynonymCollection,
+		mongoOptions.CollectionOptions(searchSynonymCollection),
+	)
+
+	return &mongoSearchSynonymRepository{
+		log:                    log,
+		mongoGenericRepository: mongoRepo,
+		tracer:                 tracer,
+	}
+}
+
+func (s *mongoSearchSynonymRepository) GetAllSearchSynonyms(
+	ctx context.Context,
+) ([]*models.SearchSynonym, error) {
+	ctx, span := s.tracer.Start(ctx, "mongoSearchSynonymRepository.GetAllSearchSynonyms")
+	defer span.End()
+
+	synonyms, err := s.mongoGenericRepository.GetByFilter(ctx, map[string]interface{}{})
+	if err != nil {
+		return nil, utils2.TraceErrStatusFromSpan(
+			span,
+			errors.WrapIf(
+				err,
+				"error in loading search synonyms from the database.",
+			),
+		)
+	}
+
+	s.log.Infow(
+		fmt.Sprintf("%d search synonyms loaded", len(synonyms)),
+		logger.Fields{"Count": len(synonyms)},
+	)
+
+	return synonyms, nil
+}
+
+func (s *mongoSearchSynonymRepository) CreateSearchSynonym(
+	ctx context.Context,
+	synonym *models.SearchSynonym,
+) (*models.SearchSynonym, error) {
+	ctx, span := s.tracer.Start(ctx, "mongoSearchSynonymRepository.CreateSearchSynonym")
+	defer span.End()
+
+	err := s.mongoGenericRepository.Add(ctx, synonym)
+	if err != nil {
+		return nil, utils2.TraceErrStatusFromSpan(
+			span,
+			errors.WrapIf(
+				err,
+				"error in the inserting search synonym into the database.",
+			),
+		)
+	}
+
+	span.SetAttributes(attribute.Object("SearchSynonym", synonym))
+
+	s.log.Infow(
+		fmt.Sprintf("search synonym with id '%s' created", synonym.Id),
+		logger.Fields{"SearchSynonym": synonym, "Id": synonym.Id},
+	)
+
+	return synonym, nil
+}
+
+func (s *mongoSearchSynonymRepository) DeleteSearchSynonymByID(
+	ctx context.Context,
+	uuid string,
+) error {
+	ctx, span := s.tracer.Start(ctx, "mongoSearchSynonymRepository.DeleteSearchSynonymByID")
+	span.SetAttributes(attribute2.String("Id", uuid))
+	defer span.End()
+
+	id, err := uuid2.FromString(uuid)
+	if err != nil {
+		return err
+	}
+
+	err = s.mongoGenericRepository.Delete(ctx, id)
+	if err != nil {
+		return utils2.TraceStatusFromSpan(
+			span,
+			errors.WrapIf(err, fmt.Sprintf(
+				"error in deleting search synonym with id %s from the database.",
+				uuid,
+			)),
+		)
+	}
+
+	s.log.Infow(
+		fmt.Sprintf("search synonym with id %s deleted", uuid),
+		logger.Fields{"Id": uuid},
+	)
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/contracts/data"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/hotproducts"
+
+	"emperror.dev/errors"
+	"github.com/redis/go-redis/v9"
+	attribute2 "go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	redisHotProductsKey = "hot_products"
+)
+
+// redisHotProductsRepository keeps the query counts of the products in a sorted set, the set is trimmed to the tracked
+// count on each query, so it keeps the most queried products
+type redisHotProductsRepository struct {
+	log         logger.Logger
+	options     *hotproducts.HotProductsOptions
+	redisClient redis.UniversalClient
+	tracer      tracing.AppTracer
+}
+
+func NewRedisHotProductsRepository(
+	log logger.Logger,
+	options *hotproducts.HotProductsOptions,
+	redisClient redis.UniversalClient,
+	tracer tracing.AppTracer,
+) data.HotProductsRepository {
+	return &redisHotProductsRepository{
+		log:         log,
+		options:     options,
+		redisClient: redisClient,
+		tracer:      tracer,
+	}
+}
+
+func (r *redisHotProductsRepository) RecordProductQuery(ctx context.Context, id string) error {
+	ctx, span := r.tracer.Start(ctx, "redisHotProductsRepository.RecordProductQuery")
+	span.SetAttributes(attribute2.String("Key", r.getRedisHotProductsKey()))
+	span.SetAttributes(attribute2.String("Id", id))
+	defer span.End()
+
+	_, err := r.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZIncrBy(ctx, r.getRedisHotProductsKey(), 1, id)
+		if r.options.TrackedCount > 0 {
+			// removes the least queried products, the ranks are ascending by the query counts
+			pipe.ZRemRangeByRank(ctx, r.getRedisHotProductsKey(), 0, int64(-r.options.TrackedCount-1))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return utils.TraceErrStatusFromSpan(
+			span,
+			errors.WrapIf(
+				err,
+				fmt.Sprintf(
+					"error in recording the query of product with id %s",
+					id,
+				),
+			),
+		)
+	}
+
+	return nil
+}
+
+func (r *redisHotProductsRepository) GetHotProductIds(ctx context.Context, count int) ([]string, error) {
+	ctx, span := r.tracer.Start(ctx, "redisHotProductsRepository.GetHotProductIds")
+	span.SetAttributes(attribute2.String("Key", r.getRedisHotProductsKey()))
+	span.SetAttributes(attribute2.Int("Count", count))
+	defer span.End()
+
+	if count <= 0 {
+		return nil, nil
+	}
+
+	ids, err := r.redisClient.ZRevRange(ctx, r.getRedisHotProductsKey(), 0, int64(count-1)).Result()
+	if err != nil {
+		return nil, utils.TraceErrStatusFromSpan(
+			span,
+			errors.WrapIf(
+				err,
+				"error in getting the hot products",
+			),
+		)
+	}
+
+	r.log.Infow(
+		fmt.Sprintf("%d hot products loaded", len(ids)),
+		logger.Fields{"Count": len(ids), "Key": r.getRedisHotProductsKey()},
+	)
+
+	return ids, nil
+}
+
+func (r *redisHotProductsRepository) getRedisHotProductsKey() string {
+	return fmt.Sprintf("%s:%s", redisProductPrefixKey, redisHotProductsKey)
+}
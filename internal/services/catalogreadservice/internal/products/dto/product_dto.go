@@ -5,11 +5,18 @@ import (
 )
 
 type ProductDto struct {
-	Id          string    `json:"id"`
-	ProductId   string    `json:"productId"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Price       float64   `json:"price"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	Id          string                 `json:"id"`
+	ProductId   string                 `json:"productId"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Price       float64                `json:"price"`
+	Category    string                 `json:"category,omitempty"`
+	Attributes  map[string]interface{} `json:"attributes,omitempty"`
+	// Locale is the resolved locale of Name and Description, it is empty for the default content
+	Locale       string                            `json:"locale,omitempty"`
+	Translations map[string]*ProductTranslationDto `json:"translations,omitempty"`
+	IsPinned     bool                              `json:"isPinned,omitempty"`
+	SortOrder    int                               `json:"sortOrder,omitempty"`
+	CreatedAt    time.Time                         `json:"createdAt"`
+	UpdatedAt    time.Time                         `json:"updatedAt"`
 }
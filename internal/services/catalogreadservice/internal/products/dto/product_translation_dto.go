@@ -0,0 +1,40 @@
+package dto
+
+import "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/localization"
+
+type ProductTranslationDto struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Localize replaces name and description with the best matching translation for the requested locales
+// and returns the resolved locale, the default content is kept when there is no matching translation.
+func (p *ProductDto) Localize(locales []string) string {
+	if p == nil {
+		return ""
+	}
+
+	translation, locale, ok := localization.Resolve(p.Translations, locales)
+	if !ok || translation == nil {
+		return ""
+	}
+
+	p.Name = translation.Name
+	if translation.Description != "" {
+		p.Description = translation.Description
+	}
+	p.Locale = locale
+
+	return locale
+}
+
+// LocalizeProducts localizes a list of products for the requested locales
+func LocalizeProducts(products []*ProductDto, locales []string) {
+	if len(locales) == 0 {
+		return
+	}
+
+	for _, product := range products {
+		product.Localize(locales)
+	}
+}
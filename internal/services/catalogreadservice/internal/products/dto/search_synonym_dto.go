@@ -0,0 +1,11 @@
+package dto
+
+import (
+	"time"
+)
+
+type SearchSynonymDto struct {
+	Id        string    `json:"id"`
+	Terms     []string  `json:"terms"`
+	CreatedAt time.Time `json:"createdAt"`
+}
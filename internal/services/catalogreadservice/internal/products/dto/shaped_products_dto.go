@@ -0,0 +1,9 @@
+package dto
+
+import "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils"
+
+// ShapedProductsResponseDto is a page of the products with a sparse fieldset, its items have only the requested fields
+// of the ProductDto
+type ShapedProductsResponseDto struct {
+	Products *utils.ListResult[map[string]interface{}]
+}
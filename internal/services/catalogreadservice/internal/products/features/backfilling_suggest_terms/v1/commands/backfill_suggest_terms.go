@@ -0,0 +1,26 @@
+package commands
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// BackfillSuggestTerms rebuilds the suggest terms of the existing products, for the products which are created before
+// indexing the suggestions or after changing the n-gram rules.
+type BackfillSuggestTerms struct {
+	StartedAt time.Time
+}
+
+func NewBackfillSuggestTerms() (*BackfillSuggestTerms, error) {
+	command := &BackfillSuggestTerms{StartedAt: time.Now()}
+	if err := command.Validate(); err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+func (c *BackfillSuggestTerms) Validate() error {
+	return validation.ValidateStruct(c, validation.Field(&c.StartedAt, validation.Required))
+}
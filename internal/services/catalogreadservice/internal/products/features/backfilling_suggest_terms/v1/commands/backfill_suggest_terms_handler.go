@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/contracts/data"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/backfilling_suggest_terms/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/suggestions"
+)
+
+type BackfillSuggestTermsHandler struct {
+	log             logger.Logger
+	mongoRepository data.ProductRepository
+	tracer          tracing.AppTracer
+}
+
+func NewBackfillSuggestTermsHandler(
+	log logger.Logger,
+	repository data.ProductRepository,
+	tracer tracing.AppTracer,
+) *BackfillSuggestTermsHandler {
+	return &BackfillSuggestTermsHandler{
+		log:             log,
+		mongoRepository: repository,
+		tracer:          tracer,
+	}
+}
+
+func (c *BackfillSuggestTermsHandler) Handle(
+	ctx context.Context,
+	command *BackfillSuggestTerms,
+) (*dtos.BackfillSuggestTermsResponseDto, error) {
+	result := &dtos.BackfillSuggestTermsResponseDto{}
+
+	// products are streamed one by one, so the memory stays flat regardless of the catalog size
+	err := c.mongoRepository.IterateProducts(ctx, func(product *models.Product) error {
+		result.ScannedCount++
+
+		suggestTerms := suggestions.BuildSuggestTerms(product)
+		if slices.Equal(product.SuggestTerms, suggestTerms) {
+			return nil
+		}
+
+		product.SuggestTerms = suggestTerms
+		if _, err := c.mongoRepository.UpdateProduct(ctx, product); err != nil {
+			return err
+		}
+
+		result.UpdatedCount++
+
+		return nil
+	})
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			fmt.Sprintf(
+				"error in backfilling suggest terms after %d scanned products",
+				result.ScannedCount,
+			),
+		)
+	}
+
+	c.log.Infow(
+		fmt.Sprintf(
+			"suggest terms of %d products backfilled from %d scanned products",
+			result.UpdatedCount,
+			result.ScannedCount,
+		),
+		logger.Fields{"ScannedCount": result.ScannedCount, "UpdatedCount": result.UpdatedCount},
+	)
+
+	return result, nil
+}
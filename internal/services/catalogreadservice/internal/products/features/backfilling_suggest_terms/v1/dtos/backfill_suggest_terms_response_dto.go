@@ -0,0 +1,8 @@
+package dtos
+
+type BackfillSuggestTermsResponseDto struct {
+	// ScannedCount is the number of iterated products
+	ScannedCount int64 `json:"scannedCount"`
+	// UpdatedCount is the number of products which their suggest terms were missing or stale
+	UpdatedCount int64 `json:"updatedCount"`
+}
@@ -0,0 +1,68 @@
+package endpoints
+
+import (
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/contracts/params"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/backfilling_suggest_terms/v1/commands"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/backfilling_suggest_terms/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type backfillSuggestTermsEndpoint struct {
+	params.ProductRouteParams
+}
+
+func NewBackfillSuggestTermsEndpoint(
+	params params.ProductRouteParams,
+) route.Endpoint {
+	return &backfillSuggestTermsEndpoint{
+		ProductRouteParams: params,
+	}
+}
+
+func (ep *backfillSuggestTermsEndpoint) MapEndpoint() {
+	ep.ProductsGroup.POST("/suggestions/backfill", ep.handler())
+}
+
+// BackfillSuggestTerms
+// @Tags Products
+// @Summary Backfill suggest terms
+// @Description Rebuild the missing or stale suggest terms of all products, the products are streamed from the database
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.BackfillSuggestTermsResponseDto
+// @Router /api/v1/products/suggestions/backfill [post]
+func (ep *backfillSuggestTermsEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		command, err := commands.NewBackfillSuggestTerms()
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"command validation failed",
+			)
+
+			return validationErr
+		}
+
+		result, err := cqrs.Send[*commands.BackfillSuggestTerms, *dtos.BackfillSuggestTermsResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending BackfillSuggestTerms",
+			)
+		}
+
+		return c.JSON(http.StatusOK, result)
+	}
+}
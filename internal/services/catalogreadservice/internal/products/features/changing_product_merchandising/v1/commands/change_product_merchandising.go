@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	uuid "github.com/satori/go.uuid"
+)
+
+type ChangeProductMerchandising struct {
+	ProductId uuid.UUID
+	IsPinned  bool
+	SortOrder int
+	ChangedAt time.Time
+}
+
+func NewChangeProductMerchandising(
+	productId uuid.UUID,
+	isPinned bool,
+	sortOrder int,
+	changedAt time.Time,
+) (*ChangeProductMerchandising, error) {
+	command := &ChangeProductMerchandising{
+		ProductId: productId,
+		IsPinned:  isPinned,
+		SortOrder: sortOrder,
+		ChangedAt: changedAt,
+	}
+	if err := command.Validate(); err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+func (p *ChangeProductMerchandising) Validate() error {
+	return validation.ValidateStruct(p,
+		validation.Field(&p.ProductId, validation.Required, is.UUIDv4),
+		validation.Field(&p.SortOrder, validation.Min(0)),
+		validation.Field(&p.ChangedAt, validation.Required))
+}
@@ -0,0 +1,16 @@
+package externalEvents
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+)
+
+type ProductMerchandisingChangedV1 struct {
+	*types.Message
+	ProductId string    `json:"productId,omitempty"`
+	Category  string    `json:"category,omitempty"`
+	IsPinned  bool      `json:"isPinned"`
+	SortOrder int       `json:"sortOrder"`
+	ChangedAt time.Time `json:"changedAt"`
+}
@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	uuid "github.com/satori/go.uuid"
+)
+
+type ChangeProductVisibility struct {
+	ProductId   uuid.UUID
+	IsPublished bool
+	PublishAt   *time.Time
+	UnpublishAt *time.Time
+	ChangedAt   time.Time
+}
+
+func NewChangeProductVisibility(
+	productId uuid.UUID,
+	isPublished bool,
+	publishAt *time.Time,
+	unpublishAt *time.Time,
+	changedAt time.Time,
+) (*ChangeProductVisibility, error) {
+	command := &ChangeProductVisibility{
+		ProductId:   productId,
+		IsPublished: isPublished,
+		PublishAt:   publishAt,
+		UnpublishAt: unpublishAt,
+		ChangedAt:   changedAt,
+	}
+	if err := command.Validate(); err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+func (p *ChangeProductVisibility) Validate() error {
+	return validation.ValidateStruct(p,
+		validation.Field(&p.ProductId, validation.Required, is.UUIDv4),
+		validation.Field(&p.ChangedAt, validation.Required))
+}
@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/contracts/data"
+
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type ChangeProductVisibilityHandler struct {
+	log             logger.Logger
+	mongoRepository data.ProductRepository
+	redisRepository data.ProductCacheRepository
+	tracer          tracing.AppTracer
+}
+
+func NewChangeProductVisibilityHandler(
+	log logger.Logger,
+	mongoRepository data.ProductRepository,
+	redisRepository data.ProductCacheRepository,
+	tracer tracing.AppTracer,
+) *ChangeProductVisibilityHandler {
+	return &ChangeProductVisibilityHandler{
+		log:             log,
+		mongoRepository: mongoRepository,
+		redisRepository: redisRepository,
+		tracer:          tracer,
+	}
+}
+
+func (c *ChangeProductVisibilityHandler) Handle(
+	ctx context.Context,
+	command *ChangeProductVisibility,
+) (*mediatr.Unit, error) {
+	product, err := c.mongoRepository.GetProductByProductId(
+		ctx,
+		command.ProductId.String(),
+	)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			fmt.Sprintf(
+				"error in fetching product with productId %s in the mongo repository",
+				command.ProductId,
+			),
+		)
+	}
+
+	if product == nil {
+		return nil, customErrors.NewNotFoundErrorWrap(
+			err,
+			fmt.Sprintf(
+				"product with productId %s not found",
+				command.ProductId,
+			),
+		)
+	}
+
+	// visibility changes can arrive out of order, so we ignore the stale ones
+	if command.ChangedAt.Before(product.UpdatedAt) {
+		c.log.Infow(
+			fmt.Sprintf(
+				"stale visibility change for product with id: {%s} ignored",
+				product.Id,
+			),
+			logger.Fields{"ProductId": command.ProductId, "Id": product.Id},
+		)
+
+		return &mediatr.Unit{}, nil
+	}
+
+	product.Unpublished = !command.IsPublished
+	product.PublishAt = command.PublishAt
+	product.UnpublishAt = command.UnpublishAt
+	product.UpdatedAt = command.ChangedAt
+
+	_, err = c.mongoRepository.UpdateProduct(ctx, product)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in updating product visibility in the mongo repository",
+		)
+	}
+
+	err = c.redisRepository.PutProduct(ctx, product.Id, product)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in updating product visibility in the redis repository",
+		)
+	}
+
+	c.log.Infow(
+		fmt.Sprintf(
+			"visibility of product with id: {%s} changed, published: %t",
+			product.Id,
+			command.IsPublished,
+		),
+		logger.Fields{"ProductId": command.ProductId, "Id": product.Id},
+	)
+
+	return &mediatr.Unit{}, nil
+}
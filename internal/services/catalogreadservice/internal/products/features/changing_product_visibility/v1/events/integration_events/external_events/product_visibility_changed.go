@@ -0,0 +1,16 @@
+package externalEvents
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+)
+
+type ProductVisibilityChangedV1 struct {
+	*types.Message
+	ProductId   string     `json:"productId,omitempty"`
+	IsPublished bool       `json:"isPublished"`
+	PublishAt   *time.Time `json:"publishAt,omitempty"`
+	UnpublishAt *time.Time `json:"unpublishAt,omitempty"`
+	ChangedAt   time.Time  `json:"changedAt"`
+}
@@ -0,0 +1,111 @@
+package externalEvents
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/attribute"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/changing_product_visibility/v1/commands"
+
+	"emperror.dev/errors"
+	"github.com/go-playground/validator"
+	"github.com/mehdihadeli/go-mediatr"
+	uuid "github.com/satori/go.uuid"
+)
+
+type productVisibilityChangedConsumer struct {
+	logger    logger.Logger
+	validator *validator.Validate
+	tracer    tracing.AppTracer
+}
+
+func NewProductVisibilityChangedConsumer(
+	logger logger.Logger,
+	validator *validator.Validate,
+	tracer tracing.AppTracer,
+) consumer.ConsumerHandler {
+	return &productVisibilityChangedConsumer{
+		logger:    logger,
+		validator: validator,
+		tracer:    tracer,
+	}
+}
+
+func (c *productVisibilityChangedConsumer) Handle(
+	ctx context.Context,
+	consumeContext types.MessageConsumeContext,
+) error {
+	message, ok := consumeContext.Message().(*ProductVisibilityChangedV1)
+	if !ok {
+		return errors.New("error in casting message to ProductVisibilityChangedV1")
+	}
+
+	ctx, span := c.tracer.Start(ctx, "productVisibilityChangedConsumer.Handle")
+	span.SetAttributes(attribute.Object("Message", consumeContext.Message()))
+	defer span.End()
+
+	productUUID, err := uuid.FromString(message.ProductId)
+	if err != nil {
+		badRequestErr := customErrors.NewBadRequestErrorWrap(
+			err,
+			"[productVisibilityChangedConsumer_Consume.uuid.FromString] error in the converting uuid",
+		)
+		c.logger.Errorf(
+			fmt.Sprintf(
+				"[productVisibilityChangedConsumer_Consume.uuid.FromString] err: %v",
+				utils.TraceErrStatusFromSpan(span, badRequestErr),
+			),
+		)
+
+		return err
+	}
+
+	command, err := commands.NewChangeProductVisibility(
+		productUUID,
+		message.IsPublished,
+		message.PublishAt,
+		message.UnpublishAt,
+		message.ChangedAt,
+	)
+	if err != nil {
+		validationErr := customErrors.NewValidationErrorWrap(
+			err,
+			"[productVisibilityChangedConsumer_Consume.NewValidationErrorWrap] command validation failed",
+		)
+		c.logger.Errorf(
+			fmt.Sprintf(
+				"[productVisibilityChangedConsumer_Consume.StructCtx] err: {%v}",
+				utils.TraceErrStatusFromSpan(span, validationErr),
+			),
+		)
+
+		return err
+	}
+
+	_, err = cqrs.Send[*commands.ChangeProductVisibility, *mediatr.Unit](ctx, command)
+	if err != nil {
+		err = errors.WithMessage(
+			err,
+			"[productVisibilityChangedConsumer_Consume.Send] error in sending ChangeProductVisibility",
+		)
+		c.logger.Errorw(
+			fmt.Sprintf(
+				"[productVisibilityChangedConsumer_Consume.Send] id: {%s}, err: {%v}",
+				command.ProductId,
+				utils.TraceErrStatusFromSpan(span, err),
+			),
+			logger.Fields{"Id": command.ProductId},
+		)
+
+		return err
+	}
+
+	return nil
+}
@@ -3,18 +3,26 @@ package v1
 import (
 	"time"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/models"
+
 	validation "github.com/go-ozzo/ozzo-validation"
 	uuid "github.com/satori/go.uuid"
 )
 
 type CreateProduct struct {
 	// we generate id ourselves because auto generate mongo string id column with type _id is not an uuid
-	Id          string
-	ProductId   string
-	Name        string
-	Description string
-	Price       float64
-	CreatedAt   time.Time
+	Id           string
+	ProductId    string
+	Name         string
+	Description  string
+	Price        float64
+	Category     string
+	Attributes   map[string]interface{}
+	Translations map[string]*models.ProductTranslation
+	Unpublished  bool
+	PublishAt    *time.Time
+	UnpublishAt  *time.Time
+	CreatedAt    time.Time
 }
 
 func NewCreateProduct(
@@ -10,6 +10,7 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/contracts/data"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/creating_product/v1/dtos"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/suggestions"
 )
 
 type CreateProductHandler struct {
@@ -38,13 +39,20 @@ func (c *CreateProductHandler) Handle(
 	command *CreateProduct,
 ) (*dtos.CreateProductResponseDto, error) {
 	product := &models.Product{
-		Id:          command.Id, // we generate id ourselves because auto generate mongo string id column with type _id is not an uuid
-		ProductId:   command.ProductId,
-		Name:        command.Name,
-		Description: command.Description,
-		Price:       command.Price,
-		CreatedAt:   command.CreatedAt,
+		Id:           command.Id, // we generate id ourselves because auto generate mongo string id column with type _id is not an uuid
+		ProductId:    command.ProductId,
+		Name:         command.Name,
+		Description:  command.Description,
+		Price:        command.Price,
+		Category:     command.Category,
+		Attributes:   command.Attributes,
+		Translations: command.Translations,
+		Unpublished:  command.Unpublished,
+		PublishAt:    command.PublishAt,
+		UnpublishAt:  command.UnpublishAt,
+		CreatedAt:    command.CreatedAt,
 	}
+	product.SuggestTerms = suggestions.BuildSuggestTerms(product)
 
 	createdProduct, err := c.mongoRepository.CreateProduct(ctx, product)
 	if err != nil {
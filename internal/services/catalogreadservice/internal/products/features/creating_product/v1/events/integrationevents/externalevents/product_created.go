@@ -4,13 +4,21 @@ import (
 	"time"
 
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/models"
 )
 
 type ProductCreatedV1 struct {
 	*types.Message
-	ProductId   string    `json:"productId,omitempty"`
-	Name        string    `json:"name,omitempty"`
-	Description string    `json:"description,omitempty"`
-	Price       float64   `json:"price,omitempty"`
-	CreatedAt   time.Time `json:"createdAt"`
+	ProductId    string                                `json:"productId,omitempty"`
+	Name         string                                `json:"name,omitempty"`
+	Description  string                                `json:"description,omitempty"`
+	Price        float64                               `json:"price,omitempty"`
+	Category     string                                `json:"category,omitempty"`
+	Attributes   map[string]interface{}                `json:"attributes,omitempty"`
+	Translations map[string]*models.ProductTranslation `json:"translations,omitempty"`
+	// IsPublished is nil for messages which are published before introducing publishing schedules
+	IsPublished *bool      `json:"isPublished,omitempty"`
+	PublishAt   *time.Time `json:"publishAt,omitempty"`
+	UnpublishAt *time.Time `json:"unpublishAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
 	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
@@ -14,7 +15,6 @@ import (
 
 	"emperror.dev/errors"
 	"github.com/go-playground/validator"
-	"github.com/mehdihadeli/go-mediatr"
 )
 
 type productCreatedConsumer struct {
@@ -59,7 +59,14 @@ func (c *productCreatedConsumer) Handle(
 
 		return validationErr
 	}
-	_, err = mediatr.Send[*v1.CreateProduct, *dtos.CreateProductResponseDto](
+	command.Category = product.Category
+	command.Attributes = product.Attributes
+	command.Translations = product.Translations
+	command.Unpublished = product.IsPublished != nil && !*product.IsPublished
+	command.PublishAt = product.PublishAt
+	command.UnpublishAt = product.UnpublishAt
+
+	_, err = cqrs.Send[*v1.CreateProduct, *dtos.CreateProductResponseDto](
 		ctx,
 		command,
 	)
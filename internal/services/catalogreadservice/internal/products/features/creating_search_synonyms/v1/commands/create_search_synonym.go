@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/searching"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+type CreateSearchSynonym struct {
+	Id uuid.UUID
+	// Terms are the normalized equivalent terms of the group
+	Terms     []string
+	CreatedAt time.Time
+}
+
+func NewCreateSearchSynonym(terms []string) (*CreateSearchSynonym, error) {
+	command := &CreateSearchSynonym{
+		Id:        uuid.NewV4(),
+		Terms:     searching.NormalizeTerms(terms),
+		CreatedAt: time.Now(),
+	}
+	if err := command.Validate(); err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+func (c *CreateSearchSynonym) Validate() error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Id, validation.Required),
+		validation.Field(
+			&c.Terms,
+			validation.Required,
+			validation.Length(2, 50).Error("synonym should have at least 2 distinct terms"),
+		),
+		validation.Field(&c.CreatedAt, validation.Required))
+}
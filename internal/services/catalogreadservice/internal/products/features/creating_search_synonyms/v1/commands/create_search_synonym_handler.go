@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/producer"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mapper"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/contracts/data"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/dto"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/creating_search_synonyms/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/searching"
+)
+
+type CreateSearchSynonymHandler struct {
+	log               logger.Logger
+	synonymRepository data.SearchSynonymRepository
+	queryBuilder      searching.SearchQueryBuilder
+	producer          producer.Producer
+	tracer            tracing.AppTracer
+}
+
+func NewCreateSearchSynonymHandler(
+	log logger.Logger,
+	synonymRepository data.SearchSynonymRepository,
+	queryBuilder searching.SearchQueryBuilder,
+	producer producer.Producer,
+	tracer tracing.AppTracer,
+) *CreateSearchSynonymHandler {
+	return &CreateSearchSynonymHandler{
+		log:               log,
+		synonymRepository: synonymRepository,
+		queryBuilder:      queryBuilder,
+		producer:          producer,
+		tracer:            tracer,
+	}
+}
+
+func (c *CreateSearchSynonymHandler) Handle(
+	ctx context.Context,
+	command *CreateSearchSynonym,
+) (*dtos.CreateSearchSynonymResponseDto, error) {
+	synonym := &models.SearchSynonym{
+		Id:        command.Id.String(),
+		Terms:     command.Terms,
+		CreatedAt: command.CreatedAt,
+	}
+
+	createdSynonym, err := c.synonymRepository.CreateSearchSynonym(ctx, synonym)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in creating search synonym in the repository",
+		)
+	}
+
+	// new synonyms should be applied on the next search
+	c.queryBuilder.InvalidateSynonyms()
+	searching.PublishSearchSynonymsChanged(ctx, c.producer, c.log)
+
+	synonymDto, err := mapper.Map[*dto.SearchSynonymDto](createdSynonym)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in the mapping SearchSynonymDto",
+		)
+	}
+
+	c.log.Infow(
+		fmt.Sprintf("search synonym with id '%s' created", createdSynonym.Id),
+		logger.Fields{"Id": createdSynonym.Id, "Terms": createdSynonym.Terms},
+	)
+
+	return &dtos.CreateSearchSynonymResponseDto{Synonym: synonymDto}, nil
+}
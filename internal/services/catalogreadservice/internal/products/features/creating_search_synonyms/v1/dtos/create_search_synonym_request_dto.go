@@ -0,0 +1,5 @@
+package dtos
+
+type CreateSearchSynonymRequestDto struct {
+	Terms []string `json:"terms"`
+}
@@ -0,0 +1,7 @@
+package dtos
+
+import "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/dto"
+
+type CreateSearchSynonymResponseDto struct {
+	Synonym *dto.SearchSynonymDto `json:"synonym"`
+}
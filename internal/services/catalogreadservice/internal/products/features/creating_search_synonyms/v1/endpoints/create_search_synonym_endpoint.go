@@ -0,0 +1,80 @@
+package endpoints
+
+import (
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/contracts/params"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/creating_search_synonyms/v1/commands"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/creating_search_synonyms/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type createSearchSynonymEndpoint struct {
+	params.BackOfficeRouteParams
+}
+
+func NewCreateSearchSynonymEndpoint(
+	params params.BackOfficeRouteParams,
+) route.Endpoint {
+	return &createSearchSynonymEndpoint{
+		BackOfficeRouteParams: params,
+	}
+}
+
+func (ep *createSearchSynonymEndpoint) MapEndpoint() {
+	ep.BackOfficeGroup.POST("/search/synonyms", ep.handler())
+}
+
+// CreateSearchSynonym
+// @Tags BackOffice
+// @Summary Create search synonym
+// @Description Create a group of equivalent search terms
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param CreateSearchSynonymRequestDto body dtos.CreateSearchSynonymRequestDto true "Search synonym data"
+// @Success 201 {object} dtos.CreateSearchSynonymResponseDto
+// @Router /api/v1/backoffice/products/search/synonyms [post]
+func (ep *createSearchSynonymEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.CreateSearchSynonymRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		command, err := commands.NewCreateSearchSynonym(request.Terms)
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"command validation failed",
+			)
+
+			return validationErr
+		}
+
+		result, err := cqrs.Send[*commands.CreateSearchSynonym, *dtos.CreateSearchSynonymResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending CreateSearchSynonym",
+			)
+		}
+
+		return c.JSON(http.StatusCreated, result)
+	}
+}
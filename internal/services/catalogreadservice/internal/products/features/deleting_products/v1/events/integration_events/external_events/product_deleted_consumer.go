@@ -3,6 +3,7 @@ package externalEvents
 import (
 	"context"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
 	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
@@ -63,7 +64,7 @@ func (c *productDeletedConsumer) Handle(
 		return validationErr
 	}
 
-	_, err = mediatr.Send[*commands.DeleteProduct, *mediatr.Unit](ctx, command)
+	_, err = cqrs.Send[*commands.DeleteProduct, *mediatr.Unit](ctx, command)
 
 	c.logger.Info("productDeletedConsumer executed successfully.")
 
@@ -0,0 +1,11 @@
+package externalEvents
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+)
+
+// ProductsDeletedV1 is published by the bulk delete of the catalog write service for a chunk of the deleted products
+type ProductsDeletedV1 struct {
+	*types.Message
+	ProductIds []string `json:"productIds,omitempty"`
+}
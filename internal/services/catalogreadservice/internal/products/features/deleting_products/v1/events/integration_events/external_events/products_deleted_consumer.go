@@ -0,0 +1,78 @@
+package externalEvents
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/deleting_products/v1/commands"
+
+	"emperror.dev/errors"
+	"github.com/go-playground/validator"
+	"github.com/mehdihadeli/go-mediatr"
+	uuid "github.com/satori/go.uuid"
+)
+
+type productsDeletedConsumer struct {
+	logger    logger.Logger
+	validator *validator.Validate
+	tracer    tracing.AppTracer
+}
+
+func NewProductsDeletedConsumer(
+	logger logger.Logger,
+	validator *validator.Validate,
+	tracer tracing.AppTracer,
+) consumer.ConsumerHandler {
+	return &productsDeletedConsumer{
+		logger:    logger,
+		validator: validator,
+		tracer:    tracer,
+	}
+}
+
+// Handle deletes the products of the message one by one, the products which are already deleted are skipped, so a
+// redelivered message only deletes the remaining products
+func (c *productsDeletedConsumer) Handle(
+	ctx context.Context,
+	consumeContext types.MessageConsumeContext,
+) error {
+	message, ok := consumeContext.Message().(*ProductsDeletedV1)
+	if !ok {
+		return errors.New("error in casting message to ProductsDeletedV1")
+	}
+
+	for _, productId := range message.ProductIds {
+		productUUID, err := uuid.FromString(productId)
+		if err != nil {
+			return customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the converting uuid",
+			)
+		}
+
+		command, err := commands.NewDeleteProduct(productUUID)
+		if err != nil {
+			return customErrors.NewValidationErrorWrap(
+				err,
+				"command validation failed",
+			)
+		}
+
+		_, err = cqrs.Send[*commands.DeleteProduct, *mediatr.Unit](ctx, command)
+		if err != nil && !customErrors.IsNotFoundError(err) {
+			return err
+		}
+	}
+
+	c.logger.Info(
+		fmt.Sprintf("productsDeletedConsumer executed successfully for %d products.", len(message.ProductIds)),
+	)
+
+	return nil
+}
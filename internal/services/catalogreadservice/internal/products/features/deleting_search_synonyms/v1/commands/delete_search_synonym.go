@@ -0,0 +1,25 @@
+package commands
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	uuid "github.com/satori/go.uuid"
+)
+
+type DeleteSearchSynonym struct {
+	Id uuid.UUID
+}
+
+func NewDeleteSearchSynonym(id uuid.UUID) (*DeleteSearchSynonym, error) {
+	command := &DeleteSearchSynonym{Id: id}
+	if err := command.Validate(); err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+func (c *DeleteSearchSynonym) Validate() error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Id, validation.Required, is.UUIDv4))
+}
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/producer"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/contracts/data"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/searching"
+
+	"emperror.dev/errors"
+	"github.com/mehdihadeli/go-mediatr"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type DeleteSearchSynonymHandler struct {
+	log               logger.Logger
+	synonymRepository data.SearchSynonymRepository
+	queryBuilder      searching.SearchQueryBuilder
+	producer          producer.Producer
+	tracer            tracing.AppTracer
+}
+
+func NewDeleteSearchSynonymHandler(
+	log logger.Logger,
+	synonymRepository data.SearchSynonymRepository,
+	queryBuilder searching.SearchQueryBuilder,
+	producer producer.Producer,
+	tracer tracing.AppTracer,
+) *DeleteSearchSynonymHandler {
+	return &DeleteSearchSynonymHandler{
+		log:               log,
+		synonymRepository: synonymRepository,
+		queryBuilder:      queryBuilder,
+		producer:          producer,
+		tracer:            tracer,
+	}
+}
+
+func (c *DeleteSearchSynonymHandler) Handle(
+	ctx context.Context,
+	command *DeleteSearchSynonym,
+) (*mediatr.Unit, error) {
+	err := c.synonymRepository.DeleteSearchSynonymByID(ctx, command.Id.String())
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, customErrors.NewNotFoundErrorWrap(
+			err,
+			fmt.Sprintf("search synonym with id %s not found", command.Id),
+		)
+	}
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in deleting search synonym in the repository",
+		)
+	}
+
+	c.queryBuilder.InvalidateSynonyms()
+	searching.PublishSearchSynonymsChanged(ctx, c.producer, c.log)
+
+	c.log.Infow(
+		fmt.Sprintf("search synonym with id: {%s} deleted", command.Id),
+		logger.Fields{"Id": command.Id},
+	)
+
+	return &mediatr.Unit{}, nil
+}
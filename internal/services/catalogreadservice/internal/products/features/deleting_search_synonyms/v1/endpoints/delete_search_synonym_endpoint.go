@@ -0,0 +1,81 @@
+package endpoints
+
+import (
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/contracts/params"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/deleting_search_synonyms/v1/commands"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/deleting_search_synonyms/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type deleteSearchSynonymEndpoint struct {
+	params.BackOfficeRouteParams
+}
+
+func NewDeleteSearchSynonymEndpoint(
+	params params.BackOfficeRouteParams,
+) route.Endpoint {
+	return &deleteSearchSynonymEndpoint{
+		BackOfficeRouteParams: params,
+	}
+}
+
+func (ep *deleteSearchSynonymEndpoint) MapEndpoint() {
+	ep.BackOfficeGroup.DELETE("/search/synonyms/:id", ep.handler())
+}
+
+// DeleteSearchSynonym
+// @Tags BackOffice
+// @Summary Delete search synonym
+// @Description Delete a group of equivalent search terms
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Search synonym ID"
+// @Success 204
+// @Router /api/v1/backoffice/products/search/synonyms/{id} [delete]
+func (ep *deleteSearchSynonymEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.DeleteSearchSynonymRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		command, err := commands.NewDeleteSearchSynonym(request.Id)
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"command validation failed",
+			)
+
+			return validationErr
+		}
+
+		_, err = cqrs.Send[*commands.DeleteSearchSynonym, *mediatr.Unit](
+			ctx,
+			command,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending DeleteSearchSynonym",
+			)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
@@ -4,4 +4,6 @@ import uuid "github.com/satori/go.uuid"
 
 type GetProductByIdRequestDto struct {
 	Id uuid.UUID `param:"id" json:"-"`
+	// Fields is the sparse fieldset of the product, e.g. `name,price`
+	Fields string `query:"fields" json:"-"`
 }
@@ -0,0 +1,7 @@
+package dtos
+
+// GetProductByIdShapedResponseDto is the product with a sparse fieldset, it has only the requested fields of the
+// ProductDto
+type GetProductByIdShapedResponseDto struct {
+	Product map[string]interface{} `json:"product"`
+}
@@ -3,15 +3,18 @@ package endpoints
 import (
 	"net/http"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
 	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/localization"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/contracts/params"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/dto"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/get_product_by_id/v1/dtos"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/get_product_by_id/v1/queries"
 
 	"emperror.dev/errors"
 	"github.com/labstack/echo/v4"
-	"github.com/mehdihadeli/go-mediatr"
 )
 
 type getProductByIdEndpoint struct {
@@ -37,6 +40,8 @@ func (ep *getProductByIdEndpoint) MapEndpoint() {
 // @Accept json
 // @Produce json
 // @Param id path string true "Product ID"
+// @Param fields query string false "Sparse fieldset of the product, e.g. name,price"
+// @Param Accept-Language header string false "Preferred locales of product content"
 // @Success 200 {object} dtos.GetProductByIdResponseDto
 // @Router /api/v1/products/{id} [get]
 func (ep *getProductByIdEndpoint) handler() echo.HandlerFunc {
@@ -53,6 +58,17 @@ func (ep *getProductByIdEndpoint) handler() echo.HandlerFunc {
 			return badRequestErr
 		}
 
+		// the cached products are whole, so the sparse fieldset of a product only shapes its response
+		fields := utils.ParseFields(request.Fields)
+		if err := utils.ValidateFields[dto.ProductDto](fields); err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"query validation failed",
+			)
+
+			return validationErr
+		}
+
 		query, err := queries.NewGetProductById(request.Id)
 		if err != nil {
 			validationErr := customErrors.NewValidationErrorWrap(
@@ -63,7 +79,7 @@ func (ep *getProductByIdEndpoint) handler() echo.HandlerFunc {
 			return validationErr
 		}
 
-		queryResult, err := mediatr.Send[*queries.GetProductById, *dtos.GetProductByIdResponseDto](
+		queryResult, err := cqrs.Send[*queries.GetProductById, *dtos.GetProductByIdResponseDto](
 			ctx,
 			query,
 		)
@@ -74,6 +90,23 @@ func (ep *getProductByIdEndpoint) handler() echo.HandlerFunc {
 			)
 		}
 
+		locales := localization.ParseAcceptLanguage(
+			c.Request().Header.Get(localization.AcceptLanguageHeader),
+		)
+		c.Response().Header().Add(echo.HeaderVary, localization.AcceptLanguageHeader)
+		if locale := queryResult.Product.Localize(locales); locale != "" {
+			c.Response().Header().Set(localization.ContentLanguageHeader, locale)
+		}
+
+		if len(fields) > 0 {
+			product, err := utils.ShapeFields(queryResult.Product, fields)
+			if err != nil {
+				return errors.WithMessage(err, "error in shaping the product")
+			}
+
+			return c.JSON(http.StatusOK, &dtos.GetProductByIdShapedResponseDto{Product: product})
+		}
+
 		return c.JSON(http.StatusOK, queryResult)
 	}
 }
@@ -18,6 +18,7 @@ type GetProductByIdHandler struct {
 	log             logger.Logger
 	mongoRepository data.ProductRepository
 	redisRepository data.ProductCacheRepository
+	hotProducts     data.HotProductsRepository
 	tracer          tracing.AppTracer
 }
 
@@ -25,12 +26,14 @@ func NewGetProductByIdHandler(
 	log logger.Logger,
 	mongoRepository data.ProductRepository,
 	redisRepository data.ProductCacheRepository,
+	hotProducts data.HotProductsRepository,
 	tracer tracing.AppTracer,
 ) *GetProductByIdHandler {
 	return &GetProductByIdHandler{
 		log:             log,
 		mongoRepository: mongoRepository,
 		redisRepository: redisRepository,
+		hotProducts:     hotProducts,
 		tracer:          tracer,
 	}
 }
@@ -77,6 +80,21 @@ func (q *GetProductByIdHandler) Handle(
 		}
 	}
 
+	// products which are unpublished by their publishing schedule are not visible in the catalog
+	if product.Unpublished {
+		return nil, customErrors.NewNotFoundError(
+			fmt.Sprintf(
+				"product with id %s not found",
+				query.Id,
+			),
+		)
+	}
+
+	// the popularity of the product is only used for warming up the cache, so its failure doesn't fail the query
+	if err := q.hotProducts.RecordProductQuery(ctx, product.Id); err != nil {
+		q.log.WarnMsg(fmt.Sprintf("error in recording the query of product with id: {%s}", product.Id), err)
+	}
+
 	productDto, err := mapper.Map[*dto.ProductDto](product)
 	if err != nil {
 		return nil, customErrors.NewApplicationErrorWrap(
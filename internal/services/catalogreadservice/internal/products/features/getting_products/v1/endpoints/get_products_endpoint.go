@@ -3,16 +3,18 @@ package endpoints
 import (
 	"net/http"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
 	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/localization"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/contracts/params"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/dto"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/getting_products/v1/dtos"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/getting_products/v1/queries"
 
 	"emperror.dev/errors"
 	"github.com/labstack/echo/v4"
-	"github.com/mehdihadeli/go-mediatr"
 )
 
 type getProductsEndpoint struct {
@@ -38,6 +40,8 @@ func (ep *getProductsEndpoint) MapEndpoint() {
 // @Accept json
 // @Produce json
 // @Param getProductsRequestDto query dtos.GetProductsRequestDto false "GetProductsRequestDto"
+// @Param fields query string false "Sparse fieldset of the products, e.g. name,price"
+// @Param Accept-Language header string false "Preferred locales of product content"
 // @Success 200 {object} dtos.GetProductsResponseDto
 // @Router /api/v1/products [get]
 func (ep *getProductsEndpoint) handler() echo.HandlerFunc {
@@ -65,7 +69,16 @@ func (ep *getProductsEndpoint) handler() echo.HandlerFunc {
 		}
 		query := &queries.GetProducts{ListQuery: request.ListQuery}
 
-		queryResult, err := mediatr.Send[*queries.GetProducts, *dtos.GetProductsResponseDto](
+		if err := query.Validate(); err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"query validation failed",
+			)
+
+			return validationErr
+		}
+
+		queryResult, err := cqrs.Send[*queries.GetProducts, *dtos.GetProductsResponseDto](
 			ctx,
 			query,
 		)
@@ -76,6 +89,25 @@ func (ep *getProductsEndpoint) handler() echo.HandlerFunc {
 			)
 		}
 
+		if queryResult.Products != nil {
+			dto.LocalizeProducts(
+				queryResult.Products.Items,
+				localization.ParseAcceptLanguage(
+					c.Request().Header.Get(localization.AcceptLanguageHeader),
+				),
+			)
+		}
+		c.Response().Header().Add(echo.HeaderVary, localization.AcceptLanguageHeader)
+
+		if fields := query.GetFields(); len(fields) > 0 {
+			products, err := utils.ShapeListResult(queryResult.Products, fields)
+			if err != nil {
+				return errors.WithMessage(err, "error in shaping the products")
+			}
+
+			return c.JSON(http.StatusOK, &dto.ShapedProductsResponseDto{Products: products})
+		}
+
 		return c.JSON(http.StatusOK, queryResult)
 	}
 }
@@ -1,6 +1,9 @@
 package queries
 
-import "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils"
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/dto"
+)
 
 // Ref: https://golangbot.com/inheritance/
 
@@ -11,3 +14,12 @@ type GetProducts struct {
 func NewGetProducts(query *utils.ListQuery) *GetProducts {
 	return &GetProducts{ListQuery: query}
 }
+
+// Validate checks the sparse fieldset of the products, the fields are the fields of the ProductDto
+func (p *GetProducts) Validate() error {
+	if p.ListQuery == nil {
+		return nil
+	}
+
+	return utils.ValidateFields[dto.ProductDto](p.GetFields())
+}
@@ -0,0 +1,7 @@
+package dtos
+
+import "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/dto"
+
+type GetSearchSynonymsResponseDto struct {
+	Synonyms []*dto.SearchSynonymDto `json:"synonyms"`
+}
@@ -0,0 +1,57 @@
+package endpoints
+
+import (
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/contracts/params"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/getting_search_synonyms/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/getting_search_synonyms/v1/queries"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type getSearchSynonymsEndpoint struct {
+	params.ProductRouteParams
+}
+
+func NewGetSearchSynonymsEndpoint(
+	params params.ProductRouteParams,
+) route.Endpoint {
+	return &getSearchSynonymsEndpoint{
+		ProductRouteParams: params,
+	}
+}
+
+func (ep *getSearchSynonymsEndpoint) MapEndpoint() {
+	ep.ProductsGroup.GET("/search/synonyms", ep.handler())
+}
+
+// GetSearchSynonyms
+// @Tags Products
+// @Summary Get search synonyms
+// @Description Get the synonym dictionary of the products search
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.GetSearchSynonymsResponseDto
+// @Router /api/v1/products/search/synonyms [get]
+func (ep *getSearchSynonymsEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		queryResult, err := cqrs.Send[*queries.GetSearchSynonyms, *dtos.GetSearchSynonymsResponseDto](
+			ctx,
+			queries.NewGetSearchSynonyms(),
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending GetSearchSynonyms",
+			)
+		}
+
+		return c.JSON(http.StatusOK, queryResult)
+	}
+}
@@ -0,0 +1,7 @@
+package queries
+
+type GetSearchSynonyms struct{}
+
+func NewGetSearchSynonyms() *GetSearchSynonyms {
+	return &GetSearchSynonyms{}
+}
@@ -0,0 +1,59 @@
+package queries
+
+import (
+	"context"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mapper"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/contracts/data"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/dto"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/getting_search_synonyms/v1/dtos"
+)
+
+type GetSearchSynonymsHandler struct {
+	log               logger.Logger
+	synonymRepository data.SearchSynonymRepository
+	tracer            tracing.AppTracer
+}
+
+func NewGetSearchSynonymsHandler(
+	log logger.Logger,
+	synonymRepository data.SearchSynonymRepository,
+	tracer tracing.AppTracer,
+) *GetSearchSynonymsHandler {
+	return &GetSearchSynonymsHandler{
+		log:               log,
+		synonymRepository: synonymRepository,
+		tracer:            tracer,
+	}
+}
+
+func (c *GetSearchSynonymsHandler) Handle(
+	ctx context.Context,
+	query *GetSearchSynonyms,
+) (*dtos.GetSearchSynonymsResponseDto, error) {
+	synonyms, err := c.synonymRepository.GetAllSearchSynonyms(ctx)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in getting search synonyms in the repository",
+		)
+	}
+
+	synonymDtos, err := mapper.Map[[]*dto.SearchSynonymDto](synonyms)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in the mapping search synonyms",
+		)
+	}
+	if synonymDtos == nil {
+		synonymDtos = []*dto.SearchSynonymDto{}
+	}
+
+	c.log.Info("search synonyms fetched")
+
+	return &dtos.GetSearchSynonymsResponseDto{Synonyms: synonymDtos}, nil
+}
@@ -0,0 +1,32 @@
+package commands
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type IncreaseProductsPopularity struct {
+	OrderId string
+	// OrderedItems is the ordered quantity by product name
+	OrderedItems map[string]int64
+}
+
+func NewIncreaseProductsPopularity(
+	orderId string,
+	orderedItems map[string]int64,
+) (*IncreaseProductsPopularity, error) {
+	command := &IncreaseProductsPopularity{
+		OrderId:      orderId,
+		OrderedItems: orderedItems,
+	}
+	if err := command.Validate(); err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+func (p *IncreaseProductsPopularity) Validate() error {
+	return validation.ValidateStruct(p,
+		validation.Field(&p.OrderId, validation.Required),
+		validation.Field(&p.OrderedItems, validation.Required))
+}
@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/contracts/data"
+
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type IncreaseProductsPopularityHandler struct {
+	log             logger.Logger
+	mongoRepository data.ProductRepository
+	tracer          tracing.AppTracer
+}
+
+func NewIncreaseProductsPopularityHandler(
+	log logger.Logger,
+	mongoRepository data.ProductRepository,
+	tracer tracing.AppTracer,
+) *IncreaseProductsPopularityHandler {
+	return &IncreaseProductsPopularityHandler{
+		log:             log,
+		mongoRepository: mongoRepository,
+		tracer:          tracer,
+	}
+}
+
+func (c *IncreaseProductsPopularityHandler) Handle(
+	ctx context.Context,
+	command *IncreaseProductsPopularity,
+) (*mediatr.Unit, error) {
+	for name, quantity := range command.OrderedItems {
+		if name == "" || quantity <= 0 {
+			continue
+		}
+
+		matched, err := c.mongoRepository.IncreaseProductsPopularity(ctx, name, quantity)
+		if err != nil {
+			return nil, customErrors.NewApplicationErrorWrap(
+				err,
+				"error in increasing products popularity in the mongo repository",
+			)
+		}
+
+		if matched == 0 {
+			c.log.Infow(
+				fmt.Sprintf(
+					"no product found for ordered item '%s' of order '%s'",
+					name,
+					command.OrderId,
+				),
+				logger.Fields{"OrderId": command.OrderId},
+			)
+		}
+	}
+
+	c.log.Infow(
+		fmt.Sprintf(
+			"products popularity increased for order '%s'",
+			command.OrderId,
+		),
+		logger.Fields{"OrderId": command.OrderId},
+	)
+
+	return &mediatr.Unit{}, nil
+}
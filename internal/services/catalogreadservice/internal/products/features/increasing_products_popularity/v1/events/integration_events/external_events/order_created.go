@@ -0,0 +1,17 @@
+package externalEvents
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+)
+
+type OrderShopItem struct {
+	Title    string `json:"title"`
+	Quantity uint64 `json:"quantity"`
+}
+
+// OrderCreatedV1 is published by orders service, we only keep the fields which are needed for products popularity
+type OrderCreatedV1 struct {
+	*types.Message
+	OrderId   string           `json:"orderId"`
+	ShopItems []*OrderShopItem `json:"shopItems"`
+}
@@ -0,0 +1,95 @@
+package externalEvents
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/attribute"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/increasing_products_popularity/v1/commands"
+
+	"emperror.dev/errors"
+	"github.com/go-playground/validator"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type orderCreatedConsumer struct {
+	logger    logger.Logger
+	validator *validator.Validate
+	tracer    tracing.AppTracer
+}
+
+func NewOrderCreatedConsumer(
+	logger logger.Logger,
+	validator *validator.Validate,
+	tracer tracing.AppTracer,
+) consumer.ConsumerHandler {
+	return &orderCreatedConsumer{
+		logger:    logger,
+		validator: validator,
+		tracer:    tracer,
+	}
+}
+
+func (c *orderCreatedConsumer) Handle(
+	ctx context.Context,
+	consumeContext types.MessageConsumeContext,
+) error {
+	message, ok := consumeContext.Message().(*OrderCreatedV1)
+	if !ok {
+		return errors.New("error in casting message to OrderCreatedV1")
+	}
+
+	ctx, span := c.tracer.Start(ctx, "orderCreatedConsumer.Handle")
+	span.SetAttributes(attribute.Object("Message", consumeContext.Message()))
+	defer span.End()
+
+	orderedItems := make(map[string]int64, len(message.ShopItems))
+	for _, item := range message.ShopItems {
+		if item != nil {
+			orderedItems[item.Title] += int64(item.Quantity)
+		}
+	}
+
+	command, err := commands.NewIncreaseProductsPopularity(message.OrderId, orderedItems)
+	if err != nil {
+		validationErr := customErrors.NewValidationErrorWrap(
+			err,
+			"[orderCreatedConsumer_Consume.NewValidationErrorWrap] command validation failed",
+		)
+		c.logger.Errorf(
+			fmt.Sprintf(
+				"[orderCreatedConsumer_Consume.StructCtx] err: {%v}",
+				utils.TraceErrStatusFromSpan(span, validationErr),
+			),
+		)
+
+		return err
+	}
+
+	_, err = cqrs.Send[*commands.IncreaseProductsPopularity, *mediatr.Unit](ctx, command)
+	if err != nil {
+		err = errors.WithMessage(
+			err,
+			"[orderCreatedConsumer_Consume.Send] error in sending IncreaseProductsPopularity",
+		)
+		c.logger.Errorw(
+			fmt.Sprintf(
+				"[orderCreatedConsumer_Consume.Send] orderId: {%s}, err: {%v}",
+				command.OrderId,
+				utils.TraceErrStatusFromSpan(span, err),
+			),
+			logger.Fields{"OrderId": command.OrderId},
+		)
+
+		return err
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package queries
 
 import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/dto"
 
 	validation "github.com/go-ozzo/ozzo-validation"
 )
@@ -12,5 +13,14 @@ type SearchProducts struct {
 }
 
 func (s *SearchProducts) Validate() error {
-	return validation.ValidateStruct(s, validation.Field(&s.SearchText, validation.Required))
+	if err := validation.ValidateStruct(s, validation.Field(&s.SearchText, validation.Required)); err != nil {
+		return err
+	}
+
+	if s.ListQuery == nil {
+		return nil
+	}
+
+	// the fields of the sparse fieldset are the fields of the ProductDto
+	return utils.ValidateFields[dto.ProductDto](s.GetFields())
 }
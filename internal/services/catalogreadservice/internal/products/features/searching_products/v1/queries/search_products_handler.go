@@ -10,22 +10,26 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/contracts/data"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/dto"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/searching_products/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/searching"
 )
 
 type SearchProductsHandler struct {
 	log             logger.Logger
 	mongoRepository data.ProductRepository
+	queryBuilder    searching.SearchQueryBuilder
 	tracer          tracing.AppTracer
 }
 
 func NewSearchProductsHandler(
 	log logger.Logger,
 	repository data.ProductRepository,
+	queryBuilder searching.SearchQueryBuilder,
 	tracer tracing.AppTracer,
 ) *SearchProductsHandler {
 	return &SearchProductsHandler{
 		log:             log,
 		mongoRepository: repository,
+		queryBuilder:    queryBuilder,
 		tracer:          tracer,
 	}
 }
@@ -34,9 +38,18 @@ func (c *SearchProductsHandler) Handle(
 	ctx context.Context,
 	query *SearchProducts,
 ) (*dtos.SearchProductsResponseDto, error) {
+	// search text is expanded with the synonyms and misspellings of its terms
+	searchPattern, err := c.queryBuilder.BuildPattern(ctx, query.SearchText)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in building search pattern",
+		)
+	}
+
 	products, err := c.mongoRepository.SearchProducts(
 		ctx,
-		query.SearchText,
+		searchPattern,
 		query.ListQuery,
 	)
 	if err != nil {
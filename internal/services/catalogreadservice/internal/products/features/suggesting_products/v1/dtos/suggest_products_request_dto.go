@@ -0,0 +1,6 @@
+package dtos
+
+type SuggestProductsRequestDto struct {
+	Prefix string `query:"q"     json:"q"`
+	Limit  int    `query:"limit" json:"limit"`
+}
@@ -0,0 +1,14 @@
+package dtos
+
+type ProductSuggestionDto struct {
+	Id        string `json:"id"`
+	ProductId string `json:"productId"`
+	Name      string `json:"name"`
+	Category  string `json:"category,omitempty"`
+	// Locale is the resolved locale of Name, it is empty for the default content
+	Locale string `json:"locale,omitempty"`
+}
+
+type SuggestProductsResponseDto struct {
+	Suggestions []*ProductSuggestionDto `json:"suggestions"`
+}
@@ -0,0 +1,89 @@
+package endpoints
+
+import (
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/localization"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/contracts/params"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/suggesting_products/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/suggesting_products/v1/queries"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type suggestProductsEndpoint struct {
+	params.ProductRouteParams
+}
+
+func NewSuggestProductsEndpoint(
+	params params.ProductRouteParams,
+) route.Endpoint {
+	return &suggestProductsEndpoint{
+		ProductRouteParams: params,
+	}
+}
+
+func (ep *suggestProductsEndpoint) MapEndpoint() {
+	ep.ProductsGroup.GET("/suggestions", ep.handler())
+}
+
+// SuggestProducts
+// @Tags Products
+// @Summary Suggest products
+// @Description Typeahead suggestions of products which their names start with the prefix, boosted by popularity
+// @Accept json
+// @Produce json
+// @Param suggestProductsRequestDto query dtos.SuggestProductsRequestDto false "SuggestProductsRequestDto"
+// @Param Accept-Language header string false "Preferred locales of product content"
+// @Success 200 {object} dtos.SuggestProductsResponseDto
+// @Router /api/v1/products/suggestions [get]
+func (ep *suggestProductsEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.SuggestProductsRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		query, err := queries.NewSuggestProducts(
+			request.Prefix,
+			request.Limit,
+			localization.ParseAcceptLanguage(
+				c.Request().Header.Get(localization.AcceptLanguageHeader),
+			),
+		)
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"query validation failed",
+			)
+
+			return validationErr
+		}
+
+		queryResult, err := cqrs.Send[*queries.SuggestProducts, *dtos.SuggestProductsResponseDto](
+			ctx,
+			query,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending SuggestProducts",
+			)
+		}
+
+		c.Response().Header().Add(echo.HeaderVary, localization.AcceptLanguageHeader)
+
+		return c.JSON(http.StatusOK, queryResult)
+	}
+}
@@ -0,0 +1,40 @@
+package queries
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const (
+	defaultSuggestionsLimit = 10
+	maxSuggestionsLimit     = 50
+)
+
+type SuggestProducts struct {
+	Prefix string
+	Limit  int
+	// Locales are the preferred locales of suggested names in priority order
+	Locales []string
+}
+
+func NewSuggestProducts(prefix string, limit int, locales []string) (*SuggestProducts, error) {
+	if limit == 0 {
+		limit = defaultSuggestionsLimit
+	}
+
+	query := &SuggestProducts{
+		Prefix:  prefix,
+		Limit:   limit,
+		Locales: locales,
+	}
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	return query, nil
+}
+
+func (s *SuggestProducts) Validate() error {
+	return validation.ValidateStruct(s,
+		validation.Field(&s.Prefix, validation.Required, validation.Length(1, 100)),
+		validation.Field(&s.Limit, validation.Min(1), validation.Max(maxSuggestionsLimit)))
+}
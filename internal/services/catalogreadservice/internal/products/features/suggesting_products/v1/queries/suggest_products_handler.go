@@ -0,0 +1,77 @@
+package queries
+
+import (
+	"context"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mapper"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/contracts/data"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/dto"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/suggesting_products/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/suggestions"
+)
+
+type SuggestProductsHandler struct {
+	log             logger.Logger
+	mongoRepository data.ProductRepository
+	tracer          tracing.AppTracer
+}
+
+func NewSuggestProductsHandler(
+	log logger.Logger,
+	repository data.ProductRepository,
+	tracer tracing.AppTracer,
+) *SuggestProductsHandler {
+	return &SuggestProductsHandler{
+		log:             log,
+		mongoRepository: repository,
+		tracer:          tracer,
+	}
+}
+
+func (c *SuggestProductsHandler) Handle(
+	ctx context.Context,
+	query *SuggestProducts,
+) (*dtos.SuggestProductsResponseDto, error) {
+	result := &dtos.SuggestProductsResponseDto{Suggestions: []*dtos.ProductSuggestionDto{}}
+
+	// prefixes shorter than the indexed n-grams have no suggestion
+	prefix := suggestions.NormalizePrefix(query.Prefix)
+	if prefix == "" {
+		return result, nil
+	}
+
+	products, err := c.mongoRepository.SuggestProducts(ctx, prefix, query.Limit)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in getting product suggestions in the repository",
+		)
+	}
+
+	productDtos, err := mapper.Map[[]*dto.ProductDto](products)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in the mapping products",
+		)
+	}
+
+	dto.LocalizeProducts(productDtos, query.Locales)
+
+	for _, product := range productDtos {
+		result.Suggestions = append(result.Suggestions, &dtos.ProductSuggestionDto{
+			Id:        product.Id,
+			ProductId: product.ProductId,
+			Name:      product.Name,
+			Category:  product.Category,
+			Locale:    product.Locale,
+		})
+	}
+
+	c.log.Info("product suggestions fetched")
+
+	return result, nil
+}
@@ -3,17 +3,22 @@ package commands
 import (
 	"time"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/models"
+
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/go-ozzo/ozzo-validation/is"
 	uuid "github.com/satori/go.uuid"
 )
 
 type UpdateProduct struct {
-	ProductId   uuid.UUID
-	Name        string
-	Description string
-	Price       float64
-	UpdatedAt   time.Time
+	ProductId    uuid.UUID
+	Name         string
+	Description  string
+	Price        float64
+	Category     string
+	Attributes   map[string]interface{}
+	Translations map[string]*models.ProductTranslation
+	UpdatedAt    time.Time
 }
 
 func NewUpdateProduct(productId uuid.UUID, name string, description string, price float64) (*UpdateProduct, error) {
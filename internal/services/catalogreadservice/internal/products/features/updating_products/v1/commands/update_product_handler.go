@@ -8,6 +8,7 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/contracts/data"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/suggestions"
 
 	"github.com/mehdihadeli/go-mediatr"
 )
@@ -64,7 +65,11 @@ func (c *UpdateProductHandler) Handle(
 	product.Price = command.Price
 	product.Name = command.Name
 	product.Description = command.Description
+	product.Category = command.Category
+	product.Attributes = command.Attributes
+	product.Translations = command.Translations
 	product.UpdatedAt = command.UpdatedAt
+	product.SuggestTerms = suggestions.BuildSuggestTerms(product)
 
 	_, err = c.mongoRepository.UpdateProduct(ctx, product)
 	if err != nil {
@@ -4,13 +4,17 @@ import (
 	"time"
 
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/models"
 )
 
 type ProductUpdatedV1 struct {
 	*types.Message
-	ProductId   string    `json:"productId,omitempty"`
-	Name        string    `json:"name,omitempty"`
-	Description string    `json:"description,omitempty"`
-	Price       float64   `json:"price,omitempty"`
-	UpdatedAt   time.Time `json:"updatedAt,omitempty"`
+	ProductId    string                                `json:"productId,omitempty"`
+	Name         string                                `json:"name,omitempty"`
+	Description  string                                `json:"description,omitempty"`
+	Price        float64                               `json:"price,omitempty"`
+	Category     string                                `json:"category,omitempty"`
+	Attributes   map[string]interface{}                `json:"attributes,omitempty"`
+	Translations map[string]*models.ProductTranslation `json:"translations,omitempty"`
+	UpdatedAt    time.Time                             `json:"updatedAt,omitempty"`
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
 	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
@@ -85,8 +86,11 @@ func (c *productUpdatedConsumer) Handle(
 		)
 		return err
 	}
+	command.Category = message.Category
+	command.Attributes = message.Attributes
+	command.Translations = message.Translations
 
-	_, err = mediatr.Send[*commands.UpdateProduct, *mediatr.Unit](ctx, command)
+	_, err = cqrs.Send[*commands.UpdateProduct, *mediatr.Unit](ctx, command)
 	if err != nil {
 		err = errors.WithMessage(
 			err,
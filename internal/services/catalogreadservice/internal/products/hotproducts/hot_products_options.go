@@ -0,0 +1,27 @@
+package hotproducts
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[HotProductsOptions]())
+
+// HotProductsOptions controls the popularity list of the products which is maintained from their queries and the
+// warm-up of the product cache with it.
+type HotProductsOptions struct {
+	// WarmUpEnabled loads the hot products into the cache before the service becomes ready
+	WarmUpEnabled bool `mapstructure:"warmUpEnabled" default:"true"`
+	// WarmUpCount is the number of the most queried products which are loaded into the cache
+	WarmUpCount int `mapstructure:"warmUpCount"   default:"200"`
+	// TrackedCount is the number of the products which are kept in the popularity list, the least queried products are
+	// removed from it, so the list is bounded
+	TrackedCount int `mapstructure:"trackedCount"  default:"5000"`
+}
+
+func NewHotProductsOptions(environment environment.Environment) (*HotProductsOptions, error) {
+	return config.BindConfigKey[*HotProductsOptions](optionName, environment)
+}
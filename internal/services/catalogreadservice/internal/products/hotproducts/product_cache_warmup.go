@@ -0,0 +1,82 @@
+package hotproducts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/contracts/data"
+
+	"emperror.dev/errors"
+)
+
+// ProductCacheWarmUp loads the most queried products from the database into the product cache, so the queries after a
+// deployment or a flush of the cache don't miss it
+type ProductCacheWarmUp struct {
+	options                *HotProductsOptions
+	productRepository      data.ProductRepository
+	productCacheRepository data.ProductCacheRepository
+	hotProductsRepository  data.HotProductsRepository
+	logger                 logger.Logger
+}
+
+func NewProductCacheWarmUp(
+	options *HotProductsOptions,
+	productRepository data.ProductRepository,
+	productCacheRepository data.ProductCacheRepository,
+	hotProductsRepository data.HotProductsRepository,
+	logger logger.Logger,
+) *ProductCacheWarmUp {
+	return &ProductCacheWarmUp{
+		options:                options,
+		productRepository:      productRepository,
+		productCacheRepository: productCacheRepository,
+		hotProductsRepository:  hotProductsRepository,
+		logger:                 logger,
+	}
+}
+
+func (w *ProductCacheWarmUp) Name() string {
+	return "hot-products"
+}
+
+func (w *ProductCacheWarmUp) WarmUp(ctx context.Context) error {
+	if !w.options.WarmUpEnabled || w.options.WarmUpCount <= 0 {
+		return nil
+	}
+
+	ids, err := w.hotProductsRepository.GetHotProductIds(ctx, w.options.WarmUpCount)
+	if err != nil {
+		return errors.WrapIf(err, "[ProductCacheWarmUp_WarmUp.GetHotProductIds] error in getting the hot products")
+	}
+
+	var loaded int
+	for _, id := range ids {
+		product, err := w.productRepository.GetProductById(ctx, id)
+		if err != nil {
+			return errors.WrapIf(
+				err,
+				fmt.Sprintf("[ProductCacheWarmUp_WarmUp.GetProductById] error in getting product with id %s", id),
+			)
+		}
+
+		// the deleted products stay in the popularity list until they are pushed out of it
+		if product == nil {
+			continue
+		}
+
+		err = w.productCacheRepository.PutProduct(ctx, product.Id, product)
+		if err != nil {
+			return errors.WrapIf(
+				err,
+				fmt.Sprintf("[ProductCacheWarmUp_WarmUp.PutProduct] error in caching product with id %s", id),
+			)
+		}
+
+		loaded++
+	}
+
+	w.logger.Infof("%d hot products are loaded into the product cache", loaded)
+
+	return nil
+}
@@ -6,13 +6,58 @@ import (
 
 type Product struct {
 	// we generate id ourselves because auto generate mongo string id column with type _id is not an uuid
-	Id          string    `json:"id"                    bson:"_id,omitempty"` // https://www.mongodb.com/docs/drivers/go/current/fundamentals/crud/write-operations/insert/#the-_id-field
-	ProductId   string    `json:"productId"             bson:"productId"`
-	Name        string    `json:"name,omitempty"        bson:"name,omitempty"`
-	Description string    `json:"description,omitempty" bson:"description,omitempty"`
-	Price       float64   `json:"price,omitempty"       bson:"price,omitempty"`
-	CreatedAt   time.Time `json:"createdAt,omitempty"   bson:"createdAt,omitempty"`
-	UpdatedAt   time.Time `json:"updatedAt,omitempty"   bson:"updatedAt,omitempty"`
+	Id          string                 `json:"id"                    bson:"_id,omitempty"` // https://www.mongodb.com/docs/drivers/go/current/fundamentals/crud/write-operations/insert/#the-_id-field
+	ProductId   string                 `json:"productId"             bson:"productId"`
+	Name        string                 `json:"name,omitempty"        bson:"name,omitempty"`
+	Description string                 `json:"description,omitempty" bson:"description,omitempty"`
+	Price       float64                `json:"price,omitempty"       bson:"price,omitempty"`
+	Category    string                 `json:"category,omitempty"   bson:"category,omitempty"`
+	Attributes  map[string]interface{} `json:"attributes,omitempty" bson:"attributes,omitempty"`
+	// Translations keeps localized name and description by locale, Name and Description are used as fallback
+	Translations map[string]*ProductTranslation `json:"translations,omitempty" bson:"translations,omitempty"`
+	// Unpublished hides the product from the catalog, we keep a negative flag so products projected before having
+	// publishing schedules remain visible
+	Unpublished bool       `json:"unpublished,omitempty" bson:"unpublished"`
+	PublishAt   *time.Time `json:"publishAt,omitempty"   bson:"publishAt"`
+	UnpublishAt *time.Time `json:"unpublishAt,omitempty" bson:"unpublishAt"`
+	// IsPinned and SortOrder are the manual merchandising of the product in its category, they are not omitted on
+	// update, so unpinning the product or clearing its sort order is projected too
+	IsPinned  bool `json:"isPinned,omitempty"  bson:"isPinned"`
+	SortOrder int  `json:"sortOrder,omitempty" bson:"sortOrder"`
+	// MerchandisingScore orders the products ahead of the default order of list and search, it is nil for the products
+	// without pinning and sort order, so they sort like the products projected before merchandising
+	MerchandisingScore *int64 `json:"-" bson:"merchandisingScore"`
+	// SuggestTerms are edge n-grams of the product names which back the typeahead suggestions
+	SuggestTerms []string `json:"-"                    bson:"suggestTerms,omitempty"`
+	// Popularity is the number of ordered items of the product and boosts it in suggestions
+	Popularity int64     `json:"popularity,omitempty" bson:"popularity,omitempty"`
+	CreatedAt  time.Time `json:"createdAt,omitempty"   bson:"createdAt,omitempty"`
+	UpdatedAt  time.Time `json:"updatedAt,omitempty"   bson:"updatedAt,omitempty"`
+	// SchemaVersion is the version of the shape of the product document, it is stamped by the repository with the
+	// current version of the ProductMigrator
+	SchemaVersion int `json:"-" bson:"schemaVersion"`
+}
+
+// SetMerchandising sets the pinning and the manual sort order of the product and its merchandising score, pinned
+// products are scored above the products with only a sort order, and lower sort orders are scored higher
+func (p *Product) SetMerchandising(isPinned bool, sortOrder int) {
+	p.IsPinned = isPinned
+	p.SortOrder = sortOrder
+
+	var score int64
+	if isPinned {
+		score += 1 << 32
+	}
+	if sortOrder > 0 {
+		score += 1<<31 - int64(sortOrder)
+	}
+
+	if score == 0 {
+		p.MerchandisingScore = nil
+
+		return
+	}
+	p.MerchandisingScore = &score
 }
 
 type ProductsList struct {
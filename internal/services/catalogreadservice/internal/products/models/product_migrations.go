@@ -0,0 +1,32 @@
+package models
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mongodb/documentmigration"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ProductMigrator upgrades the product documents to the current shape of the Product on read and in the backfill, a
+// change of the shape of the products adds a migration with the next version instead of replaying the projection
+var ProductMigrator = documentmigration.NewDocumentMigrator( //nolint:gochecknoglobals
+	&documentmigration.DocumentMigration{
+		Version:     1,
+		Description: "set the publishing and the merchandising fields of the products projected before them",
+		Migrate: func(document bson.M) error {
+			for field, value := range map[string]interface{}{"unpublished": false, "isPinned": false, "sortOrder": 0} {
+				if _, ok := document[field]; !ok {
+					document[field] = value
+				}
+			}
+
+			return nil
+		},
+	},
+)
+
+// UnmarshalBSON runs the pending migrations of the product document before decoding it
+func (p *Product) UnmarshalBSON(data []byte) error {
+	type product Product
+
+	return ProductMigrator.Unmarshal(data, (*product)(p))
+}
@@ -0,0 +1,7 @@
+package models
+
+// ProductTranslation holds localized content of a product for a locale
+type ProductTranslation struct {
+	Name        string `json:"name"        bson:"name"`
+	Description string `json:"description" bson:"description"`
+}
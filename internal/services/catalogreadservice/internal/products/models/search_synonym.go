@@ -0,0 +1,12 @@
+package models
+
+import (
+	"time"
+)
+
+// SearchSynonym is a group of equivalent search terms, searching any of the terms matches products containing the others
+type SearchSynonym struct {
+	Id        string    `json:"id"                  bson:"_id,omitempty"`
+	Terms     []string  `json:"terms"               bson:"terms"`
+	CreatedAt time.Time `json:"createdAt,omitempty" bson:"createdAt,omitempty"`
+}
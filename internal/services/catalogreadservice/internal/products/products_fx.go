@@ -3,10 +3,21 @@ package products
 import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/contracts"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mongodb/documentmigration"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/warmup"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/backoffice"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/data/repositories"
+	backfillSuggestTermsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/backfilling_suggest_terms/v1/endpoints"
+	createSearchSynonymV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/creating_search_synonyms/v1/endpoints"
+	deleteSearchSynonymV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/deleting_search_synonyms/v1/endpoints"
 	getProductByIdV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/get_product_by_id/v1/endpoints"
 	getProductsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/getting_products/v1/endpoints"
+	getSearchSynonymsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/getting_search_synonyms/v1/endpoints"
 	searchProductV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/searching_products/v1/endpoints"
+	suggestProductsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/features/suggesting_products/v1/endpoints"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/hotproducts"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/searching"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/shadowing"
 
 	"github.com/labstack/echo/v4"
 	"go.uber.org/fx"
@@ -17,7 +28,25 @@ var Module = fx.Module(
 
 	// Other provides
 	fx.Provide(repositories.NewRedisProductRepository),
-	fx.Provide(repositories.NewMongoProductRepository),
+	fx.Provide(hotproducts.NewHotProductsOptions),
+	fx.Provide(repositories.NewRedisHotProductsRepository),
+	fx.Provide(warmup.AsCacheWarmUp(hotproducts.NewProductCacheWarmUp)),
+	fx.Provide(fx.Annotate(
+		repositories.NewMongoProductRepository,
+		fx.ResultTags(`name:"primary-product-repository"`),
+	)),
+	fx.Provide(shadowing.NewShadowReadOptions),
+	fx.Provide(fx.Annotate(
+		shadowing.ProvideProductRepository,
+		fx.ParamTags(`name:"primary-product-repository"`),
+	)),
+	fx.Invoke(shadowing.RegisterCandidateIndexes),
+	fx.Invoke(repositories.RegisterMongoProductIndexes),
+	fx.Provide(documentmigration.AsCollectionMigrator(repositories.NewProductCollectionMigrator)),
+	fx.Provide(repositories.NewMongoSearchSynonymRepository),
+	fx.Provide(searching.NewSearchOptions),
+	fx.Provide(searching.NewSearchQueryBuilder),
+	fx.Provide(backoffice.NewBackOfficeOptions),
 
 	fx.Provide(fx.Annotate(func(catalogsServer contracts.EchoHttpServer) *echo.Group {
 		var g *echo.Group
@@ -28,10 +57,18 @@ var Module = fx.Module(
 
 		return g
 	}, fx.ResultTags(`name:"product-echo-group"`))),
+	fx.Provide(
+		fx.Annotate(backoffice.NewBackOfficeProductsGroup, fx.ResultTags(`name:"backoffice-product-echo-group"`)),
+	),
 
 	fx.Provide(
 		route.AsRoute(getProductsV1.NewGetProductsEndpoint, "product-routes"),
 		route.AsRoute(searchProductV1.NewSearchProductsEndpoint, "product-routes"),
 		route.AsRoute(getProductByIdV1.NewGetProductByIdEndpoint, "product-routes"),
+		route.AsRoute(suggestProductsV1.NewSuggestProductsEndpoint, "product-routes"),
+		route.AsRoute(backfillSuggestTermsV1.NewBackfillSuggestTermsEndpoint, "product-routes"),
+		route.AsRoute(createSearchSynonymV1.NewCreateSearchSynonymEndpoint, "product-routes"),
+		route.AsRoute(getSearchSynonymsV1.NewGetSearchSynonymsEndpoint, "product-routes"),
+		route.AsRoute(deleteSearchSynonymV1.NewDeleteSearchSynonymEndpoint, "product-routes"),
 	),
 )
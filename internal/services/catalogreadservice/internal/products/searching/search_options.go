@@ -0,0 +1,25 @@
+package searching
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[SearchOptions]())
+
+// SearchOptions controls how the search text of the products search is expanded at query time.
+type SearchOptions struct {
+	// FuzzyMatching matches the search terms with a single typo, missing, extra or swapped character
+	FuzzyMatching bool `mapstructure:"fuzzyMatching"`
+	// MinFuzzyTermLength is the shortest term which is matched fuzzily, shorter terms match too many products
+	MinFuzzyTermLength int `mapstructure:"minFuzzyTermLength"   default:"4"`
+	// SynonymsCacheSeconds is the lifetime of the in-memory synonym dictionary before reloading it from the database
+	SynonymsCacheSeconds int `mapstructure:"synonymsCacheSeconds" default:"30"`
+}
+
+func NewSearchOptions(environment environment.Environment) (*SearchOptions, error) {
+	return config.BindConfigKey[*SearchOptions](optionName, environment)
+}
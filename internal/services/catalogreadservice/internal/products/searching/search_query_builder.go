@@ -0,0 +1,190 @@
+package searching
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/contracts/data"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/suggestions"
+
+	"emperror.dev/errors"
+)
+
+// wordSeparator matches the separators between the words of a matched phrase, like spaces or dashes
+const wordSeparator = `\W+`
+
+// SearchQueryBuilder expands the search text with the synonym dictionary and fuzzy variants of its terms.
+type SearchQueryBuilder interface {
+	// BuildPattern returns a case-insensitive regex pattern which matches the search text, its synonyms and its
+	// misspellings
+	BuildPattern(ctx context.Context, searchText string) (string, error)
+	// InvalidateSynonyms drops the cached synonym dictionary, so the next search reloads it
+	InvalidateSynonyms()
+}
+
+type searchQueryBuilder struct {
+	options           *SearchOptions
+	synonymRepository data.SearchSynonymRepository
+	log               logger.Logger
+
+	mu         sync.RWMutex
+	synonyms   map[string][]string
+	loadedAt   time.Time
+	cacheValid bool
+}
+
+func NewSearchQueryBuilder(
+	options *SearchOptions,
+	synonymRepository data.SearchSynonymRepository,
+	log logger.Logger,
+) SearchQueryBuilder {
+	return &searchQueryBuilder{
+		options:           options,
+		synonymRepository: synonymRepository,
+		log:               log,
+	}
+}
+
+func (b *searchQueryBuilder) BuildPattern(ctx context.Context, searchText string) (string, error) {
+	// the raw text is always an alternative, so product ids and punctuated names keep matching
+	alternatives := []string{regexp.QuoteMeta(strings.TrimSpace(searchText))}
+
+	normalized := suggestions.NormalizeTerm(searchText)
+	if normalized == "" {
+		return "(?i)" + alternatives[0], nil
+	}
+
+	synonyms, err := b.loadSynonyms(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// a whole phrase can be a synonym too, like `running shoes` for `sneakers`
+	for _, synonym := range synonyms[normalized] {
+		alternatives = append(alternatives, phrasePattern(synonym))
+	}
+
+	words := strings.Fields(normalized)
+	wordPatterns := make([]string, 0, len(words))
+	for _, word := range words {
+		variants := []string{regexp.QuoteMeta(word)}
+		for _, synonym := range synonyms[word] {
+			variants = append(variants, phrasePattern(synonym))
+		}
+		if b.options.FuzzyMatching && len([]rune(word)) >= b.options.MinFuzzyTermLength {
+			variants = append(variants, FuzzyVariants(word)...)
+		}
+		wordPatterns = append(wordPatterns, "(?:"+strings.Join(variants, "|")+")")
+	}
+	alternatives = append(alternatives, strings.Join(wordPatterns, wordSeparator))
+
+	return "(?i)(?:" + strings.Join(alternatives, "|") + ")", nil
+}
+
+func (b *searchQueryBuilder) InvalidateSynonyms() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.cacheValid = false
+}
+
+// loadSynonyms returns the synonym dictionary, keyed by normalized term, from the cache or the database
+func (b *searchQueryBuilder) loadSynonyms(ctx context.Context) (map[string][]string, error) {
+	ttl := time.Duration(b.options.SynonymsCacheSeconds) * time.Second
+
+	b.mu.RLock()
+	if b.cacheValid && time.Since(b.loadedAt) < ttl {
+		synonyms := b.synonyms
+		b.mu.RUnlock()
+
+		return synonyms, nil
+	}
+	b.mu.RUnlock()
+
+	groups, err := b.synonymRepository.GetAllSearchSynonyms(ctx)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in loading search synonyms")
+	}
+
+	synonyms := make(map[string][]string)
+	for _, group := range groups {
+		terms := NormalizeTerms(group.Terms)
+		for _, term := range terms {
+			for _, other := range terms {
+				if other != term {
+					synonyms[term] = append(synonyms[term], other)
+				}
+			}
+		}
+	}
+
+	b.mu.Lock()
+	b.synonyms = synonyms
+	b.loadedAt = time.Now()
+	b.cacheValid = true
+	b.mu.Unlock()
+
+	b.log.Infow("search synonyms dictionary loaded", logger.Fields{"Terms": len(synonyms)})
+
+	return synonyms, nil
+}
+
+// NormalizeTerms normalizes the terms of a synonym group and drops empty and duplicate terms
+func NormalizeTerms(terms []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+
+	for _, term := range terms {
+		normalized := suggestions.NormalizeTerm(term)
+		if normalized == "" || seen[normalized] {
+			continue
+		}
+		seen[normalized] = true
+		result = append(result, normalized)
+	}
+
+	return result
+}
+
+// FuzzyVariants returns the patterns matching the word with one edit, a substituted, deleted, inserted or two swapped
+// characters.
+func FuzzyVariants(word string) []string {
+	runes := []rune(word)
+	quote := func(rs []rune) string {
+		return regexp.QuoteMeta(string(rs))
+	}
+
+	var variants []string
+	for i := 0; i <= len(runes); i++ {
+		prefix, rest := quote(runes[:i]), runes[i:]
+
+		// insertion
+		variants = append(variants, prefix+"."+quote(rest))
+		if i == len(runes) {
+			break
+		}
+
+		// substitution and deletion
+		variants = append(variants, prefix+"."+quote(rest[1:]), prefix+quote(rest[1:]))
+
+		// transposition
+		if i+1 < len(runes) && runes[i] != runes[i+1] {
+			variants = append(variants, prefix+quote([]rune{runes[i+1], runes[i]})+quote(rest[2:]))
+		}
+	}
+
+	return variants
+}
+
+func phrasePattern(phrase string) string {
+	words := strings.Fields(phrase)
+	for i, word := range words {
+		words[i] = regexp.QuoteMeta(word)
+	}
+
+	return strings.Join(words, wordSeparator)
+}
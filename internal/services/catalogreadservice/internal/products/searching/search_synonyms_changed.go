@@ -0,0 +1,55 @@
+package searching
+
+import (
+	"context"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/producer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// SearchSynonymsChangedV1 is broadcast to all the instances of the service after the synonyms change, so each instance
+// drops its cached synonym dictionary
+type SearchSynonymsChangedV1 struct {
+	*types.Message
+	ChangedAt time.Time `json:"changedAt"`
+}
+
+// PublishSearchSynonymsChanged publishes the change of the synonyms to the other instances, a failed publish is only
+// logged because the cached synonyms of the other instances still expire after `SynonymsCacheSeconds`
+func PublishSearchSynonymsChanged(ctx context.Context, messageProducer producer.Producer, log logger.Logger) {
+	if messageProducer == nil {
+		return
+	}
+
+	message := &SearchSynonymsChangedV1{
+		Message:   types.NewMessage(uuid.NewV4().String()),
+		ChangedAt: time.Now(),
+	}
+
+	if err := messageProducer.PublishMessage(ctx, message, nil); err != nil {
+		log.Warnf("error in publishing the change of the search synonyms to the other instances: %v", err)
+	}
+}
+
+type searchSynonymsChangedConsumer struct {
+	queryBuilder SearchQueryBuilder
+	log          logger.Logger
+}
+
+// NewSearchSynonymsChangedConsumer drops the cached synonyms of the instance, it is consumed in the broadcast mode so
+// every instance receives the change
+func NewSearchSynonymsChangedConsumer(queryBuilder SearchQueryBuilder, log logger.Logger) consumer.ConsumerHandler {
+	return &searchSynonymsChangedConsumer{queryBuilder: queryBuilder, log: log}
+}
+
+func (c *searchSynonymsChangedConsumer) Handle(_ context.Context, _ types.MessageConsumeContext) error {
+	c.queryBuilder.InvalidateSynonyms()
+	c.log.Info("cached search synonyms are invalidated")
+
+	return nil
+}
@@ -0,0 +1,252 @@
+package shadowing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mongodb"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/shadow"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/contracts/data"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/data/repositories"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/models"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/fx"
+)
+
+type shadowProductRepository struct {
+	primary      data.ProductRepository
+	candidate    data.ProductRepository
+	mirror       *shadow.Mirror
+	mirrorWrites bool
+	log          logger.Logger
+}
+
+// NewShadowProductRepository serves the products from the primary repository and mirrors the queries to the candidate
+// repository, the candidate results are compared with the served results by the mirror
+func NewShadowProductRepository(
+	primary data.ProductRepository,
+	candidate data.ProductRepository,
+	mirror *shadow.Mirror,
+	mirrorWrites bool,
+	log logger.Logger,
+) data.ProductRepository {
+	return &shadowProductRepository{
+		primary:      primary,
+		candidate:    candidate,
+		mirror:       mirror,
+		mirrorWrites: mirrorWrites,
+		log:          log,
+	}
+}
+
+// ProvideProductRepository provides the shadow repository of the primary repository when the shadow reads are enabled,
+// the candidate is the mongo repository of the candidate collection
+func ProvideProductRepository(
+	repository data.ProductRepository,
+	options *ShadowReadOptions,
+	cfg *config.Config,
+	db *mongo.Client,
+	mongoOptions *mongodb.MongoDbOptions,
+	tracer tracing.AppTracer,
+	meter metric.Meter,
+	log logger.Logger,
+) (data.ProductRepository, error) {
+	if !options.Enabled {
+		return repository, nil
+	}
+
+	mirror, err := shadow.NewMirror(
+		fmt.Sprintf("%s_products", cfg.AppOptions.ServiceName),
+		shadow.Options{SampleRate: options.SampleRate, Timeout: options.Timeout, MaxDiffs: options.MaxDiffs},
+		log,
+		meter,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	candidate := repositories.NewMongoProductRepositoryWithCollection(
+		log,
+		db,
+		mongoOptions,
+		tracer,
+		options.CandidateCollection,
+	)
+
+	log.Infof("products queries are mirrored to the '%s' candidate collection", options.CandidateCollection)
+
+	return NewShadowProductRepository(repository, candidate, mirror, options.MirrorWrites, log), nil
+}
+
+// RegisterCandidateIndexes creates the products indexes of the candidate collection on application start
+func RegisterCandidateIndexes(
+	lc fx.Lifecycle,
+	options *ShadowReadOptions,
+	db *mongo.Client,
+	mongoOptions *mongodb.MongoDbOptions,
+) {
+	if !options.Enabled {
+		return
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			return repositories.CreateMongoProductIndexes(ctx, db, mongoOptions, options.CandidateCollection)
+		},
+	})
+}
+
+func (s *shadowProductRepository) GetAllProducts(
+	ctx context.Context,
+	listQuery *utils.ListQuery,
+) (*utils.ListResult[*models.Product], error) {
+	result, err := s.primary.GetAllProducts(ctx, listQuery)
+
+	shadow.Compare(
+		ctx,
+		s.mirror,
+		"GetAllProducts",
+		result,
+		err,
+		func(ctx context.Context) (*utils.ListResult[*models.Product], error) {
+			return s.candidate.GetAllProducts(ctx, listQuery)
+		},
+	)
+
+	return result, err
+}
+
+func (s *shadowProductRepository) SearchProducts(
+	ctx context.Context,
+	searchText string,
+	listQuery *utils.ListQuery,
+) (*utils.ListResult[*models.Product], error) {
+	result, err := s.primary.SearchProducts(ctx, searchText, listQuery)
+
+	shadow.Compare(
+		ctx,
+		s.mirror,
+		"SearchProducts",
+		result,
+		err,
+		func(ctx context.Context) (*utils.ListResult[*models.Product], error) {
+			return s.candidate.SearchProducts(ctx, searchText, listQuery)
+		},
+	)
+
+	return result, err
+}
+
+func (s *shadowProductRepository) SuggestProducts(
+	ctx context.Context,
+	prefix string,
+	limit int,
+) ([]*models.Product, error) {
+	result, err := s.primary.SuggestProducts(ctx, prefix, limit)
+
+	shadow.Compare(ctx, s.mirror, "SuggestProducts", result, err, func(ctx context.Context) ([]*models.Product, error) {
+		return s.candidate.SuggestProducts(ctx, prefix, limit)
+	})
+
+	return result, err
+}
+
+func (s *shadowProductRepository) GetProductById(ctx context.Context, uuid string) (*models.Product, error) {
+	result, err := s.primary.GetProductById(ctx, uuid)
+
+	shadow.Compare(ctx, s.mirror, "GetProductById", result, err, func(ctx context.Context) (*models.Product, error) {
+		return s.candidate.GetProductById(ctx, uuid)
+	})
+
+	return result, err
+}
+
+func (s *shadowProductRepository) GetProductByProductId(ctx context.Context, uuid string) (*models.Product, error) {
+	result, err := s.primary.GetProductByProductId(ctx, uuid)
+
+	shadow.Compare(
+		ctx,
+		s.mirror,
+		"GetProductByProductId",
+		result,
+		err,
+		func(ctx context.Context) (*models.Product, error) {
+			return s.candidate.GetProductByProductId(ctx, uuid)
+		},
+	)
+
+	return result, err
+}
+
+// IterateProducts is used by the bulk flows, so it isn't mirrored
+func (s *shadowProductRepository) IterateProducts(
+	ctx context.Context,
+	fn func(product *models.Product) error,
+) error {
+	return s.primary.IterateProducts(ctx, fn)
+}
+
+func (s *shadowProductRepository) IncreaseProductsPopularity(
+	ctx context.Context,
+	name string,
+	count int64,
+) (int64, error) {
+	matched, err := s.primary.IncreaseProductsPopularity(ctx, name, count)
+	if err == nil && s.mirrorWrites {
+		_, candidateErr := s.candidate.IncreaseProductsPopularity(ctx, name, count)
+		s.logCandidateWriteError("IncreaseProductsPopularity", candidateErr)
+	}
+
+	return matched, err
+}
+
+func (s *shadowProductRepository) CreateProduct(
+	ctx context.Context,
+	product *models.Product,
+) (*models.Product, error) {
+	result, err := s.primary.CreateProduct(ctx, product)
+	if err == nil && s.mirrorWrites {
+		_, candidateErr := s.candidate.CreateProduct(ctx, product)
+		s.logCandidateWriteError("CreateProduct", candidateErr)
+	}
+
+	return result, err
+}
+
+func (s *shadowProductRepository) UpdateProduct(
+	ctx context.Context,
+	product *models.Product,
+) (*models.Product, error) {
+	result, err := s.primary.UpdateProduct(ctx, product)
+	if err == nil && s.mirrorWrites {
+		_, candidateErr := s.candidate.UpdateProduct(ctx, product)
+		s.logCandidateWriteError("UpdateProduct", candidateErr)
+	}
+
+	return result, err
+}
+
+func (s *shadowProductRepository) DeleteProductByID(ctx context.Context, uuid string) error {
+	err := s.primary.DeleteProductByID(ctx, uuid)
+	if err == nil && s.mirrorWrites {
+		s.logCandidateWriteError("DeleteProductByID", s.candidate.DeleteProductByID(ctx, uuid))
+	}
+
+	return err
+}
+
+// logCandidateWriteError logs the failed candidate writes, they don't fail the projection because the primary
+// repository is the source of the served results
+func (s *shadowProductRepository) logCandidateWriteError(operation string, err error) {
+	if err == nil {
+		return
+	}
+
+	s.log.Warnf("[shadowProductRepository.%s] error in mirroring write to candidate repository, err: %v", operation, err)
+}
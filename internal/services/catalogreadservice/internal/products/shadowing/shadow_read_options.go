@@ -0,0 +1,34 @@
+package shadowing
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[ShadowReadOptions]())
+
+// ShadowReadOptions controls mirroring the products queries to a candidate read storage, the queries are always served
+// from the current storage and the differences of the candidate results are logged and counted.
+type ShadowReadOptions struct {
+	Enabled bool `mapstructure:"enabled"`
+	// CandidateCollection is the mongo collection of the candidate read model
+	CandidateCollection string `mapstructure:"candidateCollection" default:"products_candidate"`
+	// MirrorWrites applies the writes of the projections to the candidate too, it should be disabled when the
+	// candidate is filled by its own projection
+	MirrorWrites bool `mapstructure:"mirrorWrites"`
+	// SampleRate is the ratio of the queries which are mirrored, between 0 and 1
+	SampleRate float64 `mapstructure:"sampleRate" default:"1"`
+	// Timeout is the max duration of a mirrored query
+	Timeout time.Duration `mapstructure:"timeout" default:"5s"`
+	// MaxDiffs is the max number of the logged differences of a mismatched result
+	MaxDiffs int `mapstructure:"maxDiffs" default:"10"`
+}
+
+func NewShadowReadOptions(environment environment.Environment) (*ShadowReadOptions, error) {
+	return config.BindConfigKey[*ShadowReadOptions](optionName, environment)
+}
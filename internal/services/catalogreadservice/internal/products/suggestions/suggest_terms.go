@@ -0,0 +1,97 @@
+package suggestions
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/models"
+)
+
+const (
+	// MinPrefixLength is the shortest prefix which is indexed and can be used for suggestions
+	MinPrefixLength = 2
+	// MaxPrefixLength is the longest indexed prefix, longer search terms are truncated to this length
+	MaxPrefixLength = 20
+)
+
+// NormalizeTerm lower-cases the text, drops punctuation and collapses whitespaces, so typed prefixes and indexed terms
+// are comparable.
+func NormalizeTerm(text string) string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	return strings.Join(fields, " ")
+}
+
+// NormalizePrefix normalizes a typed prefix and truncates it to MaxPrefixLength, it returns an empty string for
+// prefixes shorter than MinPrefixLength.
+func NormalizePrefix(prefix string) string {
+	normalized := []rune(NormalizeTerm(prefix))
+	if len(normalized) < MinPrefixLength {
+		return ""
+	}
+
+	if len(normalized) > MaxPrefixLength {
+		normalized = normalized[:MaxPrefixLength]
+	}
+
+	return strings.TrimSpace(string(normalized))
+}
+
+// EdgeNGrams returns the prefixes of the whole text and of each word in it, between MinPrefixLength and MaxPrefixLength.
+// Whole text prefixes make multi word prefixes like `red sh` match `red shoes`.
+func EdgeNGrams(text string) []string {
+	normalized := NormalizeTerm(text)
+	if normalized == "" {
+		return nil
+	}
+
+	var grams []string
+	grams = appendPrefixes(grams, normalized)
+	for _, word := range strings.Fields(normalized) {
+		grams = appendPrefixes(grams, word)
+	}
+
+	return grams
+}
+
+// BuildSuggestTerms builds the distinct n-grams of the product name and its translated names
+func BuildSuggestTerms(product *models.Product) []string {
+	if product == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var terms []string
+
+	add := func(text string) {
+		for _, gram := range EdgeNGrams(text) {
+			if !seen[gram] {
+				seen[gram] = true
+				terms = append(terms, gram)
+			}
+		}
+	}
+
+	add(product.Name)
+	for _, translation := range product.Translations {
+		if translation != nil {
+			add(translation.Name)
+		}
+	}
+
+	return terms
+}
+
+func appendPrefixes(grams []string, text string) []string {
+	runes := []rune(text)
+	for i := MinPrefixLength; i <= len(runes) && i <= MaxPrefixLength; i++ {
+		prefix := strings.TrimSpace(string(runes[:i]))
+		if len([]rune(prefix)) == i {
+			grams = append(grams, prefix)
+		}
+	}
+
+	return grams
+}
@@ -4,8 +4,12 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/deprecation"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/featuretoggle"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/fxapp/contracts"
 	echocontracts "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/contracts"
+	deprecationmiddleware "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/deprecation"
+	featuretogglemiddleware "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/feature_toggle"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/config"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/configurations"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/shared/configurations/catalogs/infrastructure"
@@ -46,8 +50,17 @@ func (ic *CatalogsServiceConfigurator) ConfigureCatalogs() {
 func (ic *CatalogsServiceConfigurator) MapCatalogsEndpoints() {
 	// Shared
 	ic.ResolveFunc(
-		func(catalogsServer echocontracts.EchoHttpServer, cfg *config.Config) error {
+		func(
+			catalogsServer echocontracts.EchoHttpServer,
+			cfg *config.Config,
+			toggles featuretoggle.FeatureToggles,
+			deprecations deprecation.Registry,
+		) error {
 			catalogsServer.SetupDefaultMiddlewares()
+			catalogsServer.AddMiddlewares(
+				featuretogglemiddleware.FeatureToggle(toggles),
+				deprecationmiddleware.Deprecation(deprecations),
+			)
 
 			// config catalogs root endpoint
 			catalogsServer.RouteBuilder().
@@ -10,11 +10,14 @@ import (
 
 func (ic *CatalogsServiceConfigurator) configSwagger(routeBuilder *customEcho.RouteBuilder) {
 	// https://github.com/swaggo/swag#how-to-use-it-with-gin
-	docs.SwaggerInfo.Version = "1.0"
-	docs.SwaggerInfo.Title = "Catalogs Read-Service Api"
-	docs.SwaggerInfo.Description = "Catalogs Read-Service Api."
+	docs.SwaggerInfocatalogreadservice.Version = "1.0"
+	docs.SwaggerInfocatalogreadservice.Title = "Catalogs Read-Service Api"
+	docs.SwaggerInfocatalogreadservice.Description = "Catalogs Read-Service Api."
 
 	routeBuilder.RegisterRoutes(func(e *echo.Echo) {
-		e.GET("/swagger/*", echoSwagger.WrapHandler)
+		e.GET(
+			"/swagger/*",
+			echoSwagger.EchoWrapHandler(echoSwagger.InstanceName(docs.SwaggerInfocatalogreadservice.InstanceName())),
+		)
 	})
 }
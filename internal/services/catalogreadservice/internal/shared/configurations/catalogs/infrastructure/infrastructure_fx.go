@@ -2,17 +2,32 @@ package infrastructure
 
 import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/deduplication"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/inbox"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/pipeline"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/quarantine"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer/schemaregistry"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/deprecation"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/diagnostics"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/featuretoggle"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/grpc"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/health"
 	customEcho "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	loggingpipelines "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/pipelines"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/messagebroker"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mongodb"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mongodb/documentmigration"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/metrics"
+	messagingmetricspipelines "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/metrics/messaging/pipeline"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
-	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/configurations"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/deadletter"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/redis"
+	validationpipeline "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/validation/pipeline"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/warmup"
 	rabbitmq2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/configurations/rabbitmq"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogreadservice/internal/products/searching"
 
 	"github.com/go-playground/validator"
 	"go.uber.org/fx"
@@ -23,18 +38,64 @@ var Module = fx.Module(
 	"infrastructurefx",
 	// Modules
 	core.Module,
+	schemaregistry.Module,
 	customEcho.Module,
 	grpc.Module,
+	diagnostics.Module,
+	deprecation.Module,
 	mongodb.Module,
+	mongodb.InboxModule,
+	documentmigration.Module,
+	inbox.Module,
+	redis.DeduplicationModule,
+	deduplication.Module,
 	redis.Module,
-	rabbitmq.ModuleFunc(
-		func(v *validator.Validate, l logger.Logger, tracer tracing.AppTracer) configurations.RabbitMQConfigurationBuilderFuc {
+	featuretoggle.Module,
+	messagebroker.ModuleFunc(
+		func(
+			v *validator.Validate,
+			l logger.Logger,
+			tracer tracing.AppTracer,
+			appMetrics metrics.AppMetrics,
+			toggles featuretoggle.FeatureToggles,
+			inboxStore inbox.InboxStore,
+			inboxOptions *inbox.InboxOptions,
+			deduplicationStore deduplication.DeduplicationStore,
+			deduplicationOptions *deduplication.ContentDeduplicationOptions,
+			searchQueryBuilder searching.SearchQueryBuilder,
+			deprecations deprecation.Registry,
+		) configurations.RabbitMQConfigurationBuilderFuc {
 			return func(builder configurations.RabbitMQConfigurationBuilder) {
-				rabbitmq2.ConfigProductsRabbitMQ(builder, l, v, tracer)
+				// the pipelines of all the consumers of the bus, like the pipeline behaviors of the mediator
+				builder.AddPipelines(
+					pipeline.NewRecoveryPipeline(l),
+					loggingpipelines.NewConsumerLoggingPipeline(l),
+					messagingmetricspipelines.NewMessagingMetricsPipeline(appMetrics),
+					validationpipeline.NewConsumerValidationPipeline(l),
+					deprecation.NewConsumerPipeline(deprecations),
+				)
+
+				rabbitmq2.ConfigProductsRabbitMQ(
+					builder,
+					l,
+					v,
+					tracer,
+					toggles,
+					inbox.NewInboxPipeline(inboxStore, inboxOptions, l),
+					deduplication.NewContentDeduplicationPipeline(deduplicationStore, deduplicationOptions, l),
+					searchQueryBuilder,
+				)
 			}
 		},
+		// the dead-letter admin endpoints work on the dead-letter queues of rabbitmq
+		deadletter.Module,
+		// the messages which are failed after the max retries of the consumers are quarantined in mongo and replayed
+		// to rabbitmq
+		mongodb.QuarantineModule,
+		quarantine.Module,
 	),
 	health.Module,
+	warmup.Module,
 	tracing.Module,
 	metrics.Module,
 
@@ -288,6 +288,103 @@ func (_c *ProductRepository_GetProductByProductId_Call) RunAndReturn(run func(co
 	return _c
 }
 
+// IncreaseProductsPopularity provides a mock function with given fields: ctx, name, count
+func (_m *ProductRepository) IncreaseProductsPopularity(ctx context.Context, name string, count int64) (int64, error) {
+	ret := _m.Called(ctx, name, count)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (int64, error)); ok {
+		return rf(ctx, name, count)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) int64); ok {
+		r0 = rf(ctx, name, count)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, name, count)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProductRepository_IncreaseProductsPopularity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncreaseProductsPopularity'
+type ProductRepository_IncreaseProductsPopularity_Call struct {
+	*mock.Call
+}
+
+// IncreaseProductsPopularity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - count int64
+func (_e *ProductRepository_Expecter) IncreaseProductsPopularity(ctx interface{}, name interface{}, count interface{}) *ProductRepository_IncreaseProductsPopularity_Call {
+	return &ProductRepository_IncreaseProductsPopularity_Call{Call: _e.mock.On("IncreaseProductsPopularity", ctx, name, count)}
+}
+
+func (_c *ProductRepository_IncreaseProductsPopularity_Call) Run(run func(ctx context.Context, name string, count int64)) *ProductRepository_IncreaseProductsPopularity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *ProductRepository_IncreaseProductsPopularity_Call) Return(_a0 int64, _a1 error) *ProductRepository_IncreaseProductsPopularity_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProductRepository_IncreaseProductsPopularity_Call) RunAndReturn(run func(context.Context, string, int64) (int64, error)) *ProductRepository_IncreaseProductsPopularity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IterateProducts provides a mock function with given fields: ctx, fn
+func (_m *ProductRepository) IterateProducts(ctx context.Context, fn func(*models.Product) error) error {
+	ret := _m.Called(ctx, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(*models.Product) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ProductRepository_IterateProducts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IterateProducts'
+type ProductRepository_IterateProducts_Call struct {
+	*mock.Call
+}
+
+// IterateProducts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(*models.Product) error
+func (_e *ProductRepository_Expecter) IterateProducts(ctx interface{}, fn interface{}) *ProductRepository_IterateProducts_Call {
+	return &ProductRepository_IterateProducts_Call{Call: _e.mock.On("IterateProducts", ctx, fn)}
+}
+
+func (_c *ProductRepository_IterateProducts_Call) Run(run func(ctx context.Context, fn func(*models.Product) error)) *ProductRepository_IterateProducts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(*models.Product) error))
+	})
+	return _c
+}
+
+func (_c *ProductRepository_IterateProducts_Call) Return(_a0 error) *ProductRepository_IterateProducts_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ProductRepository_IterateProducts_Call) RunAndReturn(run func(context.Context, func(*models.Product) error) error) *ProductRepository_IterateProducts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SearchProducts provides a mock function with given fields: ctx, searchText, listQuery
 func (_m *ProductRepository) SearchProducts(ctx context.Context, searchText string, listQuery *utils.ListQuery) (*utils.ListResult[*models.Product], error) {
 	ret := _m.Called(ctx, searchText, listQuery)
@@ -344,6 +441,62 @@ func (_c *ProductRepository_SearchProducts_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// SuggestProducts provides a mock function with given fields: ctx, prefix, limit
+func (_m *ProductRepository) SuggestProducts(ctx context.Context, prefix string, limit int) ([]*models.Product, error) {
+	ret := _m.Called(ctx, prefix, limit)
+
+	var r0 []*models.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]*models.Product, error)); ok {
+		return rf(ctx, prefix, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []*models.Product); ok {
+		r0 = rf(ctx, prefix, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, prefix, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProductRepository_SuggestProducts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SuggestProducts'
+type ProductRepository_SuggestProducts_Call struct {
+	*mock.Call
+}
+
+// SuggestProducts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prefix string
+//   - limit int
+func (_e *ProductRepository_Expecter) SuggestProducts(ctx interface{}, prefix interface{}, limit interface{}) *ProductRepository_SuggestProducts_Call {
+	return &ProductRepository_SuggestProducts_Call{Call: _e.mock.On("SuggestProducts", ctx, prefix, limit)}
+}
+
+func (_c *ProductRepository_SuggestProducts_Call) Run(run func(ctx context.Context, prefix string, limit int)) *ProductRepository_SuggestProducts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *ProductRepository_SuggestProducts_Call) Return(_a0 []*models.Product, _a1 error) *ProductRepository_SuggestProducts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProductRepository_SuggestProducts_Call) RunAndReturn(run func(context.Context, string, int) ([]*models.Product, error)) *ProductRepository_SuggestProducts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateProduct provides a mock function with given fields: ctx, product
 func (_m *ProductRepository) UpdateProduct(ctx context.Context, product *models.Product) (*models.Product, error) {
 	ret := _m.Called(ctx, product)
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/external/fxlog"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/zap"
+	rabbitmqConfig "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/configurations"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/topology"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/configurations/rabbitmq"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/fx"
+)
+
+func init() {
+	cmdExport.Flags().String("output", "", "Output file of the topology yaml, default is stdout")
+
+	rootCmd.AddCommand(cmdExport)
+	rootCmd.AddCommand(cmdVerify)
+}
+
+var (
+	rootCmd = &cobra.Command{ //nolint:gochecknoglobals
+		Use:   "topology",
+		Short: "A tool for exporting and verifying the rabbitmq topology",
+	}
+
+	cmdExport = &cobra.Command{ //nolint:gochecknoglobals
+		Use:   "export",
+		Short: "Export the declared rabbitmq topology as yaml",
+		Run: func(cmd *cobra.Command, args []string) {
+			output, err := cmd.Flags().GetString("output")
+			if err != nil {
+				defaultLogger.GetLogger().Fatal(err)
+			}
+
+			exportTopology(output)
+		},
+	}
+
+	cmdVerify = &cobra.Command{ //nolint:gochecknoglobals
+		Use:   "verify",
+		Short: "Verify the live rabbitmq broker matches the declared topology",
+		Run: func(cmd *cobra.Command, args []string) {
+			verifyTopology()
+		},
+	}
+)
+
+func declaredTopology() *topology.Topology {
+	return topology.NewTopologyFromBuilder(func(builder configurations.RabbitMQConfigurationBuilder) {
+		rabbitmq.ConfigProductsRabbitMQ(builder)
+	})
+}
+
+func exportTopology(output string) {
+	data, err := declaredTopology().Yaml()
+	if err != nil {
+		defaultLogger.GetLogger().Fatal(err)
+	}
+
+	if output == "" {
+		_, err = os.Stdout.Write(data)
+	} else {
+		err = os.WriteFile(output, data, 0o644)
+	}
+
+	if err != nil {
+		defaultLogger.GetLogger().Fatal(err)
+	}
+}
+
+func verifyTopology() {
+	var drifts []topology.Drift
+
+	app := fx.New(
+		config.ModuleFunc(environment.Development),
+		zap.Module,
+		fxlog.FxLogger,
+		fx.Provide(rabbitmqConfig.ProvideConfig),
+		fx.Invoke(
+			func(rabbitmqOptions *rabbitmqConfig.RabbitmqOptions, logger logger.Logger) {
+				var err error
+
+				drifts, err = topology.Verify(rabbitmqOptions.RabbitmqHostOptions, declaredTopology(), logger)
+				if err != nil {
+					logger.Fatalf("topology verification failed, err: %s", err)
+				}
+			},
+		),
+	)
+
+	err := app.Start(context.Background())
+	if err != nil {
+		defaultLogger.GetLogger().Fatal(err)
+	}
+
+	err = app.Stop(context.Background())
+	if err != nil {
+		defaultLogger.GetLogger().Fatal(err)
+	}
+
+	if len(drifts) > 0 {
+		os.Exit(1)
+	}
+}
+
+func main() {
+	if err := rootCmd.Execute(); err != nil {
+		defaultLogger.GetLogger().Error(err)
+		os.Exit(1)
+	}
+}
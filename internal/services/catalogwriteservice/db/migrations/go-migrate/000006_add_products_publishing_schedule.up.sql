@@ -0,0 +1,6 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS publish_at timestamp with time zone;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS unpublish_at timestamp with time zone;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS is_published boolean NOT NULL DEFAULT true;
+
+CREATE INDEX IF NOT EXISTS idx_products_publish_at ON products (publish_at);
+CREATE INDEX IF NOT EXISTS idx_products_unpublish_at ON products (unpublish_at);
@@ -0,0 +1,2 @@
+ALTER TABLE products DROP COLUMN IF EXISTS sort_order;
+ALTER TABLE products DROP COLUMN IF EXISTS is_pinned;
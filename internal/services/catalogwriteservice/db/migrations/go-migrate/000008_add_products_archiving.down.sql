@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_products_is_archived;
+
+ALTER TABLE products DROP COLUMN IF EXISTS archived_at;
+ALTER TABLE products DROP COLUMN IF EXISTS is_archived;
@@ -0,0 +1,4 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS is_archived boolean NOT NULL DEFAULT false;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS archived_at timestamp with time zone;
+
+CREATE INDEX IF NOT EXISTS idx_products_is_archived ON products (is_archived);
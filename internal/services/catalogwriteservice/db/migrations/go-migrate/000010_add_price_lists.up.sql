@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS price_lists
+(
+    id              uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name            text NOT NULL,
+    customer_group  text NOT NULL,
+    effective_from  timestamp with time zone NOT NULL,
+    effective_to    timestamp with time zone,
+    prices          jsonb,
+    created_at      timestamp with time zone,
+    updated_at      timestamp with time zone,
+    deleted_at      timestamp with time zone
+);
+CREATE INDEX IF NOT EXISTS idx_price_lists_customer_group ON price_lists (customer_group, effective_from);
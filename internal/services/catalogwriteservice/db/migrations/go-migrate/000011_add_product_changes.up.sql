@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS product_changes
+(
+    id              uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id      uuid NOT NULL,
+    change_type     text NOT NULL,
+    snapshot        jsonb,
+    occurred_at     timestamp with time zone NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_product_changes_occurred_at ON product_changes (occurred_at);
+CREATE INDEX IF NOT EXISTS idx_product_changes_product_id_occurred_at ON product_changes (product_id, occurred_at);
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE products ADD COLUMN IF NOT EXISTS category text;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS attributes jsonb;
+CREATE INDEX IF NOT EXISTS idx_products_category ON products (category);
+CREATE INDEX IF NOT EXISTS idx_products_attributes ON products USING gin (attributes);
+CREATE TABLE IF NOT EXISTS attribute_sets
+(
+    id          uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    category    text NOT NULL,
+    fields      jsonb,
+    created_at  timestamp with time zone,
+    updated_at  timestamp with time zone,
+    deleted_at  timestamp with time zone
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_attribute_sets_category ON attribute_sets (category);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS attribute_sets;
+DROP INDEX IF EXISTS idx_products_attributes;
+DROP INDEX IF EXISTS idx_products_category;
+ALTER TABLE products DROP COLUMN IF EXISTS attributes;
+ALTER TABLE products DROP COLUMN IF EXISTS category;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE products ADD COLUMN IF NOT EXISTS publish_at timestamp with time zone;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS unpublish_at timestamp with time zone;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS is_published boolean NOT NULL DEFAULT true;
+
+CREATE INDEX IF NOT EXISTS idx_products_publish_at ON products (publish_at);
+CREATE INDEX IF NOT EXISTS idx_products_unpublish_at ON products (unpublish_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_products_unpublish_at;
+DROP INDEX IF EXISTS idx_products_publish_at;
+
+ALTER TABLE products DROP COLUMN IF EXISTS is_published;
+ALTER TABLE products DROP COLUMN IF EXISTS unpublish_at;
+ALTER TABLE products DROP COLUMN IF EXISTS publish_at;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS product_changes
+(
+    id              uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id      uuid NOT NULL,
+    change_type     text NOT NULL,
+    snapshot        jsonb,
+    occurred_at     timestamp with time zone NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_product_changes_occurred_at ON product_changes (occurred_at);
+CREATE INDEX IF NOT EXISTS idx_product_changes_product_id_occurred_at ON product_changes (product_id, occurred_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS product_changes;
+-- +goose StatementEnd
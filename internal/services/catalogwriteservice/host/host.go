@@ -0,0 +1,30 @@
+// Package host exposes the composition of the catalogs write service to the launchers outside the service module, e.g.
+// the development host which runs all the services in one process.
+package host
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/fxapp/contracts"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/app"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/configurations/catalogs"
+)
+
+// NewApplication builds the catalogs write application with its configurations and endpoints, the caller starts and
+// stops the application.
+func NewApplication() (contracts.Application, error) {
+	appBuilder := app.NewCatalogsWriteApplicationBuilder()
+	appBuilder.ProvideModule(catalogs.CatalogsServiceModule)
+
+	application := appBuilder.Build()
+
+	err := application.ConfigureCatalogs()
+	if err != nil {
+		return nil, err
+	}
+
+	err = application.MapCatalogsEndpoints()
+	if err != nil {
+		return nil, err
+	}
+
+	return application, nil
+}
@@ -0,0 +1,107 @@
+package attributes
+
+import (
+	"context"
+	"fmt"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mapper"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/datatypes"
+	datamodel "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/datamodels"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/data/dbcontext"
+
+	"emperror.dev/errors"
+	"gorm.io/gorm"
+)
+
+type AttributesValidator interface {
+	// ValidateProductAttributes validates product custom fields against the attribute set of its category.
+	ValidateProductAttributes(
+		ctx context.Context,
+		category string,
+		attributes datatypes.JSONMap,
+	) error
+	// FindAttributeSetByCategory returns the attribute set of a category or nil if there is no attribute set for it.
+	FindAttributeSetByCategory(ctx context.Context, category string) (*models.AttributeSet, error)
+}
+
+type attributesValidator struct {
+	dbContext *dbcontext.CatalogsGormDBContext
+}
+
+func NewAttributesValidator(
+	dbContext *dbcontext.CatalogsGormDBContext,
+) AttributesValidator {
+	return &attributesValidator{dbContext: dbContext}
+}
+
+func (a *attributesValidator) ValidateProductAttributes(
+	ctx context.Context,
+	category string,
+	attributes datatypes.JSONMap,
+) error {
+	if category == "" {
+		if len(attributes) > 0 {
+			return customErrors.NewValidationError(
+				"product attributes can't be set without a category",
+			)
+		}
+
+		return nil
+	}
+
+	attributeSet, err := a.FindAttributeSetByCategory(ctx, category)
+	if err != nil {
+		return err
+	}
+
+	if attributeSet == nil {
+		if len(attributes) > 0 {
+			return customErrors.NewValidationError(
+				fmt.Sprintf("there is no attribute set for category `%s`", category),
+			)
+		}
+
+		return nil
+	}
+
+	if err := attributeSet.ValidateAttributes(attributes); err != nil {
+		return customErrors.NewValidationErrorWrap(err, "product attributes validation error")
+	}
+
+	return nil
+}
+
+func (a *attributesValidator) FindAttributeSetByCategory(
+	ctx context.Context,
+	category string,
+) (*models.AttributeSet, error) {
+	var dataModel datamodel.AttributeSetDataModel
+
+	result := a.dbContext.WithTxIfExists(ctx).
+		DB().
+		WithContext(ctx).
+		Where("category = ?", category).
+		First(&dataModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, customErrors.NewApplicationErrorWrap(
+			result.Error,
+			fmt.Sprintf("error in fetching attribute set for category `%s`", category),
+		)
+	}
+
+	attributeSet, err := mapper.Map[*models.AttributeSet](&dataModel)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in the mapping AttributeSet",
+		)
+	}
+
+	return attributeSet, nil
+}
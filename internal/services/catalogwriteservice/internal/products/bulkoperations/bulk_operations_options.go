@@ -0,0 +1,23 @@
+package bulkoperations
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[BulkOperationsOptions]())
+
+// BulkOperationsOptions controls the bulk commands of the products, like the bulk delete and the bulk archive.
+type BulkOperationsOptions struct {
+	// MaxProductIds is the max number of products of a single bulk command
+	MaxProductIds int `mapstructure:"maxProductIds" default:"1000"`
+	// ChunkSize is the number of products which are changed in a single transaction with a single integration event
+	ChunkSize int `mapstructure:"chunkSize"     default:"100"`
+}
+
+func NewBulkOperationsOptions(environment environment.Environment) (*BulkOperationsOptions, error) {
+	return config.BindConfigKey[*BulkOperationsOptions](optionName, environment)
+}
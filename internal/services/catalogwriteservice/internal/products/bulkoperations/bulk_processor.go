@@ -0,0 +1,149 @@
+package bulkoperations
+
+import (
+	"context"
+	"fmt"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/contracts"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/data/dbcontext"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// ItemStatus is the result of a single product of a bulk command
+type ItemStatus string
+
+const (
+	StatusSucceeded ItemStatus = "succeeded"
+	StatusNotFound  ItemStatus = "notFound"
+	// StatusSkipped is the status of a product which is already in the requested state, e.g. an archived product
+	StatusSkipped ItemStatus = "skipped"
+	// StatusFailed is the status of the products of a chunk which its transaction is rolled back
+	StatusFailed ItemStatus = "failed"
+)
+
+type ItemResult struct {
+	ProductId uuid.UUID
+	Status    ItemStatus
+	Error     string
+}
+
+// ChunkAction changes the products of a chunk inside the transaction of the context and returns the status of each of
+// them, the products without a status are reported as succeeded. an error rolls back all the changes of the chunk.
+type ChunkAction func(ctx context.Context, productIds []uuid.UUID) (map[uuid.UUID]ItemStatus, error)
+
+type BulkProcessor interface {
+	// Process runs the action on the chunks of the products, each chunk in its own transaction, and returns the results
+	// in the order of the product ids. a failed chunk doesn't stop the next chunks, so a bulk command can partially
+	// succeed and its failed products can be retried with a new command.
+	Process(ctx context.Context, productIds []uuid.UUID, action ChunkAction) ([]*ItemResult, error)
+}
+
+type bulkProcessor struct {
+	dbContext *dbcontext.CatalogsGormDBContext
+	options   *BulkOperationsOptions
+	log       logger.Logger
+}
+
+func NewBulkProcessor(
+	dbContext *dbcontext.CatalogsGormDBContext,
+	options *BulkOperationsOptions,
+	log logger.Logger,
+) BulkProcessor {
+	return &bulkProcessor{
+		dbContext: dbContext,
+		options:   options,
+		log:       log,
+	}
+}
+
+func (b *bulkProcessor) Process(
+	ctx context.Context,
+	productIds []uuid.UUID,
+	action ChunkAction,
+) ([]*ItemResult, error) {
+	if b.options.MaxProductIds > 0 && len(productIds) > b.options.MaxProductIds {
+		return nil, customErrors.NewBadRequestError(
+			fmt.Sprintf(
+				"a bulk command accepts at most %d products but got %d",
+				b.options.MaxProductIds,
+				len(productIds),
+			),
+		)
+	}
+
+	results := make([]*ItemResult, 0, len(productIds))
+
+	for _, chunk := range Chunks(productIds, b.options.ChunkSize) {
+		if err := customErrors.CheckContext(ctx, "bulk command canceled"); err != nil {
+			return nil, err
+		}
+
+		var statuses map[uuid.UUID]ItemStatus
+
+		err := b.dbContext.RunInTx(ctx, func(ctx context.Context, _ contracts.GormDBContext) error {
+			var err error
+			statuses, err = action(ctx, chunk)
+
+			return err
+		})
+		if err != nil {
+			if customErrors.IsCanceledError(err) {
+				return nil, err
+			}
+
+			b.log.Errorw(
+				fmt.Sprintf("error in processing a chunk of %d products, the chunk is rolled back", len(chunk)),
+				logger.Fields{"Error": err.Error()},
+			)
+
+			for _, productId := range chunk {
+				results = append(results, &ItemResult{ProductId: productId, Status: StatusFailed, Error: err.Error()})
+			}
+
+			continue
+		}
+
+		for _, productId := range chunk {
+			status, ok := statuses[productId]
+			if !ok {
+				status = StatusSucceeded
+			}
+
+			results = append(results, &ItemResult{ProductId: productId, Status: status})
+		}
+	}
+
+	return results, nil
+}
+
+// Chunks splits the product ids to the chunks of the size, a non-positive size returns a single chunk
+func Chunks(productIds []uuid.UUID, size int) [][]uuid.UUID {
+	if len(productIds) == 0 {
+		return nil
+	}
+
+	if size <= 0 || size >= len(productIds) {
+		return [][]uuid.UUID{productIds}
+	}
+
+	chunks := make([][]uuid.UUID, 0, (len(productIds)+size-1)/size)
+	for start := 0; start < len(productIds); start += size {
+		end := min(start+size, len(productIds))
+		chunks = append(chunks, productIds[start:end])
+	}
+
+	return chunks
+}
+
+// CountByStatus returns the number of the results with each status
+func CountByStatus(results []*ItemResult) map[ItemStatus]int {
+	counts := make(map[ItemStatus]int)
+	for _, result := range results {
+		counts[result.Status]++
+	}
+
+	return counts
+}
@@ -7,11 +7,17 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
 	productsService "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/grpc/genproto"
 
+	"github.com/goccy/go-json"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func ConfigureProductsMappings() error {
-	err := mapper.CreateMap[*models.Product, *dtoV1.ProductDto]()
+	err := configureProductTranslationsMappings()
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateMap[*models.Product, *dtoV1.ProductDto]()
 	if err != nil {
 		return err
 	}
@@ -31,6 +37,16 @@ func ConfigureProductsMappings() error {
 		return err
 	}
 
+	err = configureAttributeSetsMappings()
+	if err != nil {
+		return err
+	}
+
+	err = configurePriceListsMappings()
+	if err != nil {
+		return err
+	}
+
 	err = mapper.CreateCustomMap[*dtoV1.ProductDto, *productsService.Product](
 		func(product *dtoV1.ProductDto) *productsService.Product {
 			if product == nil {
@@ -65,3 +81,174 @@ func ConfigureProductsMappings() error {
 
 	return nil
 }
+
+func configureAttributeSetsMappings() error {
+	err := mapper.CreateCustomMap(
+		func(dataModel *datamodel.AttributeSetDataModel) *models.AttributeSet {
+			if dataModel == nil {
+				return nil
+			}
+
+			var fields []*models.AttributeField
+			if len(dataModel.Fields) > 0 {
+				// fields are persisted by our own mapping, so a corrupted document is just treated as an empty set
+				_ = json.Unmarshal(dataModel.Fields, &fields)
+			}
+
+			return &models.AttributeSet{
+				Id:        dataModel.Id,
+				Category:  dataModel.Category,
+				Fields:    fields,
+				CreatedAt: dataModel.CreatedAt,
+				UpdatedAt: dataModel.UpdatedAt,
+			}
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateCustomMap(
+		func(attributeSet *models.AttributeSet) *datamodel.AttributeSetDataModel {
+			if attributeSet == nil {
+				return nil
+			}
+
+			fields, _ := json.Marshal(attributeSet.Fields)
+
+			return &datamodel.AttributeSetDataModel{
+				Id:        attributeSet.Id,
+				Category:  attributeSet.Category,
+				Fields:    fields,
+				CreatedAt: attributeSet.CreatedAt,
+				UpdatedAt: attributeSet.UpdatedAt,
+			}
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return mapper.CreateCustomMap(
+		func(attributeSet *models.AttributeSet) *dtoV1.AttributeSetDto {
+			if attributeSet == nil {
+				return nil
+			}
+
+			fields := make([]*dtoV1.AttributeFieldDto, 0, len(attributeSet.Fields))
+			for _, field := range attributeSet.Fields {
+				fields = append(fields, &dtoV1.AttributeFieldDto{
+					Name:     field.Name,
+					Type:     string(field.Type),
+					Required: field.Required,
+					Options:  field.Options,
+				})
+			}
+
+			return &dtoV1.AttributeSetDto{
+				Id:        attributeSet.Id,
+				Category:  attributeSet.Category,
+				Fields:    fields,
+				CreatedAt: attributeSet.CreatedAt,
+				UpdatedAt: attributeSet.UpdatedAt,
+			}
+		},
+	)
+}
+
+func configurePriceListsMappings() error {
+	err := mapper.CreateCustomMap(
+		func(dataModel *datamodel.PriceListDataModel) *models.PriceList {
+			if dataModel == nil {
+				return nil
+			}
+
+			var prices []*models.ProductPrice
+			if len(dataModel.Prices) > 0 {
+				// prices are persisted by our own mapping, so a corrupted document is just treated as an empty list
+				_ = json.Unmarshal(dataModel.Prices, &prices)
+			}
+
+			return &models.PriceList{
+				Id:            dataModel.Id,
+				Name:          dataModel.Name,
+				CustomerGroup: dataModel.CustomerGroup,
+				EffectiveFrom: dataModel.EffectiveFrom,
+				EffectiveTo:   dataModel.EffectiveTo,
+				Prices:        prices,
+				CreatedAt:     dataModel.CreatedAt,
+				UpdatedAt:     dataModel.UpdatedAt,
+			}
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateCustomMap(
+		func(priceList *models.PriceList) *datamodel.PriceListDataModel {
+			if priceList == nil {
+				return nil
+			}
+
+			prices, _ := json.Marshal(priceList.Prices)
+
+			return &datamodel.PriceListDataModel{
+				Id:            priceList.Id,
+				Name:          priceList.Name,
+				CustomerGroup: priceList.CustomerGroup,
+				EffectiveFrom: priceList.EffectiveFrom,
+				EffectiveTo:   priceList.EffectiveTo,
+				Prices:        prices,
+				CreatedAt:     priceList.CreatedAt,
+				UpdatedAt:     priceList.UpdatedAt,
+			}
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return mapper.CreateCustomMap(
+		func(priceList *models.PriceList) *dtoV1.PriceListDto {
+			if priceList == nil {
+				return nil
+			}
+
+			prices := make([]*dtoV1.ProductPriceDto, 0, len(priceList.Prices))
+			for _, price := range priceList.Prices {
+				prices = append(prices, &dtoV1.ProductPriceDto{ProductId: price.ProductId, Price: price.Price})
+			}
+
+			return &dtoV1.PriceListDto{
+				Id:            priceList.Id,
+				Name:          priceList.Name,
+				CustomerGroup: priceList.CustomerGroup,
+				EffectiveFrom: priceList.EffectiveFrom,
+				EffectiveTo:   priceList.EffectiveTo,
+				Prices:        prices,
+				CreatedAt:     priceList.CreatedAt,
+				UpdatedAt:     priceList.UpdatedAt,
+			}
+		},
+	)
+}
+
+func configureProductTranslationsMappings() error {
+	err := mapper.CreateMap[*models.ProductTranslation, *dtoV1.ProductTranslationDto]()
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateMap[*dtoV1.ProductTranslationDto, *models.ProductTranslation]()
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateMap[*models.ProductTranslation, *datamodel.ProductTranslationDataModel]()
+	if err != nil {
+		return err
+	}
+
+	return mapper.CreateMap[*datamodel.ProductTranslationDataModel, *models.ProductTranslation]()
+}
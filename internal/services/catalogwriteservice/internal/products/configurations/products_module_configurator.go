@@ -1,8 +1,10 @@
 package configurations
 
 import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
 	fxcontracts "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/fxapp/contracts"
 	grpcServer "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/grpc"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/configurations/endpoints"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/configurations/mappings"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/configurations/mediator"
@@ -12,6 +14,9 @@ import (
 	googleGrpc "google.golang.org/grpc"
 )
 
+// catalogsRequestsPackage is the package prefix of the catalogs commands and queries which should have a handler
+const catalogsRequestsPackage = "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice"
+
 type ProductsModuleConfigurator struct {
 	fxcontracts.Application
 }
@@ -37,6 +42,18 @@ func (c *ProductsModuleConfigurator) ConfigureProductsModule() error {
 		`group:"product-handlers"`,
 	)
 
+	// pre-resolve products request handlers and validate every command and query has exactly one handler
+	c.ResolveFunc(func(logger logger.Logger) error {
+		err := cqrs.BuildDispatchTable(catalogsRequestsPackage)
+		if err != nil {
+			return err
+		}
+
+		logger.Infof("%d request handlers registered in the dispatch table", len(cqrs.RegisteredHandlers()))
+
+		return nil
+	})
+
 	return nil
 }
 
@@ -3,6 +3,7 @@ package rabbitmq
 import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/configurations"
 	producerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/producer/configurations"
+	createPriceListIntegrationEvents "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/creatingpricelist/v1/events/integrationevents"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/creatingproduct/v1/events/integrationevents"
 )
 
@@ -14,4 +15,10 @@ func ConfigProductsRabbitMQ(
 		func(builder producerConfigurations.RabbitMQProducerConfigurationBuilder) {
 		},
 	)
+
+	builder.AddProducer(
+		createPriceListIntegrationEvents.PriceListCreatedV1{},
+		func(builder producerConfigurations.RabbitMQProducerConfigurationBuilder) {
+		},
+	)
 }
@@ -20,6 +20,8 @@ type ProductRepository interface {
 		listQuery *utils.ListQuery,
 	) (*utils.ListResult[*models.Product], error)
 	GetProductById(ctx context.Context, uuid uuid.UUID) (*models.Product, error)
+	// IterateProducts streams all products to the fn through a database cursor, so bulk flows keep their memory flat
+	IterateProducts(ctx context.Context, fn func(product *models.Product) error) error
 	CreateProduct(ctx context.Context, product *models.Product) (*models.Product, error)
 	UpdateProduct(ctx context.Context, product *models.Product) (*models.Product, error)
 	DeleteProductByID(ctx context.Context, uuid uuid.UUID) error
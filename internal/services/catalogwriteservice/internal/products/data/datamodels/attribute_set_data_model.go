@@ -0,0 +1,33 @@
+package datamodels
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/datatypes"
+
+	"github.com/goccy/go-json"
+	uuid "github.com/satori/go.uuid"
+	"gorm.io/gorm"
+)
+
+// AttributeSetDataModel data model
+type AttributeSetDataModel struct {
+	Id        uuid.UUID `gorm:"primaryKey"`
+	Category  string    `gorm:"uniqueIndex"`
+	Fields    datatypes.JSON
+	CreatedAt time.Time `gorm:"default:current_timestamp"`
+	UpdatedAt time.Time
+	// for soft delete - https://gorm.io/docs/delete.html#Soft-Delete
+	gorm.DeletedAt
+}
+
+// TableName overrides the table name used by AttributeSetDataModel to `attribute_sets` - https://gorm.io/docs/conventions.html#TableName
+func (p *AttributeSetDataModel) TableName() string {
+	return "attribute_sets"
+}
+
+func (p *AttributeSetDataModel) String() string {
+	j, _ := json.Marshal(p)
+
+	return string(j)
+}
@@ -0,0 +1,36 @@
+package datamodels
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/datatypes"
+
+	"github.com/goccy/go-json"
+	uuid "github.com/satori/go.uuid"
+	"gorm.io/gorm"
+)
+
+// PriceListDataModel data model
+type PriceListDataModel struct {
+	Id            uuid.UUID `gorm:"primaryKey"`
+	Name          string
+	CustomerGroup string `gorm:"index"`
+	EffectiveFrom time.Time
+	EffectiveTo   *time.Time
+	Prices        datatypes.JSON
+	CreatedAt     time.Time `gorm:"default:current_timestamp"`
+	UpdatedAt     time.Time
+	// for soft delete - https://gorm.io/docs/delete.html#Soft-Delete
+	gorm.DeletedAt
+}
+
+// TableName overrides the table name used by PriceListDataModel to `price_lists` - https://gorm.io/docs/conventions.html#TableName
+func (p *PriceListDataModel) TableName() string {
+	return "price_lists"
+}
+
+func (p *PriceListDataModel) String() string {
+	j, _ := json.Marshal(p)
+
+	return string(j)
+}
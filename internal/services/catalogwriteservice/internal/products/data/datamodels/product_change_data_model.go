@@ -0,0 +1,30 @@
+package datamodels
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/datatypes"
+
+	"github.com/goccy/go-json"
+	uuid "github.com/satori/go.uuid"
+)
+
+// ProductChangeDataModel data model
+type ProductChangeDataModel struct {
+	Id         uuid.UUID `gorm:"primaryKey"`
+	ProductId  uuid.UUID `gorm:"index:idx_product_changes_product_id_occurred_at,priority:1"`
+	ChangeType string
+	Snapshot   datatypes.JSONMap
+	OccurredAt time.Time `gorm:"index;index:idx_product_changes_product_id_occurred_at,priority:2"`
+}
+
+// TableName overrides the table name used by ProductChangeDataModel to `product_changes` - https://gorm.io/docs/conventions.html#TableName
+func (p *ProductChangeDataModel) TableName() string {
+	return "product_changes"
+}
+
+func (p *ProductChangeDataModel) String() string {
+	j, _ := json.Marshal(p)
+
+	return string(j)
+}
@@ -3,6 +3,8 @@ package datamodels
 import (
 	"time"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/datatypes"
+
 	"github.com/goccy/go-json"
 	uuid "github.com/satori/go.uuid"
 	"gorm.io/gorm"
@@ -13,12 +15,25 @@ import (
 
 // ProductDataModel data model
 type ProductDataModel struct {
-	Id          uuid.UUID `gorm:"primaryKey"`
-	Name        string
-	Description string
-	Price       float64
-	CreatedAt   time.Time `gorm:"default:current_timestamp"`
-	UpdatedAt   time.Time
+	Id           uuid.UUID `gorm:"primaryKey"`
+	Name         string
+	Description  string
+	Price        float64
+	Sku          string `gorm:"index"`
+	Barcode      string `gorm:"index"`
+	Category     string `gorm:"index"`
+	Attributes   datatypes.JSONMap
+	Translations ProductTranslationsDataModel
+	PublishAt    *time.Time `gorm:"index"`
+	UnpublishAt  *time.Time `gorm:"index"`
+	IsPublished  bool
+	IsPinned     bool
+	SortOrder    int
+	IsArchived   bool `gorm:"index"`
+	ArchivedAt   *time.Time
+	Version      int64
+	CreatedAt    time.Time `gorm:"default:current_timestamp"`
+	UpdatedAt    time.Time
 	// for soft delete - https://gorm.io/docs/delete.html#Soft-Delete
 	gorm.DeletedAt
 }
@@ -33,3 +48,11 @@ func (p *ProductDataModel) String() string {
 
 	return string(j)
 }
+
+func (p *ProductDataModel) GetVersion() int64 {
+	return p.Version
+}
+
+func (p *ProductDataModel) SetVersion(version int64) {
+	p.Version = version
+}
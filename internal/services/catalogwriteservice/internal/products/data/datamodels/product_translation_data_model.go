@@ -0,0 +1,71 @@
+package datamodels
+
+import (
+	"database/sql/driver"
+
+	"emperror.dev/errors"
+	"github.com/goccy/go-json"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// ProductTranslationDataModel data model
+type ProductTranslationDataModel struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// ProductTranslationsDataModel keeps product translations by locale inside a json column
+type ProductTranslationsDataModel map[string]*ProductTranslationDataModel
+
+// Value return json value, implement driver.Valuer interface
+func (t ProductTranslationsDataModel) Value() (driver.Value, error) {
+	if t == nil {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(t)
+
+	return string(b), err
+}
+
+// Scan scan value into ProductTranslationsDataModel, implements sql.Scanner interface
+func (t *ProductTranslationsDataModel) Scan(value interface{}) error {
+	if value == nil {
+		*t = nil
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return errors.Errorf("failed to unmarshal product translations value: %v", value)
+	}
+
+	result := ProductTranslationsDataModel{}
+	if err := json.Unmarshal(bytes, &result); err != nil {
+		return err
+	}
+
+	*t = result
+
+	return nil
+}
+
+// GormDataType gorm common data type
+func (ProductTranslationsDataModel) GormDataType() string {
+	return "json"
+}
+
+// GormDBDataType gorm db data type
+func (ProductTranslationsDataModel) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "JSONB"
+	}
+
+	return "TEXT"
+}
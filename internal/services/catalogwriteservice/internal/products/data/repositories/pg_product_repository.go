@@ -12,6 +12,7 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/repository"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils"
 	data2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/contracts"
+	datamodel "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/datamodels"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
 
 	"emperror.dev/errors"
@@ -23,7 +24,10 @@ import (
 type postgresProductRepository struct {
 	log                   logger.Logger
 	gormGenericRepository data.GenericRepository[*models.Product]
-	tracer                tracing.AppTracer
+	// gormDataModelRepository reads the rows through the data model, which excludes the soft deleted products and maps
+	// the translations
+	gormDataModelRepository data.GenericRepositoryWithDataModel[*datamodel.ProductDataModel, *models.Product]
+	tracer                  tracing.AppTracer
 }
 
 func NewPostgresProductRepository(
@@ -32,10 +36,14 @@ func NewPostgresProductRepository(
 	tracer tracing.AppTracer,
 ) data2.ProductRepository {
 	gormRepository := repository.NewGenericGormRepository[*models.Product](db)
+	gormDataModelRepository := repository.NewGenericGormRepositoryWithDataModel[*datamodel.ProductDataModel, *models.Product](
+		db,
+	)
 	return &postgresProductRepository{
-		log:                   log,
-		gormGenericRepository: gormRepository,
-		tracer:                tracer,
+		log:                     log,
+		gormGenericRepository:   gormRepository,
+		gormDataModelRepository: gormDataModelRepository,
+		tracer:                  tracer,
 	}
 }
 
@@ -138,6 +146,39 @@ func (p *postgresProductRepository) GetProductById(
 	return product, nil
 }
 
+func (p *postgresProductRepository) IterateProducts(
+	ctx context.Context,
+	fn func(product *models.Product) error,
+) error {
+	ctx, span := p.tracer.Start(ctx, "postgresProductRepository.IterateProducts")
+	defer span.End()
+
+	count := 0
+	err := p.gormDataModelRepository.Iterate(ctx, nil, func(product *models.Product) error {
+		count++
+
+		return fn(product)
+	})
+	err = utils2.TraceStatusFromSpan(
+		span,
+		errors.WrapIf(
+			err,
+			"error in iterating products",
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	span.SetAttributes(attribute2.Int("Count", count))
+	p.log.Infow(
+		fmt.Sprintf("%d products iterated", count),
+		logger.Fields{"Count": count},
+	)
+
+	return nil
+}
+
 func (p *postgresProductRepository) CreateProduct(
 	ctx context.Context,
 	product *models.Product,
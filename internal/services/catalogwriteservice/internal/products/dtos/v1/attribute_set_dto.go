@@ -0,0 +1,22 @@
+package v1
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type AttributeSetDto struct {
+	Id        uuid.UUID            `json:"id"`
+	Category  string               `json:"category"`
+	Fields    []*AttributeFieldDto `json:"fields"`
+	CreatedAt time.Time            `json:"createdAt"`
+	UpdatedAt time.Time            `json:"updatedAt"`
+}
+
+type AttributeFieldDto struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Required bool     `json:"required"`
+	Options  []string `json:"options,omitempty"`
+}
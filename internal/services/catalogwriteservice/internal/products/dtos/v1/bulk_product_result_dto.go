@@ -0,0 +1,27 @@
+package v1
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/bulkoperations"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// BulkProductResultDto is the result of a single product of a bulk command
+type BulkProductResultDto struct {
+	ProductId uuid.UUID `json:"productId"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+}
+
+func NewBulkProductResultDtos(results []*bulkoperations.ItemResult) []*BulkProductResultDto {
+	dtos := make([]*BulkProductResultDto, 0, len(results))
+	for _, result := range results {
+		dtos = append(dtos, &BulkProductResultDto{
+			ProductId: result.ProductId,
+			Status:    string(result.Status),
+			Error:     result.Error,
+		})
+	}
+
+	return dtos
+}
@@ -1,9 +1,17 @@
 package fxparams
 
 import (
-	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/producer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/bus"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/attributes"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/bulkoperations"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/contracts"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/history"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/merchandising"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/pricing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/publishing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/skugeneration"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/data/dbcontext"
 
 	"go.uber.org/fx"
@@ -12,8 +20,16 @@ import (
 type ProductHandlerParams struct {
 	fx.In
 
-	Log               logger.Logger
-	CatalogsDBContext *dbcontext.CatalogsGormDBContext
-	RabbitmqProducer  producer.Producer
-	Tracer            tracing.AppTracer
+	Log                  logger.Logger
+	CatalogsDBContext    *dbcontext.CatalogsGormDBContext
+	OutboxPublisher      bus.OutboxPublisher
+	Tracer               tracing.AppTracer
+	SkuGenerator         skugeneration.SkuGenerator
+	AttributesValidator  attributes.AttributesValidator
+	VisibilityManager    publishing.VisibilityManager
+	MerchandisingManager merchandising.MerchandisingManager
+	ProductRepository    contracts.ProductRepository
+	BulkProcessor        bulkoperations.BulkProcessor
+	PriceResolver        pricing.PriceResolver
+	ProductHistory       history.ProductHistory
 }
@@ -1,6 +1,7 @@
 package fxparams
 
 import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/jobs"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/contracts"
 
@@ -12,8 +13,11 @@ import (
 type ProductRouteParams struct {
 	fx.In
 
-	CatalogsMetrics *contracts.CatalogsMetrics
-	Logger          logger.Logger
-	ProductsGroup   *echo.Group `name:"product-echo-group"`
-	Validator       *validator.Validate
+	CatalogsMetrics    *contracts.CatalogsMetrics
+	Logger             logger.Logger
+	ProductsGroup      *echo.Group `name:"product-echo-group"`
+	AttributeSetsGroup *echo.Group `name:"attribute-set-echo-group"`
+	PriceListsGroup    *echo.Group `name:"price-list-echo-group"`
+	Validator          *validator.Validate
+	JobRunner          *jobs.JobRunner
 }
@@ -0,0 +1,23 @@
+package v1
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type PriceListDto struct {
+	Id            uuid.UUID          `json:"id"`
+	Name          string             `json:"name"`
+	CustomerGroup string             `json:"customerGroup"`
+	EffectiveFrom time.Time          `json:"effectiveFrom"`
+	EffectiveTo   *time.Time         `json:"effectiveTo,omitempty"`
+	Prices        []*ProductPriceDto `json:"prices"`
+	CreatedAt     time.Time          `json:"createdAt"`
+	UpdatedAt     time.Time          `json:"updatedAt"`
+}
+
+type ProductPriceDto struct {
+	ProductId uuid.UUID `json:"productId"`
+	Price     float64   `json:"price"`
+}
@@ -3,14 +3,33 @@ package v1
 import (
 	"time"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/datatypes"
+
 	uuid "github.com/satori/go.uuid"
 )
 
 type ProductDto struct {
-	Id          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Price       float64   `json:"price"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	Id           uuid.UUID                         `json:"id"`
+	Name         string                            `json:"name"`
+	Description  string                            `json:"description"`
+	Price        float64                           `json:"price"`
+	Sku          string                            `json:"sku"`
+	Barcode      string                            `json:"barcode"`
+	Category     string                            `json:"category,omitempty"`
+	Attributes   datatypes.JSONMap                 `json:"attributes,omitempty"`
+	Translations map[string]*ProductTranslationDto `json:"translations,omitempty"`
+	PublishAt    *time.Time                        `json:"publishAt,omitempty"`
+	UnpublishAt  *time.Time                        `json:"unpublishAt,omitempty"`
+	IsPublished  bool                              `json:"isPublished"`
+	IsPinned     bool                              `json:"isPinned"`
+	SortOrder    int                               `json:"sortOrder,omitempty"`
+	IsArchived   bool                              `json:"isArchived"`
+	ArchivedAt   *time.Time                        `json:"archivedAt,omitempty"`
+	Version      int64                             `json:"version"`
+	CreatedAt    time.Time                         `json:"createdAt"`
+	UpdatedAt    time.Time                         `json:"updatedAt"`
+	// CustomerPrice is the price of the effective price list of the customer group, it is only set on the read
+	// endpoints when the request has a customer context
+	CustomerPrice *float64   `json:"customerPrice,omitempty"`
+	PriceListId   *uuid.UUID `json:"priceListId,omitempty"`
 }
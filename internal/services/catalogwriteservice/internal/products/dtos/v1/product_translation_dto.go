@@ -0,0 +1,6 @@
+package v1
+
+type ProductTranslationDto struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
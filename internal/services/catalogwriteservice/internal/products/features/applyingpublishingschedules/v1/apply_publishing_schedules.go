@@ -0,0 +1,54 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// ApplyPublishingSchedules publishes or unpublishes the products that their publishing window started or ended until Now
+type ApplyPublishingSchedules struct {
+	cqrs.Command
+	Now       time.Time
+	BatchSize int
+}
+
+func NewApplyPublishingSchedules(now time.Time, batchSize int) *ApplyPublishingSchedules {
+	command := &ApplyPublishingSchedules{
+		Command:   cqrs.NewCommandByT[ApplyPublishingSchedules](),
+		Now:       now,
+		BatchSize: batchSize,
+	}
+
+	return command
+}
+
+func NewApplyPublishingSchedulesWithValidation(
+	now time.Time,
+	batchSize int,
+) (*ApplyPublishingSchedules, error) {
+	command := NewApplyPublishingSchedules(now, batchSize)
+	err := command.Validate()
+
+	return command, err
+}
+
+// IsTxRequest for enabling transactions on the mediatr pipeline
+func (c *ApplyPublishingSchedules) isTxRequest() {
+}
+
+func (c *ApplyPublishingSchedules) Validate() error {
+	err := validation.ValidateStruct(
+		c,
+		validation.Field(&c.Now, validation.Required),
+		validation.Field(&c.BatchSize, validation.Required, validation.Min(1)),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
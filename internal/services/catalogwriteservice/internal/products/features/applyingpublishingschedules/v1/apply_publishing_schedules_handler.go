@@ -0,0 +1,85 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/applyingpublishingschedules/v1/dtos"
+)
+
+type applyPublishingSchedulesHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewApplyPublishingSchedulesHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*ApplyPublishingSchedules, *dtos.ApplyPublishingSchedulesResponseDto] {
+	return &applyPublishingSchedulesHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *applyPublishingSchedulesHandler) RegisterHandler() error {
+	return cqrs.RegisterRequestHandler[*ApplyPublishingSchedules, *dtos.ApplyPublishingSchedulesResponseDto](
+		c,
+	)
+}
+
+func (c *applyPublishingSchedulesHandler) Handle(
+	ctx context.Context,
+	command *ApplyPublishingSchedules,
+) (*dtos.ApplyPublishingSchedulesResponseDto, error) {
+	products, err := c.VisibilityManager.FindPendingProducts(
+		ctx,
+		command.Now,
+		command.BatchSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dtos.ApplyPublishingSchedulesResponseDto{}
+
+	for _, product := range products {
+		// a canceled run stops between the products, the remaining products are applied in the next run
+		if err = customErrors.CheckContext(ctx, "applying publishing schedules canceled"); err != nil {
+			return nil, err
+		}
+
+		product, err = c.VisibilityManager.ApplySchedule(
+			ctx,
+			product,
+			product.PublishingSchedule(),
+			command.Now,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if product.IsPublished {
+			result.PublishedProducts = append(result.PublishedProducts, product.Id)
+		} else {
+			result.UnpublishedProducts = append(result.UnpublishedProducts, product.Id)
+		}
+	}
+
+	if len(products) > 0 {
+		c.Log.Infow(
+			fmt.Sprintf(
+				"publishing schedules applied, %d products published and %d products unpublished",
+				len(result.PublishedProducts),
+				len(result.UnpublishedProducts),
+			),
+			logger.Fields{
+				"PublishedProducts":   result.PublishedProducts,
+				"UnpublishedProducts": result.UnpublishedProducts,
+			},
+		)
+	}
+
+	return result, nil
+}
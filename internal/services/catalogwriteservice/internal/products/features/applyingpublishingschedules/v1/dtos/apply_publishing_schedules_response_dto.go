@@ -0,0 +1,8 @@
+package dtos
+
+import uuid "github.com/satori/go.uuid"
+
+type ApplyPublishingSchedulesResponseDto struct {
+	PublishedProducts   []uuid.UUID `json:"publishedProducts"`
+	UnpublishedProducts []uuid.UUID `json:"unpublishedProducts"`
+}
@@ -0,0 +1,42 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/web"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/applyingpublishingschedules/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/publishing"
+
+	"go.uber.org/fx"
+)
+
+// RegisterPublishingScheduler runs ApplyPublishingSchedules periodically during the application lifetime
+func RegisterPublishingScheduler(
+	lc fx.Lifecycle,
+	options *publishing.PublishingOptions,
+	logger logger.Logger,
+) error {
+	if !options.Enabled {
+		return nil
+	}
+
+	return web.RegisterPeriodicWorker(
+		lc,
+		"publishing scheduler",
+		time.Duration(options.IntervalSeconds)*time.Second,
+		logger,
+		func(ctx context.Context) error {
+			command, err := NewApplyPublishingSchedulesWithValidation(time.Now(), options.BatchSize)
+			if err != nil {
+				return err
+			}
+
+			_, err = cqrs.Send[*ApplyPublishingSchedules, *dtos.ApplyPublishingSchedulesResponseDto](ctx, command)
+
+			return err
+		},
+	)
+}
@@ -0,0 +1,70 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+// ArchiveProducts archives a batch of products, the products are archived in chunks and each chunk has its own
+// transaction, so the command isn't a tx request of the mediatr pipeline
+type ArchiveProducts struct {
+	cqrs.Command
+	ProductIDs []uuid.UUID
+	ArchivedAt time.Time
+}
+
+// NewArchiveProducts archive a batch of products
+func NewArchiveProducts(productIDs []uuid.UUID) *ArchiveProducts {
+	command := &ArchiveProducts{
+		Command:    cqrs.NewCommandByT[ArchiveProducts](),
+		ProductIDs: productIDs,
+		ArchivedAt: time.Now(),
+	}
+
+	return command
+}
+
+// NewArchiveProductsWithValidation archive a batch of products with inline validation - for defensive programming and ensuring validation even without using middleware
+func NewArchiveProductsWithValidation(productIDs []uuid.UUID) (*ArchiveProducts, error) {
+	command := NewArchiveProducts(productIDs)
+	err := command.Validate()
+
+	return command, err
+}
+
+func (c *ArchiveProducts) Validate() error {
+	err := validation.ValidateStruct(
+		c,
+		validation.Field(
+			&c.ProductIDs,
+			validation.Required,
+			validation.By(func(value interface{}) error {
+				productIDs, _ := value.([]uuid.UUID)
+				seen := make(map[uuid.UUID]bool, len(productIDs))
+				for _, productID := range productIDs {
+					if productID == uuid.Nil {
+						return errors.New("must not contain empty ids")
+					}
+					if seen[productID] {
+						return errors.Errorf("must not contain duplicate id %s", productID)
+					}
+					seen[productID] = true
+				}
+
+				return nil
+			}),
+		),
+		validation.Field(&c.ArchivedAt, validation.Required),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
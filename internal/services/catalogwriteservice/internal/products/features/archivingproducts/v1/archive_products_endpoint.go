@@ -0,0 +1,71 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/archivingproducts/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type archiveProductsEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewArchiveProductsEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &archiveProductsEndpoint{ProductRouteParams: params}
+}
+
+func (ep *archiveProductsEndpoint) MapEndpoint() {
+	ep.ProductsGroup.POST("/bulk-archive", ep.handler())
+}
+
+// ArchiveProducts
+// @Tags Products
+// @Summary Archive products
+// @Description Archive a batch of products, the result of each product is reported and the failed products can be retried
+// @Accept json
+// @Produce json
+// @Param ArchiveProductsRequestDto body dtos.ArchiveProductsRequestDto true "Product ids"
+// @Success 200 {object} dtos.ArchiveProductsResponseDto
+// @Router /api/v1/products/bulk-archive [post]
+func (ep *archiveProductsEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.ArchiveProductsRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		command, err := NewArchiveProductsWithValidation(request.ProductIDs)
+		if err != nil {
+			return err
+		}
+
+		result, err := cqrs.Send[*ArchiveProducts, *dtos.ArchiveProductsResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending ArchiveProducts",
+			)
+		}
+
+		return c.JSON(http.StatusOK, result)
+	}
+}
@@ -0,0 +1,162 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/contracts"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/bulkoperations"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/datamodels"
+	dtoV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/archivingproducts/v1/dtos"
+	integrationEvents "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/archivingproducts/v1/events/integrationevents"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
+
+	uuid "github.com/satori/go.uuid"
+	"gorm.io/gorm"
+)
+
+type archiveProductsHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewArchiveProductsHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*ArchiveProducts, *dtos.ArchiveProductsResponseDto] {
+	return &archiveProductsHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *archiveProductsHandler) RegisterHandler() error {
+	return cqrs.RegisterRequestHandler[*ArchiveProducts, *dtos.ArchiveProductsResponseDto](
+		c,
+	)
+}
+
+func (c *archiveProductsHandler) Handle(
+	ctx context.Context,
+	command *ArchiveProducts,
+) (*dtos.ArchiveProductsResponseDto, error) {
+	results, err := c.BulkProcessor.Process(
+		ctx,
+		command.ProductIDs,
+		func(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID]bulkoperations.ItemStatus, error) {
+			return c.archiveChunk(ctx, productIDs, command.ArchivedAt)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := bulkoperations.CountByStatus(results)
+
+	c.Log.Infow(
+		fmt.Sprintf(
+			"%d of %d products archived",
+			counts[bulkoperations.StatusSucceeded],
+			len(command.ProductIDs),
+		),
+		logger.Fields{
+			"Archived":        counts[bulkoperations.StatusSucceeded],
+			"AlreadyArchived": counts[bulkoperations.StatusSkipped],
+			"NotFound":        counts[bulkoperations.StatusNotFound],
+			"Failed":          counts[bulkoperations.StatusFailed],
+		},
+	)
+
+	return &dtos.ArchiveProductsResponseDto{
+		Archived:        counts[bulkoperations.StatusSucceeded],
+		AlreadyArchived: counts[bulkoperations.StatusSkipped],
+		NotFound:        counts[bulkoperations.StatusNotFound],
+		Failed:          counts[bulkoperations.StatusFailed],
+		Results:         dtoV1.NewBulkProductResultDtos(results),
+	}, nil
+}
+
+// archiveChunk archives the existing and not archived products of the chunk and stores a single ProductsArchivedV1 for
+// them in the outbox, with the transaction of the chunk
+func (c *archiveProductsHandler) archiveChunk(
+	ctx context.Context,
+	productIDs []uuid.UUID,
+	archivedAt time.Time,
+) (map[uuid.UUID]bulkoperations.ItemStatus, error) {
+	db := c.CatalogsDBContext.WithTxIfExists(ctx).DB().WithContext(ctx)
+
+	var dataModels []*datamodels.ProductDataModel
+	if err := db.Where("id IN ?", productIDs).Find(&dataModels).Error; err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in finding the products of the chunk")
+	}
+
+	archived := make(map[uuid.UUID]bool, len(dataModels))
+	for _, dataModel := range dataModels {
+		archived[dataModel.Id] = dataModel.IsArchived
+	}
+
+	statuses := make(map[uuid.UUID]bulkoperations.ItemStatus)
+
+	var archivedIDs []uuid.UUID
+	var archivedIDStrings []string
+
+	for _, productID := range productIDs {
+		isArchived, ok := archived[productID]
+		if !ok {
+			statuses[productID] = bulkoperations.StatusNotFound
+
+			continue
+		}
+
+		if isArchived {
+			statuses[productID] = bulkoperations.StatusSkipped
+
+			continue
+		}
+
+		archivedIDs = append(archivedIDs, productID)
+		archivedIDStrings = append(archivedIDStrings, productID.String())
+	}
+
+	if len(archivedIDs) == 0 {
+		return statuses, nil
+	}
+
+	err := db.Model(&datamodels.ProductDataModel{}).
+		Where("id IN ?", archivedIDs).
+		Updates(map[string]interface{}{
+			"is_archived":           true,
+			"archived_at":           archivedAt,
+			contracts.VersionColumn: gorm.Expr(contracts.VersionColumn + " + 1"),
+		}).
+		Error
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in archiving the products of the chunk")
+	}
+
+	if err = c.ProductHistory.Record(ctx, models.ProductUpdated, archivedIDs...); err != nil {
+		return nil, err
+	}
+
+	productsArchived := integrationEvents.NewProductsArchivedV1(archivedIDStrings, archivedAt)
+
+	if err = c.OutboxPublisher.PublishViaOutbox(ctx, productsArchived, nil); err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in publishing 'ProductsArchived' message",
+		)
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf(
+			"ProductsArchived message with messageId '%s' stored in the outbox",
+			productsArchived.MessageId,
+		),
+		logger.Fields{"MessageId": productsArchived.MessageId, "Products": len(archivedIDs)},
+	)
+
+	return statuses, nil
+}
@@ -0,0 +1,13 @@
+package dtos
+
+import (
+	uuid "github.com/satori/go.uuid"
+)
+
+// https://echo.labstack.com/guide/binding/
+// https://echo.labstack.com/guide/request/
+
+// ArchiveProductsRequestDto validation will handle in command level
+type ArchiveProductsRequestDto struct {
+	ProductIDs []uuid.UUID `json:"productIds"`
+}
@@ -0,0 +1,16 @@
+package dtos
+
+import (
+	dtoV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1"
+)
+
+// https://echo.labstack.com/guide/response/
+type ArchiveProductsResponseDto struct {
+	Archived int `json:"archived"`
+	// AlreadyArchived is the number of the products which were archived before and are skipped
+	AlreadyArchived int `json:"alreadyArchived"`
+	NotFound        int `json:"notFound"`
+	Failed          int `json:"failed"`
+	// Results are the results of the products in the order of the request
+	Results []*dtoV1.BulkProductResultDto `json:"results"`
+}
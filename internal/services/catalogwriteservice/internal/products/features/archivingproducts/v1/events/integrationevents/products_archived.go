@@ -0,0 +1,24 @@
+package integrationEvents
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// ProductsArchivedV1 is published once for each chunk of a bulk archive with the products which are archived by it
+type ProductsArchivedV1 struct {
+	*types.Message
+	ProductIds []string  `json:"productIds,omitempty"`
+	ArchivedAt time.Time `json:"archivedAt"`
+}
+
+func NewProductsArchivedV1(productIds []string, archivedAt time.Time) *ProductsArchivedV1 {
+	return &ProductsArchivedV1{
+		Message:    types.NewMessage(uuid.NewV4().String()),
+		ProductIds: productIds,
+		ArchivedAt: archivedAt,
+	}
+}
@@ -0,0 +1,102 @@
+package v1
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+type CreateAttributeSet struct {
+	cqrs.Command
+	AttributeSetID uuid.UUID
+	Category       string
+	Fields         []*models.AttributeField
+	CreatedAt      time.Time
+}
+
+// NewCreateAttributeSet Create a new attribute set for a category
+func NewCreateAttributeSet(
+	category string,
+	fields []*models.AttributeField,
+) *CreateAttributeSet {
+	command := &CreateAttributeSet{
+		Command:        cqrs.NewCommandByT[CreateAttributeSet](),
+		AttributeSetID: uuid.NewV4(),
+		Category:       category,
+		Fields:         fields,
+		CreatedAt:      time.Now(),
+	}
+
+	return command
+}
+
+// NewCreateAttributeSetWithValidation Create a new attribute set with inline validation - for defensive programming and ensuring validation even without using middleware
+func NewCreateAttributeSetWithValidation(
+	category string,
+	fields []*models.AttributeField,
+) (*CreateAttributeSet, error) {
+	command := NewCreateAttributeSet(category, fields)
+	err := command.Validate()
+
+	return command, err
+}
+
+func (c *CreateAttributeSet) isTxRequest() {
+}
+
+func (c *CreateAttributeSet) Validate() error {
+	err := validation.ValidateStruct(
+		c,
+		validation.Field(&c.AttributeSetID, validation.Required),
+		validation.Field(
+			&c.Category,
+			validation.Required,
+			validation.Length(0, 255),
+		),
+		validation.Field(&c.Fields, validation.Required),
+		validation.Field(&c.CreatedAt, validation.Required),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	names := make(map[string]bool, len(c.Fields))
+	for _, field := range c.Fields {
+		err = validation.ValidateStruct(
+			field,
+			validation.Field(&field.Name, validation.Required, validation.Length(0, 100)),
+			validation.Field(
+				&field.Type,
+				validation.Required,
+				validation.In(
+					models.AttributeTypeString,
+					models.AttributeTypeNumber,
+					models.AttributeTypeBoolean,
+					models.AttributeTypeEnum,
+				),
+			),
+		)
+		if err != nil {
+			return customErrors.NewValidationErrorWrap(err, "validation error")
+		}
+
+		if field.Type == models.AttributeTypeEnum && len(field.Options) == 0 {
+			return customErrors.NewValidationError(
+				fmt.Sprintf("enum attribute field `%s` should have options", field.Name),
+			)
+		}
+
+		if names[field.Name] {
+			return customErrors.NewValidationError("attribute field names should be unique")
+		}
+		names[field.Name] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/creatingattributeset/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type createAttributeSetEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewCreateAttributeSetEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &createAttributeSetEndpoint{ProductRouteParams: params}
+}
+
+func (ep *createAttributeSetEndpoint) MapEndpoint() {
+	ep.AttributeSetsGroup.POST("", ep.handler())
+}
+
+// CreateAttributeSet
+// @Tags AttributeSets
+// @Summary Create attribute set
+// @Description Create typed custom fields definition for products of a category
+// @Accept json
+// @Produce json
+// @Param CreateAttributeSetRequestDto body dtos.CreateAttributeSetRequestDto true "Attribute set data"
+// @Success 201 {object} dtos.CreateAttributeSetResponseDto
+// @Router /api/v1/attribute-sets [post]
+func (ep *createAttributeSetEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.CreateAttributeSetRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		fields := make([]*models.AttributeField, 0, len(request.Fields))
+		for _, field := range request.Fields {
+			fields = append(fields, &models.AttributeField{
+				Name:     field.Name,
+				Type:     models.AttributeType(field.Type),
+				Required: field.Required,
+				Options:  field.Options,
+			})
+		}
+
+		command, err := NewCreateAttributeSetWithValidation(request.Category, fields)
+		if err != nil {
+			return err
+		}
+
+		result, err := cqrs.Send[*CreateAttributeSet, *dtos.CreateAttributeSetResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending CreateAttributeSet",
+			)
+		}
+
+		return c.JSON(http.StatusCreated, result)
+	}
+}
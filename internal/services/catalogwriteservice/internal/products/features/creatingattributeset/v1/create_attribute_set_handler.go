@@ -0,0 +1,79 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/gormdbcontext"
+	datamodel "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/datamodels"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/creatingattributeset/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
+)
+
+type createAttributeSetHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewCreateAttributeSetHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*CreateAttributeSet, *dtos.CreateAttributeSetResponseDto] {
+	return &createAttributeSetHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *createAttributeSetHandler) RegisterHandler() error {
+	return cqrs.RegisterRequestHandler[*CreateAttributeSet, *dtos.CreateAttributeSetResponseDto](
+		c,
+	)
+}
+
+func (c *createAttributeSetHandler) Handle(
+	ctx context.Context,
+	command *CreateAttributeSet,
+) (*dtos.CreateAttributeSetResponseDto, error) {
+	existing, err := c.AttributesValidator.FindAttributeSetByCategory(ctx, command.Category)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil {
+		return nil, customErrors.NewConflictError(
+			fmt.Sprintf(
+				"attribute set for category `%s` already exists",
+				command.Category,
+			),
+		)
+	}
+
+	attributeSet := &models.AttributeSet{
+		Id:        command.AttributeSetID,
+		Category:  command.Category,
+		Fields:    command.Fields,
+		CreatedAt: command.CreatedAt,
+	}
+
+	result, err := gormdbcontext.AddModel[*datamodel.AttributeSetDataModel, *models.AttributeSet](
+		ctx,
+		c.CatalogsDBContext,
+		attributeSet,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf(
+			"attribute set with id '%s' for category '%s' created",
+			result.Id,
+			result.Category,
+		),
+		logger.Fields{"Id": result.Id, "Category": result.Category},
+	)
+
+	return &dtos.CreateAttributeSetResponseDto{AttributeSetID: result.Id}, nil
+}
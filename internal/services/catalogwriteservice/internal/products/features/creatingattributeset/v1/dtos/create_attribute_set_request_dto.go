@@ -0,0 +1,14 @@
+package dtos
+
+import (
+	dtoV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1"
+)
+
+// https://echo.labstack.com/guide/binding/
+// https://echo.labstack.com/guide/request/
+
+// CreateAttributeSetRequestDto validation will handle in command level
+type CreateAttributeSetRequestDto struct {
+	Category string                     `json:"category"`
+	Fields   []*dtoV1.AttributeFieldDto `json:"fields"`
+}
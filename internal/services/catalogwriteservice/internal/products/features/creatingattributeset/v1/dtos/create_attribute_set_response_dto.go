@@ -0,0 +1,16 @@
+package dtos
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer/json"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// https://echo.labstack.com/guide/response/
+type CreateAttributeSetResponseDto struct {
+	AttributeSetID uuid.UUID `json:"attributeSetId"`
+}
+
+func (c *CreateAttributeSetResponseDto) String() string {
+	return json.PrettyPrint(c)
+}
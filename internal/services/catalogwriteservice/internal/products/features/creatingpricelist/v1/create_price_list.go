@@ -0,0 +1,103 @@
+package v1
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+type CreatePriceList struct {
+	cqrs.Command
+	PriceListID   uuid.UUID
+	Name          string
+	CustomerGroup string
+	EffectiveFrom time.Time
+	EffectiveTo   *time.Time
+	Prices        []*models.ProductPrice
+	CreatedAt     time.Time
+}
+
+// NewCreatePriceList Create a new price list for a customer group
+func NewCreatePriceList(
+	name string,
+	customerGroup string,
+	effectiveFrom time.Time,
+	effectiveTo *time.Time,
+	prices []*models.ProductPrice,
+) *CreatePriceList {
+	command := &CreatePriceList{
+		Command:       cqrs.NewCommandByT[CreatePriceList](),
+		PriceListID:   uuid.NewV4(),
+		Name:          name,
+		CustomerGroup: customerGroup,
+		EffectiveFrom: effectiveFrom,
+		EffectiveTo:   effectiveTo,
+		Prices:        prices,
+		CreatedAt:     time.Now(),
+	}
+
+	return command
+}
+
+// NewCreatePriceListWithValidation Create a new price list with inline validation - for defensive programming and ensuring validation even without using middleware
+func NewCreatePriceListWithValidation(
+	name string,
+	customerGroup string,
+	effectiveFrom time.Time,
+	effectiveTo *time.Time,
+	prices []*models.ProductPrice,
+) (*CreatePriceList, error) {
+	command := NewCreatePriceList(name, customerGroup, effectiveFrom, effectiveTo, prices)
+	err := command.Validate()
+
+	return command, err
+}
+
+func (c *CreatePriceList) isTxRequest() {
+}
+
+func (c *CreatePriceList) Validate() error {
+	err := validation.ValidateStruct(
+		c,
+		validation.Field(&c.PriceListID, validation.Required),
+		validation.Field(&c.Name, validation.Required, validation.Length(0, 255)),
+		validation.Field(&c.CustomerGroup, validation.Required, validation.Length(0, 100)),
+		validation.Field(&c.EffectiveFrom, validation.Required),
+		validation.Field(&c.Prices, validation.Required),
+		validation.Field(&c.CreatedAt, validation.Required),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	if c.EffectiveTo != nil && !c.EffectiveTo.After(c.EffectiveFrom) {
+		return customErrors.NewValidationError("effectiveTo should be after effectiveFrom")
+	}
+
+	products := make(map[uuid.UUID]bool, len(c.Prices))
+	for _, price := range c.Prices {
+		err = validation.ValidateStruct(
+			price,
+			validation.Field(&price.ProductId, validation.Required),
+			validation.Field(&price.Price, validation.Required, validation.Min(0.0).Exclusive()),
+		)
+		if err != nil {
+			return customErrors.NewValidationErrorWrap(err, "validation error")
+		}
+
+		if products[price.ProductId] {
+			return customErrors.NewValidationError(
+				fmt.Sprintf("product `%s` has more than one price in the price list", price.ProductId),
+			)
+		}
+		products[price.ProductId] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/creatingpricelist/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type createPriceListEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewCreatePriceListEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &createPriceListEndpoint{ProductRouteParams: params}
+}
+
+func (ep *createPriceListEndpoint) MapEndpoint() {
+	ep.PriceListsGroup.POST("", ep.handler())
+}
+
+// CreatePriceList
+// @Tags PriceLists
+// @Summary Create price list
+// @Description Create product prices of a customer group with effective dates
+// @Accept json
+// @Produce json
+// @Param CreatePriceListRequestDto body dtos.CreatePriceListRequestDto true "Price list data"
+// @Success 201 {object} dtos.CreatePriceListResponseDto
+// @Router /api/v1/price-lists [post]
+func (ep *createPriceListEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.CreatePriceListRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		prices := make([]*models.ProductPrice, 0, len(request.Prices))
+		for _, price := range request.Prices {
+			prices = append(prices, &models.ProductPrice{ProductId: price.ProductId, Price: price.Price})
+		}
+
+		command, err := NewCreatePriceListWithValidation(
+			request.Name,
+			request.CustomerGroup,
+			request.EffectiveFrom,
+			request.EffectiveTo,
+			prices,
+		)
+		if err != nil {
+			return err
+		}
+
+		result, err := cqrs.Send[*CreatePriceList, *dtos.CreatePriceListResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending CreatePriceList",
+			)
+		}
+
+		return c.JSON(http.StatusCreated, result)
+	}
+}
@@ -0,0 +1,93 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mapper"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/gormdbcontext"
+	datamodel "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/datamodels"
+	dtoV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/creatingpricelist/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/creatingpricelist/v1/events/integrationevents"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
+)
+
+type createPriceListHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewCreatePriceListHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*CreatePriceList, *dtos.CreatePriceListResponseDto] {
+	return &createPriceListHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *createPriceListHandler) RegisterHandler() error {
+	return cqrs.RegisterRequestHandler[*CreatePriceList, *dtos.CreatePriceListResponseDto](
+		c,
+	)
+}
+
+func (c *createPriceListHandler) Handle(
+	ctx context.Context,
+	command *CreatePriceList,
+) (*dtos.CreatePriceListResponseDto, error) {
+	priceList := &models.PriceList{
+		Id:            command.PriceListID,
+		Name:          command.Name,
+		CustomerGroup: command.CustomerGroup,
+		EffectiveFrom: command.EffectiveFrom,
+		EffectiveTo:   command.EffectiveTo,
+		Prices:        command.Prices,
+		CreatedAt:     command.CreatedAt,
+	}
+
+	result, err := gormdbcontext.AddModel[*datamodel.PriceListDataModel, *models.PriceList](
+		ctx,
+		c.CatalogsDBContext,
+		priceList,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	priceListDto, err := mapper.Map[*dtoV1.PriceListDto](result)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in the mapping PriceListDto",
+		)
+	}
+
+	priceListCreated := integrationevents.NewPriceListCreatedV1(priceListDto)
+
+	err = c.OutboxPublisher.PublishViaOutbox(ctx, priceListCreated, nil)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in publishing PriceListCreated integration_events event",
+		)
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf(
+			"price list with id '%s' for customer group '%s' created",
+			result.Id,
+			result.CustomerGroup,
+		),
+		logger.Fields{
+			"Id":            result.Id,
+			"CustomerGroup": result.CustomerGroup,
+			"MessageId":     priceListCreated.MessageId,
+		},
+	)
+
+	return &dtos.CreatePriceListResponseDto{PriceListID: result.Id}, nil
+}
@@ -0,0 +1,22 @@
+package dtos
+
+import (
+	"time"
+
+	dtoV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1"
+)
+
+// https://echo.labstack.com/guide/binding/
+// https://echo.labstack.com/guide/request/
+
+// CreatePriceListRequestDto validation will handle in command level
+type CreatePriceListRequestDto struct {
+	Name string `json:"name"`
+	// CustomerGroup is the customer group of the price list, like a b2b tier, it is matched with the `customer_group`
+	// claim of the customers
+	CustomerGroup string `json:"customerGroup"`
+	// EffectiveTo is optional, a price list without it stays effective from the EffectiveFrom
+	EffectiveFrom time.Time                `json:"effectiveFrom"`
+	EffectiveTo   *time.Time               `json:"effectiveTo,omitempty"`
+	Prices        []*dtoV1.ProductPriceDto `json:"prices"`
+}
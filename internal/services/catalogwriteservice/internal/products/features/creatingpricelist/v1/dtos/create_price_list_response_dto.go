@@ -0,0 +1,16 @@
+package dtos
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer/json"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// https://echo.labstack.com/guide/response/
+type CreatePriceListResponseDto struct {
+	PriceListID uuid.UUID `json:"priceListId"`
+}
+
+func (c *CreatePriceListResponseDto) String() string {
+	return json.PrettyPrint(c)
+}
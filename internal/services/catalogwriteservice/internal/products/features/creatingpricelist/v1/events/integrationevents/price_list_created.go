@@ -0,0 +1,21 @@
+package integrationevents
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	dtoV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// PriceListCreatedV1 is consumed by the orders service for resolving the prices of the order items
+type PriceListCreatedV1 struct {
+	*types.Message
+	*dtoV1.PriceListDto
+}
+
+func NewPriceListCreatedV1(priceListDto *dtoV1.PriceListDto) *PriceListCreatedV1 {
+	return &PriceListCreatedV1{
+		PriceListDto: priceListDto,
+		Message:      types.NewMessage(uuid.NewV4().String()),
+	}
+}
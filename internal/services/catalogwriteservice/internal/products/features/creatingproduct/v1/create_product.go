@@ -5,6 +5,9 @@ import (
 
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
 	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/datatypes"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/identifiers"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
 
 	validation "github.com/go-ozzo/ozzo-validation"
 	uuid "github.com/satori/go.uuid"
@@ -19,7 +22,17 @@ type CreateProduct struct {
 	Name        string
 	Description string
 	Price       float64
-	CreatedAt   time.Time
+	// Sku and Barcode are optional, they will be generated by the handler when they are empty
+	Sku     string
+	Barcode string
+	// Category and Attributes are optional, attributes will be validated against the attribute set of the category
+	Category   string
+	Attributes datatypes.JSONMap
+	// Translations are localized name and description by locale
+	Translations map[string]*models.ProductTranslation
+	// PublishingSchedule is optional, without it the product will be published immediately
+	PublishingSchedule models.PublishingSchedule
+	CreatedAt          time.Time
 }
 
 // NewCreateProduct Create a new product
@@ -52,7 +65,13 @@ func NewCreateProductWithValidation(
 	return command, err
 }
 
-func (c *CreateProduct) isTxRequest(){
+func (c *CreateProduct) isTxRequest() {
+}
+
+// Normalize normalizes the product identifiers, it is called by the validation pipeline before validating the command
+func (c *CreateProduct) Normalize() {
+	c.Sku = identifiers.NormalizeSku(c.Sku)
+	c.Barcode = identifiers.NormalizeGtin(c.Barcode)
 }
 
 func (c *CreateProduct) Validate() error {
@@ -74,11 +93,19 @@ func (c *CreateProduct) Validate() error {
 			validation.Required,
 			validation.Min(0.0).Exclusive(),
 		),
+		validation.Field(&c.Sku, identifiers.Sku),
+		validation.Field(&c.Category, validation.Length(0, 255)),
+		validation.Field(&c.Barcode, identifiers.Gtin),
 		validation.Field(&c.CreatedAt, validation.Required),
 	)
 	if err != nil {
 		return customErrors.NewValidationErrorWrap(err, "validation error")
 	}
 
+	err = c.PublishingSchedule.Validate()
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
 	return nil
 }
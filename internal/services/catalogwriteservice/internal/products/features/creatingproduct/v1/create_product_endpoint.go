@@ -3,14 +3,15 @@ package v1
 import (
 	"net/http"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
 	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/creatingproduct/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
 
 	"emperror.dev/errors"
 	"github.com/labstack/echo/v4"
-	"github.com/mehdihadeli/go-mediatr"
 )
 
 type createProductEndpoint struct {
@@ -50,16 +51,35 @@ func (ep *createProductEndpoint) handler() echo.HandlerFunc {
 			return badRequestErr
 		}
 
-		command, err := NewCreateProductWithValidation(
+		command := NewCreateProduct(
 			request.Name,
 			request.Description,
 			request.Price,
 		)
+		command.Sku = request.Sku
+		command.Barcode = request.Barcode
+		command.Category = request.Category
+		command.Attributes = request.Attributes
+
+		translations := make(map[string]*models.ProductTranslation, len(request.Translations))
+		for locale, translation := range request.Translations {
+			if translation != nil {
+				translations[locale] = models.NewProductTranslation(translation.Name, translation.Description)
+			}
+		}
+		normalizedTranslations, err := models.NormalizeTranslations(translations)
 		if err != nil {
 			return err
 		}
+		command.Translations = normalizedTranslations
+		command.PublishingSchedule = models.NewPublishingSchedule(request.PublishAt, request.UnpublishAt)
+
+		command.Normalize()
+		if err = command.Validate(); err != nil {
+			return err
+		}
 
-		result, err := mediatr.Send[*CreateProduct, *dtos.CreateProductResponseDto](
+		result, err := cqrs.Send[*CreateProduct, *dtos.CreateProductResponseDto](
 			ctx,
 			command,
 		)
@@ -16,7 +16,7 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/creatingproduct/v1/events/integrationevents"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
 
-	"github.com/mehdihadeli/go-mediatr"
+	"emperror.dev/errors"
 )
 
 type createProductHandler struct {
@@ -32,7 +32,7 @@ func NewCreateProductHandler(
 }
 
 func (c *createProductHandler) RegisterHandler() error {
-	return mediatr.RegisterRequestHandler[*CreateProduct, *dtos.CreateProductResponseDto](
+	return cqrs.RegisterRequestHandler[*CreateProduct, *dtos.CreateProductResponseDto](
 		c,
 	)
 }
@@ -41,12 +41,34 @@ func (c *createProductHandler) Handle(
 	ctx context.Context,
 	command *CreateProduct,
 ) (*dtos.CreateProductResponseDto, error) {
+	sku, barcode, err := c.resolveProductCodes(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.AttributesValidator.ValidateProductAttributes(
+		ctx,
+		command.Category,
+		command.Attributes,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	product := &models.Product{
-		Id:          command.ProductID,
-		Name:        command.Name,
-		Description: command.Description,
-		Price:       command.Price,
-		CreatedAt:   command.CreatedAt,
+		Id:           command.ProductID,
+		Name:         command.Name,
+		Description:  command.Description,
+		Price:        command.Price,
+		Sku:          sku,
+		Barcode:      barcode,
+		Category:     command.Category,
+		Attributes:   command.Attributes,
+		Translations: command.Translations,
+		PublishAt:    command.PublishingSchedule.PublishAt,
+		UnpublishAt:  command.PublishingSchedule.UnpublishAt,
+		IsPublished:  command.PublishingSchedule.IsPublishedAt(command.CreatedAt),
+		CreatedAt:    command.CreatedAt,
 	}
 
 	var createProductResult *dtos.CreateProductResponseDto
@@ -60,6 +82,11 @@ func (c *createProductHandler) Handle(
 		return nil, err
 	}
 
+	err = c.ProductHistory.Record(ctx, models.ProductCreated, result.Id)
+	if err != nil {
+		return nil, err
+	}
+
 	productDto, err := mapper.Map[*dtosv1.ProductDto](result)
 	if err != nil {
 		return nil, customErrors.NewApplicationErrorWrap(
@@ -72,7 +99,7 @@ func (c *createProductHandler) Handle(
 		productDto,
 	)
 
-	err = c.RabbitmqProducer.PublishMessage(ctx, productCreated, nil)
+	err = c.OutboxPublisher.PublishViaOutbox(ctx, productCreated, nil)
 	if err != nil {
 		return nil, customErrors.NewApplicationErrorWrap(
 			err,
@@ -82,7 +109,7 @@ func (c *createProductHandler) Handle(
 
 	c.Log.Infow(
 		fmt.Sprintf(
-			"ProductCreated message with messageId `%s` published to the rabbitmq broker",
+			"ProductCreated message with messageId `%s` stored in the outbox",
 			productCreated.MessageId,
 		),
 		logger.Fields{"MessageId": productCreated.MessageId},
@@ -105,3 +132,41 @@ func (c *createProductHandler) Handle(
 
 	return createProductResult, err
 }
+
+// resolveProductCodes uses the sku and barcode of the command if they are provided and still unique, otherwise generates new ones
+func (c *createProductHandler) resolveProductCodes(
+	ctx context.Context,
+	command *CreateProduct,
+) (string, string, error) {
+	sku := command.Sku
+	if sku == "" {
+		generatedSku, err := c.SkuGenerator.GenerateSku(ctx, command.Name)
+		if err != nil {
+			return "", "", errors.WithMessage(err, "error in generating product sku")
+		}
+		sku = generatedSku
+	} else if exists, err := c.SkuGenerator.SkuExists(ctx, sku); err != nil {
+		return "", "", err
+	} else if exists {
+		return "", "", customErrors.NewConflictError(
+			fmt.Sprintf("product with sku `%s` already exists", sku),
+		)
+	}
+
+	barcode := command.Barcode
+	if barcode == "" {
+		generatedBarcode, err := c.SkuGenerator.GenerateEan(ctx)
+		if err != nil {
+			return "", "", errors.WithMessage(err, "error in generating product barcode")
+		}
+		barcode = generatedBarcode
+	} else if exists, err := c.SkuGenerator.BarcodeExists(ctx, barcode); err != nil {
+		return "", "", err
+	} else if exists {
+		return "", "", customErrors.NewConflictError(
+			fmt.Sprintf("product with barcode `%s` already exists", barcode),
+		)
+	}
+
+	return sku, barcode, nil
+}
@@ -1,5 +1,11 @@
 package dtos
 
+import (
+	"time"
+
+	dtoV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1"
+)
+
 // https://echo.labstack.com/guide/binding/
 // https://echo.labstack.com/guide/request/
 // https://github.com/go-playground/validator
@@ -9,4 +15,15 @@ type CreateProductRequestDto struct {
 	Name        string  `json:"name"`
 	Description string  `json:"description"`
 	Price       float64 `json:"price"`
+	// Sku and Barcode are optional and will be generated when they are not provided
+	Sku     string `json:"sku,omitempty"`
+	Barcode string `json:"barcode,omitempty"`
+	// Attributes are custom fields of the product which should match with attribute set of the Category
+	Category   string                 `json:"category,omitempty"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	// Translations are localized name and description by BCP 47 locale, for example `fr-CA`
+	Translations map[string]*dtoV1.ProductTranslationDto `json:"translations,omitempty"`
+	// PublishAt and UnpublishAt are the optional publishing window of the product
+	PublishAt   *time.Time `json:"publishAt,omitempty"`
+	UnpublishAt *time.Time `json:"unpublishAt,omitempty"`
 }
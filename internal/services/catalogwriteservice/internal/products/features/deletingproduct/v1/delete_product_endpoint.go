@@ -3,6 +3,7 @@ package v1
 import (
 	"net/http"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
 	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
@@ -55,7 +56,7 @@ func (ep *deleteProductEndpoint) handler() echo.HandlerFunc {
 			return err
 		}
 
-		_, err = mediatr.Send[*DeleteProduct, *mediatr.Unit](
+		_, err = cqrs.Send[*DeleteProduct, *mediatr.Unit](
 			ctx,
 			command,
 		)
@@ -11,6 +11,7 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/datamodels"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
 	integrationEvents "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/deletingproduct/v1/events/integrationevents"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
 
 	"github.com/mehdihadeli/go-mediatr"
 )
@@ -28,7 +29,7 @@ func NewDeleteProductHandler(
 }
 
 func (c *deleteProductHandler) RegisterHandler() error {
-	return mediatr.RegisterRequestHandler[*DeleteProduct, *mediatr.Unit](
+	return cqrs.RegisterRequestHandler[*DeleteProduct, *mediatr.Unit](
 		c,
 	)
 }
@@ -46,11 +47,16 @@ func (c *deleteProductHandler) Handle(
 		return nil, err
 	}
 
+	err = c.ProductHistory.Record(ctx, models.ProductDeleted, command.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
 	productDeleted := integrationEvents.NewProductDeletedV1(
 		command.ProductID.String(),
 	)
 
-	if err = c.RabbitmqProducer.PublishMessage(ctx, productDeleted, nil); err != nil {
+	if err = c.OutboxPublisher.PublishViaOutbox(ctx, productDeleted, nil); err != nil {
 		return nil, customErrors.NewApplicationErrorWrap(
 			err,
 			"error in publishing 'ProductDeleted' message",
@@ -59,7 +65,7 @@ func (c *deleteProductHandler) Handle(
 
 	c.Log.Infow(
 		fmt.Sprintf(
-			"ProductDeleted message with messageId '%s' published to the rabbitmq broker",
+			"ProductDeleted message with messageId '%s' stored in the outbox",
 			productDeleted.MessageId,
 		),
 		logger.Fields{"MessageId": productDeleted.MessageId},
@@ -0,0 +1,65 @@
+package v1
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+// DeleteProducts deletes a batch of products, the products are deleted in chunks and each chunk has its own
+// transaction, so the command isn't a tx request of the mediatr pipeline
+type DeleteProducts struct {
+	cqrs.Command
+	ProductIDs []uuid.UUID
+}
+
+// NewDeleteProducts delete a batch of products
+func NewDeleteProducts(productIDs []uuid.UUID) *DeleteProducts {
+	command := &DeleteProducts{
+		Command:    cqrs.NewCommandByT[DeleteProducts](),
+		ProductIDs: productIDs,
+	}
+
+	return command
+}
+
+// NewDeleteProductsWithValidation delete a batch of products with inline validation - for defensive programming and ensuring validation even without using middleware
+func NewDeleteProductsWithValidation(productIDs []uuid.UUID) (*DeleteProducts, error) {
+	command := NewDeleteProducts(productIDs)
+	err := command.Validate()
+
+	return command, err
+}
+
+func (c *DeleteProducts) Validate() error {
+	err := validation.ValidateStruct(
+		c,
+		validation.Field(
+			&c.ProductIDs,
+			validation.Required,
+			validation.By(func(value interface{}) error {
+				productIDs, _ := value.([]uuid.UUID)
+				seen := make(map[uuid.UUID]bool, len(productIDs))
+				for _, productID := range productIDs {
+					if productID == uuid.Nil {
+						return errors.New("must not contain empty ids")
+					}
+					if seen[productID] {
+						return errors.Errorf("must not contain duplicate id %s", productID)
+					}
+					seen[productID] = true
+				}
+
+				return nil
+			}),
+		),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
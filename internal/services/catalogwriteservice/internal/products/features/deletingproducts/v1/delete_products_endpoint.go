@@ -0,0 +1,71 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/deletingproducts/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type deleteProductsEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewDeleteProductsEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &deleteProductsEndpoint{ProductRouteParams: params}
+}
+
+func (ep *deleteProductsEndpoint) MapEndpoint() {
+	ep.ProductsGroup.POST("/bulk-delete", ep.handler())
+}
+
+// DeleteProducts
+// @Tags Products
+// @Summary Delete products
+// @Description Delete a batch of products, the result of each product is reported and the failed products can be retried
+// @Accept json
+// @Produce json
+// @Param DeleteProductsRequestDto body dtos.DeleteProductsRequestDto true "Product ids"
+// @Success 200 {object} dtos.DeleteProductsResponseDto
+// @Router /api/v1/products/bulk-delete [post]
+func (ep *deleteProductsEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.DeleteProductsRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		command, err := NewDeleteProductsWithValidation(request.ProductIDs)
+		if err != nil {
+			return err
+		}
+
+		result, err := cqrs.Send[*DeleteProducts, *dtos.DeleteProductsResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending DeleteProducts",
+			)
+		}
+
+		return c.JSON(http.StatusOK, result)
+	}
+}
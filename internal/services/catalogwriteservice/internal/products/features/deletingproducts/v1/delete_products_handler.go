@@ -0,0 +1,136 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/bulkoperations"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/datamodels"
+	dtoV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/deletingproducts/v1/dtos"
+	integrationEvents "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/deletingproducts/v1/events/integrationevents"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type deleteProductsHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewDeleteProductsHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*DeleteProducts, *dtos.DeleteProductsResponseDto] {
+	return &deleteProductsHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *deleteProductsHandler) RegisterHandler() error {
+	return cqrs.RegisterRequestHandler[*DeleteProducts, *dtos.DeleteProductsResponseDto](
+		c,
+	)
+}
+
+func (c *deleteProductsHandler) Handle(
+	ctx context.Context,
+	command *DeleteProducts,
+) (*dtos.DeleteProductsResponseDto, error) {
+	results, err := c.BulkProcessor.Process(ctx, command.ProductIDs, c.deleteChunk)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := bulkoperations.CountByStatus(results)
+
+	c.Log.Infow(
+		fmt.Sprintf(
+			"%d of %d products deleted",
+			counts[bulkoperations.StatusSucceeded],
+			len(command.ProductIDs),
+		),
+		logger.Fields{
+			"Deleted":  counts[bulkoperations.StatusSucceeded],
+			"NotFound": counts[bulkoperations.StatusNotFound],
+			"Failed":   counts[bulkoperations.StatusFailed],
+		},
+	)
+
+	return &dtos.DeleteProductsResponseDto{
+		Deleted:  counts[bulkoperations.StatusSucceeded],
+		NotFound: counts[bulkoperations.StatusNotFound],
+		Failed:   counts[bulkoperations.StatusFailed],
+		Results:  dtoV1.NewBulkProductResultDtos(results),
+	}, nil
+}
+
+// deleteChunk deletes the existing products of the chunk and stores a single ProductsDeletedV1 for them in the outbox,
+// with the transaction of the chunk
+func (c *deleteProductsHandler) deleteChunk(
+	ctx context.Context,
+	productIDs []uuid.UUID,
+) (map[uuid.UUID]bulkoperations.ItemStatus, error) {
+	db := c.CatalogsDBContext.WithTxIfExists(ctx).DB().WithContext(ctx)
+
+	var dataModels []*datamodels.ProductDataModel
+	if err := db.Where("id IN ?", productIDs).Find(&dataModels).Error; err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in finding the products of the chunk")
+	}
+
+	existing := make(map[uuid.UUID]bool, len(dataModels))
+	for _, dataModel := range dataModels {
+		existing[dataModel.Id] = true
+	}
+
+	statuses := make(map[uuid.UUID]bulkoperations.ItemStatus)
+
+	var deletedIDs []uuid.UUID
+	var deletedIDStrings []string
+
+	for _, productID := range productIDs {
+		if !existing[productID] {
+			statuses[productID] = bulkoperations.StatusNotFound
+
+			continue
+		}
+
+		deletedIDs = append(deletedIDs, productID)
+		deletedIDStrings = append(deletedIDStrings, productID.String())
+	}
+
+	if len(deletedIDs) == 0 {
+		return statuses, nil
+	}
+
+	// https://gorm.io/docs/delete.html#Soft-Delete
+	if err := db.Where("id IN ?", deletedIDs).Delete(&datamodels.ProductDataModel{}).Error; err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in deleting the products of the chunk")
+	}
+
+	if err := c.ProductHistory.Record(ctx, models.ProductDeleted, deletedIDs...); err != nil {
+		return nil, err
+	}
+
+	productsDeleted := integrationEvents.NewProductsDeletedV1(deletedIDStrings)
+
+	if err := c.OutboxPublisher.PublishViaOutbox(ctx, productsDeleted, nil); err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in publishing 'ProductsDeleted' message",
+		)
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf(
+			"ProductsDeleted message with messageId '%s' stored in the outbox",
+			productsDeleted.MessageId,
+		),
+		logger.Fields{"MessageId": productsDeleted.MessageId, "Products": len(deletedIDs)},
+	)
+
+	return statuses, nil
+}
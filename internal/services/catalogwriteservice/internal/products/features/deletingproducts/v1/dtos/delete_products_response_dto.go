@@ -0,0 +1,14 @@
+package dtos
+
+import (
+	dtoV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1"
+)
+
+// https://echo.labstack.com/guide/response/
+type DeleteProductsResponseDto struct {
+	Deleted  int `json:"deleted"`
+	NotFound int `json:"notFound"`
+	Failed   int `json:"failed"`
+	// Results are the results of the products in the order of the request
+	Results []*dtoV1.BulkProductResultDto `json:"results"`
+}
@@ -0,0 +1,17 @@
+package integrationEvents
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// ProductsDeletedV1 is published once for each chunk of a bulk delete instead of a ProductDeletedV1 for each product
+type ProductsDeletedV1 struct {
+	*types.Message
+	ProductIds []string `json:"productIds,omitempty"`
+}
+
+func NewProductsDeletedV1(productIds []string) *ProductsDeletedV1 {
+	return &ProductsDeletedV1{ProductIds: productIds, Message: types.NewMessage(uuid.NewV4().String())}
+}
@@ -0,0 +1,10 @@
+package dtos
+
+// https://echo.labstack.com/guide/binding/
+// https://echo.labstack.com/guide/request/
+
+// ExportProductsRequestDto validation will handle in query level
+type ExportProductsRequestDto struct {
+	// ChunkSize is the number of products which are flushed to the client together
+	ChunkSize int `query:"chunkSize" json:"-"`
+}
@@ -0,0 +1,7 @@
+package dtos
+
+// ExportProductsResponseDto is the summary of an export, the products themselves are streamed to the export writer
+type ExportProductsResponseDto struct {
+	ExportedCount int64
+	FlushesCount  int64
+}
@@ -0,0 +1,63 @@
+package v1
+
+import (
+	"io"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const (
+	DefaultExportChunkSize = 100
+	MaxExportChunkSize     = 5000
+)
+
+// ExportWriter is the destination of the exported products, Flush sends the written chunk to the client
+type ExportWriter interface {
+	io.Writer
+	Flush() error
+}
+
+// ExportProducts streams all products to the Writer as NDJSON, one product per line, and flushes the Writer after each
+// chunk of products
+type ExportProducts struct {
+	cqrs.Query
+	Writer    ExportWriter `json:"-"`
+	ChunkSize int
+}
+
+func NewExportProducts(writer ExportWriter, chunkSize int) *ExportProducts {
+	if chunkSize == 0 {
+		chunkSize = DefaultExportChunkSize
+	}
+
+	query := &ExportProducts{
+		Query:     cqrs.NewQueryByT[ExportProducts](),
+		Writer:    writer,
+		ChunkSize: chunkSize,
+	}
+
+	return query
+}
+
+func NewExportProductsWithValidation(writer ExportWriter, chunkSize int) (*ExportProducts, error) {
+	query := NewExportProducts(writer, chunkSize)
+	err := query.Validate()
+
+	return query, err
+}
+
+func (p *ExportProducts) Validate() error {
+	err := validation.ValidateStruct(
+		p,
+		validation.Field(&p.Writer, validation.Required),
+		validation.Field(&p.ChunkSize, validation.Required, validation.Min(1), validation.Max(MaxExportChunkSize)),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/jobs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/exportingproducts/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	ndjsonContentType = "application/x-ndjson"
+	exportJobName     = "export-products"
+)
+
+type exportProductsEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewExportProductsEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &exportProductsEndpoint{ProductRouteParams: params}
+}
+
+func (ep *exportProductsEndpoint) MapEndpoint() {
+	ep.ProductsGroup.GET("/export", ep.handler())
+}
+
+// ExportProducts
+// @Tags Products
+// @Summary Export products
+// @Description Stream all products as NDJSON, one product per line. The stream is compressed by the gzip middleware for the clients which accept gzip encoding, and it is flushed after each chunk of products.
+// @Produce x-ndjson
+// @Param exportProductsRequestDto query dtos.ExportProductsRequestDto false "ExportProductsRequestDto"
+// @Success 200 {file} binary
+// @Router /api/v1/products/export [get]
+func (ep *exportProductsEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.ExportProductsRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		writer := &responseExportWriter{response: c.Response()}
+
+		query, err := NewExportProductsWithValidation(writer, request.ChunkSize)
+		if err != nil {
+			return err
+		}
+
+		// the export runs as a job, so its progress and summary are kept in the job runs
+		run, queryResult, err := jobs.Run(
+			ctx,
+			ep.JobRunner,
+			exportJobName,
+			func(ctx context.Context) (*dtos.ExportProductsResponseDto, error) {
+				return cqrs.Send[*ExportProducts, *dtos.ExportProductsResponseDto](ctx, query)
+			},
+		)
+		if err != nil {
+			// after committing the response the error can't be written as a problem detail anymore, the client sees a
+			// truncated stream
+			return errors.WithMessage(
+				err,
+				"error in sending ExportProducts",
+			)
+		}
+
+		ep.Logger.Infof("%d products exported in job run %s", queryResult.ExportedCount, run.Id)
+
+		return nil
+	}
+}
+
+// responseExportWriter commits the response on the first write, so failures before exporting any product are still
+// returned as problem details
+type responseExportWriter struct {
+	response *echo.Response
+}
+
+func (w *responseExportWriter) Write(p []byte) (int, error) {
+	w.commit()
+
+	return w.response.Write(p)
+}
+
+func (w *responseExportWriter) Flush() error {
+	w.commit()
+	w.response.Flush()
+
+	return nil
+}
+
+func (w *responseExportWriter) commit() {
+	if w.response.Committed {
+		return
+	}
+
+	w.response.Header().Set(echo.HeaderContentType, ndjsonContentType)
+	w.response.WriteHeader(http.StatusOK)
+}
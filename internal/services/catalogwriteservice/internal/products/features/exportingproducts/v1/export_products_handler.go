@@ -0,0 +1,96 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/jobs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mapper"
+	dtosv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/exportingproducts/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
+
+	"github.com/goccy/go-json"
+)
+
+type exportProductsHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewExportProductsHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*ExportProducts, *dtos.ExportProductsResponseDto] {
+	return &exportProductsHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *exportProductsHandler) RegisterHandler() error {
+	return cqrs.RegisterRequestHandler[*ExportProducts, *dtos.ExportProductsResponseDto](
+		c,
+	)
+}
+
+func (c *exportProductsHandler) Handle(
+	ctx context.Context,
+	query *ExportProducts,
+) (*dtos.ExportProductsResponseDto, error) {
+	result := &dtos.ExportProductsResponseDto{}
+
+	// `Encode` writes each product as a json line, which makes the stream a valid NDJSON
+	encoder := json.NewEncoder(query.Writer)
+
+	err := c.ProductRepository.IterateProducts(ctx, func(product *models.Product) error {
+		productDto, err := mapper.Map[*dtosv1.ProductDto](product)
+		if err != nil {
+			return customErrors.NewApplicationErrorWrap(
+				err,
+				"error in the mapping ProductDto",
+			)
+		}
+
+		if err := encoder.Encode(productDto); err != nil {
+			return err
+		}
+
+		result.ExportedCount++
+		if result.ExportedCount%int64(query.ChunkSize) != 0 {
+			return nil
+		}
+
+		result.FlushesCount++
+
+		// the total count of the products is not known while they are streamed
+		jobs.ReportProgress(ctx, result.ExportedCount, 0)
+
+		return query.Writer.Flush()
+	})
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			fmt.Sprintf("error in exporting products after %d exported products", result.ExportedCount),
+		)
+	}
+
+	// the last chunk is partially filled
+	if result.ExportedCount%int64(query.ChunkSize) != 0 || result.ExportedCount == 0 {
+		result.FlushesCount++
+		if err := query.Writer.Flush(); err != nil {
+			return nil, customErrors.NewApplicationErrorWrap(
+				err,
+				"error in flushing the last chunk of exported products",
+			)
+		}
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf("%d products exported in %d chunks", result.ExportedCount, result.FlushesCount),
+		logger.Fields{"ExportedCount": result.ExportedCount, "FlushesCount": result.FlushesCount},
+	)
+
+	return result, nil
+}
@@ -0,0 +1,8 @@
+package dtos
+
+// https://echo.labstack.com/guide/binding/
+
+// GetAttributeSetRequestDto validation will handle in query level
+type GetAttributeSetRequestDto struct {
+	Category string `param:"category" json:"-"`
+}
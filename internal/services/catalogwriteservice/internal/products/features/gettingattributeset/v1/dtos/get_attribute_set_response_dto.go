@@ -0,0 +1,8 @@
+package dtos
+
+import dtoV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1"
+
+// https://echo.labstack.com/guide/response/
+type GetAttributeSetResponseDto struct {
+	AttributeSet *dtoV1.AttributeSetDto `json:"attributeSet"`
+}
@@ -0,0 +1,41 @@
+package v1
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type GetAttributeSet struct {
+	cqrs.Query
+	Category string
+}
+
+func NewGetAttributeSet(category string) *GetAttributeSet {
+	query := &GetAttributeSet{
+		Query:    cqrs.NewQueryByT[GetAttributeSet](),
+		Category: category,
+	}
+
+	return query
+}
+
+func NewGetAttributeSetWithValidation(category string) (*GetAttributeSet, error) {
+	query := NewGetAttributeSet(category)
+	err := query.Validate()
+
+	return query, err
+}
+
+func (p *GetAttributeSet) Validate() error {
+	err := validation.ValidateStruct(
+		p,
+		validation.Field(&p.Category, validation.Required),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/gettingattributeset/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type getAttributeSetEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewGetAttributeSetEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &getAttributeSetEndpoint{ProductRouteParams: params}
+}
+
+func (ep *getAttributeSetEndpoint) MapEndpoint() {
+	ep.AttributeSetsGroup.GET("/:category", ep.handler())
+}
+
+// GetAttributeSet
+// @Tags AttributeSets
+// @Summary Get attribute set
+// @Description Get attribute set of a category
+// @Accept json
+// @Produce json
+// @Param category path string true "Category"
+// @Success 200 {object} dtos.GetAttributeSetResponseDto
+// @Router /api/v1/attribute-sets/{category} [get]
+func (ep *getAttributeSetEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.GetAttributeSetRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		query, err := NewGetAttributeSetWithValidation(request.Category)
+		if err != nil {
+			return err
+		}
+
+		queryResult, err := cqrs.Send[*GetAttributeSet, *dtos.GetAttributeSetResponseDto](
+			ctx,
+			query,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending GetAttributeSet",
+			)
+		}
+
+		return c.JSON(http.StatusOK, queryResult)
+	}
+}
@@ -0,0 +1,69 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mapper"
+	dtoV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/gettingattributeset/v1/dtos"
+)
+
+type getAttributeSetHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewGetAttributeSetHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*GetAttributeSet, *dtos.GetAttributeSetResponseDto] {
+	return &getAttributeSetHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *getAttributeSetHandler) RegisterHandler() error {
+	return cqrs.RegisterRequestHandler[*GetAttributeSet, *dtos.GetAttributeSetResponseDto](
+		c,
+	)
+}
+
+func (c *getAttributeSetHandler) Handle(
+	ctx context.Context,
+	query *GetAttributeSet,
+) (*dtos.GetAttributeSetResponseDto, error) {
+	attributeSet, err := c.AttributesValidator.FindAttributeSetByCategory(ctx, query.Category)
+	if err != nil {
+		return nil, err
+	}
+
+	if attributeSet == nil {
+		return nil, customErrors.NewNotFoundError(
+			fmt.Sprintf(
+				"attribute set for category `%s` not found",
+				query.Category,
+			),
+		)
+	}
+
+	attributeSetDto, err := mapper.Map[*dtoV1.AttributeSetDto](attributeSet)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in the mapping attribute set",
+		)
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf(
+			"attribute set for category: {%s} fetched",
+			query.Category,
+		),
+		logger.Fields{"Category": query.Category},
+	)
+
+	return &dtos.GetAttributeSetResponseDto{AttributeSet: attributeSetDto}, nil
+}
@@ -0,0 +1,14 @@
+package dtos
+
+import "time"
+
+// https://echo.labstack.com/guide/binding/
+// https://echo.labstack.com/guide/request/
+
+// GetCatalogDiffRequestDto validation will handle in query level
+type GetCatalogDiffRequestDto struct {
+	// From is the end of the previous sync of the partner in RFC3339, the changes at this time are not included
+	From time.Time `query:"from" json:"-"`
+	// To is the end of the diff in RFC3339, it is the current time by default
+	To time.Time `query:"to" json:"-"`
+}
@@ -0,0 +1,34 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/datatypes"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// https://echo.labstack.com/guide/response/
+type GetCatalogDiffResponseDto struct {
+	From    time.Time         `json:"from"`
+	To      time.Time         `json:"to"`
+	Changes []*ProductDiffDto `json:"changes"`
+}
+
+type ProductDiffDto struct {
+	ProductId uuid.UUID `json:"productId"`
+	// ChangeType is one of `created`, `updated` and `deleted`
+	ChangeType string    `json:"changeType"`
+	ChangedAt  time.Time `json:"changedAt"`
+	// Product is the product at the end of the diff, it is empty for a deleted product
+	Product datatypes.JSONMap `json:"product,omitempty"`
+	// Fields are the changed fields of an updated product, they are empty for an updated product which its state at the
+	// start of the diff is not in the history
+	Fields []*FieldDiffDto `json:"fields,omitempty"`
+}
+
+type FieldDiffDto struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
@@ -0,0 +1,63 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// GetCatalogDiff returns the products which are changed after From until To, a zero To is the current time
+type GetCatalogDiff struct {
+	cqrs.Query
+	From time.Time
+	To   time.Time
+}
+
+func NewGetCatalogDiff(from time.Time, to time.Time) *GetCatalogDiff {
+	if to.IsZero() {
+		to = time.Now()
+	}
+
+	query := &GetCatalogDiff{
+		Query: cqrs.NewQueryByT[GetCatalogDiff](),
+		From:  from,
+		To:    to,
+	}
+
+	return query
+}
+
+func NewGetCatalogDiffWithValidation(from time.Time, to time.Time) (*GetCatalogDiff, error) {
+	query := NewGetCatalogDiff(from, to)
+	err := query.Validate()
+
+	return query, err
+}
+
+func (p *GetCatalogDiff) Validate() error {
+	err := validation.ValidateStruct(
+		p,
+		validation.Field(&p.From, validation.Required),
+		validation.Field(
+			&p.To,
+			validation.Required,
+			validation.By(func(value interface{}) error {
+				to, _ := value.(time.Time)
+				if !to.After(p.From) {
+					return errors.New("must be after from")
+				}
+
+				return nil
+			}),
+		),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
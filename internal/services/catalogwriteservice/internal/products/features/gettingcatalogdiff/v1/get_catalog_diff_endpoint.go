@@ -0,0 +1,71 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/gettingcatalogdiff/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type getCatalogDiffEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewGetCatalogDiffEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &getCatalogDiffEndpoint{ProductRouteParams: params}
+}
+
+func (ep *getCatalogDiffEndpoint) MapEndpoint() {
+	ep.ProductsGroup.GET("/diff", ep.handler())
+}
+
+// GetCatalogDiff
+// @Tags Products
+// @Summary Get catalog diff
+// @Description Get the created, updated and deleted products between two points in time with the changed fields of the updated products, for syncing the catalog incrementally. A product which is created and deleted between the two points is not returned.
+// @Accept json
+// @Produce json
+// @Param getCatalogDiffRequestDto query dtos.GetCatalogDiffRequestDto true "GetCatalogDiffRequestDto"
+// @Success 200 {object} dtos.GetCatalogDiffResponseDto
+// @Router /api/v1/products/diff [get]
+func (ep *getCatalogDiffEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.GetCatalogDiffRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		query, err := NewGetCatalogDiffWithValidation(request.From, request.To)
+		if err != nil {
+			return err
+		}
+
+		queryResult, err := cqrs.Send[*GetCatalogDiff, *dtos.GetCatalogDiffResponseDto](
+			ctx,
+			query,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending GetCatalogDiff",
+			)
+		}
+
+		return c.JSON(http.StatusOK, queryResult)
+	}
+}
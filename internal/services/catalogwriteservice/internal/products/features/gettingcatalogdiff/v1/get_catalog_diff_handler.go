@@ -0,0 +1,63 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/gettingcatalogdiff/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
+)
+
+type getCatalogDiffHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewGetCatalogDiffHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*GetCatalogDiff, *dtos.GetCatalogDiffResponseDto] {
+	return &getCatalogDiffHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *getCatalogDiffHandler) RegisterHandler() error {
+	return cqrs.RegisterRequestHandler[*GetCatalogDiff, *dtos.GetCatalogDiffResponseDto](
+		c,
+	)
+}
+
+func (c *getCatalogDiffHandler) Handle(
+	ctx context.Context,
+	query *GetCatalogDiff,
+) (*dtos.GetCatalogDiffResponseDto, error) {
+	diffs, err := c.ProductHistory.Diff(ctx, query.From, query.To)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]*dtos.ProductDiffDto, 0, len(diffs))
+	for _, diff := range diffs {
+		changes = append(changes, toProductDiffDto(diff))
+	}
+
+	c.Log.Info(fmt.Sprintf("%d changed products fetched between %s and %s", len(changes), query.From, query.To))
+
+	return &dtos.GetCatalogDiffResponseDto{From: query.From, To: query.To, Changes: changes}, nil
+}
+
+func toProductDiffDto(diff *models.ProductDiff) *dtos.ProductDiffDto {
+	fields := make([]*dtos.FieldDiffDto, 0, len(diff.Fields))
+	for _, field := range diff.Fields {
+		fields = append(fields, &dtos.FieldDiffDto{Field: field.Field, From: field.From, To: field.To})
+	}
+
+	return &dtos.ProductDiffDto{
+		ProductId:  diff.ProductId,
+		ChangeType: string(diff.ChangeType),
+		ChangedAt:  diff.ChangedAt,
+		Product:    diff.Product,
+		Fields:     fields,
+	}
+}
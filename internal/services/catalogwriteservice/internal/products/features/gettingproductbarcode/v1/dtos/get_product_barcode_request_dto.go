@@ -0,0 +1,11 @@
+package dtos
+
+import uuid "github.com/satori/go.uuid"
+
+// https://echo.labstack.com/guide/binding/
+// https://echo.labstack.com/guide/request/
+
+// GetProductBarcodeRequestDto validation will handle in query level
+type GetProductBarcodeRequestDto struct {
+	ProductId uuid.UUID `param:"id" json:"-"`
+}
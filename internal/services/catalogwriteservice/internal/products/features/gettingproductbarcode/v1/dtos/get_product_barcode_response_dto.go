@@ -0,0 +1,8 @@
+package dtos
+
+// GetProductBarcodeResponseDto holds the rendered barcode label of a product
+type GetProductBarcodeResponseDto struct {
+	Barcode     string
+	ContentType string
+	Image       []byte
+}
@@ -0,0 +1,43 @@
+package v1
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	uuid "github.com/satori/go.uuid"
+)
+
+type GetProductBarcode struct {
+	cqrs.Query
+	ProductID uuid.UUID
+}
+
+func NewGetProductBarcode(productId uuid.UUID) *GetProductBarcode {
+	query := &GetProductBarcode{
+		Query:     cqrs.NewQueryByT[GetProductBarcode](),
+		ProductID: productId,
+	}
+
+	return query
+}
+
+func NewGetProductBarcodeWithValidation(productId uuid.UUID) (*GetProductBarcode, error) {
+	query := NewGetProductBarcode(productId)
+	err := query.Validate()
+
+	return query, err
+}
+
+func (p *GetProductBarcode) Validate() error {
+	err := validation.ValidateStruct(
+		p,
+		validation.Field(&p.ProductID, validation.Required, is.UUIDv4),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
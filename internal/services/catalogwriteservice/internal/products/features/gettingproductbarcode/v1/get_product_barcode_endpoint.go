@@ -0,0 +1,70 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/gettingproductbarcode/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type getProductBarcodeEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewGetProductBarcodeEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &getProductBarcodeEndpoint{ProductRouteParams: params}
+}
+
+func (ep *getProductBarcodeEndpoint) MapEndpoint() {
+	ep.ProductsGroup.GET("/:id/barcode", ep.handler())
+}
+
+// GetProductBarcode
+// @Tags Products
+// @Summary Get product barcode
+// @Description Render product EAN-13 barcode as a png image for label printing
+// @Produce png
+// @Param id path string true "Product ID"
+// @Success 200 {file} binary
+// @Router /api/v1/products/{id}/barcode [get]
+func (ep *getProductBarcodeEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.GetProductBarcodeRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		query, err := NewGetProductBarcodeWithValidation(request.ProductId)
+		if err != nil {
+			return err
+		}
+
+		queryResult, err := cqrs.Send[*GetProductBarcode, *dtos.GetProductBarcodeResponseDto](
+			ctx,
+			query,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending GetProductBarcode",
+			)
+		}
+
+		return c.Blob(http.StatusOK, queryResult.ContentType, queryResult.Image)
+	}
+}
@@ -0,0 +1,85 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/gormdbcontext"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/datamodels"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/gettingproductbarcode/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/identifiers"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/skugeneration"
+)
+
+type getProductBarcodeHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewGetProductBarcodeHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*GetProductBarcode, *dtos.GetProductBarcodeResponseDto] {
+	return &getProductBarcodeHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *getProductBarcodeHandler) RegisterHandler() error {
+	return cqrs.RegisterRequestHandler[*GetProductBarcode, *dtos.GetProductBarcodeResponseDto](
+		c,
+	)
+}
+
+func (c *getProductBarcodeHandler) Handle(
+	ctx context.Context,
+	query *GetProductBarcode,
+) (*dtos.GetProductBarcodeResponseDto, error) {
+	product, err := gormdbcontext.FindModelByID[*datamodels.ProductDataModel, *models.Product](
+		ctx,
+		c.CatalogsDBContext,
+		query.ProductID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if product.Barcode == "" {
+		return nil, customErrors.NewNotFoundError(
+			fmt.Sprintf("product with id `%s` has no barcode", query.ProductID),
+		)
+	}
+
+	ean13, ok := identifiers.ToEan13(product.Barcode)
+	if !ok {
+		return nil, customErrors.NewBadRequestError(
+			fmt.Sprintf("barcode `%s` of the product can not be rendered as an EAN-13 barcode", product.Barcode),
+		)
+	}
+
+	var buf bytes.Buffer
+	if err := skugeneration.RenderEan13Png(ean13, &buf); err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in rendering product barcode",
+		)
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf(
+			"barcode of product with id: {%s} rendered",
+			query.ProductID,
+		),
+		logger.Fields{"Id": query.ProductID.String(), "Barcode": product.Barcode},
+	)
+
+	return &dtos.GetProductBarcodeResponseDto{
+		Barcode:     product.Barcode,
+		ContentType: "image/png",
+		Image:       buf.Bytes(),
+	}, nil
+}
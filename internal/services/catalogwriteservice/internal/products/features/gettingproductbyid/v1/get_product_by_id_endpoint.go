@@ -2,7 +2,9 @@ package v1
 
 import (
 	"net/http"
+	"strconv"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
 	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
@@ -10,7 +12,6 @@ import (
 
 	"emperror.dev/errors"
 	"github.com/labstack/echo/v4"
-	"github.com/mehdihadeli/go-mediatr"
 )
 
 type getProductByIdEndpoint struct {
@@ -35,6 +36,7 @@ func (ep *getProductByIdEndpoint) MapEndpoint() {
 // @Produce json
 // @Param id path string true "Product ID"
 // @Success 200 {object} dtos.GetProductByIdResponseDto
+// @Header 200 {string} ETag "Product version"
 // @Router /api/v1/products/{id} [get]
 func (ep *getProductByIdEndpoint) handler() echo.HandlerFunc {
 	return func(c echo.Context) error {
@@ -55,7 +57,7 @@ func (ep *getProductByIdEndpoint) handler() echo.HandlerFunc {
 			return err
 		}
 
-		queryResult, err := mediatr.Send[*GetProductById, *dtos.GetProductByIdResponseDto](
+		queryResult, err := cqrs.Send[*GetProductById, *dtos.GetProductByIdResponseDto](
 			ctx,
 			query,
 		)
@@ -66,6 +68,11 @@ func (ep *getProductByIdEndpoint) handler() echo.HandlerFunc {
 			)
 		}
 
+		// the etag is the version of the product, it is sent back with the `If-Match` header of the update
+		if queryResult.Product != nil {
+			c.Response().Header().Set("ETag", strconv.Quote(strconv.FormatInt(queryResult.Product.Version, 10)))
+		}
+
 		return c.JSON(http.StatusOK, queryResult)
 	}
 }
@@ -14,8 +14,6 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/gettingproductbyid/v1/dtos"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
-
-	"github.com/mehdihadeli/go-mediatr"
 )
 
 type GetProductByIDHandler struct {
@@ -31,7 +29,7 @@ func NewGetProductByIDHandler(
 }
 
 func (c *GetProductByIDHandler) RegisterHandler() error {
-	return mediatr.RegisterRequestHandler[*GetProductById, *dtos.GetProductByIdResponseDto](
+	return cqrs.RegisterRequestHandler[*GetProductById, *dtos.GetProductByIdResponseDto](
 		c,
 	)
 }
@@ -57,6 +55,11 @@ func (c *GetProductByIDHandler) Handle(
 		)
 	}
 
+	err = c.PriceResolver.ApplyCustomerPrices(ctx, productDto)
+	if err != nil {
+		return nil, err
+	}
+
 	c.Log.Infow(
 		fmt.Sprintf(
 			"product with id: {%s} fetched",
@@ -3,6 +3,7 @@ package v1
 import (
 	"net/http"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
 	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils"
@@ -11,7 +12,6 @@ import (
 
 	"emperror.dev/errors"
 	"github.com/labstack/echo/v4"
-	"github.com/mehdihadeli/go-mediatr"
 )
 
 type getProductsEndpoint struct {
@@ -66,7 +66,7 @@ func (ep *getProductsEndpoint) handler() echo.HandlerFunc {
 			return err
 		}
 
-		queryResult, err := mediatr.Send[*GetProducts, *dtos.GetProductsResponseDto](
+		queryResult, err := cqrs.Send[*GetProducts, *dtos.GetProductsResponseDto](
 			ctx,
 			query,
 		)
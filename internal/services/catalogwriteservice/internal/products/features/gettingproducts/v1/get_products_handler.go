@@ -12,8 +12,6 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/gettingproducts/v1/dtos"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
-
-	"github.com/mehdihadeli/go-mediatr"
 )
 
 type getProductsHandler struct {
@@ -29,7 +27,7 @@ func NewGetProductsHandler(
 }
 
 func (c *getProductsHandler) RegisterHandler() error {
-	return mediatr.RegisterRequestHandler[*GetProducts, *dtos.GetProductsResponseDto](
+	return cqrs.RegisterRequestHandler[*GetProducts, *dtos.GetProductsResponseDto](
 		c,
 	)
 }
@@ -60,6 +58,11 @@ func (c *getProductsHandler) Handle(
 		)
 	}
 
+	err = c.PriceResolver.ApplyCustomerPrices(ctx, listResultDto.Items...)
+	if err != nil {
+		return nil, err
+	}
+
 	c.Log.Info("products fetched")
 
 	return &dtos.GetProductsResponseDto{Products: listResultDto}, nil
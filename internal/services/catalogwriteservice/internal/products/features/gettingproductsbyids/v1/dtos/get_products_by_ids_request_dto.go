@@ -0,0 +1,13 @@
+package dtos
+
+import (
+	uuid "github.com/satori/go.uuid"
+)
+
+// https://echo.labstack.com/guide/binding/
+// https://echo.labstack.com/guide/request/
+
+// GetProductsByIdsRequestDto validation will handle in query level
+type GetProductsByIdsRequestDto struct {
+	ProductIDs []uuid.UUID `json:"productIds"`
+}
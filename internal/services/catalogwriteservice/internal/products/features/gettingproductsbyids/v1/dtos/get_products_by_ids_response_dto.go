@@ -0,0 +1,17 @@
+package dtos
+
+import (
+	dtoV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// https://echo.labstack.com/guide/response/
+type GetProductsByIdsResponseDto struct {
+	// Products are the found products in the order of the request
+	Products []*dtoV1.ProductDto `json:"products"`
+	// NotFound are the requested ids without a product, a missing product doesn't fail the batch
+	NotFound []uuid.UUID `json:"notFound"`
+	// Failed are the products which are found but couldn't be returned, e.g. their customer prices couldn't be resolved
+	Failed []*dtoV1.BulkProductResultDto `json:"failed"`
+}
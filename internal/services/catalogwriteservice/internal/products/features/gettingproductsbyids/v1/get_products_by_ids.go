@@ -0,0 +1,67 @@
+package v1
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+// MaxProductIds is the max number of products of a single batch get
+const MaxProductIds = 100
+
+// GetProductsByIds gets a batch of products in a single round trip, the missing products are reported instead of
+// failing the query
+type GetProductsByIds struct {
+	cqrs.Query
+	ProductIDs []uuid.UUID
+}
+
+func NewGetProductsByIds(productIDs []uuid.UUID) *GetProductsByIds {
+	query := &GetProductsByIds{
+		Query:      cqrs.NewQueryByT[GetProductsByIds](),
+		ProductIDs: productIDs,
+	}
+
+	return query
+}
+
+func NewGetProductsByIdsWithValidation(productIDs []uuid.UUID) (*GetProductsByIds, error) {
+	query := NewGetProductsByIds(productIDs)
+	err := query.Validate()
+
+	return query, err
+}
+
+func (p *GetProductsByIds) Validate() error {
+	err := validation.ValidateStruct(
+		p,
+		validation.Field(
+			&p.ProductIDs,
+			validation.Required,
+			validation.Length(1, MaxProductIds),
+			validation.By(func(value interface{}) error {
+				productIDs, _ := value.([]uuid.UUID)
+				seen := make(map[uuid.UUID]bool, len(productIDs))
+				for _, productID := range productIDs {
+					if productID == uuid.Nil {
+						return errors.New("must not contain empty ids")
+					}
+					if seen[productID] {
+						return errors.Errorf("must not contain duplicate id %s", productID)
+					}
+					seen[productID] = true
+				}
+
+				return nil
+			}),
+		),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
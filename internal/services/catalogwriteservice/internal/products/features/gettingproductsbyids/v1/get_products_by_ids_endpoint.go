@@ -0,0 +1,71 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/gettingproductsbyids/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type getProductsByIdsEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewGetProductsByIdsEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &getProductsByIdsEndpoint{ProductRouteParams: params}
+}
+
+func (ep *getProductsByIdsEndpoint) MapEndpoint() {
+	ep.ProductsGroup.POST("/batch-get", ep.handler())
+}
+
+// GetProductsByIds
+// @Tags Products
+// @Summary Get products by ids
+// @Description Get a batch of products in a single round trip, the missing products are reported in the response
+// @Accept json
+// @Produce json
+// @Param GetProductsByIdsRequestDto body dtos.GetProductsByIdsRequestDto true "Product ids"
+// @Success 200 {object} dtos.GetProductsByIdsResponseDto
+// @Router /api/v1/products/batch-get [post]
+func (ep *getProductsByIdsEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.GetProductsByIdsRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		query, err := NewGetProductsByIdsWithValidation(request.ProductIDs)
+		if err != nil {
+			return err
+		}
+
+		queryResult, err := cqrs.Send[*GetProductsByIds, *dtos.GetProductsByIdsResponseDto](
+			ctx,
+			query,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending GetProductsByIds",
+			)
+		}
+
+		return c.JSON(http.StatusOK, queryResult)
+	}
+}
@@ -0,0 +1,118 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mapper"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/bulkoperations"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/datamodels"
+	dtoV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/gettingproductsbyids/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type getProductsByIdsHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewGetProductsByIdsHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*GetProductsByIds, *dtos.GetProductsByIdsResponseDto] {
+	return &getProductsByIdsHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *getProductsByIdsHandler) RegisterHandler() error {
+	return cqrs.RegisterRequestHandler[*GetProductsByIds, *dtos.GetProductsByIdsResponseDto](
+		c,
+	)
+}
+
+func (c *getProductsByIdsHandler) Handle(
+	ctx context.Context,
+	query *GetProductsByIds,
+) (*dtos.GetProductsByIdsResponseDto, error) {
+	var dataModels []*datamodels.ProductDataModel
+
+	err := c.CatalogsDBContext.DB().
+		WithContext(ctx).
+		Where("id IN ?", query.ProductIDs).
+		Find(&dataModels).
+		Error
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in fetching the products")
+	}
+
+	found := make(map[uuid.UUID]*datamodels.ProductDataModel, len(dataModels))
+	for _, dataModel := range dataModels {
+		found[dataModel.Id] = dataModel
+	}
+
+	result := &dtos.GetProductsByIdsResponseDto{
+		Products: make([]*dtoV1.ProductDto, 0, len(dataModels)),
+		NotFound: []uuid.UUID{},
+		Failed:   []*dtoV1.BulkProductResultDto{},
+	}
+
+	for _, productID := range query.ProductIDs {
+		dataModel, ok := found[productID]
+		if !ok {
+			result.NotFound = append(result.NotFound, productID)
+
+			continue
+		}
+
+		// a product which can't be mapped is reported as failed, so it doesn't fail the other products of the batch
+		productDto, err := mapProduct(dataModel)
+		if err != nil {
+			result.Failed = append(result.Failed, &dtoV1.BulkProductResultDto{
+				ProductId: productID,
+				Status:    string(bulkoperations.StatusFailed),
+				Error:     err.Error(),
+			})
+
+			continue
+		}
+
+		result.Products = append(result.Products, productDto)
+	}
+
+	// the price lists are shared by all the products, so failing to resolve them fails the batch
+	err = c.PriceResolver.ApplyCustomerPrices(ctx, result.Products...)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf("%d of %d products fetched", len(result.Products), len(query.ProductIDs)),
+		logger.Fields{
+			"Found":    len(result.Products),
+			"NotFound": len(result.NotFound),
+			"Failed":   len(result.Failed),
+		},
+	)
+
+	return result, nil
+}
+
+func mapProduct(dataModel *datamodels.ProductDataModel) (*dtoV1.ProductDto, error) {
+	product, err := mapper.Map[*models.Product](dataModel)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in the mapping product")
+	}
+
+	productDto, err := mapper.Map[*dtoV1.ProductDto](product)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in the mapping product")
+	}
+
+	return productDto, nil
+}
@@ -0,0 +1,14 @@
+package dtos
+
+import (
+	uuid "github.com/satori/go.uuid"
+)
+
+// https://echo.labstack.com/guide/binding/
+// https://echo.labstack.com/guide/request/
+
+// PinProductRequestDto validation will handle in command level
+type PinProductRequestDto struct {
+	ProductID uuid.UUID `json:"-"        param:"id"`
+	IsPinned  bool      `json:"isPinned"`
+}
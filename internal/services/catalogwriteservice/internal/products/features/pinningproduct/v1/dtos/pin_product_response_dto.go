@@ -0,0 +1,12 @@
+package dtos
+
+import (
+	uuid "github.com/satori/go.uuid"
+)
+
+// https://echo.labstack.com/guide/response/
+type PinProductResponseDto struct {
+	ProductID uuid.UUID `json:"productId"`
+	IsPinned  bool      `json:"isPinned"`
+	SortOrder int       `json:"sortOrder,omitempty"`
+}
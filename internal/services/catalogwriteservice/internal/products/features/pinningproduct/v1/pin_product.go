@@ -0,0 +1,56 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+// PinProduct pins or unpins a product, pinned products are listed ahead of the other products of the catalog
+type PinProduct struct {
+	cqrs.Command
+	ProductID uuid.UUID
+	IsPinned  bool
+	PinnedAt  time.Time
+}
+
+// NewPinProduct Create a new pinning for a product
+func NewPinProduct(productID uuid.UUID, isPinned bool) *PinProduct {
+	command := &PinProduct{
+		Command:   cqrs.NewCommandByT[PinProduct](),
+		ProductID: productID,
+		IsPinned:  isPinned,
+		PinnedAt:  time.Now(),
+	}
+
+	return command
+}
+
+// NewPinProductWithValidation Create a new pinning for a product with inline validation - for defensive programming and ensuring validation even without using middleware
+func NewPinProductWithValidation(productID uuid.UUID, isPinned bool) (*PinProduct, error) {
+	command := NewPinProduct(productID, isPinned)
+	err := command.Validate()
+
+	return command, err
+}
+
+// IsTxRequest for enabling transactions on the mediatr pipeline
+func (c *PinProduct) isTxRequest() {
+}
+
+func (c *PinProduct) Validate() error {
+	err := validation.ValidateStruct(
+		c,
+		validation.Field(&c.ProductID, validation.Required),
+		validation.Field(&c.PinnedAt, validation.Required),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
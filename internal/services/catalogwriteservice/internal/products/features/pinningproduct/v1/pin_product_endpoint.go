@@ -0,0 +1,75 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/pinningproduct/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type pinProductEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewPinProductEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &pinProductEndpoint{ProductRouteParams: params}
+}
+
+func (ep *pinProductEndpoint) MapEndpoint() {
+	ep.ProductsGroup.PUT("/:id/pinning", ep.handler())
+}
+
+// PinProduct
+// @Tags Products
+// @Summary Pin product
+// @Description Pin or unpin a product, pinned products are listed ahead of the other products
+// @Accept json
+// @Produce json
+// @Param PinProductRequestDto body dtos.PinProductRequestDto true "Product pinning"
+// @Param id path string true "Product ID"
+// @Success 200 {object} dtos.PinProductResponseDto
+// @Router /api/v1/products/{id}/pinning [put]
+func (ep *pinProductEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.PinProductRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		command, err := NewPinProductWithValidation(
+			request.ProductID,
+			request.IsPinned,
+		)
+		if err != nil {
+			return err
+		}
+
+		result, err := cqrs.Send[*PinProduct, *dtos.PinProductResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending PinProduct",
+			)
+		}
+
+		return c.JSON(http.StatusOK, result)
+	}
+}
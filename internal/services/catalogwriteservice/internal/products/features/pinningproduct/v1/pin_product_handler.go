@@ -0,0 +1,81 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/gormdbcontext"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/datamodels"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/pinningproduct/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
+)
+
+type pinProductHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewPinProductHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*PinProduct, *dtos.PinProductResponseDto] {
+	return &pinProductHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *pinProductHandler) RegisterHandler() error {
+	return cqrs.RegisterRequestHandler[*PinProduct, *dtos.PinProductResponseDto](
+		c,
+	)
+}
+
+func (c *pinProductHandler) Handle(
+	ctx context.Context,
+	command *PinProduct,
+) (*dtos.PinProductResponseDto, error) {
+	product, err := gormdbcontext.FindModelByID[*datamodels.ProductDataModel, *models.Product](
+		ctx,
+		c.CatalogsDBContext,
+		command.ProductID,
+	)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrapWithCode(
+			err,
+			http.StatusNotFound,
+			fmt.Sprintf(
+				"product with id `%s` not found",
+				command.ProductID,
+			),
+		)
+	}
+
+	// pinning keeps the manual sort order of the product, so the pinned products are ordered by it too
+	pinnedProduct, err := c.MerchandisingManager.Apply(
+		ctx,
+		product,
+		command.IsPinned,
+		product.SortOrder,
+		command.PinnedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf(
+			"pinning of product with id '%s' updated",
+			command.ProductID,
+		),
+		logger.Fields{"Id": command.ProductID, "IsPinned": pinnedProduct.IsPinned},
+	)
+
+	return &dtos.PinProductResponseDto{
+		ProductID: pinnedProduct.Id,
+		IsPinned:  pinnedProduct.IsPinned,
+		SortOrder: pinnedProduct.SortOrder,
+	}, nil
+}
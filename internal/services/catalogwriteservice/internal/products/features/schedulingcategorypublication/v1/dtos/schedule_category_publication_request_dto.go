@@ -0,0 +1,15 @@
+package dtos
+
+import (
+	"time"
+)
+
+// https://echo.labstack.com/guide/binding/
+// https://echo.labstack.com/guide/request/
+
+// ScheduleCategoryPublicationRequestDto validation will handle in command level
+type ScheduleCategoryPublicationRequestDto struct {
+	Category    string     `json:"-"                     param:"category"`
+	PublishAt   *time.Time `json:"publishAt,omitempty"`
+	UnpublishAt *time.Time `json:"unpublishAt,omitempty"`
+}
@@ -0,0 +1,7 @@
+package dtos
+
+// https://echo.labstack.com/guide/response/
+type ScheduleCategoryPublicationResponseDto struct {
+	Category          string `json:"category"`
+	ScheduledProducts int    `json:"scheduledProducts"`
+}
@@ -0,0 +1,73 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// ScheduleCategoryPublication sets the same publishing schedule on all products of a category, for campaign based merchandising
+type ScheduleCategoryPublication struct {
+	cqrs.Command
+	Category           string
+	PublishingSchedule models.PublishingSchedule
+	ScheduledAt        time.Time
+}
+
+// NewScheduleCategoryPublication Create a new publishing schedule for products of a category
+func NewScheduleCategoryPublication(
+	category string,
+	publishAt *time.Time,
+	unpublishAt *time.Time,
+) *ScheduleCategoryPublication {
+	command := &ScheduleCategoryPublication{
+		Command:            cqrs.NewCommandByT[ScheduleCategoryPublication](),
+		Category:           category,
+		PublishingSchedule: models.NewPublishingSchedule(publishAt, unpublishAt),
+		ScheduledAt:        time.Now(),
+	}
+
+	return command
+}
+
+// NewScheduleCategoryPublicationWithValidation Create a new publishing schedule for products of a category with inline validation - for defensive programming and ensuring validation even without using middleware
+func NewScheduleCategoryPublicationWithValidation(
+	category string,
+	publishAt *time.Time,
+	unpublishAt *time.Time,
+) (*ScheduleCategoryPublication, error) {
+	command := NewScheduleCategoryPublication(category, publishAt, unpublishAt)
+	err := command.Validate()
+
+	return command, err
+}
+
+// IsTxRequest for enabling transactions on the mediatr pipeline
+func (c *ScheduleCategoryPublication) isTxRequest() {
+}
+
+func (c *ScheduleCategoryPublication) Validate() error {
+	err := validation.ValidateStruct(
+		c,
+		validation.Field(
+			&c.Category,
+			validation.Required,
+			validation.Length(0, 255),
+		),
+		validation.Field(&c.ScheduledAt, validation.Required),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	err = c.PublishingSchedule.Validate()
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
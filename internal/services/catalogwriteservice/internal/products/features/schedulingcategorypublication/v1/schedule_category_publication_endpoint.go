@@ -0,0 +1,76 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/schedulingcategorypublication/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type scheduleCategoryPublicationEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewScheduleCategoryPublicationEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &scheduleCategoryPublicationEndpoint{ProductRouteParams: params}
+}
+
+func (ep *scheduleCategoryPublicationEndpoint) MapEndpoint() {
+	ep.ProductsGroup.PUT("/categories/:category/publishing-schedule", ep.handler())
+}
+
+// ScheduleCategoryPublication
+// @Tags Products
+// @Summary Schedule category publication
+// @Description Set publish and unpublish window of all products in a category
+// @Accept json
+// @Produce json
+// @Param ScheduleCategoryPublicationRequestDto body dtos.ScheduleCategoryPublicationRequestDto true "Publishing schedule"
+// @Param category path string true "Category"
+// @Success 200 {object} dtos.ScheduleCategoryPublicationResponseDto
+// @Router /api/v1/products/categories/{category}/publishing-schedule [put]
+func (ep *scheduleCategoryPublicationEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.ScheduleCategoryPublicationRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		command, err := NewScheduleCategoryPublicationWithValidation(
+			request.Category,
+			request.PublishAt,
+			request.UnpublishAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		result, err := cqrs.Send[*ScheduleCategoryPublication, *dtos.ScheduleCategoryPublicationResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending ScheduleCategoryPublication",
+			)
+		}
+
+		return c.JSON(http.StatusOK, result)
+	}
+}
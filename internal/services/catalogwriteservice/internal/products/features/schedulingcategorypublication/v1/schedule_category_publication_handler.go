@@ -0,0 +1,101 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mapper"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/datamodels"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/schedulingcategorypublication/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
+)
+
+type scheduleCategoryPublicationHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewScheduleCategoryPublicationHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*ScheduleCategoryPublication, *dtos.ScheduleCategoryPublicationResponseDto] {
+	return &scheduleCategoryPublicationHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *scheduleCategoryPublicationHandler) RegisterHandler() error {
+	return cqrs.RegisterRequestHandler[*ScheduleCategoryPublication, *dtos.ScheduleCategoryPublicationResponseDto](
+		c,
+	)
+}
+
+func (c *scheduleCategoryPublicationHandler) Handle(
+	ctx context.Context,
+	command *ScheduleCategoryPublication,
+) (*dtos.ScheduleCategoryPublicationResponseDto, error) {
+	var dataModels []*datamodels.ProductDataModel
+
+	result := c.CatalogsDBContext.WithTxIfExists(ctx).DB().
+		WithContext(ctx).
+		Where("category = ?", command.Category).
+		Find(&dataModels)
+	if result.Error != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			result.Error,
+			fmt.Sprintf(
+				"error in finding products of category `%s`",
+				command.Category,
+			),
+		)
+	}
+
+	if len(dataModels) == 0 {
+		return nil, customErrors.NewNotFoundError(
+			fmt.Sprintf(
+				"no product found for category `%s`",
+				command.Category,
+			),
+		)
+	}
+
+	products, err := mapper.Map[[]*models.Product](dataModels)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in the mapping products",
+		)
+	}
+
+	for _, product := range products {
+		if err = customErrors.CheckContext(ctx, "scheduling category publication canceled"); err != nil {
+			return nil, err
+		}
+
+		_, err = c.VisibilityManager.ApplySchedule(
+			ctx,
+			product,
+			command.PublishingSchedule,
+			command.ScheduledAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf(
+			"publishing schedule of %d products in category '%s' updated",
+			len(products),
+			command.Category,
+		),
+		logger.Fields{"Category": command.Category},
+	)
+
+	return &dtos.ScheduleCategoryPublicationResponseDto{
+		Category:          command.Category,
+		ScheduledProducts: len(products),
+	}, nil
+}
@@ -0,0 +1,17 @@
+package dtos
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// https://echo.labstack.com/guide/binding/
+// https://echo.labstack.com/guide/request/
+
+// ScheduleProductPublicationRequestDto validation will handle in command level
+type ScheduleProductPublicationRequestDto struct {
+	ProductID   uuid.UUID  `json:"-"                     param:"id"`
+	PublishAt   *time.Time `json:"publishAt,omitempty"`
+	UnpublishAt *time.Time `json:"unpublishAt,omitempty"`
+}
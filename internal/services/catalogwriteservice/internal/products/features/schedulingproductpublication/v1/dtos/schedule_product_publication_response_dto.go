@@ -0,0 +1,15 @@
+package dtos
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// https://echo.labstack.com/guide/response/
+type ScheduleProductPublicationResponseDto struct {
+	ProductID   uuid.UUID  `json:"productId"`
+	IsPublished bool       `json:"isPublished"`
+	PublishAt   *time.Time `json:"publishAt,omitempty"`
+	UnpublishAt *time.Time `json:"unpublishAt,omitempty"`
+}
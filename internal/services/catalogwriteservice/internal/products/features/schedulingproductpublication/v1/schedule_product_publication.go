@@ -0,0 +1,70 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+type ScheduleProductPublication struct {
+	cqrs.Command
+	ProductID uuid.UUID
+	// PublishingSchedule with empty PublishAt and UnpublishAt clears the schedule and publishes the product
+	PublishingSchedule models.PublishingSchedule
+	ScheduledAt        time.Time
+}
+
+// NewScheduleProductPublication Create a new publishing schedule for a product
+func NewScheduleProductPublication(
+	productID uuid.UUID,
+	publishAt *time.Time,
+	unpublishAt *time.Time,
+) *ScheduleProductPublication {
+	command := &ScheduleProductPublication{
+		Command:            cqrs.NewCommandByT[ScheduleProductPublication](),
+		ProductID:          productID,
+		PublishingSchedule: models.NewPublishingSchedule(publishAt, unpublishAt),
+		ScheduledAt:        time.Now(),
+	}
+
+	return command
+}
+
+// NewScheduleProductPublicationWithValidation Create a new publishing schedule for a product with inline validation - for defensive programming and ensuring validation even without using middleware
+func NewScheduleProductPublicationWithValidation(
+	productID uuid.UUID,
+	publishAt *time.Time,
+	unpublishAt *time.Time,
+) (*ScheduleProductPublication, error) {
+	command := NewScheduleProductPublication(productID, publishAt, unpublishAt)
+	err := command.Validate()
+
+	return command, err
+}
+
+// IsTxRequest for enabling transactions on the mediatr pipeline
+func (c *ScheduleProductPublication) isTxRequest() {
+}
+
+func (c *ScheduleProductPublication) Validate() error {
+	err := validation.ValidateStruct(
+		c,
+		validation.Field(&c.ProductID, validation.Required),
+		validation.Field(&c.ScheduledAt, validation.Required),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	err = c.PublishingSchedule.Validate()
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
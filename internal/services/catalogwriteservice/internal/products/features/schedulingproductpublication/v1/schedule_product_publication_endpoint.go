@@ -0,0 +1,76 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/schedulingproductpublication/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type scheduleProductPublicationEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewScheduleProductPublicationEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &scheduleProductPublicationEndpoint{ProductRouteParams: params}
+}
+
+func (ep *scheduleProductPublicationEndpoint) MapEndpoint() {
+	ep.ProductsGroup.PUT("/:id/publishing-schedule", ep.handler())
+}
+
+// ScheduleProductPublication
+// @Tags Products
+// @Summary Schedule product publication
+// @Description Set publish and unpublish window of a product, an empty window publishes the product immediately
+// @Accept json
+// @Produce json
+// @Param ScheduleProductPublicationRequestDto body dtos.ScheduleProductPublicationRequestDto true "Publishing schedule"
+// @Param id path string true "Product ID"
+// @Success 200 {object} dtos.ScheduleProductPublicationResponseDto
+// @Router /api/v1/products/{id}/publishing-schedule [put]
+func (ep *scheduleProductPublicationEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.ScheduleProductPublicationRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		command, err := NewScheduleProductPublicationWithValidation(
+			request.ProductID,
+			request.PublishAt,
+			request.UnpublishAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		result, err := cqrs.Send[*ScheduleProductPublication, *dtos.ScheduleProductPublicationResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending ScheduleProductPublication",
+			)
+		}
+
+		return c.JSON(http.StatusOK, result)
+	}
+}
@@ -0,0 +1,80 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/gormdbcontext"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/datamodels"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/schedulingproductpublication/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
+)
+
+type scheduleProductPublicationHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewScheduleProductPublicationHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*ScheduleProductPublication, *dtos.ScheduleProductPublicationResponseDto] {
+	return &scheduleProductPublicationHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *scheduleProductPublicationHandler) RegisterHandler() error {
+	return cqrs.RegisterRequestHandler[*ScheduleProductPublication, *dtos.ScheduleProductPublicationResponseDto](
+		c,
+	)
+}
+
+func (c *scheduleProductPublicationHandler) Handle(
+	ctx context.Context,
+	command *ScheduleProductPublication,
+) (*dtos.ScheduleProductPublicationResponseDto, error) {
+	product, err := gormdbcontext.FindModelByID[*datamodels.ProductDataModel, *models.Product](
+		ctx,
+		c.CatalogsDBContext,
+		command.ProductID,
+	)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrapWithCode(
+			err,
+			http.StatusNotFound,
+			fmt.Sprintf(
+				"product with id `%s` not found",
+				command.ProductID,
+			),
+		)
+	}
+
+	scheduledProduct, err := c.VisibilityManager.ApplySchedule(
+		ctx,
+		product,
+		command.PublishingSchedule,
+		command.ScheduledAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf(
+			"publishing schedule of product with id '%s' updated",
+			command.ProductID,
+		),
+		logger.Fields{"Id": command.ProductID, "IsPublished": scheduledProduct.IsPublished},
+	)
+
+	return &dtos.ScheduleProductPublicationResponseDto{
+		ProductID:   scheduledProduct.Id,
+		IsPublished: scheduledProduct.IsPublished,
+		PublishAt:   scheduledProduct.PublishAt,
+		UnpublishAt: scheduledProduct.UnpublishAt,
+	}, nil
+}
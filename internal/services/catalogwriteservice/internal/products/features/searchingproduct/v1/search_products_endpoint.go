@@ -3,6 +3,7 @@ package v1
 import (
 	"net/http"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
 	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils"
@@ -11,7 +12,6 @@ import (
 
 	"emperror.dev/errors"
 	"github.com/labstack/echo/v4"
-	"github.com/mehdihadeli/go-mediatr"
 )
 
 type searchProductsEndpoint struct {
@@ -69,7 +69,7 @@ func (ep *searchProductsEndpoint) handler() echo.HandlerFunc {
 			return err
 		}
 
-		queryResult, err := mediatr.Send[*SearchProducts, *dtos.SearchProductsResponseDto](
+		queryResult, err := cqrs.Send[*SearchProducts, *dtos.SearchProductsResponseDto](
 			ctx,
 			query,
 		)
@@ -19,7 +19,6 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
 
 	"github.com/iancoleman/strcase"
-	"github.com/mehdihadeli/go-mediatr"
 	"gorm.io/gorm"
 )
 
@@ -36,7 +35,7 @@ func NewSearchProductsHandler(
 }
 
 func (c *searchProductsHandler) RegisterHandler() error {
-	return mediatr.RegisterRequestHandler[*SearchProducts, *dtos.SearchProductsResponseDto](
+	return cqrs.RegisterRequestHandler[*SearchProducts, *dtos.SearchProductsResponseDto](
 		c,
 	)
 }
@@ -69,6 +68,11 @@ func (c *searchProductsHandler) Handle(
 		)
 	}
 
+	err = c.PriceResolver.ApplyCustomerPrices(ctx, listResultDto.Items...)
+	if err != nil {
+		return nil, err
+	}
+
 	c.Log.Info("products fetched")
 
 	return &dtos.SearchProductsResponseDto{Products: listResultDto}, nil
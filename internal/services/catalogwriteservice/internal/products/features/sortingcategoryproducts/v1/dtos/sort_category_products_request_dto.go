@@ -0,0 +1,15 @@
+package dtos
+
+import (
+	uuid "github.com/satori/go.uuid"
+)
+
+// https://echo.labstack.com/guide/binding/
+// https://echo.labstack.com/guide/request/
+
+// SortCategoryProductsRequestDto validation will handle in command level
+type SortCategoryProductsRequestDto struct {
+	Category string `json:"-"          param:"category"`
+	// ProductIDs are the products of the category in their manual order, an empty list clears the manual order
+	ProductIDs []uuid.UUID `json:"productIds"`
+}
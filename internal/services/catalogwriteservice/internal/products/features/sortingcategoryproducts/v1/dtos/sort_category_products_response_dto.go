@@ -0,0 +1,7 @@
+package dtos
+
+// https://echo.labstack.com/guide/response/
+type SortCategoryProductsResponseDto struct {
+	Category       string `json:"category"`
+	SortedProducts int    `json:"sortedProducts"`
+}
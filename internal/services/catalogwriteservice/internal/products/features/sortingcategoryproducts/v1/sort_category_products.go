@@ -0,0 +1,83 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+// SortCategoryProducts sets the manual sort order of the products of a category, the products are positioned in the
+// order of ProductIDs and the other products of the category lose their manual position
+type SortCategoryProducts struct {
+	cqrs.Command
+	Category   string
+	ProductIDs []uuid.UUID
+	SortedAt   time.Time
+}
+
+// NewSortCategoryProducts Create a new manual sort order for products of a category
+func NewSortCategoryProducts(category string, productIDs []uuid.UUID) *SortCategoryProducts {
+	command := &SortCategoryProducts{
+		Command:    cqrs.NewCommandByT[SortCategoryProducts](),
+		Category:   category,
+		ProductIDs: productIDs,
+		SortedAt:   time.Now(),
+	}
+
+	return command
+}
+
+// NewSortCategoryProductsWithValidation Create a new manual sort order for products of a category with inline validation - for defensive programming and ensuring validation even without using middleware
+func NewSortCategoryProductsWithValidation(
+	category string,
+	productIDs []uuid.UUID,
+) (*SortCategoryProducts, error) {
+	command := NewSortCategoryProducts(category, productIDs)
+	err := command.Validate()
+
+	return command, err
+}
+
+// IsTxRequest for enabling transactions on the mediatr pipeline
+func (c *SortCategoryProducts) isTxRequest() {
+}
+
+func (c *SortCategoryProducts) Validate() error {
+	err := validation.ValidateStruct(
+		c,
+		validation.Field(
+			&c.Category,
+			validation.Required,
+			validation.Length(0, 255),
+		),
+		validation.Field(
+			&c.ProductIDs,
+			validation.By(func(value interface{}) error {
+				productIDs, _ := value.([]uuid.UUID)
+				seen := make(map[uuid.UUID]bool, len(productIDs))
+				for _, productID := range productIDs {
+					if productID == uuid.Nil {
+						return errors.New("must not contain empty ids")
+					}
+					if seen[productID] {
+						return errors.Errorf("must not contain duplicate id %s", productID)
+					}
+					seen[productID] = true
+				}
+
+				return nil
+			}),
+		),
+		validation.Field(&c.SortedAt, validation.Required),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/sortingcategoryproducts/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type sortCategoryProductsEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewSortCategoryProductsEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &sortCategoryProductsEndpoint{ProductRouteParams: params}
+}
+
+func (ep *sortCategoryProductsEndpoint) MapEndpoint() {
+	ep.ProductsGroup.PUT("/categories/:category/sort-order", ep.handler())
+}
+
+// SortCategoryProducts
+// @Tags Products
+// @Summary Sort category products
+// @Description Set the manual sort order of the products in a category, the listed products are positioned in the given order
+// @Accept json
+// @Produce json
+// @Param SortCategoryProductsRequestDto body dtos.SortCategoryProductsRequestDto true "Manual sort order"
+// @Param category path string true "Category"
+// @Success 200 {object} dtos.SortCategoryProductsResponseDto
+// @Router /api/v1/products/categories/{category}/sort-order [put]
+func (ep *sortCategoryProductsEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.SortCategoryProductsRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		command, err := NewSortCategoryProductsWithValidation(
+			request.Category,
+			request.ProductIDs,
+		)
+		if err != nil {
+			return err
+		}
+
+		result, err := cqrs.Send[*SortCategoryProducts, *dtos.SortCategoryProductsResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending SortCategoryProducts",
+			)
+		}
+
+		return c.JSON(http.StatusOK, result)
+	}
+}
@@ -0,0 +1,118 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mapper"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/datamodels"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/sortingcategoryproducts/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type sortCategoryProductsHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewSortCategoryProductsHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*SortCategoryProducts, *dtos.SortCategoryProductsResponseDto] {
+	return &sortCategoryProductsHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *sortCategoryProductsHandler) RegisterHandler() error {
+	return cqrs.RegisterRequestHandler[*SortCategoryProducts, *dtos.SortCategoryProductsResponseDto](
+		c,
+	)
+}
+
+func (c *sortCategoryProductsHandler) Handle(
+	ctx context.Context,
+	command *SortCategoryProducts,
+) (*dtos.SortCategoryProductsResponseDto, error) {
+	var dataModels []*datamodels.ProductDataModel
+
+	result := c.CatalogsDBContext.WithTxIfExists(ctx).DB().
+		WithContext(ctx).
+		Where("category = ?", command.Category).
+		Find(&dataModels)
+	if result.Error != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			result.Error,
+			fmt.Sprintf(
+				"error in finding products of category `%s`",
+				command.Category,
+			),
+		)
+	}
+
+	products, err := mapper.Map[[]*models.Product](dataModels)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in the mapping products",
+		)
+	}
+
+	// sort orders start from 1, zero means the product has no manual position in its category
+	sortOrders := make(map[uuid.UUID]int, len(command.ProductIDs))
+	for i, productID := range command.ProductIDs {
+		sortOrders[productID] = i + 1
+	}
+
+	categoryProducts := make(map[uuid.UUID]bool, len(products))
+	for _, product := range products {
+		categoryProducts[product.Id] = true
+	}
+
+	for _, productID := range command.ProductIDs {
+		if !categoryProducts[productID] {
+			return nil, customErrors.NewNotFoundError(
+				fmt.Sprintf(
+					"product with id `%s` not found in category `%s`",
+					productID,
+					command.Category,
+				),
+			)
+		}
+	}
+
+	for _, product := range products {
+		if err = customErrors.CheckContext(ctx, "sorting category products canceled"); err != nil {
+			return nil, err
+		}
+
+		_, err = c.MerchandisingManager.Apply(
+			ctx,
+			product,
+			product.IsPinned,
+			sortOrders[product.Id],
+			command.SortedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf(
+			"manual sort order of %d products in category '%s' updated",
+			len(command.ProductIDs),
+			command.Category,
+		),
+		logger.Fields{"Category": command.Category},
+	)
+
+	return &dtos.SortCategoryProductsResponseDto{
+		Category:       command.Category,
+		SortedProducts: len(command.ProductIDs),
+	}, nil
+}
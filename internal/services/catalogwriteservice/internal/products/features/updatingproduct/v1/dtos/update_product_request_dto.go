@@ -1,6 +1,10 @@
 package dtos
 
-import uuid "github.com/satori/go.uuid"
+import (
+	dtoV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1"
+
+	uuid "github.com/satori/go.uuid"
+)
 
 // https://echo.labstack.com/guide/binding/
 
@@ -9,4 +13,15 @@ type UpdateProductRequestDto struct {
 	Name        string    `json:"name"`
 	Description string    `json:"description"`
 	Price       float64   `json:"price"`
+	// Sku and Barcode are optional, the existing codes of the product are kept when they are not provided
+	Sku     string `json:"sku,omitempty"`
+	Barcode string `json:"barcode,omitempty"`
+	// Attributes are custom fields of the product which should match with attribute set of the Category
+	Category   string                 `json:"category,omitempty"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	// Translations are localized name and description by BCP 47 locale, for example `fr-CA`
+	Translations map[string]*dtoV1.ProductTranslationDto `json:"translations,omitempty"`
+	// Version is the optional version of the product which was read before the update, the `If-Match` header can be
+	// used instead of it
+	Version *int64 `json:"version,omitempty"`
 }
@@ -4,6 +4,9 @@ import (
 	"time"
 
 	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/datatypes"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/identifiers"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
 
 	validation "github.com/go-ozzo/ozzo-validation"
 	uuid "github.com/satori/go.uuid"
@@ -14,7 +17,17 @@ type UpdateProduct struct {
 	Name        string
 	Description string
 	Price       float64
-	UpdatedAt   time.Time
+	// Sku and Barcode are optional, the existing codes of the product are kept when they are empty
+	Sku     string
+	Barcode string
+	// Category and Attributes are optional, attributes will be validated against the attribute set of the category
+	Category   string
+	Attributes datatypes.JSONMap
+	// Translations are localized name and description by locale
+	Translations map[string]*models.ProductTranslation
+	// ExpectedVersion is optional, the product is only updated while it still has this version
+	ExpectedVersion *int64
+	UpdatedAt       time.Time
 }
 
 func NewUpdateProduct(
@@ -50,6 +63,12 @@ func NewUpdateProductWithValidation(
 func (c *UpdateProduct) isTxRequest() {
 }
 
+// Normalize normalizes the product identifiers, it is called by the validation pipeline before validating the command
+func (c *UpdateProduct) Normalize() {
+	c.Sku = identifiers.NormalizeSku(c.Sku)
+	c.Barcode = identifiers.NormalizeGtin(c.Barcode)
+}
+
 func (c *UpdateProduct) Validate() error {
 	err := validation.ValidateStruct(
 		c,
@@ -65,6 +84,9 @@ func (c *UpdateProduct) Validate() error {
 			validation.Length(0, 5000),
 		),
 		validation.Field(&c.Price, validation.Required, validation.Min(0.0)),
+		validation.Field(&c.Sku, identifiers.Sku),
+		validation.Field(&c.Barcode, identifiers.Gtin),
+		validation.Field(&c.Category, validation.Length(0, 255)),
 		validation.Field(&c.UpdatedAt, validation.Required),
 	)
 	if err != nil {
@@ -1,18 +1,26 @@
 package v1
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
 	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/updatingproduct/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
 
 	"emperror.dev/errors"
 	"github.com/labstack/echo/v4"
 	"github.com/mehdihadeli/go-mediatr"
 )
 
+// ifMatchHeader has the etag of the product version which the update expects, like the `ETag` of the get product
+const ifMatchHeader = "If-Match"
+
 type updateProductEndpoint struct {
 	fxparams.ProductRouteParams
 }
@@ -35,7 +43,9 @@ func (ep *updateProductEndpoint) MapEndpoint() {
 // @Produce json
 // @Param UpdateProductRequestDto body dtos.UpdateProductRequestDto true "Product data"
 // @Param id path string true "Product ID"
+// @Param If-Match header string false "Expected product version"
 // @Success 204
+// @Failure 409
 // @Router /api/v1/products/{id} [put]
 func (ep *updateProductEndpoint) handler() echo.HandlerFunc {
 	return func(c echo.Context) error {
@@ -51,17 +61,41 @@ func (ep *updateProductEndpoint) handler() echo.HandlerFunc {
 			return badRequestErr
 		}
 
-		command, err := NewUpdateProductWithValidation(
+		command := NewUpdateProduct(
 			request.ProductID,
 			request.Name,
 			request.Description,
 			request.Price,
 		)
+		command.Sku = request.Sku
+		command.Barcode = request.Barcode
+		command.Category = request.Category
+		command.Attributes = request.Attributes
+
+		expectedVersion, err := expectedProductVersion(c.Request().Header.Get(ifMatchHeader), request.Version)
 		if err != nil {
 			return err
 		}
+		command.ExpectedVersion = expectedVersion
 
-		_, err = mediatr.Send[*UpdateProduct, *mediatr.Unit](
+		translations := make(map[string]*models.ProductTranslation, len(request.Translations))
+		for locale, translation := range request.Translations {
+			if translation != nil {
+				translations[locale] = models.NewProductTranslation(translation.Name, translation.Description)
+			}
+		}
+		normalizedTranslations, err := models.NormalizeTranslations(translations)
+		if err != nil {
+			return err
+		}
+		command.Translations = normalizedTranslations
+
+		command.Normalize()
+		if err = command.Validate(); err != nil {
+			return err
+		}
+
+		_, err = cqrs.Send[*UpdateProduct, *mediatr.Unit](
 			ctx,
 			command,
 		)
@@ -75,3 +109,22 @@ func (ep *updateProductEndpoint) handler() echo.HandlerFunc {
 		return c.NoContent(http.StatusNoContent)
 	}
 }
+
+// expectedProductVersion returns the version of the `If-Match` header or the version of the body, a `*` header matches
+// any version
+func expectedProductVersion(ifMatch string, version *int64) (*int64, error) {
+	ifMatch = strings.TrimSpace(ifMatch)
+	if ifMatch == "" || ifMatch == "*" {
+		return version, nil
+	}
+
+	expected, err := strconv.ParseInt(strings.Trim(strings.TrimPrefix(ifMatch, "W/"), `"`), 10, 64)
+	if err != nil {
+		return nil, customErrors.NewBadRequestErrorWrap(
+			err,
+			fmt.Sprintf("`%s` header `%s` is not a product version", ifMatchHeader, ifMatch),
+		)
+	}
+
+	return &expected, nil
+}
@@ -33,7 +33,7 @@ func NewUpdateProductHandler(
 }
 
 func (c *updateProductHandler) RegisterHandler() error {
-	return mediatr.RegisterRequestHandler[*UpdateProduct, *mediatr.Unit](
+	return cqrs.RegisterRequestHandler[*UpdateProduct, *mediatr.Unit](
 		c,
 	)
 }
@@ -62,23 +62,61 @@ func (c *updateProductHandler) Handle(
 		)
 	}
 
+	if command.ExpectedVersion != nil && *command.ExpectedVersion != product.Version {
+		return nil, customErrors.NewConcurrencyConflictError(
+			fmt.Sprintf(
+				"product with id `%s` has version `%d`, not the expected version `%d`",
+				command.ProductID,
+				product.Version,
+				*command.ExpectedVersion,
+			),
+		)
+	}
+
+	err = c.AttributesValidator.ValidateProductAttributes(
+		ctx,
+		command.Category,
+		command.Attributes,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.updateProductCodes(ctx, command, product); err != nil {
+		return nil, err
+	}
+
 	product.Name = command.Name
 	product.Price = command.Price
 	product.Description = command.Description
+	product.Category = command.Category
+	product.Attributes = command.Attributes
+	product.Translations = command.Translations
 	product.UpdatedAt = command.UpdatedAt
 
-	updatedProduct, err := gormdbcontext.UpdateModel[*datamodels.ProductDataModel, *models.Product](
+	// the product is only updated while it has the read version, so a concurrent change of the product isn't lost
+	updatedProduct, err := gormdbcontext.UpdateVersionedModel[*datamodels.ProductDataModel, *models.Product](
 		ctx,
 		c.CatalogsDBContext,
 		product,
 	)
 	if err != nil {
+		// a sku or barcode which is taken after its check violates the unique indexes of the products
+		if customErrors.IsConcurrencyConflictError(err) || customErrors.IsConflictError(err) {
+			return nil, err
+		}
+
 		return nil, customErrors.NewApplicationErrorWrap(
 			err,
 			"error in updating product in the repository",
 		)
 	}
 
+	err = c.ProductHistory.Record(ctx, models.ProductUpdated, updatedProduct.Id)
+	if err != nil {
+		return nil, err
+	}
+
 	productDto, err := mapper.Map[*dto.ProductDto](updatedProduct)
 	if err != nil {
 		return nil, customErrors.NewApplicationErrorWrap(
@@ -89,7 +127,7 @@ func (c *updateProductHandler) Handle(
 
 	productUpdated := integrationevents.NewProductUpdatedV1(productDto)
 
-	err = c.RabbitmqProducer.PublishMessage(ctx, productUpdated, nil)
+	err = c.OutboxPublisher.PublishViaOutbox(ctx, productUpdated, nil)
 	if err != nil {
 		return nil, customErrors.NewApplicationErrorWrap(
 			err,
@@ -115,3 +153,40 @@ func (c *updateProductHandler) Handle(
 
 	return &mediatr.Unit{}, err
 }
+
+// updateProductCodes replaces the sku and barcode of the product with the provided ones, if they are still unique
+func (c *updateProductHandler) updateProductCodes(
+	ctx context.Context,
+	command *UpdateProduct,
+	product *models.Product,
+) error {
+	if command.Sku != "" && command.Sku != product.Sku {
+		exists, err := c.SkuGenerator.SkuExists(ctx, command.Sku)
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			return customErrors.NewConflictError(
+				fmt.Sprintf("product with sku `%s` already exists", command.Sku),
+			)
+		}
+		product.Sku = command.Sku
+	}
+
+	if command.Barcode != "" && command.Barcode != product.Barcode {
+		exists, err := c.SkuGenerator.BarcodeExists(ctx, command.Barcode)
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			return customErrors.NewConflictError(
+				fmt.Sprintf("product with barcode `%s` already exists", command.Barcode),
+			)
+		}
+		product.Barcode = command.Barcode
+	}
+
+	return nil
+}
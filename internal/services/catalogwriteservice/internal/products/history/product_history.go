@@ -0,0 +1,175 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mapper"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/datatypes"
+	datamodel "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/datamodels"
+	dtoV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/data/dbcontext"
+
+	"github.com/goccy/go-json"
+	uuid "github.com/satori/go.uuid"
+)
+
+type ProductHistory interface {
+	// Record stores the snapshots of the products after a change in the transaction of the change, so it should be
+	// called after the change is saved. the deleted products are loaded with their soft deleted rows.
+	Record(ctx context.Context, changeType models.ProductChangeType, productIds ...uuid.UUID) error
+	// Diff returns the products which are changed after `from` until `to` with their changed fields
+	Diff(ctx context.Context, from time.Time, to time.Time) ([]*models.ProductDiff, error)
+}
+
+type productHistory struct {
+	dbContext *dbcontext.CatalogsGormDBContext
+}
+
+func NewProductHistory(dbContext *dbcontext.CatalogsGormDBContext) ProductHistory {
+	return &productHistory{dbContext: dbContext}
+}
+
+func (p *productHistory) Record(
+	ctx context.Context,
+	changeType models.ProductChangeType,
+	productIds ...uuid.UUID,
+) error {
+	if len(productIds) == 0 {
+		return nil
+	}
+
+	db := p.dbContext.WithTxIfExists(ctx).DB().WithContext(ctx)
+
+	var products []*datamodel.ProductDataModel
+	if err := db.Unscoped().Where("id IN ?", productIds).Find(&products).Error; err != nil {
+		return customErrors.NewApplicationErrorWrap(
+			customErrors.WrapIfCanceled(ctx, err, "recording product changes canceled"),
+			"error in loading the changed products",
+		)
+	}
+
+	now := time.Now()
+	changes := make([]*datamodel.ProductChangeDataModel, 0, len(products))
+
+	for _, product := range products {
+		snapshot, err := snapshotOf(product)
+		if err != nil {
+			return customErrors.NewApplicationErrorWrap(
+				err,
+				fmt.Sprintf("error in creating the snapshot of the product with id `%s`", product.Id),
+			)
+		}
+
+		changes = append(changes, &datamodel.ProductChangeDataModel{
+			Id:         uuid.NewV4(),
+			ProductId:  product.Id,
+			ChangeType: string(changeType),
+			Snapshot:   snapshot,
+			OccurredAt: now,
+		})
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	if err := db.Create(&changes).Error; err != nil {
+		return customErrors.NewApplicationErrorWrap(
+			customErrors.WrapIfCanceled(ctx, err, "recording product changes canceled"),
+			"error in recording the product changes",
+		)
+	}
+
+	return nil
+}
+
+func (p *productHistory) Diff(ctx context.Context, from time.Time, to time.Time) ([]*models.ProductDiff, error) {
+	db := p.dbContext.WithTxIfExists(ctx).DB().WithContext(ctx)
+
+	var changes []*datamodel.ProductChangeDataModel
+	err := db.Where("occurred_at > ? AND occurred_at <= ?", from, to).
+		Order("occurred_at, id").
+		Find(&changes).
+		Error
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			customErrors.WrapIfCanceled(ctx, err, "getting product changes canceled"),
+			"error in getting the product changes",
+		)
+	}
+
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	productIds := make([]uuid.UUID, 0, len(changes))
+	for _, change := range changes {
+		productIds = append(productIds, change.ProductId)
+	}
+
+	// the baseline of a product is its last change until `from`
+	var baselines []*datamodel.ProductChangeDataModel
+	err = db.Table("product_changes AS c").
+		Select("c.*").
+		Joins(
+			"JOIN (?) AS l ON l.product_id = c.product_id AND l.occurred_at = c.occurred_at",
+			db.Model(&datamodel.ProductChangeDataModel{}).
+				Select("product_id, MAX(occurred_at) AS occurred_at").
+				Where("occurred_at <= ? AND product_id IN ?", from, productIds).
+				Group("product_id"),
+		).
+		Find(&baselines).
+		Error
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			customErrors.WrapIfCanceled(ctx, err, "getting product changes canceled"),
+			"error in getting the baselines of the product changes",
+		)
+	}
+
+	return models.DiffProductChanges(toProductChanges(baselines), toProductChanges(changes)), nil
+}
+
+// snapshotOf keeps the product with the json of its api, so the diffs have the field names of the api
+func snapshotOf(product *datamodel.ProductDataModel) (datatypes.JSONMap, error) {
+	model, err := mapper.Map[*models.Product](product)
+	if err != nil {
+		return nil, err
+	}
+
+	dto, err := mapper.Map[*dtoV1.ProductDto](model)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(dto)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := datatypes.JSONMap{}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+func toProductChanges(dataModels []*datamodel.ProductChangeDataModel) []*models.ProductChange {
+	changes := make([]*models.ProductChange, 0, len(dataModels))
+	for _, dataModel := range dataModels {
+		changes = append(changes, &models.ProductChange{
+			Id:         dataModel.Id,
+			ProductId:  dataModel.ProductId,
+			ChangeType: models.ProductChangeType(dataModel.ChangeType),
+			Snapshot:   dataModel.Snapshot,
+			OccurredAt: dataModel.OccurredAt,
+		})
+	}
+
+	return changes
+}
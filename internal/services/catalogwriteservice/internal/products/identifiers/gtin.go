@@ -0,0 +1,78 @@
+package identifiers
+
+import (
+	"strings"
+
+	"emperror.dev/errors"
+)
+
+// https://www.gs1.org/services/how-calculate-check-digit-manually
+
+var gtinLengths = map[int]string{8: "GTIN-8", 12: "GTIN-12", 13: "GTIN-13", 14: "GTIN-14"}
+
+// NormalizeGtin removes the spaces and hyphens which are used for printing the gtin codes, e.g. `400-638-133393-1`
+func NormalizeGtin(code string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(code))
+}
+
+// GtinCheckDigit calculates the GS1 check digit of a gtin payload (the code without its check digit), the weights
+// alternate 3,1 starting from the rightmost digit, so it works for all the gtin lengths.
+func GtinCheckDigit(payload string) (int, error) {
+	sum := 0
+	for i := len(payload) - 1; i >= 0; i-- {
+		r := payload[i]
+		if r < '0' || r > '9' {
+			return 0, errors.Errorf("invalid gtin digit '%c'", r)
+		}
+
+		d := int(r - '0')
+		if (len(payload)-1-i)%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+
+	return (10 - sum%10) % 10, nil
+}
+
+// ValidateGtin validates the length and the check digit of a normalized GTIN-8, GTIN-12 (UPC-A), GTIN-13 (EAN-13) or
+// GTIN-14 code.
+func ValidateGtin(code string) error {
+	if _, ok := gtinLengths[len(code)]; !ok {
+		return errors.New("must be a GTIN-8, GTIN-12, GTIN-13 or GTIN-14 code")
+	}
+
+	checkDigit, err := GtinCheckDigit(code[:len(code)-1])
+	if err != nil {
+		return errors.New("must contain only digits")
+	}
+
+	if int(code[len(code)-1]-'0') != checkDigit {
+		return errors.Errorf("has an invalid %s check digit", gtinLengths[len(code)])
+	}
+
+	return nil
+}
+
+func IsValidGtin(code string) bool {
+	return ValidateGtin(code) == nil
+}
+
+// ToEan13 converts a valid gtin to the EAN-13 form for rendering its barcode, a GTIN-12 is prefixed with zero and a
+// GTIN-14 with a zero indicator digit is trimmed. GTIN-8 and the other GTIN-14 codes don't have an EAN-13 form.
+func ToEan13(code string) (string, bool) {
+	if !IsValidGtin(code) {
+		return "", false
+	}
+
+	switch {
+	case len(code) == 13:
+		return code, true
+	case len(code) == 12:
+		return "0" + code, true
+	case len(code) == 14 && code[0] == '0':
+		return code[1:], true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,25 @@
+package identifiers
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// Gtin is the validation rule of the optional gtin fields
+var Gtin = validation.By(func(value interface{}) error {
+	code, _ := value.(string)
+	if code == "" {
+		return nil
+	}
+
+	return ValidateGtin(code)
+})
+
+// Sku is the validation rule of the optional sku fields
+var Sku = validation.By(func(value interface{}) error {
+	sku, _ := value.(string)
+	if sku == "" {
+		return nil
+	}
+
+	return ValidateSku(sku)
+})
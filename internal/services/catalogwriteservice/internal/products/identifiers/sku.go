@@ -0,0 +1,34 @@
+package identifiers
+
+import (
+	"regexp"
+	"strings"
+
+	"emperror.dev/errors"
+)
+
+const maxSkuLength = 64
+
+var (
+	skuRegex        = regexp.MustCompile(`^[A-Z0-9]+([-_.][A-Z0-9]+)*$`)
+	skuSpacingRegex = regexp.MustCompile(`\s+`)
+)
+
+// NormalizeSku trims and upper-cases the sku and replaces the inner spaces with hyphens, so `abc 123` and `ABC-123`
+// are the same sku.
+func NormalizeSku(sku string) string {
+	return skuSpacingRegex.ReplaceAllString(strings.ToUpper(strings.TrimSpace(sku)), "-")
+}
+
+// ValidateSku validates a normalized sku, it should be alphanumeric segments separated by `-`, `_` or `.`
+func ValidateSku(sku string) error {
+	if len(sku) > maxSkuLength {
+		return errors.Errorf("the length must be no more than %d", maxSkuLength)
+	}
+
+	if !skuRegex.MatchString(sku) {
+		return errors.New("must contain only letters and digits separated by '-', '_' or '.'")
+	}
+
+	return nil
+}
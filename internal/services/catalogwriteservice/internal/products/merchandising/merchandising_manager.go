@@ -0,0 +1,122 @@
+package merchandising
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/bus"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/contracts"
+	datamodel "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/datamodels"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/history"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/data/dbcontext"
+
+	"gorm.io/gorm"
+)
+
+type MerchandisingManager interface {
+	// Apply stores the pinning and the manual sort order of the product and publishes a ProductMerchandisingChangedV1
+	// event for the read side, a product that its merchandising is not changed is skipped.
+	Apply(
+		ctx context.Context,
+		product *models.Product,
+		isPinned bool,
+		sortOrder int,
+		now time.Time,
+	) (*models.Product, error)
+}
+
+type merchandisingManager struct {
+	dbContext       *dbcontext.CatalogsGormDBContext
+	outboxPublisher bus.OutboxPublisher
+	productHistory  history.ProductHistory
+	log             logger.Logger
+}
+
+func NewMerchandisingManager(
+	dbContext *dbcontext.CatalogsGormDBContext,
+	outboxPublisher bus.OutboxPublisher,
+	productHistory history.ProductHistory,
+	log logger.Logger,
+) MerchandisingManager {
+	return &merchandisingManager{
+		dbContext:       dbContext,
+		outboxPublisher: outboxPublisher,
+		productHistory:  productHistory,
+		log:             log,
+	}
+}
+
+func (m *merchandisingManager) Apply(
+	ctx context.Context,
+	product *models.Product,
+	isPinned bool,
+	sortOrder int,
+	now time.Time,
+) (*models.Product, error) {
+	if product.IsPinned == isPinned && product.SortOrder == sortOrder {
+		return product, nil
+	}
+
+	// `Updates` with a struct skips zero values, so we use a map for being able to unpin the product and clear its sort order
+	result := m.dbContext.WithTxIfExists(ctx).DB().
+		WithContext(ctx).
+		Model(&datamodel.ProductDataModel{}).
+		Where("id = ?", product.Id).
+		Updates(map[string]interface{}{
+			"is_pinned":             isPinned,
+			"sort_order":            sortOrder,
+			"updated_at":            now,
+			contracts.VersionColumn: gorm.Expr(contracts.VersionColumn + " + 1"),
+		})
+	if result.Error != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			customErrors.WrapIfCanceled(ctx, result.Error, "updating product merchandising canceled"),
+			"error in updating merchandising of the product",
+		)
+	}
+
+	if err := m.productHistory.Record(ctx, models.ProductUpdated, product.Id); err != nil {
+		return nil, err
+	}
+
+	product.IsPinned = isPinned
+	product.SortOrder = sortOrder
+	product.UpdatedAt = now
+	product.Version++
+
+	merchandisingChanged := NewProductMerchandisingChangedV1(
+		product.Id,
+		product.Category,
+		product.IsPinned,
+		product.SortOrder,
+		now,
+	)
+
+	err := m.outboxPublisher.PublishViaOutbox(ctx, merchandisingChanged, nil)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in publishing 'ProductMerchandisingChanged' message",
+		)
+	}
+
+	m.log.Infow(
+		fmt.Sprintf(
+			"ProductMerchandisingChanged message with messageId `%s` for product with id '%s' published to the rabbitmq broker",
+			merchandisingChanged.MessageId,
+			product.Id,
+		),
+		logger.Fields{
+			"Id":        product.Id,
+			"IsPinned":  product.IsPinned,
+			"SortOrder": product.SortOrder,
+			"MessageId": merchandisingChanged.MessageId,
+		},
+	)
+
+	return product, nil
+}
@@ -0,0 +1,36 @@
+package merchandising
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// ProductMerchandisingChangedV1 is published whenever a product is pinned, unpinned or its manual sort order changes.
+type ProductMerchandisingChangedV1 struct {
+	*types.Message
+	ProductId uuid.UUID `json:"productId"`
+	Category  string    `json:"category,omitempty"`
+	IsPinned  bool      `json:"isPinned"`
+	SortOrder int       `json:"sortOrder"`
+	ChangedAt time.Time `json:"changedAt"`
+}
+
+func NewProductMerchandisingChangedV1(
+	productId uuid.UUID,
+	category string,
+	isPinned bool,
+	sortOrder int,
+	changedAt time.Time,
+) *ProductMerchandisingChangedV1 {
+	return &ProductMerchandisingChangedV1{
+		Message:   types.NewMessage(uuid.NewV4().String()),
+		ProductId: productId,
+		Category:  category,
+		IsPinned:  isPinned,
+		SortOrder: sortOrder,
+		ChangedAt: changedAt,
+	}
+}
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/datatypes"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+type AttributeType string
+
+const (
+	AttributeTypeString  AttributeType = "string"
+	AttributeTypeNumber  AttributeType = "number"
+	AttributeTypeBoolean AttributeType = "boolean"
+	AttributeTypeEnum    AttributeType = "enum"
+)
+
+// AttributeSet defines the typed custom fields that products of a category can have
+type AttributeSet struct {
+	Id        uuid.UUID
+	Category  string
+	Fields    []*AttributeField
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// AttributeField is a typed custom field definition inside an AttributeSet
+type AttributeField struct {
+	Name     string        `json:"name"`
+	Type     AttributeType `json:"type"`
+	Required bool          `json:"required"`
+	// Options contains allowed values for `enum` fields
+	Options []string `json:"options,omitempty"`
+}
+
+// ValidateAttributes validates product attributes against the attribute set fields
+func (s *AttributeSet) ValidateAttributes(attributes datatypes.JSONMap) error {
+	errs := validation.Errors{}
+
+	fields := make(map[string]*AttributeField, len(s.Fields))
+	for _, field := range s.Fields {
+		fields[field.Name] = field
+
+		value, ok := attributes[field.Name]
+		if !ok || value == nil {
+			if field.Required {
+				errs[field.Name] = fmt.Errorf("is required")
+			}
+
+			continue
+		}
+
+		if err := field.validateValue(value); err != nil {
+			errs[field.Name] = err
+		}
+	}
+
+	for name := range attributes {
+		if _, ok := fields[name]; !ok {
+			errs[name] = fmt.Errorf(
+				"attribute is not defined for category `%s`",
+				s.Category,
+			)
+		}
+	}
+
+	return errs.Filter()
+}
+
+func (f *AttributeField) validateValue(value interface{}) error {
+	switch f.Type {
+	case AttributeTypeString:
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("must be a string")
+		}
+	case AttributeTypeNumber:
+		switch value.(type) {
+		case float64, float32, int, int32, int64:
+		default:
+			return fmt.Errorf("must be a number")
+		}
+	case AttributeTypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("must be a boolean")
+		}
+	case AttributeTypeEnum:
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("must be one of %v", f.Options)
+		}
+		for _, option := range f.Options {
+			if option == str {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("must be one of %v", f.Options)
+	default:
+		return fmt.Errorf("unknown attribute type `%s`", f.Type)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// PriceList keeps the prices of the products for a customer group, like a b2b tier, in its effective dates
+type PriceList struct {
+	Id            uuid.UUID
+	Name          string
+	CustomerGroup string
+	EffectiveFrom time.Time
+	// EffectiveTo is nil for a price list without an end date
+	EffectiveTo *time.Time
+	Prices      []*ProductPrice
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// ProductPrice is the price of a product inside a PriceList
+type ProductPrice struct {
+	ProductId uuid.UUID `json:"productId"`
+	Price     float64   `json:"price"`
+}
+
+// IsEffectiveAt returns true when the time is in the effective dates of the price list, the end date is exclusive
+func (p *PriceList) IsEffectiveAt(t time.Time) bool {
+	if t.Before(p.EffectiveFrom) {
+		return false
+	}
+
+	return p.EffectiveTo == nil || t.Before(*p.EffectiveTo)
+}
+
+// PriceOf returns the price of the product in the price list
+func (p *PriceList) PriceOf(productId uuid.UUID) (float64, bool) {
+	for _, price := range p.Prices {
+		if uuid.Equal(price.ProductId, productId) {
+			return price.Price, true
+		}
+	}
+
+	return 0, false
+}
+
+// ResolvePrice returns the price of the product in the effective price lists at the time, the price list with the
+// latest effective from wins, so a promotional price list overrides a long-running tier price list in its dates
+func ResolvePrice(priceLists []*PriceList, productId uuid.UUID, at time.Time) (*PriceList, float64, bool) {
+	var resolved *PriceList
+	var resolvedPrice float64
+
+	for _, priceList := range priceLists {
+		if !priceList.IsEffectiveAt(at) {
+			continue
+		}
+
+		price, ok := priceList.PriceOf(productId)
+		if !ok {
+			continue
+		}
+
+		if resolved == nil || priceList.EffectiveFrom.After(resolved.EffectiveFrom) {
+			resolved = priceList
+			resolvedPrice = price
+		}
+	}
+
+	return resolved, resolvedPrice, resolved != nil
+}
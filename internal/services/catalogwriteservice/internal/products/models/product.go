@@ -3,6 +3,8 @@ package models
 import (
 	"time"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/datatypes"
+
 	uuid "github.com/satori/go.uuid"
 )
 
@@ -12,6 +14,33 @@ type Product struct {
 	Name        string
 	Description string
 	Price       float64
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	Sku         string
+	Barcode     string
+	Category    string
+	Attributes  datatypes.JSONMap
+	// Translations keeps localized name and description by locale, Name and Description are used as fallback
+	Translations map[string]*ProductTranslation
+	// PublishAt and UnpublishAt are the publishing window of the product and IsPublished is its current visibility
+	// which is kept in sync with the window by the publication scheduler
+	PublishAt   *time.Time
+	UnpublishAt *time.Time
+	IsPublished bool
+	// IsPinned and SortOrder are the manual merchandising of the product in its category, pinned products are listed
+	// first and then the products with a sort order, a zero SortOrder means the product has no manual position
+	IsPinned  bool
+	SortOrder int
+	// IsArchived and ArchivedAt mark a product which is retired from the catalog but kept for its history, unlike the
+	// deleted products
+	IsArchived bool
+	ArchivedAt *time.Time
+	// Version is the optimistic concurrency version of the product, a change of a stale version is rejected with a
+	// concurrency conflict
+	Version   int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// PublishingSchedule returns the publishing window of the product
+func (p *Product) PublishingSchedule() PublishingSchedule {
+	return NewPublishingSchedule(p.PublishAt, p.UnpublishAt)
 }
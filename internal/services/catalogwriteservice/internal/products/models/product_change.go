@@ -0,0 +1,132 @@
+package models
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/datatypes"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type ProductChangeType string
+
+const (
+	ProductCreated ProductChangeType = "created"
+	ProductUpdated ProductChangeType = "updated"
+	ProductDeleted ProductChangeType = "deleted"
+)
+
+// ProductChange is the snapshot of a product after one of its changes, the snapshot is the json of the ProductDto, so
+// the diffs have the field names of the api
+type ProductChange struct {
+	Id         uuid.UUID
+	ProductId  uuid.UUID
+	ChangeType ProductChangeType
+	Snapshot   datatypes.JSONMap
+	OccurredAt time.Time
+}
+
+// ProductDiff is what changed in a product between two points in time
+type ProductDiff struct {
+	ProductId  uuid.UUID
+	ChangeType ProductChangeType
+	// ChangedAt is the time of the last change of the product in the window
+	ChangedAt time.Time
+	// Product is the snapshot of the product at the end of the window, it is nil for a deleted product
+	Product datatypes.JSONMap
+	// Fields are the changed fields of an updated product
+	Fields []*ProductFieldDiff
+}
+
+type ProductFieldDiff struct {
+	Field string
+	From  interface{}
+	To    interface{}
+}
+
+// ignoredDiffFields are changed by every change of a product, so they are not reported as changed fields
+var ignoredDiffFields = map[string]bool{"version": true, "createdAt": true, "updatedAt": true}
+
+// DiffProductChanges returns the diffs of the products which have changes in a window, the baselines are the last
+// changes of the products before the window and the changes are the changes in the window in their order. a product
+// which is created and deleted in the window is skipped, and so is a product which is changed back to its baseline. a
+// product without a baseline which is updated in the window is changed before its history, so it is reported as
+// updated without its changed fields.
+func DiffProductChanges(baselines []*ProductChange, changes []*ProductChange) []*ProductDiff {
+	baselineOf := make(map[uuid.UUID]*ProductChange, len(baselines))
+	for _, baseline := range baselines {
+		baselineOf[baseline.ProductId] = baseline
+	}
+
+	var productIds []uuid.UUID
+	firstOf := make(map[uuid.UUID]*ProductChange)
+	lastOf := make(map[uuid.UUID]*ProductChange)
+	for _, change := range changes {
+		if _, ok := firstOf[change.ProductId]; !ok {
+			firstOf[change.ProductId] = change
+			productIds = append(productIds, change.ProductId)
+		}
+		lastOf[change.ProductId] = change
+	}
+
+	var diffs []*ProductDiff
+	for _, productId := range productIds {
+		baseline, first, last := baselineOf[productId], firstOf[productId], lastOf[productId]
+
+		existedBefore := baseline != nil && baseline.ChangeType != ProductDeleted ||
+			baseline == nil && first.ChangeType != ProductCreated
+		existsAfter := last.ChangeType != ProductDeleted
+
+		diff := &ProductDiff{ProductId: productId, ChangedAt: last.OccurredAt}
+
+		switch {
+		case !existedBefore && existsAfter:
+			diff.ChangeType = ProductCreated
+			diff.Product = last.Snapshot
+		case existedBefore && !existsAfter:
+			diff.ChangeType = ProductDeleted
+		case existedBefore && existsAfter:
+			diff.ChangeType = ProductUpdated
+			diff.Product = last.Snapshot
+			if baseline != nil {
+				diff.Fields = diffSnapshots(baseline.Snapshot, last.Snapshot)
+				if len(diff.Fields) == 0 {
+					continue
+				}
+			}
+		default:
+			continue
+		}
+
+		diffs = append(diffs, diff)
+	}
+
+	return diffs
+}
+
+func diffSnapshots(from datatypes.JSONMap, to datatypes.JSONMap) []*ProductFieldDiff {
+	fields := make(map[string]bool, len(to))
+	for field := range from {
+		fields[field] = true
+	}
+	for field := range to {
+		fields[field] = true
+	}
+
+	var diffs []*ProductFieldDiff
+	for field := range fields {
+		if ignoredDiffFields[field] || reflect.DeepEqual(from[field], to[field]) {
+			continue
+		}
+
+		diffs = append(diffs, &ProductFieldDiff{Field: field, From: from[field], To: to[field]})
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Field < diffs[j].Field
+	})
+
+	return diffs
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"fmt"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/localization"
+)
+
+// ProductTranslation holds localized content of a product for a locale
+type ProductTranslation struct {
+	Name        string
+	Description string
+}
+
+func NewProductTranslation(name string, description string) *ProductTranslation {
+	return &ProductTranslation{Name: name, Description: description}
+}
+
+// NormalizeTranslations validates translations and converts their locales to the canonical BCP 47 form
+func NormalizeTranslations(
+	translations map[string]*ProductTranslation,
+) (map[string]*ProductTranslation, error) {
+	if translations == nil {
+		return nil, nil
+	}
+
+	normalized := make(map[string]*ProductTranslation, len(translations))
+	for locale, translation := range translations {
+		normalizedLocale, err := localization.NormalizeLocale(locale)
+		if err != nil {
+			return nil, customErrors.NewValidationErrorWrap(
+				err,
+				fmt.Sprintf("locale `%s` is not a valid BCP 47 language tag", locale),
+			)
+		}
+
+		if translation == nil || translation.Name == "" {
+			return nil, customErrors.NewValidationError(
+				fmt.Sprintf("translation name for locale `%s` is required", locale),
+			)
+		}
+
+		if _, ok := normalized[normalizedLocale]; ok {
+			return nil, customErrors.NewValidationError(
+				fmt.Sprintf("duplicate translation for locale `%s`", normalizedLocale),
+			)
+		}
+
+		normalized[normalizedLocale] = translation
+	}
+
+	return normalized, nil
+}
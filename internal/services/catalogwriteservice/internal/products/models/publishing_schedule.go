@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"emperror.dev/errors"
+)
+
+// PublishingSchedule is the visibility window of a product in the catalog, a nil PublishAt means the product is
+// published immediately and a nil UnpublishAt means the product never gets unpublished
+type PublishingSchedule struct {
+	PublishAt   *time.Time
+	UnpublishAt *time.Time
+}
+
+func NewPublishingSchedule(publishAt *time.Time, unpublishAt *time.Time) PublishingSchedule {
+	return PublishingSchedule{PublishAt: publishAt, UnpublishAt: unpublishAt}
+}
+
+// IsPublishedAt reports whether the schedule window contains the given time
+func (s PublishingSchedule) IsPublishedAt(now time.Time) bool {
+	if s.PublishAt != nil && now.Before(*s.PublishAt) {
+		return false
+	}
+
+	if s.UnpublishAt != nil && !now.Before(*s.UnpublishAt) {
+		return false
+	}
+
+	return true
+}
+
+func (s PublishingSchedule) Validate() error {
+	if s.PublishAt != nil && s.UnpublishAt != nil && !s.UnpublishAt.After(*s.PublishAt) {
+		return errors.New("unpublishAt should be after publishAt")
+	}
+
+	return nil
+}
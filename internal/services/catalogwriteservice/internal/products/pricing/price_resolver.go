@@ -0,0 +1,88 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/authentication"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mapper"
+	datamodel "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/datamodels"
+	dtoV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/data/dbcontext"
+)
+
+type PriceResolver interface {
+	// EffectivePriceLists returns the price lists of the customer group which are effective at the time
+	EffectivePriceLists(ctx context.Context, customerGroup string, at time.Time) ([]*models.PriceList, error)
+	// ApplyCustomerPrices sets the customer price of the products from the price lists of the customer group of the
+	// request, the products are not changed when the request doesn't have a customer context.
+	ApplyCustomerPrices(ctx context.Context, products ...*dtoV1.ProductDto) error
+}
+
+type priceResolver struct {
+	dbContext *dbcontext.CatalogsGormDBContext
+}
+
+func NewPriceResolver(dbContext *dbcontext.CatalogsGormDBContext) PriceResolver {
+	return &priceResolver{dbContext: dbContext}
+}
+
+func (p *priceResolver) EffectivePriceLists(
+	ctx context.Context,
+	customerGroup string,
+	at time.Time,
+) ([]*models.PriceList, error) {
+	var dataModels []*datamodel.PriceListDataModel
+
+	result := p.dbContext.WithTxIfExists(ctx).
+		DB().
+		WithContext(ctx).
+		Where("customer_group = ? AND effective_from <= ? AND (effective_to IS NULL OR effective_to > ?)", customerGroup, at, at).
+		Find(&dataModels)
+	if result.Error != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			result.Error,
+			fmt.Sprintf("error in fetching price lists of customer group `%s`", customerGroup),
+		)
+	}
+
+	priceLists, err := mapper.Map[[]*models.PriceList](dataModels)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in the mapping PriceList",
+		)
+	}
+
+	return priceLists, nil
+}
+
+func (p *priceResolver) ApplyCustomerPrices(ctx context.Context, products ...*dtoV1.ProductDto) error {
+	customerGroup, ok := authentication.CustomerGroup(ctx)
+	if !ok || len(products) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+
+	priceLists, err := p.EffectivePriceLists(ctx, customerGroup, now)
+	if err != nil {
+		return err
+	}
+
+	for _, product := range products {
+		priceList, price, ok := models.ResolvePrice(priceLists, product.Id, now)
+		if !ok {
+			continue
+		}
+
+		priceListId := priceList.Id
+		product.CustomerPrice = &price
+		product.PriceListId = &priceListId
+	}
+
+	return nil
+}
@@ -4,13 +4,34 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/contracts"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/attributes"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/bulkoperations"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/repositories"
+	applyingpublishingschedulesv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/applyingpublishingschedules/v1"
+	archivingproductsv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/archivingproducts/v1"
+	creatingattributesetv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/creatingattributeset/v1"
+	creatingpricelistv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/creatingpricelist/v1"
 	creatingproductv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/creatingproduct/v1"
 	deletingproductv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/deletingproduct/v1"
+	deletingproductsv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/deletingproducts/v1"
+	exportingproductsv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/exportingproducts/v1"
+	gettingattributesetv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/gettingattributeset/v1"
+	gettingcatalogdiffv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/gettingcatalogdiff/v1"
+	gettingproductbarcodev1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/gettingproductbarcode/v1"
 	gettingproductbyidv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/gettingproductbyid/v1"
 	gettingproductsv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/gettingproducts/v1"
+	gettingproductsbyidsv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/gettingproductsbyids/v1"
+	pinningproductv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/pinningproduct/v1"
+	schedulingcategorypublicationv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/schedulingcategorypublication/v1"
+	schedulingproductpublicationv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/schedulingproductpublication/v1"
 	searchingproductsv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/searchingproduct/v1"
+	sortingcategoryproductsv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/sortingcategoryproducts/v1"
 	updatingoroductsv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/updatingproduct/v1"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/history"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/merchandising"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/pricing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/publishing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/skugeneration"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/grpc"
 
 	"github.com/labstack/echo/v4"
@@ -23,6 +44,16 @@ var Module = fx.Module(
 	// Other provides
 	fx.Provide(repositories.NewPostgresProductRepository),
 	fx.Provide(grpc.NewProductGrpcService),
+	fx.Provide(skugeneration.NewSkuOptions),
+	fx.Provide(skugeneration.NewSkuGenerator),
+	fx.Provide(attributes.NewAttributesValidator),
+	fx.Provide(publishing.NewPublishingOptions),
+	fx.Provide(publishing.NewVisibilityManager),
+	fx.Provide(merchandising.NewMerchandisingManager),
+	fx.Provide(bulkoperations.NewBulkOperationsOptions),
+	fx.Provide(bulkoperations.NewBulkProcessor),
+	fx.Provide(pricing.NewPriceResolver),
+	fx.Provide(history.NewProductHistory),
 
 	fx.Provide(
 		fx.Annotate(func(catalogsServer contracts.EchoHttpServer) *echo.Group {
@@ -37,6 +68,32 @@ var Module = fx.Module(
 		}, fx.ResultTags(`name:"product-echo-group"`)),
 	),
 
+	fx.Provide(
+		fx.Annotate(func(catalogsServer contracts.EchoHttpServer) *echo.Group {
+			var g *echo.Group
+			catalogsServer.RouteBuilder().
+				RegisterGroupFunc("/api/v1", func(v1 *echo.Group) {
+					group := v1.Group("/attribute-sets")
+					g = group
+				})
+
+			return g
+		}, fx.ResultTags(`name:"attribute-set-echo-group"`)),
+	),
+
+	fx.Provide(
+		fx.Annotate(func(catalogsServer contracts.EchoHttpServer) *echo.Group {
+			var g *echo.Group
+			catalogsServer.RouteBuilder().
+				RegisterGroupFunc("/api/v1", func(v1 *echo.Group) {
+					group := v1.Group("/price-lists")
+					g = group
+				})
+
+			return g
+		}, fx.ResultTags(`name:"price-list-echo-group"`)),
+	),
+
 	// add cqrs handlers to DI
 	fx.Provide(
 		cqrs.AsHandler(
@@ -63,6 +120,62 @@ var Module = fx.Module(
 			updatingoroductsv1.NewUpdateProductHandler,
 			"product-handlers",
 		),
+		cqrs.AsHandler(
+			gettingproductbarcodev1.NewGetProductBarcodeHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			creatingattributesetv1.NewCreateAttributeSetHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			gettingattributesetv1.NewGetAttributeSetHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			schedulingproductpublicationv1.NewScheduleProductPublicationHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			schedulingcategorypublicationv1.NewScheduleCategoryPublicationHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			applyingpublishingschedulesv1.NewApplyPublishingSchedulesHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			exportingproductsv1.NewExportProductsHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			pinningproductv1.NewPinProductHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			sortingcategoryproductsv1.NewSortCategoryProductsHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			deletingproductsv1.NewDeleteProductsHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			archivingproductsv1.NewArchiveProductsHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			creatingpricelistv1.NewCreatePriceListHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			gettingcatalogdiffv1.NewGetCatalogDiffHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			gettingproductsbyidsv1.NewGetProductsByIdsHandler,
+			"product-handlers",
+		),
 	),
 
 	// add endpoints to DI
@@ -91,5 +204,60 @@ var Module = fx.Module(
 			deletingproductv1.NewDeleteProductEndpoint,
 			"product-routes",
 		),
+		route.AsRoute(
+			gettingproductbarcodev1.NewGetProductBarcodeEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			creatingattributesetv1.NewCreateAttributeSetEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			gettingattributesetv1.NewGetAttributeSetEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			schedulingproductpublicationv1.NewScheduleProductPublicationEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			schedulingcategorypublicationv1.NewScheduleCategoryPublicationEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			exportingproductsv1.NewExportProductsEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			pinningproductv1.NewPinProductEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			sortingcategoryproductsv1.NewSortCategoryProductsEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			deletingproductsv1.NewDeleteProductsEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			archivingproductsv1.NewArchiveProductsEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			creatingpricelistv1.NewCreatePriceListEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			gettingcatalogdiffv1.NewGetCatalogDiffEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			gettingproductsbyidsv1.NewGetProductsByIdsEndpoint,
+			"product-routes",
+		),
 	),
+
+	// background jobs
+	fx.Invoke(applyingpublishingschedulesv1.RegisterPublishingScheduler),
 )
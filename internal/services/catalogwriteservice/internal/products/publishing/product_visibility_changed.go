@@ -0,0 +1,36 @@
+package publishing
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// ProductVisibilityChangedV1 is published whenever the publishing schedule or the visibility of a product changes.
+type ProductVisibilityChangedV1 struct {
+	*types.Message
+	ProductId   uuid.UUID  `json:"productId"`
+	IsPublished bool       `json:"isPublished"`
+	PublishAt   *time.Time `json:"publishAt,omitempty"`
+	UnpublishAt *time.Time `json:"unpublishAt,omitempty"`
+	ChangedAt   time.Time  `json:"changedAt"`
+}
+
+func NewProductVisibilityChangedV1(
+	productId uuid.UUID,
+	isPublished bool,
+	publishAt *time.Time,
+	unpublishAt *time.Time,
+	changedAt time.Time,
+) *ProductVisibilityChangedV1 {
+	return &ProductVisibilityChangedV1{
+		Message:     types.NewMessage(uuid.NewV4().String()),
+		ProductId:   productId,
+		IsPublished: isPublished,
+		PublishAt:   publishAt,
+		UnpublishAt: unpublishAt,
+		ChangedAt:   changedAt,
+	}
+}
@@ -0,0 +1,24 @@
+package publishing
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[PublishingOptions]())
+
+// PublishingOptions controls the publication scheduler which enforces the publishing windows of the products.
+type PublishingOptions struct {
+	Enabled bool `mapstructure:"enabled"`
+	// IntervalSeconds is the delay between two runs of the scheduler
+	IntervalSeconds int `mapstructure:"intervalSeconds" default:"60"`
+	// BatchSize is the max number of products that their visibility will be changed in a single run
+	BatchSize int `mapstructure:"batchSize"       default:"100"`
+}
+
+func NewPublishingOptions(environment environment.Environment) (*PublishingOptions, error) {
+	return config.BindConfigKey[*PublishingOptions](optionName, environment)
+}
@@ -0,0 +1,157 @@
+package publishing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/bus"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mapper"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/contracts"
+	datamodel "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/datamodels"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/history"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/data/dbcontext"
+
+	"gorm.io/gorm"
+)
+
+// pendingVisibilityCondition matches products that their `is_published` flag is out of sync with their publishing window
+const pendingVisibilityCondition = "(is_published = ? AND ((publish_at IS NOT NULL AND publish_at > ?) OR (unpublish_at IS NOT NULL AND unpublish_at <= ?))) OR " +
+	"(is_published = ? AND (publish_at IS NULL OR publish_at <= ?) AND (unpublish_at IS NULL OR unpublish_at > ?))"
+
+type VisibilityManager interface {
+	// ApplySchedule stores the publishing schedule of the product together with its visibility at `now` and publishes
+	// a ProductVisibilityChangedV1 event for the read side.
+	ApplySchedule(
+		ctx context.Context,
+		product *models.Product,
+		schedule models.PublishingSchedule,
+		now time.Time,
+	) (*models.Product, error)
+	// FindPendingProducts returns the products that their visibility doesn't match with their publishing schedule at `now`.
+	FindPendingProducts(ctx context.Context, now time.Time, limit int) ([]*models.Product, error)
+}
+
+type visibilityManager struct {
+	dbContext       *dbcontext.CatalogsGormDBContext
+	outboxPublisher bus.OutboxPublisher
+	productHistory  history.ProductHistory
+	log             logger.Logger
+}
+
+func NewVisibilityManager(
+	dbContext *dbcontext.CatalogsGormDBContext,
+	outboxPublisher bus.OutboxPublisher,
+	productHistory history.ProductHistory,
+	log logger.Logger,
+) VisibilityManager {
+	return &visibilityManager{
+		dbContext:       dbContext,
+		outboxPublisher: outboxPublisher,
+		productHistory:  productHistory,
+		log:             log,
+	}
+}
+
+func (v *visibilityManager) ApplySchedule(
+	ctx context.Context,
+	product *models.Product,
+	schedule models.PublishingSchedule,
+	now time.Time,
+) (*models.Product, error) {
+	isPublished := schedule.IsPublishedAt(now)
+
+	// `Updates` with a struct skips zero values, so we use a map for being able to clear the schedule and unpublish the product
+	result := v.dbContext.WithTxIfExists(ctx).DB().
+		WithContext(ctx).
+		Model(&datamodel.ProductDataModel{}).
+		Where("id = ?", product.Id).
+		Updates(map[string]interface{}{
+			"publish_at":            schedule.PublishAt,
+			"unpublish_at":          schedule.UnpublishAt,
+			"is_published":          isPublished,
+			"updated_at":            now,
+			contracts.VersionColumn: gorm.Expr(contracts.VersionColumn + " + 1"),
+		})
+	if result.Error != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			customErrors.WrapIfCanceled(ctx, result.Error, "updating publishing schedule canceled"),
+			"error in updating publishing schedule of the product",
+		)
+	}
+
+	if err := v.productHistory.Record(ctx, models.ProductUpdated, product.Id); err != nil {
+		return nil, err
+	}
+
+	product.PublishAt = schedule.PublishAt
+	product.UnpublishAt = schedule.UnpublishAt
+	product.IsPublished = isPublished
+	product.UpdatedAt = now
+	product.Version++
+
+	visibilityChanged := NewProductVisibilityChangedV1(
+		product.Id,
+		product.IsPublished,
+		product.PublishAt,
+		product.UnpublishAt,
+		now,
+	)
+
+	err := v.outboxPublisher.PublishViaOutbox(ctx, visibilityChanged, nil)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in publishing 'ProductVisibilityChanged' message",
+		)
+	}
+
+	v.log.Infow(
+		fmt.Sprintf(
+			"ProductVisibilityChanged message with messageId `%s` for product with id '%s' published to the rabbitmq broker",
+			visibilityChanged.MessageId,
+			product.Id,
+		),
+		logger.Fields{
+			"Id":          product.Id,
+			"IsPublished": product.IsPublished,
+			"MessageId":   visibilityChanged.MessageId,
+		},
+	)
+
+	return product, nil
+}
+
+func (v *visibilityManager) FindPendingProducts(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+) ([]*models.Product, error) {
+	var dataModels []*datamodel.ProductDataModel
+
+	result := v.dbContext.WithTxIfExists(ctx).DB().
+		WithContext(ctx).
+		Where(pendingVisibilityCondition, true, now, now, false, now, now).
+		Order("id").
+		Limit(limit).
+		Find(&dataModels)
+	if result.Error != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			customErrors.WrapIfCanceled(ctx, result.Error, "finding pending products canceled"),
+			"error in finding products with pending visibility changes",
+		)
+	}
+
+	products, err := mapper.Map[[]*models.Product](dataModels)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in the mapping products",
+		)
+	}
+
+	return products, nil
+}
@@ -0,0 +1,98 @@
+package skugeneration
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+
+	"emperror.dev/errors"
+)
+
+// https://en.wikipedia.org/wiki/International_Article_Number#Binary_encoding_of_data_digits_into_EAN-13_barcode
+
+const (
+	ean13Modules     = 95
+	quietZoneModules = 9
+	moduleWidth      = 3
+	barHeight        = 120
+)
+
+var (
+	lCodes = [10]string{
+		"0001101", "0011001", "0010011", "0111101", "0100011",
+		"0110001", "0101111", "0111011", "0110111", "0001011",
+	}
+	gCodes = [10]string{
+		"0100111", "0110011", "0011011", "0100001", "0011101",
+		"0111001", "0000101", "0010001", "0001001", "0010111",
+	}
+	rCodes = [10]string{
+		"1110010", "1100110", "1101100", "1000010", "1011100",
+		"1001110", "1010000", "1000100", "1001000", "1110100",
+	}
+	// parity of the left group is selected by the first digit
+	firstDigitParity = [10]string{
+		"LLLLLL", "LLGLGG", "LLGGLG", "LLGGGL", "LGLLGG",
+		"LGGLLG", "LGGGLL", "LGLGLG", "LGLGGL", "LGGLGL",
+	}
+)
+
+// EncodeEan13 returns the 95 module bit pattern of an EAN-13 code, where '1' is a bar and '0' is a space.
+func EncodeEan13(code string) (string, error) {
+	if !IsValidEan13(code) {
+		return "", errors.Errorf("'%s' is not a valid ean-13 code", code)
+	}
+
+	digits := make([]int, ean13Length)
+	for i := range code {
+		digits[i] = int(code[i] - '0')
+	}
+
+	var sb strings.Builder
+	sb.Grow(ean13Modules)
+
+	sb.WriteString("101")
+	parity := firstDigitParity[digits[0]]
+	for i := 1; i <= 6; i++ {
+		if parity[i-1] == 'L' {
+			sb.WriteString(lCodes[digits[i]])
+		} else {
+			sb.WriteString(gCodes[digits[i]])
+		}
+	}
+	sb.WriteString("01010")
+	for i := 7; i < ean13Length; i++ {
+		sb.WriteString(rCodes[digits[i]])
+	}
+	sb.WriteString("101")
+
+	return sb.String(), nil
+}
+
+// RenderEan13Png writes a png image of the EAN-13 barcode, suitable for label printing.
+func RenderEan13Png(code string, w io.Writer) error {
+	pattern, err := EncodeEan13(code)
+	if err != nil {
+		return err
+	}
+
+	width := (ean13Modules + 2*quietZoneModules) * moduleWidth
+	img := image.NewGray(image.Rect(0, 0, width, barHeight))
+
+	for x := 0; x < width; x++ {
+		module := x/moduleWidth - quietZoneModules
+
+		c := color.Gray{Y: 0xff}
+		if module >= 0 && module < ean13Modules && pattern[module] == '1' {
+			c = color.Gray{Y: 0x00}
+		}
+
+		for y := 0; y < barHeight; y++ {
+			img.SetGray(x, y, c)
+		}
+	}
+
+	return errors.WrapIf(png.Encode(w, img), "error in encoding barcode png")
+}
@@ -0,0 +1,73 @@
+package skugeneration
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"emperror.dev/errors"
+)
+
+const ean13Length = 13
+
+// Ean13CheckDigit calculates the GS1 check digit for the first 12 digits of an EAN-13 code.
+func Ean13CheckDigit(digits string) (int, error) {
+	if len(digits) != ean13Length-1 {
+		return 0, errors.Errorf("ean-13 payload should have %d digits", ean13Length-1)
+	}
+
+	sum := 0
+	for i, r := range digits {
+		if r < '0' || r > '9' {
+			return 0, errors.Errorf("invalid ean-13 digit '%c'", r)
+		}
+
+		d := int(r - '0')
+		// weights alternate 1,3 starting from the leftmost digit
+		if i%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+
+	return (10 - sum%10) % 10, nil
+}
+
+// IsValidEan13 reports whether code is a 13-digit ean with a correct check digit.
+func IsValidEan13(code string) bool {
+	if len(code) != ean13Length {
+		return false
+	}
+
+	checkDigit, err := Ean13CheckDigit(code[:ean13Length-1])
+	if err != nil {
+		return false
+	}
+
+	return int(code[ean13Length-1]-'0') == checkDigit
+}
+
+func randomEan13(prefix string) (string, error) {
+	if len(prefix) >= ean13Length-1 {
+		return "", errors.Errorf("ean prefix '%s' is too long", prefix)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(prefix)
+	for sb.Len() < ean13Length-1 {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(n.String())
+	}
+
+	payload := sb.String()
+	checkDigit, err := Ean13CheckDigit(payload)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s%d", payload, checkDigit), nil
+}
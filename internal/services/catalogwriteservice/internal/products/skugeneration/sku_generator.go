@@ -0,0 +1,190 @@
+package skugeneration
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	datamodel "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/datamodels"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/data/dbcontext"
+
+	"emperror.dev/errors"
+)
+
+const (
+	skuAlphabet      = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	skuNameLength    = 3
+	skuColumnName    = "sku"
+	barcodeColumName = "barcode"
+)
+
+var placeholderRegex = regexp.MustCompile(`\{([A-Z]+)(?::(\d+))?\}`)
+
+type SkuGenerator interface {
+	// GenerateSku generates a sku based on the configured pattern which is unique between existing products.
+	GenerateSku(ctx context.Context, productName string) (string, error)
+	// GenerateEan generates a valid EAN-13 barcode which is unique between existing products.
+	GenerateEan(ctx context.Context) (string, error)
+	// SkuExists checks whether a sku is already assigned to a product.
+	SkuExists(ctx context.Context, sku string) (bool, error)
+	// BarcodeExists checks whether a barcode is already assigned to a product.
+	BarcodeExists(ctx context.Context, barcode string) (bool, error)
+}
+
+type skuGenerator struct {
+	options   *SkuOptions
+	dbContext *dbcontext.CatalogsGormDBContext
+}
+
+func NewSkuGenerator(
+	options *SkuOptions,
+	dbContext *dbcontext.CatalogsGormDBContext,
+) SkuGenerator {
+	return &skuGenerator{options: options, dbContext: dbContext}
+}
+
+func (s *skuGenerator) GenerateSku(ctx context.Context, productName string) (string, error) {
+	for i := 0; i < s.options.MaxAttempts; i++ {
+		sku, err := expandSkuPattern(s.options.SkuPattern, productName, time.Now())
+		if err != nil {
+			return "", err
+		}
+
+		exists, err := s.SkuExists(ctx, sku)
+		if err != nil {
+			return "", err
+		}
+
+		if !exists {
+			return sku, nil
+		}
+	}
+
+	return "", customErrors.NewConflictError(
+		fmt.Sprintf(
+			"couldn't generate a unique sku after %d attempts",
+			s.options.MaxAttempts,
+		),
+	)
+}
+
+func (s *skuGenerator) GenerateEan(ctx context.Context) (string, error) {
+	for i := 0; i < s.options.MaxAttempts; i++ {
+		ean, err := randomEan13(s.options.EanPrefix)
+		if err != nil {
+			return "", err
+		}
+
+		exists, err := s.BarcodeExists(ctx, ean)
+		if err != nil {
+			return "", err
+		}
+
+		if !exists {
+			return ean, nil
+		}
+	}
+
+	return "", customErrors.NewConflictError(
+		fmt.Sprintf(
+			"couldn't generate a unique barcode after %d attempts",
+			s.options.MaxAttempts,
+		),
+	)
+}
+
+func (s *skuGenerator) SkuExists(ctx context.Context, sku string) (bool, error) {
+	return s.exists(ctx, skuColumnName, sku)
+}
+
+func (s *skuGenerator) BarcodeExists(ctx context.Context, barcode string) (bool, error) {
+	return s.exists(ctx, barcodeColumName, barcode)
+}
+
+func (s *skuGenerator) exists(ctx context.Context, column string, value string) (bool, error) {
+	var count int64
+
+	err := s.dbContext.WithTxIfExists(ctx).
+		DB().
+		WithContext(ctx).
+		Model(&datamodel.ProductDataModel{}).
+		Where(fmt.Sprintf("%s = ?", column), value).
+		Count(&count).
+		Error
+	if err != nil {
+		return false, customErrors.WrapIfCanceled(ctx, err, fmt.Sprintf("checking the product %s canceled", column))
+	}
+
+	return count > 0, nil
+}
+
+func expandSkuPattern(pattern string, productName string, now time.Time) (string, error) {
+	var expandErr error
+
+	sku := placeholderRegex.ReplaceAllStringFunc(pattern, func(placeholder string) string {
+		parts := placeholderRegex.FindStringSubmatch(placeholder)
+		switch parts[1] {
+		case "NAME":
+			return namePart(productName)
+		case "DATE":
+			return now.Format("060102")
+		case "RAND":
+			length := 6
+			if parts[2] != "" {
+				length, _ = strconv.Atoi(parts[2])
+			}
+			random, err := randomString(length)
+			if err != nil {
+				expandErr = err
+			}
+
+			return random
+		default:
+			expandErr = errors.Errorf("unknown sku placeholder '%s'", placeholder)
+
+			return ""
+		}
+	})
+
+	return sku, expandErr
+}
+
+func namePart(productName string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToUpper(productName) {
+		if sb.Len() == skuNameLength {
+			break
+		}
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			sb.WriteRune(r)
+		}
+	}
+
+	for sb.Len() < skuNameLength {
+		sb.WriteByte('X')
+	}
+
+	return sb.String()
+}
+
+func randomString(length int) (string, error) {
+	var sb strings.Builder
+	max := big.NewInt(int64(len(skuAlphabet)))
+
+	for i := 0; i < length; i++ {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteByte(skuAlphabet[n.Int64()])
+	}
+
+	return sb.String(), nil
+}
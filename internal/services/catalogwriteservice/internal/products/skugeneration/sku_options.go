@@ -0,0 +1,24 @@
+package skugeneration
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[SkuOptions]())
+
+// SkuOptions controls how sku and ean codes are generated for new products.
+// SkuPattern supports `{NAME}` (first letters of the product name), `{DATE}` (yyMMdd)
+// and `{RAND:n}` (n random upper-case alphanumeric characters) placeholders.
+type SkuOptions struct {
+	SkuPattern  string `mapstructure:"skuPattern"  default:"{NAME}-{RAND:6}"`
+	EanPrefix   string `mapstructure:"eanPrefix"   default:"200"`
+	MaxAttempts int    `mapstructure:"maxAttempts" default:"10"`
+}
+
+func NewSkuOptions(environment environment.Environment) (*SkuOptions, error) {
+	return config.BindConfigKey[*SkuOptions](optionName, environment)
+}
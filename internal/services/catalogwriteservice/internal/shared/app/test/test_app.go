@@ -16,6 +16,7 @@ import (
 	config2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/config"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/test/containers/testcontainer/gorm"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/test/containers/testcontainer/rabbitmq"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/test/spans"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/config"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/configurations/catalogs"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/data/dbcontext"
@@ -40,6 +41,7 @@ type TestAppResult struct {
 	GrpcClient              grpc.GrpcClient
 	PostgresMigrationRunner contracts2.PostgresMigrationRunner
 	CatalogsDBContext       *dbcontext.CatalogsGormDBContext
+	Spans                   *spans.SpanCollector
 }
 
 func NewTestApp() *TestApp {
@@ -52,6 +54,8 @@ func (a *TestApp) Run(t *testing.T) (result *TestAppResult) {
 	// ref: https://github.com/uber-go/fx/blob/master/app_test.go
 	appBuilder := NewCatalogsWriteTestApplicationBuilder(t)
 	appBuilder.ProvideModule(catalogs.CatalogsServiceModule)
+	// collecting the ended spans for asserting the instrumentation in the tests
+	appBuilder.ProvideModule(spans.Module)
 
 	appBuilder.Decorate(
 		rabbitmq.RabbitmqContainerOptionsDecorator(t, lifetimeCtx),
@@ -81,6 +85,7 @@ func (a *TestApp) Run(t *testing.T) (result *TestAppResult) {
 			echoOptions *config3.EchoHttpOptions,
 			grpcClient grpc.GrpcClient,
 			postgresMigrationRunner contracts2.PostgresMigrationRunner,
+			spanCollector *spans.SpanCollector,
 		) {
 			grpcConnection := grpcClient.GetGrpcConnection()
 
@@ -99,6 +104,7 @@ func (a *TestApp) Run(t *testing.T) (result *TestAppResult) {
 					grpcConnection,
 				),
 				GrpcClient: grpcClient,
+				Spans:      spanCollector,
 			}
 		},
 	)
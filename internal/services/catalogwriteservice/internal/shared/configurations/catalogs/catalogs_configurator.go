@@ -4,9 +4,10 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/deprecation"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/fxapp/contracts"
 	echocontracts "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/contracts"
+	deprecationmiddleware "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/deprecation"
 	migrationcontracts "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/migration/contracts"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/config"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/configurations"
@@ -51,7 +52,7 @@ func (ic *CatalogsServiceConfigurator) ConfigureCatalogs() error {
 				return err
 			}
 
-			if ic.Environment() != environment.Test {
+			if !ic.Environment().Base().IsTest() {
 				err = ic.seedCatalogs(db)
 				if err != nil {
 					return err
@@ -72,8 +73,13 @@ func (ic *CatalogsServiceConfigurator) ConfigureCatalogs() error {
 func (ic *CatalogsServiceConfigurator) MapCatalogsEndpoints() error {
 	// Shared
 	ic.ResolveFunc(
-		func(catalogsServer echocontracts.EchoHttpServer, options *config.AppOptions) error {
+		func(
+			catalogsServer echocontracts.EchoHttpServer,
+			options *config.AppOptions,
+			deprecations deprecation.Registry,
+		) error {
 			catalogsServer.SetupDefaultMiddlewares()
+			catalogsServer.AddMiddlewares(deprecationmiddleware.Deprecation(deprecations))
 
 			// config catalogs root endpoint
 			catalogsServer.RouteBuilder().
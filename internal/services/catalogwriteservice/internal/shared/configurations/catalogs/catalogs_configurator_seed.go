@@ -39,6 +39,7 @@ func seedDataManually(gormDB *gorm.DB) error {
 			CreatedAt:   time.Now(),
 			Description: gofakeit.AdjectiveDescriptive(),
 			Price:       gofakeit.Price(100, 1000),
+			IsPublished: true,
 		},
 		{
 			Id:          uuid.NewV4(),
@@ -46,6 +47,7 @@ func seedDataManually(gormDB *gorm.DB) error {
 			CreatedAt:   time.Now(),
 			Description: gofakeit.AdjectiveDescriptive(),
 			Price:       gofakeit.Price(100, 1000),
+			IsPublished: true,
 		},
 	}
 
@@ -10,11 +10,14 @@ import (
 
 func (ic *CatalogsServiceConfigurator) configSwagger(routeBuilder *customEcho.RouteBuilder) {
 	// https://github.com/swaggo/swag#how-to-use-it-with-gin
-	docs.SwaggerInfo.Version = "1.0"
-	docs.SwaggerInfo.Title = "Catalogs Write-Service Api"
-	docs.SwaggerInfo.Description = "Catalogs Write-Service Api."
+	docs.SwaggerInfocatalogwriteservice.Version = "1.0"
+	docs.SwaggerInfocatalogwriteservice.Title = "Catalogs Write-Service Api"
+	docs.SwaggerInfocatalogwriteservice.Description = "Catalogs Write-Service Api."
 
 	routeBuilder.RegisterRoutes(func(e *echo.Echo) {
-		e.GET("/swagger/*", echoSwagger.WrapHandler)
+		e.GET(
+			"/swagger/*",
+			echoSwagger.EchoWrapHandler(echoSwagger.InstanceName(docs.SwaggerInfocatalogwriteservice.InstanceName())),
+		)
 	})
 }
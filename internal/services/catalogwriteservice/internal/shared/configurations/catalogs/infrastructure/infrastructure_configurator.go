@@ -1,6 +1,7 @@
 package infrastructure
 
 import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/fxapp/contracts"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
 	loggingpipelines "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/pipelines"
@@ -11,7 +12,6 @@ import (
 	postgrespipelines "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/pipelines"
 	validationpieline "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/validation/pipeline"
 
-	"github.com/mehdihadeli/go-mediatr"
 	"gorm.io/gorm"
 )
 
@@ -30,7 +30,7 @@ func NewInfrastructureConfigurator(
 func (ic *InfrastructureConfigurator) ConfigInfrastructures() {
 	ic.ResolveFunc(
 		func(l logger.Logger, tracer tracing.AppTracer, metrics metrics.AppMetrics, db *gorm.DB) error {
-			err := mediatr.RegisterRequestPipelineBehaviors(
+			err := cqrs.RegisterRequestPipelineBehaviors(
 				loggingpipelines.NewMediatorLoggingPipeline(l),
 				validationpieline.NewMediatorValidationPipeline(l),
 				tracingpipelines.NewMediatorTracingPipeline(
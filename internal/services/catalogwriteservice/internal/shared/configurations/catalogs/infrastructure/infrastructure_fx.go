@@ -2,16 +2,23 @@ package infrastructure
 
 import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/changefeed"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/quarantine"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer/schemaregistry"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/deprecation"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/diagnostics"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/grpc"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/health"
 	customEcho "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/jobs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/messagebroker"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/migration/goose"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/metrics"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresmessaging"
-	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/configurations"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/warmup"
 	rabbitmq2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/configurations/rabbitmq"
 
 	"github.com/go-playground/validator"
@@ -24,19 +31,34 @@ var Module = fx.Module(
 	"infrastructurefx",
 	// Modules
 	core.Module,
+	schemaregistry.Module,
 	customEcho.Module,
 	grpc.Module,
+	diagnostics.Module,
+	deprecation.Module,
 	postgresgorm.Module,
 	postgresmessaging.Module,
+	// the published messages of the outbox are the change feed of the products
+	postgresmessaging.ChangeFeedModule,
+	changefeed.Module,
+	// the runs of the export jobs are kept in the `job_runs` table
+	postgresgorm.JobRunModule,
+	jobs.Module,
 	goose.Module,
-	rabbitmq.ModuleFunc(
-		func() configurations.RabbitMQConfigurationBuilderFuc {
+	messagebroker.ModuleFunc(
+		func(deprecations deprecation.Registry) configurations.RabbitMQConfigurationBuilderFuc {
 			return func(builder configurations.RabbitMQConfigurationBuilder) {
+				builder.AddPipelines(deprecation.NewConsumerPipeline(deprecations))
+
 				rabbitmq2.ConfigProductsRabbitMQ(builder)
 			}
 		},
+		// the messages which are failed after the max retries of the consumers are quarantined in postgres
+		postgresgorm.QuarantineModule,
+		quarantine.Module,
 	),
 	health.Module,
+	warmup.Module,
 	tracing.Module,
 	metrics.Module,
 
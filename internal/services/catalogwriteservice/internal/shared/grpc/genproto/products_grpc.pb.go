@@ -19,9 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	ProductsService_CreateProduct_FullMethodName  = "/products_service.ProductsService/CreateProduct"
-	ProductsService_UpdateProduct_FullMethodName  = "/products_service.ProductsService/UpdateProduct"
-	ProductsService_GetProductById_FullMethodName = "/products_service.ProductsService/GetProductById"
+	ProductsService_CreateProduct_FullMethodName    = "/products_service.ProductsService/CreateProduct"
+	ProductsService_UpdateProduct_FullMethodName    = "/products_service.ProductsService/UpdateProduct"
+	ProductsService_GetProductById_FullMethodName   = "/products_service.ProductsService/GetProductById"
+	ProductsService_GetProductsByIds_FullMethodName = "/products_service.ProductsService/GetProductsByIds"
 )
 
 // ProductsServiceClient is the client API for ProductsService service.
@@ -31,6 +32,7 @@ type ProductsServiceClient interface {
 	CreateProduct(ctx context.Context, in *CreateProductReq, opts ...grpc.CallOption) (*CreateProductRes, error)
 	UpdateProduct(ctx context.Context, in *UpdateProductReq, opts ...grpc.CallOption) (*UpdateProductRes, error)
 	GetProductById(ctx context.Context, in *GetProductByIdReq, opts ...grpc.CallOption) (*GetProductByIdRes, error)
+	GetProductsByIds(ctx context.Context, in *GetProductsByIdsReq, opts ...grpc.CallOption) (*GetProductsByIdsRes, error)
 }
 
 type productsServiceClient struct {
@@ -68,6 +70,15 @@ func (c *productsServiceClient) GetProductById(ctx context.Context, in *GetProdu
 	return out, nil
 }
 
+func (c *productsServiceClient) GetProductsByIds(ctx context.Context, in *GetProductsByIdsReq, opts ...grpc.CallOption) (*GetProductsByIdsRes, error) {
+	out := new(GetProductsByIdsRes)
+	err := c.cc.Invoke(ctx, ProductsService_GetProductsByIds_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProductsServiceServer is the server API for ProductsService service.
 // All implementations should embed UnimplementedProductsServiceServer
 // for forward compatibility
@@ -75,6 +86,7 @@ type ProductsServiceServer interface {
 	CreateProduct(context.Context, *CreateProductReq) (*CreateProductRes, error)
 	UpdateProduct(context.Context, *UpdateProductReq) (*UpdateProductRes, error)
 	GetProductById(context.Context, *GetProductByIdReq) (*GetProductByIdRes, error)
+	GetProductsByIds(context.Context, *GetProductsByIdsReq) (*GetProductsByIdsRes, error)
 }
 
 // UnimplementedProductsServiceServer should be embedded to have forward compatible implementations.
@@ -90,6 +102,9 @@ func (UnimplementedProductsServiceServer) UpdateProduct(context.Context, *Update
 func (UnimplementedProductsServiceServer) GetProductById(context.Context, *GetProductByIdReq) (*GetProductByIdRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetProductById not implemented")
 }
+func (UnimplementedProductsServiceServer) GetProductsByIds(context.Context, *GetProductsByIdsReq) (*GetProductsByIdsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductsByIds not implemented")
+}
 
 // UnsafeProductsServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to ProductsServiceServer will
@@ -156,6 +171,24 @@ func _ProductsService_GetProductById_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProductsService_GetProductsByIds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductsByIdsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductsServiceServer).GetProductsByIds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductsService_GetProductsByIds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductsServiceServer).GetProductsByIds(ctx, req.(*GetProductsByIdsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ProductsService_ServiceDesc is the grpc.ServiceDesc for ProductsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -175,6 +208,10 @@ var ProductsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProductById",
 			Handler:    _ProductsService_GetProductById_Handler,
 		},
+		{
+			MethodName: "GetProductsByIds",
+			Handler:    _ProductsService_GetProductsByIds_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "catalogwriteservice/products.proto",
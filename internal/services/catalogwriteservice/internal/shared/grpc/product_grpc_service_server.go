@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
 	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mapper"
@@ -12,6 +13,8 @@ import (
 	createProductDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/creatingproduct/v1/dtos"
 	getProductByIdQueryV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/gettingproductbyid/v1"
 	getProductByIdDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/gettingproductbyid/v1/dtos"
+	getProductsByIdsQueryV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/gettingproductsbyids/v1"
+	getProductsByIdsDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/gettingproductsbyids/v1/dtos"
 	updateProductCommandV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/updatingproduct/v1"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/contracts"
 	productsService "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/grpc/genproto"
@@ -72,7 +75,7 @@ func (s *ProductGrpcServiceServer) CreateProduct(
 		return nil, validationErr
 	}
 
-	result, err := mediatr.Send[*createProductCommandV1.CreateProduct, *createProductDtosV1.CreateProductResponseDto](
+	result, err := cqrs.Send[*createProductCommandV1.CreateProduct, *createProductDtosV1.CreateProductResponseDto](
 		ctx,
 		command,
 	)
@@ -140,7 +143,7 @@ func (s *ProductGrpcServiceServer) UpdateProduct(
 		return nil, validationErr
 	}
 
-	if _, err = mediatr.Send[*updateProductCommandV1.UpdateProduct, *mediatr.Unit](ctx, command); err != nil {
+	if _, err = cqrs.Send[*updateProductCommandV1.UpdateProduct, *mediatr.Unit](ctx, command); err != nil {
 		err = errors.WithMessage(
 			err,
 			"[ProductGrpcServiceServer_UpdateProduct.Send] error in sending CreateProduct",
@@ -201,7 +204,7 @@ func (s *ProductGrpcServiceServer) GetProductById(
 		return nil, validationErr
 	}
 
-	queryResult, err := mediatr.Send[*getProductByIdQueryV1.GetProductById, *getProductByIdDtosV1.GetProductByIdResponseDto](
+	queryResult, err := cqrs.Send[*getProductByIdQueryV1.GetProductById, *getProductByIdDtosV1.GetProductByIdResponseDto](
 		ctx,
 		query,
 	)
@@ -232,3 +235,89 @@ func (s *ProductGrpcServiceServer) GetProductById(
 
 	return &productsService.GetProductByIdRes{Product: product}, nil
 }
+
+func (s *ProductGrpcServiceServer) GetProductsByIds(
+	ctx context.Context,
+	req *productsService.GetProductsByIdsReq,
+) (*productsService.GetProductsByIdsRes, error) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Object("Request", req))
+
+	productUUIDs := make([]uuid.UUID, 0, len(req.GetProductIds()))
+	for _, productId := range req.GetProductIds() {
+		productUUID, err := uuid.FromString(productId)
+		if err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"[ProductGrpcServiceServer_GetProductsByIds.uuid.FromString] error in converting uuid",
+			)
+			s.logger.Errorf(
+				fmt.Sprintf(
+					"[ProductGrpcServiceServer_GetProductsByIds.uuid.FromString] err: %v",
+					badRequestErr,
+				),
+			)
+			return nil, badRequestErr
+		}
+
+		productUUIDs = append(productUUIDs, productUUID)
+	}
+
+	query, err := getProductsByIdsQueryV1.NewGetProductsByIdsWithValidation(productUUIDs)
+	if err != nil {
+		s.logger.Errorf(
+			fmt.Sprintf(
+				"[ProductGrpcServiceServer_GetProductsByIds.StructCtx] err: %v",
+				err,
+			),
+		)
+		return nil, err
+	}
+
+	queryResult, err := cqrs.Send[*getProductsByIdsQueryV1.GetProductsByIds, *getProductsByIdsDtosV1.GetProductsByIdsResponseDto](
+		ctx,
+		query,
+	)
+	if err != nil {
+		err = errors.WithMessage(
+			err,
+			"[ProductGrpcServiceServer_GetProductsByIds.Send] error in sending GetProductsByIds",
+		)
+		s.logger.Errorw(
+			fmt.Sprintf(
+				"[ProductGrpcServiceServer_GetProductsByIds.Send] err: %v",
+				err,
+			),
+			logger.Fields{"Count": len(query.ProductIDs)},
+		)
+		return nil, err
+	}
+
+	res := &productsService.GetProductsByIdsRes{}
+
+	for _, productDto := range queryResult.Products {
+		product, err := mapper.Map[*productsService.Product](productDto)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[ProductGrpcServiceServer_GetProductsByIds.Map] error in mapping product",
+			)
+			return nil, err
+		}
+
+		res.Products = append(res.Products, product)
+	}
+
+	for _, productId := range queryResult.NotFound {
+		res.NotFoundIds = append(res.NotFoundIds, productId.String())
+	}
+
+	for _, failed := range queryResult.Failed {
+		res.Failed = append(res.Failed, &productsService.FailedProduct{
+			ProductId: failed.ProductId.String(),
+			Error:     failed.Error,
+		})
+	}
+
+	return res, nil
+}
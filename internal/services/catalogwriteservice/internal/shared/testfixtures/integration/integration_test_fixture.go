@@ -10,6 +10,7 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
 	gormPostgres "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/helpers/gormextensions"
 	config2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/test/spans"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/testfixture"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/config"
@@ -41,6 +42,7 @@ type IntegrationTestSharedFixture struct {
 	BaseAddress          string
 	Items                []*datamodel.ProductDataModel
 	ProductServiceClient productsService.ProductsServiceClient
+	Spans                *spans.SpanCollector
 }
 
 func NewIntegrationTestSharedFixture(
@@ -70,6 +72,7 @@ func NewIntegrationTestSharedFixture(
 		Gorm:                 result.Gorm,
 		BaseAddress:          result.EchoHttpOptions.BasePathAddress(),
 		ProductServiceClient: result.ProductServiceClient,
+		Spans:                result.Spans,
 	}
 
 	return shared
@@ -78,6 +81,9 @@ func NewIntegrationTestSharedFixture(
 func (i *IntegrationTestSharedFixture) SetupTest() {
 	i.Log.Info("SetupTest started")
 
+	// the spans of the previous tests shouldn't match the assertions of the current test
+	i.Spans.Reset()
+
 	// migration will do in app configuration
 	// seed data for our tests - app seed doesn't run in test environment
 	res, err := seedDataManually(i.Gorm)
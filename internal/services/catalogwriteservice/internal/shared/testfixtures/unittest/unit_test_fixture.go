@@ -17,6 +17,7 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/config"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/configurations/mappings"
 	datamodel "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/datamodels"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/history"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/data/dbcontext"
 
 	"emperror.dev/errors"
@@ -35,8 +36,10 @@ type UnitTestSharedFixture struct {
 	suite.Suite
 	Products         []*datamodel.ProductDataModel
 	Bus              *mocks.Bus
+	OutboxPublisher  *mocks.OutboxPublisher
 	Tracer           trace.Tracer
 	CatalogDBContext *dbcontext.CatalogsGormDBContext
+	ProductHistory   history.ProductHistory
 	Ctx              context.Context
 	dbFilePath       string
 	dbFileName       string
@@ -123,11 +126,18 @@ func (c *UnitTestSharedFixture) setupBus() {
 	bus.On("PublishMessage", mock.Anything, mock.Anything, mock.Anything).
 		Return(nil)
 	c.Bus = bus
+
+	outboxPublisher := &mocks.OutboxPublisher{}
+
+	outboxPublisher.On("PublishViaOutbox", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+	c.OutboxPublisher = outboxPublisher
 }
 
 func (c *UnitTestSharedFixture) setupDB() {
 	dbContext := c.createSQLLiteDBContext()
 	c.CatalogDBContext = dbContext
+	c.ProductHistory = history.NewProductHistory(dbContext)
 
 	c.initDB(dbContext)
 }
@@ -172,7 +182,12 @@ func (c *UnitTestSharedFixture) cleanupDB() error {
 }
 
 func migrateGorm(dbContext *dbcontext.CatalogsGormDBContext) error {
-	err := dbContext.DB().AutoMigrate(&datamodel.ProductDataModel{})
+	err := dbContext.DB().AutoMigrate(
+		&datamodel.ProductDataModel{},
+		&datamodel.AttributeSetDataModel{},
+		&datamodel.PriceListDataModel{},
+		&datamodel.ProductChangeDataModel{},
+	)
 	if err != nil {
 		return err
 	}
@@ -190,6 +205,7 @@ func seedDataManually(
 			CreatedAt:   time.Now(),
 			Description: gofakeit.AdjectiveDescriptive(),
 			Price:       gofakeit.Price(100, 1000),
+			IsPublished: true,
 		},
 		{
 			Id:          uuid.NewV4(),
@@ -197,6 +213,7 @@ func seedDataManually(
 			CreatedAt:   time.Now(),
 			Description: gofakeit.AdjectiveDescriptive(),
 			Price:       gofakeit.Price(100, 1000),
+			IsPublished: true,
 		},
 	}
 
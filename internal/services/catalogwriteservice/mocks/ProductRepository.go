@@ -233,6 +233,49 @@ func (_c *ProductRepository_GetProductById_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// IterateProducts provides a mock function with given fields: ctx, fn
+func (_m *ProductRepository) IterateProducts(ctx context.Context, fn func(*models.Product) error) error {
+	ret := _m.Called(ctx, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(*models.Product) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ProductRepository_IterateProducts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IterateProducts'
+type ProductRepository_IterateProducts_Call struct {
+	*mock.Call
+}
+
+// IterateProducts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(*models.Product) error
+func (_e *ProductRepository_Expecter) IterateProducts(ctx interface{}, fn interface{}) *ProductRepository_IterateProducts_Call {
+	return &ProductRepository_IterateProducts_Call{Call: _e.mock.On("IterateProducts", ctx, fn)}
+}
+
+func (_c *ProductRepository_IterateProducts_Call) Run(run func(ctx context.Context, fn func(*models.Product) error)) *ProductRepository_IterateProducts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(*models.Product) error))
+	})
+	return _c
+}
+
+func (_c *ProductRepository_IterateProducts_Call) Return(_a0 error) *ProductRepository_IterateProducts_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ProductRepository_IterateProducts_Call) RunAndReturn(run func(context.Context, func(*models.Product) error) error) *ProductRepository_IterateProducts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SearchProducts provides a mock function with given fields: ctx, searchText, listQuery
 func (_m *ProductRepository) SearchProducts(ctx context.Context, searchText string, listQuery *utils.ListQuery) (*utils.ListResult[*models.Product], error) {
 	ret := _m.Called(ctx, searchText, listQuery)
@@ -73,6 +73,48 @@ var _ = Describe("UpdateProductE2ETest Suite", func() {
 		})
 	})
 
+	// "Scenario" step for testing the update product API with a stale version
+	Describe("Update product with a stale version returns Conflict status", func() {
+		BeforeEach(func() {
+			request = &dtos.UpdateProductRequestDto{
+				Description: gofakeit.AdjectiveDescriptive(),
+				Price:       gofakeit.Price(100, 1000),
+				Name:        gofakeit.Name(),
+			}
+		})
+
+		// "When" step
+		When("A request is made with the version before the last update of the product", func() {
+			// "Then" step
+			It("Should return a Conflict status", func() {
+				expect := httpexpect.New(GinkgoT(), integrationFixture.BaseAddress)
+				etag := expect.GET("products/{id}").
+					WithPath("id", id.String()).
+					WithContext(ctx).
+					Expect().
+					Status(http.StatusOK).
+					Header("ETag").
+					Raw()
+
+				expect.PUT("products/{id}").
+					WithPath("id", id.String()).
+					WithHeader("If-Match", etag).
+					WithJSON(request).
+					WithContext(ctx).
+					Expect().
+					Status(http.StatusNoContent)
+
+				expect.PUT("products/{id}").
+					WithPath("id", id.String()).
+					WithHeader("If-Match", etag).
+					WithJSON(request).
+					WithContext(ctx).
+					Expect().
+					Status(http.StatusConflict)
+			})
+		})
+	})
+
 	// "Scenario" step for testing the update product API with invalid input
 	Describe("Update product returns BadRequest with invalid input", func() {
 		BeforeEach(func() {
@@ -20,6 +20,7 @@ import (
 	"github.com/brianvoe/gofakeit/v6"
 	"github.com/mehdihadeli/go-mediatr"
 	uuid "github.com/satori/go.uuid"
+	"go.opentelemetry.io/otel/codes"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -111,6 +112,14 @@ var _ = Describe("Creating Product Feature", func() {
 							Expect(result).NotTo(BeNil())
 						})
 
+						It("Should trace the CreateProduct command handler", func() {
+							integrationFixture.Spans.ShouldHaveSpan(
+								GinkgoT(),
+								"command_handler.create_product_handler/create_product",
+							).WithAttribute("app.command_name", "create_product").
+								WithStatus(codes.Ok)
+						})
+
 						It(
 							"Should have a non-empty product ID matching the command ID",
 							func() {
@@ -0,0 +1,161 @@
+//go:build stress
+// +build stress
+
+package v1
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/gormdbcontext"
+	testUtils "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/test/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/attributes"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/datamodels"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	pinningproductv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/pinningproduct/v1"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/pinningproduct/v1/dtos"
+	updatingoroductsv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/updatingproduct/v1"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/merchandising"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/testfixtures/unittest"
+
+	"github.com/mehdihadeli/go-mediatr"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	// writers is the number of the concurrent writers of the same product
+	writers = 20
+	// rounds is the number of the repeats of a race which its interleaving is not deterministic
+	rounds = 20
+)
+
+type updateProductStressTests struct {
+	*unittest.UnitTestSharedFixture
+	updateHandler cqrs.RequestHandlerWithRegisterer[*updatingoroductsv1.UpdateProduct, *mediatr.Unit]
+	pinHandler    cqrs.RequestHandlerWithRegisterer[*pinningproductv1.PinProduct, *dtos.PinProductResponseDto]
+}
+
+func TestUpdateProductStress(t *testing.T) {
+	suite.Run(
+		t,
+		&updateProductStressTests{
+			UnitTestSharedFixture: unittest.NewUnitTestSharedFixture(t),
+		},
+	)
+}
+
+func (c *updateProductStressTests) SetupTest() {
+	// call base SetupTest hook before running child hook
+	c.UnitTestSharedFixture.SetupTest()
+
+	params := fxparams.ProductHandlerParams{
+		CatalogsDBContext:    c.CatalogDBContext,
+		Tracer:               c.Tracer,
+		OutboxPublisher:      c.OutboxPublisher,
+		Log:                  c.Log,
+		AttributesValidator:  attributes.NewAttributesValidator(c.CatalogDBContext),
+		MerchandisingManager: merchandising.NewMerchandisingManager(c.CatalogDBContext, c.OutboxPublisher, c.ProductHistory, c.Log),
+		ProductHistory:       c.ProductHistory,
+	}
+
+	c.updateHandler = updatingoroductsv1.NewUpdateProductHandler(params)
+	c.pinHandler = pinningproductv1.NewPinProductHandler(params)
+
+	// sqlite has a single writer, so the statements are serialized on one connection while the handlers are interleaved
+	sqlDB, err := c.CatalogDBContext.DB().DB()
+	c.Require().NoError(err)
+	sqlDB.SetMaxOpenConns(1)
+}
+
+func (c *updateProductStressTests) TearDownTest() {
+	// call base TearDownTest hook before running child hook
+	c.UnitTestSharedFixture.TearDownTest()
+}
+
+func (c *updateProductStressTests) Test_Concurrent_Updates_Should_Succeed_Or_Conflict_Without_Lost_Updates() {
+	existing := c.Products[0]
+
+	errs := testUtils.RunConcurrently(writers, func(worker int) error {
+		_, err := c.updateHandler.Handle(c.Ctx, c.updateCommand(worker))
+
+		return err
+	})
+
+	succeeded := c.assertSucceededOrConflicted(errs)
+
+	product := c.findProduct()
+	c.Equal(existing.Version+succeeded, product.Version)
+
+	// only the succeeded updates store their event in the outbox
+	c.OutboxPublisher.AssertNumberOfCalls(c.T(), "PublishViaOutbox", int(succeeded))
+}
+
+func (c *updateProductStressTests) Test_Concurrent_Updates_Should_Not_Overwrite_Concurrent_Unpinning() {
+	for round := 0; round < rounds; round++ {
+		_, err := c.pinHandler.Handle(c.Ctx, pinningproductv1.NewPinProduct(c.Products[0].Id, true))
+		c.Require().NoError(err)
+
+		// the last worker unpins the product while the others update it from their reads of the pinned product
+		errs := testUtils.RunConcurrently(writers, func(worker int) error {
+			if worker == writers-1 {
+				_, err := c.pinHandler.Handle(c.Ctx, pinningproductv1.NewPinProduct(c.Products[0].Id, false))
+
+				return err
+			}
+
+			_, err := c.updateHandler.Handle(c.Ctx, c.updateCommand(worker))
+
+			return err
+		})
+
+		c.Require().NoError(errs[writers-1])
+		c.assertSucceededOrConflicted(errs)
+
+		product := c.findProduct()
+		c.Require().False(product.IsPinned, "an update of a stale read restored the pinning of the product in round %d", round)
+	}
+}
+
+func (c *updateProductStressTests) updateCommand(worker int) *updatingoroductsv1.UpdateProduct {
+	existing := c.Products[0]
+
+	return updatingoroductsv1.NewUpdateProduct(
+		existing.Id,
+		fmt.Sprintf("product updated by writer %d", worker),
+		existing.Description,
+		existing.Price,
+	)
+}
+
+// assertSucceededOrConflicted asserts that each write succeeded or failed with a concurrency conflict, at least one of
+// them succeeded, and returns the number of the succeeded writes
+func (c *updateProductStressTests) assertSucceededOrConflicted(errs []error) int64 {
+	var succeeded int64
+
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+
+			continue
+		}
+
+		c.True(customErrors.IsConcurrencyConflictError(err), "unexpected error: %v", err)
+	}
+
+	c.Positive(succeeded)
+
+	return succeeded
+}
+
+func (c *updateProductStressTests) findProduct() *datamodels.ProductDataModel {
+	product, err := gormdbcontext.FindDataModelByID[*datamodels.ProductDataModel](
+		c.Ctx,
+		c.CatalogDBContext,
+		c.Products[0].Id,
+	)
+	c.Require().NoError(err)
+
+	return product
+}
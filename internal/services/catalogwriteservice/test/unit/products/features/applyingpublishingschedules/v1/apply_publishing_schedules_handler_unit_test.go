@@ -0,0 +1,120 @@
+//go:build unit
+// +build unit
+
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/gormdbcontext"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/datamodels"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	applyingpublishingschedulesv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/applyingpublishingschedules/v1"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/applyingpublishingschedules/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/publishing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/testfixtures/unittest"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type applyPublishingSchedulesHandlerUnitTests struct {
+	*unittest.UnitTestSharedFixture
+	handler cqrs.RequestHandlerWithRegisterer[*applyingpublishingschedulesv1.ApplyPublishingSchedules, *dtos.ApplyPublishingSchedulesResponseDto]
+}
+
+func TestApplyPublishingSchedulesHandlerUnit(t *testing.T) {
+	suite.Run(
+		t,
+		&applyPublishingSchedulesHandlerUnitTests{
+			UnitTestSharedFixture: unittest.NewUnitTestSharedFixture(t),
+		},
+	)
+}
+
+func (c *applyPublishingSchedulesHandlerUnitTests) SetupTest() {
+	// call base SetupTest hook before running child hook
+	c.UnitTestSharedFixture.SetupTest()
+	c.handler = applyingpublishingschedulesv1.NewApplyPublishingSchedulesHandler(
+		fxparams.ProductHandlerParams{
+			CatalogsDBContext: c.CatalogDBContext,
+			Tracer:            c.Tracer,
+			OutboxPublisher:   c.OutboxPublisher,
+			Log:               c.Log,
+			VisibilityManager: publishing.NewVisibilityManager(c.CatalogDBContext, c.OutboxPublisher, c.ProductHistory, c.Log),
+			ProductHistory:    c.ProductHistory,
+		},
+	)
+}
+
+func (c *applyPublishingSchedulesHandlerUnitTests) TearDownTest() {
+	// call base TearDownTest hook before running child hook
+	c.UnitTestSharedFixture.TearDownTest()
+}
+
+func (c *applyPublishingSchedulesHandlerUnitTests) Test_Handle_Should_Unpublish_Products_With_Ended_Window() {
+	now := time.Now()
+	unpublishAt := now.Add(-time.Minute)
+	expired := c.Products[0]
+
+	err := c.CatalogDBContext.DB().
+		Model(&datamodels.ProductDataModel{}).
+		Where("id = ?", expired.Id).
+		Update("unpublish_at", unpublishAt).Error
+	c.Require().NoError(err)
+
+	c.BeginTx()
+	result, err := c.handler.Handle(c.Ctx, applyingpublishingschedulesv1.NewApplyPublishingSchedules(now, 100))
+	c.CommitTx()
+
+	c.Require().NoError(err)
+	c.Assert().Empty(result.PublishedProducts)
+	c.Assert().Len(result.UnpublishedProducts, 1)
+	c.Assert().Equal(expired.Id, result.UnpublishedProducts[0])
+
+	c.OutboxPublisher.AssertNumberOfCalls(c.T(), "PublishViaOutbox", 1)
+
+	product, err := gormdbcontext.FindDataModelByID[*datamodels.ProductDataModel](
+		c.Ctx,
+		c.CatalogDBContext,
+		expired.Id,
+	)
+	c.Require().NoError(err)
+	c.Assert().False(product.IsPublished)
+}
+
+func (c *applyPublishingSchedulesHandlerUnitTests) Test_Handle_Should_Publish_Products_With_Started_Window() {
+	now := time.Now()
+	publishAt := now.Add(-time.Minute)
+	scheduled := c.Products[1]
+
+	err := c.CatalogDBContext.DB().
+		Model(&datamodels.ProductDataModel{}).
+		Where("id = ?", scheduled.Id).
+		Updates(map[string]interface{}{"publish_at": publishAt, "is_published": false}).Error
+	c.Require().NoError(err)
+
+	c.BeginTx()
+	result, err := c.handler.Handle(c.Ctx, applyingpublishingschedulesv1.NewApplyPublishingSchedules(now, 100))
+	c.CommitTx()
+
+	c.Require().NoError(err)
+	c.Assert().Len(result.PublishedProducts, 1)
+	c.Assert().Equal(scheduled.Id, result.PublishedProducts[0])
+	c.Assert().Empty(result.UnpublishedProducts)
+
+	c.OutboxPublisher.AssertNumberOfCalls(c.T(), "PublishViaOutbox", 1)
+}
+
+func (c *applyPublishingSchedulesHandlerUnitTests) Test_Handle_Should_Not_Change_Products_Inside_Their_Window() {
+	c.BeginTx()
+	result, err := c.handler.Handle(c.Ctx, applyingpublishingschedulesv1.NewApplyPublishingSchedules(time.Now(), 100))
+	c.CommitTx()
+
+	c.Require().NoError(err)
+	c.Assert().Empty(result.PublishedProducts)
+	c.Assert().Empty(result.UnpublishedProducts)
+
+	c.OutboxPublisher.AssertNumberOfCalls(c.T(), "PublishViaOutbox", 0)
+}
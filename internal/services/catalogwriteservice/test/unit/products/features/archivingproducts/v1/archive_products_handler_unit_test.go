@@ -0,0 +1,99 @@
+//go:build unit
+// +build unit
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/gormdbcontext"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/bulkoperations"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/datamodels"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	archivingproductsv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/archivingproducts/v1"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/archivingproducts/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/testfixtures/unittest"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type archiveProductsHandlerUnitTests struct {
+	*unittest.UnitTestSharedFixture
+	handler cqrs.RequestHandlerWithRegisterer[*archivingproductsv1.ArchiveProducts, *dtos.ArchiveProductsResponseDto]
+}
+
+func TestArchiveProductsHandlerUnit(t *testing.T) {
+	suite.Run(
+		t,
+		&archiveProductsHandlerUnitTests{
+			UnitTestSharedFixture: unittest.NewUnitTestSharedFixture(t),
+		},
+	)
+}
+
+func (c *archiveProductsHandlerUnitTests) SetupTest() {
+	// call base SetupTest hook before running child hook
+	c.UnitTestSharedFixture.SetupTest()
+	c.handler = archivingproductsv1.NewArchiveProductsHandler(
+		fxparams.ProductHandlerParams{
+			Log:               c.Log,
+			CatalogsDBContext: c.CatalogDBContext,
+			OutboxPublisher:   c.OutboxPublisher,
+			Tracer:            c.Tracer,
+			BulkProcessor: bulkoperations.NewBulkProcessor(
+				c.CatalogDBContext,
+				&bulkoperations.BulkOperationsOptions{MaxProductIds: 10, ChunkSize: 10},
+				c.Log,
+			),
+			ProductHistory: c.ProductHistory,
+		},
+	)
+}
+
+func (c *archiveProductsHandlerUnitTests) TearDownTest() {
+	// call base TearDownTest hook before running child hook
+	c.UnitTestSharedFixture.TearDownTest()
+}
+
+func (c *archiveProductsHandlerUnitTests) Test_Handle_Should_Archive_Products() {
+	productIDs := []uuid.UUID{c.Products[0].Id, c.Products[1].Id}
+
+	result, err := c.handler.Handle(c.Ctx, archivingproductsv1.NewArchiveProducts(productIDs))
+
+	c.Require().NoError(err)
+	c.Assert().Equal(2, result.Archived)
+
+	// a single event for the chunk
+	c.OutboxPublisher.AssertNumberOfCalls(c.T(), "PublishViaOutbox", 1)
+
+	for _, productID := range productIDs {
+		p, err := gormdbcontext.FindDataModelByID[*datamodels.ProductDataModel](c.Ctx, c.CatalogDBContext, productID)
+		c.Require().NoError(err)
+		c.Assert().True(p.IsArchived)
+		c.Assert().NotNil(p.ArchivedAt)
+	}
+}
+
+func (c *archiveProductsHandlerUnitTests) Test_Handle_Should_Skip_Archived_Products() {
+	unknownID := uuid.NewV4()
+
+	_, err := c.handler.Handle(c.Ctx, archivingproductsv1.NewArchiveProducts([]uuid.UUID{c.Products[0].Id}))
+	c.Require().NoError(err)
+
+	result, err := c.handler.Handle(
+		c.Ctx,
+		archivingproductsv1.NewArchiveProducts([]uuid.UUID{c.Products[0].Id, c.Products[1].Id, unknownID}),
+	)
+
+	c.Require().NoError(err)
+	c.Assert().Equal(1, result.Archived)
+	c.Assert().Equal(1, result.AlreadyArchived)
+	c.Assert().Equal(1, result.NotFound)
+	c.Assert().Equal(string(bulkoperations.StatusSkipped), result.Results[0].Status)
+	c.Assert().Equal(string(bulkoperations.StatusSucceeded), result.Results[1].Status)
+	c.Assert().Equal(string(bulkoperations.StatusNotFound), result.Results[2].Status)
+
+	c.OutboxPublisher.AssertNumberOfCalls(c.T(), "PublishViaOutbox", 2)
+}
@@ -11,11 +11,13 @@ import (
 	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mapper"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/gormdbcontext"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/attributes"
 	datamodels "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/datamodels"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
 	creatingproductv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/creatingproduct/v1"
 	creatingproductdtosv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/creatingproduct/v1/dtos"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/skugeneration"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/testfixtures/unittest"
 
 	"emperror.dev/errors"
@@ -42,10 +44,16 @@ func (c *createProductHandlerUnitTests) SetupTest() {
 	c.UnitTestSharedFixture.SetupTest()
 	c.handler = creatingproductv1.NewCreateProductHandler(
 		fxparams.ProductHandlerParams{
-			CatalogsDBContext: c.CatalogDBContext,
-			Tracer:            c.Tracer,
-			RabbitmqProducer:  c.Bus,
-			Log:               c.Log,
+			CatalogsDBContext:   c.CatalogDBContext,
+			Tracer:              c.Tracer,
+			OutboxPublisher:     c.OutboxPublisher,
+			Log:                 c.Log,
+			AttributesValidator: attributes.NewAttributesValidator(c.CatalogDBContext),
+			SkuGenerator: skugeneration.NewSkuGenerator(
+				&skugeneration.SkuOptions{SkuPattern: "{NAME}-{RAND:6}", EanPrefix: "200", MaxAttempts: 10},
+				c.CatalogDBContext,
+			),
+			ProductHistory: c.ProductHistory,
 		},
 	)
 }
@@ -72,7 +80,7 @@ func (c *createProductHandlerUnitTests) Test_Handle_Should_Create_New_Product_Wi
 
 	c.Require().NoError(err)
 
-	c.Bus.AssertNumberOfCalls(c.T(), "PublishMessage", 1)
+	c.OutboxPublisher.AssertNumberOfCalls(c.T(), "PublishViaOutbox", 1)
 
 	res, err := gormdbcontext.FindModelByID[*datamodels.ProductDataModel, *models.Product](
 		c.Ctx,
@@ -84,6 +92,64 @@ func (c *createProductHandlerUnitTests) Test_Handle_Should_Create_New_Product_Wi
 	c.Assert().Equal(res.Id, id)
 }
 
+func (c *createProductHandlerUnitTests) Test_Handle_Should_Generate_Sku_And_Barcode_When_Not_Provided() {
+	id := uuid.NewV4()
+
+	createProduct := &creatingproductv1.CreateProduct{
+		ProductID:   id,
+		Name:        gofakeit.Name(),
+		CreatedAt:   time.Now(),
+		Description: gofakeit.EmojiDescription(),
+		Price:       gofakeit.Price(100, 1000),
+	}
+
+	c.BeginTx()
+	_, err := c.handler.Handle(c.Ctx, createProduct)
+	c.CommitTx()
+
+	c.Require().NoError(err)
+
+	res, err := gormdbcontext.FindModelByID[*datamodels.ProductDataModel, *models.Product](
+		c.Ctx,
+		c.CatalogDBContext,
+		id,
+	)
+	c.Require().NoError(err)
+
+	c.Assert().NotEmpty(res.Sku)
+	c.Assert().True(skugeneration.IsValidEan13(res.Barcode))
+}
+
+func (c *createProductHandlerUnitTests) Test_Handle_Should_Return_Error_For_Duplicate_Sku() {
+	sku := "DUPLICATE-SKU"
+
+	c.BeginTx()
+	_, err := c.handler.Handle(c.Ctx, &creatingproductv1.CreateProduct{
+		ProductID:   uuid.NewV4(),
+		Name:        gofakeit.Name(),
+		CreatedAt:   time.Now(),
+		Description: gofakeit.EmojiDescription(),
+		Price:       gofakeit.Price(100, 1000),
+		Sku:         sku,
+	})
+	c.Require().NoError(err)
+	c.CommitTx()
+
+	c.BeginTx()
+	dto, err := c.handler.Handle(c.Ctx, &creatingproductv1.CreateProduct{
+		ProductID:   uuid.NewV4(),
+		Name:        gofakeit.Name(),
+		CreatedAt:   time.Now(),
+		Description: gofakeit.EmojiDescription(),
+		Price:       gofakeit.Price(100, 1000),
+		Sku:         sku,
+	})
+	c.CommitTx()
+
+	c.True(customErrors.IsConflictError(err))
+	c.Nil(dto)
+}
+
 func (c *createProductHandlerUnitTests) Test_Handle_Should_Return_Error_For_Duplicate_Item() {
 	id := uuid.NewV4()
 
@@ -105,7 +171,7 @@ func (c *createProductHandlerUnitTests) Test_Handle_Should_Return_Error_For_Dupl
 	dto, err = c.handler.Handle(c.Ctx, createProduct)
 	c.CommitTx()
 
-	c.Bus.AssertNumberOfCalls(c.T(), "PublishMessage", 1)
+	c.OutboxPublisher.AssertNumberOfCalls(c.T(), "PublishViaOutbox", 1)
 	c.True(customErrors.IsConflictError(err))
 	c.ErrorContains(err, "product already exists")
 	c.Nil(dto)
@@ -124,8 +190,8 @@ func (c *createProductHandlerUnitTests) Test_Handle_Should_Return_Error_For_Erro
 
 	// override called mock
 	// https://github.com/stretchr/testify/issues/558
-	c.Bus.Mock.ExpectedCalls = nil
-	c.Bus.On("PublishMessage", mock.Anything, mock.Anything, mock.Anything).
+	c.OutboxPublisher.Mock.ExpectedCalls = nil
+	c.OutboxPublisher.On("PublishViaOutbox", mock.Anything, mock.Anything, mock.Anything).
 		Once().
 		Return(errors.New("error in the publish message"))
 
@@ -135,7 +201,7 @@ func (c *createProductHandlerUnitTests) Test_Handle_Should_Return_Error_For_Erro
 
 	c.CommitTx()
 
-	c.Bus.AssertNumberOfCalls(c.T(), "PublishMessage", 1)
+	c.OutboxPublisher.AssertNumberOfCalls(c.T(), "PublishViaOutbox", 1)
 	c.ErrorContains(err, "error in the publish message")
 	c.ErrorContains(
 		err,
@@ -163,7 +229,7 @@ func (c *createProductHandlerUnitTests) Test_Handle_Should_Return_Error_For_Erro
 
 	c.CommitTx()
 
-	c.Bus.AssertNumberOfCalls(c.T(), "PublishMessage", 0)
+	c.OutboxPublisher.AssertNumberOfCalls(c.T(), "PublishViaOutbox", 0)
 	c.ErrorContains(err, "error in the mapping")
 	c.True(customErrors.IsInternalServerError(err))
 	c.Nil(dto)
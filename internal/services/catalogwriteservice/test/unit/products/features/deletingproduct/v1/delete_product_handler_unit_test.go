@@ -43,8 +43,9 @@ func (c *deleteProductHandlerUnitTests) SetupTest() {
 		fxparams.ProductHandlerParams{
 			Log:               c.Log,
 			CatalogsDBContext: c.CatalogDBContext,
-			RabbitmqProducer:  c.Bus,
+			OutboxPublisher:   c.OutboxPublisher,
 			Tracer:            c.Tracer,
+			ProductHistory:    c.ProductHistory,
 		},
 	)
 }
@@ -72,7 +73,7 @@ func (c *deleteProductHandlerUnitTests) Test_Handle_Should_Delete_Product_With_V
 	c.Require().Nil(p)
 	c.Require().Error(err)
 
-	c.Bus.AssertNumberOfCalls(c.T(), "PublishMessage", 1)
+	c.OutboxPublisher.AssertNumberOfCalls(c.T(), "PublishViaOutbox", 1)
 }
 
 func (c *deleteProductHandlerUnitTests) Test_Handle_Should_Return_NotFound_Error_When_Id_Is_Invalid() {
@@ -90,7 +91,7 @@ func (c *deleteProductHandlerUnitTests) Test_Handle_Should_Return_NotFound_Error
 	c.True(customErrors.IsNotFoundError(err))
 	c.Nil(res)
 
-	c.Bus.AssertNumberOfCalls(c.T(), "PublishMessage", 0)
+	c.OutboxPublisher.AssertNumberOfCalls(c.T(), "PublishViaOutbox", 0)
 }
 
 func (c *deleteProductHandlerUnitTests) Test_Handle_Should_Return_Error_For_Error_In_Bus() {
@@ -102,8 +103,8 @@ func (c *deleteProductHandlerUnitTests) Test_Handle_Should_Return_Error_For_Erro
 
 	// override called mock
 	// https://github.com/stretchr/testify/issues/558
-	c.Bus.Mock.ExpectedCalls = nil
-	c.Bus.On("PublishMessage", mock.Anything, mock.Anything, mock.Anything).
+	c.OutboxPublisher.Mock.ExpectedCalls = nil
+	c.OutboxPublisher.On("PublishViaOutbox", mock.Anything, mock.Anything, mock.Anything).
 		Once().
 		Return(errors.New("error in the publish message"))
 
@@ -113,7 +114,7 @@ func (c *deleteProductHandlerUnitTests) Test_Handle_Should_Return_Error_For_Erro
 
 	c.Nil(dto)
 
-	c.Bus.AssertNumberOfCalls(c.T(), "PublishMessage", 1)
+	c.OutboxPublisher.AssertNumberOfCalls(c.T(), "PublishViaOutbox", 1)
 	c.True(customErrors.IsApplicationError(err, http.StatusInternalServerError))
 	c.ErrorContains(err, "error in publishing 'ProductDeleted' message")
 }
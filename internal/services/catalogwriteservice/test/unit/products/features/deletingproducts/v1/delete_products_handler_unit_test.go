@@ -0,0 +1,127 @@
+//go:build unit
+// +build unit
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/gormdbcontext"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/bulkoperations"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/datamodels"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	deletingproductsv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/deletingproducts/v1"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/deletingproducts/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/testfixtures/unittest"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type deleteProductsHandlerUnitTests struct {
+	*unittest.UnitTestSharedFixture
+	handler cqrs.RequestHandlerWithRegisterer[*deletingproductsv1.DeleteProducts, *dtos.DeleteProductsResponseDto]
+}
+
+func TestDeleteProductsHandlerUnit(t *testing.T) {
+	suite.Run(
+		t,
+		&deleteProductsHandlerUnitTests{
+			UnitTestSharedFixture: unittest.NewUnitTestSharedFixture(t),
+		},
+	)
+}
+
+func (c *deleteProductsHandlerUnitTests) SetupTest() {
+	// call base SetupTest hook before running child hook
+	c.UnitTestSharedFixture.SetupTest()
+	c.handler = deletingproductsv1.NewDeleteProductsHandler(
+		fxparams.ProductHandlerParams{
+			Log:               c.Log,
+			CatalogsDBContext: c.CatalogDBContext,
+			OutboxPublisher:   c.OutboxPublisher,
+			Tracer:            c.Tracer,
+			BulkProcessor: bulkoperations.NewBulkProcessor(
+				c.CatalogDBContext,
+				&bulkoperations.BulkOperationsOptions{MaxProductIds: 3, ChunkSize: 2},
+				c.Log,
+			),
+			ProductHistory: c.ProductHistory,
+		},
+	)
+}
+
+func (c *deleteProductsHandlerUnitTests) TearDownTest() {
+	// call base TearDownTest hook before running child hook
+	c.UnitTestSharedFixture.TearDownTest()
+}
+
+func (c *deleteProductsHandlerUnitTests) Test_Handle_Should_Delete_Products_And_Report_Not_Found_Products() {
+	unknownID := uuid.NewV4()
+	productIDs := []uuid.UUID{c.Products[0].Id, unknownID, c.Products[1].Id}
+
+	result, err := c.handler.Handle(c.Ctx, deletingproductsv1.NewDeleteProducts(productIDs))
+
+	c.Require().NoError(err)
+	c.Assert().Equal(2, result.Deleted)
+	c.Assert().Equal(1, result.NotFound)
+	c.Assert().Equal(0, result.Failed)
+
+	c.Require().Len(result.Results, 3)
+	c.Assert().Equal(c.Products[0].Id, result.Results[0].ProductId)
+	c.Assert().Equal(string(bulkoperations.StatusSucceeded), result.Results[0].Status)
+	c.Assert().Equal(unknownID, result.Results[1].ProductId)
+	c.Assert().Equal(string(bulkoperations.StatusNotFound), result.Results[1].Status)
+
+	// a single event for each of the two chunks
+	c.OutboxPublisher.AssertNumberOfCalls(c.T(), "PublishViaOutbox", 2)
+
+	for _, product := range c.Products {
+		p, err := gormdbcontext.FindDataModelByID[*datamodels.ProductDataModel](c.Ctx, c.CatalogDBContext, product.Id)
+		c.Require().Nil(p)
+		c.Require().Error(err)
+	}
+}
+
+func (c *deleteProductsHandlerUnitTests) Test_Handle_Should_Roll_Back_Failed_Chunk_And_Continue_With_Next_Chunks() {
+	unknownID := uuid.NewV4()
+	productIDs := []uuid.UUID{c.Products[0].Id, unknownID, c.Products[1].Id}
+
+	// override called mock
+	// https://github.com/stretchr/testify/issues/558
+	c.OutboxPublisher.Mock.ExpectedCalls = nil
+	c.OutboxPublisher.On("PublishViaOutbox", mock.Anything, mock.Anything, mock.Anything).
+		Once().
+		Return(errors.New("error in the publish message"))
+	c.OutboxPublisher.On("PublishViaOutbox", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+
+	result, err := c.handler.Handle(c.Ctx, deletingproductsv1.NewDeleteProducts(productIDs))
+
+	c.Require().NoError(err)
+	c.Assert().Equal(1, result.Deleted)
+	c.Assert().Equal(2, result.Failed)
+	c.Assert().Equal(string(bulkoperations.StatusFailed), result.Results[0].Status)
+	c.Assert().Contains(result.Results[0].Error, "error in publishing 'ProductsDeleted' message")
+	c.Assert().Equal(string(bulkoperations.StatusSucceeded), result.Results[2].Status)
+
+	p, err := gormdbcontext.FindDataModelByID[*datamodels.ProductDataModel](c.Ctx, c.CatalogDBContext, c.Products[0].Id)
+	c.Require().NoError(err)
+	c.Require().NotNil(p)
+}
+
+func (c *deleteProductsHandlerUnitTests) Test_Handle_Should_Return_BadRequest_Error_For_Too_Many_Products() {
+	productIDs := []uuid.UUID{uuid.NewV4(), uuid.NewV4(), uuid.NewV4(), uuid.NewV4()}
+
+	result, err := c.handler.Handle(c.Ctx, deletingproductsv1.NewDeleteProducts(productIDs))
+
+	c.Require().Error(err)
+	c.Assert().True(customErrors.IsBadRequestError(err))
+	c.Nil(result)
+
+	c.OutboxPublisher.AssertNumberOfCalls(c.T(), "PublishViaOutbox", 0)
+}
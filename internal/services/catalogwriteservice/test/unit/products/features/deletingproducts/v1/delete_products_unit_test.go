@@ -0,0 +1,48 @@
+//go:build unit
+// +build unit
+
+package v1
+
+import (
+	"testing"
+
+	v1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/deletingproducts/v1"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/testfixtures/unittest"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type deleteProductsUnitTests struct {
+	*unittest.UnitTestSharedFixture
+}
+
+func TestDeleteProductsUnit(t *testing.T) {
+	suite.Run(
+		t,
+		&deleteProductsUnitTests{UnitTestSharedFixture: unittest.NewUnitTestSharedFixture(t)},
+	)
+}
+
+func (c *deleteProductsUnitTests) Test_New_Delete_Products_Should_Return_No_Error_For_Valid_Input() {
+	productIDs := []uuid.UUID{uuid.NewV4(), uuid.NewV4()}
+
+	command, err := v1.NewDeleteProductsWithValidation(productIDs)
+
+	c.Require().NoError(err)
+	c.Assert().Equal(productIDs, command.ProductIDs)
+}
+
+func (c *deleteProductsUnitTests) Test_New_Delete_Products_Should_Return_Error_For_Empty_Ids() {
+	_, err := v1.NewDeleteProductsWithValidation(nil)
+
+	c.Require().Error(err)
+}
+
+func (c *deleteProductsUnitTests) Test_New_Delete_Products_Should_Return_Error_For_Duplicate_Ids() {
+	id := uuid.NewV4()
+
+	_, err := v1.NewDeleteProductsWithValidation([]uuid.UUID{id, id})
+
+	c.Require().Error(err)
+}
@@ -0,0 +1,134 @@
+//go:build unit
+// +build unit
+
+package v1
+
+import (
+	"bufio"
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mapper"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/repositories"
+	dtosv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	exportingproductsv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/exportingproducts/v1"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/exportingproducts/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/testfixtures/unittest"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/suite"
+)
+
+type exportProductsHandlerUnitTests struct {
+	*unittest.UnitTestSharedFixture
+	handler cqrs.RequestHandlerWithRegisterer[*exportingproductsv1.ExportProducts, *dtos.ExportProductsResponseDto]
+}
+
+func TestExportProductsUnit(t *testing.T) {
+	suite.Run(
+		t,
+		&exportProductsHandlerUnitTests{
+			UnitTestSharedFixture: unittest.NewUnitTestSharedFixture(t),
+		},
+	)
+}
+
+func (c *exportProductsHandlerUnitTests) SetupTest() {
+	// call base SetupTest hook before running child hook
+	c.UnitTestSharedFixture.SetupTest()
+	c.handler = exportingproductsv1.NewExportProductsHandler(
+		fxparams.ProductHandlerParams{
+			CatalogsDBContext: c.CatalogDBContext,
+			Tracer:            c.Tracer,
+			OutboxPublisher:   c.OutboxPublisher,
+			Log:               c.Log,
+			ProductRepository: repositories.NewPostgresProductRepository(
+				c.Log,
+				c.CatalogDBContext.DB(),
+				c.Tracer,
+			),
+		})
+}
+
+func (c *exportProductsHandlerUnitTests) TearDownTest() {
+	// call base TearDownTest hook before running child hook
+	c.UnitTestSharedFixture.TearDownTest()
+}
+
+func (c *exportProductsHandlerUnitTests) Test_Handle_Should_Stream_Products_As_NDJSON() {
+	writer := &exportWriter{}
+	query, err := exportingproductsv1.NewExportProductsWithValidation(writer, 1)
+	c.Require().NoError(err)
+
+	res, err := c.handler.Handle(c.Ctx, query)
+	c.Require().NoError(err)
+
+	c.Equal(int64(len(c.Products)), res.ExportedCount)
+	// each product is a chunk when the chunk size is 1
+	c.Equal(len(c.Products), writer.flushes)
+
+	var exportedIds []string
+	scanner := bufio.NewScanner(bytes.NewReader(writer.Bytes()))
+	for scanner.Scan() {
+		product := &dtosv1.ProductDto{}
+		c.Require().NoError(json.Unmarshal(scanner.Bytes(), product))
+		exportedIds = append(exportedIds, product.Id.String())
+	}
+
+	var productIds []string
+	for _, product := range c.Products {
+		productIds = append(productIds, product.Id.String())
+	}
+
+	c.ElementsMatch(productIds, exportedIds)
+}
+
+func (c *exportProductsHandlerUnitTests) Test_Handle_Should_Flush_Partial_Last_Chunk() {
+	writer := &exportWriter{}
+	query, err := exportingproductsv1.NewExportProductsWithValidation(writer, len(c.Products)+1)
+	c.Require().NoError(err)
+
+	res, err := c.handler.Handle(c.Ctx, query)
+	c.Require().NoError(err)
+
+	c.Equal(int64(1), res.FlushesCount)
+	c.Equal(1, writer.flushes)
+}
+
+func (c *exportProductsHandlerUnitTests) Test_Handle_Should_Return_Error_For_Mapping_Products() {
+	writer := &exportWriter{}
+	query, err := exportingproductsv1.NewExportProductsWithValidation(writer, 10)
+	c.Require().NoError(err)
+
+	mapper.ClearMappings()
+
+	res, err := c.handler.Handle(c.Ctx, query)
+	c.Require().Error(err)
+	c.True(customErrors.IsApplicationError(err, http.StatusInternalServerError))
+	c.Nil(res)
+	c.Empty(writer.Bytes())
+}
+
+func (c *exportProductsHandlerUnitTests) Test_New_Export_Products_Should_Validate_Chunk_Size() {
+	_, err := exportingproductsv1.NewExportProductsWithValidation(
+		&exportWriter{},
+		exportingproductsv1.MaxExportChunkSize+1,
+	)
+
+	c.True(customErrors.IsValidationError(err))
+}
+
+type exportWriter struct {
+	bytes.Buffer
+	flushes int
+}
+
+func (w *exportWriter) Flush() error {
+	w.flushes++
+
+	return nil
+}
@@ -0,0 +1,108 @@
+//go:build unit
+// +build unit
+
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/datamodels"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	gettingcatalogdiffv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/gettingcatalogdiff/v1"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/gettingcatalogdiff/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/testfixtures/unittest"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type getCatalogDiffHandlerUnitTests struct {
+	*unittest.UnitTestSharedFixture
+	handler cqrs.RequestHandlerWithRegisterer[*gettingcatalogdiffv1.GetCatalogDiff, *dtos.GetCatalogDiffResponseDto]
+}
+
+func TestGetCatalogDiffHandlerUnit(t *testing.T) {
+	suite.Run(
+		t,
+		&getCatalogDiffHandlerUnitTests{
+			UnitTestSharedFixture: unittest.NewUnitTestSharedFixture(t),
+		},
+	)
+}
+
+func (c *getCatalogDiffHandlerUnitTests) SetupTest() {
+	// call base SetupTest hook before running child hook
+	c.UnitTestSharedFixture.SetupTest()
+	c.handler = gettingcatalogdiffv1.NewGetCatalogDiffHandler(
+		fxparams.ProductHandlerParams{
+			CatalogsDBContext: c.CatalogDBContext,
+			Tracer:            c.Tracer,
+			Log:               c.Log,
+			ProductHistory:    c.ProductHistory,
+		},
+	)
+}
+
+func (c *getCatalogDiffHandlerUnitTests) TearDownTest() {
+	// call base TearDownTest hook before running child hook
+	c.UnitTestSharedFixture.TearDownTest()
+}
+
+func (c *getCatalogDiffHandlerUnitTests) Test_Handle_Should_Return_Changes_Between_Two_Points_In_Time() {
+	updated, deleted := c.Products[0], c.Products[1]
+	name := updated.Name
+
+	err := c.ProductHistory.Record(c.Ctx, models.ProductCreated, updated.Id, deleted.Id)
+	c.Require().NoError(err)
+
+	from := time.Now()
+
+	err = c.CatalogDBContext.DB().Model(updated).Update("name", "updated name").Error
+	c.Require().NoError(err)
+	err = c.ProductHistory.Record(c.Ctx, models.ProductUpdated, updated.Id)
+	c.Require().NoError(err)
+
+	err = c.CatalogDBContext.DB().Delete(&datamodels.ProductDataModel{}, deleted.Id).Error
+	c.Require().NoError(err)
+	err = c.ProductHistory.Record(c.Ctx, models.ProductDeleted, deleted.Id)
+	c.Require().NoError(err)
+
+	result, err := c.handler.Handle(c.Ctx, gettingcatalogdiffv1.NewGetCatalogDiff(from, time.Time{}))
+
+	c.Require().NoError(err)
+	c.Require().Len(result.Changes, 2)
+
+	c.Assert().Equal(updated.Id, result.Changes[0].ProductId)
+	c.Assert().Equal(string(models.ProductUpdated), result.Changes[0].ChangeType)
+	c.Assert().Equal("updated name", result.Changes[0].Product["name"])
+	c.Require().Len(result.Changes[0].Fields, 1)
+	c.Assert().Equal("name", result.Changes[0].Fields[0].Field)
+	c.Assert().Equal(name, result.Changes[0].Fields[0].From)
+	c.Assert().Equal("updated name", result.Changes[0].Fields[0].To)
+
+	c.Assert().Equal(deleted.Id, result.Changes[1].ProductId)
+	c.Assert().Equal(string(models.ProductDeleted), result.Changes[1].ChangeType)
+	c.Assert().Nil(result.Changes[1].Product)
+}
+
+func (c *getCatalogDiffHandlerUnitTests) Test_Handle_Should_Not_Return_Changes_Before_From() {
+	err := c.ProductHistory.Record(c.Ctx, models.ProductCreated, c.Products[0].Id)
+	c.Require().NoError(err)
+
+	result, err := c.handler.Handle(c.Ctx, gettingcatalogdiffv1.NewGetCatalogDiff(time.Now(), time.Time{}))
+
+	c.Require().NoError(err)
+	c.Assert().Empty(result.Changes)
+}
+
+func (c *getCatalogDiffHandlerUnitTests) Test_New_Get_Catalog_Diff_Should_Return_Error_For_Invalid_Window() {
+	now := time.Now()
+
+	_, err := gettingcatalogdiffv1.NewGetCatalogDiffWithValidation(now, now.Add(-time.Hour))
+	c.Require().Error(err)
+
+	_, err = gettingcatalogdiffv1.NewGetCatalogDiffWithValidation(time.Time{}, now)
+	c.Require().Error(err)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
 	gettingproductbyidv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/gettingproductbyid/v1"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/gettingproductbyid/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/pricing"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/testfixtures/unittest"
 
 	uuid "github.com/satori/go.uuid"
@@ -37,8 +38,9 @@ func (c *getProductByIdHandlerTest) SetupTest() {
 		fxparams.ProductHandlerParams{
 			CatalogsDBContext: c.CatalogDBContext,
 			Tracer:            c.Tracer,
-			RabbitmqProducer:  c.Bus,
+			OutboxPublisher:   c.OutboxPublisher,
 			Log:               c.Log,
+			PriceResolver:     pricing.NewPriceResolver(c.CatalogDBContext),
 		})
 }
 
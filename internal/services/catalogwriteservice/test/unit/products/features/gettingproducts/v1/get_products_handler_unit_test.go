@@ -6,16 +6,23 @@ package v1
 import (
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/authentication"
 	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mapper"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/gormdbcontext"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/datamodels"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
 	gettingproductsv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/gettingproducts/v1"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/gettingproducts/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/pricing"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/testfixtures/unittest"
 
+	uuid "github.com/satori/go.uuid"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -40,8 +47,9 @@ func (c *getProductsHandlerUnitTests) SetupTest() {
 		fxparams.ProductHandlerParams{
 			CatalogsDBContext: c.CatalogDBContext,
 			Tracer:            c.Tracer,
-			RabbitmqProducer:  c.Bus,
+			OutboxPublisher:   c.OutboxPublisher,
 			Log:               c.Log,
+			PriceResolver:     pricing.NewPriceResolver(c.CatalogDBContext),
 		})
 }
 
@@ -61,6 +69,53 @@ func (c *getProductsHandlerUnitTests) Test_Handle_Should_Return_Products_Success
 	c.Equal(len(c.Products), len(res.Products.Items))
 }
 
+func (c *getProductsHandlerUnitTests) Test_Handle_Should_Return_Customer_Prices_For_Customer_Context() {
+	product := c.Products[0]
+	effectiveTo := time.Now().Add(time.Hour)
+
+	_, err := gormdbcontext.AddModel[*datamodels.PriceListDataModel, *models.PriceList](
+		c.Ctx,
+		c.CatalogDBContext,
+		&models.PriceList{
+			Id:            uuid.NewV4(),
+			Name:          "wholesale",
+			CustomerGroup: "wholesale",
+			EffectiveFrom: time.Now().Add(-time.Hour),
+			EffectiveTo:   &effectiveTo,
+			Prices:        []*models.ProductPrice{{ProductId: product.Id, Price: 80}},
+		},
+	)
+	c.Require().NoError(err)
+
+	query, err := gettingproductsv1.NewGetProducts(utils.NewListQuery(10, 1))
+	c.Require().NoError(err)
+
+	ctx := authentication.WithPrincipal(
+		c.Ctx,
+		&authentication.Principal{Scheme: authentication.JwtScheme, CustomerGroup: "wholesale"},
+	)
+
+	res, err := c.handler.Handle(ctx, query)
+	c.Require().NoError(err)
+
+	for _, item := range res.Products.Items {
+		if item.Id == product.Id {
+			c.Require().NotNil(item.CustomerPrice)
+			c.Equal(80.0, *item.CustomerPrice)
+		} else {
+			c.Nil(item.CustomerPrice)
+		}
+	}
+
+	// without a customer context the prices are not resolved
+	res, err = c.handler.Handle(c.Ctx, query)
+	c.Require().NoError(err)
+
+	for _, item := range res.Products.Items {
+		c.Nil(item.CustomerPrice)
+	}
+}
+
 func (c *getProductsHandlerUnitTests) Test_Handle_Should_Return_Error_For_Mapping_List_Result() {
 	query, err := gettingproductsv1.NewGetProducts(utils.NewListQuery(10, 1))
 	c.Require().NoError(err)
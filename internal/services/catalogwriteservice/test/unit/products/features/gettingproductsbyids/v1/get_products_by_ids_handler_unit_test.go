@@ -0,0 +1,83 @@
+//go:build unit
+// +build unit
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	gettingproductsbyidsv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/gettingproductsbyids/v1"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/gettingproductsbyids/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/pricing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/testfixtures/unittest"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type getProductsByIdsHandlerUnitTests struct {
+	*unittest.UnitTestSharedFixture
+	handler cqrs.RequestHandlerWithRegisterer[*gettingproductsbyidsv1.GetProductsByIds, *dtos.GetProductsByIdsResponseDto]
+}
+
+func TestGetProductsByIdsHandlerUnit(t *testing.T) {
+	suite.Run(
+		t,
+		&getProductsByIdsHandlerUnitTests{
+			UnitTestSharedFixture: unittest.NewUnitTestSharedFixture(t),
+		},
+	)
+}
+
+func (c *getProductsByIdsHandlerUnitTests) SetupTest() {
+	// call base SetupTest hook before running child hook
+	c.UnitTestSharedFixture.SetupTest()
+	c.handler = gettingproductsbyidsv1.NewGetProductsByIdsHandler(
+		fxparams.ProductHandlerParams{
+			Log:               c.Log,
+			CatalogsDBContext: c.CatalogDBContext,
+			Tracer:            c.Tracer,
+			PriceResolver:     pricing.NewPriceResolver(c.CatalogDBContext),
+		},
+	)
+}
+
+func (c *getProductsByIdsHandlerUnitTests) TearDownTest() {
+	// call base TearDownTest hook before running child hook
+	c.UnitTestSharedFixture.TearDownTest()
+}
+
+func (c *getProductsByIdsHandlerUnitTests) Test_Handle_Should_Return_Products_In_Request_Order_And_Report_Not_Found_Ids() {
+	unknownID := uuid.NewV4()
+	productIDs := []uuid.UUID{c.Products[1].Id, unknownID, c.Products[0].Id}
+
+	result, err := c.handler.Handle(c.Ctx, gettingproductsbyidsv1.NewGetProductsByIds(productIDs))
+
+	c.Require().NoError(err)
+	c.Require().Len(result.Products, 2)
+	c.Assert().Equal(c.Products[1].Id, result.Products[0].Id)
+	c.Assert().Equal(c.Products[0].Id, result.Products[1].Id)
+	c.Assert().Equal([]uuid.UUID{unknownID}, result.NotFound)
+	c.Assert().Empty(result.Failed)
+}
+
+func (c *getProductsByIdsHandlerUnitTests) Test_New_Get_Products_By_Ids_Should_Return_Error_For_Duplicate_Ids() {
+	id := uuid.NewV4()
+
+	_, err := gettingproductsbyidsv1.NewGetProductsByIdsWithValidation([]uuid.UUID{id, id})
+
+	c.Require().Error(err)
+}
+
+func (c *getProductsByIdsHandlerUnitTests) Test_New_Get_Products_By_Ids_Should_Return_Error_For_Too_Many_Ids() {
+	productIDs := make([]uuid.UUID, 0, gettingproductsbyidsv1.MaxProductIds+1)
+	for i := 0; i <= gettingproductsbyidsv1.MaxProductIds; i++ {
+		productIDs = append(productIDs, uuid.NewV4())
+	}
+
+	_, err := gettingproductsbyidsv1.NewGetProductsByIdsWithValidation(productIDs)
+
+	c.Require().Error(err)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
 	searchingproductsv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/searchingproduct/v1"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/searchingproduct/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/pricing"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/testfixtures/unittest"
 
 	"github.com/stretchr/testify/suite"
@@ -37,8 +38,9 @@ func (c *searchProductsHandlerUnitTests) SetupTest() {
 		fxparams.ProductHandlerParams{
 			CatalogsDBContext: c.CatalogDBContext,
 			Tracer:            c.Tracer,
-			RabbitmqProducer:  c.Bus,
+			OutboxPublisher:   c.OutboxPublisher,
 			Log:               c.Log,
+			PriceResolver:     pricing.NewPriceResolver(c.CatalogDBContext),
 		})
 }
 
@@ -0,0 +1,131 @@
+//go:build unit
+// +build unit
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/gormdbcontext"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/datamodels"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
+	sortingcategoryproductsv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/sortingcategoryproducts/v1"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/sortingcategoryproducts/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/merchandising"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/testfixtures/unittest"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+const category = "shoes"
+
+type sortCategoryProductsHandlerUnitTests struct {
+	*unittest.UnitTestSharedFixture
+	handler cqrs.RequestHandlerWithRegisterer[*sortingcategoryproductsv1.SortCategoryProducts, *dtos.SortCategoryProductsResponseDto]
+}
+
+func TestSortCategoryProductsHandlerUnit(t *testing.T) {
+	suite.Run(
+		t,
+		&sortCategoryProductsHandlerUnitTests{
+			UnitTestSharedFixture: unittest.NewUnitTestSharedFixture(t),
+		},
+	)
+}
+
+func (c *sortCategoryProductsHandlerUnitTests) SetupTest() {
+	// call base SetupTest hook before running child hook
+	c.UnitTestSharedFixture.SetupTest()
+	c.handler = sortingcategoryproductsv1.NewSortCategoryProductsHandler(
+		fxparams.ProductHandlerParams{
+			CatalogsDBContext:    c.CatalogDBContext,
+			Tracer:               c.Tracer,
+			OutboxPublisher:      c.OutboxPublisher,
+			Log:                  c.Log,
+			MerchandisingManager: merchandising.NewMerchandisingManager(c.CatalogDBContext, c.OutboxPublisher, c.ProductHistory, c.Log),
+			ProductHistory:       c.ProductHistory,
+		},
+	)
+
+	err := c.CatalogDBContext.DB().
+		Model(&datamodels.ProductDataModel{}).
+		Where("1 = 1").
+		Update("category", category).Error
+	c.Require().NoError(err)
+}
+
+func (c *sortCategoryProductsHandlerUnitTests) TearDownTest() {
+	// call base TearDownTest hook before running child hook
+	c.UnitTestSharedFixture.TearDownTest()
+}
+
+func (c *sortCategoryProductsHandlerUnitTests) Test_Handle_Should_Set_Sort_Order_Of_Listed_Products() {
+	first, second := c.Products[1], c.Products[0]
+
+	c.BeginTx()
+	result, err := c.handler.Handle(
+		c.Ctx,
+		sortingcategoryproductsv1.NewSortCategoryProducts(category, []uuid.UUID{first.Id, second.Id}),
+	)
+	c.CommitTx()
+
+	c.Require().NoError(err)
+	c.Assert().Equal(2, result.SortedProducts)
+
+	c.OutboxPublisher.AssertNumberOfCalls(c.T(), "PublishViaOutbox", 2)
+
+	c.assertSortOrder(first.Id, 1)
+	c.assertSortOrder(second.Id, 2)
+}
+
+func (c *sortCategoryProductsHandlerUnitTests) Test_Handle_Should_Clear_Sort_Order_Of_Not_Listed_Products() {
+	first, second := c.Products[0], c.Products[1]
+
+	c.BeginTx()
+	_, err := c.handler.Handle(
+		c.Ctx,
+		sortingcategoryproductsv1.NewSortCategoryProducts(category, []uuid.UUID{first.Id, second.Id}),
+	)
+	c.Require().NoError(err)
+
+	_, err = c.handler.Handle(
+		c.Ctx,
+		sortingcategoryproductsv1.NewSortCategoryProducts(category, []uuid.UUID{second.Id}),
+	)
+	c.CommitTx()
+
+	c.Require().NoError(err)
+
+	// the first call changes both of the products, the second one changes both of their positions too
+	c.OutboxPublisher.AssertNumberOfCalls(c.T(), "PublishViaOutbox", 4)
+
+	c.assertSortOrder(first.Id, 0)
+	c.assertSortOrder(second.Id, 1)
+}
+
+func (c *sortCategoryProductsHandlerUnitTests) Test_Handle_Should_Return_NotFound_Error_For_Product_Of_Other_Category() {
+	c.BeginTx()
+	_, err := c.handler.Handle(
+		c.Ctx,
+		sortingcategoryproductsv1.NewSortCategoryProducts("books", []uuid.UUID{c.Products[0].Id}),
+	)
+	c.CommitTx()
+
+	c.Require().Error(err)
+	c.Assert().True(customErrors.IsNotFoundError(err))
+
+	c.OutboxPublisher.AssertNumberOfCalls(c.T(), "PublishViaOutbox", 0)
+}
+
+func (c *sortCategoryProductsHandlerUnitTests) assertSortOrder(id uuid.UUID, sortOrder int) {
+	product, err := gormdbcontext.FindDataModelByID[*datamodels.ProductDataModel](
+		c.Ctx,
+		c.CatalogDBContext,
+		id,
+	)
+	c.Require().NoError(err)
+	c.Assert().Equal(sortOrder, product.SortOrder)
+}
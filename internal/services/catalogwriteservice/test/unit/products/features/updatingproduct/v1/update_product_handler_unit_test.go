@@ -11,6 +11,7 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
 	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/gormdbcontext"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/attributes"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/datamodels"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1/fxparams"
 	updatingoroductsv1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/features/updatingproduct/v1"
@@ -43,10 +44,12 @@ func (c *updateProductHandlerUnitTests) SetupTest() {
 	c.UnitTestSharedFixture.SetupTest()
 	c.handler = updatingoroductsv1.NewUpdateProductHandler(
 		fxparams.ProductHandlerParams{
-			CatalogsDBContext: c.CatalogDBContext,
-			Tracer:            c.Tracer,
-			RabbitmqProducer:  c.Bus,
-			Log:               c.Log,
+			CatalogsDBContext:   c.CatalogDBContext,
+			Tracer:              c.Tracer,
+			OutboxPublisher:     c.OutboxPublisher,
+			Log:                 c.Log,
+			AttributesValidator: attributes.NewAttributesValidator(c.CatalogDBContext),
+			ProductHistory:      c.ProductHistory,
 		},
 	)
 }
@@ -82,7 +85,7 @@ func (c *updateProductHandlerUnitTests) Test_Handle_Should_Update_Product_With_V
 
 	c.Assert().Equal(updatedProduct.Id, updateProductCommand.ProductID)
 	c.Assert().Equal(updatedProduct.Name, updateProductCommand.Name)
-	c.Bus.AssertNumberOfCalls(c.T(), "PublishMessage", 1)
+	c.OutboxPublisher.AssertNumberOfCalls(c.T(), "PublishViaOutbox", 1)
 }
 
 func (c *updateProductHandlerUnitTests) Test_Handle_Should_Return_Error_For_NotFound_Item() {
@@ -100,7 +103,7 @@ func (c *updateProductHandlerUnitTests) Test_Handle_Should_Return_Error_For_NotF
 	_, err = c.handler.Handle(c.Ctx, command)
 	c.CommitTx()
 
-	c.Bus.AssertNumberOfCalls(c.T(), "PublishMessage", 0)
+	c.OutboxPublisher.AssertNumberOfCalls(c.T(), "PublishViaOutbox", 0)
 	c.True(customErrors.IsApplicationError(err, http.StatusNotFound))
 	c.ErrorContains(
 		err,
@@ -121,8 +124,8 @@ func (c *updateProductHandlerUnitTests) Test_Handle_Should_Return_Error_For_Erro
 
 	// override called mock
 	// https://github.com/stretchr/testify/issues/558
-	c.Bus.Mock.ExpectedCalls = nil
-	c.Bus.On("PublishMessage", mock.Anything, mock.Anything, mock.Anything).
+	c.OutboxPublisher.Mock.ExpectedCalls = nil
+	c.OutboxPublisher.On("PublishViaOutbox", mock.Anything, mock.Anything, mock.Anything).
 		Once().
 		Return(errors.New("error in the publish message"))
 
@@ -130,7 +133,7 @@ func (c *updateProductHandlerUnitTests) Test_Handle_Should_Return_Error_For_Erro
 	_, err = c.handler.Handle(c.Ctx, updateProductCommand)
 	c.CommitTx()
 
-	c.Bus.AssertNumberOfCalls(c.T(), "PublishMessage", 1)
+	c.OutboxPublisher.AssertNumberOfCalls(c.T(), "PublishViaOutbox", 1)
 	c.ErrorContains(err, "error in the publish message")
 	c.ErrorContains(err, "error in publishing 'ProductUpdated' message")
 }
@@ -0,0 +1,67 @@
+//go:build unit
+// +build unit
+
+package identifiers
+
+import (
+	"testing"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/identifiers"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Gtin_Check_Digit(t *testing.T) {
+	checkDigit, err := identifiers.GtinCheckDigit("400638133393")
+	require.NoError(t, err)
+	assert.Equal(t, 1, checkDigit)
+
+	_, err = identifiers.GtinCheckDigit("40063813339a")
+	assert.Error(t, err)
+}
+
+func Test_Validate_Gtin(t *testing.T) {
+	// GTIN-8, GTIN-12, GTIN-13 and GTIN-14
+	assert.True(t, identifiers.IsValidGtin("96385074"))
+	assert.True(t, identifiers.IsValidGtin("036000291452"))
+	assert.True(t, identifiers.IsValidGtin("4006381333931"))
+	assert.True(t, identifiers.IsValidGtin("04006381333931"))
+
+	assert.False(t, identifiers.IsValidGtin("4006381333932"))
+	assert.False(t, identifiers.IsValidGtin("40063813339"))
+	assert.False(t, identifiers.IsValidGtin("400638133393a"))
+}
+
+func Test_Normalize_Gtin(t *testing.T) {
+	assert.Equal(t, "4006381333931", identifiers.NormalizeGtin(" 400-6381 333931 "))
+}
+
+func Test_To_Ean13(t *testing.T) {
+	ean13, ok := identifiers.ToEan13("036000291452")
+	require.True(t, ok)
+	assert.Equal(t, "0036000291452", ean13)
+
+	ean13, ok = identifiers.ToEan13("04006381333931")
+	require.True(t, ok)
+	assert.Equal(t, "4006381333931", ean13)
+
+	_, ok = identifiers.ToEan13("96385074")
+	assert.False(t, ok)
+}
+
+func Test_Normalize_And_Validate_Sku(t *testing.T) {
+	sku := identifiers.NormalizeSku("  abc 123_x.y ")
+	assert.Equal(t, "ABC-123_X.Y", sku)
+	assert.NoError(t, identifiers.ValidateSku(sku))
+
+	assert.Error(t, identifiers.ValidateSku("ABC--123"))
+	assert.Error(t, identifiers.ValidateSku("abc"))
+}
+
+func Test_Rules_Should_Allow_Empty_Values(t *testing.T) {
+	assert.NoError(t, validation.Validate("", identifiers.Gtin))
+	assert.NoError(t, validation.Validate("", identifiers.Sku))
+	assert.Error(t, validation.Validate("4006381333932", identifiers.Gtin))
+}
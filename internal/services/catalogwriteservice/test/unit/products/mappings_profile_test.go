@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mapper"
+	datamodel "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/data/datamodels"
 	dtoV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/dtos/v1"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/shared/testfixtures/unittest"
@@ -35,6 +36,9 @@ func (m *mappingProfileUnitTests) Test_Mappings() {
 		CreatedAt:   time.Now(),
 		Description: gofakeit.EmojiDescription(),
 		Price:       gofakeit.Price(100, 1000),
+		Translations: map[string]*models.ProductTranslation{
+			"fr": models.NewProductTranslation(gofakeit.Name(), gofakeit.EmojiDescription()),
+		},
 	}
 
 	productDto := &dtoV1.ProductDto{
@@ -50,6 +54,15 @@ func (m *mappingProfileUnitTests) Test_Mappings() {
 		m.Require().NoError(err)
 		m.Equal(productModel.Id, d.Id)
 		m.Equal(productModel.Name, d.Name)
+		m.Require().Contains(d.Translations, "fr")
+		m.Equal(productModel.Translations["fr"].Name, d.Translations["fr"].Name)
+	})
+
+	m.Run("Should_Map_Product_To_ProductDataModel_With_Translations", func() {
+		d, err := mapper.Map[*datamodel.ProductDataModel](productModel)
+		m.Require().NoError(err)
+		m.Require().Contains(d.Translations, "fr")
+		m.Equal(productModel.Translations["fr"].Description, d.Translations["fr"].Description)
 	})
 
 	m.Run("Should_Map_Nil_Product_To_ProductDto", func() {
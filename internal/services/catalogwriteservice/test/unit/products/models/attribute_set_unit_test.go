@@ -0,0 +1,61 @@
+//go:build unit
+// +build unit
+
+package models
+
+import (
+	"testing"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/datatypes"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newAttributeSet() *models.AttributeSet {
+	return &models.AttributeSet{
+		Category: "shoes",
+		Fields: []*models.AttributeField{
+			{Name: "size", Type: models.AttributeTypeNumber, Required: true},
+			{Name: "color", Type: models.AttributeTypeEnum, Options: []string{"red", "blue"}},
+			{Name: "waterproof", Type: models.AttributeTypeBoolean},
+		},
+	}
+}
+
+func Test_Validate_Attributes_Should_Pass_For_Valid_Attributes(t *testing.T) {
+	err := newAttributeSet().ValidateAttributes(datatypes.JSONMap{
+		"size":       42.0,
+		"color":      "red",
+		"waterproof": true,
+	})
+
+	assert.NoError(t, err)
+}
+
+func Test_Validate_Attributes_Should_Fail_For_Missing_Required_Attribute(t *testing.T) {
+	err := newAttributeSet().ValidateAttributes(datatypes.JSONMap{"color": "red"})
+
+	assert.ErrorContains(t, err, "size")
+}
+
+func Test_Validate_Attributes_Should_Fail_For_Invalid_Types(t *testing.T) {
+	err := newAttributeSet().ValidateAttributes(datatypes.JSONMap{
+		"size":       "large",
+		"color":      "green",
+		"waterproof": "yes",
+	})
+
+	assert.ErrorContains(t, err, "size")
+	assert.ErrorContains(t, err, "color")
+	assert.ErrorContains(t, err, "waterproof")
+}
+
+func Test_Validate_Attributes_Should_Fail_For_Unknown_Attribute(t *testing.T) {
+	err := newAttributeSet().ValidateAttributes(datatypes.JSONMap{
+		"size":     40.0,
+		"material": "leather",
+	})
+
+	assert.ErrorContains(t, err, "material")
+}
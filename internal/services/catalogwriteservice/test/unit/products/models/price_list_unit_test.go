@@ -0,0 +1,63 @@
+//go:build unit
+// +build unit
+
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPriceList(effectiveFrom time.Time, effectiveTo *time.Time, productId uuid.UUID, price float64) *models.PriceList {
+	return &models.PriceList{
+		Id:            uuid.NewV4(),
+		CustomerGroup: "wholesale",
+		EffectiveFrom: effectiveFrom,
+		EffectiveTo:   effectiveTo,
+		Prices:        []*models.ProductPrice{{ProductId: productId, Price: price}},
+	}
+}
+
+func Test_Price_List_Should_Be_Effective_In_Its_Dates(t *testing.T) {
+	now := time.Now()
+	effectiveTo := now.Add(time.Hour)
+	priceList := newPriceList(now, &effectiveTo, uuid.NewV4(), 10)
+
+	assert.False(t, priceList.IsEffectiveAt(now.Add(-time.Second)))
+	assert.True(t, priceList.IsEffectiveAt(now))
+	assert.True(t, priceList.IsEffectiveAt(now.Add(30*time.Minute)))
+	assert.False(t, priceList.IsEffectiveAt(effectiveTo))
+
+	priceList.EffectiveTo = nil
+	assert.True(t, priceList.IsEffectiveAt(now.AddDate(1, 0, 0)))
+}
+
+func Test_Resolve_Price_Should_Use_The_Latest_Effective_Price_List(t *testing.T) {
+	now := time.Now()
+	productId := uuid.NewV4()
+	promotionEnd := now.Add(time.Hour)
+
+	tier := newPriceList(now.AddDate(0, -1, 0), nil, productId, 90)
+	promotion := newPriceList(now.Add(-time.Hour), &promotionEnd, productId, 70)
+	upcoming := newPriceList(now.Add(time.Hour), nil, productId, 60)
+	priceLists := []*models.PriceList{tier, promotion, upcoming}
+
+	priceList, price, ok := models.ResolvePrice(priceLists, productId, now)
+	assert.True(t, ok)
+	assert.Equal(t, promotion.Id, priceList.Id)
+	assert.Equal(t, 70.0, price)
+
+	// the tier price is used again after the promotion ends
+	priceList, price, ok = models.ResolvePrice(priceLists[:2], productId, promotionEnd)
+	assert.True(t, ok)
+	assert.Equal(t, tier.Id, priceList.Id)
+	assert.Equal(t, 90.0, price)
+
+	_, _, ok = models.ResolvePrice(priceLists, uuid.NewV4(), now)
+	assert.False(t, ok)
+}
@@ -0,0 +1,120 @@
+//go:build unit
+// +build unit
+
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm/datatypes"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newProductChange(
+	productId uuid.UUID,
+	changeType models.ProductChangeType,
+	occurredAt time.Time,
+	snapshot datatypes.JSONMap,
+) *models.ProductChange {
+	return &models.ProductChange{
+		Id:         uuid.NewV4(),
+		ProductId:  productId,
+		ChangeType: changeType,
+		Snapshot:   snapshot,
+		OccurredAt: occurredAt,
+	}
+}
+
+func Test_Diff_Product_Changes_Should_Return_Changed_Fields_Against_The_Baseline(t *testing.T) {
+	now := time.Now()
+	productId := uuid.NewV4()
+
+	baseline := newProductChange(productId, models.ProductCreated, now.Add(-time.Hour), datatypes.JSONMap{
+		"name": "phone", "price": 100.0, "sku": "PH-1", "version": 1.0,
+	})
+	changes := []*models.ProductChange{
+		newProductChange(productId, models.ProductUpdated, now, datatypes.JSONMap{
+			"name": "phone", "price": 90.0, "sku": "PH-1", "version": 2.0,
+		}),
+		newProductChange(productId, models.ProductUpdated, now.Add(time.Minute), datatypes.JSONMap{
+			"name": "smart phone", "price": 90.0, "sku": "PH-1", "isPinned": true, "version": 3.0,
+		}),
+	}
+
+	diffs := models.DiffProductChanges([]*models.ProductChange{baseline}, changes)
+
+	require.Len(t, diffs, 1)
+	assert.Equal(t, models.ProductUpdated, diffs[0].ChangeType)
+	assert.Equal(t, now.Add(time.Minute), diffs[0].ChangedAt)
+	assert.Equal(t, changes[1].Snapshot, diffs[0].Product)
+	assert.Equal(t, []*models.ProductFieldDiff{
+		{Field: "isPinned", From: nil, To: true},
+		{Field: "name", From: "phone", To: "smart phone"},
+		{Field: "price", From: 100.0, To: 90.0},
+	}, diffs[0].Fields)
+}
+
+func Test_Diff_Product_Changes_Should_Return_Created_And_Deleted_Products(t *testing.T) {
+	now := time.Now()
+	createdId, deletedId, transientId := uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+
+	baselines := []*models.ProductChange{
+		newProductChange(deletedId, models.ProductCreated, now.Add(-time.Hour), datatypes.JSONMap{"name": "old"}),
+	}
+	changes := []*models.ProductChange{
+		newProductChange(createdId, models.ProductCreated, now, datatypes.JSONMap{"name": "new"}),
+		newProductChange(createdId, models.ProductUpdated, now.Add(time.Second), datatypes.JSONMap{"name": "newer"}),
+		newProductChange(deletedId, models.ProductDeleted, now, datatypes.JSONMap{"name": "old"}),
+		newProductChange(transientId, models.ProductCreated, now, datatypes.JSONMap{"name": "transient"}),
+		newProductChange(transientId, models.ProductDeleted, now.Add(time.Second), datatypes.JSONMap{"name": "transient"}),
+	}
+
+	diffs := models.DiffProductChanges(baselines, changes)
+
+	require.Len(t, diffs, 2)
+	assert.Equal(t, createdId, diffs[0].ProductId)
+	assert.Equal(t, models.ProductCreated, diffs[0].ChangeType)
+	assert.Equal(t, datatypes.JSONMap{"name": "newer"}, diffs[0].Product)
+	assert.Empty(t, diffs[0].Fields)
+	assert.Equal(t, deletedId, diffs[1].ProductId)
+	assert.Equal(t, models.ProductDeleted, diffs[1].ChangeType)
+	assert.Nil(t, diffs[1].Product)
+}
+
+func Test_Diff_Product_Changes_Should_Skip_Products_Changed_Back_To_Their_Baseline(t *testing.T) {
+	now := time.Now()
+	productId := uuid.NewV4()
+
+	baseline := newProductChange(productId, models.ProductUpdated, now.Add(-time.Hour), datatypes.JSONMap{
+		"isPinned": false, "version": 1.0,
+	})
+	changes := []*models.ProductChange{
+		newProductChange(productId, models.ProductUpdated, now, datatypes.JSONMap{"isPinned": true, "version": 2.0}),
+		newProductChange(productId, models.ProductUpdated, now.Add(time.Second), datatypes.JSONMap{
+			"isPinned": false, "version": 3.0,
+		}),
+	}
+
+	diffs := models.DiffProductChanges([]*models.ProductChange{baseline}, changes)
+
+	assert.Empty(t, diffs)
+}
+
+func Test_Diff_Product_Changes_Should_Return_Updated_Product_Without_Baseline(t *testing.T) {
+	productId := uuid.NewV4()
+	changes := []*models.ProductChange{
+		newProductChange(productId, models.ProductUpdated, time.Now(), datatypes.JSONMap{"name": "phone"}),
+	}
+
+	diffs := models.DiffProductChanges(nil, changes)
+
+	require.Len(t, diffs, 1)
+	assert.Equal(t, models.ProductUpdated, diffs[0].ChangeType)
+	assert.Equal(t, datatypes.JSONMap{"name": "phone"}, diffs[0].Product)
+	assert.Empty(t, diffs[0].Fields)
+}
@@ -0,0 +1,40 @@
+//go:build unit
+// +build unit
+
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Empty_Publishing_Schedule_Should_Be_Published(t *testing.T) {
+	schedule := models.NewPublishingSchedule(nil, nil)
+
+	assert.True(t, schedule.IsPublishedAt(time.Now()))
+	assert.NoError(t, schedule.Validate())
+}
+
+func Test_Publishing_Schedule_Should_Be_Published_Only_Inside_Window(t *testing.T) {
+	now := time.Now()
+	publishAt := now.Add(time.Hour)
+	unpublishAt := now.Add(2 * time.Hour)
+	schedule := models.NewPublishingSchedule(&publishAt, &unpublishAt)
+
+	assert.False(t, schedule.IsPublishedAt(now))
+	assert.True(t, schedule.IsPublishedAt(publishAt))
+	assert.True(t, schedule.IsPublishedAt(publishAt.Add(time.Minute)))
+	assert.False(t, schedule.IsPublishedAt(unpublishAt))
+}
+
+func Test_Publishing_Schedule_Should_Fail_When_Unpublish_Is_Before_Publish(t *testing.T) {
+	now := time.Now()
+	publishAt := now.Add(time.Hour)
+	schedule := models.NewPublishingSchedule(&publishAt, &now)
+
+	assert.Error(t, schedule.Validate())
+}
@@ -0,0 +1,36 @@
+//go:build unit
+// +build unit
+
+package skugeneration
+
+import (
+	"testing"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/catalogwriteservice/internal/products/skugeneration"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Ean13_Check_Digit(t *testing.T) {
+	checkDigit, err := skugeneration.Ean13CheckDigit("400638133393")
+	require.NoError(t, err)
+	assert.Equal(t, 1, checkDigit)
+
+	assert.True(t, skugeneration.IsValidEan13("4006381333931"))
+	assert.False(t, skugeneration.IsValidEan13("4006381333932"))
+	assert.False(t, skugeneration.IsValidEan13("40063813339"))
+}
+
+func Test_Encode_Ean13_Should_Return_95_Modules(t *testing.T) {
+	pattern, err := skugeneration.EncodeEan13("4006381333931")
+	require.NoError(t, err)
+
+	assert.Len(t, pattern, 95)
+	assert.Equal(t, "101", pattern[:3])
+	assert.Equal(t, "01010", pattern[45:50])
+	assert.Equal(t, "101", pattern[92:])
+
+	_, err = skugeneration.EncodeEan13("123")
+	assert.Error(t, err)
+}
@@ -2,7 +2,10 @@ package main
 
 import (
 	"os"
+	"strings"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/messagebroker"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/shared/app"
 
 	"github.com/pterm/pterm"
@@ -20,6 +23,22 @@ var rootCmd = &cobra.Command{
 	},
 }
 
+func init() {
+	// the flag is read by `messagebroker.GetBrokerType` when the modules are composed, it is declared here so cobra
+	// accepts it
+	rootCmd.Flags().Bool(
+		strings.TrimPrefix(messagebroker.LocalFlag, "--"),
+		false,
+		"run with the in-memory bus instead of the message broker",
+	)
+	// the flag is read by `eventstroredb.IsRebuildProjections` when the subscription worker starts
+	rootCmd.Flags().Bool(
+		strings.TrimPrefix(eventstroredb.RebuildProjectionsFlag, "--"),
+		false,
+		"rebuild the projections from the start of the event store",
+	)
+}
+
 // https://github.com/swaggo/swag#how-to-use-it-with-gin
 
 // @contact.name Mehdi Hadeli
@@ -0,0 +1,23 @@
+// Package host exposes the composition of the orders service to the launchers outside the service module, e.g. the
+// development host which runs all the services in one process.
+package host
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/fxapp/contracts"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/shared/app"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/shared/configurations/orders"
+)
+
+// NewApplication builds the orders application with its configurations and endpoints, the caller starts and stops the
+// application.
+func NewApplication() (contracts.Application, error) {
+	appBuilder := app.NewOrdersApplicationBuilder()
+	appBuilder.ProvideModule(orders.OrderServiceModule)
+
+	application := appBuilder.Build()
+
+	application.ConfigureOrders()
+	application.MapOrdersEndpoints()
+
+	return application, nil
+}
@@ -0,0 +1,73 @@
+package backoffice
+
+import (
+	echocontracts "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/contracts"
+	apikey "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/api_key"
+
+	"github.com/labstack/echo/v4"
+)
+
+// NewBackOfficeOrdersGroup creates the `/api/v1/backoffice/orders` group, every route of the group is authenticated
+// with the api keys of the back-office users
+func NewBackOfficeOrdersGroup(
+	ordersServer echocontracts.EchoHttpServer,
+	options *BackOfficeOptions,
+) *echo.Group {
+	return newBackOfficeGroup(ordersServer, options, "/backoffice/orders")
+}
+
+// NewBackOfficeCustomersGroup creates the `/api/v1/backoffice/customers` group with the same authentication of the
+// orders group
+func NewBackOfficeCustomersGroup(
+	ordersServer echocontracts.EchoHttpServer,
+	options *BackOfficeOptions,
+) *echo.Group {
+	return newBackOfficeGroup(ordersServer, options, "/backoffice/customers")
+}
+
+// NewBackOfficeGiftCardsGroup creates the `/api/v1/backoffice/giftcards` group with the same authentication of the
+// orders group
+func NewBackOfficeGiftCardsGroup(
+	ordersServer echocontracts.EchoHttpServer,
+	options *BackOfficeOptions,
+) *echo.Group {
+	return newBackOfficeGroup(ordersServer, options, "/backoffice/giftcards")
+}
+
+// NewBackOfficeProjectionsGroup creates the `/api/v1/backoffice/projections` group of the projections admin api with
+// the same authentication of the orders group
+func NewBackOfficeProjectionsGroup(
+	ordersServer echocontracts.EchoHttpServer,
+	options *BackOfficeOptions,
+) *echo.Group {
+	return newBackOfficeGroup(ordersServer, options, "/backoffice/projections")
+}
+
+// NewBackOfficeFinanceGroup creates the `/api/v1/backoffice/finance` group of the finance export admin api with the
+// same authentication of the orders group
+func NewBackOfficeFinanceGroup(
+	ordersServer echocontracts.EchoHttpServer,
+	options *BackOfficeOptions,
+) *echo.Group {
+	return newBackOfficeGroup(ordersServer, options, "/backoffice/finance")
+}
+
+func newBackOfficeGroup(
+	ordersServer echocontracts.EchoHttpServer,
+	options *BackOfficeOptions,
+	prefix string,
+) *echo.Group {
+	var keys []apikey.Option
+	for _, user := range options.Users {
+		if user != nil {
+			keys = append(keys, apikey.WithKey(user.ApiKey, user.UserId))
+		}
+	}
+
+	var g *echo.Group
+	ordersServer.RouteBuilder().RegisterGroupFunc("/api/v1", func(v1 *echo.Group) {
+		g = v1.Group(prefix, apikey.ApiKey(keys...))
+	})
+
+	return g
+}
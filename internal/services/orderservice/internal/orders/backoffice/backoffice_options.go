@@ -0,0 +1,26 @@
+package backoffice
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[BackOfficeOptions]())
+
+// BackOfficeOptions holds the users of the back-office endpoints, a request is authenticated with the api key of a
+// user in the `X-Api-Key` header, without any user all the back-office requests are unauthorized.
+type BackOfficeOptions struct {
+	Users []*BackOfficeUserOptions `mapstructure:"users"`
+}
+
+type BackOfficeUserOptions struct {
+	UserId string `mapstructure:"userId"`
+	ApiKey string `mapstructure:"apiKey" secret:"true"`
+}
+
+func NewBackOfficeOptions(environment environment.Environment) (*BackOfficeOptions, error) {
+	return config.BindConfigKey[*BackOfficeOptions](optionName, environment)
+}
@@ -4,9 +4,12 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mapper"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils"
 	dtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/dtos/v1"
+	draftsReadModels "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/drafts/read_models"
+	financeReadModels "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/finance/read_models"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/aggregate"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/read_models"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/value_objects"
+	segmentsReadModels "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/segments/read_models"
 	grpcOrderService "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/shared/grpc/genproto"
 
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -34,10 +37,13 @@ func ConfigureOrdersMappings() error {
 
 			order, err := aggregate.NewOrder(
 				orderDto.Id,
+				orderDto.OrderNumber,
 				items,
 				orderDto.AccountEmail,
 				orderDto.DeliveryAddress,
 				orderDto.DeliveredTime,
+				orderDto.Currency,
+				orderDto.ExchangeRate,
 				orderDto.CreatedAt,
 			)
 			if err != nil {
@@ -57,6 +63,40 @@ func ConfigureOrdersMappings() error {
 		return err
 	}
 
+	// read_models.OrderReadModel -> dtos.BackOfficeOrderReadDto
+	err = mapper.CreateMap[*read_models.OrderReadModel, *dtosV1.BackOfficeOrderReadDto]()
+	if err != nil {
+		return err
+	}
+
+	// read_models.OrderNoteReadModel -> dtos.OrderNoteReadDto
+	err = mapper.CreateMap[*read_models.OrderNoteReadModel, *dtosV1.OrderNoteReadDto]()
+	if err != nil {
+		return err
+	}
+
+	// segmentsReadModels.CustomerSegmentsReadModel -> dtos.CustomerSegmentsDto
+	err = mapper.CreateMap[*segmentsReadModels.CustomerSegmentsReadModel, *dtosV1.CustomerSegmentsDto]()
+	if err != nil {
+		return err
+	}
+
+	// financeReadModels.FinanceEntryReadModel -> dtos.FinanceEntryDto
+	err = mapper.CreateMap[*financeReadModels.FinanceEntryReadModel, *dtosV1.FinanceEntryDto]()
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateMap[*draftsReadModels.OrderDraftItemReadModel, *dtosV1.ShopItemDto]()
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateMap[*draftsReadModels.OrderDraftReadModel, *dtosV1.OrderDraftDto]()
+	if err != nil {
+		return err
+	}
+
 	// dtos.OrderReadDto -> grpcOrderService.OrderReadModel
 	// custom filed map not support yet like ForMember so we have to create a custom map because of some timestamp fields map to time.Time
 	err = mapper.CreateCustomMap[*dtosV1.OrderReadDto, *grpcOrderService.OrderReadModel](
@@ -99,7 +139,17 @@ func ConfigureOrdersMappings() error {
 	}
 
 	// ShopItem -> ShopItemDto
-	err = mapper.CreateMap[*value_objects.ShopItem, *dtosV1.ShopItemDto]()
+	err = mapper.CreateCustomMap[*value_objects.ShopItem, *dtosV1.ShopItemDto](
+		func(src *value_objects.ShopItem) *dtosV1.ShopItemDto {
+			return &dtosV1.ShopItemDto{
+				ProductId:   src.ProductId(),
+				Title:       src.Title(),
+				Description: src.Description(),
+				Quantity:    src.Quantity(),
+				Price:       src.Price(),
+			}
+		},
+	)
 	if err != nil {
 		return err
 	}
@@ -107,7 +157,8 @@ func ConfigureOrdersMappings() error {
 	// ShopItemDto -> ShopItem
 	err = mapper.CreateCustomMap[*dtosV1.ShopItemDto, *value_objects.ShopItem](
 		func(src *dtosV1.ShopItemDto) *value_objects.ShopItem {
-			return value_objects.CreateNewShopItem(
+			return value_objects.CreateNewProductShopItem(
+				src.ProductId,
 				src.Title,
 				src.Description,
 				src.Quantity,
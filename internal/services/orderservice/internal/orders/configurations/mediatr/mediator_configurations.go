@@ -1,17 +1,71 @@
 package mediatr
 
 import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/bus"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/commandbus"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/store"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
 	repositories2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/repositories"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/drafts"
+	activateGiftCardCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/activating_gift_card/v1/commands"
+	addOrderNoteCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/adding_order_note/v1/commands"
+	addOrderNoteDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/adding_order_note/v1/dtos"
+	cancelOrderCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/canceling_order/v1/commands"
+	convertOrderDraftCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/converting_order_draft/v1/commands"
+	convertOrderDraftDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/converting_order_draft/v1/dtos"
 	createOrderCommandV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/creating_order/v1/commands"
 	createOrderDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/creating_order/v1/dtos"
+	exportFinanceEntriesCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/exporting_finance_entries/v1/commands"
+	exportFinanceEntriesDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/exporting_finance_entries/v1/dtos"
+	exportFinanceEntriesQueriesV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/exporting_finance_entries/v1/queries"
+	fulfillOrderCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/fulfilling_order/v1/commands"
+	getCommandStatusDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_command_status/v1/dtos"
+	getCommandStatusQueryV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_command_status/v1/queries"
+	getCustomerSegmentsDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_customer_segments/v1/dtos"
+	getCustomerSegmentsQueryV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_customer_segments/v1/queries"
+	getGiftCardBalanceDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_gift_card_balance/v1/dtos"
+	getGiftCardBalanceQueryV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_gift_card_balance/v1/queries"
 	getOrderByIdDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_order_by_id/v1/dtos"
 	getOrderByIdQueryV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_order_by_id/v1/queries"
+	getOrderDraftDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_order_draft/v1/dtos"
+	getOrderDraftQueryV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_order_draft/v1/queries"
+	getOrderEventsDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_order_events/v1/dtos"
+	getOrderEventsQueryV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_order_events/v1/queries"
 	getOrdersDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_orders/v1/dtos"
 	getOrdersQueryV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_orders/v1/queries"
+	getOrdersByIdsDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_orders_by_ids/v1/dtos"
+	getOrdersByIdsQueryV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_orders_by_ids/v1/queries"
+	getSegmentCustomersDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_segment_customers/v1/dtos"
+	getSegmentCustomersQueryV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_segment_customers/v1/queries"
+	legalHoldCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/holding_order_legally/v1/commands"
+	issueGiftCardCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/issuing_gift_card/v1/commands"
+	issueGiftCardDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/issuing_gift_card/v1/dtos"
+	projectionRebuildsCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/rebuilding_projections/v1/commands"
+	projectionRebuildsDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/rebuilding_projections/v1/dtos"
+	projectionRebuildsQueryV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/rebuilding_projections/v1/queries"
+	resendOrderConfirmationCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/resending_order_confirmation/v1/commands"
+	reviewOrderCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/reviewing_order/v1/commands"
+	saveOrderDraftCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/saving_order_draft/v1/commands"
+	saveOrderDraftDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/saving_order_draft/v1/dtos"
+	searchOrdersDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/searching_orders/v1/dtos"
+	searchOrdersQueryV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/searching_orders/v1/queries"
+	splitOrderStreamCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/splitting_order_stream/v1/commands"
+	splitOrderStreamDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/splitting_order_stream/v1/dtos"
+	splitOrderStreamQueriesV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/splitting_order_stream/v1/queries"
+	orderProjectionVersionsCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/versioning_order_projection/v1/commands"
+	orderProjectionVersionsDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/versioning_order_projection/v1/dtos"
+	orderProjectionVersionsQueryV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/versioning_order_projection/v1/queries"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/fraud"
+	draftAggregate "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/drafts/aggregate"
+	giftCardAggregate "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/giftcards/aggregate"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/aggregate"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/numbering"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/pricing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/projections/versioning"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/sagas"
 
 	"github.com/mehdihadeli/go-mediatr"
 )
@@ -19,30 +73,284 @@ import (
 func ConfigOrdersMediator(
 	logger logger.Logger,
 	mongoOrderReadRepository repositories2.OrderMongoRepository,
+	customerSegmentsRepository repositories2.CustomerSegmentsRepository,
 	orderAggregateStore store.AggregateStore[*aggregate.Order],
+	giftCardAggregateStore store.AggregateStore[*giftCardAggregate.GiftCard],
+	orderDraftAggregateStore store.AggregateStore[*draftAggregate.OrderDraft],
+	orderDraftRepository repositories2.OrderDraftRepository,
+	financeEntryRepository repositories2.FinanceEntryRepository,
+	orderDraftOptions *drafts.OrderDraftOptions,
+	orderStreamSplitter store.AggregateStreamSplitter[*aggregate.Order],
+	eventStore store.EventStore,
+	outboxPublisher bus.OutboxPublisher,
+	commandBus commandbus.CommandBus,
+	fraudScreener fraud.FraudScreener,
+	priceResolver pricing.PriceResolver,
+	orderNumberGenerator *numbering.OrderNumberGenerator,
+	projectionVersioning *versioning.OrderProjectionVersioning,
+	projectionManager eventstroredb.ProjectionManager,
+	orderFulfillmentOrchestrator sagas.OrderFulfillmentOrchestrator,
 	tracer tracing.AppTracer,
 ) error {
 	// https://stackoverflow.com/questions/72034479/how-to-implement-generic-interfaces
-	err := mediatr.RegisterRequestHandler[*createOrderCommandV1.CreateOrder, *createOrderDtosV1.CreateOrderResponseDto](
-		createOrderCommandV1.NewCreateOrderHandler(logger, orderAggregateStore, tracer),
+	err := cqrs.RegisterRequestHandler[*createOrderCommandV1.CreateOrder, *createOrderDtosV1.CreateOrderResponseDto](
+		createOrderCommandV1.NewCreateOrderHandler(
+			logger,
+			orderAggregateStore,
+			giftCardAggregateStore,
+			fraudScreener,
+			priceResolver,
+			orderNumberGenerator,
+			tracer,
+		),
 	)
 	if err != nil {
 		return err
 	}
 
-	err = mediatr.RegisterRequestHandler[*getOrderByIdQueryV1.GetOrderById, *getOrderByIdDtosV1.GetOrderByIdResponseDto](
+	// the orders can be enqueued with the command bus and created by the accepted command consumer
+	commandbus.RegisterAsyncCommand[*createOrderCommandV1.CreateOrder, *createOrderDtosV1.CreateOrderResponseDto]()
+
+	err = cqrs.RegisterRequestHandler[*getCommandStatusQueryV1.GetCommandStatus, *getCommandStatusDtosV1.GetCommandStatusResponseDto](
+		getCommandStatusQueryV1.NewGetCommandStatusHandler(logger, commandBus, tracer),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.RegisterRequestHandler[*reviewOrderCommandsV1.ApproveOrderReview, *mediatr.Unit](
+		reviewOrderCommandsV1.NewApproveOrderReviewHandler(logger, orderAggregateStore, tracer),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.RegisterRequestHandler[*reviewOrderCommandsV1.RejectOrderReview, *mediatr.Unit](
+		reviewOrderCommandsV1.NewRejectOrderReviewHandler(logger, orderAggregateStore, tracer),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.RegisterRequestHandler[*getOrderByIdQueryV1.GetOrderById, *getOrderByIdDtosV1.GetOrderByIdResponseDto](
 		getOrderByIdQueryV1.NewGetOrderByIdHandler(logger, mongoOrderReadRepository, tracer),
 	)
 	if err != nil {
 		return err
 	}
 
-	err = mediatr.RegisterRequestHandler[*getOrdersQueryV1.GetOrders, *getOrdersDtosV1.GetOrdersResponseDto](
+	err = cqrs.RegisterRequestHandler[*getOrdersByIdsQueryV1.GetOrdersByIds, *getOrdersByIdsDtosV1.GetOrdersByIdsResponseDto](
+		getOrdersByIdsQueryV1.NewGetOrdersByIdsHandler(logger, mongoOrderReadRepository, tracer),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.RegisterRequestHandler[*getOrdersQueryV1.GetOrders, *getOrdersDtosV1.GetOrdersResponseDto](
 		getOrdersQueryV1.NewGetOrdersHandler(logger, mongoOrderReadRepository, tracer),
 	)
 	if err != nil {
 		return err
 	}
 
+	err = cqrs.RegisterRequestHandler[*searchOrdersQueryV1.SearchOrders, *searchOrdersDtosV1.SearchOrdersResponseDto](
+		searchOrdersQueryV1.NewSearchOrdersHandler(logger, mongoOrderReadRepository, tracer),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.RegisterRequestHandler[*cancelOrderCommandsV1.ForceCancelOrder, *mediatr.Unit](
+		cancelOrderCommandsV1.NewForceCancelOrderHandler(logger, orderAggregateStore, tracer),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.RegisterRequestHandler[*addOrderNoteCommandsV1.AddOrderNote, *addOrderNoteDtosV1.AddOrderNoteResponseDto](
+		addOrderNoteCommandsV1.NewAddOrderNoteHandler(logger, orderAggregateStore, tracer),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.RegisterRequestHandler[*resendOrderConfirmationCommandsV1.ResendOrderConfirmation, *mediatr.Unit](
+		resendOrderConfirmationCommandsV1.NewResendOrderConfirmationHandler(
+			logger,
+			mongoOrderReadRepository,
+			outboxPublisher,
+			tracer,
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.RegisterRequestHandler[*getOrderEventsQueryV1.GetOrderEvents, *getOrderEventsDtosV1.GetOrderEventsResponseDto](
+		getOrderEventsQueryV1.NewGetOrderEventsHandler(logger, eventStore, orderStreamSplitter, tracer),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.RegisterRequestHandler[*getCustomerSegmentsQueryV1.GetCustomerSegments, *getCustomerSegmentsDtosV1.GetCustomerSegmentsResponseDto](
+		getCustomerSegmentsQueryV1.NewGetCustomerSegmentsHandler(logger, customerSegmentsRepository, tracer),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.RegisterRequestHandler[*getSegmentCustomersQueryV1.GetSegmentCustomers, *getSegmentCustomersDtosV1.GetSegmentCustomersResponseDto](
+		getSegmentCustomersQueryV1.NewGetSegmentCustomersHandler(logger, customerSegmentsRepository, tracer),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.RegisterRequestHandler[*issueGiftCardCommandsV1.IssueGiftCard, *issueGiftCardDtosV1.IssueGiftCardResponseDto](
+		issueGiftCardCommandsV1.NewIssueGiftCardHandler(logger, giftCardAggregateStore, tracer),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.RegisterRequestHandler[*activateGiftCardCommandsV1.ActivateGiftCard, *mediatr.Unit](
+		activateGiftCardCommandsV1.NewActivateGiftCardHandler(logger, giftCardAggregateStore, tracer),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.RegisterRequestHandler[*getGiftCardBalanceQueryV1.GetGiftCardBalance, *getGiftCardBalanceDtosV1.GetGiftCardBalanceResponseDto](
+		getGiftCardBalanceQueryV1.NewGetGiftCardBalanceHandler(logger, giftCardAggregateStore, tracer),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.RegisterRequestHandler[*legalHoldCommandsV1.PlaceLegalHold, *mediatr.Unit](
+		legalHoldCommandsV1.NewPlaceLegalHoldHandler(logger, orderAggregateStore, tracer),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.RegisterRequestHandler[*legalHoldCommandsV1.ReleaseLegalHold, *mediatr.Unit](
+		legalHoldCommandsV1.NewReleaseLegalHoldHandler(logger, orderAggregateStore, tracer),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.RegisterRequestHandler[*splitOrderStreamCommandsV1.SplitOrderStream, *splitOrderStreamDtosV1.SplitOrderStreamResponseDto](
+		splitOrderStreamCommandsV1.NewSplitOrderStreamHandler(logger, orderStreamSplitter, tracer),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.RegisterRequestHandler[*splitOrderStreamQueriesV1.GetOrderStreamSegments, *splitOrderStreamDtosV1.GetOrderStreamSegmentsResponseDto](
+		splitOrderStreamQueriesV1.NewGetOrderStreamSegmentsHandler(logger, orderStreamSplitter, tracer),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.RegisterRequestHandler[*orderProjectionVersionsQueryV1.GetOrderProjectionVersions, *orderProjectionVersionsDtosV1.GetOrderProjectionVersionsResponseDto](
+		orderProjectionVersionsQueryV1.NewGetOrderProjectionVersionsHandler(logger, projectionVersioning),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.RegisterRequestHandler[*orderProjectionVersionsCommandsV1.StartOrderProjectionVersion, *orderProjectionVersionsDtosV1.StartOrderProjectionVersionResponseDto](
+		orderProjectionVersionsCommandsV1.NewStartOrderProjectionVersionHandler(logger, projectionVersioning),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.RegisterRequestHandler[*orderProjectionVersionsCommandsV1.RequestOrderProjectionCutover, *orderProjectionVersionsDtosV1.RequestOrderProjectionCutoverResponseDto](
+		orderProjectionVersionsCommandsV1.NewRequestOrderProjectionCutoverHandler(logger, projectionVersioning),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.RegisterRequestHandler[*orderProjectionVersionsCommandsV1.AbortOrderProjectionVersion, *mediatr.Unit](
+		orderProjectionVersionsCommandsV1.NewAbortOrderProjectionVersionHandler(logger, projectionVersioning),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.RegisterRequestHandler[*projectionRebuildsQueryV1.GetProjectionRebuilds, *projectionRebuildsDtosV1.GetProjectionRebuildsResponseDto](
+		projectionRebuildsQueryV1.NewGetProjectionRebuildsHandler(logger, projectionManager),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.RegisterRequestHandler[*projectionRebuildsCommandsV1.StartProjectionRebuild, *projectionRebuildsDtosV1.StartProjectionRebuildResponseDto](
+		projectionRebuildsCommandsV1.NewStartProjectionRebuildHandler(logger, projectionManager),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.RegisterRequestHandler[*projectionRebuildsCommandsV1.CancelProjectionRebuild, *mediatr.Unit](
+		projectionRebuildsCommandsV1.NewCancelProjectionRebuildHandler(logger, projectionManager),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.RegisterRequestHandler[*saveOrderDraftCommandsV1.SaveOrderDraft, *saveOrderDraftDtosV1.SaveOrderDraftResponseDto](
+		saveOrderDraftCommandsV1.NewSaveOrderDraftHandler(logger, orderDraftAggregateStore, orderDraftOptions, tracer),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.RegisterRequestHandler[*getOrderDraftQueryV1.GetOrderDraft, *getOrderDraftDtosV1.GetOrderDraftResponseDto](
+		getOrderDraftQueryV1.NewGetOrderDraftHandler(logger, orderDraftRepository, tracer),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.RegisterRequestHandler[*convertOrderDraftCommandsV1.ConvertOrderDraft, *convertOrderDraftDtosV1.ConvertOrderDraftResponseDto](
+		convertOrderDraftCommandsV1.NewConvertOrderDraftHandler(
+			logger,
+			orderDraftAggregateStore,
+			orderAggregateStore,
+			tracer,
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.RegisterRequestHandler[*exportFinanceEntriesQueriesV1.GetFinanceEntries, *exportFinanceEntriesDtosV1.GetFinanceEntriesResponseDto](
+		exportFinanceEntriesQueriesV1.NewGetFinanceEntriesHandler(logger, financeEntryRepository, tracer),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.RegisterRequestHandler[*exportFinanceEntriesCommandsV1.ReplayFinanceEntries, *exportFinanceEntriesDtosV1.ReplayFinanceEntriesResponseDto](
+		exportFinanceEntriesCommandsV1.NewReplayFinanceEntriesHandler(logger, financeEntryRepository, tracer),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.RegisterRequestHandler[*fulfillOrderCommandsV1.HandleOrderFulfillmentReply, *mediatr.Unit](
+		fulfillOrderCommandsV1.NewHandleOrderFulfillmentReplyHandler(logger, orderFulfillmentOrchestrator, tracer),
+	)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -1,8 +1,12 @@
 package configurations
 
 import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/bus"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/commandbus"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/store"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb"
 	contracts2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/fxapp/contracts"
 	grpcServer "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/grpc"
 	echocontracts "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/contracts"
@@ -11,7 +15,15 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/configurations/mappings"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/configurations/mediatr"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/repositories"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/drafts"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/fraud"
+	draftAggregate "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/drafts/aggregate"
+	giftCardAggregate "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/giftcards/aggregate"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/aggregate"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/numbering"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/pricing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/projections/versioning"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/sagas"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/shared/contracts"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/shared/grpc"
 	ordersservice "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/shared/grpc/genproto"
@@ -20,6 +32,9 @@ import (
 	googleGrpc "google.golang.org/grpc"
 )
 
+// ordersRequestsPackage is the package prefix of the orders commands and queries which should have a handler
+const ordersRequestsPackage = "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice"
+
 type OrdersModuleConfigurator struct {
 	contracts2.Application
 }
@@ -37,7 +52,23 @@ func (c *OrdersModuleConfigurator) ConfigureOrdersModule() {
 		func(logger logger.Logger,
 			server echocontracts.EchoHttpServer,
 			orderRepository repositories.OrderMongoRepository,
+			customerSegmentsRepository repositories.CustomerSegmentsRepository,
 			orderAggregateStore store.AggregateStore[*aggregate.Order],
+			giftCardAggregateStore store.AggregateStore[*giftCardAggregate.GiftCard],
+			orderDraftAggregateStore store.AggregateStore[*draftAggregate.OrderDraft],
+			orderDraftRepository repositories.OrderDraftRepository,
+			financeEntryRepository repositories.FinanceEntryRepository,
+			orderDraftOptions *drafts.OrderDraftOptions,
+			orderStreamSplitter store.AggregateStreamSplitter[*aggregate.Order],
+			eventStore store.EventStore,
+			outboxPublisher bus.OutboxPublisher,
+			commandBus commandbus.CommandBus,
+			fraudScreener fraud.FraudScreener,
+			priceResolver pricing.PriceResolver,
+			orderNumberGenerator *numbering.OrderNumberGenerator,
+			projectionVersioning *versioning.OrderProjectionVersioning,
+			projectionManager eventstroredb.ProjectionManager,
+			orderFulfillmentOrchestrator sagas.OrderFulfillmentOrchestrator,
 			tracer tracing.AppTracer,
 		) error {
 			// config Orders Mappings
@@ -47,10 +78,38 @@ func (c *OrdersModuleConfigurator) ConfigureOrdersModule() {
 			}
 
 			// config Orders Mediators
-			err = mediatr.ConfigOrdersMediator(logger, orderRepository, orderAggregateStore, tracer)
+			err = mediatr.ConfigOrdersMediator(
+				logger,
+				orderRepository,
+				customerSegmentsRepository,
+				orderAggregateStore,
+				giftCardAggregateStore,
+				orderDraftAggregateStore,
+				orderDraftRepository,
+				financeEntryRepository,
+				orderDraftOptions,
+				orderStreamSplitter,
+				eventStore,
+				outboxPublisher,
+				commandBus,
+				fraudScreener,
+				priceResolver,
+				orderNumberGenerator,
+				projectionVersioning,
+				projectionManager,
+				orderFulfillmentOrchestrator,
+				tracer,
+			)
+			if err != nil {
+				return err
+			}
+
+			// pre-resolve orders request handlers and validate every command and query has exactly one handler
+			err = cqrs.BuildDispatchTable(ordersRequestsPackage)
 			if err != nil {
 				return err
 			}
+			logger.Infof("%d request handlers registered in the dispatch table", len(cqrs.RegisteredHandlers()))
 
 			return nil
 		},
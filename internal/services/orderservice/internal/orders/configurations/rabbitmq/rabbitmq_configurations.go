@@ -1,12 +1,34 @@
 package rabbitmq
 
 import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/commandbus"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
 	rabbitmqConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/configurations"
+	consumerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/consumer/configurations"
 	producerConfigurations "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/producer/configurations"
+	cancelOrderIntegrationEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/canceling_order/v1/events/integration_events"
 	createOrderIntegrationEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/creating_order/v1/events/integration_events"
+	fulfillOrderIntegrationEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/fulfilling_order/v1/events/integration_events"
+	fulfillOrderExternalEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/fulfilling_order/v1/events/integration_events/external_events"
+	redeemGiftCardIntegrationEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/redeeming_gift_card/v1/events/integration_events"
+	resendOrderConfirmationIntegrationEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/resending_order_confirmation/v1/events/integration_events"
+	reviewOrderIntegrationEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/reviewing_order/v1/events/integration_events"
+	segmentCustomersIntegrationEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/segmenting_customers/v1/events/integration_events"
+	syncPriceListsExternalEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/syncing_price_lists/v1/events/integration_events/external_events"
 )
 
-func ConfigOrdersRabbitMQ(builder rabbitmqConfigurations.RabbitMQConfigurationBuilder) {
+// acceptedCommandsExchange is the exchange and the queue of the commands which are enqueued by the command bus of the
+// orders service, they are not shared with the command buses of the other services
+const acceptedCommandsExchange = "orders_accepted_command"
+
+func ConfigOrdersRabbitMQ(
+	builder rabbitmqConfigurations.RabbitMQConfigurationBuilder,
+	acceptedCommandHandler consumer.ConsumerHandler,
+	priceListCreatedHandler consumer.ConsumerHandler,
+	orderFulfillmentReplyHandler consumer.ConsumerHandler,
+	checkGiftCardRedemptionHandler consumer.ConsumerHandler,
+) {
 	// add custom message type mappings
 	// utils.RegisterCustomMessageTypesToRegistrty(map[string]types.IMessage{"orderCreatedV1": &OrderCreatedV1{}})
 
@@ -14,4 +36,109 @@ func ConfigOrdersRabbitMQ(builder rabbitmqConfigurations.RabbitMQConfigurationBu
 		createOrderIntegrationEventsV1.OrderCreatedV1{},
 		func(builder producerConfigurations.RabbitMQProducerConfigurationBuilder) {
 		})
+
+	builder.AddProducer(
+		reviewOrderIntegrationEventsV1.OrderHeldForReviewV1{},
+		func(builder producerConfigurations.RabbitMQProducerConfigurationBuilder) {
+		})
+
+	builder.AddProducer(
+		cancelOrderIntegrationEventsV1.OrderCanceledV1{},
+		func(builder producerConfigurations.RabbitMQProducerConfigurationBuilder) {
+		})
+
+	builder.AddProducer(
+		resendOrderConfirmationIntegrationEventsV1.OrderConfirmationResendRequestedV1{},
+		func(builder producerConfigurations.RabbitMQProducerConfigurationBuilder) {
+		})
+
+	builder.AddProducer(
+		segmentCustomersIntegrationEventsV1.CustomerSegmentsChangedV1{},
+		func(builder producerConfigurations.RabbitMQProducerConfigurationBuilder) {
+		})
+
+	// the commands of the order fulfillment saga, their participants reply with the id of the command as the
+	// correlation id
+	builder.AddProducer(
+		fulfillOrderIntegrationEventsV1.ReserveStockV1{},
+		func(builder producerConfigurations.RabbitMQProducerConfigurationBuilder) {
+		})
+
+	builder.AddProducer(
+		fulfillOrderIntegrationEventsV1.ReleaseStockV1{},
+		func(builder producerConfigurations.RabbitMQProducerConfigurationBuilder) {
+		})
+
+	builder.AddProducer(
+		fulfillOrderIntegrationEventsV1.ProcessPaymentV1{},
+		func(builder producerConfigurations.RabbitMQProducerConfigurationBuilder) {
+		})
+
+	builder.AddProducer(
+		fulfillOrderIntegrationEventsV1.RefundPaymentV1{},
+		func(builder producerConfigurations.RabbitMQProducerConfigurationBuilder) {
+		})
+
+	// the redemption checks are published and consumed by the orders service, they refund the redemptions of the orders
+	// which are not stored
+	builder.AddProducer(
+		redeemGiftCardIntegrationEventsV1.CheckGiftCardRedemptionV1{},
+		func(builder producerConfigurations.RabbitMQProducerConfigurationBuilder) {
+		})
+
+	builder.AddConsumer(
+		redeemGiftCardIntegrationEventsV1.CheckGiftCardRedemptionV1{},
+		func(builder consumerConfigurations.RabbitMQConsumerConfigurationBuilder) {
+			builder.WithHandlers(
+				func(handlersBuilder consumer.ConsumerHandlerConfigurationBuilder) {
+					handlersBuilder.AddHandler(checkGiftCardRedemptionHandler)
+				},
+			)
+		})
+
+	builder.AddProducer(
+		commandbus.AcceptedCommand{},
+		func(builder producerConfigurations.RabbitMQProducerConfigurationBuilder) {
+			builder.WithExchangeName(acceptedCommandsExchange)
+		})
+
+	builder.AddConsumer(
+		commandbus.AcceptedCommand{},
+		func(builder consumerConfigurations.RabbitMQConsumerConfigurationBuilder) {
+			builder.WithExchangeName(acceptedCommandsExchange)
+			builder.WithQueueName(acceptedCommandsExchange)
+			builder.WithHandlers(
+				func(handlersBuilder consumer.ConsumerHandlerConfigurationBuilder) {
+					handlersBuilder.AddHandler(acceptedCommandHandler)
+				},
+			)
+		})
+
+	builder.AddConsumer(
+		syncPriceListsExternalEventsV1.PriceListCreatedV1{},
+		func(builder consumerConfigurations.RabbitMQConsumerConfigurationBuilder) {
+			builder.WithHandlers(
+				func(handlersBuilder consumer.ConsumerHandlerConfigurationBuilder) {
+					handlersBuilder.AddHandler(priceListCreatedHandler)
+				},
+			)
+		})
+
+	// the replies of the participants of the order fulfillment saga
+	for _, reply := range []types.IMessage{
+		fulfillOrderExternalEventsV1.StockReservedV1{},
+		fulfillOrderExternalEventsV1.StockReservationFailedV1{},
+		fulfillOrderExternalEventsV1.PaymentCompletedV1{},
+		fulfillOrderExternalEventsV1.PaymentFailedV1{},
+	} {
+		builder.AddConsumer(
+			reply,
+			func(builder consumerConfigurations.RabbitMQConsumerConfigurationBuilder) {
+				builder.WithHandlers(
+					func(handlersBuilder consumer.ConsumerHandlerConfigurationBuilder) {
+						handlersBuilder.AddHandler(orderFulfillmentReplyHandler)
+					},
+				)
+			})
+	}
 }
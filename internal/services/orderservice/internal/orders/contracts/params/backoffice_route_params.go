@@ -0,0 +1,26 @@
+package params
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/shared/contracts"
+
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/fx"
+)
+
+// BackOfficeRouteParams is used by the back-office endpoints, their group authenticates the requests
+type BackOfficeRouteParams struct {
+	fx.In
+
+	OrdersMetrics            *contracts.OrdersMetrics
+	Logger                   logger.Logger
+	BackOfficeGroup          *echo.Group `name:"backoffice-order-echo-group"`
+	BackOfficeCustomersGroup *echo.Group `name:"backoffice-customer-echo-group"`
+	BackOfficeGiftCardsGroup *echo.Group `name:"backoffice-giftcard-echo-group"`
+	// BackOfficeProjectionsGroup is the admin api of the read model projections
+	BackOfficeProjectionsGroup *echo.Group `name:"backoffice-projection-echo-group"`
+	// BackOfficeFinanceGroup is the admin api of the finance export
+	BackOfficeFinanceGroup *echo.Group `name:"backoffice-finance-echo-group"`
+	Validator              *validator.Validate
+}
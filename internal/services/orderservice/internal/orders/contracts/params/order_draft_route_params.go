@@ -0,0 +1,17 @@
+package params
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/fx"
+)
+
+type OrderDraftRouteParams struct {
+	fx.In
+
+	Logger           logger.Logger
+	OrderDraftsGroup *echo.Group `name:"order-draft-echo-group"`
+	Validator        *validator.Validate
+}
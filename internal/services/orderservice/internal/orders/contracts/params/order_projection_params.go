@@ -2,6 +2,9 @@ package params
 
 import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/projection"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/featuretoggle"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
 
 	"go.uber.org/fx"
 )
@@ -9,5 +12,8 @@ import (
 type OrderProjectionParams struct {
 	fx.In
 
-	Projections []projection.IProjection `group:"projections"`
+	Projections       []projection.IProjection `group:"projections"`
+	FeatureToggles    featuretoggle.FeatureToggles
+	ProjectionManager eventstroredb.ProjectionManager
+	Logger            logger.Logger
 }
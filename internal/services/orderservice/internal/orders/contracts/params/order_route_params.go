@@ -1,6 +1,7 @@
 package params
 
 import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/commandbus"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/shared/contracts"
 
@@ -16,4 +17,5 @@ type OrderRouteParams struct {
 	Logger        logger.Logger
 	OrdersGroup   *echo.Group `name:"order-echo-group"`
 	Validator     *validator.Validate
+	CommandBus    commandbus.CommandBus
 }
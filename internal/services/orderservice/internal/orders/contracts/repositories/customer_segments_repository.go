@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/segments/read_models"
+)
+
+type CustomerSegmentsRepository interface {
+	// GetCustomerById returns nil for an unknown customer
+	GetCustomerById(ctx context.Context, customerId string) (*read_models.CustomerSegmentsReadModel, error)
+	// GetCustomerByOrderId returns nil when the order doesn't belong to any customer
+	GetCustomerByOrderId(ctx context.Context, orderId string) (*read_models.CustomerSegmentsReadModel, error)
+	GetCustomersBySegment(
+		ctx context.Context,
+		segment string,
+		listQuery *utils.ListQuery,
+	) (*utils.ListResult[*read_models.CustomerSegmentsReadModel], error)
+	SaveCustomer(ctx context.Context, customer *read_models.CustomerSegmentsReadModel) error
+	// DeleteAllCustomers deletes the customer segments for a rebuild of the read model, the indexes are kept
+	DeleteAllCustomers(ctx context.Context) error
+}
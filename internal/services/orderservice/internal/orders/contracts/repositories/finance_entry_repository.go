@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/finance/read_models"
+)
+
+// FinanceEntriesReplayFilter selects the finance entries to export again, the empty fields are not filtered
+type FinanceEntriesReplayFilter struct {
+	BatchId string
+	Status  string
+	From    time.Time
+	To      time.Time
+}
+
+type FinanceEntryRepository interface {
+	// AddFinanceEntry adds the entry when there is no entry with its id, so projecting an event again is a no-op
+	AddFinanceEntry(ctx context.Context, entry *read_models.FinanceEntryReadModel) error
+	// GetPendingFinanceEntries returns the oldest pending entries to export
+	GetPendingFinanceEntries(ctx context.Context, limit int) ([]*read_models.FinanceEntryReadModel, error)
+	GetFinanceEntries(
+		ctx context.Context,
+		status string,
+		listQuery *utils.ListQuery,
+	) (*utils.ListResult[*read_models.FinanceEntryReadModel], error)
+	MarkFinanceEntriesExported(
+		ctx context.Context,
+		ids []string,
+		batchId string,
+		location string,
+		exportedAt time.Time,
+	) error
+	// MarkFinanceEntriesFailed records the failed attempt of the entries, the entries are failed after the max attempts
+	// and they stay pending before it
+	MarkFinanceEntriesFailed(ctx context.Context, ids []string, batchId string, reason string, maxAttempts int) error
+	// ReplayFinanceEntries makes the entries of the filter pending again and returns the count of the replayed entries
+	ReplayFinanceEntries(ctx context.Context, filter *FinanceEntriesReplayFilter) (int64, error)
+}
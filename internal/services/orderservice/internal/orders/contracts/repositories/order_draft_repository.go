@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/drafts/read_models"
+)
+
+type OrderDraftRepository interface {
+	// GetOrderDraftById returns nil when the draft doesn't exist
+	GetOrderDraftById(ctx context.Context, id string) (*read_models.OrderDraftReadModel, error)
+	SaveOrderDraft(ctx context.Context, draft *read_models.OrderDraftReadModel) error
+	// DeleteAllOrderDrafts deletes the order drafts for a rebuild of the read model, the indexes are kept
+	DeleteAllOrderDrafts(ctx context.Context) error
+}
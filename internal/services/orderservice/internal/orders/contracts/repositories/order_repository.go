@@ -21,6 +21,8 @@ type orderReadRepository interface {
 	) (*utils.ListResult[*read_models.OrderReadModel], error)
 	GetOrderById(ctx context.Context, uuid uuid.UUID) (*read_models.OrderReadModel, error)
 	GetOrderByOrderId(ctx context.Context, orderId uuid.UUID) (*read_models.OrderReadModel, error)
+	// GetOrdersByIds returns the orders which their id or their orderId is one of the ids, in a single round trip
+	GetOrdersByIds(ctx context.Context, ids []uuid.UUID) ([]*read_models.OrderReadModel, error)
 	CreateOrder(
 		ctx context.Context,
 		order *read_models.OrderReadModel,
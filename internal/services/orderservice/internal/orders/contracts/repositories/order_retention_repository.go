@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/read_models"
+)
+
+// OrderRetentionRepository finds the orders of the retention worker in the orders read model
+type OrderRetentionRepository interface {
+	// GetOrdersToArchive returns the finalized orders created before the time which are not archived yet
+	GetOrdersToArchive(
+		ctx context.Context,
+		createdBefore time.Time,
+		limit int,
+	) ([]*read_models.OrderReadModel, error)
+	// GetOrdersToPurge returns the archived orders created before the time which are not purged and not under legal hold
+	GetOrdersToPurge(ctx context.Context, createdBefore time.Time, limit int) ([]*read_models.OrderReadModel, error)
+}
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/pricelists/read_models"
+)
+
+type PriceListRepository interface {
+	// GetEffectivePriceLists returns the price lists of the customer group which are effective at the time
+	GetEffectivePriceLists(
+		ctx context.Context,
+		customerGroup string,
+		at time.Time,
+	) ([]*read_models.PriceListReadModel, error)
+	SavePriceList(ctx context.Context, priceList *read_models.PriceListReadModel) error
+}
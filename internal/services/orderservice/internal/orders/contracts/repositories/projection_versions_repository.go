@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/projections/read_models"
+)
+
+type ProjectionVersionsRepository interface {
+	// GetProjectionVersions returns nil when the versions of the projection are not saved yet
+	GetProjectionVersions(ctx context.Context, projection string) (*read_models.ProjectionVersionsReadModel, error)
+	// SaveProjectionVersions saves the versions if their revision is not changed since they are loaded, otherwise a
+	// conflict error is returned
+	SaveProjectionVersions(ctx context.Context, versions *read_models.ProjectionVersionsReadModel) error
+	// DropCollection drops the collection of an aborted or a retired projection version
+	DropCollection(ctx context.Context, collection string) error
+}
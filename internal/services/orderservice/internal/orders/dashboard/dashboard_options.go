@@ -0,0 +1,37 @@
+package dashboard
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[DashboardOptions]())
+
+type DashboardOptions struct {
+	// WindowMinutes is the sliding window of the metrics of the dashboard
+	WindowMinutes int `mapstructure:"windowMinutes"      default:"60"`
+	// TopProducts is the number of the most ordered products of the window
+	TopProducts int `mapstructure:"topProducts"        default:"10"`
+	// PushIntervalMillis throttles the pushes of the changes to a stream of the dashboard
+	PushIntervalMillis int `mapstructure:"pushIntervalMillis" default:"1000"`
+	// RefreshSeconds pushes the dashboard without any change, so the rates of the window decay on the clients and the
+	// idle streams are kept open through the proxies
+	RefreshSeconds int `mapstructure:"refreshSeconds"     default:"5"`
+}
+
+func NewDashboardOptions(environment environment.Environment) (*DashboardOptions, error) {
+	return config.BindConfigKey[*DashboardOptions](optionName, environment)
+}
+
+func (o *DashboardOptions) PushInterval() time.Duration {
+	return time.Duration(o.PushIntervalMillis) * time.Millisecond
+}
+
+func (o *DashboardOptions) RefreshInterval() time.Duration {
+	return time.Duration(o.RefreshSeconds) * time.Second
+}
@@ -0,0 +1,227 @@
+package dashboard
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/changefeed"
+	dtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/dtos/v1"
+)
+
+// OperationsDashboard aggregates the order rates and the ordered quantities of the products in a sliding window of
+// minutes for the live operations dashboard of the back office. it is fed by the order events of the $all subscription
+// of the current instance, so after a restart the window is refilled only by the events after the subscription
+// checkpoint.
+type OperationsDashboard struct {
+	options  *DashboardOptions
+	notifier changefeed.ChangeNotifier
+	now      func() time.Time
+
+	mu          sync.Mutex
+	minutes     map[int64]*minuteMetrics
+	lastEventAt time.Time
+}
+
+type minuteMetrics struct {
+	created  int64
+	paid     int64
+	canceled int64
+	products map[string]*ProductDemand
+}
+
+// Snapshot is the state of the dashboard at a time
+type Snapshot struct {
+	At              time.Time
+	WindowMinutes   int
+	OrdersCreated   int64
+	OrdersPaid      int64
+	OrdersCanceled  int64
+	OrdersPerMinute float64
+	// Minutes are the metrics of each minute of the window, the oldest first
+	Minutes     []*MinuteSnapshot
+	TopProducts []*ProductDemand
+	LastEventAt time.Time
+}
+
+type MinuteSnapshot struct {
+	Minute   time.Time
+	Created  int64
+	Paid     int64
+	Canceled int64
+}
+
+// ProductDemand is the ordered quantity of a product in the window
+type ProductDemand struct {
+	ProductId string
+	Title     string
+	Quantity  uint64
+	Orders    int64
+}
+
+func NewOperationsDashboard(options *DashboardOptions) *OperationsDashboard {
+	return &OperationsDashboard{
+		options:  options,
+		notifier: changefeed.NewChangeNotifier(),
+		now:      time.Now,
+		minutes:  make(map[int64]*minuteMetrics),
+	}
+}
+
+// Changed returns a channel which is closed on the next change of the dashboard
+func (d *OperationsDashboard) Changed() <-chan struct{} {
+	return d.notifier.Changed()
+}
+
+func (d *OperationsDashboard) OrderCreated(at time.Time, items []*dtosV1.ShopItemDto) {
+	d.record(at, func(metrics *minuteMetrics) {
+		metrics.created++
+
+		for _, item := range items {
+			if item == nil {
+				continue
+			}
+
+			// the items without a product id are grouped by their title
+			key := item.ProductId
+			if key == "" {
+				key = item.Title
+			}
+
+			demand, ok := metrics.products[key]
+			if !ok {
+				demand = &ProductDemand{ProductId: item.ProductId, Title: item.Title}
+				metrics.products[key] = demand
+			}
+			demand.Quantity += item.Quantity
+			demand.Orders++
+		}
+	})
+}
+
+func (d *OperationsDashboard) OrderPaid(at time.Time) {
+	d.record(at, func(metrics *minuteMetrics) {
+		metrics.paid++
+	})
+}
+
+func (d *OperationsDashboard) OrderCanceled(at time.Time) {
+	d.record(at, func(metrics *minuteMetrics) {
+		metrics.canceled++
+	})
+}
+
+func (d *OperationsDashboard) record(at time.Time, update func(metrics *minuteMetrics)) {
+	now := d.now()
+	if at.IsZero() || at.After(now) {
+		at = now
+	}
+
+	minute := at.Truncate(time.Minute).Unix()
+	oldest := d.oldestMinute(now)
+
+	d.mu.Lock()
+	// the events before the window, e.g. the events of the catch up of the subscription, are not in the dashboard
+	if minute < oldest {
+		d.mu.Unlock()
+
+		return
+	}
+
+	metrics, ok := d.minutes[minute]
+	if !ok {
+		metrics = &minuteMetrics{products: make(map[string]*ProductDemand)}
+		d.minutes[minute] = metrics
+	}
+	update(metrics)
+
+	if at.After(d.lastEventAt) {
+		d.lastEventAt = at
+	}
+	d.prune(oldest)
+	d.mu.Unlock()
+
+	d.notifier.Notify()
+}
+
+func (d *OperationsDashboard) Snapshot() *Snapshot {
+	now := d.now()
+	oldest := d.oldestMinute(now)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.prune(oldest)
+
+	snapshot := &Snapshot{
+		At:            now,
+		WindowMinutes: d.options.WindowMinutes,
+		Minutes:       make([]*MinuteSnapshot, 0, d.options.WindowMinutes),
+		TopProducts:   make([]*ProductDemand, 0),
+		LastEventAt:   d.lastEventAt,
+	}
+	products := make(map[string]*ProductDemand)
+
+	for minute := oldest; minute <= now.Truncate(time.Minute).Unix(); minute += 60 {
+		minuteSnapshot := &MinuteSnapshot{Minute: time.Unix(minute, 0).UTC()}
+		snapshot.Minutes = append(snapshot.Minutes, minuteSnapshot)
+
+		metrics, ok := d.minutes[minute]
+		if !ok {
+			continue
+		}
+
+		minuteSnapshot.Created = metrics.created
+		minuteSnapshot.Paid = metrics.paid
+		minuteSnapshot.Canceled = metrics.canceled
+		snapshot.OrdersCreated += metrics.created
+		snapshot.OrdersPaid += metrics.paid
+		snapshot.OrdersCanceled += metrics.canceled
+
+		for key, demand := range metrics.products {
+			total, ok := products[key]
+			if !ok {
+				total = &ProductDemand{ProductId: demand.ProductId, Title: demand.Title}
+				products[key] = total
+				snapshot.TopProducts = append(snapshot.TopProducts, total)
+			}
+			total.Quantity += demand.Quantity
+			total.Orders += demand.Orders
+		}
+	}
+
+	if d.options.WindowMinutes > 0 {
+		snapshot.OrdersPerMinute = float64(snapshot.OrdersCreated) / float64(d.options.WindowMinutes)
+	}
+
+	sort.Slice(snapshot.TopProducts, func(i, j int) bool {
+		if snapshot.TopProducts[i].Quantity != snapshot.TopProducts[j].Quantity {
+			return snapshot.TopProducts[i].Quantity > snapshot.TopProducts[j].Quantity
+		}
+
+		return snapshot.TopProducts[i].Title < snapshot.TopProducts[j].Title
+	})
+	if len(snapshot.TopProducts) > d.options.TopProducts {
+		snapshot.TopProducts = snapshot.TopProducts[:d.options.TopProducts]
+	}
+
+	return snapshot
+}
+
+// oldestMinute is the first minute of the window, the current minute is the last minute of the window
+func (d *OperationsDashboard) oldestMinute(now time.Time) int64 {
+	window := d.options.WindowMinutes
+	if window < 1 {
+		window = 1
+	}
+
+	return now.Truncate(time.Minute).Add(-time.Duration(window-1) * time.Minute).Unix()
+}
+
+func (d *OperationsDashboard) prune(oldest int64) {
+	for minute := range d.minutes {
+		if minute < oldest {
+			delete(d.minutes, minute)
+		}
+	}
+}
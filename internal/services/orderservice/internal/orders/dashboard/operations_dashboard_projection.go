@@ -0,0 +1,34 @@
+package dashboard
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/projection"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models"
+	cancelOrderDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/canceling_order/v1/events/domain_events"
+	createOrderDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/creating_order/v1/events/domain_events"
+	fulfillOrderDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/fulfilling_order/v1/events/domain_events"
+)
+
+// operationsDashboardProjection feeds the operations dashboard with the order events, the dashboard is in memory, so
+// it has no read model to rebuild
+type operationsDashboardProjection struct {
+	dashboard *OperationsDashboard
+}
+
+func NewOperationsDashboardProjection(dashboard *OperationsDashboard) projection.IProjection {
+	return &operationsDashboardProjection{dashboard: dashboard}
+}
+
+func (o *operationsDashboardProjection) ProcessEvent(ctx context.Context, streamEvent *models.StreamEvent) error {
+	switch evt := streamEvent.Event.(type) {
+	case *createOrderDomainEventsV1.OrderCreatedV1:
+		o.dashboard.OrderCreated(evt.CreatedAt, evt.ShopItems)
+	case *fulfillOrderDomainEventsV1.OrderPaidV1:
+		o.dashboard.OrderPaid(evt.PaidAt)
+	case *cancelOrderDomainEventsV1.OrderCanceledV1:
+		o.dashboard.OrderCanceled(evt.CanceledAt)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package dashboard
+
+import (
+	"testing"
+	"time"
+
+	dtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/dtos/v1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDashboard(now *time.Time) *OperationsDashboard {
+	dashboard := NewOperationsDashboard(&DashboardOptions{WindowMinutes: 3, TopProducts: 2})
+	dashboard.now = func() time.Time {
+		return *now
+	}
+
+	return dashboard
+}
+
+func Test_Operations_Dashboard_Aggregates_The_Window(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 30, 20, 0, time.UTC)
+	dashboard := newTestDashboard(&now)
+
+	dashboard.OrderCreated(now.Add(-2*time.Minute), []*dtosV1.ShopItemDto{
+		{ProductId: "p1", Title: "Phone", Quantity: 2},
+		{Title: "Gift wrap", Quantity: 1},
+	})
+	dashboard.OrderCreated(now, []*dtosV1.ShopItemDto{{ProductId: "p2", Title: "Case", Quantity: 1}})
+	dashboard.OrderCreated(now, []*dtosV1.ShopItemDto{{ProductId: "p1", Title: "Phone", Quantity: 3}})
+	dashboard.OrderPaid(now)
+	dashboard.OrderCanceled(time.Time{})
+	// before the window
+	dashboard.OrderCreated(now.Add(-3*time.Minute), []*dtosV1.ShopItemDto{{ProductId: "p3", Quantity: 100}})
+
+	snapshot := dashboard.Snapshot()
+	assert.Equal(t, int64(3), snapshot.OrdersCreated)
+	assert.Equal(t, int64(1), snapshot.OrdersPaid)
+	assert.Equal(t, int64(1), snapshot.OrdersCanceled)
+	assert.Equal(t, float64(1), snapshot.OrdersPerMinute)
+	assert.Equal(t, now, snapshot.LastEventAt)
+
+	require.Len(t, snapshot.Minutes, 3)
+	assert.Equal(t, time.Date(2024, 5, 1, 10, 28, 0, 0, time.UTC), snapshot.Minutes[0].Minute)
+	assert.Equal(t, int64(1), snapshot.Minutes[0].Created)
+	assert.Equal(t, int64(0), snapshot.Minutes[1].Created)
+	assert.Equal(t, int64(2), snapshot.Minutes[2].Created)
+
+	require.Len(t, snapshot.TopProducts, 2)
+	assert.Equal(t, &ProductDemand{ProductId: "p1", Title: "Phone", Quantity: 5, Orders: 2}, snapshot.TopProducts[0])
+	assert.Equal(t, &ProductDemand{ProductId: "p2", Title: "Case", Quantity: 1, Orders: 1}, snapshot.TopProducts[1])
+}
+
+func Test_Operations_Dashboard_Slides_The_Window(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+	dashboard := newTestDashboard(&now)
+
+	changed := dashboard.Changed()
+	dashboard.OrderCreated(now, []*dtosV1.ShopItemDto{{ProductId: "p1", Title: "Phone", Quantity: 1}})
+
+	select {
+	case <-changed:
+	default:
+		t.Fatal("the change of the dashboard is not notified")
+	}
+
+	now = now.Add(3 * time.Minute)
+
+	snapshot := dashboard.Snapshot()
+	assert.Equal(t, int64(0), snapshot.OrdersCreated)
+	assert.Empty(t, snapshot.TopProducts)
+	assert.Len(t, snapshot.Minutes, 3)
+	assert.Empty(t, dashboard.minutes)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/repositories"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/read_models"
 
+	"emperror.dev/errors"
 	"github.com/elastic/go-elasticsearch/v8"
 	uuid "github.com/satori/go.uuid"
 )
@@ -60,6 +61,14 @@ func (e elasticOrderReadRepository) GetOrderByOrderId(
 	panic("implement me")
 }
 
+func (e elasticOrderReadRepository) GetOrdersByIds(
+	ctx context.Context,
+	ids []uuid.UUID,
+) ([]*read_models.OrderReadModel, error) {
+	// the orders are read by their ids from the mongo read model
+	return nil, errors.New("[elasticOrderReadRepository_GetOrdersByIds] getting orders by ids is not supported")
+}
+
 func (e elasticOrderReadRepository) CreateOrder(
 	ctx context.Context,
 	order *read_models.OrderReadModel,
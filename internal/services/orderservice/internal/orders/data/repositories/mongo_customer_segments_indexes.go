@@ -0,0 +1,51 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mongodb"
+
+	"emperror.dev/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/fx"
+)
+
+// customerSegmentsIndexes back the customers of a segment query and finding the customer of a canceled order
+var customerSegmentsIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "segments", Value: 1}},
+		Options: options.Index().SetName("segments"),
+	},
+	{
+		Keys:    bson.D{{Key: "orders.orderId", Value: 1}},
+		Options: options.Index().SetName("orders_order_id"),
+	},
+}
+
+// RegisterMongoCustomerSegmentsIndexes creates the indexes of the customer segments collection on application start,
+// creating an existing index is a no-op
+func RegisterMongoCustomerSegmentsIndexes(
+	lc fx.Lifecycle,
+	db *mongo.Client,
+	mongoOptions *mongodb.MongoDbOptions,
+	log logger.Logger,
+) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			_, err := db.Database(mongoOptions.Database).
+				Collection(customerSegmentsCollection).
+				Indexes().
+				CreateMany(ctx, customerSegmentsIndexes)
+			if err != nil {
+				return errors.WrapIf(err, "error in creating customer segments indexes")
+			}
+
+			log.Info("customer segments indexes created")
+
+			return nil
+		},
+	})
+}
@@ -0,0 +1,181 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mongodb"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	utils2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/repositories"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/segments/read_models"
+
+	"emperror.dev/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	attribute2 "go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	customerSegmentsCollection = "customer_segments"
+)
+
+type mongoCustomerSegmentsRepository struct {
+	log          logger.Logger
+	mongoOptions *mongodb.MongoDbOptions
+	mongoClient  *mongo.Client
+	tracer       tracing.AppTracer
+}
+
+func NewMongoCustomerSegmentsRepository(
+	log logger.Logger,
+	cfg *mongodb.MongoDbOptions,
+	mongoClient *mongo.Client,
+	tracer tracing.AppTracer,
+) repositories.CustomerSegmentsRepository {
+	return &mongoCustomerSegmentsRepository{
+		log:          log,
+		mongoOptions: cfg,
+		mongoClient:  mongoClient,
+		tracer:       tracer,
+	}
+}
+
+func (m *mongoCustomerSegmentsRepository) GetCustomerById(
+	ctx context.Context,
+	customerId string,
+) (*read_models.CustomerSegmentsReadModel, error) {
+	ctx, span := m.tracer.Start(ctx, "mongoCustomerSegmentsRepository.GetCustomerById")
+	span.SetAttributes(attribute2.String("CustomerId", customerId))
+	defer span.End()
+
+	return m.findOne(ctx, bson.M{"_id": customerId})
+}
+
+func (m *mongoCustomerSegmentsRepository) GetCustomerByOrderId(
+	ctx context.Context,
+	orderId string,
+) (*read_models.CustomerSegmentsReadModel, error) {
+	ctx, span := m.tracer.Start(ctx, "mongoCustomerSegmentsRepository.GetCustomerByOrderId")
+	span.SetAttributes(attribute2.String("OrderId", orderId))
+	defer span.End()
+
+	return m.findOne(ctx, bson.M{"orders.orderId": orderId})
+}
+
+func (m *mongoCustomerSegmentsRepository) GetCustomersBySegment(
+	ctx context.Context,
+	segment string,
+	listQuery *utils.ListQuery,
+) (*utils.ListResult[*read_models.CustomerSegmentsReadModel], error) {
+	ctx, span := m.tracer.Start(ctx, "mongoCustomerSegmentsRepository.GetCustomersBySegment")
+	span.SetAttributes(attribute2.String("Segment", segment))
+	defer span.End()
+
+	collection := m.mongoOptions.ListCollection(m.mongoClient, customerSegmentsCollection)
+
+	result, err := mongodb.Paginate[*read_models.CustomerSegmentsReadModel](
+		ctx,
+		listQuery,
+		collection,
+		bson.M{"segments": segment},
+	)
+	if err != nil {
+		return nil, utils2.TraceStatusFromContext(
+			ctx,
+			errors.WrapIf(
+				err,
+				"[mongoCustomerSegmentsRepository_GetCustomersBySegment.Paginate] error in the paginate",
+			),
+		)
+	}
+
+	m.log.Infow(
+		fmt.Sprintf("[mongoCustomerSegmentsRepository.GetCustomersBySegment] customers of segment '%s' loaded", segment),
+		logger.Fields{"Segment": segment, "TotalItems": result.TotalItems},
+	)
+
+	return result, nil
+}
+
+func (m *mongoCustomerSegmentsRepository) SaveCustomer(
+	ctx context.Context,
+	customer *read_models.CustomerSegmentsReadModel,
+) error {
+	ctx, span := m.tracer.Start(ctx, "mongoCustomerSegmentsRepository.SaveCustomer")
+	span.SetAttributes(attribute2.String("CustomerId", customer.Id))
+	defer span.End()
+
+	collection := m.mongoOptions.Collection(m.mongoClient, customerSegmentsCollection)
+
+	_, err := collection.ReplaceOne(ctx, bson.M{"_id": customer.Id}, customer, options.Replace().SetUpsert(true))
+	if err != nil {
+		return utils2.TraceStatusFromContext(
+			ctx,
+			errors.WrapIf(
+				err,
+				fmt.Sprintf(
+					"[mongoCustomerSegmentsRepository_SaveCustomer.ReplaceOne] error in saving customer with id %s into the database.",
+					customer.Id,
+				),
+			),
+		)
+	}
+
+	m.log.Infow(
+		fmt.Sprintf("[mongoCustomerSegmentsRepository.SaveCustomer] customer with id '%s' saved", customer.Id),
+		logger.Fields{"Id": customer.Id, "Segments": customer.Segments},
+	)
+
+	return nil
+}
+
+func (m *mongoCustomerSegmentsRepository) DeleteAllCustomers(ctx context.Context) error {
+	ctx, span := m.tracer.Start(ctx, "mongoCustomerSegmentsRepository.DeleteAllCustomers")
+	defer span.End()
+
+	collection := m.mongoOptions.Collection(m.mongoClient, customerSegmentsCollection)
+
+	result, err := collection.DeleteMany(ctx, bson.M{})
+	if err != nil {
+		return utils2.TraceStatusFromContext(
+			ctx,
+			errors.WrapIf(
+				err,
+				"[mongoCustomerSegmentsRepository_DeleteAllCustomers.DeleteMany] error in deleting customer segments from the database.",
+			),
+		)
+	}
+
+	m.log.Infow(
+		"[mongoCustomerSegmentsRepository.DeleteAllCustomers] customer segments deleted",
+		logger.Fields{"DeletedCount": result.DeletedCount},
+	)
+
+	return nil
+}
+
+func (m *mongoCustomerSegmentsRepository) findOne(
+	ctx context.Context,
+	filter bson.M,
+) (*read_models.CustomerSegmentsReadModel, error) {
+	collection := m.mongoOptions.Collection(m.mongoClient, customerSegmentsCollection)
+
+	var customer read_models.CustomerSegmentsReadModel
+	if err := collection.FindOne(ctx, filter).Decode(&customer); err != nil {
+		// ErrNoDocuments means that the filter did not match any documents in the collection
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+
+		return nil, utils2.TraceStatusFromContext(
+			ctx,
+			errors.WrapIf(err, "[mongoCustomerSegmentsRepository_findOne.FindOne] can't find the customer into the database."),
+		)
+	}
+
+	return &customer, nil
+}
@@ -0,0 +1,51 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mongodb"
+
+	"emperror.dev/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/fx"
+)
+
+// financeEntriesIndexes serve the pending entries of the export worker and the replays of the export batches
+var financeEntriesIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "status", Value: 1}, {Key: "occurredAt", Value: 1}},
+		Options: options.Index().SetName("status_occurred_at"),
+	},
+	{
+		Keys:    bson.D{{Key: "batchId", Value: 1}},
+		Options: options.Index().SetName("batch_id").SetSparse(true),
+	},
+}
+
+// RegisterMongoFinanceEntriesIndexes creates the indexes of the finance entries collection on application start,
+// creating an existing index is a no-op
+func RegisterMongoFinanceEntriesIndexes(
+	lc fx.Lifecycle,
+	db *mongo.Client,
+	mongoOptions *mongodb.MongoDbOptions,
+	log logger.Logger,
+) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			_, err := db.Database(mongoOptions.Database).
+				Collection(financeEntriesCollection).
+				Indexes().
+				CreateMany(ctx, financeEntriesIndexes)
+			if err != nil {
+				return errors.WrapIf(err, "error in creating finance entries indexes")
+			}
+
+			log.Info("finance entries indexes created")
+
+			return nil
+		},
+	})
+}
@@ -0,0 +1,294 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mongodb"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	utils2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/repositories"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/finance/read_models"
+
+	"emperror.dev/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	attribute2 "go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	financeEntriesCollection = "finance_entries"
+)
+
+type mongoFinanceEntryRepository struct {
+	log          logger.Logger
+	mongoOptions *mongodb.MongoDbOptions
+	mongoClient  *mongo.Client
+	tracer       tracing.AppTracer
+}
+
+func NewMongoFinanceEntryRepository(
+	log logger.Logger,
+	cfg *mongodb.MongoDbOptions,
+	mongoClient *mongo.Client,
+	tracer tracing.AppTracer,
+) repositories.FinanceEntryRepository {
+	return &mongoFinanceEntryRepository{
+		log:          log,
+		mongoOptions: cfg,
+		mongoClient:  mongoClient,
+		tracer:       tracer,
+	}
+}
+
+func (m *mongoFinanceEntryRepository) AddFinanceEntry(
+	ctx context.Context,
+	entry *read_models.FinanceEntryReadModel,
+) error {
+	ctx, span := m.tracer.Start(ctx, "mongoFinanceEntryRepository.AddFinanceEntry")
+	span.SetAttributes(attribute2.String("Id", entry.Id))
+	defer span.End()
+
+	collection := m.mongoOptions.Collection(m.mongoClient, financeEntriesCollection)
+
+	_, err := collection.InsertOne(ctx, entry)
+	if mongo.IsDuplicateKeyError(err) {
+		// the event is projected again, the existing entry keeps its delivery state
+		return nil
+	}
+	if err != nil {
+		return utils2.TraceStatusFromContext(
+			ctx,
+			errors.WrapIf(
+				err,
+				fmt.Sprintf(
+					"[mongoFinanceEntryRepository_AddFinanceEntry.InsertOne] error in adding finance entry with id %s into the database.",
+					entry.Id,
+				),
+			),
+		)
+	}
+
+	m.log.Infow(
+		fmt.Sprintf("[mongoFinanceEntryRepository.AddFinanceEntry] finance entry with id '%s' added", entry.Id),
+		logger.Fields{"Id": entry.Id, "Type": entry.Type, "OrderId": entry.OrderId},
+	)
+
+	return nil
+}
+
+func (m *mongoFinanceEntryRepository) GetPendingFinanceEntries(
+	ctx context.Context,
+	limit int,
+) ([]*read_models.FinanceEntryReadModel, error) {
+	ctx, span := m.tracer.Start(ctx, "mongoFinanceEntryRepository.GetPendingFinanceEntries")
+	defer span.End()
+
+	collection := m.mongoOptions.Collection(m.mongoClient, financeEntriesCollection)
+
+	ops := options.Find().SetSort(bson.D{{Key: "occurredAt", Value: 1}}).SetLimit(int64(limit))
+
+	cursor, err := collection.Find(ctx, bson.M{"status": read_models.FinanceEntryStatusPending}, ops)
+	if err != nil {
+		return nil, utils2.TraceStatusFromContext(
+			ctx,
+			errors.WrapIf(err, "[mongoFinanceEntryRepository_GetPendingFinanceEntries.Find] error in finding entries"),
+		)
+	}
+
+	var entries []*read_models.FinanceEntryReadModel
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, utils2.TraceStatusFromContext(
+			ctx,
+			errors.WrapIf(err, "[mongoFinanceEntryRepository_GetPendingFinanceEntries.All] error in decoding entries"),
+		)
+	}
+
+	return entries, nil
+}
+
+func (m *mongoFinanceEntryRepository) GetFinanceEntries(
+	ctx context.Context,
+	status string,
+	listQuery *utils.ListQuery,
+) (*utils.ListResult[*read_models.FinanceEntryReadModel], error) {
+	ctx, span := m.tracer.Start(ctx, "mongoFinanceEntryRepository.GetFinanceEntries")
+	span.SetAttributes(attribute2.String("Status", status))
+	defer span.End()
+
+	collection := m.mongoOptions.ListCollection(m.mongoClient, financeEntriesCollection)
+
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	result, err := mongodb.Paginate[*read_models.FinanceEntryReadModel](
+		ctx,
+		listQuery,
+		collection,
+		filter,
+		bson.E{Key: "occurredAt", Value: -1},
+	)
+	if err != nil {
+		return nil, utils2.TraceStatusFromContext(
+			ctx,
+			errors.WrapIf(err, "[mongoFinanceEntryRepository_GetFinanceEntries.Paginate] error in the paginate"),
+		)
+	}
+
+	m.log.Infow(
+		"[mongoFinanceEntryRepository.GetFinanceEntries] finance entries loaded",
+		logger.Fields{"Status": status, "TotalItems": result.TotalItems},
+	)
+
+	return result, nil
+}
+
+func (m *mongoFinanceEntryRepository) MarkFinanceEntriesExported(
+	ctx context.Context,
+	ids []string,
+	batchId string,
+	location string,
+	exportedAt time.Time,
+) error {
+	ctx, span := m.tracer.Start(ctx, "mongoFinanceEntryRepository.MarkFinanceEntriesExported")
+	span.SetAttributes(attribute2.String("BatchId", batchId))
+	defer span.End()
+
+	collection := m.mongoOptions.Collection(m.mongoClient, financeEntriesCollection)
+
+	_, err := collection.UpdateMany(
+		ctx,
+		bson.M{"_id": bson.M{"$in": ids}},
+		bson.M{
+			"$set": bson.M{
+				"status":     read_models.FinanceEntryStatusExported,
+				"batchId":    batchId,
+				"location":   location,
+				"exportedAt": exportedAt,
+			},
+			"$inc":   bson.M{"attempts": 1},
+			"$unset": bson.M{"lastError": ""},
+		},
+	)
+	if err != nil {
+		return utils2.TraceStatusFromContext(
+			ctx,
+			errors.WrapIf(
+				err,
+				fmt.Sprintf(
+					"[mongoFinanceEntryRepository_MarkFinanceEntriesExported.UpdateMany] error in marking entries of batch %s exported",
+					batchId,
+				),
+			),
+		)
+	}
+
+	m.log.Infow(
+		fmt.Sprintf("[mongoFinanceEntryRepository.MarkFinanceEntriesExported] %d finance entries exported", len(ids)),
+		logger.Fields{"BatchId": batchId, "Location": location},
+	)
+
+	return nil
+}
+
+func (m *mongoFinanceEntryRepository) MarkFinanceEntriesFailed(
+	ctx context.Context,
+	ids []string,
+	batchId string,
+	reason string,
+	maxAttempts int,
+) error {
+	ctx, span := m.tracer.Start(ctx, "mongoFinanceEntryRepository.MarkFinanceEntriesFailed")
+	span.SetAttributes(attribute2.String("BatchId", batchId))
+	defer span.End()
+
+	collection := m.mongoOptions.Collection(m.mongoClient, financeEntriesCollection)
+
+	_, err := collection.UpdateMany(
+		ctx,
+		bson.M{"_id": bson.M{"$in": ids}},
+		bson.M{
+			"$set": bson.M{"batchId": batchId, "lastError": reason},
+			"$inc": bson.M{"attempts": 1},
+		},
+	)
+	if err == nil {
+		_, err = collection.UpdateMany(
+			ctx,
+			bson.M{"_id": bson.M{"$in": ids}, "attempts": bson.M{"$gte": maxAttempts}},
+			bson.M{"$set": bson.M{"status": read_models.FinanceEntryStatusFailed}},
+		)
+	}
+	if err != nil {
+		return utils2.TraceStatusFromContext(
+			ctx,
+			errors.WrapIf(
+				err,
+				fmt.Sprintf(
+					"[mongoFinanceEntryRepository_MarkFinanceEntriesFailed.UpdateMany] error in marking entries of batch %s failed",
+					batchId,
+				),
+			),
+		)
+	}
+
+	return nil
+}
+
+func (m *mongoFinanceEntryRepository) ReplayFinanceEntries(
+	ctx context.Context,
+	filter *repositories.FinanceEntriesReplayFilter,
+) (int64, error) {
+	ctx, span := m.tracer.Start(ctx, "mongoFinanceEntryRepository.ReplayFinanceEntries")
+	span.SetAttributes(attribute2.String("BatchId", filter.BatchId))
+	span.SetAttributes(attribute2.String("Status", filter.Status))
+	defer span.End()
+
+	collection := m.mongoOptions.Collection(m.mongoClient, financeEntriesCollection)
+
+	query := bson.M{}
+	if filter.BatchId != "" {
+		query["batchId"] = filter.BatchId
+	}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+	occurredAt := bson.M{}
+	if !filter.From.IsZero() {
+		occurredAt["$gte"] = filter.From
+	}
+	if !filter.To.IsZero() {
+		occurredAt["$lt"] = filter.To
+	}
+	if len(occurredAt) > 0 {
+		query["occurredAt"] = occurredAt
+	}
+
+	result, err := collection.UpdateMany(
+		ctx,
+		query,
+		bson.M{
+			"$set":   bson.M{"status": read_models.FinanceEntryStatusPending, "attempts": 0},
+			"$unset": bson.M{"lastError": ""},
+		},
+	)
+	if err != nil {
+		return 0, utils2.TraceStatusFromContext(
+			ctx,
+			errors.WrapIf(err, "[mongoFinanceEntryRepository_ReplayFinanceEntries.UpdateMany] error in replaying entries"),
+		)
+	}
+
+	m.log.Infow(
+		fmt.Sprintf("[mongoFinanceEntryRepository.ReplayFinanceEntries] %d finance entries replayed", result.ModifiedCount),
+		logger.Fields{"BatchId": filter.BatchId, "Status": filter.Status, "From": filter.From, "To": filter.To},
+	)
+
+	return result.ModifiedCount, nil
+}
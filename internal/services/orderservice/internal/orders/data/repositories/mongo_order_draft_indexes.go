@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mongodb"
+
+	"emperror.dev/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/fx"
+)
+
+// orderDraftsIndexes remove the drafts at their expiration, the draft streams are kept in the event store
+var orderDraftsIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+		Options: options.Index().SetName("expires_at_ttl").SetExpireAfterSeconds(0),
+	},
+}
+
+// RegisterMongoOrderDraftsIndexes creates the indexes of the order drafts collection on application start, creating
+// an existing index is a no-op
+func RegisterMongoOrderDraftsIndexes(
+	lc fx.Lifecycle,
+	db *mongo.Client,
+	mongoOptions *mongodb.MongoDbOptions,
+	log logger.Logger,
+) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			_, err := db.Database(mongoOptions.Database).
+				Collection(orderDraftsCollection).
+				Indexes().
+				CreateMany(ctx, orderDraftsIndexes)
+			if err != nil {
+				return errors.WrapIf(err, "error in creating order drafts indexes")
+			}
+
+			log.Info("order drafts indexes created")
+
+			return nil
+		},
+	})
+}
@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mongodb"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	utils2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/repositories"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/drafts/read_models"
+
+	"emperror.dev/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	attribute2 "go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	orderDraftsCollection = "order_drafts"
+)
+
+type mongoOrderDraftRepository struct {
+	log          logger.Logger
+	mongoOptions *mongodb.MongoDbOptions
+	mongoClient  *mongo.Client
+	tracer       tracing.AppTracer
+}
+
+func NewMongoOrderDraftRepository(
+	log logger.Logger,
+	cfg *mongodb.MongoDbOptions,
+	mongoClient *mongo.Client,
+	tracer tracing.AppTracer,
+) repositories.OrderDraftRepository {
+	return &mongoOrderDraftRepository{
+		log:          log,
+		mongoOptions: cfg,
+		mongoClient:  mongoClient,
+		tracer:       tracer,
+	}
+}
+
+func (m *mongoOrderDraftRepository) GetOrderDraftById(
+	ctx context.Context,
+	id string,
+) (*read_models.OrderDraftReadModel, error) {
+	ctx, span := m.tracer.Start(ctx, "mongoOrderDraftRepository.GetOrderDraftById")
+	span.SetAttributes(attribute2.String("Id", id))
+	defer span.End()
+
+	collection := m.mongoOptions.Collection(m.mongoClient, orderDraftsCollection)
+
+	var draft read_models.OrderDraftReadModel
+	if err := collection.FindOne(ctx, bson.M{"_id": id}).Decode(&draft); err != nil {
+		// ErrNoDocuments means that the filter did not match any documents in the collection
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+
+		return nil, utils2.TraceStatusFromContext(
+			ctx,
+			errors.WrapIf(
+				err,
+				fmt.Sprintf(
+					"[mongoOrderDraftRepository_GetOrderDraftById.FindOne] can't find the order draft with id %s into the database.",
+					id,
+				),
+			),
+		)
+	}
+
+	return &draft, nil
+}
+
+func (m *mongoOrderDraftRepository) SaveOrderDraft(
+	ctx context.Context,
+	draft *read_models.OrderDraftReadModel,
+) error {
+	ctx, span := m.tracer.Start(ctx, "mongoOrderDraftRepository.SaveOrderDraft")
+	span.SetAttributes(attribute2.String("Id", draft.Id))
+	defer span.End()
+
+	collection := m.mongoOptions.Collection(m.mongoClient, orderDraftsCollection)
+
+	_, err := collection.ReplaceOne(ctx, bson.M{"_id": draft.Id}, draft, options.Replace().SetUpsert(true))
+	if err != nil {
+		return utils2.TraceStatusFromContext(
+			ctx,
+			errors.WrapIf(
+				err,
+				fmt.Sprintf(
+					"[mongoOrderDraftRepository_SaveOrderDraft.ReplaceOne] error in saving order draft with id %s into the database.",
+					draft.Id,
+				),
+			),
+		)
+	}
+
+	m.log.Infow(
+		fmt.Sprintf("[mongoOrderDraftRepository.SaveOrderDraft] order draft with id '%s' saved", draft.Id),
+		logger.Fields{"Id": draft.Id, "Converted": draft.Converted},
+	)
+
+	return nil
+}
+
+func (m *mongoOrderDraftRepository) DeleteAllOrderDrafts(ctx context.Context) error {
+	ctx, span := m.tracer.Start(ctx, "mongoOrderDraftRepository.DeleteAllOrderDrafts")
+	defer span.End()
+
+	collection := m.mongoOptions.Collection(m.mongoClient, orderDraftsCollection)
+
+	result, err := collection.DeleteMany(ctx, bson.M{})
+	if err != nil {
+		return utils2.TraceStatusFromContext(
+			ctx,
+			errors.WrapIf(
+				err,
+				"[mongoOrderDraftRepository_DeleteAllOrderDrafts.DeleteMany] error in deleting order drafts from the database.",
+			),
+		)
+	}
+
+	m.log.Infow(
+		"[mongoOrderDraftRepository.DeleteAllOrderDrafts] order drafts deleted",
+		logger.Fields{"DeletedCount": result.DeletedCount},
+	)
+
+	return nil
+}
@@ -3,7 +3,9 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"regexp"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/versionguard"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mongodb"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
@@ -26,11 +28,33 @@ const (
 	orderCollection = "orders"
 )
 
+// OrderCollectionFunc returns the orders collection which is used by a repository, it is resolved on every operation,
+// so the repositories follow the cutover of the orders projection versions
+type OrderCollectionFunc func() string
+
+// OrderCollection returns the collection of a version of the orders projection, the first version keeps the `orders`
+// collection
+func OrderCollection(version int) string {
+	if version <= 1 {
+		return orderCollection
+	}
+
+	return fmt.Sprintf("%s_v%d", orderCollection, version)
+}
+
+// StaticOrderCollection always uses the given collection
+func StaticOrderCollection(collection string) OrderCollectionFunc {
+	return func() string {
+		return collection
+	}
+}
+
 type mongoOrderReadRepository struct {
 	log          logger.Logger
 	mongoOptions *mongodb.MongoDbOptions
 	mongoClient  *mongo.Client
 	tracer       tracing.AppTracer
+	collection   OrderCollectionFunc
 }
 
 func NewMongoOrderReadRepository(
@@ -38,12 +62,18 @@ func NewMongoOrderReadRepository(
 	cfg *mongodb.MongoDbOptions,
 	mongoClient *mongo.Client,
 	tracer tracing.AppTracer,
+	collection OrderCollectionFunc,
 ) repositories.OrderMongoRepository {
+	if collection == nil {
+		collection = StaticOrderCollection(orderCollection)
+	}
+
 	return &mongoOrderReadRepository{
 		log:          log,
 		mongoOptions: cfg,
 		mongoClient:  mongoClient,
 		tracer:       tracer,
+		collection:   collection,
 	}
 }
 
@@ -54,7 +84,7 @@ func (m mongoOrderReadRepository) GetAllOrders(
 	ctx, span := m.tracer.Start(ctx, "mongoOrderReadRepository.GetAllOrders")
 	defer span.End()
 
-	collection := m.mongoClient.Database(m.mongoOptions.Database).Collection(orderCollection)
+	collection := ordersCollection(m.mongoOptions, m.mongoClient, m.collection(), true)
 
 	result, err := mongodb.Paginate[*read_models.OrderReadModel](ctx, listQuery, collection, nil)
 	if err != nil {
@@ -86,14 +116,17 @@ func (m mongoOrderReadRepository) SearchOrders(
 	span.SetAttributes(attribute2.String("SearchText", searchText))
 	defer span.End()
 
-	collection := m.mongoClient.Database(m.mongoOptions.Database).Collection(orderCollection)
+	collection := ordersCollection(m.mongoOptions, m.mongoClient, m.collection(), true)
 
+	// the search text is matched literally, an empty search text matches all the orders
+	pattern := primitive.Regex{Pattern: regexp.QuoteMeta(searchText), Options: "i"}
 	filter := bson.D{
 		{Key: "$or", Value: bson.A{
-			bson.D{{Key: "name", Value: primitive.Regex{Pattern: searchText, Options: "gi"}}},
-			bson.D{
-				{Key: "description", Value: primitive.Regex{Pattern: searchText, Options: "gi"}},
-			},
+			bson.D{{Key: "orderId", Value: pattern}},
+			bson.D{{Key: "orderNumber", Value: pattern}},
+			bson.D{{Key: "accountEmail", Value: pattern}},
+			bson.D{{Key: "deliveryAddress", Value: pattern}},
+			bson.D{{Key: "shopItems.title", Value: pattern}},
 		}},
 	}
 
@@ -128,7 +161,7 @@ func (m mongoOrderReadRepository) GetOrderById(
 	span.SetAttributes(attribute2.String("Id", id.String()))
 	defer span.End()
 
-	collection := m.mongoClient.Database(m.mongoOptions.Database).Collection(orderCollection)
+	collection := ordersCollection(m.mongoOptions, m.mongoClient, m.collection(), false)
 
 	var order read_models.OrderReadModel
 	if err := collection.FindOne(ctx, bson.M{"_id": id.String()}).Decode(&order); err != nil {
@@ -165,7 +198,7 @@ func (m mongoOrderReadRepository) GetOrderByOrderId(
 	span.SetAttributes(attribute2.String("OrderId", orderId.String()))
 	defer span.End()
 
-	collection := m.mongoClient.Database(m.mongoOptions.Database).Collection(orderCollection)
+	collection := ordersCollection(m.mongoOptions, m.mongoClient, m.collection(), false)
 
 	var order read_models.OrderReadModel
 	if err := collection.FindOne(ctx, bson.M{"orderId": orderId.String()}).Decode(&order); err != nil {
@@ -197,6 +230,55 @@ func (m mongoOrderReadRepository) GetOrderByOrderId(
 	return &order, nil
 }
 
+func (m mongoOrderReadRepository) GetOrdersByIds(
+	ctx context.Context,
+	ids []uuid.UUID,
+) ([]*read_models.OrderReadModel, error) {
+	ctx, span := m.tracer.Start(ctx, "mongoOrderReadRepository.GetOrdersByIds")
+	span.SetAttributes(attribute2.Int("Count", len(ids)))
+	defer span.End()
+
+	collection := ordersCollection(m.mongoOptions, m.mongoClient, m.collection(), true)
+
+	stringIds := make(bson.A, 0, len(ids))
+	for _, id := range ids {
+		stringIds = append(stringIds, id.String())
+	}
+
+	// like GetOrderById, an id matches the order-read id or the order-write id
+	filter := bson.D{
+		{Key: "$or", Value: bson.A{
+			bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: stringIds}}}},
+			bson.D{{Key: "orderId", Value: bson.D{{Key: "$in", Value: stringIds}}}},
+		}},
+	}
+
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return nil, utils2.TraceStatusFromContext(
+			ctx,
+			errors.WrapIf(err, "[mongoOrderReadRepository_GetOrdersByIds.Find] error in finding the orders"),
+		)
+	}
+
+	var orders []*read_models.OrderReadModel
+	if err := cursor.All(ctx, &orders); err != nil {
+		return nil, utils2.TraceStatusFromContext(
+			ctx,
+			errors.WrapIf(err, "[mongoOrderReadRepository_GetOrdersByIds.All] error in decoding the orders"),
+		)
+	}
+
+	m.log.Infow(
+		fmt.Sprintf("[mongoOrderReadRepository.GetOrdersByIds] %d of %d orders loaded", len(orders), len(ids)),
+		logger.Fields{"Count": len(ids)},
+	)
+
+	return orders, nil
+}
+
+// CreateOrder inserts the order only when there is no order with its orderId, a created event which is projected again
+// returns versionguard.StaleEventError instead of inserting a duplicate order
 func (m mongoOrderReadRepository) CreateOrder(
 	ctx context.Context,
 	order *read_models.OrderReadModel,
@@ -204,17 +286,28 @@ func (m mongoOrderReadRepository) CreateOrder(
 	ctx, span := m.tracer.Start(ctx, "mongoOrderReadRepository.CreateOrder")
 	defer span.End()
 
-	collection := m.mongoClient.Database(m.mongoOptions.Database).Collection(orderCollection)
-	_, err := collection.InsertOne(ctx, order, &options.InsertOneOptions{})
+	collection := ordersCollection(m.mongoOptions, m.mongoClient, m.collection(), false)
+	result, err := collection.UpdateOne(
+		ctx,
+		bson.M{"orderId": order.OrderId},
+		bson.M{"$setOnInsert": order},
+		options.Update().SetUpsert(true),
+	)
 	if err != nil {
 		return nil, utils2.TraceStatusFromContext(
 			ctx,
 			errors.WrapIf(
 				err,
-				"[mongoOrderReadRepository_CreateOrder.InsertOne] error in the inserting order into the database.",
+				"[mongoOrderReadRepository_CreateOrder.UpdateOne] error in the inserting order into the database.",
 			),
 		)
 	}
+	if result.UpsertedCount == 0 {
+		return nil, errors.WrapIf(
+			versionguard.StaleEventError,
+			fmt.Sprintf("[mongoOrderReadRepository_CreateOrder.UpdateOne] order with id %s already exists", order.OrderId),
+		)
+	}
 	span.SetAttributes(attribute.Object("Order", order))
 
 	m.log.Infow(
@@ -228,6 +321,8 @@ func (m mongoOrderReadRepository) CreateOrder(
 	return order, nil
 }
 
+// UpdateOrder updates the order only when its version is newer than the stored version, an update which is not newer
+// returns versionguard.StaleEventError, so a duplicate or a stale event doesn't overwrite a newer state of the order
 func (m mongoOrderReadRepository) UpdateOrder(
 	ctx context.Context,
 	order *read_models.OrderReadModel,
@@ -235,14 +330,25 @@ func (m mongoOrderReadRepository) UpdateOrder(
 	ctx, span := m.tracer.Start(ctx, "mongoOrderReadRepository.UpdateOrder")
 	defer span.End()
 
-	collection := m.mongoClient.Database(m.mongoOptions.Database).Collection(orderCollection)
+	collection := ordersCollection(m.mongoOptions, m.mongoClient, m.collection(), false)
 
 	ops := options.FindOneAndUpdate()
 	ops.SetReturnDocument(options.After)
-	ops.SetUpsert(true)
+
+	filter := mongodb.VersionGuardFilter(bson.M{"orderId": order.OrderId}, "version", order.Version)
 
 	var updated read_models.OrderReadModel
-	if err := collection.FindOneAndUpdate(ctx, bson.M{"_id": order.OrderId}, bson.M{"$set": order}, ops).Decode(&updated); err != nil {
+	if err := collection.FindOneAndUpdate(ctx, filter, bson.M{"$set": order}, ops).Decode(&updated); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.WrapIf(
+				versionguard.StaleEventError,
+				fmt.Sprintf(
+					"[mongoOrderReadRepository_UpdateOrder.FindOneAndUpdate] order with id %s has no version before %d",
+					order.OrderId,
+					order.Version,
+				),
+			)
+		}
 		return nil, utils2.TraceStatusFromContext(
 			ctx,
 			errors.WrapIf(
@@ -272,7 +378,7 @@ func (m mongoOrderReadRepository) DeleteOrderByID(ctx context.Context, uuid uuid
 	span.SetAttributes(attribute2.String("Id", uuid.String()))
 	defer span.End()
 
-	collection := m.mongoClient.Database(m.mongoOptions.Database).Collection(orderCollection)
+	collection := ordersCollection(m.mongoOptions, m.mongoClient, m.collection(), false)
 
 	if err := collection.FindOneAndDelete(ctx, bson.M{"_id": uuid.String()}).Err(); err != nil {
 		return utils2.TraceStatusFromContext(ctx, errors.WrapIf(err, fmt.Sprintf(
@@ -288,3 +394,20 @@ func (m mongoOrderReadRepository) DeleteOrderByID(ctx context.Context, uuid uuid
 
 	return nil
 }
+
+// ordersCollection returns a version of the orders collection, all the versions share the configured options of the
+// `orders` collection
+func ordersCollection(
+	mongoOptions *mongodb.MongoDbOptions,
+	mongoClient *mongo.Client,
+	collection string,
+	forList bool,
+) *mongo.Collection {
+	return mongodb.NewCollection(
+		mongoClient,
+		mongoOptions.Database,
+		collection,
+		mongoOptions.CollectionOptions(orderCollection),
+		forList,
+	)
+}
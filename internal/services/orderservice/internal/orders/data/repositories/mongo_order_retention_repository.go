@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mongodb"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	utils2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/repositories"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/read_models"
+
+	"emperror.dev/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	attribute2 "go.opentelemetry.io/otel/attribute"
+)
+
+type mongoOrderRetentionRepository struct {
+	log          logger.Logger
+	mongoOptions *mongodb.MongoDbOptions
+	mongoClient  *mongo.Client
+	tracer       tracing.AppTracer
+	collection   OrderCollectionFunc
+}
+
+func NewMongoOrderRetentionRepository(
+	log logger.Logger,
+	cfg *mongodb.MongoDbOptions,
+	mongoClient *mongo.Client,
+	tracer tracing.AppTracer,
+	collection OrderCollectionFunc,
+) repositories.OrderRetentionRepository {
+	if collection == nil {
+		collection = StaticOrderCollection(orderCollection)
+	}
+
+	return &mongoOrderRetentionRepository{
+		log:          log,
+		mongoOptions: cfg,
+		mongoClient:  mongoClient,
+		tracer:       tracer,
+		collection:   collection,
+	}
+}
+
+func (m *mongoOrderRetentionRepository) GetOrdersToArchive(
+	ctx context.Context,
+	createdBefore time.Time,
+	limit int,
+) ([]*read_models.OrderReadModel, error) {
+	ctx, span := m.tracer.Start(ctx, "mongoOrderRetentionRepository.GetOrdersToArchive")
+	span.SetAttributes(attribute2.String("CreatedBefore", createdBefore.String()))
+	defer span.End()
+
+	// an order is finalized when it is canceled, completed or its delivery time is passed without a pending review
+	filter := bson.D{
+		{Key: "archivedAt", Value: bson.D{{Key: "$exists", Value: false}}},
+		{Key: "createdAt", Value: bson.D{{Key: "$lt", Value: createdBefore}}},
+		{Key: "heldForReview", Value: bson.D{{Key: "$ne", Value: true}}},
+		{Key: "$or", Value: bson.A{
+			bson.D{{Key: "canceled", Value: true}},
+			bson.D{{Key: "completed", Value: true}},
+			bson.D{{Key: "deliveredTime", Value: bson.D{{Key: "$lt", Value: time.Now()}}}},
+		}},
+	}
+
+	orders, err := m.find(ctx, filter, limit)
+	if err != nil {
+		return nil, utils2.TraceStatusFromContext(
+			ctx,
+			errors.WrapIf(err, "[mongoOrderRetentionRepository_GetOrdersToArchive.Find] error in finding orders"),
+		)
+	}
+
+	m.log.Infow(
+		fmt.Sprintf("[mongoOrderRetentionRepository.GetOrdersToArchive] %d orders to archive loaded", len(orders)),
+		logger.Fields{"CreatedBefore": createdBefore},
+	)
+
+	return orders, nil
+}
+
+func (m *mongoOrderRetentionRepository) GetOrdersToPurge(
+	ctx context.Context,
+	createdBefore time.Time,
+	limit int,
+) ([]*read_models.OrderReadModel, error) {
+	ctx, span := m.tracer.Start(ctx, "mongoOrderRetentionRepository.GetOrdersToPurge")
+	span.SetAttributes(attribute2.String("CreatedBefore", createdBefore.String()))
+	defer span.End()
+
+	filter := bson.D{
+		{Key: "archivedAt", Value: bson.D{{Key: "$exists", Value: true}}},
+		{Key: "dataPurgedAt", Value: bson.D{{Key: "$exists", Value: false}}},
+		{Key: "legalHold", Value: bson.D{{Key: "$ne", Value: true}}},
+		{Key: "createdAt", Value: bson.D{{Key: "$lt", Value: createdBefore}}},
+	}
+
+	orders, err := m.find(ctx, filter, limit)
+	if err != nil {
+		return nil, utils2.TraceStatusFromContext(
+			ctx,
+			errors.WrapIf(err, "[mongoOrderRetentionRepository_GetOrdersToPurge.Find] error in finding orders"),
+		)
+	}
+
+	m.log.Infow(
+		fmt.Sprintf("[mongoOrderRetentionRepository.GetOrdersToPurge] %d orders to purge loaded", len(orders)),
+		logger.Fields{"CreatedBefore": createdBefore},
+	)
+
+	return orders, nil
+}
+
+func (m *mongoOrderRetentionRepository) find(
+	ctx context.Context,
+	filter bson.D,
+	limit int,
+) ([]*read_models.OrderReadModel, error) {
+	collection := ordersCollection(m.mongoOptions, m.mongoClient, m.collection(), false)
+
+	ops := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}}).SetLimit(int64(limit))
+
+	cursor, err := collection.Find(ctx, filter, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []*read_models.OrderReadModel
+	if err := cursor.All(ctx, &orders); err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
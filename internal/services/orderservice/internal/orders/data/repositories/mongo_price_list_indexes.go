@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mongodb"
+
+	"emperror.dev/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/fx"
+)
+
+// priceListsIndexes back the effective price lists of a customer group query
+var priceListsIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "customerGroup", Value: 1}, {Key: "effectiveFrom", Value: 1}},
+		Options: options.Index().SetName("customer_group_effective_from"),
+	},
+}
+
+// RegisterMongoPriceListsIndexes creates the indexes of the price lists collection on application start, creating an
+// existing index is a no-op
+func RegisterMongoPriceListsIndexes(
+	lc fx.Lifecycle,
+	db *mongo.Client,
+	mongoOptions *mongodb.MongoDbOptions,
+	log logger.Logger,
+) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			_, err := db.Database(mongoOptions.Database).
+				Collection(priceListsCollection).
+				Indexes().
+				CreateMany(ctx, priceListsIndexes)
+			if err != nil {
+				return errors.WrapIf(err, "error in creating price lists indexes")
+			}
+
+			log.Info("price lists indexes created")
+
+			return nil
+		},
+	})
+}
@@ -0,0 +1,122 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mongodb"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	utils2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/repositories"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/pricelists/read_models"
+
+	"emperror.dev/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	attribute2 "go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	priceListsCollection = "price_lists"
+)
+
+type mongoPriceListRepository struct {
+	log          logger.Logger
+	mongoOptions *mongodb.MongoDbOptions
+	mongoClient  *mongo.Client
+	tracer       tracing.AppTracer
+}
+
+func NewMongoPriceListRepository(
+	log logger.Logger,
+	cfg *mongodb.MongoDbOptions,
+	mongoClient *mongo.Client,
+	tracer tracing.AppTracer,
+) repositories.PriceListRepository {
+	return &mongoPriceListRepository{
+		log:          log,
+		mongoOptions: cfg,
+		mongoClient:  mongoClient,
+		tracer:       tracer,
+	}
+}
+
+func (m *mongoPriceListRepository) GetEffectivePriceLists(
+	ctx context.Context,
+	customerGroup string,
+	at time.Time,
+) ([]*read_models.PriceListReadModel, error) {
+	ctx, span := m.tracer.Start(ctx, "mongoPriceListRepository.GetEffectivePriceLists")
+	span.SetAttributes(attribute2.String("CustomerGroup", customerGroup))
+	defer span.End()
+
+	collection := m.mongoOptions.Collection(m.mongoClient, priceListsCollection)
+
+	filter := bson.M{
+		"customerGroup": customerGroup,
+		"effectiveFrom": bson.M{"$lte": at},
+		"$or": bson.A{
+			bson.M{"effectiveTo": bson.M{"$exists": false}},
+			bson.M{"effectiveTo": bson.M{"$gt": at}},
+		},
+	}
+
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return nil, utils2.TraceStatusFromContext(
+			ctx,
+			errors.WrapIf(
+				err,
+				"[mongoPriceListRepository_GetEffectivePriceLists.Find] error in finding the price lists into the database.",
+			),
+		)
+	}
+
+	var priceLists []*read_models.PriceListReadModel
+	if err := cursor.All(ctx, &priceLists); err != nil {
+		return nil, utils2.TraceStatusFromContext(
+			ctx,
+			errors.WrapIf(
+				err,
+				"[mongoPriceListRepository_GetEffectivePriceLists.All] error in decoding the price lists.",
+			),
+		)
+	}
+
+	return priceLists, nil
+}
+
+func (m *mongoPriceListRepository) SavePriceList(
+	ctx context.Context,
+	priceList *read_models.PriceListReadModel,
+) error {
+	ctx, span := m.tracer.Start(ctx, "mongoPriceListRepository.SavePriceList")
+	span.SetAttributes(attribute2.String("PriceListId", priceList.Id))
+	defer span.End()
+
+	collection := m.mongoOptions.Collection(m.mongoClient, priceListsCollection)
+
+	_, err := collection.ReplaceOne(ctx, bson.M{"_id": priceList.Id}, priceList, options.Replace().SetUpsert(true))
+	if err != nil {
+		return utils2.TraceStatusFromContext(
+			ctx,
+			errors.WrapIf(
+				err,
+				fmt.Sprintf(
+					"[mongoPriceListRepository_SavePriceList.ReplaceOne] error in saving price list with id %s into the database.",
+					priceList.Id,
+				),
+			),
+		)
+	}
+
+	m.log.Infow(
+		fmt.Sprintf("[mongoPriceListRepository.SavePriceList] price list with id '%s' saved", priceList.Id),
+		logger.Fields{"Id": priceList.Id, "CustomerGroup": priceList.CustomerGroup},
+	)
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mongodb"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	utils2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/repositories"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/projections/read_models"
+
+	"emperror.dev/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	attribute2 "go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	projectionVersionsCollection = "projection_versions"
+)
+
+type mongoProjectionVersionsRepository struct {
+	log          logger.Logger
+	mongoOptions *mongodb.MongoDbOptions
+	mongoClient  *mongo.Client
+	tracer       tracing.AppTracer
+}
+
+func NewMongoProjectionVersionsRepository(
+	log logger.Logger,
+	cfg *mongodb.MongoDbOptions,
+	mongoClient *mongo.Client,
+	tracer tracing.AppTracer,
+) repositories.ProjectionVersionsRepository {
+	return &mongoProjectionVersionsRepository{
+		log:          log,
+		mongoOptions: cfg,
+		mongoClient:  mongoClient,
+		tracer:       tracer,
+	}
+}
+
+func (m *mongoProjectionVersionsRepository) GetProjectionVersions(
+	ctx context.Context,
+	projection string,
+) (*read_models.ProjectionVersionsReadModel, error) {
+	ctx, span := m.tracer.Start(ctx, "mongoProjectionVersionsRepository.GetProjectionVersions")
+	span.SetAttributes(attribute2.String("Projection", projection))
+	defer span.End()
+
+	collection := m.mongoOptions.Collection(m.mongoClient, projectionVersionsCollection)
+
+	var versions read_models.ProjectionVersionsReadModel
+	if err := collection.FindOne(ctx, bson.M{"_id": projection}).Decode(&versions); err != nil {
+		// ErrNoDocuments means that the filter did not match any documents in the collection
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+
+		return nil, utils2.TraceStatusFromContext(
+			ctx,
+			errors.WrapIf(
+				err,
+				fmt.Sprintf(
+					"[mongoProjectionVersionsRepository_GetProjectionVersions.FindOne] can't find the versions of projection %s into the database.",
+					projection,
+				),
+			),
+		)
+	}
+
+	return &versions, nil
+}
+
+func (m *mongoProjectionVersionsRepository) SaveProjectionVersions(
+	ctx context.Context,
+	versions *read_models.ProjectionVersionsReadModel,
+) error {
+	ctx, span := m.tracer.Start(ctx, "mongoProjectionVersionsRepository.SaveProjectionVersions")
+	span.SetAttributes(attribute2.String("Projection", versions.Projection))
+	defer span.End()
+
+	collection := m.mongoOptions.Collection(m.mongoClient, projectionVersionsCollection)
+
+	saved := *versions
+	saved.Revision = versions.Revision + 1
+	saved.UpdatedAt = time.Now()
+
+	var err error
+	if versions.Revision == 0 {
+		_, err = collection.InsertOne(ctx, &saved)
+		if mongo.IsDuplicateKeyError(err) {
+			err = m.conflictError(versions)
+		}
+	} else {
+		var result *mongo.UpdateResult
+		result, err = collection.ReplaceOne(
+			ctx,
+			bson.M{"_id": versions.Projection, "revision": versions.Revision},
+			&saved,
+		)
+		if err == nil && result.MatchedCount == 0 {
+			err = m.conflictError(versions)
+		}
+	}
+
+	if err != nil {
+		return utils2.TraceStatusFromContext(
+			ctx,
+			errors.WrapIf(
+				err,
+				fmt.Sprintf(
+					"[mongoProjectionVersionsRepository_SaveProjectionVersions] error in saving the versions of projection %s into the database.",
+					versions.Projection,
+				),
+			),
+		)
+	}
+
+	versions.Revision = saved.Revision
+	versions.UpdatedAt = saved.UpdatedAt
+
+	m.log.Infow(
+		fmt.Sprintf(
+			"[mongoProjectionVersionsRepository.SaveProjectionVersions] versions of projection '%s' saved",
+			versions.Projection,
+		),
+		logger.Fields{"Projection": versions.Projection, "Revision": versions.Revision},
+	)
+
+	return nil
+}
+
+func (m *mongoProjectionVersionsRepository) DropCollection(ctx context.Context, collection string) error {
+	ctx, span := m.tracer.Start(ctx, "mongoProjectionVersionsRepository.DropCollection")
+	span.SetAttributes(attribute2.String("Collection", collection))
+	defer span.End()
+
+	// dropping a missing collection is a no-op
+	err := m.mongoClient.Database(m.mongoOptions.Database).Collection(collection).Drop(ctx)
+	if err != nil {
+		return utils2.TraceStatusFromContext(
+			ctx,
+			errors.WrapIf(
+				err,
+				fmt.Sprintf("[mongoProjectionVersionsRepository_DropCollection.Drop] error in dropping collection %s.", collection),
+			),
+		)
+	}
+
+	m.log.Infow(
+		fmt.Sprintf("[mongoProjectionVersionsRepository.DropCollection] collection '%s' dropped", collection),
+		logger.Fields{"Collection": collection},
+	)
+
+	return nil
+}
+
+func (m *mongoProjectionVersionsRepository) conflictError(versions *read_models.ProjectionVersionsReadModel) error {
+	return customErrors.NewConflictError(
+		fmt.Sprintf(
+			"versions of projection %s are changed since revision %d",
+			versions.Projection,
+			versions.Revision,
+		),
+	)
+}
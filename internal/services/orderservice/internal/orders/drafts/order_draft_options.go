@@ -0,0 +1,27 @@
+package drafts
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[OrderDraftOptions]())
+
+// OrderDraftOptions controls the lifetime of the order drafts, a draft expires when it is not saved for the TTL
+type OrderDraftOptions struct {
+	TTL time.Duration `mapstructure:"ttl" default:"168h"`
+}
+
+func NewOrderDraftOptions(environment environment.Environment) (*OrderDraftOptions, error) {
+	return config.BindConfigKey[*OrderDraftOptions](optionName, environment)
+}
+
+// ExpiresAt returns the expiration of a draft which is saved at the time
+func (o *OrderDraftOptions) ExpiresAt(savedAt time.Time) time.Time {
+	return savedAt.Add(o.TTL)
+}
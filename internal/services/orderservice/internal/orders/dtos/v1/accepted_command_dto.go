@@ -0,0 +1,8 @@
+package dtosV1
+
+// AcceptedCommandDto is the response of the endpoints which enqueue their command, the status of the command is
+// polled from the StatusUrl
+type AcceptedCommandDto struct {
+	TrackingId string `json:"trackingId"`
+	StatusUrl  string `json:"statusUrl"`
+}
@@ -0,0 +1,38 @@
+package dtosV1
+
+import "time"
+
+// BackOfficeOrderReadDto is the order of the back-office endpoints, unlike OrderReadDto it has the internal fields
+// which are not visible to the customers
+type BackOfficeOrderReadDto struct {
+	Id              string              `json:"id"`
+	OrderId         string              `json:"orderId"`
+	OrderNumber     string              `json:"orderNumber"`
+	ShopItems       []*ShopItemReadDto  `json:"shopItems"`
+	AccountEmail    string              `json:"accountEmail"`
+	DeliveryAddress string              `json:"deliveryAddress"`
+	CancelReason    string              `json:"cancelReason"`
+	CanceledBy      string              `json:"canceledBy"`
+	TotalPrice      float64             `json:"totalPrice"`
+	Currency        string              `json:"currency,omitempty"`
+	ExchangeRate    float64             `json:"exchangeRate,omitempty"`
+	DeliveredTime   time.Time           `json:"deliveredTime"`
+	Paid            bool                `json:"paid"`
+	Submitted       bool                `json:"submitted"`
+	Completed       bool                `json:"completed"`
+	Canceled        bool                `json:"canceled"`
+	HeldForReview   bool                `json:"heldForReview"`
+	HoldReasons     []string            `json:"holdReasons"`
+	ReviewNote      string              `json:"reviewNote"`
+	InternalNotes   []*OrderNoteReadDto `json:"internalNotes"`
+	GiftCardId      string              `json:"giftCardId"`
+	GiftCardAmount  float64             `json:"giftCardAmount"`
+	LegalHold       bool                `json:"legalHold"`
+	LegalHoldReason string              `json:"legalHoldReason"`
+	ArchiveLocation string              `json:"archiveLocation"`
+	ArchivedAt      time.Time           `json:"archivedAt"`
+	DataPurgedAt    time.Time           `json:"dataPurgedAt"`
+	PaymentId       string              `json:"paymentId"`
+	CreatedAt       time.Time           `json:"createdAt"`
+	UpdatedAt       time.Time           `json:"updatedAt"`
+}
@@ -0,0 +1,12 @@
+package dtosV1
+
+import "time"
+
+type CustomerSegmentsDto struct {
+	AccountEmail  string    `json:"accountEmail"`
+	Segments      []string  `json:"segments"`
+	OrdersCount   int       `json:"ordersCount"`
+	LifetimeValue float64   `json:"lifetimeValue"`
+	FirstOrderAt  time.Time `json:"firstOrderAt"`
+	LastOrderAt   time.Time `json:"lastOrderAt"`
+}
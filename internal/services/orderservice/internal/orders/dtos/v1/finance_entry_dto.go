@@ -0,0 +1,23 @@
+package dtosV1
+
+import "time"
+
+type FinanceEntryDto struct {
+	Id             string     `json:"id"`
+	Type           string     `json:"type"`
+	OrderId        string     `json:"orderId"`
+	OrderNumber    string     `json:"orderNumber,omitempty"`
+	PaymentId      string     `json:"paymentId,omitempty"`
+	Amount         float64    `json:"amount"`
+	GiftCardAmount float64    `json:"giftCardAmount,omitempty"`
+	Currency       string     `json:"currency"`
+	ExchangeRate   float64    `json:"exchangeRate"`
+	Reason         string     `json:"reason,omitempty"`
+	OccurredAt     time.Time  `json:"occurredAt"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	BatchId        string     `json:"batchId,omitempty"`
+	Location       string     `json:"location,omitempty"`
+	LastError      string     `json:"lastError,omitempty"`
+	ExportedAt     *time.Time `json:"exportedAt,omitempty"`
+}
@@ -0,0 +1,17 @@
+package dtosV1
+
+import "time"
+
+type OrderDraftDto struct {
+	Id              string         `json:"id"`
+	ShopItems       []*ShopItemDto `json:"shopItems"`
+	AccountEmail    string         `json:"accountEmail"`
+	DeliveryAddress string         `json:"deliveryAddress"`
+	DeliveryTime    time.Time      `json:"deliveryTime"`
+	Converted       bool           `json:"converted"`
+	OrderId         string         `json:"orderId,omitempty"`
+	CreatedAt       time.Time      `json:"createdAt"`
+	UpdatedAt       time.Time      `json:"updatedAt"`
+	ExpiresAt       time.Time      `json:"expiresAt"`
+	ConvertedAt     *time.Time     `json:"convertedAt,omitempty"`
+}
@@ -8,11 +8,14 @@ import (
 
 type OrderDto struct {
 	Id              uuid.UUID      `json:"id"`
+	OrderNumber     string         `json:"orderNumber"`
 	ShopItems       []*ShopItemDto `json:"shopItems"`
 	AccountEmail    string         `json:"accountEmail"`
 	DeliveryAddress string         `json:"deliveryAddress"`
 	CancelReason    string         `json:"cancelReason"`
 	TotalPrice      float64        `json:"totalPrice"`
+	Currency        string         `json:"currency,omitempty"`
+	ExchangeRate    float64        `json:"exchangeRate,omitempty"`
 	DeliveredTime   time.Time      `json:"deliveredTime"`
 	Paid            bool           `json:"paid"`
 	Submitted       bool           `json:"submitted"`
@@ -0,0 +1,10 @@
+package dtosV1
+
+import "time"
+
+type OrderNoteReadDto struct {
+	NoteId  string    `json:"noteId"`
+	Text    string    `json:"text"`
+	Author  string    `json:"author"`
+	AddedAt time.Time `json:"addedAt"`
+}
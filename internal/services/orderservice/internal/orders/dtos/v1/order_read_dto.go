@@ -5,16 +5,23 @@ import "time"
 type OrderReadDto struct {
 	Id              string             `json:"id"`
 	OrderId         string             `json:"orderId"`
+	OrderNumber     string             `json:"orderNumber"`
 	ShopItems       []*ShopItemReadDto `json:"shopItems"`
 	AccountEmail    string             `json:"accountEmail"`
 	DeliveryAddress string             `json:"deliveryAddress"`
 	CancelReason    string             `json:"cancelReason"`
 	TotalPrice      float64            `json:"totalPrice"`
+	Currency        string             `json:"currency,omitempty"`
+	ExchangeRate    float64            `json:"exchangeRate,omitempty"`
 	DeliveredTime   time.Time          `json:"deliveredTime"`
 	Paid            bool               `json:"paid"`
 	Submitted       bool               `json:"submitted"`
 	Completed       bool               `json:"completed"`
 	Canceled        bool               `json:"canceled"`
+	HeldForReview   bool               `json:"heldForReview"`
+	HoldReasons     []string           `json:"holdReasons"`
+	ReviewNote      string             `json:"reviewNote"`
+	GiftCardAmount  float64            `json:"giftCardAmount"`
 	PaymentId       string             `json:"paymentId"`
 	CreatedAt       time.Time          `json:"createdAt"`
 	UpdatedAt       time.Time          `json:"updatedAt"`
@@ -1,6 +1,9 @@
 package dtosV1
 
 type ShopItemDto struct {
+	// ProductId is optional, the price of an item with a product id is resolved from the price lists of the customer
+	// group
+	ProductId   string  `json:"productId,omitempty"`
 	Title       string  `json:"title"`
 	Description string  `json:"description"`
 	Quantity    uint64  `json:"quantity"`
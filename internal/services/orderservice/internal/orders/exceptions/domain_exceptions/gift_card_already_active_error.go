@@ -0,0 +1,38 @@
+package domainExceptions
+
+import (
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+)
+
+type giftCardAlreadyActiveError struct {
+	customErrors.ConflictError
+}
+
+type GiftCardAlreadyActiveError interface {
+	customErrors.ConflictError
+}
+
+func NewGiftCardAlreadyActiveError(message string) error {
+	conflict := customErrors.NewConflictError(message)
+	customErr := customErrors.GetCustomError(conflict).(customErrors.ConflictError)
+	br := &giftCardAlreadyActiveError{
+		ConflictError: customErr,
+	}
+
+	return errors.WithStackIf(br)
+}
+
+func (i *giftCardAlreadyActiveError) isGiftCardAlreadyActiveError() bool {
+	return true
+}
+
+func IsGiftCardAlreadyActiveError(err error) bool {
+	var oh *giftCardAlreadyActiveError
+	if errors.As(err, &oh) {
+		return oh.isGiftCardAlreadyActiveError()
+	}
+
+	return false
+}
@@ -0,0 +1,38 @@
+package domainExceptions
+
+import (
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+)
+
+type giftCardInsufficientBalanceError struct {
+	customErrors.ConflictError
+}
+
+type GiftCardInsufficientBalanceError interface {
+	customErrors.ConflictError
+}
+
+func NewGiftCardInsufficientBalanceError(message string) error {
+	conflict := customErrors.NewConflictError(message)
+	customErr := customErrors.GetCustomError(conflict).(customErrors.ConflictError)
+	br := &giftCardInsufficientBalanceError{
+		ConflictError: customErr,
+	}
+
+	return errors.WithStackIf(br)
+}
+
+func (i *giftCardInsufficientBalanceError) isGiftCardInsufficientBalanceError() bool {
+	return true
+}
+
+func IsGiftCardInsufficientBalanceError(err error) bool {
+	var oh *giftCardInsufficientBalanceError
+	if errors.As(err, &oh) {
+		return oh.isGiftCardInsufficientBalanceError()
+	}
+
+	return false
+}
@@ -0,0 +1,38 @@
+package domainExceptions
+
+import (
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+)
+
+type giftCardNotActiveError struct {
+	customErrors.ConflictError
+}
+
+type GiftCardNotActiveError interface {
+	customErrors.ConflictError
+}
+
+func NewGiftCardNotActiveError(message string) error {
+	conflict := customErrors.NewConflictError(message)
+	customErr := customErrors.GetCustomError(conflict).(customErrors.ConflictError)
+	br := &giftCardNotActiveError{
+		ConflictError: customErr,
+	}
+
+	return errors.WithStackIf(br)
+}
+
+func (i *giftCardNotActiveError) isGiftCardNotActiveError() bool {
+	return true
+}
+
+func IsGiftCardNotActiveError(err error) bool {
+	var oh *giftCardNotActiveError
+	if errors.As(err, &oh) {
+		return oh.isGiftCardNotActiveError()
+	}
+
+	return false
+}
@@ -0,0 +1,38 @@
+package domainExceptions
+
+import (
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+)
+
+type orderAlreadyCanceledError struct {
+	customErrors.ConflictError
+}
+
+type OrderAlreadyCanceledError interface {
+	customErrors.ConflictError
+}
+
+func NewOrderAlreadyCanceledError(message string) error {
+	conflict := customErrors.NewConflictError(message)
+	customErr := customErrors.GetCustomError(conflict).(customErrors.ConflictError)
+	br := &orderAlreadyCanceledError{
+		ConflictError: customErr,
+	}
+
+	return errors.WithStackIf(br)
+}
+
+func (i *orderAlreadyCanceledError) isOrderAlreadyCanceledError() bool {
+	return true
+}
+
+func IsOrderAlreadyCanceledError(err error) bool {
+	var oh *orderAlreadyCanceledError
+	if errors.As(err, &oh) {
+		return oh.isOrderAlreadyCanceledError()
+	}
+
+	return false
+}
@@ -53,3 +53,60 @@ func Test_Is_Not_InvalidEmail_Address_Error(t *testing.T) {
 	err := customErrors.NewBadRequestError("email address is not valid")
 	assert.False(t, IsInvalidEmailAddressError(err))
 }
+
+func Test_Order_Not_Held_For_Review_Error(t *testing.T) {
+	t.Parallel()
+
+	err := NewOrderNotHeldForReviewError("order is not held for review")
+	assert.True(t, IsOrderNotHeldForReviewError(err))
+	assert.True(t, customErrors.IsConflictError(err))
+	fmt.Println(errorUtils.ErrorsWithStack(err))
+}
+
+func Test_Order_Already_Canceled_Error(t *testing.T) {
+	t.Parallel()
+
+	err := NewOrderAlreadyCanceledError("order is already canceled")
+	assert.True(t, IsOrderAlreadyCanceledError(err))
+	assert.False(t, IsOrderNotHeldForReviewError(err))
+	assert.True(t, customErrors.IsConflictError(err))
+}
+
+func Test_Gift_Card_Errors(t *testing.T) {
+	t.Parallel()
+
+	notActive := NewGiftCardNotActiveError("gift card is not active")
+	assert.True(t, IsGiftCardNotActiveError(notActive))
+	assert.False(t, IsGiftCardInsufficientBalanceError(notActive))
+	assert.True(t, customErrors.IsConflictError(notActive))
+
+	insufficient := NewGiftCardInsufficientBalanceError("gift card balance is not enough")
+	assert.True(t, IsGiftCardInsufficientBalanceError(insufficient))
+	assert.True(t, customErrors.IsConflictError(insufficient))
+
+	alreadyActive := NewGiftCardAlreadyActiveError("gift card is already active")
+	assert.True(t, IsGiftCardAlreadyActiveError(alreadyActive))
+	assert.False(t, IsGiftCardNotActiveError(alreadyActive))
+}
+
+func Test_Order_Under_Legal_Hold_Error(t *testing.T) {
+	t.Parallel()
+
+	err := NewOrderUnderLegalHoldError("order is under legal hold")
+	assert.True(t, IsOrderUnderLegalHoldError(err))
+	assert.False(t, IsOrderAlreadyCanceledError(err))
+	assert.True(t, customErrors.IsConflictError(err))
+}
+
+func Test_Order_Draft_Errors(t *testing.T) {
+	t.Parallel()
+
+	expired := NewOrderDraftExpiredError("order draft is expired")
+	assert.True(t, IsOrderDraftExpiredError(expired))
+	assert.False(t, IsOrderDraftAlreadyConvertedError(expired))
+	assert.True(t, customErrors.IsConflictError(expired))
+
+	converted := NewOrderDraftAlreadyConvertedError("order draft is already converted")
+	assert.True(t, IsOrderDraftAlreadyConvertedError(converted))
+	assert.True(t, customErrors.IsConflictError(converted))
+}
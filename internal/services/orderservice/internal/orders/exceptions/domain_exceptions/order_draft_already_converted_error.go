@@ -0,0 +1,38 @@
+package domainExceptions
+
+import (
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+)
+
+type orderDraftAlreadyConvertedError struct {
+	customErrors.ConflictError
+}
+
+type OrderDraftAlreadyConvertedError interface {
+	customErrors.ConflictError
+}
+
+func NewOrderDraftAlreadyConvertedError(message string) error {
+	conflict := customErrors.NewConflictError(message)
+	customErr := customErrors.GetCustomError(conflict).(customErrors.ConflictError)
+	br := &orderDraftAlreadyConvertedError{
+		ConflictError: customErr,
+	}
+
+	return errors.WithStackIf(br)
+}
+
+func (i *orderDraftAlreadyConvertedError) isOrderDraftAlreadyConvertedError() bool {
+	return true
+}
+
+func IsOrderDraftAlreadyConvertedError(err error) bool {
+	var oh *orderDraftAlreadyConvertedError
+	if errors.As(err, &oh) {
+		return oh.isOrderDraftAlreadyConvertedError()
+	}
+
+	return false
+}
@@ -0,0 +1,38 @@
+package domainExceptions
+
+import (
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+)
+
+type orderDraftExpiredError struct {
+	customErrors.ConflictError
+}
+
+type OrderDraftExpiredError interface {
+	customErrors.ConflictError
+}
+
+func NewOrderDraftExpiredError(message string) error {
+	conflict := customErrors.NewConflictError(message)
+	customErr := customErrors.GetCustomError(conflict).(customErrors.ConflictError)
+	br := &orderDraftExpiredError{
+		ConflictError: customErr,
+	}
+
+	return errors.WithStackIf(br)
+}
+
+func (i *orderDraftExpiredError) isOrderDraftExpiredError() bool {
+	return true
+}
+
+func IsOrderDraftExpiredError(err error) bool {
+	var oh *orderDraftExpiredError
+	if errors.As(err, &oh) {
+		return oh.isOrderDraftExpiredError()
+	}
+
+	return false
+}
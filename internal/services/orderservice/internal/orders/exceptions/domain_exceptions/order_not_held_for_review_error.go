@@ -0,0 +1,38 @@
+package domainExceptions
+
+import (
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+)
+
+type orderNotHeldForReviewError struct {
+	customErrors.ConflictError
+}
+
+type OrderNotHeldForReviewError interface {
+	customErrors.ConflictError
+}
+
+func NewOrderNotHeldForReviewError(message string) error {
+	conflict := customErrors.NewConflictError(message)
+	customErr := customErrors.GetCustomError(conflict).(customErrors.ConflictError)
+	br := &orderNotHeldForReviewError{
+		ConflictError: customErr,
+	}
+
+	return errors.WithStackIf(br)
+}
+
+func (i *orderNotHeldForReviewError) isOrderNotHeldForReviewError() bool {
+	return true
+}
+
+func IsOrderNotHeldForReviewError(err error) bool {
+	var oh *orderNotHeldForReviewError
+	if errors.As(err, &oh) {
+		return oh.isOrderNotHeldForReviewError()
+	}
+
+	return false
+}
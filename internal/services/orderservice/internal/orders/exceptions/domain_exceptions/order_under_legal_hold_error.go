@@ -0,0 +1,38 @@
+package domainExceptions
+
+import (
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+)
+
+type orderUnderLegalHoldError struct {
+	customErrors.ConflictError
+}
+
+type OrderUnderLegalHoldError interface {
+	customErrors.ConflictError
+}
+
+func NewOrderUnderLegalHoldError(message string) error {
+	conflict := customErrors.NewConflictError(message)
+	customErr := customErrors.GetCustomError(conflict).(customErrors.ConflictError)
+	br := &orderUnderLegalHoldError{
+		ConflictError: customErr,
+	}
+
+	return errors.WithStackIf(br)
+}
+
+func (i *orderUnderLegalHoldError) isOrderUnderLegalHoldError() bool {
+	return true
+}
+
+func IsOrderUnderLegalHoldError(err error) bool {
+	var oh *orderUnderLegalHoldError
+	if errors.As(err, &oh) {
+		return oh.isOrderUnderLegalHoldError()
+	}
+
+	return false
+}
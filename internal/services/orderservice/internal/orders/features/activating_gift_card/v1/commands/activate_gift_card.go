@@ -0,0 +1,37 @@
+package activateGiftCardCommandsV1
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+type ActivateGiftCard struct {
+	GiftCardId  uuid.UUID
+	ActivatedBy string
+	ActivatedAt time.Time
+}
+
+func NewActivateGiftCard(giftCardId uuid.UUID, activatedBy string) (*ActivateGiftCard, error) {
+	command := &ActivateGiftCard{
+		GiftCardId:  giftCardId,
+		ActivatedBy: activatedBy,
+		ActivatedAt: time.Now(),
+	}
+
+	err := command.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+func (c ActivateGiftCard) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.GiftCardId, validation.Required),
+		validation.Field(&c.ActivatedBy, validation.Required),
+		validation.Field(&c.ActivatedAt, validation.Required),
+	)
+}
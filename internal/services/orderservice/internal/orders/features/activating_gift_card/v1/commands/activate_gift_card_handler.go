@@ -0,0 +1,79 @@
+package activateGiftCardCommandsV1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/store"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/giftcards/aggregate"
+
+	"emperror.dev/errors"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type ActivateGiftCardHandler struct {
+	log            logger.Logger
+	aggregateStore store.AggregateStore[*aggregate.GiftCard]
+	tracer         tracing.AppTracer
+}
+
+func NewActivateGiftCardHandler(
+	log logger.Logger,
+	aggregateStore store.AggregateStore[*aggregate.GiftCard],
+	tracer tracing.AppTracer,
+) *ActivateGiftCardHandler {
+	return &ActivateGiftCardHandler{
+		log:            log,
+		aggregateStore: aggregateStore,
+		tracer:         tracer,
+	}
+}
+
+func (c *ActivateGiftCardHandler) Handle(
+	ctx context.Context,
+	command *ActivateGiftCard,
+) (*mediatr.Unit, error) {
+	exists, err := c.aggregateStore.Exists(ctx, command.GiftCardId)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[ActivateGiftCardHandler_Handle.Exists] error in checking gift card existence",
+		)
+	}
+	if !exists {
+		return nil, customErrors.NewNotFoundError(
+			fmt.Sprintf("[ActivateGiftCardHandler_Handle.Exists] gift card with id %s not found", command.GiftCardId),
+		)
+	}
+
+	giftCard, err := c.aggregateStore.Load(ctx, command.GiftCardId)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[ActivateGiftCardHandler_Handle.Load] error in loading gift card aggregate",
+		)
+	}
+
+	err = giftCard.Activate(command.ActivatedBy, command.ActivatedAt)
+	if err != nil {
+		return nil, errors.WithMessage(err, "[ActivateGiftCardHandler_Handle.Activate] error in activating the gift card")
+	}
+
+	_, err = c.aggregateStore.Store(giftCard, nil, ctx)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[ActivateGiftCardHandler_Handle.Store] error in storing gift card aggregate",
+		)
+	}
+
+	c.log.Infow(
+		fmt.Sprintf("[ActivateGiftCardHandler.Handle] gift card with id: {%s} activated", command.GiftCardId),
+		logger.Fields{"Id": command.GiftCardId, "ActivatedBy": command.ActivatedBy},
+	)
+
+	return &mediatr.Unit{}, nil
+}
@@ -0,0 +1,7 @@
+package dtos
+
+import uuid "github.com/satori/go.uuid"
+
+type ActivateGiftCardRequestDto struct {
+	GiftCardId uuid.UUID `json:"-" param:"id"`
+}
@@ -0,0 +1,95 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	apikey "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/api_key"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	activateGiftCardCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/activating_gift_card/v1/commands"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/activating_gift_card/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type activateGiftCardEndpoint struct {
+	params.BackOfficeRouteParams
+}
+
+func NewActivateGiftCardEndpoint(params params.BackOfficeRouteParams) route.Endpoint {
+	return &activateGiftCardEndpoint{BackOfficeRouteParams: params}
+}
+
+func (ep *activateGiftCardEndpoint) MapEndpoint() {
+	ep.BackOfficeGiftCardsGroup.POST("/:id/activate", ep.handler())
+}
+
+// ActivateGiftCard
+// @Tags BackOffice
+// @Summary Activate gift card
+// @Description Activate an issued gift card, so it can be redeemed in the checkout
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Gift card ID"
+// @Success 204
+// @Router /api/v1/backoffice/giftcards/{id}/activate [post]
+func (ep *activateGiftCardEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.ActivateGiftCardRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"[activateGiftCardEndpoint_handler.Bind] error in the binding request",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[activateGiftCardEndpoint_handler.Bind] err: %v", badRequestErr),
+			)
+			return badRequestErr
+		}
+
+		userId, _ := apikey.UserId(ctx)
+
+		command, err := activateGiftCardCommandsV1.NewActivateGiftCard(request.GiftCardId, userId)
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"[activateGiftCardEndpoint_handler.StructCtx] command validation failed",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[activateGiftCardEndpoint_handler.StructCtx] err: %v", validationErr),
+			)
+			return validationErr
+		}
+
+		_, err = cqrs.Send[*activateGiftCardCommandsV1.ActivateGiftCard, *mediatr.Unit](
+			ctx,
+			command,
+		)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[activateGiftCardEndpoint_handler.Send] error in sending ActivateGiftCard",
+			)
+			ep.Logger.Errorw(
+				fmt.Sprintf(
+					"[activateGiftCardEndpoint_handler.Send] id: {%s}, err: %v",
+					command.GiftCardId,
+					err,
+				),
+				logger.Fields{"Id": command.GiftCardId},
+			)
+			return err
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,29 @@
+package domainEvents
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/domain"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+)
+
+type GiftCardActivatedV1 struct {
+	*domain.DomainEvent
+	ActivatedBy string    `json:"activatedBy"`
+	ActivatedAt time.Time `json:"activatedAt"`
+}
+
+func NewGiftCardActivatedV1(activatedBy string, activatedAt time.Time) (*GiftCardActivatedV1, error) {
+	if activatedAt.IsZero() {
+		return nil, customErrors.NewDomainError("activatedAt can't be zero")
+	}
+
+	eventData := &GiftCardActivatedV1{
+		ActivatedBy: activatedBy,
+		ActivatedAt: activatedAt,
+	}
+	eventData.DomainEvent = domain.NewDomainEvent(typeMapper.GetTypeName(eventData))
+
+	return eventData, nil
+}
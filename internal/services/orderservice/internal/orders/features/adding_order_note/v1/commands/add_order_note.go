@@ -0,0 +1,44 @@
+package addOrderNoteCommandsV1
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+// AddOrderNote adds an internal note of a back-office user to the order
+type AddOrderNote struct {
+	OrderId uuid.UUID
+	NoteId  uuid.UUID
+	Text    string
+	Author  string
+	AddedAt time.Time
+}
+
+func NewAddOrderNote(orderId uuid.UUID, text string, author string) (*AddOrderNote, error) {
+	command := &AddOrderNote{
+		OrderId: orderId,
+		NoteId:  uuid.NewV4(),
+		Text:    text,
+		Author:  author,
+		AddedAt: time.Now(),
+	}
+
+	err := command.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+func (c AddOrderNote) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.OrderId, validation.Required),
+		validation.Field(&c.NoteId, validation.Required),
+		validation.Field(&c.Text, validation.Required, validation.Length(0, 2000)),
+		validation.Field(&c.Author, validation.Required),
+		validation.Field(&c.AddedAt, validation.Required),
+	)
+}
@@ -0,0 +1,73 @@
+package addOrderNoteCommandsV1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/store"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/adding_order_note/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/aggregate"
+
+	"emperror.dev/errors"
+)
+
+type AddOrderNoteHandler struct {
+	log            logger.Logger
+	aggregateStore store.AggregateStore[*aggregate.Order]
+	tracer         tracing.AppTracer
+}
+
+func NewAddOrderNoteHandler(
+	log logger.Logger,
+	aggregateStore store.AggregateStore[*aggregate.Order],
+	tracer tracing.AppTracer,
+) *AddOrderNoteHandler {
+	return &AddOrderNoteHandler{
+		log:            log,
+		aggregateStore: aggregateStore,
+		tracer:         tracer,
+	}
+}
+
+func (c *AddOrderNoteHandler) Handle(
+	ctx context.Context,
+	command *AddOrderNote,
+) (*dtos.AddOrderNoteResponseDto, error) {
+	exists, err := c.aggregateStore.Exists(ctx, command.OrderId)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[AddOrderNoteHandler_Handle.Exists] error in checking order existence",
+		)
+	}
+	if !exists {
+		return nil, customErrors.NewNotFoundError(
+			fmt.Sprintf("[AddOrderNoteHandler_Handle.Exists] order with id %s not found", command.OrderId),
+		)
+	}
+
+	// the note is merged with the concurrent updates of the order, see `Order.Commutes`
+	_, _, err = c.aggregateStore.Update(ctx, command.OrderId, nil, func(order *aggregate.Order) error {
+		return order.AddInternalNote(command.NoteId, command.Text, command.Author, command.AddedAt)
+	})
+	// the unresolved concurrency conflicts and the notes of the archived orders result in conflict responses
+	if customErrors.IsConflictError(err) {
+		return nil, errors.WithMessage(err, "[AddOrderNoteHandler_Handle.Update] error in adding note to the order")
+	}
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[AddOrderNoteHandler_Handle.Update] error in adding note to the order",
+		)
+	}
+
+	c.log.Infow(
+		fmt.Sprintf("[AddOrderNoteHandler.Handle] note added to the order with id: {%s}", command.OrderId),
+		logger.Fields{"Id": command.OrderId, "NoteId": command.NoteId, "Author": command.Author},
+	)
+
+	return &dtos.AddOrderNoteResponseDto{NoteId: command.NoteId}, nil
+}
@@ -0,0 +1,8 @@
+package dtos
+
+import uuid "github.com/satori/go.uuid"
+
+type AddOrderNoteRequestDto struct {
+	OrderId uuid.UUID `json:"-"    param:"id"`
+	Text    string    `json:"text"`
+}
@@ -0,0 +1,7 @@
+package dtos
+
+import uuid "github.com/satori/go.uuid"
+
+type AddOrderNoteResponseDto struct {
+	NoteId uuid.UUID `json:"noteId"`
+}
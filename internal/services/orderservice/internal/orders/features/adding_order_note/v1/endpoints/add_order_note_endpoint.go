@@ -0,0 +1,95 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	apikey "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/api_key"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	addOrderNoteCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/adding_order_note/v1/commands"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/adding_order_note/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type addOrderNoteEndpoint struct {
+	params.BackOfficeRouteParams
+}
+
+func NewAddOrderNoteEndpoint(params params.BackOfficeRouteParams) route.Endpoint {
+	return &addOrderNoteEndpoint{BackOfficeRouteParams: params}
+}
+
+func (ep *addOrderNoteEndpoint) MapEndpoint() {
+	ep.BackOfficeGroup.POST("/:id/notes", ep.handler())
+}
+
+// AddOrderNote
+// @Tags BackOffice
+// @Summary Add order note
+// @Description Add an internal note to the order, the notes are not visible to the customer
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param AddOrderNoteRequestDto body dtos.AddOrderNoteRequestDto true "Note data"
+// @Param id path string true "Order ID"
+// @Success 201 {object} dtos.AddOrderNoteResponseDto
+// @Router /api/v1/backoffice/orders/{id}/notes [post]
+func (ep *addOrderNoteEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.AddOrderNoteRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"[addOrderNoteEndpoint_handler.Bind] error in the binding request",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[addOrderNoteEndpoint_handler.Bind] err: %v", badRequestErr),
+			)
+			return badRequestErr
+		}
+
+		userId, _ := apikey.UserId(ctx)
+
+		command, err := addOrderNoteCommandsV1.NewAddOrderNote(request.OrderId, request.Text, userId)
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"[addOrderNoteEndpoint_handler.StructCtx] command validation failed",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[addOrderNoteEndpoint_handler.StructCtx] err: %v", validationErr),
+			)
+			return validationErr
+		}
+
+		result, err := cqrs.Send[*addOrderNoteCommandsV1.AddOrderNote, *dtos.AddOrderNoteResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[addOrderNoteEndpoint_handler.Send] error in sending AddOrderNote",
+			)
+			ep.Logger.Errorw(
+				fmt.Sprintf(
+					"[addOrderNoteEndpoint_handler.Send] id: {%s}, err: %v",
+					command.OrderId,
+					err,
+				),
+				logger.Fields{"Id": command.OrderId},
+			)
+			return err
+		}
+
+		return c.JSON(http.StatusCreated, result)
+	}
+}
@@ -0,0 +1,41 @@
+package domainEvents
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/domain"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// OrderNoteAddedV1 is applied when a back-office user adds an internal note to an order, the notes are not visible to
+// the customer
+type OrderNoteAddedV1 struct {
+	*domain.DomainEvent
+	NoteId  uuid.UUID `json:"noteId"`
+	Text    string    `json:"text"`
+	Author  string    `json:"author"`
+	AddedAt time.Time `json:"addedAt"`
+}
+
+func NewOrderNoteAddedV1(noteId uuid.UUID, text string, author string, addedAt time.Time) (*OrderNoteAddedV1, error) {
+	if text == "" {
+		return nil, customErrors.NewDomainError("text of the note is required")
+	}
+
+	if addedAt.IsZero() {
+		return nil, customErrors.NewDomainError("addedAt can't be zero")
+	}
+
+	eventData := &OrderNoteAddedV1{
+		NoteId:  noteId,
+		Text:    text,
+		Author:  author,
+		AddedAt: addedAt,
+	}
+	eventData.DomainEvent = domain.NewDomainEvent(typeMapper.GetTypeName(eventData))
+
+	return eventData, nil
+}
@@ -0,0 +1,34 @@
+package domainEvents
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/domain"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+)
+
+// OrderArchivedV1 is applied when the retention worker exports the order document to the archive store
+type OrderArchivedV1 struct {
+	*domain.DomainEvent
+	Location   string    `json:"location"`
+	ArchivedAt time.Time `json:"archivedAt"`
+}
+
+func NewOrderArchivedV1(location string, archivedAt time.Time) (*OrderArchivedV1, error) {
+	if location == "" {
+		return nil, customErrors.NewDomainError("location of the archived order is required")
+	}
+
+	if archivedAt.IsZero() {
+		return nil, customErrors.NewDomainError("archivedAt can't be zero")
+	}
+
+	eventData := &OrderArchivedV1{
+		Location:   location,
+		ArchivedAt: archivedAt,
+	}
+	eventData.DomainEvent = domain.NewDomainEvent(typeMapper.GetTypeName(eventData))
+
+	return eventData, nil
+}
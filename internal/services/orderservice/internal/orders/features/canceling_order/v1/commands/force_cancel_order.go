@@ -0,0 +1,41 @@
+package cancelOrderCommandsV1
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+// ForceCancelOrder cancels an order regardless of its state, it is sent by the back-office users
+type ForceCancelOrder struct {
+	OrderId    uuid.UUID
+	Reason     string
+	CanceledBy string
+	CanceledAt time.Time
+}
+
+func NewForceCancelOrder(orderId uuid.UUID, reason string, canceledBy string) (*ForceCancelOrder, error) {
+	command := &ForceCancelOrder{
+		OrderId:    orderId,
+		Reason:     reason,
+		CanceledBy: canceledBy,
+		CanceledAt: time.Now(),
+	}
+
+	err := command.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+func (c ForceCancelOrder) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.OrderId, validation.Required),
+		validation.Field(&c.Reason, validation.Required, validation.Length(0, 1000)),
+		validation.Field(&c.CanceledBy, validation.Required),
+		validation.Field(&c.CanceledAt, validation.Required),
+	)
+}
@@ -0,0 +1,76 @@
+package cancelOrderCommandsV1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/store"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/aggregate"
+
+	"emperror.dev/errors"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type ForceCancelOrderHandler struct {
+	log            logger.Logger
+	aggregateStore store.AggregateStore[*aggregate.Order]
+	tracer         tracing.AppTracer
+}
+
+func NewForceCancelOrderHandler(
+	log logger.Logger,
+	aggregateStore store.AggregateStore[*aggregate.Order],
+	tracer tracing.AppTracer,
+) *ForceCancelOrderHandler {
+	return &ForceCancelOrderHandler{
+		log:            log,
+		aggregateStore: aggregateStore,
+		tracer:         tracer,
+	}
+}
+
+func (c *ForceCancelOrderHandler) Handle(
+	ctx context.Context,
+	command *ForceCancelOrder,
+) (*mediatr.Unit, error) {
+	exists, err := c.aggregateStore.Exists(ctx, command.OrderId)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[ForceCancelOrderHandler_Handle.Exists] error in checking order existence",
+		)
+	}
+	if !exists {
+		return nil, customErrors.NewNotFoundError(
+			fmt.Sprintf("[ForceCancelOrderHandler_Handle.Exists] order with id %s not found", command.OrderId),
+		)
+	}
+
+	// the order is reloaded and updated again when it is changed by a concurrent update
+	_, _, err = c.aggregateStore.Update(ctx, command.OrderId, nil, func(order *aggregate.Order) error {
+		// the domain error is kept as is, so an already canceled order results in a conflict response
+		return order.ForceCancel(command.Reason, command.CanceledBy, command.CanceledAt)
+	})
+	if err != nil {
+		// the domain errors and the unresolved concurrency conflicts are kept as is, so they result in conflict responses
+		if customErrors.IsConflictError(err) || customErrors.IsDomainError(err, http.StatusBadRequest) {
+			return nil, errors.WithMessage(err, "[ForceCancelOrderHandler_Handle.Update] error in canceling the order")
+		}
+
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[ForceCancelOrderHandler_Handle.Update] error in updating order aggregate",
+		)
+	}
+
+	c.log.Infow(
+		fmt.Sprintf("[ForceCancelOrderHandler.Handle] order with id: {%s} force canceled", command.OrderId),
+		logger.Fields{"Id": command.OrderId, "CanceledBy": command.CanceledBy},
+	)
+
+	return &mediatr.Unit{}, nil
+}
@@ -0,0 +1,8 @@
+package dtos
+
+import uuid "github.com/satori/go.uuid"
+
+type ForceCancelOrderRequestDto struct {
+	OrderId uuid.UUID `json:"-"      param:"id"`
+	Reason  string    `json:"reason"`
+}
@@ -0,0 +1,96 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	apikey "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/api_key"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	cancelOrderCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/canceling_order/v1/commands"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/canceling_order/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type forceCancelOrderEndpoint struct {
+	params.BackOfficeRouteParams
+}
+
+func NewForceCancelOrderEndpoint(params params.BackOfficeRouteParams) route.Endpoint {
+	return &forceCancelOrderEndpoint{BackOfficeRouteParams: params}
+}
+
+func (ep *forceCancelOrderEndpoint) MapEndpoint() {
+	ep.BackOfficeGroup.POST("/:id/force-cancel", ep.handler())
+}
+
+// ForceCancelOrder
+// @Tags BackOffice
+// @Summary Force cancel order
+// @Description Cancel the order regardless of its state
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param ForceCancelOrderRequestDto body dtos.ForceCancelOrderRequestDto true "Cancel data"
+// @Param id path string true "Order ID"
+// @Success 204
+// @Router /api/v1/backoffice/orders/{id}/force-cancel [post]
+func (ep *forceCancelOrderEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.ForceCancelOrderRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"[forceCancelOrderEndpoint_handler.Bind] error in the binding request",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[forceCancelOrderEndpoint_handler.Bind] err: %v", badRequestErr),
+			)
+			return badRequestErr
+		}
+
+		userId, _ := apikey.UserId(ctx)
+
+		command, err := cancelOrderCommandsV1.NewForceCancelOrder(request.OrderId, request.Reason, userId)
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"[forceCancelOrderEndpoint_handler.StructCtx] command validation failed",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[forceCancelOrderEndpoint_handler.StructCtx] err: %v", validationErr),
+			)
+			return validationErr
+		}
+
+		_, err = cqrs.Send[*cancelOrderCommandsV1.ForceCancelOrder, *mediatr.Unit](
+			ctx,
+			command,
+		)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[forceCancelOrderEndpoint_handler.Send] error in sending ForceCancelOrder",
+			)
+			ep.Logger.Errorw(
+				fmt.Sprintf(
+					"[forceCancelOrderEndpoint_handler.Send] id: {%s}, err: %v",
+					command.OrderId,
+					err,
+				),
+				logger.Fields{"Id": command.OrderId},
+			)
+			return err
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,47 @@
+package domainEvents
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/domain"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// OrderCanceledV1 is applied when an order is canceled, a forced cancel is done by a back-office user regardless of
+// the order state. the refund of a paid order is kept in the event, so the projections of a replay get the refunded
+// amounts instead of the current state of the order
+type OrderCanceledV1 struct {
+	*domain.DomainEvent
+	Reason     string `json:"reason"`
+	CanceledBy string `json:"canceledBy"`
+	Forced     bool   `json:"forced"`
+	// Refunded is set for the paid orders, the refunded amount is without the gift card redemption of the order
+	Refunded               bool      `json:"refunded"`
+	RefundedPaymentId      uuid.UUID `json:"refundedPaymentId"`
+	RefundedAmount         float64   `json:"refundedAmount"`
+	RefundedGiftCardAmount float64   `json:"refundedGiftCardAmount"`
+	CanceledAt             time.Time `json:"canceledAt"`
+}
+
+func NewOrderCanceledV1(reason string, canceledBy string, forced bool, canceledAt time.Time) (*OrderCanceledV1, error) {
+	if reason == "" {
+		return nil, customErrors.NewDomainError("reason of canceling the order is required")
+	}
+
+	if canceledAt.IsZero() {
+		return nil, customErrors.NewDomainError("canceledAt can't be zero")
+	}
+
+	eventData := &OrderCanceledV1{
+		Reason:     reason,
+		CanceledBy: canceledBy,
+		Forced:     forced,
+		CanceledAt: canceledAt,
+	}
+	eventData.DomainEvent = domain.NewDomainEvent(typeMapper.GetTypeName(eventData))
+
+	return eventData, nil
+}
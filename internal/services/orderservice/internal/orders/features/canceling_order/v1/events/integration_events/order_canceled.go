@@ -0,0 +1,27 @@
+package integrationEvents
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type OrderCanceledV1 struct {
+	*types.Message
+	OrderId    string    `json:"orderId"`
+	Reason     string    `json:"reason"`
+	Forced     bool      `json:"forced"`
+	CanceledAt time.Time `json:"canceledAt"`
+}
+
+func NewOrderCanceledV1(orderId string, reason string, forced bool, canceledAt time.Time) *OrderCanceledV1 {
+	return &OrderCanceledV1{
+		Message:    types.NewMessage(uuid.NewV4().String()),
+		OrderId:    orderId,
+		Reason:     reason,
+		Forced:     forced,
+		CanceledAt: canceledAt,
+	}
+}
@@ -0,0 +1,42 @@
+package convertOrderDraftCommandsV1
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+// ConvertOrderDraft places the order of a draft, the order is created with the id of the draft
+type ConvertOrderDraft struct {
+	DraftId uuid.UUID
+	// GiftCardCode is optional, the gift card pays the order total up to its balance
+	GiftCardCode string
+	// CustomerGroup is optional, the prices of the items are resolved at the conversion with the current price lists
+	CustomerGroup string
+	ConvertedAt   time.Time
+}
+
+func NewConvertOrderDraft(draftId uuid.UUID, giftCardCode string, customerGroup string) (*ConvertOrderDraft, error) {
+	command := &ConvertOrderDraft{
+		DraftId:       draftId,
+		GiftCardCode:  giftCardCode,
+		CustomerGroup: customerGroup,
+		ConvertedAt:   time.Now(),
+	}
+
+	err := command.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+func (c ConvertOrderDraft) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.DraftId, validation.Required),
+		validation.Field(&c.GiftCardCode, validation.Length(16, 32)),
+		validation.Field(&c.ConvertedAt, validation.Required),
+	)
+}
@@ -0,0 +1,166 @@
+package convertOrderDraftCommandsV1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/store"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/converting_order_draft/v1/dtos"
+	createOrderCommandV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/creating_order/v1/commands"
+	createOrderDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/creating_order/v1/dtos"
+	draftAggregate "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/drafts/aggregate"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/aggregate"
+
+	"emperror.dev/errors"
+)
+
+// ConvertOrderDraftHandler creates the order of a draft and marks the draft as converted. the order has the id of the
+// draft, so the order stream is created only once, and a conversion which is retried after storing the order but
+// before storing the draft completes the draft with the existing order.
+type ConvertOrderDraftHandler struct {
+	log        logger.Logger
+	draftStore store.AggregateStore[*draftAggregate.OrderDraft]
+	orderStore store.AggregateStore[*aggregate.Order]
+	tracer     tracing.AppTracer
+}
+
+func NewConvertOrderDraftHandler(
+	log logger.Logger,
+	draftStore store.AggregateStore[*draftAggregate.OrderDraft],
+	orderStore store.AggregateStore[*aggregate.Order],
+	tracer tracing.AppTracer,
+) *ConvertOrderDraftHandler {
+	return &ConvertOrderDraftHandler{
+		log:        log,
+		draftStore: draftStore,
+		orderStore: orderStore,
+		tracer:     tracer,
+	}
+}
+
+func (c *ConvertOrderDraftHandler) Handle(
+	ctx context.Context,
+	command *ConvertOrderDraft,
+) (*dtos.ConvertOrderDraftResponseDto, error) {
+	exists, err := c.draftStore.Exists(ctx, command.DraftId)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[ConvertOrderDraftHandler_Handle.Exists] error in checking order draft existence",
+		)
+	}
+	if !exists {
+		return nil, customErrors.NewNotFoundError(
+			fmt.Sprintf("[ConvertOrderDraftHandler_Handle.Exists] order draft with id %s not found", command.DraftId),
+		)
+	}
+
+	draft, err := c.draftStore.Load(ctx, command.DraftId)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[ConvertOrderDraftHandler_Handle.Load] error in loading order draft aggregate",
+		)
+	}
+
+	// the draft is converted before creating the order, so an expired or converted draft doesn't create an order, the
+	// conversion is stored after the order
+	err = draft.Convert(command.ConvertedAt)
+	if err != nil {
+		return nil, errors.WithMessage(err, "[ConvertOrderDraftHandler_Handle.Convert] error in converting the order draft")
+	}
+
+	response, err := c.createOrder(ctx, draft, command)
+	if err != nil {
+		return nil, err
+	}
+
+	// the loaded version of the draft is expected, so a concurrent save of the draft fails the conversion after the
+	// order is created, and the retried conversion completes it
+	_, err = c.draftStore.Store(draft, nil, ctx)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[ConvertOrderDraftHandler_Handle.Store] error in storing order draft aggregate",
+		)
+	}
+
+	c.log.Infow(
+		fmt.Sprintf("[ConvertOrderDraftHandler.Handle] order draft with id: {%s} converted", command.DraftId),
+		logger.Fields{"Id": command.DraftId, "OrderId": response.OrderId},
+	)
+
+	return response, nil
+}
+
+// createOrder creates the order of the draft with the create order command, an order which is created by a previous
+// attempt of the conversion is returned as is
+func (c *ConvertOrderDraftHandler) createOrder(
+	ctx context.Context,
+	draft *draftAggregate.OrderDraft,
+	command *ConvertOrderDraft,
+) (*dtos.ConvertOrderDraftResponseDto, error) {
+	exists, err := c.orderStore.Exists(ctx, draft.Id())
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[ConvertOrderDraftHandler_createOrder.Exists] error in checking order existence",
+		)
+	}
+
+	if exists {
+		order, err := c.orderStore.Load(ctx, draft.Id())
+		if err != nil {
+			return nil, customErrors.NewApplicationErrorWrap(
+				err,
+				"[ConvertOrderDraftHandler_createOrder.Load] error in loading order aggregate",
+			)
+		}
+
+		return &dtos.ConvertOrderDraftResponseDto{
+			DraftId:       draft.Id(),
+			OrderId:       order.Id(),
+			OrderNumber:   order.OrderNumber(),
+			HeldForReview: order.HeldForReview(),
+		}, nil
+	}
+
+	createOrder, err := createOrderCommandV1.NewCreateOrder(
+		draft.ShopItems(),
+		draft.AccountEmail(),
+		draft.DeliveryAddress(),
+		draft.DeliveryTime(),
+		command.GiftCardCode,
+		command.CustomerGroup,
+		"",
+	)
+	if err != nil {
+		return nil, customErrors.NewValidationErrorWrap(
+			err,
+			"[ConvertOrderDraftHandler_createOrder.NewCreateOrder] order draft is not complete",
+		)
+	}
+	createOrder.OrderId = draft.Id()
+
+	result, err := cqrs.Send[*createOrderCommandV1.CreateOrder, *createOrderDtosV1.CreateOrderResponseDto](
+		ctx,
+		createOrder,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(
+			err,
+			"[ConvertOrderDraftHandler_createOrder.Send] error in sending CreateOrder",
+		)
+	}
+
+	return &dtos.ConvertOrderDraftResponseDto{
+		DraftId:       draft.Id(),
+		OrderId:       result.OrderId,
+		OrderNumber:   result.OrderNumber,
+		HeldForReview: result.HeldForReview,
+	}, nil
+}
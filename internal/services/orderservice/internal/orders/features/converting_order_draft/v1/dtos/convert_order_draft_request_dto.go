@@ -0,0 +1,8 @@
+package dtos
+
+import uuid "github.com/satori/go.uuid"
+
+type ConvertOrderDraftRequestDto struct {
+	DraftId      uuid.UUID `json:"-"                      param:"id"`
+	GiftCardCode string    `json:"giftCardCode,omitempty"`
+}
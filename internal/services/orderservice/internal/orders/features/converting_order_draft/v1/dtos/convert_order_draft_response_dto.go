@@ -0,0 +1,11 @@
+package dtos
+
+import uuid "github.com/satori/go.uuid"
+
+type ConvertOrderDraftResponseDto struct {
+	DraftId uuid.UUID `json:"draftId"`
+	// OrderId is the id of the created order, it is the same as the id of the draft
+	OrderId       uuid.UUID `json:"orderId"`
+	OrderNumber   string    `json:"orderNumber"`
+	HeldForReview bool      `json:"heldForReview,omitempty"`
+}
@@ -0,0 +1,98 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/authentication"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	convertOrderDraftCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/converting_order_draft/v1/commands"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/converting_order_draft/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type convertOrderDraftEndpoint struct {
+	params.OrderDraftRouteParams
+}
+
+func NewConvertOrderDraftEndpoint(params params.OrderDraftRouteParams) route.Endpoint {
+	return &convertOrderDraftEndpoint{OrderDraftRouteParams: params}
+}
+
+func (ep *convertOrderDraftEndpoint) MapEndpoint() {
+	ep.OrderDraftsGroup.POST("/:id/convert", ep.handler())
+}
+
+// ConvertOrderDraft
+// @Tags OrderDrafts
+// @Summary Convert order draft
+// @Description Place the order of a complete draft, the order is created with the id of the draft and the draft can't be saved afterwards
+// @Accept json
+// @Produce json
+// @Param id path string true "Order draft ID"
+// @Param ConvertOrderDraftRequestDto body dtos.ConvertOrderDraftRequestDto false "Conversion data"
+// @Success 201 {object} dtos.ConvertOrderDraftResponseDto
+// @Router /api/v1/order-drafts/{id}/convert [post]
+func (ep *convertOrderDraftEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.ConvertOrderDraftRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"[convertOrderDraftEndpoint_handler.Bind] error in the binding request",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[convertOrderDraftEndpoint_handler.Bind] err: %v", badRequestErr),
+			)
+			return badRequestErr
+		}
+
+		customerGroup, _ := authentication.CustomerGroup(ctx)
+
+		command, err := convertOrderDraftCommandsV1.NewConvertOrderDraft(
+			request.DraftId,
+			request.GiftCardCode,
+			customerGroup,
+		)
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"[convertOrderDraftEndpoint_handler.StructCtx] command validation failed",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[convertOrderDraftEndpoint_handler.StructCtx] err: %v", validationErr),
+			)
+			return validationErr
+		}
+
+		result, err := cqrs.Send[*convertOrderDraftCommandsV1.ConvertOrderDraft, *dtos.ConvertOrderDraftResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[convertOrderDraftEndpoint_handler.Send] error in sending ConvertOrderDraft",
+			)
+			ep.Logger.Errorw(
+				fmt.Sprintf(
+					"[convertOrderDraftEndpoint_handler.Send] id: {%s}, err: %v",
+					command.DraftId,
+					err,
+				),
+				logger.Fields{"Id": command.DraftId},
+			)
+			return err
+		}
+
+		return c.JSON(http.StatusCreated, result)
+	}
+}
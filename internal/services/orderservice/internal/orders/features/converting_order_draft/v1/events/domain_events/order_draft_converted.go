@@ -0,0 +1,37 @@
+package domainEvents
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/domain"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// OrderDraftConvertedV1 is applied when the draft is placed as an order, a converted draft can't be saved or converted
+// again
+type OrderDraftConvertedV1 struct {
+	*domain.DomainEvent
+	OrderId     uuid.UUID `json:"orderId"`
+	ConvertedAt time.Time `json:"convertedAt"`
+}
+
+func NewOrderDraftConvertedV1(orderId uuid.UUID, convertedAt time.Time) (*OrderDraftConvertedV1, error) {
+	if orderId == uuid.Nil {
+		return nil, customErrors.NewDomainError("orderId of the converted draft is required")
+	}
+
+	if convertedAt.IsZero() {
+		return nil, customErrors.NewDomainError("convertedAt can't be zero")
+	}
+
+	eventData := &OrderDraftConvertedV1{
+		OrderId:     orderId,
+		ConvertedAt: convertedAt,
+	}
+	eventData.DomainEvent = domain.NewDomainEvent(typeMapper.GetTypeName(eventData))
+
+	return eventData, nil
+}
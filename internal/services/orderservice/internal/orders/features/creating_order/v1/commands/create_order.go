@@ -17,13 +17,23 @@ type CreateOrder struct {
 	AccountEmail    string
 	DeliveryAddress string
 	DeliveryTime    time.Time
-	CreatedAt       time.Time
+	// GiftCardCode is optional, the gift card pays the order total up to its balance
+	GiftCardCode string
+	// CustomerGroup is optional, the prices of the items are resolved from the price lists of the customer group
+	CustomerGroup string
+	// Currency is optional, the prices and the totals of the order are presented in the currency instead of the base
+	// currency
+	Currency  string
+	CreatedAt time.Time
 }
 
 func NewCreateOrder(
 	shopItems []*dtosV1.ShopItemDto,
 	accountEmail, deliveryAddress string,
 	deliveryTime time.Time,
+	giftCardCode string,
+	customerGroup string,
+	currency string,
 ) (*CreateOrder, error) {
 	command := &CreateOrder{
 		OrderId:         uuid.NewV4(),
@@ -31,6 +41,9 @@ func NewCreateOrder(
 		AccountEmail:    accountEmail,
 		DeliveryAddress: deliveryAddress,
 		DeliveryTime:    deliveryTime,
+		GiftCardCode:    giftCardCode,
+		CustomerGroup:   customerGroup,
+		Currency:        currency,
 		CreatedAt:       time.Now(),
 	}
 
@@ -49,6 +62,8 @@ func (c CreateOrder) Validate() error {
 		validation.Field(&c.AccountEmail, validation.Required),
 		validation.Field(&c.DeliveryAddress, validation.Required),
 		validation.Field(&c.DeliveryTime, validation.Required),
+		validation.Field(&c.GiftCardCode, validation.Length(16, 32)),
+		validation.Field(&c.Currency, validation.Length(3, 3)),
 		validation.Field(&c.CreatedAt, validation.Required),
 	)
 }
@@ -3,36 +3,83 @@ package createOrderCommandV1
 import (
 	"context"
 	"fmt"
+	"math"
 
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/store"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/exchangerates"
 	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mapper"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/creating_order/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/fraud"
+	giftCardAggregate "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/giftcards/aggregate"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/aggregate"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/value_objects"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/numbering"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/pricing"
+
+	"emperror.dev/errors"
 )
 
 type CreateOrderHandler struct {
 	log logger.Logger
 	// goland can't detect this generic type, but it is ok in vscode
 	aggregateStore store.AggregateStore[*aggregate.Order]
+	giftCardStore  store.AggregateStore[*giftCardAggregate.GiftCard]
+	fraudScreener  fraud.FraudScreener
+	priceResolver  pricing.PriceResolver
+	orderNumbers   *numbering.OrderNumberGenerator
 	tracer         tracing.AppTracer
 }
 
 func NewCreateOrderHandler(
 	log logger.Logger,
 	aggregateStore store.AggregateStore[*aggregate.Order],
+	giftCardStore store.AggregateStore[*giftCardAggregate.GiftCard],
+	fraudScreener fraud.FraudScreener,
+	priceResolver pricing.PriceResolver,
+	orderNumbers *numbering.OrderNumberGenerator,
 	tracer tracing.AppTracer,
 ) *CreateOrderHandler {
-	return &CreateOrderHandler{log: log, aggregateStore: aggregateStore, tracer: tracer}
+	return &CreateOrderHandler{
+		log:            log,
+		aggregateStore: aggregateStore,
+		giftCardStore:  giftCardStore,
+		fraudScreener:  fraudScreener,
+		priceResolver:  priceResolver,
+		orderNumbers:   orderNumbers,
+		tracer:         tracer,
+	}
 }
 
 func (c *CreateOrderHandler) Handle(
 	ctx context.Context,
 	command *CreateOrder,
 ) (*dtos.CreateOrderResponseDto, error) {
+	presentment, err := c.priceResolver.Presentment(command.Currency)
+	if errors.Is(err, exchangerates.ErrUnknownCurrency) {
+		return nil, customErrors.NewBadRequestErrorWrap(
+			err,
+			"[CreateOrderHandler_Handle.Presentment] currency of the order is not supported",
+		)
+	}
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[CreateOrderHandler_Handle.Presentment] error in getting the exchange rate of the order currency",
+		)
+	}
+
+	// the prices are resolved before creating the order, so the order total and the fraud screening use them
+	err = c.priceResolver.ResolvePrices(ctx, command.CustomerGroup, presentment, command.ShopItems, command.CreatedAt)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[CreateOrderHandler_Handle.ResolvePrices] error in resolving the prices of the shop items",
+		)
+	}
+
 	shopItems, err := mapper.Map[[]*value_objects.ShopItem](command.ShopItems)
 	if err != nil {
 		return nil,
@@ -42,12 +89,23 @@ func (c *CreateOrderHandler) Handle(
 			)
 	}
 
+	orderNumber, err := c.orderNumbers.Next(ctx)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[CreateOrderHandler_Handle.Next] error in generating the order number",
+		)
+	}
+
 	order, err := aggregate.NewOrder(
 		command.OrderId,
+		orderNumber,
 		shopItems,
 		command.AccountEmail,
 		command.DeliveryAddress,
 		command.DeliveryTime,
+		presentment.Currency,
+		presentment.ExchangeRate,
 		command.CreatedAt,
 	)
 	if err != nil {
@@ -57,6 +115,37 @@ func (c *CreateOrderHandler) Handle(
 		)
 	}
 
+	// screening runs before storing the order, so a flagged order is created and held in the same append
+	screening, err := c.fraudScreener.Screen(ctx, order)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[CreateOrderHandler_Handle.Screen] error in fraud screening of the order",
+		)
+	}
+
+	if screening.Hold {
+		err = order.HoldForReview(screening.Reasons, command.CreatedAt)
+		if err != nil {
+			return nil, customErrors.NewApplicationErrorWrap(
+				err,
+				"[CreateOrderHandler_Handle.HoldForReview] error in holding order for review",
+			)
+		}
+
+		c.log.Infow(
+			fmt.Sprintf("[CreateOrderHandler.Handle] order with id: {%s} held for review", command.OrderId),
+			logger.Fields{"Id": command.OrderId, "Reasons": screening.Reasons},
+		)
+	}
+
+	if command.GiftCardCode != "" {
+		err = c.redeemGiftCard(ctx, order, command)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	_, err = c.aggregateStore.Store(order, nil, ctx)
 	if err != nil {
 		return nil, customErrors.NewApplicationErrorWrap(
@@ -65,7 +154,12 @@ func (c *CreateOrderHandler) Handle(
 		)
 	}
 
-	response := &dtos.CreateOrderResponseDto{OrderId: order.Id()}
+	response := &dtos.CreateOrderResponseDto{
+		OrderId:        order.Id(),
+		OrderNumber:    order.OrderNumber(),
+		HeldForReview:  order.HeldForReview(),
+		GiftCardAmount: order.GiftCardAmount(),
+	}
 
 	c.log.Infow(
 		fmt.Sprintf("[CreateOrderHandler.Handle] order with id: {%s} created", command.OrderId),
@@ -74,3 +168,59 @@ func (c *CreateOrderHandler) Handle(
 
 	return response, nil
 }
+
+// redeemGiftCard pays the order total up to the balance of the gift card, the gift card is stored before the order, the
+// redemption of an order which is not stored is refunded by the gift card compensation projection after a delay. the
+// balance of the gift card is in the base currency, so the redeemed amount is applied to the order in its currency.
+func (c *CreateOrderHandler) redeemGiftCard(
+	ctx context.Context,
+	order *aggregate.Order,
+	command *CreateOrder,
+) error {
+	giftCardId := giftCardAggregate.GiftCardId(command.GiftCardCode)
+
+	exists, err := c.giftCardStore.Exists(ctx, giftCardId)
+	if err != nil {
+		return customErrors.NewApplicationErrorWrap(
+			err,
+			"[CreateOrderHandler_redeemGiftCard.Exists] error in checking gift card existence",
+		)
+	}
+	if !exists {
+		return customErrors.NewBadRequestError("[CreateOrderHandler_redeemGiftCard.Exists] gift card code is not valid")
+	}
+
+	giftCard, err := c.giftCardStore.Load(ctx, giftCardId)
+	if err != nil {
+		return customErrors.NewApplicationErrorWrap(
+			err,
+			"[CreateOrderHandler_redeemGiftCard.Load] error in loading gift card aggregate",
+		)
+	}
+
+	amount := math.Min(giftCard.Balance(), order.BaseTotalPrice())
+
+	// the domain errors are kept as is, so an inactive or empty gift card results in a conflict response
+	err = giftCard.Redeem(order.Id(), amount, command.CreatedAt)
+	if err != nil {
+		return errors.WithMessage(err, "[CreateOrderHandler_redeemGiftCard.Redeem] error in redeeming the gift card")
+	}
+
+	err = order.ApplyGiftCard(giftCard.Id(), amount*order.ExchangeRate(), command.CreatedAt)
+	if err != nil {
+		return customErrors.NewApplicationErrorWrap(
+			err,
+			"[CreateOrderHandler_redeemGiftCard.ApplyGiftCard] error in applying the gift card to the order",
+		)
+	}
+
+	_, err = c.giftCardStore.Store(giftCard, nil, ctx)
+	if err != nil {
+		return customErrors.NewApplicationErrorWrap(
+			err,
+			"[CreateOrderHandler_redeemGiftCard.Store] error in storing gift card aggregate",
+		)
+	}
+
+	return nil
+}
@@ -15,4 +15,6 @@ type CreateOrderRequestDto struct {
 	AccountEmail    string                 `json:"accountEmail"`
 	DeliveryAddress string                 `json:"deliveryAddress"`
 	DeliveryTime    customTypes.CustomTime `json:"deliveryTime"`
+	GiftCardCode    string                 `json:"giftCardCode,omitempty"`
+	Currency        string                 `json:"currency,omitempty"`
 }
@@ -5,4 +5,10 @@ import uuid "github.com/satori/go.uuid"
 // https://echo.labstack.com/guide/response/
 type CreateOrderResponseDto struct {
 	OrderId uuid.UUID `json:"Id"`
+	// OrderNumber is the human-readable number of the order, e.g. `ORD-000042`
+	OrderNumber string `json:"orderNumber"`
+	// HeldForReview is true when the fraud screening holds the order for a back-office review
+	HeldForReview bool `json:"heldForReview,omitempty"`
+	// GiftCardAmount is the part of the order total which is paid with the gift card
+	GiftCardAmount float64 `json:"giftCardAmount,omitempty"`
 }
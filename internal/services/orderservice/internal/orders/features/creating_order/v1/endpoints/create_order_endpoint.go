@@ -5,16 +5,19 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/commandbus"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/authentication"
 	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	dtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/dtos/v1"
 	createOrderCommandV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/creating_order/v1/commands"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/creating_order/v1/dtos"
 
 	"emperror.dev/errors"
 	"github.com/labstack/echo/v4"
-	"github.com/mehdihadeli/go-mediatr"
 )
 
 type createOrderEndpoint struct {
@@ -32,11 +35,13 @@ func (ep *createOrderEndpoint) MapEndpoint() {
 // Create Order
 // @Tags Orders
 // @Summary Create order
-// @Description Create new order
+// @Description Create new order, with the `Prefer: respond-async` header the order is enqueued and its status is polled from the returned status url
 // @Accept json
 // @Produce json
 // @Param CreateOrderRequestDto body dtos.CreateOrderRequestDto true "Order data"
+// @Param Prefer header string false "respond-async to enqueue the order"
 // @Success 201 {object} dtos.CreateOrderResponseDto
+// @Success 202 {object} dtosV1.AcceptedCommandDto
 // @Router /api/v1/orders [post]
 func (ep *createOrderEndpoint) handler() echo.HandlerFunc {
 	return func(c echo.Context) error {
@@ -55,11 +60,17 @@ func (ep *createOrderEndpoint) handler() echo.HandlerFunc {
 			return badRequestErr
 		}
 
+		// the customer group comes from the credentials, so customers can't choose the prices of another group
+		customerGroup, _ := authentication.CustomerGroup(ctx)
+
 		command, err := createOrderCommandV1.NewCreateOrder(
 			request.ShopItems,
 			request.AccountEmail,
 			request.DeliveryAddress,
 			time.Time(request.DeliveryTime),
+			request.GiftCardCode,
+			customerGroup,
+			request.Currency,
 		)
 		if err != nil {
 			validationErr := customErrors.NewValidationErrorWrap(
@@ -72,7 +83,11 @@ func (ep *createOrderEndpoint) handler() echo.HandlerFunc {
 			return validationErr
 		}
 
-		result, err := mediatr.Send[*createOrderCommandV1.CreateOrder, *dtos.CreateOrderResponseDto](
+		if commandbus.IsRespondAsyncPreferred(c.Request().Header.Get(commandbus.PreferHeader)) {
+			return ep.accept(c, command)
+		}
+
+		result, err := cqrs.Send[*createOrderCommandV1.CreateOrder, *dtos.CreateOrderResponseDto](
 			ctx,
 			command,
 		)
@@ -95,3 +110,29 @@ func (ep *createOrderEndpoint) handler() echo.HandlerFunc {
 		return c.JSON(http.StatusCreated, result)
 	}
 }
+
+// accept enqueues the command with the command bus, so the slow orders under load don't hold the request
+func (ep *createOrderEndpoint) accept(c echo.Context, command *createOrderCommandV1.CreateOrder) error {
+	trackingId, err := ep.CommandBus.Enqueue(c.Request().Context(), command)
+	if err != nil {
+		err = errors.WithMessage(
+			err,
+			"[createOrderEndpoint_accept.Enqueue] error in enqueuing CreateOrder",
+		)
+		ep.Logger.Errorw(
+			fmt.Sprintf(
+				"[createOrderEndpoint_accept.Enqueue] id: {%s}, err: %v",
+				command.OrderId,
+				err,
+			),
+			logger.Fields{"Id": command.OrderId},
+		)
+		return err
+	}
+
+	// the route of the create endpoint is the root of the orders group
+	statusUrl := fmt.Sprintf("%s/commands/%s", c.Path(), trackingId)
+	c.Response().Header().Set(echo.HeaderLocation, statusUrl)
+
+	return c.JSON(http.StatusAccepted, &dtosV1.AcceptedCommandDto{TrackingId: trackingId, StatusUrl: statusUrl})
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/domain"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/upcasting"
 	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
 	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
 	dtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/dtos/v1"
@@ -12,21 +13,40 @@ import (
 	uuid "github.com/satori/go.uuid"
 )
 
+// OrderCreatedV1 has the schema version 2, its `order_id` field is renamed to `orderId`, the events of the first
+// schema version are upcasted by NewOrderCreatedV1Upcaster
 type OrderCreatedV1 struct {
 	*domain.DomainEvent
-	OrderId         uuid.UUID             `json:"order_id"`
+	OrderId         uuid.UUID             `json:"orderId"`
+	OrderNumber     string                `json:"orderNumber"     bson:"orderNumber,omitempty"`
 	ShopItems       []*dtosV1.ShopItemDto `json:"shopItems"       bson:"shopItems,omitempty"`
 	AccountEmail    string                `json:"accountEmail"    bson:"accountEmail,omitempty"`
 	DeliveryAddress string                `json:"deliveryAddress" bson:"deliveryAddress,omitempty"`
 	CreatedAt       time.Time             `json:"createdAt"       bson:"createdAt,omitempty"`
 	DeliveredTime   time.Time             `json:"deliveredTime"   bson:"deliveredTime,omitempty"`
+	// Currency is the presentment currency of the prices, the orders in the base currency don't have it
+	Currency string `json:"currency,omitempty"     bson:"currency,omitempty"`
+	// ExchangeRate is the amount of the presentment currency for one unit of the base currency at the order creation
+	ExchangeRate float64 `json:"exchangeRate,omitempty" bson:"exchangeRate,omitempty"`
+}
+
+func (o *OrderCreatedV1) SchemaVersion() int {
+	return 2
+}
+
+// NewOrderCreatedV1Upcaster renames the `order_id` field of the events of the first schema version
+func NewOrderCreatedV1Upcaster() upcasting.Upcaster {
+	return upcasting.RenameField[*OrderCreatedV1](1, "order_id", "orderId")
 }
 
 func NewOrderCreatedEventV1(
 	aggregateId uuid.UUID,
+	orderNumber string,
 	shopItems []*dtosV1.ShopItemDto,
 	accountEmail, deliveryAddress string,
 	deliveredTime time.Time,
+	currency string,
+	exchangeRate float64,
 	createdAt time.Time,
 ) (*OrderCreatedV1, error) {
 	if shopItems == nil || len(shopItems) == 0 {
@@ -49,13 +69,20 @@ func NewOrderCreatedEventV1(
 		return nil, customErrors.NewDomainError("deliveredTime can't be zero")
 	}
 
+	if currency != "" && exchangeRate <= 0 {
+		return nil, customErrors.NewDomainError("exchangeRate of the currency should be greater than zero")
+	}
+
 	eventData := &OrderCreatedV1{
 		ShopItems:       shopItems,
 		OrderId:         aggregateId,
+		OrderNumber:     orderNumber,
 		AccountEmail:    accountEmail,
 		DeliveryAddress: deliveryAddress,
 		CreatedAt:       createdAt,
 		DeliveredTime:   deliveredTime,
+		Currency:        currency,
+		ExchangeRate:    exchangeRate,
 	}
 
 	eventData.DomainEvent = domain.NewDomainEvent(typeMapper.GetTypeName(eventData))
@@ -0,0 +1,73 @@
+package exportFinanceEntriesCommandsV1
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/finance/read_models"
+
+	"emperror.dev/errors"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// ReplayFinanceEntries makes the entries of a batch, a status or an occurrence period pending again, so they are
+// exported again by the finance export worker, e.g. after the ERP lost an import
+type ReplayFinanceEntries struct {
+	BatchId    string
+	Status     string
+	From       time.Time
+	To         time.Time
+	ReplayedBy string
+}
+
+func NewReplayFinanceEntries(
+	batchId string,
+	status string,
+	from time.Time,
+	to time.Time,
+	replayedBy string,
+) (*ReplayFinanceEntries, error) {
+	command := &ReplayFinanceEntries{
+		BatchId:    batchId,
+		Status:     status,
+		From:       from,
+		To:         to,
+		ReplayedBy: replayedBy,
+	}
+
+	err := command.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+func (c ReplayFinanceEntries) Validate() error {
+	// a replay without filter would export all the entries again
+	filtered := c.BatchId != "" || c.Status != "" || !c.From.IsZero() || !c.To.IsZero()
+
+	return validation.ValidateStruct(&c,
+		validation.Field(
+			&c.BatchId,
+			validation.By(func(interface{}) error {
+				if !filtered {
+					return errors.New("batchId, status or period is required")
+				}
+
+				return nil
+			}),
+		),
+		validation.Field(&c.Status, validation.In(
+			read_models.FinanceEntryStatusExported,
+			read_models.FinanceEntryStatusFailed,
+		)),
+		validation.Field(&c.To, validation.By(func(interface{}) error {
+			if !c.From.IsZero() && !c.To.IsZero() && !c.To.After(c.From) {
+				return errors.New("to should be after from")
+			}
+
+			return nil
+		})),
+		validation.Field(&c.ReplayedBy, validation.Required),
+	)
+}
@@ -0,0 +1,55 @@
+package exportFinanceEntriesCommandsV1
+
+import (
+	"context"
+	"fmt"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/repositories"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/exporting_finance_entries/v1/dtos"
+)
+
+type ReplayFinanceEntriesHandler struct {
+	log                    logger.Logger
+	financeEntryRepository repositories.FinanceEntryRepository
+	tracer                 tracing.AppTracer
+}
+
+func NewReplayFinanceEntriesHandler(
+	log logger.Logger,
+	financeEntryRepository repositories.FinanceEntryRepository,
+	tracer tracing.AppTracer,
+) *ReplayFinanceEntriesHandler {
+	return &ReplayFinanceEntriesHandler{
+		log:                    log,
+		financeEntryRepository: financeEntryRepository,
+		tracer:                 tracer,
+	}
+}
+
+func (c *ReplayFinanceEntriesHandler) Handle(
+	ctx context.Context,
+	command *ReplayFinanceEntries,
+) (*dtos.ReplayFinanceEntriesResponseDto, error) {
+	replayed, err := c.financeEntryRepository.ReplayFinanceEntries(ctx, &repositories.FinanceEntriesReplayFilter{
+		BatchId: command.BatchId,
+		Status:  command.Status,
+		From:    command.From,
+		To:      command.To,
+	})
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[ReplayFinanceEntriesHandler_Handle.ReplayFinanceEntries] error in replaying finance entries in the repository",
+		)
+	}
+
+	c.log.Infow(
+		fmt.Sprintf("[ReplayFinanceEntriesHandler.Handle] %d finance entries replayed", replayed),
+		logger.Fields{"BatchId": command.BatchId, "Status": command.Status, "ReplayedBy": command.ReplayedBy},
+	)
+
+	return &dtos.ReplayFinanceEntriesResponseDto{Replayed: replayed}, nil
+}
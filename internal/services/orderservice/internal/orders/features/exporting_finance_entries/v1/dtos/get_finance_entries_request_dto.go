@@ -0,0 +1,8 @@
+package dtos
+
+import "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils"
+
+type GetFinanceEntriesRequestDto struct {
+	Status           string `json:"status"    query:"status"`
+	*utils.ListQuery `       json:"listQuery"`
+}
@@ -0,0 +1,11 @@
+package dtos
+
+import "time"
+
+// ReplayFinanceEntriesRequestDto selects the entries to export again by their batch, status or occurrence period
+type ReplayFinanceEntriesRequestDto struct {
+	BatchId string    `json:"batchId"`
+	Status  string    `json:"status"`
+	From    time.Time `json:"from"`
+	To      time.Time `json:"to"`
+}
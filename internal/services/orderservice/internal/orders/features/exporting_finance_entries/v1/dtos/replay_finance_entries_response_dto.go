@@ -0,0 +1,5 @@
+package dtos
+
+type ReplayFinanceEntriesResponseDto struct {
+	Replayed int64 `json:"replayed"`
+}
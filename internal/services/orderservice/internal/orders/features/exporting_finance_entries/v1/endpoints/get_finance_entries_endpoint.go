@@ -0,0 +1,92 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/exporting_finance_entries/v1/dtos"
+	exportFinanceEntriesQueriesV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/exporting_finance_entries/v1/queries"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type getFinanceEntriesEndpoint struct {
+	params.BackOfficeRouteParams
+}
+
+func NewGetFinanceEntriesEndpoint(params params.BackOfficeRouteParams) route.Endpoint {
+	return &getFinanceEntriesEndpoint{BackOfficeRouteParams: params}
+}
+
+func (ep *getFinanceEntriesEndpoint) MapEndpoint() {
+	ep.BackOfficeFinanceGroup.GET("/entries", ep.handler())
+}
+
+// GetFinanceEntries
+// @Tags BackOffice
+// @Summary Get finance entries
+// @Description Get the finance entries of the ERP export with their delivery state, like `pending`, `exported` or `failed`
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param getFinanceEntriesRequestDto query dtos.GetFinanceEntriesRequestDto false "GetFinanceEntriesRequestDto"
+// @Success 200 {object} dtos.GetFinanceEntriesResponseDto
+// @Router /api/v1/backoffice/finance/entries [get]
+func (ep *getFinanceEntriesEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		listQuery, err := utils.GetListQueryFromCtx(c)
+		if err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"[getFinanceEntriesEndpoint_handler.GetListQueryFromCtx] error in getting data from query string",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[getFinanceEntriesEndpoint_handler.GetListQueryFromCtx] err: %v", badRequestErr),
+			)
+			return badRequestErr
+		}
+
+		request := &dtos.GetFinanceEntriesRequestDto{ListQuery: listQuery}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"[getFinanceEntriesEndpoint_handler.Bind] error in the binding request",
+			)
+			ep.Logger.Errorf(fmt.Sprintf("[getFinanceEntriesEndpoint_handler.Bind] err: %v", badRequestErr))
+			return badRequestErr
+		}
+
+		query, err := exportFinanceEntriesQueriesV1.NewGetFinanceEntries(request.Status, request.ListQuery)
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"[getFinanceEntriesEndpoint_handler.StructCtx] query validation failed",
+			)
+			ep.Logger.Errorf("[getFinanceEntriesEndpoint_handler.StructCtx] err: %v", validationErr)
+			return validationErr
+		}
+
+		queryResult, err := cqrs.Send[*exportFinanceEntriesQueriesV1.GetFinanceEntries, *dtos.GetFinanceEntriesResponseDto](
+			ctx,
+			query,
+		)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[getFinanceEntriesEndpoint_handler.Send] error in sending GetFinanceEntries",
+			)
+			ep.Logger.Error(fmt.Sprintf("[getFinanceEntriesEndpoint_handler.Send] err: {%v}", err))
+			return err
+		}
+
+		return c.JSON(http.StatusOK, queryResult)
+	}
+}
@@ -0,0 +1,90 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	apikey "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/api_key"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	exportFinanceEntriesCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/exporting_finance_entries/v1/commands"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/exporting_finance_entries/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type replayFinanceEntriesEndpoint struct {
+	params.BackOfficeRouteParams
+}
+
+func NewReplayFinanceEntriesEndpoint(params params.BackOfficeRouteParams) route.Endpoint {
+	return &replayFinanceEntriesEndpoint{BackOfficeRouteParams: params}
+}
+
+func (ep *replayFinanceEntriesEndpoint) MapEndpoint() {
+	ep.BackOfficeFinanceGroup.POST("/entries/replay", ep.handler())
+}
+
+// ReplayFinanceEntries
+// @Tags BackOffice
+// @Summary Replay finance entries
+// @Description Export the entries of a batch, a status or a period to the ERP again, the ERP deduplicates the entries by their id
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param ReplayFinanceEntriesRequestDto body dtos.ReplayFinanceEntriesRequestDto true "Replay data"
+// @Success 202 {object} dtos.ReplayFinanceEntriesResponseDto
+// @Router /api/v1/backoffice/finance/entries/replay [post]
+func (ep *replayFinanceEntriesEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.ReplayFinanceEntriesRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"[replayFinanceEntriesEndpoint_handler.Bind] error in the binding request",
+			)
+			ep.Logger.Errorf(fmt.Sprintf("[replayFinanceEntriesEndpoint_handler.Bind] err: %v", badRequestErr))
+			return badRequestErr
+		}
+
+		userId, _ := apikey.UserId(ctx)
+
+		command, err := exportFinanceEntriesCommandsV1.NewReplayFinanceEntries(
+			request.BatchId,
+			request.Status,
+			request.From,
+			request.To,
+			userId,
+		)
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"[replayFinanceEntriesEndpoint_handler.StructCtx] command validation failed",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[replayFinanceEntriesEndpoint_handler.StructCtx] err: %v", validationErr),
+			)
+			return validationErr
+		}
+
+		result, err := cqrs.Send[*exportFinanceEntriesCommandsV1.ReplayFinanceEntries, *dtos.ReplayFinanceEntriesResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[replayFinanceEntriesEndpoint_handler.Send] error in sending ReplayFinanceEntries",
+			)
+			ep.Logger.Error(fmt.Sprintf("[replayFinanceEntriesEndpoint_handler.Send] err: {%v}", err))
+			return err
+		}
+
+		return c.JSON(http.StatusAccepted, result)
+	}
+}
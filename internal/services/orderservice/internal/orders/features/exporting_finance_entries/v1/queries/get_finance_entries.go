@@ -0,0 +1,33 @@
+package exportFinanceEntriesQueriesV1
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/finance/read_models"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// GetFinanceEntries returns the finance entries with their delivery state, all the entries are returned without status
+type GetFinanceEntries struct {
+	Status string
+	*utils.ListQuery
+}
+
+func NewGetFinanceEntries(status string, query *utils.ListQuery) (*GetFinanceEntries, error) {
+	getFinanceEntries := &GetFinanceEntries{Status: status, ListQuery: query}
+
+	err := getFinanceEntries.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return getFinanceEntries, nil
+}
+
+func (q GetFinanceEntries) Validate() error {
+	return validation.ValidateStruct(&q, validation.Field(&q.Status, validation.In(
+		read_models.FinanceEntryStatusPending,
+		read_models.FinanceEntryStatusExported,
+		read_models.FinanceEntryStatusFailed,
+	)))
+}
@@ -0,0 +1,56 @@
+package exportFinanceEntriesQueriesV1
+
+import (
+	"context"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/repositories"
+	dtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/dtos/v1"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/exporting_finance_entries/v1/dtos"
+)
+
+type GetFinanceEntriesHandler struct {
+	log                    logger.Logger
+	financeEntryRepository repositories.FinanceEntryRepository
+	tracer                 tracing.AppTracer
+}
+
+func NewGetFinanceEntriesHandler(
+	log logger.Logger,
+	financeEntryRepository repositories.FinanceEntryRepository,
+	tracer tracing.AppTracer,
+) *GetFinanceEntriesHandler {
+	return &GetFinanceEntriesHandler{
+		log:                    log,
+		financeEntryRepository: financeEntryRepository,
+		tracer:                 tracer,
+	}
+}
+
+func (c *GetFinanceEntriesHandler) Handle(
+	ctx context.Context,
+	query *GetFinanceEntries,
+) (*dtos.GetFinanceEntriesResponseDto, error) {
+	entries, err := c.financeEntryRepository.GetFinanceEntries(ctx, query.Status, query.ListQuery)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[GetFinanceEntriesHandler_Handle.GetFinanceEntries] error in getting finance entries in the repository",
+		)
+	}
+
+	listResultDto, err := utils.ListResultToListResultDto[*dtosV1.FinanceEntryDto](entries)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[GetFinanceEntriesHandler_Handle.ListResultToListResultDto] error in the mapping ListResultToListResultDto",
+		)
+	}
+
+	c.log.Info("[GetFinanceEntriesHandler.Handle] finance entries fetched")
+
+	return &dtos.GetFinanceEntriesResponseDto{Entries: listResultDto}, nil
+}
@@ -0,0 +1,14 @@
+package fulfillOrderCommandsV1
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+)
+
+// HandleOrderFulfillmentReply passes a reply of a participant of the order fulfillment saga to the saga
+type HandleOrderFulfillmentReply struct {
+	ConsumeContext types.MessageConsumeContext
+}
+
+func NewHandleOrderFulfillmentReply(consumeContext types.MessageConsumeContext) *HandleOrderFulfillmentReply {
+	return &HandleOrderFulfillmentReply{ConsumeContext: consumeContext}
+}
@@ -0,0 +1,60 @@
+package fulfillOrderCommandsV1
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/utils"
+
+	"emperror.dev/errors"
+	"github.com/mehdihadeli/go-mediatr"
+	attribute2 "go.opentelemetry.io/otel/attribute"
+)
+
+// ReplyHandler is the orchestrator of the order fulfillment saga, the saga depends on the replies of this feature, so
+// the handler doesn't depend on the saga package
+type ReplyHandler interface {
+	Handle(ctx context.Context, consumeContext types.MessageConsumeContext) error
+}
+
+type HandleOrderFulfillmentReplyHandler struct {
+	log          logger.Logger
+	orchestrator ReplyHandler
+	tracer       tracing.AppTracer
+}
+
+func NewHandleOrderFulfillmentReplyHandler(
+	log logger.Logger,
+	orchestrator ReplyHandler,
+	tracer tracing.AppTracer,
+) *HandleOrderFulfillmentReplyHandler {
+	return &HandleOrderFulfillmentReplyHandler{
+		log:          log,
+		orchestrator: orchestrator,
+		tracer:       tracer,
+	}
+}
+
+func (h *HandleOrderFulfillmentReplyHandler) Handle(
+	ctx context.Context,
+	command *HandleOrderFulfillmentReply,
+) (*mediatr.Unit, error) {
+	ctx, span := h.tracer.Start(ctx, "HandleOrderFulfillmentReplyHandler.Handle")
+	span.SetAttributes(attribute2.String("CorrelationId", command.ConsumeContext.CorrelationId()))
+	defer span.End()
+
+	err := h.orchestrator.Handle(ctx, command.ConsumeContext)
+	if err != nil {
+		return nil, utils.TraceStatusFromSpan(
+			span,
+			errors.WithMessage(
+				err,
+				"[HandleOrderFulfillmentReplyHandler_Handle.Handle] error in handling the order fulfillment reply",
+			),
+		)
+	}
+
+	return &mediatr.Unit{}, nil
+}
@@ -0,0 +1,39 @@
+package domainEvents
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/domain"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// OrderPaidV1 is raised by the order fulfillment saga after the stock of the order is reserved and its payment is
+// completed, the amounts are kept in the event, so the projections of a replay get the amounts of the payment instead
+// of the current state of the order
+type OrderPaidV1 struct {
+	*domain.DomainEvent
+	PaymentId uuid.UUID `json:"paymentId"`
+	// Amount is the paid amount without the gift card redemption of the order
+	Amount         float64   `json:"amount"`
+	GiftCardAmount float64   `json:"giftCardAmount"`
+	PaidAt         time.Time `json:"paidAt"`
+}
+
+func NewOrderPaidV1(paymentId uuid.UUID, amount float64, giftCardAmount float64, paidAt time.Time) (*OrderPaidV1, error) {
+	if paidAt.IsZero() {
+		return nil, customErrors.NewDomainError("paidAt can't be zero")
+	}
+
+	eventData := &OrderPaidV1{
+		PaymentId:      paymentId,
+		Amount:         amount,
+		GiftCardAmount: giftCardAmount,
+		PaidAt:         paidAt,
+	}
+	eventData.DomainEvent = domain.NewDomainEvent(typeMapper.GetTypeName(eventData))
+
+	return eventData, nil
+}
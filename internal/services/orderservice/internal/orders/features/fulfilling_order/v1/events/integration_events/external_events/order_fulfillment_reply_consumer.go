@@ -0,0 +1,53 @@
+package externalEvents
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/attribute"
+	fulfillOrderCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/fulfilling_order/v1/commands"
+
+	"emperror.dev/errors"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type orderFulfillmentReplyConsumer struct {
+	logger logger.Logger
+	tracer tracing.AppTracer
+}
+
+// NewOrderFulfillmentReplyConsumer passes the replies of the catalogs and the payments services to the order fulfillment
+// saga, the replies are sent with the mediator because the saga publishes its commands with the bus of this consumer
+func NewOrderFulfillmentReplyConsumer(logger logger.Logger, tracer tracing.AppTracer) consumer.ConsumerHandler {
+	return &orderFulfillmentReplyConsumer{logger: logger, tracer: tracer}
+}
+
+func (c *orderFulfillmentReplyConsumer) Handle(
+	ctx context.Context,
+	consumeContext types.MessageConsumeContext,
+) error {
+	ctx, span := c.tracer.Start(ctx, "orderFulfillmentReplyConsumer.Handle")
+	span.SetAttributes(attribute.Object("Message", consumeContext.Message()))
+	defer span.End()
+
+	_, err := cqrs.Send[*fulfillOrderCommandsV1.HandleOrderFulfillmentReply, *mediatr.Unit](
+		ctx,
+		fulfillOrderCommandsV1.NewHandleOrderFulfillmentReply(consumeContext),
+	)
+	if err != nil {
+		return errors.WithMessage(
+			err,
+			fmt.Sprintf(
+				"error in handling order fulfillment reply with correlation id: {%s}",
+				consumeContext.CorrelationId(),
+			),
+		)
+	}
+
+	return nil
+}
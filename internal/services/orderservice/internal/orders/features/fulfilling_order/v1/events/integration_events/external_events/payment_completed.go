@@ -0,0 +1,13 @@
+package externalEvents
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+)
+
+// PaymentCompletedV1 is the reply of the payments service to the order fulfillment saga, its correlation id is
+// the id of the command
+type PaymentCompletedV1 struct {
+	*types.Message
+	OrderId   string `json:"orderId"`
+	PaymentId string `json:"paymentId"`
+}
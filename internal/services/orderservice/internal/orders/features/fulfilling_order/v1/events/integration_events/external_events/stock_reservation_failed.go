@@ -0,0 +1,13 @@
+package externalEvents
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+)
+
+// StockReservationFailedV1 is the reply of the catalogs service to the order fulfillment saga, its correlation id is
+// the id of the command
+type StockReservationFailedV1 struct {
+	*types.Message
+	OrderId string `json:"orderId"`
+	Reason  string `json:"reason"`
+}
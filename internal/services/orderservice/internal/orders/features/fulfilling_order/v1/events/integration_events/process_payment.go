@@ -0,0 +1,25 @@
+package integrationEvents
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// ProcessPaymentV1 is sent to the payments service by the order fulfillment saga, it replies with PaymentCompletedV1 or
+// PaymentFailedV1 with the id of this message as the correlation id
+type ProcessPaymentV1 struct {
+	*types.Message
+	OrderId  string  `json:"orderId"`
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+func NewProcessPaymentV1(orderId string, amount float64, currency string) *ProcessPaymentV1 {
+	return &ProcessPaymentV1{
+		Message:  types.NewMessage(uuid.NewV4().String()),
+		OrderId:  orderId,
+		Amount:   amount,
+		Currency: currency,
+	}
+}
@@ -0,0 +1,23 @@
+package integrationEvents
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// RefundPaymentV1 compensates the payment of an order, the payment id is empty when the payment is timed out, so the
+// payments service refunds the payments of the order
+type RefundPaymentV1 struct {
+	*types.Message
+	OrderId   string `json:"orderId"`
+	PaymentId string `json:"paymentId,omitempty"`
+}
+
+func NewRefundPaymentV1(orderId string, paymentId string) *RefundPaymentV1 {
+	return &RefundPaymentV1{
+		Message:   types.NewMessage(uuid.NewV4().String()),
+		OrderId:   orderId,
+		PaymentId: paymentId,
+	}
+}
@@ -0,0 +1,23 @@
+package integrationEvents
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// ReleaseStockV1 compensates the stock reservation of an order, the reservation id is empty when the reservation is
+// timed out, so the catalogs service releases the reservations of the order
+type ReleaseStockV1 struct {
+	*types.Message
+	OrderId       string `json:"orderId"`
+	ReservationId string `json:"reservationId,omitempty"`
+}
+
+func NewReleaseStockV1(orderId string, reservationId string) *ReleaseStockV1 {
+	return &ReleaseStockV1{
+		Message:       types.NewMessage(uuid.NewV4().String()),
+		OrderId:       orderId,
+		ReservationId: reservationId,
+	}
+}
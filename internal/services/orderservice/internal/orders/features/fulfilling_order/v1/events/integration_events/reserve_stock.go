@@ -0,0 +1,28 @@
+package integrationEvents
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type StockItem struct {
+	ProductId string `json:"productId"`
+	Quantity  uint64 `json:"quantity"`
+}
+
+// ReserveStockV1 is sent to the catalogs service by the order fulfillment saga, it replies with StockReservedV1 or
+// StockReservationFailedV1 with the id of this message as the correlation id
+type ReserveStockV1 struct {
+	*types.Message
+	OrderId string       `json:"orderId"`
+	Items   []*StockItem `json:"items"`
+}
+
+func NewReserveStockV1(orderId string, items []*StockItem) *ReserveStockV1 {
+	return &ReserveStockV1{
+		Message: types.NewMessage(uuid.NewV4().String()),
+		OrderId: orderId,
+		Items:   items,
+	}
+}
@@ -0,0 +1,5 @@
+package dtos
+
+type GetCommandStatusRequestDto struct {
+	TrackingId string `json:"-" param:"trackingId"`
+}
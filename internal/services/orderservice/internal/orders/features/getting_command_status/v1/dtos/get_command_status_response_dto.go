@@ -0,0 +1,15 @@
+package dtos
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type GetCommandStatusResponseDto struct {
+	TrackingId string          `json:"trackingId"`
+	Status     string          `json:"status"`
+	Result     json.RawMessage `json:"result,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	AcceptedAt time.Time       `json:"acceptedAt"`
+	UpdatedAt  time.Time       `json:"updatedAt"`
+}
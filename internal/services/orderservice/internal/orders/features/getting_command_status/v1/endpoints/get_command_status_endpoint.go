@@ -0,0 +1,80 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_command_status/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_command_status/v1/queries"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type getCommandStatusEndpoint struct {
+	params.OrderRouteParams
+}
+
+func NewGetCommandStatusEndpoint(params params.OrderRouteParams) route.Endpoint {
+	return &getCommandStatusEndpoint{OrderRouteParams: params}
+}
+
+func (ep *getCommandStatusEndpoint) MapEndpoint() {
+	ep.OrdersGroup.GET("/commands/:trackingId", ep.handler())
+}
+
+// GetCommandStatus
+// @Tags Orders
+// @Summary Get command status
+// @Description Get the status of an accepted command with its tracking id, the result of the command is returned when it is succeeded
+// @Accept json
+// @Produce json
+// @Param trackingId path string true "Command tracking id"
+// @Success 200 {object} dtos.GetCommandStatusResponseDto
+// @Router /api/v1/orders/commands/{trackingId} [get]
+func (ep *getCommandStatusEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.GetCommandStatusRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"[getCommandStatusEndpoint_handler.Bind] error in the binding request",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[getCommandStatusEndpoint_handler.Bind] err: %v", badRequestErr),
+			)
+			return badRequestErr
+		}
+
+		query, err := queries.NewGetCommandStatus(request.TrackingId)
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"[getCommandStatusEndpoint_handler.StructCtx] query validation failed",
+			)
+			ep.Logger.Errorf("[getCommandStatusEndpoint_handler.StructCtx] err: %v", validationErr)
+			return validationErr
+		}
+
+		queryResult, err := cqrs.Send[*queries.GetCommandStatus, *dtos.GetCommandStatusResponseDto](
+			ctx,
+			query,
+		)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[getCommandStatusEndpoint_handler.Send] error in sending GetCommandStatus",
+			)
+			ep.Logger.Error(fmt.Sprintf("[getCommandStatusEndpoint_handler.Send] err: {%v}", err))
+			return err
+		}
+
+		return c.JSON(http.StatusOK, queryResult)
+	}
+}
@@ -0,0 +1,25 @@
+package queries
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+)
+
+type GetCommandStatus struct {
+	TrackingId string
+}
+
+func NewGetCommandStatus(trackingId string) (*GetCommandStatus, error) {
+	query := &GetCommandStatus{TrackingId: trackingId}
+
+	err := query.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return query, nil
+}
+
+func (q GetCommandStatus) Validate() error {
+	return validation.ValidateStruct(&q, validation.Field(&q.TrackingId, validation.Required, is.UUIDv4))
+}
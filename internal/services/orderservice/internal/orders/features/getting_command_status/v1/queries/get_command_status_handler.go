@@ -0,0 +1,64 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/commandbus"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_command_status/v1/dtos"
+
+	"emperror.dev/errors"
+)
+
+// GetCommandStatusHandler reads the status of a command which is enqueued by the command bus
+type GetCommandStatusHandler struct {
+	log        logger.Logger
+	commandBus commandbus.CommandBus
+	tracer     tracing.AppTracer
+}
+
+func NewGetCommandStatusHandler(
+	log logger.Logger,
+	commandBus commandbus.CommandBus,
+	tracer tracing.AppTracer,
+) *GetCommandStatusHandler {
+	return &GetCommandStatusHandler{
+		log:        log,
+		commandBus: commandBus,
+		tracer:     tracer,
+	}
+}
+
+func (q *GetCommandStatusHandler) Handle(
+	ctx context.Context,
+	query *GetCommandStatus,
+) (*dtos.GetCommandStatusResponseDto, error) {
+	trackedCommand, err := q.commandBus.Status(ctx, query.TrackingId)
+	if customErrors.IsNotFoundError(err) {
+		return nil, errors.WithMessage(err, "[GetCommandStatusHandler_Handle.Status] command not found")
+	}
+
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[GetCommandStatusHandler_Handle.Status] error in getting the command status",
+		)
+	}
+
+	q.log.Infow(
+		fmt.Sprintf("[GetCommandStatusHandler.Handle] status of command with tracking id: {%s} fetched", query.TrackingId),
+		logger.Fields{"TrackingId": query.TrackingId},
+	)
+
+	return &dtos.GetCommandStatusResponseDto{
+		TrackingId: trackedCommand.TrackingId,
+		Status:     string(trackedCommand.Status),
+		Result:     trackedCommand.Result,
+		Error:      trackedCommand.Error,
+		AcceptedAt: trackedCommand.AcceptedAt,
+		UpdatedAt:  trackedCommand.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,5 @@
+package dtos
+
+type GetCustomerSegmentsRequestDto struct {
+	AccountEmail string `json:"-" param:"email"`
+}
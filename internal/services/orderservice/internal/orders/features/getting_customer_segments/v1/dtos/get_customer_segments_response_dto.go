@@ -0,0 +1,7 @@
+package dtos
+
+import dtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/dtos/v1"
+
+type GetCustomerSegmentsResponseDto struct {
+	Customer *dtosV1.CustomerSegmentsDto `json:"customer"`
+}
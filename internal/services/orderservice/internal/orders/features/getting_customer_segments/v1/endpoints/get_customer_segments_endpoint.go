@@ -0,0 +1,81 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_customer_segments/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_customer_segments/v1/queries"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type getCustomerSegmentsEndpoint struct {
+	params.BackOfficeRouteParams
+}
+
+func NewGetCustomerSegmentsEndpoint(params params.BackOfficeRouteParams) route.Endpoint {
+	return &getCustomerSegmentsEndpoint{BackOfficeRouteParams: params}
+}
+
+func (ep *getCustomerSegmentsEndpoint) MapEndpoint() {
+	ep.BackOfficeCustomersGroup.GET("/:email/segments", ep.handler())
+}
+
+// GetCustomerSegments
+// @Tags BackOffice
+// @Summary Get customer segments
+// @Description Get the marketing segments of the customer
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param email path string true "Customer account email"
+// @Success 200 {object} dtos.GetCustomerSegmentsResponseDto
+// @Router /api/v1/backoffice/customers/{email}/segments [get]
+func (ep *getCustomerSegmentsEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.GetCustomerSegmentsRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"[getCustomerSegmentsEndpoint_handler.Bind] error in the binding request",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[getCustomerSegmentsEndpoint_handler.Bind] err: %v", badRequestErr),
+			)
+			return badRequestErr
+		}
+
+		query, err := queries.NewGetCustomerSegments(request.AccountEmail)
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"[getCustomerSegmentsEndpoint_handler.StructCtx] query validation failed",
+			)
+			ep.Logger.Errorf("[getCustomerSegmentsEndpoint_handler.StructCtx] err: %v", validationErr)
+			return validationErr
+		}
+
+		queryResult, err := cqrs.Send[*queries.GetCustomerSegments, *dtos.GetCustomerSegmentsResponseDto](
+			ctx,
+			query,
+		)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[getCustomerSegmentsEndpoint_handler.Send] error in sending GetCustomerSegments",
+			)
+			ep.Logger.Error(fmt.Sprintf("[getCustomerSegmentsEndpoint_handler.Send] err: {%v}", err))
+			return err
+		}
+
+		return c.JSON(http.StatusOK, queryResult)
+	}
+}
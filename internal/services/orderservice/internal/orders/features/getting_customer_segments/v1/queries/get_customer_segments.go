@@ -0,0 +1,25 @@
+package queries
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+)
+
+type GetCustomerSegments struct {
+	AccountEmail string
+}
+
+func NewGetCustomerSegments(accountEmail string) (*GetCustomerSegments, error) {
+	query := &GetCustomerSegments{AccountEmail: accountEmail}
+
+	err := query.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return query, nil
+}
+
+func (q GetCustomerSegments) Validate() error {
+	return validation.ValidateStruct(&q, validation.Field(&q.AccountEmail, validation.Required, is.Email))
+}
@@ -0,0 +1,66 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mapper"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/repositories"
+	dtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/dtos/v1"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_customer_segments/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/segments/read_models"
+)
+
+type GetCustomerSegmentsHandler struct {
+	log                        logger.Logger
+	customerSegmentsRepository repositories.CustomerSegmentsRepository
+	tracer                     tracing.AppTracer
+}
+
+func NewGetCustomerSegmentsHandler(
+	log logger.Logger,
+	customerSegmentsRepository repositories.CustomerSegmentsRepository,
+	tracer tracing.AppTracer,
+) *GetCustomerSegmentsHandler {
+	return &GetCustomerSegmentsHandler{
+		log:                        log,
+		customerSegmentsRepository: customerSegmentsRepository,
+		tracer:                     tracer,
+	}
+}
+
+func (c *GetCustomerSegmentsHandler) Handle(
+	ctx context.Context,
+	query *GetCustomerSegments,
+) (*dtos.GetCustomerSegmentsResponseDto, error) {
+	customer, err := c.customerSegmentsRepository.GetCustomerById(ctx, read_models.CustomerId(query.AccountEmail))
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[GetCustomerSegmentsHandler_Handle.GetCustomerById] error in getting customer in the repository",
+		)
+	}
+	if customer == nil {
+		return nil, customErrors.NewNotFoundError(
+			fmt.Sprintf("[GetCustomerSegmentsHandler_Handle.GetCustomerById] customer with email %s not found", query.AccountEmail),
+		)
+	}
+
+	customerDto, err := mapper.Map[*dtosV1.CustomerSegmentsDto](customer)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[GetCustomerSegmentsHandler_Handle.Map] error in the mapping customer",
+		)
+	}
+
+	c.log.Infow(
+		fmt.Sprintf("[GetCustomerSegmentsHandler.Handle] segments of customer with id: {%s} fetched", customer.Id),
+		logger.Fields{"Id": customer.Id},
+	)
+
+	return &dtos.GetCustomerSegmentsResponseDto{Customer: customerDto}, nil
+}
@@ -0,0 +1,5 @@
+package dtos
+
+type GetGiftCardBalanceRequestDto struct {
+	Code string `json:"-" param:"code"`
+}
@@ -0,0 +1,7 @@
+package dtos
+
+type GetGiftCardBalanceResponseDto struct {
+	Amount  float64 `json:"amount"`
+	Balance float64 `json:"balance"`
+	Active  bool    `json:"active"`
+}
@@ -0,0 +1,80 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_gift_card_balance/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_gift_card_balance/v1/queries"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type getGiftCardBalanceEndpoint struct {
+	params.GiftCardRouteParams
+}
+
+func NewGetGiftCardBalanceEndpoint(params params.GiftCardRouteParams) route.Endpoint {
+	return &getGiftCardBalanceEndpoint{GiftCardRouteParams: params}
+}
+
+func (ep *getGiftCardBalanceEndpoint) MapEndpoint() {
+	ep.GiftCardsGroup.GET("/:code/balance", ep.handler())
+}
+
+// GetGiftCardBalance
+// @Tags GiftCards
+// @Summary Get gift card balance
+// @Description Get the balance of the gift card with the code
+// @Accept json
+// @Produce json
+// @Param code path string true "Gift card code"
+// @Success 200 {object} dtos.GetGiftCardBalanceResponseDto
+// @Router /api/v1/giftcards/{code}/balance [get]
+func (ep *getGiftCardBalanceEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.GetGiftCardBalanceRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"[getGiftCardBalanceEndpoint_handler.Bind] error in the binding request",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[getGiftCardBalanceEndpoint_handler.Bind] err: %v", badRequestErr),
+			)
+			return badRequestErr
+		}
+
+		query, err := queries.NewGetGiftCardBalance(request.Code)
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"[getGiftCardBalanceEndpoint_handler.StructCtx] query validation failed",
+			)
+			ep.Logger.Errorf("[getGiftCardBalanceEndpoint_handler.StructCtx] err: %v", validationErr)
+			return validationErr
+		}
+
+		queryResult, err := cqrs.Send[*queries.GetGiftCardBalance, *dtos.GetGiftCardBalanceResponseDto](
+			ctx,
+			query,
+		)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[getGiftCardBalanceEndpoint_handler.Send] error in sending GetGiftCardBalance",
+			)
+			ep.Logger.Error(fmt.Sprintf("[getGiftCardBalanceEndpoint_handler.Send] err: {%v}", err))
+			return err
+		}
+
+		return c.JSON(http.StatusOK, queryResult)
+	}
+}
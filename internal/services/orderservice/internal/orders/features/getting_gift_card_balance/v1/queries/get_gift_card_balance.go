@@ -0,0 +1,24 @@
+package queries
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type GetGiftCardBalance struct {
+	Code string
+}
+
+func NewGetGiftCardBalance(code string) (*GetGiftCardBalance, error) {
+	query := &GetGiftCardBalance{Code: code}
+
+	err := query.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return query, nil
+}
+
+func (q GetGiftCardBalance) Validate() error {
+	return validation.ValidateStruct(&q, validation.Field(&q.Code, validation.Required, validation.Length(16, 32)))
+}
@@ -0,0 +1,69 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/store"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_gift_card_balance/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/giftcards/aggregate"
+)
+
+// GetGiftCardBalanceHandler reads the balance from the gift card stream, the gift cards don't have a read model
+type GetGiftCardBalanceHandler struct {
+	log            logger.Logger
+	aggregateStore store.AggregateStore[*aggregate.GiftCard]
+	tracer         tracing.AppTracer
+}
+
+func NewGetGiftCardBalanceHandler(
+	log logger.Logger,
+	aggregateStore store.AggregateStore[*aggregate.GiftCard],
+	tracer tracing.AppTracer,
+) *GetGiftCardBalanceHandler {
+	return &GetGiftCardBalanceHandler{
+		log:            log,
+		aggregateStore: aggregateStore,
+		tracer:         tracer,
+	}
+}
+
+func (q *GetGiftCardBalanceHandler) Handle(
+	ctx context.Context,
+	query *GetGiftCardBalance,
+) (*dtos.GetGiftCardBalanceResponseDto, error) {
+	giftCardId := aggregate.GiftCardId(query.Code)
+
+	exists, err := q.aggregateStore.Exists(ctx, giftCardId)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[GetGiftCardBalanceHandler_Handle.Exists] error in checking gift card existence",
+		)
+	}
+	if !exists {
+		return nil, customErrors.NewNotFoundError("[GetGiftCardBalanceHandler_Handle.Exists] gift card not found")
+	}
+
+	giftCard, err := q.aggregateStore.Load(ctx, giftCardId)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[GetGiftCardBalanceHandler_Handle.Load] error in loading gift card aggregate",
+		)
+	}
+
+	q.log.Infow(
+		fmt.Sprintf("[GetGiftCardBalanceHandler.Handle] balance of gift card with id: {%s} fetched", giftCardId),
+		logger.Fields{"Id": giftCardId},
+	)
+
+	return &dtos.GetGiftCardBalanceResponseDto{
+		Amount:  giftCard.Amount(),
+		Balance: giftCard.Balance(),
+		Active:  giftCard.Active(),
+	}, nil
+}
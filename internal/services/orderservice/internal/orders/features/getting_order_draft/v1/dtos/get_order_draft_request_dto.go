@@ -0,0 +1,7 @@
+package dtos
+
+import uuid "github.com/satori/go.uuid"
+
+type GetOrderDraftRequestDto struct {
+	Id uuid.UUID `param:"id" json:"-"`
+}
@@ -0,0 +1,88 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_order_draft/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_order_draft/v1/queries"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type getOrderDraftEndpoint struct {
+	params.OrderDraftRouteParams
+}
+
+func NewGetOrderDraftEndpoint(params params.OrderDraftRouteParams) route.Endpoint {
+	return &getOrderDraftEndpoint{OrderDraftRouteParams: params}
+}
+
+func (ep *getOrderDraftEndpoint) MapEndpoint() {
+	ep.OrderDraftsGroup.GET("/:id", ep.handler())
+}
+
+// GetOrderDraft
+// @Tags OrderDrafts
+// @Summary Get order draft
+// @Description Get the last saved state of an order draft to resume it, the expired drafts are not found
+// @Accept json
+// @Produce json
+// @Param id path string true "Order draft ID"
+// @Success 200 {object} dtos.GetOrderDraftResponseDto
+// @Router /api/v1/order-drafts/{id} [get]
+func (ep *getOrderDraftEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.GetOrderDraftRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"[getOrderDraftEndpoint_handler.Bind] error in the binding request",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[getOrderDraftEndpoint_handler.Bind] err: %v", badRequestErr),
+			)
+			return badRequestErr
+		}
+
+		query, err := queries.NewGetOrderDraft(request.Id)
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"[getOrderDraftEndpoint_handler.StructCtx] query validation failed",
+			)
+			ep.Logger.Errorf("[getOrderDraftEndpoint_handler.StructCtx] err: %v", validationErr)
+			return validationErr
+		}
+
+		queryResult, err := cqrs.Send[*queries.GetOrderDraft, *dtos.GetOrderDraftResponseDto](
+			ctx,
+			query,
+		)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[getOrderDraftEndpoint_handler.Send] error in sending GetOrderDraft",
+			)
+			ep.Logger.Errorw(
+				fmt.Sprintf(
+					"[getOrderDraftEndpoint_handler.Send] id: {%s}, err: %v",
+					query.Id,
+					err,
+				),
+				logger.Fields{"Id": query.Id},
+			)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, queryResult)
+	}
+}
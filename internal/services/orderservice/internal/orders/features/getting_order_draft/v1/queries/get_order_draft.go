@@ -0,0 +1,27 @@
+package queries
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+type GetOrderDraft struct {
+	Id uuid.UUID
+}
+
+func NewGetOrderDraft(id uuid.UUID) (*GetOrderDraft, error) {
+	query := &GetOrderDraft{Id: id}
+
+	err := query.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return query, nil
+}
+
+func (g GetOrderDraft) Validate() error {
+	return validation.ValidateStruct(&g,
+		validation.Field(&g.Id, validation.Required),
+	)
+}
@@ -0,0 +1,71 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mapper"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/repositories"
+	dtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/dtos/v1"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_order_draft/v1/dtos"
+)
+
+type GetOrderDraftHandler struct {
+	log                  logger.Logger
+	orderDraftRepository repositories.OrderDraftRepository
+	tracer               tracing.AppTracer
+}
+
+func NewGetOrderDraftHandler(
+	log logger.Logger,
+	orderDraftRepository repositories.OrderDraftRepository,
+	tracer tracing.AppTracer,
+) *GetOrderDraftHandler {
+	return &GetOrderDraftHandler{
+		log:                  log,
+		orderDraftRepository: orderDraftRepository,
+		tracer:               tracer,
+	}
+}
+
+func (q *GetOrderDraftHandler) Handle(
+	ctx context.Context,
+	query *GetOrderDraft,
+) (*dtos.GetOrderDraftResponseDto, error) {
+	draft, err := q.orderDraftRepository.GetOrderDraftById(ctx, query.Id.String())
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			fmt.Sprintf(
+				"[GetOrderDraftHandler_Handle.GetOrderDraftById] error in getting order draft with id %s in the mongo repository",
+				query.Id,
+			),
+		)
+	}
+
+	// the ttl index removes the expired drafts lazily, so an expired draft can still be in the read model
+	if draft == nil || draft.IsExpired(time.Now()) {
+		return nil, customErrors.NewNotFoundError(
+			fmt.Sprintf("[GetOrderDraftHandler_Handle] order draft with id %s not found", query.Id),
+		)
+	}
+
+	draftDto, err := mapper.Map[*dtosV1.OrderDraftDto](draft)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[GetOrderDraftHandler_Handle.Map] error in the mapping order draft",
+		)
+	}
+
+	q.log.Infow(
+		fmt.Sprintf("[GetOrderDraftHandler.Handle] order draft with id: {%s} fetched", query.Id),
+		logger.Fields{"Id": query.Id},
+	)
+
+	return &dtos.GetOrderDraftResponseDto{Draft: draftDto}, nil
+}
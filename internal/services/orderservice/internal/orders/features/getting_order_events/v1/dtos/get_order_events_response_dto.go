@@ -0,0 +1,25 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type GetOrderEventsResponseDto struct {
+	OrderId uuid.UUID        `json:"orderId"`
+	Events  []*OrderEventDto `json:"events"`
+}
+
+// OrderEventDto is a stored event of the order stream with its payload and metadata as they are stored
+type OrderEventDto struct {
+	EventId    uuid.UUID         `json:"eventId"`
+	EventType  string            `json:"eventType"`
+	Version    int64             `json:"version"`
+	Position   int64             `json:"position"`
+	OccurredOn time.Time         `json:"occurredOn"`
+	Data       interface{}       `json:"data"`
+	Metadata   metadata.Metadata `json:"metadata"`
+}
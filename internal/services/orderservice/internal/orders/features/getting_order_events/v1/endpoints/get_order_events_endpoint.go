@@ -0,0 +1,89 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_order_events/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_order_events/v1/queries"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type getOrderEventsEndpoint struct {
+	params.BackOfficeRouteParams
+}
+
+func NewGetOrderEventsEndpoint(params params.BackOfficeRouteParams) route.Endpoint {
+	return &getOrderEventsEndpoint{BackOfficeRouteParams: params}
+}
+
+func (ep *getOrderEventsEndpoint) MapEndpoint() {
+	ep.BackOfficeGroup.GET("/:id/events", ep.handler())
+}
+
+// GetOrderEvents
+// @Tags BackOffice
+// @Summary Get order events
+// @Description Get the raw event history of the order
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Order ID"
+// @Success 200 {object} dtos.GetOrderEventsResponseDto
+// @Router /api/v1/backoffice/orders/{id}/events [get]
+func (ep *getOrderEventsEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.GetOrderEventsRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"[getOrderEventsEndpoint_handler.Bind] error in the binding request",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[getOrderEventsEndpoint_handler.Bind] err: %v", badRequestErr),
+			)
+			return badRequestErr
+		}
+
+		query, err := queries.NewGetOrderEvents(request.OrderId)
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"[getOrderEventsEndpoint_handler.StructCtx] query validation failed",
+			)
+			ep.Logger.Errorf("[getOrderEventsEndpoint_handler.StructCtx] err: %v", validationErr)
+			return validationErr
+		}
+
+		queryResult, err := cqrs.Send[*queries.GetOrderEvents, *dtos.GetOrderEventsResponseDto](
+			ctx,
+			query,
+		)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[getOrderEventsEndpoint_handler.Send] error in sending GetOrderEvents",
+			)
+			ep.Logger.Errorw(
+				fmt.Sprintf(
+					"[getOrderEventsEndpoint_handler.Send] id: {%s}, err: %v",
+					query.OrderId,
+					err,
+				),
+				logger.Fields{"Id": query.OrderId},
+			)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, queryResult)
+	}
+}
@@ -0,0 +1,27 @@
+package queries
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+// GetOrderEvents returns the raw events of the order stream in the stored order, with the events of its archived
+// segments when the stream is split
+type GetOrderEvents struct {
+	OrderId uuid.UUID
+}
+
+func NewGetOrderEvents(orderId uuid.UUID) (*GetOrderEvents, error) {
+	query := &GetOrderEvents{OrderId: orderId}
+
+	err := query.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return query, nil
+}
+
+func (q GetOrderEvents) Validate() error {
+	return validation.ValidateStruct(&q, validation.Field(&q.OrderId, validation.Required))
+}
@@ -0,0 +1,90 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/store"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models"
+	streamName "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_name"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_order_events/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/aggregate"
+)
+
+type GetOrderEventsHandler struct {
+	log            logger.Logger
+	eventStore     store.EventStore
+	streamSplitter store.AggregateStreamSplitter[*aggregate.Order]
+	tracer         tracing.AppTracer
+}
+
+func NewGetOrderEventsHandler(
+	log logger.Logger,
+	eventStore store.EventStore,
+	streamSplitter store.AggregateStreamSplitter[*aggregate.Order],
+	tracer tracing.AppTracer,
+) *GetOrderEventsHandler {
+	return &GetOrderEventsHandler{
+		log:            log,
+		eventStore:     eventStore,
+		streamSplitter: streamSplitter,
+		tracer:         tracer,
+	}
+}
+
+func (c *GetOrderEventsHandler) Handle(
+	ctx context.Context,
+	query *GetOrderEvents,
+) (*dtos.GetOrderEventsResponseDto, error) {
+	stream := streamName.ForID[*aggregate.Order](query.OrderId)
+
+	exists, err := c.eventStore.StreamExists(stream, ctx)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[GetOrderEventsHandler_Handle.StreamExists] error in checking order stream existence",
+		)
+	}
+	if !exists {
+		return nil, customErrors.NewNotFoundError(
+			fmt.Sprintf("[GetOrderEventsHandler_Handle.StreamExists] order with id %s not found", query.OrderId),
+		)
+	}
+
+	// the events of the archived segments of a split order stream come first, so the history is complete
+	streamEvents, err := c.streamSplitter.ReadAllEvents(ctx, query.OrderId)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[GetOrderEventsHandler_Handle.ReadAllEvents] error in reading order stream events",
+		)
+	}
+
+	var events []*dtos.OrderEventDto
+	for _, streamEvent := range streamEvents {
+		events = append(events, toOrderEventDto(streamEvent))
+	}
+
+	c.log.Infow(
+		fmt.Sprintf("[GetOrderEventsHandler.Handle] %d events of order with id: {%s} fetched", len(events), query.OrderId),
+		logger.Fields{"Id": query.OrderId},
+	)
+
+	return &dtos.GetOrderEventsResponseDto{OrderId: query.OrderId, Events: events}, nil
+}
+
+func toOrderEventDto(streamEvent *models.StreamEvent) *dtos.OrderEventDto {
+	return &dtos.OrderEventDto{
+		EventId:    streamEvent.EventID,
+		EventType:  typeMapper.GetTypeName(streamEvent.Event),
+		Version:    streamEvent.Version,
+		Position:   streamEvent.Position,
+		OccurredOn: streamEvent.Event.GetOccurredOn(),
+		Data:       streamEvent.Event,
+		Metadata:   streamEvent.Metadata,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
 	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils"
@@ -13,7 +14,6 @@ import (
 
 	"emperror.dev/errors"
 	"github.com/labstack/echo/v4"
-	"github.com/mehdihadeli/go-mediatr"
 )
 
 type getOrdersEndpoint struct {
@@ -69,7 +69,7 @@ func (ep *getOrdersEndpoint) handler() echo.HandlerFunc {
 
 		query := queries.NewGetOrders(request.ListQuery)
 
-		queryResult, err := mediatr.Send[*queries.GetOrders, *dtos.GetOrdersResponseDto](ctx, query)
+		queryResult, err := cqrs.Send[*queries.GetOrders, *dtos.GetOrdersResponseDto](ctx, query)
 		if err != nil {
 			err = errors.WithMessage(
 				err,
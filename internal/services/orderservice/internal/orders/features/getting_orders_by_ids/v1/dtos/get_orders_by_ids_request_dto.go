@@ -0,0 +1,7 @@
+package dtos
+
+import uuid "github.com/satori/go.uuid"
+
+type GetOrdersByIdsRequestDto struct {
+	Ids []uuid.UUID `json:"ids"`
+}
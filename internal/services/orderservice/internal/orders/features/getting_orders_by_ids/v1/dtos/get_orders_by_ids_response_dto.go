@@ -0,0 +1,21 @@
+package dtos
+
+import (
+	dtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/dtos/v1"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type GetOrdersByIdsResponseDto struct {
+	// Orders are the found orders in the order of the request
+	Orders []*dtosV1.OrderReadDto `json:"orders"`
+	// NotFound are the requested ids without an order, a missing order doesn't fail the batch
+	NotFound []uuid.UUID `json:"notFound"`
+	// Failed are the orders which are found but couldn't be returned
+	Failed []*FailedOrderDto `json:"failed"`
+}
+
+type FailedOrderDto struct {
+	Id    uuid.UUID `json:"id"`
+	Error string    `json:"error"`
+}
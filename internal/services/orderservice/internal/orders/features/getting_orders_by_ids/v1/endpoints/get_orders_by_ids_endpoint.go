@@ -0,0 +1,84 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_orders_by_ids/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_orders_by_ids/v1/queries"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type getOrdersByIdsEndpoint struct {
+	params.OrderRouteParams
+}
+
+func NewGetOrdersByIdsEndpoint(params params.OrderRouteParams) route.Endpoint {
+	return &getOrdersByIdsEndpoint{OrderRouteParams: params}
+}
+
+func (ep *getOrdersByIdsEndpoint) MapEndpoint() {
+	ep.OrdersGroup.POST("/batch-get", ep.handler())
+}
+
+// Get Orders By Ids
+// @Tags Orders
+// @Summary Get orders by ids
+// @Description Get a batch of orders in a single round trip, the missing orders are reported in the response
+// @Accept json
+// @Produce json
+// @Param GetOrdersByIdsRequestDto body dtos.GetOrdersByIdsRequestDto true "Order ids"
+// @Success 200 {object} dtos.GetOrdersByIdsResponseDto
+// @Router /api/v1/orders/batch-get [post]
+func (ep *getOrdersByIdsEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.GetOrdersByIdsRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"[getOrdersByIdsEndpoint_handler.Bind] error in the binding request",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[getOrdersByIdsEndpoint_handler.Bind] err: %v", badRequestErr),
+			)
+			return badRequestErr
+		}
+
+		query, err := queries.NewGetOrdersByIds(request.Ids)
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"[getOrdersByIdsEndpoint_handler.StructCtx] query validation failed",
+			)
+			ep.Logger.Errorf("[getOrdersByIdsEndpoint_handler.StructCtx] err: %v", validationErr)
+			return validationErr
+		}
+
+		queryResult, err := cqrs.Send[*queries.GetOrdersByIds, *dtos.GetOrdersByIdsResponseDto](
+			ctx,
+			query,
+		)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[getOrdersByIdsEndpoint_handler.Send] error in sending GetOrdersByIds",
+			)
+			ep.Logger.Errorw(
+				fmt.Sprintf("[getOrdersByIdsEndpoint_handler.Send] err: %v", err),
+				logger.Fields{"Count": len(query.Ids)},
+			)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, queryResult)
+	}
+}
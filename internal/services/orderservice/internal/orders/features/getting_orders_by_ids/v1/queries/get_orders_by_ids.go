@@ -0,0 +1,52 @@
+package queries
+
+import (
+	"emperror.dev/errors"
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+// MaxOrderIds is the max number of orders of a single batch get
+const MaxOrderIds = 100
+
+// GetOrdersByIds returns a batch of orders in a single round trip, an id is the order-read id or the order-write id
+// like GetOrderById, and the missing orders are reported instead of failing the query
+type GetOrdersByIds struct {
+	Ids []uuid.UUID
+}
+
+func NewGetOrdersByIds(ids []uuid.UUID) (*GetOrdersByIds, error) {
+	query := &GetOrdersByIds{Ids: ids}
+
+	err := query.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return query, nil
+}
+
+func (q GetOrdersByIds) Validate() error {
+	return validation.ValidateStruct(&q,
+		validation.Field(
+			&q.Ids,
+			validation.Required,
+			validation.Length(1, MaxOrderIds),
+			validation.By(func(value interface{}) error {
+				ids, _ := value.([]uuid.UUID)
+				seen := make(map[uuid.UUID]bool, len(ids))
+				for _, id := range ids {
+					if id == uuid.Nil {
+						return errors.New("must not contain empty ids")
+					}
+					if seen[id] {
+						return errors.Errorf("must not contain duplicate id %s", id)
+					}
+					seen[id] = true
+				}
+
+				return nil
+			}),
+		),
+	)
+}
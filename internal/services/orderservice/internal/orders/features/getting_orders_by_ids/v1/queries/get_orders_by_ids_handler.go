@@ -0,0 +1,95 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mapper"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/repositories"
+	dtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/dtos/v1"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_orders_by_ids/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/read_models"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type GetOrdersByIdsHandler struct {
+	log                  logger.Logger
+	orderMongoRepository repositories.OrderMongoRepository
+	tracer               tracing.AppTracer
+}
+
+func NewGetOrdersByIdsHandler(
+	log logger.Logger,
+	orderMongoRepository repositories.OrderMongoRepository,
+	tracer tracing.AppTracer,
+) *GetOrdersByIdsHandler {
+	return &GetOrdersByIdsHandler{
+		log:                  log,
+		orderMongoRepository: orderMongoRepository,
+		tracer:               tracer,
+	}
+}
+
+func (q *GetOrdersByIdsHandler) Handle(
+	ctx context.Context,
+	query *GetOrdersByIds,
+) (*dtos.GetOrdersByIdsResponseDto, error) {
+	orders, err := q.orderMongoRepository.GetOrdersByIds(ctx, query.Ids)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[GetOrdersByIdsHandler_Handle.GetOrdersByIds] error in getting the orders in the mongo repository",
+		)
+	}
+
+	// an order is found by its order-read id or by its order-write id
+	found := make(map[string]*read_models.OrderReadModel, 2*len(orders))
+	for _, order := range orders {
+		found[order.Id] = order
+		found[order.OrderId] = order
+	}
+
+	result := &dtos.GetOrdersByIdsResponseDto{
+		Orders:   make([]*dtosV1.OrderReadDto, 0, len(orders)),
+		NotFound: []uuid.UUID{},
+		Failed:   []*dtos.FailedOrderDto{},
+	}
+
+	for _, id := range query.Ids {
+		order, ok := found[id.String()]
+		if !ok {
+			result.NotFound = append(result.NotFound, id)
+
+			continue
+		}
+
+		// an order which can't be mapped is reported as failed, so it doesn't fail the other orders of the batch
+		orderDto, err := mapper.Map[*dtosV1.OrderReadDto](order)
+		if err != nil {
+			result.Failed = append(result.Failed, &dtos.FailedOrderDto{Id: id, Error: err.Error()})
+
+			continue
+		}
+
+		result.Orders = append(result.Orders, orderDto)
+	}
+
+	q.log.Infow(
+		fmt.Sprintf(
+			"[GetOrdersByIdsHandler.Handle] %d of %d orders fetched",
+			len(result.Orders),
+			len(query.Ids),
+		),
+		logger.Fields{
+			"Found":    len(result.Orders),
+			"NotFound": len(result.NotFound),
+			"Failed":   len(result.Failed),
+		},
+	)
+
+	return result, nil
+}
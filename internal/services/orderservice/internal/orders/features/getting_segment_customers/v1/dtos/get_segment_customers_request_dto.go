@@ -0,0 +1,8 @@
+package dtos
+
+import "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils"
+
+type GetSegmentCustomersRequestDto struct {
+	Segment          string `json:"-"         param:"segment"`
+	*utils.ListQuery `       json:"listQuery"`
+}
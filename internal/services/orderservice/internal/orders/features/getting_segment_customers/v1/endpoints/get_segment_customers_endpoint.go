@@ -0,0 +1,93 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_segment_customers/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_segment_customers/v1/queries"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type getSegmentCustomersEndpoint struct {
+	params.BackOfficeRouteParams
+}
+
+func NewGetSegmentCustomersEndpoint(params params.BackOfficeRouteParams) route.Endpoint {
+	return &getSegmentCustomersEndpoint{BackOfficeRouteParams: params}
+}
+
+func (ep *getSegmentCustomersEndpoint) MapEndpoint() {
+	ep.BackOfficeCustomersGroup.GET("/segments/:segment", ep.handler())
+}
+
+// GetSegmentCustomers
+// @Tags BackOffice
+// @Summary Get segment customers
+// @Description Get the customers of a marketing segment, like `first-time-buyer` or `vip`
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param segment path string true "Segment"
+// @Param getSegmentCustomersRequestDto query dtos.GetSegmentCustomersRequestDto false "GetSegmentCustomersRequestDto"
+// @Success 200 {object} dtos.GetSegmentCustomersResponseDto
+// @Router /api/v1/backoffice/customers/segments/{segment} [get]
+func (ep *getSegmentCustomersEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		listQuery, err := utils.GetListQueryFromCtx(c)
+		if err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"[getSegmentCustomersEndpoint_handler.GetListQueryFromCtx] error in getting data from query string",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf(
+					"[getSegmentCustomersEndpoint_handler.GetListQueryFromCtx] err: %v",
+					badRequestErr,
+				),
+			)
+			return badRequestErr
+		}
+
+		request := &dtos.GetSegmentCustomersRequestDto{ListQuery: listQuery}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"[getSegmentCustomersEndpoint_handler.Bind] error in the binding request",
+			)
+			ep.Logger.Errorf(fmt.Sprintf("[getSegmentCustomersEndpoint_handler.Bind] err: %v", badRequestErr))
+			return badRequestErr
+		}
+
+		query, err := queries.NewGetSegmentCustomers(request.Segment, request.ListQuery)
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"[getSegmentCustomersEndpoint_handler.StructCtx] query validation failed",
+			)
+			ep.Logger.Errorf("[getSegmentCustomersEndpoint_handler.StructCtx] err: %v", validationErr)
+			return validationErr
+		}
+
+		queryResult, err := cqrs.Send[*queries.GetSegmentCustomers, *dtos.GetSegmentCustomersResponseDto](ctx, query)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[getSegmentCustomersEndpoint_handler.Send] error in sending GetSegmentCustomers",
+			)
+			ep.Logger.Error(fmt.Sprintf("[getSegmentCustomersEndpoint_handler.Send] err: {%v}", err))
+			return err
+		}
+
+		return c.JSON(http.StatusOK, queryResult)
+	}
+}
@@ -0,0 +1,34 @@
+package queries
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/segments"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// GetSegmentCustomers returns the customers of a segment, it is used for the targeted campaigns
+type GetSegmentCustomers struct {
+	Segment string
+	*utils.ListQuery
+}
+
+func NewGetSegmentCustomers(segment string, query *utils.ListQuery) (*GetSegmentCustomers, error) {
+	getSegmentCustomers := &GetSegmentCustomers{Segment: segment, ListQuery: query}
+
+	err := getSegmentCustomers.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return getSegmentCustomers, nil
+}
+
+func (q GetSegmentCustomers) Validate() error {
+	known := make([]interface{}, 0, len(segments.All))
+	for _, segment := range segments.All {
+		known = append(known, segment)
+	}
+
+	return validation.ValidateStruct(&q, validation.Field(&q.Segment, validation.Required, validation.In(known...)))
+}
@@ -0,0 +1,57 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/repositories"
+	dtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/dtos/v1"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_segment_customers/v1/dtos"
+)
+
+type GetSegmentCustomersHandler struct {
+	log                        logger.Logger
+	customerSegmentsRepository repositories.CustomerSegmentsRepository
+	tracer                     tracing.AppTracer
+}
+
+func NewGetSegmentCustomersHandler(
+	log logger.Logger,
+	customerSegmentsRepository repositories.CustomerSegmentsRepository,
+	tracer tracing.AppTracer,
+) *GetSegmentCustomersHandler {
+	return &GetSegmentCustomersHandler{
+		log:                        log,
+		customerSegmentsRepository: customerSegmentsRepository,
+		tracer:                     tracer,
+	}
+}
+
+func (c *GetSegmentCustomersHandler) Handle(
+	ctx context.Context,
+	query *GetSegmentCustomers,
+) (*dtos.GetSegmentCustomersResponseDto, error) {
+	customers, err := c.customerSegmentsRepository.GetCustomersBySegment(ctx, query.Segment, query.ListQuery)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[GetSegmentCustomersHandler_Handle.GetCustomersBySegment] error in getting customers in the repository",
+		)
+	}
+
+	listResultDto, err := utils.ListResultToListResultDto[*dtosV1.CustomerSegmentsDto](customers)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[GetSegmentCustomersHandler_Handle.ListResultToListResultDto] error in the mapping ListResultToListResultDto",
+		)
+	}
+
+	c.log.Info(fmt.Sprintf("[GetSegmentCustomersHandler.Handle] customers of segment '%s' fetched", query.Segment))
+
+	return &dtos.GetSegmentCustomersResponseDto{Customers: listResultDto}, nil
+}
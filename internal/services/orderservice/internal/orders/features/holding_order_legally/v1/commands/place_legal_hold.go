@@ -0,0 +1,42 @@
+package legalHoldCommandsV1
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+// PlaceLegalHold keeps the order from the personal data purge of the retention policy, it is sent by the back-office
+// users
+type PlaceLegalHold struct {
+	OrderId  uuid.UUID
+	Reason   string
+	PlacedBy string
+	PlacedAt time.Time
+}
+
+func NewPlaceLegalHold(orderId uuid.UUID, reason string, placedBy string) (*PlaceLegalHold, error) {
+	command := &PlaceLegalHold{
+		OrderId:  orderId,
+		Reason:   reason,
+		PlacedBy: placedBy,
+		PlacedAt: time.Now(),
+	}
+
+	err := command.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+func (c PlaceLegalHold) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.OrderId, validation.Required),
+		validation.Field(&c.Reason, validation.Required, validation.Length(0, 1000)),
+		validation.Field(&c.PlacedBy, validation.Required),
+		validation.Field(&c.PlacedAt, validation.Required),
+	)
+}
@@ -0,0 +1,75 @@
+package legalHoldCommandsV1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/store"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/aggregate"
+
+	"emperror.dev/errors"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type PlaceLegalHoldHandler struct {
+	log            logger.Logger
+	aggregateStore store.AggregateStore[*aggregate.Order]
+	tracer         tracing.AppTracer
+}
+
+func NewPlaceLegalHoldHandler(
+	log logger.Logger,
+	aggregateStore store.AggregateStore[*aggregate.Order],
+	tracer tracing.AppTracer,
+) *PlaceLegalHoldHandler {
+	return &PlaceLegalHoldHandler{
+		log:            log,
+		aggregateStore: aggregateStore,
+		tracer:         tracer,
+	}
+}
+
+func (c *PlaceLegalHoldHandler) Handle(
+	ctx context.Context,
+	command *PlaceLegalHold,
+) (*mediatr.Unit, error) {
+	exists, err := c.aggregateStore.Exists(ctx, command.OrderId)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[PlaceLegalHoldHandler_Handle.Exists] error in checking order existence",
+		)
+	}
+	if !exists {
+		return nil, customErrors.NewNotFoundError(
+			fmt.Sprintf("[PlaceLegalHoldHandler_Handle.Exists] order with id %s not found", command.OrderId),
+		)
+	}
+
+	// the order is reloaded and updated again when it is changed by a concurrent update
+	_, _, err = c.aggregateStore.Update(ctx, command.OrderId, nil, func(order *aggregate.Order) error {
+		return order.PlaceLegalHold(command.Reason, command.PlacedBy, command.PlacedAt)
+	})
+	if err != nil {
+		// the domain errors and the unresolved concurrency conflicts are kept as is, so they result in conflict responses
+		if customErrors.IsConflictError(err) || customErrors.IsDomainError(err, http.StatusBadRequest) {
+			return nil, errors.WithMessage(err, "[PlaceLegalHoldHandler_Handle.Update] error in placing the legal hold")
+		}
+
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[PlaceLegalHoldHandler_Handle.Update] error in updating order aggregate",
+		)
+	}
+
+	c.log.Infow(
+		fmt.Sprintf("[PlaceLegalHoldHandler.Handle] legal hold of order with id: {%s} placed", command.OrderId),
+		logger.Fields{"Id": command.OrderId, "PlacedBy": command.PlacedBy},
+	)
+
+	return &mediatr.Unit{}, nil
+}
@@ -0,0 +1,37 @@
+package legalHoldCommandsV1
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+type ReleaseLegalHold struct {
+	OrderId    uuid.UUID
+	ReleasedBy string
+	ReleasedAt time.Time
+}
+
+func NewReleaseLegalHold(orderId uuid.UUID, releasedBy string) (*ReleaseLegalHold, error) {
+	command := &ReleaseLegalHold{
+		OrderId:    orderId,
+		ReleasedBy: releasedBy,
+		ReleasedAt: time.Now(),
+	}
+
+	err := command.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+func (c ReleaseLegalHold) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.OrderId, validation.Required),
+		validation.Field(&c.ReleasedBy, validation.Required),
+		validation.Field(&c.ReleasedAt, validation.Required),
+	)
+}
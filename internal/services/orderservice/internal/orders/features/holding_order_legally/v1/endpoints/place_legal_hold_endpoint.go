@@ -0,0 +1,96 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	apikey "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/api_key"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	legalHoldCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/holding_order_legally/v1/commands"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/holding_order_legally/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type placeLegalHoldEndpoint struct {
+	params.BackOfficeRouteParams
+}
+
+func NewPlaceLegalHoldEndpoint(params params.BackOfficeRouteParams) route.Endpoint {
+	return &placeLegalHoldEndpoint{BackOfficeRouteParams: params}
+}
+
+func (ep *placeLegalHoldEndpoint) MapEndpoint() {
+	ep.BackOfficeGroup.POST("/:id/legal-hold", ep.handler())
+}
+
+// PlaceLegalHold
+// @Tags BackOffice
+// @Summary Place legal hold
+// @Description Place a legal hold on the order, the personal data of the order is not purged while it is under legal hold
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param PlaceLegalHoldRequestDto body dtos.PlaceLegalHoldRequestDto true "Legal hold data"
+// @Param id path string true "Order ID"
+// @Success 204
+// @Router /api/v1/backoffice/orders/{id}/legal-hold [post]
+func (ep *placeLegalHoldEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.PlaceLegalHoldRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"[placeLegalHoldEndpoint_handler.Bind] error in the binding request",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[placeLegalHoldEndpoint_handler.Bind] err: %v", badRequestErr),
+			)
+			return badRequestErr
+		}
+
+		userId, _ := apikey.UserId(ctx)
+
+		command, err := legalHoldCommandsV1.NewPlaceLegalHold(request.OrderId, request.Reason, userId)
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"[placeLegalHoldEndpoint_handler.StructCtx] command validation failed",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[placeLegalHoldEndpoint_handler.StructCtx] err: %v", validationErr),
+			)
+			return validationErr
+		}
+
+		_, err = cqrs.Send[*legalHoldCommandsV1.PlaceLegalHold, *mediatr.Unit](
+			ctx,
+			command,
+		)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[placeLegalHoldEndpoint_handler.Send] error in sending PlaceLegalHold",
+			)
+			ep.Logger.Errorw(
+				fmt.Sprintf(
+					"[placeLegalHoldEndpoint_handler.Send] id: {%s}, err: %v",
+					command.OrderId,
+					err,
+				),
+				logger.Fields{"Id": command.OrderId},
+			)
+			return err
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
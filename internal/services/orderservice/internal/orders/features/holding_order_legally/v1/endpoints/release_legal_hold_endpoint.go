@@ -0,0 +1,95 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	apikey "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/api_key"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	legalHoldCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/holding_order_legally/v1/commands"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/holding_order_legally/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type releaseLegalHoldEndpoint struct {
+	params.BackOfficeRouteParams
+}
+
+func NewReleaseLegalHoldEndpoint(params params.BackOfficeRouteParams) route.Endpoint {
+	return &releaseLegalHoldEndpoint{BackOfficeRouteParams: params}
+}
+
+func (ep *releaseLegalHoldEndpoint) MapEndpoint() {
+	ep.BackOfficeGroup.DELETE("/:id/legal-hold", ep.handler())
+}
+
+// ReleaseLegalHold
+// @Tags BackOffice
+// @Summary Release legal hold
+// @Description Release the legal hold of the order
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Order ID"
+// @Success 204
+// @Router /api/v1/backoffice/orders/{id}/legal-hold [delete]
+func (ep *releaseLegalHoldEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.ReleaseLegalHoldRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"[releaseLegalHoldEndpoint_handler.Bind] error in the binding request",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[releaseLegalHoldEndpoint_handler.Bind] err: %v", badRequestErr),
+			)
+			return badRequestErr
+		}
+
+		userId, _ := apikey.UserId(ctx)
+
+		command, err := legalHoldCommandsV1.NewReleaseLegalHold(request.OrderId, userId)
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"[releaseLegalHoldEndpoint_handler.StructCtx] command validation failed",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[releaseLegalHoldEndpoint_handler.StructCtx] err: %v", validationErr),
+			)
+			return validationErr
+		}
+
+		_, err = cqrs.Send[*legalHoldCommandsV1.ReleaseLegalHold, *mediatr.Unit](
+			ctx,
+			command,
+		)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[releaseLegalHoldEndpoint_handler.Send] error in sending ReleaseLegalHold",
+			)
+			ep.Logger.Errorw(
+				fmt.Sprintf(
+					"[releaseLegalHoldEndpoint_handler.Send] id: {%s}, err: %v",
+					command.OrderId,
+					err,
+				),
+				logger.Fields{"Id": command.OrderId},
+			)
+			return err
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
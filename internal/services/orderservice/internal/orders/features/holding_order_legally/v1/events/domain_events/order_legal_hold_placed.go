@@ -0,0 +1,37 @@
+package domainEvents
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/domain"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+)
+
+// OrderLegalHoldPlacedV1 is applied when a back-office user places a legal hold on the order, the personal data of an
+// order under legal hold is not purged by the retention policy
+type OrderLegalHoldPlacedV1 struct {
+	*domain.DomainEvent
+	Reason   string    `json:"reason"`
+	PlacedBy string    `json:"placedBy"`
+	PlacedAt time.Time `json:"placedAt"`
+}
+
+func NewOrderLegalHoldPlacedV1(reason string, placedBy string, placedAt time.Time) (*OrderLegalHoldPlacedV1, error) {
+	if reason == "" {
+		return nil, customErrors.NewDomainError("reason of the legal hold is required")
+	}
+
+	if placedAt.IsZero() {
+		return nil, customErrors.NewDomainError("placedAt can't be zero")
+	}
+
+	eventData := &OrderLegalHoldPlacedV1{
+		Reason:   reason,
+		PlacedBy: placedBy,
+		PlacedAt: placedAt,
+	}
+	eventData.DomainEvent = domain.NewDomainEvent(typeMapper.GetTypeName(eventData))
+
+	return eventData, nil
+}
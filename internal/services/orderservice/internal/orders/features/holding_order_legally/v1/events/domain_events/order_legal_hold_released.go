@@ -0,0 +1,29 @@
+package domainEvents
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/domain"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+)
+
+type OrderLegalHoldReleasedV1 struct {
+	*domain.DomainEvent
+	ReleasedBy string    `json:"releasedBy"`
+	ReleasedAt time.Time `json:"releasedAt"`
+}
+
+func NewOrderLegalHoldReleasedV1(releasedBy string, releasedAt time.Time) (*OrderLegalHoldReleasedV1, error) {
+	if releasedAt.IsZero() {
+		return nil, customErrors.NewDomainError("releasedAt can't be zero")
+	}
+
+	eventData := &OrderLegalHoldReleasedV1{
+		ReleasedBy: releasedBy,
+		ReleasedAt: releasedAt,
+	}
+	eventData.DomainEvent = domain.NewDomainEvent(typeMapper.GetTypeName(eventData))
+
+	return eventData, nil
+}
@@ -0,0 +1,71 @@
+package issueGiftCardCommandsV1
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"strings"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/giftcards/aggregate"
+
+	"emperror.dev/errors"
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+// IssueGiftCard issues a gift card with a random code, it is sent by the back-office users
+type IssueGiftCard struct {
+	GiftCardId uuid.UUID
+	Code       string
+	Amount     float64
+	IssuedBy   string
+	IssuedAt   time.Time
+}
+
+func NewIssueGiftCard(amount float64, issuedBy string) (*IssueGiftCard, error) {
+	code, err := generateCode()
+	if err != nil {
+		return nil, err
+	}
+
+	command := &IssueGiftCard{
+		GiftCardId: aggregate.GiftCardId(code),
+		Code:       code,
+		Amount:     amount,
+		IssuedBy:   issuedBy,
+		IssuedAt:   time.Now(),
+	}
+
+	err = command.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+func (c IssueGiftCard) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.GiftCardId, validation.Required),
+		validation.Field(&c.Code, validation.Required),
+		validation.Field(&c.Amount, validation.Required, validation.Min(0.01), validation.Max(10000.0)),
+		validation.Field(&c.IssuedBy, validation.Required),
+		validation.Field(&c.IssuedAt, validation.Required),
+	)
+}
+
+// generateCode returns a random code in the `XXXX-XXXX-XXXX-XXXX` format
+func generateCode() (string, error) {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.WrapIf(err, "error in generating gift card code")
+	}
+
+	code := base32.StdEncoding.EncodeToString(b)
+	groups := make([]string, 0, 4)
+	for i := 0; i < len(code); i += 4 {
+		groups = append(groups, code[i:i+4])
+	}
+
+	return strings.Join(groups, "-"), nil
+}
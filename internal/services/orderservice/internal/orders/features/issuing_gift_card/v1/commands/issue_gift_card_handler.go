@@ -0,0 +1,72 @@
+package issueGiftCardCommandsV1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/store"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/issuing_gift_card/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/giftcards/aggregate"
+)
+
+type IssueGiftCardHandler struct {
+	log            logger.Logger
+	aggregateStore store.AggregateStore[*aggregate.GiftCard]
+	tracer         tracing.AppTracer
+}
+
+func NewIssueGiftCardHandler(
+	log logger.Logger,
+	aggregateStore store.AggregateStore[*aggregate.GiftCard],
+	tracer tracing.AppTracer,
+) *IssueGiftCardHandler {
+	return &IssueGiftCardHandler{
+		log:            log,
+		aggregateStore: aggregateStore,
+		tracer:         tracer,
+	}
+}
+
+func (c *IssueGiftCardHandler) Handle(
+	ctx context.Context,
+	command *IssueGiftCard,
+) (*dtos.IssueGiftCardResponseDto, error) {
+	exists, err := c.aggregateStore.Exists(ctx, command.GiftCardId)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[IssueGiftCardHandler_Handle.Exists] error in checking gift card existence",
+		)
+	}
+	if exists {
+		return nil, customErrors.NewConflictError(
+			fmt.Sprintf("[IssueGiftCardHandler_Handle.Exists] gift card with id %s already exists", command.GiftCardId),
+		)
+	}
+
+	giftCard, err := aggregate.NewGiftCard(command.Code, command.Amount, command.IssuedBy, command.IssuedAt)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[IssueGiftCardHandler_Handle.NewGiftCard] error in issuing new gift card",
+		)
+	}
+
+	_, err = c.aggregateStore.Store(giftCard, nil, ctx)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[IssueGiftCardHandler_Handle.Store] error in storing gift card aggregate",
+		)
+	}
+
+	c.log.Infow(
+		fmt.Sprintf("[IssueGiftCardHandler.Handle] gift card with id: {%s} issued", command.GiftCardId),
+		logger.Fields{"Id": command.GiftCardId, "IssuedBy": command.IssuedBy},
+	)
+
+	return &dtos.IssueGiftCardResponseDto{GiftCardId: giftCard.Id(), Code: command.Code}, nil
+}
@@ -0,0 +1,5 @@
+package dtos
+
+type IssueGiftCardRequestDto struct {
+	Amount float64 `json:"amount"`
+}
@@ -0,0 +1,8 @@
+package dtos
+
+import uuid "github.com/satori/go.uuid"
+
+type IssueGiftCardResponseDto struct {
+	GiftCardId uuid.UUID `json:"giftCardId"`
+	Code       string    `json:"code"`
+}
@@ -0,0 +1,94 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	apikey "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/api_key"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	issueGiftCardCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/issuing_gift_card/v1/commands"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/issuing_gift_card/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type issueGiftCardEndpoint struct {
+	params.BackOfficeRouteParams
+}
+
+func NewIssueGiftCardEndpoint(params params.BackOfficeRouteParams) route.Endpoint {
+	return &issueGiftCardEndpoint{BackOfficeRouteParams: params}
+}
+
+func (ep *issueGiftCardEndpoint) MapEndpoint() {
+	ep.BackOfficeGiftCardsGroup.POST("", ep.handler())
+}
+
+// IssueGiftCard
+// @Tags BackOffice
+// @Summary Issue gift card
+// @Description Issue a gift card with a random code, the gift card should be activated before redeeming
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param IssueGiftCardRequestDto body dtos.IssueGiftCardRequestDto true "Gift card data"
+// @Success 201 {object} dtos.IssueGiftCardResponseDto
+// @Router /api/v1/backoffice/giftcards [post]
+func (ep *issueGiftCardEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.IssueGiftCardRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"[issueGiftCardEndpoint_handler.Bind] error in the binding request",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[issueGiftCardEndpoint_handler.Bind] err: %v", badRequestErr),
+			)
+			return badRequestErr
+		}
+
+		userId, _ := apikey.UserId(ctx)
+
+		command, err := issueGiftCardCommandsV1.NewIssueGiftCard(request.Amount, userId)
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"[issueGiftCardEndpoint_handler.StructCtx] command validation failed",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[issueGiftCardEndpoint_handler.StructCtx] err: %v", validationErr),
+			)
+			return validationErr
+		}
+
+		result, err := cqrs.Send[*issueGiftCardCommandsV1.IssueGiftCard, *dtos.IssueGiftCardResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[issueGiftCardEndpoint_handler.Send] error in sending IssueGiftCard",
+			)
+			ep.Logger.Errorw(
+				fmt.Sprintf(
+					"[issueGiftCardEndpoint_handler.Send] id: {%s}, err: %v",
+					command.GiftCardId,
+					err,
+				),
+				logger.Fields{"Id": command.GiftCardId},
+			)
+			return err
+		}
+
+		return c.JSON(http.StatusCreated, result)
+	}
+}
@@ -0,0 +1,42 @@
+package domainEvents
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/domain"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+)
+
+// GiftCardIssuedV1 is applied when a back-office user issues a gift card, the card can't be redeemed before activation
+type GiftCardIssuedV1 struct {
+	*domain.DomainEvent
+	Code     string    `json:"code"`
+	Amount   float64   `json:"amount"`
+	IssuedBy string    `json:"issuedBy"`
+	IssuedAt time.Time `json:"issuedAt"`
+}
+
+func NewGiftCardIssuedV1(code string, amount float64, issuedBy string, issuedAt time.Time) (*GiftCardIssuedV1, error) {
+	if code == "" {
+		return nil, customErrors.NewDomainError("code of the gift card is required")
+	}
+
+	if amount <= 0 {
+		return nil, customErrors.NewDomainError("amount of the gift card should be greater than zero")
+	}
+
+	if issuedAt.IsZero() {
+		return nil, customErrors.NewDomainError("issuedAt can't be zero")
+	}
+
+	eventData := &GiftCardIssuedV1{
+		Code:     code,
+		Amount:   amount,
+		IssuedBy: issuedBy,
+		IssuedAt: issuedAt,
+	}
+	eventData.DomainEvent = domain.NewDomainEvent(typeMapper.GetTypeName(eventData))
+
+	return eventData, nil
+}
@@ -0,0 +1,31 @@
+package domainEvents
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/domain"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+)
+
+// OrderPersonalDataPurgedV1 is applied when the retention period of an archived order is over, the projections
+// redact the personal data of the order in the read models.
+type OrderPersonalDataPurgedV1 struct {
+	*domain.DomainEvent
+	Policy   string    `json:"policy"`
+	PurgedAt time.Time `json:"purgedAt"`
+}
+
+func NewOrderPersonalDataPurgedV1(policy string, purgedAt time.Time) (*OrderPersonalDataPurgedV1, error) {
+	if purgedAt.IsZero() {
+		return nil, customErrors.NewDomainError("purgedAt can't be zero")
+	}
+
+	eventData := &OrderPersonalDataPurgedV1{
+		Policy:   policy,
+		PurgedAt: purgedAt,
+	}
+	eventData.DomainEvent = domain.NewDomainEvent(typeMapper.GetTypeName(eventData))
+
+	return eventData, nil
+}
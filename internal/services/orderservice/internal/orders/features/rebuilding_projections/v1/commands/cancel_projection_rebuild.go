@@ -0,0 +1,31 @@
+package projectionRebuildsCommandsV1
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// CancelProjectionRebuild stops the running rebuild of a projection, its live projection stays paused until the read
+// model is rebuilt again
+type CancelProjectionRebuild struct {
+	Projection string
+	CanceledBy string
+}
+
+func NewCancelProjectionRebuild(projection string, canceledBy string) (*CancelProjectionRebuild, error) {
+	command := &CancelProjectionRebuild{Projection: projection, CanceledBy: canceledBy}
+
+	err := command.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+func (c CancelProjectionRebuild) Validate() error {
+	return validation.ValidateStruct(
+		&c,
+		validation.Field(&c.Projection, validation.Required),
+		validation.Field(&c.CanceledBy, validation.Required),
+	)
+}
@@ -0,0 +1,47 @@
+package projectionRebuildsCommandsV1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+
+	"emperror.dev/errors"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type CancelProjectionRebuildHandler struct {
+	log               logger.Logger
+	projectionManager eventstroredb.ProjectionManager
+}
+
+func NewCancelProjectionRebuildHandler(
+	log logger.Logger,
+	projectionManager eventstroredb.ProjectionManager,
+) *CancelProjectionRebuildHandler {
+	return &CancelProjectionRebuildHandler{
+		log:               log,
+		projectionManager: projectionManager,
+	}
+}
+
+func (c *CancelProjectionRebuildHandler) Handle(
+	ctx context.Context,
+	command *CancelProjectionRebuild,
+) (*mediatr.Unit, error) {
+	err := c.projectionManager.Cancel(command.Projection)
+	if err != nil {
+		return nil, errors.WithMessage(
+			err,
+			"[CancelProjectionRebuildHandler_Handle.Cancel] error in canceling the projection rebuild",
+		)
+	}
+
+	c.log.Infow(
+		fmt.Sprintf("[CancelProjectionRebuildHandler.Handle] rebuild of projection %s canceled", command.Projection),
+		logger.Fields{"Projection": command.Projection, "CanceledBy": command.CanceledBy},
+	)
+
+	return &mediatr.Unit{}, nil
+}
@@ -0,0 +1,31 @@
+package projectionRebuildsCommandsV1
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// StartProjectionRebuild drops the read model of a projection and rebuilds it by replaying the events from the start
+// of the event store, it is sent by the back-office users
+type StartProjectionRebuild struct {
+	Projection string
+	StartedBy  string
+}
+
+func NewStartProjectionRebuild(projection string, startedBy string) (*StartProjectionRebuild, error) {
+	command := &StartProjectionRebuild{Projection: projection, StartedBy: startedBy}
+
+	err := command.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+func (c StartProjectionRebuild) Validate() error {
+	return validation.ValidateStruct(
+		&c,
+		validation.Field(&c.Projection, validation.Required),
+		validation.Field(&c.StartedBy, validation.Required),
+	)
+}
@@ -0,0 +1,47 @@
+package projectionRebuildsCommandsV1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/rebuilding_projections/v1/dtos"
+
+	"emperror.dev/errors"
+)
+
+type StartProjectionRebuildHandler struct {
+	log               logger.Logger
+	projectionManager eventstroredb.ProjectionManager
+}
+
+func NewStartProjectionRebuildHandler(
+	log logger.Logger,
+	projectionManager eventstroredb.ProjectionManager,
+) *StartProjectionRebuildHandler {
+	return &StartProjectionRebuildHandler{
+		log:               log,
+		projectionManager: projectionManager,
+	}
+}
+
+func (c *StartProjectionRebuildHandler) Handle(
+	ctx context.Context,
+	command *StartProjectionRebuild,
+) (*dtos.StartProjectionRebuildResponseDto, error) {
+	progress, err := c.projectionManager.Rebuild(ctx, command.Projection, command.StartedBy)
+	if err != nil {
+		return nil, errors.WithMessage(
+			err,
+			"[StartProjectionRebuildHandler_Handle.Rebuild] error in starting the projection rebuild",
+		)
+	}
+
+	c.log.Infow(
+		fmt.Sprintf("[StartProjectionRebuildHandler.Handle] rebuild of projection %s started", command.Projection),
+		logger.Fields{"Projection": command.Projection, "StartedBy": command.StartedBy},
+	)
+
+	return &dtos.StartProjectionRebuildResponseDto{Rebuild: dtos.NewProjectionRebuildDto(progress)}, nil
+}
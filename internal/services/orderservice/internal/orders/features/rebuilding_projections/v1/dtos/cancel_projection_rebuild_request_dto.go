@@ -0,0 +1,5 @@
+package dtos
+
+type CancelProjectionRebuildRequestDto struct {
+	Projection string `json:"-" param:"projection"`
+}
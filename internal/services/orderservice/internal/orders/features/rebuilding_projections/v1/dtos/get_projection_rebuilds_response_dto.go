@@ -0,0 +1,5 @@
+package dtos
+
+type GetProjectionRebuildsResponseDto struct {
+	Rebuilds []*ProjectionRebuildDto `json:"rebuilds"`
+}
@@ -0,0 +1,38 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb"
+)
+
+// ProjectionRebuildDto is the progress of the last rebuild of a projection in the responding instance
+type ProjectionRebuildDto struct {
+	Projection     string     `json:"projection"`
+	Status         string     `json:"status"`
+	Paused         bool       `json:"paused"`
+	StartedBy      string     `json:"startedBy,omitempty"`
+	ReplayedEvents int64      `json:"replayedEvents"`
+	Position       uint64     `json:"position"`
+	EndPosition    uint64     `json:"endPosition"`
+	Percent        float64    `json:"percent"`
+	StartedAt      *time.Time `json:"startedAt,omitempty"`
+	CompletedAt    *time.Time `json:"completedAt,omitempty"`
+	Error          string     `json:"error,omitempty"`
+}
+
+func NewProjectionRebuildDto(progress *eventstroredb.RebuildProgress) *ProjectionRebuildDto {
+	return &ProjectionRebuildDto{
+		Projection:     progress.Projection,
+		Status:         string(progress.Status),
+		Paused:         progress.Paused,
+		StartedBy:      progress.StartedBy,
+		ReplayedEvents: progress.ReplayedEvents,
+		Position:       progress.Position,
+		EndPosition:    progress.EndPosition,
+		Percent:        progress.Percent(),
+		StartedAt:      progress.StartedAt,
+		CompletedAt:    progress.CompletedAt,
+		Error:          progress.Error,
+	}
+}
@@ -0,0 +1,5 @@
+package dtos
+
+type StartProjectionRebuildRequestDto struct {
+	Projection string `json:"-" param:"projection"`
+}
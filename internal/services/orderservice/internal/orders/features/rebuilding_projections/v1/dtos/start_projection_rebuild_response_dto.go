@@ -0,0 +1,5 @@
+package dtos
+
+type StartProjectionRebuildResponseDto struct {
+	Rebuild *ProjectionRebuildDto `json:"rebuild"`
+}
@@ -0,0 +1,87 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	apikey "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/api_key"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	projectionRebuildsCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/rebuilding_projections/v1/commands"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/rebuilding_projections/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type cancelProjectionRebuildEndpoint struct {
+	params.BackOfficeRouteParams
+}
+
+func NewCancelProjectionRebuildEndpoint(params params.BackOfficeRouteParams) route.Endpoint {
+	return &cancelProjectionRebuildEndpoint{BackOfficeRouteParams: params}
+}
+
+func (ep *cancelProjectionRebuildEndpoint) MapEndpoint() {
+	ep.BackOfficeProjectionsGroup.DELETE("/rebuilds/:projection", ep.handler())
+}
+
+// CancelProjectionRebuild
+// @Tags BackOffice
+// @Summary Cancel projection rebuild
+// @Description Stop the running rebuild of a projection, the live projection stays paused until its read model is rebuilt again
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param projection path string true "Projection name, like mongo_customer_segments_projection"
+// @Success 204
+// @Router /api/v1/backoffice/projections/rebuilds/{projection} [delete]
+func (ep *cancelProjectionRebuildEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.CancelProjectionRebuildRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"[cancelProjectionRebuildEndpoint_handler.Bind] error in the binding request",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[cancelProjectionRebuildEndpoint_handler.Bind] err: %v", badRequestErr),
+			)
+			return badRequestErr
+		}
+
+		userId, _ := apikey.UserId(ctx)
+
+		command, err := projectionRebuildsCommandsV1.NewCancelProjectionRebuild(request.Projection, userId)
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"[cancelProjectionRebuildEndpoint_handler.StructCtx] command validation failed",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[cancelProjectionRebuildEndpoint_handler.StructCtx] err: %v", validationErr),
+			)
+			return validationErr
+		}
+
+		_, err = cqrs.Send[*projectionRebuildsCommandsV1.CancelProjectionRebuild, *mediatr.Unit](
+			ctx,
+			command,
+		)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[cancelProjectionRebuildEndpoint_handler.Send] error in sending CancelProjectionRebuild",
+			)
+			ep.Logger.Error(fmt.Sprintf("[cancelProjectionRebuildEndpoint_handler.Send] err: {%v}", err))
+			return err
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,62 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/rebuilding_projections/v1/dtos"
+	projectionRebuildsQueryV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/rebuilding_projections/v1/queries"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type getProjectionRebuildsEndpoint struct {
+	params.BackOfficeRouteParams
+}
+
+func NewGetProjectionRebuildsEndpoint(params params.BackOfficeRouteParams) route.Endpoint {
+	return &getProjectionRebuildsEndpoint{BackOfficeRouteParams: params}
+}
+
+func (ep *getProjectionRebuildsEndpoint) MapEndpoint() {
+	ep.BackOfficeProjectionsGroup.GET("/rebuilds", ep.handler())
+}
+
+// GetProjectionRebuilds
+// @Tags BackOffice
+// @Summary Get projection rebuilds
+// @Description Get the rebuild progress of the rebuildable projections in the responding instance
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} dtos.GetProjectionRebuildsResponseDto
+// @Router /api/v1/backoffice/projections/rebuilds [get]
+func (ep *getProjectionRebuildsEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		query, err := projectionRebuildsQueryV1.NewGetProjectionRebuilds()
+		if err != nil {
+			return err
+		}
+
+		queryResult, err := cqrs.Send[*projectionRebuildsQueryV1.GetProjectionRebuilds, *dtos.GetProjectionRebuildsResponseDto](
+			ctx,
+			query,
+		)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[getProjectionRebuildsEndpoint_handler.Send] error in sending GetProjectionRebuilds",
+			)
+			ep.Logger.Error(fmt.Sprintf("[getProjectionRebuildsEndpoint_handler.Send] err: {%v}", err))
+			return err
+		}
+
+		return c.JSON(http.StatusOK, queryResult)
+	}
+}
@@ -0,0 +1,86 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	apikey "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/api_key"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	projectionRebuildsCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/rebuilding_projections/v1/commands"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/rebuilding_projections/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type startProjectionRebuildEndpoint struct {
+	params.BackOfficeRouteParams
+}
+
+func NewStartProjectionRebuildEndpoint(params params.BackOfficeRouteParams) route.Endpoint {
+	return &startProjectionRebuildEndpoint{BackOfficeRouteParams: params}
+}
+
+func (ep *startProjectionRebuildEndpoint) MapEndpoint() {
+	ep.BackOfficeProjectionsGroup.POST("/rebuilds/:projection", ep.handler())
+}
+
+// StartProjectionRebuild
+// @Tags BackOffice
+// @Summary Start projection rebuild
+// @Description Pause the live projection, drop its read model and rebuild it by replaying the events from the start of the event store
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param projection path string true "Projection name, like mongo_customer_segments_projection"
+// @Success 202 {object} dtos.StartProjectionRebuildResponseDto
+// @Router /api/v1/backoffice/projections/rebuilds/{projection} [post]
+func (ep *startProjectionRebuildEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.StartProjectionRebuildRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"[startProjectionRebuildEndpoint_handler.Bind] error in the binding request",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[startProjectionRebuildEndpoint_handler.Bind] err: %v", badRequestErr),
+			)
+			return badRequestErr
+		}
+
+		userId, _ := apikey.UserId(ctx)
+
+		command, err := projectionRebuildsCommandsV1.NewStartProjectionRebuild(request.Projection, userId)
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"[startProjectionRebuildEndpoint_handler.StructCtx] command validation failed",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[startProjectionRebuildEndpoint_handler.StructCtx] err: %v", validationErr),
+			)
+			return validationErr
+		}
+
+		result, err := cqrs.Send[*projectionRebuildsCommandsV1.StartProjectionRebuild, *dtos.StartProjectionRebuildResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[startProjectionRebuildEndpoint_handler.Send] error in sending StartProjectionRebuild",
+			)
+			ep.Logger.Error(fmt.Sprintf("[startProjectionRebuildEndpoint_handler.Send] err: {%v}", err))
+			return err
+		}
+
+		return c.JSON(http.StatusAccepted, result)
+	}
+}
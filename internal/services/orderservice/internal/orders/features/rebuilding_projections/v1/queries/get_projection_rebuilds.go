@@ -0,0 +1,8 @@
+package projectionRebuildsQueryV1
+
+// GetProjectionRebuilds returns the rebuild progress of the rebuildable projections in the responding instance
+type GetProjectionRebuilds struct{}
+
+func NewGetProjectionRebuilds() (*GetProjectionRebuilds, error) {
+	return &GetProjectionRebuilds{}, nil
+}
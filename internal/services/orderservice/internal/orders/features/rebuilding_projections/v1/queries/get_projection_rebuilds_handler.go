@@ -0,0 +1,40 @@
+package projectionRebuildsQueryV1
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/rebuilding_projections/v1/dtos"
+)
+
+type GetProjectionRebuildsHandler struct {
+	log               logger.Logger
+	projectionManager eventstroredb.ProjectionManager
+}
+
+func NewGetProjectionRebuildsHandler(
+	log logger.Logger,
+	projectionManager eventstroredb.ProjectionManager,
+) *GetProjectionRebuildsHandler {
+	return &GetProjectionRebuildsHandler{
+		log:               log,
+		projectionManager: projectionManager,
+	}
+}
+
+func (c *GetProjectionRebuildsHandler) Handle(
+	ctx context.Context,
+	query *GetProjectionRebuilds,
+) (*dtos.GetProjectionRebuildsResponseDto, error) {
+	rebuilds := c.projectionManager.Rebuilds()
+
+	rebuildsDto := make([]*dtos.ProjectionRebuildDto, 0, len(rebuilds))
+	for _, rebuild := range rebuilds {
+		rebuildsDto = append(rebuildsDto, dtos.NewProjectionRebuildDto(rebuild))
+	}
+
+	c.log.Info("[GetProjectionRebuildsHandler.Handle] projection rebuilds fetched")
+
+	return &dtos.GetProjectionRebuildsResponseDto{Rebuilds: rebuildsDto}, nil
+}
@@ -0,0 +1,42 @@
+package domainEvents
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/domain"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// GiftCardRedeemedV1 is applied on the gift card when it pays an order
+type GiftCardRedeemedV1 struct {
+	*domain.DomainEvent
+	OrderId    uuid.UUID `json:"orderId"`
+	Amount     float64   `json:"amount"`
+	RedeemedAt time.Time `json:"redeemedAt"`
+}
+
+func NewGiftCardRedeemedV1(orderId uuid.UUID, amount float64, redeemedAt time.Time) (*GiftCardRedeemedV1, error) {
+	if orderId == uuid.Nil {
+		return nil, customErrors.NewDomainError("orderId of the redemption is required")
+	}
+
+	if amount <= 0 {
+		return nil, customErrors.NewDomainError("redeemed amount should be greater than zero")
+	}
+
+	if redeemedAt.IsZero() {
+		return nil, customErrors.NewDomainError("redeemedAt can't be zero")
+	}
+
+	eventData := &GiftCardRedeemedV1{
+		OrderId:    orderId,
+		Amount:     amount,
+		RedeemedAt: redeemedAt,
+	}
+	eventData.DomainEvent = domain.NewDomainEvent(typeMapper.GetTypeName(eventData))
+
+	return eventData, nil
+}
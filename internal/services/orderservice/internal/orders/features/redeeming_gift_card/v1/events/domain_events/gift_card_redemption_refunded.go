@@ -0,0 +1,39 @@
+package domainEvents
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/domain"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// GiftCardRedemptionRefundedV1 is the compensation of GiftCardRedeemedV1, it is applied when the paid order is canceled
+// or storing the order fails
+type GiftCardRedemptionRefundedV1 struct {
+	*domain.DomainEvent
+	OrderId    uuid.UUID `json:"orderId"`
+	Amount     float64   `json:"amount"`
+	RefundedAt time.Time `json:"refundedAt"`
+}
+
+func NewGiftCardRedemptionRefundedV1(
+	orderId uuid.UUID,
+	amount float64,
+	refundedAt time.Time,
+) (*GiftCardRedemptionRefundedV1, error) {
+	if refundedAt.IsZero() {
+		return nil, customErrors.NewDomainError("refundedAt can't be zero")
+	}
+
+	eventData := &GiftCardRedemptionRefundedV1{
+		OrderId:    orderId,
+		Amount:     amount,
+		RefundedAt: refundedAt,
+	}
+	eventData.DomainEvent = domain.NewDomainEvent(typeMapper.GetTypeName(eventData))
+
+	return eventData, nil
+}
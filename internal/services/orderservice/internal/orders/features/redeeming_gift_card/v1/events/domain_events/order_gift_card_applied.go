@@ -0,0 +1,42 @@
+package domainEvents
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/domain"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// OrderGiftCardAppliedV1 is applied on the order when a part or all of its total price is paid with a gift card
+type OrderGiftCardAppliedV1 struct {
+	*domain.DomainEvent
+	GiftCardId uuid.UUID `json:"giftCardId"`
+	Amount     float64   `json:"amount"`
+	AppliedAt  time.Time `json:"appliedAt"`
+}
+
+func NewOrderGiftCardAppliedV1(giftCardId uuid.UUID, amount float64, appliedAt time.Time) (*OrderGiftCardAppliedV1, error) {
+	if giftCardId == uuid.Nil {
+		return nil, customErrors.NewDomainError("giftCardId is required")
+	}
+
+	if amount <= 0 {
+		return nil, customErrors.NewDomainError("applied amount should be greater than zero")
+	}
+
+	if appliedAt.IsZero() {
+		return nil, customErrors.NewDomainError("appliedAt can't be zero")
+	}
+
+	eventData := &OrderGiftCardAppliedV1{
+		GiftCardId: giftCardId,
+		Amount:     amount,
+		AppliedAt:  appliedAt,
+	}
+	eventData.DomainEvent = domain.NewDomainEvent(typeMapper.GetTypeName(eventData))
+
+	return eventData, nil
+}
@@ -0,0 +1,23 @@
+package integrationEvents
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// CheckGiftCardRedemptionV1 is published by the orders service to itself after a gift card redemption, the redemption is
+// refunded when its order is not stored until the message is delivered
+type CheckGiftCardRedemptionV1 struct {
+	*types.Message
+	GiftCardId string `json:"giftCardId"`
+	OrderId    string `json:"orderId"`
+}
+
+func NewCheckGiftCardRedemptionV1(giftCardId string, orderId string) *CheckGiftCardRedemptionV1 {
+	return &CheckGiftCardRedemptionV1{
+		Message:    types.NewMessage(uuid.NewV4().String()),
+		GiftCardId: giftCardId,
+		OrderId:    orderId,
+	}
+}
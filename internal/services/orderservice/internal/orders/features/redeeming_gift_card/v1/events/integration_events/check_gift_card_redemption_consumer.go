@@ -0,0 +1,117 @@
+package integrationEvents
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/store"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/attribute"
+	giftCardAggregate "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/giftcards/aggregate"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/aggregate"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+type checkGiftCardRedemptionConsumer struct {
+	logger                 logger.Logger
+	orderAggregateStore    store.AggregateStore[*aggregate.Order]
+	giftCardAggregateStore store.AggregateStore[*giftCardAggregate.GiftCard]
+	tracer                 tracing.AppTracer
+}
+
+// NewCheckGiftCardRedemptionConsumer refunds the gift card redemption of an order which is not stored, e.g. storing the
+// order failed after the gift card was stored. the errors are returned, so the message is redelivered until the refund
+// is stored, refunding is a no-op for a refunded redemption, so a redelivered message doesn't refund it twice.
+func NewCheckGiftCardRedemptionConsumer(
+	logger logger.Logger,
+	orderAggregateStore store.AggregateStore[*aggregate.Order],
+	giftCardAggregateStore store.AggregateStore[*giftCardAggregate.GiftCard],
+	tracer tracing.AppTracer,
+) consumer.ConsumerHandler {
+	return &checkGiftCardRedemptionConsumer{
+		logger:                 logger,
+		orderAggregateStore:    orderAggregateStore,
+		giftCardAggregateStore: giftCardAggregateStore,
+		tracer:                 tracer,
+	}
+}
+
+func (c *checkGiftCardRedemptionConsumer) Handle(
+	ctx context.Context,
+	consumeContext types.MessageConsumeContext,
+) error {
+	message, ok := consumeContext.Message().(*CheckGiftCardRedemptionV1)
+	if !ok {
+		return errors.New("error in casting message to CheckGiftCardRedemptionV1")
+	}
+
+	ctx, span := c.tracer.Start(ctx, "checkGiftCardRedemptionConsumer.Handle")
+	span.SetAttributes(attribute.Object("Message", consumeContext.Message()))
+	defer span.End()
+
+	orderId, err := uuid.FromString(message.OrderId)
+	if err != nil {
+		return errors.WrapIf(err, "[checkGiftCardRedemptionConsumer_Handle.FromString] orderId is not valid")
+	}
+
+	giftCardId, err := uuid.FromString(message.GiftCardId)
+	if err != nil {
+		return errors.WrapIf(err, "[checkGiftCardRedemptionConsumer_Handle.FromString] giftCardId is not valid")
+	}
+
+	// the redemption of a stored order is refunded by the gift card compensation projection when the order is canceled
+	exists, err := c.orderAggregateStore.Exists(ctx, orderId)
+	if err != nil {
+		return errors.WithMessage(
+			err,
+			"[checkGiftCardRedemptionConsumer_Handle.Exists] error in checking order existence",
+		)
+	}
+	if exists {
+		return nil
+	}
+
+	giftCard, err := c.giftCardAggregateStore.Load(ctx, giftCardId)
+	if err != nil {
+		return errors.WithMessage(
+			err,
+			"[checkGiftCardRedemptionConsumer_Handle.Load] error in loading gift card aggregate",
+		)
+	}
+
+	refunded, err := giftCard.RefundRedemption(orderId, time.Now())
+	if err != nil {
+		return errors.WithMessage(
+			err,
+			"[checkGiftCardRedemptionConsumer_Handle.RefundRedemption] error in refunding the gift card",
+		)
+	}
+	if !refunded {
+		return nil
+	}
+
+	_, err = c.giftCardAggregateStore.Store(giftCard, nil, ctx)
+	if err != nil {
+		return errors.WithMessage(
+			err,
+			"[checkGiftCardRedemptionConsumer_Handle.Store] error in storing gift card aggregate",
+		)
+	}
+
+	c.logger.Infow(
+		fmt.Sprintf(
+			"[checkGiftCardRedemptionConsumer.Handle] gift card with id: {%s} refunded for not stored order with id: {%s}",
+			giftCardId,
+			orderId,
+		),
+		logger.Fields{"GiftCardId": giftCardId, "OrderId": orderId},
+	)
+
+	return nil
+}
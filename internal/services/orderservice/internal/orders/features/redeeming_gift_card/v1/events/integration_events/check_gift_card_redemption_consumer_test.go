@@ -0,0 +1,135 @@
+package integrationEvents
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	esMocks "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/mocks"
+	appendResult "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/append_result"
+	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	giftCardAggregate "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/giftcards/aggregate"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/aggregate"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testGiftCardCode = "ABCD-EFGH-IJKL-MNOP"
+
+// checkGiftCardRedemptionFixture loads the gift card from its stored state, like the aggregate store, so a refund which
+// is not stored is not loaded again
+type checkGiftCardRedemptionFixture struct {
+	orderAggregateStore    *esMocks.AggregateStore[*aggregate.Order]
+	giftCardAggregateStore *esMocks.AggregateStore[*giftCardAggregate.GiftCard]
+	giftCardId             uuid.UUID
+	orderId                uuid.UUID
+	refundStored           bool
+}
+
+func newCheckGiftCardRedemptionFixture(t *testing.T) *checkGiftCardRedemptionFixture {
+	t.Helper()
+
+	fixture := &checkGiftCardRedemptionFixture{
+		orderAggregateStore:    esMocks.NewAggregateStore[*aggregate.Order](t),
+		giftCardAggregateStore: esMocks.NewAggregateStore[*giftCardAggregate.GiftCard](t),
+		giftCardId:             giftCardAggregate.GiftCardId(testGiftCardCode),
+		orderId:                uuid.NewV4(),
+	}
+
+	fixture.giftCardAggregateStore.EXPECT().
+		Load(mock.Anything, fixture.giftCardId).
+		RunAndReturn(func(context.Context, uuid.UUID) (*giftCardAggregate.GiftCard, error) {
+			return fixture.loadGiftCard(t), nil
+		}).
+		Maybe()
+
+	return fixture
+}
+
+func (f *checkGiftCardRedemptionFixture) loadGiftCard(t *testing.T) *giftCardAggregate.GiftCard {
+	t.Helper()
+
+	giftCard, err := giftCardAggregate.NewGiftCard(testGiftCardCode, 100, "backoffice-admin", time.Now())
+	require.NoError(t, err)
+	require.NoError(t, giftCard.Activate("backoffice-admin", time.Now()))
+	require.NoError(t, giftCard.Redeem(f.orderId, 60, time.Now()))
+
+	if f.refundStored {
+		_, err = giftCard.RefundRedemption(f.orderId, time.Now())
+		require.NoError(t, err)
+	}
+
+	return giftCard
+}
+
+func (f *checkGiftCardRedemptionFixture) handle() error {
+	message := NewCheckGiftCardRedemptionV1(f.giftCardId.String(), f.orderId.String())
+	consumer := NewCheckGiftCardRedemptionConsumer(
+		defaultLogger.GetLogger(),
+		f.orderAggregateStore,
+		f.giftCardAggregateStore,
+		tracing.NewAppTracer("check-gift-card-redemption-consumer-test"),
+	)
+
+	return consumer.Handle(
+		context.Background(),
+		types.NewMessageConsumeContext(
+			message,
+			metadata.Metadata{},
+			"application/json",
+			"CheckGiftCardRedemptionV1",
+			time.Now(),
+			1,
+			message.GeMessageId(),
+			"",
+		),
+	)
+}
+
+func Test_Check_Gift_Card_Redemption_Keeps_The_Redemption_Of_A_Stored_Order(t *testing.T) {
+	fixture := newCheckGiftCardRedemptionFixture(t)
+	fixture.orderAggregateStore.EXPECT().Exists(mock.Anything, fixture.orderId).Return(true, nil)
+
+	// the gift card is not loaded and stored
+	require.NoError(t, fixture.handle())
+}
+
+func Test_Check_Gift_Card_Redemption_Refunds_The_Redemption_Of_A_Not_Stored_Order(t *testing.T) {
+	fixture := newCheckGiftCardRedemptionFixture(t)
+	fixture.orderAggregateStore.EXPECT().Exists(mock.Anything, fixture.orderId).Return(false, nil)
+
+	// a failed store is returned, so the message is redelivered and the refund is retried
+	fixture.giftCardAggregateStore.EXPECT().
+		Store(mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("connection refused")).
+		Once()
+	assert.ErrorContains(t, fixture.handle(), "connection refused")
+
+	var stored *giftCardAggregate.GiftCard
+	fixture.giftCardAggregateStore.EXPECT().
+		Store(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(
+			giftCard *giftCardAggregate.GiftCard,
+			_ metadata.Metadata,
+			_ context.Context,
+		) (*appendResult.AppendEventsResult, error) {
+			stored = giftCard
+			fixture.refundStored = true
+
+			return &appendResult.AppendEventsResult{}, nil
+		}).
+		Once()
+	require.NoError(t, fixture.handle())
+	require.NotNil(t, stored)
+	assert.Equal(t, 100.0, stored.Balance())
+
+	// a redelivered message doesn't refund the redemption twice
+	require.NoError(t, fixture.handle())
+}
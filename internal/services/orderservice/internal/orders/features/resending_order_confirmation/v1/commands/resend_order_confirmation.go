@@ -0,0 +1,33 @@
+package resendOrderConfirmationCommandsV1
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+// ResendOrderConfirmation publishes the order again for sending its confirmation email
+type ResendOrderConfirmation struct {
+	OrderId     uuid.UUID
+	RequestedBy string
+}
+
+func NewResendOrderConfirmation(orderId uuid.UUID, requestedBy string) (*ResendOrderConfirmation, error) {
+	command := &ResendOrderConfirmation{
+		OrderId:     orderId,
+		RequestedBy: requestedBy,
+	}
+
+	err := command.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+func (c ResendOrderConfirmation) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.OrderId, validation.Required),
+		validation.Field(&c.RequestedBy, validation.Required),
+	)
+}
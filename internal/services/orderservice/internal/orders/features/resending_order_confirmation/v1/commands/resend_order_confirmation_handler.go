@@ -0,0 +1,94 @@
+package resendOrderConfirmationCommandsV1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/bus"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mapper"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/repositories"
+	dtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/dtos/v1"
+	integrationEvents "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/resending_order_confirmation/v1/events/integration_events"
+
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type ResendOrderConfirmationHandler struct {
+	log                      logger.Logger
+	mongoOrderReadRepository repositories.OrderMongoRepository
+	outboxPublisher          bus.OutboxPublisher
+	tracer                   tracing.AppTracer
+}
+
+// NewResendOrderConfirmationHandler stores the resend request in the outbox, so it is published by the outbox worker
+// even when the broker is not available during the request
+func NewResendOrderConfirmationHandler(
+	log logger.Logger,
+	mongoOrderReadRepository repositories.OrderMongoRepository,
+	outboxPublisher bus.OutboxPublisher,
+	tracer tracing.AppTracer,
+) *ResendOrderConfirmationHandler {
+	return &ResendOrderConfirmationHandler{
+		log:                      log,
+		mongoOrderReadRepository: mongoOrderReadRepository,
+		outboxPublisher:          outboxPublisher,
+		tracer:                   tracer,
+	}
+}
+
+func (c *ResendOrderConfirmationHandler) Handle(
+	ctx context.Context,
+	command *ResendOrderConfirmation,
+) (*mediatr.Unit, error) {
+	order, err := c.mongoOrderReadRepository.GetOrderByOrderId(ctx, command.OrderId)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			fmt.Sprintf(
+				"[ResendOrderConfirmationHandler_Handle.GetOrderByOrderId] error in getting order with id %s in the mongo repository",
+				command.OrderId,
+			),
+		)
+	}
+	if order == nil {
+		return nil, customErrors.NewNotFoundError(
+			fmt.Sprintf("[ResendOrderConfirmationHandler_Handle.GetOrderByOrderId] order with id %s not found", command.OrderId),
+		)
+	}
+	if order.Canceled {
+		return nil, customErrors.NewConflictError(
+			fmt.Sprintf("[ResendOrderConfirmationHandler_Handle] order with id %s is canceled", command.OrderId),
+		)
+	}
+
+	orderReadDto, err := mapper.Map[*dtosV1.OrderReadDto](order)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[ResendOrderConfirmationHandler_Handle.Map] error in the mapping OrderReadDto",
+		)
+	}
+
+	resendEvent := integrationEvents.NewOrderConfirmationResendRequestedV1(orderReadDto, command.RequestedBy)
+
+	err = c.outboxPublisher.PublishViaOutbox(ctx, resendEvent, nil)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[ResendOrderConfirmationHandler_Handle.PublishViaOutbox] error in publishing OrderConfirmationResendRequested integration_events event",
+		)
+	}
+
+	c.log.Infow(
+		fmt.Sprintf(
+			"[ResendOrderConfirmationHandler.Handle] confirmation of order with id: {%s} requested to be resent, the request is stored in the outbox",
+			command.OrderId,
+		),
+		logger.Fields{"Id": command.OrderId, "MessageId": resendEvent.MessageId, "RequestedBy": command.RequestedBy},
+	)
+
+	return &mediatr.Unit{}, nil
+}
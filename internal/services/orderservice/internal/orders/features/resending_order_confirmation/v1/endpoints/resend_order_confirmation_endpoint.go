@@ -0,0 +1,95 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	apikey "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/api_key"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	resendOrderConfirmationCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/resending_order_confirmation/v1/commands"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/resending_order_confirmation/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type resendOrderConfirmationEndpoint struct {
+	params.BackOfficeRouteParams
+}
+
+func NewResendOrderConfirmationEndpoint(params params.BackOfficeRouteParams) route.Endpoint {
+	return &resendOrderConfirmationEndpoint{BackOfficeRouteParams: params}
+}
+
+func (ep *resendOrderConfirmationEndpoint) MapEndpoint() {
+	ep.BackOfficeGroup.POST("/:id/confirmation/resend", ep.handler())
+}
+
+// ResendOrderConfirmation
+// @Tags BackOffice
+// @Summary Resend order confirmation
+// @Description Publish the order again for sending its confirmation email
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Order ID"
+// @Success 202
+// @Router /api/v1/backoffice/orders/{id}/confirmation/resend [post]
+func (ep *resendOrderConfirmationEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.ResendOrderConfirmationRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"[resendOrderConfirmationEndpoint_handler.Bind] error in the binding request",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[resendOrderConfirmationEndpoint_handler.Bind] err: %v", badRequestErr),
+			)
+			return badRequestErr
+		}
+
+		userId, _ := apikey.UserId(ctx)
+
+		command, err := resendOrderConfirmationCommandsV1.NewResendOrderConfirmation(request.OrderId, userId)
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"[resendOrderConfirmationEndpoint_handler.StructCtx] command validation failed",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[resendOrderConfirmationEndpoint_handler.StructCtx] err: %v", validationErr),
+			)
+			return validationErr
+		}
+
+		_, err = cqrs.Send[*resendOrderConfirmationCommandsV1.ResendOrderConfirmation, *mediatr.Unit](
+			ctx,
+			command,
+		)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[resendOrderConfirmationEndpoint_handler.Send] error in sending ResendOrderConfirmation",
+			)
+			ep.Logger.Errorw(
+				fmt.Sprintf(
+					"[resendOrderConfirmationEndpoint_handler.Send] id: {%s}, err: %v",
+					command.OrderId,
+					err,
+				),
+				logger.Fields{"Id": command.OrderId},
+			)
+			return err
+		}
+
+		return c.NoContent(http.StatusAccepted)
+	}
+}
@@ -0,0 +1,26 @@
+package integrationEvents
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	dtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/dtos/v1"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// OrderConfirmationResendRequestedV1 asks the notification consumers to send the confirmation email of the order again
+type OrderConfirmationResendRequestedV1 struct {
+	*types.Message
+	*dtosV1.OrderReadDto
+	RequestedBy string `json:"requestedBy"`
+}
+
+func NewOrderConfirmationResendRequestedV1(
+	orderReadDto *dtosV1.OrderReadDto,
+	requestedBy string,
+) *OrderConfirmationResendRequestedV1 {
+	return &OrderConfirmationResendRequestedV1{
+		Message:      types.NewMessage(uuid.NewV4().String()),
+		OrderReadDto: orderReadDto,
+		RequestedBy:  requestedBy,
+	}
+}
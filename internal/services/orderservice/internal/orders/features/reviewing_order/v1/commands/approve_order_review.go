@@ -0,0 +1,37 @@
+package reviewOrderCommandsV1
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+type ApproveOrderReview struct {
+	OrderId    uuid.UUID
+	Note       string
+	ReviewedAt time.Time
+}
+
+func NewApproveOrderReview(orderId uuid.UUID, note string) (*ApproveOrderReview, error) {
+	command := &ApproveOrderReview{
+		OrderId:    orderId,
+		Note:       note,
+		ReviewedAt: time.Now(),
+	}
+
+	err := command.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+func (c ApproveOrderReview) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.OrderId, validation.Required),
+		validation.Field(&c.Note, validation.Length(0, 1000)),
+		validation.Field(&c.ReviewedAt, validation.Required),
+	)
+}
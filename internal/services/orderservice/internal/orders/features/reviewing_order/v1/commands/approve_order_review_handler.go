@@ -0,0 +1,76 @@
+package reviewOrderCommandsV1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/store"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/aggregate"
+
+	"emperror.dev/errors"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type ApproveOrderReviewHandler struct {
+	log            logger.Logger
+	aggregateStore store.AggregateStore[*aggregate.Order]
+	tracer         tracing.AppTracer
+}
+
+func NewApproveOrderReviewHandler(
+	log logger.Logger,
+	aggregateStore store.AggregateStore[*aggregate.Order],
+	tracer tracing.AppTracer,
+) *ApproveOrderReviewHandler {
+	return &ApproveOrderReviewHandler{
+		log:            log,
+		aggregateStore: aggregateStore,
+		tracer:         tracer,
+	}
+}
+
+func (c *ApproveOrderReviewHandler) Handle(
+	ctx context.Context,
+	command *ApproveOrderReview,
+) (*mediatr.Unit, error) {
+	exists, err := c.aggregateStore.Exists(ctx, command.OrderId)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[ApproveOrderReviewHandler_Handle.Exists] error in checking order existence",
+		)
+	}
+	if !exists {
+		return nil, customErrors.NewNotFoundError(
+			fmt.Sprintf("[ApproveOrderReviewHandler_Handle.Exists] order with id %s not found", command.OrderId),
+		)
+	}
+
+	// the order is reloaded and updated again when it is changed by a concurrent update
+	_, _, err = c.aggregateStore.Update(ctx, command.OrderId, nil, func(order *aggregate.Order) error {
+		// the domain error is kept as is, so a not held order results in a conflict response
+		return order.ApproveReview(command.Note, command.ReviewedAt)
+	})
+	if err != nil {
+		// the domain errors and the unresolved concurrency conflicts are kept as is, so they result in conflict responses
+		if customErrors.IsConflictError(err) || customErrors.IsDomainError(err, http.StatusBadRequest) {
+			return nil, errors.WithMessage(err, "[ApproveOrderReviewHandler_Handle.Update] error in reviewing the order")
+		}
+
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[ApproveOrderReviewHandler_Handle.Update] error in updating order aggregate",
+		)
+	}
+
+	c.log.Infow(
+		fmt.Sprintf("[ApproveOrderReviewHandler.Handle] review of order with id: {%s} approved", command.OrderId),
+		logger.Fields{"Id": command.OrderId},
+	)
+
+	return &mediatr.Unit{}, nil
+}
@@ -0,0 +1,37 @@
+package reviewOrderCommandsV1
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+type RejectOrderReview struct {
+	OrderId    uuid.UUID
+	Reason     string
+	ReviewedAt time.Time
+}
+
+func NewRejectOrderReview(orderId uuid.UUID, reason string) (*RejectOrderReview, error) {
+	command := &RejectOrderReview{
+		OrderId:    orderId,
+		Reason:     reason,
+		ReviewedAt: time.Now(),
+	}
+
+	err := command.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+func (c RejectOrderReview) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.OrderId, validation.Required),
+		validation.Field(&c.Reason, validation.Required, validation.Length(0, 1000)),
+		validation.Field(&c.ReviewedAt, validation.Required),
+	)
+}
@@ -0,0 +1,8 @@
+package dtos
+
+import uuid "github.com/satori/go.uuid"
+
+type ApproveOrderReviewRequestDto struct {
+	OrderId uuid.UUID `json:"-"    param:"id"`
+	Note    string    `json:"note"`
+}
@@ -0,0 +1,92 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	reviewOrderCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/reviewing_order/v1/commands"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/reviewing_order/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type approveOrderReviewEndpoint struct {
+	params.OrderRouteParams
+}
+
+func NewApproveOrderReviewEndpoint(params params.OrderRouteParams) route.Endpoint {
+	return &approveOrderReviewEndpoint{OrderRouteParams: params}
+}
+
+func (ep *approveOrderReviewEndpoint) MapEndpoint() {
+	ep.OrdersGroup.POST("/:id/review/approve", ep.handler())
+}
+
+// ApproveOrderReview
+// @Tags Orders
+// @Summary Approve the order held for review
+// @Description Approve the order held for review
+// @Accept json
+// @Produce json
+// @Param ApproveOrderReviewRequestDto body dtos.ApproveOrderReviewRequestDto true "Review data"
+// @Param id path string true "Order ID"
+// @Success 204
+// @Router /api/v1/orders/{id}/review/approve [post]
+func (ep *approveOrderReviewEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.ApproveOrderReviewRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"[approveOrderReviewEndpoint_handler.Bind] error in the binding request",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[approveOrderReviewEndpoint_handler.Bind] err: %v", badRequestErr),
+			)
+			return badRequestErr
+		}
+
+		command, err := reviewOrderCommandsV1.NewApproveOrderReview(request.OrderId, request.Note)
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"[approveOrderReviewEndpoint_handler.StructCtx] command validation failed",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[approveOrderReviewEndpoint_handler.StructCtx] err: %v", validationErr),
+			)
+			return validationErr
+		}
+
+		_, err = cqrs.Send[*reviewOrderCommandsV1.ApproveOrderReview, *mediatr.Unit](
+			ctx,
+			command,
+		)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[approveOrderReviewEndpoint_handler.Send] error in sending ApproveOrderReview",
+			)
+			ep.Logger.Errorw(
+				fmt.Sprintf(
+					"[approveOrderReviewEndpoint_handler.Send] id: {%s}, err: %v",
+					command.OrderId,
+					err,
+				),
+				logger.Fields{"Id": command.OrderId},
+			)
+			return err
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,92 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	reviewOrderCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/reviewing_order/v1/commands"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/reviewing_order/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type rejectOrderReviewEndpoint struct {
+	params.OrderRouteParams
+}
+
+func NewRejectOrderReviewEndpoint(params params.OrderRouteParams) route.Endpoint {
+	return &rejectOrderReviewEndpoint{OrderRouteParams: params}
+}
+
+func (ep *rejectOrderReviewEndpoint) MapEndpoint() {
+	ep.OrdersGroup.POST("/:id/review/reject", ep.handler())
+}
+
+// RejectOrderReview
+// @Tags Orders
+// @Summary Reject the order held for review, the rejected order is canceled
+// @Description Reject the order held for review, the rejected order is canceled
+// @Accept json
+// @Produce json
+// @Param RejectOrderReviewRequestDto body dtos.RejectOrderReviewRequestDto true "Review data"
+// @Param id path string true "Order ID"
+// @Success 204
+// @Router /api/v1/orders/{id}/review/reject [post]
+func (ep *rejectOrderReviewEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.RejectOrderReviewRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"[rejectOrderReviewEndpoint_handler.Bind] error in the binding request",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[rejectOrderReviewEndpoint_handler.Bind] err: %v", badRequestErr),
+			)
+			return badRequestErr
+		}
+
+		command, err := reviewOrderCommandsV1.NewRejectOrderReview(request.OrderId, request.Reason)
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"[rejectOrderReviewEndpoint_handler.StructCtx] command validation failed",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[rejectOrderReviewEndpoint_handler.StructCtx] err: %v", validationErr),
+			)
+			return validationErr
+		}
+
+		_, err = cqrs.Send[*reviewOrderCommandsV1.RejectOrderReview, *mediatr.Unit](
+			ctx,
+			command,
+		)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[rejectOrderReviewEndpoint_handler.Send] error in sending RejectOrderReview",
+			)
+			ep.Logger.Errorw(
+				fmt.Sprintf(
+					"[rejectOrderReviewEndpoint_handler.Send] id: {%s}, err: %v",
+					command.OrderId,
+					err,
+				),
+				logger.Fields{"Id": command.OrderId},
+			)
+			return err
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,35 @@
+package domainEvents
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/domain"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+)
+
+// OrderHeldForReviewV1 is applied when the fraud screening flags an order, a held order waits for a back-office review
+// before going further
+type OrderHeldForReviewV1 struct {
+	*domain.DomainEvent
+	Reasons []string  `json:"reasons"`
+	HeldAt  time.Time `json:"heldAt"`
+}
+
+func NewOrderHeldForReviewV1(reasons []string, heldAt time.Time) (*OrderHeldForReviewV1, error) {
+	if len(reasons) == 0 {
+		return nil, customErrors.NewDomainError("reasons of holding the order are required")
+	}
+
+	if heldAt.IsZero() {
+		return nil, customErrors.NewDomainError("heldAt can't be zero")
+	}
+
+	eventData := &OrderHeldForReviewV1{
+		Reasons: reasons,
+		HeldAt:  heldAt,
+	}
+	eventData.DomainEvent = domain.NewDomainEvent(typeMapper.GetTypeName(eventData))
+
+	return eventData, nil
+}
@@ -0,0 +1,30 @@
+package domainEvents
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/domain"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+)
+
+// OrderReviewApprovedV1 is applied when a back-office user releases a held order
+type OrderReviewApprovedV1 struct {
+	*domain.DomainEvent
+	Note       string    `json:"note,omitempty"`
+	ReviewedAt time.Time `json:"reviewedAt"`
+}
+
+func NewOrderReviewApprovedV1(note string, reviewedAt time.Time) (*OrderReviewApprovedV1, error) {
+	if reviewedAt.IsZero() {
+		return nil, customErrors.NewDomainError("reviewedAt can't be zero")
+	}
+
+	eventData := &OrderReviewApprovedV1{
+		Note:       note,
+		ReviewedAt: reviewedAt,
+	}
+	eventData.DomainEvent = domain.NewDomainEvent(typeMapper.GetTypeName(eventData))
+
+	return eventData, nil
+}
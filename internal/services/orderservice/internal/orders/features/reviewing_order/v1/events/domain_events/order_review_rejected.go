@@ -0,0 +1,34 @@
+package domainEvents
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/domain"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+)
+
+// OrderReviewRejectedV1 is applied when a back-office user rejects a held order, a rejected order is canceled
+type OrderReviewRejectedV1 struct {
+	*domain.DomainEvent
+	Reason     string    `json:"reason"`
+	ReviewedAt time.Time `json:"reviewedAt"`
+}
+
+func NewOrderReviewRejectedV1(reason string, reviewedAt time.Time) (*OrderReviewRejectedV1, error) {
+	if reason == "" {
+		return nil, customErrors.NewDomainError("reason of rejecting the order is required")
+	}
+
+	if reviewedAt.IsZero() {
+		return nil, customErrors.NewDomainError("reviewedAt can't be zero")
+	}
+
+	eventData := &OrderReviewRejectedV1{
+		Reason:     reason,
+		ReviewedAt: reviewedAt,
+	}
+	eventData.DomainEvent = domain.NewDomainEvent(typeMapper.GetTypeName(eventData))
+
+	return eventData, nil
+}
@@ -0,0 +1,36 @@
+package integrationEvents
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// OrderHeldForReviewV1 notifies the back-office that an order is waiting for a fraud review
+type OrderHeldForReviewV1 struct {
+	*types.Message
+	OrderId      string    `json:"orderId"`
+	AccountEmail string    `json:"accountEmail"`
+	TotalPrice   float64   `json:"totalPrice"`
+	Reasons      []string  `json:"reasons"`
+	HeldAt       time.Time `json:"heldAt"`
+}
+
+func NewOrderHeldForReviewV1(
+	orderId string,
+	accountEmail string,
+	totalPrice float64,
+	reasons []string,
+	heldAt time.Time,
+) *OrderHeldForReviewV1 {
+	return &OrderHeldForReviewV1{
+		Message:      types.NewMessage(uuid.NewV4().String()),
+		OrderId:      orderId,
+		AccountEmail: accountEmail,
+		TotalPrice:   totalPrice,
+		Reasons:      reasons,
+		HeldAt:       heldAt,
+	}
+}
@@ -0,0 +1,64 @@
+package saveOrderDraftCommandsV1
+
+import (
+	"time"
+
+	dtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/dtos/v1"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+// SaveOrderDraft creates a new draft or replaces the incomplete order of an existing draft, the fields of the order
+// are validated when the draft is converted
+type SaveOrderDraft struct {
+	DraftId uuid.UUID
+	// IsNew is true when the draft is created with this save, an existing draft should be found otherwise
+	IsNew           bool
+	ShopItems       []*dtosV1.ShopItemDto
+	AccountEmail    string
+	DeliveryAddress string
+	DeliveryTime    time.Time
+	CustomerGroup   string
+	SavedAt         time.Time
+}
+
+// NewSaveOrderDraft creates a new draft when the draftId is empty
+func NewSaveOrderDraft(
+	draftId uuid.UUID,
+	shopItems []*dtosV1.ShopItemDto,
+	accountEmail, deliveryAddress string,
+	deliveryTime time.Time,
+	customerGroup string,
+) (*SaveOrderDraft, error) {
+	isNew := draftId == uuid.Nil
+	if isNew {
+		draftId = uuid.NewV4()
+	}
+
+	command := &SaveOrderDraft{
+		DraftId:         draftId,
+		IsNew:           isNew,
+		ShopItems:       shopItems,
+		AccountEmail:    accountEmail,
+		DeliveryAddress: deliveryAddress,
+		DeliveryTime:    deliveryTime,
+		CustomerGroup:   customerGroup,
+		SavedAt:         time.Now(),
+	}
+
+	err := command.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+func (c SaveOrderDraft) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.DraftId, validation.Required),
+		validation.Field(&c.ShopItems, validation.Length(0, 100)),
+		validation.Field(&c.SavedAt, validation.Required),
+	)
+}
@@ -0,0 +1,125 @@
+package saveOrderDraftCommandsV1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/store"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/drafts"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/saving_order_draft/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/drafts/aggregate"
+
+	"emperror.dev/errors"
+)
+
+type SaveOrderDraftHandler struct {
+	log            logger.Logger
+	aggregateStore store.AggregateStore[*aggregate.OrderDraft]
+	draftOptions   *drafts.OrderDraftOptions
+	tracer         tracing.AppTracer
+}
+
+func NewSaveOrderDraftHandler(
+	log logger.Logger,
+	aggregateStore store.AggregateStore[*aggregate.OrderDraft],
+	draftOptions *drafts.OrderDraftOptions,
+	tracer tracing.AppTracer,
+) *SaveOrderDraftHandler {
+	return &SaveOrderDraftHandler{
+		log:            log,
+		aggregateStore: aggregateStore,
+		draftOptions:   draftOptions,
+		tracer:         tracer,
+	}
+}
+
+func (c *SaveOrderDraftHandler) Handle(
+	ctx context.Context,
+	command *SaveOrderDraft,
+) (*dtos.SaveOrderDraftResponseDto, error) {
+	draft, err := c.loadOrCreate(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
+	// the stored version of a loaded draft is expected, so the concurrent saves of the same draft are rejected
+	_, err = c.aggregateStore.Store(draft, nil, ctx)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[SaveOrderDraftHandler_Handle.Store] error in storing order draft aggregate",
+		)
+	}
+
+	c.log.Infow(
+		fmt.Sprintf("[SaveOrderDraftHandler.Handle] order draft with id: {%s} saved", command.DraftId),
+		logger.Fields{"Id": command.DraftId, "ExpiresAt": draft.ExpiresAt()},
+	)
+
+	return &dtos.SaveOrderDraftResponseDto{DraftId: draft.Id(), ExpiresAt: draft.ExpiresAt()}, nil
+}
+
+func (c *SaveOrderDraftHandler) loadOrCreate(ctx context.Context, command *SaveOrderDraft) (*aggregate.OrderDraft, error) {
+	expiresAt := c.draftOptions.ExpiresAt(command.SavedAt)
+
+	if command.IsNew {
+		draft, err := aggregate.NewOrderDraft(
+			command.DraftId,
+			command.ShopItems,
+			command.AccountEmail,
+			command.DeliveryAddress,
+			command.DeliveryTime,
+			command.CustomerGroup,
+			command.SavedAt,
+			expiresAt,
+		)
+		if err != nil {
+			return nil, customErrors.NewApplicationErrorWrap(
+				err,
+				"[SaveOrderDraftHandler_loadOrCreate.NewOrderDraft] error in creating new order draft",
+			)
+		}
+
+		return draft, nil
+	}
+
+	exists, err := c.aggregateStore.Exists(ctx, command.DraftId)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[SaveOrderDraftHandler_loadOrCreate.Exists] error in checking order draft existence",
+		)
+	}
+	if !exists {
+		return nil, customErrors.NewNotFoundError(
+			fmt.Sprintf("[SaveOrderDraftHandler_loadOrCreate.Exists] order draft with id %s not found", command.DraftId),
+		)
+	}
+
+	draft, err := c.aggregateStore.Load(ctx, command.DraftId)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[SaveOrderDraftHandler_loadOrCreate.Load] error in loading order draft aggregate",
+		)
+	}
+
+	// the domain errors are kept as is, so an expired or converted draft results in a conflict response
+	err = draft.Save(
+		command.ShopItems,
+		command.AccountEmail,
+		command.DeliveryAddress,
+		command.DeliveryTime,
+		command.CustomerGroup,
+		command.SavedAt,
+		expiresAt,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "[SaveOrderDraftHandler_loadOrCreate.Save] error in saving the order draft")
+	}
+
+	return draft, nil
+}
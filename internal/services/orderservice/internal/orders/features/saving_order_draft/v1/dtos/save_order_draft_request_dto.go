@@ -0,0 +1,17 @@
+package dtos
+
+import (
+	customTypes "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/customtypes"
+	dtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/dtos/v1"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// SaveOrderDraftRequestDto is an incomplete order, all of its fields are optional until the draft is converted
+type SaveOrderDraftRequestDto struct {
+	DraftId         uuid.UUID              `json:"-"               param:"id"`
+	ShopItems       []*dtosV1.ShopItemDto  `json:"shopItems"`
+	AccountEmail    string                 `json:"accountEmail"`
+	DeliveryAddress string                 `json:"deliveryAddress"`
+	DeliveryTime    customTypes.CustomTime `json:"deliveryTime"`
+}
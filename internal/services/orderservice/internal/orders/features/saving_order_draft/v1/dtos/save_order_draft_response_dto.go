@@ -0,0 +1,12 @@
+package dtos
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type SaveOrderDraftResponseDto struct {
+	DraftId   uuid.UUID `json:"draftId"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
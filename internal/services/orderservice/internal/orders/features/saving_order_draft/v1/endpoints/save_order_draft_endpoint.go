@@ -0,0 +1,111 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/authentication"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	saveOrderDraftCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/saving_order_draft/v1/commands"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/saving_order_draft/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	uuid "github.com/satori/go.uuid"
+)
+
+type saveOrderDraftEndpoint struct {
+	params.OrderDraftRouteParams
+}
+
+func NewSaveOrderDraftEndpoint(params params.OrderDraftRouteParams) route.Endpoint {
+	return &saveOrderDraftEndpoint{OrderDraftRouteParams: params}
+}
+
+func (ep *saveOrderDraftEndpoint) MapEndpoint() {
+	ep.OrderDraftsGroup.POST("", ep.handler(http.StatusCreated))
+	ep.OrderDraftsGroup.PUT("/:id", ep.handler(http.StatusOK))
+}
+
+// SaveOrderDraft
+// @Tags OrderDrafts
+// @Summary Save order draft
+// @Description Create a draft of an incomplete order or resume an existing draft with its id, every save extends the expiration of the draft
+// @Accept json
+// @Produce json
+// @Param id path string false "Order draft ID"
+// @Param SaveOrderDraftRequestDto body dtos.SaveOrderDraftRequestDto true "Incomplete order data"
+// @Success 201 {object} dtos.SaveOrderDraftResponseDto
+// @Success 200 {object} dtos.SaveOrderDraftResponseDto
+// @Router /api/v1/order-drafts [post]
+// @Router /api/v1/order-drafts/{id} [put]
+func (ep *saveOrderDraftEndpoint) handler(successStatus int) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.SaveOrderDraftRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"[saveOrderDraftEndpoint_handler.Bind] error in the binding request",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[saveOrderDraftEndpoint_handler.Bind] err: %v", badRequestErr),
+			)
+			return badRequestErr
+		}
+
+		if successStatus == http.StatusOK && request.DraftId == uuid.Nil {
+			return customErrors.NewBadRequestError("[saveOrderDraftEndpoint_handler] id of the order draft is required")
+		}
+
+		// the customer group comes from the credentials, so it is resolved again when the draft is converted
+		customerGroup, _ := authentication.CustomerGroup(ctx)
+
+		command, err := saveOrderDraftCommandsV1.NewSaveOrderDraft(
+			request.DraftId,
+			request.ShopItems,
+			request.AccountEmail,
+			request.DeliveryAddress,
+			time.Time(request.DeliveryTime),
+			customerGroup,
+		)
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"[saveOrderDraftEndpoint_handler.StructCtx] command validation failed",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[saveOrderDraftEndpoint_handler.StructCtx] err: %v", validationErr),
+			)
+			return validationErr
+		}
+
+		result, err := cqrs.Send[*saveOrderDraftCommandsV1.SaveOrderDraft, *dtos.SaveOrderDraftResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[saveOrderDraftEndpoint_handler.Send] error in sending SaveOrderDraft",
+			)
+			ep.Logger.Errorw(
+				fmt.Sprintf(
+					"[saveOrderDraftEndpoint_handler.Send] id: {%s}, err: %v",
+					command.DraftId,
+					err,
+				),
+				logger.Fields{"Id": command.DraftId},
+			)
+			return err
+		}
+
+		return c.JSON(successStatus, result)
+	}
+}
@@ -0,0 +1,53 @@
+package domainEvents
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/domain"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+	dtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/dtos/v1"
+)
+
+// OrderDraftSavedV1 is applied on every save of a draft with the whole incomplete order, so the last save is the
+// state of the draft and its expiration is extended with each save
+type OrderDraftSavedV1 struct {
+	*domain.DomainEvent
+	ShopItems       []*dtosV1.ShopItemDto `json:"shopItems"`
+	AccountEmail    string                `json:"accountEmail"`
+	DeliveryAddress string                `json:"deliveryAddress"`
+	DeliveryTime    time.Time             `json:"deliveryTime"`
+	CustomerGroup   string                `json:"customerGroup,omitempty"`
+	SavedAt         time.Time             `json:"savedAt"`
+	ExpiresAt       time.Time             `json:"expiresAt"`
+}
+
+func NewOrderDraftSavedV1(
+	shopItems []*dtosV1.ShopItemDto,
+	accountEmail, deliveryAddress string,
+	deliveryTime time.Time,
+	customerGroup string,
+	savedAt time.Time,
+	expiresAt time.Time,
+) (*OrderDraftSavedV1, error) {
+	if savedAt.IsZero() {
+		return nil, customErrors.NewDomainError("savedAt can't be zero")
+	}
+
+	if !expiresAt.After(savedAt) {
+		return nil, customErrors.NewDomainError("expiresAt should be after savedAt")
+	}
+
+	eventData := &OrderDraftSavedV1{
+		ShopItems:       shopItems,
+		AccountEmail:    accountEmail,
+		DeliveryAddress: deliveryAddress,
+		DeliveryTime:    deliveryTime,
+		CustomerGroup:   customerGroup,
+		SavedAt:         savedAt,
+		ExpiresAt:       expiresAt,
+	}
+	eventData.DomainEvent = domain.NewDomainEvent(typeMapper.GetTypeName(eventData))
+
+	return eventData, nil
+}
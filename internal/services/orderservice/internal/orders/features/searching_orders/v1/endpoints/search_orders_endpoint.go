@@ -0,0 +1,85 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/searching_orders/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/searching_orders/v1/queries"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type searchOrdersEndpoint struct {
+	params.BackOfficeRouteParams
+}
+
+func NewSearchOrdersEndpoint(params params.BackOfficeRouteParams) route.Endpoint {
+	return &searchOrdersEndpoint{BackOfficeRouteParams: params}
+}
+
+func (ep *searchOrdersEndpoint) MapEndpoint() {
+	ep.BackOfficeGroup.GET("", ep.handler())
+}
+
+// SearchOrders
+// @Tags BackOffice
+// @Summary Search orders
+// @Description Search the orders of all the customers
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param searchOrdersRequestDto query dtos.SearchOrdersRequestDto false "SearchOrdersRequestDto"
+// @Success 200 {object} dtos.SearchOrdersResponseDto
+// @Router /api/v1/backoffice/orders [get]
+func (ep *searchOrdersEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		ep.OrdersMetrics.SearchOrderHttpRequests.Add(ctx, 1)
+
+		listQuery, err := utils.GetListQueryFromCtx(c)
+		if err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"[searchOrdersEndpoint_handler.GetListQueryFromCtx] error in getting data from query string",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf(
+					"[searchOrdersEndpoint_handler.GetListQueryFromCtx] err: %v",
+					badRequestErr,
+				),
+			)
+			return badRequestErr
+		}
+
+		request := &dtos.SearchOrdersRequestDto{ListQuery: listQuery}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"[searchOrdersEndpoint_handler.Bind] error in the binding request",
+			)
+			ep.Logger.Errorf(fmt.Sprintf("[searchOrdersEndpoint_handler.Bind] err: %v", badRequestErr))
+			return badRequestErr
+		}
+
+		query := queries.NewSearchOrders(request.SearchText, request.ListQuery)
+
+		queryResult, err := cqrs.Send[*queries.SearchOrders, *dtos.SearchOrdersResponseDto](ctx, query)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[searchOrdersEndpoint_handler.Send] error in sending SearchOrders",
+			)
+			ep.Logger.Error(fmt.Sprintf("[searchOrdersEndpoint_handler.Send] err: {%v}", err))
+			return err
+		}
+
+		return c.JSON(http.StatusOK, queryResult)
+	}
+}
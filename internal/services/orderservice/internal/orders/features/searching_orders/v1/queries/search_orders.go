@@ -0,0 +1,16 @@
+package queries
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils"
+)
+
+// SearchOrders searches the orders of all the customers by order id, account email, delivery address and item title,
+// an empty search text returns all the orders
+type SearchOrders struct {
+	SearchText string
+	*utils.ListQuery
+}
+
+func NewSearchOrders(searchText string, query *utils.ListQuery) *SearchOrders {
+	return &SearchOrders{SearchText: searchText, ListQuery: query}
+}
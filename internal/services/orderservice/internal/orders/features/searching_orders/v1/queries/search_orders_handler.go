@@ -0,0 +1,57 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/repositories"
+	dtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/dtos/v1"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/searching_orders/v1/dtos"
+)
+
+type SearchOrdersHandler struct {
+	log                      logger.Logger
+	mongoOrderReadRepository repositories.OrderMongoRepository
+	tracer                   tracing.AppTracer
+}
+
+func NewSearchOrdersHandler(
+	log logger.Logger,
+	mongoOrderReadRepository repositories.OrderMongoRepository,
+	tracer tracing.AppTracer,
+) *SearchOrdersHandler {
+	return &SearchOrdersHandler{
+		log:                      log,
+		mongoOrderReadRepository: mongoOrderReadRepository,
+		tracer:                   tracer,
+	}
+}
+
+func (c *SearchOrdersHandler) Handle(
+	ctx context.Context,
+	query *SearchOrders,
+) (*dtos.SearchOrdersResponseDto, error) {
+	orders, err := c.mongoOrderReadRepository.SearchOrders(ctx, query.SearchText, query.ListQuery)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[SearchOrdersHandler_Handle.SearchOrders] error in searching orders in the repository",
+		)
+	}
+
+	listResultDto, err := utils.ListResultToListResultDto[*dtosV1.BackOfficeOrderReadDto](orders)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[SearchOrdersHandler_Handle.ListResultToListResultDto] error in the mapping ListResultToListResultDto",
+		)
+	}
+
+	c.log.Info(fmt.Sprintf("[SearchOrdersHandler.Handle] orders fetched for search term '%s'", query.SearchText))
+
+	return &dtos.SearchOrdersResponseDto{Orders: listResultDto}, nil
+}
@@ -0,0 +1,38 @@
+package integrationEvents
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// CustomerSegmentsChangedV1 notifies the marketing consumers like the notification service about the segments of a
+// customer, it is published only when a segment is added or removed
+type CustomerSegmentsChangedV1 struct {
+	*types.Message
+	AccountEmail    string   `json:"accountEmail"`
+	Segments        []string `json:"segments"`
+	AddedSegments   []string `json:"addedSegments"`
+	RemovedSegments []string `json:"removedSegments"`
+	OrdersCount     int      `json:"ordersCount"`
+	LifetimeValue   float64  `json:"lifetimeValue"`
+}
+
+func NewCustomerSegmentsChangedV1(
+	accountEmail string,
+	segments []string,
+	addedSegments []string,
+	removedSegments []string,
+	ordersCount int,
+	lifetimeValue float64,
+) *CustomerSegmentsChangedV1 {
+	return &CustomerSegmentsChangedV1{
+		Message:         types.NewMessage(uuid.NewV4().String()),
+		AccountEmail:    accountEmail,
+		Segments:        segments,
+		AddedSegments:   addedSegments,
+		RemovedSegments: removedSegments,
+		OrdersCount:     ordersCount,
+		LifetimeValue:   lifetimeValue,
+	}
+}
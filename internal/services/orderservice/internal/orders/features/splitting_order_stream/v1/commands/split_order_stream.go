@@ -0,0 +1,37 @@
+package splitOrderStreamCommandsV1
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+// SplitOrderStream moves the events of an oversized order stream to an archived segment and continues the stream from
+// a snapshot of the order, the stream is split only when it has at least MinEvents events, zero always splits it
+type SplitOrderStream struct {
+	OrderId   uuid.UUID
+	MinEvents int64
+	SplitBy   string
+}
+
+func NewSplitOrderStream(orderId uuid.UUID, minEvents int64, splitBy string) (*SplitOrderStream, error) {
+	command := &SplitOrderStream{
+		OrderId:   orderId,
+		MinEvents: minEvents,
+		SplitBy:   splitBy,
+	}
+
+	err := command.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+func (c SplitOrderStream) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.OrderId, validation.Required),
+		validation.Field(&c.MinEvents, validation.Min(int64(0))),
+		validation.Field(&c.SplitBy, validation.Required),
+	)
+}
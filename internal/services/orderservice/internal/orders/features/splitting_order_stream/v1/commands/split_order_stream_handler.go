@@ -0,0 +1,67 @@
+package splitOrderStreamCommandsV1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/store"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/splitting_order_stream/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/aggregate"
+
+	"emperror.dev/errors"
+)
+
+type SplitOrderStreamHandler struct {
+	log            logger.Logger
+	streamSplitter store.AggregateStreamSplitter[*aggregate.Order]
+	tracer         tracing.AppTracer
+}
+
+func NewSplitOrderStreamHandler(
+	log logger.Logger,
+	streamSplitter store.AggregateStreamSplitter[*aggregate.Order],
+	tracer tracing.AppTracer,
+) *SplitOrderStreamHandler {
+	return &SplitOrderStreamHandler{
+		log:            log,
+		streamSplitter: streamSplitter,
+		tracer:         tracer,
+	}
+}
+
+func (c *SplitOrderStreamHandler) Handle(
+	ctx context.Context,
+	command *SplitOrderStream,
+) (*dtos.SplitOrderStreamResponseDto, error) {
+	result, err := c.streamSplitter.Split(ctx, command.OrderId, command.MinEvents, nil)
+	if customErrors.IsNotFoundError(err) {
+		return nil, errors.WithMessage(err, "[SplitOrderStreamHandler_Handle.Split] order stream not found")
+	}
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[SplitOrderStreamHandler_Handle.Split] error in splitting order stream",
+		)
+	}
+
+	response := &dtos.SplitOrderStreamResponseDto{
+		Split:           result.Split,
+		EventsCount:     result.EventsCount,
+		Segment:         dtos.NewOrderStreamSegmentDto(result.Segment),
+		SnapshotVersion: result.SnapshotVersion,
+	}
+
+	c.log.Infow(
+		fmt.Sprintf(
+			"[SplitOrderStreamHandler.Handle] stream of order with id: {%s} split: %v",
+			command.OrderId,
+			result.Split,
+		),
+		logger.Fields{"Id": command.OrderId, "SplitBy": command.SplitBy, "EventsCount": result.EventsCount},
+	)
+
+	return response, nil
+}
@@ -0,0 +1,8 @@
+package dtos
+
+import uuid "github.com/satori/go.uuid"
+
+type GetOrderStreamSegmentsResponseDto struct {
+	OrderId  uuid.UUID                `json:"orderId"`
+	Segments []*OrderStreamSegmentDto `json:"segments"`
+}
@@ -0,0 +1,8 @@
+package dtos
+
+import uuid "github.com/satori/go.uuid"
+
+type SplitOrderStreamRequestDto struct {
+	OrderId   uuid.UUID `json:"-"         param:"id"`
+	MinEvents int64     `json:"minEvents"`
+}
@@ -0,0 +1,33 @@
+package dtos
+
+import streamSegment "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models/stream_segment"
+
+type SplitOrderStreamResponseDto struct {
+	Split           bool                   `json:"split"`
+	EventsCount     int64                  `json:"eventsCount"`
+	Segment         *OrderStreamSegmentDto `json:"segment,omitempty"`
+	SnapshotVersion int64                  `json:"snapshotVersion,omitempty"`
+}
+
+// OrderStreamSegmentDto is an archived segment of the order stream with the versions of its events in the order stream
+type OrderStreamSegmentDto struct {
+	Number       int    `json:"number"`
+	StreamName   string `json:"streamName"`
+	FirstVersion int64  `json:"firstVersion"`
+	LastVersion  int64  `json:"lastVersion"`
+	EventsCount  int64  `json:"eventsCount"`
+}
+
+func NewOrderStreamSegmentDto(segment *streamSegment.StreamSegment) *OrderStreamSegmentDto {
+	if segment == nil {
+		return nil
+	}
+
+	return &OrderStreamSegmentDto{
+		Number:       segment.Number,
+		StreamName:   segment.StreamName,
+		FirstVersion: segment.FirstVersion,
+		LastVersion:  segment.LastVersion,
+		EventsCount:  segment.EventsCount,
+	}
+}
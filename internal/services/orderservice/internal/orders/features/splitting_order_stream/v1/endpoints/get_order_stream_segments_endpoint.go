@@ -0,0 +1,89 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/splitting_order_stream/v1/dtos"
+	splitOrderStreamQueriesV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/splitting_order_stream/v1/queries"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type getOrderStreamSegmentsEndpoint struct {
+	params.BackOfficeRouteParams
+}
+
+func NewGetOrderStreamSegmentsEndpoint(params params.BackOfficeRouteParams) route.Endpoint {
+	return &getOrderStreamSegmentsEndpoint{BackOfficeRouteParams: params}
+}
+
+func (ep *getOrderStreamSegmentsEndpoint) MapEndpoint() {
+	ep.BackOfficeGroup.GET("/:id/stream/segments", ep.handler())
+}
+
+// GetOrderStreamSegments
+// @Tags BackOffice
+// @Summary Get order stream segments
+// @Description Get the archived segments of a split order stream, the oldest first
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Order ID"
+// @Success 200 {object} dtos.GetOrderStreamSegmentsResponseDto
+// @Router /api/v1/backoffice/orders/{id}/stream/segments [get]
+func (ep *getOrderStreamSegmentsEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.GetOrderStreamSegmentsRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"[getOrderStreamSegmentsEndpoint_handler.Bind] error in the binding request",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[getOrderStreamSegmentsEndpoint_handler.Bind] err: %v", badRequestErr),
+			)
+			return badRequestErr
+		}
+
+		query, err := splitOrderStreamQueriesV1.NewGetOrderStreamSegments(request.OrderId)
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"[getOrderStreamSegmentsEndpoint_handler.StructCtx] query validation failed",
+			)
+			ep.Logger.Errorf("[getOrderStreamSegmentsEndpoint_handler.StructCtx] err: %v", validationErr)
+			return validationErr
+		}
+
+		queryResult, err := cqrs.Send[*splitOrderStreamQueriesV1.GetOrderStreamSegments, *dtos.GetOrderStreamSegmentsResponseDto](
+			ctx,
+			query,
+		)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[getOrderStreamSegmentsEndpoint_handler.Send] error in sending GetOrderStreamSegments",
+			)
+			ep.Logger.Errorw(
+				fmt.Sprintf(
+					"[getOrderStreamSegmentsEndpoint_handler.Send] id: {%s}, err: %v",
+					query.OrderId,
+					err,
+				),
+				logger.Fields{"Id": query.OrderId},
+			)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, queryResult)
+	}
+}
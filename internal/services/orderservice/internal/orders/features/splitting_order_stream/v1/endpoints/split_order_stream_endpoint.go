@@ -0,0 +1,95 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	apikey "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/api_key"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	splitOrderStreamCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/splitting_order_stream/v1/commands"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/splitting_order_stream/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type splitOrderStreamEndpoint struct {
+	params.BackOfficeRouteParams
+}
+
+func NewSplitOrderStreamEndpoint(params params.BackOfficeRouteParams) route.Endpoint {
+	return &splitOrderStreamEndpoint{BackOfficeRouteParams: params}
+}
+
+func (ep *splitOrderStreamEndpoint) MapEndpoint() {
+	ep.BackOfficeGroup.POST("/:id/stream/split", ep.handler())
+}
+
+// SplitOrderStream
+// @Tags BackOffice
+// @Summary Split order stream
+// @Description Move the events of an oversized order stream to an archived segment and continue the stream from a snapshot of the order, the stream is split only when it has at least minEvents events
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param SplitOrderStreamRequestDto body dtos.SplitOrderStreamRequestDto true "Split data"
+// @Param id path string true "Order ID"
+// @Success 200 {object} dtos.SplitOrderStreamResponseDto
+// @Router /api/v1/backoffice/orders/{id}/stream/split [post]
+func (ep *splitOrderStreamEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.SplitOrderStreamRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"[splitOrderStreamEndpoint_handler.Bind] error in the binding request",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[splitOrderStreamEndpoint_handler.Bind] err: %v", badRequestErr),
+			)
+			return badRequestErr
+		}
+
+		userId, _ := apikey.UserId(ctx)
+
+		command, err := splitOrderStreamCommandsV1.NewSplitOrderStream(request.OrderId, request.MinEvents, userId)
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"[splitOrderStreamEndpoint_handler.StructCtx] command validation failed",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[splitOrderStreamEndpoint_handler.StructCtx] err: %v", validationErr),
+			)
+			return validationErr
+		}
+
+		result, err := cqrs.Send[*splitOrderStreamCommandsV1.SplitOrderStream, *dtos.SplitOrderStreamResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[splitOrderStreamEndpoint_handler.Send] error in sending SplitOrderStream",
+			)
+			ep.Logger.Errorw(
+				fmt.Sprintf(
+					"[splitOrderStreamEndpoint_handler.Send] id: {%s}, err: %v",
+					command.OrderId,
+					err,
+				),
+				logger.Fields{"Id": command.OrderId},
+			)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, result)
+	}
+}
@@ -0,0 +1,62 @@
+package domainEvents
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/domain"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+	dtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/dtos/v1"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// OrderStreamContinuedV1 is the continuation snapshot of the order, it is appended when the order stream is split and
+// the earlier events are truncated, so it keeps the whole state of the order
+type OrderStreamContinuedV1 struct {
+	*domain.DomainEvent
+	OrderId         uuid.UUID             `json:"orderId"`
+	OrderNumber     string                `json:"orderNumber"`
+	ShopItems       []*dtosV1.ShopItemDto `json:"shopItems"`
+	AccountEmail    string                `json:"accountEmail"`
+	DeliveryAddress string                `json:"deliveryAddress"`
+	CancelReason    string                `json:"cancelReason"`
+	DeliveredTime   time.Time             `json:"deliveredTime"`
+	Paid            bool                  `json:"paid"`
+	Submitted       bool                  `json:"submitted"`
+	Completed       bool                  `json:"completed"`
+	Canceled        bool                  `json:"canceled"`
+	HeldForReview   bool                  `json:"heldForReview"`
+	HoldReasons     []string              `json:"holdReasons"`
+	ReviewNote      string                `json:"reviewNote"`
+	GiftCardId      uuid.UUID             `json:"giftCardId"`
+	GiftCardAmount  float64               `json:"giftCardAmount"`
+	LegalHold       bool                  `json:"legalHold"`
+	Archived        bool                  `json:"archived"`
+	DataPurged      bool                  `json:"dataPurged"`
+	PaymentId       uuid.UUID             `json:"paymentId"`
+	Currency        string                `json:"currency,omitempty"`
+	ExchangeRate    float64               `json:"exchangeRate,omitempty"`
+	CreatedAt       time.Time             `json:"createdAt"`
+	UpdatedAt       time.Time             `json:"updatedAt"`
+	ContinuedAt     time.Time             `json:"continuedAt"`
+}
+
+// NewOrderStreamContinuedV1 creates the continuation snapshot, the state of the order is set by the order aggregate
+func NewOrderStreamContinuedV1(orderId uuid.UUID, continuedAt time.Time) (*OrderStreamContinuedV1, error) {
+	if orderId == uuid.Nil {
+		return nil, customErrors.NewDomainError("orderId of the continued order stream is required")
+	}
+
+	if continuedAt.IsZero() {
+		return nil, customErrors.NewDomainError("continuedAt can't be zero")
+	}
+
+	eventData := &OrderStreamContinuedV1{
+		OrderId:     orderId,
+		ContinuedAt: continuedAt,
+	}
+	eventData.DomainEvent = domain.NewDomainEvent(typeMapper.GetTypeName(eventData))
+
+	return eventData, nil
+}
@@ -0,0 +1,26 @@
+package splitOrderStreamQueriesV1
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+// GetOrderStreamSegments returns the archived segments of a split order stream, the oldest first
+type GetOrderStreamSegments struct {
+	OrderId uuid.UUID
+}
+
+func NewGetOrderStreamSegments(orderId uuid.UUID) (*GetOrderStreamSegments, error) {
+	query := &GetOrderStreamSegments{OrderId: orderId}
+
+	err := query.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return query, nil
+}
+
+func (q GetOrderStreamSegments) Validate() error {
+	return validation.ValidateStruct(&q, validation.Field(&q.OrderId, validation.Required))
+}
@@ -0,0 +1,65 @@
+package splitOrderStreamQueriesV1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/store"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/splitting_order_stream/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/aggregate"
+
+	"emperror.dev/errors"
+)
+
+type GetOrderStreamSegmentsHandler struct {
+	log            logger.Logger
+	streamSplitter store.AggregateStreamSplitter[*aggregate.Order]
+	tracer         tracing.AppTracer
+}
+
+func NewGetOrderStreamSegmentsHandler(
+	log logger.Logger,
+	streamSplitter store.AggregateStreamSplitter[*aggregate.Order],
+	tracer tracing.AppTracer,
+) *GetOrderStreamSegmentsHandler {
+	return &GetOrderStreamSegmentsHandler{
+		log:            log,
+		streamSplitter: streamSplitter,
+		tracer:         tracer,
+	}
+}
+
+func (c *GetOrderStreamSegmentsHandler) Handle(
+	ctx context.Context,
+	query *GetOrderStreamSegments,
+) (*dtos.GetOrderStreamSegmentsResponseDto, error) {
+	segments, err := c.streamSplitter.Segments(ctx, query.OrderId)
+	if customErrors.IsNotFoundError(err) {
+		return nil, errors.WithMessage(err, "[GetOrderStreamSegmentsHandler_Handle.Segments] order stream not found")
+	}
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[GetOrderStreamSegmentsHandler_Handle.Segments] error in reading order stream segments",
+		)
+	}
+
+	segmentsDto := make([]*dtos.OrderStreamSegmentDto, 0, len(segments))
+	for _, segment := range segments {
+		segmentsDto = append(segmentsDto, dtos.NewOrderStreamSegmentDto(segment))
+	}
+
+	c.log.Infow(
+		fmt.Sprintf(
+			"[GetOrderStreamSegmentsHandler.Handle] %d segments of order with id: {%s} fetched",
+			len(segmentsDto),
+			query.OrderId,
+		),
+		logger.Fields{"Id": query.OrderId},
+	)
+
+	return &dtos.GetOrderStreamSegmentsResponseDto{OrderId: query.OrderId, Segments: segmentsDto}, nil
+}
@@ -0,0 +1,71 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/dashboard"
+)
+
+// OperationsDashboardDto is the data of a `dashboard` event of the operations dashboard stream
+type OperationsDashboardDto struct {
+	At              time.Time           `json:"at"`
+	WindowMinutes   int                 `json:"windowMinutes"`
+	OrdersCreated   int64               `json:"ordersCreated"`
+	OrdersPaid      int64               `json:"ordersPaid"`
+	OrdersCanceled  int64               `json:"ordersCanceled"`
+	OrdersPerMinute float64             `json:"ordersPerMinute"`
+	Minutes         []*OrderRateDto     `json:"minutes"`
+	TopProducts     []*ProductDemandDto `json:"topProducts"`
+	LastEventAt     *time.Time          `json:"lastEventAt,omitempty"`
+}
+
+type OrderRateDto struct {
+	Minute   time.Time `json:"minute"`
+	Created  int64     `json:"created"`
+	Paid     int64     `json:"paid"`
+	Canceled int64     `json:"canceled"`
+}
+
+type ProductDemandDto struct {
+	ProductId string `json:"productId,omitempty"`
+	Title     string `json:"title"`
+	Quantity  uint64 `json:"quantity"`
+	Orders    int64  `json:"orders"`
+}
+
+func NewOperationsDashboardDto(snapshot *dashboard.Snapshot) *OperationsDashboardDto {
+	dto := &OperationsDashboardDto{
+		At:              snapshot.At,
+		WindowMinutes:   snapshot.WindowMinutes,
+		OrdersCreated:   snapshot.OrdersCreated,
+		OrdersPaid:      snapshot.OrdersPaid,
+		OrdersCanceled:  snapshot.OrdersCanceled,
+		OrdersPerMinute: snapshot.OrdersPerMinute,
+		Minutes:         make([]*OrderRateDto, 0, len(snapshot.Minutes)),
+		TopProducts:     make([]*ProductDemandDto, 0, len(snapshot.TopProducts)),
+	}
+
+	if !snapshot.LastEventAt.IsZero() {
+		dto.LastEventAt = &snapshot.LastEventAt
+	}
+
+	for _, minute := range snapshot.Minutes {
+		dto.Minutes = append(dto.Minutes, &OrderRateDto{
+			Minute:   minute.Minute,
+			Created:  minute.Created,
+			Paid:     minute.Paid,
+			Canceled: minute.Canceled,
+		})
+	}
+
+	for _, product := range snapshot.TopProducts {
+		dto.TopProducts = append(dto.TopProducts, &ProductDemandDto{
+			ProductId: product.ProductId,
+			Title:     product.Title,
+			Quantity:  product.Quantity,
+			Orders:    product.Orders,
+		})
+	}
+
+	return dto
+}
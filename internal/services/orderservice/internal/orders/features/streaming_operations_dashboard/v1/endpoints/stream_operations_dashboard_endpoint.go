@@ -0,0 +1,118 @@
+package endpoints
+
+import (
+	"context"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	echocontracts "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/contracts"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/eventstream"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/dashboard"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/streaming_operations_dashboard/v1/dtos"
+
+	"github.com/labstack/echo/v4"
+)
+
+const dashboardEvent = "dashboard"
+
+type streamOperationsDashboardEndpoint struct {
+	params.BackOfficeRouteParams
+	dashboard *dashboard.OperationsDashboard
+	options   *dashboard.DashboardOptions
+	// shutdown is closed on the shutdown of the server, so the open streams don't hold the graceful shutdown
+	shutdown chan struct{}
+}
+
+func NewStreamOperationsDashboardEndpoint(
+	params params.BackOfficeRouteParams,
+	operationsDashboard *dashboard.OperationsDashboard,
+	options *dashboard.DashboardOptions,
+	server echocontracts.EchoHttpServer,
+) route.Endpoint {
+	shutdown := make(chan struct{})
+	server.GetEchoInstance().Server.RegisterOnShutdown(func() {
+		close(shutdown)
+	})
+
+	return &streamOperationsDashboardEndpoint{
+		BackOfficeRouteParams: params,
+		dashboard:             operationsDashboard,
+		options:               options,
+		shutdown:              shutdown,
+	}
+}
+
+func (ep *streamOperationsDashboardEndpoint) MapEndpoint() {
+	ep.BackOfficeGroup.GET("/dashboard/stream", ep.handler())
+}
+
+// StreamOperationsDashboard
+// @Tags BackOffice
+// @Summary Stream operations dashboard
+// @Description Stream the order rates and the most ordered products of the sliding window of the responding instance as the server sent events, a `dashboard` event is sent on connect, on the changes and periodically
+// @Produce text/event-stream
+// @Security ApiKeyAuth
+// @Success 200 {object} dtos.OperationsDashboardDto
+// @Router /api/v1/backoffice/orders/dashboard/stream [get]
+func (ep *streamOperationsDashboardEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		stream, err := eventstream.NewStream(c, ep.options.RefreshInterval())
+		if err != nil {
+			return err
+		}
+
+		refresh := time.NewTicker(ep.options.RefreshInterval())
+		defer refresh.Stop()
+
+		for {
+			// the channel is taken before the snapshot, so a change after the snapshot is pushed on the next loop
+			changed := ep.dashboard.Changed()
+			pushedAt := time.Now()
+
+			err := stream.Send(dashboardEvent, "", dtos.NewOperationsDashboardDto(ep.dashboard.Snapshot()))
+			if err != nil {
+				ep.Logger.Debugw(
+					"[streamOperationsDashboardEndpoint_handler.Send] the dashboard stream is closed",
+					map[string]interface{}{"error": err},
+				)
+
+				return nil
+			}
+
+			select {
+			case <-changed:
+				// the changes are pushed at most once in the push interval, a busy shop doesn't flood the clients
+				if !ep.wait(ctx, time.Until(pushedAt.Add(ep.options.PushInterval()))) {
+					return nil
+				}
+			case <-refresh.C:
+			case <-ep.shutdown:
+				return nil
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+}
+
+// wait returns false when the stream is closed in the wait
+func (ep *streamOperationsDashboardEndpoint) wait(ctx context.Context, duration time.Duration) bool {
+	if duration <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ep.shutdown:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
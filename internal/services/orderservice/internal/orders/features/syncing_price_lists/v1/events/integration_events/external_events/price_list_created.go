@@ -0,0 +1,25 @@
+package externalEvents
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+)
+
+type PriceListProductPrice struct {
+	ProductId string  `json:"productId"`
+	Price     float64 `json:"price"`
+}
+
+// PriceListCreatedV1 is published by catalogs service, we only keep the fields which are needed for resolving the
+// prices of the orders
+type PriceListCreatedV1 struct {
+	*types.Message
+	Id            string                   `json:"id"`
+	Name          string                   `json:"name"`
+	CustomerGroup string                   `json:"customerGroup"`
+	EffectiveFrom time.Time                `json:"effectiveFrom"`
+	EffectiveTo   *time.Time               `json:"effectiveTo,omitempty"`
+	Prices        []*PriceListProductPrice `json:"prices"`
+	UpdatedAt     time.Time                `json:"updatedAt"`
+}
@@ -0,0 +1,80 @@
+package externalEvents
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/consumer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/attribute"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/repositories"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/pricelists/read_models"
+
+	"emperror.dev/errors"
+)
+
+type priceListCreatedConsumer struct {
+	logger              logger.Logger
+	priceListRepository repositories.PriceListRepository
+	tracer              tracing.AppTracer
+}
+
+// NewPriceListCreatedConsumer keeps a copy of the price lists of the catalogs service for resolving the prices of the
+// orders, saving a price list is an upsert, so a redelivered message doesn't duplicate it
+func NewPriceListCreatedConsumer(
+	logger logger.Logger,
+	priceListRepository repositories.PriceListRepository,
+	tracer tracing.AppTracer,
+) consumer.ConsumerHandler {
+	return &priceListCreatedConsumer{
+		logger:              logger,
+		priceListRepository: priceListRepository,
+		tracer:              tracer,
+	}
+}
+
+func (c *priceListCreatedConsumer) Handle(
+	ctx context.Context,
+	consumeContext types.MessageConsumeContext,
+) error {
+	message, ok := consumeContext.Message().(*PriceListCreatedV1)
+	if !ok {
+		return errors.New("error in casting message to PriceListCreatedV1")
+	}
+
+	ctx, span := c.tracer.Start(ctx, "priceListCreatedConsumer.Handle")
+	span.SetAttributes(attribute.Object("Message", consumeContext.Message()))
+	defer span.End()
+
+	prices := make([]*read_models.ProductPriceReadModel, 0, len(message.Prices))
+	for _, price := range message.Prices {
+		if price != nil {
+			prices = append(prices, &read_models.ProductPriceReadModel{ProductId: price.ProductId, Price: price.Price})
+		}
+	}
+
+	err := c.priceListRepository.SavePriceList(ctx, &read_models.PriceListReadModel{
+		Id:            message.Id,
+		Name:          message.Name,
+		CustomerGroup: message.CustomerGroup,
+		EffectiveFrom: message.EffectiveFrom,
+		EffectiveTo:   message.EffectiveTo,
+		Prices:        prices,
+		UpdatedAt:     message.UpdatedAt,
+	})
+	if err != nil {
+		return errors.WithMessage(
+			err,
+			"[priceListCreatedConsumer_Handle.SavePriceList] error in saving the price list",
+		)
+	}
+
+	c.logger.Infow(
+		fmt.Sprintf("[priceListCreatedConsumer.Handle] price list with id '%s' saved", message.Id),
+		logger.Fields{"Id": message.Id, "CustomerGroup": message.CustomerGroup},
+	)
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package orderProjectionVersionsCommandsV1
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// AbortOrderProjectionVersion stops the candidate version of the orders projection and drops its collection
+type AbortOrderProjectionVersion struct {
+	AbortedBy string
+}
+
+func NewAbortOrderProjectionVersion(abortedBy string) (*AbortOrderProjectionVersion, error) {
+	command := &AbortOrderProjectionVersion{AbortedBy: abortedBy}
+
+	err := command.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+func (c AbortOrderProjectionVersion) Validate() error {
+	return validation.ValidateStruct(&c, validation.Field(&c.AbortedBy, validation.Required))
+}
@@ -0,0 +1,46 @@
+package orderProjectionVersionsCommandsV1
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/projections/versioning"
+
+	"emperror.dev/errors"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type AbortOrderProjectionVersionHandler struct {
+	log        logger.Logger
+	versioning *versioning.OrderProjectionVersioning
+}
+
+func NewAbortOrderProjectionVersionHandler(
+	log logger.Logger,
+	versioning *versioning.OrderProjectionVersioning,
+) *AbortOrderProjectionVersionHandler {
+	return &AbortOrderProjectionVersionHandler{
+		log:        log,
+		versioning: versioning,
+	}
+}
+
+func (c *AbortOrderProjectionVersionHandler) Handle(
+	ctx context.Context,
+	command *AbortOrderProjectionVersion,
+) (*mediatr.Unit, error) {
+	err := c.versioning.AbortVersion(ctx)
+	if err != nil {
+		return nil, errors.WithMessage(
+			err,
+			"[AbortOrderProjectionVersionHandler_Handle.AbortVersion] error in aborting the orders projection version",
+		)
+	}
+
+	c.log.Infow(
+		"[AbortOrderProjectionVersionHandler.Handle] candidate version of the orders projection aborted",
+		logger.Fields{"AbortedBy": command.AbortedBy},
+	)
+
+	return &mediatr.Unit{}, nil
+}
@@ -0,0 +1,26 @@
+package orderProjectionVersionsCommandsV1
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// RequestOrderProjectionCutover switches the reads to the candidate version of the orders projection when it catches
+// up with the active version
+type RequestOrderProjectionCutover struct {
+	RequestedBy string
+}
+
+func NewRequestOrderProjectionCutover(requestedBy string) (*RequestOrderProjectionCutover, error) {
+	command := &RequestOrderProjectionCutover{RequestedBy: requestedBy}
+
+	err := command.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+func (c RequestOrderProjectionCutover) Validate() error {
+	return validation.ValidateStruct(&c, validation.Field(&c.RequestedBy, validation.Required))
+}
@@ -0,0 +1,50 @@
+package orderProjectionVersionsCommandsV1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/versioning_order_projection/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/projections/versioning"
+
+	"emperror.dev/errors"
+)
+
+type RequestOrderProjectionCutoverHandler struct {
+	log        logger.Logger
+	versioning *versioning.OrderProjectionVersioning
+}
+
+func NewRequestOrderProjectionCutoverHandler(
+	log logger.Logger,
+	versioning *versioning.OrderProjectionVersioning,
+) *RequestOrderProjectionCutoverHandler {
+	return &RequestOrderProjectionCutoverHandler{
+		log:        log,
+		versioning: versioning,
+	}
+}
+
+func (c *RequestOrderProjectionCutoverHandler) Handle(
+	ctx context.Context,
+	command *RequestOrderProjectionCutover,
+) (*dtos.RequestOrderProjectionCutoverResponseDto, error) {
+	candidate, err := c.versioning.RequestCutover(ctx, command.RequestedBy)
+	if err != nil {
+		return nil, errors.WithMessage(
+			err,
+			"[RequestOrderProjectionCutoverHandler_Handle.RequestCutover] error in requesting the cutover of the orders projection",
+		)
+	}
+
+	c.log.Infow(
+		fmt.Sprintf(
+			"[RequestOrderProjectionCutoverHandler.Handle] cutover to version %d of the orders projection requested",
+			candidate.Version,
+		),
+		logger.Fields{"Version": candidate.Version, "RequestedBy": command.RequestedBy},
+	)
+
+	return &dtos.RequestOrderProjectionCutoverResponseDto{Candidate: dtos.NewOrderProjectionVersionDto(candidate)}, nil
+}
@@ -0,0 +1,26 @@
+package orderProjectionVersionsCommandsV1
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// StartOrderProjectionVersion starts building the next version of the orders projection in parallel to the active
+// version, it is sent by the back-office users
+type StartOrderProjectionVersion struct {
+	StartedBy string
+}
+
+func NewStartOrderProjectionVersion(startedBy string) (*StartOrderProjectionVersion, error) {
+	command := &StartOrderProjectionVersion{StartedBy: startedBy}
+
+	err := command.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+func (c StartOrderProjectionVersion) Validate() error {
+	return validation.ValidateStruct(&c, validation.Field(&c.StartedBy, validation.Required))
+}
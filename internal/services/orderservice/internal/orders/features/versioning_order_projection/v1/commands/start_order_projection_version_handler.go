@@ -0,0 +1,47 @@
+package orderProjectionVersionsCommandsV1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/versioning_order_projection/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/projections/versioning"
+
+	"emperror.dev/errors"
+)
+
+type StartOrderProjectionVersionHandler struct {
+	log        logger.Logger
+	versioning *versioning.OrderProjectionVersioning
+}
+
+func NewStartOrderProjectionVersionHandler(
+	log logger.Logger,
+	versioning *versioning.OrderProjectionVersioning,
+) *StartOrderProjectionVersionHandler {
+	return &StartOrderProjectionVersionHandler{
+		log:        log,
+		versioning: versioning,
+	}
+}
+
+func (c *StartOrderProjectionVersionHandler) Handle(
+	ctx context.Context,
+	command *StartOrderProjectionVersion,
+) (*dtos.StartOrderProjectionVersionResponseDto, error) {
+	candidate, err := c.versioning.StartVersion(ctx, command.StartedBy)
+	if err != nil {
+		return nil, errors.WithMessage(
+			err,
+			"[StartOrderProjectionVersionHandler_Handle.StartVersion] error in starting the orders projection version",
+		)
+	}
+
+	c.log.Infow(
+		fmt.Sprintf("[StartOrderProjectionVersionHandler.Handle] version %d of the orders projection started", candidate.Version),
+		logger.Fields{"Version": candidate.Version, "StartedBy": command.StartedBy},
+	)
+
+	return &dtos.StartOrderProjectionVersionResponseDto{Candidate: dtos.NewOrderProjectionVersionDto(candidate)}, nil
+}
@@ -0,0 +1,5 @@
+package dtos
+
+type GetOrderProjectionVersionsResponseDto struct {
+	Versions *OrderProjectionVersionsDto `json:"versions"`
+}
@@ -0,0 +1,51 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/projections/read_models"
+)
+
+type OrderProjectionVersionDto struct {
+	Version            int        `json:"version"`
+	Collection         string     `json:"collection"`
+	Status             string     `json:"status"`
+	HandoffPosition    uint64     `json:"handoffPosition,omitempty"`
+	StartedBy          string     `json:"startedBy,omitempty"`
+	CutoverRequestedBy string     `json:"cutoverRequestedBy,omitempty"`
+	CreatedAt          time.Time  `json:"createdAt"`
+	ActivatedAt        *time.Time `json:"activatedAt,omitempty"`
+	RetiredAt          *time.Time `json:"retiredAt,omitempty"`
+	CleanupAt          *time.Time `json:"cleanupAt,omitempty"`
+	CleanedUp          bool       `json:"cleanedUp,omitempty"`
+}
+
+type OrderProjectionVersionsDto struct {
+	Active    *OrderProjectionVersionDto   `json:"active"`
+	Candidate *OrderProjectionVersionDto   `json:"candidate,omitempty"`
+	Retired   []*OrderProjectionVersionDto `json:"retired"`
+	// CandidateRunning and CandidatePosition are the state of the candidate subscription in the responding instance
+	CandidateRunning  bool   `json:"candidateRunning"`
+	CandidatePosition uint64 `json:"candidatePosition,omitempty"`
+	CandidateError    string `json:"candidateError,omitempty"`
+}
+
+func NewOrderProjectionVersionDto(version *read_models.ProjectionVersionModel) *OrderProjectionVersionDto {
+	if version == nil {
+		return nil
+	}
+
+	return &OrderProjectionVersionDto{
+		Version:            version.Version,
+		Collection:         version.Collection,
+		Status:             string(version.Status),
+		HandoffPosition:    version.HandoffPosition,
+		StartedBy:          version.StartedBy,
+		CutoverRequestedBy: version.CutoverRequestedBy,
+		CreatedAt:          version.CreatedAt,
+		ActivatedAt:        version.ActivatedAt,
+		RetiredAt:          version.RetiredAt,
+		CleanupAt:          version.CleanupAt,
+		CleanedUp:          version.CleanedUp,
+	}
+}
@@ -0,0 +1,5 @@
+package dtos
+
+type RequestOrderProjectionCutoverResponseDto struct {
+	Candidate *OrderProjectionVersionDto `json:"candidate"`
+}
@@ -0,0 +1,5 @@
+package dtos
+
+type StartOrderProjectionVersionResponseDto struct {
+	Candidate *OrderProjectionVersionDto `json:"candidate"`
+}
@@ -0,0 +1,73 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	apikey "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/api_key"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	orderProjectionVersionsCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/versioning_order_projection/v1/commands"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type abortOrderProjectionVersionEndpoint struct {
+	params.BackOfficeRouteParams
+}
+
+func NewAbortOrderProjectionVersionEndpoint(params params.BackOfficeRouteParams) route.Endpoint {
+	return &abortOrderProjectionVersionEndpoint{BackOfficeRouteParams: params}
+}
+
+func (ep *abortOrderProjectionVersionEndpoint) MapEndpoint() {
+	ep.BackOfficeProjectionsGroup.DELETE("/orders/versions/candidate", ep.handler())
+}
+
+// AbortOrderProjectionVersion
+// @Tags BackOffice
+// @Summary Abort orders projection version
+// @Description Stop building the candidate version of the orders projection and drop its collection
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 204
+// @Router /api/v1/backoffice/projections/orders/versions/candidate [delete]
+func (ep *abortOrderProjectionVersionEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		userId, _ := apikey.UserId(ctx)
+
+		command, err := orderProjectionVersionsCommandsV1.NewAbortOrderProjectionVersion(userId)
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"[abortOrderProjectionVersionEndpoint_handler.StructCtx] command validation failed",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[abortOrderProjectionVersionEndpoint_handler.StructCtx] err: %v", validationErr),
+			)
+			return validationErr
+		}
+
+		_, err = cqrs.Send[*orderProjectionVersionsCommandsV1.AbortOrderProjectionVersion, *mediatr.Unit](
+			ctx,
+			command,
+		)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[abortOrderProjectionVersionEndpoint_handler.Send] error in sending AbortOrderProjectionVersion",
+			)
+			ep.Logger.Error(fmt.Sprintf("[abortOrderProjectionVersionEndpoint_handler.Send] err: {%v}", err))
+			return err
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
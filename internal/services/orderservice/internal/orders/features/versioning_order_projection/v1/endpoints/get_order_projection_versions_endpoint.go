@@ -0,0 +1,62 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/versioning_order_projection/v1/dtos"
+	orderProjectionVersionsQueryV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/versioning_order_projection/v1/queries"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type getOrderProjectionVersionsEndpoint struct {
+	params.BackOfficeRouteParams
+}
+
+func NewGetOrderProjectionVersionsEndpoint(params params.BackOfficeRouteParams) route.Endpoint {
+	return &getOrderProjectionVersionsEndpoint{BackOfficeRouteParams: params}
+}
+
+func (ep *getOrderProjectionVersionsEndpoint) MapEndpoint() {
+	ep.BackOfficeProjectionsGroup.GET("/orders/versions", ep.handler())
+}
+
+// GetOrderProjectionVersions
+// @Tags BackOffice
+// @Summary Get orders projection versions
+// @Description Get the active, candidate and retired versions of the orders projection
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} dtos.GetOrderProjectionVersionsResponseDto
+// @Router /api/v1/backoffice/projections/orders/versions [get]
+func (ep *getOrderProjectionVersionsEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		query, err := orderProjectionVersionsQueryV1.NewGetOrderProjectionVersions()
+		if err != nil {
+			return err
+		}
+
+		queryResult, err := cqrs.Send[*orderProjectionVersionsQueryV1.GetOrderProjectionVersions, *dtos.GetOrderProjectionVersionsResponseDto](
+			ctx,
+			query,
+		)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[getOrderProjectionVersionsEndpoint_handler.Send] error in sending GetOrderProjectionVersions",
+			)
+			ep.Logger.Error(fmt.Sprintf("[getOrderProjectionVersionsEndpoint_handler.Send] err: {%v}", err))
+			return err
+		}
+
+		return c.JSON(http.StatusOK, queryResult)
+	}
+}
@@ -0,0 +1,73 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	apikey "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/api_key"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	orderProjectionVersionsCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/versioning_order_projection/v1/commands"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/versioning_order_projection/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type requestOrderProjectionCutoverEndpoint struct {
+	params.BackOfficeRouteParams
+}
+
+func NewRequestOrderProjectionCutoverEndpoint(params params.BackOfficeRouteParams) route.Endpoint {
+	return &requestOrderProjectionCutoverEndpoint{BackOfficeRouteParams: params}
+}
+
+func (ep *requestOrderProjectionCutoverEndpoint) MapEndpoint() {
+	ep.BackOfficeProjectionsGroup.POST("/orders/versions/cutover", ep.handler())
+}
+
+// RequestOrderProjectionCutover
+// @Tags BackOffice
+// @Summary Request orders projection cutover
+// @Description Switch the reads to the candidate version of the orders projection when it catches up with the active version, the collection of the active version is dropped after the cleanup delay
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 202 {object} dtos.RequestOrderProjectionCutoverResponseDto
+// @Router /api/v1/backoffice/projections/orders/versions/cutover [post]
+func (ep *requestOrderProjectionCutoverEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		userId, _ := apikey.UserId(ctx)
+
+		command, err := orderProjectionVersionsCommandsV1.NewRequestOrderProjectionCutover(userId)
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"[requestOrderProjectionCutoverEndpoint_handler.StructCtx] command validation failed",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[requestOrderProjectionCutoverEndpoint_handler.StructCtx] err: %v", validationErr),
+			)
+			return validationErr
+		}
+
+		result, err := cqrs.Send[*orderProjectionVersionsCommandsV1.RequestOrderProjectionCutover, *dtos.RequestOrderProjectionCutoverResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[requestOrderProjectionCutoverEndpoint_handler.Send] error in sending RequestOrderProjectionCutover",
+			)
+			ep.Logger.Error(fmt.Sprintf("[requestOrderProjectionCutoverEndpoint_handler.Send] err: {%v}", err))
+			return err
+		}
+
+		return c.JSON(http.StatusAccepted, result)
+	}
+}
@@ -0,0 +1,73 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/web/route"
+	apikey "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/api_key"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	orderProjectionVersionsCommandsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/versioning_order_projection/v1/commands"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/versioning_order_projection/v1/dtos"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type startOrderProjectionVersionEndpoint struct {
+	params.BackOfficeRouteParams
+}
+
+func NewStartOrderProjectionVersionEndpoint(params params.BackOfficeRouteParams) route.Endpoint {
+	return &startOrderProjectionVersionEndpoint{BackOfficeRouteParams: params}
+}
+
+func (ep *startOrderProjectionVersionEndpoint) MapEndpoint() {
+	ep.BackOfficeProjectionsGroup.POST("/orders/versions", ep.handler())
+}
+
+// StartOrderProjectionVersion
+// @Tags BackOffice
+// @Summary Start orders projection version
+// @Description Start building the next version of the orders projection into a new collection while the active version serves the reads
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 202 {object} dtos.StartOrderProjectionVersionResponseDto
+// @Router /api/v1/backoffice/projections/orders/versions [post]
+func (ep *startOrderProjectionVersionEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		userId, _ := apikey.UserId(ctx)
+
+		command, err := orderProjectionVersionsCommandsV1.NewStartOrderProjectionVersion(userId)
+		if err != nil {
+			validationErr := customErrors.NewValidationErrorWrap(
+				err,
+				"[startOrderProjectionVersionEndpoint_handler.StructCtx] command validation failed",
+			)
+			ep.Logger.Errorf(
+				fmt.Sprintf("[startOrderProjectionVersionEndpoint_handler.StructCtx] err: %v", validationErr),
+			)
+			return validationErr
+		}
+
+		result, err := cqrs.Send[*orderProjectionVersionsCommandsV1.StartOrderProjectionVersion, *dtos.StartOrderProjectionVersionResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[startOrderProjectionVersionEndpoint_handler.Send] error in sending StartOrderProjectionVersion",
+			)
+			ep.Logger.Error(fmt.Sprintf("[startOrderProjectionVersionEndpoint_handler.Send] err: {%v}", err))
+			return err
+		}
+
+		return c.JSON(http.StatusAccepted, result)
+	}
+}
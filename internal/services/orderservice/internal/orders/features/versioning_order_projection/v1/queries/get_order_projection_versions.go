@@ -0,0 +1,8 @@
+package orderProjectionVersionsQueryV1
+
+// GetOrderProjectionVersions returns the active, candidate and retired versions of the orders projection
+type GetOrderProjectionVersions struct{}
+
+func NewGetOrderProjectionVersions() (*GetOrderProjectionVersions, error) {
+	return &GetOrderProjectionVersions{}, nil
+}
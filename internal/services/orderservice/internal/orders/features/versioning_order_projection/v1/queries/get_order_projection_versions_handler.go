@@ -0,0 +1,58 @@
+package orderProjectionVersionsQueryV1
+
+import (
+	"context"
+
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/versioning_order_projection/v1/dtos"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/projections/versioning"
+)
+
+type GetOrderProjectionVersionsHandler struct {
+	log        logger.Logger
+	versioning *versioning.OrderProjectionVersioning
+}
+
+func NewGetOrderProjectionVersionsHandler(
+	log logger.Logger,
+	versioning *versioning.OrderProjectionVersioning,
+) *GetOrderProjectionVersionsHandler {
+	return &GetOrderProjectionVersionsHandler{
+		log:        log,
+		versioning: versioning,
+	}
+}
+
+func (c *GetOrderProjectionVersionsHandler) Handle(
+	ctx context.Context,
+	query *GetOrderProjectionVersions,
+) (*dtos.GetOrderProjectionVersionsResponseDto, error) {
+	versions, err := c.versioning.Versions(ctx)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"[GetOrderProjectionVersionsHandler_Handle.Versions] error in loading the orders projection versions",
+		)
+	}
+
+	versionsDto := &dtos.OrderProjectionVersionsDto{
+		Active:           dtos.NewOrderProjectionVersionDto(versions.Active),
+		Candidate:        dtos.NewOrderProjectionVersionDto(versions.Candidate),
+		Retired:          make([]*dtos.OrderProjectionVersionDto, 0, len(versions.Retired)),
+		CandidateRunning: c.versioning.CandidateRunning(),
+	}
+	for _, retired := range versions.Retired {
+		versionsDto.Retired = append(versionsDto.Retired, dtos.NewOrderProjectionVersionDto(retired))
+	}
+	if versions.Candidate != nil {
+		versionsDto.CandidatePosition = c.versioning.CandidatePosition()
+		if err := c.versioning.CandidateErr(); err != nil {
+			versionsDto.CandidateError = err.Error()
+		}
+	}
+
+	c.log.Info("[GetOrderProjectionVersionsHandler.Handle] orders projection versions fetched")
+
+	return &dtos.GetOrderProjectionVersionsResponseDto{Versions: versionsDto}, nil
+}
@@ -0,0 +1,142 @@
+package finance
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/client"
+
+	"emperror.dev/errors"
+	"github.com/go-resty/resty/v2"
+)
+
+// ErpBatch is a batch of the ERP records, the batch id is the idempotency key of the batch in the ERP
+type ErpBatch struct {
+	Id        string
+	CreatedAt time.Time
+	Header    []string
+	Records   [][]string
+}
+
+// ErpExporter delivers the batches to the ERP
+type ErpExporter interface {
+	// Export delivers the batch and returns its location in the ERP, like the path of the batch file
+	Export(ctx context.Context, batch *ErpBatch) (string, error)
+}
+
+// NewErpExporter creates the exporter of the target of the options
+func NewErpExporter(options *FinanceExportOptions) (ErpExporter, error) {
+	switch options.Target {
+	case TargetFile:
+		return &fileErpExporter{directory: options.Directory}, nil
+	case TargetApi:
+		if options.Endpoint == "" {
+			return nil, errors.New("finance export endpoint is required for the `api` target")
+		}
+
+		httpClient := client.NewHttpClient().SetTimeout(options.Timeout)
+		if options.ApiKey != "" {
+			httpClient.SetHeader("X-Api-Key", options.ApiKey)
+		}
+
+		return &apiErpExporter{client: httpClient, endpoint: options.Endpoint}, nil
+	default:
+		return nil, errors.Errorf("finance export target `%s` is not supported", options.Target)
+	}
+}
+
+type fileErpExporter struct {
+	directory string
+}
+
+// Export writes the batch to a temporary file and renames it to `<batch id>.csv`, so the ERP never imports a partially
+// written batch
+func (f *fileErpExporter) Export(ctx context.Context, batch *ErpBatch) (string, error) {
+	if err := os.MkdirAll(f.directory, 0o755); err != nil {
+		return "", errors.WrapIf(err, "[fileErpExporter_Export.MkdirAll] error in creating finance export directory")
+	}
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	if err := writer.Write(batch.Header); err != nil {
+		return "", errors.WrapIf(err, "[fileErpExporter_Export.Write] error in writing batch header")
+	}
+	if err := writer.WriteAll(batch.Records); err != nil {
+		return "", errors.WrapIf(err, "[fileErpExporter_Export.WriteAll] error in writing batch records")
+	}
+
+	tmp, err := os.CreateTemp(f.directory, ".finance-*")
+	if err != nil {
+		return "", errors.WrapIf(err, "[fileErpExporter_Export.CreateTemp] error in creating temporary batch file")
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(buffer.Bytes())
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", errors.WrapIf(err, "[fileErpExporter_Export.Write] error in writing batch file")
+	}
+
+	location := filepath.Join(f.directory, fmt.Sprintf("%s.csv", batch.Id))
+	if err = os.Rename(tmp.Name(), location); err != nil {
+		return "", errors.WrapIf(err, "[fileErpExporter_Export.Rename] error in renaming batch file")
+	}
+
+	return location, nil
+}
+
+type apiErpExporter struct {
+	client   *resty.Client
+	endpoint string
+}
+
+type erpBatchRequest struct {
+	BatchId   string              `json:"batchId"`
+	CreatedAt time.Time           `json:"createdAt"`
+	Records   []map[string]string `json:"records"`
+}
+
+// Export posts the batch to the import endpoint of the ERP with the batch id in the `Idempotency-Key` header
+func (a *apiErpExporter) Export(ctx context.Context, batch *ErpBatch) (string, error) {
+	request := &erpBatchRequest{
+		BatchId:   batch.Id,
+		CreatedAt: batch.CreatedAt,
+		Records:   make([]map[string]string, 0, len(batch.Records)),
+	}
+	for _, record := range batch.Records {
+		item := make(map[string]string, len(batch.Header))
+		for i, column := range batch.Header {
+			item[column] = record[i]
+		}
+		request.Records = append(request.Records, item)
+	}
+
+	response, err := a.client.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetHeader("Idempotency-Key", batch.Id).
+		SetBody(request).
+		Post(a.endpoint)
+	if err != nil {
+		return "", errors.WrapIf(err, "[apiErpExporter_Export.Post] error in posting batch to the ERP")
+	}
+	if response.IsError() {
+		return "", errors.Errorf(
+			"[apiErpExporter_Export.Post] ERP responded with status `%d`: %s",
+			response.StatusCode(),
+			response.String(),
+		)
+	}
+
+	return a.endpoint, nil
+}
@@ -0,0 +1,93 @@
+package finance
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBatch() *ErpBatch {
+	return &ErpBatch{
+		Id:        "batch-1",
+		CreatedAt: time.Now(),
+		Header:    []string{"id", "amount"},
+		Records:   [][]string{{"entry-1", "10.00"}, {"entry-2", "20.00"}},
+	}
+}
+
+func Test_File_Erp_Exporter_Writes_The_Batch_File(t *testing.T) {
+	t.Parallel()
+
+	directory := t.TempDir()
+	exporter, err := NewErpExporter(&FinanceExportOptions{Target: TargetFile, Directory: directory})
+	require.NoError(t, err)
+
+	location, err := exporter.Export(context.Background(), newTestBatch())
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(directory, "batch-1.csv"), location)
+
+	content, err := os.ReadFile(location)
+	require.NoError(t, err)
+	assert.Equal(t, "id,amount\nentry-1,10.00\nentry-2,20.00\n", string(content))
+
+	// only the batch file is in the directory, the temporary file is renamed
+	files, err := os.ReadDir(directory)
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+}
+
+func Test_Api_Erp_Exporter_Posts_The_Batch(t *testing.T) {
+	t.Parallel()
+
+	var request erpBatchRequest
+	var idempotencyKey, apiKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idempotencyKey = r.Header.Get("Idempotency-Key")
+		apiKey = r.Header.Get("X-Api-Key")
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	exporter, err := NewErpExporter(&FinanceExportOptions{
+		Target:   TargetApi,
+		Endpoint: server.URL,
+		ApiKey:   "erp-key",
+		Timeout:  time.Second,
+	})
+	require.NoError(t, err)
+
+	_, err = exporter.Export(context.Background(), newTestBatch())
+	require.NoError(t, err)
+
+	assert.Equal(t, "batch-1", idempotencyKey)
+	assert.Equal(t, "erp-key", apiKey)
+	assert.Equal(t, "batch-1", request.BatchId)
+	assert.Equal(t, []map[string]string{
+		{"id": "entry-1", "amount": "10.00"},
+		{"id": "entry-2", "amount": "20.00"},
+	}, request.Records)
+}
+
+func Test_Api_Erp_Exporter_Fails_On_Error_Status(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	exporter, err := NewErpExporter(&FinanceExportOptions{Target: TargetApi, Endpoint: server.URL, Timeout: time.Second})
+	require.NoError(t, err)
+
+	_, err = exporter.Export(context.Background(), newTestBatch())
+	assert.Error(t, err)
+}
@@ -0,0 +1,119 @@
+package finance
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/finance/read_models"
+
+	"emperror.dev/errors"
+)
+
+// fields are the exportable fields of the finance entries in their default column order
+var fields = []string{
+	"id",
+	"type",
+	"account",
+	"orderId",
+	"orderNumber",
+	"paymentId",
+	"amount",
+	"giftCardAmount",
+	"currency",
+	"exchangeRate",
+	"reason",
+	"occurredAt",
+}
+
+// ErpRecordMapper maps the finance entries to the records of the ERP with the configured columns
+type ErpRecordMapper struct {
+	columns  []*FinanceExportColumnOptions
+	accounts map[string]string
+}
+
+func NewErpRecordMapper(options *FinanceExportOptions) (*ErpRecordMapper, error) {
+	columns := options.Columns
+	if len(columns) == 0 {
+		for _, field := range fields {
+			columns = append(columns, &FinanceExportColumnOptions{Name: field, Field: field})
+		}
+	}
+
+	for _, column := range columns {
+		if column == nil || column.Name == "" {
+			return nil, errors.New("finance export column should have a name")
+		}
+
+		if !isField(column.Field) {
+			return nil, errors.Errorf(
+				"finance export column `%s` has the unknown field `%s`",
+				column.Name,
+				column.Field,
+			)
+		}
+	}
+
+	return &ErpRecordMapper{columns: columns, accounts: options.Accounts}, nil
+}
+
+// Header returns the names of the columns
+func (m *ErpRecordMapper) Header() []string {
+	header := make([]string, 0, len(m.columns))
+	for _, column := range m.columns {
+		header = append(header, column.Name)
+	}
+
+	return header
+}
+
+// Record returns the values of the columns for the entry, the amounts are formatted with two decimals and the times
+// in RFC3339 UTC
+func (m *ErpRecordMapper) Record(entry *read_models.FinanceEntryReadModel) []string {
+	record := make([]string, 0, len(m.columns))
+	for _, column := range m.columns {
+		record = append(record, m.value(entry, column.Field))
+	}
+
+	return record
+}
+
+func (m *ErpRecordMapper) value(entry *read_models.FinanceEntryReadModel, field string) string {
+	switch field {
+	case "id":
+		return entry.Id
+	case "type":
+		return entry.Type
+	case "account":
+		return m.accounts[entry.Type]
+	case "orderId":
+		return entry.OrderId
+	case "orderNumber":
+		return entry.OrderNumber
+	case "paymentId":
+		return entry.PaymentId
+	case "amount":
+		return strconv.FormatFloat(entry.Amount, 'f', 2, 64)
+	case "giftCardAmount":
+		return strconv.FormatFloat(entry.GiftCardAmount, 'f', 2, 64)
+	case "currency":
+		return entry.Currency
+	case "exchangeRate":
+		return strconv.FormatFloat(entry.ExchangeRate, 'f', -1, 64)
+	case "reason":
+		return entry.Reason
+	case "occurredAt":
+		return entry.OccurredAt.UTC().Format(time.RFC3339)
+	}
+
+	return ""
+}
+
+func isField(field string) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,56 @@
+package finance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/finance/read_models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Erp_Record_Mapper_Maps_The_Configured_Columns(t *testing.T) {
+	t.Parallel()
+
+	mapper, err := NewErpRecordMapper(&FinanceExportOptions{
+		Columns: []*FinanceExportColumnOptions{
+			{Name: "DocumentNo", Field: "id"},
+			{Name: "GLAccount", Field: "account"},
+			{Name: "Amount", Field: "amount"},
+			{Name: "Currency", Field: "currency"},
+			{Name: "PostingDate", Field: "occurredAt"},
+		},
+		Accounts: map[string]string{read_models.FinanceEntryTypeRefund: "4100"},
+	})
+	require.NoError(t, err)
+
+	record := mapper.Record(&read_models.FinanceEntryReadModel{
+		Id:         "entry-1",
+		Type:       read_models.FinanceEntryTypeRefund,
+		Amount:     12.5,
+		Currency:   "EUR",
+		OccurredAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600)),
+	})
+
+	assert.Equal(t, []string{"DocumentNo", "GLAccount", "Amount", "Currency", "PostingDate"}, mapper.Header())
+	assert.Equal(t, []string{"entry-1", "4100", "12.50", "EUR", "2024-01-02T02:04:05Z"}, record)
+}
+
+func Test_Erp_Record_Mapper_Exports_All_Fields_Without_Columns(t *testing.T) {
+	t.Parallel()
+
+	mapper, err := NewErpRecordMapper(&FinanceExportOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, fields, mapper.Header())
+}
+
+func Test_Erp_Record_Mapper_Rejects_Unknown_Fields(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewErpRecordMapper(&FinanceExportOptions{
+		Columns: []*FinanceExportColumnOptions{{Name: "Customer", Field: "accountEmail"}},
+	})
+	assert.Error(t, err)
+}
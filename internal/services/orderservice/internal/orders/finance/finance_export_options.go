@@ -0,0 +1,48 @@
+package finance
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+const (
+	// TargetFile exports the batches as csv files to the export directory, e.g. a directory imported by the ERP
+	TargetFile = "file"
+	// TargetApi pushes the batches as json to the import endpoint of the ERP
+	TargetApi = "api"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[FinanceExportOptions]())
+
+// FinanceExportOptions controls the finance export worker, the finance entries of the order events are exported to the
+// ERP in batches of BatchSize and an entry is failed after MaxAttempts failed exports.
+type FinanceExportOptions struct {
+	Enabled     bool          `mapstructure:"enabled"`
+	Interval    time.Duration `mapstructure:"interval"    default:"5m"`
+	BatchSize   int           `mapstructure:"batchSize"   default:"500"`
+	MaxAttempts int           `mapstructure:"maxAttempts" default:"5"`
+	// Target is `file` or `api`
+	Target    string        `mapstructure:"target"    default:"file"`
+	Directory string        `mapstructure:"directory" default:"./finance-exports"`
+	Endpoint  string        `mapstructure:"endpoint"`
+	ApiKey    string        `mapstructure:"apiKey"    secret:"true"`
+	Timeout   time.Duration `mapstructure:"timeout"   default:"30s"`
+	// Columns are the columns of the ERP records and their entry fields, all the fields are exported without columns
+	Columns []*FinanceExportColumnOptions `mapstructure:"columns"`
+	// Accounts maps the entry types like `payment` to the ledger accounts of the ERP, it is the `account` field
+	Accounts map[string]string `mapstructure:"accounts"`
+}
+
+type FinanceExportColumnOptions struct {
+	Name  string `mapstructure:"name"`
+	Field string `mapstructure:"field"`
+}
+
+func NewFinanceExportOptions(environment environment.Environment) (*FinanceExportOptions, error) {
+	return config.BindConfigKey[*FinanceExportOptions](optionName, environment)
+}
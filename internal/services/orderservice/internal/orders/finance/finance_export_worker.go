@@ -0,0 +1,136 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/featuretoggle"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/web"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/repositories"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+	"go.uber.org/fx"
+)
+
+// FinanceExportWorker exports the pending finance entries to the ERP, a failed batch is exported again in the next run
+// with a new batch id, so the ERP should deduplicate the records by the entry id.
+type FinanceExportWorker struct {
+	options                *FinanceExportOptions
+	financeEntryRepository repositories.FinanceEntryRepository
+	recordMapper           *ErpRecordMapper
+	exporter               ErpExporter
+	logger                 logger.Logger
+	tracer                 tracing.AppTracer
+}
+
+func NewFinanceExportWorker(
+	options *FinanceExportOptions,
+	financeEntryRepository repositories.FinanceEntryRepository,
+	recordMapper *ErpRecordMapper,
+	exporter ErpExporter,
+	logger logger.Logger,
+	tracer tracing.AppTracer,
+) *FinanceExportWorker {
+	return &FinanceExportWorker{
+		options:                options,
+		financeEntryRepository: financeEntryRepository,
+		recordMapper:           recordMapper,
+		exporter:               exporter,
+		logger:                 logger,
+		tracer:                 tracer,
+	}
+}
+
+// Run exports a batch of the pending entries
+func (f *FinanceExportWorker) Run(ctx context.Context) error {
+	ctx, span := f.tracer.Start(ctx, "FinanceExportWorker.Run")
+	defer span.End()
+
+	entries, err := f.financeEntryRepository.GetPendingFinanceEntries(ctx, f.options.BatchSize)
+	if err != nil {
+		return errors.WrapIf(
+			err,
+			"[FinanceExportWorker_Run.GetPendingFinanceEntries] error in getting pending finance entries",
+		)
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	batch := &ErpBatch{
+		Id:        uuid.NewV4().String(),
+		CreatedAt: time.Now(),
+		Header:    f.recordMapper.Header(),
+		Records:   make([][]string, 0, len(entries)),
+	}
+	ids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		batch.Records = append(batch.Records, f.recordMapper.Record(entry))
+		ids = append(ids, entry.Id)
+	}
+
+	location, err := f.exporter.Export(ctx, batch)
+	if err != nil {
+		markErr := f.financeEntryRepository.MarkFinanceEntriesFailed(
+			ctx,
+			ids,
+			batch.Id,
+			err.Error(),
+			f.options.MaxAttempts,
+		)
+		if markErr != nil {
+			f.logger.Errorw(
+				fmt.Sprintf("[FinanceExportWorker_Run.MarkFinanceEntriesFailed] err: %v", markErr),
+				logger.Fields{"BatchId": batch.Id},
+			)
+		}
+
+		return errors.WrapIf(err, "[FinanceExportWorker_Run.Export] error in exporting finance entries batch")
+	}
+
+	err = f.financeEntryRepository.MarkFinanceEntriesExported(ctx, ids, batch.Id, location, time.Now())
+	if err != nil {
+		return errors.WrapIf(
+			err,
+			"[FinanceExportWorker_Run.MarkFinanceEntriesExported] error in marking finance entries exported",
+		)
+	}
+
+	f.logger.Infow(
+		fmt.Sprintf("[FinanceExportWorker.Run] %d finance entries exported to: {%s}", len(ids), location),
+		logger.Fields{"BatchId": batch.Id, "Target": f.options.Target},
+	)
+
+	return nil
+}
+
+// WorkerName is the name of the finance export worker in the `worker:finance-export` feature toggle
+const WorkerName = "finance-export"
+
+// RegisterFinanceExportWorker runs the finance export worker on the interval of the options while the application is
+// running, the runs are skipped while the worker is disabled by its feature toggle
+func RegisterFinanceExportWorker(
+	lc fx.Lifecycle,
+	worker *FinanceExportWorker,
+	options *FinanceExportOptions,
+	toggles featuretoggle.FeatureToggles,
+	log logger.Logger,
+) error {
+	if !options.Enabled {
+		return nil
+	}
+
+	return web.RegisterPeriodicWorker(lc, "finance export worker", options.Interval, log, func(ctx context.Context) error {
+		if !toggles.IsEnabled(featuretoggle.WorkerToggle(WorkerName)) {
+			log.Info("[FinanceExportWorker] finance export worker is disabled by its feature toggle")
+
+			return nil
+		}
+
+		return worker.Run(ctx)
+	})
+}
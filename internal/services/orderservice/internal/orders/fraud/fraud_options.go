@@ -0,0 +1,26 @@
+package fraud
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[FraudOptions]())
+
+// FraudOptions controls the rules of the default fraud screener, a zero limit disables its rule.
+type FraudOptions struct {
+	Enabled bool `mapstructure:"enabled"`
+	// MaxOrderTotal holds the orders with a higher total price in the base currency
+	MaxOrderTotal float64 `mapstructure:"maxOrderTotal"       default:"5000"`
+	// MaxItemQuantity holds the orders with a shop item of a higher quantity
+	MaxItemQuantity int `mapstructure:"maxItemQuantity"     default:"50"`
+	// BlockedEmailDomains holds the orders of the accounts with these email domains, e.g. disposable mail providers
+	BlockedEmailDomains []string `mapstructure:"blockedEmailDomains"`
+}
+
+func NewFraudOptions(environment environment.Environment) (*FraudOptions, error) {
+	return config.BindConfigKey[*FraudOptions](optionName, environment)
+}
@@ -0,0 +1,19 @@
+package fraud
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/aggregate"
+)
+
+// ScreeningResult is the verdict of a fraud screening, Reasons explain why the order should be held
+type ScreeningResult struct {
+	Hold    bool
+	Reasons []string
+}
+
+// FraudScreener screens the new orders before they go further, the rules based screener is the default provider and
+// can be replaced with an external fraud service by decorating it in the fx container.
+type FraudScreener interface {
+	Screen(ctx context.Context, order *aggregate.Order) (*ScreeningResult, error)
+}
@@ -0,0 +1,71 @@
+package fraud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/aggregate"
+)
+
+type rulesFraudScreener struct {
+	options *FraudOptions
+}
+
+// NewRulesFraudScreener creates a screener which holds the orders breaking the rules of the FraudOptions
+func NewRulesFraudScreener(options *FraudOptions) FraudScreener {
+	return &rulesFraudScreener{options: options}
+}
+
+func (r *rulesFraudScreener) Screen(_ context.Context, order *aggregate.Order) (*ScreeningResult, error) {
+	result := &ScreeningResult{}
+	if !r.options.Enabled {
+		return result, nil
+	}
+
+	if r.options.MaxOrderTotal > 0 && order.BaseTotalPrice() > r.options.MaxOrderTotal {
+		result.Reasons = append(
+			result.Reasons,
+			fmt.Sprintf("total price %.2f exceeds the limit %.2f", order.BaseTotalPrice(), r.options.MaxOrderTotal),
+		)
+	}
+
+	if r.options.MaxItemQuantity > 0 {
+		for _, item := range order.ShopItems() {
+			if item.Quantity() > uint64(r.options.MaxItemQuantity) {
+				result.Reasons = append(
+					result.Reasons,
+					fmt.Sprintf(
+						"quantity %d of item `%s` exceeds the limit %d",
+						item.Quantity(),
+						item.Title(),
+						r.options.MaxItemQuantity,
+					),
+				)
+			}
+		}
+	}
+
+	if domain := emailDomain(order.AccountEmail()); domain != "" {
+		for _, blocked := range r.options.BlockedEmailDomains {
+			if strings.EqualFold(domain, strings.TrimSpace(blocked)) {
+				result.Reasons = append(result.Reasons, fmt.Sprintf("email domain `%s` is blocked", domain))
+
+				break
+			}
+		}
+	}
+
+	result.Hold = len(result.Reasons) > 0
+
+	return result, nil
+}
+
+func emailDomain(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ""
+	}
+
+	return email[at+1:]
+}
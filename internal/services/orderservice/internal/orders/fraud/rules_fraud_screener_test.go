@@ -0,0 +1,95 @@
+package fraud
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/configurations/mappings"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/aggregate"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/value_objects"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	_ = mappings.ConfigureOrdersMappings()
+}
+
+var defaultOptions = &FraudOptions{
+	Enabled:             true,
+	MaxOrderTotal:       5000,
+	MaxItemQuantity:     50,
+	BlockedEmailDomains: []string{"mailinator.com"},
+}
+
+func newOrder(t *testing.T, email string, quantity uint64, price float64) *aggregate.Order {
+	t.Helper()
+
+	order, err := aggregate.NewOrder(
+		uuid.NewV4(),
+		"ORD-000001",
+		[]*value_objects.ShopItem{value_objects.CreateNewShopItem("item", "description", quantity, price)},
+		email,
+		"address",
+		time.Now().Add(time.Hour),
+		"",
+		0,
+		time.Now(),
+	)
+	require.NoError(t, err)
+
+	return order
+}
+
+func Test_Screen_Passes_Order_Within_Rules(t *testing.T) {
+	screener := NewRulesFraudScreener(defaultOptions)
+
+	result, err := screener.Screen(context.Background(), newOrder(t, "john@example.com", 2, 100))
+	require.NoError(t, err)
+	assert.False(t, result.Hold)
+	assert.Empty(t, result.Reasons)
+}
+
+func Test_Screen_Holds_Order_Breaking_Rules(t *testing.T) {
+	screener := NewRulesFraudScreener(defaultOptions)
+
+	result, err := screener.Screen(context.Background(), newOrder(t, "john@Mailinator.com", 60, 100))
+	require.NoError(t, err)
+	assert.True(t, result.Hold)
+	// total price, item quantity and email domain
+	assert.Len(t, result.Reasons, 3)
+}
+
+func Test_Screen_Skips_When_Disabled(t *testing.T) {
+	screener := NewRulesFraudScreener(&FraudOptions{Enabled: false, MaxOrderTotal: 1})
+
+	result, err := screener.Screen(context.Background(), newOrder(t, "john@example.com", 2, 100))
+	require.NoError(t, err)
+	assert.False(t, result.Hold)
+}
+
+func Test_Screen_Compares_Total_In_Base_Currency(t *testing.T) {
+	screener := NewRulesFraudScreener(defaultOptions)
+
+	order, err := aggregate.NewOrder(
+		uuid.NewV4(),
+		"ORD-000002",
+		[]*value_objects.ShopItem{value_objects.CreateNewShopItem("item", "description", 30, 200)},
+		"john@example.com",
+		"address",
+		time.Now().Add(time.Hour),
+		"EUR",
+		2,
+		time.Now(),
+	)
+	require.NoError(t, err)
+
+	// 6000 EUR is 3000 in the base currency
+	result, err := screener.Screen(context.Background(), order)
+	require.NoError(t, err)
+	assert.False(t, result.Hold)
+	assert.Equal(t, 3000.0, order.BaseTotalPrice())
+}
@@ -0,0 +1,203 @@
+package aggregate
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/domain"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/errors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+	dtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/dtos/v1"
+	domainExceptions "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/exceptions/domain_exceptions"
+	convertOrderDraftDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/converting_order_draft/v1/events/domain_events"
+	saveOrderDraftDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/saving_order_draft/v1/events/domain_events"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// OrderDraft is an incomplete order of a customer, it is saved until it expires or is converted into an order with
+// the same id
+type OrderDraft struct {
+	*models.EventSourcedAggregateRoot
+	shopItems       []*dtosV1.ShopItemDto
+	accountEmail    string
+	deliveryAddress string
+	deliveryTime    time.Time
+	customerGroup   string
+	createdAt       time.Time
+	savedAt         time.Time
+	expiresAt       time.Time
+	orderId         uuid.UUID
+	convertedAt     time.Time
+}
+
+func (d *OrderDraft) NewEmptyAggregate() {
+	base := models.NewEventSourcedAggregateRoot(typeMapper.GetFullTypeName(d), d.When)
+	d.EventSourcedAggregateRoot = base
+}
+
+// NewOrderDraft creates a draft with the first save of the incomplete order
+func NewOrderDraft(
+	id uuid.UUID,
+	shopItems []*dtosV1.ShopItemDto,
+	accountEmail, deliveryAddress string,
+	deliveryTime time.Time,
+	customerGroup string,
+	savedAt time.Time,
+	expiresAt time.Time,
+) (*OrderDraft, error) {
+	draft := &OrderDraft{}
+	draft.NewEmptyAggregate()
+	draft.SetId(id)
+
+	err := draft.Save(shopItems, accountEmail, deliveryAddress, deliveryTime, customerGroup, savedAt, expiresAt)
+	if err != nil {
+		return nil, customErrors.NewDomainErrorWrap(
+			err,
+			"[OrderDraft_NewOrderDraft.Save] error in saving the new order draft",
+		)
+	}
+
+	return draft, nil
+}
+
+// Save replaces the incomplete order of the draft and extends its expiration, the expired and the converted drafts
+// can't be saved
+func (d *OrderDraft) Save(
+	shopItems []*dtosV1.ShopItemDto,
+	accountEmail, deliveryAddress string,
+	deliveryTime time.Time,
+	customerGroup string,
+	savedAt time.Time,
+	expiresAt time.Time,
+) error {
+	if err := d.checkOpen(savedAt); err != nil {
+		return err
+	}
+
+	event, err := saveOrderDraftDomainEventsV1.NewOrderDraftSavedV1(
+		shopItems,
+		accountEmail,
+		deliveryAddress,
+		deliveryTime,
+		customerGroup,
+		savedAt,
+		expiresAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return d.Apply(event, true)
+}
+
+// Convert marks the draft as placed with the order, the order id is the id of the draft, so a retried conversion
+// finds the already created order
+func (d *OrderDraft) Convert(convertedAt time.Time) error {
+	if err := d.checkOpen(convertedAt); err != nil {
+		return err
+	}
+
+	event, err := convertOrderDraftDomainEventsV1.NewOrderDraftConvertedV1(d.Id(), convertedAt)
+	if err != nil {
+		return err
+	}
+
+	return d.Apply(event, true)
+}
+
+func (d *OrderDraft) checkOpen(at time.Time) error {
+	if d.Converted() {
+		return domainExceptions.NewOrderDraftAlreadyConvertedError(
+			fmt.Sprintf("order draft with id %s is already converted to order %s", d.Id(), d.orderId),
+		)
+	}
+
+	if d.IsExpired(at) {
+		return domainExceptions.NewOrderDraftExpiredError(
+			fmt.Sprintf("order draft with id %s is expired at %s", d.Id(), d.expiresAt.Format(time.RFC3339)),
+		)
+	}
+
+	return nil
+}
+
+func (d *OrderDraft) When(event domain.IDomainEvent) error {
+	switch evt := event.(type) {
+
+	case *saveOrderDraftDomainEventsV1.OrderDraftSavedV1:
+		d.shopItems = evt.ShopItems
+		d.accountEmail = evt.AccountEmail
+		d.deliveryAddress = evt.DeliveryAddress
+		d.deliveryTime = evt.DeliveryTime
+		d.customerGroup = evt.CustomerGroup
+		d.savedAt = evt.SavedAt
+		d.expiresAt = evt.ExpiresAt
+		if d.createdAt.IsZero() {
+			d.createdAt = evt.SavedAt
+		}
+		d.SetId(evt.GetAggregateId())
+
+		return nil
+
+	case *convertOrderDraftDomainEventsV1.OrderDraftConvertedV1:
+		d.orderId = evt.OrderId
+		d.convertedAt = evt.ConvertedAt
+
+		return nil
+
+	default:
+		return errors.InvalidEventTypeError
+	}
+}
+
+// IsExpired returns true when the not converted draft is not saved for its TTL
+func (d *OrderDraft) IsExpired(at time.Time) bool {
+	return !d.Converted() && !d.expiresAt.IsZero() && !at.Before(d.expiresAt)
+}
+
+func (d *OrderDraft) Converted() bool {
+	return d.orderId != uuid.Nil
+}
+
+func (d *OrderDraft) ShopItems() []*dtosV1.ShopItemDto {
+	return d.shopItems
+}
+
+func (d *OrderDraft) AccountEmail() string {
+	return d.accountEmail
+}
+
+func (d *OrderDraft) DeliveryAddress() string {
+	return d.deliveryAddress
+}
+
+func (d *OrderDraft) DeliveryTime() time.Time {
+	return d.deliveryTime
+}
+
+func (d *OrderDraft) CustomerGroup() string {
+	return d.customerGroup
+}
+
+func (d *OrderDraft) CreatedAt() time.Time {
+	return d.createdAt
+}
+
+func (d *OrderDraft) SavedAt() time.Time {
+	return d.savedAt
+}
+
+func (d *OrderDraft) ExpiresAt() time.Time {
+	return d.expiresAt
+}
+
+func (d *OrderDraft) OrderId() uuid.UUID {
+	return d.orderId
+}
+
+func (d *OrderDraft) ConvertedAt() time.Time {
+	return d.convertedAt
+}
@@ -0,0 +1,71 @@
+package aggregate
+
+import (
+	"testing"
+	"time"
+
+	dtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/dtos/v1"
+	domainExceptions "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/exceptions/domain_exceptions"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Order_Draft_Save_Extends_Expiration(t *testing.T) {
+	t.Parallel()
+
+	savedAt := time.Now()
+	draft, err := NewOrderDraft(uuid.NewV4(), nil, "", "", time.Time{}, "", savedAt, savedAt.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, draft.ShopItems())
+
+	items := []*dtosV1.ShopItemDto{{Title: "book", Quantity: 1, Price: 10}}
+	resavedAt := savedAt.Add(30 * time.Minute)
+	err = draft.Save(items, "john@example.com", "street 1", time.Time{}, "", resavedAt, resavedAt.Add(time.Hour))
+	require.NoError(t, err)
+
+	assert.Equal(t, items, draft.ShopItems())
+	assert.Equal(t, "john@example.com", draft.AccountEmail())
+	assert.Equal(t, savedAt, draft.CreatedAt())
+	assert.Equal(t, resavedAt.Add(time.Hour), draft.ExpiresAt())
+	assert.False(t, draft.IsExpired(savedAt.Add(time.Hour)))
+}
+
+func Test_Order_Draft_Expired_Can_Not_Be_Saved_Or_Converted(t *testing.T) {
+	t.Parallel()
+
+	savedAt := time.Now()
+	draft, err := NewOrderDraft(uuid.NewV4(), nil, "", "", time.Time{}, "", savedAt, savedAt.Add(time.Hour))
+	require.NoError(t, err)
+
+	expiredAt := savedAt.Add(time.Hour)
+	assert.True(t, draft.IsExpired(expiredAt))
+
+	err = draft.Save(nil, "", "", time.Time{}, "", expiredAt, expiredAt.Add(time.Hour))
+	assert.True(t, domainExceptions.IsOrderDraftExpiredError(err))
+
+	err = draft.Convert(expiredAt)
+	assert.True(t, domainExceptions.IsOrderDraftExpiredError(err))
+}
+
+func Test_Order_Draft_Converted_Once(t *testing.T) {
+	t.Parallel()
+
+	savedAt := time.Now()
+	draft, err := NewOrderDraft(uuid.NewV4(), nil, "", "", time.Time{}, "", savedAt, savedAt.Add(time.Hour))
+	require.NoError(t, err)
+
+	require.NoError(t, draft.Convert(savedAt))
+	assert.True(t, draft.Converted())
+	// the order of a draft has the id of the draft
+	assert.Equal(t, draft.Id(), draft.OrderId())
+	// a converted draft doesn't expire
+	assert.False(t, draft.IsExpired(savedAt.Add(2*time.Hour)))
+
+	err = draft.Convert(savedAt)
+	assert.True(t, domainExceptions.IsOrderDraftAlreadyConvertedError(err))
+
+	err = draft.Save(nil, "", "", time.Time{}, "", savedAt, savedAt.Add(time.Hour))
+	assert.True(t, domainExceptions.IsOrderDraftAlreadyConvertedError(err))
+}
@@ -0,0 +1,35 @@
+package read_models
+
+import (
+	"time"
+)
+
+// OrderDraftReadModel is the last saved state of an order draft, the expired drafts are removed by the ttl index of
+// the collection
+type OrderDraftReadModel struct {
+	Id              string                     `json:"id"                    bson:"_id"`
+	ShopItems       []*OrderDraftItemReadModel `json:"shopItems"             bson:"shopItems"`
+	AccountEmail    string                     `json:"accountEmail"          bson:"accountEmail,omitempty"`
+	DeliveryAddress string                     `json:"deliveryAddress"       bson:"deliveryAddress,omitempty"`
+	DeliveryTime    time.Time                  `json:"deliveryTime"          bson:"deliveryTime,omitempty"`
+	CustomerGroup   string                     `json:"customerGroup"         bson:"customerGroup,omitempty"`
+	Converted       bool                       `json:"converted"             bson:"converted"`
+	OrderId         string                     `json:"orderId,omitempty"     bson:"orderId,omitempty"`
+	CreatedAt       time.Time                  `json:"createdAt"             bson:"createdAt"`
+	UpdatedAt       time.Time                  `json:"updatedAt"             bson:"updatedAt"`
+	ExpiresAt       time.Time                  `json:"expiresAt"             bson:"expiresAt"`
+	ConvertedAt     *time.Time                 `json:"convertedAt,omitempty" bson:"convertedAt,omitempty"`
+}
+
+type OrderDraftItemReadModel struct {
+	ProductId   string  `json:"productId,omitempty"   bson:"productId,omitempty"`
+	Title       string  `json:"title,omitempty"       bson:"title,omitempty"`
+	Description string  `json:"description,omitempty" bson:"description,omitempty"`
+	Quantity    uint64  `json:"quantity,omitempty"    bson:"quantity,omitempty"`
+	Price       float64 `json:"price,omitempty"       bson:"price,omitempty"`
+}
+
+// IsExpired returns true when the not converted draft is expired, the ttl index removes the expired drafts lazily
+func (d *OrderDraftReadModel) IsExpired(at time.Time) bool {
+	return !d.Converted && !at.Before(d.ExpiresAt)
+}
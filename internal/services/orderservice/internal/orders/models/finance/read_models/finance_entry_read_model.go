@@ -0,0 +1,47 @@
+package read_models
+
+import (
+	"time"
+)
+
+const (
+	FinanceEntryTypeOrder   = "order"
+	FinanceEntryTypePayment = "payment"
+	FinanceEntryTypeRefund  = "refund"
+)
+
+const (
+	// FinanceEntryStatusPending entries are exported in the next run of the finance export worker
+	FinanceEntryStatusPending = "pending"
+	// FinanceEntryStatusExported entries are delivered to the ERP, they are exported again only by a replay
+	FinanceEntryStatusExported = "exported"
+	// FinanceEntryStatusFailed entries are not delivered after the max attempts, they are exported again only by a replay
+	FinanceEntryStatusFailed = "failed"
+)
+
+// FinanceEntryReadModel is an accounting entry of an order event for the ERP export, the id is the id of the event,
+// so the entries are not duplicated when the events are projected again and the ERP can deduplicate the replayed
+// entries by their id
+type FinanceEntryReadModel struct {
+	Id          string `json:"id"                    bson:"_id"`
+	Type        string `json:"type"                  bson:"type"`
+	OrderId     string `json:"orderId"               bson:"orderId"`
+	OrderNumber string `json:"orderNumber,omitempty" bson:"orderNumber,omitempty"`
+	PaymentId   string `json:"paymentId,omitempty"   bson:"paymentId,omitempty"`
+	// Amount is the amount of the entry in the currency of the entry, the gift card part of the payments and the refunds
+	// is not included
+	Amount         float64   `json:"amount"                   bson:"amount"`
+	GiftCardAmount float64   `json:"giftCardAmount,omitempty" bson:"giftCardAmount,omitempty"`
+	Currency       string    `json:"currency"                 bson:"currency"`
+	ExchangeRate   float64   `json:"exchangeRate"             bson:"exchangeRate"`
+	Reason         string    `json:"reason,omitempty"         bson:"reason,omitempty"`
+	OccurredAt     time.Time `json:"occurredAt"               bson:"occurredAt"`
+	// delivery tracking of the entry
+	Status     string     `json:"status"               bson:"status"`
+	Attempts   int        `json:"attempts"             bson:"attempts"`
+	BatchId    string     `json:"batchId,omitempty"    bson:"batchId,omitempty"`
+	Location   string     `json:"location,omitempty"   bson:"location,omitempty"`
+	LastError  string     `json:"lastError,omitempty"  bson:"lastError,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"            bson:"createdAt"`
+	ExportedAt *time.Time `json:"exportedAt,omitempty" bson:"exportedAt,omitempty"`
+}
@@ -0,0 +1,212 @@
+package aggregate
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/domain"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/errors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+	domainExceptions "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/exceptions/domain_exceptions"
+	activateGiftCardDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/activating_gift_card/v1/events/domain_events"
+	issueGiftCardDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/issuing_gift_card/v1/events/domain_events"
+	redeemGiftCardDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/redeeming_gift_card/v1/events/domain_events"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// giftCardNamespace is the namespace of the gift card ids, the id of a gift card is derived from its code, so a card
+// can be loaded by the code the customer enters in the checkout
+var giftCardNamespace = uuid.FromStringOrNil("0f3c5d5e-8a31-4c61-9d7e-2f4b6a9c1e27")
+
+// GiftCard is the store credit of a customer, its balance is the issued amount minus the not refunded redemptions
+type GiftCard struct {
+	*models.EventSourcedAggregateRoot
+	code        string
+	amount      float64
+	balance     float64
+	active      bool
+	redemptions map[uuid.UUID]float64
+	issuedAt    time.Time
+	activatedAt time.Time
+	updatedAt   time.Time
+}
+
+// NormalizeCode removes the separators and the case of a gift card code
+func NormalizeCode(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	code = strings.ReplaceAll(code, "-", "")
+
+	return strings.ReplaceAll(code, " ", "")
+}
+
+// GiftCardId returns the id of the gift card with the code
+func GiftCardId(code string) uuid.UUID {
+	return uuid.NewV5(giftCardNamespace, NormalizeCode(code))
+}
+
+func (g *GiftCard) NewEmptyAggregate() {
+	base := models.NewEventSourcedAggregateRoot(typeMapper.GetFullTypeName(g), g.When)
+	g.EventSourcedAggregateRoot = base
+	g.redemptions = make(map[uuid.UUID]float64)
+}
+
+// NewGiftCard issues a not activated gift card with the code
+func NewGiftCard(code string, amount float64, issuedBy string, issuedAt time.Time) (*GiftCard, error) {
+	giftCard := &GiftCard{}
+	giftCard.NewEmptyAggregate()
+	giftCard.SetId(GiftCardId(code))
+
+	event, err := issueGiftCardDomainEventsV1.NewGiftCardIssuedV1(NormalizeCode(code), amount, issuedBy, issuedAt)
+	if err != nil {
+		return nil, customErrors.NewDomainErrorWrap(
+			err,
+			"[GiftCard_NewGiftCard.NewGiftCardIssuedV1] error in creating gift card issued event",
+		)
+	}
+
+	err = giftCard.Apply(event, true)
+	if err != nil {
+		return nil, customErrors.NewDomainErrorWrap(
+			err,
+			"[GiftCard_NewGiftCard.Apply] error in applying issued event",
+		)
+	}
+
+	return giftCard, nil
+}
+
+func (g *GiftCard) Activate(activatedBy string, activatedAt time.Time) error {
+	if g.active {
+		return domainExceptions.NewGiftCardAlreadyActiveError(
+			fmt.Sprintf("gift card with id %s is already active", g.Id()),
+		)
+	}
+
+	event, err := activateGiftCardDomainEventsV1.NewGiftCardActivatedV1(activatedBy, activatedAt)
+	if err != nil {
+		return err
+	}
+
+	return g.Apply(event, true)
+}
+
+// Redeem pays the amount of the order from the balance, an order can be paid only once with a gift card
+func (g *GiftCard) Redeem(orderId uuid.UUID, amount float64, redeemedAt time.Time) error {
+	if !g.active {
+		return domainExceptions.NewGiftCardNotActiveError(
+			fmt.Sprintf("gift card with id %s is not active", g.Id()),
+		)
+	}
+
+	if _, ok := g.redemptions[orderId]; ok {
+		return customErrors.NewConflictError(
+			fmt.Sprintf("order with id %s is already paid with gift card %s", orderId, g.Id()),
+		)
+	}
+
+	if amount <= 0 || amount > g.balance {
+		return domainExceptions.NewGiftCardInsufficientBalanceError(
+			fmt.Sprintf("balance of gift card with id %s is not enough for amount %.2f", g.Id(), amount),
+		)
+	}
+
+	event, err := redeemGiftCardDomainEventsV1.NewGiftCardRedeemedV1(orderId, amount, redeemedAt)
+	if err != nil {
+		return err
+	}
+
+	return g.Apply(event, true)
+}
+
+// RefundRedemption returns the redeemed amount of the order to the balance, it returns false when there is no
+// redemption for the order, so compensating the same order more than once is a no-op
+func (g *GiftCard) RefundRedemption(orderId uuid.UUID, refundedAt time.Time) (bool, error) {
+	amount, ok := g.redemptions[orderId]
+	if !ok {
+		return false, nil
+	}
+
+	event, err := redeemGiftCardDomainEventsV1.NewGiftCardRedemptionRefundedV1(orderId, amount, refundedAt)
+	if err != nil {
+		return false, err
+	}
+
+	return true, g.Apply(event, true)
+}
+
+func (g *GiftCard) When(event domain.IDomainEvent) error {
+	switch evt := event.(type) {
+
+	case *issueGiftCardDomainEventsV1.GiftCardIssuedV1:
+		g.code = evt.Code
+		g.amount = evt.Amount
+		g.balance = evt.Amount
+		g.issuedAt = evt.IssuedAt
+		g.updatedAt = evt.IssuedAt
+		g.SetId(evt.GetAggregateId())
+
+		return nil
+
+	case *activateGiftCardDomainEventsV1.GiftCardActivatedV1:
+		g.active = true
+		g.activatedAt = evt.ActivatedAt
+		g.updatedAt = evt.ActivatedAt
+
+		return nil
+
+	case *redeemGiftCardDomainEventsV1.GiftCardRedeemedV1:
+		g.redemptions[evt.OrderId] = evt.Amount
+		g.balance = roundAmount(g.balance - evt.Amount)
+		g.updatedAt = evt.RedeemedAt
+
+		return nil
+
+	case *redeemGiftCardDomainEventsV1.GiftCardRedemptionRefundedV1:
+		delete(g.redemptions, evt.OrderId)
+		g.balance = roundAmount(g.balance + evt.Amount)
+		g.updatedAt = evt.RefundedAt
+
+		return nil
+
+	default:
+		return errors.InvalidEventTypeError
+	}
+}
+
+func (g *GiftCard) Code() string {
+	return g.code
+}
+
+func (g *GiftCard) Amount() float64 {
+	return g.amount
+}
+
+func (g *GiftCard) Balance() float64 {
+	return g.balance
+}
+
+func (g *GiftCard) Active() bool {
+	return g.active
+}
+
+func (g *GiftCard) IssuedAt() time.Time {
+	return g.issuedAt
+}
+
+func (g *GiftCard) ActivatedAt() time.Time {
+	return g.activatedAt
+}
+
+func (g *GiftCard) UpdatedAt() time.Time {
+	return g.updatedAt
+}
+
+// roundAmount keeps the balance in cents, so the floating point errors don't accumulate with the redemptions
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,59 @@
+package aggregate
+
+import (
+	"testing"
+	"time"
+
+	domainExceptions "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/exceptions/domain_exceptions"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Gift_Card_Id_Is_Derived_From_Normalized_Code(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, GiftCardId("ABCD-EFGH-IJKL-MNOP"), GiftCardId(" abcdefgh ijklmnop"))
+	assert.NotEqual(t, GiftCardId("ABCD-EFGH-IJKL-MNOP"), GiftCardId("ABCD-EFGH-IJKL-MNOQ"))
+}
+
+func Test_Gift_Card_Should_Be_Active_To_Redeem(t *testing.T) {
+	t.Parallel()
+
+	giftCard, err := NewGiftCard("ABCD-EFGH-IJKL-MNOP", 100, "backoffice-admin", time.Now())
+	require.NoError(t, err)
+
+	err = giftCard.Redeem(uuid.NewV4(), 10, time.Now())
+	assert.True(t, domainExceptions.IsGiftCardNotActiveError(err))
+
+	require.NoError(t, giftCard.Activate("backoffice-admin", time.Now()))
+	err = giftCard.Activate("backoffice-admin", time.Now())
+	assert.True(t, domainExceptions.IsGiftCardAlreadyActiveError(err))
+}
+
+func Test_Gift_Card_Redemption_And_Refund(t *testing.T) {
+	t.Parallel()
+
+	giftCard, err := NewGiftCard("ABCD-EFGH-IJKL-MNOP", 100, "backoffice-admin", time.Now())
+	require.NoError(t, err)
+	require.NoError(t, giftCard.Activate("backoffice-admin", time.Now()))
+
+	orderId := uuid.NewV4()
+	require.NoError(t, giftCard.Redeem(orderId, 60.1, time.Now()))
+	assert.Equal(t, 39.9, giftCard.Balance())
+
+	err = giftCard.Redeem(uuid.NewV4(), 40, time.Now())
+	assert.True(t, domainExceptions.IsGiftCardInsufficientBalanceError(err))
+
+	refunded, err := giftCard.RefundRedemption(orderId, time.Now())
+	require.NoError(t, err)
+	assert.True(t, refunded)
+	assert.Equal(t, 100.0, giftCard.Balance())
+
+	// compensating the same order again is a no-op
+	refunded, err = giftCard.RefundRedemption(orderId, time.Now())
+	require.NoError(t, err)
+	assert.False(t, refunded)
+	assert.Equal(t, 100.0, giftCard.Balance())
+}
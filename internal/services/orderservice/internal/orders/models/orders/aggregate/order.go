@@ -3,6 +3,7 @@ package aggregate
 // https://www.eventstore.com/blog/what-is-event-sourcing
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/domain"
@@ -13,7 +14,16 @@ import (
 	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
 	dtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/dtos/v1"
 	domainExceptions "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/exceptions/domain_exceptions"
+	addOrderNoteDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/adding_order_note/v1/events/domain_events"
+	archiveOrderDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/archiving_order/v1/events/domain_events"
+	cancelOrderDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/canceling_order/v1/events/domain_events"
 	createOrderDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/creating_order/v1/events/domain_events"
+	fulfillOrderDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/fulfilling_order/v1/events/domain_events"
+	legalHoldDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/holding_order_legally/v1/events/domain_events"
+	purgeOrderDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/purging_order_personal_data/v1/events/domain_events"
+	redeemGiftCardDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/redeeming_gift_card/v1/events/domain_events"
+	reviewOrderDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/reviewing_order/v1/events/domain_events"
+	splitOrderStreamDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/splitting_order_stream/v1/events/domain_events"
 	updateOrderDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/updating_shopping_card/v1/events"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/value_objects"
 
@@ -23,6 +33,7 @@ import (
 
 type Order struct {
 	*models.EventSourcedAggregateRoot
+	orderNumber     string
 	shopItems       []*value_objects.ShopItem
 	accountEmail    string
 	deliveryAddress string
@@ -33,7 +44,17 @@ type Order struct {
 	submitted       bool
 	completed       bool
 	canceled        bool
+	heldForReview   bool
+	holdReasons     []string
+	reviewNote      string
+	giftCardId      uuid.UUID
+	giftCardAmount  float64
+	legalHold       bool
+	archived        bool
+	dataPurged      bool
 	paymentId       uuid.UUID
+	currency        string
+	exchangeRate    float64
 	createdAt       time.Time
 	updatedAt       time.Time
 }
@@ -46,9 +67,12 @@ func (o *Order) NewEmptyAggregate() {
 
 func NewOrder(
 	id uuid.UUID,
+	orderNumber string,
 	shopItems []*value_objects.ShopItem,
 	accountEmail, deliveryAddress string,
 	deliveredTime time.Time,
+	currency string,
+	exchangeRate float64,
 	createdAt time.Time,
 ) (*Order, error) {
 	order := &Order{}
@@ -71,10 +95,13 @@ func NewOrder(
 
 	event, err := createOrderDomainEventsV1.NewOrderCreatedEventV1(
 		id,
+		orderNumber,
 		itemsDto,
 		accountEmail,
 		deliveryAddress,
 		deliveredTime,
+		currency,
+		exchangeRate,
 		createdAt,
 	)
 	if err != nil {
@@ -109,12 +136,323 @@ func (o *Order) UpdateShoppingCard(shopItems []*value_objects.ShopItem) error {
 	return nil
 }
 
+// HoldForReview holds the order for a back-office review, it is called when the fraud screening flags the order
+func (o *Order) HoldForReview(reasons []string, heldAt time.Time) error {
+	if o.canceled {
+		return customErrors.NewDomainError("canceled order can't be held for review")
+	}
+
+	event, err := reviewOrderDomainEventsV1.NewOrderHeldForReviewV1(reasons, heldAt)
+	if err != nil {
+		return err
+	}
+
+	return o.Apply(event, true)
+}
+
+// ApproveReview releases the held order
+func (o *Order) ApproveReview(note string, reviewedAt time.Time) error {
+	if !o.heldForReview {
+		return domainExceptions.NewOrderNotHeldForReviewError(
+			fmt.Sprintf("order with id %s is not held for review", o.Id()),
+		)
+	}
+
+	event, err := reviewOrderDomainEventsV1.NewOrderReviewApprovedV1(note, reviewedAt)
+	if err != nil {
+		return err
+	}
+
+	return o.Apply(event, true)
+}
+
+// RejectReview releases the held order and cancels it with the rejection reason
+func (o *Order) RejectReview(reason string, reviewedAt time.Time) error {
+	if !o.heldForReview {
+		return domainExceptions.NewOrderNotHeldForReviewError(
+			fmt.Sprintf("order with id %s is not held for review", o.Id()),
+		)
+	}
+
+	event, err := reviewOrderDomainEventsV1.NewOrderReviewRejectedV1(reason, reviewedAt)
+	if err != nil {
+		return err
+	}
+
+	return o.Apply(event, true)
+}
+
+// ForceCancel cancels the order regardless of its state, it is used by the back-office users
+func (o *Order) ForceCancel(reason string, canceledBy string, canceledAt time.Time) error {
+	if o.canceled {
+		return domainExceptions.NewOrderAlreadyCanceledError(
+			fmt.Sprintf("order with id %s is already canceled", o.Id()),
+		)
+	}
+
+	event, err := cancelOrderDomainEventsV1.NewOrderCanceledV1(reason, canceledBy, true, canceledAt)
+	if err != nil {
+		return err
+	}
+
+	// the payment of a paid order is refunded
+	if o.paid {
+		event.Refunded = true
+		event.RefundedPaymentId = o.paymentId
+		event.RefundedAmount = o.TotalPrice() - o.giftCardAmount
+		event.RefundedGiftCardAmount = o.giftCardAmount
+	}
+
+	return o.Apply(event, true)
+}
+
+// Pay records the payment of the order, it is called by the order fulfillment saga after the stock of the order is
+// reserved and its payment is completed
+func (o *Order) Pay(paymentId uuid.UUID, paidAt time.Time) error {
+	if o.canceled {
+		return domainExceptions.NewOrderAlreadyCanceledError(
+			fmt.Sprintf("order with id %s is canceled", o.Id()),
+		)
+	}
+
+	if o.paid {
+		return customErrors.NewDomainError(fmt.Sprintf("order with id %s is already paid", o.Id()))
+	}
+
+	event, err := fulfillOrderDomainEventsV1.NewOrderPaidV1(
+		paymentId,
+		o.TotalPrice()-o.giftCardAmount,
+		o.giftCardAmount,
+		paidAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return o.Apply(event, true)
+}
+
+// AddInternalNote adds a back-office note to the order, the notes are kept in the events and the read models
+func (o *Order) AddInternalNote(noteId uuid.UUID, text string, author string, addedAt time.Time) error {
+	// the archived document of the order doesn't have the later notes and a note could have personal data
+	if o.archived || o.dataPurged {
+		return customErrors.NewConflictError(fmt.Sprintf("order with id %s is archived", o.Id()))
+	}
+
+	event, err := addOrderNoteDomainEventsV1.NewOrderNoteAddedV1(noteId, text, author, addedAt)
+	if err != nil {
+		return err
+	}
+
+	return o.Apply(event, true)
+}
+
+// ApplyGiftCard records the part of the total price which is paid with a gift card, the amount is already redeemed
+// from the gift card
+func (o *Order) ApplyGiftCard(giftCardId uuid.UUID, amount float64, appliedAt time.Time) error {
+	if o.canceled {
+		return domainExceptions.NewOrderAlreadyCanceledError(
+			fmt.Sprintf("order with id %s is canceled", o.Id()),
+		)
+	}
+
+	if o.giftCardId != uuid.Nil {
+		return customErrors.NewDomainError(fmt.Sprintf("order with id %s is already paid with a gift card", o.Id()))
+	}
+
+	event, err := redeemGiftCardDomainEventsV1.NewOrderGiftCardAppliedV1(giftCardId, amount, appliedAt)
+	if err != nil {
+		return err
+	}
+
+	return o.Apply(event, true)
+}
+
+// PlaceLegalHold keeps the personal data of the order from being purged until the hold is released
+func (o *Order) PlaceLegalHold(reason string, placedBy string, placedAt time.Time) error {
+	if o.legalHold {
+		return domainExceptions.NewOrderUnderLegalHoldError(
+			fmt.Sprintf("order with id %s is already under legal hold", o.Id()),
+		)
+	}
+
+	if o.dataPurged {
+		return customErrors.NewConflictError(
+			fmt.Sprintf("personal data of order with id %s is already purged", o.Id()),
+		)
+	}
+
+	event, err := legalHoldDomainEventsV1.NewOrderLegalHoldPlacedV1(reason, placedBy, placedAt)
+	if err != nil {
+		return err
+	}
+
+	return o.Apply(event, true)
+}
+
+func (o *Order) ReleaseLegalHold(releasedBy string, releasedAt time.Time) error {
+	if !o.legalHold {
+		return customErrors.NewConflictError(fmt.Sprintf("order with id %s is not under legal hold", o.Id()))
+	}
+
+	event, err := legalHoldDomainEventsV1.NewOrderLegalHoldReleasedV1(releasedBy, releasedAt)
+	if err != nil {
+		return err
+	}
+
+	return o.Apply(event, true)
+}
+
+// Archive records the location of the exported order document in the archive store
+func (o *Order) Archive(location string, archivedAt time.Time) error {
+	if o.archived {
+		return customErrors.NewDomainError(fmt.Sprintf("order with id %s is already archived", o.Id()))
+	}
+
+	event, err := archiveOrderDomainEventsV1.NewOrderArchivedV1(location, archivedAt)
+	if err != nil {
+		return err
+	}
+
+	return o.Apply(event, true)
+}
+
+// PurgePersonalData redacts the personal data of an archived order which is not under legal hold
+func (o *Order) PurgePersonalData(policy string, purgedAt time.Time) error {
+	if o.legalHold {
+		return domainExceptions.NewOrderUnderLegalHoldError(
+			fmt.Sprintf("order with id %s is under legal hold", o.Id()),
+		)
+	}
+
+	if !o.archived {
+		return customErrors.NewDomainError(
+			fmt.Sprintf("order with id %s should be archived before purging its personal data", o.Id()),
+		)
+	}
+
+	if o.dataPurged {
+		return customErrors.NewDomainError(
+			fmt.Sprintf("personal data of order with id %s is already purged", o.Id()),
+		)
+	}
+
+	event, err := purgeOrderDomainEventsV1.NewOrderPersonalDataPurgedV1(policy, purgedAt)
+	if err != nil {
+		return err
+	}
+
+	return o.Apply(event, true)
+}
+
+// ContinuationSnapshot returns the state of the order for continuing its stream after the earlier events are truncated,
+// the long-lived orders (e.g. subscription orders) are split by the back-office
+func (o *Order) ContinuationSnapshot() (domain.IDomainEvent, error) {
+	itemsDto, err := mapper.Map[[]*dtosV1.ShopItemDto](o.shopItems)
+	if err != nil {
+		return nil, customErrors.NewDomainErrorWrap(
+			err,
+			"[Order_ContinuationSnapshot.Map] error in the mapping []ShopItems to []ShopItemsDto",
+		)
+	}
+
+	event, err := splitOrderStreamDomainEventsV1.NewOrderStreamContinuedV1(o.Id(), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	event.OrderNumber = o.orderNumber
+	event.ShopItems = itemsDto
+	event.AccountEmail = o.accountEmail
+	event.DeliveryAddress = o.deliveryAddress
+	event.CancelReason = o.cancelReason
+	event.DeliveredTime = o.deliveredTime
+	event.Paid = o.paid
+	event.Submitted = o.submitted
+	event.Completed = o.completed
+	event.Canceled = o.canceled
+	event.HeldForReview = o.heldForReview
+	event.HoldReasons = o.holdReasons
+	event.ReviewNote = o.reviewNote
+	event.GiftCardId = o.giftCardId
+	event.GiftCardAmount = o.giftCardAmount
+	event.LegalHold = o.legalHold
+	event.Archived = o.archived
+	event.DataPurged = o.dataPurged
+	event.PaymentId = o.paymentId
+	event.Currency = o.currency
+	event.ExchangeRate = o.exchangeRate
+	event.CreatedAt = o.createdAt
+	event.UpdatedAt = o.updatedAt
+
+	return event, nil
+}
+
+// Commutes reports the internal notes can be merged with the concurrent updates of the order, adding a note only
+// moves the update time of the order, except the archive and the purge of the order which reject the later notes, so
+// a note is not merged with them and the note is added again on the reloaded order
+func (o *Order) Commutes(concurrent domain.IDomainEvent, uncommitted domain.IDomainEvent) bool {
+	_, concurrentNote := concurrent.(*addOrderNoteDomainEventsV1.OrderNoteAddedV1)
+	_, uncommittedNote := uncommitted.(*addOrderNoteDomainEventsV1.OrderNoteAddedV1)
+
+	if !concurrentNote && !uncommittedNote {
+		return false
+	}
+
+	return !closesNotes(concurrent) && !closesNotes(uncommitted)
+}
+
+func closesNotes(event domain.IDomainEvent) bool {
+	switch event.(type) {
+	case *archiveOrderDomainEventsV1.OrderArchivedV1, *purgeOrderDomainEventsV1.OrderPersonalDataPurgedV1:
+		return true
+	default:
+		return false
+	}
+}
+
 func (o *Order) When(event domain.IDomainEvent) error {
 	switch evt := event.(type) {
 
 	case *createOrderDomainEventsV1.OrderCreatedV1:
 		return o.onOrderCreated(evt)
 
+	case *reviewOrderDomainEventsV1.OrderHeldForReviewV1:
+		return o.onOrderHeldForReview(evt)
+
+	case *reviewOrderDomainEventsV1.OrderReviewApprovedV1:
+		return o.onOrderReviewApproved(evt)
+
+	case *reviewOrderDomainEventsV1.OrderReviewRejectedV1:
+		return o.onOrderReviewRejected(evt)
+
+	case *cancelOrderDomainEventsV1.OrderCanceledV1:
+		return o.onOrderCanceled(evt)
+
+	case *addOrderNoteDomainEventsV1.OrderNoteAddedV1:
+		return o.onOrderNoteAdded(evt)
+
+	case *redeemGiftCardDomainEventsV1.OrderGiftCardAppliedV1:
+		return o.onOrderGiftCardApplied(evt)
+
+	case *fulfillOrderDomainEventsV1.OrderPaidV1:
+		return o.onOrderPaid(evt)
+
+	case *legalHoldDomainEventsV1.OrderLegalHoldPlacedV1:
+		return o.onOrderLegalHoldPlaced(evt)
+
+	case *legalHoldDomainEventsV1.OrderLegalHoldReleasedV1:
+		return o.onOrderLegalHoldReleased(evt)
+
+	case *archiveOrderDomainEventsV1.OrderArchivedV1:
+		return o.onOrderArchived(evt)
+
+	case *purgeOrderDomainEventsV1.OrderPersonalDataPurgedV1:
+		return o.onOrderPersonalDataPurged(evt)
+
+	case *splitOrderStreamDomainEventsV1.OrderStreamContinuedV1:
+		return o.onOrderStreamContinued(evt)
+
 	default:
 		return errors.InvalidEventTypeError
 	}
@@ -126,16 +464,145 @@ func (o *Order) onOrderCreated(evt *createOrderDomainEventsV1.OrderCreatedV1) er
 		return err
 	}
 
+	o.orderNumber = evt.OrderNumber
 	o.accountEmail = evt.AccountEmail
 	o.shopItems = items
 	o.deliveryAddress = evt.DeliveryAddress
 	o.deliveredTime = evt.DeliveredTime
+	o.currency = evt.Currency
+	o.exchangeRate = evt.ExchangeRate
 	o.createdAt = evt.CreatedAt
 	o.SetId(evt.GetAggregateId()) // o.SetId(evt.Id)
 
 	return nil
 }
 
+func (o *Order) onOrderHeldForReview(evt *reviewOrderDomainEventsV1.OrderHeldForReviewV1) error {
+	o.heldForReview = true
+	o.holdReasons = evt.Reasons
+	o.updatedAt = evt.HeldAt
+
+	return nil
+}
+
+func (o *Order) onOrderReviewApproved(evt *reviewOrderDomainEventsV1.OrderReviewApprovedV1) error {
+	o.heldForReview = false
+	o.reviewNote = evt.Note
+	o.updatedAt = evt.ReviewedAt
+
+	return nil
+}
+
+func (o *Order) onOrderReviewRejected(evt *reviewOrderDomainEventsV1.OrderReviewRejectedV1) error {
+	o.heldForReview = false
+	o.canceled = true
+	o.cancelReason = evt.Reason
+	o.updatedAt = evt.ReviewedAt
+
+	return nil
+}
+
+func (o *Order) onOrderCanceled(evt *cancelOrderDomainEventsV1.OrderCanceledV1) error {
+	o.heldForReview = false
+	o.canceled = true
+	o.cancelReason = evt.Reason
+	o.updatedAt = evt.CanceledAt
+
+	return nil
+}
+
+func (o *Order) onOrderNoteAdded(evt *addOrderNoteDomainEventsV1.OrderNoteAddedV1) error {
+	o.updatedAt = evt.AddedAt
+
+	return nil
+}
+
+func (o *Order) onOrderGiftCardApplied(evt *redeemGiftCardDomainEventsV1.OrderGiftCardAppliedV1) error {
+	o.giftCardId = evt.GiftCardId
+	o.giftCardAmount = evt.Amount
+	o.updatedAt = evt.AppliedAt
+
+	return nil
+}
+
+func (o *Order) onOrderPaid(evt *fulfillOrderDomainEventsV1.OrderPaidV1) error {
+	o.paid = true
+	o.paymentId = evt.PaymentId
+	o.updatedAt = evt.PaidAt
+
+	return nil
+}
+
+func (o *Order) onOrderLegalHoldPlaced(evt *legalHoldDomainEventsV1.OrderLegalHoldPlacedV1) error {
+	o.legalHold = true
+	o.updatedAt = evt.PlacedAt
+
+	return nil
+}
+
+func (o *Order) onOrderLegalHoldReleased(evt *legalHoldDomainEventsV1.OrderLegalHoldReleasedV1) error {
+	o.legalHold = false
+	o.updatedAt = evt.ReleasedAt
+
+	return nil
+}
+
+func (o *Order) onOrderArchived(evt *archiveOrderDomainEventsV1.OrderArchivedV1) error {
+	o.archived = true
+	o.updatedAt = evt.ArchivedAt
+
+	return nil
+}
+
+func (o *Order) onOrderPersonalDataPurged(evt *purgeOrderDomainEventsV1.OrderPersonalDataPurgedV1) error {
+	o.dataPurged = true
+	o.accountEmail = ""
+	o.deliveryAddress = ""
+	o.updatedAt = evt.PurgedAt
+
+	return nil
+}
+
+// onOrderStreamContinued restores the whole state of the order, it is the first event of a split order stream
+func (o *Order) onOrderStreamContinued(evt *splitOrderStreamDomainEventsV1.OrderStreamContinuedV1) error {
+	items, err := mapper.Map[[]*value_objects.ShopItem](evt.ShopItems)
+	if err != nil {
+		return err
+	}
+
+	o.SetId(evt.OrderId)
+	o.orderNumber = evt.OrderNumber
+	o.shopItems = items
+	o.accountEmail = evt.AccountEmail
+	o.deliveryAddress = evt.DeliveryAddress
+	o.cancelReason = evt.CancelReason
+	o.deliveredTime = evt.DeliveredTime
+	o.paid = evt.Paid
+	o.submitted = evt.Submitted
+	o.completed = evt.Completed
+	o.canceled = evt.Canceled
+	o.heldForReview = evt.HeldForReview
+	o.holdReasons = evt.HoldReasons
+	o.reviewNote = evt.ReviewNote
+	o.giftCardId = evt.GiftCardId
+	o.giftCardAmount = evt.GiftCardAmount
+	o.legalHold = evt.LegalHold
+	o.archived = evt.Archived
+	o.dataPurged = evt.DataPurged
+	o.paymentId = evt.PaymentId
+	o.currency = evt.Currency
+	o.exchangeRate = evt.ExchangeRate
+	o.createdAt = evt.CreatedAt
+	o.updatedAt = evt.UpdatedAt
+
+	return nil
+}
+
+// OrderNumber is the human-readable number of the order, the orders created before the order numbers don't have it
+func (o *Order) OrderNumber() string {
+	return o.orderNumber
+}
+
 func (o *Order) ShopItems() []*value_objects.ShopItem {
 	return o.shopItems
 }
@@ -160,10 +627,31 @@ func (o *Order) CreatedAt() time.Time {
 	return o.createdAt
 }
 
+// TotalPrice is the total of the items in the currency of the order
 func (o *Order) TotalPrice() float64 {
 	return getShopItemsTotalPrice(o.shopItems)
 }
 
+// BaseTotalPrice is the total of the items in the base currency with the exchange rate of the order creation
+func (o *Order) BaseTotalPrice() float64 {
+	return o.TotalPrice() / o.ExchangeRate()
+}
+
+// Currency is the presentment currency of the order, it is empty for the orders in the base currency
+func (o *Order) Currency() string {
+	return o.currency
+}
+
+// ExchangeRate is the amount of the currency of the order for one unit of the base currency, it is 1 for the orders in
+// the base currency
+func (o *Order) ExchangeRate() float64 {
+	if o.exchangeRate <= 0 {
+		return 1
+	}
+
+	return o.exchangeRate
+}
+
 func (o *Order) Paid() bool {
 	return o.paid
 }
@@ -184,6 +672,39 @@ func (o *Order) CancelReason() string {
 	return o.cancelReason
 }
 
+// HeldForReview is true while the order is waiting for a back-office review
+func (o *Order) HeldForReview() bool {
+	return o.heldForReview
+}
+
+func (o *Order) HoldReasons() []string {
+	return o.holdReasons
+}
+
+func (o *Order) ReviewNote() string {
+	return o.reviewNote
+}
+
+func (o *Order) GiftCardId() uuid.UUID {
+	return o.giftCardId
+}
+
+func (o *Order) GiftCardAmount() float64 {
+	return o.giftCardAmount
+}
+
+func (o *Order) LegalHold() bool {
+	return o.legalHold
+}
+
+func (o *Order) Archived() bool {
+	return o.archived
+}
+
+func (o *Order) PersonalDataPurged() bool {
+	return o.dataPurged
+}
+
 func (o *Order) String() string {
 	j, _ := json.Marshal(o)
 	return string(j)
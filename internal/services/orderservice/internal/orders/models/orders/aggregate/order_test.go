@@ -0,0 +1,70 @@
+package aggregate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/domain"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	addOrderNoteDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/adding_order_note/v1/events/domain_events"
+	archiveOrderDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/archiving_order/v1/events/domain_events"
+	fulfillOrderDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/fulfilling_order/v1/events/domain_events"
+	purgeOrderDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/purging_order_personal_data/v1/events/domain_events"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestOrder() *Order {
+	order := &Order{}
+	order.NewEmptyAggregate()
+	order.SetId(uuid.NewV4())
+
+	return order
+}
+
+func Test_Order_Notes_Commute_With_The_Updates_Except_The_Archive_And_The_Purge(t *testing.T) {
+	t.Parallel()
+
+	note, err := addOrderNoteDomainEventsV1.NewOrderNoteAddedV1(uuid.NewV4(), "called the customer", "admin", time.Now())
+	require.NoError(t, err)
+	paid, err := fulfillOrderDomainEventsV1.NewOrderPaidV1(uuid.NewV4(), 10, 0, time.Now())
+	require.NoError(t, err)
+	archived, err := archiveOrderDomainEventsV1.NewOrderArchivedV1("orders/1.json", time.Now())
+	require.NoError(t, err)
+	purged, err := purgeOrderDomainEventsV1.NewOrderPersonalDataPurgedV1("retention", time.Now())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		concurrent  domain.IDomainEvent
+		uncommitted domain.IDomainEvent
+		commutes    bool
+	}{
+		{name: "note after note", concurrent: note, uncommitted: note, commutes: true},
+		{name: "note after payment", concurrent: paid, uncommitted: note, commutes: true},
+		{name: "payment after note", concurrent: note, uncommitted: paid, commutes: true},
+		{name: "payment after payment", concurrent: paid, uncommitted: paid},
+		{name: "note after archive", concurrent: archived, uncommitted: note},
+		{name: "note after purge", concurrent: purged, uncommitted: note},
+		{name: "archive after note", concurrent: note, uncommitted: archived},
+		{name: "purge after note", concurrent: note, uncommitted: purged},
+	}
+
+	order := newTestOrder()
+	for _, test := range tests {
+		assert.Equal(t, test.commutes, order.Commutes(test.concurrent, test.uncommitted), test.name)
+	}
+}
+
+func Test_Order_Rejects_The_Notes_Of_An_Archived_Order(t *testing.T) {
+	t.Parallel()
+
+	order := newTestOrder()
+	require.NoError(t, order.AddInternalNote(uuid.NewV4(), "called the customer", "admin", time.Now()))
+	require.NoError(t, order.Archive("orders/1.json", time.Now()))
+
+	err := order.AddInternalNote(uuid.NewV4(), "called the customer again", "admin", time.Now())
+	assert.True(t, customErrors.IsConflictError(err))
+}
@@ -0,0 +1,15 @@
+package read_models
+
+import "time"
+
+// OrderNoteReadModel is an internal back-office note of the order
+type OrderNoteReadModel struct {
+	NoteId  string    `json:"noteId"  bson:"noteId"`
+	Text    string    `json:"text"    bson:"text"`
+	Author  string    `json:"author"  bson:"author,omitempty"`
+	AddedAt time.Time `json:"addedAt" bson:"addedAt"`
+}
+
+func NewOrderNoteReadModel(noteId string, text string, author string, addedAt time.Time) *OrderNoteReadModel {
+	return &OrderNoteReadModel{NoteId: noteId, Text: text, Author: author, AddedAt: addedAt}
+}
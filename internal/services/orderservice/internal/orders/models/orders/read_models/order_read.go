@@ -8,25 +8,45 @@ import (
 
 type OrderReadModel struct {
 	// we generate id ourself because auto generate mongo string id column with type _id is not an uuid
-	Id              string               `json:"id"                        bson:"_id,omitempty"` // https://www.mongodb.com/docs/drivers/go/current/fundamentals/crud/write-operations/insert/#the-_id-field
-	OrderId         string               `json:"orderId"                   bson:"orderId,omitempty"`
-	ShopItems       []*ShopItemReadModel `json:"shopItems,omitempty"       bson:"shopItems,omitempty"`
-	AccountEmail    string               `json:"accountEmail,omitempty"    bson:"accountEmail,omitempty"`
-	DeliveryAddress string               `json:"deliveryAddress,omitempty" bson:"deliveryAddress,omitempty"`
-	CancelReason    string               `json:"cancelReason,omitempty"    bson:"cancelReason,omitempty"`
-	TotalPrice      float64              `json:"totalPrice,omitempty"      bson:"totalPrice,omitempty"`
-	DeliveredTime   time.Time            `json:"deliveredTime,omitempty"   bson:"deliveredTime,omitempty"`
-	Paid            bool                 `json:"paid,omitempty"            bson:"paid,omitempty"`
-	Submitted       bool                 `json:"submitted,omitempty"       bson:"submitted,omitempty"`
-	Completed       bool                 `json:"completed,omitempty"       bson:"completed,omitempty"`
-	Canceled        bool                 `json:"canceled,omitempty"        bson:"canceled,omitempty"`
-	PaymentId       string               `json:"paymentId"                 bson:"paymentId,omitempty"`
-	CreatedAt       time.Time            `json:"createdAt,omitempty"       bson:"createdAt,omitempty"`
-	UpdatedAt       time.Time            `json:"updatedAt,omitempty"       bson:"updatedAt,omitempty"`
+	Id              string                `json:"id"                        bson:"_id,omitempty"` // https://www.mongodb.com/docs/drivers/go/current/fundamentals/crud/write-operations/insert/#the-_id-field
+	OrderId         string                `json:"orderId"                   bson:"orderId,omitempty"`
+	OrderNumber     string                `json:"orderNumber,omitempty"     bson:"orderNumber,omitempty"`
+	ShopItems       []*ShopItemReadModel  `json:"shopItems,omitempty"       bson:"shopItems,omitempty"`
+	AccountEmail    string                `json:"accountEmail,omitempty"    bson:"accountEmail,omitempty"`
+	DeliveryAddress string                `json:"deliveryAddress,omitempty" bson:"deliveryAddress,omitempty"`
+	CancelReason    string                `json:"cancelReason,omitempty"    bson:"cancelReason,omitempty"`
+	TotalPrice      float64               `json:"totalPrice,omitempty"      bson:"totalPrice,omitempty"`
+	Currency        string                `json:"currency,omitempty"        bson:"currency,omitempty"`     // empty for the base currency
+	ExchangeRate    float64               `json:"exchangeRate,omitempty"    bson:"exchangeRate,omitempty"` // the amount of the currency for one unit of the base currency
+	DeliveredTime   time.Time             `json:"deliveredTime,omitempty"   bson:"deliveredTime,omitempty"`
+	Paid            bool                  `json:"paid,omitempty"            bson:"paid,omitempty"`
+	Submitted       bool                  `json:"submitted,omitempty"       bson:"submitted,omitempty"`
+	Completed       bool                  `json:"completed,omitempty"       bson:"completed,omitempty"`
+	Canceled        bool                  `json:"canceled,omitempty"        bson:"canceled,omitempty"`
+	HeldForReview   bool                  `json:"heldForReview"             bson:"heldForReview"` // not omitted, so releasing the order clears it in the `$set` update
+	HoldReasons     []string              `json:"holdReasons,omitempty"     bson:"holdReasons,omitempty"`
+	ReviewNote      string                `json:"reviewNote,omitempty"      bson:"reviewNote,omitempty"`
+	CanceledBy      string                `json:"canceledBy,omitempty"      bson:"canceledBy,omitempty"`
+	InternalNotes   []*OrderNoteReadModel `json:"internalNotes,omitempty"   bson:"internalNotes,omitempty"`
+	GiftCardId      string                `json:"giftCardId,omitempty"      bson:"giftCardId,omitempty"`
+	GiftCardAmount  float64               `json:"giftCardAmount,omitempty"  bson:"giftCardAmount,omitempty"`
+	LegalHold       bool                  `json:"legalHold"                 bson:"legalHold"`
+	LegalHoldReason string                `json:"legalHoldReason,omitempty" bson:"legalHoldReason"`
+	ArchiveLocation string                `json:"archiveLocation,omitempty" bson:"archiveLocation,omitempty"`
+	ArchivedAt      time.Time             `json:"archivedAt,omitempty"      bson:"archivedAt,omitempty"`
+	DataPurgedAt    time.Time             `json:"dataPurgedAt,omitempty"    bson:"dataPurgedAt,omitempty"`
+	PaymentId       string                `json:"paymentId"                 bson:"paymentId,omitempty"`
+	CreatedAt       time.Time             `json:"createdAt,omitempty"       bson:"createdAt,omitempty"`
+	UpdatedAt       time.Time             `json:"updatedAt,omitempty"       bson:"updatedAt,omitempty"`
+	Version         int64                 `json:"version"                   bson:"version"` // the version of the last applied event of the order stream
 }
 
+// RedactedValue replaces the purged personal data, the empty values are not set by the `$set` updates
+const RedactedValue = "[redacted]"
+
 func NewOrderReadModel(
 	orderId uuid.UUID,
+	orderNumber string,
 	items []*ShopItemReadModel,
 	accountEmail string,
 	deliveryAddress string,
@@ -37,6 +57,7 @@ func NewOrderReadModel(
 			String(),
 		// we generate id ourself because auto generate mongo string id column with type _id is not an uuid
 		OrderId:         orderId.String(),
+		OrderNumber:     orderNumber,
 		ShopItems:       items,
 		AccountEmail:    accountEmail,
 		DeliveryAddress: deliveryAddress,
@@ -46,6 +67,21 @@ func NewOrderReadModel(
 	}
 }
 
+func (o *OrderReadModel) GetVersion() int64 {
+	return o.Version
+}
+
+func (o *OrderReadModel) SetVersion(version int64) {
+	o.Version = version
+}
+
+// RedactPersonalData replaces the personal data of the order with RedactedValue
+func (o *OrderReadModel) RedactPersonalData(purgedAt time.Time) {
+	o.AccountEmail = RedactedValue
+	o.DeliveryAddress = RedactedValue
+	o.DataPurgedAt = purgedAt
+}
+
 func getShopItemsTotalPrice(shopItems []*ShopItemReadModel) float64 {
 	var totalPrice float64 = 0
 	for _, item := range shopItems {
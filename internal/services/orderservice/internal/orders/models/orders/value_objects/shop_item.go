@@ -5,6 +5,7 @@ import (
 )
 
 type ShopItem struct {
+	productId   string
 	title       string
 	description string
 	quantity    uint64
@@ -20,6 +21,26 @@ func CreateNewShopItem(title string, description string, quantity uint64, price
 	}
 }
 
+// CreateNewProductShopItem creates an item of a catalog product, the stock of the product is reserved by the order
+// fulfillment saga
+func CreateNewProductShopItem(
+	productId string,
+	title string,
+	description string,
+	quantity uint64,
+	price float64,
+) *ShopItem {
+	item := CreateNewShopItem(title, description, quantity, price)
+	item.productId = productId
+
+	return item
+}
+
+// ProductId is empty for the items which are not a catalog product
+func (s *ShopItem) ProductId() string {
+	return s.productId
+}
+
 func (s *ShopItem) Title() string {
 	return s.title
 }
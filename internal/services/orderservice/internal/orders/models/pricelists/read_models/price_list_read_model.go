@@ -0,0 +1,42 @@
+package read_models
+
+import (
+	"time"
+)
+
+// PriceListReadModel is a copy of a price list of the catalogs service, it keeps the prices of the products for a
+// customer group in its effective dates
+type PriceListReadModel struct {
+	Id            string                   `json:"id"            bson:"_id"`
+	Name          string                   `json:"name"          bson:"name"`
+	CustomerGroup string                   `json:"customerGroup" bson:"customerGroup"`
+	EffectiveFrom time.Time                `json:"effectiveFrom" bson:"effectiveFrom"`
+	EffectiveTo   *time.Time               `json:"effectiveTo"   bson:"effectiveTo,omitempty"`
+	Prices        []*ProductPriceReadModel `json:"prices"        bson:"prices"`
+	UpdatedAt     time.Time                `json:"updatedAt"     bson:"updatedAt"`
+}
+
+type ProductPriceReadModel struct {
+	ProductId string  `json:"productId" bson:"productId"`
+	Price     float64 `json:"price"     bson:"price"`
+}
+
+// IsEffectiveAt returns true when the time is in the effective dates of the price list, the end date is exclusive
+func (p *PriceListReadModel) IsEffectiveAt(t time.Time) bool {
+	if t.Before(p.EffectiveFrom) {
+		return false
+	}
+
+	return p.EffectiveTo == nil || t.Before(*p.EffectiveTo)
+}
+
+// PriceOf returns the price of the product in the price list
+func (p *PriceListReadModel) PriceOf(productId string) (float64, bool) {
+	for _, price := range p.Prices {
+		if price.ProductId == productId {
+			return price.Price, true
+		}
+	}
+
+	return 0, false
+}
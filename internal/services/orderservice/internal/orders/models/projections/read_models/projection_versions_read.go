@@ -0,0 +1,102 @@
+package read_models
+
+import (
+	"time"
+)
+
+type ProjectionVersionStatus string
+
+const (
+	// ProjectionVersionBuilding is a candidate version which is rebuilt by its own subscription from the start
+	ProjectionVersionBuilding ProjectionVersionStatus = "building"
+	// ProjectionVersionCutoverRequested is a candidate version which is activated when it catches up with the active
+	// version
+	ProjectionVersionCutoverRequested ProjectionVersionStatus = "cutover_requested"
+	// ProjectionVersionHandingOver is a caught up candidate version, its subscription is stopped and the active
+	// version projects the events until the handoff position, the candidate is activated after that position
+	ProjectionVersionHandingOver ProjectionVersionStatus = "handing_over"
+	ProjectionVersionActive      ProjectionVersionStatus = "active"
+	// ProjectionVersionRetired is a replaced version, its collection is dropped after its cleanup time
+	ProjectionVersionRetired ProjectionVersionStatus = "retired"
+)
+
+// ProjectionVersionsReadModel keeps the versions of a projection, the active version serves the reads and a candidate
+// version is built in parallel to its own collection until the cutover
+type ProjectionVersionsReadModel struct {
+	Projection string                    `json:"projection"          bson:"_id"`
+	Active     *ProjectionVersionModel   `json:"active"              bson:"active"`
+	Candidate  *ProjectionVersionModel   `json:"candidate,omitempty" bson:"candidate,omitempty"`
+	Retired    []*ProjectionVersionModel `json:"retired,omitempty"   bson:"retired,omitempty"`
+	// Revision is increased on every save, so concurrent changes of the instances are detected
+	Revision  int64     `json:"revision"  bson:"revision"`
+	UpdatedAt time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+type ProjectionVersionModel struct {
+	Version        int                     `json:"version"                  bson:"version"`
+	Collection     string                  `json:"collection"               bson:"collection"`
+	Status         ProjectionVersionStatus `json:"status"                   bson:"status"`
+	SubscriptionId string                  `json:"subscriptionId,omitempty" bson:"subscriptionId,omitempty"`
+	// HandoffPosition is the last position of the candidate subscription, the events after it are projected to the
+	// candidate by the subscription of the active version
+	HandoffPosition    uint64     `json:"handoffPosition,omitempty"    bson:"handoffPosition,omitempty"`
+	StartedBy          string     `json:"startedBy,omitempty"          bson:"startedBy,omitempty"`
+	CutoverRequestedBy string     `json:"cutoverRequestedBy,omitempty" bson:"cutoverRequestedBy,omitempty"`
+	CreatedAt          time.Time  `json:"createdAt"                    bson:"createdAt"`
+	ActivatedAt        *time.Time `json:"activatedAt,omitempty"        bson:"activatedAt,omitempty"`
+	RetiredAt          *time.Time `json:"retiredAt,omitempty"          bson:"retiredAt,omitempty"`
+	CleanupAt          *time.Time `json:"cleanupAt,omitempty"          bson:"cleanupAt,omitempty"`
+	CleanedUp          bool       `json:"cleanedUp,omitempty"          bson:"cleanedUp,omitempty"`
+}
+
+// NewProjectionVersionsReadModel creates the versions of a projection which is served by its first version
+func NewProjectionVersionsReadModel(projection string, collection string) *ProjectionVersionsReadModel {
+	return &ProjectionVersionsReadModel{
+		Projection: projection,
+		Active: &ProjectionVersionModel{
+			Version:    1,
+			Collection: collection,
+			Status:     ProjectionVersionActive,
+		},
+	}
+}
+
+// NextVersion returns the version of a new candidate, the versions are never reused, so a new candidate doesn't
+// continue the subscription of an aborted one
+func (p *ProjectionVersionsReadModel) NextVersion() int {
+	next := p.Active.Version + 1
+	if p.Candidate != nil && p.Candidate.Version >= next {
+		next = p.Candidate.Version + 1
+	}
+	for _, retired := range p.Retired {
+		if retired.Version >= next {
+			next = retired.Version + 1
+		}
+	}
+
+	return next
+}
+
+// Clone copies the versions, the cached versions are replaced with a changed clone, so their readers don't see a
+// partial change
+func (p *ProjectionVersionsReadModel) Clone() *ProjectionVersionsReadModel {
+	clone := *p
+	clone.Active = p.Active.clone()
+	clone.Candidate = p.Candidate.clone()
+	clone.Retired = make([]*ProjectionVersionModel, 0, len(p.Retired))
+	for _, retired := range p.Retired {
+		clone.Retired = append(clone.Retired, retired.clone())
+	}
+
+	return &clone
+}
+
+func (v *ProjectionVersionModel) clone() *ProjectionVersionModel {
+	if v == nil {
+		return nil
+	}
+
+	clone := *v
+
+	return &clone
+}
@@ -0,0 +1,40 @@
+package read_models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Next_Version_Skips_Used_Versions(t *testing.T) {
+	t.Parallel()
+
+	versions := NewProjectionVersionsReadModel("orders", "orders")
+	assert.Equal(t, 2, versions.NextVersion())
+
+	// an aborted candidate is retired, so its subscription is not continued by a new candidate
+	versions.Retired = append(versions.Retired, &ProjectionVersionModel{Version: 3, Status: ProjectionVersionRetired})
+	assert.Equal(t, 4, versions.NextVersion())
+
+	versions.Candidate = &ProjectionVersionModel{Version: 4, Status: ProjectionVersionBuilding}
+	assert.Equal(t, 5, versions.NextVersion())
+}
+
+func Test_Clone_Does_Not_Share_Versions(t *testing.T) {
+	t.Parallel()
+
+	versions := NewProjectionVersionsReadModel("orders", "orders")
+	versions.Candidate = &ProjectionVersionModel{Version: 2, Status: ProjectionVersionBuilding}
+	versions.Retired = []*ProjectionVersionModel{{Version: 0, Status: ProjectionVersionRetired}}
+
+	clone := versions.Clone()
+	clone.Candidate.Status = ProjectionVersionCutoverRequested
+	clone.Active.Status = ProjectionVersionRetired
+	clone.Retired[0].CleanedUp = true
+	clone.Retired = append(clone.Retired, clone.Active)
+
+	assert.Equal(t, ProjectionVersionBuilding, versions.Candidate.Status)
+	assert.Equal(t, ProjectionVersionActive, versions.Active.Status)
+	assert.False(t, versions.Retired[0].CleanedUp)
+	assert.Len(t, versions.Retired, 1)
+}
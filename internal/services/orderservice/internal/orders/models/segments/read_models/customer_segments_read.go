@@ -0,0 +1,72 @@
+package read_models
+
+import (
+	"strings"
+	"time"
+)
+
+// CustomerSegmentsReadModel keeps the orders of a customer and its derived segments, the id is the normalized
+// account email
+type CustomerSegmentsReadModel struct {
+	Id            string                    `json:"id"            bson:"_id"`
+	AccountEmail  string                    `json:"accountEmail"  bson:"accountEmail"`
+	Orders        []*CustomerOrderReadModel `json:"orders"        bson:"orders"`
+	OrdersCount   int                       `json:"ordersCount"   bson:"ordersCount"`
+	LifetimeValue float64                   `json:"lifetimeValue" bson:"lifetimeValue"`
+	Segments      []string                  `json:"segments"      bson:"segments"`
+	FirstOrderAt  time.Time                 `json:"firstOrderAt"  bson:"firstOrderAt"`
+	LastOrderAt   time.Time                 `json:"lastOrderAt"   bson:"lastOrderAt"`
+	UpdatedAt     time.Time                 `json:"updatedAt"     bson:"updatedAt"`
+}
+
+type CustomerOrderReadModel struct {
+	OrderId    string    `json:"orderId"    bson:"orderId"`
+	TotalPrice float64   `json:"totalPrice" bson:"totalPrice"`
+	Canceled   bool      `json:"canceled"   bson:"canceled"`
+	CreatedAt  time.Time `json:"createdAt"  bson:"createdAt"`
+}
+
+func NewCustomerSegmentsReadModel(accountEmail string) *CustomerSegmentsReadModel {
+	return &CustomerSegmentsReadModel{
+		Id:           CustomerId(accountEmail),
+		AccountEmail: accountEmail,
+	}
+}
+
+// CustomerId normalizes the account email, so the orders of the same customer with a different email case are
+// grouped together
+func CustomerId(accountEmail string) string {
+	return strings.ToLower(strings.TrimSpace(accountEmail))
+}
+
+// AddOrder adds the order if it is not added before, so replaying an order event doesn't count it twice
+func (c *CustomerSegmentsReadModel) AddOrder(orderId string, totalPrice float64, createdAt time.Time) bool {
+	for _, order := range c.Orders {
+		if order.OrderId == orderId {
+			return false
+		}
+	}
+
+	c.Orders = append(c.Orders, &CustomerOrderReadModel{OrderId: orderId, TotalPrice: totalPrice, CreatedAt: createdAt})
+	if c.FirstOrderAt.IsZero() || createdAt.Before(c.FirstOrderAt) {
+		c.FirstOrderAt = createdAt
+	}
+	if createdAt.After(c.LastOrderAt) {
+		c.LastOrderAt = createdAt
+	}
+
+	return true
+}
+
+// CancelOrder marks the order as canceled, it returns false for an unknown or an already canceled order
+func (c *CustomerSegmentsReadModel) CancelOrder(orderId string) bool {
+	for _, order := range c.Orders {
+		if order.OrderId == orderId && !order.Canceled {
+			order.Canceled = true
+
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,59 @@
+package numbering
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/sequence"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/tenant"
+
+	"emperror.dev/errors"
+)
+
+const sequencePrefix = "orders-"
+
+// OrderNumberGenerator generates the human-readable numbers of the orders, each prefix has its own sequence, so the
+// order numbers are unique while the orders are identified by their uuids. a number of a failed order creation is not
+// reused, so the numbers can have gaps.
+type OrderNumberGenerator struct {
+	options   *OrderNumberOptions
+	sequences sequence.SequenceGenerator
+}
+
+func NewOrderNumberGenerator(
+	options *OrderNumberOptions,
+	sequences sequence.SequenceGenerator,
+) *OrderNumberGenerator {
+	return &OrderNumberGenerator{options: options, sequences: sequences}
+}
+
+// Next returns the next order number of the tenant of the context
+func (g *OrderNumberGenerator) Next(ctx context.Context) (string, error) {
+	prefix := g.prefix(ctx)
+
+	number, err := g.sequences.Next(ctx, sequencePrefix+prefix)
+	if err != nil {
+		return "", errors.WrapIf(err, "error in generating the order number")
+	}
+
+	return FormatOrderNumber(prefix, number, g.options.Padding), nil
+}
+
+func (g *OrderNumberGenerator) prefix(ctx context.Context) string {
+	if tenantId, ok := tenant.GetTenantId(ctx); ok {
+		// the keys of the config maps are lower cased by the config loader
+		for id, prefix := range g.options.TenantPrefixes {
+			if strings.EqualFold(id, tenantId) && prefix != "" {
+				return prefix
+			}
+		}
+	}
+
+	return g.options.Prefix
+}
+
+// FormatOrderNumber returns the order number of the prefix and the sequence number, e.g. `ORD-000042`
+func FormatOrderNumber(prefix string, number int64, padding int) string {
+	return fmt.Sprintf("%s-%0*d", prefix, padding, number)
+}
@@ -0,0 +1,68 @@
+package numbering
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/sequence"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/tenant"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var defaultOptions = &OrderNumberOptions{
+	Prefix:         "ORD",
+	TenantPrefixes: map[string]string{"acme": "ACME", "acme-eu": "ACME"},
+	Padding:        6,
+}
+
+func Test_FormatOrderNumber(t *testing.T) {
+	assert.Equal(t, "ORD-000042", FormatOrderNumber("ORD", 42, 6))
+	assert.Equal(t, "ORD-1234567", FormatOrderNumber("ORD", 1234567, 6))
+	assert.Equal(t, "ORD-7", FormatOrderNumber("ORD", 7, 0))
+}
+
+func Test_Next_Uses_Default_Prefix_Without_Tenant(t *testing.T) {
+	generator := NewOrderNumberGenerator(defaultOptions, sequence.NewInMemorySequenceGenerator())
+
+	first, err := generator.Next(context.Background())
+	require.NoError(t, err)
+	second, err := generator.Next(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "ORD-000001", first)
+	assert.Equal(t, "ORD-000002", second)
+}
+
+func Test_Next_Uses_Prefix_Of_Tenant(t *testing.T) {
+	generator := NewOrderNumberGenerator(defaultOptions, sequence.NewInMemorySequenceGenerator())
+
+	number, err := generator.Next(tenantContext("ACME"))
+	require.NoError(t, err)
+	assert.Equal(t, "ACME-000001", number)
+
+	// the tenants without their own prefix share the sequence of the default prefix
+	number, err = generator.Next(tenantContext("globex"))
+	require.NoError(t, err)
+	assert.Equal(t, "ORD-000001", number)
+}
+
+func Test_Next_Shares_Sequence_Of_Tenants_With_Same_Prefix(t *testing.T) {
+	generator := NewOrderNumberGenerator(defaultOptions, sequence.NewInMemorySequenceGenerator())
+
+	first, err := generator.Next(tenantContext("acme"))
+	require.NoError(t, err)
+	second, err := generator.Next(tenantContext("acme-eu"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "ACME-000001", first)
+	assert.Equal(t, "ACME-000002", second)
+}
+
+func tenantContext(tenantId string) context.Context {
+	ctx := tenant.NewContext(context.Background())
+	tenant.SetTenantId(ctx, tenantId)
+
+	return ctx
+}
@@ -0,0 +1,25 @@
+package numbering
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[OrderNumberOptions]())
+
+// OrderNumberOptions controls the human-readable order numbers, e.g. `ORD-000042`
+type OrderNumberOptions struct {
+	// Prefix is the prefix of the orders without a tenant or of the tenants without their own prefix
+	Prefix string `mapstructure:"prefix"         default:"ORD"`
+	// TenantPrefixes are the prefixes of the tenants by their ids, the tenants with the same prefix share its sequence
+	TenantPrefixes map[string]string `mapstructure:"tenantPrefixes"`
+	// Padding is the minimum number of the digits of an order number, the shorter numbers are padded with zeros
+	Padding int `mapstructure:"padding"        default:"6"`
+}
+
+func NewOrderNumberOptions(environment environment.Environment) (*OrderNumberOptions, error) {
+	return config.BindConfigKey[*OrderNumberOptions](optionName, environment)
+}
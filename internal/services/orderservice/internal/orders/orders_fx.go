@@ -5,12 +5,50 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb"
 	echocontracts "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/contracts"
+	apikey "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/api_key"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/saga"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/backoffice"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/dashboard"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/data/repositories"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/drafts"
+	activateGiftCardV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/activating_gift_card/v1/endpoints"
+	addOrderNoteV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/adding_order_note/v1/endpoints"
+	cancelOrderV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/canceling_order/v1/endpoints"
+	convertOrderDraftV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/converting_order_draft/v1/endpoints"
 	createOrderV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/creating_order/v1/endpoints"
+	createOrderDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/creating_order/v1/events/domain_events"
+	exportFinanceEntriesV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/exporting_finance_entries/v1/endpoints"
+	getCommandStatusV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_command_status/v1/endpoints"
+	getCustomerSegmentsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_customer_segments/v1/endpoints"
+	getGiftCardBalanceV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_gift_card_balance/v1/endpoints"
 	getOrderByIdV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_order_by_id/v1/endpoints"
+	getOrderDraftV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_order_draft/v1/endpoints"
+	getOrderEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_order_events/v1/endpoints"
 	getOrdersV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_orders/v1/endpoints"
+	getOrdersByIdsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_orders_by_ids/v1/endpoints"
+	getSegmentCustomersV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_segment_customers/v1/endpoints"
+	legalHoldV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/holding_order_legally/v1/endpoints"
+	issueGiftCardV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/issuing_gift_card/v1/endpoints"
+	projectionRebuildsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/rebuilding_projections/v1/endpoints"
+	resendOrderConfirmationV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/resending_order_confirmation/v1/endpoints"
+	reviewOrderV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/reviewing_order/v1/endpoints"
+	saveOrderDraftV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/saving_order_draft/v1/endpoints"
+	searchOrdersV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/searching_orders/v1/endpoints"
+	splitOrderStreamV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/splitting_order_stream/v1/endpoints"
+	streamOperationsDashboardV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/streaming_operations_dashboard/v1/endpoints"
+	orderProjectionVersionsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/versioning_order_projection/v1/endpoints"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/finance"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/fraud"
+	draftAggregate "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/drafts/aggregate"
+	giftCardAggregate "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/giftcards/aggregate"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/aggregate"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/numbering"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/pricing"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/projections"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/projections/versioning"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/retention"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/sagas"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/segments"
 
 	"github.com/labstack/echo/v4"
 	"go.uber.org/fx"
@@ -22,8 +60,61 @@ var Module = fx.Module(
 	// Other provides
 	fx.Provide(fx.Annotate(repositories.NewMongoOrderReadRepository)),
 	fx.Provide(repositories.NewElasticOrderReadRepository),
+	fx.Provide(repositories.NewMongoCustomerSegmentsRepository),
+	fx.Invoke(repositories.RegisterMongoCustomerSegmentsIndexes),
+	fx.Provide(segments.NewSegmentOptions),
+	fx.Provide(repositories.NewMongoPriceListRepository),
+	fx.Invoke(repositories.RegisterMongoPriceListsIndexes),
+	fx.Provide(pricing.NewPriceResolver),
+	fx.Provide(retention.NewRetentionOptions),
+	fx.Provide(repositories.NewMongoOrderRetentionRepository),
+	fx.Provide(retention.NewFileArchiveStore),
+	fx.Provide(retention.NewRetentionWorker),
+	fx.Invoke(retention.RegisterRetentionWorker),
+	fx.Provide(repositories.NewMongoFinanceEntryRepository),
+	fx.Invoke(repositories.RegisterMongoFinanceEntriesIndexes),
+	fx.Provide(finance.NewFinanceExportOptions),
+	fx.Provide(finance.NewErpRecordMapper),
+	fx.Provide(finance.NewErpExporter),
+	fx.Provide(finance.NewFinanceExportWorker),
+	fx.Invoke(finance.RegisterFinanceExportWorker),
+	fx.Provide(repositories.NewMongoProjectionVersionsRepository),
+	fx.Provide(versioning.NewProjectionVersioningOptions),
+	fx.Provide(versioning.NewOrderProjectionVersioning),
+	// the served reads of the orders follow the active version of the orders projection
+	fx.Provide(func(projectionVersioning *versioning.OrderProjectionVersioning) repositories.OrderCollectionFunc {
+		return projectionVersioning.ActiveCollection
+	}),
+	fx.Invoke(versioning.RegisterProjectionVersioningWorker),
+	fx.Provide(fraud.NewFraudOptions),
+	fx.Provide(fraud.NewRulesFraudScreener),
+	fx.Provide(numbering.NewOrderNumberOptions),
+	fx.Provide(numbering.NewOrderNumberGenerator),
+	fx.Provide(backoffice.NewBackOfficeOptions),
+	fx.Provide(drafts.NewOrderDraftOptions),
+	fx.Provide(repositories.NewMongoOrderDraftRepository),
+	fx.Provide(dashboard.NewDashboardOptions),
+	fx.Provide(dashboard.NewOperationsDashboard),
+	fx.Invoke(repositories.RegisterMongoOrderDraftsIndexes),
+	fx.Provide(sagas.NewOrderFulfillmentOrchestrator),
+	// the timed out order fulfillment sagas are compensated by the timeout worker of the saga module
+	fx.Provide(saga.AsOrchestration(
+		func(orchestrator sagas.OrderFulfillmentOrchestrator) sagas.OrderFulfillmentOrchestrator {
+			return orchestrator
+		},
+	)),
+	// the user of the back-office requests is added to the metadata of their events
+	fx.Provide(fx.Annotate(
+		func() eventstroredb.MetadataEnricher { return eventstroredb.NewUserMetadataEnricher(apikey.UserId) },
+		fx.ResultTags(`group:"esdbMetadataEnrichers"`),
+	)),
+	// the stored events of the old schema versions are upcasted to their current structs
+	fx.Provide(es.AsUpcaster(createOrderDomainEventsV1.NewOrderCreatedV1Upcaster)),
 
 	fx.Provide(eventstroredb.NewEventStoreAggregateStore[*aggregate.Order]),
+	fx.Provide(eventstroredb.NewEventStoreAggregateStore[*giftCardAggregate.GiftCard]),
+	fx.Provide(eventstroredb.NewEventStoreAggregateStore[*draftAggregate.OrderDraft]),
+	fx.Provide(eventstroredb.NewEventStoreAggregateStreamSplitter[*aggregate.Order]),
 	fx.Provide(fx.Annotate(func(catalogsServer echocontracts.EchoHttpServer) *echo.Group {
 		var g *echo.Group
 		catalogsServer.RouteBuilder().RegisterGroupFunc("/api/v1", func(v1 *echo.Group) {
@@ -33,15 +124,76 @@ var Module = fx.Module(
 
 		return g
 	}, fx.ResultTags(`name:"order-echo-group"`))),
+	fx.Provide(fx.Annotate(func(ordersServer echocontracts.EchoHttpServer) *echo.Group {
+		var g *echo.Group
+		ordersServer.RouteBuilder().RegisterGroupFunc("/api/v1", func(v1 *echo.Group) {
+			g = v1.Group("/giftcards")
+		})
+
+		return g
+	}, fx.ResultTags(`name:"giftcard-echo-group"`))),
+	fx.Provide(fx.Annotate(func(ordersServer echocontracts.EchoHttpServer) *echo.Group {
+		var g *echo.Group
+		ordersServer.RouteBuilder().RegisterGroupFunc("/api/v1", func(v1 *echo.Group) {
+			g = v1.Group("/order-drafts")
+		})
+
+		return g
+	}, fx.ResultTags(`name:"order-draft-echo-group"`))),
+	fx.Provide(fx.Annotate(backoffice.NewBackOfficeOrdersGroup, fx.ResultTags(`name:"backoffice-order-echo-group"`))),
+	fx.Provide(
+		fx.Annotate(backoffice.NewBackOfficeCustomersGroup, fx.ResultTags(`name:"backoffice-customer-echo-group"`)),
+		fx.Annotate(backoffice.NewBackOfficeGiftCardsGroup, fx.ResultTags(`name:"backoffice-giftcard-echo-group"`)),
+		fx.Annotate(backoffice.NewBackOfficeProjectionsGroup, fx.ResultTags(`name:"backoffice-projection-echo-group"`)),
+		fx.Annotate(backoffice.NewBackOfficeFinanceGroup, fx.ResultTags(`name:"backoffice-finance-echo-group"`)),
+	),
 
 	fx.Provide(
 		route.AsRoute(createOrderV1.NewCreteOrderEndpoint, "order-routes"),
 		route.AsRoute(getOrderByIdV1.NewGetOrderByIdEndpoint, "order-routes"),
+		route.AsRoute(getOrdersByIdsV1.NewGetOrdersByIdsEndpoint, "order-routes"),
+		route.AsRoute(getCommandStatusV1.NewGetCommandStatusEndpoint, "order-routes"),
 		route.AsRoute(getOrdersV1.NewGetOrdersEndpoint, "order-routes"),
+		route.AsRoute(reviewOrderV1.NewApproveOrderReviewEndpoint, "order-routes"),
+		route.AsRoute(reviewOrderV1.NewRejectOrderReviewEndpoint, "order-routes"),
+		route.AsRoute(searchOrdersV1.NewSearchOrdersEndpoint, "order-routes"),
+		route.AsRoute(cancelOrderV1.NewForceCancelOrderEndpoint, "order-routes"),
+		route.AsRoute(addOrderNoteV1.NewAddOrderNoteEndpoint, "order-routes"),
+		route.AsRoute(resendOrderConfirmationV1.NewResendOrderConfirmationEndpoint, "order-routes"),
+		route.AsRoute(getOrderEventsV1.NewGetOrderEventsEndpoint, "order-routes"),
+		route.AsRoute(getCustomerSegmentsV1.NewGetCustomerSegmentsEndpoint, "order-routes"),
+		route.AsRoute(getSegmentCustomersV1.NewGetSegmentCustomersEndpoint, "order-routes"),
+		route.AsRoute(issueGiftCardV1.NewIssueGiftCardEndpoint, "order-routes"),
+		route.AsRoute(activateGiftCardV1.NewActivateGiftCardEndpoint, "order-routes"),
+		route.AsRoute(getGiftCardBalanceV1.NewGetGiftCardBalanceEndpoint, "order-routes"),
+		route.AsRoute(legalHoldV1.NewPlaceLegalHoldEndpoint, "order-routes"),
+		route.AsRoute(legalHoldV1.NewReleaseLegalHoldEndpoint, "order-routes"),
+		route.AsRoute(splitOrderStreamV1.NewSplitOrderStreamEndpoint, "order-routes"),
+		route.AsRoute(splitOrderStreamV1.NewGetOrderStreamSegmentsEndpoint, "order-routes"),
+		route.AsRoute(orderProjectionVersionsV1.NewGetOrderProjectionVersionsEndpoint, "order-routes"),
+		route.AsRoute(orderProjectionVersionsV1.NewStartOrderProjectionVersionEndpoint, "order-routes"),
+		route.AsRoute(orderProjectionVersionsV1.NewRequestOrderProjectionCutoverEndpoint, "order-routes"),
+		route.AsRoute(orderProjectionVersionsV1.NewAbortOrderProjectionVersionEndpoint, "order-routes"),
+		route.AsRoute(projectionRebuildsV1.NewGetProjectionRebuildsEndpoint, "order-routes"),
+		route.AsRoute(projectionRebuildsV1.NewStartProjectionRebuildEndpoint, "order-routes"),
+		route.AsRoute(projectionRebuildsV1.NewCancelProjectionRebuildEndpoint, "order-routes"),
+		route.AsRoute(saveOrderDraftV1.NewSaveOrderDraftEndpoint, "order-routes"),
+		route.AsRoute(getOrderDraftV1.NewGetOrderDraftEndpoint, "order-routes"),
+		route.AsRoute(convertOrderDraftV1.NewConvertOrderDraftEndpoint, "order-routes"),
+		route.AsRoute(exportFinanceEntriesV1.NewGetFinanceEntriesEndpoint, "order-routes"),
+		route.AsRoute(exportFinanceEntriesV1.NewReplayFinanceEntriesEndpoint, "order-routes"),
+		route.AsRoute(streamOperationsDashboardV1.NewStreamOperationsDashboardEndpoint, "order-routes"),
 	),
 
 	fx.Provide(
 		es.AsProjection(projections.NewElasticOrderProjection),
-		es.AsProjection(projections.NewMongoOrderProjection),
+		// the mongo orders projection is blue/green versioned, its active version is selected per event
+		es.AsProjection(versioning.NewVersionedMongoOrderProjection),
+		es.AsProjection(projections.NewMongoCustomerSegmentsProjection),
+		es.AsProjection(projections.NewGiftCardCompensationProjection),
+		es.AsProjection(projections.NewMongoOrderDraftProjection),
+		es.AsProjection(projections.NewOrderFulfillmentProjection),
+		es.AsProjection(projections.NewFinanceEntryProjection),
+		es.AsProjection(dashboard.NewOperationsDashboardProjection),
 	),
 )
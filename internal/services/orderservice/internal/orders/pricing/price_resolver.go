@@ -0,0 +1,125 @@
+package pricing
+
+import (
+	"context"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/exchangerates"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/repositories"
+	dtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/dtos/v1"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/pricelists/read_models"
+
+	"emperror.dev/errors"
+)
+
+// PriceResolver resolves the prices of the order items from the price lists of the catalogs service, the price lists
+// are kept in the orders service by the PriceListCreatedV1 consumer.
+type PriceResolver interface {
+	// Presentment returns the exchange rate of the presentment currency, the empty currency and the base currency
+	// present the prices in the base currency
+	Presentment(currency string) (*Presentment, error)
+	// ResolvePrices replaces the prices of the items which have a product id with their prices in the effective price
+	// lists of the customer group converted to the presentment currency, the other items keep their prices
+	ResolvePrices(
+		ctx context.Context,
+		customerGroup string,
+		presentment *Presentment,
+		shopItems []*dtosV1.ShopItemDto,
+		at time.Time,
+	) error
+}
+
+// Presentment is the currency the prices of an order are presented in, the prices of the price lists are in the base
+// currency and are converted with the exchange rate
+type Presentment struct {
+	// Currency is empty for the base currency
+	Currency     string
+	ExchangeRate float64
+}
+
+// BasePresentment presents the prices in the base currency without a conversion
+var BasePresentment = &Presentment{ExchangeRate: 1} //nolint:gochecknoglobals
+
+type priceResolver struct {
+	priceListRepository repositories.PriceListRepository
+	exchangeRates       exchangerates.ExchangeRates
+}
+
+func NewPriceResolver(
+	priceListRepository repositories.PriceListRepository,
+	exchangeRates exchangerates.ExchangeRates,
+) PriceResolver {
+	return &priceResolver{priceListRepository: priceListRepository, exchangeRates: exchangeRates}
+}
+
+func (p *priceResolver) Presentment(currency string) (*Presentment, error) {
+	currency = exchangerates.NormalizeCurrency(currency)
+	if currency == "" || currency == p.exchangeRates.BaseCurrency() {
+		return BasePresentment, nil
+	}
+
+	rate, err := p.exchangeRates.Rate(p.exchangeRates.BaseCurrency(), currency)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "error in getting the exchange rate of currency '%s'", currency)
+	}
+
+	return &Presentment{Currency: currency, ExchangeRate: rate}, nil
+}
+
+func (p *priceResolver) ResolvePrices(
+	ctx context.Context,
+	customerGroup string,
+	presentment *Presentment,
+	shopItems []*dtosV1.ShopItemDto,
+	at time.Time,
+) error {
+	if customerGroup == "" {
+		return nil
+	}
+
+	if presentment == nil {
+		presentment = BasePresentment
+	}
+
+	priceLists, err := p.priceListRepository.GetEffectivePriceLists(ctx, customerGroup, at)
+	if err != nil {
+		return errors.WithMessage(err, "error in getting the effective price lists")
+	}
+
+	for _, item := range shopItems {
+		if item == nil || item.ProductId == "" {
+			continue
+		}
+
+		if price, ok := ResolvePrice(priceLists, item.ProductId, at); ok {
+			item.Price = price * presentment.ExchangeRate
+		}
+	}
+
+	return nil
+}
+
+// ResolvePrice returns the price of the product in the effective price lists at the time, the price list with the
+// latest effective from wins, the same as the price resolution of the catalogs service
+func ResolvePrice(priceLists []*read_models.PriceListReadModel, productId string, at time.Time) (float64, bool) {
+	var resolved *read_models.PriceListReadModel
+	var resolvedPrice float64
+
+	for _, priceList := range priceLists {
+		if !priceList.IsEffectiveAt(at) {
+			continue
+		}
+
+		price, ok := priceList.PriceOf(productId)
+		if !ok {
+			continue
+		}
+
+		if resolved == nil || priceList.EffectiveFrom.After(resolved.EffectiveFrom) {
+			resolved = priceList
+			resolvedPrice = price
+		}
+	}
+
+	return resolvedPrice, resolved != nil
+}
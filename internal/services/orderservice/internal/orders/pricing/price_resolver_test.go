@@ -0,0 +1,146 @@
+package pricing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/exchangerates"
+	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+	dtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/dtos/v1"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/pricelists/read_models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePriceListRepository struct {
+	priceLists []*read_models.PriceListReadModel
+}
+
+func (f *fakePriceListRepository) GetEffectivePriceLists(
+	_ context.Context,
+	customerGroup string,
+	at time.Time,
+) ([]*read_models.PriceListReadModel, error) {
+	var result []*read_models.PriceListReadModel
+	for _, priceList := range f.priceLists {
+		if priceList.CustomerGroup == customerGroup && priceList.IsEffectiveAt(at) {
+			result = append(result, priceList)
+		}
+	}
+
+	return result, nil
+}
+
+func (f *fakePriceListRepository) SavePriceList(_ context.Context, priceList *read_models.PriceListReadModel) error {
+	f.priceLists = append(f.priceLists, priceList)
+
+	return nil
+}
+
+type stubRatesProvider struct {
+	rates map[string]float64
+}
+
+func (s *stubRatesProvider) Name() string {
+	return "stub"
+}
+
+func (s *stubRatesProvider) Rates(_ context.Context, _ string) (map[string]float64, error) {
+	return s.rates, nil
+}
+
+func newExchangeRates(t *testing.T) exchangerates.ExchangeRates {
+	t.Helper()
+
+	rates := exchangerates.NewExchangeRates(
+		&exchangerates.ExchangeRateOptions{BaseCurrency: "USD"},
+		defaultLogger.GetLogger(),
+		&stubRatesProvider{rates: map[string]float64{"EUR": 0.5}},
+	)
+	require.NoError(t, rates.Refresh(context.Background()))
+
+	return rates
+}
+
+func newPriceList(
+	customerGroup string,
+	effectiveFrom time.Time,
+	effectiveTo *time.Time,
+	productId string,
+	price float64,
+) *read_models.PriceListReadModel {
+	return &read_models.PriceListReadModel{
+		CustomerGroup: customerGroup,
+		EffectiveFrom: effectiveFrom,
+		EffectiveTo:   effectiveTo,
+		Prices:        []*read_models.ProductPriceReadModel{{ProductId: productId, Price: price}},
+	}
+}
+
+func Test_Resolve_Prices_Uses_The_Effective_Price_Lists_Of_The_Customer_Group(t *testing.T) {
+	now := time.Now()
+	promotionEnd := now.Add(time.Hour)
+
+	resolver := NewPriceResolver(&fakePriceListRepository{priceLists: []*read_models.PriceListReadModel{
+		newPriceList("wholesale", now.AddDate(0, -1, 0), nil, "product-1", 90),
+		newPriceList("wholesale", now.Add(-time.Hour), &promotionEnd, "product-1", 70),
+		newPriceList("retail", now.AddDate(0, -1, 0), nil, "product-2", 50),
+	}}, newExchangeRates(t))
+
+	items := []*dtosV1.ShopItemDto{
+		{ProductId: "product-1", Title: "product 1", Quantity: 1, Price: 100},
+		{ProductId: "product-2", Title: "product 2", Quantity: 1, Price: 60},
+		{Title: "product 3", Quantity: 1, Price: 30},
+	}
+
+	require.NoError(t, resolver.ResolvePrices(context.Background(), "wholesale", BasePresentment, items, now))
+	assert.Equal(t, 70.0, items[0].Price)
+	assert.Equal(t, 60.0, items[1].Price)
+	assert.Equal(t, 30.0, items[2].Price)
+
+	// the tier price is used after the promotion ends
+	require.NoError(t, resolver.ResolvePrices(context.Background(), "wholesale", BasePresentment, items, promotionEnd))
+	assert.Equal(t, 90.0, items[0].Price)
+}
+
+func Test_Resolve_Prices_Keeps_The_Prices_Without_A_Customer_Group(t *testing.T) {
+	resolver := NewPriceResolver(&fakePriceListRepository{priceLists: []*read_models.PriceListReadModel{
+		newPriceList("", time.Now().Add(-time.Hour), nil, "product-1", 10),
+	}}, newExchangeRates(t))
+
+	items := []*dtosV1.ShopItemDto{{ProductId: "product-1", Title: "product 1", Quantity: 1, Price: 100}}
+
+	require.NoError(t, resolver.ResolvePrices(context.Background(), "", BasePresentment, items, time.Now()))
+	assert.Equal(t, 100.0, items[0].Price)
+}
+
+func Test_Resolve_Prices_Converts_The_Price_List_Prices_To_The_Presentment_Currency(t *testing.T) {
+	now := time.Now()
+
+	resolver := NewPriceResolver(&fakePriceListRepository{priceLists: []*read_models.PriceListReadModel{
+		newPriceList("wholesale", now.AddDate(0, -1, 0), nil, "product-1", 90),
+	}}, newExchangeRates(t))
+
+	presentment, err := resolver.Presentment("eur")
+	require.NoError(t, err)
+	assert.Equal(t, "EUR", presentment.Currency)
+	assert.Equal(t, 0.5, presentment.ExchangeRate)
+
+	items := []*dtosV1.ShopItemDto{
+		{ProductId: "product-1", Title: "product 1", Quantity: 1, Price: 100},
+		{Title: "product 2", Quantity: 1, Price: 30},
+	}
+
+	require.NoError(t, resolver.ResolvePrices(context.Background(), "wholesale", presentment, items, now))
+	assert.Equal(t, 45.0, items[0].Price)
+	assert.Equal(t, 30.0, items[1].Price)
+
+	presentment, err = resolver.Presentment("USD")
+	require.NoError(t, err)
+	assert.Equal(t, BasePresentment, presentment)
+
+	_, err = resolver.Presentment("JPY")
+	assert.ErrorIs(t, err, exchangerates.ErrUnknownCurrency)
+}
@@ -0,0 +1,38 @@
+package projections
+
+import (
+	"context"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/producer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+)
+
+// DiscardProducer drops the integration events of a replayed projection, they are published by the live projection
+type DiscardProducer struct{}
+
+var _ producer.Producer = DiscardProducer{}
+
+func (DiscardProducer) PublishMessage(context.Context, types.IMessage, metadata.Metadata) error {
+	return nil
+}
+
+func (DiscardProducer) PublishMessageWithTopicName(context.Context, types.IMessage, metadata.Metadata, string) error {
+	return nil
+}
+
+func (DiscardProducer) PublishMessageWithDelay(
+	context.Context,
+	types.IMessage,
+	metadata.Metadata,
+	time.Duration,
+) error {
+	return nil
+}
+
+func (DiscardProducer) PublishMessages(context.Context, []types.IMessage) error {
+	return nil
+}
+
+func (DiscardProducer) IsProduced(func(message types.IMessage)) {}
@@ -0,0 +1,214 @@
+package projections
+
+import (
+	"context"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/projection"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/store"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/exchangerates"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/repositories"
+	cancelOrderDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/canceling_order/v1/events/domain_events"
+	createOrderDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/creating_order/v1/events/domain_events"
+	fulfillOrderDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/fulfilling_order/v1/events/domain_events"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/finance/read_models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/aggregate"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+	attribute2 "go.opentelemetry.io/otel/attribute"
+)
+
+// financeEntryProjection adds the finance entries of the created, paid and refunded orders for the ERP export, the id
+// of an entry is the id of its event, so replaying the events doesn't add an entry twice. the amounts of the payments
+// and the refunds are read from their events, only the order number, the currency and the exchange rate, which don't
+// change after the creation of the order, are loaded from the order aggregate. the orders are paid by the order
+// fulfillment saga, so there are no payment and refund entries while it is disabled.
+type financeEntryProjection struct {
+	financeEntryRepository repositories.FinanceEntryRepository
+	orderAggregateStore    store.AggregateStore[*aggregate.Order]
+	exchangeRateOptions    *exchangerates.ExchangeRateOptions
+	logger                 logger.Logger
+	tracer                 tracing.AppTracer
+}
+
+func NewFinanceEntryProjection(
+	financeEntryRepository repositories.FinanceEntryRepository,
+	orderAggregateStore store.AggregateStore[*aggregate.Order],
+	exchangeRateOptions *exchangerates.ExchangeRateOptions,
+	logger logger.Logger,
+	tracer tracing.AppTracer,
+) projection.IProjection {
+	return &financeEntryProjection{
+		financeEntryRepository: financeEntryRepository,
+		orderAggregateStore:    orderAggregateStore,
+		exchangeRateOptions:    exchangeRateOptions,
+		logger:                 logger,
+		tracer:                 tracer,
+	}
+}
+
+func (f *financeEntryProjection) ProcessEvent(ctx context.Context, streamEvent *models.StreamEvent) error {
+	switch evt := streamEvent.Event.(type) {
+	case *createOrderDomainEventsV1.OrderCreatedV1:
+		return f.onOrderCreated(ctx, streamEvent.EventID, evt)
+	case *fulfillOrderDomainEventsV1.OrderPaidV1:
+		return f.onOrderPaid(ctx, streamEvent.EventID, evt)
+	case *cancelOrderDomainEventsV1.OrderCanceledV1:
+		return f.onOrderCanceled(ctx, streamEvent.EventID, evt)
+	}
+
+	return nil
+}
+
+func (f *financeEntryProjection) onOrderCreated(
+	ctx context.Context,
+	eventId uuid.UUID,
+	evt *createOrderDomainEventsV1.OrderCreatedV1,
+) error {
+	ctx, span := f.tracer.Start(ctx, "financeEntryProjection.onOrderCreated")
+	span.SetAttributes(attribute2.String("OrderId", evt.OrderId.String()))
+	defer span.End()
+
+	var totalPrice float64
+	for _, item := range evt.ShopItems {
+		totalPrice += item.Price * float64(item.Quantity)
+	}
+
+	exchangeRate := evt.ExchangeRate
+	if exchangeRate <= 0 {
+		exchangeRate = 1
+	}
+
+	entry := &read_models.FinanceEntryReadModel{
+		Id:           eventId.String(),
+		Type:         read_models.FinanceEntryTypeOrder,
+		OrderId:      evt.OrderId.String(),
+		OrderNumber:  evt.OrderNumber,
+		Amount:       totalPrice,
+		Currency:     f.currency(evt.Currency),
+		ExchangeRate: exchangeRate,
+		OccurredAt:   evt.CreatedAt,
+	}
+
+	return utils.TraceStatusFromSpan(span, f.addEntry(ctx, entry))
+}
+
+func (f *financeEntryProjection) onOrderPaid(
+	ctx context.Context,
+	eventId uuid.UUID,
+	evt *fulfillOrderDomainEventsV1.OrderPaidV1,
+) error {
+	ctx, span := f.tracer.Start(ctx, "financeEntryProjection.onOrderPaid")
+	span.SetAttributes(attribute2.String("OrderId", evt.GetAggregateId().String()))
+	defer span.End()
+
+	order, err := f.orderAggregateStore.Load(ctx, evt.GetAggregateId())
+	if err != nil {
+		return utils.TraceStatusFromSpan(
+			span,
+			errors.WrapIf(err, "[financeEntryProjection_onOrderPaid.Load] error in loading order aggregate"),
+		)
+	}
+
+	entry := f.orderEntry(
+		eventId,
+		read_models.FinanceEntryTypePayment,
+		order,
+		evt.Amount,
+		evt.GiftCardAmount,
+		evt.PaidAt,
+	)
+	entry.PaymentId = evt.PaymentId.String()
+
+	return utils.TraceStatusFromSpan(span, f.addEntry(ctx, entry))
+}
+
+func (f *financeEntryProjection) onOrderCanceled(
+	ctx context.Context,
+	eventId uuid.UUID,
+	evt *cancelOrderDomainEventsV1.OrderCanceledV1,
+) error {
+	ctx, span := f.tracer.Start(ctx, "financeEntryProjection.onOrderCanceled")
+	span.SetAttributes(attribute2.String("OrderId", evt.GetAggregateId().String()))
+	defer span.End()
+
+	// only the payments of the canceled orders are refunded
+	if !evt.Refunded {
+		return nil
+	}
+
+	order, err := f.orderAggregateStore.Load(ctx, evt.GetAggregateId())
+	if err != nil {
+		return utils.TraceStatusFromSpan(
+			span,
+			errors.WrapIf(err, "[financeEntryProjection_onOrderCanceled.Load] error in loading order aggregate"),
+		)
+	}
+
+	entry := f.orderEntry(
+		eventId,
+		read_models.FinanceEntryTypeRefund,
+		order,
+		evt.RefundedAmount,
+		evt.RefundedGiftCardAmount,
+		evt.CanceledAt,
+	)
+	entry.PaymentId = evt.RefundedPaymentId.String()
+	entry.Reason = evt.Reason
+
+	return utils.TraceStatusFromSpan(span, f.addEntry(ctx, entry))
+}
+
+// orderEntry creates the payment or the refund entry of the order, the gift card part of the order is not paid or
+// refunded by the payment
+func (f *financeEntryProjection) orderEntry(
+	eventId uuid.UUID,
+	entryType string,
+	order *aggregate.Order,
+	amount float64,
+	giftCardAmount float64,
+	occurredAt time.Time,
+) *read_models.FinanceEntryReadModel {
+	return &read_models.FinanceEntryReadModel{
+		Id:             eventId.String(),
+		Type:           entryType,
+		OrderId:        order.Id().String(),
+		OrderNumber:    order.OrderNumber(),
+		Amount:         amount,
+		GiftCardAmount: giftCardAmount,
+		Currency:       f.currency(order.Currency()),
+		ExchangeRate:   order.ExchangeRate(),
+		OccurredAt:     occurredAt,
+	}
+}
+
+// currency returns the base currency for the orders in the base currency
+func (f *financeEntryProjection) currency(currency string) string {
+	if currency == "" {
+		return f.exchangeRateOptions.BaseCurrency
+	}
+
+	return currency
+}
+
+func (f *financeEntryProjection) addEntry(ctx context.Context, entry *read_models.FinanceEntryReadModel) error {
+	entry.Status = read_models.FinanceEntryStatusPending
+	entry.CreatedAt = time.Now()
+
+	err := f.financeEntryRepository.AddFinanceEntry(ctx, entry)
+	if err != nil {
+		return errors.WrapIf(err, "[financeEntryProjection_addEntry.AddFinanceEntry] error in adding finance entry")
+	}
+
+	f.logger.Infow(
+		"[financeEntryProjection.addEntry] finance entry projected",
+		logger.Fields{"Id": entry.Id, "Type": entry.Type, "OrderId": entry.OrderId},
+	)
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package projections
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	esMocks "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/mocks"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/exchangerates"
+	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/repositories"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/finance/read_models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/aggregate"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/value_objects"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeFinanceEntryRepository struct {
+	repositories.FinanceEntryRepository
+	entries []*read_models.FinanceEntryReadModel
+}
+
+func (f *fakeFinanceEntryRepository) AddFinanceEntry(_ context.Context, entry *read_models.FinanceEntryReadModel) error {
+	f.entries = append(f.entries, entry)
+
+	return nil
+}
+
+func newTestOrder(t *testing.T) *aggregate.Order {
+	t.Helper()
+
+	order, err := aggregate.NewOrder(
+		uuid.NewV4(),
+		"ORD-000001",
+		[]*value_objects.ShopItem{value_objects.CreateNewShopItem("item", "description", 2, 50)},
+		"john@example.com",
+		"address",
+		time.Now().Add(time.Hour),
+		"",
+		0,
+		time.Now(),
+	)
+	require.NoError(t, err)
+
+	return order
+}
+
+func Test_Finance_Entries_Of_Payment_And_Refund_Read_The_Amounts_Of_Their_Events(t *testing.T) {
+	order := newTestOrder(t)
+	paymentId := uuid.NewV4()
+	require.NoError(t, order.ApplyGiftCard(uuid.NewV4(), 30, time.Now()))
+	require.NoError(t, order.Pay(paymentId, time.Now()))
+	require.NoError(t, order.ForceCancel("out of stock", "admin", time.Now()))
+	events := order.UncommittedEvents()
+
+	// the current state of the order is different from its state at the events, e.g. while the projection is rebuilt
+	current := newTestOrder(t)
+	orderAggregateStore := esMocks.NewAggregateStore[*aggregate.Order](t)
+	orderAggregateStore.EXPECT().Load(mock.Anything, order.Id()).Return(current, nil)
+
+	repository := &fakeFinanceEntryRepository{}
+	projection := NewFinanceEntryProjection(
+		repository,
+		orderAggregateStore,
+		&exchangerates.ExchangeRateOptions{BaseCurrency: "USD"},
+		defaultLogger.GetLogger(),
+		tracing.NewAppTracer("finance-entry-projection-test"),
+	)
+
+	for _, event := range events {
+		err := projection.ProcessEvent(context.Background(), &models.StreamEvent{EventID: event.GetEventId(), Event: event})
+		require.NoError(t, err)
+	}
+
+	require.Len(t, repository.entries, 3)
+
+	payment := repository.entries[1]
+	assert.Equal(t, read_models.FinanceEntryTypePayment, payment.Type)
+	assert.Equal(t, paymentId.String(), payment.PaymentId)
+	assert.Equal(t, 70.0, payment.Amount)
+	assert.Equal(t, 30.0, payment.GiftCardAmount)
+
+	refund := repository.entries[2]
+	assert.Equal(t, read_models.FinanceEntryTypeRefund, refund.Type)
+	assert.Equal(t, paymentId.String(), refund.PaymentId)
+	assert.Equal(t, 70.0, refund.Amount)
+	assert.Equal(t, 30.0, refund.GiftCardAmount)
+	assert.Equal(t, "out of stock", refund.Reason)
+}
+
+func Test_Finance_Entry_Of_Refund_Is_Not_Added_For_The_Unpaid_Orders(t *testing.T) {
+	order := newTestOrder(t)
+	require.NoError(t, order.ForceCancel("out of stock", "admin", time.Now()))
+	canceled := order.UncommittedEvents()[1]
+
+	repository := &fakeFinanceEntryRepository{}
+	projection := NewFinanceEntryProjection(
+		repository,
+		esMocks.NewAggregateStore[*aggregate.Order](t),
+		&exchangerates.ExchangeRateOptions{BaseCurrency: "USD"},
+		defaultLogger.GetLogger(),
+		tracing.NewAppTracer("finance-entry-projection-test"),
+	)
+
+	err := projection.ProcessEvent(context.Background(), &models.StreamEvent{EventID: canceled.GetEventId(), Event: canceled})
+	require.NoError(t, err)
+	assert.Empty(t, repository.entries)
+}
@@ -0,0 +1,174 @@
+package projections
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/producer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/projection"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/store"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/utils"
+	cancelOrderDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/canceling_order/v1/events/domain_events"
+	redeemGiftCardDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/redeeming_gift_card/v1/events/domain_events"
+	redeemGiftCardIntegrationEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/redeeming_gift_card/v1/events/integration_events"
+	reviewOrderDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/reviewing_order/v1/events/domain_events"
+	giftCardAggregate "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/giftcards/aggregate"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/aggregate"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+	attribute2 "go.opentelemetry.io/otel/attribute"
+)
+
+// redemptionCheckDelay is the delay of checking a gift card redemption, its order is stored right after the gift card
+// by the create order handler, so the order of a checked redemption exists unless storing it failed
+const redemptionCheckDelay = time.Minute
+
+// giftCardCompensationProjection refunds the gift card redemption of the canceled orders, refunding is a no-op for an
+// order without redemption, so replaying the events doesn't refund an order twice. the redemptions of the orders which
+// are not stored are refunded by checking each redemption after a delay, the check is published from the gift card
+// events, so it is not lost when the create order handler fails between storing the gift card and the order.
+type giftCardCompensationProjection struct {
+	orderAggregateStore    store.AggregateStore[*aggregate.Order]
+	giftCardAggregateStore store.AggregateStore[*giftCardAggregate.GiftCard]
+	rabbitmqProducer       producer.Producer
+	logger                 logger.Logger
+	tracer                 tracing.AppTracer
+}
+
+func NewGiftCardCompensationProjection(
+	orderAggregateStore store.AggregateStore[*aggregate.Order],
+	giftCardAggregateStore store.AggregateStore[*giftCardAggregate.GiftCard],
+	rabbitmqProducer producer.Producer,
+	logger logger.Logger,
+	tracer tracing.AppTracer,
+) projection.IProjection {
+	return &giftCardCompensationProjection{
+		orderAggregateStore:    orderAggregateStore,
+		giftCardAggregateStore: giftCardAggregateStore,
+		rabbitmqProducer:       rabbitmqProducer,
+		logger:                 logger,
+		tracer:                 tracer,
+	}
+}
+
+func (g *giftCardCompensationProjection) ProcessEvent(
+	ctx context.Context,
+	streamEvent *models.StreamEvent,
+) error {
+	switch evt := streamEvent.Event.(type) {
+	case *cancelOrderDomainEventsV1.OrderCanceledV1:
+		return g.onOrderCanceled(ctx, evt.GetAggregateId(), evt.CanceledAt)
+	case *reviewOrderDomainEventsV1.OrderReviewRejectedV1:
+		return g.onOrderCanceled(ctx, evt.GetAggregateId(), evt.ReviewedAt)
+	case *redeemGiftCardDomainEventsV1.GiftCardRedeemedV1:
+		return g.onGiftCardRedeemed(ctx, evt)
+	}
+
+	return nil
+}
+
+func (g *giftCardCompensationProjection) onGiftCardRedeemed(
+	ctx context.Context,
+	evt *redeemGiftCardDomainEventsV1.GiftCardRedeemedV1,
+) error {
+	ctx, span := g.tracer.Start(ctx, "giftCardCompensationProjection.onGiftCardRedeemed")
+	span.SetAttributes(attribute2.String("GiftCardId", evt.GetAggregateId().String()))
+	span.SetAttributes(attribute2.String("OrderId", evt.OrderId.String()))
+	defer span.End()
+
+	checkRedemption := redeemGiftCardIntegrationEventsV1.NewCheckGiftCardRedemptionV1(
+		evt.GetAggregateId().String(),
+		evt.OrderId.String(),
+	)
+
+	// a failed publish fails the projection, so the event is projected again after resubscribing
+	err := g.rabbitmqProducer.PublishMessageWithDelay(ctx, checkRedemption, nil, redemptionCheckDelay)
+	if err != nil {
+		return utils.TraceStatusFromSpan(
+			span,
+			errors.WrapIf(
+				err,
+				"[giftCardCompensationProjection_onGiftCardRedeemed.PublishMessageWithDelay] error in publishing CheckGiftCardRedemption integration_events event",
+			),
+		)
+	}
+
+	return nil
+}
+
+func (g *giftCardCompensationProjection) onOrderCanceled(
+	ctx context.Context,
+	orderId uuid.UUID,
+	canceledAt time.Time,
+) error {
+	ctx, span := g.tracer.Start(ctx, "giftCardCompensationProjection.onOrderCanceled")
+	span.SetAttributes(attribute2.String("OrderId", orderId.String()))
+	defer span.End()
+
+	order, err := g.orderAggregateStore.Load(ctx, orderId)
+	if err != nil {
+		return utils.TraceStatusFromSpan(
+			span,
+			errors.WrapIf(
+				err,
+				"[giftCardCompensationProjection_onOrderCanceled.Load] error in loading order aggregate",
+			),
+		)
+	}
+
+	if order.GiftCardId() == uuid.Nil {
+		return nil
+	}
+
+	giftCard, err := g.giftCardAggregateStore.Load(ctx, order.GiftCardId())
+	if err != nil {
+		return utils.TraceStatusFromSpan(
+			span,
+			errors.WrapIf(
+				err,
+				"[giftCardCompensationProjection_onOrderCanceled.Load] error in loading gift card aggregate",
+			),
+		)
+	}
+
+	refunded, err := giftCard.RefundRedemption(orderId, canceledAt)
+	if err != nil {
+		return utils.TraceStatusFromSpan(
+			span,
+			errors.WrapIf(
+				err,
+				"[giftCardCompensationProjection_onOrderCanceled.RefundRedemption] error in refunding the gift card",
+			),
+		)
+	}
+	if !refunded {
+		return nil
+	}
+
+	_, err = g.giftCardAggregateStore.Store(giftCard, nil, ctx)
+	if err != nil {
+		return utils.TraceStatusFromSpan(
+			span,
+			errors.WrapIf(
+				err,
+				"[giftCardCompensationProjection_onOrderCanceled.Store] error in storing gift card aggregate",
+			),
+		)
+	}
+
+	g.logger.Infow(
+		fmt.Sprintf(
+			"[giftCardCompensationProjection.onOrderCanceled] gift card with id: {%s} refunded for canceled order with id: {%s}",
+			giftCard.Id(),
+			orderId,
+		),
+		logger.Fields{"GiftCardId": giftCard.Id(), "OrderId": orderId},
+	)
+
+	return nil
+}
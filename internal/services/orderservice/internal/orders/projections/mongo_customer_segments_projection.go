@@ -0,0 +1,187 @@
+package projections
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/producer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/projection"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/repositories"
+	cancelOrderDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/canceling_order/v1/events/domain_events"
+	createOrderDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/creating_order/v1/events/domain_events"
+	reviewOrderDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/reviewing_order/v1/events/domain_events"
+	segmentIntegrationEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/segmenting_customers/v1/events/integration_events"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/segments/read_models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/segments"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+	attribute2 "go.opentelemetry.io/otel/attribute"
+)
+
+// mongoCustomerSegmentsProjection derives the customer segments from the order events into the customer segments
+// read model and publishes the segment changes for the marketing consumers
+type mongoCustomerSegmentsProjection struct {
+	customerSegmentsRepository repositories.CustomerSegmentsRepository
+	rabbitmqProducer           producer.Producer
+	segmentOptions             *segments.SegmentOptions
+	logger                     logger.Logger
+	tracer                     tracing.AppTracer
+}
+
+func NewMongoCustomerSegmentsProjection(
+	customerSegmentsRepository repositories.CustomerSegmentsRepository,
+	rabbitmqProducer producer.Producer,
+	segmentOptions *segments.SegmentOptions,
+	logger logger.Logger,
+	tracer tracing.AppTracer,
+) projection.IProjection {
+	return &mongoCustomerSegmentsProjection{
+		customerSegmentsRepository: customerSegmentsRepository,
+		rabbitmqProducer:           rabbitmqProducer,
+		segmentOptions:             segmentOptions,
+		logger:                     logger,
+		tracer:                     tracer,
+	}
+}
+
+var _ projection.IRebuildableProjection = (*mongoCustomerSegmentsProjection)(nil)
+
+func (m *mongoCustomerSegmentsProjection) ProcessEvent(
+	ctx context.Context,
+	streamEvent *models.StreamEvent,
+) error {
+	switch evt := streamEvent.Event.(type) {
+	case *createOrderDomainEventsV1.OrderCreatedV1:
+		return m.onOrderCreated(ctx, evt)
+	case *cancelOrderDomainEventsV1.OrderCanceledV1:
+		return m.onOrderCanceled(ctx, evt.GetAggregateId())
+	case *reviewOrderDomainEventsV1.OrderReviewRejectedV1:
+		return m.onOrderCanceled(ctx, evt.GetAggregateId())
+	}
+
+	return nil
+}
+
+// DropReadModel deletes the customer segments before they are derived again from the order events
+func (m *mongoCustomerSegmentsProjection) DropReadModel(ctx context.Context) error {
+	return m.customerSegmentsRepository.DeleteAllCustomers(ctx)
+}
+
+// ReplayProjection doesn't publish the segment changes again, the marketing consumers received them from the live
+// projection
+func (m *mongoCustomerSegmentsProjection) ReplayProjection() projection.IProjection {
+	replay := *m
+	replay.rabbitmqProducer = DiscardProducer{}
+
+	return &replay
+}
+
+func (m *mongoCustomerSegmentsProjection) onOrderCreated(
+	ctx context.Context,
+	evt *createOrderDomainEventsV1.OrderCreatedV1,
+) error {
+	ctx, span := m.tracer.Start(ctx, "mongoCustomerSegmentsProjection.onOrderCreated")
+	span.SetAttributes(attribute2.String("OrderId", evt.OrderId.String()))
+	defer span.End()
+
+	customer, err := m.customerSegmentsRepository.GetCustomerById(ctx, read_models.CustomerId(evt.AccountEmail))
+	if err != nil {
+		return utils.TraceStatusFromSpan(
+			span,
+			errors.WrapIf(
+				err,
+				"[mongoCustomerSegmentsProjection_onOrderCreated.GetCustomerById] error in getting customer",
+			),
+		)
+	}
+	if customer == nil {
+		customer = read_models.NewCustomerSegmentsReadModel(evt.AccountEmail)
+	}
+
+	var totalPrice float64
+	for _, item := range evt.ShopItems {
+		totalPrice += item.Price * float64(item.Quantity)
+	}
+
+	if !customer.AddOrder(evt.OrderId.String(), totalPrice, evt.CreatedAt) {
+		// the event is replayed
+		return nil
+	}
+	customer.UpdatedAt = evt.CreatedAt
+
+	return utils.TraceStatusFromSpan(span, m.saveCustomer(ctx, customer))
+}
+
+func (m *mongoCustomerSegmentsProjection) onOrderCanceled(ctx context.Context, orderId uuid.UUID) error {
+	ctx, span := m.tracer.Start(ctx, "mongoCustomerSegmentsProjection.onOrderCanceled")
+	span.SetAttributes(attribute2.String("OrderId", orderId.String()))
+	defer span.End()
+
+	customer, err := m.customerSegmentsRepository.GetCustomerByOrderId(ctx, orderId.String())
+	if err != nil {
+		return utils.TraceStatusFromSpan(
+			span,
+			errors.WrapIf(
+				err,
+				"[mongoCustomerSegmentsProjection_onOrderCanceled.GetCustomerByOrderId] error in getting customer",
+			),
+		)
+	}
+	if customer == nil || !customer.CancelOrder(orderId.String()) {
+		return nil
+	}
+
+	return utils.TraceStatusFromSpan(span, m.saveCustomer(ctx, customer))
+}
+
+func (m *mongoCustomerSegmentsProjection) saveCustomer(
+	ctx context.Context,
+	customer *read_models.CustomerSegmentsReadModel,
+) error {
+	added, removed := segments.Recalculate(customer, m.segmentOptions)
+
+	err := m.customerSegmentsRepository.SaveCustomer(ctx, customer)
+	if err != nil {
+		return errors.WrapIf(
+			err,
+			"[mongoCustomerSegmentsProjection_saveCustomer.SaveCustomer] error in saving customer segments",
+		)
+	}
+
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	segmentsChangedEvent := segmentIntegrationEventsV1.NewCustomerSegmentsChangedV1(
+		customer.AccountEmail,
+		customer.Segments,
+		added,
+		removed,
+		customer.OrdersCount,
+		customer.LifetimeValue,
+	)
+
+	err = m.rabbitmqProducer.PublishMessage(ctx, segmentsChangedEvent, nil)
+	if err != nil {
+		return customErrors.NewApplicationErrorWrap(
+			err,
+			"[mongoCustomerSegmentsProjection_saveCustomer.PublishMessage] error in publishing CustomerSegmentsChanged integration_events event",
+		)
+	}
+
+	m.logger.Infow(
+		fmt.Sprintf(
+			"[mongoCustomerSegmentsProjection.saveCustomer] segments of customer '%s' changed",
+			customer.Id,
+		),
+		logger.Fields{"Id": customer.Id, "Segments": customer.Segments, "MessageId": segmentsChangedEvent.MessageId},
+	)
+
+	return nil
+}
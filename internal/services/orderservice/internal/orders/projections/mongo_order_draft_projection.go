@@ -0,0 +1,147 @@
+package projections
+
+import (
+	"context"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/projection"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/repositories"
+	convertOrderDraftDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/converting_order_draft/v1/events/domain_events"
+	saveOrderDraftDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/saving_order_draft/v1/events/domain_events"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/drafts/read_models"
+
+	"emperror.dev/errors"
+	attribute2 "go.opentelemetry.io/otel/attribute"
+)
+
+// mongoOrderDraftProjection keeps the last saved state of the order drafts in the order drafts read model
+type mongoOrderDraftProjection struct {
+	orderDraftRepository repositories.OrderDraftRepository
+	logger               logger.Logger
+	tracer               tracing.AppTracer
+}
+
+func NewMongoOrderDraftProjection(
+	orderDraftRepository repositories.OrderDraftRepository,
+	logger logger.Logger,
+	tracer tracing.AppTracer,
+) projection.IProjection {
+	return &mongoOrderDraftProjection{
+		orderDraftRepository: orderDraftRepository,
+		logger:               logger,
+		tracer:               tracer,
+	}
+}
+
+var _ projection.IRebuildableProjection = (*mongoOrderDraftProjection)(nil)
+
+func (m *mongoOrderDraftProjection) ProcessEvent(ctx context.Context, streamEvent *models.StreamEvent) error {
+	switch evt := streamEvent.Event.(type) {
+	case *saveOrderDraftDomainEventsV1.OrderDraftSavedV1:
+		return m.onOrderDraftSaved(ctx, evt)
+	case *convertOrderDraftDomainEventsV1.OrderDraftConvertedV1:
+		return m.onOrderDraftConverted(ctx, evt)
+	}
+
+	return nil
+}
+
+// DropReadModel deletes the order drafts before they are projected again from the draft events
+func (m *mongoOrderDraftProjection) DropReadModel(ctx context.Context) error {
+	return m.orderDraftRepository.DeleteAllOrderDrafts(ctx)
+}
+
+// ReplayProjection is the projection itself, projecting the draft events has no side effects
+func (m *mongoOrderDraftProjection) ReplayProjection() projection.IProjection {
+	return m
+}
+
+func (m *mongoOrderDraftProjection) onOrderDraftSaved(
+	ctx context.Context,
+	evt *saveOrderDraftDomainEventsV1.OrderDraftSavedV1,
+) error {
+	ctx, span := m.tracer.Start(ctx, "mongoOrderDraftProjection.onOrderDraftSaved")
+	span.SetAttributes(attribute2.String("Id", evt.GetAggregateId().String()))
+	defer span.End()
+
+	draft, err := m.orderDraftRepository.GetOrderDraftById(ctx, evt.GetAggregateId().String())
+	if err != nil {
+		return utils.TraceStatusFromSpan(
+			span,
+			errors.WrapIf(
+				err,
+				"[mongoOrderDraftProjection_onOrderDraftSaved.GetOrderDraftById] error in getting order draft",
+			),
+		)
+	}
+	if draft == nil {
+		draft = &read_models.OrderDraftReadModel{Id: evt.GetAggregateId().String(), CreatedAt: evt.SavedAt}
+	}
+
+	items := make([]*read_models.OrderDraftItemReadModel, 0, len(evt.ShopItems))
+	for _, item := range evt.ShopItems {
+		items = append(items, &read_models.OrderDraftItemReadModel{
+			ProductId:   item.ProductId,
+			Title:       item.Title,
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			Price:       item.Price,
+		})
+	}
+
+	draft.ShopItems = items
+	draft.AccountEmail = evt.AccountEmail
+	draft.DeliveryAddress = evt.DeliveryAddress
+	draft.DeliveryTime = evt.DeliveryTime
+	draft.CustomerGroup = evt.CustomerGroup
+	draft.UpdatedAt = evt.SavedAt
+	draft.ExpiresAt = evt.ExpiresAt
+
+	return utils.TraceStatusFromSpan(span, m.saveDraft(ctx, draft))
+}
+
+func (m *mongoOrderDraftProjection) onOrderDraftConverted(
+	ctx context.Context,
+	evt *convertOrderDraftDomainEventsV1.OrderDraftConvertedV1,
+) error {
+	ctx, span := m.tracer.Start(ctx, "mongoOrderDraftProjection.onOrderDraftConverted")
+	span.SetAttributes(attribute2.String("Id", evt.GetAggregateId().String()))
+	defer span.End()
+
+	draft, err := m.orderDraftRepository.GetOrderDraftById(ctx, evt.GetAggregateId().String())
+	if err != nil {
+		return utils.TraceStatusFromSpan(
+			span,
+			errors.WrapIf(
+				err,
+				"[mongoOrderDraftProjection_onOrderDraftConverted.GetOrderDraftById] error in getting order draft",
+			),
+		)
+	}
+	if draft == nil {
+		// the draft is removed by the ttl index before its conversion is projected
+		return nil
+	}
+
+	convertedAt := evt.ConvertedAt
+	draft.Converted = true
+	draft.OrderId = evt.OrderId.String()
+	draft.ConvertedAt = &convertedAt
+	draft.UpdatedAt = evt.ConvertedAt
+
+	return utils.TraceStatusFromSpan(span, m.saveDraft(ctx, draft))
+}
+
+func (m *mongoOrderDraftProjection) saveDraft(ctx context.Context, draft *read_models.OrderDraftReadModel) error {
+	err := m.orderDraftRepository.SaveOrderDraft(ctx, draft)
+	if err != nil {
+		return errors.WrapIf(err, "[mongoOrderDraftProjection_saveDraft.SaveOrderDraft] error in saving order draft")
+	}
+
+	m.logger.Infow("[mongoOrderDraftProjection.saveDraft] order draft projected", logger.Fields{"Id": draft.Id})
+
+	return nil
+}
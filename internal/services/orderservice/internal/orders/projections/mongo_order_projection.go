@@ -7,6 +7,7 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/producer"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/projection"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/versionguard"
 	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mapper"
@@ -15,14 +16,28 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/utils"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/repositories"
 	dtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/dtos/v1"
+	addOrderNoteDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/adding_order_note/v1/events/domain_events"
+	archiveOrderDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/archiving_order/v1/events/domain_events"
+	cancelOrderDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/canceling_order/v1/events/domain_events"
+	cancelOrderIntegrationEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/canceling_order/v1/events/integration_events"
 	createOrderDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/creating_order/v1/events/domain_events"
 	createOrderIntegrationEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/creating_order/v1/events/integration_events"
+	fulfillOrderDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/fulfilling_order/v1/events/domain_events"
+	legalHoldDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/holding_order_legally/v1/events/domain_events"
+	purgeOrderDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/purging_order_personal_data/v1/events/domain_events"
+	redeemGiftCardDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/redeeming_gift_card/v1/events/domain_events"
+	reviewOrderDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/reviewing_order/v1/events/domain_events"
+	reviewOrderIntegrationEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/reviewing_order/v1/events/integration_events"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/read_models"
 
 	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
 	attribute2 "go.opentelemetry.io/otel/attribute"
 )
 
+// mongoOrderProjection publishes the integration events from the event store subscription after the domain events are
+// appended, and the checkpoint is stored after the publish, so the event store acts as the outbox of the orders service
+// and a failed publish is retried from the last checkpoint.
 type mongoOrderProjection struct {
 	mongoOrderRepository repositories.OrderMongoRepository
 	rabbitmqProducer     producer.Producer
@@ -51,7 +66,29 @@ func (m mongoOrderProjection) ProcessEvent(
 	// Handling and projecting event to elastic read model
 	switch evt := streamEvent.Event.(type) {
 	case *createOrderDomainEventsV1.OrderCreatedV1:
-		return m.onOrderCreated(ctx, evt)
+		return m.onOrderCreated(ctx, evt, streamEvent.Version)
+	case *reviewOrderDomainEventsV1.OrderHeldForReviewV1:
+		return m.onOrderHeldForReview(ctx, evt, streamEvent.Version)
+	case *reviewOrderDomainEventsV1.OrderReviewApprovedV1:
+		return m.onOrderReviewApproved(ctx, evt, streamEvent.Version)
+	case *reviewOrderDomainEventsV1.OrderReviewRejectedV1:
+		return m.onOrderReviewRejected(ctx, evt, streamEvent.Version)
+	case *cancelOrderDomainEventsV1.OrderCanceledV1:
+		return m.onOrderCanceled(ctx, evt, streamEvent.Version)
+	case *addOrderNoteDomainEventsV1.OrderNoteAddedV1:
+		return m.onOrderNoteAdded(ctx, evt, streamEvent.Version)
+	case *redeemGiftCardDomainEventsV1.OrderGiftCardAppliedV1:
+		return m.onOrderGiftCardApplied(ctx, evt, streamEvent.Version)
+	case *fulfillOrderDomainEventsV1.OrderPaidV1:
+		return m.onOrderPaid(ctx, evt, streamEvent.Version)
+	case *legalHoldDomainEventsV1.OrderLegalHoldPlacedV1:
+		return m.onOrderLegalHoldPlaced(ctx, evt, streamEvent.Version)
+	case *legalHoldDomainEventsV1.OrderLegalHoldReleasedV1:
+		return m.onOrderLegalHoldReleased(ctx, evt, streamEvent.Version)
+	case *archiveOrderDomainEventsV1.OrderArchivedV1:
+		return m.onOrderArchived(ctx, evt, streamEvent.Version)
+	case *purgeOrderDomainEventsV1.OrderPersonalDataPurgedV1:
+		return m.onOrderPersonalDataPurged(ctx, evt, streamEvent.Version)
 	}
 
 	return nil
@@ -60,6 +97,7 @@ func (m mongoOrderProjection) ProcessEvent(
 func (m *mongoOrderProjection) onOrderCreated(
 	ctx context.Context,
 	evt *createOrderDomainEventsV1.OrderCreatedV1,
+	version int64,
 ) error {
 	ctx, span := m.tracer.Start(ctx, "mongoOrderProjection.onOrderCreated")
 	span.SetAttributes(attribute.Object("Event", evt))
@@ -76,13 +114,24 @@ func (m *mongoOrderProjection) onOrderCreated(
 
 	orderRead := read_models.NewOrderReadModel(
 		evt.OrderId,
+		evt.OrderNumber,
 		items,
 		evt.AccountEmail,
 		evt.DeliveryAddress,
 		evt.DeliveredTime,
 	)
+	orderRead.Currency = evt.Currency
+	orderRead.ExchangeRate = evt.ExchangeRate
+	orderRead.Version = version
+
 	_, err = m.mongoOrderRepository.CreateOrder(ctx, orderRead)
-	if err != nil {
+	if versionguard.IsSkipped(err) {
+		// the integration event is published again, the publish of the first projection may be failed
+		m.logger.Debugw(
+			fmt.Sprintf("[mongoOrderProjection.onOrderCreated] order with orderId '%s' is already created", evt.OrderId),
+			logger.Fields{"OrderId": evt.OrderId, "Version": version},
+		)
+	} else if err != nil {
 		return utils.TraceStatusFromSpan(
 			span,
 			errors.WrapIf(
@@ -134,3 +183,329 @@ func (m *mongoOrderProjection) onOrderCreated(
 
 	return nil
 }
+
+func (m *mongoOrderProjection) onOrderHeldForReview(
+	ctx context.Context,
+	evt *reviewOrderDomainEventsV1.OrderHeldForReviewV1,
+	version int64,
+) error {
+	ctx, span := m.tracer.Start(ctx, "mongoOrderProjection.onOrderHeldForReview")
+	span.SetAttributes(attribute.Object("Event", evt))
+	defer span.End()
+
+	orderRead, err := m.updateOrderReadModel(ctx, evt.GetAggregateId(), version, func(order *read_models.OrderReadModel) {
+		order.HeldForReview = true
+		order.HoldReasons = evt.Reasons
+		order.UpdatedAt = evt.HeldAt
+	})
+	if err != nil {
+		return utils.TraceStatusFromSpan(span, err)
+	}
+
+	orderHeldEvent := reviewOrderIntegrationEventsV1.NewOrderHeldForReviewV1(
+		orderRead.OrderId,
+		orderRead.AccountEmail,
+		orderRead.TotalPrice,
+		evt.Reasons,
+		evt.HeldAt,
+	)
+
+	err = m.rabbitmqProducer.PublishMessage(ctx, orderHeldEvent, nil)
+	if err != nil {
+		return utils.TraceErrStatusFromSpan(
+			span,
+			customErrors.NewApplicationErrorWrap(
+				err,
+				"[mongoOrderProjection_onOrderHeldForReview.PublishMessage] error in publishing OrderHeldForReview integration_events event",
+			),
+		)
+	}
+
+	m.logger.Infow(
+		fmt.Sprintf(
+			"[mongoOrderProjection.onOrderHeldForReview] order with orderId '%s' held for review",
+			orderRead.OrderId,
+		),
+		logger.Fields{"OrderId": orderRead.OrderId, "MessageId": orderHeldEvent.MessageId},
+	)
+
+	return nil
+}
+
+func (m *mongoOrderProjection) onOrderReviewApproved(
+	ctx context.Context,
+	evt *reviewOrderDomainEventsV1.OrderReviewApprovedV1,
+	version int64,
+) error {
+	ctx, span := m.tracer.Start(ctx, "mongoOrderProjection.onOrderReviewApproved")
+	span.SetAttributes(attribute.Object("Event", evt))
+	defer span.End()
+
+	_, err := m.updateOrderReadModel(ctx, evt.GetAggregateId(), version, func(order *read_models.OrderReadModel) {
+		order.HeldForReview = false
+		order.ReviewNote = evt.Note
+		order.UpdatedAt = evt.ReviewedAt
+	})
+
+	return utils.TraceStatusFromSpan(span, err)
+}
+
+func (m *mongoOrderProjection) onOrderReviewRejected(
+	ctx context.Context,
+	evt *reviewOrderDomainEventsV1.OrderReviewRejectedV1,
+	version int64,
+) error {
+	ctx, span := m.tracer.Start(ctx, "mongoOrderProjection.onOrderReviewRejected")
+	span.SetAttributes(attribute.Object("Event", evt))
+	defer span.End()
+
+	_, err := m.updateOrderReadModel(ctx, evt.GetAggregateId(), version, func(order *read_models.OrderReadModel) {
+		order.HeldForReview = false
+		order.Canceled = true
+		order.CancelReason = evt.Reason
+		order.UpdatedAt = evt.ReviewedAt
+	})
+
+	return utils.TraceStatusFromSpan(span, err)
+}
+
+func (m *mongoOrderProjection) onOrderCanceled(
+	ctx context.Context,
+	evt *cancelOrderDomainEventsV1.OrderCanceledV1,
+	version int64,
+) error {
+	ctx, span := m.tracer.Start(ctx, "mongoOrderProjection.onOrderCanceled")
+	span.SetAttributes(attribute.Object("Event", evt))
+	defer span.End()
+
+	orderRead, err := m.updateOrderReadModel(ctx, evt.GetAggregateId(), version, func(order *read_models.OrderReadModel) {
+		order.HeldForReview = false
+		order.Canceled = true
+		order.CancelReason = evt.Reason
+		order.CanceledBy = evt.CanceledBy
+		order.UpdatedAt = evt.CanceledAt
+	})
+	if err != nil {
+		return utils.TraceStatusFromSpan(span, err)
+	}
+
+	orderCanceledEvent := cancelOrderIntegrationEventsV1.NewOrderCanceledV1(
+		orderRead.OrderId,
+		evt.Reason,
+		evt.Forced,
+		evt.CanceledAt,
+	)
+
+	err = m.rabbitmqProducer.PublishMessage(ctx, orderCanceledEvent, nil)
+	if err != nil {
+		return utils.TraceErrStatusFromSpan(
+			span,
+			customErrors.NewApplicationErrorWrap(
+				err,
+				"[mongoOrderProjection_onOrderCanceled.PublishMessage] error in publishing OrderCanceled integration_events event",
+			),
+		)
+	}
+
+	m.logger.Infow(
+		fmt.Sprintf(
+			"[mongoOrderProjection.onOrderCanceled] order with orderId '%s' canceled",
+			orderRead.OrderId,
+		),
+		logger.Fields{"OrderId": orderRead.OrderId, "MessageId": orderCanceledEvent.MessageId},
+	)
+
+	return nil
+}
+
+func (m *mongoOrderProjection) onOrderNoteAdded(
+	ctx context.Context,
+	evt *addOrderNoteDomainEventsV1.OrderNoteAddedV1,
+	version int64,
+) error {
+	ctx, span := m.tracer.Start(ctx, "mongoOrderProjection.onOrderNoteAdded")
+	span.SetAttributes(attribute.Object("Event", evt))
+	defer span.End()
+
+	_, err := m.updateOrderReadModel(ctx, evt.GetAggregateId(), version, func(order *read_models.OrderReadModel) {
+		order.InternalNotes = append(
+			order.InternalNotes,
+			read_models.NewOrderNoteReadModel(evt.NoteId.String(), evt.Text, evt.Author, evt.AddedAt),
+		)
+		order.UpdatedAt = evt.AddedAt
+	})
+
+	return utils.TraceStatusFromSpan(span, err)
+}
+
+func (m *mongoOrderProjection) onOrderGiftCardApplied(
+	ctx context.Context,
+	evt *redeemGiftCardDomainEventsV1.OrderGiftCardAppliedV1,
+	version int64,
+) error {
+	ctx, span := m.tracer.Start(ctx, "mongoOrderProjection.onOrderGiftCardApplied")
+	span.SetAttributes(attribute.Object("Event", evt))
+	defer span.End()
+
+	_, err := m.updateOrderReadModel(ctx, evt.GetAggregateId(), version, func(order *read_models.OrderReadModel) {
+		order.GiftCardId = evt.GiftCardId.String()
+		order.GiftCardAmount = evt.Amount
+		order.UpdatedAt = evt.AppliedAt
+	})
+
+	return utils.TraceStatusFromSpan(span, err)
+}
+
+func (m *mongoOrderProjection) onOrderPaid(
+	ctx context.Context,
+	evt *fulfillOrderDomainEventsV1.OrderPaidV1,
+	version int64,
+) error {
+	ctx, span := m.tracer.Start(ctx, "mongoOrderProjection.onOrderPaid")
+	span.SetAttributes(attribute.Object("Event", evt))
+	defer span.End()
+
+	_, err := m.updateOrderReadModel(ctx, evt.GetAggregateId(), version, func(order *read_models.OrderReadModel) {
+		order.Paid = true
+		order.PaymentId = evt.PaymentId.String()
+		order.UpdatedAt = evt.PaidAt
+	})
+
+	return utils.TraceStatusFromSpan(span, err)
+}
+
+func (m *mongoOrderProjection) onOrderLegalHoldPlaced(
+	ctx context.Context,
+	evt *legalHoldDomainEventsV1.OrderLegalHoldPlacedV1,
+	version int64,
+) error {
+	ctx, span := m.tracer.Start(ctx, "mongoOrderProjection.onOrderLegalHoldPlaced")
+	span.SetAttributes(attribute.Object("Event", evt))
+	defer span.End()
+
+	_, err := m.updateOrderReadModel(ctx, evt.GetAggregateId(), version, func(order *read_models.OrderReadModel) {
+		order.LegalHold = true
+		order.LegalHoldReason = evt.Reason
+		order.UpdatedAt = evt.PlacedAt
+	})
+
+	return utils.TraceStatusFromSpan(span, err)
+}
+
+func (m *mongoOrderProjection) onOrderLegalHoldReleased(
+	ctx context.Context,
+	evt *legalHoldDomainEventsV1.OrderLegalHoldReleasedV1,
+	version int64,
+) error {
+	ctx, span := m.tracer.Start(ctx, "mongoOrderProjection.onOrderLegalHoldReleased")
+	span.SetAttributes(attribute.Object("Event", evt))
+	defer span.End()
+
+	_, err := m.updateOrderReadModel(ctx, evt.GetAggregateId(), version, func(order *read_models.OrderReadModel) {
+		order.LegalHold = false
+		order.LegalHoldReason = ""
+		order.UpdatedAt = evt.ReleasedAt
+	})
+
+	return utils.TraceStatusFromSpan(span, err)
+}
+
+func (m *mongoOrderProjection) onOrderArchived(
+	ctx context.Context,
+	evt *archiveOrderDomainEventsV1.OrderArchivedV1,
+	version int64,
+) error {
+	ctx, span := m.tracer.Start(ctx, "mongoOrderProjection.onOrderArchived")
+	span.SetAttributes(attribute.Object("Event", evt))
+	defer span.End()
+
+	_, err := m.updateOrderReadModel(ctx, evt.GetAggregateId(), version, func(order *read_models.OrderReadModel) {
+		order.ArchiveLocation = evt.Location
+		order.ArchivedAt = evt.ArchivedAt
+	})
+
+	return utils.TraceStatusFromSpan(span, err)
+}
+
+func (m *mongoOrderProjection) onOrderPersonalDataPurged(
+	ctx context.Context,
+	evt *purgeOrderDomainEventsV1.OrderPersonalDataPurgedV1,
+	version int64,
+) error {
+	ctx, span := m.tracer.Start(ctx, "mongoOrderProjection.onOrderPersonalDataPurged")
+	span.SetAttributes(attribute2.String("OrderId", evt.GetAggregateId().String()))
+	defer span.End()
+
+	_, err := m.updateOrderReadModel(ctx, evt.GetAggregateId(), version, func(order *read_models.OrderReadModel) {
+		order.RedactPersonalData(evt.PurgedAt)
+	})
+
+	return utils.TraceStatusFromSpan(span, err)
+}
+
+// updateOrderReadModel applies the update of the event with the version to the order, the write of a duplicate or a
+// stale event is skipped by the version guard and the stored order is returned, so the integration event of the event
+// is still published, the publish of its first projection may be failed
+func (m *mongoOrderProjection) updateOrderReadModel(
+	ctx context.Context,
+	orderId uuid.UUID,
+	version int64,
+	update func(order *read_models.OrderReadModel),
+) (*read_models.OrderReadModel, error) {
+	orderRead, err := m.mongoOrderRepository.GetOrderByOrderId(ctx, orderId)
+	if err != nil {
+		return nil, errors.WrapIf(
+			err,
+			"[mongoOrderProjection_updateOrderReadModel.GetOrderByOrderId] error in fetching order with mongoOrderRepository",
+		)
+	}
+	if orderRead == nil {
+		return nil, customErrors.NewNotFoundError(
+			fmt.Sprintf(
+				"[mongoOrderProjection_updateOrderReadModel.GetOrderByOrderId] order with orderId %s not found",
+				orderId,
+			),
+		)
+	}
+
+	decision := versionguard.ApplyEvent(orderRead, version, func() {
+		update(orderRead)
+	})
+	if decision != versionguard.Apply {
+		m.logSkippedEvent(orderRead, version, decision)
+
+		return orderRead, nil
+	}
+
+	updated, err := m.mongoOrderRepository.UpdateOrder(ctx, orderRead)
+	if versionguard.IsSkipped(err) {
+		// a newer event is applied by a concurrent consumer after the read
+		m.logSkippedEvent(orderRead, version, versionguard.Stale)
+
+		return orderRead, nil
+	}
+	if err != nil {
+		return nil, errors.WrapIf(
+			err,
+			"[mongoOrderProjection_updateOrderReadModel.UpdateOrder] error in updating order with mongoOrderRepository",
+		)
+	}
+
+	return updated, nil
+}
+
+func (m *mongoOrderProjection) logSkippedEvent(
+	orderRead *read_models.OrderReadModel,
+	version int64,
+	decision versionguard.Decision,
+) {
+	m.logger.Debugw(
+		fmt.Sprintf(
+			"[mongoOrderProjection.updateOrderReadModel] %s event with version %d of order with orderId '%s' skipped",
+			decision,
+			version,
+			orderRead.OrderId,
+		),
+		logger.Fields{"OrderId": orderRead.OrderId, "Version": version},
+	)
+}
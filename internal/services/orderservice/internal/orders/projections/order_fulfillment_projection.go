@@ -0,0 +1,115 @@
+package projections
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/projection"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/store"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/exchangerates"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/utils"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/saga"
+	createOrderDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/creating_order/v1/events/domain_events"
+	reviewOrderDomainEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/reviewing_order/v1/events/domain_events"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/aggregate"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/sagas"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+	attribute2 "go.opentelemetry.io/otel/attribute"
+)
+
+// orderFulfillmentProjection starts the order fulfillment saga of the created orders, the orders which are held for
+// review are fulfilled after their review is approved. the id of the saga is the id of the order, so replaying the
+// events doesn't start a saga twice. the sagas are not started until the `enabled` of the saga options is set, because
+// the catalogs service doesn't reserve the stock and there is no payments service yet.
+type orderFulfillmentProjection struct {
+	orderAggregateStore store.AggregateStore[*aggregate.Order]
+	orchestrator        sagas.OrderFulfillmentOrchestrator
+	sagaOptions         *saga.SagaOptions
+	exchangeRateOptions *exchangerates.ExchangeRateOptions
+	logger              logger.Logger
+	tracer              tracing.AppTracer
+}
+
+func NewOrderFulfillmentProjection(
+	orderAggregateStore store.AggregateStore[*aggregate.Order],
+	orchestrator sagas.OrderFulfillmentOrchestrator,
+	sagaOptions *saga.SagaOptions,
+	exchangeRateOptions *exchangerates.ExchangeRateOptions,
+	logger logger.Logger,
+	tracer tracing.AppTracer,
+) projection.IProjection {
+	return &orderFulfillmentProjection{
+		orderAggregateStore: orderAggregateStore,
+		orchestrator:        orchestrator,
+		sagaOptions:         sagaOptions,
+		exchangeRateOptions: exchangeRateOptions,
+		logger:              logger,
+		tracer:              tracer,
+	}
+}
+
+func (o *orderFulfillmentProjection) ProcessEvent(ctx context.Context, streamEvent *models.StreamEvent) error {
+	if !o.sagaOptions.Enabled {
+		return nil
+	}
+
+	switch evt := streamEvent.Event.(type) {
+	case *createOrderDomainEventsV1.OrderCreatedV1:
+		return o.startFulfillment(ctx, evt.GetAggregateId())
+	case *reviewOrderDomainEventsV1.OrderReviewApprovedV1:
+		return o.startFulfillment(ctx, evt.GetAggregateId())
+	}
+
+	return nil
+}
+
+func (o *orderFulfillmentProjection) startFulfillment(ctx context.Context, orderId uuid.UUID) error {
+	ctx, span := o.tracer.Start(ctx, "orderFulfillmentProjection.startFulfillment")
+	span.SetAttributes(attribute2.String("OrderId", orderId.String()))
+	defer span.End()
+
+	order, err := o.orderAggregateStore.Load(ctx, orderId)
+	if err != nil {
+		return utils.TraceStatusFromSpan(
+			span,
+			errors.WrapIf(
+				err,
+				"[orderFulfillmentProjection_startFulfillment.Load] error in loading order aggregate",
+			),
+		)
+	}
+
+	if order.HeldForReview() || order.Canceled() || order.Paid() {
+		return nil
+	}
+
+	err = o.orchestrator.Start(ctx, orderId.String(), sagas.NewOrderFulfillmentState(order, o.exchangeRateOptions))
+	if customErrors.IsConflictError(err) {
+		return nil
+	}
+	if err != nil {
+		return utils.TraceStatusFromSpan(
+			span,
+			errors.WrapIf(
+				err,
+				"[orderFulfillmentProjection_startFulfillment.Start] error in starting the order fulfillment saga",
+			),
+		)
+	}
+
+	o.logger.Infow(
+		fmt.Sprintf(
+			"[orderFulfillmentProjection.startFulfillment] order fulfillment saga is started for order with id: {%s}",
+			orderId,
+		),
+		logger.Fields{"OrderId": orderId},
+	)
+
+	return nil
+}
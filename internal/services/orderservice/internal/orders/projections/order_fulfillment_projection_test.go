@@ -0,0 +1,127 @@
+package projections
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/mocks"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/metadata"
+	esMocks "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/mocks"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/exchangerates"
+	defaultLogger "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/defaultlogger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/saga"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/configurations/mappings"
+	integrationEvents "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/fulfilling_order/v1/events/integration_events"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/aggregate"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/value_objects"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/sagas"
+
+	"github.com/mcuadros/go-defaults"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	_ = mappings.ConfigureOrdersMappings()
+}
+
+type orderFulfillmentFixture struct {
+	projection   *orderFulfillmentProjection
+	orchestrator sagas.OrderFulfillmentOrchestrator
+	producer     *mocks.Producer
+	order        *aggregate.Order
+}
+
+func newOrderFulfillmentFixture(t *testing.T, sagaOptions *saga.SagaOptions) *orderFulfillmentFixture {
+	t.Helper()
+
+	order, err := aggregate.NewOrder(
+		uuid.NewV4(),
+		"ORD-000001",
+		[]*value_objects.ShopItem{value_objects.CreateNewShopItem("item", "description", 1, 100)},
+		"john@example.com",
+		"address",
+		time.Now().Add(time.Hour),
+		"",
+		0,
+		time.Now(),
+	)
+	require.NoError(t, err)
+
+	// the order is not stored, so a cancellation of the order fails the test with an unexpected call of the mock
+	orderAggregateStore := esMocks.NewAggregateStore[*aggregate.Order](t)
+	orderAggregateStore.EXPECT().Load(mock.Anything, order.Id()).Return(order, nil).Maybe()
+
+	producer := mocks.NewProducer(t)
+	log := defaultLogger.GetLogger()
+	orchestrator := sagas.NewOrderFulfillmentOrchestrator(
+		saga.NewInMemorySagaStore(),
+		producer,
+		sagaOptions,
+		orderAggregateStore,
+		log,
+	)
+
+	projection := NewOrderFulfillmentProjection(
+		orderAggregateStore,
+		orchestrator,
+		sagaOptions,
+		&exchangerates.ExchangeRateOptions{BaseCurrency: "USD"},
+		log,
+		tracing.NewAppTracer("order-fulfillment-projection-test"),
+	).(*orderFulfillmentProjection)
+
+	return &orderFulfillmentFixture{
+		projection:   projection,
+		orchestrator: orchestrator,
+		producer:     producer,
+		order:        order,
+	}
+}
+
+func (f *orderFulfillmentFixture) processOrderCreated(t *testing.T) {
+	t.Helper()
+
+	event := f.order.UncommittedEvents()[0]
+	err := f.projection.ProcessEvent(context.Background(), &models.StreamEvent{EventID: event.GetEventId(), Event: event})
+	require.NoError(t, err)
+}
+
+func Test_Order_Fulfillment_Is_Not_Started_With_The_Default_Options(t *testing.T) {
+	sagaOptions := &saga.SagaOptions{}
+	defaults.SetDefaults(sagaOptions)
+
+	fixture := newOrderFulfillmentFixture(t, sagaOptions)
+	fixture.processOrderCreated(t)
+
+	// nothing is published and there is no saga to be compensated, so the order is not canceled after the timeout
+	count, err := fixture.orchestrator.CompensateTimedOut(context.Background(), time.Now().Add(24*time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	assert.False(t, fixture.order.Canceled())
+}
+
+func Test_Order_Fulfillment_Is_Started_When_Enabled(t *testing.T) {
+	sagaOptions := &saga.SagaOptions{Enabled: true}
+	defaults.SetDefaults(sagaOptions)
+
+	fixture := newOrderFulfillmentFixture(t, sagaOptions)
+
+	var published types.IMessage
+	fixture.producer.EXPECT().
+		PublishMessage(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(_ context.Context, message types.IMessage, _ metadata.Metadata) { published = message }).
+		Return(nil).
+		Once()
+
+	fixture.processOrderCreated(t)
+
+	// the order has no catalog products, so the stock reservation is skipped and its payment is processed
+	assert.IsType(t, &integrationEvents.ProcessPaymentV1{}, published)
+}
@@ -0,0 +1,213 @@
+package versioning
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/projection"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mongodb"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/data/repositories"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/projections/read_models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/projections"
+
+	"emperror.dev/errors"
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// candidateRunner runs the subscription of a candidate version, the candidate is projected from the start of the
+// event store into its own collection, without publishing the integration events of the orders projection again.
+type candidateRunner struct {
+	db                    *esdb.Client
+	esdbOptions           *config.EventStoreDbOptions
+	esdbSerializer        *eventstroredb.EsdbSerializer
+	checkpointRepository  contracts.SubscriptionCheckpointRepository
+	mongoOptions          *mongodb.MongoDbOptions
+	mongoClient           *mongo.Client
+	log                   logger.Logger
+	tracer                tracing.AppTracer
+	mu                    sync.Mutex
+	cancel                context.CancelFunc
+	done                  chan struct{}
+	position              atomic.Uint64
+	err                   atomic.Pointer[error]
+	runningSubscriptionId string
+}
+
+func newCandidateRunner(
+	db *esdb.Client,
+	esdbOptions *config.EventStoreDbOptions,
+	esdbSerializer *eventstroredb.EsdbSerializer,
+	checkpointRepository contracts.SubscriptionCheckpointRepository,
+	mongoOptions *mongodb.MongoDbOptions,
+	mongoClient *mongo.Client,
+	log logger.Logger,
+	tracer tracing.AppTracer,
+) *candidateRunner {
+	return &candidateRunner{
+		db:                   db,
+		esdbOptions:          esdbOptions,
+		esdbSerializer:       esdbSerializer,
+		checkpointRepository: checkpointRepository,
+		mongoOptions:         mongoOptions,
+		mongoClient:          mongoClient,
+		log:                  log,
+		tracer:               tracer,
+	}
+}
+
+// subscriptionId returns the subscription of a candidate version, every version has its own checkpoint
+func (r *candidateRunner) subscriptionId(collection string) string {
+	return fmt.Sprintf("%s-%s", r.esdbOptions.Subscription.SubscriptionId, collection)
+}
+
+// Start runs the subscription of the candidate, it continues from the checkpoint of the candidate if it was running
+// before a restart
+func (r *candidateRunner) Start(ctx context.Context, candidate *read_models.ProjectionVersionModel) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cancel != nil {
+		return nil
+	}
+
+	checkpoint, err := r.checkpointRepository.Load(candidate.SubscriptionId, ctx)
+	if err != nil {
+		return errors.WrapIf(err, "[candidateRunner_Start.Load] error in loading the candidate checkpoint")
+	}
+	r.position.Store(checkpoint)
+	r.err.Store(nil)
+
+	repository := repositories.NewMongoOrderReadRepository(
+		r.log,
+		r.mongoOptions,
+		r.mongoClient,
+		r.tracer,
+		repositories.StaticOrderCollection(candidate.Collection),
+	)
+	candidateProjection := &positionedProjection{
+		projection: projections.NewMongoOrderProjection(repository, projections.DiscardProducer{}, r.log, r.tracer),
+		position:   &r.position,
+	}
+
+	worker := eventstroredb.NewEsdbSubscriptionAllWorker(
+		r.log,
+		r.db,
+		r.esdbOptions,
+		r.esdbSerializer,
+		r.checkpointRepository,
+		func(builder eventstroredb.ProjectionsBuilder) {
+			builder.AddProjection(candidateProjection)
+		},
+	)
+
+	// the candidate lives until its cutover or abort, so it doesn't use the request context
+	lifetimeCtx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	r.cancel = cancel
+	r.done = done
+	r.runningSubscriptionId = candidate.SubscriptionId
+
+	go func() {
+		defer close(done)
+
+		err := worker.SubscribeAll(lifetimeCtx, &eventstroredb.EventStoreDBSubscriptionToAllOptions{
+			FilterOptions: &esdb.SubscriptionFilter{
+				Type:     esdb.StreamFilterType,
+				Prefixes: r.esdbOptions.Subscription.Prefix,
+			},
+			SubscriptionId: candidate.SubscriptionId,
+			SkipEventBus:   true,
+		})
+		if err != nil && lifetimeCtx.Err() == nil {
+			r.err.Store(&err)
+			r.log.Errorf(
+				"(candidateRunner.Start) error in running subscription '%s' of the candidate: {%v}",
+				candidate.SubscriptionId,
+				err,
+			)
+		}
+	}()
+
+	r.log.Info(fmt.Sprintf("subscription '%s' of the candidate is started.", candidate.SubscriptionId))
+
+	return nil
+}
+
+// Stop stops the subscription and waits for its last event, the returned position is the last projected event of
+// the candidate
+func (r *candidateRunner) Stop() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cancel != nil {
+		r.cancel()
+		<-r.done
+
+		r.log.Info(fmt.Sprintf("subscription '%s' of the candidate is stopped.", r.runningSubscriptionId))
+
+		r.cancel = nil
+		r.done = nil
+		r.runningSubscriptionId = ""
+	}
+
+	return r.position.Load()
+}
+
+// Running reports whether the subscription of the candidate is running in this instance
+func (r *candidateRunner) Running() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.done == nil {
+		return false
+	}
+
+	select {
+	case <-r.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// Position returns the position of the last projected event of the candidate
+func (r *candidateRunner) Position() uint64 {
+	return r.position.Load()
+}
+
+// Err returns the error which stopped the subscription of the candidate
+func (r *candidateRunner) Err() error {
+	if err := r.err.Load(); err != nil {
+		return *err
+	}
+
+	return nil
+}
+
+// positionedProjection keeps the position of the last projected event, the checkpoint of a canceled subscription may
+// be behind its last projected event.
+type positionedProjection struct {
+	projection projection.IProjection
+	position   *atomic.Uint64
+}
+
+func (p *positionedProjection) ProcessEvent(ctx context.Context, streamEvent *models.StreamEvent) error {
+	// a stopping subscription finishes its current event, so the candidate is never partially projected
+	err := p.projection.ProcessEvent(context.WithoutCancel(ctx), streamEvent)
+	if err != nil {
+		return err
+	}
+
+	p.position.Store(uint64(streamEvent.Position))
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package versioning
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/producer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/projection"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/models"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mongodb"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/data/repositories"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/projections"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// mongoOrderProjection projects the events of the active subscription to the collection of the active version, it
+// keeps the type name of the wrapped projection, so the `projection:mongo_order_projection` toggle is unchanged.
+type mongoOrderProjection struct {
+	versioning       *OrderProjectionVersioning
+	mongoOptions     *mongodb.MongoDbOptions
+	mongoClient      *mongo.Client
+	rabbitmqProducer producer.Producer
+	logger           logger.Logger
+	tracer           tracing.AppTracer
+	projections      sync.Map
+}
+
+func NewVersionedMongoOrderProjection(
+	versioning *OrderProjectionVersioning,
+	mongoOptions *mongodb.MongoDbOptions,
+	mongoClient *mongo.Client,
+	rabbitmqProducer producer.Producer,
+	logger logger.Logger,
+	tracer tracing.AppTracer,
+) projection.IProjection {
+	return &mongoOrderProjection{
+		versioning:       versioning,
+		mongoOptions:     mongoOptions,
+		mongoClient:      mongoClient,
+		rabbitmqProducer: rabbitmqProducer,
+		logger:           logger,
+		tracer:           tracer,
+	}
+}
+
+func (m *mongoOrderProjection) ProcessEvent(ctx context.Context, streamEvent *models.StreamEvent) error {
+	collection, err := m.versioning.projectionCollection(ctx, uint64(streamEvent.Position))
+	if err != nil {
+		return err
+	}
+
+	return m.projection(collection).ProcessEvent(ctx, streamEvent)
+}
+
+func (m *mongoOrderProjection) projection(collection string) projection.IProjection {
+	if p, ok := m.projections.Load(collection); ok {
+		return p.(projection.IProjection)
+	}
+
+	p, _ := m.projections.LoadOrStore(collection, projections.NewMongoOrderProjection(
+		repositories.NewMongoOrderReadRepository(
+			m.logger,
+			m.mongoOptions,
+			m.mongoClient,
+			m.tracer,
+			repositories.StaticOrderCollection(collection),
+		),
+		m.rabbitmqProducer,
+		m.logger,
+		m.tracer,
+	))
+
+	return p.(projection.IProjection)
+}
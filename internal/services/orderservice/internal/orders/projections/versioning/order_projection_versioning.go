@@ -0,0 +1,468 @@
+package versioning
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb/config"
+	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mongodb"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	contractsRepositories "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/repositories"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/data/repositories"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/projections/read_models"
+
+	"emperror.dev/errors"
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// OrderProjectionName is the id of the orders projection in the projection versions
+const OrderProjectionName = "orders"
+
+// OrderProjectionVersioning runs the blue/green versions of the mongo orders projection. the active version serves
+// the reads and a candidate version is rebuilt from the start into its own collection, after a cutover request the
+// candidate is handed over to the subscription of the active version when it is caught up, so the switch happens
+// between two events and the integration events are published once.
+type OrderProjectionVersioning struct {
+	repository contractsRepositories.ProjectionVersionsRepository
+	runner     *candidateRunner
+	options    *ProjectionVersioningOptions
+	log        logger.Logger
+	// mu serializes the changes of the versions, the readers use the cached versions without locking
+	mu       sync.Mutex
+	versions atomic.Pointer[read_models.ProjectionVersionsReadModel]
+}
+
+func NewOrderProjectionVersioning(
+	repository contractsRepositories.ProjectionVersionsRepository,
+	options *ProjectionVersioningOptions,
+	db *esdb.Client,
+	esdbOptions *config.EventStoreDbOptions,
+	esdbSerializer *eventstroredb.EsdbSerializer,
+	checkpointRepository contracts.SubscriptionCheckpointRepository,
+	mongoOptions *mongodb.MongoDbOptions,
+	mongoClient *mongo.Client,
+	log logger.Logger,
+	tracer tracing.AppTracer,
+) *OrderProjectionVersioning {
+	return &OrderProjectionVersioning{
+		repository: repository,
+		runner: newCandidateRunner(
+			db,
+			esdbOptions,
+			esdbSerializer,
+			checkpointRepository,
+			mongoOptions,
+			mongoClient,
+			log,
+			tracer,
+		),
+		options: options,
+		log:     log,
+	}
+}
+
+// ActiveCollection returns the orders collection of the active version, it is used by the repositories of the
+// served reads
+func (o *OrderProjectionVersioning) ActiveCollection() string {
+	versions, err := o.current(context.Background())
+	if err != nil {
+		o.log.Errorf("[OrderProjectionVersioning.ActiveCollection] error in loading the projection versions: {%v}", err)
+
+		return repositories.OrderCollection(1)
+	}
+
+	return versions.Active.Collection
+}
+
+// Versions reloads the versions of the orders projection
+func (o *OrderProjectionVersioning) Versions(ctx context.Context) (*read_models.ProjectionVersionsReadModel, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.load(ctx)
+}
+
+// CandidateRunning reports whether the subscription of the candidate is running in this instance
+func (o *OrderProjectionVersioning) CandidateRunning() bool {
+	return o.runner.Running()
+}
+
+// CandidatePosition returns the position of the last projected event of the candidate in this instance
+func (o *OrderProjectionVersioning) CandidatePosition() uint64 {
+	return o.runner.Position()
+}
+
+// CandidateErr returns the error which stopped the subscription of the candidate
+func (o *OrderProjectionVersioning) CandidateErr() error {
+	return o.runner.Err()
+}
+
+// StartVersion creates the next version of the orders projection as the candidate and starts its subscription
+func (o *OrderProjectionVersioning) StartVersion(
+	ctx context.Context,
+	startedBy string,
+) (*read_models.ProjectionVersionModel, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	versions, err := o.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if versions.Candidate != nil {
+		return nil, customErrors.NewConflictError(
+			fmt.Sprintf(
+				"candidate version %d of the orders projection is already %s",
+				versions.Candidate.Version,
+				versions.Candidate.Status,
+			),
+		)
+	}
+
+	changed := versions.Clone()
+	version := changed.NextVersion()
+	collection := repositories.OrderCollection(version)
+
+	// a leftover of a failed start is dropped, so the candidate is built from an empty collection
+	err = o.repository.DropCollection(ctx, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	changed.Candidate = &read_models.ProjectionVersionModel{
+		Version:        version,
+		Collection:     collection,
+		Status:         read_models.ProjectionVersionBuilding,
+		SubscriptionId: o.runner.subscriptionId(collection),
+		StartedBy:      startedBy,
+		CreatedAt:      time.Now(),
+	}
+
+	err = o.save(ctx, changed)
+	if err != nil {
+		return nil, err
+	}
+
+	err = o.runner.Start(ctx, changed.Candidate)
+	if err != nil {
+		return nil, err
+	}
+
+	return changed.Candidate, nil
+}
+
+// RequestCutover marks the candidate for the cutover, it is activated by the subscription of the active version after
+// the candidate catches up with it
+func (o *OrderProjectionVersioning) RequestCutover(
+	ctx context.Context,
+	requestedBy string,
+) (*read_models.ProjectionVersionModel, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	versions, err := o.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if versions.Candidate == nil {
+		return nil, customErrors.NewConflictError("there is no candidate version of the orders projection")
+	}
+	if versions.Candidate.Status != read_models.ProjectionVersionBuilding {
+		return nil, customErrors.NewConflictError(
+			fmt.Sprintf("cutover of candidate version %d is already requested", versions.Candidate.Version),
+		)
+	}
+	if err := o.runner.Err(); err != nil {
+		return nil, customErrors.NewConflictErrorWrap(
+			err,
+			fmt.Sprintf("subscription of candidate version %d is failed", versions.Candidate.Version),
+		)
+	}
+
+	changed := versions.Clone()
+	changed.Candidate.Status = read_models.ProjectionVersionCutoverRequested
+	changed.Candidate.CutoverRequestedBy = requestedBy
+
+	err = o.save(ctx, changed)
+	if err != nil {
+		return nil, err
+	}
+
+	return changed.Candidate, nil
+}
+
+// AbortVersion stops the candidate and drops its collection, the version number is not reused
+func (o *OrderProjectionVersioning) AbortVersion(ctx context.Context) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	versions, err := o.load(ctx)
+	if err != nil {
+		return err
+	}
+
+	if versions.Candidate == nil {
+		return customErrors.NewConflictError("there is no candidate version of the orders projection")
+	}
+
+	o.runner.Stop()
+
+	now := time.Now()
+	changed := versions.Clone()
+	aborted := changed.Candidate
+	aborted.Status = read_models.ProjectionVersionRetired
+	aborted.RetiredAt = &now
+	aborted.CleanupAt = &now
+	changed.Candidate = nil
+	changed.Retired = append(changed.Retired, aborted)
+
+	err = o.save(ctx, changed)
+	if err != nil {
+		return err
+	}
+
+	// a failed drop is retried by the cleanup
+	if err := o.cleanupVersion(ctx, aborted); err != nil {
+		o.log.Errorf("[OrderProjectionVersioning.AbortVersion] error in dropping collection of version %d: {%v}", aborted.Version, err)
+	}
+
+	return nil
+}
+
+// Resume restarts the subscription of a candidate which was building before the restart of the application
+func (o *OrderProjectionVersioning) Resume(ctx context.Context) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	versions, err := o.load(ctx)
+	if err != nil {
+		return err
+	}
+
+	candidate := versions.Candidate
+	if candidate == nil || candidate.Status == read_models.ProjectionVersionHandingOver {
+		return nil
+	}
+
+	return o.runner.Start(ctx, candidate)
+}
+
+// Refresh reloads the versions, so the instances follow the changes of the other instances
+func (o *OrderProjectionVersioning) Refresh(ctx context.Context) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	_, err := o.load(ctx)
+
+	return err
+}
+
+// Cleanup drops the collections of the retired versions after their cleanup time
+func (o *OrderProjectionVersioning) Cleanup(ctx context.Context) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	versions, err := o.load(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	changed := versions.Clone()
+	cleaned := 0
+
+	for _, retired := range changed.Retired {
+		if retired.CleanedUp || retired.CleanupAt == nil || retired.CleanupAt.After(now) {
+			continue
+		}
+
+		if err := o.cleanupVersion(ctx, retired); err != nil {
+			o.log.Errorf("[OrderProjectionVersioning.Cleanup] error in dropping collection of version %d: {%v}", retired.Version, err)
+			continue
+		}
+		cleaned++
+	}
+
+	if cleaned == 0 {
+		return nil
+	}
+
+	return o.save(ctx, changed)
+}
+
+// Stop stops the subscription of the candidate in this instance
+func (o *OrderProjectionVersioning) Stop() {
+	o.runner.Stop()
+}
+
+// projectionCollection returns the collection which an event of the active subscription is projected to, the pending
+// cutover of the candidate is completed here, so the switch happens between two events of the active subscription.
+func (o *OrderProjectionVersioning) projectionCollection(ctx context.Context, position uint64) (string, error) {
+	versions, err := o.current(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if versions.Candidate == nil || versions.Candidate.Status == read_models.ProjectionVersionBuilding {
+		return versions.Active.Collection, nil
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	versions = o.versions.Load()
+	candidate := versions.Candidate
+	if candidate == nil {
+		return versions.Active.Collection, nil
+	}
+
+	switch candidate.Status {
+	case read_models.ProjectionVersionCutoverRequested:
+		// the candidate projected this event already when its position is not behind the event
+		if !o.runner.Running() || o.runner.Position() < position {
+			return versions.Active.Collection, nil
+		}
+
+		handoffPosition := o.runner.Stop()
+
+		changed := versions.Clone()
+		changed.Candidate.Status = read_models.ProjectionVersionHandingOver
+		changed.Candidate.HandoffPosition = handoffPosition
+
+		if err := o.save(ctx, changed); err != nil {
+			o.log.Errorf("[OrderProjectionVersioning.projectionCollection] error in handing over the candidate: {%v}", err)
+
+			// the candidate continues building and the handoff is retried on the next event
+			if err := o.runner.Start(ctx, candidate); err != nil {
+				o.log.Errorf("[OrderProjectionVersioning.projectionCollection] error in restarting the candidate: {%v}", err)
+			}
+
+			return versions.Active.Collection, nil
+		}
+
+		o.log.Infof(
+			"candidate version %d of the orders projection is handed over at position %d",
+			candidate.Version,
+			handoffPosition,
+		)
+
+		// the handoff position is not behind this event, so it is projected to the active version
+		return changed.Active.Collection, nil
+
+	case read_models.ProjectionVersionHandingOver:
+		if position <= candidate.HandoffPosition {
+			return versions.Active.Collection, nil
+		}
+
+		changed := o.promote(versions)
+
+		// the event is not checkpointed, so a failed promotion projects it again after the restart of the subscription
+		if err := o.save(ctx, changed); err != nil {
+			return "", errors.WrapIf(err, "[OrderProjectionVersioning_projectionCollection] error in activating the candidate")
+		}
+
+		o.log.Infof(
+			"version %d of the orders projection is activated, version %d is retired",
+			changed.Active.Version,
+			versions.Active.Version,
+		)
+
+		return changed.Active.Collection, nil
+	}
+
+	return versions.Active.Collection, nil
+}
+
+func (o *OrderProjectionVersioning) promote(
+	versions *read_models.ProjectionVersionsReadModel,
+) *read_models.ProjectionVersionsReadModel {
+	now := time.Now()
+	cleanupAt := now.Add(o.options.CleanupAfter)
+
+	changed := versions.Clone()
+
+	retired := changed.Active
+	retired.Status = read_models.ProjectionVersionRetired
+	retired.RetiredAt = &now
+	retired.CleanupAt = &cleanupAt
+
+	active := changed.Candidate
+	active.Status = read_models.ProjectionVersionActive
+	active.ActivatedAt = &now
+
+	changed.Active = active
+	changed.Candidate = nil
+	changed.Retired = append(changed.Retired, retired)
+
+	return changed
+}
+
+func (o *OrderProjectionVersioning) cleanupVersion(ctx context.Context, version *read_models.ProjectionVersionModel) error {
+	err := o.repository.DropCollection(ctx, version.Collection)
+	if err != nil {
+		return err
+	}
+
+	version.CleanedUp = true
+
+	return nil
+}
+
+// current returns the cached versions, they are loaded on the first use
+func (o *OrderProjectionVersioning) current(ctx context.Context) (*read_models.ProjectionVersionsReadModel, error) {
+	if versions := o.versions.Load(); versions != nil {
+		return versions, nil
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if versions := o.versions.Load(); versions != nil {
+		return versions, nil
+	}
+
+	return o.load(ctx)
+}
+
+// load reads the versions into the cache, the orders projection is served by its first version until a version is
+// saved
+func (o *OrderProjectionVersioning) load(ctx context.Context) (*read_models.ProjectionVersionsReadModel, error) {
+	versions, err := o.repository.GetProjectionVersions(ctx, OrderProjectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	if versions == nil {
+		versions = read_models.NewProjectionVersionsReadModel(OrderProjectionName, repositories.OrderCollection(1))
+	}
+
+	o.versions.Store(versions)
+
+	return versions, nil
+}
+
+func (o *OrderProjectionVersioning) save(ctx context.Context, versions *read_models.ProjectionVersionsReadModel) error {
+	err := o.repository.SaveProjectionVersions(ctx, versions)
+	if err != nil {
+		// the versions may be changed by another instance
+		if _, loadErr := o.load(ctx); loadErr != nil {
+			o.log.Errorf("[OrderProjectionVersioning.save] error in reloading the projection versions: {%v}", loadErr)
+		}
+
+		return err
+	}
+
+	o.versions.Store(versions)
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package versioning
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[ProjectionVersioningOptions]())
+
+// ProjectionVersioningOptions controls the blue/green versions of the orders projection, the collection of a retired
+// version is dropped after CleanupAfter, so the cutover can be checked against the old data before it is removed.
+type ProjectionVersioningOptions struct {
+	CleanupAfter    time.Duration `mapstructure:"cleanupAfter"    default:"24h"`
+	CleanupInterval time.Duration `mapstructure:"cleanupInterval" default:"1h"`
+	// RefreshInterval is the interval of reloading the versions, so the other instances follow a cutover
+	RefreshInterval time.Duration `mapstructure:"refreshInterval" default:"10s"`
+}
+
+func NewProjectionVersioningOptions(environment environment.Environment) (*ProjectionVersioningOptions, error) {
+	return config.BindConfigKey[*ProjectionVersioningOptions](optionName, environment)
+}
@@ -0,0 +1,77 @@
+package versioning
+
+import (
+	"context"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/featuretoggle"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/web"
+
+	"emperror.dev/errors"
+	"go.uber.org/fx"
+)
+
+// CleanupWorkerName is the name of the cleanup of the retired versions in the `worker:projection_cleanup` feature
+// toggle
+const CleanupWorkerName = "projection_cleanup"
+
+// RegisterProjectionVersioningWorker resumes the building candidate on start, reloads the versions on the refresh
+// interval and drops the collections of the retired versions on the cleanup interval while the application is running
+func RegisterProjectionVersioningWorker(
+	lc fx.Lifecycle,
+	versioning *OrderProjectionVersioning,
+	options *ProjectionVersioningOptions,
+	toggles featuretoggle.FeatureToggles,
+	log logger.Logger,
+) error {
+	if options.RefreshInterval <= 0 || options.CleanupInterval <= 0 {
+		return errors.Errorf(
+			"refresh and cleanup intervals of the projection versioning should be positive, got %s and %s",
+			options.RefreshInterval,
+			options.CleanupInterval,
+		)
+	}
+
+	// the hook is appended before the worker, so the versioning is stopped after the worker is stopped
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			versioning.Stop()
+
+			return nil
+		},
+	})
+
+	web.RegisterLifetimeWorker(lc, "projection versioning worker", log, func(ctx context.Context) error {
+		if err := versioning.Resume(ctx); err != nil {
+			log.Errorf("[ProjectionVersioningWorker] error in resuming the candidate version: {%v}", err)
+		}
+
+		refreshTicker := time.NewTicker(options.RefreshInterval)
+		defer refreshTicker.Stop()
+		cleanupTicker := time.NewTicker(options.CleanupInterval)
+		defer cleanupTicker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-refreshTicker.C:
+				if err := versioning.Refresh(ctx); err != nil {
+					log.Errorf("[ProjectionVersioningWorker] error in refreshing the projection versions: {%v}", err)
+				}
+			case <-cleanupTicker.C:
+				if !toggles.IsEnabled(featuretoggle.WorkerToggle(CleanupWorkerName)) {
+					log.Info("[ProjectionVersioningWorker] projection cleanup is disabled by its feature toggle")
+					continue
+				}
+
+				if err := versioning.Cleanup(ctx); err != nil {
+					log.Errorf("[ProjectionVersioningWorker] error in cleaning up the retired versions: {%v}", err)
+				}
+			}
+		}
+	})
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package retention
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"emperror.dev/errors"
+)
+
+// ErrAlreadyArchived is returned when a document with the same key exists, the archived documents are never overwritten
+var ErrAlreadyArchived = errors.NewPlain("document is already archived")
+
+// ArchiveStore is an immutable storage of the archived documents
+type ArchiveStore interface {
+	// Put stores the document with the key and returns its location, it returns ErrAlreadyArchived with the location of
+	// the existing document when the key exists
+	Put(ctx context.Context, key string, document []byte) (string, error)
+}
+
+type fileArchiveStore struct {
+	directory string
+}
+
+// NewFileArchiveStore creates an archive store on the archive directory, the documents are written to a temporary file
+// and linked to their key, so a document is never partially written or replaced
+func NewFileArchiveStore(options *RetentionOptions) ArchiveStore {
+	return &fileArchiveStore{directory: options.ArchiveDirectory}
+}
+
+func (f *fileArchiveStore) Put(ctx context.Context, key string, document []byte) (string, error) {
+	location := filepath.Join(f.directory, filepath.FromSlash(key))
+
+	if err := os.MkdirAll(filepath.Dir(location), 0o755); err != nil {
+		return "", errors.WrapIf(err, "[fileArchiveStore_Put.MkdirAll] error in creating archive directory")
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(location), ".archive-*")
+	if err != nil {
+		return "", errors.WrapIf(err, "[fileArchiveStore_Put.CreateTemp] error in creating temporary archive file")
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(document)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", errors.WrapIf(err, "[fileArchiveStore_Put.Write] error in writing archive file")
+	}
+
+	if err = os.Chmod(tmp.Name(), 0o444); err != nil {
+		return "", errors.WrapIf(err, "[fileArchiveStore_Put.Chmod] error in making archive file read-only")
+	}
+
+	// unlike rename, link fails when the target exists
+	if err = os.Link(tmp.Name(), location); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return location, ErrAlreadyArchived
+		}
+
+		return "", errors.WrapIf(err, "[fileArchiveStore_Put.Link] error in linking archive file")
+	}
+
+	return location, nil
+}
@@ -0,0 +1,31 @@
+package retention
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_File_Archive_Store_Does_Not_Overwrite_Documents(t *testing.T) {
+	t.Parallel()
+
+	store := NewFileArchiveStore(&RetentionOptions{ArchiveDirectory: t.TempDir()})
+
+	location, err := store.Put(context.Background(), "orders/2024/01/order.json", []byte(`{"version":1}`))
+	require.NoError(t, err)
+
+	existing, err := store.Put(context.Background(), "orders/2024/01/order.json", []byte(`{"version":2}`))
+	assert.ErrorIs(t, err, ErrAlreadyArchived)
+	assert.Equal(t, location, existing)
+
+	document, err := os.ReadFile(location)
+	require.NoError(t, err)
+	assert.Equal(t, `{"version":1}`, string(document))
+
+	info, err := os.Stat(location)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o444), info.Mode().Perm())
+}
@@ -0,0 +1,41 @@
+package retention
+
+import (
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[RetentionOptions]())
+
+// RetentionOptions controls the retention worker, the finalized orders are archived after ArchiveAfterDays and the
+// personal data of the archived orders is purged after PurgeAfterDays, unless they are under legal hold.
+type RetentionOptions struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Policy is recorded in the purge events, so the applied policy of a purge is known in the audits
+	Policy           string        `mapstructure:"policy"           default:"default"`
+	Interval         time.Duration `mapstructure:"interval"         default:"1h"`
+	ArchiveAfterDays int           `mapstructure:"archiveAfterDays" default:"30"`
+	PurgeAfterDays   int           `mapstructure:"purgeAfterDays"   default:"730"`
+	BatchSize        int           `mapstructure:"batchSize"        default:"100"`
+	// ArchiveDirectory is the root of the file archive store, it should be a write-once (WORM) mount in production
+	ArchiveDirectory string `mapstructure:"archiveDirectory" default:"./archive"`
+}
+
+func NewRetentionOptions(environment environment.Environment) (*RetentionOptions, error) {
+	return config.BindConfigKey[*RetentionOptions](optionName, environment)
+}
+
+// ArchiveBefore returns the creation time before which the finalized orders are archived
+func (o *RetentionOptions) ArchiveBefore(now time.Time) time.Time {
+	return now.AddDate(0, 0, -o.ArchiveAfterDays)
+}
+
+// PurgeBefore returns the creation time before which the personal data of the archived orders is purged
+func (o *RetentionOptions) PurgeBefore(now time.Time) time.Time {
+	return now.AddDate(0, 0, -o.PurgeAfterDays)
+}
@@ -0,0 +1,240 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/store"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/featuretoggle"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/web"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/repositories"
+	getOrderEventsDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_order_events/v1/dtos"
+	getOrderEventsQueryV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_order_events/v1/queries"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/aggregate"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/read_models"
+
+	"emperror.dev/errors"
+	"github.com/goccy/go-json"
+	uuid "github.com/satori/go.uuid"
+	"go.uber.org/fx"
+)
+
+// ArchivedOrderDocument is the exported document of an order in the archive store
+type ArchivedOrderDocument struct {
+	OrderId    string                                `json:"orderId"`
+	Policy     string                                `json:"policy"`
+	ArchivedAt time.Time                             `json:"archivedAt"`
+	Order      *read_models.OrderReadModel           `json:"order"`
+	Events     []*getOrderEventsDtosV1.OrderEventDto `json:"events"`
+}
+
+// RetentionWorker archives the finalized orders and purges the personal data of the archived orders, the read model
+// is used to find the orders and every change is applied on the order aggregate, so a lagging read model doesn't
+// purge an order under legal hold.
+type RetentionWorker struct {
+	options             *RetentionOptions
+	retentionRepository repositories.OrderRetentionRepository
+	aggregateStore      store.AggregateStore[*aggregate.Order]
+	archiveStore        ArchiveStore
+	logger              logger.Logger
+	tracer              tracing.AppTracer
+}
+
+func NewRetentionWorker(
+	options *RetentionOptions,
+	retentionRepository repositories.OrderRetentionRepository,
+	aggregateStore store.AggregateStore[*aggregate.Order],
+	archiveStore ArchiveStore,
+	logger logger.Logger,
+	tracer tracing.AppTracer,
+) *RetentionWorker {
+	return &RetentionWorker{
+		options:             options,
+		retentionRepository: retentionRepository,
+		aggregateStore:      aggregateStore,
+		archiveStore:        archiveStore,
+		logger:              logger,
+		tracer:              tracer,
+	}
+}
+
+// Run archives and purges a batch of orders
+func (r *RetentionWorker) Run(ctx context.Context) error {
+	ctx, span := r.tracer.Start(ctx, "RetentionWorker.Run")
+	defer span.End()
+
+	now := time.Now()
+
+	err := r.archive(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	return r.purge(ctx, now)
+}
+
+func (r *RetentionWorker) archive(ctx context.Context, now time.Time) error {
+	orders, err := r.retentionRepository.GetOrdersToArchive(ctx, r.options.ArchiveBefore(now), r.options.BatchSize)
+	if err != nil {
+		return errors.WrapIf(err, "[RetentionWorker_archive.GetOrdersToArchive] error in getting orders to archive")
+	}
+
+	for _, order := range orders {
+		// a failed order is retried in the next run, so it doesn't stop the other orders of the batch
+		if err := r.archiveOrder(ctx, order, now); err != nil {
+			r.logger.Errorw(
+				fmt.Sprintf("[RetentionWorker_archive] error in archiving order with id: {%s}, err: %v", order.OrderId, err),
+				logger.Fields{"Id": order.OrderId},
+			)
+		}
+	}
+
+	return nil
+}
+
+func (r *RetentionWorker) archiveOrder(ctx context.Context, orderRead *read_models.OrderReadModel, now time.Time) error {
+	orderId, err := uuid.FromString(orderRead.OrderId)
+	if err != nil {
+		return err
+	}
+
+	order, err := r.aggregateStore.Load(ctx, orderId)
+	if err != nil {
+		return err
+	}
+	if order.Archived() {
+		return nil
+	}
+
+	query, err := getOrderEventsQueryV1.NewGetOrderEvents(orderId)
+	if err != nil {
+		return err
+	}
+
+	events, err := cqrs.Send[*getOrderEventsQueryV1.GetOrderEvents, *getOrderEventsDtosV1.GetOrderEventsResponseDto](
+		ctx,
+		query,
+	)
+	if err != nil {
+		return err
+	}
+
+	document, err := json.Marshal(&ArchivedOrderDocument{
+		OrderId:    orderRead.OrderId,
+		Policy:     r.options.Policy,
+		ArchivedAt: now,
+		Order:      orderRead,
+		Events:     events.Events,
+	})
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("orders/%s/%s.json", orderRead.CreatedAt.Format("2006/01"), orderRead.OrderId)
+
+	// an existing document is from a previous run which failed before storing the archived event
+	location, err := r.archiveStore.Put(ctx, key, document)
+	if err != nil && !errors.Is(err, ErrAlreadyArchived) {
+		return err
+	}
+
+	err = order.Archive(location, now)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.aggregateStore.Store(order, nil, ctx)
+	if err != nil {
+		return err
+	}
+
+	r.logger.Infow(
+		fmt.Sprintf("[RetentionWorker.archiveOrder] order with id: {%s} archived", orderRead.OrderId),
+		logger.Fields{"Id": orderRead.OrderId, "Location": location},
+	)
+
+	return nil
+}
+
+func (r *RetentionWorker) purge(ctx context.Context, now time.Time) error {
+	orders, err := r.retentionRepository.GetOrdersToPurge(ctx, r.options.PurgeBefore(now), r.options.BatchSize)
+	if err != nil {
+		return errors.WrapIf(err, "[RetentionWorker_purge.GetOrdersToPurge] error in getting orders to purge")
+	}
+
+	for _, order := range orders {
+		if err := r.purgeOrder(ctx, order, now); err != nil {
+			r.logger.Errorw(
+				fmt.Sprintf("[RetentionWorker_purge] error in purging order with id: {%s}, err: %v", order.OrderId, err),
+				logger.Fields{"Id": order.OrderId},
+			)
+		}
+	}
+
+	return nil
+}
+
+func (r *RetentionWorker) purgeOrder(ctx context.Context, orderRead *read_models.OrderReadModel, now time.Time) error {
+	orderId, err := uuid.FromString(orderRead.OrderId)
+	if err != nil {
+		return err
+	}
+
+	order, err := r.aggregateStore.Load(ctx, orderId)
+	if err != nil {
+		return err
+	}
+
+	// the read model may be behind the aggregate
+	if order.LegalHold() || order.PersonalDataPurged() || !order.Archived() {
+		return nil
+	}
+
+	err = order.PurgePersonalData(r.options.Policy, now)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.aggregateStore.Store(order, nil, ctx)
+	if err != nil {
+		return err
+	}
+
+	r.logger.Infow(
+		fmt.Sprintf("[RetentionWorker.purgeOrder] personal data of order with id: {%s} purged", orderRead.OrderId),
+		logger.Fields{"Id": orderRead.OrderId, "Policy": r.options.Policy},
+	)
+
+	return nil
+}
+
+// WorkerName is the name of the retention worker in the `worker:retention` feature toggle
+const WorkerName = "retention"
+
+// RegisterRetentionWorker runs the retention worker on the interval of the options while the application is running,
+// the runs are skipped while the worker is disabled by its feature toggle
+func RegisterRetentionWorker(
+	lc fx.Lifecycle,
+	worker *RetentionWorker,
+	options *RetentionOptions,
+	toggles featuretoggle.FeatureToggles,
+	log logger.Logger,
+) error {
+	if !options.Enabled {
+		return nil
+	}
+
+	return web.RegisterPeriodicWorker(lc, "retention worker", options.Interval, log, func(ctx context.Context) error {
+		if !toggles.IsEnabled(featuretoggle.WorkerToggle(WorkerName)) {
+			log.Info("[RetentionWorker] retention worker is disabled by its feature toggle")
+
+			return nil
+		}
+
+		return worker.Run(ctx)
+	})
+}
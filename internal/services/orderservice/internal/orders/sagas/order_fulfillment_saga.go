@@ -0,0 +1,235 @@
+package sagas
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/producer"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/types"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/store"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/exchangerates"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/saga"
+	integrationEvents "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/fulfilling_order/v1/events/integration_events"
+	externalEvents "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/fulfilling_order/v1/events/integration_events/external_events"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/aggregate"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	OrderFulfillmentSagaName = "order_fulfillment"
+	orderFulfillmentCanceler = "order_fulfillment_saga"
+)
+
+// OrderFulfillmentState is the state of the order fulfillment saga, it is saved with the saga after each step
+type OrderFulfillmentState struct {
+	OrderId       string                         `json:"orderId"`
+	Items         []*integrationEvents.StockItem `json:"items"`
+	Amount        float64                        `json:"amount"`
+	Currency      string                         `json:"currency"`
+	ReservationId string                         `json:"reservationId"`
+	PaymentId     string                         `json:"paymentId"`
+}
+
+type OrderFulfillmentOrchestrator = *saga.Orchestrator[OrderFulfillmentState]
+
+type orderFulfillmentSaga struct {
+	orderAggregateStore store.AggregateStore[*aggregate.Order]
+	log                 logger.Logger
+}
+
+// NewOrderFulfillmentOrchestrator creates the orchestrator of the order fulfillment saga, the saga reserves the stock
+// of the catalog products of the order in the catalogs service, processes the payment of the order in the payments
+// service and marks the order as paid. the order is canceled when the stock can't be reserved or the payment fails.
+func NewOrderFulfillmentOrchestrator(
+	store saga.SagaStore,
+	producer producer.Producer,
+	options *saga.SagaOptions,
+	orderAggregateStore store.AggregateStore[*aggregate.Order],
+	log logger.Logger,
+) OrderFulfillmentOrchestrator {
+	s := &orderFulfillmentSaga{orderAggregateStore: orderAggregateStore, log: log}
+
+	definition := saga.NewDefinition[OrderFulfillmentState](OrderFulfillmentSagaName)
+	definition.Step("reserve_stock").
+		Invoke(s.reserveStock).
+		OnReply(&externalEvents.StockReservedV1{}, s.onStockReserved).
+		OnFailure(&externalEvents.StockReservationFailedV1{}, nil).
+		Compensate(s.releaseStock)
+	definition.Step("process_payment").
+		Invoke(s.processPayment).
+		OnReply(&externalEvents.PaymentCompletedV1{}, s.onPaymentCompleted).
+		OnFailure(&externalEvents.PaymentFailedV1{}, nil).
+		Compensate(s.refundPayment)
+	definition.Step("confirm_order").
+		Invoke(s.confirmOrder)
+	definition.OnCompensated(s.cancelOrder)
+
+	return saga.NewOrchestrator(definition, store, producer, options, log)
+}
+
+// NewOrderFulfillmentState creates the state of the saga from the order, the payment amount is the total of the order
+// without its gift card redemption in the currency of the order
+func NewOrderFulfillmentState(
+	order *aggregate.Order,
+	exchangeRateOptions *exchangerates.ExchangeRateOptions,
+) *OrderFulfillmentState {
+	var items []*integrationEvents.StockItem
+	for _, item := range order.ShopItems() {
+		if item.ProductId() == "" {
+			continue
+		}
+
+		items = append(items, &integrationEvents.StockItem{ProductId: item.ProductId(), Quantity: item.Quantity()})
+	}
+
+	currency := order.Currency()
+	if currency == "" {
+		currency = exchangeRateOptions.BaseCurrency
+	}
+
+	return &OrderFulfillmentState{
+		OrderId:  order.Id().String(),
+		Items:    items,
+		Amount:   order.TotalPrice() - order.GiftCardAmount(),
+		Currency: currency,
+	}
+}
+
+// reserveStock is skipped for the orders without catalog products
+func (s *orderFulfillmentSaga) reserveStock(_ context.Context, state *OrderFulfillmentState) (types.IMessage, error) {
+	if len(state.Items) == 0 {
+		return nil, nil
+	}
+
+	return integrationEvents.NewReserveStockV1(state.OrderId, state.Items), nil
+}
+
+func (s *orderFulfillmentSaga) onStockReserved(
+	_ context.Context,
+	state *OrderFulfillmentState,
+	reply types.IMessage,
+) error {
+	if stockReserved, ok := reply.(*externalEvents.StockReservedV1); ok {
+		state.ReservationId = stockReserved.ReservationId
+	}
+
+	return nil
+}
+
+// releaseStock releases the stock of the order, the reservation id is empty when the reservation is timed out, so the
+// catalogs service releases the reservations of the order
+func (s *orderFulfillmentSaga) releaseStock(_ context.Context, state *OrderFulfillmentState) (types.IMessage, error) {
+	if len(state.Items) == 0 {
+		return nil, nil
+	}
+
+	return integrationEvents.NewReleaseStockV1(state.OrderId, state.ReservationId), nil
+}
+
+// processPayment is skipped for the orders which are paid by their gift card
+func (s *orderFulfillmentSaga) processPayment(
+	_ context.Context,
+	state *OrderFulfillmentState,
+) (types.IMessage, error) {
+	if state.Amount <= 0 {
+		return nil, nil
+	}
+
+	return integrationEvents.NewProcessPaymentV1(state.OrderId, state.Amount, state.Currency), nil
+}
+
+func (s *orderFulfillmentSaga) onPaymentCompleted(
+	_ context.Context,
+	state *OrderFulfillmentState,
+	reply types.IMessage,
+) error {
+	if paymentCompleted, ok := reply.(*externalEvents.PaymentCompletedV1); ok {
+		state.PaymentId = paymentCompleted.PaymentId
+	}
+
+	return nil
+}
+
+// refundPayment refunds the payment of the order, the payment id is empty when the payment is timed out, so the
+// payments service refunds the payments of the order
+func (s *orderFulfillmentSaga) refundPayment(_ context.Context, state *OrderFulfillmentState) (types.IMessage, error) {
+	if state.Amount <= 0 {
+		return nil, nil
+	}
+
+	return integrationEvents.NewRefundPaymentV1(state.OrderId, state.PaymentId), nil
+}
+
+// confirmOrder marks the order as paid, an order which is paid by a redelivered reply is not paid again
+func (s *orderFulfillmentSaga) confirmOrder(ctx context.Context, state *OrderFulfillmentState) (types.IMessage, error) {
+	order, err := s.loadOrder(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.Paid() {
+		return nil, nil
+	}
+
+	err = order.Pay(uuid.FromStringOrNil(state.PaymentId), time.Now())
+	if err != nil {
+		return nil, errors.WrapIf(err, "[orderFulfillmentSaga_confirmOrder.Pay] error in paying the order")
+	}
+
+	if _, err = s.orderAggregateStore.Store(order, nil, ctx); err != nil {
+		return nil, errors.WrapIf(err, "[orderFulfillmentSaga_confirmOrder.Store] error in storing order aggregate")
+	}
+
+	s.log.Infow(
+		fmt.Sprintf("[orderFulfillmentSaga.confirmOrder] order with id: {%s} is paid", state.OrderId),
+		logger.Fields{"OrderId": state.OrderId, "PaymentId": state.PaymentId},
+	)
+
+	return nil, nil
+}
+
+// cancelOrder cancels the order of a compensated saga, an order which is canceled in the meantime is not canceled again
+func (s *orderFulfillmentSaga) cancelOrder(ctx context.Context, state *OrderFulfillmentState, reason string) error {
+	order, err := s.loadOrder(ctx, state)
+	if err != nil {
+		return err
+	}
+
+	if order.Canceled() {
+		return nil
+	}
+
+	err = order.ForceCancel(fmt.Sprintf("order fulfillment is failed, %s", reason), orderFulfillmentCanceler, time.Now())
+	if err != nil {
+		return errors.WrapIf(err, "[orderFulfillmentSaga_cancelOrder.ForceCancel] error in canceling the order")
+	}
+
+	if _, err = s.orderAggregateStore.Store(order, nil, ctx); err != nil {
+		return errors.WrapIf(err, "[orderFulfillmentSaga_cancelOrder.Store] error in storing order aggregate")
+	}
+
+	s.log.Infow(
+		fmt.Sprintf("[orderFulfillmentSaga.cancelOrder] order with id: {%s} is canceled, %s", state.OrderId, reason),
+		logger.Fields{"OrderId": state.OrderId},
+	)
+
+	return nil
+}
+
+func (s *orderFulfillmentSaga) loadOrder(ctx context.Context, state *OrderFulfillmentState) (*aggregate.Order, error) {
+	orderId, err := uuid.FromString(state.OrderId)
+	if err != nil {
+		return nil, errors.WrapIf(err, "[orderFulfillmentSaga_loadOrder.FromString] invalid order id")
+	}
+
+	order, err := s.orderAggregateStore.Load(ctx, orderId)
+	if err != nil {
+		return nil, errors.WrapIf(err, "[orderFulfillmentSaga_loadOrder.Load] error in loading order aggregate")
+	}
+
+	return order, nil
+}
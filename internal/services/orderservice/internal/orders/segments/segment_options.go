@@ -0,0 +1,20 @@
+package segments
+
+import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/config/environment"
+	typeMapper "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[SegmentOptions]())
+
+type SegmentOptions struct {
+	// VipLifetimeValue is the total price of the not canceled orders which makes a customer vip
+	VipLifetimeValue float64 `mapstructure:"vipLifetimeValue" default:"1000"`
+}
+
+func NewSegmentOptions(environment environment.Environment) (*SegmentOptions, error) {
+	return config.BindConfigKey[*SegmentOptions](optionName, environment)
+}
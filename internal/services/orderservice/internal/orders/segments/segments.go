@@ -0,0 +1,79 @@
+package segments
+
+import (
+	"sort"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/segments/read_models"
+)
+
+const (
+	// FirstTimeBuyer is a customer with exactly one not canceled order
+	FirstTimeBuyer = "first-time-buyer"
+	// Vip is a customer with a lifetime value of at least the `VipLifetimeValue` option
+	Vip = "vip"
+)
+
+// All is the list of the known segments
+var All = []string{FirstTimeBuyer, Vip}
+
+// IsKnown returns true for the known segments
+func IsKnown(segment string) bool {
+	for _, s := range All {
+		if s == segment {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Recalculate updates the derived values and the segments of the customer from its orders and returns the added and
+// removed segments
+func Recalculate(
+	customer *read_models.CustomerSegmentsReadModel,
+	options *SegmentOptions,
+) (added []string, removed []string) {
+	customer.OrdersCount = 0
+	customer.LifetimeValue = 0
+	for _, order := range customer.Orders {
+		if order.Canceled {
+			continue
+		}
+		customer.OrdersCount++
+		customer.LifetimeValue += order.TotalPrice
+	}
+
+	var segments []string
+	if customer.OrdersCount == 1 {
+		segments = append(segments, FirstTimeBuyer)
+	}
+	if options.VipLifetimeValue > 0 && customer.LifetimeValue >= options.VipLifetimeValue {
+		segments = append(segments, Vip)
+	}
+	sort.Strings(segments)
+
+	added = difference(segments, customer.Segments)
+	removed = difference(customer.Segments, segments)
+	customer.Segments = segments
+
+	return added, removed
+}
+
+func difference(a []string, b []string) []string {
+	var result []string
+	for _, item := range a {
+		found := false
+		for _, other := range b {
+			if item == other {
+				found = true
+
+				break
+			}
+		}
+		if !found {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,56 @@
+package segments
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/segments/read_models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var options = &SegmentOptions{VipLifetimeValue: 1000}
+
+func Test_Recalculate_First_Time_Buyer(t *testing.T) {
+	customer := read_models.NewCustomerSegmentsReadModel("John@Example.com")
+	assert.Equal(t, "john@example.com", customer.Id)
+
+	assert.True(t, customer.AddOrder("order-1", 100, time.Now()))
+	added, removed := Recalculate(customer, options)
+
+	assert.Equal(t, []string{FirstTimeBuyer}, customer.Segments)
+	assert.Equal(t, []string{FirstTimeBuyer}, added)
+	assert.Empty(t, removed)
+}
+
+func Test_Recalculate_Vip(t *testing.T) {
+	customer := read_models.NewCustomerSegmentsReadModel("john@example.com")
+	customer.AddOrder("order-1", 100, time.Now())
+	Recalculate(customer, options)
+
+	customer.AddOrder("order-2", 900, time.Now())
+	added, removed := Recalculate(customer, options)
+
+	assert.Equal(t, 2, customer.OrdersCount)
+	assert.Equal(t, float64(1000), customer.LifetimeValue)
+	assert.Equal(t, []string{Vip}, customer.Segments)
+	assert.Equal(t, []string{Vip}, added)
+	assert.Equal(t, []string{FirstTimeBuyer}, removed)
+}
+
+func Test_Recalculate_Ignores_Canceled_And_Replayed_Orders(t *testing.T) {
+	customer := read_models.NewCustomerSegmentsReadModel("john@example.com")
+	customer.AddOrder("order-1", 600, time.Now())
+	customer.AddOrder("order-2", 600, time.Now())
+	assert.False(t, customer.AddOrder("order-2", 600, time.Now()))
+	Recalculate(customer, options)
+	assert.Equal(t, []string{Vip}, customer.Segments)
+
+	assert.True(t, customer.CancelOrder("order-2"))
+	assert.False(t, customer.CancelOrder("order-2"))
+	added, removed := Recalculate(customer, options)
+
+	assert.Equal(t, []string{FirstTimeBuyer}, customer.Segments)
+	assert.Equal(t, []string{FirstTimeBuyer}, added)
+	assert.Equal(t, []string{Vip}, removed)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/bus"
 	config2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/config"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/test/containers/testcontainer/eventstoredb"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/test/containers/testcontainer/gorm"
 	mongo2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/test/containers/testcontainer/mongo"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/test/containers/testcontainer/rabbitmq"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/test/containers/testcontainer/redis"
@@ -64,6 +65,7 @@ func (a *TestApp) Run(t *testing.T) (result *TestAppResult) {
 	appBuilder.Decorate(eventstoredb.EventstoreDBContainerOptionsDecorator(t, lifetimeCtx))
 	appBuilder.Decorate(mongo2.MongoContainerOptionsDecorator(t, lifetimeCtx))
 	appBuilder.Decorate(redis.RedisContainerOptionsDecorator(t, lifetimeCtx))
+	appBuilder.Decorate(gorm.GormContainerOptionsDecorator(t, lifetimeCtx))
 
 	testApp := appBuilder.Build()
 
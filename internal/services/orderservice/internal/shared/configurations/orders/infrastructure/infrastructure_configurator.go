@@ -1,6 +1,7 @@
 package infrastructure
 
 import (
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/fxapp/contracts"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
 	loggingpipelines "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/pipelines"
@@ -8,8 +9,6 @@ import (
 	metricspipelines "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/metrics/mediatr/pipelines"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
 	tracingpipelines "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing/mediatr/pipelines"
-
-	"github.com/mehdihadeli/go-mediatr"
 )
 
 type InfrastructureConfigurator struct {
@@ -27,7 +26,7 @@ func NewInfrastructureConfigurator(
 func (ic *InfrastructureConfigurator) ConfigInfrastructures() {
 	ic.ResolveFunc(
 		func(l logger.Logger, tracer tracing.AppTracer, metrics metrics.AppMetrics) error {
-			err := mediatr.RegisterRequestPipelineBehaviors(
+			err := cqrs.RegisterRequestPipelineBehaviors(
 				loggingpipelines.NewMediatorLoggingPipeline(l),
 				tracingpipelines.NewMediatorTracingPipeline(
 					tracer,
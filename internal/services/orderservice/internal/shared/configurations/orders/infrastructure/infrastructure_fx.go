@@ -2,18 +2,42 @@ package infrastructure
 
 import (
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/changefeed"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/commandbus"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/pipeline"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/messaging/quarantine"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/serializer/schemaregistry"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/deprecation"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/diagnostics"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/elasticsearch"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/es/contracts/store"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/eventstroredb"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/exchangerates"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/featuretoggle"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/grpc"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/health"
 	customEcho "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
+	loggingpipelines "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger/pipelines"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/messagebroker"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mongodb"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/metrics"
+	messagingmetricspipelines "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/metrics/messaging/pipeline"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/otel/tracing"
-	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresgorm"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/postgresmessaging"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/rabbitmq/configurations"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/saga"
+	validationpipeline "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/validation/pipeline"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/warmup"
 	rabbitmq2 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/configurations/rabbitmq"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/params"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/contracts/repositories"
+	fulfillOrderExternalEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/fulfilling_order/v1/events/integration_events/external_events"
+	redeemGiftCardIntegrationEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/redeeming_gift_card/v1/events/integration_events"
+	syncPriceListsExternalEventsV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/syncing_price_lists/v1/events/integration_events/external_events"
+	giftCardAggregate "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/giftcards/aggregate"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/models/orders/aggregate"
 
 	"github.com/go-playground/validator"
 	"go.uber.org/fx"
@@ -25,25 +49,82 @@ var Module = fx.Module(
 	"infrastructurefx",
 	// Modules
 	core.Module,
+	schemaregistry.Module,
 	customEcho.Module,
 	grpc.Module,
+	diagnostics.Module,
+	deprecation.Module,
 	mongodb.Module,
+	mongodb.CommandStatusModule,
+	mongodb.SequenceModule,
+	commandbus.Module,
 	elasticsearch.Module,
+	featuretoggle.Module,
+	exchangerates.Module,
+	postgresgorm.Module,
+	postgresgorm.SagaModule,
+	// the messages which are not published by the projections are published through the outbox, the projections
+	// publish after their events are stored and are retried from their esdb checkpoints
+	postgresmessaging.Module,
+	// the checkpoints of the esdb subscriptions are kept in postgres, so the projections resume after a redeploy
+	postgresgorm.CheckpointModule,
+	saga.Module,
 	eventstroredb.ModuleFunc(
 		func(params params.OrderProjectionParams) eventstroredb.ProjectionBuilderFuc {
 			return func(builder eventstroredb.ProjectionsBuilder) {
-				builder.AddProjections(params.Projections)
+				// the rebuildable projections are paused while their read models are rebuilt by the projection manager
+				builder.AddProjections(
+					params.ProjectionManager.Manage(
+						featuretoggle.NewToggledProjections(params.FeatureToggles, params.Logger, params.Projections...)...,
+					),
+				)
 			}
 		},
 	),
-	rabbitmq.ModuleFunc(
-		func() configurations.RabbitMQConfigurationBuilderFuc {
+	// the events of the order streams are the change feed of the orders
+	eventstroredb.ChangeFeedModule,
+	changefeed.Module,
+	messagebroker.ModuleFunc(
+		func(
+			l logger.Logger,
+			commandStatusStore commandbus.CommandStatusStore,
+			priceListRepository repositories.PriceListRepository,
+			orderAggregateStore store.AggregateStore[*aggregate.Order],
+			giftCardAggregateStore store.AggregateStore[*giftCardAggregate.GiftCard],
+			tracer tracing.AppTracer,
+			appMetrics metrics.AppMetrics,
+			deprecations deprecation.Registry,
+		) configurations.RabbitMQConfigurationBuilderFuc {
 			return func(builder configurations.RabbitMQConfigurationBuilder) {
-				rabbitmq2.ConfigOrdersRabbitMQ(builder)
+				// the pipelines of all the consumers of the bus, like the pipeline behaviors of the mediator
+				builder.AddPipelines(
+					pipeline.NewRecoveryPipeline(l),
+					loggingpipelines.NewConsumerLoggingPipeline(l),
+					messagingmetricspipelines.NewMessagingMetricsPipeline(appMetrics),
+					validationpipeline.NewConsumerValidationPipeline(l),
+					deprecation.NewConsumerPipeline(deprecations),
+				)
+
+				rabbitmq2.ConfigOrdersRabbitMQ(
+					builder,
+					commandbus.NewAcceptedCommandHandler(commandStatusStore, l),
+					syncPriceListsExternalEventsV1.NewPriceListCreatedConsumer(l, priceListRepository, tracer),
+					fulfillOrderExternalEventsV1.NewOrderFulfillmentReplyConsumer(l, tracer),
+					redeemGiftCardIntegrationEventsV1.NewCheckGiftCardRedemptionConsumer(
+						l,
+						orderAggregateStore,
+						giftCardAggregateStore,
+						tracer,
+					),
+				)
 			}
 		},
+		// the messages which are failed after the max retries of the consumers are quarantined in postgres
+		postgresgorm.QuarantineModule,
+		quarantine.Module,
 	),
 	health.Module,
+	warmup.Module,
 	tracing.Module,
 	metrics.Module,
 
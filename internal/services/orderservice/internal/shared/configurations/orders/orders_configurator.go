@@ -4,8 +4,12 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/deprecation"
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/featuretoggle"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/fxapp/contracts"
 	echocontracts "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/contracts"
+	deprecationmiddleware "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/deprecation"
+	featuretogglemiddleware "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/customecho/middlewares/feature_toggle"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/config"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/configurations"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/shared/configurations/orders/infrastructure"
@@ -48,8 +52,17 @@ func (ic *OrdersServiceConfigurator) ConfigureOrders() {
 func (ic *OrdersServiceConfigurator) MapOrdersEndpoints() {
 	// Shared
 	ic.ResolveFunc(
-		func(ordersServer echocontracts.EchoHttpServer, cfg *config.Config) error {
+		func(
+			ordersServer echocontracts.EchoHttpServer,
+			cfg *config.Config,
+			toggles featuretoggle.FeatureToggles,
+			deprecations deprecation.Registry,
+		) error {
 			ordersServer.SetupDefaultMiddlewares()
+			ordersServer.AddMiddlewares(
+				featuretogglemiddleware.FeatureToggle(toggles),
+				deprecationmiddleware.Deprecation(deprecations),
+			)
 
 			// config orders root endpoint
 			ordersServer.RouteBuilder().
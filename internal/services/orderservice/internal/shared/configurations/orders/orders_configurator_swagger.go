@@ -9,11 +9,14 @@ import (
 )
 
 func (ic *OrdersServiceConfigurator) configSwagger(routeBuilder *customEcho.RouteBuilder) {
-	docs.SwaggerInfo.Version = "1.0"
-	docs.SwaggerInfo.Title = "Orders Service Api"
-	docs.SwaggerInfo.Description = "Orders Service Api."
+	docs.SwaggerInfoorderservice.Version = "1.0"
+	docs.SwaggerInfoorderservice.Title = "Orders Service Api"
+	docs.SwaggerInfoorderservice.Description = "Orders Service Api."
 
 	routeBuilder.RegisterRoutes(func(e *echo.Echo) {
-		e.GET("/swagger/*", echoSwagger.WrapHandler)
+		e.GET(
+			"/swagger/*",
+			echoSwagger.EchoWrapHandler(echoSwagger.InstanceName(docs.SwaggerInfoorderservice.InstanceName())),
+		)
 	})
 }
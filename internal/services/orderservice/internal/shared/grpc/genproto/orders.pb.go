@@ -1069,6 +1069,171 @@ func (x *Pagination) GetHasMore() bool {
 	return false
 }
 
+type GetOrdersByIdsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ids []string `protobuf:"bytes,1,rep,name=Ids,proto3" json:"Ids,omitempty"`
+}
+
+func (x *GetOrdersByIdsReq) Reset() {
+	*x = GetOrdersByIdsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_order_service_orders_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetOrdersByIdsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrdersByIdsReq) ProtoMessage() {}
+
+func (x *GetOrdersByIdsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_orders_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrdersByIdsReq.ProtoReflect.Descriptor instead.
+func (*GetOrdersByIdsReq) Descriptor() ([]byte, []int) {
+	return file_order_service_orders_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetOrdersByIdsReq) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type FailedOrder struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    string `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	Error string `protobuf:"bytes,2,opt,name=Error,proto3" json:"Error,omitempty"`
+}
+
+func (x *FailedOrder) Reset() {
+	*x = FailedOrder{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_order_service_orders_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FailedOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FailedOrder) ProtoMessage() {}
+
+func (x *FailedOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_orders_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FailedOrder.ProtoReflect.Descriptor instead.
+func (*FailedOrder) Descriptor() ([]byte, []int) {
+	return file_order_service_orders_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *FailedOrder) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FailedOrder) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type GetOrdersByIdsRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Orders      []*OrderReadModel `protobuf:"bytes,1,rep,name=Orders,proto3" json:"Orders,omitempty"`
+	NotFoundIds []string          `protobuf:"bytes,2,rep,name=NotFoundIds,proto3" json:"NotFoundIds,omitempty"`
+	Failed      []*FailedOrder    `protobuf:"bytes,3,rep,name=Failed,proto3" json:"Failed,omitempty"`
+}
+
+func (x *GetOrdersByIdsRes) Reset() {
+	*x = GetOrdersByIdsRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_order_service_orders_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetOrdersByIdsRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrdersByIdsRes) ProtoMessage() {}
+
+func (x *GetOrdersByIdsRes) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_orders_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrdersByIdsRes.ProtoReflect.Descriptor instead.
+func (*GetOrdersByIdsRes) Descriptor() ([]byte, []int) {
+	return file_order_service_orders_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetOrdersByIdsRes) GetOrders() []*OrderReadModel {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *GetOrdersByIdsRes) GetNotFoundIds() []string {
+	if x != nil {
+		return x.NotFoundIds
+	}
+	return nil
+}
+
+func (x *GetOrdersByIdsRes) GetFailed() []*FailedOrder {
+	if x != nil {
+		return x.Failed
+	}
+	return nil
+}
+
 var File_order_service_orders_proto protoreflect.FileDescriptor
 
 var file_order_service_orders_proto_rawDesc = []byte{
@@ -1223,36 +1388,57 @@ var file_order_service_orders_proto_rawDesc = []byte{
 	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x50, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a,
 	0x04, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x53, 0x69, 0x7a,
 	0x65, 0x12, 0x18, 0x0a, 0x07, 0x48, 0x61, 0x73, 0x4d, 0x6f, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x07, 0x48, 0x61, 0x73, 0x4d, 0x6f, 0x72, 0x65, 0x32, 0xac, 0x03, 0x0a, 0x0d,
-	0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4d, 0x0a,
-	0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x1e, 0x2e, 0x6f,
-	0x72, 0x64, 0x65, 0x72, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x1a, 0x1e, 0x2e, 0x6f,
-	0x72, 0x64, 0x65, 0x72, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x12, 0x4d, 0x0a, 0x0b,
-	0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x1e, 0x2e, 0x6f, 0x72,
-	0x64, 0x65, 0x72, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x53, 0x75, 0x62,
-	0x6d, 0x69, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x1a, 0x1e, 0x2e, 0x6f, 0x72,
-	0x64, 0x65, 0x72, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x53, 0x75, 0x62,
-	0x6d, 0x69, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x12, 0x62, 0x0a, 0x12, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72,
-	0x74, 0x12, 0x25, 0x2e, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e,
-	0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x25, 0x2e, 0x6f, 0x72, 0x64, 0x65, 0x72,
-	0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x12,
-	0x50, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x79, 0x49, 0x44, 0x12,
-	0x1f, 0x2e, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x2e, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71,
-	0x1a, 0x1f, 0x2e, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x28, 0x08, 0x52, 0x07, 0x48, 0x61, 0x73, 0x4d, 0x6f, 0x72, 0x65, 0x22, 0x25, 0x0a, 0x11, 0x47,
+	0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x42, 0x79, 0x49, 0x64, 0x73, 0x52, 0x65, 0x71,
+	0x12, 0x10, 0x0a, 0x03, 0x49, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x49,
+	0x64, 0x73, 0x22, 0x33, 0x0a, 0x0b, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xa2, 0x01, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x73, 0x42, 0x79, 0x49, 0x64, 0x73, 0x52, 0x65, 0x73, 0x12, 0x36, 0x0a,
+	0x06, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x61, 0x64, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x52, 0x06, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x4e, 0x6f, 0x74, 0x46, 0x6f, 0x75, 0x6e,
+	0x64, 0x49, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x4e, 0x6f, 0x74, 0x46,
+	0x6f, 0x75, 0x6e, 0x64, 0x49, 0x64, 0x73, 0x12, 0x33, 0x0a, 0x06, 0x46, 0x61, 0x69, 0x6c, 0x65,
+	0x64, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73,
+	0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x52, 0x06, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x32, 0x84, 0x04, 0x0a,
+	0x0d, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4d,
+	0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x1e, 0x2e,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x1a, 0x1e, 0x2e,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x12, 0x4d, 0x0a,
+	0x0b, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x1e, 0x2e, 0x6f,
+	0x72, 0x64, 0x65, 0x72, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x53, 0x75,
+	0x62, 0x6d, 0x69, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x1a, 0x1e, 0x2e, 0x6f,
+	0x72, 0x64, 0x65, 0x72, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x53, 0x75,
+	0x62, 0x6d, 0x69, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x12, 0x62, 0x0a, 0x12,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61,
+	0x72, 0x74, 0x12, 0x25, 0x2e, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69,
+	0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x25, 0x2e, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73,
+	0x12, 0x50, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x79, 0x49, 0x44,
+	0x12, 0x1f, 0x2e, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
 	0x65, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65,
-	0x73, 0x12, 0x47, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x12, 0x1c,
-	0x2e, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
-	0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x1c, 0x2e, 0x6f,
-	0x72, 0x64, 0x65, 0x72, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x65,
-	0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x42, 0x13, 0x5a, 0x11, 0x2e, 0x2f,
-	0x3b, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x71, 0x1a, 0x1f, 0x2e, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x79, 0x49, 0x44, 0x52,
+	0x65, 0x73, 0x12, 0x47, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x12,
+	0x1c, 0x2e, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x1c, 0x2e,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47,
+	0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x12, 0x56, 0x0a, 0x0e, 0x47,
+	0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x42, 0x79, 0x49, 0x64, 0x73, 0x12, 0x21, 0x2e,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47,
+	0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x42, 0x79, 0x49, 0x64, 0x73, 0x52, 0x65, 0x71,
+	0x1a, 0x21, 0x2e, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x42, 0x79, 0x49, 0x64, 0x73,
+	0x52, 0x65, 0x73, 0x42, 0x13, 0x5a, 0x11, 0x2e, 0x2f, 0x3b, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73,
+	0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1267,7 +1453,7 @@ func file_order_service_orders_proto_rawDescGZIP() []byte {
 	return file_order_service_orders_proto_rawDescData
 }
 
-var file_order_service_orders_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_order_service_orders_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_order_service_orders_proto_goTypes = []interface{}{
 	(*ShopItem)(nil),              // 0: orders_service.ShopItem
 	(*Order)(nil),                 // 1: orders_service.Order
@@ -1284,38 +1470,45 @@ var file_order_service_orders_proto_goTypes = []interface{}{
 	(*GetOrdersReq)(nil),          // 12: orders_service.GetOrdersReq
 	(*GetOrdersRes)(nil),          // 13: orders_service.GetOrdersRes
 	(*Pagination)(nil),            // 14: orders_service.Pagination
-	(*timestamppb.Timestamp)(nil), // 15: google.protobuf.Timestamp
+	(*GetOrdersByIdsReq)(nil),     // 15: orders_service.GetOrdersByIdsReq
+	(*FailedOrder)(nil),           // 16: orders_service.FailedOrder
+	(*GetOrdersByIdsRes)(nil),     // 17: orders_service.GetOrdersByIdsRes
+	(*timestamppb.Timestamp)(nil), // 18: google.protobuf.Timestamp
 }
 var file_order_service_orders_proto_depIdxs = []int32{
 	0,  // 0: orders_service.Order.ShopItems:type_name -> orders_service.ShopItem
-	15, // 1: orders_service.Order.DeliveredTime:type_name -> google.protobuf.Timestamp
-	15, // 2: orders_service.Order.CreatedAt:type_name -> google.protobuf.Timestamp
-	15, // 3: orders_service.Order.UpdatedAt:type_name -> google.protobuf.Timestamp
+	18, // 1: orders_service.Order.DeliveredTime:type_name -> google.protobuf.Timestamp
+	18, // 2: orders_service.Order.CreatedAt:type_name -> google.protobuf.Timestamp
+	18, // 3: orders_service.Order.UpdatedAt:type_name -> google.protobuf.Timestamp
 	3,  // 4: orders_service.OrderReadModel.ShopItems:type_name -> orders_service.ShopItemReadModel
-	15, // 5: orders_service.OrderReadModel.DeliveredTime:type_name -> google.protobuf.Timestamp
-	15, // 6: orders_service.OrderReadModel.CreatedAt:type_name -> google.protobuf.Timestamp
-	15, // 7: orders_service.OrderReadModel.UpdatedAt:type_name -> google.protobuf.Timestamp
+	18, // 5: orders_service.OrderReadModel.DeliveredTime:type_name -> google.protobuf.Timestamp
+	18, // 6: orders_service.OrderReadModel.CreatedAt:type_name -> google.protobuf.Timestamp
+	18, // 7: orders_service.OrderReadModel.UpdatedAt:type_name -> google.protobuf.Timestamp
 	0,  // 8: orders_service.CreateOrderReq.ShopItems:type_name -> orders_service.ShopItem
-	15, // 9: orders_service.CreateOrderReq.DeliveryTime:type_name -> google.protobuf.Timestamp
+	18, // 9: orders_service.CreateOrderReq.DeliveryTime:type_name -> google.protobuf.Timestamp
 	2,  // 10: orders_service.GetOrderByIDRes.Order:type_name -> orders_service.OrderReadModel
 	0,  // 11: orders_service.UpdateShoppingCartReq.ShopItems:type_name -> orders_service.ShopItem
 	14, // 12: orders_service.GetOrdersRes.Pagination:type_name -> orders_service.Pagination
 	2,  // 13: orders_service.GetOrdersRes.Orders:type_name -> orders_service.OrderReadModel
-	4,  // 14: orders_service.OrdersService.CreateOrder:input_type -> orders_service.CreateOrderReq
-	6,  // 15: orders_service.OrdersService.SubmitOrder:input_type -> orders_service.SubmitOrderReq
-	10, // 16: orders_service.OrdersService.UpdateShoppingCart:input_type -> orders_service.UpdateShoppingCartReq
-	8,  // 17: orders_service.OrdersService.GetOrderByID:input_type -> orders_service.GetOrderByIDReq
-	12, // 18: orders_service.OrdersService.GetOrders:input_type -> orders_service.GetOrdersReq
-	5,  // 19: orders_service.OrdersService.CreateOrder:output_type -> orders_service.CreateOrderRes
-	7,  // 20: orders_service.OrdersService.SubmitOrder:output_type -> orders_service.SubmitOrderRes
-	11, // 21: orders_service.OrdersService.UpdateShoppingCart:output_type -> orders_service.UpdateShoppingCartRes
-	9,  // 22: orders_service.OrdersService.GetOrderByID:output_type -> orders_service.GetOrderByIDRes
-	13, // 23: orders_service.OrdersService.GetOrders:output_type -> orders_service.GetOrdersRes
-	19, // [19:24] is the sub-list for method output_type
-	14, // [14:19] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	2,  // 14: orders_service.GetOrdersByIdsRes.Orders:type_name -> orders_service.OrderReadModel
+	16, // 15: orders_service.GetOrdersByIdsRes.Failed:type_name -> orders_service.FailedOrder
+	4,  // 16: orders_service.OrdersService.CreateOrder:input_type -> orders_service.CreateOrderReq
+	6,  // 17: orders_service.OrdersService.SubmitOrder:input_type -> orders_service.SubmitOrderReq
+	10, // 18: orders_service.OrdersService.UpdateShoppingCart:input_type -> orders_service.UpdateShoppingCartReq
+	8,  // 19: orders_service.OrdersService.GetOrderByID:input_type -> orders_service.GetOrderByIDReq
+	12, // 20: orders_service.OrdersService.GetOrders:input_type -> orders_service.GetOrdersReq
+	15, // 21: orders_service.OrdersService.GetOrdersByIds:input_type -> orders_service.GetOrdersByIdsReq
+	5,  // 22: orders_service.OrdersService.CreateOrder:output_type -> orders_service.CreateOrderRes
+	7,  // 23: orders_service.OrdersService.SubmitOrder:output_type -> orders_service.SubmitOrderRes
+	11, // 24: orders_service.OrdersService.UpdateShoppingCart:output_type -> orders_service.UpdateShoppingCartRes
+	9,  // 25: orders_service.OrdersService.GetOrderByID:output_type -> orders_service.GetOrderByIDRes
+	13, // 26: orders_service.OrdersService.GetOrders:output_type -> orders_service.GetOrdersRes
+	17, // 27: orders_service.OrdersService.GetOrdersByIds:output_type -> orders_service.GetOrdersByIdsRes
+	22, // [22:28] is the sub-list for method output_type
+	16, // [16:22] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_order_service_orders_proto_init() }
@@ -1504,6 +1697,42 @@ func file_order_service_orders_proto_init() {
 				return nil
 			}
 		}
+		file_order_service_orders_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetOrdersByIdsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_order_service_orders_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FailedOrder); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_order_service_orders_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetOrdersByIdsRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1511,7 +1740,7 @@ func file_order_service_orders_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_order_service_orders_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   15,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -24,6 +24,7 @@ const (
 	OrdersService_UpdateShoppingCart_FullMethodName = "/orders_service.OrdersService/UpdateShoppingCart"
 	OrdersService_GetOrderByID_FullMethodName       = "/orders_service.OrdersService/GetOrderByID"
 	OrdersService_GetOrders_FullMethodName          = "/orders_service.OrdersService/GetOrders"
+	OrdersService_GetOrdersByIds_FullMethodName     = "/orders_service.OrdersService/GetOrdersByIds"
 )
 
 // OrdersServiceClient is the client API for OrdersService service.
@@ -35,6 +36,7 @@ type OrdersServiceClient interface {
 	UpdateShoppingCart(ctx context.Context, in *UpdateShoppingCartReq, opts ...grpc.CallOption) (*UpdateShoppingCartRes, error)
 	GetOrderByID(ctx context.Context, in *GetOrderByIDReq, opts ...grpc.CallOption) (*GetOrderByIDRes, error)
 	GetOrders(ctx context.Context, in *GetOrdersReq, opts ...grpc.CallOption) (*GetOrdersRes, error)
+	GetOrdersByIds(ctx context.Context, in *GetOrdersByIdsReq, opts ...grpc.CallOption) (*GetOrdersByIdsRes, error)
 }
 
 type ordersServiceClient struct {
@@ -90,6 +92,15 @@ func (c *ordersServiceClient) GetOrders(ctx context.Context, in *GetOrdersReq, o
 	return out, nil
 }
 
+func (c *ordersServiceClient) GetOrdersByIds(ctx context.Context, in *GetOrdersByIdsReq, opts ...grpc.CallOption) (*GetOrdersByIdsRes, error) {
+	out := new(GetOrdersByIdsRes)
+	err := c.cc.Invoke(ctx, OrdersService_GetOrdersByIds_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrdersServiceServer is the server API for OrdersService service.
 // All implementations should embed UnimplementedOrdersServiceServer
 // for forward compatibility
@@ -99,6 +110,7 @@ type OrdersServiceServer interface {
 	UpdateShoppingCart(context.Context, *UpdateShoppingCartReq) (*UpdateShoppingCartRes, error)
 	GetOrderByID(context.Context, *GetOrderByIDReq) (*GetOrderByIDRes, error)
 	GetOrders(context.Context, *GetOrdersReq) (*GetOrdersRes, error)
+	GetOrdersByIds(context.Context, *GetOrdersByIdsReq) (*GetOrdersByIdsRes, error)
 }
 
 // UnimplementedOrdersServiceServer should be embedded to have forward compatible implementations.
@@ -120,6 +132,9 @@ func (UnimplementedOrdersServiceServer) GetOrderByID(context.Context, *GetOrderB
 func (UnimplementedOrdersServiceServer) GetOrders(context.Context, *GetOrdersReq) (*GetOrdersRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetOrders not implemented")
 }
+func (UnimplementedOrdersServiceServer) GetOrdersByIds(context.Context, *GetOrdersByIdsReq) (*GetOrdersByIdsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrdersByIds not implemented")
+}
 
 // UnsafeOrdersServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to OrdersServiceServer will
@@ -222,6 +237,24 @@ func _OrdersService_GetOrders_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrdersService_GetOrdersByIds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrdersByIdsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServiceServer).GetOrdersByIds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrdersService_GetOrdersByIds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServiceServer).GetOrdersByIds(ctx, req.(*GetOrdersByIdsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OrdersService_ServiceDesc is the grpc.ServiceDesc for OrdersService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -249,6 +282,10 @@ var OrdersService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetOrders",
 			Handler:    _OrdersService_GetOrders_Handler,
 		},
+		{
+			MethodName: "GetOrdersByIds",
+			Handler:    _OrdersService_GetOrdersByIds_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "orderservice/orders.proto",
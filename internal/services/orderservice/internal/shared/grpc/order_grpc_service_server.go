@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/core/cqrs"
 	customErrors "github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/http/httperrors/customerrors"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/logger"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/pkg/mapper"
@@ -17,12 +18,13 @@ import (
 	getOrderByIdQueryV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_order_by_id/v1/queries"
 	getOrdersDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_orders/v1/dtos"
 	getOrdersQueryV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_orders/v1/queries"
+	getOrdersByIdsDtosV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_orders_by_ids/v1/dtos"
+	getOrdersByIdsQueryV1 "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/orders/features/getting_orders_by_ids/v1/queries"
 	"github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/shared/contracts"
 	grpcOrderService "github.com/mehdihadeli/go-food-delivery-microservices/internal/services/orderservice/internal/shared/grpc/genproto"
 
 	"emperror.dev/errors"
 	"github.com/go-playground/validator"
-	"github.com/mehdihadeli/go-mediatr"
 	uuid "github.com/satori/go.uuid"
 	"go.opentelemetry.io/otel/attribute"
 	api "go.opentelemetry.io/otel/metric"
@@ -69,6 +71,9 @@ func (o OrderGrpcServiceServer) CreateOrder(
 		req.AccountEmail,
 		req.DeliveryAddress,
 		req.DeliveryTime.AsTime(),
+		"",
+		"",
+		"",
 	)
 	if err != nil {
 		validationErr := customErrors.NewValidationErrorWrap(
@@ -81,7 +86,7 @@ func (o OrderGrpcServiceServer) CreateOrder(
 		return nil, validationErr
 	}
 
-	result, err := mediatr.Send[*createOrderCommandV1.CreateOrder, *createOrderDtosV1.CreateOrderResponseDto](
+	result, err := cqrs.Send[*createOrderCommandV1.CreateOrder, *createOrderDtosV1.CreateOrderResponseDto](
 		ctx,
 		command,
 	)
@@ -139,7 +144,7 @@ func (o OrderGrpcServiceServer) GetOrderByID(
 		return nil, validationErr
 	}
 
-	queryResult, err := mediatr.Send[*getOrderByIdQueryV1.GetOrderById, *getOrderByIdDtosV1.GetOrderByIdResponseDto](
+	queryResult, err := cqrs.Send[*getOrderByIdQueryV1.GetOrderById, *getOrderByIdDtosV1.GetOrderByIdResponseDto](
 		ctx,
 		query,
 	)
@@ -172,6 +177,90 @@ func (o OrderGrpcServiceServer) GetOrderByID(
 	return &grpcOrderService.GetOrderByIDRes{Order: order}, nil
 }
 
+func (o OrderGrpcServiceServer) GetOrdersByIds(
+	ctx context.Context,
+	req *grpcOrderService.GetOrdersByIdsReq,
+) (*grpcOrderService.GetOrdersByIdsRes, error) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute2.Object("Request", req))
+
+	ids := make([]uuid.UUID, 0, len(req.GetIds()))
+	for _, id := range req.GetIds() {
+		idUUID, err := uuid.FromString(id)
+		if err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"[OrderGrpcServiceServer_GetOrdersByIds.uuid.FromString] error in converting uuid",
+			)
+			o.logger.Errorf(
+				fmt.Sprintf(
+					"[OrderGrpcServiceServer_GetOrdersByIds.uuid.FromString] err: %v",
+					badRequestErr,
+				),
+			)
+			return nil, badRequestErr
+		}
+
+		ids = append(ids, idUUID)
+	}
+
+	query, err := getOrdersByIdsQueryV1.NewGetOrdersByIds(ids)
+	if err != nil {
+		validationErr := customErrors.NewValidationErrorWrap(
+			err,
+			"[OrderGrpcServiceServer_GetOrdersByIds.StructCtx] query validation failed",
+		)
+		o.logger.Errorf(
+			fmt.Sprintf("[OrderGrpcServiceServer_GetOrdersByIds.StructCtx] err: %v", validationErr),
+		)
+		return nil, validationErr
+	}
+
+	queryResult, err := cqrs.Send[*getOrdersByIdsQueryV1.GetOrdersByIds, *getOrdersByIdsDtosV1.GetOrdersByIdsResponseDto](
+		ctx,
+		query,
+	)
+	if err != nil {
+		err = errors.WithMessage(
+			err,
+			"[OrderGrpcServiceServer_GetOrdersByIds.Send] error in sending GetOrdersByIds",
+		)
+		o.logger.Errorw(
+			fmt.Sprintf("[OrderGrpcServiceServer_GetOrdersByIds.Send] err: %v", err),
+			logger.Fields{"Count": len(query.Ids)},
+		)
+		return nil, err
+	}
+
+	res := &grpcOrderService.GetOrdersByIdsRes{}
+
+	for _, orderDto := range queryResult.Orders {
+		order, err := mapper.Map[*grpcOrderService.OrderReadModel](orderDto)
+		if err != nil {
+			err = errors.WithMessage(
+				err,
+				"[OrderGrpcServiceServer_GetOrdersByIds.Map] error in mapping order",
+			)
+			return nil, utils2.TraceStatusFromContext(ctx, err)
+		}
+
+		res.Orders = append(res.Orders, order)
+	}
+
+	for _, id := range queryResult.NotFound {
+		res.NotFoundIds = append(res.NotFoundIds, id.String())
+	}
+
+	for _, failed := range queryResult.Failed {
+		res.Failed = append(res.Failed, &grpcOrderService.FailedOrder{
+			Id:    failed.Id.String(),
+			Error: failed.Error,
+		})
+	}
+
+	return res, nil
+}
+
 func (o OrderGrpcServiceServer) SubmitOrder(
 	ctx context.Context,
 	req *grpcOrderService.SubmitOrderReq,
@@ -198,7 +287,7 @@ func (o OrderGrpcServiceServer) GetOrders(
 		&utils.ListQuery{Page: int(req.Page), Size: int(req.Size)},
 	)
 
-	queryResult, err := mediatr.Send[*getOrdersQueryV1.GetOrders, *getOrdersDtosV1.GetOrdersResponseDto](
+	queryResult, err := cqrs.Send[*getOrdersQueryV1.GetOrders, *getOrdersDtosV1.GetOrdersResponseDto](
 		ctx,
 		query,
 	)
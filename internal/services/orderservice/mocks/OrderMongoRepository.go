@@ -289,6 +289,61 @@ func (_c *OrderMongoRepository_GetOrderByOrderId_Call) RunAndReturn(run func(con
 	return _c
 }
 
+// GetOrdersByIds provides a mock function with given fields: ctx, ids
+func (_m *OrderMongoRepository) GetOrdersByIds(ctx context.Context, ids []uuid.UUID) ([]*read_models.OrderReadModel, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 []*read_models.OrderReadModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) ([]*read_models.OrderReadModel, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) []*read_models.OrderReadModel); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*read_models.OrderReadModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OrderMongoRepository_GetOrdersByIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOrdersByIds'
+type OrderMongoRepository_GetOrdersByIds_Call struct {
+	*mock.Call
+}
+
+// GetOrdersByIds is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []uuid.UUID
+func (_e *OrderMongoRepository_Expecter) GetOrdersByIds(ctx interface{}, ids interface{}) *OrderMongoRepository_GetOrdersByIds_Call {
+	return &OrderMongoRepository_GetOrdersByIds_Call{Call: _e.mock.On("GetOrdersByIds", ctx, ids)}
+}
+
+func (_c *OrderMongoRepository_GetOrdersByIds_Call) Run(run func(ctx context.Context, ids []uuid.UUID)) *OrderMongoRepository_GetOrdersByIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *OrderMongoRepository_GetOrdersByIds_Call) Return(_a0 []*read_models.OrderReadModel, _a1 error) *OrderMongoRepository_GetOrdersByIds_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OrderMongoRepository_GetOrdersByIds_Call) RunAndReturn(run func(context.Context, []uuid.UUID) ([]*read_models.OrderReadModel, error)) *OrderMongoRepository_GetOrdersByIds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SearchOrders provides a mock function with given fields: ctx, searchText, listQuery
 func (_m *OrderMongoRepository) SearchOrders(ctx context.Context, searchText string, listQuery *utils.ListQuery) (*utils.ListResult[*read_models.OrderReadModel], error) {
 	ret := _m.Called(ctx, searchText, listQuery)
@@ -89,6 +89,9 @@ var _ = Describe("Create Order Feature", func() {
 				gofakeit.Email(),
 				gofakeit.Address().Address,
 				time.Now(),
+				"",
+				"",
+				"",
 			)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(command).ToNot(BeNil())
@@ -126,6 +129,9 @@ var _ = Describe("Create Order Feature", func() {
 				gofakeit.Email(),
 				gofakeit.Address().Address,
 				time.Now(),
+				"",
+				"",
+				"",
 			)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(command).ToNot(BeNil())
@@ -180,6 +186,9 @@ var _ = Describe("Create Order Feature", func() {
 				gofakeit.Email(),
 				gofakeit.Address().Address,
 				time.Now(),
+				"",
+				"",
+				"",
 			)
 
 			Expect(err).ToNot(HaveOccurred())
@@ -5,5 +5,6 @@ set -e
 
 readonly service="$1"
 
-swag init --parseDependency --parseInternal --parseDepth 1  -g ./cmd/app/main.go  -d "./internal/services/$service/" -o "./internal/services/$service/docs"
+# the instance name keeps the swagger registrations of the services apart when they run in one process (dev host)
+swag init --parseDependency --parseInternal --parseDepth 1 --instanceName "$service" -g ./cmd/app/main.go  -d "./internal/services/$service/" -o "./internal/services/$service/docs"
 swag init --parseDependency --parseInternal --parseDepth 1  -g ./cmd/app/main.go  -d "./internal/services/$service/" -o "./api/openapi/$service/"